@@ -0,0 +1,422 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"io"
+	"maps"
+	"os"
+	"slices"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/IBM/sarama"
+	log "github.com/sirupsen/logrus"
+
+	"github.com/vladislavdragonenkov/oms/internal/messaging/kafka"
+)
+
+const (
+	actionDescribe = "describe"
+	actionReset    = "reset"
+
+	resetEarliest  = "earliest"
+	resetLatest    = "latest"
+	resetTimestamp = "timestamp"
+
+	// noOffset — группа ещё не коммитила оффсет партиции.
+	noOffset int64 = -1
+)
+
+type config struct {
+	brokers []string
+	action  string
+	group   string
+	topics  []string
+	resetTo string
+	// timestamp — для reset -to=timestamp: первый оффсет с временем сообщения не раньше timestamp.
+	timestamp time.Time
+	execute   bool
+}
+
+type offsetClient interface {
+	Partitions(topic string) ([]int32, error)
+	GetOffset(topic string, partition int32, time int64) (int64, error)
+}
+
+type groupAdmin interface {
+	ListConsumerGroupOffsets(group string, topicPartitions map[string][]int32) (*sarama.OffsetFetchResponse, error)
+	DescribeConsumerGroups(groups []string) ([]*sarama.GroupDescription, error)
+}
+
+// offsetCommitter записывает оффсеты группы; в отличие от обычного commit consumer'а,
+// умеет переносить оффсет назад.
+type offsetCommitter interface {
+	CommitOffsets(group string, offsets []partitionOffsets) error
+}
+
+// partitionOffsets — положение группы в партиции.
+type partitionOffsets struct {
+	topic     string
+	partition int32
+	committed int64
+	logStart  int64
+	logEnd    int64
+	// target — оффсет после reset; для describe не используется.
+	target int64
+}
+
+// lag — сколько сообщений осталось прочитать группе с оффсета offset. Оффсет раньше начала лога
+// (сообщения удалены retention) считается от начала лога. Без оффсета группа начнёт с конца лога
+// (Consumer.Offsets.Initial = OffsetNewest), поэтому лаг 0.
+func (p partitionOffsets) lag(offset int64) int64 {
+	if offset == noOffset {
+		return 0
+	}
+	return p.logEnd - max(offset, p.logStart)
+}
+
+var outputWriter io.Writer = os.Stdout
+
+var newAdminDependencies = func(cfg config) (offsetClient, groupAdmin, offsetCommitter, func(), error) {
+	saramaConfig := sarama.NewConfig()
+	saramaConfig.Version = sarama.V2_1_0_0
+	saramaConfig.Consumer.Return.Errors = true
+	saramaConfig.Consumer.Offsets.AutoCommit.Enable = false
+
+	client, err := sarama.NewClient(cfg.brokers, saramaConfig)
+	if err != nil {
+		return nil, nil, nil, nil, fmt.Errorf("create kafka client: %w", err)
+	}
+	admin, err := sarama.NewClusterAdminFromClient(client)
+	if err != nil {
+		_ = client.Close()
+		return nil, nil, nil, nil, fmt.Errorf("create kafka cluster admin: %w", err)
+	}
+	// ClusterAdmin закрывает client вместе с собой.
+	return client, admin, saramaOffsetCommitter{client: client}, func() { _ = admin.Close() }, nil
+}
+
+func main() {
+	log.SetFormatter(&log.TextFormatter{FullTimestamp: true})
+	log.SetLevel(log.InfoLevel)
+
+	cfg, err := readConfig()
+	if err != nil {
+		fail("%v", err)
+	}
+
+	if err := run(cfg); err != nil {
+		fail("consumer-admin %s failed: %v", cfg.action, err)
+	}
+}
+
+func readConfig() (config, error) {
+	var (
+		brokersRaw   string
+		topicPrefix  string
+		topicsRaw    string
+		timestampRaw string
+		cfg          config
+	)
+
+	flag.StringVar(&brokersRaw, "brokers", "", "Kafka brokers as comma-separated list (fallback: KAFKA_BROKERS)")
+	flag.StringVar(&topicPrefix, "topic-prefix", "", "environment topic prefix, e.g. staging (fallback: OMS_KAFKA_TOPIC_PREFIX)")
+	flag.StringVar(&cfg.action, "action", actionDescribe, "describe|reset")
+	flag.StringVar(&cfg.group, "group", kafka.ConsumerGroupBackorders, "consumer group without environment prefix")
+	flag.StringVar(&topicsRaw, "topics", "", "comma-separated topics of the group (default: all topics the group subscribes to)")
+	flag.StringVar(&cfg.resetTo, "to", "", "reset target: earliest|latest|timestamp")
+	flag.StringVar(&timestampRaw, "timestamp", "", "RFC3339 time for -to=timestamp")
+	flag.BoolVar(&cfg.execute, "execute", false, "commit new offsets; default is dry-run")
+	flag.Parse()
+
+	if strings.TrimSpace(brokersRaw) == "" {
+		brokersRaw = os.Getenv("KAFKA_BROKERS")
+	}
+	if strings.TrimSpace(topicPrefix) == "" {
+		topicPrefix = os.Getenv("OMS_KAFKA_TOPIC_PREFIX")
+	}
+	cfg.brokers = splitList(brokersRaw)
+	if len(cfg.brokers) == 0 {
+		return config{}, fmt.Errorf("kafka brokers are required (-brokers or KAFKA_BROKERS)")
+	}
+
+	// Работаем только с группами сервиса: имена и подписки берутся из TopicConfig окружения.
+	topicConfig, err := kafka.NewTopicConfig(topicPrefix)
+	if err != nil {
+		return config{}, fmt.Errorf("topic-prefix: %w", err)
+	}
+	groups := topicConfig.ConsumerGroups()
+	cfg.group = strings.TrimSpace(cfg.group)
+	if topicConfig.Prefix != "" {
+		cfg.group = topicConfig.Prefix + "." + cfg.group
+	}
+	groupTopics, ok := groups[cfg.group]
+	if !ok {
+		return config{}, fmt.Errorf("unknown consumer group %q (known: %s)", cfg.group, strings.Join(slices.Sorted(maps.Keys(groups)), ", "))
+	}
+	cfg.topics = groupTopics
+	if requested := splitList(topicsRaw); len(requested) > 0 {
+		for _, topic := range requested {
+			if !slices.Contains(groupTopics, topic) {
+				return config{}, fmt.Errorf("group %s does not consume topic %q (topics: %s)", cfg.group, topic, strings.Join(groupTopics, ", "))
+			}
+		}
+		cfg.topics = requested
+	}
+
+	switch cfg.action {
+	case actionDescribe:
+		if cfg.resetTo != "" || cfg.execute {
+			return config{}, fmt.Errorf("-to and -execute are only valid with -action=reset")
+		}
+	case actionReset:
+		switch cfg.resetTo {
+		case resetEarliest, resetLatest:
+		case resetTimestamp:
+			cfg.timestamp, err = time.Parse(time.RFC3339, strings.TrimSpace(timestampRaw))
+			if err != nil {
+				return config{}, fmt.Errorf("timestamp must be RFC3339: %w", err)
+			}
+		default:
+			return config{}, fmt.Errorf("-to must be one of %s, %s, %s", resetEarliest, resetLatest, resetTimestamp)
+		}
+	default:
+		return config{}, fmt.Errorf("unknown action %q (allowed: %s, %s)", cfg.action, actionDescribe, actionReset)
+	}
+
+	return cfg, nil
+}
+
+func run(cfg config) error {
+	client, admin, committer, closeFn, err := newAdminDependencies(cfg)
+	if err != nil {
+		return err
+	}
+	defer closeFn()
+
+	if cfg.action == actionDescribe {
+		return runDescribe(cfg, client, admin)
+	}
+	return runReset(cfg, client, admin, committer)
+}
+
+func runDescribe(cfg config, client offsetClient, admin groupAdmin) error {
+	state, err := groupState(admin, cfg.group)
+	if err != nil {
+		return err
+	}
+	offsets, err := collectOffsets(client, admin, cfg.group, cfg.topics)
+	if err != nil {
+		return err
+	}
+
+	_, _ = fmt.Fprintf(outputWriter, "GROUP %s (state: %s)\n", cfg.group, state)
+	w := tabwriter.NewWriter(outputWriter, 0, 0, 2, ' ', tabwriter.AlignRight)
+	_, _ = fmt.Fprintf(w, "TOPIC\tPARTITION\tCOMMITTED\tLOG-START\tLOG-END\tLAG\t\n")
+	var total int64
+	for _, p := range offsets {
+		lag := p.lag(p.committed)
+		total += lag
+		_, _ = fmt.Fprintf(w, "%s\t%d\t%s\t%d\t%d\t%d\t\n", p.topic, p.partition, formatOffset(p.committed), p.logStart, p.logEnd, lag)
+	}
+	_, _ = fmt.Fprintf(w, "TOTAL\t\t\t\t\t%d\t\n", total)
+	return w.Flush()
+}
+
+// runReset показывает, как изменится лаг по каждой партиции, и с -execute коммитит новые оффсеты.
+// Активная группа перезаписала бы их своим commit'ом, поэтому перед записью consumer'ы должны быть остановлены.
+func runReset(cfg config, client offsetClient, admin groupAdmin, committer offsetCommitter) error {
+	state, err := groupState(admin, cfg.group)
+	if err != nil {
+		return err
+	}
+	offsets, err := collectOffsets(client, admin, cfg.group, cfg.topics)
+	if err != nil {
+		return err
+	}
+	for i := range offsets {
+		if offsets[i].target, err = resolveTarget(client, cfg, offsets[i]); err != nil {
+			return err
+		}
+	}
+
+	mode := "dry-run"
+	if cfg.execute {
+		mode = "execute"
+	}
+	_, _ = fmt.Fprintf(outputWriter, "GROUP %s (state: %s), reset to %s (%s)\n", cfg.group, state, describeTarget(cfg), mode)
+	w := tabwriter.NewWriter(outputWriter, 0, 0, 2, ' ', tabwriter.AlignRight)
+	_, _ = fmt.Fprintf(w, "TOPIC\tPARTITION\tCOMMITTED\tTARGET\tLOG-END\tLAG-BEFORE\tLAG-AFTER\t\n")
+	var before, after int64
+	for _, p := range offsets {
+		lagBefore, lagAfter := p.lag(p.committed), p.lag(p.target)
+		before += lagBefore
+		after += lagAfter
+		_, _ = fmt.Fprintf(w, "%s\t%d\t%s\t%d\t%d\t%d\t%d\t\n", p.topic, p.partition, formatOffset(p.committed), p.target, p.logEnd, lagBefore, lagAfter)
+	}
+	_, _ = fmt.Fprintf(w, "TOTAL\t\t\t\t\t%d\t%d\t\n", before, after)
+	if err := w.Flush(); err != nil {
+		return err
+	}
+
+	if !cfg.execute {
+		return nil
+	}
+	if !groupInactive(state) {
+		return fmt.Errorf("group %s is %s: stop its consumers before resetting offsets", cfg.group, state)
+	}
+	if err := committer.CommitOffsets(cfg.group, offsets); err != nil {
+		return fmt.Errorf("commit offsets: %w", err)
+	}
+
+	// Commit в sarama асинхронный: сверяем записанное с планом.
+	committed, err := collectOffsets(client, admin, cfg.group, cfg.topics)
+	if err != nil {
+		return fmt.Errorf("verify offsets: %w", err)
+	}
+	for i, p := range committed {
+		if p.committed != offsets[i].target {
+			return fmt.Errorf("verify offsets: %s/%d committed %s, expected %d", p.topic, p.partition, formatOffset(p.committed), offsets[i].target)
+		}
+	}
+	log.WithFields(log.Fields{"group": cfg.group, "partitions": len(offsets)}).Info("consumer group offsets reset")
+	return nil
+}
+
+func collectOffsets(client offsetClient, admin groupAdmin, group string, topics []string) ([]partitionOffsets, error) {
+	topicPartitions := make(map[string][]int32, len(topics))
+	for _, topic := range topics {
+		partitions, err := client.Partitions(topic)
+		if err != nil {
+			return nil, fmt.Errorf("get partitions for topic %s: %w", topic, err)
+		}
+		slices.Sort(partitions)
+		topicPartitions[topic] = partitions
+	}
+
+	resp, err := admin.ListConsumerGroupOffsets(group, topicPartitions)
+	if err != nil {
+		return nil, fmt.Errorf("list offsets of group %s: %w", group, err)
+	}
+	if resp.Err != sarama.ErrNoError {
+		return nil, fmt.Errorf("list offsets of group %s: %w", group, resp.Err)
+	}
+
+	var offsets []partitionOffsets
+	for _, topic := range topics {
+		for _, partition := range topicPartitions[topic] {
+			p := partitionOffsets{topic: topic, partition: partition, committed: noOffset}
+			if block := resp.GetBlock(topic, partition); block != nil {
+				if block.Err != sarama.ErrNoError {
+					return nil, fmt.Errorf("offset of %s/%d: %w", topic, partition, block.Err)
+				}
+				p.committed = block.Offset
+			}
+			if p.logStart, err = client.GetOffset(topic, partition, sarama.OffsetOldest); err != nil {
+				return nil, fmt.Errorf("get oldest offset for %s/%d: %w", topic, partition, err)
+			}
+			if p.logEnd, err = client.GetOffset(topic, partition, sarama.OffsetNewest); err != nil {
+				return nil, fmt.Errorf("get newest offset for %s/%d: %w", topic, partition, err)
+			}
+			offsets = append(offsets, p)
+		}
+	}
+	return offsets, nil
+}
+
+func resolveTarget(client offsetClient, cfg config, p partitionOffsets) (int64, error) {
+	switch cfg.resetTo {
+	case resetEarliest:
+		return p.logStart, nil
+	case resetLatest:
+		return p.logEnd, nil
+	}
+	offset, err := client.GetOffset(p.topic, p.partition, cfg.timestamp.UnixMilli())
+	if err != nil {
+		return 0, fmt.Errorf("get offset by time for %s/%d: %w", p.topic, p.partition, err)
+	}
+	// Сообщений не раньше timestamp нет — группа продолжит с конца лога.
+	if offset < 0 {
+		return p.logEnd, nil
+	}
+	return min(max(offset, p.logStart), p.logEnd), nil
+}
+
+func groupState(admin groupAdmin, group string) (string, error) {
+	descriptions, err := admin.DescribeConsumerGroups([]string{group})
+	if err != nil {
+		return "", fmt.Errorf("describe group %s: %w", group, err)
+	}
+	for _, description := range descriptions {
+		if description.GroupId == group && description.State != "" {
+			return description.State, nil
+		}
+	}
+	return "Dead", nil
+}
+
+// groupInactive — у группы нет участников, и её оффсеты никто не перезапишет.
+func groupInactive(state string) bool {
+	return state == "Empty" || state == "Dead"
+}
+
+func describeTarget(cfg config) string {
+	if cfg.resetTo == resetTimestamp {
+		return cfg.timestamp.UTC().Format(time.RFC3339)
+	}
+	return cfg.resetTo
+}
+
+func formatOffset(offset int64) string {
+	if offset == noOffset {
+		return "-"
+	}
+	return fmt.Sprintf("%d", offset)
+}
+
+type saramaOffsetCommitter struct {
+	client sarama.Client
+}
+
+func (c saramaOffsetCommitter) CommitOffsets(group string, offsets []partitionOffsets) error {
+	manager, err := sarama.NewOffsetManagerFromClient(group, c.client)
+	if err != nil {
+		return fmt.Errorf("create offset manager: %w", err)
+	}
+	defer func() { _ = manager.Close() }()
+
+	partitionManagers := make([]sarama.PartitionOffsetManager, 0, len(offsets))
+	defer func() {
+		for _, pom := range partitionManagers {
+			_ = pom.Close()
+		}
+	}()
+	for _, p := range offsets {
+		pom, err := manager.ManagePartition(p.topic, p.partition)
+		if err != nil {
+			return fmt.Errorf("manage partition %s/%d: %w", p.topic, p.partition, err)
+		}
+		partitionManagers = append(partitionManagers, pom)
+		pom.ResetOffset(p.target, "reset by consumer-admin")
+	}
+	manager.Commit()
+	return nil
+}
+
+func splitList(raw string) []string {
+	chunks := strings.Split(raw, ",")
+	values := make([]string, 0, len(chunks))
+	for _, chunk := range chunks {
+		if value := strings.TrimSpace(chunk); value != "" {
+			values = append(values, value)
+		}
+	}
+	return values
+}
+
+func fail(format string, args ...any) {
+	_, _ = fmt.Fprintf(os.Stderr, format+"\n", args...)
+	os.Exit(1)
+}
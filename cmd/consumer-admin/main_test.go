@@ -0,0 +1,238 @@
+package main
+
+import (
+	"bytes"
+	"errors"
+	"flag"
+	"os"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/IBM/sarama"
+)
+
+const restockTopic = "oms.inventory.restock"
+
+func withFlagArgs(t *testing.T, args []string, fn func()) {
+	t.Helper()
+	oldArgs := os.Args
+	oldCommandLine := flag.CommandLine
+	defer func() {
+		os.Args = oldArgs
+		flag.CommandLine = oldCommandLine
+	}()
+	os.Args = append([]string{"consumer-admin"}, args...)
+	flag.CommandLine = flag.NewFlagSet(os.Args[0], flag.ContinueOnError)
+	fn()
+}
+
+func captureOutput(t *testing.T) *bytes.Buffer {
+	t.Helper()
+	var buf bytes.Buffer
+	old := outputWriter
+	outputWriter = &buf
+	t.Cleanup(func() { outputWriter = old })
+	return &buf
+}
+
+func TestReadConfig(t *testing.T) {
+	t.Setenv("KAFKA_BROKERS", "")
+	t.Setenv("OMS_KAFKA_TOPIC_PREFIX", "")
+	withFlagArgs(t, []string{"-brokers=b1:9092", "-topic-prefix=staging", "-action=reset", "-to=timestamp", "-timestamp=2026-01-02T03:04:05Z", "-execute"}, func() {
+		cfg, err := readConfig()
+		if err != nil {
+			t.Fatalf("readConfig failed: %v", err)
+		}
+		if cfg.group != "staging.oms-backorders" || len(cfg.topics) != 1 || cfg.topics[0] != "staging."+restockTopic {
+			t.Fatalf("unexpected group/topics: %s %v", cfg.group, cfg.topics)
+		}
+		if !cfg.execute || cfg.timestamp != time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC) {
+			t.Fatalf("unexpected reset config: %+v", cfg)
+		}
+	})
+}
+
+func TestReadConfig_ValidationErrors(t *testing.T) {
+	t.Setenv("KAFKA_BROKERS", "")
+	t.Setenv("OMS_KAFKA_TOPIC_PREFIX", "")
+	cases := map[string][]string{
+		"no brokers":            {},
+		"unknown group":         {"-brokers=b:9092", "-group=payments"},
+		"foreign topic":         {"-brokers=b:9092", "-topics=oms.order.events"},
+		"unknown action":        {"-brokers=b:9092", "-action=delete"},
+		"describe with execute": {"-brokers=b:9092", "-execute"},
+		"reset without target":  {"-brokers=b:9092", "-action=reset"},
+		"bad timestamp":         {"-brokers=b:9092", "-action=reset", "-to=timestamp", "-timestamp=yesterday"},
+	}
+	for name, args := range cases {
+		withFlagArgs(t, args, func() {
+			if _, err := readConfig(); err == nil {
+				t.Fatalf("%s: expected error", name)
+			}
+		})
+	}
+}
+
+func TestRunDescribe(t *testing.T) {
+	out := captureOutput(t)
+	client := newStubClient()
+	admin := &stubGroupAdmin{state: "Stable", committed: map[int32]int64{0: 7}}
+
+	if err := runDescribe(config{group: "oms-backorders", topics: []string{restockTopic}}, client, admin); err != nil {
+		t.Fatalf("runDescribe failed: %v", err)
+	}
+	got := out.String()
+	// Партиция 0: лаг 10-7=3; партиция 1 без оффсета группы.
+	for _, want := range []string{"state: Stable", "COMMITTED", "TOTAL"} {
+		if !strings.Contains(got, want) {
+			t.Fatalf("output misses %q:\n%s", want, got)
+		}
+	}
+	lines := strings.Split(strings.TrimSpace(got), "\n")
+	if fields := strings.Fields(lines[2]); fields[2] != "7" || fields[5] != "3" {
+		t.Fatalf("unexpected partition 0 row: %q", lines[2])
+	}
+	if fields := strings.Fields(lines[3]); fields[2] != "-" || fields[5] != "0" {
+		t.Fatalf("unexpected partition 1 row: %q", lines[3])
+	}
+}
+
+func TestRunReset_DryRunDoesNotCommit(t *testing.T) {
+	out := captureOutput(t)
+	client := newStubClient()
+	admin := &stubGroupAdmin{state: "Stable", committed: map[int32]int64{0: 7, 1: 4}}
+	committer := &stubCommitter{admin: admin}
+
+	cfg := config{group: "oms-backorders", topics: []string{restockTopic}, resetTo: resetEarliest}
+	if err := runReset(cfg, client, admin, committer); err != nil {
+		t.Fatalf("runReset failed: %v", err)
+	}
+	if committer.calls != 0 {
+		t.Fatal("dry-run must not commit offsets")
+	}
+	lines := strings.Split(strings.TrimSpace(out.String()), "\n")
+	// TOTAL: лаг до (3+2) и после сброса на начало лога (8+5).
+	if fields := strings.Fields(lines[len(lines)-1]); fields[1] != "5" || fields[2] != "13" {
+		t.Fatalf("unexpected total row: %q", lines[len(lines)-1])
+	}
+}
+
+func TestRunReset_Execute(t *testing.T) {
+	captureOutput(t)
+	client := newStubClient()
+	client.byTime = map[int32]int64{0: 5, 1: -1}
+	admin := &stubGroupAdmin{state: "Stable", committed: map[int32]int64{0: 7, 1: 4}}
+	committer := &stubCommitter{admin: admin}
+	cfg := config{group: "oms-backorders", topics: []string{restockTopic}, resetTo: resetTimestamp, timestamp: time.Now(), execute: true}
+
+	if err := runReset(cfg, client, admin, committer); err == nil || !strings.Contains(err.Error(), "stop its consumers") {
+		t.Fatalf("expected active group error, got %v", err)
+	}
+
+	admin.state = "Empty"
+	if err := runReset(cfg, client, admin, committer); err != nil {
+		t.Fatalf("runReset failed: %v", err)
+	}
+	// Партиция 1 без сообщений после timestamp переходит на конец лога.
+	if admin.committed[0] != 5 || admin.committed[1] != 6 {
+		t.Fatalf("unexpected committed offsets: %v", admin.committed)
+	}
+
+	admin.committed = map[int32]int64{0: 7, 1: 4}
+	committer.lose = true
+	if err := runReset(cfg, client, admin, committer); err == nil || !strings.Contains(err.Error(), "verify offsets") {
+		t.Fatalf("expected verification error, got %v", err)
+	}
+}
+
+func TestRun_UsesDependencies(t *testing.T) {
+	old := newAdminDependencies
+	defer func() { newAdminDependencies = old }()
+
+	newAdminDependencies = func(config) (offsetClient, groupAdmin, offsetCommitter, func(), error) {
+		return nil, nil, nil, nil, errors.New("deps failed")
+	}
+	if err := run(config{action: actionDescribe}); err == nil || !strings.Contains(err.Error(), "deps failed") {
+		t.Fatalf("expected deps error, got %v", err)
+	}
+
+	captureOutput(t)
+	closed := false
+	newAdminDependencies = func(config) (offsetClient, groupAdmin, offsetCommitter, func(), error) {
+		admin := &stubGroupAdmin{state: "Empty"}
+		return newStubClient(), admin, &stubCommitter{admin: admin}, func() { closed = true }, nil
+	}
+	if err := run(config{action: actionDescribe, group: "oms-backorders", topics: []string{restockTopic}}); err != nil {
+		t.Fatalf("run failed: %v", err)
+	}
+	if !closed {
+		t.Fatal("expected dependencies to be closed")
+	}
+}
+
+type stubClient struct {
+	oldest map[int32]int64
+	newest map[int32]int64
+	byTime map[int32]int64
+}
+
+func newStubClient() *stubClient {
+	return &stubClient{oldest: map[int32]int64{0: 2, 1: 1}, newest: map[int32]int64{0: 10, 1: 6}}
+}
+
+func (c *stubClient) Partitions(string) ([]int32, error) {
+	return []int32{1, 0}, nil
+}
+
+func (c *stubClient) GetOffset(_ string, partition int32, at int64) (int64, error) {
+	switch at {
+	case sarama.OffsetOldest:
+		return c.oldest[partition], nil
+	case sarama.OffsetNewest:
+		return c.newest[partition], nil
+	default:
+		return c.byTime[partition], nil
+	}
+}
+
+type stubGroupAdmin struct {
+	state     string
+	committed map[int32]int64
+}
+
+func (a *stubGroupAdmin) ListConsumerGroupOffsets(_ string, topicPartitions map[string][]int32) (*sarama.OffsetFetchResponse, error) {
+	resp := &sarama.OffsetFetchResponse{}
+	for topic, partitions := range topicPartitions {
+		for _, partition := range partitions {
+			offset, ok := a.committed[partition]
+			if !ok {
+				offset = noOffset
+			}
+			resp.AddBlock(topic, partition, &sarama.OffsetFetchResponseBlock{Offset: offset})
+		}
+	}
+	return resp, nil
+}
+
+func (a *stubGroupAdmin) DescribeConsumerGroups(groups []string) ([]*sarama.GroupDescription, error) {
+	return []*sarama.GroupDescription{{GroupId: groups[0], State: a.state}}, nil
+}
+
+type stubCommitter struct {
+	admin *stubGroupAdmin
+	calls int
+	// lose имитирует commit, который брокер не применил.
+	lose bool
+}
+
+func (c *stubCommitter) CommitOffsets(_ string, offsets []partitionOffsets) error {
+	c.calls++
+	if c.lose {
+		return nil
+	}
+	for _, p := range offsets {
+		c.admin.committed[p.partition] = p.target
+	}
+	return nil
+}
@@ -2,6 +2,7 @@ package main
 
 import (
 	"context"
+	"encoding/base64"
 	"encoding/json"
 	"flag"
 	"fmt"
@@ -29,18 +30,36 @@ type config struct {
 	execute     bool
 	fromNewest  bool
 	idleTimeout time.Duration
+	// quiet отключает прогресс, итоговую таблицу и info-логи — для использования в скриптах.
+	quiet            bool
+	progressInterval time.Duration
+	// stateFile — журнал переотправленных (partition, offset); пусто — журнал не ведётся.
+	stateFile string
+	// force переотправляет сообщения, уже записанные в журнал.
+	force bool
+	// verifyTopic — проверочный режим: сообщения отправляются в этот топик вместо настоящих
+	// и читаются обратно (см. verifyReplay). Журнал replay при этом не пишется.
+	verifyTopic string
+	// orderTopic и sagaTopic — топики событий окружения; по ним verify-режим выбирает парсер.
+	orderTopic string
+	sagaTopic  string
 }
 
 type replayMessage struct {
 	topic string
 	key   string
 	value []byte
+	// contentType — формат исходного сообщения; пусто для JSON без header.
+	contentType string
 }
 
 type consumerDLQPayload struct {
 	OriginalTopic string `json:"original_topic"`
 	OriginalKey   string `json:"original_key"`
 	OriginalValue string `json:"original_value"`
+	// OriginalValueBase64 заполнен вместо OriginalValue для бинарных (protobuf) сообщений.
+	OriginalValueBase64 string `json:"original_value_base64"`
+	OriginalContentType string `json:"original_content_type"`
 }
 
 type outboxEnvelope struct {
@@ -125,7 +144,7 @@ var newReplayDependencies = func(cfg config) (offsetClient, partitionConsumerSou
 	}
 	consumer := saramaConsumerAdapter{consumer: rawConsumer}
 
-	if !cfg.execute {
+	if !cfg.execute && cfg.verifyTopic == "" {
 		return client, consumer, nil, nil
 	}
 
@@ -155,6 +174,9 @@ func main() {
 	if err != nil {
 		fail("%v", err)
 	}
+	if cfg.quiet {
+		log.SetLevel(log.WarnLevel)
+	}
 
 	if err := run(context.Background(), cfg); err != nil {
 		fail("dlq replay failed: %v", err)
@@ -163,23 +185,50 @@ func main() {
 
 func readConfig() (config, error) {
 	var (
-		brokersRaw string
-		cfg        config
+		brokersRaw  string
+		topicPrefix string
+		cfg         config
 	)
 
 	flag.StringVar(&brokersRaw, "brokers", "", "Kafka brokers as comma-separated list (fallback: KAFKA_BROKERS)")
-	flag.StringVar(&cfg.sourceTopic, "source-topic", kafka.TopicDeadLetterQueue, "DLQ source topic")
-	flag.StringVar(&cfg.targetTopic, "target-topic", kafka.TopicOrderEvents, "target topic for replay")
+	flag.StringVar(&topicPrefix, "topic-prefix", "", "environment topic prefix, e.g. staging (fallback: OMS_KAFKA_TOPIC_PREFIX)")
+	flag.StringVar(&cfg.sourceTopic, "source-topic", kafka.TopicDeadLetterQueue, "DLQ source topic (default: <prefix>.oms.dlq)")
+	flag.StringVar(&cfg.targetTopic, "target-topic", kafka.TopicOrderEvents, "target topic for replay (default: <prefix>.oms.order.events)")
 	flag.IntVar(&cfg.limit, "limit", defaultReplayLimit, "max number of messages to scan/replay")
 	flag.BoolVar(&cfg.execute, "execute", false, "execute replay; default is dry-run")
 	flag.BoolVar(&cfg.fromNewest, "from-newest", false, "scan latest messages first (bounded by limit)")
 	flag.DurationVar(&cfg.idleTimeout, "idle-timeout", defaultIdleTimeout, "idle timeout per partition")
+	flag.BoolVar(&cfg.quiet, "quiet", false, "suppress progress, summary table and info logs")
+	flag.DurationVar(&cfg.progressInterval, "progress-interval", defaultProgressInterval, "interval between progress log lines")
+	flag.StringVar(&cfg.stateFile, "state-file", defaultStateFile, "file recording replayed (partition, offset) pairs; empty disables deduplication")
+	flag.BoolVar(&cfg.force, "force", false, "replay messages already recorded in the state file")
+	flag.StringVar(&cfg.verifyTopic, "verify-topic", "", "replay into this test topic and read it back to check payloads instead of the real targets")
 	flag.Parse()
 
 	if strings.TrimSpace(brokersRaw) == "" {
 		brokersRaw = os.Getenv("KAFKA_BROKERS")
 	}
+	if strings.TrimSpace(topicPrefix) == "" {
+		topicPrefix = os.Getenv("OMS_KAFKA_TOPIC_PREFIX")
+	}
+
+	// Топики по умолчанию берутся из TopicConfig окружения; явно заданные флаги не трогаем.
+	topics, err := kafka.NewTopicConfig(topicPrefix)
+	if err != nil {
+		return config{}, fmt.Errorf("topic-prefix: %w", err)
+	}
+	explicit := make(map[string]bool)
+	flag.Visit(func(f *flag.Flag) { explicit[f.Name] = true })
+	if !explicit["source-topic"] {
+		cfg.sourceTopic = topics.DeadLetter
+	}
+	if !explicit["target-topic"] {
+		cfg.targetTopic = topics.OrderEvents
+	}
+	cfg.orderTopic = topics.OrderEvents
+	cfg.sagaTopic = topics.SagaEvents
 
+	cfg.stateFile = strings.TrimSpace(cfg.stateFile)
 	cfg.brokers = parseBrokers(brokersRaw)
 	if len(cfg.brokers) == 0 {
 		return config{}, fmt.Errorf("kafka brokers are required (-brokers or KAFKA_BROKERS)")
@@ -187,15 +236,37 @@ func readConfig() (config, error) {
 	if strings.TrimSpace(cfg.sourceTopic) == "" {
 		return config{}, fmt.Errorf("source-topic is required")
 	}
+	if err := kafka.ValidateTopicName(cfg.sourceTopic); err != nil {
+		return config{}, fmt.Errorf("source-topic: %w", err)
+	}
 	if strings.TrimSpace(cfg.targetTopic) == "" {
 		return config{}, fmt.Errorf("target-topic is required")
 	}
+	if err := kafka.ValidateTopicName(cfg.targetTopic); err != nil {
+		return config{}, fmt.Errorf("target-topic: %w", err)
+	}
+	cfg.verifyTopic = strings.TrimSpace(cfg.verifyTopic)
+	if cfg.verifyTopic != "" {
+		if err := kafka.ValidateTopicName(cfg.verifyTopic); err != nil {
+			return config{}, fmt.Errorf("verify-topic: %w", err)
+		}
+		if cfg.execute {
+			return config{}, fmt.Errorf("verify-topic cannot be combined with execute")
+		}
+		switch cfg.verifyTopic {
+		case cfg.sourceTopic, cfg.targetTopic, cfg.orderTopic, cfg.sagaTopic:
+			return config{}, fmt.Errorf("verify-topic must differ from source and event topics")
+		}
+	}
 	if cfg.limit <= 0 {
 		return config{}, fmt.Errorf("limit must be > 0")
 	}
 	if cfg.idleTimeout <= 0 {
 		return config{}, fmt.Errorf("idle-timeout must be > 0")
 	}
+	if cfg.progressInterval <= 0 {
+		return config{}, fmt.Errorf("progress-interval must be > 0")
+	}
 
 	return cfg, nil
 }
@@ -220,6 +291,9 @@ func run(ctx context.Context, cfg config) error {
 		"limit":        cfg.limit,
 		"execute":      cfg.execute,
 		"from_newest":  cfg.fromNewest,
+		"state_file":   cfg.stateFile,
+		"force":        cfg.force,
+		"verify_topic": cfg.verifyTopic,
 	}).Info("starting dlq replay")
 
 	client, consumer, producer, err := newReplayDependencies(cfg)
@@ -248,6 +322,9 @@ func runReplay(ctx context.Context, cfg config, client offsetClient, consumer pa
 	if cfg.execute && producer == nil {
 		return fmt.Errorf("producer is required in execute mode")
 	}
+	if cfg.verifyTopic != "" && producer == nil {
+		return fmt.Errorf("producer is required in verify mode")
+	}
 
 	partitions, err := client.Partitions(cfg.sourceTopic)
 	if err != nil {
@@ -259,10 +336,23 @@ func runReplay(ctx context.Context, cfg config, client offsetClient, consumer pa
 	}
 	sort.Slice(partitions, func(i, j int) bool { return partitions[i] < partitions[j] })
 
+	state, err := loadReplayState(cfg.stateFile)
+	if err != nil {
+		return err
+	}
+
+	var progress *replayProgress
+	if !cfg.quiet {
+		backlog := planBacklog(client, cfg.sourceTopic, partitions)
+		progress = newReplayProgress(replayOutput, cfg.progressInterval, cfg.limit, backlog, time.Now)
+	}
+
 	var (
-		processed int
-		replayed  int
-		skipped   int
+		processed       int
+		replayed        int
+		skipped         int
+		alreadyReplayed int
+		verified        []*verifyRecord
 	)
 
 	for _, partition := range partitions {
@@ -271,7 +361,7 @@ func runReplay(ctx context.Context, cfg config, client offsetClient, consumer pa
 		}
 
 		remaining := cfg.limit - processed
-		stats, err := processPartition(ctx, consumer, client, producer, cfg, partition, remaining)
+		stats, err := processPartition(ctx, consumer, client, producer, cfg, state, partition, remaining, progress)
 		if err != nil {
 			return err
 		}
@@ -279,27 +369,46 @@ func runReplay(ctx context.Context, cfg config, client offsetClient, consumer pa
 		processed += stats.processed
 		replayed += stats.replayed
 		skipped += stats.skipped
+		alreadyReplayed += stats.alreadyReplayed
+		verified = append(verified, stats.verified...)
 	}
 
 	mode := "dry-run"
-	if cfg.execute {
+	switch {
+	case cfg.execute:
 		mode = "execute"
+	case cfg.verifyTopic != "":
+		mode = "verify"
 	}
 
 	log.WithFields(log.Fields{
-		"mode":      mode,
-		"processed": processed,
-		"replayed":  replayed,
-		"skipped":   skipped,
+		"mode":             mode,
+		"processed":        processed,
+		"replayed":         replayed,
+		"skipped":          skipped,
+		"already_replayed": alreadyReplayed,
 	}).Info("dlq replay finished")
+	progress.printSummary(mode)
 
+	if cfg.verifyTopic != "" && len(verified) > 0 {
+		return verifyReplay(ctx, cfg, consumer, verified)
+	}
 	return nil
 }
 
 type partitionStats struct {
-	processed int
-	replayed  int
-	skipped   int
+	partition   int32
+	startOffset int64
+	endOffset   int64
+	nextOffset  int64
+	done        bool
+	processed   int
+	replayed    int
+	skipped     int
+	// alreadyReplayed — пропущенные (входят в skipped), потому что уже есть в журнале replay.
+	alreadyReplayed int
+	// verified — сообщения, отправленные в проверочный топик (режим -verify-topic).
+	verified []*verifyRecord
 }
 
 func processPartition(
@@ -308,10 +417,12 @@ func processPartition(
 	client offsetClient,
 	producer replayProducer,
 	cfg config,
+	state *replayState,
 	partition int32,
 	limit int,
+	progress *replayProgress,
 ) (partitionStats, error) {
-	var stats partitionStats
+	stats := partitionStats{partition: partition}
 	if limit <= 0 {
 		return stats, nil
 	}
@@ -343,6 +454,16 @@ func processPartition(
 	defer func() { _ = partitionConsumer.Close() }()
 
 	endOffset := newest
+	stats.startOffset = startOffset
+	stats.endOffset = endOffset
+	stats.nextOffset = startOffset
+	progress.startPartition(&stats)
+	defer func() { stats.done = true }()
+
+	observe := func(msg *sarama.ConsumerMessage) {
+		stats.nextOffset = msg.Offset + 1
+		progress.observe(&stats)
+	}
 	idleTimer := time.NewTimer(cfg.idleTimeout)
 	defer idleTimer.Stop()
 
@@ -371,6 +492,21 @@ func processPartition(
 				return stats, nil
 			}
 
+			if !cfg.force && state.replayed(cfg.sourceTopic, partition, msg.Offset) {
+				stats.processed++
+				stats.skipped++
+				stats.alreadyReplayed++
+				log.WithFields(log.Fields{
+					"partition": msg.Partition,
+					"offset":    msg.Offset,
+				}).Debug("skip already replayed dlq message")
+				observe(msg)
+				if msg.Offset+1 >= endOffset {
+					return stats, nil
+				}
+				continue
+			}
+
 			replayMsg, ok, err := extractReplayMessage(msg, cfg.targetTopic)
 			if err != nil {
 				stats.processed++
@@ -379,20 +515,38 @@ func processPartition(
 					"partition": msg.Partition,
 					"offset":    msg.Offset,
 				}).Warn("skip unsupported dlq message")
+				observe(msg)
 				continue
 			}
 			if !ok {
 				stats.processed++
 				stats.skipped++
+				observe(msg)
 				continue
 			}
 
-			if cfg.execute {
+			switch {
+			case cfg.verifyTopic != "":
+				record, err := publishVerification(producer, cfg, replayMsg)
+				if err != nil {
+					return stats, fmt.Errorf("publish verification message: %w", err)
+				}
+				record.sourcePartition = partition
+				record.sourceOffset = msg.Offset
+				stats.verified = append(stats.verified, &record)
+				stats.replayed++
+			case cfg.execute:
 				if err := publishReplay(producer, replayMsg); err != nil {
 					return stats, fmt.Errorf("publish replay message: %w", err)
 				}
 				stats.replayed++
-			} else {
+				// Журнал сохраняется после каждой отправки: упавший посреди диапазона запуск
+				// при повторе продолжит с первого непереотправленного сообщения.
+				state.mark(cfg.sourceTopic, partition, msg.Offset)
+				if err := state.save(); err != nil {
+					return stats, fmt.Errorf("message at partition %d offset %d was replayed but not recorded: %w", partition, msg.Offset, err)
+				}
+			default:
 				log.WithFields(log.Fields{
 					"partition":    msg.Partition,
 					"offset":       msg.Offset,
@@ -403,6 +557,7 @@ func processPartition(
 			}
 
 			stats.processed++
+			observe(msg)
 
 			if msg.Offset+1 >= endOffset {
 				return stats, nil
@@ -420,28 +575,43 @@ func publishReplay(producer replayProducer, msg replayMessage) error {
 		return fmt.Errorf("producer is nil")
 	}
 
+	_, _, err := producer.SendMessage(newProducerMessage(msg))
+	return err
+}
+
+func newProducerMessage(msg replayMessage) *sarama.ProducerMessage {
 	producerMessage := &sarama.ProducerMessage{
 		Topic:     msg.topic,
 		Key:       sarama.StringEncoder(msg.key),
 		Value:     sarama.ByteEncoder(msg.value),
 		Timestamp: time.Now().UTC(),
 	}
-
-	_, _, err := producer.SendMessage(producerMessage)
-	return err
+	if msg.contentType != "" {
+		producerMessage.Headers = []sarama.RecordHeader{{Key: []byte(kafka.HeaderContentType), Value: []byte(msg.contentType)}}
+	}
+	return producerMessage
 }
 
 func extractReplayMessage(msg *sarama.ConsumerMessage, defaultTopic string) (replayMessage, bool, error) {
 	var consumerPayload consumerDLQPayload
-	if err := json.Unmarshal(msg.Value, &consumerPayload); err == nil && consumerPayload.OriginalValue != "" {
+	if err := json.Unmarshal(msg.Value, &consumerPayload); err == nil && (consumerPayload.OriginalValue != "" || consumerPayload.OriginalValueBase64 != "") {
 		targetTopic := strings.TrimSpace(consumerPayload.OriginalTopic)
 		if targetTopic == "" {
 			targetTopic = defaultTopic
 		}
+		value := []byte(consumerPayload.OriginalValue)
+		if consumerPayload.OriginalValueBase64 != "" {
+			decoded, err := base64.StdEncoding.DecodeString(consumerPayload.OriginalValueBase64)
+			if err != nil {
+				return replayMessage{}, false, fmt.Errorf("decode original_value_base64: %w", err)
+			}
+			value = decoded
+		}
 		return replayMessage{
-			topic: targetTopic,
-			key:   consumerPayload.OriginalKey,
-			value: []byte(consumerPayload.OriginalValue),
+			topic:       targetTopic,
+			key:         consumerPayload.OriginalKey,
+			value:       value,
+			contentType: consumerPayload.OriginalContentType,
 		}, true, nil
 	}
 
@@ -1,18 +1,23 @@
 package main
 
 import (
+	"bytes"
 	"context"
+	"encoding/base64"
 	"encoding/json"
 	"errors"
 	"flag"
 	"fmt"
 	"os"
 	"os/exec"
+	"path/filepath"
 	"strings"
 	"testing"
 	"time"
 
 	"github.com/IBM/sarama"
+
+	"github.com/vladislavdragonenkov/oms/internal/messaging/kafka"
 )
 
 func TestParseBrokers(t *testing.T) {
@@ -55,6 +60,39 @@ func TestExtractReplayMessage_ConsumerDLQPayload(t *testing.T) {
 	}
 }
 
+func TestExtractReplayMessage_BinaryConsumerDLQPayload(t *testing.T) {
+	original := []byte{0x0a, 0x0e, 's', 'a', 'g', 'a', '.', 's', 't', 'a', 'r', 't', 'e', 'd', 0x00, 0xff}
+	raw, err := json.Marshal(map[string]any{
+		"original_topic":        "oms.saga.events",
+		"original_key":          "order-1",
+		"original_value_base64": base64.StdEncoding.EncodeToString(original),
+		"original_content_type": kafka.ContentTypeProtobuf,
+	})
+	if err != nil {
+		t.Fatalf("marshal payload failed: %v", err)
+	}
+
+	got, ok, err := extractReplayMessage(&sarama.ConsumerMessage{Value: raw}, "fallback-topic")
+	if err != nil || !ok {
+		t.Fatalf("extractReplayMessage = ok %v, err %v", ok, err)
+	}
+	if !bytes.Equal(got.value, original) {
+		t.Fatalf("binary value was not restored: %x", got.value)
+	}
+	if got.contentType != kafka.ContentTypeProtobuf {
+		t.Fatalf("unexpected content type: %q", got.contentType)
+	}
+
+	producer := &stubReplayProducer{}
+	if err := publishReplay(producer, got); err != nil {
+		t.Fatalf("publishReplay failed: %v", err)
+	}
+	headers := producer.lastMsg.Headers
+	if len(headers) != 1 || string(headers[0].Key) != kafka.HeaderContentType || string(headers[0].Value) != kafka.ContentTypeProtobuf {
+		t.Fatalf("content-type header was not restored: %+v", headers)
+	}
+}
+
 func TestExtractReplayMessage_OutboxDLQPayload(t *testing.T) {
 	envelope := map[string]any{
 		"id":             "outbox-1",
@@ -156,6 +194,10 @@ func TestReadConfig_FromFlags(t *testing.T) {
 		"-execute=true",
 		"-from-newest=true",
 		"-idle-timeout=3s",
+		"-quiet=true",
+		"-progress-interval=10s",
+		"-state-file= /tmp/replay.json ",
+		"-force=true",
 	}, func() {
 		cfg, err := readConfig()
 		if err != nil {
@@ -176,6 +218,51 @@ func TestReadConfig_FromFlags(t *testing.T) {
 		if cfg.idleTimeout.Seconds() != 3 {
 			t.Fatalf("unexpected idle-timeout: %s", cfg.idleTimeout)
 		}
+		if !cfg.quiet {
+			t.Fatal("expected quiet=true")
+		}
+		if cfg.progressInterval != 10*time.Second {
+			t.Fatalf("unexpected progress-interval: %s", cfg.progressInterval)
+		}
+		if cfg.stateFile != "/tmp/replay.json" || !cfg.force {
+			t.Fatalf("unexpected state settings: file=%q force=%v", cfg.stateFile, cfg.force)
+		}
+	})
+}
+
+func TestReadConfig_TopicPrefix(t *testing.T) {
+	t.Setenv("OMS_KAFKA_TOPIC_PREFIX", "")
+	withFlagArgs(t, []string{"-brokers=broker:9092", "-topic-prefix=staging"}, func() {
+		cfg, err := readConfig()
+		if err != nil {
+			t.Fatalf("readConfig failed: %v", err)
+		}
+		if cfg.sourceTopic != "staging.oms.dlq" || cfg.targetTopic != "staging.oms.order.events" {
+			t.Fatalf("unexpected topics: %s -> %s", cfg.sourceTopic, cfg.targetTopic)
+		}
+	})
+
+	t.Setenv("OMS_KAFKA_TOPIC_PREFIX", "prod")
+	withFlagArgs(t, []string{"-brokers=broker:9092", "-target-topic=manual.replay"}, func() {
+		cfg, err := readConfig()
+		if err != nil {
+			t.Fatalf("readConfig failed: %v", err)
+		}
+		if cfg.sourceTopic != "prod.oms.dlq" || cfg.targetTopic != "manual.replay" {
+			t.Fatalf("explicit topic must win over prefix: %s -> %s", cfg.sourceTopic, cfg.targetTopic)
+		}
+	})
+
+	withFlagArgs(t, []string{"-brokers=broker:9092", "-topic-prefix=bad/prefix"}, func() {
+		if _, err := readConfig(); err == nil || !strings.Contains(err.Error(), "topic-prefix") {
+			t.Fatalf("expected topic-prefix validation error, got: %v", err)
+		}
+	})
+
+	withFlagArgs(t, []string{"-brokers=broker:9092", "-source-topic=oms dlq"}, func() {
+		if _, err := readConfig(); err == nil || !strings.Contains(err.Error(), "source-topic") {
+			t.Fatalf("expected source-topic name validation error, got: %v", err)
+		}
 	})
 }
 
@@ -214,6 +301,13 @@ func TestReadConfig_ValidationErrors(t *testing.T) {
 			t.Fatalf("expected idle-timeout validation error, got: %v", err)
 		}
 	})
+
+	withFlagArgs(t, []string{"-brokers=broker:9092", "-source-topic=oms.dlq", "-target-topic=oms.order.events", "-progress-interval=0s"}, func() {
+		_, err := readConfig()
+		if err == nil || !strings.Contains(err.Error(), "progress-interval must be > 0") {
+			t.Fatalf("expected progress-interval validation error, got: %v", err)
+		}
+	})
 }
 
 func TestPublishReplay(t *testing.T) {
@@ -263,7 +357,7 @@ func TestProcessPartition_DryRun(t *testing.T) {
 		idleTimeout: 20 * time.Millisecond,
 	}
 
-	stats, err := processPartition(context.Background(), consumer, client, nil, cfg, 0, 10)
+	stats, err := processPartition(context.Background(), consumer, client, nil, cfg, nil, 0, 10, nil)
 	if err != nil {
 		t.Fatalf("processPartition failed: %v", err)
 	}
@@ -292,7 +386,7 @@ func TestProcessPartition_Execute(t *testing.T) {
 
 	cfg := config{sourceTopic: "oms.dlq", targetTopic: "oms.order.events", execute: true, idleTimeout: 20 * time.Millisecond}
 
-	stats, err := processPartition(context.Background(), consumer, client, producer, cfg, 0, 10)
+	stats, err := processPartition(context.Background(), consumer, client, producer, cfg, nil, 0, 10, nil)
 	if err != nil {
 		t.Fatalf("processPartition failed: %v", err)
 	}
@@ -304,17 +398,59 @@ func TestProcessPartition_Execute(t *testing.T) {
 	}
 }
 
+func TestProcessPartition_SkipsAlreadyReplayed(t *testing.T) {
+	messages := func() []*sarama.ConsumerMessage {
+		return []*sarama.ConsumerMessage{
+			{Partition: 0, Offset: 0, Value: []byte(`{"original_topic":"oms.order.events","original_key":"order-1","original_value":"{\"id\":\"evt-1\"}"}`)},
+			{Partition: 0, Offset: 1, Value: []byte(`{"original_topic":"oms.order.events","original_key":"order-2","original_value":"{\"id\":\"evt-2\"}"}`)},
+		}
+	}
+	client := &stubOffsetClient{offsets: map[int32]offsetRange{0: {oldest: 0, newest: 2}}}
+	cfg := config{sourceTopic: "oms.dlq", targetTopic: "oms.order.events", execute: true, idleTimeout: 20 * time.Millisecond}
+	path := filepath.Join(t.TempDir(), "state.json")
+
+	replay := func(cfg config) (partitionStats, *stubReplayProducer) {
+		t.Helper()
+		state, err := loadReplayState(path)
+		if err != nil {
+			t.Fatalf("load state: %v", err)
+		}
+		consumer := &stubPartitionConsumerSource{consumers: map[int32]partitionConsumer{0: closedPartitionConsumer(messages())}}
+		producer := &stubReplayProducer{}
+		stats, err := processPartition(context.Background(), consumer, client, producer, cfg, state, 0, 10, nil)
+		if err != nil {
+			t.Fatalf("processPartition failed: %v", err)
+		}
+		return stats, producer
+	}
+
+	if stats, producer := replay(cfg); stats.replayed != 2 || producer.calls != 2 {
+		t.Fatalf("first run must replay both messages: %+v calls=%d", stats, producer.calls)
+	}
+
+	stats, producer := replay(cfg)
+	if producer.calls != 0 || stats.alreadyReplayed != 2 || stats.skipped != 2 || stats.processed != 2 {
+		t.Fatalf("second run must skip replayed messages: %+v calls=%d", stats, producer.calls)
+	}
+
+	forced := cfg
+	forced.force = true
+	if stats, producer := replay(forced); stats.replayed != 2 || producer.calls != 2 {
+		t.Fatalf("-force must replay recorded messages again: %+v calls=%d", stats, producer.calls)
+	}
+}
+
 func TestProcessPartition_ErrorBranches(t *testing.T) {
 	cfg := config{sourceTopic: "oms.dlq", targetTopic: "oms.order.events", execute: true, idleTimeout: 20 * time.Millisecond}
 
 	clientOffsetErr := &stubOffsetClient{offsetErr: map[int32]error{0: errors.New("offset")}}
-	if _, err := processPartition(context.Background(), &stubPartitionConsumerSource{}, clientOffsetErr, &stubReplayProducer{}, cfg, 0, 1); err == nil {
+	if _, err := processPartition(context.Background(), &stubPartitionConsumerSource{}, clientOffsetErr, &stubReplayProducer{}, cfg, nil, 0, 1, nil); err == nil {
 		t.Fatal("expected offset error")
 	}
 
 	client := &stubOffsetClient{offsets: map[int32]offsetRange{0: {oldest: 0, newest: 2}}}
 	consumerErr := &stubPartitionConsumerSource{consumeErr: errors.New("consume")}
-	if _, err := processPartition(context.Background(), consumerErr, client, &stubReplayProducer{}, cfg, 0, 1); err == nil {
+	if _, err := processPartition(context.Background(), consumerErr, client, &stubReplayProducer{}, cfg, nil, 0, 1, nil); err == nil {
 		t.Fatal("expected consume error")
 	}
 
@@ -325,7 +461,7 @@ func TestProcessPartition_ErrorBranches(t *testing.T) {
 	pcWithErr.errors <- &sarama.ConsumerError{Err: errors.New("consumer boom")}
 	close(pcWithErr.errors)
 	consumer := &stubPartitionConsumerSource{consumers: map[int32]partitionConsumer{0: pcWithErr}}
-	if _, err := processPartition(context.Background(), consumer, client, &stubReplayProducer{}, cfg, 0, 1); err == nil {
+	if _, err := processPartition(context.Background(), consumer, client, &stubReplayProducer{}, cfg, nil, 0, 1, nil); err == nil {
 		t.Fatal("expected consumer error branch")
 	}
 	close(pcWithErr.messages)
@@ -336,7 +472,7 @@ func TestProcessPartition_ErrorBranches(t *testing.T) {
 		Value:     []byte(`{"id":"x","payload":"not-an-object"}`),
 	}})
 	consumer = &stubPartitionConsumerSource{consumers: map[int32]partitionConsumer{0: pcBadPayload}}
-	stats, err := processPartition(context.Background(), consumer, client, &stubReplayProducer{}, cfg, 0, 1)
+	stats, err := processPartition(context.Background(), consumer, client, &stubReplayProducer{}, cfg, nil, 0, 1, nil)
 	if err != nil {
 		t.Fatalf("unexpected bad-payload error: %v", err)
 	}
@@ -351,7 +487,7 @@ func TestProcessPartition_ErrorBranches(t *testing.T) {
 	}})
 	consumer = &stubPartitionConsumerSource{consumers: map[int32]partitionConsumer{0: pcOK}}
 	producer := &stubReplayProducer{sendErr: errors.New("send fail")}
-	if _, err := processPartition(context.Background(), consumer, client, producer, cfg, 0, 1); err == nil {
+	if _, err := processPartition(context.Background(), consumer, client, producer, cfg, nil, 0, 1, nil); err == nil {
 		t.Fatal("expected producer send error")
 	}
 }
@@ -366,7 +502,7 @@ func TestProcessPartition_IdleTimeoutAndContext(t *testing.T) {
 	consumer := &stubPartitionConsumerSource{consumers: map[int32]partitionConsumer{0: idleConsumer}}
 	cfg := config{sourceTopic: "oms.dlq", targetTopic: "oms.order.events", idleTimeout: 10 * time.Millisecond}
 
-	stats, err := processPartition(context.Background(), consumer, client, nil, cfg, 0, 1)
+	stats, err := processPartition(context.Background(), consumer, client, nil, cfg, nil, 0, 1, nil)
 	if err != nil {
 		t.Fatalf("unexpected idle-timeout error: %v", err)
 	}
@@ -384,7 +520,7 @@ func TestProcessPartition_IdleTimeoutAndContext(t *testing.T) {
 		errors:   make(chan *sarama.ConsumerError),
 	}
 	canceledConsumer := &stubPartitionConsumerSource{consumers: map[int32]partitionConsumer{0: canceledPC}}
-	if _, err := processPartition(ctx, canceledConsumer, client, nil, cfg, 0, 1); !errors.Is(err, context.Canceled) {
+	if _, err := processPartition(ctx, canceledConsumer, client, nil, cfg, nil, 0, 1, nil); !errors.Is(err, context.Canceled) {
 		t.Fatalf("expected context canceled, got %v", err)
 	}
 	close(canceledPC.messages)
@@ -0,0 +1,163 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"math"
+	"os"
+	"text/tabwriter"
+	"time"
+
+	"github.com/IBM/sarama"
+	log "github.com/sirupsen/logrus"
+)
+
+const defaultProgressInterval = 5 * time.Second
+
+// replayOutput — куда печатается итоговая таблица по партициям.
+var replayOutput io.Writer = os.Stdout
+
+// replayProgress периодически логирует ход replay и печатает итоговую сводку по партициям.
+// Nil-трекер (режим -quiet) ничего не выводит.
+type replayProgress struct {
+	out      io.Writer
+	interval time.Duration
+	now      func() time.Time
+	limit    int
+
+	startedAt  time.Time
+	lastReport time.Time
+	// backlog — число offset'ов в партициях, которые ещё не начали читать.
+	backlog    map[int32]int64
+	partitions []*partitionStats
+}
+
+func newReplayProgress(out io.Writer, interval time.Duration, limit int, backlog map[int32]int64, now func() time.Time) *replayProgress {
+	if now == nil {
+		now = time.Now
+	}
+	if interval <= 0 {
+		interval = defaultProgressInterval
+	}
+	startedAt := now()
+	return &replayProgress{
+		out:        out,
+		interval:   interval,
+		now:        now,
+		limit:      limit,
+		startedAt:  startedAt,
+		lastReport: startedAt,
+		backlog:    backlog,
+	}
+}
+
+// planBacklog оценивает объём DLQ по партициям для расчёта ETA.
+// Ошибки чтения offset'ов здесь не фатальны: их вернёт processPartition.
+func planBacklog(client offsetClient, topic string, partitions []int32) map[int32]int64 {
+	backlog := make(map[int32]int64, len(partitions))
+	for _, partition := range partitions {
+		oldest, err := client.GetOffset(topic, partition, sarama.OffsetOldest)
+		if err != nil {
+			continue
+		}
+		newest, err := client.GetOffset(topic, partition, sarama.OffsetNewest)
+		if err != nil || newest <= oldest {
+			continue
+		}
+		backlog[partition] = newest - oldest
+	}
+	return backlog
+}
+
+func (p *replayProgress) startPartition(stats *partitionStats) {
+	if p == nil {
+		return
+	}
+	delete(p.backlog, stats.partition)
+	p.partitions = append(p.partitions, stats)
+}
+
+// observe вызывается после каждого обработанного сообщения и логирует прогресс не чаще interval.
+func (p *replayProgress) observe(current *partitionStats) {
+	if p == nil {
+		return
+	}
+	now := p.now()
+	if now.Sub(p.lastReport) < p.interval {
+		return
+	}
+	p.lastReport = now
+	p.report(current, now)
+}
+
+func (p *replayProgress) report(current *partitionStats, now time.Time) {
+	totals := p.totals()
+	elapsed := now.Sub(p.startedAt)
+	remaining := p.remaining(totals.processed)
+
+	fields := log.Fields{
+		"partition":           current.partition,
+		"partition_processed": current.processed,
+		"partition_replayed":  current.replayed,
+		"partition_skipped":   current.skipped,
+		"processed":           totals.processed,
+		"replayed":            totals.replayed,
+		"skipped":             totals.skipped,
+		"remaining":           remaining,
+		"elapsed":             elapsed.Round(time.Second).String(),
+		"eta":                 "unknown",
+	}
+	if elapsed > 0 && totals.processed > 0 {
+		rate := float64(totals.processed) / elapsed.Seconds()
+		fields["rate_per_sec"] = math.Round(rate*10) / 10
+		fields["eta"] = (time.Duration(float64(remaining)/rate) * time.Second).Round(time.Second).String()
+	}
+	log.WithFields(fields).Info("dlq replay progress")
+}
+
+// remaining — сколько offset'ов ещё предстоит прочитать с учётом limit.
+func (p *replayProgress) remaining(processed int) int64 {
+	var remaining int64
+	for _, stats := range p.partitions {
+		if left := stats.endOffset - stats.nextOffset; left > 0 && !stats.done {
+			remaining += left
+		}
+	}
+	for _, count := range p.backlog {
+		remaining += count
+	}
+	if byLimit := int64(p.limit - processed); byLimit < remaining {
+		remaining = byLimit
+	}
+	if remaining < 0 {
+		return 0
+	}
+	return remaining
+}
+
+func (p *replayProgress) totals() partitionStats {
+	var totals partitionStats
+	for _, stats := range p.partitions {
+		totals.processed += stats.processed
+		totals.replayed += stats.replayed
+		totals.skipped += stats.skipped
+	}
+	return totals
+}
+
+// printSummary печатает итоговую таблицу по партициям.
+func (p *replayProgress) printSummary(mode string) {
+	if p == nil || p.out == nil {
+		return
+	}
+
+	w := tabwriter.NewWriter(p.out, 0, 0, 2, ' ', tabwriter.AlignRight)
+	_, _ = fmt.Fprintf(w, "PARTITION\tSTART\tEND\tPROCESSED\tREPLAYED\tSKIPPED\t\n")
+	for _, stats := range p.partitions {
+		_, _ = fmt.Fprintf(w, "%d\t%d\t%d\t%d\t%d\t%d\t\n",
+			stats.partition, stats.startOffset, stats.endOffset, stats.processed, stats.replayed, stats.skipped)
+	}
+	totals := p.totals()
+	_, _ = fmt.Fprintf(w, "TOTAL (%s)\t\t\t%d\t%d\t%d\t\n", mode, totals.processed, totals.replayed, totals.skipped)
+	_ = w.Flush()
+}
@@ -0,0 +1,134 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/IBM/sarama"
+	logtest "github.com/sirupsen/logrus/hooks/test"
+)
+
+type fakeClock struct {
+	now time.Time
+}
+
+func (c *fakeClock) Now() time.Time { return c.now }
+
+func TestReplayProgress_ObserveReportsRateAndETA(t *testing.T) {
+	hook := logtest.NewGlobal()
+	defer hook.Reset()
+
+	clock := &fakeClock{now: time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)}
+	progress := newReplayProgress(nil, 10*time.Second, 1000, map[int32]int64{1: 300}, clock.Now)
+
+	current := &partitionStats{partition: 0, startOffset: 0, endOffset: 100}
+	progress.startPartition(current)
+
+	current.processed, current.replayed, current.skipped, current.nextOffset = 50, 48, 2, 50
+	clock.now = clock.now.Add(5 * time.Second)
+	progress.observe(current)
+	if len(hook.AllEntries()) != 0 {
+		t.Fatal("expected no progress log before interval elapsed")
+	}
+
+	clock.now = clock.now.Add(5 * time.Second)
+	progress.observe(current)
+	entry := hook.LastEntry()
+	if entry == nil || entry.Message != "dlq replay progress" {
+		t.Fatalf("expected progress log entry, got %+v", entry)
+	}
+
+	// 50 offset'ов осталось в текущей партиции и 300 в ещё не начатой; скорость 5 msg/s.
+	if entry.Data["remaining"] != int64(350) {
+		t.Fatalf("unexpected remaining: %v", entry.Data["remaining"])
+	}
+	if entry.Data["rate_per_sec"] != 5.0 {
+		t.Fatalf("unexpected rate: %v", entry.Data["rate_per_sec"])
+	}
+	if entry.Data["eta"] != "1m10s" {
+		t.Fatalf("unexpected eta: %v", entry.Data["eta"])
+	}
+	if entry.Data["partition_skipped"] != 2 || entry.Data["replayed"] != 48 {
+		t.Fatalf("unexpected counters: %+v", entry.Data)
+	}
+}
+
+func TestReplayProgress_RemainingBoundedByLimit(t *testing.T) {
+	progress := newReplayProgress(nil, time.Second, 20, map[int32]int64{1: 500}, nil)
+	progress.startPartition(&partitionStats{partition: 0, endOffset: 100, nextOffset: 90, processed: 15})
+
+	if got := progress.remaining(15); got != 5 {
+		t.Fatalf("expected remaining to be bounded by limit, got %d", got)
+	}
+}
+
+func TestReplayProgress_PrintSummary(t *testing.T) {
+	var out bytes.Buffer
+	progress := newReplayProgress(&out, time.Second, 100, nil, nil)
+	progress.startPartition(&partitionStats{partition: 0, startOffset: 0, endOffset: 3, processed: 3, replayed: 2, skipped: 1})
+	progress.startPartition(&partitionStats{partition: 2, startOffset: 5, endOffset: 7, processed: 2, replayed: 2})
+
+	progress.printSummary("execute")
+
+	lines := strings.Split(strings.TrimSpace(out.String()), "\n")
+	if len(lines) != 4 {
+		t.Fatalf("expected header, two partitions and total, got:\n%s", out.String())
+	}
+	if !strings.Contains(lines[0], "PARTITION") || !strings.Contains(lines[3], "TOTAL (execute)") {
+		t.Fatalf("unexpected summary:\n%s", out.String())
+	}
+	if fields := strings.Fields(lines[3]); fields[len(fields)-3] != "5" || fields[len(fields)-2] != "4" || fields[len(fields)-1] != "1" {
+		t.Fatalf("unexpected totals row: %q", lines[3])
+	}
+
+	var nilProgress *replayProgress
+	nilProgress.printSummary("dry-run")
+	nilProgress.observe(&partitionStats{})
+}
+
+func TestRunReplay_QuietSkipsSummary(t *testing.T) {
+	oldOutput := replayOutput
+	defer func() { replayOutput = oldOutput }()
+
+	newDeps := func() (*stubOffsetClient, *stubPartitionConsumerSource) {
+		client := &stubOffsetClient{
+			partitions: []int32{0},
+			offsets:    map[int32]offsetRange{0: {oldest: 0, newest: 1}},
+		}
+		consumer := &stubPartitionConsumerSource{
+			consumers: map[int32]partitionConsumer{
+				0: closedPartitionConsumer([]*sarama.ConsumerMessage{{
+					Partition: 0,
+					Offset:    0,
+					Value:     []byte(`{"original_topic":"oms.order.events","original_key":"order-1","original_value":"{\"id\":\"evt-1\"}"}`),
+				}}),
+			},
+		}
+		return client, consumer
+	}
+
+	cfg := config{sourceTopic: "oms.dlq", targetTopic: "oms.order.events", limit: 10, idleTimeout: 20 * time.Millisecond, progressInterval: time.Second}
+
+	var out bytes.Buffer
+	replayOutput = &out
+	client, consumer := newDeps()
+	if err := runReplay(context.Background(), cfg, client, consumer, nil); err != nil {
+		t.Fatalf("runReplay failed: %v", err)
+	}
+	if !strings.Contains(out.String(), "TOTAL (dry-run)") {
+		t.Fatalf("expected summary table, got %q", out.String())
+	}
+
+	out.Reset()
+	cfg.quiet = true
+	client, consumer = newDeps()
+	if err := runReplay(context.Background(), cfg, client, consumer, nil); err != nil {
+		t.Fatalf("runReplay failed: %v", err)
+	}
+	if out.Len() != 0 {
+		t.Fatalf("expected no output in quiet mode, got %q", out.String())
+	}
+}
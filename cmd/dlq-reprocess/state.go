@@ -0,0 +1,103 @@
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"sort"
+
+	"github.com/vladislavdragonenkov/oms/internal/fileutil"
+)
+
+const defaultStateFile = "dlq-replay-state.json"
+
+// replayState — журнал уже переотправленных сообщений DLQ по (source topic, partition, offset).
+// Повторный запуск по тому же диапазону пропускает их, поэтому downstream не получает дубликаты.
+// Offset'ы хранятся диапазонами: непрерывный replay занимает одну запись на партицию.
+// Nil-состояние (пустой -state-file) ничего не помнит и ничего не пишет.
+type replayState struct {
+	path   string
+	Topics map[string]map[int32][]offsetSpan `json:"topics"`
+}
+
+// offsetSpan — диапазон offset'ов [From, To] включительно.
+type offsetSpan struct {
+	From int64 `json:"from"`
+	To   int64 `json:"to"`
+}
+
+// loadReplayState читает журнал из path; отсутствие файла означает пустой журнал.
+func loadReplayState(path string) (*replayState, error) {
+	if path == "" {
+		return nil, nil
+	}
+	state := &replayState{path: path, Topics: make(map[string]map[int32][]offsetSpan)}
+
+	data, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return state, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("read replay state: %w", err)
+	}
+	if err := json.Unmarshal(data, state); err != nil {
+		return nil, fmt.Errorf("decode replay state %s: %w", path, err)
+	}
+	if state.Topics == nil {
+		state.Topics = make(map[string]map[int32][]offsetSpan)
+	}
+	return state, nil
+}
+
+func (s *replayState) replayed(topic string, partition int32, offset int64) bool {
+	if s == nil {
+		return false
+	}
+	spans := s.Topics[topic][partition]
+	idx := sort.Search(len(spans), func(i int) bool { return spans[i].To >= offset })
+	return idx < len(spans) && spans[idx].From <= offset
+}
+
+// mark добавляет offset в журнал, склеивая соседние диапазоны.
+func (s *replayState) mark(topic string, partition int32, offset int64) {
+	if s == nil || s.replayed(topic, partition, offset) {
+		return
+	}
+	partitions := s.Topics[topic]
+	if partitions == nil {
+		partitions = make(map[int32][]offsetSpan)
+		s.Topics[topic] = partitions
+	}
+
+	spans := append(partitions[partition], offsetSpan{From: offset, To: offset})
+	sort.Slice(spans, func(i, j int) bool { return spans[i].From < spans[j].From })
+	merged := spans[:1]
+	for _, span := range spans[1:] {
+		last := &merged[len(merged)-1]
+		if span.From <= last.To+1 {
+			if span.To > last.To {
+				last.To = span.To
+			}
+			continue
+		}
+		merged = append(merged, span)
+	}
+	partitions[partition] = merged
+}
+
+// save пишет журнал атомарно, чтобы прерванный replay не оставил битый файл.
+func (s *replayState) save() error {
+	if s == nil {
+		return nil
+	}
+	data, err := json.MarshalIndent(s, "", "  ")
+	if err != nil {
+		return fmt.Errorf("encode replay state: %w", err)
+	}
+
+	if err := fileutil.WriteFileAtomic(s.path, data); err != nil {
+		return fmt.Errorf("save replay state: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,65 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestReplayState_MarkMergesSpans(t *testing.T) {
+	state, err := loadReplayState(filepath.Join(t.TempDir(), "state.json"))
+	if err != nil {
+		t.Fatalf("load empty state: %v", err)
+	}
+
+	for _, offset := range []int64{5, 3, 7, 4, 6, 10} {
+		state.mark("oms.dlq", 0, offset)
+	}
+	state.mark("oms.dlq", 1, 3)
+
+	spans := state.Topics["oms.dlq"][0]
+	if len(spans) != 2 || spans[0] != (offsetSpan{From: 3, To: 7}) || spans[1] != (offsetSpan{From: 10, To: 10}) {
+		t.Fatalf("unexpected spans: %+v", spans)
+	}
+	for offset, want := range map[int64]bool{2: false, 3: true, 7: true, 8: false, 10: true, 11: false} {
+		if got := state.replayed("oms.dlq", 0, offset); got != want {
+			t.Fatalf("replayed(%d) = %v, want %v", offset, got, want)
+		}
+	}
+	if state.replayed("oms.dlq", 2, 3) || state.replayed("staging.oms.dlq", 0, 3) {
+		t.Fatal("state must be scoped by topic and partition")
+	}
+}
+
+func TestReplayState_SaveAndLoad(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "state.json")
+	state, err := loadReplayState(path)
+	if err != nil {
+		t.Fatalf("load empty state: %v", err)
+	}
+	state.mark("oms.dlq", 1, 42)
+	if err := state.save(); err != nil {
+		t.Fatalf("save state: %v", err)
+	}
+
+	reloaded, err := loadReplayState(path)
+	if err != nil {
+		t.Fatalf("reload state: %v", err)
+	}
+	if !reloaded.replayed("oms.dlq", 1, 42) {
+		t.Fatalf("expected offset 42 to survive reload: %+v", reloaded.Topics)
+	}
+
+	if err := os.WriteFile(path, []byte("{broken"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := loadReplayState(path); err == nil {
+		t.Fatal("expected decode error for corrupted state file")
+	}
+
+	var disabled *replayState
+	disabled.mark("oms.dlq", 0, 1)
+	if disabled.replayed("oms.dlq", 0, 1) || disabled.save() != nil {
+		t.Fatal("nil state must be a no-op")
+	}
+}
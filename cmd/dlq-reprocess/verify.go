@@ -0,0 +1,242 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"sort"
+	"text/tabwriter"
+	"time"
+
+	"github.com/IBM/sarama"
+	log "github.com/sirupsen/logrus"
+
+	"github.com/vladislavdragonenkov/oms/internal/messaging/kafka"
+)
+
+// Классы сообщений в отчёте -verify-topic: по исходному топику определяется, каким парсером
+// читать сообщение после обратного чтения.
+const (
+	messageClassSagaEvent  = "saga_event"
+	messageClassOrderEvent = "order_event"
+	// messageClassOther — топик без парсера в OMS: проверяется только совпадение ключа и payload.
+	messageClassOther = "other"
+)
+
+// verifyRecord — сообщение DLQ, отправленное в проверочный топик вместо настоящего.
+type verifyRecord struct {
+	class           string
+	sourcePartition int32
+	sourceOffset    int64
+	// partition и offset — куда сообщение записано в проверочном топике.
+	partition int32
+	offset    int64
+	msg       replayMessage
+	// failure — причина провала проверки; пусто — сообщение прошло.
+	failure string
+}
+
+type classVerdict struct {
+	messages int
+	passed   int
+	failed   int
+}
+
+func classifyReplay(cfg config, msg replayMessage) string {
+	switch msg.topic {
+	case cfg.sagaTopic:
+		return messageClassSagaEvent
+	case cfg.orderTopic, cfg.targetTopic:
+		return messageClassOrderEvent
+	default:
+		return messageClassOther
+	}
+}
+
+// publishVerification отправляет сообщение в проверочный топик с тем же ключом, payload и content-type.
+func publishVerification(producer replayProducer, cfg config, msg replayMessage) (verifyRecord, error) {
+	record := verifyRecord{class: classifyReplay(cfg, msg), msg: msg}
+	redirected := msg
+	redirected.topic = cfg.verifyTopic
+	partition, offset, err := producer.SendMessage(newProducerMessage(redirected))
+	if err != nil {
+		return verifyRecord{}, err
+	}
+	record.partition = partition
+	record.offset = offset
+	return record, nil
+}
+
+// verifyReplay читает отправленные сообщения обратно из проверочного топика и сверяет их
+// с исходными. Возвращает ошибку, если хотя бы одно сообщение не прошло проверку.
+func verifyReplay(ctx context.Context, cfg config, consumer partitionConsumerSource, records []*verifyRecord) error {
+	byPartition := make(map[int32][]*verifyRecord)
+	for _, record := range records {
+		byPartition[record.partition] = append(byPartition[record.partition], record)
+	}
+	partitions := make([]int32, 0, len(byPartition))
+	for partition := range byPartition {
+		partitions = append(partitions, partition)
+	}
+	sort.Slice(partitions, func(i, j int) bool { return partitions[i] < partitions[j] })
+
+	for _, partition := range partitions {
+		if err := readBackPartition(ctx, cfg, consumer, partition, byPartition[partition]); err != nil {
+			return err
+		}
+	}
+
+	verdicts := make(map[string]*classVerdict)
+	failed := 0
+	for _, record := range records {
+		verdict, ok := verdicts[record.class]
+		if !ok {
+			verdict = &classVerdict{}
+			verdicts[record.class] = verdict
+		}
+		verdict.messages++
+		if record.failure == "" {
+			verdict.passed++
+			continue
+		}
+		verdict.failed++
+		failed++
+		log.WithFields(log.Fields{
+			"class":     record.class,
+			"partition": record.sourcePartition,
+			"offset":    record.sourceOffset,
+			"key":       record.msg.key,
+			"reason":    record.failure,
+		}).Warn("dlq message failed verification")
+	}
+
+	log.WithFields(log.Fields{
+		"verify_topic": cfg.verifyTopic,
+		"messages":     len(records),
+		"failed":       failed,
+	}).Info("dlq replay verification finished")
+	if !cfg.quiet {
+		printVerdicts(replayOutput, verdicts)
+	}
+	if failed > 0 {
+		return fmt.Errorf("verification failed for %d of %d messages", failed, len(records))
+	}
+	return nil
+}
+
+// readBackPartition читает партицию проверочного топика с первого отправленного offset'а до
+// последнего. Сообщения, не прочитанные до idle-timeout, считаются потерянными.
+func readBackPartition(ctx context.Context, cfg config, consumer partitionConsumerSource, partition int32, records []*verifyRecord) error {
+	pending := make(map[int64]*verifyRecord, len(records))
+	first, last := records[0].offset, records[0].offset
+	for _, record := range records {
+		pending[record.offset] = record
+		first = min(first, record.offset)
+		last = max(last, record.offset)
+	}
+	defer func() {
+		for _, record := range pending {
+			record.failure = "not found in verify topic"
+		}
+	}()
+
+	partitionConsumer, err := consumer.ConsumePartition(cfg.verifyTopic, partition, first)
+	if err != nil {
+		return fmt.Errorf("consume verify topic partition %d: %w", partition, err)
+	}
+	defer func() { _ = partitionConsumer.Close() }()
+
+	idleTimer := time.NewTimer(cfg.idleTimeout)
+	defer idleTimer.Stop()
+	for len(pending) > 0 {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case err := <-partitionConsumer.Errors():
+			if err != nil {
+				return fmt.Errorf("verify topic partition %d consumer error: %w", partition, err)
+			}
+		case msg, ok := <-partitionConsumer.Messages():
+			if !ok || msg == nil || msg.Offset > last {
+				return nil
+			}
+			if !idleTimer.Stop() {
+				select {
+				case <-idleTimer.C:
+				default:
+				}
+			}
+			idleTimer.Reset(cfg.idleTimeout)
+
+			record, ok := pending[msg.Offset]
+			if !ok {
+				continue
+			}
+			delete(pending, msg.Offset)
+			if err := checkReadBack(record, msg); err != nil {
+				record.failure = err.Error()
+			}
+		case <-idleTimer.C:
+			return nil
+		}
+	}
+	return nil
+}
+
+// checkReadBack сверяет прочитанное сообщение с отправленным и разбирает его тем же парсером,
+// что и consumer'ы OMS.
+func checkReadBack(record *verifyRecord, msg *sarama.ConsumerMessage) error {
+	if string(msg.Key) != record.msg.key {
+		return fmt.Errorf("key mismatch: got %q", msg.Key)
+	}
+	if !bytes.Equal(msg.Value, record.msg.value) {
+		return fmt.Errorf("payload mismatch: %d bytes read back, %d sent", len(msg.Value), len(record.msg.value))
+	}
+	if got := headerValue(msg, kafka.HeaderContentType); got != record.msg.contentType {
+		return fmt.Errorf("content-type mismatch: got %q, sent %q", got, record.msg.contentType)
+	}
+
+	switch record.class {
+	case messageClassSagaEvent:
+		_, err := kafka.ParseSagaEvent(msg)
+		return err
+	case messageClassOrderEvent:
+		_, err := kafka.ParseOrderEvent(msg)
+		return err
+	default:
+		return nil
+	}
+}
+
+func headerValue(msg *sarama.ConsumerMessage, key string) string {
+	for _, header := range msg.Headers {
+		if header != nil && string(header.Key) == key {
+			return string(header.Value)
+		}
+	}
+	return ""
+}
+
+func printVerdicts(out io.Writer, verdicts map[string]*classVerdict) {
+	if out == nil {
+		return
+	}
+	classes := make([]string, 0, len(verdicts))
+	for class := range verdicts {
+		classes = append(classes, class)
+	}
+	sort.Strings(classes)
+
+	w := tabwriter.NewWriter(out, 0, 0, 2, ' ', 0)
+	_, _ = fmt.Fprintf(w, "CLASS\tMESSAGES\tPASSED\tFAILED\tVERDICT\n")
+	for _, class := range classes {
+		verdict := verdicts[class]
+		result := "PASS"
+		if verdict.failed > 0 {
+			result = "FAIL"
+		}
+		_, _ = fmt.Fprintf(w, "%s\t%d\t%d\t%d\t%s\n", class, verdict.messages, verdict.passed, verdict.failed, result)
+	}
+	_ = w.Flush()
+}
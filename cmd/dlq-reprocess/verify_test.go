@@ -0,0 +1,180 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/IBM/sarama"
+)
+
+const (
+	verifyTestSagaTopic  = "oms.saga.events"
+	verifyTestOrderTopic = "oms.order.events"
+	verifyTestTopic      = "oms.dlq.verify"
+)
+
+func verifyTestConfig() config {
+	return config{
+		sourceTopic: "oms.dlq",
+		targetTopic: verifyTestOrderTopic,
+		orderTopic:  verifyTestOrderTopic,
+		sagaTopic:   verifyTestSagaTopic,
+		verifyTopic: verifyTestTopic,
+		limit:       10,
+		idleTimeout: 20 * time.Millisecond,
+		quiet:       true,
+	}
+}
+
+func verifyTestDLQ() []*sarama.ConsumerMessage {
+	return []*sarama.ConsumerMessage{
+		{Partition: 0, Offset: 0, Value: []byte(`{"original_topic":"oms.saga.events","original_key":"order-1","original_value":"{\"order_id\":\"order-1\",\"event_type\":\"saga.started\"}"}`)},
+		{Partition: 0, Offset: 1, Value: []byte(`{"original_topic":"oms.order.events","original_key":"order-2","original_value":"{\"id\":\"evt-2\"}"}`)},
+		{Partition: 0, Offset: 2, Value: []byte(`{"original_topic":"oms.saga.events","original_key":"order-3","original_value":"not json"}`)},
+		{Partition: 0, Offset: 3, Value: []byte(`{"original_topic":"partner.events","original_key":"p-1","original_value":"opaque"}`)},
+	}
+}
+
+// loopbackKafka отдаёт сообщения, отправленные в проверочный топик, обратно consumer'у;
+// mutate портит сообщение по пути, drop подтверждает отправку, но теряет сообщение.
+type loopbackKafka struct {
+	dlq    []*sarama.ConsumerMessage
+	sent   []*sarama.ConsumerMessage
+	mutate func(*sarama.ConsumerMessage)
+	drop   bool
+}
+
+func (l *loopbackKafka) SendMessage(msg *sarama.ProducerMessage) (int32, int64, error) {
+	key, _ := msg.Key.Encode()
+	value, _ := msg.Value.Encode()
+	consumed := &sarama.ConsumerMessage{Topic: msg.Topic, Offset: int64(len(l.sent)), Key: key, Value: value}
+	for _, header := range msg.Headers {
+		consumed.Headers = append(consumed.Headers, &sarama.RecordHeader{Key: header.Key, Value: header.Value})
+	}
+	if l.mutate != nil {
+		l.mutate(consumed)
+	}
+	if !l.drop {
+		l.sent = append(l.sent, consumed)
+	}
+	return 0, consumed.Offset, nil
+}
+
+func (l *loopbackKafka) Close() error { return nil }
+
+func (l *loopbackKafka) ConsumePartition(topic string, _ int32, offset int64) (partitionConsumer, error) {
+	if topic != verifyTestTopic {
+		return closedPartitionConsumer(l.dlq), nil
+	}
+	return closedPartitionConsumer(l.sent[offset:]), nil
+}
+
+func runVerify(t *testing.T, kafka *loopbackKafka) (string, error) {
+	t.Helper()
+	var out bytes.Buffer
+	original := replayOutput
+	replayOutput = &out
+	t.Cleanup(func() { replayOutput = original })
+
+	cfg := verifyTestConfig()
+	cfg.quiet = false
+	first, last := kafka.dlq[0].Offset, kafka.dlq[len(kafka.dlq)-1].Offset
+	client := &stubOffsetClient{partitions: []int32{0}, offsets: map[int32]offsetRange{0: {oldest: first, newest: last + 1}}}
+	err := runReplay(context.Background(), cfg, client, kafka, kafka)
+	return out.String(), err
+}
+
+func TestVerify_ReportsVerdictPerClass(t *testing.T) {
+	kafka := &loopbackKafka{dlq: verifyTestDLQ()}
+	out, err := runVerify(t, kafka)
+	if err == nil || !strings.Contains(err.Error(), "1 of 4") {
+		t.Fatalf("expected unparseable saga event to fail verification, got %v", err)
+	}
+
+	for _, sent := range kafka.sent {
+		if sent.Topic != verifyTestTopic {
+			t.Fatalf("verify mode must not publish to real topics, got %s", sent.Topic)
+		}
+	}
+	for _, want := range []string{
+		"order_event  1         1       0       PASS",
+		"other        1         1       0       PASS",
+		"saga_event   2         1       1       FAIL",
+	} {
+		if !strings.Contains(out, want) {
+			t.Fatalf("verdict table misses %q:\n%s", want, out)
+		}
+	}
+}
+
+func TestVerify_DetectsCorruptionAndLoss(t *testing.T) {
+	dlq := verifyTestDLQ()[1:2]
+
+	corrupted := &loopbackKafka{dlq: dlq, mutate: func(msg *sarama.ConsumerMessage) { msg.Value = msg.Value[:len(msg.Value)-1] }}
+	if _, err := runVerify(t, corrupted); err == nil {
+		t.Fatal("expected truncated payload to fail verification")
+	}
+
+	retyped := &loopbackKafka{dlq: dlq, mutate: func(msg *sarama.ConsumerMessage) {
+		msg.Headers = append(msg.Headers, &sarama.RecordHeader{Key: []byte("content-type"), Value: []byte("application/x-protobuf")})
+	}}
+	if _, err := runVerify(t, retyped); err == nil {
+		t.Fatal("expected unexpected content-type to fail verification")
+	}
+
+	lost := &loopbackKafka{dlq: dlq, drop: true}
+	if _, err := runVerify(t, lost); err == nil || !strings.Contains(fmt.Sprint(err), "1 of 1") {
+		t.Fatalf("expected message missing from verify topic to fail, got %v", err)
+	}
+}
+
+func TestVerify_DoesNotRecordState(t *testing.T) {
+	kafka := &loopbackKafka{dlq: verifyTestDLQ()[:2]}
+	cfg := verifyTestConfig()
+	cfg.stateFile = t.TempDir() + "/state.json"
+	client := &stubOffsetClient{partitions: []int32{0}, offsets: map[int32]offsetRange{0: {oldest: 0, newest: 2}}}
+
+	if err := runReplay(context.Background(), cfg, client, kafka, kafka); err != nil {
+		t.Fatalf("verification of valid messages failed: %v", err)
+	}
+	state, err := loadReplayState(cfg.stateFile)
+	if err != nil {
+		t.Fatalf("load state: %v", err)
+	}
+	if state.replayed(cfg.sourceTopic, 0, 0) {
+		t.Fatal("verify mode must not mark messages as replayed")
+	}
+
+	if err := runReplay(context.Background(), cfg, client, kafka, nil); err == nil {
+		t.Fatal("expected verify mode to require producer")
+	}
+}
+
+func TestReadConfig_VerifyTopic(t *testing.T) {
+	t.Setenv("OMS_KAFKA_TOPIC_PREFIX", "")
+	withFlagArgs(t, []string{"-brokers=broker:9092", "-verify-topic= oms.dlq.verify "}, func() {
+		cfg, err := readConfig()
+		if err != nil {
+			t.Fatalf("readConfig failed: %v", err)
+		}
+		if cfg.verifyTopic != "oms.dlq.verify" || cfg.sagaTopic != "oms.saga.events" || cfg.orderTopic != "oms.order.events" {
+			t.Fatalf("unexpected verify settings: %+v", cfg)
+		}
+	})
+
+	for _, args := range [][]string{
+		{"-verify-topic=oms.dlq.verify", "-execute"},
+		{"-verify-topic=oms.saga.events"},
+		{"-verify-topic=bad topic"},
+	} {
+		withFlagArgs(t, append([]string{"-brokers=broker:9092"}, args...), func() {
+			if _, err := readConfig(); err == nil || !strings.Contains(err.Error(), "verify-topic") {
+				t.Fatalf("%v: expected verify-topic validation error, got %v", args, err)
+			}
+		})
+	}
+}
@@ -0,0 +1,111 @@
+package main
+
+import (
+	"encoding/json"
+	"sync"
+	"time"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+)
+
+const (
+	defaultErrorsPerCode = 5
+	// maxErrorMessageLen обрезает текст ошибки: сервер может вернуть в нём весь запрос.
+	maxErrorMessageLen = 1024
+)
+
+// errorSample — одна неуспешная RPC вместе с запросом, который её вызвал.
+type errorSample struct {
+	At             time.Time       `json:"at"`
+	Method         string          `json:"method"`
+	Target         string          `json:"target"`
+	Message        string          `json:"message"`
+	IdempotencyKey string          `json:"idempotency_key"`
+	Request        json.RawMessage `json:"request,omitempty"`
+}
+
+type codeSamples struct {
+	Total   int64         `json:"total"`
+	Samples []errorSample `json:"samples"`
+}
+
+// errorsReport — содержимое -errors-output: первые N ошибок каждого gRPC-кода.
+type errorsReport struct {
+	PerCode int                    `json:"per_code"`
+	Codes   map[string]codeSamples `json:"codes"`
+}
+
+// errorSampler хранит не больше perCode сэмплов на код, остальные ошибки только считает.
+type errorSampler struct {
+	mu      sync.Mutex
+	perCode int
+	codes   map[codes.Code]*codeSamples
+}
+
+func newErrorSampler(perCode int) *errorSampler {
+	if perCode <= 0 {
+		perCode = defaultErrorsPerCode
+	}
+	return &errorSampler{perCode: perCode, codes: make(map[codes.Code]*codeSamples)}
+}
+
+func (s *errorSampler) add(method string, p *peer.Peer, req proto.Message, key string, err error) {
+	if s == nil || err == nil {
+		return
+	}
+	st := status.Convert(err)
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	bucket, ok := s.codes[st.Code()]
+	if !ok {
+		bucket = &codeSamples{}
+		s.codes[st.Code()] = bucket
+	}
+	bucket.Total++
+	if len(bucket.Samples) >= s.perCode {
+		return
+	}
+
+	message := st.Message()
+	if len(message) > maxErrorMessageLen {
+		message = message[:maxErrorMessageLen] + "..."
+	}
+	sample := errorSample{
+		At:             time.Now().UTC(),
+		Method:         method,
+		Target:         peerTarget(p),
+		Message:        message,
+		IdempotencyKey: key,
+	}
+	if req != nil {
+		if raw, marshalErr := protojson.Marshal(req); marshalErr == nil {
+			sample.Request = raw
+		}
+	}
+	bucket.Samples = append(bucket.Samples, sample)
+}
+
+func (s *errorSampler) report() errorsReport {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	result := errorsReport{PerCode: s.perCode, Codes: make(map[string]codeSamples, len(s.codes))}
+	for code, bucket := range s.codes {
+		result.Codes[code.String()] = codeSamples{
+			Total:   bucket.Total,
+			Samples: append([]errorSample(nil), bucket.Samples...),
+		}
+	}
+	return result
+}
+
+// recordError сохраняет сэмпл ошибки, если включён -errors-output.
+func (c *collector) recordError(method string, p *peer.Peer, req proto.Message, key string, err error) {
+	c.errors.add(method, p, req, key, err)
+}
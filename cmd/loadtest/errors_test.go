@@ -0,0 +1,88 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+
+	omsv1 "github.com/vladislavdragonenkov/oms/proto/oms/v1"
+)
+
+func TestErrorSampler_BoundsSamplesPerCode(t *testing.T) {
+	sampler := newErrorSampler(2)
+	req := &omsv1.CreateOrderRequest{CustomerId: "c-1", Currency: "USD"}
+
+	for i := 0; i < 5; i++ {
+		sampler.add("CreateOrder", &peer.Peer{}, req, "key", status.Error(codes.InvalidArgument, "currency is required"))
+	}
+	sampler.add("PayOrder", nil, nil, "pay-key", status.Error(codes.Unavailable, strings.Repeat("x", maxErrorMessageLen+10)))
+	sampler.add("PayOrder", nil, nil, "pay-key", nil)
+
+	result := sampler.report()
+	invalid := result.Codes[codes.InvalidArgument.String()]
+	if result.PerCode != 2 || invalid.Total != 5 || len(invalid.Samples) != 2 {
+		t.Fatalf("expected 2 samples of 5 InvalidArgument errors, got %+v", invalid)
+	}
+	sample := invalid.Samples[0]
+	if sample.Method != "CreateOrder" || sample.Target != unknownTarget || sample.IdempotencyKey != "key" {
+		t.Fatalf("unexpected sample: %+v", sample)
+	}
+	var decoded map[string]any
+	if err := json.Unmarshal(sample.Request, &decoded); err != nil || decoded["customerId"] != "c-1" {
+		t.Fatalf("expected request payload in sample, got %s (%v)", sample.Request, err)
+	}
+
+	unavailable := result.Codes[codes.Unavailable.String()]
+	if unavailable.Total != 1 || len(unavailable.Samples[0].Message) != maxErrorMessageLen+len("...") {
+		t.Fatalf("expected truncated message, got %+v", unavailable)
+	}
+	if _, ok := result.Codes[codes.OK.String()]; ok {
+		t.Fatal("successful calls must not be sampled")
+	}
+}
+
+func TestCallCreateOrder_RecordsErrorSample(t *testing.T) {
+	client := &fakeOrderServiceClient{
+		createFn: func(context.Context, *omsv1.CreateOrderRequest, ...grpc.CallOption) (*omsv1.CreateOrderResponse, error) {
+			return nil, status.Error(codes.InvalidArgument, "items[0].price must be > 0")
+		},
+	}
+	col := newCollector()
+	col.errors = newErrorSampler(defaultErrorsPerCode)
+
+	req := &omsv1.CreateOrderRequest{CustomerId: "c-1", Currency: "USD"}
+	if _, err := callCreateOrder(client, time.Second, req, "lt-create-1", col); err == nil {
+		t.Fatal("expected error")
+	}
+
+	path := filepath.Join(t.TempDir(), "errors.json")
+	if err := writeJSONReport(path, col.errors.report()); err != nil {
+		t.Fatalf("write errors report: %v", err)
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var written errorsReport
+	if err := json.Unmarshal(data, &written); err != nil {
+		t.Fatalf("decode errors report: %v", err)
+	}
+	samples := written.Codes[codes.InvalidArgument.String()].Samples
+	if len(samples) != 1 || samples[0].Message != "items[0].price must be > 0" || samples[0].IdempotencyKey != "lt-create-1" {
+		t.Fatalf("unexpected samples: %+v", samples)
+	}
+
+	// Без -errors-output сэмплер не создаётся и запись ошибок ничего не делает.
+	if _, err := callCreateOrder(client, time.Second, req, "lt-create-2", newCollector()); err == nil {
+		t.Fatal("expected error")
+	}
+}
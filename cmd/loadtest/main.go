@@ -2,7 +2,6 @@ package main
 
 import (
 	"context"
-	"encoding/json"
 	"errors"
 	"flag"
 	"fmt"
@@ -17,10 +16,12 @@ import (
 
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/codes"
-	"google.golang.org/grpc/credentials/insecure"
 	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
 	"google.golang.org/grpc/status"
 
+	"github.com/vladislavdragonenkov/oms/internal/fileutil"
+	"github.com/vladislavdragonenkov/oms/internal/objectstore"
 	omsv1 "github.com/vladislavdragonenkov/oms/proto/oms/v1"
 )
 
@@ -40,6 +41,8 @@ const (
 
 type config struct {
 	addr        string
+	targets     []string
+	balance     balanceMode
 	total       int
 	totalSet    bool
 	duration    time.Duration
@@ -53,6 +56,18 @@ type config struct {
 	amountMinor int64
 	customerTag string
 	outputPath  string
+	payload     payloadConfig
+
+	// errorsOutput — файл с сэмплами ошибок (errors.json); пусто — сэмплы не собираются.
+	errorsOutput  string
+	errorsPerCode int
+
+	reportInterval time.Duration
+	reportWindow   time.Duration
+
+	// uploadReport — URL объектного хранилища для отчётов (s3://, gs://, file://); пусто — без выгрузки.
+	uploadReport    string
+	uploadRetention time.Duration
 }
 
 type latencySummary struct {
@@ -83,6 +98,8 @@ type report struct {
 	RPS               float64                 `json:"rps"`
 	ScenarioLatencyMs latencySummary          `json:"scenario_latency_ms"`
 	Methods           map[string]methodReport `json:"methods"`
+	Targets           map[string]methodReport `json:"targets,omitempty"`
+	Intervals         []intervalReport        `json:"intervals,omitempty"`
 }
 
 type methodStats struct {
@@ -96,6 +113,12 @@ type methodStats struct {
 type collector struct {
 	mu      sync.Mutex
 	methods map[string]*methodStats
+	// window — скользящее окно сценариев для soak-отчётов; nil, если -report-interval не задан.
+	window *rollingWindow
+	// targets — RPC по адресам серверов (см. recordTarget).
+	targets map[string]*methodStats
+	// errors — сэмплы неуспешных RPC; nil, если -errors-output не задан.
+	errors *errorSampler
 }
 
 func newCollector() *collector {
@@ -105,6 +128,10 @@ func newCollector() *collector {
 }
 
 func (c *collector) record(method string, latency time.Duration, code codes.Code) {
+	if method == "scenario" {
+		c.window.add(time.Now(), latency, code != codes.OK)
+	}
+
 	c.mu.Lock()
 	defer c.mu.Unlock()
 
@@ -115,15 +142,33 @@ func (c *collector) record(method string, latency time.Duration, code codes.Code
 		}
 		c.methods[method] = stats
 	}
+	stats.add(latency, code)
+}
 
-	stats.calls++
+func (s *methodStats) add(latency time.Duration, code codes.Code) {
+	s.calls++
 	if code == codes.OK {
-		stats.success++
+		s.success++
 	} else {
-		stats.failed++
+		s.failed++
+	}
+	s.codes[code.String()]++
+	s.latencies = append(s.latencies, float64(latency.Microseconds())/1000.0)
+}
+
+func (s *methodStats) report() methodReport {
+	codesCopy := make(map[string]int64, len(s.codes))
+	for code, count := range s.codes {
+		codesCopy[code] = count
+	}
+	return methodReport{
+		Calls:     s.calls,
+		Success:   s.success,
+		Failed:    s.failed,
+		ErrorRate: ratio(s.failed, s.calls),
+		Codes:     codesCopy,
+		LatencyMs: buildLatencySummary(s.latencies),
 	}
-	stats.codes[code.String()]++
-	stats.latencies = append(stats.latencies, float64(latency.Microseconds())/1000.0)
 }
 
 func (c *collector) snapshot(name string) (methodReport, bool) {
@@ -134,20 +179,7 @@ func (c *collector) snapshot(name string) (methodReport, bool) {
 	if !ok {
 		return methodReport{}, false
 	}
-
-	codesCopy := make(map[string]int64, len(stats.codes))
-	for code, count := range stats.codes {
-		codesCopy[code] = count
-	}
-
-	return methodReport{
-		Calls:     stats.calls,
-		Success:   stats.success,
-		Failed:    stats.failed,
-		ErrorRate: ratio(stats.failed, stats.calls),
-		Codes:     codesCopy,
-		LatencyMs: buildLatencySummary(stats.latencies),
-	}, true
+	return stats.report(), true
 }
 
 func (c *collector) buildReport(startedAt time.Time, duration time.Duration) report {
@@ -173,17 +205,12 @@ func (c *collector) buildReport(startedAt time.Time, duration time.Duration) rep
 	}
 
 	for name, stats := range c.methods {
-		codesCopy := make(map[string]int64, len(stats.codes))
-		for code, count := range stats.codes {
-			codesCopy[code] = count
-		}
-		result.Methods[name] = methodReport{
-			Calls:     stats.calls,
-			Success:   stats.success,
-			Failed:    stats.failed,
-			ErrorRate: ratio(stats.failed, stats.calls),
-			Codes:     codesCopy,
-			LatencyMs: buildLatencySummary(stats.latencies),
+		result.Methods[name] = stats.report()
+	}
+	if len(c.targets) > 0 {
+		result.Targets = make(map[string]methodReport, len(c.targets))
+		for target, stats := range c.targets {
+			result.Targets[target] = stats.report()
 		}
 	}
 
@@ -195,8 +222,15 @@ func parseConfig() (config, error) {
 	var modeValue string
 	var timeoutValue string
 	var durationValue string
-
-	flag.StringVar(&cfg.addr, "addr", "localhost:50051", "gRPC target address")
+	var itemsDistValue string
+	var seedValue int64
+	var reportIntervalValue string
+	var reportWindowValue string
+	var balanceValue string
+	var uploadRetentionValue string
+
+	flag.StringVar(&cfg.addr, "addr", "localhost:50051", "gRPC target address or comma-separated list of addresses (e.g. pod-a:50051,pod-b:50051)")
+	flag.StringVar(&balanceValue, "lb", string(balanceConn), "load balancing across -addr: conn (pin each connection to one address) | round_robin (balance every connection over all addresses, dns:/// targets included)")
 	flag.IntVar(&cfg.total, "total", 400, "total scenarios to execute in count mode; in duration mode only used when explicitly set")
 	flag.StringVar(&durationValue, "duration", "0s", "optional time-based run duration (e.g. 10m, 15m)")
 	flag.IntVar(&cfg.concurrency, "concurrency", 40, "number of concurrent workers")
@@ -208,7 +242,19 @@ func parseConfig() (config, error) {
 	flag.StringVar(&cfg.sku, "sku", "SKU-LOAD", "order item SKU")
 	flag.Int64Var(&cfg.amountMinor, "amount-minor", defaultAmount, "order item amount in minor units")
 	flag.StringVar(&cfg.customerTag, "customer-tag", "load", "customer id prefix")
-	flag.StringVar(&cfg.outputPath, "output", "", "optional JSON report output file path")
+	flag.StringVar(&cfg.outputPath, "output", "", "optional JSON report output file path (.gz suffix enables gzip)")
+	flag.StringVar(&cfg.errorsOutput, "errors-output", "", "optional file for sampled failed RPCs with their requests (e.g. errors.json, errors.json.gz)")
+	flag.IntVar(&cfg.errorsPerCode, "errors-per-code", defaultErrorsPerCode, "max error samples kept per gRPC code for -errors-output")
+	flag.StringVar(&itemsDistValue, "items-dist", "", "weighted distribution of line items per order, count:weight list (e.g. 1:60,3:25,10:15); empty means one item")
+	flag.IntVar(&cfg.payload.skuPool, "sku-pool", 1, "number of distinct SKUs derived from -sku; 1 keeps the fixed SKU")
+	flag.Int64Var(&cfg.payload.priceMin, "price-min", 0, "minimal item price in minor units (0 means -amount-minor)")
+	flag.Int64Var(&cfg.payload.priceMax, "price-max", 0, "maximal item price in minor units (0 means -amount-minor)")
+	flag.IntVar(&cfg.payload.qtyMax, "qty-max", 1, "maximal quantity per line item, drawn uniformly from 1..qty-max")
+	flag.Int64Var(&seedValue, "seed", 1, "seed for payload generation; the same seed reproduces the same orders")
+	flag.StringVar(&reportIntervalValue, "report-interval", "0s", "optional interval of intermediate soak reports (e.g. 1m); 0 disables them")
+	flag.StringVar(&reportWindowValue, "report-window", defaultReportWindow.String(), "rolling window for intermediate soak reports")
+	flag.StringVar(&cfg.uploadReport, "upload-report", "", "optional object storage URL for the JSON report and error samples: s3://bucket/prefix[?endpoint=...&region=...], gs://bucket/prefix or file:///dir; credentials from AWS_ACCESS_KEY_ID/AWS_SECRET_ACCESS_KEY")
+	flag.StringVar(&uploadRetentionValue, "upload-retention", defaultUploadRetention.String(), "retention tag for uploaded reports, rounded up to days (enforced by the bucket lifecycle rule)")
 	flag.Parse()
 
 	timeout, err := time.ParseDuration(strings.TrimSpace(timeoutValue))
@@ -223,6 +269,27 @@ func parseConfig() (config, error) {
 	}
 	cfg.duration = duration
 
+	reportInterval, err := time.ParseDuration(strings.TrimSpace(reportIntervalValue))
+	if err != nil {
+		return cfg, fmt.Errorf("parse report-interval: %w", err)
+	}
+	cfg.reportInterval = reportInterval
+
+	reportWindow, err := time.ParseDuration(strings.TrimSpace(reportWindowValue))
+	if err != nil {
+		return cfg, fmt.Errorf("parse report-window: %w", err)
+	}
+	cfg.reportWindow = reportWindow
+
+	uploadRetention, err := time.ParseDuration(strings.TrimSpace(uploadRetentionValue))
+	if err != nil {
+		return cfg, fmt.Errorf("parse upload-retention: %w", err)
+	}
+	if uploadRetention <= 0 {
+		return cfg, errors.New("upload-retention must be > 0")
+	}
+	cfg.uploadRetention = uploadRetention
+
 	flag.CommandLine.Visit(func(f *flag.Flag) {
 		if f.Name == "total" {
 			cfg.totalSet = true
@@ -235,6 +302,31 @@ func parseConfig() (config, error) {
 	}
 	cfg.mode = mode
 
+	targets, err := parseTargets(cfg.addr)
+	if err != nil {
+		return cfg, err
+	}
+	cfg.targets = targets
+
+	balance, err := parseBalanceMode(balanceValue)
+	if err != nil {
+		return cfg, err
+	}
+	cfg.balance = balance
+
+	itemsDist, err := parseItemsDistribution(itemsDistValue)
+	if err != nil {
+		return cfg, err
+	}
+	cfg.payload.itemsDist = itemsDist
+	cfg.payload.seed = uint64(seedValue) // #nosec G115 -- seed используется только как состояние PRNG.
+	if cfg.payload.priceMin == 0 {
+		cfg.payload.priceMin = cfg.amountMinor
+	}
+	if cfg.payload.priceMax == 0 {
+		cfg.payload.priceMax = cfg.amountMinor
+	}
+
 	if cfg.duration < 0 {
 		return cfg, errors.New("duration must be >= 0")
 	}
@@ -244,6 +336,12 @@ func parseConfig() (config, error) {
 	if cfg.duration > 0 && cfg.totalSet && cfg.total <= 0 {
 		return cfg, errors.New("total must be > 0 when explicitly set with duration")
 	}
+	if cfg.reportInterval < 0 {
+		return cfg, errors.New("report-interval must be >= 0")
+	}
+	if cfg.reportWindow <= 0 {
+		return cfg, errors.New("report-window must be > 0")
+	}
 	if cfg.concurrency <= 0 {
 		return cfg, errors.New("concurrency must be > 0")
 	}
@@ -268,6 +366,21 @@ func parseConfig() (config, error) {
 	if strings.TrimSpace(cfg.customerTag) == "" {
 		return cfg, errors.New("customer-tag is required")
 	}
+	if cfg.errorsPerCode <= 0 {
+		return cfg, errors.New("errors-per-code must be > 0")
+	}
+	if cfg.payload.skuPool <= 0 {
+		return cfg, errors.New("sku-pool must be > 0")
+	}
+	if cfg.payload.priceMin <= 0 || cfg.payload.priceMax <= 0 {
+		return cfg, errors.New("price-min and price-max must be > 0")
+	}
+	if cfg.payload.priceMin > cfg.payload.priceMax {
+		return cfg, errors.New("price-min must be <= price-max")
+	}
+	if cfg.payload.qtyMax <= 0 || cfg.payload.qtyMax > math.MaxInt32 {
+		return cfg, errors.New("qty-max must be > 0")
+	}
 
 	return cfg, nil
 }
@@ -292,15 +405,23 @@ func main() {
 		os.Exit(1)
 	}
 
-	conns := make([]*grpc.ClientConn, 0, cfg.connections)
-	clients := make([]omsv1.OrderServiceClient, 0, cfg.connections)
-	for i := 0; i < cfg.connections; i++ {
-		conn, dialErr := grpc.NewClient(cfg.addr, grpc.WithTransportCredentials(insecure.NewCredentials()))
-		if dialErr != nil {
-			_, _ = fmt.Fprintf(os.Stderr, "failed to create grpc client connection: %v\n", dialErr)
+	var uploader objectstore.Uploader
+	if cfg.uploadReport != "" {
+		// Ошибку URL или ключей лучше увидеть до прогона, а не после часа нагрузки.
+		uploader, err = objectstore.Open(cfg.uploadReport)
+		if err != nil {
+			_, _ = fmt.Fprintf(os.Stderr, "invalid upload-report: %v\n", err)
 			os.Exit(1)
 		}
-		conns = append(conns, conn)
+	}
+
+	conns, dialErr := dialConnections(cfg)
+	if dialErr != nil {
+		_, _ = fmt.Fprintf(os.Stderr, "failed to create grpc client connection: %v\n", dialErr)
+		os.Exit(1)
+	}
+	clients := make([]omsv1.OrderServiceClient, 0, len(conns))
+	for _, conn := range conns {
 		clients = append(clients, omsv1.NewOrderServiceClient(conn))
 	}
 	defer func() {
@@ -312,6 +433,10 @@ func main() {
 	startedAt := time.Now()
 	runID := fmt.Sprintf("%d-%d", startedAt.UnixNano(), os.Getpid())
 	col := newCollector()
+	if cfg.errorsOutput != "" {
+		col.errors = newErrorSampler(cfg.errorsPerCode)
+	}
+	stopSoakReports := startSoakReports(cfg, col, startedAt, os.Stdout)
 
 	jobs := make(chan int, cfg.concurrency*2)
 	var failures int64
@@ -332,9 +457,11 @@ func main() {
 
 	dispatchJobs(jobs, cfg)
 	wg.Wait()
+	intervals := stopSoakReports()
 
 	duration := time.Since(startedAt)
 	result := col.buildReport(startedAt, duration)
+	result.Intervals = intervals
 	if result.FailedScenarios == 0 && failures > 0 {
 		result.FailedScenarios = failures
 		result.ErrorRate = ratio(result.FailedScenarios, result.TotalScenarios)
@@ -347,6 +474,21 @@ func main() {
 			os.Exit(1)
 		}
 	}
+	var errorSamples any
+	if col.errors != nil {
+		samples := col.errors.report()
+		errorSamples = samples
+		if err := writeJSONReport(cfg.errorsOutput, samples); err != nil {
+			_, _ = fmt.Fprintf(os.Stderr, "failed to write error samples: %v\n", err)
+			os.Exit(1)
+		}
+	}
+	if uploader != nil {
+		if err := uploadReports(context.Background(), uploader, cfg, runID, result, errorSamples, os.Stdout); err != nil {
+			_, _ = fmt.Fprintf(os.Stderr, "failed to upload report: %v\n", err)
+			os.Exit(1)
+		}
+	}
 
 	if result.FailedScenarios > 0 {
 		os.Exit(1)
@@ -395,20 +537,17 @@ func runScenario(
 	createReq := &omsv1.CreateOrderRequest{
 		CustomerId: fmt.Sprintf("%s-%s-%d", cfg.customerTag, runID, index),
 		Currency:   cfg.currency,
-		Items: []*omsv1.OrderItem{
-			{
-				Sku: cfg.sku,
-				Qty: defaultQty,
-				Price: &omsv1.Money{
-					Currency:    cfg.currency,
-					AmountMinor: cfg.amountMinor,
-				},
-			},
-		},
+		Items:      buildOrderItems(cfg, index),
 	}
 
 	createKey := fmt.Sprintf("lt-create-%s-%d", runID, index)
+	createStart := time.Now()
 	orderResp, err := callCreateOrder(client, cfg.timeout, createReq, createKey, col)
+	if cfg.payload.dynamic() {
+		// Стоимость CreateOrder растёт с числом позиций, поэтому латентность дополнительно
+		// раскладывается по размеру заказа.
+		col.record(fmt.Sprintf("CreateOrder[items=%d]", len(createReq.Items)), time.Since(createStart), grpcCode(err))
+	}
 	if err != nil {
 		scenarioCode = grpcCode(err)
 		return err
@@ -452,8 +591,10 @@ func callCreateOrder(
 	defer cancel()
 	ctx = metadata.AppendToOutgoingContext(ctx, idempotencyHeader, key)
 
-	resp, err := client.CreateOrder(ctx, req)
-	col.record("CreateOrder", time.Since(start), grpcCode(err))
+	var p peer.Peer
+	resp, err := client.CreateOrder(ctx, req, grpc.Peer(&p))
+	col.recordRPC("CreateOrder", &p, time.Since(start), grpcCode(err))
+	col.recordError("CreateOrder", &p, req, key, err)
 	return resp, err
 }
 
@@ -468,8 +609,11 @@ func callPayOrder(
 	defer cancel()
 	ctx = metadata.AppendToOutgoingContext(ctx, idempotencyHeader, key)
 
-	_, err := client.PayOrder(ctx, &omsv1.PayOrderRequest{OrderId: orderID})
-	col.record("PayOrder", time.Since(start), grpcCode(err))
+	var p peer.Peer
+	req := &omsv1.PayOrderRequest{OrderId: orderID}
+	_, err := client.PayOrder(ctx, req, grpc.Peer(&p))
+	col.recordRPC("PayOrder", &p, time.Since(start), grpcCode(err))
+	col.recordError("PayOrder", &p, req, key, err)
 	return err
 }
 
@@ -484,11 +628,14 @@ func callCancelOrder(
 	defer cancel()
 	ctx = metadata.AppendToOutgoingContext(ctx, idempotencyHeader, key)
 
-	_, err := client.CancelOrder(ctx, &omsv1.CancelOrderRequest{
+	var p peer.Peer
+	req := &omsv1.CancelOrderRequest{
 		OrderId: orderID,
 		Reason:  "load-cancel",
-	})
-	col.record("CancelOrder", time.Since(start), grpcCode(err))
+	}
+	_, err := client.CancelOrder(ctx, req, grpc.Peer(&p))
+	col.recordRPC("CancelOrder", &p, time.Since(start), grpcCode(err))
+	col.recordError("CancelOrder", &p, req, key, err)
 	return err
 }
 
@@ -509,7 +656,9 @@ func shouldCancelScenario(index, cancelRate int) bool {
 	return index%100 < cancelRate
 }
 
-func writeJSONReport(path string, result report) error {
+// writeJSONReport атомарно пишет отчёт: CI читает его сразу после прогона, а soak-режим
+// перезаписывает промежуточный отчёт по ходу теста. Путь с суффиксом .gz сжимается gzip'ом.
+func writeJSONReport(path string, result any) error {
 	cleanPath := filepath.Clean(path)
 	if cleanPath == "." || cleanPath == string(filepath.Separator) {
 		return errors.New("output path must point to a file")
@@ -518,16 +667,7 @@ func writeJSONReport(path string, result report) error {
 		return fmt.Errorf("output path must be inside current directory: %s", path)
 	}
 
-	// #nosec G304 -- path is an explicit CLI output parameter for local load-test reports.
-	file, err := os.Create(cleanPath)
-	if err != nil {
-		return err
-	}
-	defer file.Close()
-
-	encoder := json.NewEncoder(file)
-	encoder.SetIndent("", "  ")
-	return encoder.Encode(result)
+	return fileutil.WriteJSONAtomic(cleanPath, result, fileutil.WithPerm(0o644), fileutil.WithGzip(fileutil.HasGzipSuffix(cleanPath)))
 }
 
 func printReport(result report, cfg config) {
@@ -570,6 +710,24 @@ func printReport(result report, cfg config) {
 			stats.LatencyMs.P95,
 		)
 	}
+
+	targetNames := make([]string, 0, len(result.Targets))
+	for target := range result.Targets {
+		targetNames = append(targetNames, target)
+	}
+	sort.Strings(targetNames)
+	for _, target := range targetNames {
+		stats := result.Targets[target]
+		fmt.Printf(
+			"target %s: calls=%d failed=%d error_rate=%.4f p50=%.2fms p95=%.2fms\n",
+			target,
+			stats.Calls,
+			stats.Failed,
+			stats.ErrorRate,
+			stats.LatencyMs.P50,
+			stats.LatencyMs.P95,
+		)
+	}
 }
 
 func runTarget(cfg config) string {
@@ -1,6 +1,7 @@
 package main
 
 import (
+	"compress/gzip"
 	"context"
 	"encoding/json"
 	"errors"
@@ -60,6 +61,22 @@ func (f *fakeOrderServiceClient) GetOrder(ctx context.Context, req *omsv1.GetOrd
 	return f.getFn(ctx, req, opts...)
 }
 
+func (f *fakeOrderServiceClient) GetOrders(context.Context, *omsv1.GetOrdersRequest, ...grpc.CallOption) (*omsv1.GetOrdersResponse, error) {
+	return nil, errors.New("unexpected GetOrders call")
+}
+
+func (f *fakeOrderServiceClient) StreamOrderTimeline(context.Context, *omsv1.StreamOrderTimelineRequest, ...grpc.CallOption) (omsv1.OrderService_StreamOrderTimelineClient, error) {
+	return nil, errors.New("unexpected StreamOrderTimeline call")
+}
+
+func (f *fakeOrderServiceClient) WatchOrder(context.Context, *omsv1.WatchOrderRequest, ...grpc.CallOption) (omsv1.OrderService_WatchOrderClient, error) {
+	return nil, errors.New("unexpected WatchOrder call")
+}
+
+func (f *fakeOrderServiceClient) UpdateOrder(context.Context, *omsv1.UpdateOrderRequest, ...grpc.CallOption) (*omsv1.UpdateOrderResponse, error) {
+	return nil, errors.New("unexpected UpdateOrder call")
+}
+
 func (f *fakeOrderServiceClient) ListOrders(ctx context.Context, req *omsv1.ListOrdersRequest, opts ...grpc.CallOption) (*omsv1.ListOrdersResponse, error) {
 	if f.listFn == nil {
 		return nil, errors.New("unexpected ListOrders call")
@@ -74,6 +91,54 @@ func (f *fakeOrderServiceClient) RefundOrder(ctx context.Context, req *omsv1.Ref
 	return f.refundFn(ctx, req, opts...)
 }
 
+func (f *fakeOrderServiceClient) CaptureOrder(context.Context, *omsv1.CaptureOrderRequest, ...grpc.CallOption) (*omsv1.CaptureOrderResponse, error) {
+	return nil, errors.New("unexpected CaptureOrder call")
+}
+
+func (f *fakeOrderServiceClient) HoldOrder(context.Context, *omsv1.HoldOrderRequest, ...grpc.CallOption) (*omsv1.HoldOrderResponse, error) {
+	return nil, errors.New("unexpected HoldOrder call")
+}
+
+func (f *fakeOrderServiceClient) ReleaseOrder(context.Context, *omsv1.ReleaseOrderRequest, ...grpc.CallOption) (*omsv1.ReleaseOrderResponse, error) {
+	return nil, errors.New("unexpected ReleaseOrder call")
+}
+
+func (f *fakeOrderServiceClient) ApproveOrder(context.Context, *omsv1.ApproveOrderRequest, ...grpc.CallOption) (*omsv1.ApproveOrderResponse, error) {
+	return nil, errors.New("unexpected ApproveOrder call")
+}
+
+func (f *fakeOrderServiceClient) RejectOrder(context.Context, *omsv1.RejectOrderRequest, ...grpc.CallOption) (*omsv1.RejectOrderResponse, error) {
+	return nil, errors.New("unexpected RejectOrder call")
+}
+
+func (f *fakeOrderServiceClient) ScheduleCancel(context.Context, *omsv1.ScheduleCancelRequest, ...grpc.CallOption) (*omsv1.ScheduleCancelResponse, error) {
+	return nil, errors.New("unexpected ScheduleCancel call")
+}
+
+func (f *fakeOrderServiceClient) ListScheduledCancels(context.Context, *omsv1.ListScheduledCancelsRequest, ...grpc.CallOption) (*omsv1.ListScheduledCancelsResponse, error) {
+	return nil, errors.New("unexpected ListScheduledCancels call")
+}
+
+func (f *fakeOrderServiceClient) DeleteScheduledCancel(context.Context, *omsv1.DeleteScheduledCancelRequest, ...grpc.CallOption) (*omsv1.DeleteScheduledCancelResponse, error) {
+	return nil, errors.New("unexpected DeleteScheduledCancel call")
+}
+
+func (f *fakeOrderServiceClient) CreateReturn(context.Context, *omsv1.CreateReturnRequest, ...grpc.CallOption) (*omsv1.CreateReturnResponse, error) {
+	return nil, errors.New("unexpected CreateReturn call")
+}
+
+func (f *fakeOrderServiceClient) GetReturn(context.Context, *omsv1.GetReturnRequest, ...grpc.CallOption) (*omsv1.GetReturnResponse, error) {
+	return nil, errors.New("unexpected GetReturn call")
+}
+
+func (f *fakeOrderServiceClient) AdvanceReturn(context.Context, *omsv1.AdvanceReturnRequest, ...grpc.CallOption) (*omsv1.AdvanceReturnResponse, error) {
+	return nil, errors.New("unexpected AdvanceReturn call")
+}
+
+func (f *fakeOrderServiceClient) GetServiceInfo(context.Context, *omsv1.GetServiceInfoRequest, ...grpc.CallOption) (*omsv1.GetServiceInfoResponse, error) {
+	return nil, errors.New("unexpected GetServiceInfo call")
+}
+
 func withCLIArgs(t *testing.T, args []string, fn func()) {
 	t.Helper()
 
@@ -179,6 +244,9 @@ func TestParseConfig(t *testing.T) {
 			if cfg.totalSet {
 				t.Fatalf("expected totalSet=false when -total was not provided")
 			}
+			if cfg.reportInterval != 0 || cfg.reportWindow != defaultReportWindow {
+				t.Fatalf("unexpected soak report defaults: interval=%s window=%s", cfg.reportInterval, cfg.reportWindow)
+			}
 		})
 	})
 
@@ -192,6 +260,13 @@ func TestParseConfig(t *testing.T) {
 			{name: "negative duration", args: []string{"-duration=-1s"}, wantErr: "duration must be >= 0"},
 			{name: "invalid cancel rate", args: []string{"-cancel-rate=101"}, wantErr: "cancel-rate must be between 0 and 100"},
 			{name: "empty total", args: []string{"-duration=0s", "-total=0"}, wantErr: "total must be > 0"},
+			{name: "invalid items dist", args: []string{"-items-dist=3"}, wantErr: "must be count:weight"},
+			{name: "invalid price range", args: []string{"-price-min=500", "-price-max=100"}, wantErr: "price-min must be <= price-max"},
+			{name: "invalid sku pool", args: []string{"-sku-pool=0"}, wantErr: "sku-pool must be > 0"},
+			{name: "negative report interval", args: []string{"-report-interval=-1m"}, wantErr: "report-interval must be >= 0"},
+			{name: "invalid report window", args: []string{"-report-interval=1m", "-report-window=0s"}, wantErr: "report-window must be > 0"},
+			{name: "empty addr list", args: []string{"-addr= , "}, wantErr: "addr must contain at least one target"},
+			{name: "invalid lb mode", args: []string{"-lb=random"}, wantErr: "unsupported lb mode"},
 		}
 
 		for _, tc := range tests {
@@ -339,6 +414,30 @@ func TestWriteJSONReport(t *testing.T) {
 	}
 }
 
+func TestWriteJSONReport_Gzip(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "report.json.gz")
+	if err := writeJSONReport(path, report{TotalScenarios: 5}); err != nil {
+		t.Fatalf("writeJSONReport error: %v", err)
+	}
+
+	file, err := os.Open(path)
+	if err != nil {
+		t.Fatalf("open report: %v", err)
+	}
+	defer file.Close()
+	zr, err := gzip.NewReader(file)
+	if err != nil {
+		t.Fatalf("expected gzip report: %v", err)
+	}
+	var decoded report
+	if err := json.NewDecoder(zr).Decode(&decoded); err != nil {
+		t.Fatalf("decode report: %v", err)
+	}
+	if decoded.TotalScenarios != 5 {
+		t.Fatalf("unexpected decoded report: %+v", decoded)
+	}
+}
+
 func TestRPCHelpersAndRunScenario(t *testing.T) {
 	c := newCollector()
 
@@ -0,0 +1,141 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"math/rand/v2"
+	"strconv"
+	"strings"
+
+	omsv1 "github.com/vladislavdragonenkov/oms/proto/oms/v1"
+)
+
+// maxItemsPerOrder ограничивает размер сгенерированного заказа, чтобы опечатка в -items-dist
+// не превратилась в запрос на мегабайты.
+const maxItemsPerOrder = 1000
+
+// itemsBucket — вариант количества позиций в заказе и его относительный вес.
+type itemsBucket struct {
+	count  int
+	weight int
+}
+
+// payloadConfig описывает генерацию позиций заказа. Нулевое значение даёт прежнее поведение:
+// одна позиция с фиксированными SKU и ценой.
+type payloadConfig struct {
+	itemsDist []itemsBucket
+	skuPool   int
+	priceMin  int64
+	priceMax  int64
+	qtyMax    int
+	seed      uint64
+}
+
+func (p payloadConfig) dynamic() bool {
+	return len(p.itemsDist) > 0 || p.skuPool > 1 || p.priceMax > p.priceMin || p.qtyMax > 1
+}
+
+// parseItemsDistribution разбирает строку вида "1:60,3:25,10:15" (кол-во позиций:вес).
+func parseItemsDistribution(value string) ([]itemsBucket, error) {
+	value = strings.TrimSpace(value)
+	if value == "" {
+		return nil, nil
+	}
+
+	parts := strings.Split(value, ",")
+	buckets := make([]itemsBucket, 0, len(parts))
+	for _, part := range parts {
+		countValue, weightValue, ok := strings.Cut(strings.TrimSpace(part), ":")
+		if !ok {
+			return nil, fmt.Errorf("items-dist entry %q must be count:weight", part)
+		}
+		count, err := strconv.Atoi(strings.TrimSpace(countValue))
+		if err != nil || count <= 0 || count > maxItemsPerOrder {
+			return nil, fmt.Errorf("items-dist count %q must be between 1 and %d", countValue, maxItemsPerOrder)
+		}
+		weight, err := strconv.Atoi(strings.TrimSpace(weightValue))
+		if err != nil || weight < 0 {
+			return nil, fmt.Errorf("items-dist weight %q must be a non-negative integer", weightValue)
+		}
+		if weight == 0 {
+			continue
+		}
+		buckets = append(buckets, itemsBucket{count: count, weight: weight})
+	}
+	if len(buckets) == 0 {
+		return nil, errors.New("items-dist must contain at least one entry with positive weight")
+	}
+
+	return buckets, nil
+}
+
+// buildOrderItems генерирует позиции заказа для сценария index.
+// Генератор детерминирован по (seed, index): повторный прогон с тем же seed даёт те же заказы
+// независимо от порядка, в котором воркеры разбирают задания.
+func buildOrderItems(cfg config, index int) []*omsv1.OrderItem {
+	payload := cfg.payload
+	if !payload.dynamic() {
+		return []*omsv1.OrderItem{newOrderItem(cfg.sku, defaultQty, cfg.currency, cfg.amountMinor)}
+	}
+
+	// #nosec G404 -- нагрузочные данные, криптостойкость не нужна.
+	rng := rand.New(rand.NewPCG(payload.seed, uint64(index)))
+
+	count := pickItemsCount(rng, payload.itemsDist)
+	skuOffset := 0
+	if payload.skuPool > 1 {
+		skuOffset = rng.IntN(payload.skuPool)
+	}
+
+	items := make([]*omsv1.OrderItem, 0, count)
+	for i := 0; i < count; i++ {
+		qty := defaultQty
+		if payload.qtyMax > 1 {
+			qty = int32(1 + rng.IntN(payload.qtyMax)) // #nosec G115 -- qtyMax проверен в parseConfig.
+		}
+		price := cfg.amountMinor
+		if payload.priceMax > payload.priceMin {
+			price = payload.priceMin + rng.Int64N(payload.priceMax-payload.priceMin+1)
+		}
+		items = append(items, newOrderItem(itemSKU(cfg.sku, payload.skuPool, skuOffset+i), qty, cfg.currency, price))
+	}
+	return items
+}
+
+func pickItemsCount(rng *rand.Rand, buckets []itemsBucket) int {
+	if len(buckets) == 0 {
+		return 1
+	}
+
+	total := 0
+	for _, bucket := range buckets {
+		total += bucket.weight
+	}
+	pick := rng.IntN(total)
+	for _, bucket := range buckets {
+		if pick < bucket.weight {
+			return bucket.count
+		}
+		pick -= bucket.weight
+	}
+	return buckets[len(buckets)-1].count
+}
+
+// itemSKU выбирает SKU из пула; соседние позиции заказа получают разные SKU, пока хватает пула.
+func itemSKU(base string, pool, n int) string {
+	if pool <= 1 {
+		return base
+	}
+	return fmt.Sprintf("%s-%05d", base, n%pool)
+}
+
+func newOrderItem(sku string, qty int32, currency string, priceMinor int64) *omsv1.OrderItem {
+	return &omsv1.OrderItem{
+		Sku: sku,
+		Qty: qty,
+		Price: &omsv1.Money{
+			Currency:    currency,
+			AmountMinor: priceMinor,
+		},
+	}
+}
@@ -0,0 +1,94 @@
+package main
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestParseItemsDistribution(t *testing.T) {
+	got, err := parseItemsDistribution(" 1:60, 3:25 ,10:15,20:0")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	want := []itemsBucket{{count: 1, weight: 60}, {count: 3, weight: 25}, {count: 10, weight: 15}}
+	if !reflect.DeepEqual(got, want) {
+		t.Fatalf("unexpected buckets: got %+v want %+v", got, want)
+	}
+
+	if got, err := parseItemsDistribution(""); err != nil || got != nil {
+		t.Fatalf("expected empty distribution, got %+v err=%v", got, err)
+	}
+
+	for _, value := range []string{"1", "0:10", "x:10", "2:-1", "5:0", "100000:1"} {
+		if _, err := parseItemsDistribution(value); err == nil {
+			t.Fatalf("expected error for %q", value)
+		}
+	}
+}
+
+func TestBuildOrderItems(t *testing.T) {
+	t.Run("fixed payload by default", func(t *testing.T) {
+		cfg := config{currency: "USD", sku: "SKU-1", amountMinor: 100}
+		items := buildOrderItems(cfg, 7)
+		if len(items) != 1 || items[0].GetSku() != "SKU-1" || items[0].GetQty() != defaultQty || items[0].GetPrice().GetAmountMinor() != 100 {
+			t.Fatalf("unexpected fixed items: %+v", items)
+		}
+	})
+
+	t.Run("dynamic payload", func(t *testing.T) {
+		cfg := config{
+			currency:    "USD",
+			sku:         "SKU",
+			amountMinor: 100,
+			payload: payloadConfig{
+				itemsDist: []itemsBucket{{count: 2, weight: 1}, {count: 5, weight: 1}},
+				skuPool:   50,
+				priceMin:  10,
+				priceMax:  99,
+				qtyMax:    3,
+				seed:      42,
+			},
+		}
+
+		seenCounts := make(map[int]bool)
+		for index := 0; index < 200; index++ {
+			items := buildOrderItems(cfg, index)
+			if len(items) != 2 && len(items) != 5 {
+				t.Fatalf("unexpected items count %d", len(items))
+			}
+			seenCounts[len(items)] = true
+
+			skus := make(map[string]bool, len(items))
+			for _, item := range items {
+				if !strings.HasPrefix(item.GetSku(), "SKU-") {
+					t.Fatalf("unexpected sku %q", item.GetSku())
+				}
+				if skus[item.GetSku()] {
+					t.Fatalf("duplicate sku %q within order", item.GetSku())
+				}
+				skus[item.GetSku()] = true
+				if item.GetQty() < 1 || item.GetQty() > 3 {
+					t.Fatalf("qty out of range: %d", item.GetQty())
+				}
+				if price := item.GetPrice().GetAmountMinor(); price < 10 || price > 99 {
+					t.Fatalf("price out of range: %d", price)
+				}
+			}
+		}
+		if !seenCounts[2] || !seenCounts[5] {
+			t.Fatalf("expected both distribution buckets to be used, got %v", seenCounts)
+		}
+
+		first := buildOrderItems(cfg, 11)
+		second := buildOrderItems(cfg, 11)
+		if len(first) != len(second) {
+			t.Fatalf("generation is not deterministic: %d vs %d items", len(first), len(second))
+		}
+		for i := range first {
+			if first[i].GetSku() != second[i].GetSku() || first[i].GetPrice().GetAmountMinor() != second[i].GetPrice().GetAmountMinor() {
+				t.Fatalf("generation is not deterministic at item %d", i)
+			}
+		}
+	})
+}
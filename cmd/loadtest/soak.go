@@ -0,0 +1,210 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"sync"
+	"time"
+)
+
+const defaultReportWindow = 5 * time.Minute
+
+// intervalReport — промежуточный снимок soak-прогона за скользящее окно.
+type intervalReport struct {
+	At             time.Time      `json:"at"`
+	ElapsedSeconds float64        `json:"elapsed_seconds"`
+	WindowSeconds  float64        `json:"window_seconds"`
+	Scenarios      int64          `json:"scenarios"`
+	Failed         int64          `json:"failed"`
+	ErrorRate      float64        `json:"error_rate"`
+	RPS            float64        `json:"rps"`
+	LatencyMs      latencySummary `json:"latency_ms"`
+	TotalScenarios int64          `json:"total_scenarios"`
+}
+
+// windowSample — завершённый сценарий, попавший в скользящее окно.
+type windowSample struct {
+	at        time.Time
+	latencyMs float64
+	failed    bool
+}
+
+// rollingWindow хранит сценарии за последние size; старые сэмплы отбрасываются при снимке.
+type rollingWindow struct {
+	mu      sync.Mutex
+	size    time.Duration
+	samples []windowSample
+	total   int64
+}
+
+func newRollingWindow(size time.Duration) *rollingWindow {
+	if size <= 0 {
+		size = defaultReportWindow
+	}
+	return &rollingWindow{size: size}
+}
+
+func (w *rollingWindow) add(at time.Time, latency time.Duration, failed bool) {
+	if w == nil {
+		return
+	}
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	w.samples = append(w.samples, windowSample{at: at, latencyMs: float64(latency.Microseconds()) / 1000.0, failed: failed})
+	w.total++
+}
+
+// snapshot считает статистику за окно, заканчивающееся в now. Окно не длиннее elapsed,
+// чтобы RPS в начале прогона не занижался.
+func (w *rollingWindow) snapshot(now time.Time, elapsed time.Duration) intervalReport {
+	w.mu.Lock()
+	cutoff := now.Add(-w.size)
+	keep := 0
+	for keep < len(w.samples) && w.samples[keep].at.Before(cutoff) {
+		keep++
+	}
+	w.samples = append(w.samples[:0], w.samples[keep:]...)
+
+	latencies := make([]float64, 0, len(w.samples))
+	var failed int64
+	for _, sample := range w.samples {
+		latencies = append(latencies, sample.latencyMs)
+		if sample.failed {
+			failed++
+		}
+	}
+	total := w.total
+	w.mu.Unlock()
+
+	window := w.size
+	if elapsed < window {
+		window = elapsed
+	}
+	result := intervalReport{
+		At:             now.UTC(),
+		ElapsedSeconds: elapsed.Seconds(),
+		WindowSeconds:  window.Seconds(),
+		Scenarios:      int64(len(latencies)),
+		Failed:         failed,
+		ErrorRate:      ratio(failed, int64(len(latencies))),
+		LatencyMs:      buildLatencySummary(latencies),
+		TotalScenarios: total,
+	}
+	if window > 0 {
+		result.RPS = float64(len(latencies)) / window.Seconds()
+	}
+	return result
+}
+
+// soakReporter раз в interval печатает снимок окна и накапливает временной ряд для JSON-отчёта.
+type soakReporter struct {
+	interval  time.Duration
+	window    *rollingWindow
+	startedAt time.Time
+	out       io.Writer
+	now       func() time.Time
+	// onReport вызывается после каждого снимка, например чтобы переписать частичный JSON-отчёт.
+	onReport func([]intervalReport)
+
+	mu        sync.Mutex
+	intervals []intervalReport
+}
+
+func newSoakReporter(interval time.Duration, window *rollingWindow, startedAt time.Time, out io.Writer) *soakReporter {
+	return &soakReporter{
+		interval:  interval,
+		window:    window,
+		startedAt: startedAt,
+		out:       out,
+		now:       time.Now,
+	}
+}
+
+// run снимает отчёты до отмены ctx.
+func (r *soakReporter) run(ctx context.Context) {
+	ticker := time.NewTicker(r.interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			r.report()
+		}
+	}
+}
+
+func (r *soakReporter) report() intervalReport {
+	now := r.now()
+	snapshot := r.window.snapshot(now, now.Sub(r.startedAt))
+
+	r.mu.Lock()
+	r.intervals = append(r.intervals, snapshot)
+	series := append([]intervalReport(nil), r.intervals...)
+	r.mu.Unlock()
+
+	if r.out != nil {
+		_, _ = fmt.Fprintf(r.out,
+			"[soak] elapsed=%s window=%s scenarios=%d failed=%d error_rate=%.4f rps=%.2f p50=%.2fms p95=%.2fms p99=%.2fms total=%d\n",
+			time.Duration(snapshot.ElapsedSeconds*float64(time.Second)).Round(time.Second),
+			time.Duration(snapshot.WindowSeconds*float64(time.Second)).Round(time.Second),
+			snapshot.Scenarios,
+			snapshot.Failed,
+			snapshot.ErrorRate,
+			snapshot.RPS,
+			snapshot.LatencyMs.P50,
+			snapshot.LatencyMs.P95,
+			snapshot.LatencyMs.P99,
+			snapshot.TotalScenarios,
+		)
+	}
+	if r.onReport != nil {
+		r.onReport(series)
+	}
+	return snapshot
+}
+
+// series возвращает накопленный временной ряд.
+func (r *soakReporter) series() []intervalReport {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return append([]intervalReport(nil), r.intervals...)
+}
+
+// startSoakReports включает скользящее окно в collector и запускает периодические отчёты.
+// Если задан -output, файл переписывается после каждого снимка, чтобы данные многочасового
+// прогона не терялись при его аварийном завершении. Возвращённая функция останавливает
+// отчёты и отдаёт накопленный временной ряд.
+func startSoakReports(cfg config, col *collector, startedAt time.Time, out io.Writer) func() []intervalReport {
+	if cfg.reportInterval <= 0 {
+		return func() []intervalReport { return nil }
+	}
+
+	col.window = newRollingWindow(cfg.reportWindow)
+	reporter := newSoakReporter(cfg.reportInterval, col.window, startedAt, out)
+	if cfg.outputPath != "" {
+		reporter.onReport = func(series []intervalReport) {
+			partial := col.buildReport(startedAt, time.Since(startedAt))
+			partial.Intervals = series
+			if err := writeJSONReport(cfg.outputPath, partial); err != nil {
+				_, _ = fmt.Fprintf(os.Stderr, "failed to write intermediate report: %v\n", err)
+			}
+		}
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		reporter.run(ctx)
+	}()
+
+	return func() []intervalReport {
+		cancel()
+		<-done
+		return reporter.series()
+	}
+}
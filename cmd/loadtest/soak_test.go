@@ -0,0 +1,123 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"google.golang.org/grpc/codes"
+)
+
+func TestRollingWindow_SnapshotDropsOldSamples(t *testing.T) {
+	start := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	window := newRollingWindow(time.Minute)
+
+	window.add(start.Add(10*time.Second), 100*time.Millisecond, true)
+	window.add(start.Add(70*time.Second), 20*time.Millisecond, false)
+	window.add(start.Add(80*time.Second), 40*time.Millisecond, true)
+	window.add(start.Add(90*time.Second), 60*time.Millisecond, false)
+
+	got := window.snapshot(start.Add(100*time.Second), 100*time.Second)
+	if got.Scenarios != 3 || got.Failed != 1 || got.TotalScenarios != 4 {
+		t.Fatalf("unexpected counters: %+v", got)
+	}
+	if got.WindowSeconds != 60 || got.RPS != 0.05 {
+		t.Fatalf("unexpected window/rps: window=%v rps=%v", got.WindowSeconds, got.RPS)
+	}
+	if got.LatencyMs.Max != 60 || got.LatencyMs.Min != 20 {
+		t.Fatalf("unexpected latency summary: %+v", got.LatencyMs)
+	}
+	if len(window.samples) != 3 {
+		t.Fatalf("expected old samples to be pruned, got %d", len(window.samples))
+	}
+}
+
+func TestRollingWindow_WindowBoundedByElapsed(t *testing.T) {
+	start := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	window := newRollingWindow(5 * time.Minute)
+	for i := 0; i < 10; i++ {
+		window.add(start.Add(time.Duration(i)*time.Second), time.Millisecond, false)
+	}
+
+	got := window.snapshot(start.Add(10*time.Second), 10*time.Second)
+	if got.WindowSeconds != 10 || got.RPS != 1 {
+		t.Fatalf("expected window bounded by elapsed time, got window=%v rps=%v", got.WindowSeconds, got.RPS)
+	}
+}
+
+func TestSoakReporter_PrintsAndAccumulatesSeries(t *testing.T) {
+	start := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	now := start
+	window := newRollingWindow(time.Minute)
+
+	var out bytes.Buffer
+	reporter := newSoakReporter(time.Minute, window, start, &out)
+	reporter.now = func() time.Time { return now }
+	var published int
+	reporter.onReport = func(series []intervalReport) { published = len(series) }
+
+	window.add(start.Add(30*time.Second), 10*time.Millisecond, false)
+	now = start.Add(time.Minute)
+	reporter.report()
+	now = start.Add(2 * time.Minute)
+	reporter.report()
+
+	series := reporter.series()
+	if len(series) != 2 || published != 2 {
+		t.Fatalf("expected two snapshots, got series=%d published=%d", len(series), published)
+	}
+	if series[0].Scenarios != 1 || series[1].Scenarios != 0 || series[1].TotalScenarios != 1 {
+		t.Fatalf("unexpected series: %+v", series)
+	}
+	if lines := strings.Split(strings.TrimSpace(out.String()), "\n"); len(lines) != 2 || !strings.HasPrefix(lines[0], "[soak] elapsed=1m0s window=1m0s scenarios=1") {
+		t.Fatalf("unexpected soak output:\n%s", out.String())
+	}
+}
+
+func TestStartSoakReports(t *testing.T) {
+	t.Run("disabled", func(t *testing.T) {
+		col := newCollector()
+		stop := startSoakReports(config{}, col, time.Now(), nil)
+		if col.window != nil || stop() != nil {
+			t.Fatal("expected soak reports to be disabled without -report-interval")
+		}
+	})
+
+	t.Run("writes intermediate report", func(t *testing.T) {
+		dir := t.TempDir()
+		wd, err := os.Getwd()
+		if err != nil {
+			t.Fatalf("getwd: %v", err)
+		}
+		if err := os.Chdir(dir); err != nil {
+			t.Fatalf("chdir: %v", err)
+		}
+		defer func() { _ = os.Chdir(wd) }()
+
+		col := newCollector()
+		cfg := config{reportInterval: 10 * time.Millisecond, reportWindow: time.Minute, outputPath: "soak.json"}
+		stop := startSoakReports(cfg, col, time.Now(), nil)
+		col.record("scenario", time.Millisecond, codes.OK)
+
+		deadline := time.Now().Add(2 * time.Second)
+		for {
+			data, readErr := os.ReadFile(filepath.Join(dir, "soak.json"))
+			var partial report
+			if readErr == nil && json.Unmarshal(data, &partial) == nil && len(partial.Intervals) > 0 && partial.TotalScenarios == 1 {
+				break
+			}
+			if time.Now().After(deadline) {
+				t.Fatalf("intermediate report was not written: %v", readErr)
+			}
+			time.Sleep(10 * time.Millisecond)
+		}
+
+		if series := stop(); len(series) == 0 || series[len(series)-1].TotalScenarios != 1 {
+			t.Fatalf("unexpected series after stop: %+v", series)
+		}
+	})
+}
@@ -0,0 +1,124 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/resolver"
+	"google.golang.org/grpc/resolver/manual"
+)
+
+// balanceMode — способ распределения нагрузки между адресами из -addr.
+type balanceMode string
+
+const (
+	// balanceConn закрепляет каждое соединение за одним адресом, адреса раздаются по кругу.
+	balanceConn balanceMode = "conn"
+	// balanceRoundRobin — каждое соединение само балансирует RPC по всем адресам (политика round_robin).
+	// С одним адресом вида dns:///host:port балансирует по всем A-записям.
+	balanceRoundRobin balanceMode = "round_robin"
+
+	roundRobinServiceConfig = `{"loadBalancingConfig":[{"round_robin":{}}]}`
+	unknownTarget           = "unknown"
+)
+
+func parseBalanceMode(value string) (balanceMode, error) {
+	switch balanceMode(strings.TrimSpace(value)) {
+	case balanceConn:
+		return balanceConn, nil
+	case balanceRoundRobin:
+		return balanceRoundRobin, nil
+	default:
+		return "", fmt.Errorf("unsupported lb mode: %s", value)
+	}
+}
+
+// parseTargets разбирает список адресов через запятую.
+func parseTargets(raw string) ([]string, error) {
+	var targets []string
+	for _, part := range strings.Split(raw, ",") {
+		if target := strings.TrimSpace(part); target != "" {
+			targets = append(targets, target)
+		}
+	}
+	if len(targets) == 0 {
+		return nil, errors.New("addr must contain at least one target")
+	}
+	return targets, nil
+}
+
+// dialConnections создаёт cfg.connections клиентских соединений согласно cfg.balance.
+func dialConnections(cfg config) ([]*grpc.ClientConn, error) {
+	conns := make([]*grpc.ClientConn, 0, cfg.connections)
+	for i := 0; i < cfg.connections; i++ {
+		conn, err := dialConnection(cfg, i)
+		if err != nil {
+			for _, opened := range conns {
+				_ = opened.Close()
+			}
+			return nil, err
+		}
+		conns = append(conns, conn)
+	}
+	return conns, nil
+}
+
+func dialConnection(cfg config, index int) (*grpc.ClientConn, error) {
+	opts := []grpc.DialOption{grpc.WithTransportCredentials(insecure.NewCredentials())}
+
+	if cfg.balance != balanceRoundRobin {
+		return grpc.NewClient(cfg.targets[index%len(cfg.targets)], opts...)
+	}
+
+	opts = append(opts, grpc.WithDefaultServiceConfig(roundRobinServiceConfig))
+	if len(cfg.targets) == 1 {
+		return grpc.NewClient(cfg.targets[0], opts...)
+	}
+
+	// Статический resolver отдаёт все адреса сразу; у каждого соединения свой экземпляр.
+	builder := manual.NewBuilderWithScheme(fmt.Sprintf("loadtest%d", index))
+	addresses := make([]resolver.Address, 0, len(cfg.targets))
+	for _, target := range cfg.targets {
+		addresses = append(addresses, resolver.Address{Addr: target})
+	}
+	builder.InitialState(resolver.State{Addresses: addresses})
+	opts = append(opts, grpc.WithResolvers(builder))
+	return grpc.NewClient(builder.Scheme()+":///oms", opts...)
+}
+
+// peerTarget возвращает адрес сервера, обработавшего RPC. Если соединение не установилось,
+// адрес неизвестен.
+func peerTarget(p *peer.Peer) string {
+	if p == nil || p.Addr == nil {
+		return unknownTarget
+	}
+	return p.Addr.String()
+}
+
+// recordTarget учитывает вызов в статистике по адресу сервера.
+func (c *collector) recordTarget(target string, latency time.Duration, code codes.Code) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.targets == nil {
+		c.targets = make(map[string]*methodStats)
+	}
+	stats, ok := c.targets[target]
+	if !ok {
+		stats = &methodStats{codes: make(map[string]int64)}
+		c.targets[target] = stats
+	}
+	stats.add(latency, code)
+}
+
+// recordRPC учитывает RPC и по методу, и по адресу сервера.
+func (c *collector) recordRPC(method string, p *peer.Peer, latency time.Duration, code codes.Code) {
+	c.record(method, latency, code)
+	c.recordTarget(peerTarget(p), latency, code)
+}
@@ -0,0 +1,107 @@
+package main
+
+import (
+	"net"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/peer"
+
+	omsv1 "github.com/vladislavdragonenkov/oms/proto/oms/v1"
+)
+
+func TestParseTargetsAndBalanceMode(t *testing.T) {
+	targets, err := parseTargets(" pod-a:50051, ,pod-b:50051,")
+	if err != nil {
+		t.Fatalf("parse targets: %v", err)
+	}
+	if !reflect.DeepEqual(targets, []string{"pod-a:50051", "pod-b:50051"}) {
+		t.Fatalf("unexpected targets: %v", targets)
+	}
+	if _, err := parseTargets(" , "); err == nil {
+		t.Fatal("expected error for empty target list")
+	}
+
+	if mode, err := parseBalanceMode("round_robin"); err != nil || mode != balanceRoundRobin {
+		t.Fatalf("unexpected balance mode: %s (%v)", mode, err)
+	}
+	if _, err := parseBalanceMode("random"); err == nil {
+		t.Fatal("expected error for unsupported balance mode")
+	}
+}
+
+func TestDialConnections_ConnModeSpreadsTargets(t *testing.T) {
+	conns, err := dialConnections(config{targets: []string{"pod-a:1", "pod-b:2"}, balance: balanceConn, connections: 3})
+	if err != nil {
+		t.Fatalf("dial: %v", err)
+	}
+	defer func() {
+		for _, conn := range conns {
+			_ = conn.Close()
+		}
+	}()
+
+	got := []string{conns[0].Target(), conns[1].Target(), conns[2].Target()}
+	if !reflect.DeepEqual(got, []string{"pod-a:1", "pod-b:2", "pod-a:1"}) {
+		t.Fatalf("expected round-robin assignment of targets, got %v", got)
+	}
+}
+
+func TestDialConnections_RoundRobinHitsEveryTarget(t *testing.T) {
+	addrs := make([]string, 0, 2)
+	for i := 0; i < 2; i++ {
+		lis, err := net.Listen("tcp", "127.0.0.1:0")
+		if err != nil {
+			t.Fatalf("listen: %v", err)
+		}
+		srv := grpc.NewServer()
+		omsv1.RegisterOrderServiceServer(srv, &loadtestMainServer{})
+		go func() { _ = srv.Serve(lis) }()
+		defer srv.Stop()
+		addrs = append(addrs, lis.Addr().String())
+	}
+
+	cfg := config{targets: addrs, balance: balanceRoundRobin, connections: 1}
+	conns, err := dialConnections(cfg)
+	if err != nil {
+		t.Fatalf("dial: %v", err)
+	}
+	defer func() { _ = conns[0].Close() }()
+
+	client := omsv1.NewOrderServiceClient(conns[0])
+	col := newCollector()
+	for i := 0; i < 20; i++ {
+		if _, err := callCreateOrder(client, 2*time.Second, &omsv1.CreateOrderRequest{CustomerId: "c"}, "key", col); err != nil {
+			t.Fatalf("create order: %v", err)
+		}
+	}
+
+	result := col.buildReport(time.Now(), time.Second)
+	for _, addr := range addrs {
+		if result.Targets[addr].Calls == 0 {
+			t.Fatalf("expected calls to reach %s, got targets %+v", addr, result.Targets)
+		}
+	}
+	if result.Methods["CreateOrder"].Calls != 20 {
+		t.Fatalf("unexpected method stats: %+v", result.Methods["CreateOrder"])
+	}
+}
+
+func TestCollector_RecordRPCWithoutPeer(t *testing.T) {
+	col := newCollector()
+	col.recordRPC("PayOrder", &peer.Peer{}, time.Millisecond, codes.Unavailable)
+
+	result := col.buildReport(time.Now(), time.Second)
+	if stats := result.Targets[unknownTarget]; stats.Calls != 1 || stats.Failed != 1 {
+		t.Fatalf("expected failed call attributed to unknown target, got %+v", result.Targets)
+	}
+
+	output := captureStdout(t, func() { printReport(result, config{mode: modeCreatePay, total: 1}) })
+	if !strings.Contains(output, "target unknown: calls=1 failed=1") {
+		t.Fatalf("expected per-target line in summary, got:\n%s", output)
+	}
+}
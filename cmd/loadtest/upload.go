@@ -0,0 +1,54 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/vladislavdragonenkov/oms/internal/objectstore"
+)
+
+const (
+	defaultUploadRetention = 30 * 24 * time.Hour
+	uploadTimeout          = 2 * time.Minute
+	artifactReport         = "loadtest-report"
+	artifactErrors         = "loadtest-errors"
+)
+
+type uploadArtifact struct {
+	name     string
+	artifact string
+	value    any
+}
+
+// uploadReports выгружает отчёт и сэмплы ошибок (если есть) в <upload-report>/<runID>/,
+// чтобы результаты прогона в CI не пропадали вместе с подом.
+func uploadReports(ctx context.Context, up objectstore.Uploader, cfg config, runID string, result report, errorSamples any, out io.Writer) error {
+	ctx, cancel := context.WithTimeout(ctx, uploadTimeout)
+	defer cancel()
+
+	artifacts := []uploadArtifact{{"report.json", artifactReport, result}}
+	if errorSamples != nil {
+		artifacts = append(artifacts, uploadArtifact{"errors.json", artifactErrors, errorSamples})
+	}
+
+	for _, a := range artifacts {
+		body, err := json.MarshalIndent(a.value, "", "  ")
+		if err != nil {
+			return fmt.Errorf("encode %s: %w", a.name, err)
+		}
+		location, err := up.Put(ctx, objectstore.Object{
+			Key:         runID + "/" + a.name,
+			Body:        body,
+			ContentType: "application/json",
+			Tags:        objectstore.RetentionTags(a.artifact, cfg.uploadRetention),
+		})
+		if err != nil {
+			return err
+		}
+		_, _ = fmt.Fprintf(out, "uploaded %s\n", location)
+	}
+	return nil
+}
@@ -0,0 +1,45 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/vladislavdragonenkov/oms/internal/objectstore"
+)
+
+func TestUploadReports(t *testing.T) {
+	dir := t.TempDir()
+	up, err := objectstore.Open("file://" + dir)
+	if err != nil {
+		t.Fatalf("open: %v", err)
+	}
+	cfg := config{uploadRetention: 14 * 24 * time.Hour}
+	var out bytes.Buffer
+
+	result := report{TotalScenarios: 3, SuccessScenarios: 3}
+	if err := uploadReports(context.Background(), up, cfg, "run-1", result, map[string]int{"Unavailable": 1}, &out); err != nil {
+		t.Fatalf("upload: %v", err)
+	}
+
+	data, err := os.ReadFile(filepath.Join(dir, "run-1", "report.json"))
+	if err != nil {
+		t.Fatalf("read report: %v", err)
+	}
+	var uploaded report
+	if err := json.Unmarshal(data, &uploaded); err != nil || uploaded.TotalScenarios != 3 {
+		t.Fatalf("unexpected uploaded report %s (%v)", data, err)
+	}
+	tags, err := os.ReadFile(filepath.Join(dir, "run-1", "errors.json.tags.json"))
+	if err != nil || string(tags) != `{"artifact":"loadtest-errors","retention":"14d"}` {
+		t.Fatalf("unexpected error samples tags %s (%v)", tags, err)
+	}
+	if strings.Count(out.String(), "uploaded file://") != 2 {
+		t.Fatalf("expected both locations to be printed, got %q", out.String())
+	}
+}
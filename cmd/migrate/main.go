@@ -19,14 +19,21 @@ func main() {
 	var (
 		direction string
 		steps     int
+		version   int64
 		dsn       string
 	)
 
-	flag.StringVar(&direction, "direction", "up", "migration direction: up|down|status")
+	flag.StringVar(&direction, "direction", "up", "migration direction: up|down|status|force-version")
 	flag.IntVar(&steps, "steps", 0, "number of migrations to apply/rollback (0=all for up, 1 for down)")
+	flag.Int64Var(&version, "version", -1, "target version for force-version (0=no migrations applied)")
 	flag.StringVar(&dsn, "dsn", "", "PostgreSQL DSN (fallback: OMS_POSTGRES_DSN)")
 	flag.Parse()
 
+	direction = strings.ToLower(strings.TrimSpace(direction))
+	if direction == "force-version" && version < 0 {
+		fail("-version is required for force-version")
+	}
+
 	if strings.TrimSpace(dsn) == "" {
 		dsn = strings.TrimSpace(os.Getenv("OMS_POSTGRES_DSN"))
 	}
@@ -43,7 +50,7 @@ func main() {
 	}
 	defer store.Close()
 
-	switch strings.ToLower(strings.TrimSpace(direction)) {
+	switch direction {
 	case "up":
 		if err := store.MigrateUp(ctx, steps); err != nil {
 			fail("migrate up failed: %v", err)
@@ -70,9 +77,27 @@ func main() {
 		if err != nil {
 			fail("migration status failed: %v", err)
 		}
+		dirtyVersion, dirty, err := store.DirtyMigration(ctx)
+		if err != nil {
+			fail("migration status failed: %v", err)
+		}
+		if dirty {
+			fmt.Printf("migration status: version=%d applied=%d dirty=%d\n", version, count, dirtyVersion)
+			return
+		}
 		fmt.Printf("migration status: version=%d applied=%d\n", version, count)
+	case "force-version":
+		// Только запись в schema_migrations: схему оператор проверяет и чинит до вызова.
+		if err := store.ForceVersion(ctx, version); err != nil {
+			fail("force version failed: %v", err)
+		}
+		current, count, err := store.MigrationStatus(ctx)
+		if err != nil {
+			fail("migration status failed: %v", err)
+		}
+		fmt.Printf("force-version ok: version=%d applied=%d\n", current, count)
 	default:
-		fail("unsupported direction: %s (use up|down|status)", direction)
+		fail("unsupported direction: %s (use up|down|status|force-version)", direction)
 	}
 }
 
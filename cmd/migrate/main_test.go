@@ -141,3 +141,22 @@ func TestMainUnsupportedDirectionExits(t *testing.T) {
 		t.Fatalf("expected non-zero exit code, got %v", err)
 	}
 }
+
+func TestMainForceVersionRequiresVersion(t *testing.T) {
+	if os.Getenv("MIGRATE_TEST_FORCE_NO_VERSION") == "1" {
+		withMigrateCLIArgs(t, []string{"-direction=force-version", "-dsn=postgres://unused"}, func() {
+			main()
+		})
+		return
+	}
+
+	cmd := exec.Command(os.Args[0], "-test.run=TestMainForceVersionRequiresVersion")
+	cmd.Env = append(os.Environ(), "MIGRATE_TEST_FORCE_NO_VERSION=1")
+	output, err := cmd.CombinedOutput()
+	if err == nil {
+		t.Fatal("expected subprocess to exit with error")
+	}
+	if !strings.Contains(string(output), "-version is required") {
+		t.Fatalf("unexpected output: %s", output)
+	}
+}
@@ -0,0 +1,130 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"net"
+	"os"
+	"os/signal"
+	"strings"
+	"syscall"
+
+	log "github.com/sirupsen/logrus"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/health"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/reflection"
+
+	omsv1 "github.com/vladislavdragonenkov/oms/proto/oms/v1"
+)
+
+const defaultAddr = ":50051"
+
+type config struct {
+	addr         string
+	scenarioPath string
+	// seed переопределяет seed сценария; 0 — взять из сценария, а если и там 0 — случайный.
+	seed int64
+}
+
+func main() {
+	log.SetFormatter(&log.TextFormatter{FullTimestamp: true})
+	log.SetLevel(log.InfoLevel)
+
+	cfg, err := readConfig(os.Args[1:], os.Getenv)
+	if err != nil {
+		fail("%v", err)
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	if err := run(ctx, cfg); err != nil {
+		fail("oms-mock failed: %v", err)
+	}
+}
+
+func readConfig(args []string, getenv func(string) string) (config, error) {
+	var cfg config
+	fs := flag.NewFlagSet("oms-mock", flag.ContinueOnError)
+	fs.StringVar(&cfg.addr, "addr", "", "gRPC listen address (fallback: OMS_MOCK_ADDR, default "+defaultAddr+")")
+	fs.StringVar(&cfg.scenarioPath, "scenario", "", "YAML scenario with latencies, scripted responses and faults (fallback: OMS_MOCK_SCENARIO)")
+	fs.Int64Var(&cfg.seed, "seed", 0, "random seed for jitter and faults (0 = scenario seed or random)")
+	if err := fs.Parse(args); err != nil {
+		return config{}, err
+	}
+
+	if strings.TrimSpace(cfg.addr) == "" {
+		cfg.addr = strings.TrimSpace(getenv("OMS_MOCK_ADDR"))
+	}
+	if cfg.addr == "" {
+		cfg.addr = defaultAddr
+	}
+	if strings.TrimSpace(cfg.scenarioPath) == "" {
+		cfg.scenarioPath = strings.TrimSpace(getenv("OMS_MOCK_SCENARIO"))
+	}
+	if fs.NArg() > 0 {
+		return config{}, fmt.Errorf("unexpected arguments: %v", fs.Args())
+	}
+	return cfg, nil
+}
+
+func run(ctx context.Context, cfg config) error {
+	sc, err := loadScenario(cfg.scenarioPath)
+	if err != nil {
+		return err
+	}
+	listener, err := net.Listen("tcp", cfg.addr)
+	if err != nil {
+		return fmt.Errorf("listen %s: %w", cfg.addr, err)
+	}
+
+	log.WithFields(log.Fields{
+		"addr":     listener.Addr().String(),
+		"scenario": cfg.scenarioPath,
+		"methods":  len(sc.methods),
+	}).Info("oms-mock listening")
+	return serve(ctx, listener, newServer(sc, cfg.seed))
+}
+
+func newServer(sc *scenario, seed int64) *grpc.Server {
+	if seed == 0 {
+		seed = sc.seed
+	}
+	inject := newInjector(sc, seed)
+	server := grpc.NewServer(
+		grpc.ChainUnaryInterceptor(inject.unary),
+		grpc.ChainStreamInterceptor(inject.stream),
+	)
+	omsv1.RegisterOrderServiceServer(server, newOrderStore())
+	// Как у настоящего сервиса: grpcurl и health-пробы клиентов работают без изменений.
+	reflection.Register(server)
+	healthServer := health.NewServer()
+	healthServer.SetServingStatus("", healthpb.HealthCheckResponse_SERVING)
+	healthpb.RegisterHealthServer(server, healthServer)
+	return server
+}
+
+// serve обслуживает listener до отмены ctx, затем дожидается активных RPC.
+func serve(ctx context.Context, listener net.Listener, server *grpc.Server) error {
+	errCh := make(chan error, 1)
+	go func() { errCh <- server.Serve(listener) }()
+
+	select {
+	case err := <-errCh:
+		return err
+	case <-ctx.Done():
+		server.GracefulStop()
+		if err := <-errCh; err != nil && !errors.Is(err, grpc.ErrServerStopped) {
+			return err
+		}
+		return nil
+	}
+}
+
+func fail(format string, args ...any) {
+	_, _ = fmt.Fprintf(os.Stderr, format+"\n", args...)
+	os.Exit(1)
+}
@@ -0,0 +1,197 @@
+package main
+
+import (
+	"context"
+	"net"
+	"testing"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+
+	omsv1 "github.com/vladislavdragonenkov/oms/proto/oms/v1"
+)
+
+func TestReadConfig(t *testing.T) {
+	env := map[string]string{"OMS_MOCK_ADDR": "127.0.0.1:6000", "OMS_MOCK_SCENARIO": "faults.yaml"}
+	cfg, err := readConfig(nil, func(key string) string { return env[key] })
+	if err != nil {
+		t.Fatalf("readConfig failed: %v", err)
+	}
+	if cfg.addr != "127.0.0.1:6000" || cfg.scenarioPath != "faults.yaml" {
+		t.Fatalf("env fallback not applied: %+v", cfg)
+	}
+
+	cfg, err = readConfig([]string{"-scenario=local.yaml", "-seed=7"}, func(string) string { return "" })
+	if err != nil {
+		t.Fatalf("readConfig failed: %v", err)
+	}
+	if cfg.addr != defaultAddr || cfg.scenarioPath != "local.yaml" || cfg.seed != 7 {
+		t.Fatalf("unexpected config: %+v", cfg)
+	}
+
+	if _, err := readConfig([]string{"extra"}, func(string) string { return "" }); err == nil {
+		t.Fatal("expected error for positional arguments")
+	}
+}
+
+func TestMock_StatefulOrderFlow(t *testing.T) {
+	client := startMock(t, "")
+	ctx := context.Background()
+
+	created, err := client.CreateOrder(ctx, &omsv1.CreateOrderRequest{
+		CustomerId: "customer-1",
+		Currency:   "USD",
+		Items:      []*omsv1.OrderItem{{Sku: "sku-1", Qty: 2, Price: &omsv1.Money{Currency: "USD", AmountMinor: 500}}},
+	})
+	if err != nil {
+		t.Fatalf("CreateOrder failed: %v", err)
+	}
+	order := created.GetOrder()
+	if order.GetId() == "" || order.GetStatus() != omsv1.OrderStatus_ORDER_STATUS_PENDING || order.GetAmount().GetAmountMinor() != 1000 {
+		t.Fatalf("unexpected created order: %v", order)
+	}
+
+	paid, err := client.PayOrder(ctx, &omsv1.PayOrderRequest{OrderId: order.GetId()})
+	if err != nil || paid.GetStatus() != omsv1.OrderStatus_ORDER_STATUS_PAID {
+		t.Fatalf("PayOrder: %v, %v", paid, err)
+	}
+	if _, err := client.PayOrder(ctx, &omsv1.PayOrderRequest{OrderId: order.GetId()}); status.Code(err) != codes.FailedPrecondition {
+		t.Fatalf("expected FailedPrecondition on second pay, got %v", err)
+	}
+	refunded, err := client.RefundOrder(ctx, &omsv1.RefundOrderRequest{OrderId: order.GetId()})
+	if err != nil || refunded.GetStatus() != omsv1.OrderStatus_ORDER_STATUS_REFUNDED {
+		t.Fatalf("RefundOrder: %v, %v", refunded, err)
+	}
+
+	got, err := client.GetOrder(ctx, &omsv1.GetOrderRequest{OrderId: order.GetId()})
+	if err != nil || got.GetOrder().GetStatus() != omsv1.OrderStatus_ORDER_STATUS_REFUNDED || got.GetOrder().GetVersion() != 3 {
+		t.Fatalf("GetOrder: %v, %v", got, err)
+	}
+	if _, err := client.GetOrder(ctx, &omsv1.GetOrderRequest{OrderId: "missing"}); status.Code(err) != codes.NotFound {
+		t.Fatalf("expected NotFound, got %v", err)
+	}
+
+	listed, err := client.ListOrders(ctx, &omsv1.ListOrdersRequest{
+		CustomerId:     "customer-1",
+		FilterStatuses: []omsv1.OrderStatus{omsv1.OrderStatus_ORDER_STATUS_REFUNDED},
+	})
+	if err != nil || len(listed.GetOrders()) != 1 {
+		t.Fatalf("ListOrders: %v, %v", listed, err)
+	}
+	if _, err := client.ListOrders(ctx, &omsv1.ListOrdersRequest{PageSize: maxListPageSize + 1}); status.Code(err) != codes.InvalidArgument {
+		t.Fatalf("expected InvalidArgument above max page size, got %v", err)
+	}
+	if _, err := client.ScheduleCancel(ctx, &omsv1.ScheduleCancelRequest{OrderId: order.GetId()}); status.Code(err) != codes.Unimplemented {
+		t.Fatalf("expected Unimplemented for unscripted method, got %v", err)
+	}
+}
+
+func TestMock_ScriptedResponses(t *testing.T) {
+	client := startMock(t, `
+methods:
+  CreateOrder:
+    responses:
+      - match: {customer_id: blocked}
+        error: {code: PermissionDenied, message: blocked customer}
+      - times: 1
+        error: {code: UNAVAILABLE}
+  GetOrder:
+    responses:
+      - match: {order_id: fixture}
+        response:
+          order: {id: fixture, status: ORDER_STATUS_CONFIRMED}
+  ScheduleCancel:
+    responses:
+      - response: {scheduledCancel: {id: sc-1}}
+`)
+	ctx := context.Background()
+	create := &omsv1.CreateOrderRequest{
+		CustomerId: "blocked",
+		Currency:   "USD",
+		Items:      []*omsv1.OrderItem{{Sku: "sku-1", Qty: 1}},
+	}
+
+	_, err := client.CreateOrder(ctx, create)
+	if st := status.Convert(err); st.Code() != codes.PermissionDenied || st.Message() != "blocked customer" {
+		t.Fatalf("expected scripted PermissionDenied, got %v", err)
+	}
+	create.CustomerId = "regular"
+	if _, err := client.CreateOrder(ctx, create); status.Code(err) != codes.Unavailable {
+		t.Fatalf("expected scripted Unavailable on first call, got %v", err)
+	}
+	if _, err := client.CreateOrder(ctx, create); err != nil {
+		t.Fatalf("rule with times must be exhausted, got %v", err)
+	}
+
+	got, err := client.GetOrder(ctx, &omsv1.GetOrderRequest{OrderId: "fixture"})
+	if err != nil || got.GetOrder().GetStatus() != omsv1.OrderStatus_ORDER_STATUS_CONFIRMED {
+		t.Fatalf("expected scripted order, got %v, %v", got, err)
+	}
+	scheduled, err := client.ScheduleCancel(ctx, &omsv1.ScheduleCancelRequest{OrderId: "any"})
+	if err != nil || scheduled.GetScheduledCancel().GetId() != "sc-1" {
+		t.Fatalf("expected scripted response for method without fallback, got %v, %v", scheduled, err)
+	}
+}
+
+func TestMock_FaultProfile(t *testing.T) {
+	sc, err := parseScenario([]byte(`
+defaults:
+  latency: 10ms
+  faults: {slow_rate: 1, slow_latency: 2s}
+methods:
+  PayOrder:
+    faults: {unavailable_rate: 1}
+`))
+	if err != nil {
+		t.Fatalf("parseScenario failed: %v", err)
+	}
+	inject := newInjector(sc, 1)
+	var slept []time.Duration
+	inject.sleep = func(_ context.Context, d time.Duration) error {
+		slept = append(slept, d)
+		return nil
+	}
+	handler := func(context.Context, any) (any, error) { return &omsv1.GetOrderResponse{}, nil }
+
+	if _, err := inject.unary(context.Background(), &omsv1.PayOrderRequest{}, &grpc.UnaryServerInfo{FullMethod: "/oms.v1.OrderService/PayOrder"}, handler); status.Code(err) != codes.Unavailable {
+		t.Fatalf("expected injected Unavailable, got %v", err)
+	}
+	if _, err := inject.unary(context.Background(), &omsv1.GetOrderRequest{}, &grpc.UnaryServerInfo{FullMethod: "/oms.v1.OrderService/GetOrder"}, handler); err != nil {
+		t.Fatalf("GetOrder failed: %v", err)
+	}
+	if len(slept) != 1 || slept[0] != 10*time.Millisecond+2*time.Second {
+		t.Fatalf("expected base latency plus slow latency, got %v", slept)
+	}
+}
+
+func startMock(t *testing.T, scenarioYAML string) omsv1.OrderServiceClient {
+	t.Helper()
+	sc, err := parseScenario([]byte(scenarioYAML))
+	if err != nil {
+		t.Fatalf("parseScenario failed: %v", err)
+	}
+	listener := bufconn.Listen(1 << 20)
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan error, 1)
+	go func() { done <- serve(ctx, listener, newServer(sc, 1)) }()
+
+	conn, err := grpc.NewClient("passthrough:///bufnet",
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) { return listener.DialContext(ctx) }),
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+	)
+	if err != nil {
+		t.Fatalf("dial mock: %v", err)
+	}
+	t.Cleanup(func() {
+		_ = conn.Close()
+		cancel()
+		if err := <-done; err != nil {
+			t.Errorf("serve returned error: %v", err)
+		}
+	})
+	return omsv1.NewOrderServiceClient(conn)
+}
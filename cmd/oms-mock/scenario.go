@@ -0,0 +1,279 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"sync"
+	"time"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/reflect/protoregistry"
+	"gopkg.in/yaml.v3"
+
+	omsv1 "github.com/vladislavdragonenkov/oms/proto/oms/v1"
+)
+
+// scenarioFile — YAML-сценарий мока, пример в cmd/oms-mock/scenario.example.yaml.
+type scenarioFile struct {
+	Seed     int64                 `yaml:"seed"`
+	Defaults methodSpec            `yaml:"defaults"`
+	Methods  map[string]methodSpec `yaml:"methods"`
+}
+
+// methodSpec — поведение одного RPC. Незаданные поля метода берутся из defaults.
+type methodSpec struct {
+	Latency   *time.Duration `yaml:"latency"`
+	Jitter    *time.Duration `yaml:"jitter"`
+	Faults    *faultSpec     `yaml:"faults"`
+	Responses []responseSpec `yaml:"responses"`
+}
+
+type faultSpec struct {
+	// UnavailableRate — доля вызовов, сразу получающих codes.Unavailable.
+	UnavailableRate float64 `yaml:"unavailable_rate"`
+	// SlowRate — доля вызовов, к задержке которых добавляется SlowLatency.
+	SlowRate    float64       `yaml:"slow_rate"`
+	SlowLatency time.Duration `yaml:"slow_latency"`
+}
+
+// responseSpec — скриптованный ответ. Правила проверяются по порядку; первое подходящее
+// по match отвечает error или response. Times ограничивает число срабатываний (0 — без
+// ограничения): так задаются сценарии вроде «два раза Unavailable, затем успех».
+type responseSpec struct {
+	Match    map[string]string `yaml:"match"`
+	Times    int               `yaml:"times"`
+	Error    *errorSpec        `yaml:"error"`
+	Response map[string]any    `yaml:"response"`
+}
+
+type errorSpec struct {
+	Code    string `yaml:"code"`
+	Message string `yaml:"message"`
+}
+
+// scenario — проверенный сценарий, готовый к применению в interceptor'ах.
+type scenario struct {
+	seed     int64
+	defaults *methodPlan
+	methods  map[string]*methodPlan
+}
+
+type methodPlan struct {
+	latency time.Duration
+	jitter  time.Duration
+	faults  faultSpec
+	rules   []*rule
+}
+
+type rule struct {
+	match    map[protoreflect.FieldDescriptor]string
+	err      error
+	response proto.Message
+
+	mu        sync.Mutex
+	remaining int // -1 — без ограничения
+}
+
+// take проверяет запрос и, если правило подходит и не исчерпано, засчитывает срабатывание.
+// req может быть nil только для правил без match.
+func (r *rule) take(req proto.Message) bool {
+	for field, want := range r.match {
+		if fieldString(req.ProtoReflect(), field) != want {
+			return false
+		}
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.remaining == 0 {
+		return false
+	}
+	if r.remaining > 0 {
+		r.remaining--
+	}
+	return true
+}
+
+// plan возвращает поведение метода; методы без секции в сценарии ведут себя как defaults.
+func (s *scenario) plan(method string) *methodPlan {
+	if plan, ok := s.methods[method]; ok {
+		return plan
+	}
+	return s.defaults
+}
+
+func loadScenario(path string) (*scenario, error) {
+	if path == "" {
+		return parseScenario(nil)
+	}
+	raw, err := os.ReadFile(path) // #nosec G304 -- путь к сценарию задаёт оператор.
+	if err != nil {
+		return nil, fmt.Errorf("read scenario: %w", err)
+	}
+	sc, err := parseScenario(raw)
+	if err != nil {
+		return nil, fmt.Errorf("parse scenario %s: %w", path, err)
+	}
+	return sc, nil
+}
+
+func parseScenario(raw []byte) (*scenario, error) {
+	var file scenarioFile
+	decoder := yaml.NewDecoder(bytes.NewReader(raw))
+	decoder.KnownFields(true)
+	// Пустой сценарий допустим: мок отвечает без задержек и отказов.
+	if err := decoder.Decode(&file); err != nil && !errors.Is(err, io.EOF) {
+		return nil, err
+	}
+
+	service := omsv1.File_proto_oms_v1_order_service_proto.Services().ByName("OrderService")
+	if len(file.Defaults.Responses) > 0 {
+		return nil, errors.New("defaults: responses are set per method")
+	}
+	defaults, err := compilePlan(file.Defaults, methodSpec{}, nil)
+	if err != nil {
+		return nil, fmt.Errorf("defaults: %w", err)
+	}
+	sc := &scenario{seed: file.Seed, defaults: defaults, methods: make(map[string]*methodPlan, len(file.Methods))}
+	for name, spec := range file.Methods {
+		method := service.Methods().ByName(protoreflect.Name(name))
+		if method == nil {
+			return nil, fmt.Errorf("methods: unknown OrderService method %q", name)
+		}
+		plan, err := compilePlan(spec, file.Defaults, method)
+		if err != nil {
+			return nil, fmt.Errorf("methods.%s: %w", name, err)
+		}
+		sc.methods[name] = plan
+	}
+	return sc, nil
+}
+
+func compilePlan(spec, defaults methodSpec, method protoreflect.MethodDescriptor) (*methodPlan, error) {
+	plan := &methodPlan{
+		latency: durationOr(spec.Latency, durationOr(defaults.Latency, 0)),
+		jitter:  durationOr(spec.Jitter, durationOr(defaults.Jitter, 0)),
+	}
+	switch {
+	case spec.Faults != nil:
+		plan.faults = *spec.Faults
+	case defaults.Faults != nil:
+		plan.faults = *defaults.Faults
+	}
+	if plan.latency < 0 || plan.jitter < 0 || plan.faults.SlowLatency < 0 {
+		return nil, errors.New("latency, jitter and slow_latency must be >= 0")
+	}
+	if !validRate(plan.faults.UnavailableRate) || !validRate(plan.faults.SlowRate) {
+		return nil, errors.New("fault rates must be within [0, 1]")
+	}
+	for idx, response := range spec.Responses {
+		compiled, err := compileRule(response, method)
+		if err != nil {
+			return nil, fmt.Errorf("responses[%d]: %w", idx, err)
+		}
+		plan.rules = append(plan.rules, compiled)
+	}
+	return plan, nil
+}
+
+func compileRule(spec responseSpec, method protoreflect.MethodDescriptor) (*rule, error) {
+	if (spec.Error == nil) == (spec.Response == nil) {
+		return nil, errors.New("exactly one of error or response is required")
+	}
+	if spec.Times < 0 {
+		return nil, errors.New("times must be >= 0")
+	}
+	compiled := &rule{remaining: -1, match: make(map[protoreflect.FieldDescriptor]string, len(spec.Match))}
+	if spec.Times > 0 {
+		compiled.remaining = spec.Times
+	}
+
+	input := method.Input()
+	for name, value := range spec.Match {
+		field := input.Fields().ByName(protoreflect.Name(name))
+		if field == nil {
+			return nil, fmt.Errorf("match: %s has no field %q", input.Name(), name)
+		}
+		if field.IsList() || field.IsMap() || field.Kind() == protoreflect.MessageKind {
+			return nil, fmt.Errorf("match: field %q is not a scalar", name)
+		}
+		compiled.match[field] = value
+	}
+
+	if spec.Error != nil {
+		code, err := parseCode(spec.Error.Code)
+		if err != nil {
+			return nil, err
+		}
+		if code == codes.OK {
+			return nil, errors.New("error.code must not be OK, use response instead")
+		}
+		message := spec.Error.Message
+		if message == "" {
+			message = "oms-mock: scripted " + code.String()
+		}
+		compiled.err = status.Error(code, message)
+		return compiled, nil
+	}
+
+	if method.IsStreamingServer() || method.IsStreamingClient() {
+		return nil, errors.New("response is not supported for streaming methods, use error")
+	}
+	// response задаётся в protojson-форме выходного сообщения метода.
+	encoded, err := json.Marshal(spec.Response)
+	if err != nil {
+		return nil, fmt.Errorf("response: %w", err)
+	}
+	outputType, err := protoregistry.GlobalTypes.FindMessageByName(method.Output().FullName())
+	if err != nil {
+		return nil, fmt.Errorf("response: %w", err)
+	}
+	message := outputType.New().Interface()
+	if err := protojson.Unmarshal(encoded, message); err != nil {
+		return nil, fmt.Errorf("response is not a valid %s: %w", method.Output().Name(), err)
+	}
+	compiled.response = message
+	return compiled, nil
+}
+
+func durationOr(value *time.Duration, fallback time.Duration) time.Duration {
+	if value == nil {
+		return fallback
+	}
+	return *value
+}
+
+func validRate(rate float64) bool {
+	return rate >= 0 && rate <= 1
+}
+
+// parseCode принимает имя gRPC-кода в виде NotFound или NOT_FOUND.
+func parseCode(raw string) (codes.Code, error) {
+	normalized := strings.ToLower(strings.ReplaceAll(strings.TrimSpace(raw), "_", ""))
+	for code := codes.OK; code <= codes.Unauthenticated; code++ {
+		if strings.ToLower(code.String()) == normalized {
+			return code, nil
+		}
+	}
+	return codes.Unknown, fmt.Errorf("error.code: unknown gRPC code %q", raw)
+}
+
+// fieldString приводит скалярное поле запроса к строке для сравнения с match;
+// enum сравнивается по имени значения (ORDER_STATUS_PAID).
+func fieldString(msg protoreflect.Message, field protoreflect.FieldDescriptor) string {
+	value := msg.Get(field)
+	if field.Kind() == protoreflect.EnumKind {
+		if enumValue := field.Enum().Values().ByNumber(value.Enum()); enumValue != nil {
+			return string(enumValue.Name())
+		}
+	}
+	return fmt.Sprint(value.Interface())
+}
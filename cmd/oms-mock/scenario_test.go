@@ -0,0 +1,96 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"google.golang.org/grpc/codes"
+)
+
+func TestParseScenario_MethodOverridesDefaults(t *testing.T) {
+	sc, err := parseScenario([]byte(`
+seed: 9
+defaults:
+  latency: 5ms
+  jitter: 1ms
+  faults: {unavailable_rate: 0.5}
+methods:
+  GetOrder:
+    latency: 50ms
+`))
+	if err != nil {
+		t.Fatalf("parseScenario failed: %v", err)
+	}
+	if sc.seed != 9 {
+		t.Fatalf("expected seed 9, got %d", sc.seed)
+	}
+	get := sc.plan("GetOrder")
+	if get.latency != 50*time.Millisecond || get.jitter != time.Millisecond || get.faults.UnavailableRate != 0.5 {
+		t.Fatalf("unexpected GetOrder plan: %+v", get)
+	}
+	if pay := sc.plan("PayOrder"); pay != sc.defaults {
+		t.Fatalf("methods without section must use defaults, got %+v", pay)
+	}
+}
+
+func TestParseScenario_Errors(t *testing.T) {
+	cases := map[string]string{
+		"unknown method":        "methods:\n  DropOrder: {}\n",
+		"unknown key":           "defaults:\n  latncy: 1ms\n",
+		"rate above one":        "defaults:\n  faults: {slow_rate: 1.5}\n",
+		"negative latency":      "defaults:\n  latency: -1ms\n",
+		"defaults responses":    "defaults:\n  responses:\n    - error: {code: NotFound}\n",
+		"error and response":    "methods:\n  GetOrder:\n    responses:\n      - error: {code: NotFound}\n        response: {}\n",
+		"neither":               "methods:\n  GetOrder:\n    responses:\n      - times: 1\n",
+		"unknown code":          "methods:\n  GetOrder:\n    responses:\n      - error: {code: Teapot}\n",
+		"ok code":               "methods:\n  GetOrder:\n    responses:\n      - error: {code: OK}\n",
+		"unknown match field":   "methods:\n  GetOrder:\n    responses:\n      - match: {id: x}\n        error: {code: NotFound}\n",
+		"non-scalar match":      "methods:\n  CreateOrder:\n    responses:\n      - match: {items: x}\n        error: {code: NotFound}\n",
+		"invalid response":      "methods:\n  GetOrder:\n    responses:\n      - response: {orders: []}\n",
+		"streaming response":    "methods:\n  StreamOrderTimeline:\n    responses:\n      - response: {}\n",
+		"negative times":        "methods:\n  GetOrder:\n    responses:\n      - times: -1\n        error: {code: NotFound}\n",
+		"invalid yaml document": "methods: [\n",
+	}
+	for name, raw := range cases {
+		t.Run(name, func(t *testing.T) {
+			if _, err := parseScenario([]byte(raw)); err == nil {
+				t.Fatalf("expected error for %q", raw)
+			}
+		})
+	}
+}
+
+func TestParseCode(t *testing.T) {
+	for raw, want := range map[string]codes.Code{
+		"NotFound":           codes.NotFound,
+		"NOT_FOUND":          codes.NotFound,
+		"deadline_exceeded":  codes.DeadlineExceeded,
+		" ResourceExhausted": codes.ResourceExhausted,
+	} {
+		got, err := parseCode(raw)
+		if err != nil || got != want {
+			t.Fatalf("parseCode(%q) = %v, %v; want %v", raw, got, err, want)
+		}
+	}
+}
+
+func TestLoadScenario_ExampleFile(t *testing.T) {
+	sc, err := loadScenario("scenario.example.yaml")
+	if err != nil {
+		t.Fatalf("example scenario must stay valid: %v", err)
+	}
+	if len(sc.plan("CreateOrder").rules) == 0 {
+		t.Fatal("expected scripted CreateOrder responses in example")
+	}
+
+	path := filepath.Join(t.TempDir(), "broken.yaml")
+	if err := os.WriteFile(path, []byte("methods:\n  Nope: {}\n"), 0o600); err != nil {
+		t.Fatalf("WriteFile failed: %v", err)
+	}
+	if _, err := loadScenario(path); err == nil || !strings.Contains(err.Error(), path) {
+		t.Fatalf("expected error mentioning the file, got %v", err)
+	}
+}
@@ -0,0 +1,363 @@
+package main
+
+import (
+	"context"
+	"math/rand/v2"
+	"path"
+	"slices"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+
+	omsv1 "github.com/vladislavdragonenkov/oms/proto/oms/v1"
+)
+
+// Лимиты страниц совпадают со значениями сервиса по умолчанию (grpcsvc.DefaultPageLimits).
+const (
+	defaultListPageSize = 100
+	maxListPageSize     = 1000
+)
+
+// orderStore — упрощённый OrderService в памяти: без саги, склада и платежей. Статусы
+// меняются сразу, чтобы клиент мог пройти Create→Pay→Cancel/Refund→Get без бэкенда.
+// Методы, которых здесь нет, отвечают Unimplemented, если сценарий не задаёт для них ответ.
+type orderStore struct {
+	omsv1.UnimplementedOrderServiceServer
+
+	mu     sync.Mutex
+	orders map[string]*omsv1.Order
+	order  []string // порядок создания для ListOrders
+	// beforeHold — статус, в который ReleaseOrder возвращает заказ.
+	beforeHold map[string]omsv1.OrderStatus
+	now        func() time.Time
+}
+
+func newOrderStore() *orderStore {
+	return &orderStore{
+		orders:     make(map[string]*omsv1.Order),
+		beforeHold: make(map[string]omsv1.OrderStatus),
+		now:        time.Now,
+	}
+}
+
+func (s *orderStore) CreateOrder(_ context.Context, req *omsv1.CreateOrderRequest) (*omsv1.CreateOrderResponse, error) {
+	if req.GetCustomerId() == "" {
+		return nil, status.Error(codes.InvalidArgument, "customer_id is required")
+	}
+	if len(req.GetItems()) == 0 {
+		return nil, status.Error(codes.InvalidArgument, "order must contain at least one item")
+	}
+	currency := req.GetCurrency()
+	if currency == "" {
+		return nil, status.Error(codes.InvalidArgument, "currency is required")
+	}
+
+	createdAt := s.now().Unix()
+	order := &omsv1.Order{
+		Id:         uuid.NewString(),
+		CustomerId: req.GetCustomerId(),
+		Status:     omsv1.OrderStatus_ORDER_STATUS_PENDING,
+		Version:    1,
+		Currency:   currency,
+		TestMode:   req.GetTestMode(),
+	}
+	var total int64
+	for idx, item := range req.GetItems() {
+		if item.GetSku() == "" || item.GetQty() <= 0 {
+			return nil, status.Errorf(codes.InvalidArgument, "item[%d] must have sku and qty > 0", idx)
+		}
+		total += item.GetPrice().GetAmountMinor() * int64(item.GetQty())
+		order.Items = append(order.Items, &omsv1.OrderItem{
+			Id:            uuid.NewString(),
+			Sku:           item.GetSku(),
+			Qty:           item.GetQty(),
+			Price:         &omsv1.Money{Currency: currency, AmountMinor: item.GetPrice().GetAmountMinor()},
+			CreatedAtUnix: createdAt,
+		})
+	}
+	order.Amount = &omsv1.Money{Currency: currency, AmountMinor: total}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.orders[order.Id] = order
+	s.order = append(s.order, order.Id)
+	return &omsv1.CreateOrderResponse{Order: cloneOrder(order)}, nil
+}
+
+func (s *orderStore) GetOrder(_ context.Context, req *omsv1.GetOrderRequest) (*omsv1.GetOrderResponse, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	order, err := s.lookup(req.GetOrderId())
+	if err != nil {
+		return nil, err
+	}
+	return &omsv1.GetOrderResponse{Order: cloneOrder(order)}, nil
+}
+
+func (s *orderStore) ListOrders(_ context.Context, req *omsv1.ListOrdersRequest) (*omsv1.ListOrdersResponse, error) {
+	pageSize := int(req.GetPageSize())
+	switch {
+	case pageSize <= 0:
+		pageSize = defaultListPageSize
+	case pageSize > maxListPageSize:
+		return nil, status.Errorf(codes.InvalidArgument, "page_size %d exceeds maximum %d", pageSize, maxListPageSize)
+	}
+	offset := 0
+	if token := req.GetPageToken(); token != "" {
+		parsed, err := strconv.Atoi(token)
+		if err != nil || parsed < 0 {
+			return nil, status.Error(codes.InvalidArgument, "invalid page_token")
+		}
+		offset = parsed
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	var matched []*omsv1.Order
+	for _, id := range s.order {
+		order := s.orders[id]
+		if req.GetCustomerId() != "" && order.GetCustomerId() != req.GetCustomerId() {
+			continue
+		}
+		if len(req.GetFilterStatuses()) > 0 && !slices.Contains(req.GetFilterStatuses(), order.GetStatus()) {
+			continue
+		}
+		matched = append(matched, order)
+	}
+
+	resp := &omsv1.ListOrdersResponse{}
+	end := min(offset+pageSize, len(matched))
+	for idx := offset; idx < end; idx++ {
+		resp.Orders = append(resp.Orders, cloneOrder(matched[idx]))
+	}
+	if end < len(matched) {
+		resp.NextPageToken = strconv.Itoa(end)
+	}
+	return resp, nil
+}
+
+func (s *orderStore) GetServiceInfo(context.Context, *omsv1.GetServiceInfoRequest) (*omsv1.GetServiceInfoResponse, error) {
+	return &omsv1.GetServiceInfoResponse{
+		PageLimits: &omsv1.PageLimits{DefaultPageSize: defaultListPageSize, MaxPageSize: maxListPageSize},
+	}, nil
+}
+
+func (s *orderStore) PayOrder(_ context.Context, req *omsv1.PayOrderRequest) (*omsv1.PayOrderResponse, error) {
+	next, err := s.transition(req.GetOrderId(), omsv1.OrderStatus_ORDER_STATUS_PAID, "",
+		omsv1.OrderStatus_ORDER_STATUS_PENDING, omsv1.OrderStatus_ORDER_STATUS_RESERVED)
+	if err != nil {
+		return nil, err
+	}
+	return &omsv1.PayOrderResponse{OrderId: req.GetOrderId(), Status: next}, nil
+}
+
+func (s *orderStore) CaptureOrder(_ context.Context, req *omsv1.CaptureOrderRequest) (*omsv1.CaptureOrderResponse, error) {
+	next, err := s.transition(req.GetOrderId(), omsv1.OrderStatus_ORDER_STATUS_PAID, "",
+		omsv1.OrderStatus_ORDER_STATUS_AUTHORIZED)
+	if err != nil {
+		return nil, err
+	}
+	return &omsv1.CaptureOrderResponse{OrderId: req.GetOrderId(), Status: next}, nil
+}
+
+func (s *orderStore) CancelOrder(_ context.Context, req *omsv1.CancelOrderRequest) (*omsv1.CancelOrderResponse, error) {
+	next, err := s.transition(req.GetOrderId(), omsv1.OrderStatus_ORDER_STATUS_CANCELED, "",
+		omsv1.OrderStatus_ORDER_STATUS_PENDING, omsv1.OrderStatus_ORDER_STATUS_RESERVED,
+		omsv1.OrderStatus_ORDER_STATUS_AUTHORIZED, omsv1.OrderStatus_ORDER_STATUS_PAID,
+		omsv1.OrderStatus_ORDER_STATUS_ON_HOLD, omsv1.OrderStatus_ORDER_STATUS_BACKORDERED,
+		omsv1.OrderStatus_ORDER_STATUS_AWAITING_APPROVAL)
+	if err != nil {
+		return nil, err
+	}
+	return &omsv1.CancelOrderResponse{OrderId: req.GetOrderId(), Status: next}, nil
+}
+
+func (s *orderStore) RefundOrder(_ context.Context, req *omsv1.RefundOrderRequest) (*omsv1.RefundOrderResponse, error) {
+	next, err := s.transition(req.GetOrderId(), omsv1.OrderStatus_ORDER_STATUS_REFUNDED, "",
+		omsv1.OrderStatus_ORDER_STATUS_PAID, omsv1.OrderStatus_ORDER_STATUS_CONFIRMED)
+	if err != nil {
+		return nil, err
+	}
+	return &omsv1.RefundOrderResponse{OrderId: req.GetOrderId(), Status: next}, nil
+}
+
+func (s *orderStore) HoldOrder(_ context.Context, req *omsv1.HoldOrderRequest) (*omsv1.HoldOrderResponse, error) {
+	if req.GetReason() == "" {
+		return nil, status.Error(codes.InvalidArgument, "reason is required")
+	}
+	next, err := s.transition(req.GetOrderId(), omsv1.OrderStatus_ORDER_STATUS_ON_HOLD, req.GetReason(),
+		omsv1.OrderStatus_ORDER_STATUS_PENDING, omsv1.OrderStatus_ORDER_STATUS_RESERVED,
+		omsv1.OrderStatus_ORDER_STATUS_BACKORDERED)
+	if err != nil {
+		return nil, err
+	}
+	return &omsv1.HoldOrderResponse{OrderId: req.GetOrderId(), Status: next, HoldReason: req.GetReason()}, nil
+}
+
+func (s *orderStore) ReleaseOrder(_ context.Context, req *omsv1.ReleaseOrderRequest) (*omsv1.ReleaseOrderResponse, error) {
+	s.mu.Lock()
+	previous, held := s.beforeHold[req.GetOrderId()]
+	s.mu.Unlock()
+	if !held {
+		previous = omsv1.OrderStatus_ORDER_STATUS_PENDING
+	}
+	next, err := s.transition(req.GetOrderId(), previous, "", omsv1.OrderStatus_ORDER_STATUS_ON_HOLD)
+	if err != nil {
+		return nil, err
+	}
+	return &omsv1.ReleaseOrderResponse{OrderId: req.GetOrderId(), Status: next}, nil
+}
+
+func (s *orderStore) ApproveOrder(_ context.Context, req *omsv1.ApproveOrderRequest) (*omsv1.ApproveOrderResponse, error) {
+	next, err := s.transition(req.GetOrderId(), omsv1.OrderStatus_ORDER_STATUS_PENDING, "",
+		omsv1.OrderStatus_ORDER_STATUS_AWAITING_APPROVAL)
+	if err != nil {
+		return nil, err
+	}
+	return &omsv1.ApproveOrderResponse{OrderId: req.GetOrderId(), Status: next}, nil
+}
+
+func (s *orderStore) RejectOrder(_ context.Context, req *omsv1.RejectOrderRequest) (*omsv1.RejectOrderResponse, error) {
+	if req.GetReason() == "" {
+		return nil, status.Error(codes.InvalidArgument, "reason is required")
+	}
+	next, err := s.transition(req.GetOrderId(), omsv1.OrderStatus_ORDER_STATUS_CANCELED, "",
+		omsv1.OrderStatus_ORDER_STATUS_AWAITING_APPROVAL)
+	if err != nil {
+		return nil, err
+	}
+	return &omsv1.RejectOrderResponse{OrderId: req.GetOrderId(), Status: next}, nil
+}
+
+// transition переводит заказ в next, если текущий статус входит в from; иначе FailedPrecondition,
+// как у настоящего сервиса при недопустимом переходе.
+func (s *orderStore) transition(orderID string, next omsv1.OrderStatus, holdReason string, from ...omsv1.OrderStatus) (omsv1.OrderStatus, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	order, err := s.lookup(orderID)
+	if err != nil {
+		return 0, err
+	}
+	if !slices.Contains(from, order.GetStatus()) {
+		return 0, status.Errorf(codes.FailedPrecondition, "cannot move order from %s to %s", order.GetStatus(), next)
+	}
+	if next == omsv1.OrderStatus_ORDER_STATUS_ON_HOLD {
+		s.beforeHold[orderID] = order.GetStatus()
+	} else {
+		delete(s.beforeHold, orderID)
+	}
+	order.Status = next
+	order.HoldReason = holdReason
+	order.Version++
+	return next, nil
+}
+
+func (s *orderStore) lookup(orderID string) (*omsv1.Order, error) {
+	if orderID == "" {
+		return nil, status.Error(codes.InvalidArgument, "order_id is required")
+	}
+	order, ok := s.orders[orderID]
+	if !ok {
+		return nil, status.Error(codes.NotFound, "order not found")
+	}
+	return order, nil
+}
+
+func cloneOrder(order *omsv1.Order) *omsv1.Order {
+	return proto.Clone(order).(*omsv1.Order)
+}
+
+// injector применяет сценарий до обработчика: задержку, профиль отказов и скриптованные ответы.
+type injector struct {
+	scenario *scenario
+
+	mu  sync.Mutex
+	rng *rand.Rand
+	// sleep подменяется в тестах.
+	sleep func(ctx context.Context, d time.Duration) error
+}
+
+func newInjector(sc *scenario, seed int64) *injector {
+	if seed == 0 {
+		seed = time.Now().UnixNano()
+	}
+	return &injector{
+		scenario: sc,
+		rng:      rand.New(rand.NewPCG(uint64(seed), uint64(seed))), // #nosec G115 G404 -- детерминированный генератор для воспроизводимых сценариев.
+		sleep:    sleepContext,
+	}
+}
+
+func (i *injector) unary(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+	plan := i.scenario.plan(path.Base(info.FullMethod))
+	if err := i.delayOrFail(ctx, plan); err != nil {
+		return nil, err
+	}
+	if message, ok := req.(proto.Message); ok {
+		for _, rule := range plan.rules {
+			if !rule.take(message) {
+				continue
+			}
+			if rule.err != nil {
+				return nil, rule.err
+			}
+			return proto.Clone(rule.response), nil
+		}
+	}
+	return handler(ctx, req)
+}
+
+// stream применяет к потоковым методам задержку перед открытием, отказы и скриптованные
+// ошибки; match для них не проверяется, потому что запрос ещё не прочитан.
+func (i *injector) stream(srv any, stream grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+	plan := i.scenario.plan(path.Base(info.FullMethod))
+	if err := i.delayOrFail(stream.Context(), plan); err != nil {
+		return err
+	}
+	for _, rule := range plan.rules {
+		if len(rule.match) == 0 && rule.take(nil) {
+			return rule.err
+		}
+	}
+	return handler(srv, stream)
+}
+
+func (i *injector) delayOrFail(ctx context.Context, plan *methodPlan) error {
+	i.mu.Lock()
+	unavailable := plan.faults.UnavailableRate > 0 && i.rng.Float64() < plan.faults.UnavailableRate
+	delay := plan.latency
+	if plan.jitter > 0 {
+		delay += time.Duration(i.rng.Int64N(int64(plan.jitter) + 1))
+	}
+	if plan.faults.SlowRate > 0 && i.rng.Float64() < plan.faults.SlowRate {
+		delay += plan.faults.SlowLatency
+	}
+	i.mu.Unlock()
+
+	if unavailable {
+		return status.Error(codes.Unavailable, "oms-mock: injected unavailable fault")
+	}
+	if delay > 0 {
+		if err := i.sleep(ctx, delay); err != nil {
+			return status.FromContextError(err).Err()
+		}
+	}
+	return nil
+}
+
+func sleepContext(ctx context.Context, d time.Duration) error {
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
@@ -0,0 +1,252 @@
+package main
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"path/filepath"
+	"strconv"
+	"strings"
+)
+
+type inputFormat string
+
+const (
+	formatAuto  inputFormat = "auto"
+	formatCSV   inputFormat = "csv"
+	formatJSONL inputFormat = "jsonl"
+)
+
+// maxJSONLineSize ограничивает длину одной строки JSONL (заказ с большим числом позиций).
+const maxJSONLineSize = 4 << 20
+
+type importItem struct {
+	SKU        string `json:"sku"`
+	Qty        int32  `json:"qty"`
+	PriceMinor int64  `json:"price_minor"`
+}
+
+// importRecord — один заказ из входного файла.
+type importRecord struct {
+	// Line — номер строки, с которой начинается заказ (с 1); по нему работает -resume-from-line.
+	Line       int          `json:"-"`
+	ExternalID string       `json:"external_id"`
+	CustomerID string       `json:"customer_id"`
+	Currency   string       `json:"currency"`
+	Items      []importItem `json:"items"`
+	// AmountMinor — сумма заказа в legacy-системе; если задана, должна совпасть с суммой позиций.
+	AmountMinor *int64 `json:"amount_minor,omitempty"`
+
+	// err — ошибка разбора или валидации; такой заказ не отправляется и попадает в failures.
+	err error
+}
+
+func detectFormat(path string, format inputFormat) (inputFormat, error) {
+	if format != formatAuto {
+		if format != formatCSV && format != formatJSONL {
+			return "", fmt.Errorf("unsupported format %q (csv|jsonl)", format)
+		}
+		return format, nil
+	}
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".csv":
+		return formatCSV, nil
+	case ".json", ".jsonl", ".ndjson":
+		return formatJSONL, nil
+	default:
+		return "", fmt.Errorf("cannot detect format of %q, use -format", path)
+	}
+}
+
+func readRecords(r io.Reader, format inputFormat) ([]importRecord, error) {
+	var (
+		records []importRecord
+		err     error
+	)
+	switch format {
+	case formatCSV:
+		records, err = readCSV(r)
+	case formatJSONL:
+		records, err = readJSONL(r)
+	default:
+		return nil, fmt.Errorf("unsupported format %q", format)
+	}
+	if err != nil {
+		return nil, err
+	}
+	for i := range records {
+		if records[i].err == nil {
+			records[i].err = records[i].validate()
+		}
+	}
+	return records, nil
+}
+
+// readJSONL читает по одному заказу в строке; пустые строки пропускаются.
+func readJSONL(r io.Reader) ([]importRecord, error) {
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 0, 64<<10), maxJSONLineSize)
+
+	var records []importRecord
+	line := 0
+	for scanner.Scan() {
+		line++
+		raw := bytes.TrimSpace(scanner.Bytes())
+		if len(raw) == 0 {
+			continue
+		}
+		var record importRecord
+		decoder := json.NewDecoder(bytes.NewReader(raw))
+		decoder.DisallowUnknownFields()
+		if err := decoder.Decode(&record); err != nil {
+			record = importRecord{err: fmt.Errorf("decode json: %w", err)}
+		}
+		record.Line = line
+		records = append(records, record)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("read jsonl: %w", err)
+	}
+	return records, nil
+}
+
+var requiredCSVColumns = []string{"customer_id", "currency", "sku", "qty", "price_minor"}
+
+// readCSV читает файл с заголовком; каждая строка — позиция заказа. Идущие подряд строки
+// с одинаковым external_id объединяются в один заказ, строка без external_id — отдельный заказ.
+func readCSV(r io.Reader) ([]importRecord, error) {
+	reader := csv.NewReader(r)
+	reader.FieldsPerRecord = -1
+	reader.TrimLeadingSpace = true
+
+	header, err := reader.Read()
+	if err != nil {
+		return nil, fmt.Errorf("read csv header: %w", err)
+	}
+	columns := make(map[string]int, len(header))
+	for i, name := range header {
+		columns[strings.ToLower(strings.TrimSpace(name))] = i
+	}
+	for _, name := range requiredCSVColumns {
+		if _, ok := columns[name]; !ok {
+			return nil, fmt.Errorf("csv header: missing column %q", name)
+		}
+	}
+	field := func(row []string, name string) string {
+		idx, ok := columns[name]
+		if !ok || idx >= len(row) {
+			return ""
+		}
+		return strings.TrimSpace(row[idx])
+	}
+
+	var records []importRecord
+	for {
+		row, err := reader.Read()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			var parseErr *csv.ParseError
+			if !errors.As(err, &parseErr) {
+				return nil, fmt.Errorf("read csv: %w", err)
+			}
+			records = append(records, importRecord{Line: parseErr.StartLine, err: err})
+			continue
+		}
+		line, _ := reader.FieldPos(0)
+
+		externalID := field(row, "external_id")
+		var amount *int64
+		if raw := field(row, "amount_minor"); raw != "" {
+			value, parseErr := strconv.ParseInt(raw, 10, 64)
+			if parseErr != nil {
+				records = append(records, importRecord{Line: line, ExternalID: externalID, err: fmt.Errorf("amount_minor: %w", parseErr)})
+				continue
+			}
+			amount = &value
+		}
+		item, itemErr := parseCSVItem(field(row, "sku"), field(row, "qty"), field(row, "price_minor"))
+
+		if last := len(records) - 1; externalID != "" && last >= 0 && records[last].ExternalID == externalID {
+			current := &records[last]
+			if current.err != nil {
+				continue
+			}
+			switch {
+			case itemErr != nil:
+				current.err = fmt.Errorf("line %d: %w", line, itemErr)
+			case current.CustomerID != field(row, "customer_id") || current.Currency != field(row, "currency"):
+				current.err = fmt.Errorf("line %d: customer_id/currency differ within order %s", line, externalID)
+			case !sameAmount(current.AmountMinor, amount):
+				current.err = fmt.Errorf("line %d: amount_minor differs within order %s", line, externalID)
+			default:
+				current.Items = append(current.Items, item)
+			}
+			continue
+		}
+
+		record := importRecord{
+			Line:        line,
+			ExternalID:  externalID,
+			CustomerID:  field(row, "customer_id"),
+			Currency:    field(row, "currency"),
+			AmountMinor: amount,
+			err:         itemErr,
+		}
+		if itemErr == nil {
+			record.Items = []importItem{item}
+		}
+		records = append(records, record)
+	}
+	return records, nil
+}
+
+func parseCSVItem(sku, qtyRaw, priceRaw string) (importItem, error) {
+	qty, err := strconv.ParseInt(qtyRaw, 10, 32)
+	if err != nil {
+		return importItem{}, fmt.Errorf("qty: %w", err)
+	}
+	price, err := strconv.ParseInt(priceRaw, 10, 64)
+	if err != nil {
+		return importItem{}, fmt.Errorf("price_minor: %w", err)
+	}
+	return importItem{SKU: sku, Qty: int32(qty), PriceMinor: price}, nil
+}
+
+func sameAmount(a, b *int64) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+	return *a == *b
+}
+
+// validate повторяет проверки CreateOrder локально, чтобы заведомо битые строки не расходовали RPC.
+func (r importRecord) validate() error {
+	switch {
+	case r.CustomerID == "":
+		return errors.New("customer_id is required")
+	case r.Currency == "":
+		return errors.New("currency is required")
+	case len(r.Items) == 0:
+		return errors.New("order must contain at least one item")
+	}
+	var sum int64
+	for idx, item := range r.Items {
+		if item.Qty <= 0 {
+			return fmt.Errorf("item[%d].qty must be > 0", idx)
+		}
+		if item.PriceMinor < 0 {
+			return fmt.Errorf("item[%d].price_minor must be >= 0", idx)
+		}
+		sum += int64(item.Qty) * item.PriceMinor
+	}
+	if r.AmountMinor != nil && *r.AmountMinor != sum {
+		return fmt.Errorf("amount_minor %d does not match items sum %d", *r.AmountMinor, sum)
+	}
+	return nil
+}
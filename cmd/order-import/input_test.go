@@ -0,0 +1,75 @@
+package main
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestReadCSV_GroupsRowsByExternalID(t *testing.T) {
+	input := `external_id,customer_id,currency,sku,qty,price_minor,amount_minor
+legacy-1,c-1,USD,sku-a,2,100,350
+legacy-1,c-1,USD,sku-b,1,150,350
+,c-2,USD,sku-c,1,500,
+legacy-2,c-3,USD,sku-d,0,100,
+legacy-3,c-4,USD,sku-e,1,100,999
+`
+	records, err := readRecords(strings.NewReader(input), formatCSV)
+	if err != nil {
+		t.Fatalf("read csv: %v", err)
+	}
+	if len(records) != 4 {
+		t.Fatalf("expected 4 orders, got %d", len(records))
+	}
+
+	first := records[0]
+	if first.Line != 2 || first.ExternalID != "legacy-1" || len(first.Items) != 2 || first.err != nil {
+		t.Fatalf("unexpected grouped order: %+v", first)
+	}
+	if records[1].Line != 4 || records[1].err != nil {
+		t.Fatalf("row without external_id must be a separate order: %+v", records[1])
+	}
+	if records[2].err == nil || !strings.Contains(records[2].err.Error(), "qty") {
+		t.Fatalf("expected qty validation error, got %v", records[2].err)
+	}
+	if records[3].err == nil || !strings.Contains(records[3].err.Error(), "does not match items sum") {
+		t.Fatalf("expected amount mismatch, got %v", records[3].err)
+	}
+}
+
+func TestReadCSV_MissingColumn(t *testing.T) {
+	if _, err := readRecords(strings.NewReader("customer_id,currency,sku,qty\n"), formatCSV); err == nil {
+		t.Fatal("expected missing column error")
+	}
+}
+
+func TestReadJSONL(t *testing.T) {
+	input := `{"external_id":"legacy-1","customer_id":"c-1","currency":"USD","items":[{"sku":"a","qty":1,"price_minor":100}],"amount_minor":100}
+
+{"customer_id":"c-2","currency":"USD","items":[],"unknown":1}
+`
+	records, err := readRecords(strings.NewReader(input), formatJSONL)
+	if err != nil {
+		t.Fatalf("read jsonl: %v", err)
+	}
+	if len(records) != 2 {
+		t.Fatalf("expected 2 records, got %d", len(records))
+	}
+	if records[0].Line != 1 || records[0].err != nil || records[0].Items[0].PriceMinor != 100 {
+		t.Fatalf("unexpected record: %+v", records[0])
+	}
+	if records[1].Line != 3 || records[1].err == nil {
+		t.Fatalf("expected decode error on line 3, got %+v", records[1])
+	}
+}
+
+func TestDetectFormat(t *testing.T) {
+	if format, err := detectFormat("orders.CSV", formatAuto); err != nil || format != formatCSV {
+		t.Fatalf("unexpected format: %q, %v", format, err)
+	}
+	if format, err := detectFormat("orders.ndjson", formatAuto); err != nil || format != formatJSONL {
+		t.Fatalf("unexpected format: %q, %v", format, err)
+	}
+	if _, err := detectFormat("orders.txt", formatAuto); err == nil {
+		t.Fatal("expected detection error")
+	}
+}
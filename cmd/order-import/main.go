@@ -0,0 +1,424 @@
+package main
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/csv"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"os/signal"
+	"strconv"
+	"strings"
+	"sync"
+	"syscall"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+
+	"github.com/vladislavdragonenkov/oms/internal/ctxutil"
+	omsv1 "github.com/vladislavdragonenkov/oms/proto/oms/v1"
+)
+
+const (
+	idempotencyHeader = "idempotency-key"
+
+	defaultAddr        = "localhost:50051"
+	defaultOutput      = "order-import-result.csv"
+	defaultConcurrency = 4
+	defaultTimeout     = 10 * time.Second
+	defaultRetries     = 3
+	defaultRetryDelay  = 500 * time.Millisecond
+	defaultKeyPrefix   = "order-import"
+)
+
+const (
+	resultCreated   = "created"
+	resultFailed    = "failed"
+	resultValidated = "validated"
+)
+
+type config struct {
+	addr           string
+	inputPath      string
+	format         inputFormat
+	outputPath     string
+	concurrency    int
+	timeout        time.Duration
+	retries        int
+	retryDelay     time.Duration
+	resumeFromLine int
+	keyPrefix      string
+	dryRun         bool
+}
+
+// orderCreator — часть OrderServiceClient, нужная импорту.
+type orderCreator interface {
+	CreateOrder(ctx context.Context, in *omsv1.CreateOrderRequest, opts ...grpc.CallOption) (*omsv1.CreateOrderResponse, error)
+}
+
+type importResult struct {
+	Line       int
+	ExternalID string
+	Status     string
+	OrderID    string
+	Err        error
+}
+
+type summary struct {
+	Total     int `json:"total"`
+	Created   int `json:"created"`
+	Validated int `json:"validated,omitempty"`
+	Failed    int `json:"failed"`
+	Skipped   int `json:"skipped"`
+	// ResumeFromLine — значение для -resume-from-line, если импорт прерван; 0 — всё обработано.
+	ResumeFromLine int `json:"resume_from_line,omitempty"`
+}
+
+func main() {
+	log.SetFormatter(&log.TextFormatter{FullTimestamp: true})
+	log.SetLevel(log.InfoLevel)
+
+	cfg, err := readConfig(os.Args[1:])
+	if err != nil {
+		fail("%v", err)
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	if err := run(ctx, cfg); err != nil {
+		fail("order import failed: %v", err)
+	}
+}
+
+func readConfig(args []string) (config, error) {
+	cfg := config{}
+	var format string
+
+	fs := flag.NewFlagSet("order-import", flag.ContinueOnError)
+	fs.StringVar(&cfg.addr, "addr", defaultAddr, "OrderService gRPC address")
+	fs.StringVar(&cfg.inputPath, "input", "", "CSV or JSONL file with orders")
+	fs.StringVar(&format, "format", string(formatAuto), "input format: auto|csv|jsonl")
+	fs.StringVar(&cfg.outputPath, "output", defaultOutput, "result CSV: line, external_id, status, order_id, error")
+	fs.IntVar(&cfg.concurrency, "concurrency", defaultConcurrency, "number of parallel CreateOrder calls")
+	fs.DurationVar(&cfg.timeout, "timeout", defaultTimeout, "timeout per CreateOrder call")
+	fs.IntVar(&cfg.retries, "retries", defaultRetries, "retries for transient gRPC errors (same idempotency key)")
+	fs.DurationVar(&cfg.retryDelay, "retry-delay", defaultRetryDelay, "base delay between retries")
+	fs.IntVar(&cfg.resumeFromLine, "resume-from-line", 0, "skip orders starting before this input line")
+	fs.StringVar(&cfg.keyPrefix, "key-prefix", defaultKeyPrefix, "prefix of generated idempotency keys")
+	fs.BoolVar(&cfg.dryRun, "dry-run", false, "validate input without calling the API")
+	if err := fs.Parse(args); err != nil {
+		return config{}, err
+	}
+	cfg.format = inputFormat(strings.ToLower(strings.TrimSpace(format)))
+
+	switch {
+	case strings.TrimSpace(cfg.inputPath) == "":
+		return config{}, errors.New("input is required")
+	case strings.TrimSpace(cfg.outputPath) == "":
+		return config{}, errors.New("output is required")
+	case !cfg.dryRun && strings.TrimSpace(cfg.addr) == "":
+		return config{}, errors.New("addr is required")
+	case cfg.concurrency <= 0:
+		return config{}, errors.New("concurrency must be > 0")
+	case cfg.timeout <= 0:
+		return config{}, errors.New("timeout must be > 0")
+	case cfg.retries < 0:
+		return config{}, errors.New("retries must be >= 0")
+	case cfg.retryDelay < 0:
+		return config{}, errors.New("retry-delay must be >= 0")
+	case cfg.resumeFromLine < 0:
+		return config{}, errors.New("resume-from-line must be >= 0")
+	case strings.TrimSpace(cfg.keyPrefix) == "":
+		return config{}, errors.New("key-prefix is required")
+	}
+	if _, err := detectFormat(cfg.inputPath, cfg.format); err != nil {
+		return config{}, err
+	}
+	return cfg, nil
+}
+
+func run(ctx context.Context, cfg config) error {
+	format, err := detectFormat(cfg.inputPath, cfg.format)
+	if err != nil {
+		return err
+	}
+	input, err := os.Open(cfg.inputPath)
+	if err != nil {
+		return fmt.Errorf("open input: %w", err)
+	}
+	records, err := readRecords(input, format)
+	_ = input.Close()
+	if err != nil {
+		return err
+	}
+
+	output, err := openResultWriter(cfg.outputPath, cfg.resumeFromLine > 0)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if closeErr := output.Close(); closeErr != nil {
+			log.WithError(closeErr).Warn("failed to close result file")
+		}
+	}()
+
+	var client orderCreator
+	if !cfg.dryRun {
+		conn, err := grpc.NewClient(cfg.addr, grpc.WithTransportCredentials(insecure.NewCredentials()))
+		if err != nil {
+			return fmt.Errorf("create grpc client: %w", err)
+		}
+		defer func() { _ = conn.Close() }()
+		client = omsv1.NewOrderServiceClient(conn)
+	}
+
+	log.WithFields(log.Fields{
+		"input":            cfg.inputPath,
+		"format":           format,
+		"orders":           len(records),
+		"resume_from_line": cfg.resumeFromLine,
+		"dry_run":          cfg.dryRun,
+	}).Info("starting order import")
+
+	result := runImport(ctx, cfg, client, records, output)
+	encoded, _ := json.Marshal(result)
+	fmt.Println(string(encoded))
+
+	if result.ResumeFromLine > 0 {
+		return fmt.Errorf("import interrupted, rerun with -resume-from-line %d", result.ResumeFromLine)
+	}
+	if err := output.Err(); err != nil {
+		return fmt.Errorf("write results: %w", err)
+	}
+	return nil
+}
+
+// runImport отправляет заказы с line >= cfg.resumeFromLine в cfg.concurrency потоков.
+// Ключи идемпотентности детерминированы, поэтому повторный запуск по тем же строкам не
+// создаёт дублей, а возвращает уже созданные заказы.
+func runImport(ctx context.Context, cfg config, client orderCreator, records []importRecord, output resultSink) summary {
+	var result summary
+	jobs := make(chan importRecord)
+	pending := make(map[int]struct{})
+	var mu sync.Mutex
+
+	var wg sync.WaitGroup
+	for range cfg.concurrency {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for record := range jobs {
+				res := importOne(ctx, cfg, client, record)
+				if res.Status == "" {
+					// Контекст отменён до ответа: строка остаётся в pending для resume.
+					continue
+				}
+				output.Write(res)
+
+				mu.Lock()
+				delete(pending, record.Line)
+				switch res.Status {
+				case resultCreated:
+					result.Created++
+				case resultValidated:
+					result.Validated++
+				default:
+					result.Failed++
+				}
+				mu.Unlock()
+			}
+		}()
+	}
+
+dispatch:
+	for _, record := range records {
+		if record.Line < cfg.resumeFromLine {
+			result.Skipped++
+			continue
+		}
+		mu.Lock()
+		result.Total++
+		pending[record.Line] = struct{}{}
+		mu.Unlock()
+
+		select {
+		case jobs <- record:
+		case <-ctx.Done():
+			break dispatch
+		}
+	}
+	close(jobs)
+	wg.Wait()
+
+	// Первая незавершённая строка; обработанные после неё строки при resume отправятся
+	// повторно с теми же ключами и вернут уже созданные заказы.
+	for _, record := range records {
+		if _, ok := pending[record.Line]; ok {
+			result.ResumeFromLine = record.Line
+			break
+		}
+	}
+	return result
+}
+
+func importOne(ctx context.Context, cfg config, client orderCreator, record importRecord) importResult {
+	res := importResult{Line: record.Line, ExternalID: record.ExternalID}
+	if record.err != nil {
+		res.Status, res.Err = resultFailed, record.err
+		return res
+	}
+	if cfg.dryRun {
+		res.Status = resultValidated
+		return res
+	}
+
+	request := buildRequest(record)
+	callCtx := metadata.AppendToOutgoingContext(ctx, idempotencyHeader, idempotencyKey(cfg.keyPrefix, record))
+	for attempt := 0; ; attempt++ {
+		attemptCtx, cancel := context.WithTimeout(callCtx, cfg.timeout)
+		resp, err := client.CreateOrder(attemptCtx, request)
+		cancel()
+		if err == nil {
+			res.Status, res.OrderID = resultCreated, resp.GetOrder().GetId()
+			return res
+		}
+		if ctx.Err() != nil {
+			return importResult{}
+		}
+		if attempt >= cfg.retries || !isRetryable(err) {
+			res.Status, res.Err = resultFailed, err
+			return res
+		}
+		if ctxutil.Sleep(ctx, cfg.retryDelay*time.Duration(attempt+1)) != nil {
+			return importResult{}
+		}
+	}
+}
+
+func buildRequest(record importRecord) *omsv1.CreateOrderRequest {
+	items := make([]*omsv1.OrderItem, 0, len(record.Items))
+	for _, item := range record.Items {
+		items = append(items, &omsv1.OrderItem{
+			Sku:   item.SKU,
+			Qty:   item.Qty,
+			Price: &omsv1.Money{Currency: record.Currency, AmountMinor: item.PriceMinor},
+		})
+	}
+	return &omsv1.CreateOrderRequest{
+		CustomerId: record.CustomerID,
+		Currency:   record.Currency,
+		Items:      items,
+	}
+}
+
+// idempotencyKey строится из external_id, а для заказов без него — из содержимого и номера строки.
+func idempotencyKey(prefix string, record importRecord) string {
+	var source string
+	if record.ExternalID != "" {
+		source = "external:" + record.ExternalID
+	} else {
+		encoded, _ := json.Marshal(record)
+		source = "line:" + strconv.Itoa(record.Line) + ":" + string(encoded)
+	}
+	sum := sha256.Sum256([]byte(source))
+	return prefix + "-" + hex.EncodeToString(sum[:16])
+}
+
+func isRetryable(err error) bool {
+	switch status.Code(err) {
+	case codes.Unavailable, codes.DeadlineExceeded, codes.ResourceExhausted, codes.Aborted:
+		return true
+	default:
+		return false
+	}
+}
+
+// resultSink принимает результаты импорта; реализация должна быть потокобезопасной.
+type resultSink interface {
+	Write(importResult)
+}
+
+// resultWriter пишет результаты в CSV и сбрасывает каждую строку на диск, чтобы
+// после аварийного завершения файл отражал фактический прогресс.
+type resultWriter struct {
+	mu     sync.Mutex
+	file   io.WriteCloser
+	writer *csv.Writer
+	err    error
+}
+
+var resultHeader = []string{"line", "external_id", "status", "order_id", "error"}
+
+func openResultWriter(path string, appendMode bool) (*resultWriter, error) {
+	flags := os.O_CREATE | os.O_WRONLY | os.O_TRUNC
+	if appendMode {
+		flags = os.O_CREATE | os.O_WRONLY | os.O_APPEND
+	}
+	file, err := os.OpenFile(path, flags, 0o644)
+	if err != nil {
+		return nil, fmt.Errorf("open result file: %w", err)
+	}
+	info, err := file.Stat()
+	if err != nil {
+		_ = file.Close()
+		return nil, fmt.Errorf("stat result file: %w", err)
+	}
+
+	w := &resultWriter{file: file, writer: csv.NewWriter(file)}
+	if info.Size() == 0 {
+		w.writeRow(resultHeader)
+	}
+	return w, w.err
+}
+
+func (w *resultWriter) Write(res importResult) {
+	errText := ""
+	if res.Err != nil {
+		errText = res.Err.Error()
+		if st, ok := status.FromError(res.Err); ok {
+			errText = st.Code().String() + ": " + st.Message()
+		}
+	}
+	w.writeRow([]string{strconv.Itoa(res.Line), res.ExternalID, res.Status, res.OrderID, errText})
+}
+
+func (w *resultWriter) writeRow(row []string) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	if w.err != nil {
+		return
+	}
+	if err := w.writer.Write(row); err != nil {
+		w.err = err
+		return
+	}
+	w.writer.Flush()
+	w.err = w.writer.Error()
+}
+
+// Err возвращает первую ошибку записи.
+func (w *resultWriter) Err() error {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	return w.err
+}
+
+func (w *resultWriter) Close() error {
+	return w.file.Close()
+}
+
+func fail(format string, args ...any) {
+	_, _ = fmt.Fprintf(os.Stderr, format+"\n", args...)
+	os.Exit(1)
+}
@@ -0,0 +1,179 @@
+package main
+
+import (
+	"context"
+	"encoding/csv"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+
+	omsv1 "github.com/vladislavdragonenkov/oms/proto/oms/v1"
+)
+
+type fakeCreator struct {
+	mu       sync.Mutex
+	keys     []string
+	failures map[string]int // customer_id -> число Unavailable перед успехом
+	reject   map[string]bool
+}
+
+func (f *fakeCreator) CreateOrder(ctx context.Context, in *omsv1.CreateOrderRequest, _ ...grpc.CallOption) (*omsv1.CreateOrderResponse, error) {
+	md, _ := metadata.FromOutgoingContext(ctx)
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.keys = append(f.keys, md.Get(idempotencyHeader)[0])
+	if f.reject[in.CustomerId] {
+		return nil, status.Error(codes.InvalidArgument, "rejected")
+	}
+	if f.failures[in.CustomerId] > 0 {
+		f.failures[in.CustomerId]--
+		return nil, status.Error(codes.Unavailable, "try again")
+	}
+	return &omsv1.CreateOrderResponse{Order: &omsv1.Order{Id: "order-" + in.CustomerId}}, nil
+}
+
+type memorySink struct {
+	mu      sync.Mutex
+	results []importResult
+}
+
+func (s *memorySink) Write(res importResult) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.results = append(s.results, res)
+}
+
+func testRecords() []importRecord {
+	item := []importItem{{SKU: "sku", Qty: 1, PriceMinor: 100}}
+	return []importRecord{
+		{Line: 2, ExternalID: "legacy-1", CustomerID: "c-1", Currency: "USD", Items: item},
+		{Line: 3, ExternalID: "legacy-2", CustomerID: "c-2", Currency: "USD", Items: item},
+		{Line: 4, ExternalID: "legacy-3", CustomerID: "c-3", Currency: "USD", Items: item},
+	}
+}
+
+func testConfig() config {
+	return config{concurrency: 2, timeout: time.Second, retries: 2, keyPrefix: "import"}
+}
+
+func TestRunImport_RetriesAndReportsFailures(t *testing.T) {
+	client := &fakeCreator{failures: map[string]int{"c-1": 2}, reject: map[string]bool{"c-3": true}}
+	sink := &memorySink{}
+
+	result := runImport(context.Background(), testConfig(), client, testRecords(), sink)
+	if result.Total != 3 || result.Created != 2 || result.Failed != 1 || result.ResumeFromLine != 0 {
+		t.Fatalf("unexpected summary: %+v", result)
+	}
+	// c-1: два Unavailable и успех с тем же ключом.
+	keys := map[string]int{}
+	for _, key := range client.keys {
+		keys[key]++
+	}
+	if keys[idempotencyKey("import", testRecords()[0])] != 3 {
+		t.Fatalf("expected retries with the same idempotency key, got %v", keys)
+	}
+	for _, res := range sink.results {
+		if res.Line == 4 && (res.Status != resultFailed || status.Code(res.Err) != codes.InvalidArgument) {
+			t.Fatalf("expected rejected order to be reported as failure: %+v", res)
+		}
+	}
+}
+
+func TestRunImport_ResumeAndInvalidRecords(t *testing.T) {
+	records := testRecords()
+	records[2].Items = nil
+	records[2].err = records[2].validate()
+
+	cfg := testConfig()
+	cfg.resumeFromLine = 3
+	client := &fakeCreator{}
+	result := runImport(context.Background(), cfg, client, records, &memorySink{})
+	if result.Skipped != 1 || result.Created != 1 || result.Failed != 1 {
+		t.Fatalf("unexpected summary: %+v", result)
+	}
+	if len(client.keys) != 1 {
+		t.Fatalf("invalid record must not reach the API, calls=%d", len(client.keys))
+	}
+}
+
+func TestRunImport_CanceledReportsResumeLine(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	result := runImport(ctx, testConfig(), &fakeCreator{}, testRecords(), &memorySink{})
+	if result.ResumeFromLine == 0 {
+		t.Fatalf("expected resume line after cancellation, got %+v", result)
+	}
+}
+
+func TestIdempotencyKeyIsDeterministic(t *testing.T) {
+	records := testRecords()
+	if idempotencyKey("p", records[0]) != idempotencyKey("p", records[0]) {
+		t.Fatal("expected stable key")
+	}
+	if idempotencyKey("p", records[0]) == idempotencyKey("p", records[1]) {
+		t.Fatal("expected different keys for different orders")
+	}
+
+	withoutID := records[0]
+	withoutID.ExternalID = ""
+	moved := withoutID
+	moved.Line = 10
+	if idempotencyKey("p", withoutID) == idempotencyKey("p", moved) {
+		t.Fatal("orders without external_id must be keyed by line")
+	}
+}
+
+func TestResultWriter_AppendKeepsSingleHeader(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "result.csv")
+	for i, appendMode := range []bool{false, true} {
+		w, err := openResultWriter(path, appendMode)
+		if err != nil {
+			t.Fatalf("open: %v", err)
+		}
+		w.Write(importResult{Line: i + 1, Status: resultCreated, OrderID: "o"})
+		if err := w.Close(); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	file, err := os.Open(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer file.Close()
+	rows, err := csv.NewReader(file).ReadAll()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(rows) != 3 || rows[0][0] != "line" || rows[2][0] != "2" {
+		t.Fatalf("unexpected result file: %v", rows)
+	}
+}
+
+func TestReadConfig(t *testing.T) {
+	cfg, err := readConfig([]string{"-input", "orders.csv", "-concurrency", "8", "-resume-from-line", "10"})
+	if err != nil {
+		t.Fatalf("read config: %v", err)
+	}
+	if cfg.concurrency != 8 || cfg.resumeFromLine != 10 || cfg.outputPath != defaultOutput {
+		t.Fatalf("unexpected config: %+v", cfg)
+	}
+	for _, args := range [][]string{
+		{},
+		{"-input", "orders.txt"},
+		{"-input", "orders.csv", "-concurrency", "0"},
+		{"-input", "orders.csv", "-format", "xml"},
+	} {
+		if _, err := readConfig(args); err == nil {
+			t.Fatalf("expected error for %v", args)
+		}
+	}
+}
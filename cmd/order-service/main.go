@@ -14,6 +14,16 @@ import (
 	log "github.com/sirupsen/logrus"
 
 	"github.com/vladislavdragonenkov/oms/internal/app"
+	"github.com/vladislavdragonenkov/oms/internal/featureflags"
+	"github.com/vladislavdragonenkov/oms/internal/logging"
+	"github.com/vladislavdragonenkov/oms/internal/messaging/kafka"
+	"github.com/vladislavdragonenkov/oms/internal/metrics"
+	"github.com/vladislavdragonenkov/oms/internal/metrics/otlp"
+	"github.com/vladislavdragonenkov/oms/internal/service/catalog"
+	grpcsvc "github.com/vladislavdragonenkov/oms/internal/service/grpc"
+	"github.com/vladislavdragonenkov/oms/internal/service/inventory"
+	"github.com/vladislavdragonenkov/oms/internal/slo"
+	"github.com/vladislavdragonenkov/oms/internal/storage/chaos"
 	"github.com/vladislavdragonenkov/oms/internal/version"
 )
 
@@ -21,6 +31,14 @@ const (
 	envLogLevel                    = "LOG_LEVEL"
 	envGRPCAddr                    = "OMS_GRPC_ADDR"
 	envMetricsAddr                 = "OMS_METRICS_ADDR"
+	envMetricsNamespace            = "OMS_METRICS_NAMESPACE"
+	envMetricsEnvironment          = "OMS_METRICS_ENVIRONMENT"
+	envMetricsRegion               = "OMS_METRICS_REGION"
+	envOTLPMetricsEndpoint         = "OMS_OTLP_METRICS_ENDPOINT"
+	envOTLPMetricsHeaders          = "OMS_OTLP_METRICS_HEADERS"
+	envOTLPMetricsInterval         = "OMS_OTLP_METRICS_INTERVAL"
+	envAdminUICredentials          = "OMS_ADMIN_UI_CREDENTIALS"
+	envAdminUICredentialsFile      = "OMS_ADMIN_UI_CREDENTIALS_FILE"
 	envStorageDriver               = "OMS_STORAGE_DRIVER"
 	envPostgresDSN                 = "OMS_POSTGRES_DSN"
 	envPostgresAutoMigrate         = "OMS_POSTGRES_AUTO_MIGRATE"
@@ -30,8 +48,66 @@ const (
 	envOutboxMaxAttempts           = "OMS_OUTBOX_MAX_ATTEMPTS"
 	envOutboxRetryDelay            = "OMS_OUTBOX_RETRY_DELAY"
 	envOutboxMaxPending            = "OMS_OUTBOX_MAX_PENDING"
+	envOutboxCleanupInterval       = "OMS_OUTBOX_CLEANUP_INTERVAL"
+	envOutboxSentRetention         = "OMS_OUTBOX_SENT_RETENTION"
 	envIdempotencyCleanupInterval  = "OMS_IDEMPOTENCY_CLEANUP_INTERVAL"
 	envIdempotencyCleanupBatchSize = "OMS_IDEMPOTENCY_CLEANUP_BATCH_SIZE"
+	envIdempotencyStaleProcessing  = "OMS_IDEMPOTENCY_STALE_PROCESSING_AFTER"
+	envInventoryReconcileInterval  = "OMS_INVENTORY_RECONCILE_INTERVAL"
+	envInventoryReconcileDryRun    = "OMS_INVENTORY_RECONCILE_DRY_RUN"
+	envInventoryRoutes             = "OMS_INVENTORY_ROUTES"
+	envAmountCheckInterval         = "OMS_AMOUNT_CHECK_INTERVAL"
+	envAmountCheckRepair           = "OMS_AMOUNT_CHECK_REPAIR"
+	envCanaryInterval              = "OMS_CANARY_INTERVAL"
+	envCanaryTimeout               = "OMS_CANARY_TIMEOUT"
+	envSagaTimeout                 = "OMS_SAGA_TIMEOUT"
+	envSagaHookTimeout             = "OMS_SAGA_HOOK_TIMEOUT"
+	envFeatureFlags                = "OMS_FEATURE_FLAGS"
+	envKafkaDLQPolicies            = "OMS_KAFKA_DLQ_POLICIES"
+	envKafkaTopicPrefix            = "OMS_KAFKA_TOPIC_PREFIX"
+	envKafkaKeyStrategies          = "OMS_KAFKA_KEY_STRATEGIES"
+	envKafkaCodecs                 = "OMS_KAFKA_CODECS"
+	envKafkaBreakerThreshold       = "OMS_KAFKA_PRODUCER_BREAKER_THRESHOLD"
+	envKafkaBreakerCooldown        = "OMS_KAFKA_PRODUCER_BREAKER_COOLDOWN"
+	envDependencyBreakerThreshold  = "OMS_DEPENDENCY_BREAKER_THRESHOLD"
+	envDependencyBreakerCooldown   = "OMS_DEPENDENCY_BREAKER_COOLDOWN"
+	envKafkaSagaEventBuffer        = "OMS_KAFKA_SAGA_EVENT_BUFFER"
+	envPaymentEventsParkTTL        = "OMS_PAYMENT_EVENTS_PARK_TTL"
+	envPaymentAuthorizationTTL     = "OMS_PAYMENT_AUTHORIZATION_TTL"
+	envPaymentAuthorizationCheck   = "OMS_PAYMENT_AUTHORIZATION_CHECK_INTERVAL"
+	envPaymentMaxReauthorizations  = "OMS_PAYMENT_MAX_REAUTHORIZATIONS"
+	envPaymentRetryMaxAttempts     = "OMS_PAYMENT_RETRY_MAX_ATTEMPTS"
+	envPaymentRetryBaseDelay       = "OMS_PAYMENT_RETRY_BASE_DELAY"
+	envPaymentRetryMaxDelay        = "OMS_PAYMENT_RETRY_MAX_DELAY"
+	envPaymentRetryInterval        = "OMS_PAYMENT_RETRY_INTERVAL"
+	envOrderListCacheTTL           = "OMS_ORDER_LIST_CACHE_TTL"
+	envOrderListCacheMaxCustomers  = "OMS_ORDER_LIST_CACHE_MAX_CUSTOMERS"
+	envDuplicateOrderWindow        = "OMS_DUPLICATE_ORDER_WINDOW"
+	envKafkaConsumerConcurrency    = "OMS_KAFKA_CONSUMER_CONCURRENCY"
+	envEventEncryptionKeys         = "OMS_EVENT_ENCRYPTION_KEYS"
+	envEventEncryptedFields        = "OMS_EVENT_ENCRYPTED_FIELDS"
+	envOrderQuotas                 = "OMS_ORDER_QUOTAS"
+	envApprovalCustomers           = "OMS_APPROVAL_CUSTOMERS"
+	envAdminTokens                 = "OMS_ADMIN_TOKENS"
+	envAdminTokensFile             = "OMS_ADMIN_TOKENS_FILE"
+	envCatalogPrices               = "OMS_CATALOG_PRICES"
+	envDevPersistPath              = "OMS_DEV_PERSIST_PATH"
+	envStorageChaos                = "OMS_STORAGE_CHAOS"
+	envLogLevels                   = "OMS_LOG_LEVELS"
+	envLogLevelsFile               = "OMS_LOG_LEVELS_FILE"
+	envTuningFile                  = "OMS_TUNING_FILE"
+	envGRPCLogSampleRate           = "OMS_GRPC_LOG_SAMPLE_RATE"
+	envGRPCSlowRequestThreshold    = "OMS_GRPC_SLOW_REQUEST_THRESHOLD"
+	envGRPCConcurrencyLimits       = "OMS_GRPC_CONCURRENCY_LIMITS"
+	envGRPCAdminEnabled            = "OMS_GRPC_ADMIN_ENABLED"
+	envScheduledCancelInterval     = "OMS_SCHEDULED_CANCEL_INTERVAL"
+	envSLOObjectives               = "OMS_SLO_OBJECTIVES"
+	envSLOInterval                 = "OMS_SLO_INTERVAL"
+	envSaturationInterval          = "OMS_SATURATION_INTERVAL"
+	envSaturationSagaLimit         = "OMS_SATURATION_SAGA_LIMIT"
+	envSaturationInFlightRPCLimit  = "OMS_SATURATION_INFLIGHT_RPC_LIMIT"
+	envListDefaultPageSize         = "OMS_LIST_DEFAULT_PAGE_SIZE"
+	envListMaxPageSize             = "OMS_LIST_MAX_PAGE_SIZE"
 )
 
 type configWarning struct {
@@ -82,6 +158,12 @@ func readConfigFromEnv(lookup envLookup) (app.Config, []configWarning) {
 	if v, ok := lookupEnvTrimmed(lookup, envMetricsAddr); ok {
 		cfg.MetricsAddr = v
 	}
+	if v, ok := lookupEnvTrimmed(lookup, envMetricsEnvironment); ok {
+		cfg.MetricsEnvironment = v
+	}
+	if v, ok := lookupEnvTrimmed(lookup, envMetricsRegion); ok {
+		cfg.MetricsRegion = v
+	}
 	if v, ok := lookupEnvTrimmed(lookup, envStorageDriver); ok {
 		cfg.StorageDriver = strings.ToLower(v)
 	}
@@ -91,6 +173,44 @@ func readConfigFromEnv(lookup envLookup) (app.Config, []configWarning) {
 
 	var warnings []configWarning
 
+	if raw, ok := lookupEnvTrimmed(lookup, envMetricsNamespace); ok {
+		if err := (metrics.Deployment{Namespace: raw}).Validate(); err != nil {
+			warnings = append(warnings, configWarning{env: envMetricsNamespace, value: raw, err: err})
+		} else {
+			cfg.MetricsNamespace = raw
+		}
+	}
+
+	if raw, ok := lookupEnvTrimmed(lookup, envOTLPMetricsEndpoint); ok {
+		if _, err := otlp.MetricsURL(raw); err != nil {
+			warnings = append(warnings, configWarning{env: envOTLPMetricsEndpoint, value: raw, err: err})
+		} else {
+			cfg.OTLPMetricsEndpoint = raw
+		}
+	}
+
+	// Заголовки разбираются при сборке приложения: в предупреждение попало бы значение с ключом API.
+	if raw, ok := lookupEnvTrimmed(lookup, envOTLPMetricsHeaders); ok {
+		cfg.OTLPMetricsHeaders = raw
+	}
+
+	// Как и заголовки OTLP, проверяется при сборке приложения: значение содержит пароль.
+	if raw, ok := lookupEnvTrimmed(lookup, envAdminUICredentials); ok {
+		cfg.AdminUICredentials = raw
+	}
+	if raw, ok := lookupEnvTrimmed(lookup, envAdminUICredentialsFile); ok {
+		cfg.AdminUICredentialsFile = raw
+	}
+
+	if raw, ok := lookupEnvTrimmed(lookup, envOTLPMetricsInterval); ok {
+		value, err := parseDuration(raw, func(d time.Duration) bool { return d > 0 }, "must be > 0")
+		if err != nil {
+			warnings = append(warnings, configWarning{env: envOTLPMetricsInterval, value: raw, err: err})
+		} else {
+			cfg.OTLPMetricsInterval = value
+		}
+	}
+
 	if raw, ok := lookupEnvTrimmed(lookup, envPostgresAutoMigrate); ok {
 		value, err := parseBool(raw)
 		if err != nil {
@@ -154,6 +274,24 @@ func readConfigFromEnv(lookup envLookup) (app.Config, []configWarning) {
 		}
 	}
 
+	if raw, ok := lookupEnvTrimmed(lookup, envOutboxCleanupInterval); ok {
+		value, err := parseDuration(raw, func(d time.Duration) bool { return d >= 0 }, "must be >= 0")
+		if err != nil {
+			warnings = append(warnings, configWarning{env: envOutboxCleanupInterval, value: raw, err: err})
+		} else {
+			cfg.OutboxCleanupInterval = value
+		}
+	}
+
+	if raw, ok := lookupEnvTrimmed(lookup, envOutboxSentRetention); ok {
+		value, err := parseDuration(raw, func(d time.Duration) bool { return d > 0 }, "must be > 0")
+		if err != nil {
+			warnings = append(warnings, configWarning{env: envOutboxSentRetention, value: raw, err: err})
+		} else {
+			cfg.OutboxSentRetention = value
+		}
+	}
+
 	if raw, ok := lookupEnvTrimmed(lookup, envIdempotencyCleanupInterval); ok {
 		value, err := parseDuration(raw, func(d time.Duration) bool { return d >= 0 }, "must be >= 0")
 		if err != nil {
@@ -172,6 +310,462 @@ func readConfigFromEnv(lookup envLookup) (app.Config, []configWarning) {
 		}
 	}
 
+	if raw, ok := lookupEnvTrimmed(lookup, envIdempotencyStaleProcessing); ok {
+		value, err := parseDuration(raw, func(d time.Duration) bool { return d >= 0 }, "must be >= 0")
+		if err != nil {
+			warnings = append(warnings, configWarning{env: envIdempotencyStaleProcessing, value: raw, err: err})
+		} else {
+			cfg.IdempotencyStaleProcessing = value
+		}
+	}
+
+	if raw, ok := lookupEnvTrimmed(lookup, envInventoryReconcileInterval); ok {
+		value, err := parseDuration(raw, func(d time.Duration) bool { return d >= 0 }, "must be >= 0")
+		if err != nil {
+			warnings = append(warnings, configWarning{env: envInventoryReconcileInterval, value: raw, err: err})
+		} else {
+			cfg.InventoryReconcileInterval = value
+		}
+	}
+
+	if raw, ok := lookupEnvTrimmed(lookup, envInventoryReconcileDryRun); ok {
+		value, err := parseBool(raw)
+		if err != nil {
+			warnings = append(warnings, configWarning{env: envInventoryReconcileDryRun, value: raw, err: err})
+		} else {
+			cfg.InventoryReconcileDryRun = value
+		}
+	}
+
+	if raw, ok := lookupEnvTrimmed(lookup, envInventoryRoutes); ok {
+		if _, err := inventory.ParseRoutes(raw); err != nil {
+			warnings = append(warnings, configWarning{env: envInventoryRoutes, value: raw, err: err})
+		} else {
+			cfg.InventoryRoutes = raw
+		}
+	}
+
+	if raw, ok := lookupEnvTrimmed(lookup, envAmountCheckInterval); ok {
+		value, err := parseDuration(raw, func(d time.Duration) bool { return d >= 0 }, "must be >= 0")
+		if err != nil {
+			warnings = append(warnings, configWarning{env: envAmountCheckInterval, value: raw, err: err})
+		} else {
+			cfg.AmountCheckInterval = value
+		}
+	}
+
+	if raw, ok := lookupEnvTrimmed(lookup, envAmountCheckRepair); ok {
+		value, err := parseBool(raw)
+		if err != nil {
+			warnings = append(warnings, configWarning{env: envAmountCheckRepair, value: raw, err: err})
+		} else {
+			cfg.AmountCheckRepair = value
+		}
+	}
+
+	if raw, ok := lookupEnvTrimmed(lookup, envCanaryInterval); ok {
+		value, err := parseDuration(raw, func(d time.Duration) bool { return d >= 0 }, "must be >= 0")
+		if err != nil {
+			warnings = append(warnings, configWarning{env: envCanaryInterval, value: raw, err: err})
+		} else {
+			cfg.CanaryInterval = value
+		}
+	}
+
+	if raw, ok := lookupEnvTrimmed(lookup, envCanaryTimeout); ok {
+		value, err := parseDuration(raw, func(d time.Duration) bool { return d > 0 }, "must be > 0")
+		if err != nil {
+			warnings = append(warnings, configWarning{env: envCanaryTimeout, value: raw, err: err})
+		} else {
+			cfg.CanaryTimeout = value
+		}
+	}
+
+	if raw, ok := lookupEnvTrimmed(lookup, envSagaTimeout); ok {
+		value, err := parseDuration(raw, func(d time.Duration) bool { return d > 0 }, "must be > 0")
+		if err != nil {
+			warnings = append(warnings, configWarning{env: envSagaTimeout, value: raw, err: err})
+		} else {
+			cfg.SagaTimeout = value
+		}
+	}
+
+	if raw, ok := lookupEnvTrimmed(lookup, envSagaHookTimeout); ok {
+		value, err := parseDuration(raw, func(d time.Duration) bool { return d > 0 }, "must be > 0")
+		if err != nil {
+			warnings = append(warnings, configWarning{env: envSagaHookTimeout, value: raw, err: err})
+		} else {
+			cfg.SagaHookTimeout = value
+		}
+	}
+
+	if raw, ok := lookupEnvTrimmed(lookup, envScheduledCancelInterval); ok {
+		value, err := parseDuration(raw, func(d time.Duration) bool { return d >= 0 }, "must be >= 0")
+		if err != nil {
+			warnings = append(warnings, configWarning{env: envScheduledCancelInterval, value: raw, err: err})
+		} else {
+			cfg.ScheduledCancelInterval = value
+		}
+	}
+
+	if raw, ok := lookupEnvTrimmed(lookup, envPaymentAuthorizationTTL); ok {
+		value, err := parseDuration(raw, func(d time.Duration) bool { return d > 0 }, "must be > 0")
+		if err != nil {
+			warnings = append(warnings, configWarning{env: envPaymentAuthorizationTTL, value: raw, err: err})
+		} else {
+			cfg.PaymentAuthorizationTTL = value
+		}
+	}
+
+	if raw, ok := lookupEnvTrimmed(lookup, envPaymentAuthorizationCheck); ok {
+		value, err := parseDuration(raw, func(d time.Duration) bool { return d >= 0 }, "must be >= 0")
+		if err != nil {
+			warnings = append(warnings, configWarning{env: envPaymentAuthorizationCheck, value: raw, err: err})
+		} else {
+			cfg.PaymentAuthorizationCheckInterval = value
+		}
+	}
+
+	if raw, ok := lookupEnvTrimmed(lookup, envPaymentMaxReauthorizations); ok {
+		value, err := parseInt(raw, func(n int) bool { return n >= 0 }, "must be >= 0")
+		if err != nil {
+			warnings = append(warnings, configWarning{env: envPaymentMaxReauthorizations, value: raw, err: err})
+		} else {
+			cfg.PaymentMaxReauthorizations = value
+		}
+	}
+
+	if raw, ok := lookupEnvTrimmed(lookup, envPaymentRetryMaxAttempts); ok {
+		value, err := parseInt(raw, func(n int) bool { return n >= 0 }, "must be >= 0")
+		if err != nil {
+			warnings = append(warnings, configWarning{env: envPaymentRetryMaxAttempts, value: raw, err: err})
+		} else {
+			cfg.PaymentRetryMaxAttempts = value
+		}
+	}
+
+	if raw, ok := lookupEnvTrimmed(lookup, envPaymentRetryBaseDelay); ok {
+		value, err := parseDuration(raw, func(d time.Duration) bool { return d > 0 }, "must be > 0")
+		if err != nil {
+			warnings = append(warnings, configWarning{env: envPaymentRetryBaseDelay, value: raw, err: err})
+		} else {
+			cfg.PaymentRetryBaseDelay = value
+		}
+	}
+
+	if raw, ok := lookupEnvTrimmed(lookup, envPaymentRetryMaxDelay); ok {
+		value, err := parseDuration(raw, func(d time.Duration) bool { return d > 0 }, "must be > 0")
+		if err != nil {
+			warnings = append(warnings, configWarning{env: envPaymentRetryMaxDelay, value: raw, err: err})
+		} else {
+			cfg.PaymentRetryMaxDelay = value
+		}
+	}
+
+	if raw, ok := lookupEnvTrimmed(lookup, envPaymentRetryInterval); ok {
+		value, err := parseDuration(raw, func(d time.Duration) bool { return d > 0 }, "must be > 0")
+		if err != nil {
+			warnings = append(warnings, configWarning{env: envPaymentRetryInterval, value: raw, err: err})
+		} else {
+			cfg.PaymentRetryInterval = value
+		}
+	}
+
+	if raw, ok := lookupEnvTrimmed(lookup, envOrderListCacheTTL); ok {
+		value, err := parseDuration(raw, func(d time.Duration) bool { return d >= 0 }, "must be >= 0")
+		if err != nil {
+			warnings = append(warnings, configWarning{env: envOrderListCacheTTL, value: raw, err: err})
+		} else {
+			cfg.OrderListCacheTTL = value
+		}
+	}
+
+	if raw, ok := lookupEnvTrimmed(lookup, envOrderListCacheMaxCustomers); ok {
+		value, err := parseInt(raw, func(n int) bool { return n > 0 }, "must be > 0")
+		if err != nil {
+			warnings = append(warnings, configWarning{env: envOrderListCacheMaxCustomers, value: raw, err: err})
+		} else {
+			cfg.OrderListCacheMaxCustomers = value
+		}
+	}
+
+	if raw, ok := lookupEnvTrimmed(lookup, envDuplicateOrderWindow); ok {
+		value, err := parseDuration(raw, func(d time.Duration) bool { return d >= 0 }, "must be >= 0")
+		if err != nil {
+			warnings = append(warnings, configWarning{env: envDuplicateOrderWindow, value: raw, err: err})
+		} else {
+			cfg.DuplicateOrderWindow = value
+		}
+	}
+
+	if raw, ok := lookupEnvTrimmed(lookup, envGRPCLogSampleRate); ok {
+		value, err := parseFloat(raw, func(f float64) bool { return f >= 0 && f <= 1 }, "must be in [0, 1]")
+		if err != nil {
+			warnings = append(warnings, configWarning{env: envGRPCLogSampleRate, value: raw, err: err})
+		} else {
+			cfg.GRPCLogSampleRate = value
+		}
+	}
+
+	if raw, ok := lookupEnvTrimmed(lookup, envGRPCSlowRequestThreshold); ok {
+		value, err := parseDuration(raw, func(d time.Duration) bool { return d >= 0 }, "must be >= 0")
+		if err != nil {
+			warnings = append(warnings, configWarning{env: envGRPCSlowRequestThreshold, value: raw, err: err})
+		} else {
+			cfg.GRPCSlowRequestThreshold = value
+		}
+	}
+
+	if raw, ok := lookupEnvTrimmed(lookup, envGRPCConcurrencyLimits); ok {
+		if _, err := grpcsvc.ParseConcurrencyLimits(raw); err != nil {
+			warnings = append(warnings, configWarning{env: envGRPCConcurrencyLimits, value: raw, err: err})
+		} else {
+			cfg.GRPCConcurrencyLimits = raw
+		}
+	}
+
+	if raw, ok := lookupEnvTrimmed(lookup, envGRPCAdminEnabled); ok {
+		value, err := parseBool(raw)
+		if err != nil {
+			warnings = append(warnings, configWarning{env: envGRPCAdminEnabled, value: raw, err: err})
+		} else {
+			cfg.GRPCAdminEnabled = value
+		}
+	}
+
+	if raw, ok := lookupEnvTrimmed(lookup, envSLOInterval); ok {
+		value, err := parseDuration(raw, func(d time.Duration) bool { return d > 0 }, "must be > 0")
+		if err != nil {
+			warnings = append(warnings, configWarning{env: envSLOInterval, value: raw, err: err})
+		} else {
+			cfg.SLOInterval = value
+		}
+	}
+
+	if raw, ok := lookupEnvTrimmed(lookup, envSaturationInterval); ok {
+		value, err := parseDuration(raw, func(d time.Duration) bool { return d >= 0 }, "must be >= 0")
+		if err != nil {
+			warnings = append(warnings, configWarning{env: envSaturationInterval, value: raw, err: err})
+		} else {
+			cfg.SaturationInterval = value
+		}
+	}
+
+	if raw, ok := lookupEnvTrimmed(lookup, envSaturationSagaLimit); ok {
+		value, err := parseInt(raw, func(v int) bool { return v > 0 }, "must be > 0")
+		if err != nil {
+			warnings = append(warnings, configWarning{env: envSaturationSagaLimit, value: raw, err: err})
+		} else {
+			cfg.SaturationSagaLimit = value
+		}
+	}
+
+	if raw, ok := lookupEnvTrimmed(lookup, envSaturationInFlightRPCLimit); ok {
+		value, err := parseInt(raw, func(v int) bool { return v > 0 }, "must be > 0")
+		if err != nil {
+			warnings = append(warnings, configWarning{env: envSaturationInFlightRPCLimit, value: raw, err: err})
+		} else {
+			cfg.SaturationInFlightRPCLimit = value
+		}
+	}
+
+	// Согласованность пары (default <= max) проверяет app.BuildApp.
+	if raw, ok := lookupEnvTrimmed(lookup, envListDefaultPageSize); ok {
+		value, err := parseInt(raw, func(v int) bool { return v > 0 }, "must be > 0")
+		if err != nil {
+			warnings = append(warnings, configWarning{env: envListDefaultPageSize, value: raw, err: err})
+		} else {
+			cfg.ListDefaultPageSize = value
+		}
+	}
+
+	if raw, ok := lookupEnvTrimmed(lookup, envListMaxPageSize); ok {
+		value, err := parseInt(raw, func(v int) bool { return v > 0 && v <= grpcsvc.PageSizeCeiling }, fmt.Sprintf("must be within [1, %d]", grpcsvc.PageSizeCeiling))
+		if err != nil {
+			warnings = append(warnings, configWarning{env: envListMaxPageSize, value: raw, err: err})
+		} else {
+			cfg.ListMaxPageSize = value
+		}
+	}
+
+	if raw, ok := lookupEnvTrimmed(lookup, envFeatureFlags); ok {
+		if _, err := featureflags.Parse(raw); err != nil {
+			warnings = append(warnings, configWarning{env: envFeatureFlags, value: raw, err: err})
+		} else {
+			cfg.FeatureFlags = raw
+		}
+	}
+
+	if raw, ok := lookupEnvTrimmed(lookup, envKafkaDLQPolicies); ok {
+		if _, err := kafka.ParseDLQPolicies(raw); err != nil {
+			warnings = append(warnings, configWarning{env: envKafkaDLQPolicies, value: raw, err: err})
+		} else {
+			cfg.KafkaDLQPolicies = raw
+		}
+	}
+
+	if raw, ok := lookupEnvTrimmed(lookup, envKafkaTopicPrefix); ok {
+		if _, err := kafka.NewTopicConfig(raw); err != nil {
+			warnings = append(warnings, configWarning{env: envKafkaTopicPrefix, value: raw, err: err})
+		} else {
+			cfg.KafkaTopicPrefix = raw
+		}
+	}
+
+	if raw, ok := lookupEnvTrimmed(lookup, envKafkaKeyStrategies); ok {
+		if _, err := kafka.DefaultTopicConfig().WithKeyStrategies(raw); err != nil {
+			warnings = append(warnings, configWarning{env: envKafkaKeyStrategies, value: raw, err: err})
+		} else {
+			cfg.KafkaKeyStrategies = raw
+		}
+	}
+	if raw, ok := lookupEnvTrimmed(lookup, envKafkaCodecs); ok {
+		if _, err := kafka.DefaultTopicConfig().WithCodecs(raw); err != nil {
+			warnings = append(warnings, configWarning{env: envKafkaCodecs, value: raw, err: err})
+		} else {
+			cfg.KafkaCodecs = raw
+		}
+	}
+
+	if raw, ok := lookupEnvTrimmed(lookup, envKafkaBreakerThreshold); ok {
+		value, err := parseInt(raw, func(v int) bool { return v >= 0 }, "must be >= 0")
+		if err != nil {
+			warnings = append(warnings, configWarning{env: envKafkaBreakerThreshold, value: raw, err: err})
+		} else {
+			cfg.KafkaProducerBreakerThreshold = value
+		}
+	}
+
+	if raw, ok := lookupEnvTrimmed(lookup, envKafkaBreakerCooldown); ok {
+		value, err := parseDuration(raw, func(d time.Duration) bool { return d > 0 }, "must be > 0")
+		if err != nil {
+			warnings = append(warnings, configWarning{env: envKafkaBreakerCooldown, value: raw, err: err})
+		} else {
+			cfg.KafkaProducerBreakerCooldown = value
+		}
+	}
+
+	if raw, ok := lookupEnvTrimmed(lookup, envDependencyBreakerThreshold); ok {
+		value, err := parseInt(raw, func(v int) bool { return v >= 0 }, "must be >= 0")
+		if err != nil {
+			warnings = append(warnings, configWarning{env: envDependencyBreakerThreshold, value: raw, err: err})
+		} else {
+			cfg.DependencyBreakerThreshold = value
+		}
+	}
+
+	if raw, ok := lookupEnvTrimmed(lookup, envDependencyBreakerCooldown); ok {
+		value, err := parseDuration(raw, func(d time.Duration) bool { return d > 0 }, "must be > 0")
+		if err != nil {
+			warnings = append(warnings, configWarning{env: envDependencyBreakerCooldown, value: raw, err: err})
+		} else {
+			cfg.DependencyBreakerCooldown = value
+		}
+	}
+
+	if raw, ok := lookupEnvTrimmed(lookup, envKafkaSagaEventBuffer); ok {
+		value, err := parseInt(raw, func(v int) bool { return v >= 0 }, "must be >= 0")
+		if err != nil {
+			warnings = append(warnings, configWarning{env: envKafkaSagaEventBuffer, value: raw, err: err})
+		} else {
+			cfg.KafkaSagaEventBuffer = value
+		}
+	}
+
+	if raw, ok := lookupEnvTrimmed(lookup, envPaymentEventsParkTTL); ok {
+		value, err := parseDuration(raw, func(d time.Duration) bool { return d > 0 }, "must be > 0")
+		if err != nil {
+			warnings = append(warnings, configWarning{env: envPaymentEventsParkTTL, value: raw, err: err})
+		} else {
+			cfg.PaymentEventsParkTTL = value
+		}
+	}
+
+	if raw, ok := lookupEnvTrimmed(lookup, envKafkaConsumerConcurrency); ok {
+		value, err := parseInt(raw, func(v int) bool { return v >= 0 }, "must be >= 0")
+		if err != nil {
+			warnings = append(warnings, configWarning{env: envKafkaConsumerConcurrency, value: raw, err: err})
+		} else {
+			cfg.KafkaConsumerConcurrency = value
+		}
+	}
+
+	if raw, ok := lookupEnvTrimmed(lookup, envOrderQuotas); ok {
+		if _, err := grpcsvc.ParseOrderQuotas(raw); err != nil {
+			warnings = append(warnings, configWarning{env: envOrderQuotas, value: raw, err: err})
+		} else {
+			cfg.OrderQuotas = raw
+		}
+	}
+
+	if raw, ok := lookupEnvTrimmed(lookup, envApprovalCustomers); ok {
+		if _, err := grpcsvc.ParseApprovalCustomers(raw); err != nil {
+			warnings = append(warnings, configWarning{env: envApprovalCustomers, value: raw, err: err})
+		} else {
+			cfg.ApprovalCustomers = raw
+		}
+	}
+
+	if raw, ok := lookupEnvTrimmed(lookup, envCatalogPrices); ok {
+		if _, err := catalog.ParseStaticCatalog(raw); err != nil {
+			warnings = append(warnings, configWarning{env: envCatalogPrices, value: raw, err: err})
+		} else {
+			cfg.CatalogPrices = raw
+		}
+	}
+
+	if raw, ok := lookupEnvTrimmed(lookup, envSLOObjectives); ok {
+		if _, err := slo.ParseObjectives(raw); err != nil {
+			warnings = append(warnings, configWarning{env: envSLOObjectives, value: raw, err: err})
+		} else {
+			cfg.SLOObjectives = raw
+		}
+	}
+
+	if raw, ok := lookupEnvTrimmed(lookup, envDevPersistPath); ok {
+		cfg.DevPersistPath = raw
+	}
+
+	if raw, ok := lookupEnvTrimmed(lookup, envStorageChaos); ok {
+		if _, err := chaos.ParseRules(raw); err != nil {
+			warnings = append(warnings, configWarning{env: envStorageChaos, value: raw, err: err})
+		} else {
+			cfg.StorageChaos = raw
+		}
+	}
+
+	if raw, ok := lookupEnvTrimmed(lookup, envLogLevels); ok {
+		if _, err := logging.Parse(raw, log.InfoLevel); err != nil {
+			warnings = append(warnings, configWarning{env: envLogLevels, value: raw, err: err})
+		} else {
+			cfg.LogLevels = raw
+		}
+	}
+
+	if raw, ok := lookupEnvTrimmed(lookup, envLogLevelsFile); ok {
+		cfg.LogLevelsFile = raw
+	}
+
+	if raw, ok := lookupEnvTrimmed(lookup, envTuningFile); ok {
+		cfg.TuningFile = raw
+	}
+
+	// Ключи шифрования не валидируются здесь: предупреждение записало бы секрет в лог.
+	// Некорректные ключи останавливают запуск в app.Run.
+	if raw, ok := lookupEnvTrimmed(lookup, envEventEncryptionKeys); ok {
+		cfg.EventEncryptionKeys = raw
+	}
+	// Токены операторов — тоже секрет; некорректный список останавливает запуск в app.Run.
+	if raw, ok := lookupEnvTrimmed(lookup, envAdminTokens); ok {
+		cfg.AdminTokens = raw
+	}
+	if raw, ok := lookupEnvTrimmed(lookup, envAdminTokensFile); ok {
+		cfg.AdminTokensFile = raw
+	}
+	if raw, ok := lookupEnvTrimmed(lookup, envEventEncryptedFields); ok {
+		cfg.EventEncryptedFields = raw
+	}
+
 	return cfg, warnings
 }
 
@@ -211,6 +805,17 @@ func parseInt(value string, validate func(int) bool, constraints string) (int, e
 	return number, nil
 }
 
+func parseFloat(value string, validate func(float64) bool, constraints string) (float64, error) {
+	number, err := strconv.ParseFloat(strings.TrimSpace(value), 64)
+	if err != nil {
+		return 0, err
+	}
+	if !validate(number) {
+		return 0, fmt.Errorf("invalid float value: %s", constraints)
+	}
+	return number, nil
+}
+
 func parseDuration(value string, validate func(time.Duration) bool, constraints string) (time.Duration, error) {
 	duration, err := time.ParseDuration(strings.TrimSpace(value))
 	if err != nil {
@@ -222,7 +827,36 @@ func parseDuration(value string, validate func(time.Duration) bool, constraints
 	return duration, nil
 }
 
+// Коды выхода: оркестратор различает по ним штатную остановку, сбой запуска и брошенную при
+// остановке работу (саги или outbox не успели за graceful-таймаут).
+const (
+	exitOK            = 0
+	exitRuntimeError  = 1
+	exitStartupFailed = 2
+	exitDrainTimeout  = 3
+)
+
+// exitCode выбирает код выхода по ошибке app.Run; отмена по сигналу — штатная остановка.
+func exitCode(err error) int {
+	switch {
+	case err == nil:
+		return exitOK
+	case errors.Is(err, app.ErrStartupFailed):
+		return exitStartupFailed
+	case errors.Is(err, app.ErrDrainTimeout):
+		return exitDrainTimeout
+	case errors.Is(err, context.Canceled):
+		return exitOK
+	default:
+		return exitRuntimeError
+	}
+}
+
 func main() {
+	os.Exit(run())
+}
+
+func run() int {
 	setupLogger()
 	cfg := readConfig()
 
@@ -232,6 +866,11 @@ func main() {
 	log.WithFields(log.Fields{
 		"grpc_addr":                      cfg.GRPCAddr,
 		"metrics_addr":                   cfg.MetricsAddr,
+		"metrics_namespace":              cfg.MetricsNamespace,
+		"metrics_environment":            cfg.MetricsEnvironment,
+		"metrics_region":                 cfg.MetricsRegion,
+		"otlp_metrics_endpoint":          cfg.OTLPMetricsEndpoint,
+		"otlp_metrics_interval":          cfg.OTLPMetricsInterval.String(),
 		"storage_driver":                 cfg.StorageDriver,
 		"postgres_auto_migrate":          cfg.PostgresAutoMigrate,
 		"allow_mock_integrations":        cfg.AllowMockIntegrations,
@@ -240,12 +879,74 @@ func main() {
 		"outbox_max_attempts":            cfg.OutboxMaxAttempts,
 		"outbox_retry_delay":             cfg.OutboxRetryDelay.String(),
 		"outbox_max_pending":             cfg.OutboxMaxPending,
+		"outbox_cleanup_interval":        cfg.OutboxCleanupInterval.String(),
+		"outbox_sent_retention":          cfg.OutboxSentRetention.String(),
 		"idempotency_cleanup_interval":   cfg.IdempotencyCleanupInterval.String(),
 		"idempotency_cleanup_batch_size": cfg.IdempotencyCleanupBatchSize,
+		"idempotency_stale_processing":   cfg.IdempotencyStaleProcessing.String(),
+		"inventory_reconcile_interval":   cfg.InventoryReconcileInterval.String(),
+		"inventory_reconcile_dry_run":    cfg.InventoryReconcileDryRun,
+		"inventory_routes":               cfg.InventoryRoutes,
+		"amount_check_interval":          cfg.AmountCheckInterval.String(),
+		"amount_check_repair":            cfg.AmountCheckRepair,
+		"canary_interval":                cfg.CanaryInterval.String(),
+		"canary_timeout":                 cfg.CanaryTimeout.String(),
+		"saga_timeout":                   cfg.SagaTimeout.String(),
+		"saga_hook_timeout":              cfg.SagaHookTimeout.String(),
+		"scheduled_cancel_interval":      cfg.ScheduledCancelInterval.String(),
+		"payment_authorization_ttl":      cfg.PaymentAuthorizationTTL.String(),
+		"payment_authorization_check":    cfg.PaymentAuthorizationCheckInterval.String(),
+		"payment_max_reauthorizations":   cfg.PaymentMaxReauthorizations,
+		"payment_retry_max_attempts":     cfg.PaymentRetryMaxAttempts,
+		"payment_retry_base_delay":       cfg.PaymentRetryBaseDelay.String(),
+		"payment_retry_max_delay":        cfg.PaymentRetryMaxDelay.String(),
+		"payment_retry_interval":         cfg.PaymentRetryInterval.String(),
+		"order_list_cache_ttl":           cfg.OrderListCacheTTL.String(),
+		"order_list_cache_max_customers": cfg.OrderListCacheMaxCustomers,
+		"duplicate_order_window":         cfg.DuplicateOrderWindow.String(),
+		"grpc_log_sample_rate":           cfg.GRPCLogSampleRate,
+		"grpc_slow_request_threshold":    cfg.GRPCSlowRequestThreshold.String(),
+		"grpc_concurrency_limits":        cfg.GRPCConcurrencyLimits,
+		"grpc_admin_enabled":             cfg.GRPCAdminEnabled,
+		"feature_flags":                  cfg.FeatureFlags,
+		"kafka_dlq_policies":             cfg.KafkaDLQPolicies,
+		"kafka_topic_prefix":             cfg.KafkaTopicPrefix,
+		"kafka_key_strategies":           cfg.KafkaKeyStrategies,
+		"kafka_codecs":                   cfg.KafkaCodecs,
+		"kafka_breaker_threshold":        cfg.KafkaProducerBreakerThreshold,
+		"kafka_breaker_cooldown":         cfg.KafkaProducerBreakerCooldown.String(),
+		"dependency_breaker_threshold":   cfg.DependencyBreakerThreshold,
+		"dependency_breaker_cooldown":    cfg.DependencyBreakerCooldown.String(),
+		"kafka_saga_event_buffer":        cfg.KafkaSagaEventBuffer,
+		"payment_events_park_ttl":        cfg.PaymentEventsParkTTL.String(),
+		"kafka_consumer_concurrency":     cfg.KafkaConsumerConcurrency,
+		"event_encryption_enabled":       cfg.EventEncryptionKeys != "",
+		"event_encrypted_fields":         cfg.EventEncryptedFields,
+		"order_quotas":                   cfg.OrderQuotas,
+		"approval_customers":             cfg.ApprovalCustomers,
+		"admin_auth_enabled":             cfg.AdminTokens != "" || cfg.AdminTokensFile != "",
+		"admin_tokens_file":              cfg.AdminTokensFile,
+		"admin_ui_credentials_file":      cfg.AdminUICredentialsFile,
+		"catalog_prices":                 cfg.CatalogPrices,
+		"slo_objectives":                 cfg.SLOObjectives,
+		"slo_interval":                   cfg.SLOInterval.String(),
+		"saturation_interval":            cfg.SaturationInterval.String(),
+		"saturation_saga_limit":          cfg.SaturationSagaLimit,
+		"saturation_inflight_rpc_limit":  cfg.SaturationInFlightRPCLimit,
+		"list_default_page_size":         cfg.ListDefaultPageSize,
+		"list_max_page_size":             cfg.ListMaxPageSize,
+		"dev_persist_path":               cfg.DevPersistPath,
+		"storage_chaos":                  cfg.StorageChaos,
+		"log_levels":                     cfg.LogLevels,
+		"log_levels_file":                cfg.LogLevelsFile,
+		"tuning_file":                    cfg.TuningFile,
 		"build":                          version.String(),
 	}).Info("запускаем OrderService")
 
-	if err := app.Run(ctx, cfg); err != nil && !errors.Is(err, context.Canceled) {
-		log.WithError(err).Fatal("приложение завершилось с ошибкой")
+	err := app.Run(ctx, cfg)
+	code := exitCode(err)
+	if code != exitOK {
+		log.WithError(err).WithField("exit_code", code).Error("приложение завершилось с ошибкой")
 	}
+	return code
 }
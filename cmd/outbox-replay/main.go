@@ -0,0 +1,275 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"maps"
+	"os"
+	"os/signal"
+	"strings"
+	"syscall"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+
+	"github.com/vladislavdragonenkov/oms/internal/domain"
+	"github.com/vladislavdragonenkov/oms/internal/messaging/kafka"
+	"github.com/vladislavdragonenkov/oms/internal/storage/postgres"
+)
+
+const (
+	defaultBatchSize       = 100
+	defaultEncryptedFields = "customer_id"
+	openTimeout            = 30 * time.Second
+)
+
+type config struct {
+	dsn           string
+	brokers       []string
+	targetTopic   string
+	from          time.Time
+	to            time.Time
+	aggregateType string
+	aggregateID   string
+	replayID      string
+	batchSize     int
+	// limit ограничивает число переопубликованных сообщений; 0 — без ограничения.
+	limit   int
+	execute bool
+	// encryptionKeys и encryptedFields повторяют настройки сервиса: replay должен шифровать
+	// payload так же, как обычная публикация.
+	encryptionKeys  string
+	encryptedFields string
+}
+
+type summary struct {
+	ReplayID    string `json:"replay_id"`
+	TargetTopic string `json:"target_topic"`
+	Matched     int    `json:"matched"`
+	Published   int    `json:"published"`
+	Execute     bool   `json:"execute"`
+	// LastCreatedAt и LastID — последнее обработанное сообщение: с его created_at можно продолжить прерванный replay.
+	LastCreatedAt time.Time `json:"last_created_at,omitzero"`
+	LastID        string    `json:"last_id,omitempty"`
+}
+
+func main() {
+	log.SetFormatter(&log.TextFormatter{FullTimestamp: true})
+	log.SetLevel(log.InfoLevel)
+
+	cfg, err := readConfig(os.Args[1:], os.Getenv)
+	if err != nil {
+		fail("%v", err)
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	if err := run(ctx, cfg); err != nil {
+		fail("outbox replay failed: %v", err)
+	}
+}
+
+func readConfig(args []string, getenv func(string) string) (config, error) {
+	var (
+		cfg        config
+		brokersRaw string
+		from, to   string
+	)
+
+	fs := flag.NewFlagSet("outbox-replay", flag.ContinueOnError)
+	fs.StringVar(&cfg.dsn, "dsn", "", "PostgreSQL DSN (fallback: OMS_POSTGRES_DSN)")
+	fs.StringVar(&brokersRaw, "brokers", "", "Kafka brokers as comma-separated list (fallback: KAFKA_BROKERS)")
+	fs.StringVar(&cfg.targetTopic, "target-topic", "", "topic to publish replayed events to (required)")
+	fs.StringVar(&from, "from", "", "replay events enqueued at or after this RFC3339 time")
+	fs.StringVar(&to, "to", "", "replay events enqueued before this RFC3339 time")
+	fs.StringVar(&cfg.aggregateType, "aggregate-type", "", "replay only events of this aggregate type")
+	fs.StringVar(&cfg.aggregateID, "aggregate-id", "", "replay only events of this aggregate")
+	fs.StringVar(&cfg.replayID, "replay-id", "", "value of the x-replay header (default: replay-<unix time>)")
+	fs.IntVar(&cfg.batchSize, "batch-size", defaultBatchSize, "outbox rows read per query")
+	fs.IntVar(&cfg.limit, "limit", 0, "max number of events to replay (0 = all matching)")
+	fs.BoolVar(&cfg.execute, "execute", false, "publish events; default is dry-run")
+	if err := fs.Parse(args); err != nil {
+		return config{}, err
+	}
+
+	if strings.TrimSpace(cfg.dsn) == "" {
+		cfg.dsn = strings.TrimSpace(getenv("OMS_POSTGRES_DSN"))
+	}
+	if strings.TrimSpace(brokersRaw) == "" {
+		brokersRaw = getenv("KAFKA_BROKERS")
+	}
+	cfg.brokers = parseBrokers(brokersRaw)
+	cfg.encryptionKeys = strings.TrimSpace(getenv("OMS_EVENT_ENCRYPTION_KEYS"))
+	cfg.encryptedFields = strings.TrimSpace(getenv("OMS_EVENT_ENCRYPTED_FIELDS"))
+	if cfg.encryptedFields == "" {
+		cfg.encryptedFields = defaultEncryptedFields
+	}
+
+	var err error
+	if cfg.from, err = parseTime(from); err != nil {
+		return config{}, fmt.Errorf("from: %w", err)
+	}
+	if cfg.to, err = parseTime(to); err != nil {
+		return config{}, fmt.Errorf("to: %w", err)
+	}
+	if strings.TrimSpace(cfg.replayID) == "" {
+		cfg.replayID = fmt.Sprintf("replay-%d", time.Now().Unix())
+	}
+
+	switch {
+	case cfg.dsn == "":
+		return config{}, errors.New("OMS_POSTGRES_DSN (or -dsn) is required")
+	case cfg.execute && len(cfg.brokers) == 0:
+		return config{}, errors.New("kafka brokers are required (-brokers or KAFKA_BROKERS)")
+	case strings.TrimSpace(cfg.targetTopic) == "":
+		// Явный topic вместо значения по умолчанию: replay в боевой топик должен быть осознанным.
+		return config{}, errors.New("target-topic is required")
+	case cfg.from.IsZero() && cfg.aggregateID == "":
+		return config{}, errors.New("either from or aggregate-id is required")
+	case !cfg.to.IsZero() && !cfg.to.After(cfg.from):
+		return config{}, errors.New("to must be after from")
+	case cfg.batchSize <= 0:
+		return config{}, errors.New("batch-size must be > 0")
+	case cfg.limit < 0:
+		return config{}, errors.New("limit must be >= 0")
+	}
+	if err := kafka.ValidateTopicName(cfg.targetTopic); err != nil {
+		return config{}, fmt.Errorf("target-topic: %w", err)
+	}
+	return cfg, nil
+}
+
+func parseTime(raw string) (time.Time, error) {
+	raw = strings.TrimSpace(raw)
+	if raw == "" {
+		return time.Time{}, nil
+	}
+	return time.Parse(time.RFC3339, raw)
+}
+
+func parseBrokers(raw string) []string {
+	chunks := strings.Split(raw, ",")
+	brokers := make([]string, 0, len(chunks))
+	for _, chunk := range chunks {
+		if broker := strings.TrimSpace(chunk); broker != "" {
+			brokers = append(brokers, broker)
+		}
+	}
+	return brokers
+}
+
+func run(ctx context.Context, cfg config) error {
+	openCtx, cancel := context.WithTimeout(ctx, openTimeout)
+	store, err := postgres.Open(openCtx, cfg.dsn)
+	cancel()
+	if err != nil {
+		return fmt.Errorf("open postgres store: %w", err)
+	}
+	defer store.Close()
+
+	source, ok := postgres.NewOutboxRepository(store).(domain.OutboxReplaySource)
+	if !ok {
+		return errors.New("outbox repository does not support replay")
+	}
+
+	var publisher domain.OutboxPublisher
+	if cfg.execute {
+		var publisherOpts []kafka.OutboxPublisherOption
+		if cfg.encryptionKeys != "" {
+			encryptor, err := newFieldEncryptor(cfg)
+			if err != nil {
+				return err
+			}
+			publisherOpts = append(publisherOpts, kafka.WithFieldEncryptor(encryptor))
+		}
+		producer, err := kafka.NewProducer(cfg.brokers)
+		if err != nil {
+			return fmt.Errorf("create kafka producer: %w", err)
+		}
+		defer func() { _ = producer.Close() }()
+		publisher = kafka.NewOutboxPublisher(producer, cfg.targetTopic, publisherOpts...)
+	}
+
+	log.WithFields(log.Fields{
+		"replay_id":      cfg.replayID,
+		"target_topic":   cfg.targetTopic,
+		"from":           cfg.from,
+		"to":             cfg.to,
+		"aggregate_type": cfg.aggregateType,
+		"aggregate_id":   cfg.aggregateID,
+		"execute":        cfg.execute,
+	}).Info("starting outbox replay")
+
+	result, err := replay(ctx, cfg, source, publisher)
+	encoded, _ := json.Marshal(result)
+	fmt.Println(string(encoded))
+	return err
+}
+
+func newFieldEncryptor(cfg config) (*kafka.FieldEncryptor, error) {
+	wrapper, err := kafka.ParseStaticKeyWrapper(cfg.encryptionKeys)
+	if err != nil {
+		return nil, fmt.Errorf("parse event encryption keys: %w", err)
+	}
+	encryptor, err := kafka.NewFieldEncryptor(wrapper, kafka.ParseEncryptedFields(cfg.encryptedFields)...)
+	if err != nil {
+		return nil, fmt.Errorf("init event encryption: %w", err)
+	}
+	return encryptor, nil
+}
+
+// replay постранично читает опубликованные сообщения и отправляет их в cfg.targetTopic с
+// header x-replay. event-id сохраняется, поэтому получатель может дедуплицировать replay
+// против исходной публикации. Без cfg.execute (publisher == nil) только считает совпадения.
+func replay(ctx context.Context, cfg config, source domain.OutboxReplaySource, publisher domain.OutboxPublisher) (summary, error) {
+	result := summary{ReplayID: cfg.replayID, TargetTopic: cfg.targetTopic, Execute: cfg.execute}
+	filter := domain.OutboxReplayFilter{
+		From:          cfg.from,
+		To:            cfg.to,
+		AggregateType: cfg.aggregateType,
+		AggregateID:   cfg.aggregateID,
+		Limit:         cfg.batchSize,
+	}
+
+	for {
+		if err := ctx.Err(); err != nil {
+			return result, err
+		}
+		page, err := source.ListSent(filter)
+		if err != nil {
+			return result, fmt.Errorf("list sent outbox messages: %w", err)
+		}
+		for _, msg := range page {
+			if cfg.limit > 0 && result.Matched >= cfg.limit {
+				return result, nil
+			}
+			result.Matched++
+			if publisher != nil {
+				msg.Headers = maps.Clone(msg.Headers)
+				if msg.Headers == nil {
+					msg.Headers = make(map[string]string, 1)
+				}
+				msg.Headers[kafka.HeaderReplay] = cfg.replayID
+				if err := publisher.Publish(msg); err != nil {
+					return result, fmt.Errorf("publish outbox message %s: %w", msg.ID, err)
+				}
+				result.Published++
+			}
+			result.LastCreatedAt, result.LastID = msg.CreatedAt, msg.ID
+		}
+		if len(page) < filter.Limit {
+			return result, nil
+		}
+		last := page[len(page)-1]
+		filter.AfterCreatedAt, filter.AfterID = last.CreatedAt, last.ID
+	}
+}
+
+func fail(format string, args ...any) {
+	_, _ = fmt.Fprintf(os.Stderr, format+"\n", args...)
+	os.Exit(1)
+}
@@ -0,0 +1,114 @@
+package main
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/vladislavdragonenkov/oms/internal/domain"
+	"github.com/vladislavdragonenkov/oms/internal/messaging/kafka"
+	"github.com/vladislavdragonenkov/oms/internal/storage/memory"
+)
+
+func TestReadConfig(t *testing.T) {
+	env := map[string]string{"OMS_POSTGRES_DSN": "postgres://oms", "KAFKA_BROKERS": "k1:9092, k2:9092"}
+	cfg, err := readConfig([]string{
+		"-target-topic", "oms.order.events.replay",
+		"-from", "2026-05-01T00:00:00Z",
+		"-to", "2026-05-02T00:00:00Z",
+		"-execute",
+	}, func(key string) string { return env[key] })
+	if err != nil {
+		t.Fatalf("readConfig: %v", err)
+	}
+	if cfg.dsn != "postgres://oms" || len(cfg.brokers) != 2 || !cfg.execute || cfg.encryptedFields != defaultEncryptedFields {
+		t.Fatalf("unexpected config: %+v", cfg)
+	}
+	if !strings.HasPrefix(cfg.replayID, "replay-") {
+		t.Fatalf("expected generated replay id, got %q", cfg.replayID)
+	}
+
+	cases := map[string][]string{
+		"target-topic is required":          {"-from", "2026-05-01T00:00:00Z"},
+		"either from or aggregate-id":       {"-target-topic", "replay"},
+		"to must be after from":             {"-target-topic", "replay", "-from", "2026-05-02T00:00:00Z", "-to", "2026-05-01T00:00:00Z"},
+		"from:":                             {"-target-topic", "replay", "-from", "yesterday"},
+		"batch-size must be > 0":            {"-target-topic", "replay", "-aggregate-id", "order-1", "-batch-size", "0"},
+		"target-topic: kafka: invalid":      {"-target-topic", "bad topic", "-aggregate-id", "order-1"},
+		"kafka brokers are required":        {"-target-topic", "replay", "-aggregate-id", "order-1", "-execute", "-dsn", "postgres://oms"},
+		"OMS_POSTGRES_DSN (or -dsn) is req": {"-target-topic", "replay", "-aggregate-id", "order-1", "-dsn", " "},
+	}
+	for want, args := range cases {
+		lookup := func(key string) string { return env[key] }
+		if want == "kafka brokers are required" || want == "OMS_POSTGRES_DSN (or -dsn) is req" {
+			lookup = func(string) string { return "" }
+		}
+		if _, err := readConfig(args, lookup); err == nil || !strings.Contains(err.Error(), want) {
+			t.Fatalf("args %v: expected error containing %q, got %v", args, want, err)
+		}
+	}
+}
+
+type recordingPublisher struct {
+	published []domain.OutboxMessage
+}
+
+func (p *recordingPublisher) Publish(event domain.OutboxMessage) error {
+	p.published = append(p.published, event)
+	return nil
+}
+
+func TestReplay_PublishesSentMessagesWithReplayHeader(t *testing.T) {
+	repo := memory.NewOutboxRepository()
+	var ids []string
+	for _, aggregateID := range []string{"order-1", "order-2", "order-1", "order-pending"} {
+		saved, err := repo.Enqueue(domain.OutboxMessage{
+			AggregateType: "order",
+			AggregateID:   aggregateID,
+			EventType:     "OrderCreated",
+			Headers:       map[string]string{kafka.HeaderTraceParent: "trace"},
+		})
+		if err != nil {
+			t.Fatalf("enqueue: %v", err)
+		}
+		ids = append(ids, saved.ID)
+		time.Sleep(time.Millisecond)
+	}
+	for _, id := range ids[:3] {
+		if err := repo.MarkSent(id); err != nil {
+			t.Fatalf("mark sent: %v", err)
+		}
+	}
+
+	cfg := config{targetTopic: "replay", replayID: "replay-1", batchSize: 1, execute: true}
+	cfg.from = time.Now().Add(-time.Hour)
+
+	dryRun, err := replay(context.Background(), cfg, repo, nil)
+	if err != nil || dryRun.Matched != 3 || dryRun.Published != 0 {
+		t.Fatalf("unexpected dry-run result: %+v (%v)", dryRun, err)
+	}
+
+	publisher := &recordingPublisher{}
+	cfg.aggregateID = "order-1"
+	result, err := replay(context.Background(), cfg, repo, publisher)
+	if err != nil {
+		t.Fatalf("replay: %v", err)
+	}
+	if result.Published != 2 || len(publisher.published) != 2 || result.LastID != ids[2] {
+		t.Fatalf("expected both order-1 events replayed, got %+v", result)
+	}
+	for _, msg := range publisher.published {
+		if msg.Headers[kafka.HeaderReplay] != "replay-1" || msg.Headers[kafka.HeaderTraceParent] != "trace" {
+			t.Fatalf("expected replay header next to stored headers, got %v", msg.Headers)
+		}
+	}
+	if stored, _ := repo.ListSent(domain.OutboxReplayFilter{AggregateID: "order-1"}); stored[0].Headers[kafka.HeaderReplay] != "" {
+		t.Fatal("replay must not modify stored headers")
+	}
+
+	cfg.aggregateID, cfg.limit = "", 1
+	if limited, _ := replay(context.Background(), cfg, repo, &recordingPublisher{}); limited.Published != 1 {
+		t.Fatalf("expected limit to be respected, got %+v", limited)
+	}
+}
@@ -0,0 +1,219 @@
+package main
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"sort"
+	"time"
+)
+
+// maxArchiveLineSize ограничивает длину одной строки архива (заказ с большим числом позиций).
+const maxArchiveLineSize = 4 << 20
+
+// Типы событий архива, по которым восстанавливаются вызовы клиентов. Остальные события
+// (OrderSagaFailed, PaymentAuthorized, ...) порождает сага нового стенда сама.
+const (
+	eventOrderCreated       = "OrderCreated"
+	eventOrderStatusChanged = "OrderStatusChanged"
+	eventOrderCanceled      = "OrderCanceled"
+	eventOrderRefunded      = "OrderRefunded"
+)
+
+// Вызовы OrderService, которые воспроизводит replay.
+const (
+	methodCreateOrder = "CreateOrder"
+	methodPayOrder    = "PayOrder"
+	methodCancelOrder = "CancelOrder"
+	methodRefundOrder = "RefundOrder"
+)
+
+// archiveEvent — строка архива: событие outbox (поля как в outbox_messages) или OrderCreated
+// с содержимым заказа, выгруженное из orders/order_items.
+type archiveEvent struct {
+	ID            string          `json:"id"`
+	AggregateType string          `json:"aggregate_type"`
+	AggregateID   string          `json:"aggregate_id"`
+	EventType     string          `json:"event_type"`
+	CreatedAt     time.Time       `json:"created_at"`
+	Payload       json.RawMessage `json:"payload"`
+
+	line int
+}
+
+type archiveItem struct {
+	SKU        string `json:"sku"`
+	Qty        int32  `json:"qty"`
+	PriceMinor int64  `json:"price_minor"`
+}
+
+// eventPayload — поля payload, которые нужны для построения запросов.
+type eventPayload struct {
+	CustomerID  string        `json:"customer_id"`
+	Currency    string        `json:"currency"`
+	Items       []archiveItem `json:"items"`
+	Status      string        `json:"status"`
+	Reason      string        `json:"reason"`
+	AmountMinor int64         `json:"amount_minor"`
+}
+
+// step — один вызов API в расписании replay.
+type step struct {
+	// At — смещение от первого события архива в исходном времени.
+	At       time.Duration
+	Method   string
+	OrderKey string
+	// EventID — ID события архива; из него выводится ключ идемпотентности.
+	EventID  string
+	Currency string
+	Payload  eventPayload
+}
+
+// plan — расписание вызовов, построенное по архиву.
+type plan struct {
+	Steps  []step
+	Events int
+	Orders int
+	// Skipped — события, которые сага нового стенда воспроизведёт сама.
+	Skipped int
+	// Orphaned — события заказов, чьего OrderCreated нет в архиве.
+	Orphaned int
+	// Invalid — события с payload, по которому нельзя построить запрос.
+	Invalid int
+	Span    time.Duration
+}
+
+// readArchive читает JSONL-архив; пустые строки пропускаются. Битая строка — ошибка целиком:
+// архив выгружается автоматически, и частичный replay исказил бы профиль нагрузки.
+func readArchive(r io.Reader) ([]archiveEvent, error) {
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 0, 64<<10), maxArchiveLineSize)
+
+	var events []archiveEvent
+	line := 0
+	for scanner.Scan() {
+		line++
+		raw := bytes.TrimSpace(scanner.Bytes())
+		if len(raw) == 0 {
+			continue
+		}
+		var event archiveEvent
+		if err := json.Unmarshal(raw, &event); err != nil {
+			return nil, fmt.Errorf("line %d: decode event: %w", line, err)
+		}
+		if event.AggregateID == "" || event.EventType == "" || event.CreatedAt.IsZero() {
+			return nil, fmt.Errorf("line %d: aggregate_id, event_type and created_at are required", line)
+		}
+		event.line = line
+		events = append(events, event)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("read archive: %w", err)
+	}
+	return events, nil
+}
+
+// buildPlan сортирует события по времени и переводит их в вызовы API:
+//   - OrderCreated → CreateOrder;
+//   - первый OrderStatusChanged в reserved или backordered → PayOrder (клиент запустил сагу);
+//   - OrderCanceled → CancelOrder, OrderRefunded → RefundOrder.
+//
+// Остальное сага нового стенда породит сама. Отмены и возвраты, которые в исходной системе
+// сделала сага или воркеры, тоже станут вызовами клиента: архив их не различает.
+func buildPlan(events []archiveEvent) plan {
+	sort.SliceStable(events, func(i, j int) bool { return events[i].CreatedAt.Before(events[j].CreatedAt) })
+
+	type orderState struct {
+		currency string
+		paid     bool
+	}
+	orders := make(map[string]*orderState)
+	result := plan{Events: len(events)}
+	if len(events) == 0 {
+		return result
+	}
+	first := events[0].CreatedAt
+	result.Span = events[len(events)-1].CreatedAt.Sub(first)
+
+	for _, event := range events {
+		if event.AggregateType != "" && event.AggregateType != "order" {
+			result.Skipped++
+			continue
+		}
+		var payload eventPayload
+		if len(event.Payload) > 0 {
+			if err := json.Unmarshal(event.Payload, &payload); err != nil {
+				result.Invalid++
+				continue
+			}
+		}
+
+		s := step{At: event.CreatedAt.Sub(first), OrderKey: event.AggregateID, EventID: eventID(event), Payload: payload}
+		if event.EventType == eventOrderCreated {
+			if _, ok := orders[event.AggregateID]; ok || validateCreate(payload) != nil {
+				result.Invalid++
+				continue
+			}
+			orders[event.AggregateID] = &orderState{currency: payload.Currency}
+			s.Method, s.Currency = methodCreateOrder, payload.Currency
+			result.Steps = append(result.Steps, s)
+			continue
+		}
+
+		state, known := orders[event.AggregateID]
+		switch {
+		case event.EventType == eventOrderStatusChanged && (payload.Status == "reserved" || payload.Status == "backordered"):
+			if known && state.paid {
+				result.Skipped++
+				continue
+			}
+			s.Method = methodPayOrder
+		case event.EventType == eventOrderCanceled:
+			s.Method = methodCancelOrder
+		case event.EventType == eventOrderRefunded:
+			s.Method = methodRefundOrder
+		default:
+			result.Skipped++
+			continue
+		}
+		if !known {
+			result.Orphaned++
+			continue
+		}
+		if s.Method == methodPayOrder {
+			state.paid = true
+		}
+		s.Currency = state.currency
+		result.Steps = append(result.Steps, s)
+	}
+	result.Orders = len(orders)
+	return result
+}
+
+func validateCreate(payload eventPayload) error {
+	switch {
+	case payload.CustomerID == "":
+		return errors.New("customer_id is required")
+	case payload.Currency == "":
+		return errors.New("currency is required")
+	case len(payload.Items) == 0:
+		return errors.New("order must contain at least one item")
+	}
+	for idx, item := range payload.Items {
+		if item.Qty <= 0 || item.PriceMinor < 0 {
+			return fmt.Errorf("item[%d] has invalid qty or price", idx)
+		}
+	}
+	return nil
+}
+
+// eventID возвращает ID события, а для строк без него — номер строки архива.
+func eventID(event archiveEvent) string {
+	if event.ID != "" {
+		return event.ID
+	}
+	return fmt.Sprintf("line-%d", event.line)
+}
@@ -0,0 +1,75 @@
+package main
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+const testArchive = `
+{"id":"e1","aggregate_type":"order","aggregate_id":"o-1","event_type":"OrderCreated","created_at":"2026-05-01T10:00:00Z","payload":{"customer_id":"c-1","currency":"USD","items":[{"sku":"sku-1","qty":2,"price_minor":500}]}}
+{"id":"e2","aggregate_type":"order","aggregate_id":"o-1","event_type":"OrderStatusChanged","created_at":"2026-05-01T10:00:05Z","payload":{"status":"reserved"}}
+{"id":"e3","aggregate_type":"order","aggregate_id":"o-1","event_type":"OrderStatusChanged","created_at":"2026-05-01T10:00:06Z","payload":{"status":"reserved"}}
+{"id":"e4","aggregate_type":"order","aggregate_id":"o-1","event_type":"PaymentAuthorized","created_at":"2026-05-01T10:00:07Z","payload":{}}
+{"id":"e5","aggregate_type":"order","aggregate_id":"o-1","event_type":"OrderRefunded","created_at":"2026-05-01T10:01:00Z","payload":{"reason":"damaged","amount_minor":300}}
+{"id":"e6","aggregate_type":"order","aggregate_id":"o-2","event_type":"OrderCanceled","created_at":"2026-05-01T10:00:30Z","payload":{"reason":"late"}}
+{"id":"e7","aggregate_type":"order","aggregate_id":"o-3","event_type":"OrderCreated","created_at":"2026-05-01T10:00:10Z","payload":{"customer_id":"c-3","currency":"USD","items":[]}}
+{"id":"e8","aggregate_type":"inventory","aggregate_id":"sku-1","event_type":"StockReserved","created_at":"2026-05-01T10:00:02Z","payload":{}}
+`
+
+func TestBuildPlan_MapsEventsToCalls(t *testing.T) {
+	events, err := readArchive(strings.NewReader(testArchive))
+	if err != nil {
+		t.Fatalf("readArchive: %v", err)
+	}
+	result := buildPlan(events)
+
+	if result.Events != 8 || result.Orders != 1 || result.Orphaned != 1 || result.Invalid != 1 || result.Skipped != 3 {
+		t.Fatalf("unexpected counters: %+v", result)
+	}
+	if result.Span != time.Minute {
+		t.Fatalf("expected span 1m, got %s", result.Span)
+	}
+	want := []struct {
+		method string
+		at     time.Duration
+	}{
+		{methodCreateOrder, 0},
+		{methodPayOrder, 5 * time.Second},
+		{methodRefundOrder, time.Minute},
+	}
+	if len(result.Steps) != len(want) {
+		t.Fatalf("expected %d steps, got %+v", len(want), result.Steps)
+	}
+	for i, w := range want {
+		s := result.Steps[i]
+		if s.Method != w.method || s.At != w.at || s.OrderKey != "o-1" || s.Currency != "USD" {
+			t.Fatalf("step %d: unexpected %+v", i, s)
+		}
+	}
+	if refund := result.Steps[2]; refund.Payload.AmountMinor != 300 || refund.Payload.Reason != "damaged" || refund.EventID != "e5" {
+		t.Fatalf("unexpected refund step: %+v", refund)
+	}
+}
+
+func TestReadArchive_RejectsBrokenLines(t *testing.T) {
+	cases := map[string]string{
+		"line 2: decode event": "\n{bad json}\n",
+		"line 1: aggregate_id": `{"event_type":"OrderCreated","created_at":"2026-05-01T10:00:00Z"}`,
+	}
+	for want, input := range cases {
+		if _, err := readArchive(strings.NewReader(input)); err == nil || !strings.Contains(err.Error(), want) {
+			t.Fatalf("expected error containing %q, got %v", want, err)
+		}
+	}
+}
+
+func TestEventID_FallsBackToLine(t *testing.T) {
+	events, err := readArchive(strings.NewReader("\n" + `{"aggregate_id":"o-1","event_type":"OrderCreated","created_at":"2026-05-01T10:00:00Z"}`))
+	if err != nil {
+		t.Fatalf("readArchive: %v", err)
+	}
+	if got := eventID(events[0]); got != "line-2" {
+		t.Fatalf("expected line-2, got %q", got)
+	}
+}
@@ -0,0 +1,383 @@
+package main
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"math"
+	"os"
+	"os/signal"
+	"sort"
+	"strings"
+	"sync"
+	"syscall"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+
+	"github.com/vladislavdragonenkov/oms/internal/ctxutil"
+	omsv1 "github.com/vladislavdragonenkov/oms/proto/oms/v1"
+)
+
+const (
+	idempotencyHeader = "idempotency-key"
+
+	defaultAddr        = "localhost:50051"
+	defaultSpeed       = 1.0
+	defaultConcurrency = 64
+	defaultTimeout     = 10 * time.Second
+	defaultKeyPrefix   = "replay"
+)
+
+type config struct {
+	addr      string
+	inputPath string
+	// speed — во сколько раз быстрее исходного времени идут вызовы; 0 — без пауз.
+	speed       float64
+	concurrency int
+	timeout     time.Duration
+	keyPrefix   string
+	dryRun      bool
+}
+
+// orderClient — часть OrderServiceClient, которую вызывает replay.
+type orderClient interface {
+	CreateOrder(ctx context.Context, in *omsv1.CreateOrderRequest, opts ...grpc.CallOption) (*omsv1.CreateOrderResponse, error)
+	PayOrder(ctx context.Context, in *omsv1.PayOrderRequest, opts ...grpc.CallOption) (*omsv1.PayOrderResponse, error)
+	CancelOrder(ctx context.Context, in *omsv1.CancelOrderRequest, opts ...grpc.CallOption) (*omsv1.CancelOrderResponse, error)
+	RefundOrder(ctx context.Context, in *omsv1.RefundOrderRequest, opts ...grpc.CallOption) (*omsv1.RefundOrderResponse, error)
+}
+
+type methodSummary struct {
+	Planned int `json:"planned"`
+	Sent    int `json:"sent"`
+	Failed  int `json:"failed"`
+	// Codes — ответы с ошибкой по gRPC-кодам.
+	Codes     map[string]int `json:"codes,omitempty"`
+	LatencyMs latencySummary `json:"latency_ms"`
+
+	latencies []time.Duration
+}
+
+type latencySummary struct {
+	P50 float64 `json:"p50"`
+	P95 float64 `json:"p95"`
+	P99 float64 `json:"p99"`
+	Max float64 `json:"max"`
+}
+
+type summary struct {
+	Events   int     `json:"events"`
+	Orders   int     `json:"orders"`
+	Skipped  int     `json:"skipped"`
+	Orphaned int     `json:"orphaned"`
+	Invalid  int     `json:"invalid"`
+	Speed    float64 `json:"speed"`
+	// Span — длительность архива; Expected — сколько займёт replay на заданной скорости.
+	Span     string `json:"span"`
+	Expected string `json:"expected"`
+	Elapsed  string `json:"elapsed,omitempty"`
+	// MaxLagMs — наибольшее опоздание вызова относительно расписания. Рост означает, что стенд
+	// (или сам replay, упёршийся в -concurrency) не держит заданную скорость.
+	MaxLagMs float64 `json:"max_lag_ms"`
+	// Unsent — вызовы, не отправленные из-за отмены или неудачного CreateOrder своего заказа.
+	Unsent  int                       `json:"unsent"`
+	Methods map[string]*methodSummary `json:"methods"`
+	DryRun  bool                      `json:"dry_run,omitempty"`
+}
+
+func main() {
+	log.SetFormatter(&log.TextFormatter{FullTimestamp: true})
+	log.SetLevel(log.InfoLevel)
+
+	cfg, err := readConfig(os.Args[1:])
+	if err != nil {
+		fail("%v", err)
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	if err := run(ctx, cfg); err != nil {
+		fail("replay failed: %v", err)
+	}
+}
+
+func readConfig(args []string) (config, error) {
+	cfg := config{}
+
+	fs := flag.NewFlagSet("replay", flag.ContinueOnError)
+	fs.StringVar(&cfg.addr, "addr", defaultAddr, "OrderService gRPC address of the target environment")
+	fs.StringVar(&cfg.inputPath, "input", "", "JSONL archive of order events")
+	fs.Float64Var(&cfg.speed, "speed", defaultSpeed, "replay speed relative to archived time (1 = real time, 10 = 10x faster, 0 = no pauses)")
+	fs.IntVar(&cfg.concurrency, "concurrency", defaultConcurrency, "max number of in-flight calls")
+	fs.DurationVar(&cfg.timeout, "timeout", defaultTimeout, "timeout per call")
+	fs.StringVar(&cfg.keyPrefix, "key-prefix", defaultKeyPrefix, "prefix of idempotency keys derived from event ids")
+	fs.BoolVar(&cfg.dryRun, "dry-run", false, "build the schedule without calling the API")
+	if err := fs.Parse(args); err != nil {
+		return config{}, err
+	}
+
+	switch {
+	case strings.TrimSpace(cfg.inputPath) == "":
+		return config{}, errors.New("input is required")
+	case !cfg.dryRun && strings.TrimSpace(cfg.addr) == "":
+		return config{}, errors.New("addr is required")
+	case cfg.speed < 0 || math.IsInf(cfg.speed, 0) || math.IsNaN(cfg.speed):
+		return config{}, errors.New("speed must be >= 0")
+	case cfg.concurrency <= 0:
+		return config{}, errors.New("concurrency must be > 0")
+	case cfg.timeout <= 0:
+		return config{}, errors.New("timeout must be > 0")
+	case strings.TrimSpace(cfg.keyPrefix) == "":
+		return config{}, errors.New("key-prefix is required")
+	}
+	return cfg, nil
+}
+
+func run(ctx context.Context, cfg config) error {
+	input, err := os.Open(cfg.inputPath)
+	if err != nil {
+		return fmt.Errorf("open input: %w", err)
+	}
+	events, err := readArchive(input)
+	_ = input.Close()
+	if err != nil {
+		return err
+	}
+	schedule := buildPlan(events)
+
+	var client orderClient
+	if !cfg.dryRun {
+		conn, err := grpc.NewClient(cfg.addr, grpc.WithTransportCredentials(insecure.NewCredentials()))
+		if err != nil {
+			return fmt.Errorf("create grpc client: %w", err)
+		}
+		defer func() { _ = conn.Close() }()
+		client = omsv1.NewOrderServiceClient(conn)
+	}
+
+	log.WithFields(log.Fields{
+		"input":    cfg.inputPath,
+		"events":   schedule.Events,
+		"calls":    len(schedule.Steps),
+		"span":     schedule.Span,
+		"expected": scaled(schedule.Span, cfg.speed),
+		"speed":    cfg.speed,
+		"dry_run":  cfg.dryRun,
+	}).Info("starting order replay")
+
+	result := runReplay(ctx, cfg, client, schedule)
+	encoded, _ := json.Marshal(result)
+	fmt.Println(string(encoded))
+	if ctx.Err() != nil {
+		return errors.New("replay interrupted")
+	}
+	return nil
+}
+
+// replayOrder — состояние заказа во время replay. Вызовы одного заказа выполняются строго
+// по очереди: каждый ждёт закрытия done предыдущего.
+type replayOrder struct {
+	orderID string
+	done    chan struct{}
+}
+
+// runReplay выполняет шаги в моменты первое_событие + At/speed. Вызовы разных заказов идут
+// параллельно (не больше cfg.concurrency одновременно), вызовы одного заказа — по порядку:
+// PayOrder нужен ID, выданный CreateOrder нового стенда.
+func runReplay(ctx context.Context, cfg config, client orderClient, schedule plan) summary {
+	result := summary{
+		Events:   schedule.Events,
+		Orders:   schedule.Orders,
+		Skipped:  schedule.Skipped,
+		Orphaned: schedule.Orphaned,
+		Invalid:  schedule.Invalid,
+		Speed:    cfg.speed,
+		Span:     schedule.Span.String(),
+		Expected: scaled(schedule.Span, cfg.speed).String(),
+		Methods:  make(map[string]*methodSummary),
+		DryRun:   cfg.dryRun,
+	}
+	for _, s := range schedule.Steps {
+		methodStats(result.Methods, s.Method).Planned++
+	}
+	if cfg.dryRun {
+		return result
+	}
+
+	var (
+		mu     sync.Mutex
+		wg     sync.WaitGroup
+		maxLag time.Duration
+	)
+	slots := make(chan struct{}, cfg.concurrency)
+	orders := make(map[string]*replayOrder)
+	start := time.Now()
+
+	for _, s := range schedule.Steps {
+		due := start.Add(scaled(s.At, cfg.speed))
+		if ctxutil.Sleep(ctx, time.Until(due)) != nil {
+			break
+		}
+
+		order, ok := orders[s.OrderKey]
+		if !ok {
+			order = &replayOrder{}
+			orders[s.OrderKey] = order
+		}
+		prev, done := order.done, make(chan struct{})
+		order.done = done
+
+		wg.Add(1)
+		go func(s step, prev <-chan struct{}) {
+			defer wg.Done()
+			defer close(done)
+			if prev != nil {
+				<-prev
+			}
+			// order.orderID пишется только вызовом CreateOrder этого же заказа, который завершился до close(prev).
+			if s.Method != methodCreateOrder && order.orderID == "" {
+				return
+			}
+			select {
+			case slots <- struct{}{}:
+			case <-ctx.Done():
+				return
+			}
+			lag := time.Since(due)
+			began := time.Now()
+			orderID, err := call(ctx, cfg, client, s, order.orderID)
+			latency := time.Since(began)
+			<-slots
+			if ctx.Err() != nil && err != nil {
+				return
+			}
+			if s.Method == methodCreateOrder && err == nil {
+				order.orderID = orderID
+			}
+
+			mu.Lock()
+			defer mu.Unlock()
+			stats := methodStats(result.Methods, s.Method)
+			stats.Sent++
+			stats.latencies = append(stats.latencies, latency)
+			if err != nil {
+				stats.Failed++
+				if stats.Codes == nil {
+					stats.Codes = make(map[string]int)
+				}
+				stats.Codes[status.Code(err).String()]++
+			}
+			maxLag = max(maxLag, lag)
+		}(s, prev)
+	}
+	wg.Wait()
+
+	result.Elapsed = time.Since(start).Round(time.Millisecond).String()
+	result.MaxLagMs = milliseconds(maxLag)
+	for _, stats := range result.Methods {
+		result.Unsent += stats.Planned - stats.Sent
+		stats.LatencyMs = summarizeLatencies(stats.latencies)
+	}
+	return result
+}
+
+func methodStats(methods map[string]*methodSummary, method string) *methodSummary {
+	stats, ok := methods[method]
+	if !ok {
+		stats = &methodSummary{}
+		methods[method] = stats
+	}
+	return stats
+}
+
+// call отправляет шаг с ключом идемпотентности из ID события: повторный replay того же архива
+// в тот же стенд не создаёт дублей. Возвращает ID заказа нового стенда.
+func call(ctx context.Context, cfg config, client orderClient, s step, orderID string) (string, error) {
+	callCtx, cancel := context.WithTimeout(metadata.AppendToOutgoingContext(ctx, idempotencyHeader, idempotencyKey(cfg.keyPrefix, s.EventID)), cfg.timeout)
+	defer cancel()
+
+	switch s.Method {
+	case methodCreateOrder:
+		resp, err := client.CreateOrder(callCtx, buildCreateRequest(s.Payload))
+		return resp.GetOrder().GetId(), err
+	case methodPayOrder:
+		_, err := client.PayOrder(callCtx, &omsv1.PayOrderRequest{OrderId: orderID})
+		return orderID, err
+	case methodCancelOrder:
+		_, err := client.CancelOrder(callCtx, &omsv1.CancelOrderRequest{OrderId: orderID, Reason: s.Payload.Reason})
+		return orderID, err
+	case methodRefundOrder:
+		req := &omsv1.RefundOrderRequest{OrderId: orderID, Reason: s.Payload.Reason}
+		if s.Payload.AmountMinor > 0 {
+			req.Amount = &omsv1.Money{Currency: s.Currency, AmountMinor: s.Payload.AmountMinor}
+		}
+		_, err := client.RefundOrder(callCtx, req)
+		return orderID, err
+	default:
+		return orderID, fmt.Errorf("unsupported method %s", s.Method)
+	}
+}
+
+func buildCreateRequest(payload eventPayload) *omsv1.CreateOrderRequest {
+	items := make([]*omsv1.OrderItem, 0, len(payload.Items))
+	for _, item := range payload.Items {
+		items = append(items, &omsv1.OrderItem{
+			Sku:   item.SKU,
+			Qty:   item.Qty,
+			Price: &omsv1.Money{Currency: payload.Currency, AmountMinor: item.PriceMinor},
+		})
+	}
+	return &omsv1.CreateOrderRequest{
+		CustomerId: payload.CustomerID,
+		Currency:   payload.Currency,
+		Items:      items,
+	}
+}
+
+func idempotencyKey(prefix, eventID string) string {
+	sum := sha256.Sum256([]byte(eventID))
+	return prefix + "-" + hex.EncodeToString(sum[:16])
+}
+
+// scaled переводит длительность исходного времени во время replay.
+func scaled(d time.Duration, speed float64) time.Duration {
+	if speed == 0 {
+		return 0
+	}
+	return time.Duration(float64(d) / speed)
+}
+
+func summarizeLatencies(latencies []time.Duration) latencySummary {
+	if len(latencies) == 0 {
+		return latencySummary{}
+	}
+	sort.Slice(latencies, func(i, j int) bool { return latencies[i] < latencies[j] })
+	quantile := func(q float64) float64 {
+		return milliseconds(latencies[int(math.Ceil(q*float64(len(latencies))))-1])
+	}
+	return latencySummary{
+		P50: quantile(0.5),
+		P95: quantile(0.95),
+		P99: quantile(0.99),
+		Max: milliseconds(latencies[len(latencies)-1]),
+	}
+}
+
+func milliseconds(d time.Duration) float64 {
+	return math.Round(float64(d)/float64(time.Microsecond)) / 1000
+}
+
+func fail(format string, args ...any) {
+	_, _ = fmt.Fprintf(os.Stderr, format+"\n", args...)
+	os.Exit(1)
+}
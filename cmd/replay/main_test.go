@@ -0,0 +1,148 @@
+package main
+
+import (
+	"context"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+
+	omsv1 "github.com/vladislavdragonenkov/oms/proto/oms/v1"
+)
+
+type fakeOrders struct {
+	mu     sync.Mutex
+	calls  []string
+	keys   map[string]bool
+	reject map[string]bool // customer_id -> CreateOrder отвечает InvalidArgument
+}
+
+func (f *fakeOrders) record(ctx context.Context, call string) {
+	md, _ := metadata.FromOutgoingContext(ctx)
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.calls = append(f.calls, call)
+	if f.keys == nil {
+		f.keys = make(map[string]bool)
+	}
+	f.keys[md.Get(idempotencyHeader)[0]] = true
+}
+
+func (f *fakeOrders) CreateOrder(ctx context.Context, in *omsv1.CreateOrderRequest, _ ...grpc.CallOption) (*omsv1.CreateOrderResponse, error) {
+	f.record(ctx, "create:"+in.CustomerId)
+	if f.reject[in.CustomerId] {
+		return nil, status.Error(codes.InvalidArgument, "rejected")
+	}
+	return &omsv1.CreateOrderResponse{Order: &omsv1.Order{Id: "new-" + in.CustomerId}}, nil
+}
+
+func (f *fakeOrders) PayOrder(ctx context.Context, in *omsv1.PayOrderRequest, _ ...grpc.CallOption) (*omsv1.PayOrderResponse, error) {
+	f.record(ctx, "pay:"+in.OrderId)
+	return &omsv1.PayOrderResponse{}, nil
+}
+
+func (f *fakeOrders) CancelOrder(ctx context.Context, in *omsv1.CancelOrderRequest, _ ...grpc.CallOption) (*omsv1.CancelOrderResponse, error) {
+	f.record(ctx, "cancel:"+in.OrderId)
+	return &omsv1.CancelOrderResponse{}, nil
+}
+
+func (f *fakeOrders) RefundOrder(ctx context.Context, in *omsv1.RefundOrderRequest, _ ...grpc.CallOption) (*omsv1.RefundOrderResponse, error) {
+	f.record(ctx, "refund:"+in.OrderId+":"+in.GetAmount().GetCurrency())
+	return &omsv1.RefundOrderResponse{}, nil
+}
+
+func TestReadConfig(t *testing.T) {
+	cfg, err := readConfig([]string{"-input", "events.jsonl", "-speed", "10", "-concurrency", "8"})
+	if err != nil {
+		t.Fatalf("readConfig: %v", err)
+	}
+	if cfg.speed != 10 || cfg.concurrency != 8 || cfg.addr != defaultAddr || cfg.keyPrefix != defaultKeyPrefix {
+		t.Fatalf("unexpected config: %+v", cfg)
+	}
+
+	cases := map[string][]string{
+		"input is required":       {},
+		"speed must be >= 0":      {"-input", "a", "-speed", "-1"},
+		"concurrency must be > 0": {"-input", "a", "-concurrency", "0"},
+		"timeout must be > 0":     {"-input", "a", "-timeout", "0s"},
+		"key-prefix is required":  {"-input", "a", "-key-prefix", " "},
+		"addr is required":        {"-input", "a", "-addr", ""},
+	}
+	for want, args := range cases {
+		if _, err := readConfig(args); err == nil || !strings.Contains(err.Error(), want) {
+			t.Fatalf("args %v: expected error containing %q, got %v", args, want, err)
+		}
+	}
+}
+
+func testPlan() plan {
+	created := func(customer string) eventPayload {
+		return eventPayload{CustomerID: customer, Currency: "USD", Items: []archiveItem{{SKU: "sku", Qty: 1, PriceMinor: 100}}}
+	}
+	return plan{
+		Events: 6,
+		Orders: 2,
+		Span:   time.Hour,
+		Steps: []step{
+			{At: 0, Method: methodCreateOrder, OrderKey: "o-1", EventID: "e1", Currency: "USD", Payload: created("c-1")},
+			{At: time.Second, Method: methodCreateOrder, OrderKey: "o-2", EventID: "e2", Currency: "USD", Payload: created("c-2")},
+			{At: 2 * time.Second, Method: methodPayOrder, OrderKey: "o-1", EventID: "e3", Currency: "USD"},
+			{At: 3 * time.Second, Method: methodPayOrder, OrderKey: "o-2", EventID: "e4", Currency: "USD"},
+			{At: 4 * time.Second, Method: methodRefundOrder, OrderKey: "o-1", EventID: "e5", Currency: "USD", Payload: eventPayload{AmountMinor: 50}},
+			{At: 5 * time.Second, Method: methodCancelOrder, OrderKey: "o-2", EventID: "e6", Currency: "USD"},
+		},
+	}
+}
+
+func TestRunReplay_ChainsCallsByOrder(t *testing.T) {
+	client := &fakeOrders{reject: map[string]bool{"c-2": true}}
+	cfg := config{speed: 0, concurrency: 4, timeout: time.Second, keyPrefix: "replay"}
+
+	result := runReplay(context.Background(), cfg, client, testPlan())
+
+	// o-2 не создан: его PayOrder и CancelOrder не отправляются.
+	if result.Unsent != 2 || result.Methods[methodCreateOrder].Failed != 1 || result.Methods[methodCreateOrder].Codes["InvalidArgument"] != 1 {
+		t.Fatalf("unexpected summary: %+v", result)
+	}
+	calls := map[string]bool{}
+	for _, call := range client.calls {
+		calls[call] = true
+	}
+	for _, want := range []string{"create:c-1", "create:c-2", "pay:new-c-1", "refund:new-c-1:USD"} {
+		if !calls[want] {
+			t.Fatalf("expected call %q, got %v", want, client.calls)
+		}
+	}
+	if len(client.calls) != 4 || len(client.keys) != 4 {
+		t.Fatalf("expected 4 calls with distinct keys, got %v", client.calls)
+	}
+	if !client.keys[idempotencyKey("replay", "e3")] {
+		t.Fatalf("expected idempotency key derived from event id, got %v", client.keys)
+	}
+}
+
+func TestRunReplay_DryRunOnlyPlans(t *testing.T) {
+	cfg := config{speed: 10, concurrency: 1, timeout: time.Second, keyPrefix: "replay", dryRun: true}
+
+	result := runReplay(context.Background(), cfg, nil, testPlan())
+	if !result.DryRun || result.Methods[methodPayOrder].Planned != 2 || result.Methods[methodPayOrder].Sent != 0 {
+		t.Fatalf("unexpected summary: %+v", result.Methods)
+	}
+	if result.Expected != (6 * time.Minute).String() {
+		t.Fatalf("expected 6m at 10x, got %s", result.Expected)
+	}
+}
+
+func TestScaled(t *testing.T) {
+	if got := scaled(time.Minute, 10); got != 6*time.Second {
+		t.Fatalf("expected 6s, got %s", got)
+	}
+	if got := scaled(time.Minute, 0); got != 0 {
+		t.Fatalf("expected no pauses at speed 0, got %s", got)
+	}
+}
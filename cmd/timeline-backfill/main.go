@@ -0,0 +1,222 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+	"os/signal"
+	"strings"
+	"syscall"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+
+	"github.com/vladislavdragonenkov/oms/internal/domain"
+	"github.com/vladislavdragonenkov/oms/internal/storage/postgres"
+)
+
+const (
+	defaultBatchSize = 500
+	defaultStateFile = "timeline-backfill-state.json"
+	openTimeout      = 30 * time.Second
+
+	// eventOrderStatusChanged совпадает с типом, который пишут OrderService и сага:
+	// UI не должен отличать синтезированную запись от настоящей.
+	eventOrderStatusChanged = "OrderStatusChanged"
+)
+
+type config struct {
+	dsn       string
+	batchSize int
+	// afterID — курсор: обрабатываются заказы с ID строго больше; перекрывает -state-file.
+	afterID   string
+	stateFile string
+	// limit ограничивает число дописанных историй; 0 — без ограничения.
+	limit   int
+	execute bool
+}
+
+type summary struct {
+	Scanned    int  `json:"scanned"`
+	Backfilled int  `json:"backfilled"`
+	Skipped    int  `json:"skipped"`
+	Execute    bool `json:"execute"`
+	// LastOrderID — последний просмотренный заказ: значение для -after-id, если прогон прерван.
+	LastOrderID string `json:"last_order_id,omitempty"`
+}
+
+func main() {
+	log.SetFormatter(&log.TextFormatter{FullTimestamp: true})
+	log.SetLevel(log.InfoLevel)
+
+	cfg, err := readConfig(os.Args[1:], os.Getenv)
+	if err != nil {
+		fail("%v", err)
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	if err := run(ctx, cfg); err != nil {
+		fail("timeline backfill failed: %v", err)
+	}
+}
+
+func readConfig(args []string, getenv func(string) string) (config, error) {
+	var cfg config
+	fs := flag.NewFlagSet("timeline-backfill", flag.ContinueOnError)
+	fs.StringVar(&cfg.dsn, "dsn", "", "PostgreSQL DSN (fallback: OMS_POSTGRES_DSN)")
+	fs.IntVar(&cfg.batchSize, "batch-size", defaultBatchSize, "orders read per query")
+	fs.StringVar(&cfg.afterID, "after-id", "", "start after this order ID (overrides the state file)")
+	fs.StringVar(&cfg.stateFile, "state-file", defaultStateFile, "file with the last processed order ID for resuming (empty = disabled)")
+	fs.IntVar(&cfg.limit, "limit", 0, "max number of orders to backfill (0 = all)")
+	fs.BoolVar(&cfg.execute, "execute", false, "write timeline events; default is dry-run")
+	if err := fs.Parse(args); err != nil {
+		return config{}, err
+	}
+
+	if strings.TrimSpace(cfg.dsn) == "" {
+		cfg.dsn = strings.TrimSpace(getenv("OMS_POSTGRES_DSN"))
+	}
+	cfg.afterID = strings.TrimSpace(cfg.afterID)
+	cfg.stateFile = strings.TrimSpace(cfg.stateFile)
+
+	switch {
+	case cfg.dsn == "":
+		return config{}, errors.New("OMS_POSTGRES_DSN (or -dsn) is required")
+	case cfg.batchSize <= 0:
+		return config{}, errors.New("batch-size must be > 0")
+	case cfg.limit < 0:
+		return config{}, errors.New("limit must be >= 0")
+	case fs.NArg() > 0:
+		return config{}, fmt.Errorf("unexpected arguments: %v", fs.Args())
+	}
+	return cfg, nil
+}
+
+func run(ctx context.Context, cfg config) error {
+	state, err := loadState(cfg.stateFile)
+	if err != nil {
+		return err
+	}
+	if cfg.afterID == "" {
+		cfg.afterID = state.lastOrderID()
+	}
+
+	openCtx, cancel := context.WithTimeout(ctx, openTimeout)
+	store, err := postgres.Open(openCtx, cfg.dsn)
+	cancel()
+	if err != nil {
+		return fmt.Errorf("open postgres store: %w", err)
+	}
+	defer store.Close()
+
+	log.WithFields(log.Fields{
+		"after_id":   cfg.afterID,
+		"batch_size": cfg.batchSize,
+		"state_file": cfg.stateFile,
+		"execute":    cfg.execute,
+	}).Info("starting timeline backfill")
+
+	orders, ok := postgres.NewOrderRepository(store).(domain.OrderScanner)
+	if !ok {
+		return errors.New("order repository does not support scanning")
+	}
+	result, err := backfill(ctx, cfg, orders, postgres.NewTimelineRepository(store), state)
+	encoded, _ := json.Marshal(result)
+	fmt.Println(string(encoded))
+	return err
+}
+
+// backfill проходит заказы по возрастанию ID и каждому заказу без истории дописывает одно
+// событие OrderStatusChanged с текущим статусом на момент created_at. Заказы, у которых
+// timeline уже есть, пропускаются, поэтому повторный прогон ничего не дублирует.
+// Курсор сохраняется в state после каждой страницы (только с cfg.execute).
+func backfill(ctx context.Context, cfg config, orders domain.OrderScanner, timeline domain.TimelineRepository, state *backfillState) (summary, error) {
+	result := summary{Execute: cfg.execute, LastOrderID: cfg.afterID}
+	afterID := cfg.afterID
+
+	for {
+		if err := ctx.Err(); err != nil {
+			return result, err
+		}
+		page, err := orders.ListAfter(afterID, cfg.batchSize)
+		if err != nil {
+			return result, fmt.Errorf("list orders after %q: %w", afterID, err)
+		}
+		for _, order := range page {
+			if cfg.limit > 0 && result.Backfilled >= cfg.limit {
+				return result, saveCursor(cfg, state, result.LastOrderID)
+			}
+			written, err := backfillOrder(order, timeline, cfg.execute)
+			if err != nil {
+				// Курсор указывает на последний полностью обработанный заказ: повтор начнёт с этого.
+				_ = saveCursor(cfg, state, result.LastOrderID)
+				return result, err
+			}
+			result.Scanned++
+			if written {
+				result.Backfilled++
+			} else {
+				result.Skipped++
+			}
+			result.LastOrderID = order.ID
+		}
+		if err := saveCursor(cfg, state, result.LastOrderID); err != nil {
+			return result, err
+		}
+		if len(page) < cfg.batchSize {
+			return result, nil
+		}
+		afterID = page[len(page)-1].ID
+		log.WithFields(log.Fields{
+			"scanned":    result.Scanned,
+			"backfilled": result.Backfilled,
+			"last_id":    afterID,
+		}).Info("timeline backfill progress")
+	}
+}
+
+// backfillOrder возвращает true, если заказу нужна (и при execute записана) синтетическая история.
+func backfillOrder(order domain.Order, timeline domain.TimelineRepository, execute bool) (bool, error) {
+	existing, err := timeline.List(order.ID)
+	if err != nil {
+		return false, fmt.Errorf("list timeline of order %s: %w", order.ID, err)
+	}
+	if len(existing) > 0 {
+		return false, nil
+	}
+	if !execute {
+		return true, nil
+	}
+
+	occurred := order.CreatedAt
+	if occurred.IsZero() {
+		occurred = order.UpdatedAt
+	}
+	event := domain.TimelineEvent{
+		OrderID:  order.ID,
+		Type:     eventOrderStatusChanged,
+		Reason:   string(order.Status),
+		Occurred: occurred,
+	}
+	if err := timeline.Append(event); err != nil {
+		return false, fmt.Errorf("append timeline event for order %s: %w", order.ID, err)
+	}
+	return true, nil
+}
+
+func saveCursor(cfg config, state *backfillState, lastOrderID string) error {
+	if !cfg.execute || lastOrderID == "" {
+		return nil
+	}
+	return state.save(lastOrderID)
+}
+
+func fail(format string, args ...any) {
+	_, _ = fmt.Fprintf(os.Stderr, format+"\n", args...)
+	os.Exit(1)
+}
@@ -0,0 +1,196 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/vladislavdragonenkov/oms/internal/domain"
+	"github.com/vladislavdragonenkov/oms/internal/storage/memory"
+)
+
+func TestReadConfig(t *testing.T) {
+	env := map[string]string{"OMS_POSTGRES_DSN": "postgres://oms"}
+	cfg, err := readConfig([]string{"-batch-size", "50", "-after-id", " order-9 ", "-execute"}, func(key string) string { return env[key] })
+	if err != nil {
+		t.Fatalf("readConfig: %v", err)
+	}
+	if cfg.dsn != "postgres://oms" || cfg.batchSize != 50 || cfg.afterID != "order-9" || !cfg.execute || cfg.stateFile != defaultStateFile {
+		t.Fatalf("unexpected config: %+v", cfg)
+	}
+
+	cases := map[string][]string{
+		"batch-size must be > 0": {"-batch-size", "0"},
+		"limit must be >= 0":     {"-limit", "-1"},
+		"unexpected arguments":   {"extra"},
+	}
+	for want, args := range cases {
+		if _, err := readConfig(args, func(key string) string { return env[key] }); err == nil || !strings.Contains(err.Error(), want) {
+			t.Fatalf("args %v: expected error containing %q, got %v", args, want, err)
+		}
+	}
+	if _, err := readConfig(nil, func(string) string { return "" }); err == nil || !strings.Contains(err.Error(), "OMS_POSTGRES_DSN") {
+		t.Fatalf("expected missing DSN error, got %v", err)
+	}
+}
+
+// legacyCreatedAt — время создания заказов из seedLegacyOrders.
+var legacyCreatedAt = time.Date(2024, 3, 1, 10, 0, 0, 0, time.UTC)
+
+// seedLegacyOrders создаёт count заказов; у заказов из withHistory уже есть timeline.
+func seedLegacyOrders(t *testing.T, orders domain.OrderRepository, timeline domain.TimelineRepository, count int, withHistory ...int) {
+	t.Helper()
+	for i := 1; i <= count; i++ {
+		order := domain.Order{
+			ID:          fmt.Sprintf("order-%02d", i),
+			CustomerID:  "customer-1",
+			Status:      domain.OrderStatusConfirmed,
+			Currency:    "USD",
+			AmountMinor: 100,
+			Items:       []domain.OrderItem{{ID: "item-1", SKU: "sku-1", Qty: 1, PriceMinor: 100}},
+			CreatedAt:   legacyCreatedAt,
+			UpdatedAt:   legacyCreatedAt.Add(time.Hour),
+		}
+		if err := orders.Create(order); err != nil {
+			t.Fatalf("create order: %v", err)
+		}
+	}
+	for _, i := range withHistory {
+		if err := timeline.Append(domain.TimelineEvent{OrderID: fmt.Sprintf("order-%02d", i), Type: "OrderCreated", Occurred: legacyCreatedAt}); err != nil {
+			t.Fatalf("append timeline: %v", err)
+		}
+	}
+}
+
+func listTimeline(t *testing.T, timeline domain.TimelineRepository, orderID string) []domain.TimelineEvent {
+	t.Helper()
+	events, err := timeline.List(orderID)
+	if err != nil {
+		t.Fatalf("list timeline: %v", err)
+	}
+	return events
+}
+
+func TestBackfill_SynthesizesStatusEventForLegacyOrders(t *testing.T) {
+	orders := memory.NewOrderRepository()
+	timeline := memory.NewTimelineRepository()
+	seedLegacyOrders(t, orders, timeline, 5, 2)
+
+	result, err := backfill(context.Background(), config{batchSize: 2, execute: true}, orders.(domain.OrderScanner), timeline, nil)
+	if err != nil {
+		t.Fatalf("backfill: %v", err)
+	}
+	if result.Scanned != 5 || result.Backfilled != 4 || result.Skipped != 1 || result.LastOrderID != "order-05" {
+		t.Fatalf("unexpected summary: %+v", result)
+	}
+
+	events := listTimeline(t, timeline, "order-01")
+	if len(events) != 1 {
+		t.Fatalf("expected one synthesized event, got %+v", events)
+	}
+	got := events[0]
+	if got.Type != eventOrderStatusChanged || got.Reason != string(domain.OrderStatusConfirmed) || !got.Occurred.Equal(legacyCreatedAt) {
+		t.Fatalf("unexpected synthesized event: %+v", got)
+	}
+	if events := listTimeline(t, timeline, "order-02"); len(events) != 1 || events[0].Type != "OrderCreated" {
+		t.Fatalf("orders with history must stay untouched, got %+v", events)
+	}
+
+	// Повторный прогон ничего не дописывает.
+	again, err := backfill(context.Background(), config{batchSize: 2, execute: true}, orders.(domain.OrderScanner), timeline, nil)
+	if err != nil || again.Backfilled != 0 || again.Skipped != 5 {
+		t.Fatalf("expected idempotent rerun, got %+v, %v", again, err)
+	}
+}
+
+func TestBackfill_DryRunWritesNothing(t *testing.T) {
+	orders := memory.NewOrderRepository()
+	timeline := memory.NewTimelineRepository()
+	seedLegacyOrders(t, orders, timeline, 3)
+	state, err := loadState(filepath.Join(t.TempDir(), "state.json"))
+	if err != nil {
+		t.Fatalf("loadState: %v", err)
+	}
+
+	result, err := backfill(context.Background(), config{batchSize: 10}, orders.(domain.OrderScanner), timeline, state)
+	if err != nil || result.Backfilled != 3 || result.Execute {
+		t.Fatalf("unexpected dry-run result: %+v, %v", result, err)
+	}
+	if events := listTimeline(t, timeline, "order-01"); len(events) != 0 {
+		t.Fatalf("dry-run must not write timeline, got %+v", events)
+	}
+	if state.LastOrderID != "" {
+		t.Fatalf("dry-run must not move the cursor, got %q", state.LastOrderID)
+	}
+}
+
+type failingTimeline struct {
+	domain.TimelineRepository
+	failOn string
+}
+
+func (f failingTimeline) Append(event domain.TimelineEvent) error {
+	if event.OrderID == f.failOn {
+		return errors.New("db down")
+	}
+	return f.TimelineRepository.Append(event)
+}
+
+func TestBackfill_ResumesFromSavedCursor(t *testing.T) {
+	orders := memory.NewOrderRepository()
+	timeline := memory.NewTimelineRepository()
+	seedLegacyOrders(t, orders, timeline, 5)
+	path := filepath.Join(t.TempDir(), "state.json")
+	state, err := loadState(path)
+	if err != nil {
+		t.Fatalf("loadState: %v", err)
+	}
+
+	_, err = backfill(context.Background(), config{batchSize: 2, execute: true}, orders.(domain.OrderScanner),
+		failingTimeline{TimelineRepository: timeline, failOn: "order-04"}, state)
+	if err == nil || !strings.Contains(err.Error(), "order-04") {
+		t.Fatalf("expected failure on order-04, got %v", err)
+	}
+
+	reloaded, err := loadState(path)
+	if err != nil {
+		t.Fatalf("reload state: %v", err)
+	}
+	if reloaded.lastOrderID() != "order-03" {
+		t.Fatalf("expected cursor at last completed order, got %q", reloaded.lastOrderID())
+	}
+
+	result, err := backfill(context.Background(), config{batchSize: 2, execute: true, afterID: reloaded.lastOrderID()}, orders.(domain.OrderScanner), timeline, reloaded)
+	if err != nil {
+		t.Fatalf("resume: %v", err)
+	}
+	if result.Scanned != 2 || result.Backfilled != 2 || result.LastOrderID != "order-05" {
+		t.Fatalf("expected resume to process only the rest, got %+v", result)
+	}
+	for i := 1; i <= 5; i++ {
+		if events := listTimeline(t, timeline, fmt.Sprintf("order-%02d", i)); len(events) != 1 {
+			t.Fatalf("order-%02d: expected exactly one event, got %+v", i, events)
+		}
+	}
+}
+
+func TestBackfill_Limit(t *testing.T) {
+	orders := memory.NewOrderRepository()
+	timeline := memory.NewTimelineRepository()
+	seedLegacyOrders(t, orders, timeline, 4, 1)
+
+	result, err := backfill(context.Background(), config{batchSize: 10, limit: 2, execute: true}, orders.(domain.OrderScanner), timeline, nil)
+	if err != nil {
+		t.Fatalf("backfill: %v", err)
+	}
+	if result.Backfilled != 2 || result.LastOrderID != "order-03" {
+		t.Fatalf("unexpected summary: %+v", result)
+	}
+	if events := listTimeline(t, timeline, "order-04"); len(events) != 0 {
+		t.Fatalf("orders beyond the limit must not be touched, got %+v", events)
+	}
+}
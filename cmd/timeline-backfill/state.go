@@ -0,0 +1,62 @@
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/vladislavdragonenkov/oms/internal/fileutil"
+)
+
+// backfillState — курсор прогона: ID последнего обработанного заказа. Повторный запуск
+// продолжает с него. Nil-состояние (пустой -state-file) ничего не помнит и ничего не пишет.
+type backfillState struct {
+	path        string
+	LastOrderID string    `json:"last_order_id"`
+	UpdatedAt   time.Time `json:"updated_at"`
+}
+
+// loadState читает курсор из path; отсутствие файла означает прогон с начала.
+func loadState(path string) (*backfillState, error) {
+	if path == "" {
+		return nil, nil
+	}
+	state := &backfillState{path: path}
+
+	data, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return state, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("read backfill state: %w", err)
+	}
+	if err := json.Unmarshal(data, state); err != nil {
+		return nil, fmt.Errorf("decode backfill state %s: %w", path, err)
+	}
+	return state, nil
+}
+
+func (s *backfillState) lastOrderID() string {
+	if s == nil {
+		return ""
+	}
+	return s.LastOrderID
+}
+
+// save пишет курсор атомарно, чтобы прерванный прогон не оставил битый файл.
+func (s *backfillState) save(lastOrderID string) error {
+	if s == nil {
+		return nil
+	}
+	s.LastOrderID, s.UpdatedAt = lastOrderID, time.Now().UTC()
+	data, err := json.MarshalIndent(s, "", "  ")
+	if err != nil {
+		return fmt.Errorf("encode backfill state: %w", err)
+	}
+	if err := fileutil.WriteFileAtomic(s.path, data); err != nil {
+		return fmt.Errorf("save backfill state: %w", err)
+	}
+	return nil
+}
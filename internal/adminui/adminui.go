@@ -0,0 +1,307 @@
+// Package adminui — read-only веб-страницы для дежурных: поиск заказов, карточка заказа
+// с timeline, backlog outbox и счётчики DLQ. Монтируется на HTTP-сервер метрик под basic auth.
+package adminui
+
+import (
+	"crypto/sha256"
+	"crypto/subtle"
+	"embed"
+	"errors"
+	"fmt"
+	"html/template"
+	"net/http"
+	"net/url"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	log "github.com/sirupsen/logrus"
+
+	"github.com/vladislavdragonenkov/oms/internal/domain"
+)
+
+// Prefix — путь, под которым UI монтируется на HTTP-сервер.
+const Prefix = "/admin/ui/"
+
+// searchLimit ограничивает выдачу поиска по покупателю и статусу.
+const searchLimit = 50
+
+// dlqDecisionsMetric — счётчик решений DLQ-политик consumer'ов (internal/messaging/kafka).
+const dlqDecisionsMetric = "oms_kafka_dlq_policy_decisions_total"
+
+//go:embed templates/*.html
+var templateFS embed.FS
+
+var pages = template.Must(template.New("").Funcs(template.FuncMap{
+	"money": formatMoney,
+	"ts":    formatTime,
+}).ParseFS(templateFS, "templates/*.html"))
+
+// CredentialVerifier находит пользователя по паролю basic auth. Реализуется *keyring.Keyring
+// с ключами keyring.ParseTokens ("user:password"): kid — логин, секрет — пароль; пароли
+// ротируются без рестарта (keyring.Watch).
+type CredentialVerifier interface {
+	VerifyToken(token string) (string, error)
+}
+
+// Sources — откуда UI читает данные. Gatherer nil — счётчики DLQ не показываются.
+type Sources struct {
+	Orders   domain.OrderRepository
+	Timeline domain.TimelineRepository
+	Outbox   domain.OutboxRepository
+	Gatherer prometheus.Gatherer
+}
+
+// Handler отдаёт страницы UI: Prefix — сводка и поиск, Prefix+"orders/{id}" — карточка заказа.
+// Все запросы, кроме GET с верными учётными данными, отклоняются.
+func Handler(src Sources, creds CredentialVerifier, logger *log.Entry) http.Handler {
+	if logger == nil {
+		logger = log.WithField("component", "admin-ui")
+	}
+	ui := &handler{src: src, logger: logger}
+	mux := http.NewServeMux()
+	mux.HandleFunc(Prefix+"{$}", ui.index)
+	mux.HandleFunc(Prefix+"orders/{id}", ui.order)
+	return RequireAuth(creds, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet {
+			w.Header().Set("Allow", "GET")
+			http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+			return
+		}
+		w.Header().Set("Cache-Control", "no-store")
+		w.Header().Set("X-Frame-Options", "DENY")
+		w.Header().Set("Content-Security-Policy", "default-src 'none'; style-src 'unsafe-inline'; form-action 'self'")
+		mux.ServeHTTP(w, r)
+	}))
+}
+
+// RequireAuth пропускает к next только запросы с учётными данными админки (basic auth, те же,
+// что у UI): находит владельца пароля и сравнивает его с логином по хешам за постоянное время.
+// Без creds отклоняет все запросы — закрытый эндпоинт не становится открытым из-за конфигурации.
+func RequireAuth(creds CredentialVerifier, next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if creds == nil {
+			http.Error(w, "admin credentials are not configured", http.StatusForbidden)
+			return
+		}
+		user, password, ok := r.BasicAuth()
+		owner, err := creds.VerifyToken(password)
+		gotUser := sha256.Sum256([]byte(user))
+		wantUser := sha256.Sum256([]byte(owner))
+		if !ok || err != nil || subtle.ConstantTimeCompare(gotUser[:], wantUser[:]) != 1 {
+			w.Header().Set("WWW-Authenticate", `Basic realm="oms admin", charset="UTF-8"`)
+			http.Error(w, "unauthorized", http.StatusUnauthorized)
+			return
+		}
+		next.ServeHTTP(w, r)
+	})
+}
+
+type handler struct {
+	src    Sources
+	logger *log.Entry
+}
+
+type outboxView struct {
+	Pending   int
+	OldestAge time.Duration
+	Error     string
+}
+
+type dlqView struct {
+	Policy     string
+	DeadLetter float64
+	Failed     float64
+}
+
+type indexPage struct {
+	Query    string
+	By       string
+	Statuses []domain.OrderStatus
+	Searched bool
+	Orders   []domain.Order
+	Error    string
+	Outbox   outboxView
+	DLQ      []dlqView
+	DLQKnown bool
+}
+
+type orderPage struct {
+	Order         domain.Order
+	Timeline      []domain.TimelineEvent
+	TimelineError string
+}
+
+var searchStatuses = []domain.OrderStatus{
+	domain.OrderStatusAwaitingApproval,
+	domain.OrderStatusPending,
+	domain.OrderStatusReserved,
+	domain.OrderStatusBackordered,
+	domain.OrderStatusAuthorized,
+	domain.OrderStatusPaid,
+	domain.OrderStatusConfirmed,
+	domain.OrderStatusOnHold,
+	domain.OrderStatusCanceled,
+	domain.OrderStatusRefunded,
+}
+
+func (h *handler) index(w http.ResponseWriter, r *http.Request) {
+	page := indexPage{
+		Query:    strings.TrimSpace(r.URL.Query().Get("q")),
+		By:       r.URL.Query().Get("by"),
+		Statuses: searchStatuses,
+		Outbox:   h.outbox(),
+	}
+	page.DLQ, page.DLQKnown = h.dlq()
+
+	if page.Query != "" {
+		if page.By == "id" || page.By == "" {
+			// Точный ID сразу открывает карточку: основной сценарий дежурного.
+			if _, err := h.src.Orders.Get(page.Query); err == nil {
+				http.Redirect(w, r, Prefix+"orders/"+url.PathEscape(page.Query), http.StatusFound)
+				return
+			}
+		}
+		page.Searched = true
+		page.Orders, page.Error = h.search(page.By, page.Query)
+	}
+	h.render(w, http.StatusOK, "index.html", page)
+}
+
+func (h *handler) search(by, query string) ([]domain.Order, string) {
+	var (
+		orders []domain.Order
+		err    error
+	)
+	switch by {
+	case "", "id":
+		order, getErr := h.src.Orders.Get(query)
+		if getErr == nil {
+			orders = []domain.Order{order}
+		} else if !errors.Is(getErr, domain.ErrOrderNotFound) {
+			err = getErr
+		}
+	case "customer":
+		orders, err = h.src.Orders.ListByCustomer(query, searchLimit)
+	case "status":
+		orders, err = h.src.Orders.ListByStatus(domain.OrderStatus(query), searchLimit)
+	default:
+		return nil, fmt.Sprintf("unknown search field %q", by)
+	}
+	if err != nil {
+		h.logger.WithError(err).WithField("by", by).Error("admin ui search failed")
+		return nil, "search failed: " + err.Error()
+	}
+	return orders, ""
+}
+
+func (h *handler) order(w http.ResponseWriter, r *http.Request) {
+	id := r.PathValue("id")
+	order, err := h.src.Orders.Get(id)
+	switch {
+	case errors.Is(err, domain.ErrOrderNotFound):
+		http.Error(w, domain.ErrOrderNotFound.Error(), http.StatusNotFound)
+		return
+	case err != nil:
+		h.logger.WithError(err).WithField("order_id", id).Error("admin ui failed to load order")
+		http.Error(w, "failed to load order", http.StatusInternalServerError)
+		return
+	}
+	page := orderPage{Order: order}
+	if h.src.Timeline != nil {
+		if page.Timeline, err = h.src.Timeline.List(id); err != nil {
+			page.TimelineError = err.Error()
+		}
+	}
+	h.render(w, http.StatusOK, "order.html", page)
+}
+
+func (h *handler) outbox() outboxView {
+	if h.src.Outbox == nil {
+		return outboxView{Error: "outbox is not configured"}
+	}
+	stats, err := h.src.Outbox.Stats()
+	if err != nil {
+		return outboxView{Error: err.Error()}
+	}
+	view := outboxView{Pending: stats.PendingCount}
+	if !stats.OldestPendingAt.IsZero() {
+		view.OldestAge = time.Since(stats.OldestPendingAt).Round(time.Second)
+	}
+	return view
+}
+
+// dlq суммирует решения dead_letter и dead_letter_failed по политикам. Счётчики живут с момента
+// запуска инстанса: это сигнал «DLQ растёт», а не размер топика.
+func (h *handler) dlq() ([]dlqView, bool) {
+	if h.src.Gatherer == nil {
+		return nil, false
+	}
+	families, err := h.src.Gatherer.Gather()
+	if err != nil {
+		h.logger.WithError(err).Warn("admin ui failed to gather dlq metrics")
+		return nil, false
+	}
+	byPolicy := make(map[string]*dlqView)
+	for _, family := range families {
+		if family.GetName() != dlqDecisionsMetric {
+			continue
+		}
+		for _, metric := range family.GetMetric() {
+			var policy, decision string
+			for _, label := range metric.GetLabel() {
+				switch label.GetName() {
+				case "policy":
+					policy = label.GetValue()
+				case "decision":
+					decision = label.GetValue()
+				}
+			}
+			view, ok := byPolicy[policy]
+			if !ok {
+				view = &dlqView{Policy: policy}
+				byPolicy[policy] = view
+			}
+			switch decision {
+			case "dead_letter":
+				view.DeadLetter += metric.GetCounter().GetValue()
+			case "dead_letter_failed":
+				view.Failed += metric.GetCounter().GetValue()
+			}
+		}
+	}
+	views := make([]dlqView, 0, len(byPolicy))
+	for _, view := range byPolicy {
+		views = append(views, *view)
+	}
+	sort.Slice(views, func(i, j int) bool { return views[i].Policy < views[j].Policy })
+	return views, true
+}
+
+func (h *handler) render(w http.ResponseWriter, status int, name string, data any) {
+	var buf strings.Builder
+	if err := pages.ExecuteTemplate(&buf, name, data); err != nil {
+		h.logger.WithError(err).WithField("template", name).Error("admin ui render failed")
+		http.Error(w, "render failed", http.StatusInternalServerError)
+		return
+	}
+	w.Header().Set("Content-Type", "text/html; charset=utf-8")
+	w.WriteHeader(status)
+	_, _ = w.Write([]byte(buf.String()))
+}
+
+func formatMoney(amountMinor int64, currency string) string {
+	sign := ""
+	if amountMinor < 0 {
+		sign, amountMinor = "-", -amountMinor
+	}
+	return fmt.Sprintf("%s%d.%02d %s", sign, amountMinor/100, amountMinor%100, currency)
+}
+
+func formatTime(t time.Time) string {
+	if t.IsZero() {
+		return "—"
+	}
+	return t.UTC().Format(time.RFC3339)
+}
@@ -0,0 +1,168 @@
+package adminui
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+
+	"github.com/vladislavdragonenkov/oms/internal/domain"
+	"github.com/vladislavdragonenkov/oms/internal/keyring"
+	"github.com/vladislavdragonenkov/oms/internal/storage/memory"
+)
+
+const (
+	testUser     = "oncall"
+	testPassword = "secret"
+)
+
+func newTestCredentials(t *testing.T) *keyring.Keyring {
+	t.Helper()
+	keys, err := keyring.ParseTokens(testUser + ":" + testPassword + ",auditor:audit-pass")
+	if err != nil {
+		t.Fatalf("parse credentials: %v", err)
+	}
+	creds, err := keyring.New(keys, keyring.WithRegisterer(prometheus.NewRegistry()))
+	if err != nil {
+		t.Fatalf("new keyring: %v", err)
+	}
+	return creds
+}
+
+func newTestHandler(t *testing.T) http.Handler {
+	t.Helper()
+	orders := memory.NewOrderRepository()
+	now := time.Now().UTC()
+	for _, order := range []domain.Order{
+		{ID: "order-1", CustomerID: "customer-1", Status: domain.OrderStatusPaid, Currency: "USD", AmountMinor: 1250,
+			Items: []domain.OrderItem{{ID: "item-1", SKU: "sku-<1>", Qty: 1, PriceMinor: 1250}}, CreatedAt: now, UpdatedAt: now},
+		{ID: "order-2", CustomerID: "customer-1", Status: domain.OrderStatusPending, Currency: "USD", AmountMinor: 100,
+			Items: []domain.OrderItem{{ID: "item-2", SKU: "sku-2", Qty: 1, PriceMinor: 100}}, CreatedAt: now, UpdatedAt: now},
+	} {
+		if err := orders.Create(order); err != nil {
+			t.Fatalf("create order: %v", err)
+		}
+	}
+	timeline := memory.NewTimelineRepository()
+	if err := timeline.Append(domain.TimelineEvent{OrderID: "order-1", Type: "OrderPaid", Reason: "psp-ok", Occurred: now}); err != nil {
+		t.Fatalf("append timeline: %v", err)
+	}
+	outbox := memory.NewOutboxRepository()
+	if _, err := outbox.Enqueue(domain.OutboxMessage{AggregateType: "order", AggregateID: "order-1", EventType: "OrderPaid"}); err != nil {
+		t.Fatalf("enqueue: %v", err)
+	}
+
+	registry := prometheus.NewRegistry()
+	decisions := prometheus.NewCounterVec(prometheus.CounterOpts{Name: dlqDecisionsMetric, Help: "test"}, []string{"policy", "decision"})
+	registry.MustRegister(decisions)
+	decisions.WithLabelValues("oms-payments", "dead_letter").Add(3)
+	decisions.WithLabelValues("oms-payments", "dead_letter_failed").Inc()
+	decisions.WithLabelValues("oms-payments", "retry").Add(10)
+
+	return Handler(Sources{Orders: orders, Timeline: timeline, Outbox: outbox, Gatherer: registry}, newTestCredentials(t), nil)
+}
+
+func get(handler http.Handler, target string, authorized bool) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(http.MethodGet, target, nil)
+	if authorized {
+		req.SetBasicAuth(testUser, testPassword)
+	}
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, req)
+	return rec
+}
+
+func TestHandler_RequiresAuth(t *testing.T) {
+	handler := newTestHandler(t)
+
+	rec := get(handler, Prefix, false)
+	if rec.Code != http.StatusUnauthorized || rec.Header().Get("WWW-Authenticate") == "" {
+		t.Fatalf("expected basic auth challenge, got %d", rec.Code)
+	}
+	req := httptest.NewRequest(http.MethodGet, Prefix, nil)
+	req.SetBasicAuth(testUser, "wrong")
+	rec = httptest.NewRecorder()
+	handler.ServeHTTP(rec, req)
+	if rec.Code != http.StatusUnauthorized {
+		t.Fatalf("expected 401 for wrong password, got %d", rec.Code)
+	}
+	req = httptest.NewRequest(http.MethodGet, Prefix, nil)
+	req.SetBasicAuth(testUser, "audit-pass")
+	rec = httptest.NewRecorder()
+	handler.ServeHTTP(rec, req)
+	if rec.Code != http.StatusUnauthorized {
+		t.Fatalf("expected 401 for another user's password, got %d", rec.Code)
+	}
+
+	req = httptest.NewRequest(http.MethodPost, Prefix, nil)
+	req.SetBasicAuth(testUser, testPassword)
+	rec = httptest.NewRecorder()
+	handler.ServeHTTP(rec, req)
+	if rec.Code != http.StatusMethodNotAllowed {
+		t.Fatalf("expected 405 for POST, got %d", rec.Code)
+	}
+	// Без учётных данных закрытый эндпоинт отклоняет всё.
+	rec = get(RequireAuth(nil, http.NotFoundHandler()), Prefix, true)
+	if rec.Code != http.StatusForbidden {
+		t.Fatalf("expected 403 without configured credentials, got %d", rec.Code)
+	}
+}
+
+func TestHandler_OverviewShowsOutboxAndDLQ(t *testing.T) {
+	rec := get(newTestHandler(t), Prefix, true)
+	if rec.Code != http.StatusOK {
+		t.Fatalf("unexpected status %d: %s", rec.Code, rec.Body.String())
+	}
+	body := rec.Body.String()
+	for _, want := range []string{"<tr><th>Pending</th><td>1</td></tr>", "<td>oms-payments</td><td>3</td><td>1</td>"} {
+		if !strings.Contains(body, want) {
+			t.Fatalf("expected %q in overview:\n%s", want, body)
+		}
+	}
+}
+
+func TestHandler_Search(t *testing.T) {
+	handler := newTestHandler(t)
+
+	rec := get(handler, Prefix+"?by=id&q=order-1", true)
+	if rec.Code != http.StatusFound || rec.Header().Get("Location") != Prefix+"orders/order-1" {
+		t.Fatalf("expected redirect to order page, got %d %q", rec.Code, rec.Header().Get("Location"))
+	}
+
+	rec = get(handler, Prefix+"?by=customer&q=customer-1", true)
+	if rec.Code != http.StatusOK || !strings.Contains(rec.Body.String(), "orders/order-1") || !strings.Contains(rec.Body.String(), "orders/order-2") {
+		t.Fatalf("expected both customer orders, got %d:\n%s", rec.Code, rec.Body.String())
+	}
+
+	rec = get(handler, Prefix+"?by=status&q=pending", true)
+	if body := rec.Body.String(); !strings.Contains(body, "orders/order-2") || strings.Contains(body, "orders/order-1") {
+		t.Fatalf("expected only pending order:\n%s", body)
+	}
+
+	rec = get(handler, Prefix+"?by=id&q=missing", true)
+	if rec.Code != http.StatusOK || !strings.Contains(rec.Body.String(), "No orders found.") {
+		t.Fatalf("expected empty result, got %d", rec.Code)
+	}
+}
+
+func TestHandler_OrderPage(t *testing.T) {
+	handler := newTestHandler(t)
+
+	rec := get(handler, Prefix+"orders/order-1", true)
+	if rec.Code != http.StatusOK {
+		t.Fatalf("unexpected status %d", rec.Code)
+	}
+	body := rec.Body.String()
+	for _, want := range []string{"12.50 USD", "OrderPaid", "psp-ok", "sku-&lt;1&gt;"} {
+		if !strings.Contains(body, want) {
+			t.Fatalf("expected %q in order page:\n%s", want, body)
+		}
+	}
+
+	if rec := get(handler, Prefix+"orders/missing", true); rec.Code != http.StatusNotFound {
+		t.Fatalf("expected 404, got %d", rec.Code)
+	}
+}
@@ -0,0 +1,44 @@
+package app
+
+import (
+	"errors"
+	"time"
+
+	"github.com/vladislavdragonenkov/oms/internal/keyring"
+)
+
+// accessTokensReloadInterval — как часто перечитываются файлы с токенами админских интерфейсов.
+const accessTokensReloadInterval = time.Minute
+
+// errAccessTokensConflict — токены заданы и значением env, и файлом.
+var errAccessTokensConflict = errors.New("set either the value or the file, not both")
+
+// loadAccessTokens собирает keyring токенов доступа ("owner:secret", формат keyring.ParseTokens)
+// из значения env или из файла. Для файла возвращается source, которым keyring перечитывается
+// без рестарта (keyring.Watch). Оба пустые — nil: интерфейс выключен.
+func loadAccessTokens(raw, path string) (*keyring.Keyring, keyring.Source, error) {
+	var source keyring.Source
+	switch {
+	case raw != "" && path != "":
+		return nil, nil, errAccessTokensConflict
+	case path != "":
+		source = keyring.TokenFileSource(path)
+	case raw != "":
+		source = func() ([]keyring.Key, error) { return keyring.ParseTokens(raw) }
+	default:
+		return nil, nil, nil
+	}
+
+	keys, err := source()
+	if err != nil {
+		return nil, nil, err
+	}
+	tokens, err := keyring.New(keys)
+	if err != nil {
+		return nil, nil, err
+	}
+	if path == "" {
+		return tokens, nil, nil
+	}
+	return tokens, source, nil
+}
@@ -6,27 +6,29 @@ import (
 	"fmt"
 	"net"
 	"net/http"
-	"os"
 	"strings"
 	"time"
 
-	promgrpc "github.com/grpc-ecosystem/go-grpc-prometheus"
-	"github.com/prometheus/client_golang/prometheus/promhttp"
 	log "github.com/sirupsen/logrus"
 	"google.golang.org/grpc"
-	"google.golang.org/grpc/health"
-	healthpb "google.golang.org/grpc/health/grpc_health_v1"
-	"google.golang.org/grpc/reflection"
+	"google.golang.org/grpc/credentials/insecure"
 
+	"github.com/vladislavdragonenkov/oms/internal/adminui"
+	"github.com/vladislavdragonenkov/oms/internal/ctxutil"
 	"github.com/vladislavdragonenkov/oms/internal/domain"
 	healthcheck "github.com/vladislavdragonenkov/oms/internal/health"
 	"github.com/vladislavdragonenkov/oms/internal/messaging/kafka"
+	"github.com/vladislavdragonenkov/oms/internal/metrics"
+	"github.com/vladislavdragonenkov/oms/internal/metrics/otlp"
+	"github.com/vladislavdragonenkov/oms/internal/openapi"
+	"github.com/vladislavdragonenkov/oms/internal/saturation"
+	"github.com/vladislavdragonenkov/oms/internal/service/canary"
 	grpcsvc "github.com/vladislavdragonenkov/oms/internal/service/grpc"
-	idempotencysvc "github.com/vladislavdragonenkov/oms/internal/service/idempotency"
 	"github.com/vladislavdragonenkov/oms/internal/service/inventory"
-	outboxsvc "github.com/vladislavdragonenkov/oms/internal/service/outbox"
+	"github.com/vladislavdragonenkov/oms/internal/service/ordercache"
 	"github.com/vladislavdragonenkov/oms/internal/service/payment"
 	"github.com/vladislavdragonenkov/oms/internal/service/saga"
+	"github.com/vladislavdragonenkov/oms/internal/slo"
 	"github.com/vladislavdragonenkov/oms/internal/storage/memory"
 	"github.com/vladislavdragonenkov/oms/internal/storage/postgres"
 	"github.com/vladislavdragonenkov/oms/internal/version"
@@ -56,8 +58,150 @@ type Config struct {
 	OutboxMaxAttempts           int
 	OutboxRetryDelay            time.Duration
 	OutboxMaxPending            int
+	OutboxCleanupInterval       time.Duration
+	OutboxSentRetention         time.Duration
 	IdempotencyCleanupInterval  time.Duration
 	IdempotencyCleanupBatchSize int
+	IdempotencyStaleProcessing  time.Duration
+	InventoryReconcileInterval  time.Duration
+	InventoryReconcileDryRun    bool
+	AmountCheckInterval         time.Duration
+	AmountCheckRepair           bool
+	CanaryInterval              time.Duration
+	CanaryTimeout               time.Duration
+	SagaTimeout                 time.Duration
+	// SagaHookTimeout ограничивает один вызов хука плагина саги (saga.RegisterPlugin).
+	SagaHookTimeout time.Duration
+	// FeatureFlags — переопределения фичефлагов в формате "read_cache=true,shedding=false".
+	FeatureFlags string
+	// KafkaDLQPolicies — политики повторов и DLQ consumer group'ов, формат kafka.ParseDLQPolicies.
+	KafkaDLQPolicies string
+	// KafkaTopicPrefix — префикс окружения для топиков и consumer group'ов (см. kafka.NewTopicConfig).
+	KafkaTopicPrefix string
+	// KafkaKeyStrategies — стратегии ключей сообщений по топикам, формат TopicConfig.WithKeyStrategies.
+	KafkaKeyStrategies string
+	// KafkaCodecs — форматы сериализации событий по топикам, формат TopicConfig.WithCodecs.
+	KafkaCodecs string
+	// KafkaProducerBreakerThreshold — число подряд неудачных отправок, после которого producer
+	// перестаёт обращаться к брокеру на KafkaProducerBreakerCooldown; 0 выключает breaker.
+	KafkaProducerBreakerThreshold int
+	KafkaProducerBreakerCooldown  time.Duration
+	// DependencyBreakerThreshold — число подряд отказов склада или PSP, после которого вызовы к нему
+	// DependencyBreakerCooldown сразу завершаются временной ошибкой; 0 выключает breaker.
+	DependencyBreakerThreshold int
+	DependencyBreakerCooldown  time.Duration
+	// KafkaSagaEventBuffer — ёмкость очереди досылки событий саги; 0 — события при ошибке теряются.
+	KafkaSagaEventBuffer int
+	// PaymentEventsParkTTL — сколько событие PSP ждёт в памяти появления заказа или нужного шага саги
+	// (флаг payment_events); по истечении событие учитывается как expired и отбрасывается.
+	PaymentEventsParkTTL time.Duration
+	// KafkaConsumerConcurrency — сколько сообщений consumer group обрабатывает одновременно по всем
+	// партициям; 0 — без ограничения.
+	KafkaConsumerConcurrency int
+	// EventEncryptionKeys — ключи шифрования полей событий "kid:base64(32 байта),...", первый — primary.
+	// Пусто — шифрование выключено.
+	EventEncryptionKeys string
+	// EventEncryptedFields — поля payload событий, которые шифруются при включённом шифровании.
+	EventEncryptedFields string
+	// OrderQuotas — дневные квоты CreateOrder по principal'ам, формат grpcsvc.ParseOrderQuotas.
+	// Пусто — квоты выключены.
+	OrderQuotas string
+	// ApprovalCustomers — customer_id через запятую, чьи заказы ждут ApproveOrder перед обработкой
+	// (B2B-аккаунты), формат grpcsvc.ParseApprovalCustomers. Пусто — согласование выключено.
+	ApprovalCustomers string
+	// AdminTokens — токены операторов AdminService "operator:token,...", формат keyring.ParseTokens;
+	// секрет. AdminTokensFile — файл в том же формате, перечитывается без рестарта (ротация);
+	// задаётся что-то одно. Оба пусты — AdminService не регистрируется.
+	AdminTokens     string
+	AdminTokensFile string
+	// CatalogPrices — прайс-лист для RecalculateOrder, формат catalog.ParseStaticCatalog.
+	// Пусто — пересчёт заказов недоступен.
+	CatalogPrices string
+	// DevPersistPath — JSON-файл, в который memory-хранилище сохраняется при остановке
+	// и из которого восстанавливается при старте. Только для локальной разработки и демо.
+	DevPersistPath string
+	// StorageChaos — отказы, внедряемые в хранилище саги, формат chaos.ParseRules. Пусто — выключено;
+	// включается только при MetricsEnvironment, отличном от production.
+	StorageChaos string
+	// GRPCLogSampleRate — доля RPC (0..1), которые interceptor пишет в debug-лог.
+	GRPCLogSampleRate float64
+	// GRPCSlowRequestThreshold — unary RPC дольше порога логируются на warn всегда; 0 — выключено.
+	GRPCSlowRequestThreshold time.Duration
+	// LogLevels — уровни логирования по компонентам поверх LOG_LEVEL, формат logging.Parse
+	// ("saga=debug,kafka=warn"); элемент без "=" переопределяет общий уровень.
+	LogLevels string
+	// LogLevelsFile — файл с уровнями в том же формате, перечитывается по SIGHUP. Пусто — SIGHUP
+	// возвращает уровни из LOG_LEVEL и LogLevels.
+	LogLevelsFile string
+	// TuningFile — файл с параметрами воркеров (формат tuning.Parse) поверх значений из env.
+	// Перечитывается по SIGHUP, при изменении файла и через POST /admin/tuning. Пусто — параметры
+	// меняются только рестартом.
+	TuningFile string
+	// GRPCConcurrencyLimits — лимиты одновременных unary RPC по методам, формат
+	// grpcsvc.ParseConcurrencyLimits. Пусто — методы не ограничиваются.
+	GRPCConcurrencyLimits string
+	// ScheduledCancelInterval — период опроса отложенных отмен заказов; 0 — отмены не выполняются.
+	ScheduledCancelInterval time.Duration
+	// SLOObjectives — SLO для burn-rate метрик, формат slo.ParseObjectives. Пусто — экспортёр выключен.
+	SLOObjectives string
+	// SLOInterval — период пересчёта oms_slo_error_budget_burn.
+	SLOInterval time.Duration
+	// SaturationInterval — период пересчёта oms_saturation_ratio; 0 — сигнал не публикуется.
+	SaturationInterval time.Duration
+	// SaturationSagaLimit — число одновременных саг, соответствующее полной загрузке.
+	// Лимит outbox берётся из OutboxMaxPending.
+	SaturationSagaLimit int
+	// SaturationInFlightRPCLimit — число одновременных unary RPC, соответствующее полной загрузке.
+	SaturationInFlightRPCLimit int
+	// ListDefaultPageSize и ListMaxPageSize — размер страницы списков без page_size и верхняя
+	// граница page_size; больший запрос отклоняется с InvalidArgument.
+	ListDefaultPageSize int
+	ListMaxPageSize     int
+	// InventoryRoutes — маршрутизация резервов по нескольким складам, формат inventory.ParseRoutes.
+	// Пусто — один склад на все заказы.
+	InventoryRoutes string
+	// GRPCAdminEnabled регистрирует на gRPC-порту channelz и admin-сервисы gRPC (для grpcdebug).
+	// Выключено по умолчанию: сервисы раскрывают адреса клиентов и состояние соединений.
+	GRPCAdminEnabled bool
+	// PaymentAuthorizationTTL — срок блокировки суммы у PSP при двухфазной оплате (authorized → CaptureOrder).
+	PaymentAuthorizationTTL time.Duration
+	// PaymentAuthorizationCheckInterval — период проверки истекающих блокировок; 0 — не проверяются.
+	PaymentAuthorizationCheckInterval time.Duration
+	// PaymentMaxReauthorizations — сколько раз истекающая блокировка продлевается до отмены заказа.
+	PaymentMaxReauthorizations int
+	// PaymentRetryMaxAttempts — сколько раз повторяется оплата после временной ошибки PSP, пока
+	// заказ держит резерв в reserved; 0 — заказ отменяется сразу, как раньше.
+	PaymentRetryMaxAttempts int
+	// PaymentRetryBaseDelay — пауза перед первым повтором; каждая следующая вдвое длиннее.
+	PaymentRetryBaseDelay time.Duration
+	// PaymentRetryMaxDelay ограничивает паузу между повторами.
+	PaymentRetryMaxDelay time.Duration
+	// PaymentRetryInterval — период опроса наступивших повторов.
+	PaymentRetryInterval time.Duration
+	// OrderListCacheTTL — срок жизни закэшированного ListOrders; 0 — кэш выключен. Он же ограничивает
+	// устаревание списка от записей других инстансов: их события до этого кэша не доходят.
+	OrderListCacheTTL time.Duration
+	// OrderListCacheMaxCustomers — сколько покупателей держит кэш списков до вытеснения.
+	OrderListCacheMaxCustomers int
+	// DuplicateOrderWindow — окно, в котором CreateOrder с тем же покупателем и составом возвращает
+	// уже созданный заказ вместо двойника (клиент потерял idempotency-key); 0 — проверка выключена.
+	DuplicateOrderWindow time.Duration
+	// MetricsNamespace заменяет префикс oms_ в именах метрик на /metrics.
+	MetricsNamespace string
+	// MetricsEnvironment и MetricsRegion добавляются лейблами environment и region ко всем сериям,
+	// чтобы несколько развёртываний делили один Prometheus; пусто — лейбл не добавляется.
+	MetricsEnvironment string
+	MetricsRegion      string
+	// OTLPMetricsEndpoint — OTLP/HTTP endpoint, куда метрики /metrics дополнительно отправляются
+	// каждые OTLPMetricsInterval; пусто — отправка выключена. OTLPMetricsHeaders — формат otlp.ParseHeaders.
+	OTLPMetricsEndpoint string
+	OTLPMetricsHeaders  string
+	OTLPMetricsInterval time.Duration
+	// AdminUICredentials — "user:password,..." basic auth для веб-страниц /admin/ui/ на HTTP-сервере
+	// метрик, формат keyring.ParseTokens. AdminUICredentialsFile — то же из файла, перечитывается
+	// без рестарта; задаётся что-то одно. Оба пусты — UI выключен.
+	AdminUICredentials     string
+	AdminUICredentialsFile string
 }
 
 // DefaultConfig возвращает базовые адреса для gRPC и HTTP-метрик.
@@ -73,182 +217,82 @@ func DefaultConfig() Config {
 		OutboxMaxAttempts:           3,
 		OutboxRetryDelay:            50 * time.Millisecond,
 		OutboxMaxPending:            10000,
+		OutboxCleanupInterval:       time.Hour,
+		OutboxSentRetention:         7 * 24 * time.Hour,
 		IdempotencyCleanupInterval:  10 * time.Minute,
 		IdempotencyCleanupBatchSize: 500,
+		IdempotencyStaleProcessing:  10 * time.Minute,
+		InventoryReconcileInterval:  5 * time.Minute,
+		InventoryReconcileDryRun:    false,
+		AmountCheckInterval:         time.Hour,
+		AmountCheckRepair:           false,
+		CanaryInterval:              0,
+		CanaryTimeout:               30 * time.Second,
+		SagaTimeout:                 saga.DefaultTimeout,
+		SagaHookTimeout:             2 * time.Second,
+		EventEncryptedFields:        "customer_id",
+		GRPCLogSampleRate:           grpcsvc.DefaultRequestLogSampleRate,
+		GRPCSlowRequestThreshold:    grpcsvc.DefaultSlowRequestThreshold,
+		SLOInterval:                 slo.DefaultInterval,
+		ScheduledCancelInterval:     30 * time.Second,
+		SaturationInterval:          saturation.DefaultInterval,
+		SaturationSagaLimit:         200,
+		SaturationInFlightRPCLimit:  100,
+		ListDefaultPageSize:         grpcsvc.DefaultListPageSize,
+		ListMaxPageSize:             grpcsvc.DefaultMaxListPageSize,
+
+		KafkaProducerBreakerThreshold: 5,
+		KafkaProducerBreakerCooldown:  10 * time.Second,
+		DependencyBreakerCooldown:     30 * time.Second,
+		KafkaSagaEventBuffer:          1000,
+		PaymentEventsParkTTL:          15 * time.Minute,
+
+		PaymentAuthorizationTTL:           saga.DefaultAuthorizationTTL,
+		PaymentAuthorizationCheckInterval: time.Minute,
+		PaymentMaxReauthorizations:        1,
+
+		PaymentRetryMaxAttempts: 3,
+		PaymentRetryBaseDelay:   30 * time.Second,
+		PaymentRetryMaxDelay:    5 * time.Minute,
+		PaymentRetryInterval:    10 * time.Second,
+
+		OrderListCacheMaxCustomers: ordercache.DefaultMaxCustomers,
+
+		OTLPMetricsInterval: otlp.DefaultInterval,
+
+		MetricsNamespace: metrics.DefaultNamespace,
 	}
 }
 
+// Run собирает приложение и работает до отмены ctx или остановки gRPC-сервера.
+// Ошибка сборки или запуска оборачивает ErrStartupFailed, брошенные при остановке компоненты — ErrDrainTimeout.
 func Run(ctx context.Context, cfg Config) error {
-	logger := log.WithField("component", "app")
-
-	if err := validateMockIntegrationsPolicy(cfg); err != nil {
-		return err
-	}
-
-	runtimeDeps, err := initRuntimeDependencies(ctx, cfg, logger)
+	application, err := BuildApp(ctx, cfg)
 	if err != nil {
-		return err
-	}
-	defer func() {
-		if runtimeDeps.closeFn == nil {
-			return
-		}
-		if closeErr := runtimeDeps.closeFn(); closeErr != nil {
-			logger.WithError(closeErr).Warn("failed to close storage")
-		}
-	}()
-
-	deps := newAppDependencies(runtimeDeps, logger)
-
-	// Kafka producer опционален: если брокер недоступен, сервис продолжает работу.
-	var kafkaProducer *kafka.Producer
-	var outboxWorkerCancel context.CancelFunc
-	var outboxWorkerDone chan struct{}
-	var idempotencyCleanupCancel context.CancelFunc
-	var idempotencyCleanupDone chan struct{}
-	var outboxChecker healthcheck.Checker
-	var sagaOrchestrator saga.Orchestrator
-
-	if runtimeDeps.idempotencyRepo != nil && cfg.IdempotencyCleanupInterval > 0 {
-		cleanupWorker := idempotencysvc.NewCleanupWorker(
-			runtimeDeps.idempotencyRepo,
-			idempotencysvc.WithLogger(logger.WithField("component", "idempotency-cleanup-worker")),
-			idempotencysvc.WithInterval(cfg.IdempotencyCleanupInterval),
-			idempotencysvc.WithBatchSize(cfg.IdempotencyCleanupBatchSize),
-		)
-		cleanupCtx, cleanupCancel := context.WithCancel(ctx)
-		idempotencyCleanupCancel = cleanupCancel
-		idempotencyCleanupDone = make(chan struct{})
-		go func() {
-			defer close(idempotencyCleanupDone)
-			cleanupWorker.Run(cleanupCtx)
-		}()
-	}
-
-	rawKafkaBrokers := os.Getenv("KAFKA_BROKERS")
-	brokers := parseKafkaBrokers(rawKafkaBrokers)
-	if strings.TrimSpace(rawKafkaBrokers) != "" && len(brokers) == 0 {
-		return fmt.Errorf("KAFKA_BROKERS is set but no valid broker addresses were parsed")
-	}
-	if len(brokers) > 0 {
-		producer, err := initKafkaProducerWithRetry(ctx, brokers, logger, kafkaInitTimeout, kafkaInitRetryDelay)
-		if err != nil {
-			return err
-		}
-
-		kafkaProducer = producer
-		logger.WithField("brokers", brokers).Info("kafka producer initialized")
-
-		outboxWorker := outboxsvc.NewWorker(
-			deps.OutboxRepo,
-			kafka.NewOutboxPublisher(kafkaProducer, kafka.TopicOrderEvents),
-			outboxsvc.WithDLQPublisher(kafka.NewOutboxPublisher(kafkaProducer, kafka.TopicDeadLetterQueue)),
-			outboxsvc.WithLogger(logger.WithField("component", "outbox-worker")),
-			outboxsvc.WithPollInterval(cfg.OutboxPollInterval),
-			outboxsvc.WithBatchSize(cfg.OutboxBatchSize),
-			outboxsvc.WithMaxAttempts(cfg.OutboxMaxAttempts),
-			outboxsvc.WithRetryBaseDelay(cfg.OutboxRetryDelay),
-		)
-		workerCtx, workerCancel := context.WithCancel(ctx)
-		outboxWorkerCancel = workerCancel
-		outboxWorkerDone = make(chan struct{})
-		go func() {
-			defer close(outboxWorkerDone)
-			outboxWorker.Run(workerCtx)
-		}()
-
-		outboxChecker = healthcheck.NewSimpleChecker("outbox", func() error {
-			stats, err := deps.OutboxRepo.Stats()
-			if err != nil {
-				return err
-			}
-			if cfg.OutboxMaxPending > 0 && stats.PendingCount > cfg.OutboxMaxPending {
-				return fmt.Errorf("outbox backlog %d exceeds threshold %d", stats.PendingCount, cfg.OutboxMaxPending)
-			}
-			return nil
-		})
-
-		sagaOrchestrator = createOrchestrator(deps, kafkaProducer)
-	}
-
-	// Если Kafka не настроен, используем обычный orchestrator
-	if sagaOrchestrator == nil {
-		sagaOrchestrator = createOrchestrator(deps, nil)
+		return fmt.Errorf("%w: %w", ErrStartupFailed, err)
 	}
+	return application.Run(ctx)
+}
 
-	serviceLogger := logger.WithField("layer", "grpc")
-	orderService := grpcsvc.NewOrderService(deps.Repo, deps.TimelineRepo, runtimeDeps.idempotencyRepo, sagaOrchestrator, serviceLogger)
-	courierService := grpcsvc.NewCourierService(deps.CourierRepo, serviceLogger.WithField("service", "courier"))
-	grpcMetrics := promgrpc.DefaultServerMetrics
-	grpcServer := grpc.NewServer(grpc.ChainUnaryInterceptor(grpcMetrics.UnaryServerInterceptor()))
-
-	omsv1.RegisterOrderServiceServer(grpcServer, orderService)
-	omsv1.RegisterCourierServiceServer(grpcServer, courierService)
-	grpcMetrics.InitializeMetrics(grpcServer)
-
-	// Register reflection service for grpcurl and load testing tools
-	reflection.Register(grpcServer)
-
-	healthServer := health.NewServer()
-	healthServer.SetServingStatus("", healthpb.HealthCheckResponse_SERVING)
-	healthpb.RegisterHealthServer(grpcServer, healthServer)
-
-	// HTTP Health checks
-	healthHandler := healthcheck.NewHandler(version.GetVersion())
-	if runtimeDeps.storageChecker != nil {
-		healthHandler.RegisterChecker("storage", runtimeDeps.storageChecker)
-	}
-	if outboxChecker != nil {
-		healthHandler.RegisterChecker("outbox", outboxChecker)
+// restoreDevSnapshot загружает снимок memory-хранилища и возвращает closeFn, сохраняющую его
+// при остановке. Пустой path — снимки выключены, closeFn == nil.
+func restoreDevSnapshot(path string, repos memory.SnapshotRepositories, logger *log.Entry) (func() error, error) {
+	if path == "" {
+		return nil, nil
 	}
-
-	metricsSrv := startMetricsServer(ctx, cfg.MetricsAddr, logger, healthHandler)
-
-	lis, err := net.Listen("tcp", cfg.GRPCAddr)
+	restored, err := memory.LoadSnapshotFile(path, repos)
 	if err != nil {
-		return err
+		return nil, fmt.Errorf("restore dev snapshot %s: %w", path, err)
 	}
+	logger.WithFields(log.Fields{"path": path, "restored": restored}).Warn("memory storage persists to a dev snapshot file; not for production")
 
-	errCh := make(chan error, 1)
-	go func() {
-		logger.Infof("gRPC сервер слушает %s", cfg.GRPCAddr)
-		errCh <- grpcServer.Serve(lis)
-	}()
-
-	select {
-	case <-ctx.Done():
-		logger.Info("получен сигнал остановки, останавливаем gRPC сервер")
-		stoppedCh := make(chan struct{})
-		go func() {
-			grpcServer.GracefulStop()
-			healthServer.SetServingStatus("", healthpb.HealthCheckResponse_NOT_SERVING)
-			close(stoppedCh)
-		}()
-		select {
-		case <-stoppedCh:
-		case <-time.After(gracefulShutdownTimeout):
-			logger.Warn("graceful stop превысил таймаут, принудительно останавливаем")
-			grpcServer.Stop()
-		}
-		shutdownOrderService(orderService, logger)
-		shutdownOutboxWorker(outboxWorkerCancel, outboxWorkerDone, logger)
-		shutdownIdempotencyCleanupWorker(idempotencyCleanupCancel, idempotencyCleanupDone, logger)
-
-		closeKafkaProducer(kafkaProducer, logger)
-
-		return ctx.Err()
-	case err := <-errCh:
-		shutdownOrderService(orderService, logger)
-		shutdownHTTP(metricsSrv, logger)
-		shutdownOutboxWorker(outboxWorkerCancel, outboxWorkerDone, logger)
-		shutdownIdempotencyCleanupWorker(idempotencyCleanupCancel, idempotencyCleanupDone, logger)
-		closeKafkaProducer(kafkaProducer, logger)
-
-		if errors.Is(err, grpc.ErrServerStopped) {
-			return nil
+	return func() error {
+		if err := memory.SaveSnapshotFile(path, repos); err != nil {
+			return fmt.Errorf("save dev snapshot %s: %w", path, err)
 		}
-		return err
-	}
+		logger.WithField("path", path).Info("dev snapshot saved")
+		return nil
+	}, nil
 }
 
 func validateMockIntegrationsPolicy(cfg Config) error {
@@ -262,6 +306,22 @@ func validateMockIntegrationsPolicy(cfg Config) error {
 	return nil
 }
 
+// productionEnvironments — значения OMS_METRICS_ENVIRONMENT, при которых отладочные отказы запрещены.
+var productionEnvironments = map[string]bool{"prod": true, "production": true}
+
+// validateStorageChaosPolicy не даёт включить отказы хранилища в production или в развёртывании,
+// окружение которого не указано.
+func validateStorageChaosPolicy(cfg Config) error {
+	if cfg.StorageChaos == "" {
+		return nil
+	}
+	environment := strings.ToLower(strings.TrimSpace(cfg.MetricsEnvironment))
+	if environment == "" || productionEnvironments[environment] {
+		return fmt.Errorf("OMS_STORAGE_CHAOS requires a non-production OMS_METRICS_ENVIRONMENT, got %q", cfg.MetricsEnvironment)
+	}
+	return nil
+}
+
 func newAppDependencies(runtime runtimeDependencies, logger *log.Entry) *Dependencies {
 	logger.Warn("using mock inventory/payment integrations")
 
@@ -282,8 +342,20 @@ type runtimeDependencies struct {
 	outboxRepo      domain.OutboxRepository
 	timelineRepo    domain.TimelineRepository
 	idempotencyRepo domain.IdempotencyRepository
-	storageChecker  healthcheck.Checker
-	closeFn         func() error
+	sagaDispatch    domain.SagaDispatchRepository
+	// scheduledCancels — отложенные отмены заказов (ScheduleCancel).
+	scheduledCancels domain.ScheduledCancelRepository
+	// returns — возвраты позиций заказов (CreateReturn).
+	returns domain.ReturnRepository
+	// paymentAuthorizations — блокировки сумм двухфазной оплаты, ждущие capture.
+	paymentAuthorizations domain.PaymentAuthorizationRepository
+	// paymentRetries — отложенные повторы оплаты после временных ошибок PSP.
+	paymentRetries domain.PaymentRetryRepository
+	quotaRepo      domain.QuotaRepository
+	orderUoW       domain.OrderUnitOfWork
+	customerEraser domain.CustomerDataEraser
+	storageChecker healthcheck.Checker
+	closeFn        func() error
 }
 
 func initRuntimeDependencies(ctx context.Context, cfg Config, logger *log.Entry) (runtimeDependencies, error) {
@@ -291,12 +363,37 @@ func initRuntimeDependencies(ctx context.Context, cfg Config, logger *log.Entry)
 
 	switch driver {
 	case StorageDriverMemory:
+		repo := memory.NewOrderRepository()
+		timelineRepo := memory.NewTimelineRepository()
+		idempotencyRepo := memory.NewIdempotencyRepository()
+		outboxRepo := memory.NewOutboxRepository()
+		eraser, err := memory.NewCustomerDataEraser(repo, timelineRepo, idempotencyRepo, outboxRepo)
+		if err != nil {
+			return runtimeDependencies{}, fmt.Errorf("init memory customer data eraser: %w", err)
+		}
+		closeFn, err := restoreDevSnapshot(cfg.DevPersistPath, memory.SnapshotRepositories{
+			Orders:      repo,
+			Timeline:    timelineRepo,
+			Outbox:      outboxRepo,
+			Idempotency: idempotencyRepo,
+		}, logger)
+		if err != nil {
+			return runtimeDependencies{}, err
+		}
 		return runtimeDependencies{
-			repo:            memory.NewOrderRepository(),
-			courierRepo:     memory.NewCourierRepository(),
-			outboxRepo:      memory.NewOutboxRepository(),
-			timelineRepo:    memory.NewTimelineRepository(),
-			idempotencyRepo: memory.NewIdempotencyRepository(),
+			repo:                  repo,
+			courierRepo:           memory.NewCourierRepository(),
+			outboxRepo:            outboxRepo,
+			timelineRepo:          timelineRepo,
+			idempotencyRepo:       idempotencyRepo,
+			sagaDispatch:          memory.NewSagaDispatchRepository(),
+			scheduledCancels:      memory.NewScheduledCancelRepository(),
+			returns:               memory.NewReturnRepository(),
+			paymentAuthorizations: memory.NewPaymentAuthorizationRepository(),
+			paymentRetries:        memory.NewPaymentRetryRepository(),
+			quotaRepo:             memory.NewQuotaRepository(),
+			customerEraser:        eraser,
+			closeFn:               closeFn,
 		}, nil
 	case StorageDriverPostgres:
 		if strings.TrimSpace(cfg.PostgresDSN) == "" {
@@ -308,6 +405,10 @@ func initRuntimeDependencies(ctx context.Context, cfg Config, logger *log.Entry)
 			return runtimeDependencies{}, fmt.Errorf("init postgres store: %w", err)
 		}
 
+		if cfg.DevPersistPath != "" {
+			logger.Warn("OMS_DEV_PERSIST_PATH is ignored for postgres storage driver")
+		}
+
 		if cfg.PostgresAutoMigrate {
 			if err := store.EnsureSchema(ctx); err != nil {
 				_ = store.Close()
@@ -324,13 +425,21 @@ func initRuntimeDependencies(ctx context.Context, cfg Config, logger *log.Entry)
 		logger.Info("postgres storage initialized")
 
 		return runtimeDependencies{
-			repo:            postgres.NewOrderRepository(store),
-			courierRepo:     postgres.NewCourierRepository(store),
-			outboxRepo:      postgres.NewOutboxRepository(store),
-			timelineRepo:    postgres.NewTimelineRepository(store),
-			idempotencyRepo: postgres.NewIdempotencyRepository(store),
-			storageChecker:  checker,
-			closeFn:         store.Close,
+			repo:                  postgres.NewOrderRepository(store),
+			courierRepo:           postgres.NewCourierRepository(store),
+			outboxRepo:            postgres.NewOutboxRepository(store),
+			timelineRepo:          postgres.NewTimelineRepository(store),
+			idempotencyRepo:       postgres.NewIdempotencyRepository(store),
+			sagaDispatch:          postgres.NewSagaDispatchRepository(store),
+			scheduledCancels:      postgres.NewScheduledCancelRepository(store),
+			returns:               postgres.NewReturnRepository(store),
+			paymentAuthorizations: postgres.NewPaymentAuthorizationRepository(store),
+			paymentRetries:        postgres.NewPaymentRetryRepository(store),
+			quotaRepo:             postgres.NewQuotaRepository(store),
+			orderUoW:              postgres.NewOrderUnitOfWork(store),
+			customerEraser:        postgres.NewCustomerDataEraser(store),
+			storageChecker:        checker,
+			closeFn:               store.Close,
 		}, nil
 	default:
 		return runtimeDependencies{}, fmt.Errorf("unsupported storage driver: %s", driver)
@@ -346,9 +455,11 @@ func normalizedStorageDriver(value string) string {
 }
 
 // startMetricsServer запускает HTTP-обработчик /metrics для Prometheus.
-func startMetricsServer(ctx context.Context, addr string, logger *log.Entry, healthHandler http.Handler) *http.Server {
+// На том же сервере публикуются featureFlags — /admin/featureflags, tuningAdmin — /admin/tuning,
+// adminUI — /admin/ui/, а timelineStream монтируется как SSE-поток /orders/timeline/stream.
+func startMetricsServer(ctx context.Context, addr string, logger *log.Entry, deployment metrics.Deployment, healthHandler http.Handler, featureFlags, tuningAdmin, adminUI, timelineStream http.Handler) *http.Server {
 	mux := http.NewServeMux()
-	mux.Handle("/metrics", promhttp.Handler())
+	mux.Handle("/metrics", deployment.Handler())
 	mux.Handle("/healthz", healthHandler)
 	mux.HandleFunc("/livez", func(w http.ResponseWriter, _ *http.Request) {
 		w.WriteHeader(http.StatusOK)
@@ -357,6 +468,23 @@ func startMetricsServer(ctx context.Context, addr string, logger *log.Entry, hea
 	if handler, ok := healthHandler.(*healthcheck.Handler); ok {
 		mux.HandleFunc("/readyz", handler.ReadinessHandler)
 	}
+	if featureFlags != nil {
+		mux.Handle("/admin/featureflags", featureFlags)
+	}
+	if tuningAdmin != nil {
+		mux.Handle("/admin/tuning", tuningAdmin)
+	}
+	if adminUI != nil {
+		mux.Handle(adminui.Prefix, adminUI)
+	}
+	if timelineStream != nil {
+		mux.Handle("/orders/timeline/stream", timelineStream)
+	}
+	if handler, err := newOpenAPIHandler(); err != nil {
+		logger.WithError(err).Warn("openapi document is not available")
+	} else {
+		mux.Handle("/openapi.json", handler)
+	}
 
 	srv := &http.Server{
 		Addr:              addr,
@@ -373,25 +501,62 @@ func startMetricsServer(ctx context.Context, addr string, logger *log.Entry, hea
 
 	go func() {
 		<-ctx.Done()
-		shutdownHTTP(srv, logger)
+		shutdownHTTP(srv, logger, nil)
 	}()
 
 	return srv
 }
 
+// adminWrites требует учётные данные админки (как у /admin/ui/) для изменяющих запросов handler'а
+// (переключение фичефлагов, перечитывание tuning);
+// GET и HEAD остаются открытыми, как /metrics. Без creds изменяющие запросы отклоняются.
+func adminWrites(creds adminui.CredentialVerifier, handler http.Handler) http.Handler {
+	protected := adminui.RequireAuth(creds, handler)
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == http.MethodGet || r.Method == http.MethodHead {
+			handler.ServeHTTP(w, r)
+			return
+		}
+		protected.ServeHTTP(w, r)
+	})
+}
+
+// newOpenAPIHandler строит OpenAPI-документ REST-фасада по аннотациям OrderService и CourierService.
+func newOpenAPIHandler() (http.Handler, error) {
+	services := omsv1.File_proto_oms_v1_order_service_proto.Services()
+	doc, err := openapi.Build(
+		openapi.Info{Title: "OMS API", Version: version.GetVersion()},
+		services.ByName("OrderService"),
+		services.ByName("CourierService"),
+	)
+	if err != nil {
+		return nil, err
+	}
+	return openapi.Handler(doc)
+}
+
 // shutdownHTTP аккуратно останавливает HTTP-сервер.
-func shutdownHTTP(srv *http.Server, logger *log.Entry) {
+func shutdownHTTP(srv *http.Server, logger *log.Entry, report *shutdownRecorder) {
 	if srv == nil {
 		return
 	}
 	ctx, cancel := context.WithTimeout(context.Background(), gracefulShutdownTimeout)
 	defer cancel()
-	if err := srv.Shutdown(ctx); err != nil && !errors.Is(err, http.ErrServerClosed) {
+	err := srv.Shutdown(ctx)
+	switch {
+	case err == nil, errors.Is(err, http.ErrServerClosed):
+	case errors.Is(err, context.DeadlineExceeded):
+		logger.WithError(err).Warn("metrics shutdown timeout")
+		report.timedOut("metrics-server")
+	default:
 		logger.WithError(err).Warn("metrics shutdown with error")
+		report.failed("metrics-server", err)
 	}
 }
 
-func shutdownOrderService(orderService *grpcsvc.OrderService, logger *log.Entry) {
+// shutdownOrderService ждёт фоновые саги не дольше gracefulShutdownTimeout; недождавшиеся
+// попадают в отчёт как брошенные.
+func shutdownOrderService(orderService *grpcsvc.OrderService, logger *log.Entry, report *shutdownRecorder) {
 	if orderService == nil {
 		return
 	}
@@ -399,37 +564,115 @@ func shutdownOrderService(orderService *grpcsvc.OrderService, logger *log.Entry)
 	ctx, cancel := context.WithTimeout(context.Background(), gracefulShutdownTimeout)
 	defer cancel()
 
-	if err := orderService.Shutdown(ctx); err != nil && !errors.Is(err, context.Canceled) && !errors.Is(err, context.DeadlineExceeded) {
+	inFlight := orderService.InFlightSagas()
+	err := orderService.Shutdown(ctx)
+	abandoned := orderService.InFlightSagas()
+	report.sagas(max(inFlight-abandoned, 0), abandoned)
+	switch {
+	case err == nil:
+	case errors.Is(err, context.DeadlineExceeded):
+		logger.WithField("sagas_abandoned", abandoned).Warn("order service shutdown timeout")
+		report.timedOut("order-service")
+	case !errors.Is(err, context.Canceled):
 		logger.WithError(err).Warn("order service shutdown with error")
+		report.failed("order-service", err)
 	}
 }
 
-func shutdownOutboxWorker(cancel context.CancelFunc, done <-chan struct{}, logger *log.Entry) {
-	if cancel == nil || done == nil {
-		return
+// startCanaryProber запускает синтетическую проверку против собственного gRPC endpoint.
+func startCanaryProber(
+	ctx context.Context,
+	cfg Config,
+	listenAddr string,
+	deps *Dependencies,
+	idempotency domain.IdempotencyRepository,
+	logger *log.Entry,
+) (context.CancelFunc, chan struct{}, error) {
+	if cfg.CanaryInterval <= 0 {
+		return nil, nil, nil
 	}
 
-	cancel()
-
-	select {
-	case <-done:
-	case <-time.After(gracefulShutdownTimeout):
-		logger.Warn("outbox worker shutdown timeout")
+	conn, err := grpc.NewClient(loopbackTarget(listenAddr), grpc.WithTransportCredentials(insecure.NewCredentials()))
+	if err != nil {
+		return nil, nil, fmt.Errorf("create canary grpc client: %w", err)
 	}
+
+	prober := canary.NewProber(
+		omsv1.NewOrderServiceClient(conn),
+		canary.WithLogger(logger.WithField("component", "canary")),
+		canary.WithInterval(cfg.CanaryInterval),
+		canary.WithTimeout(cfg.CanaryTimeout),
+		canary.WithCleanup(canaryCleanup(deps, idempotency)),
+	)
+
+	canaryCtx, cancel := context.WithCancel(ctx)
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		defer func() {
+			_ = conn.Close()
+		}()
+		prober.Run(canaryCtx)
+	}()
+
+	logger.WithField("interval", cfg.CanaryInterval.String()).Info("canary prober started")
+	return cancel, done, nil
 }
 
-func shutdownIdempotencyCleanupWorker(cancel context.CancelFunc, done <-chan struct{}, logger *log.Entry) {
-	if cancel == nil || done == nil {
-		return
+// canaryCleanup удаляет синтетический заказ вместе со следами в outbox и idempotency-хранилище.
+// Неотправленные события заказа так и не публикуются; уже отправленные несут header
+// domain.EventHeaderSynthetic, по которому их отбрасывают потребители.
+func canaryCleanup(deps *Dependencies, idempotency domain.IdempotencyRepository) canary.CleanupFunc {
+	return func(order canary.SyntheticOrder) error {
+		if outbox, ok := deps.OutboxRepo.(domain.OutboxAggregateDeleter); ok {
+			if _, err := outbox.DeleteByAggregate("order", order.OrderID); err != nil {
+				return err
+			}
+		}
+		if records, ok := idempotency.(domain.IdempotencyRecordDeleter); ok {
+			for _, scope := range order.IdempotencyScopes {
+				if err := records.Delete(scope); err != nil {
+					return err
+				}
+			}
+		}
+		if err := deps.TimelineRepo.DeleteByOrder(order.OrderID); err != nil {
+			return err
+		}
+		if err := deps.Repo.Delete(order.OrderID); err != nil && !errors.Is(err, domain.ErrOrderNotFound) {
+			return err
+		}
+		return nil
 	}
+}
 
-	cancel()
+// loopbackTarget превращает адрес listener'а (в т.ч. ":50051" или "[::]:50051") в адрес для локального клиента.
+func loopbackTarget(addr string) string {
+	host, port, err := net.SplitHostPort(addr)
+	if err != nil {
+		return addr
+	}
+	if ip := net.ParseIP(host); host == "" || (ip != nil && ip.IsUnspecified()) {
+		host = "127.0.0.1"
+	}
+	return net.JoinHostPort(host, port)
+}
 
-	select {
-	case <-done:
-	case <-time.After(gracefulShutdownTimeout):
-		logger.Warn("idempotency cleanup worker shutdown timeout")
+// newSaturationMonitor создаёт монитор oms_saturation_ratio; при выключенном интервале возвращает nil.
+// Монитор создаётся до gRPC-сервера: его значение использует UnaryRetryInfoInterceptor.
+func newSaturationMonitor(cfg Config, logger *log.Entry) *saturation.Monitor {
+	if cfg.SaturationInterval <= 0 {
+		return nil
 	}
+	return saturation.NewMonitor(
+		saturation.Limits{
+			Sagas:       float64(cfg.SaturationSagaLimit),
+			Outbox:      float64(cfg.OutboxMaxPending),
+			InFlightRPC: float64(cfg.SaturationInFlightRPCLimit),
+		},
+		saturation.WithLogger(logger.WithField("component", "saturation-monitor")),
+		saturation.WithInterval(cfg.SaturationInterval),
+	)
 }
 
 func parseKafkaBrokers(raw string) []string {
@@ -456,10 +699,11 @@ func initKafkaProducerWithRetry(
 	logger *log.Entry,
 	initTimeout time.Duration,
 	retryDelay time.Duration,
+	options ...kafka.ProducerOption,
 ) (*kafka.Producer, error) {
 	var producer *kafka.Producer
 	if err := retryWithDeadline(ctx, logger, "kafka producer initialization", initTimeout, retryDelay, func() error {
-		p, err := kafka.NewProducer(brokers)
+		p, err := kafka.NewProducer(brokers, options...)
 		if err != nil {
 			return err
 		}
@@ -523,28 +767,32 @@ func retryWithDeadline(
 			"attempt":   attempt,
 		}).Warn("operation failed, retrying")
 
-		timer := time.NewTimer(retryDelay)
-		select {
-		case <-ctx.Done():
-			if !timer.Stop() {
-				<-timer.C
-			}
-			if lastErr == nil {
-				return ctx.Err()
-			}
-			return fmt.Errorf("%s aborted: %w", operation, ctx.Err())
-		case <-timer.C:
+		if err := ctxutil.Sleep(ctx, retryDelay); err != nil {
+			return fmt.Errorf("%s aborted: %w", operation, err)
 		}
 	}
 }
 
-func closeKafkaProducer(producer *kafka.Producer, logger *log.Entry) {
+func stopGroupConsumer(name string, consumer groupConsumer, logger *log.Entry, report *shutdownRecorder) {
+	if consumer == nil {
+		return
+	}
+	if err := consumer.Stop(); err != nil {
+		logger.WithError(err).Warnf("failed to stop %s", name)
+		report.failed(name, err)
+		return
+	}
+	report.consumerClosed()
+}
+
+func closeKafkaProducer(producer *kafka.Producer, logger *log.Entry, report *shutdownRecorder) {
 	if producer == nil {
 		return
 	}
 
 	if err := producer.Close(); err != nil {
 		logger.WithError(err).Warn("failed to close kafka producer")
+		report.failed("kafka-producer", err)
 		return
 	}
 
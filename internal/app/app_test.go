@@ -1,8 +1,16 @@
 package app
 
 import (
+	"errors"
 	"strings"
 	"testing"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+
+	"github.com/vladislavdragonenkov/oms/internal/domain"
+	"github.com/vladislavdragonenkov/oms/internal/service/canary"
+	"github.com/vladislavdragonenkov/oms/internal/storage/memory"
 )
 
 func TestDefaultConfig(t *testing.T) {
@@ -85,6 +93,56 @@ func TestParseKafkaBrokers_Empty(t *testing.T) {
 	}
 }
 
+func TestLoopbackTarget(t *testing.T) {
+	cases := map[string]string{
+		":50051":         "127.0.0.1:50051",
+		"[::]:50051":     "127.0.0.1:50051",
+		"0.0.0.0:50051":  "127.0.0.1:50051",
+		"10.0.0.5:50051": "10.0.0.5:50051",
+		"not-an-address": "not-an-address",
+	}
+	for addr, want := range cases {
+		if got := loopbackTarget(addr); got != want {
+			t.Fatalf("loopbackTarget(%q) = %q, want %q", addr, got, want)
+		}
+	}
+}
+
+func TestCanaryCleanup_RemovesOutboxAndIdempotency(t *testing.T) {
+	deps := NewDependencies(log.NewEntry(log.New()))
+	idempotency := memory.NewIdempotencyRepository()
+	now := time.Now().UTC()
+	if err := deps.Repo.Create(domain.Order{ID: "canary-order", CustomerID: canary.DefaultCustomerID, Status: domain.OrderStatusCanceled, CreatedAt: now}); err != nil {
+		t.Fatalf("create order: %v", err)
+	}
+	if _, err := deps.OutboxRepo.Enqueue(domain.OutboxMessage{AggregateType: "order", AggregateID: "canary-order", EventType: "OrderCanceled"}); err != nil {
+		t.Fatalf("enqueue: %v", err)
+	}
+	if _, err := deps.OutboxRepo.Enqueue(domain.OutboxMessage{AggregateType: "order", AggregateID: "real-order", EventType: "OrderCreated"}); err != nil {
+		t.Fatalf("enqueue: %v", err)
+	}
+	scope := domain.IdempotencyScope{Method: "/oms.v1.OrderService/CreateOrder", CustomerID: canary.DefaultCustomerID, Key: "canary-run-create"}
+	if _, err := idempotency.CreateProcessing(scope, "hash", now.Add(time.Hour)); err != nil {
+		t.Fatalf("create idempotency record: %v", err)
+	}
+
+	cleanup := canaryCleanup(deps, idempotency)
+	if err := cleanup(canary.SyntheticOrder{OrderID: "canary-order", IdempotencyScopes: []domain.IdempotencyScope{scope}}); err != nil {
+		t.Fatalf("cleanup: %v", err)
+	}
+
+	if _, err := deps.Repo.Get("canary-order"); !errors.Is(err, domain.ErrOrderNotFound) {
+		t.Fatalf("expected order to be deleted, got %v", err)
+	}
+	if _, err := idempotency.Get(scope); !errors.Is(err, domain.ErrIdempotencyKeyNotFound) {
+		t.Fatalf("expected idempotency record to be deleted, got %v", err)
+	}
+	pending, err := deps.OutboxRepo.PullPending(10)
+	if err != nil || len(pending) != 1 || pending[0].AggregateID != "real-order" {
+		t.Fatalf("expected only events of real orders to stay, got %+v err=%v", pending, err)
+	}
+}
+
 func TestValidateMockIntegrationsPolicy(t *testing.T) {
 	if err := validateMockIntegrationsPolicy(Config{StorageDriver: StorageDriverMemory}); err != nil {
 		t.Fatalf("memory storage should allow mocks by default, got %v", err)
@@ -102,3 +160,17 @@ func TestValidateMockIntegrationsPolicy(t *testing.T) {
 		t.Fatalf("unexpected error text: %v", err)
 	}
 }
+
+func TestValidateStorageChaosPolicy(t *testing.T) {
+	if err := validateStorageChaosPolicy(Config{MetricsEnvironment: "production"}); err != nil {
+		t.Fatalf("disabled chaos must pass in any environment, got %v", err)
+	}
+	if err := validateStorageChaosPolicy(Config{StorageChaos: "*:latency=10ms", MetricsEnvironment: "staging"}); err != nil {
+		t.Fatalf("staging should allow storage chaos, got %v", err)
+	}
+	for _, environment := range []string{"", "prod", " Production "} {
+		if err := validateStorageChaosPolicy(Config{StorageChaos: "*:latency=10ms", MetricsEnvironment: environment}); err == nil {
+			t.Fatalf("expected storage chaos to be rejected for environment %q", environment)
+		}
+	}
+}
@@ -38,12 +38,27 @@ func TestDefaultConfig_Values(t *testing.T) {
 	if cfg.OutboxMaxPending <= 0 {
 		t.Error("expected OutboxMaxPending to be > 0")
 	}
+	if cfg.OutboxCleanupInterval <= 0 {
+		t.Error("expected OutboxCleanupInterval to be > 0")
+	}
+	if cfg.OutboxSentRetention <= 0 {
+		t.Error("expected OutboxSentRetention to be > 0")
+	}
 	if cfg.IdempotencyCleanupInterval <= 0 {
 		t.Error("expected IdempotencyCleanupInterval to be > 0")
 	}
 	if cfg.IdempotencyCleanupBatchSize <= 0 {
 		t.Error("expected IdempotencyCleanupBatchSize to be > 0")
 	}
+	if cfg.IdempotencyStaleProcessing <= 0 {
+		t.Error("expected IdempotencyStaleProcessing to be > 0")
+	}
+	if cfg.InventoryReconcileInterval <= 0 {
+		t.Error("expected InventoryReconcileInterval to be > 0")
+	}
+	if cfg.SagaTimeout <= 0 {
+		t.Error("expected SagaTimeout to be > 0")
+	}
 }
 
 func TestConfig_CustomValues(t *testing.T) {
@@ -0,0 +1,985 @@
+package app
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"os"
+	"strings"
+	"sync"
+	"time"
+
+	promgrpc "github.com/grpc-ecosystem/go-grpc-prometheus"
+	"github.com/prometheus/client_golang/prometheus"
+	log "github.com/sirupsen/logrus"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/admin"
+	"google.golang.org/grpc/health"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/reflection"
+
+	"github.com/vladislavdragonenkov/oms/internal/adminui"
+	"github.com/vladislavdragonenkov/oms/internal/dependency"
+	"github.com/vladislavdragonenkov/oms/internal/domain"
+	"github.com/vladislavdragonenkov/oms/internal/featureflags"
+	healthcheck "github.com/vladislavdragonenkov/oms/internal/health"
+	"github.com/vladislavdragonenkov/oms/internal/logging"
+	"github.com/vladislavdragonenkov/oms/internal/messaging/kafka"
+	"github.com/vladislavdragonenkov/oms/internal/metrics"
+	"github.com/vladislavdragonenkov/oms/internal/metrics/otlp"
+	"github.com/vladislavdragonenkov/oms/internal/notify"
+	"github.com/vladislavdragonenkov/oms/internal/service/catalog"
+	"github.com/vladislavdragonenkov/oms/internal/service/consistency"
+	grpcsvc "github.com/vladislavdragonenkov/oms/internal/service/grpc"
+	idempotencysvc "github.com/vladislavdragonenkov/oms/internal/service/idempotency"
+	"github.com/vladislavdragonenkov/oms/internal/service/inventory"
+	"github.com/vladislavdragonenkov/oms/internal/service/ordercache"
+	outboxsvc "github.com/vladislavdragonenkov/oms/internal/service/outbox"
+	"github.com/vladislavdragonenkov/oms/internal/service/payment"
+	"github.com/vladislavdragonenkov/oms/internal/service/saga"
+	"github.com/vladislavdragonenkov/oms/internal/slo"
+	"github.com/vladislavdragonenkov/oms/internal/storage/chaos"
+	"github.com/vladislavdragonenkov/oms/internal/tuning"
+	"github.com/vladislavdragonenkov/oms/internal/version"
+	omsv1 "github.com/vladislavdragonenkov/oms/proto/oms/v1"
+)
+
+// Component — часть приложения с жизненным циклом. App запускает компоненты в порядке
+// регистрации и останавливает в обратном, поэтому зависимость регистрируется раньше своих
+// потребителей (producer — раньше outbox worker'а). Stop должен быть безопасен и для
+// незапущенного компонента: им же освобождаются ресурсы, если сборка App не удалась.
+type Component interface {
+	Name() string
+	Start(ctx context.Context) error
+	Stop()
+}
+
+// runner — фоновый цикл вида Run(ctx), завершающийся по отмене контекста.
+type runner struct {
+	name   string
+	run    func(ctx context.Context)
+	logger *log.Entry
+	report *shutdownRecorder
+	cancel context.CancelFunc
+	done   chan struct{}
+}
+
+func newRunner(name string, logger *log.Entry, report *shutdownRecorder, run func(ctx context.Context)) *runner {
+	return &runner{name: name, run: run, logger: logger, report: report}
+}
+
+func (r *runner) Name() string { return r.name }
+
+func (r *runner) Start(ctx context.Context) error {
+	runCtx, cancel := context.WithCancel(ctx)
+	r.cancel = cancel
+	r.done = make(chan struct{})
+	go func() {
+		defer close(r.done)
+		r.run(runCtx)
+	}()
+	return nil
+}
+
+func (r *runner) Stop() {
+	stopRunner(r.name, r.cancel, r.done, r.logger, r.report)
+}
+
+// stopRunner отменяет контекст фонового цикла и ждёт его не дольше gracefulShutdownTimeout.
+func stopRunner(name string, cancel context.CancelFunc, done <-chan struct{}, logger *log.Entry, report *shutdownRecorder) {
+	if cancel == nil || done == nil {
+		return
+	}
+	cancel()
+	select {
+	case <-done:
+	case <-time.After(gracefulShutdownTimeout):
+		logger.Warnf("%s shutdown timeout", name)
+		report.timedOut(name)
+	}
+}
+
+// hooks — компонент из функций запуска и остановки; nil-функция ничего не делает.
+type hooks struct {
+	name  string
+	start func(ctx context.Context) error
+	stop  func()
+}
+
+func (h *hooks) Name() string { return h.name }
+
+func (h *hooks) Start(ctx context.Context) error {
+	if h.start == nil {
+		return nil
+	}
+	return h.start(ctx)
+}
+
+func (h *hooks) Stop() {
+	if h.stop != nil {
+		h.stop()
+	}
+}
+
+// grpcComponent слушает cfg.GRPCAddr; результат Serve уходит в serveErr.
+type grpcComponent struct {
+	server   *grpc.Server
+	health   *health.Server
+	addr     string
+	logger   *log.Entry
+	report   *shutdownRecorder
+	listener net.Listener
+	serveErr chan<- error
+}
+
+func (c *grpcComponent) Name() string { return "grpc-server" }
+
+func (c *grpcComponent) Start(context.Context) error {
+	lis, err := net.Listen("tcp", c.addr)
+	if err != nil {
+		return err
+	}
+	c.listener = lis
+	go func() {
+		c.logger.Infof("gRPC сервер слушает %s", c.addr)
+		c.serveErr <- c.server.Serve(lis)
+	}()
+	return nil
+}
+
+func (c *grpcComponent) Stop() {
+	stoppedCh := make(chan struct{})
+	go func() {
+		c.server.GracefulStop()
+		c.health.SetServingStatus("", healthpb.HealthCheckResponse_NOT_SERVING)
+		close(stoppedCh)
+	}()
+	select {
+	case <-stoppedCh:
+	case <-time.After(gracefulShutdownTimeout):
+		c.logger.Warn("graceful stop превысил таймаут, принудительно останавливаем")
+		c.report.timedOut(c.Name())
+		c.server.Stop()
+	}
+}
+
+// listenAddr — фактический адрес listener'а (с выбранным портом при ":0").
+func (c *grpcComponent) listenAddr() string {
+	if c.listener == nil {
+		return c.addr
+	}
+	return c.listener.Addr().String()
+}
+
+// groupConsumer — жизненный цикл consumer'а одной consumer group; *kafka.Consumer.
+type groupConsumer interface {
+	Start(ctx context.Context) error
+	Stop() error
+}
+
+// concurrencySetter — consumer, лимит параллельности которого меняется на лету; *kafka.Consumer.
+type concurrencySetter interface {
+	SetConcurrency(limit int)
+}
+
+// consumerConcurrencyTuner применяет consumer_concurrency к consumer'у, если тот это поддерживает.
+func consumerConcurrencyTuner(consumer groupConsumer) func(tuning.Values) {
+	return func(v tuning.Values) {
+		if setter, ok := consumer.(concurrencySetter); ok {
+			setter.SetConcurrency(v.ConsumerConcurrency)
+		}
+	}
+}
+
+// Подключения к Kafka вынесены в переменные, чтобы тесты собирали App без брокера.
+var (
+	connectKafkaProducer = func(ctx context.Context, brokers []string, logger *log.Entry, options ...kafka.ProducerOption) (*kafka.Producer, error) {
+		return initKafkaProducerWithRetry(ctx, brokers, logger, kafkaInitTimeout, kafkaInitRetryDelay, options...)
+	}
+	// newInventoryBackend создаёт склад для имени из OMS_INVENTORY_ROUTES; реальные интеграции
+	// складов ещё не подключены, поэтому каждый backend — отдельная заглушка.
+	newInventoryBackend = func(string) domain.InventoryService {
+		return inventory.NewMockService()
+	}
+	newGroupConsumer = func(brokers []string, groupID string, topics []string, handler kafka.MessageHandler, dlqProducer *kafka.Producer, policy kafka.DLQPolicy, options ...kafka.ConsumerOption) (groupConsumer, error) {
+		return kafka.NewConsumerWithPolicy(brokers, groupID, topics, handler, dlqProducer, policy, options...)
+	}
+)
+
+// App — собранное приложение: компоненты в порядке запуска и ресурсы, освобождаемые после их остановки.
+type App struct {
+	logger     *log.Entry
+	components []Component
+	// closers выполняются в обратном порядке после остановки компонентов (хранилище).
+	closers   []func()
+	serveErr  chan error
+	closeOnce sync.Once
+	builtAt   time.Time
+	shutdown  *shutdownRecorder
+}
+
+func (a *App) add(component Component) {
+	a.components = append(a.components, component)
+}
+
+func (a *App) addRunner(name string, run func(ctx context.Context)) {
+	a.add(newRunner(name, a.logger, a.shutdown, run))
+}
+
+// ComponentNames возвращает имена компонентов в порядке запуска.
+func (a *App) ComponentNames() []string {
+	names := make([]string, 0, len(a.components))
+	for _, component := range a.components {
+		names = append(names, component.Name())
+	}
+	return names
+}
+
+// Run запускает компоненты и ждёт отмены ctx или остановки gRPC-сервера, после чего
+// останавливает компоненты, освобождает ресурсы и пишет в лог ShutdownReport.
+func (a *App) Run(ctx context.Context) error {
+	for _, component := range a.components {
+		if err := component.Start(ctx); err != nil {
+			a.Close()
+			return fmt.Errorf("%w: start %s: %w", ErrStartupFailed, component.Name(), err)
+		}
+	}
+
+	var (
+		reason string
+		runErr error
+	)
+	select {
+	case <-ctx.Done():
+		a.logger.Info("получен сигнал остановки, останавливаем gRPC сервер")
+		reason, runErr = ShutdownReasonSignal, ctx.Err()
+	case err := <-a.serveErr:
+		reason = ShutdownReasonServerFailed
+		if errors.Is(err, grpc.ErrServerStopped) {
+			reason, err = ShutdownReasonServerStopped, nil
+		}
+		runErr = err
+	}
+
+	stoppingAt := time.Now()
+	a.Close()
+	a.shutdown.update(func(report *ShutdownReport) {
+		report.Reason = reason
+		report.Uptime = stoppingAt.Sub(a.builtAt)
+		report.Duration = time.Since(stoppingAt)
+	})
+	report := a.ShutdownReport()
+	entry := a.logger.WithFields(report.fields())
+	if !report.Clean() {
+		entry.Warn("shutdown report")
+	} else {
+		entry.Info("shutdown report")
+	}
+	if len(report.TimedOut) > 0 {
+		return errors.Join(runErr, fmt.Errorf("%w: %s", ErrDrainTimeout, strings.Join(report.TimedOut, ", ")))
+	}
+	return runErr
+}
+
+// ShutdownReport возвращает отчёт об остановке; до завершения Run он заполнен частично.
+func (a *App) ShutdownReport() ShutdownReport {
+	return a.shutdown.snapshot()
+}
+
+// Close останавливает компоненты в обратном порядке и освобождает ресурсы; повторный вызов ничего не делает.
+func (a *App) Close() {
+	a.closeOnce.Do(func() {
+		for i := len(a.components) - 1; i >= 0; i-- {
+			a.components[i].Stop()
+		}
+		for i := len(a.closers) - 1; i >= 0; i-- {
+			a.closers[i]()
+		}
+	})
+}
+
+// BuildApp собирает приложение по cfg: открывает хранилище и подключается к Kafka, но фоновые
+// циклы и серверы не запускает — это делает Run. При ошибке уже созданные ресурсы освобождаются.
+func BuildApp(ctx context.Context, cfg Config) (*App, error) {
+	logger := log.WithField("component", "app")
+	a := &App{logger: logger, serveErr: make(chan error, 1), builtAt: time.Now(), shutdown: &shutdownRecorder{}}
+	if err := a.build(ctx, cfg); err != nil {
+		a.Close()
+		return nil, err
+	}
+	return a, nil
+}
+
+func (a *App) build(ctx context.Context, cfg Config) error {
+	logger := a.logger
+
+	if err := validateMockIntegrationsPolicy(cfg); err != nil {
+		return err
+	}
+	if err := validateStorageChaosPolicy(cfg); err != nil {
+		return err
+	}
+
+	globalLogLevel := log.GetLevel()
+	logLevelsConfig, err := loadLogLevels(cfg, globalLogLevel)
+	if err != nil {
+		return err
+	}
+	logLevels := logging.Install(log.StandardLogger(), logLevelsConfig)
+	var stopLogLevelReloader func()
+	a.add(&hooks{
+		name: "log-level-reloader",
+		start: func(ctx context.Context) error {
+			stopLogLevelReloader = startLogLevelReloader(ctx, logLevels, cfg, globalLogLevel, logger)
+			return nil
+		},
+		stop: func() {
+			if stopLogLevelReloader != nil {
+				stopLogLevelReloader()
+			}
+		},
+	})
+
+	var (
+		tuningStore  *tuning.Store
+		tuningReload func(source string) ([]tuning.Change, error)
+	)
+	if cfg.TuningFile != "" {
+		values, err := loadTuning(cfg)
+		if err != nil {
+			return err
+		}
+		if tuningStore, err = tuning.NewStore(values, tuning.WithLogger(logger.WithField("component", "tuning"))); err != nil {
+			return fmt.Errorf("init tuning: %w", err)
+		}
+		// Перечитывание накладывает файл на значения из env, поэтому reload строится до подмены cfg.
+		tuningReload = newTuningReload(tuningStore, cfg)
+		cfg = withTuning(cfg, values)
+		var stopTuningReloader func()
+		a.add(&hooks{
+			name: "tuning-reloader",
+			start: func(ctx context.Context) error {
+				stopTuningReloader = startTuningReloader(ctx, tuningReload, cfg.TuningFile, logger)
+				return nil
+			},
+			stop: func() {
+				if stopTuningReloader != nil {
+					stopTuningReloader()
+				}
+			},
+		})
+	}
+	// onTuning подписывает компонент на изменения параметров; без TuningFile ничего не делает.
+	onTuning := func(apply func(tuning.Values)) {
+		if tuningStore != nil {
+			tuningStore.Subscribe(apply)
+		}
+	}
+
+	flagOverrides, err := featureflags.Parse(cfg.FeatureFlags)
+	if err != nil {
+		return fmt.Errorf("parse feature flags: %w", err)
+	}
+	flags, err := featureflags.New(flagOverrides)
+	if err != nil {
+		return fmt.Errorf("init feature flags: %w", err)
+	}
+
+	dlqPolicies, err := kafka.ParseDLQPolicies(cfg.KafkaDLQPolicies)
+	if err != nil {
+		return fmt.Errorf("parse kafka dlq policies: %w", err)
+	}
+	topics, err := kafka.NewTopicConfig(cfg.KafkaTopicPrefix)
+	if err != nil {
+		return fmt.Errorf("kafka topic config: %w", err)
+	}
+	if topics, err = topics.WithKeyStrategies(cfg.KafkaKeyStrategies); err != nil {
+		return fmt.Errorf("kafka key strategies: %w", err)
+	}
+	if topics, err = topics.WithCodecs(cfg.KafkaCodecs); err != nil {
+		return fmt.Errorf("kafka codecs: %w", err)
+	}
+	metricsDeployment := metrics.Deployment{Namespace: cfg.MetricsNamespace, Environment: cfg.MetricsEnvironment, Region: cfg.MetricsRegion}
+	if err := metricsDeployment.Validate(); err != nil {
+		return err
+	}
+	otlpHeaders, err := otlp.ParseHeaders(cfg.OTLPMetricsHeaders)
+	if err != nil {
+		return fmt.Errorf("parse otlp metrics headers: %w", err)
+	}
+	adminUICreds, adminUICredsSource, err := loadAccessTokens(cfg.AdminUICredentials, cfg.AdminUICredentialsFile)
+	if err != nil {
+		return fmt.Errorf("parse admin ui credentials: %w", err)
+	}
+	chaosRules, err := chaos.ParseRules(cfg.StorageChaos)
+	if err != nil {
+		return fmt.Errorf("parse storage chaos rules: %w", err)
+	}
+	orderQuotas, err := grpcsvc.ParseOrderQuotas(cfg.OrderQuotas)
+	if err != nil {
+		return fmt.Errorf("parse order quotas: %w", err)
+	}
+	approvalCustomers, err := grpcsvc.ParseApprovalCustomers(cfg.ApprovalCustomers)
+	if err != nil {
+		return fmt.Errorf("parse approval customers: %w", err)
+	}
+	adminTokens, adminTokensSource, err := loadAccessTokens(cfg.AdminTokens, cfg.AdminTokensFile)
+	if err != nil {
+		return fmt.Errorf("parse admin tokens: %w", err)
+	}
+	var inventoryRoutes inventory.RoutingTable
+	if cfg.InventoryRoutes != "" {
+		if inventoryRoutes, err = inventory.ParseRoutes(cfg.InventoryRoutes); err != nil {
+			return fmt.Errorf("parse inventory routes: %w", err)
+		}
+	}
+	pageLimits := grpcsvc.PageLimits{Default: cfg.ListDefaultPageSize, Max: cfg.ListMaxPageSize}
+	if err := pageLimits.Validate(); err != nil {
+		return fmt.Errorf("list page limits: %w", err)
+	}
+	catalogPrices, err := catalog.ParseStaticCatalog(cfg.CatalogPrices)
+	if err != nil {
+		return fmt.Errorf("parse catalog prices: %w", err)
+	}
+	concurrencyLimits, err := grpcsvc.ParseConcurrencyLimits(cfg.GRPCConcurrencyLimits)
+	if err != nil {
+		return fmt.Errorf("parse grpc concurrency limits: %w", err)
+	}
+	sloObjectives, err := slo.ParseObjectives(cfg.SLOObjectives)
+	if err != nil {
+		return fmt.Errorf("parse slo objectives: %w", err)
+	}
+
+	var (
+		producerOpts = []kafka.ProducerOption{
+			kafka.WithTopicCodec(topics.OrderEvents, topics.OrderEventsCodec),
+			kafka.WithTopicCodec(topics.SagaEvents, topics.SagaEventsCodec),
+			kafka.WithProducerCircuitBreaker(cfg.KafkaProducerBreakerThreshold, cfg.KafkaProducerBreakerCooldown, nil),
+			kafka.WithProducerDependencyMetrics(nil),
+		}
+		outboxPublisherOpts []kafka.OutboxPublisherOption
+	)
+	if cfg.EventEncryptionKeys != "" {
+		encryptor, err := newEventFieldEncryptor(cfg)
+		if err != nil {
+			return err
+		}
+		producerOpts = append(producerOpts, kafka.WithProducerEncryptor(encryptor))
+		outboxPublisherOpts = append(outboxPublisherOpts, kafka.WithFieldEncryptor(encryptor))
+	}
+
+	runtimeDeps, err := initRuntimeDependencies(ctx, cfg, logger)
+	if err != nil {
+		return err
+	}
+	if runtimeDeps.closeFn != nil {
+		a.closers = append(a.closers, func() {
+			if closeErr := runtimeDeps.closeFn(); closeErr != nil {
+				logger.WithError(closeErr).Warn("failed to close storage")
+				a.shutdown.failed("storage", closeErr)
+			}
+		})
+	}
+
+	// Все записи timeline проходят через notifier, чтобы StreamOrderTimeline/WatchOrder/SSE получали их без опроса.
+	timelineNotifier := notify.NewTimelineRepository(runtimeDeps.timelineRepo, notify.NewHub[domain.TimelineEvent]())
+	runtimeDeps.timelineRepo = timelineNotifier
+
+	deps := newAppDependencies(runtimeDeps, logger)
+	if cfg.InventoryRoutes != "" {
+		backends := make(map[string]domain.InventoryService)
+		for _, name := range inventoryRoutes.Backends() {
+			backends[name] = newInventoryBackend(name)
+		}
+		router, err := inventory.NewRouter(inventoryRoutes, backends,
+			inventory.WithRouterLogger(logger.WithField("component", "inventory-router")))
+		if err != nil {
+			return fmt.Errorf("inventory router: %w", err)
+		}
+		deps.InventorySvc = router
+	}
+
+	a.addStorageWorkers(cfg, deps, runtimeDeps)
+	// Обёртки метрик зависимостей ставятся после воркеров хранилища: сверка резервов ищет
+	// ReservationLister у исходного склада. Сага получает хранилище через обёртки db —
+	// расширения репозиториев ей не нужны, в отличие от gRPC-сервиса.
+	breaker := dependency.WithBreaker(cfg.DependencyBreakerThreshold, cfg.DependencyBreakerCooldown)
+	deps.InventorySvc = dependency.InstrumentInventory(deps.InventorySvc, nil, breaker)
+	deps.PaymentSvc = dependency.InstrumentPayment(deps.PaymentSvc, nil, breaker)
+	sagaDeps := *deps
+	if len(chaosRules) > 0 {
+		// Отказы внедряются под обёртками метрик: на дашборде они выглядят как сбои БД.
+		logger.WithField("rules", cfg.StorageChaos).Warn("storage chaos enabled: saga storage calls will fail on purpose")
+		injector := chaos.NewInjector(chaosRules, nil)
+		sagaDeps.Repo = chaos.Orders(sagaDeps.Repo, injector)
+		sagaDeps.OutboxRepo = chaos.Outbox(sagaDeps.OutboxRepo, injector)
+		sagaDeps.TimelineRepo = chaos.Timeline(sagaDeps.TimelineRepo, injector)
+	}
+	sagaDeps.Repo = dependency.InstrumentOrders(sagaDeps.Repo, nil)
+	sagaDeps.OutboxRepo = dependency.InstrumentOutbox(sagaDeps.OutboxRepo, nil)
+	sagaDeps.TimelineRepo = dependency.InstrumentTimeline(sagaDeps.TimelineRepo, nil)
+
+	orchestratorOpts := []saga.OrchestratorOption{
+		saga.WithBackorders(flags.Enabled(featureflags.Backorders)),
+		saga.WithEventsTopic(topics.SagaEvents),
+		saga.WithEventsKeyStrategy(topics.SagaEventsKey),
+		// Sandbox-заказы всегда идут через отдельные заглушки, даже если основные сервисы — тоже mock.
+		saga.WithTestModeServices(inventory.NewMockService(), payment.NewMockService()),
+		saga.WithPaymentAuthorizations(runtimeDeps.paymentAuthorizations, cfg.PaymentAuthorizationTTL),
+		saga.WithPaymentRetries(runtimeDeps.paymentRetries, saga.PaymentRetryPolicy{
+			MaxAttempts: cfg.PaymentRetryMaxAttempts,
+			BaseDelay:   cfg.PaymentRetryBaseDelay,
+			MaxDelay:    cfg.PaymentRetryMaxDelay,
+		}),
+		saga.WithPlugins(saga.RegisteredPlugins()...),
+		saga.WithHookTimeout(cfg.SagaHookTimeout),
+	}
+
+	rawKafkaBrokers := os.Getenv("KAFKA_BROKERS")
+	brokers := parseKafkaBrokers(rawKafkaBrokers)
+	if strings.TrimSpace(rawKafkaBrokers) != "" && len(brokers) == 0 {
+		return fmt.Errorf("KAFKA_BROKERS is set but no valid broker addresses were parsed")
+	}
+	if len(brokers) > 0 && !flags.Enabled(featureflags.KafkaEnabled) {
+		logger.WithField("flag", featureflags.KafkaEnabled).Warn("kafka disabled by feature flag, brokers ignored")
+		brokers = nil
+	}
+
+	// Kafka producer опционален: без брокеров сервис работает с обычным orchestrator.
+	var (
+		sagaOrchestrator saga.Orchestrator
+		outboxChecker    healthcheck.Checker
+	)
+	if len(brokers) > 0 {
+		kafkaProducer, err := connectKafkaProducer(ctx, brokers, logger, producerOpts...)
+		if err != nil {
+			return err
+		}
+		logger.WithField("brokers", brokers).Info("kafka producer initialized")
+		a.add(&hooks{name: "kafka-producer", stop: func() { closeKafkaProducer(kafkaProducer, logger, a.shutdown) }})
+
+		outboxWorker := outboxsvc.NewWorker(
+			deps.OutboxRepo,
+			kafka.NewOutboxPublisher(kafkaProducer, topics.OrderEvents, append(outboxPublisherOpts, kafka.WithKeyStrategy(topics.OrderEventsKey))...),
+			outboxsvc.WithDLQPublisher(kafka.NewOutboxPublisher(kafkaProducer, topics.DeadLetter, outboxPublisherOpts...)),
+			outboxsvc.WithLogger(logger.WithField("component", "outbox-worker")),
+			outboxsvc.WithPollInterval(cfg.OutboxPollInterval),
+			outboxsvc.WithBatchSize(cfg.OutboxBatchSize),
+			outboxsvc.WithMaxAttempts(cfg.OutboxMaxAttempts),
+			outboxsvc.WithRetryBaseDelay(cfg.OutboxRetryDelay),
+		)
+		onTuning(func(v tuning.Values) {
+			outboxWorker.Reconfigure(
+				outboxsvc.WithPollInterval(v.OutboxPollInterval),
+				outboxsvc.WithBatchSize(v.OutboxBatchSize),
+				outboxsvc.WithMaxAttempts(v.OutboxMaxAttempts),
+				outboxsvc.WithRetryBaseDelay(v.OutboxRetryDelay),
+			)
+		})
+		a.addRunner("outbox-worker", func(ctx context.Context) {
+			outboxWorker.Run(ctx)
+			// Остановка идёт после order-service: события дождавшихся саг публикуются сейчас.
+			flushCtx, cancel := context.WithTimeout(context.WithoutCancel(ctx), outboxFlushTimeout)
+			defer cancel()
+			a.shutdown.outboxFlushed(outboxWorker.Flush(flushCtx))
+		})
+
+		outboxChecker = healthcheck.NewSimpleChecker("outbox", func() error {
+			stats, err := deps.OutboxRepo.Stats()
+			if err != nil {
+				return err
+			}
+			if cfg.OutboxMaxPending > 0 && stats.PendingCount > cfg.OutboxMaxPending {
+				return fmt.Errorf("outbox backlog %d exceeds threshold %d", stats.PendingCount, cfg.OutboxMaxPending)
+			}
+			return nil
+		})
+
+		var sagaEvents kafka.EventPublisher = kafkaProducer
+		if cfg.KafkaSagaEventBuffer > 0 {
+			// Очередь останавливается раньше producer'а, чтобы последняя попытка досылки ещё могла пройти.
+			queue := kafka.NewRetryQueue(
+				kafkaProducer,
+				kafka.WithRetryQueueCapacity(cfg.KafkaSagaEventBuffer),
+				kafka.WithRetryQueueLogger(logger.WithField("component", "saga-events-queue")),
+			)
+			a.addRunner("saga-events-queue", queue.Run)
+			sagaEvents = queue
+		}
+		sagaOrchestrator = createOrchestrator(&sagaDeps, sagaEvents, orchestratorOpts...)
+
+		if flags.Enabled(featureflags.Backorders) {
+			resumer := saga.NewBackorderResumer(
+				deps.Repo,
+				sagaOrchestrator,
+				saga.WithBackorderLogger(logger.WithField("component", "backorder-resumer")),
+				saga.WithBackorderSagaTimeout(cfg.SagaTimeout),
+			)
+			onTuning(func(v tuning.Values) { resumer.SetSagaTimeout(v.SagaTimeout) })
+			consumer, err := newGroupConsumer(
+				brokers,
+				topics.BackordersGroup,
+				topics.ConsumerGroups()[topics.BackordersGroup],
+				resumer.HandleMessage,
+				kafkaProducer,
+				topics.ResolveDLQPolicy(kafka.DLQPolicyFor(dlqPolicies, topics.BackordersGroup)),
+				kafka.WithConsumerMiddleware(kafka.DefaultMiddleware(topics.BackordersGroup, logger.WithField("component", "kafka-consumer"), nil)...),
+				kafka.WithConsumerConcurrency(cfg.KafkaConsumerConcurrency),
+			)
+			if err != nil {
+				return fmt.Errorf("init restock consumer: %w", err)
+			}
+			onTuning(consumerConcurrencyTuner(consumer))
+			a.add(&hooks{
+				name:  "restock-consumer",
+				start: consumer.Start,
+				stop:  func() { stopGroupConsumer("restock consumer", consumer, logger, a.shutdown) },
+			})
+		}
+
+		if flags.Enabled(featureflags.PaymentEvents) {
+			handler := saga.NewPaymentEventHandler(
+				deps.Repo,
+				sagaOrchestrator,
+				saga.WithPaymentEventsLogger(logger.WithField("component", "payment-events")),
+				saga.WithPaymentEventsSagaTimeout(cfg.SagaTimeout),
+				saga.WithPaymentEventsParking(cfg.PaymentEventsParkTTL, 0, 0),
+			)
+			onTuning(func(v tuning.Values) { handler.SetSagaTimeout(v.SagaTimeout) })
+			consumer, err := newGroupConsumer(
+				brokers,
+				topics.PaymentsGroup,
+				topics.ConsumerGroups()[topics.PaymentsGroup],
+				handler.HandleMessage,
+				kafkaProducer,
+				topics.ResolveDLQPolicy(kafka.DLQPolicyFor(dlqPolicies, topics.PaymentsGroup)),
+				kafka.WithConsumerMiddleware(kafka.DefaultMiddleware(topics.PaymentsGroup, logger.WithField("component", "kafka-consumer"), nil)...),
+				kafka.WithConsumerConcurrency(cfg.KafkaConsumerConcurrency),
+			)
+			if err != nil {
+				return fmt.Errorf("init payment events consumer: %w", err)
+			}
+			onTuning(consumerConcurrencyTuner(consumer))
+			// Повторы отложенных событий останавливаются после consumer'а, который их добавляет.
+			a.addRunner("payment-events-parking", handler.Run)
+			a.add(&hooks{
+				name:  "payment-events-consumer",
+				start: consumer.Start,
+				stop:  func() { stopGroupConsumer("payment events consumer", consumer, logger, a.shutdown) },
+			})
+		}
+	}
+
+	if sagaOrchestrator == nil {
+		sagaOrchestrator = createOrchestrator(&sagaDeps, nil, orchestratorOpts...)
+		if flags.Enabled(featureflags.Backorders) {
+			logger.Warn("backorders enabled without kafka: backordered orders will not resume on restock")
+		}
+		if flags.Enabled(featureflags.PaymentEvents) {
+			logger.Warn("payment_events enabled without kafka: PSP payment events will not be consumed")
+		}
+	}
+
+	serviceLogger := logger.WithField("layer", "grpc")
+	orderServiceOptions := []grpcsvc.OrderServiceOption{
+		grpcsvc.WithSagaTimeout(cfg.SagaTimeout),
+		grpcsvc.WithTimelineWatcher(timelineNotifier),
+		grpcsvc.WithSagaDispatchRepository(runtimeDeps.sagaDispatch),
+		grpcsvc.WithScheduledCancels(runtimeDeps.scheduledCancels),
+		grpcsvc.WithReturns(runtimeDeps.returns, deps.InventorySvc, deps.PaymentSvc),
+		grpcsvc.WithOrderEvents(deps.OutboxRepo),
+		grpcsvc.WithPageLimits(pageLimits),
+	}
+	if runtimeDeps.orderUoW != nil {
+		orderServiceOptions = append(orderServiceOptions, grpcsvc.WithOrderUnitOfWork(runtimeDeps.orderUoW))
+	}
+	if cfg.OrderListCacheTTL > 0 {
+		listCache := ordercache.NewListCache(cfg.OrderListCacheTTL,
+			ordercache.WithMaxCustomers(cfg.OrderListCacheMaxCustomers),
+			ordercache.WithLogger(logger.WithField("component", "order-list-cache")),
+		)
+		orderServiceOptions = append(orderServiceOptions,
+			grpcsvc.WithOrderListCache(listCache),
+			grpcsvc.WithOrderListCacheSwitch(func() bool { return flags.Enabled(featureflags.ReadCache) }),
+		)
+		a.addRunner("order-list-cache", func(ctx context.Context) { listCache.WatchTimeline(ctx, timelineNotifier) })
+	}
+	if cfg.DuplicateOrderWindow > 0 {
+		orderServiceOptions = append(orderServiceOptions, grpcsvc.WithDuplicateOrderWindow(cfg.DuplicateOrderWindow, nil))
+	}
+	adminServiceOptions := []grpcsvc.AdminServiceOption{grpcsvc.WithLogLevels(logLevels)}
+	if len(orderQuotas) > 0 {
+		orderServiceOptions = append(orderServiceOptions, grpcsvc.WithOrderQuotas(runtimeDeps.quotaRepo, orderQuotas))
+		adminServiceOptions = append(adminServiceOptions, grpcsvc.WithAdminQuotas(runtimeDeps.quotaRepo, orderQuotas))
+	}
+	if len(approvalCustomers) > 0 {
+		orderServiceOptions = append(orderServiceOptions, grpcsvc.WithApprovalCustomers(approvalCustomers))
+	}
+	if catalogPrices.Len() > 0 {
+		adminServiceOptions = append(adminServiceOptions, grpcsvc.WithOrderRecalculation(deps.Repo, catalogPrices))
+	}
+	if adminTokens != nil {
+		// Принудительная смена статуса доступна только с аутентификацией операторов.
+		adminServiceOptions = append(adminServiceOptions, grpcsvc.WithOrderTransitions(deps.Repo))
+	}
+	orderService := grpcsvc.NewOrderService(deps.Repo, deps.TimelineRepo, idempotencysvc.InstrumentRepository(runtimeDeps.idempotencyRepo, nil), sagaOrchestrator, serviceLogger, orderServiceOptions...)
+	onTuning(func(v tuning.Values) { orderService.SetSagaTimeout(v.SagaTimeout) })
+	a.add(&hooks{
+		name: "order-service",
+		stop: func() { shutdownOrderService(orderService, logger, a.shutdown) },
+	})
+	// Намерения, не выполненные остановленными процессами, забираются по истечении их аренды.
+	a.addRunner("saga-replay", orderService.RunSagaReplay)
+	if runtimeDeps.scheduledCancels != nil && cfg.ScheduledCancelInterval > 0 {
+		scheduler := saga.NewCancelScheduler(
+			runtimeDeps.scheduledCancels,
+			deps.Repo,
+			sagaOrchestrator,
+			saga.WithScheduledCancelLogger(logger.WithField("component", "cancel-scheduler")),
+			saga.WithScheduledCancelInterval(cfg.ScheduledCancelInterval),
+			saga.WithScheduledCancelSagaTimeout(cfg.SagaTimeout),
+		)
+		onTuning(func(v tuning.Values) { scheduler.SetSagaTimeout(v.SagaTimeout) })
+		a.addRunner("cancel-scheduler", scheduler.Run)
+	}
+	if runtimeDeps.paymentAuthorizations != nil && cfg.PaymentAuthorizationCheckInterval > 0 {
+		expiry := saga.NewAuthorizationExpiryWorker(
+			runtimeDeps.paymentAuthorizations,
+			deps.Repo,
+			sagaOrchestrator,
+			saga.WithAuthorizationExpiryLogger(logger.WithField("component", "authorization-expiry")),
+			saga.WithAuthorizationExpiryInterval(cfg.PaymentAuthorizationCheckInterval),
+			// Запас до истечения — час, но не больше четверти срока: короткий hold иначе продлевался бы сразу.
+			saga.WithAuthorizationRenewBefore(min(time.Hour, cfg.PaymentAuthorizationTTL/4)),
+			saga.WithMaxReauthorizations(cfg.PaymentMaxReauthorizations),
+			saga.WithAuthorizationExpirySagaTimeout(cfg.SagaTimeout),
+		)
+		onTuning(func(v tuning.Values) { expiry.SetSagaTimeout(v.SagaTimeout) })
+		a.addRunner("authorization-expiry", expiry.Run)
+	}
+	if runtimeDeps.paymentRetries != nil && cfg.PaymentRetryMaxAttempts > 0 {
+		retries := saga.NewPaymentRetryScheduler(
+			runtimeDeps.paymentRetries,
+			deps.Repo,
+			sagaOrchestrator,
+			saga.WithPaymentRetryLogger(logger.WithField("component", "payment-retry")),
+			saga.WithPaymentRetryInterval(cfg.PaymentRetryInterval),
+			saga.WithPaymentRetrySagaTimeout(cfg.SagaTimeout),
+		)
+		onTuning(func(v tuning.Values) { retries.SetSagaTimeout(v.SagaTimeout) })
+		a.addRunner("payment-retry", retries.Run)
+	}
+
+	courierService := grpcsvc.NewCourierService(deps.CourierRepo, serviceLogger.WithField("service", "courier"))
+	adminService := grpcsvc.NewAdminService(runtimeDeps.customerEraser, deps.TimelineRepo, deps.OutboxRepo, serviceLogger.WithField("service", "admin"), adminServiceOptions...)
+	grpcMetrics := promgrpc.DefaultServerMetrics
+	if slo.HasLatency(sloObjectives) {
+		promgrpc.EnableHandlingTimeHistogram(promgrpc.WithHistogramBuckets(slo.HistogramBuckets(sloObjectives)))
+	}
+	requestLogging := grpcsvc.RequestLogging{SampleRate: cfg.GRPCLogSampleRate, SlowThreshold: cfg.GRPCSlowRequestThreshold}
+	requestLogger := logger.WithField("component", "grpc-requests")
+	saturationMonitor := newSaturationMonitor(cfg, logger)
+	var retryAdvisorOpts []grpcsvc.RetryAdvisorOption
+	if saturationMonitor != nil {
+		retryAdvisorOpts = append(retryAdvisorOpts, grpcsvc.WithRetryLoad(saturationMonitor.Ratio))
+	}
+	// Без токенов interceptor отклоняет AdminService целиком (nil-интерфейс, а не nil-*Keyring).
+	var adminVerifier grpcsvc.AdminTokenVerifier
+	if adminTokens != nil {
+		adminVerifier = adminTokens
+	}
+	grpcServer := grpc.NewServer(
+		grpc.ChainUnaryInterceptor(
+			grpcMetrics.UnaryServerInterceptor(),
+			grpcsvc.UnaryRequestLoggingInterceptor(requestLogger, requestLogging),
+			grpcsvc.UnaryEventHeadersInterceptor(),
+			grpcsvc.UnaryAdminAuthInterceptor(adminVerifier),
+			// До idempotency: невалидный запрос не занимает ключ и слот конкурентности.
+			grpcsvc.UnaryValidationInterceptor(nil),
+			grpcsvc.UnaryIdempotencyMetricsInterceptor(nil),
+			grpcsvc.UnaryRetryInfoInterceptor(grpcsvc.NewRetryAdvisor(retryAdvisorOpts...)),
+			// После RetryInfo, чтобы отказ по лимиту получил паузу перед повтором.
+			grpcsvc.UnaryConcurrencyLimitInterceptor(concurrencyLimits, nil,
+				grpcsvc.WithConcurrencyLimitSwitch(func() bool { return flags.Enabled(featureflags.Shedding) })),
+		),
+		grpc.ChainStreamInterceptor(
+			grpcMetrics.StreamServerInterceptor(),
+			grpcsvc.StreamRequestLoggingInterceptor(requestLogger, requestLogging),
+		),
+	)
+
+	omsv1.RegisterOrderServiceServer(grpcServer, orderService)
+	omsv1.RegisterCourierServiceServer(grpcServer, courierService)
+	if adminTokens != nil {
+		omsv1.RegisterAdminServiceServer(grpcServer, adminService)
+		if adminTokensSource != nil {
+			a.addRunner("admin-tokens-reloader", func(ctx context.Context) {
+				adminTokens.Watch(ctx, adminTokensSource, accessTokensReloadInterval, logger.WithField("component", "admin-tokens"))
+			})
+		}
+	} else {
+		logger.Warn("admin service and operator order methods (hold, release, approval) are disabled: OMS_ADMIN_TOKENS and OMS_ADMIN_TOKENS_FILE are not set")
+	}
+	grpcMetrics.InitializeMetrics(grpcServer)
+	if len(sloObjectives) > 0 {
+		exporter := slo.NewExporter(
+			sloObjectives,
+			slo.WithLogger(logger.WithField("component", "slo-exporter")),
+			slo.WithInterval(cfg.SLOInterval),
+		)
+		a.addRunner("slo-exporter", exporter.Run)
+	}
+	if saturationMonitor != nil {
+		a.addRunner("saturation-monitor", saturationMonitor.Run)
+	}
+	if cfg.OTLPMetricsEndpoint != "" {
+		// Тот же Gatherer, что у /metrics: имена и лейблы развёртывания совпадают со скрейпом.
+		otlpExporter, err := otlp.NewExporter(cfg.OTLPMetricsEndpoint,
+			otlp.WithLogger(logger.WithField("component", "otlp-metrics")),
+			otlp.WithInterval(cfg.OTLPMetricsInterval),
+			otlp.WithHeaders(otlpHeaders),
+			otlp.WithGatherer(metricsDeployment.Gatherer(prometheus.DefaultGatherer)),
+			otlp.WithResource(map[string]string{
+				"service.name":           "order-service",
+				"service.version":        version.GetVersion(),
+				"deployment.environment": cfg.MetricsEnvironment,
+				"cloud.region":           cfg.MetricsRegion,
+			}),
+		)
+		if err != nil {
+			return fmt.Errorf("otlp metrics exporter: %w", err)
+		}
+		a.addRunner("otlp-metrics", otlpExporter.Run)
+	}
+
+	// Register reflection service for grpcurl and load testing tools
+	reflection.Register(grpcServer)
+
+	healthServer := health.NewServer()
+	healthServer.SetServingStatus("", healthpb.HealthCheckResponse_SERVING)
+	healthpb.RegisterHealthServer(grpcServer, healthServer)
+
+	if cfg.GRPCAdminEnabled {
+		cleanup, err := admin.Register(grpcServer)
+		if err != nil {
+			return fmt.Errorf("register grpc admin services: %w", err)
+		}
+		a.add(&hooks{name: "grpc-admin", stop: cleanup})
+		logger.Warn("grpc admin services (channelz) are enabled on the public grpc port")
+	}
+
+	// HTTP Health checks
+	healthHandler := healthcheck.NewHandler(version.GetVersion())
+	if runtimeDeps.storageChecker != nil {
+		healthHandler.RegisterChecker("storage", runtimeDeps.storageChecker)
+	}
+	if outboxChecker != nil {
+		healthHandler.RegisterChecker("outbox", outboxChecker)
+	}
+
+	timelineSSE := notify.TimelineSSEHandler(timelineNotifier, deps.Repo, logger.WithField("component", "timeline-sse"))
+	// Учётные данные админки закрывают изменяющие запросы /admin/featureflags и /admin/tuning
+	// (nil-интерфейс, а не nil-*Keyring: без них такие запросы отклоняются).
+	var adminCreds adminui.CredentialVerifier
+	if adminUICreds != nil {
+		adminCreds = adminUICreds
+	}
+	featureFlagsAdmin := adminWrites(adminCreds, featureflags.Handler(flags, logger.WithField("component", "featureflags")))
+	var tuningAdmin http.Handler
+	if tuningStore != nil {
+		tuningAdmin = adminWrites(adminCreds, tuning.Handler(tuningStore, func() ([]tuning.Change, error) { return tuningReload("http") }, logger.WithField("component", "tuning")))
+	}
+	var adminUI http.Handler
+	if adminUICreds != nil {
+		adminUI = adminui.Handler(adminui.Sources{
+			Orders:   deps.Repo,
+			Timeline: deps.TimelineRepo,
+			Outbox:   deps.OutboxRepo,
+			Gatherer: prometheus.DefaultGatherer,
+		}, adminUICreds, logger.WithField("component", "admin-ui"))
+		logger.Infof("admin ui: %s%s", cfg.MetricsAddr, adminui.Prefix)
+		if adminUICredsSource != nil {
+			a.addRunner("admin-ui-credentials-reloader", func(ctx context.Context) {
+				adminUICreds.Watch(ctx, adminUICredsSource, accessTokensReloadInterval, logger.WithField("component", "admin-ui-credentials"))
+			})
+		}
+	}
+	var metricsSrv *http.Server
+	a.add(&hooks{
+		name: "metrics-server",
+		start: func(ctx context.Context) error {
+			metricsSrv = startMetricsServer(ctx, cfg.MetricsAddr, logger, metricsDeployment, healthHandler, featureFlagsAdmin, tuningAdmin, adminUI, timelineSSE)
+			return nil
+		},
+		stop: func() { shutdownHTTP(metricsSrv, logger, a.shutdown) },
+	})
+
+	server := &grpcComponent{server: grpcServer, health: healthServer, addr: cfg.GRPCAddr, logger: logger, report: a.shutdown, serveErr: a.serveErr}
+	a.add(server)
+
+	if cfg.CanaryInterval > 0 {
+		var canaryCancel context.CancelFunc
+		var canaryDone chan struct{}
+		a.add(&hooks{
+			name: "canary-prober",
+			start: func(ctx context.Context) error {
+				cancel, done, err := startCanaryProber(ctx, cfg, server.listenAddr(), deps, runtimeDeps.idempotencyRepo, logger)
+				canaryCancel, canaryDone = cancel, done
+				return err
+			},
+			stop: func() { stopRunner("canary-prober", canaryCancel, canaryDone, logger, a.shutdown) },
+		})
+	}
+
+	return nil
+}
+
+// addStorageWorkers регистрирует фоновое обслуживание хранилища; нулевой интервал выключает воркер.
+func (a *App) addStorageWorkers(cfg Config, deps *Dependencies, runtimeDeps runtimeDependencies) {
+	logger := a.logger
+
+	if deps.OutboxRepo != nil && cfg.OutboxCleanupInterval > 0 {
+		worker := outboxsvc.NewCleanupWorker(
+			deps.OutboxRepo,
+			outboxsvc.WithCleanupLogger(logger.WithField("component", "outbox-cleanup-worker")),
+			outboxsvc.WithCleanupInterval(cfg.OutboxCleanupInterval),
+			outboxsvc.WithSentRetention(cfg.OutboxSentRetention),
+		)
+		a.addRunner("outbox-cleanup-worker", worker.Run)
+	}
+
+	if runtimeDeps.idempotencyRepo != nil && cfg.IdempotencyCleanupInterval > 0 {
+		worker := idempotencysvc.NewCleanupWorker(
+			runtimeDeps.idempotencyRepo,
+			idempotencysvc.WithLogger(logger.WithField("component", "idempotency-cleanup-worker")),
+			idempotencysvc.WithInterval(cfg.IdempotencyCleanupInterval),
+			idempotencysvc.WithBatchSize(cfg.IdempotencyCleanupBatchSize),
+			idempotencysvc.WithStaleProcessingAfter(cfg.IdempotencyStaleProcessing),
+		)
+		a.addRunner("idempotency-cleanup-worker", worker.Run)
+	}
+
+	if lister, ok := deps.InventorySvc.(domain.ReservationLister); ok && cfg.InventoryReconcileInterval > 0 {
+		reconciler := inventory.NewReconciler(
+			deps.Repo,
+			deps.InventorySvc,
+			lister,
+			inventory.WithLogger(logger.WithField("component", "inventory-reconciler")),
+			inventory.WithInterval(cfg.InventoryReconcileInterval),
+			inventory.WithDryRun(cfg.InventoryReconcileDryRun),
+		)
+		a.addRunner("inventory-reconciler", reconciler.Run)
+	}
+
+	if cfg.AmountCheckInterval > 0 {
+		checker := consistency.NewChecker(
+			deps.Repo,
+			consistency.WithLogger(logger.WithField("component", "amount-consistency")),
+			consistency.WithInterval(cfg.AmountCheckInterval),
+			consistency.WithRepair(cfg.AmountCheckRepair),
+		)
+		a.addRunner("amount-checker", checker.Run)
+	}
+}
@@ -0,0 +1,496 @@
+package app
+
+import (
+	"context"
+	"errors"
+	"maps"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/IBM/sarama/mocks"
+	log "github.com/sirupsen/logrus"
+	"google.golang.org/grpc"
+
+	"github.com/vladislavdragonenkov/oms/internal/domain"
+	"github.com/vladislavdragonenkov/oms/internal/messaging/kafka"
+	"github.com/vladislavdragonenkov/oms/internal/service/inventory"
+)
+
+// closeCountingProducer — sarama.SyncProducer без брокера, считающий вызовы Close.
+type closeCountingProducer struct {
+	*mocks.SyncProducer
+	closed atomic.Int32
+}
+
+func (p *closeCountingProducer) Close() error {
+	p.closed.Add(1)
+	return p.SyncProducer.Close()
+}
+
+type stubGroupConsumer struct {
+	topics           []string
+	started, stopped bool
+}
+
+func (c *stubGroupConsumer) Start(context.Context) error {
+	c.started = true
+	return nil
+}
+
+func (c *stubGroupConsumer) Stop() error {
+	c.stopped = true
+	return nil
+}
+
+// withFakeKafka подменяет подключение к Kafka на время теста и возвращает producer и consumer-заглушки
+// по consumer group.
+func withFakeKafka(t *testing.T) (*closeCountingProducer, map[string]*stubGroupConsumer) {
+	t.Helper()
+	t.Setenv("KAFKA_BROKERS", "broker-1:9092")
+
+	producer := &closeCountingProducer{SyncProducer: mocks.NewSyncProducer(t, nil)}
+	consumers := make(map[string]*stubGroupConsumer)
+	oldProducer, oldConsumer := connectKafkaProducer, newGroupConsumer
+	t.Cleanup(func() { connectKafkaProducer, newGroupConsumer = oldProducer, oldConsumer })
+
+	connectKafkaProducer = func(_ context.Context, brokers []string, _ *log.Entry, options ...kafka.ProducerOption) (*kafka.Producer, error) {
+		if !slices.Equal(brokers, []string{"broker-1:9092"}) {
+			t.Fatalf("unexpected brokers: %v", brokers)
+		}
+		return kafka.NewProducerFromSync(producer, options...), nil
+	}
+	newGroupConsumer = func(_ []string, groupID string, topics []string, _ kafka.MessageHandler, _ *kafka.Producer, _ kafka.DLQPolicy, _ ...kafka.ConsumerOption) (groupConsumer, error) {
+		consumer := &stubGroupConsumer{topics: topics}
+		consumers[groupID] = consumer
+		return consumer, nil
+	}
+	return producer, consumers
+}
+
+func testAppConfig() Config {
+	cfg := DefaultConfig()
+	cfg.GRPCAddr = "127.0.0.1:0"
+	cfg.MetricsAddr = "127.0.0.1:0"
+	return cfg
+}
+
+func buildTestApp(t *testing.T, cfg Config) *App {
+	t.Helper()
+	application, err := BuildApp(context.Background(), cfg)
+	if err != nil {
+		t.Fatalf("BuildApp failed: %v", err)
+	}
+	t.Cleanup(application.Close)
+	return application
+}
+
+func requireComponents(t *testing.T, application *App, present []string, absent []string) {
+	t.Helper()
+	names := application.ComponentNames()
+	for _, name := range present {
+		if !slices.Contains(names, name) {
+			t.Fatalf("expected component %q, got %v", name, names)
+		}
+	}
+	for _, name := range absent {
+		if slices.Contains(names, name) {
+			t.Fatalf("unexpected component %q in %v", name, names)
+		}
+	}
+}
+
+func TestBuildApp_MemoryWithoutKafka(t *testing.T) {
+	t.Setenv("KAFKA_BROKERS", "")
+
+	application := buildTestApp(t, testAppConfig())
+
+	requireComponents(t, application,
+		[]string{"log-level-reloader", "outbox-cleanup-worker", "idempotency-cleanup-worker", "inventory-reconciler", "amount-checker", "order-service", "saga-replay", "cancel-scheduler", "authorization-expiry", "saturation-monitor", "metrics-server", "grpc-server"},
+		[]string{"kafka-producer", "outbox-worker", "saga-events-queue", "restock-consumer", "payment-events-consumer", "slo-exporter", "canary-prober", "grpc-admin", "order-list-cache"},
+	)
+}
+
+func TestBuildApp_WorkersFollowIntervals(t *testing.T) {
+	t.Setenv("KAFKA_BROKERS", "")
+
+	cfg := testAppConfig()
+	cfg.OutboxCleanupInterval = 0
+	cfg.IdempotencyCleanupInterval = 0
+	cfg.InventoryReconcileInterval = 0
+	cfg.AmountCheckInterval = 0
+	cfg.ScheduledCancelInterval = 0
+	cfg.PaymentAuthorizationCheckInterval = 0
+	cfg.SaturationInterval = 0
+	cfg.CanaryInterval = time.Minute
+	cfg.SLOObjectives = "api:kind=availability,target=0.999"
+
+	application := buildTestApp(t, cfg)
+
+	requireComponents(t, application,
+		[]string{"slo-exporter", "canary-prober"},
+		[]string{"outbox-cleanup-worker", "idempotency-cleanup-worker", "inventory-reconciler", "amount-checker", "cancel-scheduler", "authorization-expiry", "saturation-monitor"},
+	)
+	names := application.ComponentNames()
+	if names[len(names)-1] != "canary-prober" {
+		t.Fatalf("canary must start after the grpc server it probes: %v", names)
+	}
+}
+
+func TestBuildApp_InventoryRoutesCreateBackends(t *testing.T) {
+	t.Setenv("KAFKA_BROKERS", "")
+
+	var created []string
+	original := newInventoryBackend
+	newInventoryBackend = func(name string) domain.InventoryService {
+		created = append(created, name)
+		return inventory.NewMockService()
+	}
+	t.Cleanup(func() { newInventoryBackend = original })
+
+	cfg := testAppConfig()
+	cfg.InventoryRoutes = "sku:DIG-=digital;default=warehouse,fallback=reserve"
+	application := buildTestApp(t, cfg)
+
+	if strings.Join(created, ",") != "digital,reserve,warehouse" {
+		t.Fatalf("expected one backend per route target, got %v", created)
+	}
+	// Router отдаёт резервы всех складов, поэтому сверка остаётся включённой.
+	requireComponents(t, application, []string{"inventory-reconciler"}, nil)
+}
+
+func TestBuildApp_OrderListCache(t *testing.T) {
+	t.Setenv("KAFKA_BROKERS", "")
+
+	cfg := testAppConfig()
+	cfg.OrderListCacheTTL = time.Second
+	requireComponents(t, buildTestApp(t, cfg), []string{"order-list-cache"}, nil)
+}
+
+func TestBuildApp_OTLPMetrics(t *testing.T) {
+	t.Setenv("KAFKA_BROKERS", "")
+
+	cfg := testAppConfig()
+	requireComponents(t, buildTestApp(t, cfg), nil, []string{"otlp-metrics"})
+
+	cfg.OTLPMetricsEndpoint = "http://127.0.0.1:4318"
+	cfg.OTLPMetricsHeaders = "api-key=secret"
+	requireComponents(t, buildTestApp(t, cfg), []string{"otlp-metrics"}, nil)
+}
+
+func TestBuildApp_GRPCAdminServices(t *testing.T) {
+	t.Setenv("KAFKA_BROKERS", "")
+
+	cfg := testAppConfig()
+	cfg.GRPCAdminEnabled = true
+	application := buildTestApp(t, cfg)
+
+	requireComponents(t, application, []string{"grpc-admin"}, nil)
+	var services map[string]grpc.ServiceInfo
+	for _, component := range application.components {
+		if server, ok := component.(*grpcComponent); ok {
+			services = server.server.GetServiceInfo()
+		}
+	}
+	if _, ok := services["grpc.channelz.v1.Channelz"]; !ok {
+		t.Fatalf("expected channelz service to be registered, got %v", slices.Collect(maps.Keys(services)))
+	}
+}
+
+func TestBuildApp_AdminServiceRequiresTokens(t *testing.T) {
+	t.Setenv("KAFKA_BROKERS", "")
+
+	services := func(application *App) map[string]grpc.ServiceInfo {
+		for _, component := range application.components {
+			if server, ok := component.(*grpcComponent); ok {
+				return server.server.GetServiceInfo()
+			}
+		}
+		return nil
+	}
+
+	cfg := testAppConfig()
+	if _, ok := services(buildTestApp(t, cfg))["oms.v1.AdminService"]; ok {
+		t.Fatal("AdminService must not be registered without admin tokens")
+	}
+
+	cfg.AdminTokensFile = filepath.Join(t.TempDir(), "admin-tokens")
+	if err := os.WriteFile(cfg.AdminTokensFile, []byte("alice:s3cr3t\n"), 0o600); err != nil {
+		t.Fatalf("WriteFile failed: %v", err)
+	}
+	application := buildTestApp(t, cfg)
+	if _, ok := services(application)["oms.v1.AdminService"]; !ok {
+		t.Fatal("AdminService must be registered with admin tokens")
+	}
+	requireComponents(t, application, []string{"admin-tokens-reloader"}, nil)
+
+	cfg.AdminTokensFile = ""
+	cfg.AdminTokens = "alice:s3cr3t"
+	requireComponents(t, buildTestApp(t, cfg), nil, []string{"admin-tokens-reloader"})
+}
+
+func TestBuildApp_AdminUICredentialsFile(t *testing.T) {
+	t.Setenv("KAFKA_BROKERS", "")
+
+	cfg := testAppConfig()
+	cfg.AdminUICredentials = "oncall:secret"
+	requireComponents(t, buildTestApp(t, cfg), nil, []string{"admin-ui-credentials-reloader"})
+
+	cfg.AdminUICredentials = ""
+	cfg.AdminUICredentialsFile = filepath.Join(t.TempDir(), "admin-ui")
+	if err := os.WriteFile(cfg.AdminUICredentialsFile, []byte("oncall:secret\n"), 0o600); err != nil {
+		t.Fatalf("WriteFile failed: %v", err)
+	}
+	requireComponents(t, buildTestApp(t, cfg), []string{"admin-ui-credentials-reloader"}, nil)
+}
+
+func TestBuildApp_KafkaWiring(t *testing.T) {
+	producer, _ := withFakeKafka(t)
+
+	cfg := testAppConfig()
+	application := buildTestApp(t, cfg)
+
+	requireComponents(t, application, []string{"kafka-producer", "outbox-worker", "saga-events-queue"}, []string{"restock-consumer", "payment-events-consumer"})
+	names := application.ComponentNames()
+	producerIdx := slices.Index(names, "kafka-producer")
+	for _, dependent := range []string{"outbox-worker", "saga-events-queue", "order-service"} {
+		if slices.Index(names, dependent) < producerIdx {
+			t.Fatalf("%s must start after kafka-producer: %v", dependent, names)
+		}
+	}
+
+	application.Close()
+	if got := producer.closed.Load(); got != 1 {
+		t.Fatalf("expected producer to be closed once, got %d", got)
+	}
+
+	cfg.KafkaSagaEventBuffer = 0
+	requireComponents(t, buildTestApp(t, cfg), []string{"kafka-producer"}, []string{"saga-events-queue"})
+}
+
+func TestBuildApp_KafkaDisabledByFlag(t *testing.T) {
+	withFakeKafka(t)
+
+	cfg := testAppConfig()
+	cfg.FeatureFlags = "kafka_enabled=false,backorders=true,payment_events=true"
+
+	requireComponents(t, buildTestApp(t, cfg), nil, []string{"kafka-producer", "outbox-worker", "restock-consumer", "payment-events-consumer"})
+}
+
+func TestBuildApp_BackordersStartRestockConsumer(t *testing.T) {
+	_, consumers := withFakeKafka(t)
+
+	cfg := testAppConfig()
+	cfg.FeatureFlags = "backorders=true"
+	application := buildTestApp(t, cfg)
+	requireComponents(t, application, []string{"restock-consumer"}, nil)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if err := application.Run(ctx); !errors.Is(err, context.Canceled) {
+		t.Fatalf("expected context.Canceled, got %v", err)
+	}
+	consumer := consumers[kafka.ConsumerGroupBackorders]
+	if consumer == nil || !consumer.started || !consumer.stopped {
+		t.Fatalf("expected restock consumer to be started and stopped: %+v", consumer)
+	}
+}
+
+func TestBuildApp_PaymentEventsConsumer(t *testing.T) {
+	_, consumers := withFakeKafka(t)
+
+	cfg := testAppConfig()
+	cfg.FeatureFlags = "payment_events=true"
+	application := buildTestApp(t, cfg)
+	requireComponents(t, application, []string{"payment-events-parking", "payment-events-consumer"}, []string{"restock-consumer"})
+
+	names := application.ComponentNames()
+	if slices.Index(names, "payment-events-parking") > slices.Index(names, "payment-events-consumer") {
+		t.Fatalf("parking retries must stop after the consumer that feeds them: %v", names)
+	}
+	consumer := consumers[kafka.ConsumerGroupPayments]
+	if consumer == nil || !slices.Equal(consumer.topics, []string{kafka.TopicPaymentEvents}) {
+		t.Fatalf("expected payments consumer on %s, got %+v", kafka.TopicPaymentEvents, consumer)
+	}
+}
+
+func TestBuildApp_TuningFile(t *testing.T) {
+	withFakeKafka(t)
+
+	cfg := testAppConfig()
+	cfg.FeatureFlags = "payment_events=true"
+	application := buildTestApp(t, cfg)
+	requireComponents(t, application, nil, []string{"tuning-reloader"})
+
+	cfg.TuningFile = filepath.Join(t.TempDir(), "tuning.conf")
+	application = buildTestApp(t, cfg)
+	requireComponents(t, application, []string{"tuning-reloader", "outbox-worker", "payment-events-consumer"}, nil)
+}
+
+func TestBuildApp_FailureReleasesResources(t *testing.T) {
+	producer, _ := withFakeKafka(t)
+	newGroupConsumer = func([]string, string, []string, kafka.MessageHandler, *kafka.Producer, kafka.DLQPolicy, ...kafka.ConsumerOption) (groupConsumer, error) {
+		return nil, errors.New("group coordinator unavailable")
+	}
+
+	cfg := testAppConfig()
+	cfg.FeatureFlags = "backorders=true"
+	cfg.DevPersistPath = filepath.Join(t.TempDir(), "oms-dev.json")
+
+	_, err := BuildApp(context.Background(), cfg)
+	if err == nil || !strings.Contains(err.Error(), "init restock consumer") {
+		t.Fatalf("expected restock consumer error, got %v", err)
+	}
+	if got := producer.closed.Load(); got != 1 {
+		t.Fatalf("producer must be closed when build fails, closed=%d", got)
+	}
+	if _, statErr := os.Stat(cfg.DevPersistPath); statErr != nil {
+		t.Fatalf("storage must be closed (dev snapshot saved) when build fails: %v", statErr)
+	}
+}
+
+func TestBuildApp_ConfigErrors(t *testing.T) {
+	t.Setenv("KAFKA_BROKERS", "")
+
+	tests := []struct {
+		name   string
+		mutate func(*testing.T, *Config)
+		want   string
+	}{
+		{name: "storage driver", mutate: func(_ *testing.T, cfg *Config) { cfg.StorageDriver = "invalid-driver" }, want: "unsupported storage driver"},
+		{name: "mock integrations", mutate: func(_ *testing.T, cfg *Config) { cfg.StorageDriver = StorageDriverPostgres }, want: "OMS_ALLOW_MOCK_INTEGRATIONS"},
+		{name: "feature flags", mutate: func(_ *testing.T, cfg *Config) { cfg.FeatureFlags = "unknown=true" }, want: "parse feature flags"},
+		{name: "kafka brokers", mutate: func(t *testing.T, _ *Config) { t.Setenv("KAFKA_BROKERS", " , ") }, want: "KAFKA_BROKERS is set"},
+		{name: "list page limits", mutate: func(_ *testing.T, cfg *Config) { cfg.ListDefaultPageSize = cfg.ListMaxPageSize + 1 }, want: "list page limits"},
+		{name: "inventory routes", mutate: func(_ *testing.T, cfg *Config) { cfg.InventoryRoutes = "sku:DIG-=digital" }, want: "parse inventory routes"},
+		{name: "storage chaos in production", mutate: func(_ *testing.T, cfg *Config) {
+			cfg.StorageChaos, cfg.MetricsEnvironment = "*:error=0.1", "production"
+		}, want: "OMS_STORAGE_CHAOS"},
+		{name: "otlp metrics headers", mutate: func(_ *testing.T, cfg *Config) { cfg.OTLPMetricsHeaders = "api-key" }, want: "parse otlp metrics headers"},
+		{name: "admin ui credentials", mutate: func(_ *testing.T, cfg *Config) { cfg.AdminUICredentials = "oncall" }, want: "parse admin ui credentials"},
+		{name: "admin tokens", mutate: func(_ *testing.T, cfg *Config) {
+			cfg.AdminTokens, cfg.AdminTokensFile = "alice:s3cr3t", "/etc/oms/admin-tokens"
+		}, want: "parse admin tokens"},
+		{name: "storage chaos rules", mutate: func(_ *testing.T, cfg *Config) {
+			cfg.StorageChaos, cfg.MetricsEnvironment = "*:error=5", "staging"
+		}, want: "parse storage chaos rules"},
+		{name: "tuning file", mutate: func(t *testing.T, cfg *Config) {
+			cfg.TuningFile = filepath.Join(t.TempDir(), "tuning.conf")
+			if err := os.WriteFile(cfg.TuningFile, []byte("outbox_batch_size=0\n"), 0o600); err != nil {
+				t.Fatalf("WriteFile failed: %v", err)
+			}
+		}, want: "parse tuning file"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := testAppConfig()
+			tt.mutate(t, &cfg)
+			if _, err := BuildApp(context.Background(), cfg); err == nil || !strings.Contains(err.Error(), tt.want) {
+				t.Fatalf("expected error containing %q, got %v", tt.want, err)
+			}
+		})
+	}
+}
+
+// recordingComponent пишет события жизненного цикла в общий журнал.
+type recordingComponent struct {
+	name     string
+	startErr error
+	events   *[]string
+}
+
+func (c *recordingComponent) Name() string { return c.name }
+
+func (c *recordingComponent) Start(context.Context) error {
+	*c.events = append(*c.events, "start "+c.name)
+	return c.startErr
+}
+
+func (c *recordingComponent) Stop() {
+	*c.events = append(*c.events, "stop "+c.name)
+}
+
+func TestApp_RunStopsComponentsInReverseOrder(t *testing.T) {
+	var events []string
+	application := &App{logger: log.WithField("test", "app-run"), serveErr: make(chan error, 1)}
+	for _, name := range []string{"storage", "producer", "server"} {
+		application.add(&recordingComponent{name: name, events: &events})
+	}
+	application.closers = append(application.closers, func() { events = append(events, "close storage") })
+
+	application.serveErr <- errors.New("listener closed")
+	if err := application.Run(context.Background()); err == nil || err.Error() != "listener closed" {
+		t.Fatalf("expected serve error, got %v", err)
+	}
+	application.Close()
+
+	want := []string{"start storage", "start producer", "start server", "stop server", "stop producer", "stop storage", "close storage"}
+	if !slices.Equal(events, want) {
+		t.Fatalf("unexpected lifecycle:\n got %v\nwant %v", events, want)
+	}
+}
+
+func TestApp_RunStartFailure(t *testing.T) {
+	var events []string
+	application := &App{logger: log.WithField("test", "app-start-failure"), serveErr: make(chan error, 1)}
+	application.add(&recordingComponent{name: "producer", events: &events})
+	application.add(&recordingComponent{name: "server", startErr: errors.New("address in use"), events: &events})
+	application.add(&recordingComponent{name: "canary", events: &events})
+
+	err := application.Run(context.Background())
+	if !errors.Is(err, ErrStartupFailed) || !strings.HasSuffix(err.Error(), "start server: address in use") {
+		t.Fatalf("expected wrapped start error, got %v", err)
+	}
+	want := []string{"start producer", "start server", "stop canary", "stop server", "stop producer"}
+	if !slices.Equal(events, want) {
+		t.Fatalf("unexpected lifecycle:\n got %v\nwant %v", events, want)
+	}
+}
+
+func TestApp_RunShutdownReport(t *testing.T) {
+	newApp := func() *App {
+		return &App{logger: log.WithField("test", "app-shutdown-report"), serveErr: make(chan error, 1), builtAt: time.Now(), shutdown: &shutdownRecorder{}}
+	}
+
+	t.Run("clean", func(t *testing.T) {
+		application := newApp()
+		application.add(&hooks{name: "order-service", stop: func() { application.shutdown.sagas(2, 0) }})
+		application.add(&hooks{name: "consumer", stop: func() { application.shutdown.consumerClosed() }})
+
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+		if err := application.Run(ctx); !errors.Is(err, context.Canceled) || errors.Is(err, ErrDrainTimeout) {
+			t.Fatalf("expected context.Canceled, got %v", err)
+		}
+		report := application.ShutdownReport()
+		if report.Reason != ShutdownReasonSignal || report.SagasDrained != 2 || report.ConsumersClosed != 1 || !report.Clean() {
+			t.Fatalf("unexpected report: %+v", report)
+		}
+	})
+
+	t.Run("drain timeout", func(t *testing.T) {
+		application := newApp()
+		application.add(&hooks{name: "order-service", stop: func() {
+			application.shutdown.sagas(1, 3)
+			application.shutdown.timedOut("order-service")
+		}})
+		application.add(&hooks{name: "producer", stop: func() { application.shutdown.failed("kafka-producer", errors.New("flush failed")) }})
+
+		application.serveErr <- grpc.ErrServerStopped
+		err := application.Run(context.Background())
+		if !errors.Is(err, ErrDrainTimeout) || !strings.Contains(err.Error(), "order-service") {
+			t.Fatalf("expected drain timeout, got %v", err)
+		}
+		report := application.ShutdownReport()
+		if report.Reason != ShutdownReasonServerStopped || report.SagasAbandoned != 3 || report.Clean() {
+			t.Fatalf("unexpected report: %+v", report)
+		}
+		if !slices.Equal(report.Errors, []string{"kafka-producer: flush failed"}) {
+			t.Fatalf("unexpected shutdown errors: %v", report.Errors)
+		}
+	})
+}
@@ -2,16 +2,23 @@ package app
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
 	"io"
 	"net"
 	"net/http"
+	"net/http/httptest"
+	"strings"
 	"testing"
 	"time"
 
+	"github.com/prometheus/client_golang/prometheus"
 	log "github.com/sirupsen/logrus"
 
+	"github.com/vladislavdragonenkov/oms/internal/featureflags"
 	healthcheck "github.com/vladislavdragonenkov/oms/internal/health"
+	"github.com/vladislavdragonenkov/oms/internal/keyring"
+	"github.com/vladislavdragonenkov/oms/internal/metrics"
 	"github.com/vladislavdragonenkov/oms/internal/version"
 )
 
@@ -26,7 +33,7 @@ func TestStartMetricsServer_Endpoints(t *testing.T) {
 	defer cancel()
 
 	healthHandler := healthcheck.NewHandler(version.GetVersion())
-	srv := startMetricsServer(ctx, addr, logger, healthHandler)
+	srv := startMetricsServer(ctx, addr, logger, metrics.Deployment{}, healthHandler, nil, nil, nil, nil)
 
 	// Проверяем /metrics
 	metricsURL := fmt.Sprintf("http://localhost:%d/metrics", port)
@@ -99,6 +106,40 @@ func TestStartMetricsServer_Endpoints(t *testing.T) {
 	}
 }
 
+func TestStartMetricsServer_OpenAPI(t *testing.T) {
+	logger := log.WithField("test", "http-openapi")
+
+	port := findFreePort(t)
+	addr := fmt.Sprintf(":%d", port)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	healthHandler := healthcheck.NewHandler(version.GetVersion())
+	startMetricsServer(ctx, addr, logger, metrics.Deployment{}, healthHandler, nil, nil, nil, nil)
+
+	url := fmt.Sprintf("http://localhost:%d/openapi.json", port)
+	waitForHTTPStatus(t, url, http.StatusOK, 2*time.Second)
+	resp, err := http.Get(url)
+	if err != nil {
+		t.Fatalf("failed to get /openapi.json: %v", err)
+	}
+	defer resp.Body.Close()
+
+	var doc struct {
+		Paths map[string]map[string]json.RawMessage `json:"paths"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&doc); err != nil {
+		t.Fatalf("decode openapi document: %v", err)
+	}
+	if _, ok := doc.Paths["/v1/orders/{order_id}"]["get"]; !ok {
+		t.Fatal("expected GetOrder route in openapi document")
+	}
+	if _, ok := doc.Paths["/v1/couriers"]["post"]; !ok {
+		t.Fatal("expected RegisterCourier route in openapi document")
+	}
+}
+
 func TestStartMetricsServer_Shutdown(t *testing.T) {
 	logger := log.WithField("test", "http-shutdown")
 
@@ -108,7 +149,7 @@ func TestStartMetricsServer_Shutdown(t *testing.T) {
 	ctx, cancel := context.WithCancel(context.Background())
 
 	healthHandler := healthcheck.NewHandler(version.GetVersion())
-	srv := startMetricsServer(ctx, addr, logger, healthHandler)
+	srv := startMetricsServer(ctx, addr, logger, metrics.Deployment{}, healthHandler, nil, nil, nil, nil)
 
 	// Проверяем что сервер работает
 	url := fmt.Sprintf("http://localhost:%d/livez", port)
@@ -127,7 +168,7 @@ func TestShutdownHTTP_NilServer(_ *testing.T) {
 	logger := log.WithField("test", "http-nil")
 
 	// Не должно паниковать
-	shutdownHTTP(nil, logger)
+	shutdownHTTP(nil, logger, nil)
 }
 
 func TestShutdownHTTP_WithServer(t *testing.T) {
@@ -153,7 +194,7 @@ func TestShutdownHTTP_WithServer(t *testing.T) {
 	waitForHTTPStatus(t, url, http.StatusOK, 2*time.Second)
 
 	// Останавливаем
-	shutdownHTTP(srv, logger)
+	shutdownHTTP(srv, logger, nil)
 
 	// Проверяем что остановился
 	waitForHTTPFailure(t, url, 2*time.Second)
@@ -176,7 +217,7 @@ func TestStartMetricsServer_InvalidAddr(t *testing.T) {
 	healthHandler := healthcheck.NewHandler(version.GetVersion())
 
 	// Сервер всё равно создаётся, но не может стартовать
-	srv := startMetricsServer(ctx, addr, logger, healthHandler)
+	srv := startMetricsServer(ctx, addr, logger, metrics.Deployment{}, healthHandler, nil, nil, nil, nil)
 
 	if srv == nil {
 		t.Error("startMetricsServer should not return nil even with invalid addr")
@@ -195,7 +236,11 @@ func TestStartMetricsServer_MultipleEndpoints(t *testing.T) {
 	defer cancel()
 
 	healthHandler := healthcheck.NewHandler(version.GetVersion())
-	srv := startMetricsServer(ctx, addr, logger, healthHandler)
+	flags, err := featureflags.New(nil)
+	if err != nil {
+		t.Fatalf("init feature flags: %v", err)
+	}
+	srv := startMetricsServer(ctx, addr, logger, metrics.Deployment{}, healthHandler, adminWrites(nil, featureflags.Handler(flags, nil)), nil, nil, nil)
 
 	// Проверяем все endpoints
 	endpoints := []string{
@@ -203,6 +248,7 @@ func TestStartMetricsServer_MultipleEndpoints(t *testing.T) {
 		fmt.Sprintf("http://localhost:%d/healthz", port),
 		fmt.Sprintf("http://localhost:%d/livez", port),
 		fmt.Sprintf("http://localhost:%d/readyz", port),
+		fmt.Sprintf("http://localhost:%d/admin/featureflags", port),
 	}
 
 	for _, url := range endpoints {
@@ -286,3 +332,50 @@ func findFreePort(t *testing.T) int {
 
 	return listener.Addr().(*net.TCPAddr).Port
 }
+
+func TestAdminWrites_RequiresCredentialsForChanges(t *testing.T) {
+	keys, err := keyring.ParseTokens("oncall:s3cr3t")
+	if err != nil {
+		t.Fatalf("parse credentials: %v", err)
+	}
+	creds, err := keyring.New(keys, keyring.WithRegisterer(prometheus.NewRegistry()))
+	if err != nil {
+		t.Fatalf("new keyring: %v", err)
+	}
+	flags, err := featureflags.New(nil, featureflags.WithRegisterer(prometheus.NewRegistry()))
+	if err != nil {
+		t.Fatalf("init feature flags: %v", err)
+	}
+	handler := adminWrites(creds, featureflags.Handler(flags, nil))
+	serve := func(handler http.Handler, method, password string) int {
+		req := httptest.NewRequest(method, "/admin/featureflags", strings.NewReader(`{"name":"shedding","enabled":false}`))
+		if password != "" {
+			req.SetBasicAuth("oncall", password)
+		}
+		rec := httptest.NewRecorder()
+		handler.ServeHTTP(rec, req)
+		return rec.Code
+	}
+
+	if code := serve(handler, http.MethodGet, ""); code != http.StatusOK {
+		t.Fatalf("GET must stay open, got %d", code)
+	}
+	if code := serve(handler, http.MethodPost, ""); code != http.StatusUnauthorized {
+		t.Fatalf("expected 401 for POST without credentials, got %d", code)
+	}
+	if code := serve(handler, http.MethodPost, "wrong"); code != http.StatusUnauthorized {
+		t.Fatalf("expected 401 for POST with wrong password, got %d", code)
+	}
+	if !flags.Enabled(featureflags.Shedding) {
+		t.Fatal("rejected POST must not toggle the flag")
+	}
+	if code := serve(handler, http.MethodPost, "s3cr3t"); code != http.StatusOK {
+		t.Fatalf("expected 200 for authenticated POST, got %d", code)
+	}
+	if flags.Enabled(featureflags.Shedding) {
+		t.Fatal("authenticated POST must toggle the flag")
+	}
+	if code := serve(adminWrites(nil, featureflags.Handler(flags, nil)), http.MethodPost, "s3cr3t"); code != http.StatusForbidden {
+		t.Fatalf("expected 403 without configured credentials, got %d", code)
+	}
+}
@@ -1,6 +1,7 @@
 package app
 
 import (
+	"fmt"
 	"strings"
 
 	log "github.com/sirupsen/logrus"
@@ -38,3 +39,17 @@ func closeKafka(producer *kafka.Producer, logger *log.Entry) {
 		logger.Info("kafka producer closed")
 	}
 }
+
+// newEventFieldEncryptor собирает шифратор полей outbox-событий из ключей конфигурации.
+// Ошибка в ключах останавливает запуск: публиковать PII открытым текстом молча нельзя.
+func newEventFieldEncryptor(cfg Config) (*kafka.FieldEncryptor, error) {
+	wrapper, err := kafka.ParseStaticKeyWrapper(cfg.EventEncryptionKeys)
+	if err != nil {
+		return nil, fmt.Errorf("parse event encryption keys: %w", err)
+	}
+	encryptor, err := kafka.NewFieldEncryptor(wrapper, kafka.ParseEncryptedFields(cfg.EventEncryptedFields)...)
+	if err != nil {
+		return nil, fmt.Errorf("init event encryption: %w", err)
+	}
+	return encryptor, nil
+}
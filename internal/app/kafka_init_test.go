@@ -1,6 +1,7 @@
 package app
 
 import (
+	"encoding/base64"
 	"testing"
 
 	log "github.com/sirupsen/logrus"
@@ -87,3 +88,22 @@ func TestInitKafkaProducer_BrokersWithSpaces(t *testing.T) {
 		t.Error("expected nil producer on error")
 	}
 }
+
+func TestNewEventFieldEncryptor(t *testing.T) {
+	cfg := DefaultConfig()
+	cfg.EventEncryptionKeys = "k1:" + base64.StdEncoding.EncodeToString(make([]byte, 32))
+	if _, err := newEventFieldEncryptor(cfg); err != nil {
+		t.Fatalf("expected valid encryptor, got %v", err)
+	}
+
+	cfg.EventEncryptedFields = " , "
+	if _, err := newEventFieldEncryptor(cfg); err == nil {
+		t.Fatal("expected error for empty field list")
+	}
+
+	cfg = DefaultConfig()
+	cfg.EventEncryptionKeys = "k1:too-short"
+	if _, err := newEventFieldEncryptor(cfg); err == nil {
+		t.Fatal("expected error for invalid key")
+	}
+}
@@ -0,0 +1,78 @@
+package app
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"os/signal"
+	"syscall"
+
+	log "github.com/sirupsen/logrus"
+
+	"github.com/vladislavdragonenkov/oms/internal/logging"
+)
+
+// loadLogLevels собирает уровни логирования: global (LOG_LEVEL), поверх него OMS_LOG_LEVELS,
+// поверх — файл OMS_LOG_LEVELS_FILE. Отсутствующий файл не ошибка: ConfigMap может быть не смонтирован.
+func loadLogLevels(cfg Config, global log.Level) (logging.Config, error) {
+	levels, err := logging.Parse(cfg.LogLevels, global)
+	if err != nil {
+		return logging.Config{}, fmt.Errorf("parse log levels: %w", err)
+	}
+	if cfg.LogLevelsFile == "" {
+		return levels, nil
+	}
+
+	raw, err := os.ReadFile(cfg.LogLevelsFile)
+	if errors.Is(err, fs.ErrNotExist) {
+		return levels, nil
+	}
+	if err != nil {
+		return logging.Config{}, fmt.Errorf("read log levels file: %w", err)
+	}
+	fromFile, err := logging.Parse(string(raw), levels.Global)
+	if err != nil {
+		return logging.Config{}, fmt.Errorf("parse log levels file %s: %w", cfg.LogLevelsFile, err)
+	}
+	return levels.Merge(fromFile), nil
+}
+
+// startLogLevelReloader перечитывает уровни по SIGHUP. Runtime-изменения через SetLogLevel
+// при этом сбрасываются к конфигурации. Возвращает stop, снимающий обработчик сигнала.
+func startLogLevelReloader(ctx context.Context, levels *logging.Levels, cfg Config, global log.Level, logger *log.Entry) func() {
+	signals := make(chan os.Signal, 1)
+	signal.Notify(signals, syscall.SIGHUP)
+	reloadCtx, cancel := context.WithCancel(ctx)
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		watchLogLevelReloads(reloadCtx, signals, levels, func() (logging.Config, error) {
+			return loadLogLevels(cfg, global)
+		}, logger)
+	}()
+	return func() {
+		signal.Stop(signals)
+		cancel()
+		<-done
+	}
+}
+
+func watchLogLevelReloads(ctx context.Context, signals <-chan os.Signal, levels *logging.Levels, load func() (logging.Config, error), logger *log.Entry) {
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-signals:
+			next, err := load()
+			if err != nil {
+				// Ошибка в файле не должна сбрасывать рабочие уровни.
+				logger.WithError(err).Error("failed to reload log levels, keeping current")
+				continue
+			}
+			levels.Set(next)
+			logger.WithField("levels", next.String()).Warn("log levels reloaded")
+		}
+	}
+}
@@ -0,0 +1,88 @@
+package app
+
+import (
+	"context"
+	"io"
+	"os"
+	"path/filepath"
+	"syscall"
+	"testing"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+
+	"github.com/vladislavdragonenkov/oms/internal/logging"
+)
+
+func TestLoadLogLevels_FileOverridesEnv(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "levels")
+	if err := os.WriteFile(path, []byte("warn\nsaga=trace\n"), 0o600); err != nil {
+		t.Fatalf("WriteFile failed: %v", err)
+	}
+
+	cfg, err := loadLogLevels(Config{LogLevels: "saga=debug,kafka=error", LogLevelsFile: path}, log.InfoLevel)
+	if err != nil {
+		t.Fatalf("loadLogLevels failed: %v", err)
+	}
+	if got := cfg.String(); got != "warning,kafka=error,saga=trace" {
+		t.Fatalf("unexpected levels %q", got)
+	}
+}
+
+func TestLoadLogLevels_MissingFile(t *testing.T) {
+	cfg, err := loadLogLevels(Config{LogLevels: "saga=debug", LogLevelsFile: filepath.Join(t.TempDir(), "absent")}, log.InfoLevel)
+	if err != nil {
+		t.Fatalf("loadLogLevels failed: %v", err)
+	}
+	if got := cfg.String(); got != "info,saga=debug" {
+		t.Fatalf("unexpected levels %q", got)
+	}
+}
+
+func TestLoadLogLevels_InvalidFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "levels")
+	if err := os.WriteFile(path, []byte("saga=loud"), 0o600); err != nil {
+		t.Fatalf("WriteFile failed: %v", err)
+	}
+	if _, err := loadLogLevels(Config{LogLevelsFile: path}, log.InfoLevel); err == nil {
+		t.Fatal("expected error for invalid file")
+	}
+}
+
+func TestWatchLogLevelReloads_KeepsLevelsOnError(t *testing.T) {
+	logger := log.New()
+	logger.SetOutput(io.Discard)
+	levels := logging.Install(logger, logging.Config{Global: log.InfoLevel})
+
+	results := make(chan error, 2)
+	next := logging.Config{Global: log.WarnLevel, Components: map[string]log.Level{"saga": log.DebugLevel}}
+	load := func() (logging.Config, error) {
+		if err := <-results; err != nil {
+			return logging.Config{}, err
+		}
+		return next, nil
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	signals := make(chan os.Signal)
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		watchLogLevelReloads(ctx, signals, levels, load, log.NewEntry(logger))
+	}()
+
+	results <- os.ErrInvalid
+	signals <- syscall.SIGHUP
+	results <- nil
+	signals <- syscall.SIGHUP
+	cancel()
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		t.Fatal("watcher did not stop")
+	}
+
+	if got := levels.Config().String(); got != "warning,saga=debug" {
+		t.Fatalf("unexpected levels after reload %q", got)
+	}
+}
@@ -9,7 +9,8 @@ import (
 // от наличия kafka producer.
 func createOrchestrator(
 	deps *Dependencies,
-	kafkaProducer *kafka.Producer,
+	kafkaProducer kafka.EventPublisher,
+	opts ...saga.OrchestratorOption,
 ) saga.Orchestrator {
 	if kafkaProducer != nil {
 		return saga.NewOrchestratorWithKafka(
@@ -20,6 +21,7 @@ func createOrchestrator(
 			deps.PaymentSvc,
 			kafkaProducer,
 			deps.Logger,
+			opts...,
 		)
 	}
 
@@ -30,5 +32,6 @@ func createOrchestrator(
 		deps.InventorySvc,
 		deps.PaymentSvc,
 		deps.Logger,
+		opts...,
 	)
 }
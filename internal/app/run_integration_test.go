@@ -90,20 +90,20 @@ func TestShutdownHelpers(t *testing.T) {
 		saga.NewNoop(nil),
 		logger,
 	)
-	shutdownOrderService(orderService, logger)
-	shutdownOrderService(nil, logger)
+	shutdownOrderService(orderService, logger, nil)
+	shutdownOrderService(nil, logger, nil)
 
 	cancelCalled := false
 	done := make(chan struct{})
 	close(done)
-	shutdownOutboxWorker(func() { cancelCalled = true }, done, logger)
+	stopRunner("outbox-worker", func() { cancelCalled = true }, done, logger, nil)
 	if !cancelCalled {
 		t.Fatal("expected outbox cancel func to be called")
 	}
 
-	shutdownOutboxWorker(nil, nil, logger)
+	stopRunner("outbox-worker", nil, nil, logger, nil)
 
-	closeKafkaProducer(nil, logger)
+	closeKafkaProducer(nil, logger, nil)
 }
 
 func TestCloseKafkaProducer_NonNil(t *testing.T) {
@@ -111,7 +111,7 @@ func TestCloseKafkaProducer_NonNil(t *testing.T) {
 	if err != nil {
 		t.Skipf("kafka is not available for integration test: %v", err)
 	}
-	closeKafkaProducer(producer, log.WithField("test", "kafka-close"))
+	closeKafkaProducer(producer, log.WithField("test", "kafka-close"), nil)
 }
 
 func postgresTestDSNCandidate() string {
@@ -0,0 +1,119 @@
+package app
+
+import (
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+)
+
+var (
+	// ErrStartupFailed — приложение не собралось или компонент не запустился; ресурсы уже освобождены.
+	ErrStartupFailed = errors.New("startup failed")
+	// ErrDrainTimeout — при остановке компонент не уложился в gracefulShutdownTimeout и был брошен.
+	ErrDrainTimeout = errors.New("shutdown drain timeout")
+)
+
+// Причины остановки (поле reason отчёта).
+const (
+	ShutdownReasonSignal        = "signal"
+	ShutdownReasonServerStopped = "grpc_stopped"
+	ShutdownReasonServerFailed  = "grpc_failed"
+	ShutdownReasonStartupFailed = "startup_failed"
+)
+
+// outboxFlushTimeout ограничивает финальную публикацию outbox: она идёт внутри остановки
+// outbox-worker'а и должна закончиться раньше gracefulShutdownTimeout.
+const outboxFlushTimeout = 3 * time.Second
+
+// ShutdownReport — итог остановки приложения. App.Run пишет его в лог одной записью.
+type ShutdownReport struct {
+	Reason   string
+	Uptime   time.Duration
+	Duration time.Duration
+	// SagasDrained — фоновые саги, которых дождались при остановке; SagasAbandoned — брошенные
+	// по таймауту (их намерения повторятся при следующем запуске).
+	SagasDrained   int
+	SagasAbandoned int
+	// OutboxFlushed — записи outbox, опубликованные финальным проходом.
+	OutboxFlushed   int
+	ConsumersClosed int
+	// TimedOut — компоненты, не остановившиеся за gracefulShutdownTimeout.
+	TimedOut []string
+	// Errors — ошибки остановки в виде "компонент: ошибка".
+	Errors []string
+}
+
+// Clean сообщает, что все компоненты остановились вовремя и без ошибок.
+func (r ShutdownReport) Clean() bool {
+	return len(r.TimedOut) == 0 && len(r.Errors) == 0
+}
+
+func (r ShutdownReport) fields() log.Fields {
+	return log.Fields{
+		"reason":           r.Reason,
+		"uptime":           r.Uptime.Round(time.Second).String(),
+		"shutdown_took":    r.Duration.Round(time.Millisecond).String(),
+		"sagas_drained":    r.SagasDrained,
+		"sagas_abandoned":  r.SagasAbandoned,
+		"outbox_flushed":   r.OutboxFlushed,
+		"consumers_closed": r.ConsumersClosed,
+		"timed_out":        r.TimedOut,
+		"errors":           r.Errors,
+	}
+}
+
+// shutdownRecorder собирает ShutdownReport из Stop компонентов. Nil-recorder ничего не пишет:
+// так те же функции остановки работают и вне App.
+type shutdownRecorder struct {
+	mu     sync.Mutex
+	report ShutdownReport
+}
+
+func (r *shutdownRecorder) update(fn func(report *ShutdownReport)) {
+	if r == nil {
+		return
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	fn(&r.report)
+}
+
+func (r *shutdownRecorder) timedOut(component string) {
+	r.update(func(report *ShutdownReport) { report.TimedOut = append(report.TimedOut, component) })
+}
+
+func (r *shutdownRecorder) failed(component string, err error) {
+	r.update(func(report *ShutdownReport) {
+		report.Errors = append(report.Errors, fmt.Sprintf("%s: %v", component, err))
+	})
+}
+
+func (r *shutdownRecorder) sagas(drained, abandoned int) {
+	r.update(func(report *ShutdownReport) {
+		report.SagasDrained += drained
+		report.SagasAbandoned += abandoned
+	})
+}
+
+func (r *shutdownRecorder) outboxFlushed(count int) {
+	r.update(func(report *ShutdownReport) { report.OutboxFlushed += count })
+}
+
+func (r *shutdownRecorder) consumerClosed() {
+	r.update(func(report *ShutdownReport) { report.ConsumersClosed++ })
+}
+
+func (r *shutdownRecorder) snapshot() ShutdownReport {
+	if r == nil {
+		return ShutdownReport{}
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	report := r.report
+	report.TimedOut = append([]string(nil), r.report.TimedOut...)
+	report.Errors = append([]string(nil), r.report.Errors...)
+	return report
+}
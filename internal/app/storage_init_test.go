@@ -2,9 +2,12 @@ package app
 
 import (
 	"context"
+	"path/filepath"
 	"testing"
 
 	log "github.com/sirupsen/logrus"
+
+	"github.com/vladislavdragonenkov/oms/internal/domain"
 )
 
 func TestInitRuntimeDependencies_Memory(t *testing.T) {
@@ -51,3 +54,32 @@ func TestInitRuntimeDependencies_UnsupportedDriver(t *testing.T) {
 		t.Fatal("expected error for unsupported storage driver")
 	}
 }
+
+func TestInitRuntimeDependencies_MemoryDevSnapshot(t *testing.T) {
+	t.Parallel()
+
+	cfg := Config{StorageDriver: StorageDriverMemory, DevPersistPath: filepath.Join(t.TempDir(), "oms-dev.json")}
+	logger := log.WithField("test", "memory-dev-snapshot")
+
+	deps, err := initRuntimeDependencies(context.Background(), cfg, logger)
+	if err != nil {
+		t.Fatalf("initRuntimeDependencies failed: %v", err)
+	}
+	if deps.closeFn == nil {
+		t.Fatal("closeFn must save the snapshot when DevPersistPath is set")
+	}
+	if err := deps.repo.Create(domain.Order{ID: "o-1", CustomerID: "c-1", Status: domain.OrderStatusPending, Currency: "RUB"}); err != nil {
+		t.Fatalf("create order: %v", err)
+	}
+	if err := deps.closeFn(); err != nil {
+		t.Fatalf("save snapshot: %v", err)
+	}
+
+	restarted, err := initRuntimeDependencies(context.Background(), cfg, logger)
+	if err != nil {
+		t.Fatalf("initRuntimeDependencies after restart failed: %v", err)
+	}
+	if _, err := restarted.repo.Get("o-1"); err != nil {
+		t.Fatalf("order must survive restart via snapshot: %v", err)
+	}
+}
@@ -0,0 +1,135 @@
+package app
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+
+	"github.com/vladislavdragonenkov/oms/internal/tuning"
+)
+
+// tuningWatchInterval — как часто проверяется, не изменился ли файл TuningFile.
+const tuningWatchInterval = 10 * time.Second
+
+func tuningFromConfig(cfg Config) tuning.Values {
+	return tuning.Values{
+		OutboxPollInterval:  cfg.OutboxPollInterval,
+		OutboxBatchSize:     cfg.OutboxBatchSize,
+		OutboxMaxAttempts:   cfg.OutboxMaxAttempts,
+		OutboxRetryDelay:    cfg.OutboxRetryDelay,
+		SagaTimeout:         cfg.SagaTimeout,
+		ConsumerConcurrency: cfg.KafkaConsumerConcurrency,
+	}
+}
+
+// withTuning возвращает cfg со значениями из values, чтобы компоненты стартовали с ними.
+func withTuning(cfg Config, values tuning.Values) Config {
+	cfg.OutboxPollInterval = values.OutboxPollInterval
+	cfg.OutboxBatchSize = values.OutboxBatchSize
+	cfg.OutboxMaxAttempts = values.OutboxMaxAttempts
+	cfg.OutboxRetryDelay = values.OutboxRetryDelay
+	cfg.SagaTimeout = values.SagaTimeout
+	cfg.KafkaConsumerConcurrency = values.ConsumerConcurrency
+	return cfg
+}
+
+// loadTuning накладывает файл TuningFile на значения из env. Удалённый из файла ключ
+// возвращается к значению из env; отсутствующий файл — не ошибка.
+func loadTuning(cfg Config) (tuning.Values, error) {
+	base := tuningFromConfig(cfg)
+	raw, err := os.ReadFile(cfg.TuningFile)
+	if errors.Is(err, fs.ErrNotExist) {
+		return base, base.Validate()
+	}
+	if err != nil {
+		return tuning.Values{}, fmt.Errorf("read tuning file: %w", err)
+	}
+	values, err := tuning.Parse(string(raw), base)
+	if err != nil {
+		return tuning.Values{}, fmt.Errorf("parse tuning file %s: %w", cfg.TuningFile, err)
+	}
+	return values, nil
+}
+
+// newTuningReload возвращает функцию перечитывания файла, общую для SIGHUP, слежения за файлом
+// и POST /admin/tuning.
+func newTuningReload(store *tuning.Store, cfg Config) func(source string) ([]tuning.Change, error) {
+	return func(source string) ([]tuning.Change, error) {
+		return store.Reload(func() (tuning.Values, error) { return loadTuning(cfg) }, source)
+	}
+}
+
+// startTuningReloader перечитывает TuningFile по SIGHUP и при изменении mtime или размера файла.
+// Возвращает stop, снимающий обработчик сигнала.
+func startTuningReloader(ctx context.Context, reload func(source string) ([]tuning.Change, error), path string, logger *log.Entry) func() {
+	signals := make(chan os.Signal, 1)
+	signal.Notify(signals, syscall.SIGHUP)
+	ticker := time.NewTicker(tuningWatchInterval)
+	reloadCtx, cancel := context.WithCancel(ctx)
+	done := make(chan struct{})
+	stamp := statTuningFile(path)
+	go func() {
+		defer close(done)
+		watchTuningReloads(reloadCtx, signals, ticker.C, func() bool {
+			next := statTuningFile(path)
+			changed := next != stamp
+			stamp = next
+			return changed
+		}, reload, logger)
+	}()
+	return func() {
+		signal.Stop(signals)
+		ticker.Stop()
+		cancel()
+		<-done
+	}
+}
+
+func watchTuningReloads(
+	ctx context.Context,
+	signals <-chan os.Signal,
+	ticks <-chan time.Time,
+	changed func() bool,
+	reload func(source string) ([]tuning.Change, error),
+	logger *log.Entry,
+) {
+	for {
+		source := ""
+		select {
+		case <-ctx.Done():
+			return
+		case <-signals:
+			source = "sighup"
+		case <-ticks:
+			if !changed() {
+				continue
+			}
+			source = "file"
+		}
+		if _, err := reload(source); err != nil {
+			// Ошибка в файле не должна сбрасывать рабочие значения.
+			logger.WithError(err).WithField("source", source).Error("failed to reload tuning, keeping current")
+		}
+	}
+}
+
+// tuningFileStamp — признаки изменения файла; нулевое значение — файла нет.
+type tuningFileStamp struct {
+	modTime time.Time
+	size    int64
+}
+
+func statTuningFile(path string) tuningFileStamp {
+	info, err := os.Stat(path)
+	if err != nil {
+		return tuningFileStamp{}
+	}
+	return tuningFileStamp{modTime: info.ModTime(), size: info.Size()}
+}
@@ -0,0 +1,128 @@
+package app
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"syscall"
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	log "github.com/sirupsen/logrus"
+
+	"github.com/vladislavdragonenkov/oms/internal/tuning"
+)
+
+func TestLoadTuning_FileOverridesEnv(t *testing.T) {
+	cfg := DefaultConfig()
+	cfg.TuningFile = filepath.Join(t.TempDir(), "tuning.conf")
+
+	values, err := loadTuning(cfg)
+	if err != nil {
+		t.Fatalf("loadTuning without file failed: %v", err)
+	}
+	if values != tuningFromConfig(cfg) {
+		t.Fatalf("missing file must keep env values, got %+v", values)
+	}
+
+	if err := os.WriteFile(cfg.TuningFile, []byte("outbox_batch_size=500\nconsumer_concurrency=4\n"), 0o600); err != nil {
+		t.Fatalf("WriteFile failed: %v", err)
+	}
+	values, err = loadTuning(cfg)
+	if err != nil {
+		t.Fatalf("loadTuning failed: %v", err)
+	}
+	if values.OutboxBatchSize != 500 || values.ConsumerConcurrency != 4 || values.SagaTimeout != cfg.SagaTimeout {
+		t.Fatalf("unexpected values %+v", values)
+	}
+	if got := withTuning(cfg, values); got.OutboxBatchSize != 500 || got.KafkaConsumerConcurrency != 4 {
+		t.Fatalf("withTuning did not apply values: %+v", got)
+	}
+
+	if err := os.WriteFile(cfg.TuningFile, []byte("outbox_batch_size=lots\n"), 0o600); err != nil {
+		t.Fatalf("WriteFile failed: %v", err)
+	}
+	if _, err := loadTuning(cfg); !errors.Is(err, tuning.ErrInvalid) {
+		t.Fatalf("expected ErrInvalid, got %v", err)
+	}
+}
+
+func TestWatchTuningReloads(t *testing.T) {
+	logger := log.New()
+	logger.SetOutput(io.Discard)
+
+	signals := make(chan os.Signal)
+	ticks := make(chan time.Time)
+	changed := make(chan bool, 1)
+	sources := make(chan string, 3)
+	reload := func(source string) ([]tuning.Change, error) {
+		sources <- source
+		if source == "sighup" {
+			return nil, tuning.ErrInvalid
+		}
+		return nil, nil
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		watchTuningReloads(ctx, signals, ticks, func() bool { return <-changed }, reload, log.NewEntry(logger))
+	}()
+
+	changed <- false
+	ticks <- time.Now()
+	signals <- syscall.SIGHUP
+	changed <- true
+	ticks <- time.Now()
+	cancel()
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		t.Fatal("watcher did not stop")
+	}
+
+	close(sources)
+	var got []string
+	for source := range sources {
+		got = append(got, source)
+	}
+	if len(got) != 2 || got[0] != "sighup" || got[1] != "file" {
+		t.Fatalf("expected reloads on SIGHUP and file change only, got %v", got)
+	}
+}
+
+func TestTuningAdmin_ReloadRequiresCredentials(t *testing.T) {
+	store, err := tuning.NewStore(tuning.Values{
+		OutboxPollInterval: time.Second,
+		OutboxBatchSize:    100,
+		OutboxMaxAttempts:  3,
+		OutboxRetryDelay:   50 * time.Millisecond,
+		SagaTimeout:        30 * time.Second,
+	}, tuning.WithRegisterer(prometheus.NewRegistry()))
+	if err != nil {
+		t.Fatalf("NewStore failed: %v", err)
+	}
+	reloads := 0
+	handler := adminWrites(nil, tuning.Handler(store, func() ([]tuning.Change, error) {
+		reloads++
+		return nil, nil
+	}, nil))
+	serve := func(method string) int {
+		rec := httptest.NewRecorder()
+		handler.ServeHTTP(rec, httptest.NewRequest(method, "/admin/tuning", nil))
+		return rec.Code
+	}
+
+	if code := serve(http.MethodGet); code != http.StatusOK {
+		t.Fatalf("GET must stay open, got %d", code)
+	}
+	if code := serve(http.MethodPost); code != http.StatusForbidden || reloads != 0 {
+		t.Fatalf("POST without admin credentials must be rejected, got %d after %d reloads", code, reloads)
+	}
+}
@@ -0,0 +1,26 @@
+// Package ctxutil содержит помощники для работы с context.Context.
+package ctxutil
+
+import (
+	"context"
+	"time"
+)
+
+// Sleep ждёт d или отмены ctx — что наступит раньше. Возвращает ctx.Err(), если ожидание
+// прервано, иначе nil. При d <= 0 не ждёт, но всё равно сообщает об уже отменённом ctx,
+// чтобы retry-цикл с нулевой задержкой тоже останавливался на shutdown.
+func Sleep(ctx context.Context, d time.Duration) error {
+	if d <= 0 {
+		return ctx.Err()
+	}
+
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
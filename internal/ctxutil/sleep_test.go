@@ -0,0 +1,42 @@
+package ctxutil
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestSleep_Waits(t *testing.T) {
+	start := time.Now()
+	if err := Sleep(context.Background(), 10*time.Millisecond); err != nil {
+		t.Fatalf("Sleep: %v", err)
+	}
+	if elapsed := time.Since(start); elapsed < 10*time.Millisecond {
+		t.Fatalf("Sleep returned after %s", elapsed)
+	}
+}
+
+func TestSleep_ReturnsOnCancel(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	time.AfterFunc(10*time.Millisecond, cancel)
+
+	start := time.Now()
+	if err := Sleep(ctx, time.Minute); !errors.Is(err, context.Canceled) {
+		t.Fatalf("expected context.Canceled, got %v", err)
+	}
+	if elapsed := time.Since(start); elapsed > 5*time.Second {
+		t.Fatalf("Sleep ignored cancellation for %s", elapsed)
+	}
+}
+
+func TestSleep_NonPositiveDelay(t *testing.T) {
+	if err := Sleep(context.Background(), 0); err != nil {
+		t.Fatalf("Sleep(0): %v", err)
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if err := Sleep(ctx, -time.Second); !errors.Is(err, context.Canceled) {
+		t.Fatalf("expected context.Canceled for canceled ctx, got %v", err)
+	}
+}
@@ -0,0 +1,155 @@
+package dependency
+
+import (
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+
+	"github.com/vladislavdragonenkov/oms/internal/domain"
+)
+
+// newDBTracker — Tracker хранилища. Отсутствие заказа и конфликт версии — ответы БД, а не отказ.
+func newDBTracker(registerer prometheus.Registerer) *Tracker {
+	return NewTracker(DB, registerer, WithBusinessErrors(domain.ErrOrderNotFound, domain.ErrOrderVersionConflict))
+}
+
+type instrumentedOrders struct {
+	next    domain.OrderRepository
+	tracker *Tracker
+}
+
+// InstrumentOrders оборачивает репозиторий заказов метриками зависимости db. Необязательные
+// расширения репозитория (OrderScanner, OrderStreamer и др.) обёртка не пробрасывает, поэтому её
+// стоит отдавать только потребителям базового интерфейса — например, саге.
+func InstrumentOrders(repo domain.OrderRepository, registerer prometheus.Registerer) domain.OrderRepository {
+	return &instrumentedOrders{next: repo, tracker: newDBTracker(registerer)}
+}
+
+func (r *instrumentedOrders) Create(order domain.Order) error {
+	return r.tracker.Do("order_create", func() error { return r.next.Create(order) })
+}
+
+func (r *instrumentedOrders) Get(id string) (order domain.Order, err error) {
+	err = r.tracker.Do("order_get", func() error {
+		order, err = r.next.Get(id)
+		return err
+	})
+	return order, err
+}
+
+func (r *instrumentedOrders) ListByCustomer(customerID string, limit int) (orders []domain.Order, err error) {
+	err = r.tracker.Do("order_list_by_customer", func() error {
+		orders, err = r.next.ListByCustomer(customerID, limit)
+		return err
+	})
+	return orders, err
+}
+
+func (r *instrumentedOrders) ListByStatus(status domain.OrderStatus, limit int) (orders []domain.Order, err error) {
+	err = r.tracker.Do("order_list_by_status", func() error {
+		orders, err = r.next.ListByStatus(status, limit)
+		return err
+	})
+	return orders, err
+}
+
+func (r *instrumentedOrders) Save(order domain.Order) error {
+	return r.tracker.Do("order_save", func() error { return r.next.Save(order) })
+}
+
+func (r *instrumentedOrders) UpdateStatusCAS(orderID string, from, to domain.OrderStatus, expectedVersion int64) (version int64, err error) {
+	err = r.tracker.Do("order_update_status", func() error {
+		version, err = r.next.UpdateStatusCAS(orderID, from, to, expectedVersion)
+		return err
+	})
+	return version, err
+}
+
+func (r *instrumentedOrders) Delete(id string) error {
+	return r.tracker.Do("order_delete", func() error { return r.next.Delete(id) })
+}
+
+type instrumentedOutbox struct {
+	next    domain.OutboxRepository
+	tracker *Tracker
+}
+
+// InstrumentOutbox оборачивает outbox метриками зависимости db; как и InstrumentOrders,
+// расширения репозитория (replay, feed) не пробрасываются.
+func InstrumentOutbox(repo domain.OutboxRepository, registerer prometheus.Registerer) domain.OutboxRepository {
+	return &instrumentedOutbox{next: repo, tracker: newDBTracker(registerer)}
+}
+
+func (r *instrumentedOutbox) Enqueue(msg domain.OutboxMessage) (stored domain.OutboxMessage, err error) {
+	err = r.tracker.Do("outbox_enqueue", func() error {
+		stored, err = r.next.Enqueue(msg)
+		return err
+	})
+	return stored, err
+}
+
+func (r *instrumentedOutbox) EnqueueBatch(msgs []domain.OutboxMessage) (stored []domain.OutboxMessage, err error) {
+	err = r.tracker.Do("outbox_enqueue_batch", func() error {
+		stored, err = r.next.EnqueueBatch(msgs)
+		return err
+	})
+	return stored, err
+}
+
+func (r *instrumentedOutbox) PullPending(limit int) (msgs []domain.OutboxMessage, err error) {
+	err = r.tracker.Do("outbox_pull_pending", func() error {
+		msgs, err = r.next.PullPending(limit)
+		return err
+	})
+	return msgs, err
+}
+
+func (r *instrumentedOutbox) Stats() (stats domain.OutboxStats, err error) {
+	err = r.tracker.Do("outbox_stats", func() error {
+		stats, err = r.next.Stats()
+		return err
+	})
+	return stats, err
+}
+
+func (r *instrumentedOutbox) MarkSent(id string) error {
+	return r.tracker.Do("outbox_mark_sent", func() error { return r.next.MarkSent(id) })
+}
+
+func (r *instrumentedOutbox) MarkFailed(id string) error {
+	return r.tracker.Do("outbox_mark_failed", func() error { return r.next.MarkFailed(id) })
+}
+
+func (r *instrumentedOutbox) DeleteSent(before time.Time, limit int) (deleted int, err error) {
+	err = r.tracker.Do("outbox_delete_sent", func() error {
+		deleted, err = r.next.DeleteSent(before, limit)
+		return err
+	})
+	return deleted, err
+}
+
+type instrumentedTimeline struct {
+	next    domain.TimelineRepository
+	tracker *Tracker
+}
+
+// InstrumentTimeline оборачивает timeline заказов метриками зависимости db.
+func InstrumentTimeline(repo domain.TimelineRepository, registerer prometheus.Registerer) domain.TimelineRepository {
+	return &instrumentedTimeline{next: repo, tracker: newDBTracker(registerer)}
+}
+
+func (r *instrumentedTimeline) Append(event domain.TimelineEvent) error {
+	return r.tracker.Do("timeline_append", func() error { return r.next.Append(event) })
+}
+
+func (r *instrumentedTimeline) List(orderID string) (events []domain.TimelineEvent, err error) {
+	err = r.tracker.Do("timeline_list", func() error {
+		events, err = r.next.List(orderID)
+		return err
+	})
+	return events, err
+}
+
+func (r *instrumentedTimeline) DeleteByOrder(orderID string) error {
+	return r.tracker.Do("timeline_delete", func() error { return r.next.DeleteByOrder(orderID) })
+}
@@ -0,0 +1,97 @@
+package dependency
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+
+	"github.com/vladislavdragonenkov/oms/internal/domain"
+	"github.com/vladislavdragonenkov/oms/internal/metrics/metricstest"
+	"github.com/vladislavdragonenkov/oms/internal/service/inventory"
+	"github.com/vladislavdragonenkov/oms/internal/service/payment"
+	"github.com/vladislavdragonenkov/oms/internal/storage/memory"
+)
+
+func TestInstrumentInventory(t *testing.T) {
+	registry := prometheus.NewRegistry()
+	mock := inventory.NewMockService()
+	svc := InstrumentInventory(mock, registry, WithBreaker(1, time.Minute))
+	items := []domain.OrderItem{{SKU: "SKU-1", Qty: 1}}
+
+	if _, ok := svc.(domain.InventoryRestocker); !ok {
+		t.Fatal("instrumented inventory must accept returns")
+	}
+	if err := svc.(domain.InventoryRestocker).Restock("order-1", items); err != nil || mock.RestockCalls != 1 {
+		t.Fatalf("expected restock to reach inventory, err=%v calls=%d", err, mock.RestockCalls)
+	}
+	if svc.(domain.CustomerScopedInventory).ForCustomer("c-1") != svc {
+		t.Fatal("unscoped inventory must serve every customer itself")
+	}
+
+	mock.ReserveErr = fmt.Errorf("%w: SKU-1", domain.ErrInventoryUnavailable)
+	if err := svc.Reserve("order-1", items); !errors.Is(err, domain.ErrInventoryUnavailable) {
+		t.Fatalf("expected out-of-stock to pass through, got %v", err)
+	}
+	metricstest.RequireAbsent(t, registry, "oms_dependency_errors_total", metricstest.Labels{"dependency": Inventory})
+
+	mock.ReserveErr = errors.New("connection refused")
+	_ = svc.Reserve("order-1", items)
+	if err := svc.Reserve("order-1", items); !errors.Is(err, domain.ErrInventoryTemporary) || !errors.Is(err, ErrCircuitOpen) {
+		t.Fatalf("expected open circuit as temporary error, got %v", err)
+	}
+	metricstest.RequireValue(t, registry, "oms_dependency_errors_total", metricstest.Labels{"dependency": Inventory, "operation": "reserve"}, 1)
+	metricstest.RequireValue(t, registry, "oms_dependency_request_duration_seconds", metricstest.Labels{"dependency": Inventory}, 3)
+}
+
+// plainPayment — PSP без двухфазной оплаты.
+type plainPayment struct{ domain.PaymentService }
+
+func TestInstrumentPayment(t *testing.T) {
+	registry := prometheus.NewRegistry()
+	mock := payment.NewMockService()
+
+	svc := InstrumentPayment(mock, registry)
+	capturer, ok := svc.(domain.PaymentCapturer)
+	if !ok {
+		t.Fatal("instrumented two-phase PSP must keep Capture")
+	}
+	if _, ok := InstrumentPayment(plainPayment{mock}, registry).(domain.PaymentCapturer); ok {
+		t.Fatal("instrumented single-phase PSP must not pretend to capture")
+	}
+
+	if _, err := svc.Pay("order-1", 100, "USD"); err != nil {
+		t.Fatalf("pay: %v", err)
+	}
+	if _, err := capturer.Capture("order-1", 100, "USD"); err != nil {
+		t.Fatalf("capture: %v", err)
+	}
+	mock.RefundErr = domain.ErrPaymentDeclined
+	if _, err := svc.Refund("order-1", 100, "USD"); !errors.Is(err, domain.ErrPaymentDeclined) {
+		t.Fatalf("expected decline to pass through, got %v", err)
+	}
+
+	for _, operation := range []string{"pay", "capture", "refund"} {
+		metricstest.RequireValue(t, registry, "oms_dependency_request_duration_seconds", metricstest.Labels{"dependency": Payment, "operation": operation}, 1)
+	}
+	metricstest.RequireAbsent(t, registry, "oms_dependency_errors_total", metricstest.Labels{"dependency": Payment})
+}
+
+func TestInstrumentOrders(t *testing.T) {
+	registry := prometheus.NewRegistry()
+	repo := InstrumentOrders(memory.NewOrderRepository(), registry)
+	timeline := InstrumentTimeline(memory.NewTimelineRepository(), registry)
+
+	if _, err := repo.Get("missing"); !errors.Is(err, domain.ErrOrderNotFound) {
+		t.Fatalf("expected ErrOrderNotFound, got %v", err)
+	}
+	if err := timeline.Append(domain.TimelineEvent{OrderID: "order-1", Type: "created", Occurred: time.Now()}); err != nil {
+		t.Fatalf("append: %v", err)
+	}
+
+	metricstest.RequireValue(t, registry, "oms_dependency_request_duration_seconds", metricstest.Labels{"dependency": DB, "operation": "order_get"}, 1)
+	metricstest.RequireValue(t, registry, "oms_dependency_request_duration_seconds", metricstest.Labels{"dependency": DB, "operation": "timeline_append"}, 1)
+	metricstest.RequireAbsent(t, registry, "oms_dependency_errors_total", metricstest.Labels{"dependency": DB})
+}
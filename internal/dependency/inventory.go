@@ -0,0 +1,64 @@
+package dependency
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/prometheus/client_golang/prometheus"
+
+	"github.com/vladislavdragonenkov/oms/internal/domain"
+)
+
+type instrumentedInventory struct {
+	next    domain.InventoryService
+	tracker *Tracker
+}
+
+// InstrumentInventory оборачивает склад метриками зависимости inventory. ErrInventoryUnavailable —
+// ответ склада, а не отказ; вызов, отклонённый открытой цепью, возвращается как ErrInventoryTemporary,
+// чтобы сага повторила шаг позже. ReservationLister не пробрасывается: сверку резервов нужно
+// подключать к исходному сервису.
+func InstrumentInventory(svc domain.InventoryService, registerer prometheus.Registerer, opts ...Option) domain.InventoryService {
+	opts = append([]Option{WithBusinessErrors(domain.ErrInventoryUnavailable)}, opts...)
+	return &instrumentedInventory{next: svc, tracker: NewTracker(Inventory, registerer, opts...)}
+}
+
+func (i *instrumentedInventory) Reserve(orderID string, items []domain.OrderItem) error {
+	return inventoryError(i.tracker.Do("reserve", func() error {
+		return i.next.Reserve(orderID, items)
+	}))
+}
+
+func (i *instrumentedInventory) Release(orderID string, items []domain.OrderItem) error {
+	return inventoryError(i.tracker.Do("release", func() error {
+		return i.next.Release(orderID, items)
+	}))
+}
+
+// Restock пробрасывается, только если склад умеет принимать возвраты; иначе ничего не делает,
+// как и вызывающий код, не нашедший у склада InventoryRestocker.
+func (i *instrumentedInventory) Restock(orderID string, items []domain.OrderItem) error {
+	restocker, ok := i.next.(domain.InventoryRestocker)
+	if !ok {
+		return nil
+	}
+	return inventoryError(i.tracker.Do("restock", func() error {
+		return restocker.Restock(orderID, items)
+	}))
+}
+
+// ForCustomer сохраняет tenant-маршрутизацию: выбранный backend измеряется тем же Tracker'ом.
+func (i *instrumentedInventory) ForCustomer(customerID string) domain.InventoryService {
+	scoped, ok := i.next.(domain.CustomerScopedInventory)
+	if !ok {
+		return i
+	}
+	return &instrumentedInventory{next: scoped.ForCustomer(customerID), tracker: i.tracker}
+}
+
+func inventoryError(err error) error {
+	if errors.Is(err, ErrCircuitOpen) {
+		return fmt.Errorf("%w: %w", domain.ErrInventoryTemporary, err)
+	}
+	return err
+}
@@ -0,0 +1,70 @@
+package dependency
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/prometheus/client_golang/prometheus"
+
+	"github.com/vladislavdragonenkov/oms/internal/domain"
+)
+
+type instrumentedPayment struct {
+	next    domain.PaymentService
+	tracker *Tracker
+}
+
+// instrumentedCapturer — PSP с двухфазной оплатой; отдельный тип, чтобы проверка
+// domain.PaymentCapturer у обёртки давала тот же ответ, что и у исходного сервиса.
+type instrumentedCapturer struct {
+	*instrumentedPayment
+	capturer domain.PaymentCapturer
+}
+
+// InstrumentPayment оборачивает PSP метриками зависимости payment. ErrPaymentDeclined — ответ PSP,
+// а не отказ; вызов, отклонённый открытой цепью, возвращается как ErrPaymentTemporary.
+func InstrumentPayment(svc domain.PaymentService, registerer prometheus.Registerer, opts ...Option) domain.PaymentService {
+	opts = append([]Option{WithBusinessErrors(domain.ErrPaymentDeclined)}, opts...)
+	payment := &instrumentedPayment{next: svc, tracker: NewTracker(Payment, registerer, opts...)}
+	if capturer, ok := svc.(domain.PaymentCapturer); ok {
+		return &instrumentedCapturer{instrumentedPayment: payment, capturer: capturer}
+	}
+	return payment
+}
+
+func (p *instrumentedPayment) Pay(orderID string, amountMinor int64, currency string) (domain.PaymentStatus, error) {
+	return p.call("pay", func() (domain.PaymentStatus, error) {
+		return p.next.Pay(orderID, amountMinor, currency)
+	})
+}
+
+func (p *instrumentedPayment) Refund(orderID string, amountMinor int64, currency string) (domain.PaymentStatus, error) {
+	return p.call("refund", func() (domain.PaymentStatus, error) {
+		return p.next.Refund(orderID, amountMinor, currency)
+	})
+}
+
+func (p *instrumentedCapturer) Capture(orderID string, amountMinor int64, currency string) (domain.PaymentStatus, error) {
+	return p.call("capture", func() (domain.PaymentStatus, error) {
+		return p.capturer.Capture(orderID, amountMinor, currency)
+	})
+}
+
+func (p *instrumentedCapturer) Void(orderID string, amountMinor int64, currency string) (domain.PaymentStatus, error) {
+	return p.call("void", func() (domain.PaymentStatus, error) {
+		return p.capturer.Void(orderID, amountMinor, currency)
+	})
+}
+
+func (p *instrumentedPayment) call(operation string, fn func() (domain.PaymentStatus, error)) (domain.PaymentStatus, error) {
+	var status domain.PaymentStatus
+	err := p.tracker.Do(operation, func() error {
+		var err error
+		status, err = fn()
+		return err
+	})
+	if errors.Is(err, ErrCircuitOpen) {
+		return status, fmt.Errorf("%w: %w", domain.ErrPaymentTemporary, err)
+	}
+	return status, err
+}
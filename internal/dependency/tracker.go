@@ -0,0 +1,220 @@
+// Package dependency — общие метрики внешних зависимостей сервиса (склад, PSP, Kafka, БД):
+// задержка, ошибки, вызовы в полёте и состояние circuit breaker с меткой dependency,
+// чтобы на одном ряду дашборда было видно, какая зависимость тормозит саги.
+package dependency
+
+import (
+	"errors"
+	"sync"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+
+	"github.com/vladislavdragonenkov/oms/internal/metrics"
+)
+
+// Значения метки dependency.
+const (
+	Inventory = "inventory"
+	Payment   = "payment"
+	Kafka     = "kafka"
+	DB        = "db"
+)
+
+// ErrCircuitOpen возвращается без обращения к зависимости, пока её circuit breaker открыт.
+var ErrCircuitOpen = errors.New("dependency circuit is open")
+
+// CircuitState — состояние circuit breaker; значение пишется в oms_dependency_circuit_state.
+type CircuitState int
+
+const (
+	CircuitClosed CircuitState = iota
+	CircuitHalfOpen
+	CircuitOpen
+)
+
+// Option настраивает Tracker.
+type Option func(*Tracker)
+
+// WithBreaker открывает цепь после threshold подряд отказов: следующие cooldown вызовы сразу
+// получают ErrCircuitOpen, затем один пробный вызов решает, закрыть ли цепь. threshold <= 0 выключает breaker.
+func WithBreaker(threshold int, cooldown time.Duration) Option {
+	return func(t *Tracker) {
+		t.threshold = threshold
+		t.cooldown = cooldown
+	}
+}
+
+// WithBusinessErrors перечисляет ошибки-ответы зависимости (отказ в оплате, нет стока):
+// они не считаются отказом — не попадают в oms_dependency_errors_total и не открывают цепь.
+func WithBusinessErrors(errs ...error) Option {
+	return func(t *Tracker) {
+		t.business = append(t.business, errs...)
+	}
+}
+
+// Tracker измеряет вызовы одной зависимости.
+type Tracker struct {
+	name     string
+	business []error
+	now      func() time.Time
+
+	mu        sync.Mutex
+	threshold int
+	cooldown  time.Duration
+	state     CircuitState
+	failures  int
+	openedAt  time.Time
+	probing   bool
+
+	latency  *prometheus.HistogramVec
+	errors   *prometheus.CounterVec
+	inFlight prometheus.Gauge
+	circuit  prometheus.Gauge
+	rejected prometheus.Counter
+}
+
+// NewTracker создаёт Tracker зависимости name. Метрики общие для всех зависимостей и различаются
+// меткой dependency, поэтому несколько Tracker'ов на одном реестре не конфликтуют.
+func NewTracker(name string, registerer prometheus.Registerer, opts ...Option) *Tracker {
+	t := &Tracker{
+		name: name,
+		now:  time.Now,
+		latency: metrics.Register(registerer, prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Name:    "oms_dependency_request_duration_seconds",
+			Help:    "Latency of calls to downstream dependencies grouped by dependency and operation.",
+			Buckets: prometheus.DefBuckets,
+		}, []string{"dependency", "operation"})),
+		errors: metrics.Register(registerer, prometheus.NewCounterVec(prometheus.CounterOpts{
+			Name: "oms_dependency_errors_total",
+			Help: "Total number of failed calls to downstream dependencies (business rejections excluded).",
+		}, []string{"dependency", "operation"})),
+	}
+	t.inFlight = metrics.Register(registerer, prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "oms_dependency_in_flight",
+		Help: "Number of calls to downstream dependencies currently in progress.",
+	}, []string{"dependency"})).WithLabelValues(name)
+	t.circuit = metrics.Register(registerer, prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "oms_dependency_circuit_state",
+		Help: "Circuit breaker state of downstream dependencies: 0 closed, 1 half-open, 2 open.",
+	}, []string{"dependency"})).WithLabelValues(name)
+	t.rejected = metrics.Register(registerer, prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: "oms_dependency_circuit_rejected_total",
+		Help: "Total number of calls rejected without contacting the dependency because its circuit was open.",
+	}, []string{"dependency"})).WithLabelValues(name)
+	for _, opt := range opts {
+		opt(t)
+	}
+	t.circuit.Set(float64(CircuitClosed))
+	return t
+}
+
+// Name возвращает значение метки dependency.
+func (t *Tracker) Name() string {
+	return t.name
+}
+
+// Do вызывает fn как операцию operation зависимости: учитывает задержку, вызов в полёте и отказ.
+// При открытой цепи fn не вызывается, возвращается ErrCircuitOpen.
+func (t *Tracker) Do(operation string, fn func() error) error {
+	if !t.allow() {
+		t.rejected.Inc()
+		return ErrCircuitOpen
+	}
+
+	t.inFlight.Inc()
+	start := t.now()
+	err := fn()
+	t.latency.WithLabelValues(t.name, operation).Observe(t.now().Sub(start).Seconds())
+	t.inFlight.Dec()
+
+	if t.failed(err) {
+		t.errors.WithLabelValues(t.name, operation).Inc()
+		t.failure()
+	} else {
+		t.success()
+	}
+	return err
+}
+
+// SetCircuitState публикует состояние внешнего breaker'а — для клиентов со своим circuit breaker
+// (Kafka producer), у которых WithBreaker не включён.
+func (t *Tracker) SetCircuitState(state CircuitState) {
+	t.circuit.Set(float64(state))
+}
+
+// RecordRejected учитывает вызов, отклонённый внешним breaker'ом без обращения к зависимости.
+func (t *Tracker) RecordRejected() {
+	t.rejected.Inc()
+}
+
+func (t *Tracker) failed(err error) bool {
+	if err == nil {
+		return false
+	}
+	for _, business := range t.business {
+		if errors.Is(err, business) {
+			return false
+		}
+	}
+	return true
+}
+
+// allow решает, можно ли обращаться к зависимости. В half-open пропускается один пробный вызов.
+func (t *Tracker) allow() bool {
+	if t.threshold <= 0 {
+		return true
+	}
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	switch t.state {
+	case CircuitOpen:
+		if t.now().Sub(t.openedAt) < t.cooldown {
+			return false
+		}
+		t.setState(CircuitHalfOpen)
+		t.probing = true
+		return true
+	case CircuitHalfOpen:
+		if t.probing {
+			return false
+		}
+		t.probing = true
+		return true
+	default:
+		return true
+	}
+}
+
+func (t *Tracker) success() {
+	if t.threshold <= 0 {
+		return
+	}
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	t.failures = 0
+	t.probing = false
+	t.setState(CircuitClosed)
+}
+
+func (t *Tracker) failure() {
+	if t.threshold <= 0 {
+		return
+	}
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	t.probing = false
+	t.failures++
+	if t.state == CircuitHalfOpen || t.failures >= t.threshold {
+		t.openedAt = t.now()
+		t.setState(CircuitOpen)
+	}
+}
+
+func (t *Tracker) setState(state CircuitState) {
+	t.state = state
+	t.circuit.Set(float64(state))
+}
@@ -0,0 +1,76 @@
+package dependency
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+
+	"github.com/vladislavdragonenkov/oms/internal/metrics/metricstest"
+)
+
+var errBusiness = errors.New("declined")
+
+func TestTracker_RecordsLatencyAndErrors(t *testing.T) {
+	registry := prometheus.NewRegistry()
+	tracker := NewTracker(Payment, registry, WithBusinessErrors(errBusiness))
+
+	var inFlight float64
+	_ = tracker.Do("pay", func() error {
+		inFlight, _ = metricstest.Scrape(t, registry).Value("oms_dependency_in_flight", metricstest.Labels{"dependency": Payment})
+		return nil
+	})
+	if inFlight != 1 {
+		t.Fatalf("expected call to be in flight, got %v", inFlight)
+	}
+	if err := tracker.Do("pay", func() error { return errBusiness }); !errors.Is(err, errBusiness) {
+		t.Fatalf("expected business error to pass through, got %v", err)
+	}
+	_ = tracker.Do("refund", func() error { return errors.New("psp timeout") })
+
+	labels := metricstest.Labels{"dependency": Payment, "operation": "pay"}
+	metricstest.RequireValue(t, registry, "oms_dependency_request_duration_seconds", labels, 2)
+	metricstest.RequireAbsent(t, registry, "oms_dependency_errors_total", labels)
+	metricstest.RequireValue(t, registry, "oms_dependency_errors_total", metricstest.Labels{"dependency": Payment, "operation": "refund"}, 1)
+	metricstest.RequireValue(t, registry, "oms_dependency_in_flight", metricstest.Labels{"dependency": Payment}, 0)
+	metricstest.RequireValue(t, registry, "oms_dependency_circuit_state", metricstest.Labels{"dependency": Payment}, float64(CircuitClosed))
+}
+
+func TestTracker_BreakerOpensAndRecovers(t *testing.T) {
+	registry := prometheus.NewRegistry()
+	tracker := NewTracker(Inventory, registry, WithBreaker(2, time.Minute), WithBusinessErrors(errBusiness))
+	now := time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC)
+	tracker.now = func() time.Time { return now }
+	state := metricstest.Labels{"dependency": Inventory}
+	failing := func() error { return errors.New("connection refused") }
+
+	// Бизнес-ошибки не приближают открытие цепи.
+	_ = tracker.Do("reserve", failing)
+	_ = tracker.Do("reserve", func() error { return errBusiness })
+	_ = tracker.Do("reserve", failing)
+	metricstest.RequireValue(t, registry, "oms_dependency_circuit_state", state, float64(CircuitClosed))
+
+	_ = tracker.Do("reserve", failing)
+	metricstest.RequireValue(t, registry, "oms_dependency_circuit_state", state, float64(CircuitOpen))
+
+	called := false
+	if err := tracker.Do("reserve", func() error { called = true; return nil }); !errors.Is(err, ErrCircuitOpen) || called {
+		t.Fatalf("expected rejection without call, got err=%v called=%v", err, called)
+	}
+	metricstest.RequireValue(t, registry, "oms_dependency_circuit_rejected_total", state, 1)
+
+	// Неудачная проба снова открывает цепь.
+	now = now.Add(time.Minute)
+	_ = tracker.Do("reserve", failing)
+	metricstest.RequireValue(t, registry, "oms_dependency_circuit_state", state, float64(CircuitOpen))
+
+	now = now.Add(time.Minute)
+	if err := tracker.Do("reserve", func() error {
+		metricstest.RequireValue(t, registry, "oms_dependency_circuit_state", state, float64(CircuitHalfOpen))
+		return nil
+	}); err != nil {
+		t.Fatalf("expected probe to succeed, got %v", err)
+	}
+	metricstest.RequireValue(t, registry, "oms_dependency_circuit_state", state, float64(CircuitClosed))
+}
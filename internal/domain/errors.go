@@ -5,6 +5,8 @@ import "errors"
 var (
 	// Ошибка отсутствующего идентификатора клиента.
 	ErrCustomerRequired = errors.New("customer_id is required")
+	// ErrCustomerIDInvalid — customer_id длиннее MaxIDLength или содержит недопустимые символы.
+	ErrCustomerIDInvalid = errors.New("customer_id is malformed")
 	// Ошибка отсутствующего кода валюты.
 	ErrCurrencyRequired = errors.New("currency is required")
 	// Ошибка отсутствия хотя бы одного товара в заказе.
@@ -17,18 +19,40 @@ var (
 	ErrItemPriceInvalid = errors.New("item price must be non-negative")
 	// Ошибка несоответствия суммы заказа и сумм позиций.
 	ErrAmountMismatch = errors.New("order amount does not match items sum")
+	// ErrAdjustmentInvalid — некорректная строка скидки или налога.
+	ErrAdjustmentInvalid = errors.New("invalid price adjustment")
+	// ErrDiscountExceedsSubtotal — скидки в сумме больше суммы позиций.
+	ErrDiscountExceedsSubtotal = errors.New("discounts exceed order subtotal")
 	// Ошибка отрицательной суммы платежа.
 	ErrPaymentAmountNegative = errors.New("payment amount must be non-negative")
 	// Ошибка отсутствующего кода платёжного провайдера.
 	ErrPaymentProviderRequired = errors.New("payment provider is required")
 	// Ошибка отсутствующего идентификатора заказа в платежах/резервах.
 	ErrOrderIDRequired = errors.New("order_id is required")
+	// ErrOrderIDInvalid — order_id длиннее MaxIDLength или содержит недопустимые символы.
+	ErrOrderIDInvalid = errors.New("order_id is malformed")
 	// Ошибка отсутствующего SKU в резерве.
 	ErrReservationSKURequired = errors.New("reservation sku is required")
 	// Ошибка некорректного количества в резерве.
 	ErrReservationQtyInvalid = errors.New("reservation qty must be greater than zero")
 	// ErrOrderNotFound возвращается, если заказ не найден в репозитории.
 	ErrOrderNotFound = errors.New("order not found")
+	// ErrOrderItemNotFound возвращается, если в заказе нет позиции с указанным ID.
+	ErrOrderItemNotFound = errors.New("order item not found")
+	// ErrHoldReasonRequired — hold без причины запрещён.
+	ErrHoldReasonRequired = errors.New("hold reason is required")
+	// ErrOrderAlreadyOnHold — заказ уже остановлен.
+	ErrOrderAlreadyOnHold = errors.New("order is already on hold")
+	// ErrOrderNotOnHold — release для заказа, который не на hold.
+	ErrOrderNotOnHold = errors.New("order is not on hold")
+	// ErrOrderNotHoldable — заказ в статусе, из которого hold невозможен.
+	ErrOrderNotHoldable = errors.New("order cannot be put on hold in current status")
+	// ErrOrderNotAwaitingApproval — approve/reject для заказа, который не ждёт согласования.
+	ErrOrderNotAwaitingApproval = errors.New("order is not awaiting approval")
+	// ErrOrderStatusUnchanged — принудительный перевод в статус, в котором заказ уже находится.
+	ErrOrderStatusUnchanged = errors.New("order is already in target status")
+	// ErrCatalogPriceNotFound — в каталоге нет цены товара в валюте заказа.
+	ErrCatalogPriceNotFound = errors.New("catalog price not found")
 	// ErrOrderVersionConflict сигнализирует о конфликте версий при сохранении.
 	ErrOrderVersionConflict = errors.New("order version conflict")
 	// ErrInventoryUnavailable — бизнес-ошибка от склада (нет стока/недоступность позиции).
@@ -41,8 +65,12 @@ var (
 	ErrPaymentIndeterminate = errors.New("payment indeterminate state")
 	// ErrPaymentTemporary — временная ошибка платёжного провайдера.
 	ErrPaymentTemporary = errors.New("payment temporary error")
+	// ErrTestModeUnavailable — sandbox-заказ, а заглушки склада и оплаты не настроены.
+	ErrTestModeUnavailable = errors.New("test mode services are not configured")
 	// ErrOutboxPublish — ошибка при публикации сообщения из outbox.
 	ErrOutboxPublish = errors.New("outbox publish failed")
+	// ErrQuotaExceeded — заказ не помещается в дневную квоту principal'а.
+	ErrQuotaExceeded = errors.New("order quota exceeded")
 	// ErrIdempotencyKeyRequired — отсутствует обязательный idempotency-key.
 	ErrIdempotencyKeyRequired = errors.New("idempotency key is required")
 	// ErrIdempotencyRequestHashRequired — отсутствует hash тела запроса для проверки replays.
@@ -0,0 +1,117 @@
+package domain
+
+import (
+	"context"
+	"sync"
+)
+
+// Headers корреляции событий; в Kafka-сообщения переносятся под теми же именами.
+const (
+	EventHeaderCorrelationID = "x-correlation-id"
+	EventHeaderCausationID   = "x-causation-id"
+)
+
+// EventHeaderSynthetic помечает события синтетических заказов (canary-проверка): значение —
+// источник, например "canary". Аналитика и бизнес-потребители такие события пропускают.
+const EventHeaderSynthetic = "x-synthetic"
+
+type eventHeadersContextKey struct{}
+
+// ContextWithEventHeaders сохраняет в контексте headers, которые получат события, записанные
+// в outbox в рамках этого контекста. Пустые значения отбрасываются.
+func ContextWithEventHeaders(ctx context.Context, headers map[string]string) context.Context {
+	copied := make(map[string]string, len(headers))
+	for key, value := range headers {
+		if value != "" {
+			copied[key] = value
+		}
+	}
+	if len(copied) == 0 {
+		return ctx
+	}
+	return context.WithValue(ctx, eventHeadersContextKey{}, copied)
+}
+
+// EventHeadersFromContext возвращает копию headers из контекста или nil.
+func EventHeadersFromContext(ctx context.Context) map[string]string {
+	if ctx == nil {
+		return nil
+	}
+	headers, ok := ctx.Value(eventHeadersContextKey{}).(map[string]string)
+	if !ok {
+		return nil
+	}
+	copied := make(map[string]string, len(headers))
+	for key, value := range headers {
+		copied[key] = value
+	}
+	return copied
+}
+
+// OutboxHeadersFromContext возвращает headers для записи события в outbox: EventHeadersFromContext
+// вместе с correlation и causation цепочки событий (ContextWithEventChain). Без них — nil.
+func OutboxHeadersFromContext(ctx context.Context) map[string]string {
+	headers := EventHeadersFromContext(ctx)
+	correlationID, causationID := EventChainFromContext(ctx).IDs()
+	if correlationID == "" {
+		return headers
+	}
+	if headers == nil {
+		headers = make(map[string]string, 2)
+	}
+	headers[EventHeaderCorrelationID] = correlationID
+	headers[EventHeaderCausationID] = causationID
+	return headers
+}
+
+type eventChainContextKey struct{}
+
+// EventChain связывает события, порождённые одним запросом: correlation — ID исходного запроса,
+// causation — ID события (или запроса), вслед за которым пишется следующее событие. По этим ID
+// потребители восстанавливают цепочку created → reserved → paid без знания саги.
+type EventChain struct {
+	mu            sync.Mutex
+	correlationID string
+	causationID   string
+}
+
+// ContextWithEventChain начинает цепочку событий в контексте. Пустой causationID означает, что
+// первое событие вызвано самим запросом; пустой correlationID оставляет контекст без цепочки.
+func ContextWithEventChain(ctx context.Context, correlationID, causationID string) context.Context {
+	if correlationID == "" {
+		return ctx
+	}
+	if causationID == "" {
+		causationID = correlationID
+	}
+	return context.WithValue(ctx, eventChainContextKey{}, &EventChain{correlationID: correlationID, causationID: causationID})
+}
+
+// EventChainFromContext возвращает цепочку событий контекста или nil.
+func EventChainFromContext(ctx context.Context) *EventChain {
+	if ctx == nil {
+		return nil
+	}
+	chain, _ := ctx.Value(eventChainContextKey{}).(*EventChain)
+	return chain
+}
+
+// IDs возвращает correlation и causation для следующего события; для nil — пустые строки.
+func (c *EventChain) IDs() (correlationID, causationID string) {
+	if c == nil {
+		return "", ""
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.correlationID, c.causationID
+}
+
+// Advance делает eventID причиной следующих событий цепочки. Вызывается после записи события.
+func (c *EventChain) Advance(eventID string) {
+	if c == nil || eventID == "" {
+		return
+	}
+	c.mu.Lock()
+	c.causationID = eventID
+	c.mu.Unlock()
+}
@@ -0,0 +1,30 @@
+package domain
+
+import (
+	"context"
+	"testing"
+)
+
+func TestEventChain(t *testing.T) {
+	if got := OutboxHeadersFromContext(context.Background()); got != nil {
+		t.Fatalf("expected no headers without chain, got %v", got)
+	}
+	if ctx := ContextWithEventChain(context.Background(), "", "evt-0"); EventChainFromContext(ctx) != nil {
+		t.Fatal("empty correlation id must not start a chain")
+	}
+
+	ctx := ContextWithEventHeaders(context.Background(), map[string]string{"x-tenant-id": "tenant-a"})
+	ctx = ContextWithEventChain(ctx, "req-1", "")
+	headers := OutboxHeadersFromContext(ctx)
+	if headers["x-tenant-id"] != "tenant-a" || headers[EventHeaderCorrelationID] != "req-1" || headers[EventHeaderCausationID] != "req-1" {
+		t.Fatalf("first event must be caused by the request, got %v", headers)
+	}
+
+	EventChainFromContext(ctx).Advance("outbox-1")
+	if correlationID, causationID := EventChainFromContext(ctx).IDs(); correlationID != "req-1" || causationID != "outbox-1" {
+		t.Fatalf("expected chain req-1/outbox-1, got %s/%s", correlationID, causationID)
+	}
+	if len(EventHeadersFromContext(ctx)) != 1 {
+		t.Fatal("event chain must not leak into EventHeadersFromContext")
+	}
+}
@@ -1,6 +1,9 @@
 package domain
 
-import "time"
+import (
+	"strings"
+	"time"
+)
 
 // IdempotencyStatus описывает жизненный цикл ключа идемпотентности.
 type IdempotencyStatus string
@@ -14,8 +17,36 @@ const (
 	IdempotencyStatusFailed IdempotencyStatus = "failed"
 )
 
+// IdempotencyReasonStaleProcessing — причина в ответе ключа, который sweeper перевёл из зависшего
+// processing в failed: исход прерванного запроса неизвестен.
+const IdempotencyReasonStaleProcessing = "stale_processing"
+
+// IdempotencyScope определяет область действия idempotency-key: один и тот же ключ
+// у разных клиентов или методов не пересекается.
+type IdempotencyScope struct {
+	Method     string
+	CustomerID string
+	Key        string
+}
+
+// Normalize убирает пробелы по краям всех частей области.
+func (s IdempotencyScope) Normalize() IdempotencyScope {
+	return IdempotencyScope{
+		Method:     strings.TrimSpace(s.Method),
+		CustomerID: strings.TrimSpace(s.CustomerID),
+		Key:        strings.TrimSpace(s.Key),
+	}
+}
+
+// Legacy возвращает область записи, созданной до разделения ключей по методу и клиенту.
+func (s IdempotencyScope) Legacy() IdempotencyScope {
+	return IdempotencyScope{Key: s.Key}
+}
+
 // IdempotencyRecord хранит состояние обработки запроса с idempotency-key.
 type IdempotencyRecord struct {
+	Method       string
+	CustomerID   string
 	Key          string
 	RequestHash  string
 	ResponseBody []byte
@@ -26,6 +57,11 @@ type IdempotencyRecord struct {
 	UpdatedAt    time.Time
 }
 
+// Scope возвращает область, к которой относится запись.
+func (r IdempotencyRecord) Scope() IdempotencyScope {
+	return IdempotencyScope{Method: r.Method, CustomerID: r.CustomerID, Key: r.Key}
+}
+
 // Valid проверяет, что статус относится к поддерживаемым значениям.
 func (s IdempotencyStatus) Valid() bool {
 	switch s {
@@ -0,0 +1,123 @@
+package domain
+
+import (
+	"fmt"
+
+	"github.com/google/uuid"
+)
+
+// MaxIDLength — предел длины идентификаторов заказа и клиента (колонки TEXT, но индексы и логи не резиновые).
+const MaxIDLength = 128
+
+// OrderID — проверенный идентификатор заказа. Сервис генерирует UUID, но внешние системы
+// и тесты передают произвольные непрозрачные ID, поэтому формат ограничен только алфавитом и длиной.
+type OrderID string
+
+// CustomerID — проверенный идентификатор клиента (включая pseudonym'ы после обезличивания).
+type CustomerID string
+
+// NewOrderID генерирует идентификатор для нового заказа.
+func NewOrderID() OrderID {
+	return OrderID(uuid.NewString())
+}
+
+// ParseOrderID проверяет строку с границы API/хранилища: пустая — ErrOrderIDRequired,
+// некорректная — ErrOrderIDInvalid.
+func ParseOrderID(raw string) (OrderID, error) {
+	if err := validateID(raw, ErrOrderIDRequired, ErrOrderIDInvalid); err != nil {
+		return "", err
+	}
+	return OrderID(raw), nil
+}
+
+// ParseCustomerID — аналог ParseOrderID для клиента: ErrCustomerRequired или ErrCustomerIDInvalid.
+func ParseCustomerID(raw string) (CustomerID, error) {
+	if err := validateID(raw, ErrCustomerRequired, ErrCustomerIDInvalid); err != nil {
+		return "", err
+	}
+	return CustomerID(raw), nil
+}
+
+// String возвращает идентификатор в виде строки для proto, SQL и логов.
+func (id OrderID) String() string { return string(id) }
+
+// IsZero сообщает, что идентификатор не задан.
+func (id OrderID) IsZero() bool { return id == "" }
+
+// MarshalText реализует encoding.TextMarshaler: в JSON OrderID остаётся обычной строкой.
+func (id OrderID) MarshalText() ([]byte, error) { return []byte(id), nil }
+
+// UnmarshalText проверяет значение при декодировании; пустая строка допустима и даёт нулевой ID.
+func (id *OrderID) UnmarshalText(text []byte) error {
+	if len(text) == 0 {
+		*id = ""
+		return nil
+	}
+	parsed, err := ParseOrderID(string(text))
+	if err != nil {
+		return err
+	}
+	*id = parsed
+	return nil
+}
+
+// String возвращает идентификатор в виде строки для proto, SQL и логов.
+func (id CustomerID) String() string { return string(id) }
+
+// IsZero сообщает, что идентификатор не задан.
+func (id CustomerID) IsZero() bool { return id == "" }
+
+// MarshalText реализует encoding.TextMarshaler: в JSON CustomerID остаётся обычной строкой.
+func (id CustomerID) MarshalText() ([]byte, error) { return []byte(id), nil }
+
+// UnmarshalText проверяет значение при декодировании; пустая строка допустима и даёт нулевой ID.
+func (id *CustomerID) UnmarshalText(text []byte) error {
+	if len(text) == 0 {
+		*id = ""
+		return nil
+	}
+	parsed, err := ParseCustomerID(string(text))
+	if err != nil {
+		return err
+	}
+	*id = parsed
+	return nil
+}
+
+// validateID допускает латиницу, цифры и разделители . _ - : @ — этого хватает для UUID,
+// внешних ключей партнёров и pseudonym'ов, а пробелы и управляющие байты отсекаются до SQL.
+func validateID(raw string, required, invalid error) error {
+	if raw == "" {
+		return required
+	}
+	if len(raw) > MaxIDLength {
+		return fmt.Errorf("%w: longer than %d bytes", invalid, MaxIDLength)
+	}
+	for i := 0; i < len(raw); i++ {
+		if !isIDByte(raw[i]) {
+			return fmt.Errorf("%w: unexpected character %q at position %d", invalid, raw[i], i)
+		}
+	}
+	return nil
+}
+
+func isIDByte(c byte) bool {
+	switch {
+	case c >= 'a' && c <= 'z', c >= 'A' && c <= 'Z', c >= '0' && c <= '9':
+		return true
+	case c == '.', c == '_', c == '-', c == ':', c == '@':
+		return true
+	default:
+		return false
+	}
+}
+
+// ValidateIDs проверяет идентификаторы заказа и клиента; хранилища вызывают её перед вставкой,
+// чтобы некорректный ID не доходил до SQL.
+func (o *Order) ValidateIDs() error {
+	if _, err := ParseOrderID(o.ID); err != nil {
+		return err
+	}
+	_, err := ParseCustomerID(o.CustomerID)
+	return err
+}
@@ -0,0 +1,87 @@
+package domain
+
+import (
+	"encoding/json"
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestParseOrderID(t *testing.T) {
+	tests := []struct {
+		name string
+		raw  string
+		want error
+	}{
+		{name: "uuid", raw: NewOrderID().String()},
+		{name: "opaque partner id", raw: "partner-1:order_42.v2"},
+		{name: "empty", raw: "", want: ErrOrderIDRequired},
+		{name: "whitespace", raw: "order 1", want: ErrOrderIDInvalid},
+		{name: "control byte", raw: "order-\x00", want: ErrOrderIDInvalid},
+		{name: "sql fragment", raw: "1';DROP", want: ErrOrderIDInvalid},
+		{name: "too long", raw: strings.Repeat("a", MaxIDLength+1), want: ErrOrderIDInvalid},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			id, err := ParseOrderID(tt.raw)
+			if !errors.Is(err, tt.want) {
+				t.Fatalf("expected %v, got %v", tt.want, err)
+			}
+			if tt.want == nil && id.String() != tt.raw {
+				t.Fatalf("expected %q, got %q", tt.raw, id)
+			}
+		})
+	}
+}
+
+func TestParseCustomerID(t *testing.T) {
+	if _, err := ParseCustomerID(""); !errors.Is(err, ErrCustomerRequired) {
+		t.Fatalf("expected ErrCustomerRequired, got %v", err)
+	}
+	if _, err := ParseCustomerID("alice\n"); !errors.Is(err, ErrCustomerIDInvalid) {
+		t.Fatalf("expected ErrCustomerIDInvalid, got %v", err)
+	}
+	id, err := ParseCustomerID("erased-3f1c@tenant")
+	if err != nil || id.IsZero() {
+		t.Fatalf("expected valid customer id, got %q, %v", id, err)
+	}
+}
+
+func TestIDs_JSONRoundTrip(t *testing.T) {
+	type payload struct {
+		OrderID    OrderID    `json:"order_id"`
+		CustomerID CustomerID `json:"customer_id,omitempty"`
+	}
+
+	data, err := json.Marshal(payload{OrderID: "order-1", CustomerID: "customer-1"})
+	if err != nil {
+		t.Fatalf("marshal: %v", err)
+	}
+	if string(data) != `{"order_id":"order-1","customer_id":"customer-1"}` {
+		t.Fatalf("ids must stay plain JSON strings, got %s", data)
+	}
+
+	var decoded payload
+	if err := json.Unmarshal([]byte(`{"order_id":"order-1"}`), &decoded); err != nil {
+		t.Fatalf("unmarshal: %v", err)
+	}
+	if decoded.OrderID != "order-1" || !decoded.CustomerID.IsZero() {
+		t.Fatalf("unexpected decoded payload: %+v", decoded)
+	}
+
+	err = json.Unmarshal([]byte(`{"order_id":"order 1"}`), &decoded)
+	if !errors.Is(err, ErrOrderIDInvalid) {
+		t.Fatalf("expected ErrOrderIDInvalid from decoder, got %v", err)
+	}
+}
+
+func TestOrder_ValidateIDs(t *testing.T) {
+	order := Order{ID: "order-1", CustomerID: "customer 1"}
+	if err := order.ValidateIDs(); !errors.Is(err, ErrCustomerIDInvalid) {
+		t.Fatalf("expected ErrCustomerIDInvalid, got %v", err)
+	}
+	order.CustomerID = "customer-1"
+	if err := order.ValidateIDs(); err != nil {
+		t.Fatalf("expected valid ids, got %v", err)
+	}
+}
@@ -18,6 +18,14 @@ const (
 	OrderStatusCanceled OrderStatus = "canceled"
 	// OrderStatusRefunded — заказ полностью или частично возвращён клиенту.
 	OrderStatusRefunded OrderStatus = "refunded"
+	// OrderStatusOnHold — заказ остановлен для ручной/антифрод-проверки, сага не продвигается.
+	OrderStatusOnHold OrderStatus = "on_hold"
+	// OrderStatusBackordered — товара нет на складе, заказ ждёт пополнения; резерв не сделан.
+	OrderStatusBackordered OrderStatus = "backordered"
+	// OrderStatusAuthorized — сумма заблокирована у PSP (двухфазная оплата), заказ ждёт capture.
+	OrderStatusAuthorized OrderStatus = "authorized"
+	// OrderStatusAwaitingApproval — заказ клиента с согласованием ждёт решения; сага не запускается.
+	OrderStatusAwaitingApproval OrderStatus = "awaiting_approval"
 )
 
 // OrderItem представляет одну позицию заказа.
@@ -45,14 +53,24 @@ type Order struct {
 	Version     int64
 	CreatedAt   time.Time
 	UpdatedAt   time.Time
+	// HoldReason — причина остановки заказа (заполнена только в статусе on_hold).
+	HoldReason string
+	// HeldFromStatus — статус до постановки на hold, в него заказ возвращается при release.
+	HeldFromStatus OrderStatus
+	// Pricing — разбивка суммы со скидками и налогами; пустая, если корректировок нет
+	// и AmountMinor равен сумме позиций.
+	Pricing OrderPricing
+	// TestMode — sandbox-заказ партнёра: сага ведёт его через заглушки склада и оплаты,
+	// в бизнес-метриках он учитывается отдельно (metrics.OrderModeTest).
+	TestMode bool
 }
 
 // ValidateInvariants проверяет базовые инварианты заказа и возвращает список замечаний.
 func (o *Order) ValidateInvariants() []error {
 	var errs []error
 
-	if o.CustomerID == "" {
-		errs = append(errs, ErrCustomerRequired)
+	if _, err := ParseCustomerID(o.CustomerID); err != nil {
+		errs = append(errs, err)
 	}
 	if o.Currency == "" {
 		errs = append(errs, ErrCurrencyRequired)
@@ -75,9 +93,126 @@ func (o *Order) ValidateInvariants() []error {
 		}
 		calc += int64(item.Qty) * item.PriceMinor
 	}
-	if calc != o.AmountMinor {
+	if o.Pricing.IsZero() {
+		if calc != o.AmountMinor {
+			errs = append(errs, ErrAmountMismatch)
+		}
+		return errs
+	}
+
+	// С корректировками позиции сверяются с subtotal, а итог разбивки — с суммой заказа.
+	p := o.Pricing
+	if calc != p.SubtotalMinor || p.TotalMinor != o.AmountMinor ||
+		p.SubtotalMinor-p.DiscountMinor+p.TaxMinor != p.TotalMinor {
 		errs = append(errs, ErrAmountMismatch)
 	}
 
 	return errs
 }
+
+// ItemByID возвращает позицию заказа по её идентификатору или ErrOrderItemNotFound.
+func (o *Order) ItemByID(itemID string) (OrderItem, error) {
+	for _, item := range o.Items {
+		if item.ID == itemID {
+			return item, nil
+		}
+	}
+	return OrderItem{}, ErrOrderItemNotFound
+}
+
+// CanHold сообщает, можно ли поставить заказ на hold: только до подтверждения.
+func (o *Order) CanHold() bool {
+	switch o.Status {
+	case OrderStatusPending, OrderStatusReserved, OrderStatusAuthorized, OrderStatusPaid:
+		return true
+	default:
+		return false
+	}
+}
+
+// Hold переводит заказ в on_hold, запоминая текущий статус.
+func (o *Order) Hold(reason string) error {
+	if reason == "" {
+		return ErrHoldReasonRequired
+	}
+	if o.Status == OrderStatusOnHold {
+		return ErrOrderAlreadyOnHold
+	}
+	if !o.CanHold() {
+		return ErrOrderNotHoldable
+	}
+	o.HeldFromStatus = o.Status
+	o.HoldReason = reason
+	o.Status = OrderStatusOnHold
+	return nil
+}
+
+// Release снимает hold и возвращает заказ в статус, в котором он был остановлен.
+func (o *Order) Release() error {
+	if o.Status != OrderStatusOnHold {
+		return ErrOrderNotOnHold
+	}
+	o.Status = o.HeldFromStatus
+	if o.Status == "" {
+		o.Status = OrderStatusPending
+	}
+	o.HeldFromStatus = ""
+	o.HoldReason = ""
+	return nil
+}
+
+// Approve согласует заказ: из awaiting_approval он переходит в pending и дальше обрабатывается
+// как обычный заказ.
+func (o *Order) Approve() error {
+	if o.Status != OrderStatusAwaitingApproval {
+		return ErrOrderNotAwaitingApproval
+	}
+	o.Status = OrderStatusPending
+	return nil
+}
+
+// Reject отклоняет заказ на согласовании; резерва и оплаты ещё не было, поэтому заказ просто отменяется.
+func (o *Order) Reject() error {
+	if o.Status != OrderStatusAwaitingApproval {
+		return ErrOrderNotAwaitingApproval
+	}
+	o.Status = OrderStatusCanceled
+	return nil
+}
+
+// ForceStatus переводит заказ в target без проверки допустимости перехода — ручное исправление
+// зависшего заказа оператором. Перевод в on_hold запоминает прежний статус для release,
+// уход с on_hold снимает hold.
+func (o *Order) ForceStatus(target OrderStatus, reason string) error {
+	if o.Status == target {
+		return ErrOrderStatusUnchanged
+	}
+	if target == OrderStatusOnHold {
+		o.HeldFromStatus = o.Status
+		o.HoldReason = reason
+	} else {
+		o.HeldFromStatus = ""
+		o.HoldReason = ""
+	}
+	o.Status = target
+	return nil
+}
+
+// HasSKU сообщает, есть ли в заказе позиция с указанным SKU.
+func (o *Order) HasSKU(sku string) bool {
+	for _, item := range o.Items {
+		if item.SKU == sku {
+			return true
+		}
+	}
+	return false
+}
+
+// EffectiveStatus возвращает статус, определяющий компенсации: для заказа на hold —
+// статус, в котором он был остановлен.
+func (o *Order) EffectiveStatus() OrderStatus {
+	if o.Status == OrderStatusOnHold && o.HeldFromStatus != "" {
+		return o.HeldFromStatus
+	}
+	return o.Status
+}
@@ -0,0 +1,179 @@
+package domain
+
+import (
+	"errors"
+	"fmt"
+	"time"
+)
+
+// ReturnStatus — этап возврата позиций заказа (RMA).
+type ReturnStatus string
+
+const (
+	// ReturnStatusRequested — покупатель заявил возврат, магазин его ещё не одобрил.
+	ReturnStatusRequested ReturnStatus = "requested"
+	// ReturnStatusApproved — возврат одобрен, товар ждут на складе.
+	ReturnStatusApproved ReturnStatus = "approved"
+	// ReturnStatusReceived — товар получен и возвращён в остатки склада.
+	ReturnStatusReceived ReturnStatus = "received"
+	// ReturnStatusRefunded — деньги за возвращённые позиции переведены покупателю.
+	ReturnStatusRefunded ReturnStatus = "refunded"
+
+	// ReturnStatusReceiving — приёмка захвачена обработчиком, товар возвращается в остатки.
+	ReturnStatusReceiving ReturnStatus = "receiving"
+	// ReturnStatusRefunding — возврат денег захвачен обработчиком, идёт вызов PSP.
+	ReturnStatusRefunding ReturnStatus = "refunding"
+)
+
+// Next возвращает следующий этап возврата; false — возврат завершён, захвачен обработчиком
+// или статус неизвестен.
+func (s ReturnStatus) Next() (ReturnStatus, bool) {
+	switch s {
+	case ReturnStatusRequested:
+		return ReturnStatusApproved, true
+	case ReturnStatusApproved:
+		return ReturnStatusReceived, true
+	case ReturnStatusReceived:
+		return ReturnStatusRefunded, true
+	default:
+		return "", false
+	}
+}
+
+// Claim возвращает промежуточный статус, которым обработчик захватывает переход в s до побочного
+// эффекта (Restock, Refund PSP): захватить его из предыдущего статуса может только один вызов.
+// false — переход в s побочных эффектов не имеет.
+func (s ReturnStatus) Claim() (ReturnStatus, bool) {
+	switch s {
+	case ReturnStatusReceived:
+		return ReturnStatusReceiving, true
+	case ReturnStatusRefunded:
+		return ReturnStatusRefunding, true
+	default:
+		return "", false
+	}
+}
+
+// Claimed сообщает, что возврат захвачен обработчиком и ещё не перешёл в следующий этап.
+func (s ReturnStatus) Claimed() bool {
+	return s == ReturnStatusReceiving || s == ReturnStatusRefunding
+}
+
+var (
+	// ErrReturnNotFound — возврата с таким ID нет.
+	ErrReturnNotFound = errors.New("order return not found")
+	// ErrReturnStatusConflict — возврат уже не в том статусе, из которого его переводят.
+	ErrReturnStatusConflict = errors.New("order return status conflict")
+	// ErrReturnsChanged — у заказа появился возврат после того, как новый был проверен PlanReturn.
+	ErrReturnsChanged = errors.New("order returns changed concurrently")
+	// ErrReturnItemsRequired — возврат без позиций.
+	ErrReturnItemsRequired = errors.New("return must contain at least one item")
+	// ErrReturnQtyExceeded — позиций возвращают больше, чем куплено (с учётом прошлых возвратов).
+	ErrReturnQtyExceeded = errors.New("return qty exceeds ordered qty")
+	// ErrReturnNotRefundable — сумма заказа уже полностью возвращена.
+	ErrReturnNotRefundable = errors.New("order has nothing left to refund")
+)
+
+// ReturnItem — позиция заказа в возврате.
+type ReturnItem struct {
+	// ItemID — OrderItem.ID возвращаемой позиции.
+	ItemID string
+	SKU    string
+	Qty    int32
+	// RefundMinor — сумма к возврату за позицию в валюте заказа.
+	RefundMinor int64
+}
+
+// OrderReturn — возврат части позиций заказа. В отличие от RefundOrder, заказ остаётся
+// в своём статусе: возврат ведёт собственный статус и сумму.
+type OrderReturn struct {
+	ID      string
+	OrderID string
+	Status  ReturnStatus
+	Items   []ReturnItem
+	Reason  string
+	// RefundMinor — сумма к возврату по всем позициям; не больше остатка, не возвращённого ранее.
+	RefundMinor int64
+	Currency    string
+	CreatedAt   time.Time
+	UpdatedAt   time.Time
+}
+
+// PlanReturn проверяет позиции возврата против заказа и прошлых возвратов и считает суммы.
+// Сумма позиции — цена за единицу × количество; общая сумма ограничена тем, что ещё не
+// обещано прошлыми возвратами, поэтому скидки заказа не дают вернуть больше, чем заплачено.
+func PlanReturn(order Order, previous []OrderReturn, items []ReturnItem) (OrderReturn, error) {
+	if len(items) == 0 {
+		return OrderReturn{}, ErrReturnItemsRequired
+	}
+
+	ordered := make(map[string]OrderItem, len(order.Items))
+	for _, item := range order.Items {
+		ordered[item.ID] = item
+	}
+	returned := make(map[string]int32)
+	var committed int64
+	for _, ret := range previous {
+		for _, item := range ret.Items {
+			returned[item.ItemID] += item.Qty
+		}
+		committed += ret.RefundMinor
+	}
+
+	planned := make([]ReturnItem, 0, len(items))
+	var total int64
+	for _, item := range items {
+		orderItem, ok := ordered[item.ItemID]
+		if !ok {
+			return OrderReturn{}, fmt.Errorf("%w: %s", ErrOrderItemNotFound, item.ItemID)
+		}
+		if item.Qty <= 0 {
+			return OrderReturn{}, fmt.Errorf("%w: %s", ErrItemQtyInvalid, item.ItemID)
+		}
+		returned[item.ItemID] += item.Qty
+		if returned[item.ItemID] > orderItem.Qty {
+			return OrderReturn{}, fmt.Errorf("%w: %s", ErrReturnQtyExceeded, item.ItemID)
+		}
+		refund := orderItem.PriceMinor * int64(item.Qty)
+		planned = append(planned, ReturnItem{ItemID: item.ItemID, SKU: orderItem.SKU, Qty: item.Qty, RefundMinor: refund})
+		total += refund
+	}
+
+	remaining := order.AmountMinor - committed
+	if remaining <= 0 {
+		return OrderReturn{}, ErrReturnNotRefundable
+	}
+	return OrderReturn{
+		OrderID:     order.ID,
+		Status:      ReturnStatusRequested,
+		Items:       planned,
+		RefundMinor: min(total, remaining),
+		Currency:    order.Currency,
+	}, nil
+}
+
+// RefundedByReturns — сумма, возвращённая покупателю через возвраты позиций, включая те,
+// по которым вызов PSP ещё идёт.
+func RefundedByReturns(returns []OrderReturn) int64 {
+	var refunded int64
+	for _, ret := range returns {
+		if ret.Status == ReturnStatusRefunded || ret.Status == ReturnStatusRefunding {
+			refunded += ret.RefundMinor
+		}
+	}
+	return refunded
+}
+
+// ReturnRepository хранит возвраты позиций заказов.
+type ReturnRepository interface {
+	// Create сохраняет возврат в статусе requested; пустой ID генерируется. previous — число
+	// возвратов заказа, против которых ret проверен PlanReturn: если их уже больше, возврат
+	// не сохраняется и возвращается ErrReturnsChanged. Проверка и запись атомарны между репликами.
+	Create(ret OrderReturn, previous int) (OrderReturn, error)
+	// Get возвращает возврат или ErrReturnNotFound.
+	Get(id string) (OrderReturn, error)
+	// ListByOrder возвращает возвраты заказа от старых к новым.
+	ListByOrder(orderID string) ([]OrderReturn, error)
+	// Advance переводит возврат из from в to; если возврат уже не в from — ErrReturnStatusConflict.
+	Advance(id string, from, to ReturnStatus) (OrderReturn, error)
+}
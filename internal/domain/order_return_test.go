@@ -0,0 +1,107 @@
+package domain_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/vladislavdragonenkov/oms/internal/domain"
+)
+
+func returnTestOrder() domain.Order {
+	return domain.Order{
+		ID:          "order-1",
+		Currency:    "USD",
+		AmountMinor: 450, // 500 по позициям минус скидка 50.
+		Items: []domain.OrderItem{
+			{ID: "item-1", SKU: "sku-1", Qty: 3, PriceMinor: 100},
+			{ID: "item-2", SKU: "sku-2", Qty: 1, PriceMinor: 200},
+		},
+	}
+}
+
+func TestPlanReturn_ComputesRefundPerItem(t *testing.T) {
+	ret, err := domain.PlanReturn(returnTestOrder(), nil, []domain.ReturnItem{{ItemID: "item-1", Qty: 2}})
+	if err != nil {
+		t.Fatalf("plan return: %v", err)
+	}
+	if ret.Status != domain.ReturnStatusRequested || ret.RefundMinor != 200 || ret.Currency != "USD" {
+		t.Fatalf("unexpected return: %+v", ret)
+	}
+	if len(ret.Items) != 1 || ret.Items[0].SKU != "sku-1" || ret.Items[0].RefundMinor != 200 {
+		t.Fatalf("unexpected items: %+v", ret.Items)
+	}
+}
+
+func TestPlanReturn_CountsPreviousReturns(t *testing.T) {
+	order := returnTestOrder()
+	first, err := domain.PlanReturn(order, nil, []domain.ReturnItem{{ItemID: "item-1", Qty: 2}, {ItemID: "item-2", Qty: 1}})
+	if err != nil {
+		t.Fatalf("plan first return: %v", err)
+	}
+	previous := []domain.OrderReturn{first}
+
+	if _, err := domain.PlanReturn(order, previous, []domain.ReturnItem{{ItemID: "item-1", Qty: 2}}); !errors.Is(err, domain.ErrReturnQtyExceeded) {
+		t.Fatalf("expected ErrReturnQtyExceeded, got %v", err)
+	}
+
+	// Остаток заказа после первого возврата — 50, хотя позиция стоит 100: скидка не возвращается дважды.
+	last, err := domain.PlanReturn(order, previous, []domain.ReturnItem{{ItemID: "item-1", Qty: 1}})
+	if err != nil {
+		t.Fatalf("plan last return: %v", err)
+	}
+	if last.RefundMinor != 50 {
+		t.Fatalf("expected refund capped at remaining 50, got %d", last.RefundMinor)
+	}
+
+	if _, err := domain.PlanReturn(order, append(previous, last), []domain.ReturnItem{{ItemID: "item-2", Qty: 1}}); !errors.Is(err, domain.ErrReturnQtyExceeded) {
+		t.Fatalf("expected ErrReturnQtyExceeded, got %v", err)
+	}
+}
+
+func TestPlanReturn_RejectsInvalidItems(t *testing.T) {
+	order := returnTestOrder()
+	cases := map[string]struct {
+		items []domain.ReturnItem
+		want  error
+	}{
+		"empty":        {items: nil, want: domain.ErrReturnItemsRequired},
+		"unknown item": {items: []domain.ReturnItem{{ItemID: "item-9", Qty: 1}}, want: domain.ErrOrderItemNotFound},
+		"zero qty":     {items: []domain.ReturnItem{{ItemID: "item-1"}}, want: domain.ErrItemQtyInvalid},
+		"same item twice over qty": {
+			items: []domain.ReturnItem{{ItemID: "item-1", Qty: 2}, {ItemID: "item-1", Qty: 2}},
+			want:  domain.ErrReturnQtyExceeded,
+		},
+	}
+	for name, tc := range cases {
+		if _, err := domain.PlanReturn(order, nil, tc.items); !errors.Is(err, tc.want) {
+			t.Fatalf("%s: expected %v, got %v", name, tc.want, err)
+		}
+	}
+}
+
+func TestRefundedByReturns_CountsOnlyRefunded(t *testing.T) {
+	returns := []domain.OrderReturn{
+		{Status: domain.ReturnStatusRefunded, RefundMinor: 100},
+		{Status: domain.ReturnStatusReceived, RefundMinor: 200},
+		{Status: domain.ReturnStatusRefunded, RefundMinor: 50},
+	}
+	if got := domain.RefundedByReturns(returns); got != 150 {
+		t.Fatalf("expected 150, got %d", got)
+	}
+}
+
+func TestReturnStatus_Next(t *testing.T) {
+	status := domain.ReturnStatusRequested
+	var path []domain.ReturnStatus
+	for {
+		next, ok := status.Next()
+		if !ok {
+			break
+		}
+		path = append(path, next)
+		status = next
+	}
+	if len(path) != 3 || path[2] != domain.ReturnStatusRefunded {
+		t.Fatalf("unexpected return path: %v", path)
+	}
+}
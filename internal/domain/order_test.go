@@ -1,6 +1,7 @@
 package domain_test
 
 import (
+	"errors"
 	"testing"
 	"time"
 
@@ -97,3 +98,107 @@ func TestOrderValidateInvariants_Errors(t *testing.T) {
 		})
 	}
 }
+
+func TestOrderItemByID(t *testing.T) {
+	order := makeOrder()
+
+	item, err := order.ItemByID("item-1")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if item.SKU != "sku-1" {
+		t.Fatalf("unexpected item: %+v", item)
+	}
+
+	if _, err := order.ItemByID("missing"); !errors.Is(err, domain.ErrOrderItemNotFound) {
+		t.Fatalf("expected ErrOrderItemNotFound, got %v", err)
+	}
+}
+
+func TestOrderHoldAndRelease(t *testing.T) {
+	order := makeOrder()
+	order.Status = domain.OrderStatusReserved
+
+	if err := order.Hold(""); !errors.Is(err, domain.ErrHoldReasonRequired) {
+		t.Fatalf("expected ErrHoldReasonRequired, got %v", err)
+	}
+	if err := order.Hold("fraud score 0.97"); err != nil {
+		t.Fatalf("Hold failed: %v", err)
+	}
+	if order.Status != domain.OrderStatusOnHold || order.HoldReason != "fraud score 0.97" {
+		t.Fatalf("unexpected order after hold: %+v", order)
+	}
+	if order.EffectiveStatus() != domain.OrderStatusReserved {
+		t.Fatalf("expected effective status reserved, got %s", order.EffectiveStatus())
+	}
+	if err := order.Hold("again"); !errors.Is(err, domain.ErrOrderAlreadyOnHold) {
+		t.Fatalf("expected ErrOrderAlreadyOnHold, got %v", err)
+	}
+
+	if err := order.Release(); err != nil {
+		t.Fatalf("Release failed: %v", err)
+	}
+	if order.Status != domain.OrderStatusReserved || order.HoldReason != "" || order.HeldFromStatus != "" {
+		t.Fatalf("unexpected order after release: %+v", order)
+	}
+	if err := order.Release(); !errors.Is(err, domain.ErrOrderNotOnHold) {
+		t.Fatalf("expected ErrOrderNotOnHold, got %v", err)
+	}
+}
+
+func TestOrderHold_RejectsFinalStatuses(t *testing.T) {
+	for _, status := range []domain.OrderStatus{domain.OrderStatusConfirmed, domain.OrderStatusCanceled, domain.OrderStatusRefunded} {
+		order := makeOrder()
+		order.Status = status
+		if err := order.Hold("fraud"); !errors.Is(err, domain.ErrOrderNotHoldable) {
+			t.Fatalf("status %s: expected ErrOrderNotHoldable, got %v", status, err)
+		}
+	}
+}
+
+func TestOrderApproveAndReject(t *testing.T) {
+	order := makeOrder()
+	if err := order.Approve(); !errors.Is(err, domain.ErrOrderNotAwaitingApproval) {
+		t.Fatalf("expected ErrOrderNotAwaitingApproval for pending order, got %v", err)
+	}
+
+	order.Status = domain.OrderStatusAwaitingApproval
+	if err := order.Approve(); err != nil || order.Status != domain.OrderStatusPending {
+		t.Fatalf("Approve: status=%s err=%v", order.Status, err)
+	}
+
+	order.Status = domain.OrderStatusAwaitingApproval
+	if err := order.Reject(); err != nil || order.Status != domain.OrderStatusCanceled {
+		t.Fatalf("Reject: status=%s err=%v", order.Status, err)
+	}
+	if err := order.Reject(); !errors.Is(err, domain.ErrOrderNotAwaitingApproval) {
+		t.Fatalf("expected ErrOrderNotAwaitingApproval for canceled order, got %v", err)
+	}
+}
+
+func TestOrderForceStatus(t *testing.T) {
+	order := makeOrder()
+	if err := order.ForceStatus(domain.OrderStatusPending, "noop"); !errors.Is(err, domain.ErrOrderStatusUnchanged) {
+		t.Fatalf("expected ErrOrderStatusUnchanged, got %v", err)
+	}
+
+	if err := order.ForceStatus(domain.OrderStatusOnHold, "stuck in psp"); err != nil {
+		t.Fatalf("ForceStatus on_hold: %v", err)
+	}
+	if order.HeldFromStatus != domain.OrderStatusPending || order.HoldReason != "stuck in psp" {
+		t.Fatalf("hold fields not set: %+v", order)
+	}
+	if err := order.ForceStatus(domain.OrderStatusCanceled, "psp refunded"); err != nil {
+		t.Fatalf("ForceStatus canceled: %v", err)
+	}
+	if order.Status != domain.OrderStatusCanceled || order.HeldFromStatus != "" || order.HoldReason != "" {
+		t.Fatalf("expected canceled without hold, got %+v", order)
+	}
+}
+
+func TestOrder_HasSKU(t *testing.T) {
+	order := domain.Order{Items: []domain.OrderItem{{SKU: "sku-1"}, {SKU: "sku-2"}}}
+	if !order.HasSKU("sku-2") || order.HasSKU("sku-3") {
+		t.Fatalf("unexpected HasSKU result for items %+v", order.Items)
+	}
+}
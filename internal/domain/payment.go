@@ -16,6 +16,8 @@ const (
 	PaymentStatusRefunded PaymentStatus = "refunded"
 	// PaymentStatusFailed — провайдер отклонил платёж или произошла ошибка.
 	PaymentStatusFailed PaymentStatus = "failed"
+	// PaymentStatusVoided — блокировка суммы снята без списания.
+	PaymentStatusVoided PaymentStatus = "voided"
 )
 
 // Payment описывает платёж, связанный с заказом.
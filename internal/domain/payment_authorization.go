@@ -0,0 +1,35 @@
+package domain
+
+import (
+	"errors"
+	"time"
+)
+
+// ErrPaymentAuthorizationNotFound — по заказу нет отслеживаемой блокировки суммы.
+var ErrPaymentAuthorizationNotFound = errors.New("payment authorization not found")
+
+// PaymentAuthorization — блокировка суммы у PSP по заказу в статусе authorized. PSP держит
+// hold ограниченное время; к ExpiresAt заказ нужно либо списать (capture), либо авторизовать
+// повторно, либо отменить.
+type PaymentAuthorization struct {
+	OrderID      string
+	AmountMinor  int64
+	Currency     string
+	AuthorizedAt time.Time
+	ExpiresAt    time.Time
+	// Reauthorizations — сколько раз hold продлевали повторной авторизацией.
+	Reauthorizations int
+}
+
+// PaymentAuthorizationRepository хранит активные блокировки сумм, по одной на заказ.
+type PaymentAuthorizationRepository interface {
+	// Save создаёт или заменяет блокировку заказа.
+	Save(auth PaymentAuthorization) error
+	// Get возвращает блокировку заказа или ErrPaymentAuthorizationNotFound.
+	Get(orderID string) (PaymentAuthorization, error)
+	// Delete удаляет блокировку; отсутствие записи ошибкой не считается.
+	Delete(orderID string) error
+	// ListExpiring возвращает блокировки с ExpiresAt <= before, начиная с самых ранних;
+	// limit <= 0 — без ограничения.
+	ListExpiring(before time.Time, limit int) ([]PaymentAuthorization, error)
+}
@@ -0,0 +1,35 @@
+package domain
+
+import (
+	"errors"
+	"time"
+)
+
+// ErrPaymentRetryNotFound — у заказа нет запланированного повтора оплаты.
+var ErrPaymentRetryNotFound = errors.New("payment retry not found")
+
+// PaymentRetry — повтор оплаты заказа, оставшегося в reserved после временной ошибки PSP.
+// Резерв склада держится до повтора; когда попытки заканчиваются, заказ отменяется.
+type PaymentRetry struct {
+	OrderID string
+	// Attempt — номер запланированного повтора, начиная с 1.
+	Attempt int
+	RunAt   time.Time
+	// LastError — ошибка попытки, после которой запланирован повтор.
+	LastError string
+	UpdatedAt time.Time
+}
+
+// PaymentRetryRepository хранит запланированные повторы оплаты, по одному на заказ.
+type PaymentRetryRepository interface {
+	// Save создаёт или заменяет повтор заказа и снимает с него аренду ListDue.
+	Save(retry PaymentRetry) error
+	// Get возвращает повтор заказа или ErrPaymentRetryNotFound.
+	Get(orderID string) (PaymentRetry, error)
+	// Delete удаляет повтор; отсутствие записи ошибкой не считается.
+	Delete(orderID string) error
+	// ListDue забирает повторы с RunAt <= now, начиная с самых ранних; limit <= 0 — без ограничения.
+	// Забранные повторы закрепляются арендой: до её истечения повторные вызовы, в том числе
+	// с других реплик, их не возвращают, поэтому оплату заказа не запускают дважды.
+	ListDue(now time.Time, limit int) ([]PaymentRetry, error)
+}
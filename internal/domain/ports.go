@@ -10,6 +10,21 @@ type InventoryService interface {
 	Release(orderID string, items []OrderItem) error
 }
 
+// ReservationLister отдаёт снимок активных резервов склада (для сверки с заказами).
+type ReservationLister interface {
+	ListReservations() ([]Reservation, error)
+}
+
+// InventoryRestocker — склад, принимающий возвращённый покупателем товар обратно в остатки.
+type InventoryRestocker interface {
+	Restock(orderID string, items []OrderItem) error
+}
+
+// CustomerScopedInventory — склад, выбирающий backend с учётом покупателя (tenant-маршрутизация).
+type CustomerScopedInventory interface {
+	ForCustomer(customerID string) InventoryService
+}
+
 // PaymentService описывает взаимодействие с платёжным провайдером.
 type PaymentService interface {
 	// Pay инициирует списание средств по заказу.
@@ -18,6 +33,20 @@ type PaymentService interface {
 	Refund(orderID string, amountMinor int64, currency string) (PaymentStatus, error)
 }
 
+// PaymentCapturer — PSP с двухфазной оплатой: Pay только блокирует сумму (PaymentStatusAuthorized),
+// Capture списывает заблокированное, Void снимает блокировку без списания.
+type PaymentCapturer interface {
+	Capture(orderID string, amountMinor int64, currency string) (PaymentStatus, error)
+	Void(orderID string, amountMinor int64, currency string) (PaymentStatus, error)
+}
+
+// CatalogService отдаёт актуальные цены товаров.
+type CatalogService interface {
+	// Prices возвращает цены за единицу в минимальных единицах currency по SKU. SKU без цены
+	// в ответ не попадают.
+	Prices(currency string, skus []string) (map[string]int64, error)
+}
+
 // OutboxPublisher публикует события из transactional outbox.
 type OutboxPublisher interface {
 	// Publish передаёт событие наружу; должен быть идемпотентным.
@@ -27,25 +56,110 @@ type OutboxPublisher interface {
 // OutboxRepository позволяет сохранять события для последующей публикации.
 type OutboxRepository interface {
 	Enqueue(msg OutboxMessage) (OutboxMessage, error)
+	// EnqueueBatch сохраняет сообщения одной записью: либо все, либо ни одного. Порядок
+	// публикации сообщений внутри пакета совпадает с порядком в msgs.
+	EnqueueBatch(msgs []OutboxMessage) ([]OutboxMessage, error)
 	PullPending(limit int) ([]OutboxMessage, error)
 	Stats() (OutboxStats, error)
 	MarkSent(id string) error
 	MarkFailed(id string) error
+	// DeleteSent удаляет до limit опубликованных (status=sent) сообщений, отправленных не позже before.
+	// Сообщения в pending/processing/failed не удаляются никогда.
+	DeleteSent(before time.Time, limit int) (int, error)
+}
+
+// OutboxReplayFilter выбирает опубликованные сообщения для повторной публикации.
+// Пустые поля не ограничивают выборку.
+type OutboxReplayFilter struct {
+	// From и To задают полуинтервал [From, To) по времени постановки в outbox.
+	From          time.Time
+	To            time.Time
+	AggregateType string
+	AggregateID   string
+	// AfterCreatedAt и AfterID — курсор: выдаются записи строго после (created_at, id).
+	AfterCreatedAt time.Time
+	AfterID        string
+	Limit          int
+}
+
+// OutboxReplaySource читает уже опубликованные (status=sent) сообщения в порядке (created_at, id).
+// Доступны только записи, ещё не удалённые cleanup-воркером outbox.
+type OutboxReplaySource interface {
+	ListSent(filter OutboxReplayFilter) ([]OutboxMessage, error)
+}
+
+// OutboxFeedSource читает outbox как pull-ленту событий (AdminService.GetEventsSince).
+// В отличие от ListSent, ListEvents возвращает записи в любом статусе: лента не зависит
+// от доступности Kafka. Порядок и курсор — те же (created_at, id).
+type OutboxFeedSource interface {
+	ListEvents(filter OutboxReplayFilter) ([]OutboxMessage, error)
+}
+
+// OutboxAggregateDeleter удаляет сообщения агрегата в любом статусе: неотправленные уже не будут
+// опубликованы, отправленные пропадают из feed и replay. Используется уборкой синтетических заказов.
+type OutboxAggregateDeleter interface {
+	DeleteByAggregate(aggregateType, aggregateID string) (int, error)
 }
 
 // TimelineRepository хранит события жизненного цикла заказа.
 type TimelineRepository interface {
 	Append(event TimelineEvent) error
 	List(orderID string) ([]TimelineEvent, error)
+	DeleteByOrder(orderID string) error
 }
 
-// IdempotencyRepository хранит состояние обработки запросов по idempotency-key.
+// IdempotencyRepository хранит состояние обработки запросов по idempotency-key в пределах IdempotencyScope.
+// Записи без метода и клиента, оставшиеся от глобальных ключей, CreateProcessing учитывает
+// как повтор, только если совпадает хеш запроса.
 type IdempotencyRepository interface {
-	CreateProcessing(key, requestHash string, ttlAt time.Time) (IdempotencyRecord, error)
-	Get(key string) (IdempotencyRecord, error)
-	MarkDone(key string, responseBody []byte, httpStatus int) error
-	MarkFailed(key string, responseBody []byte, httpStatus int) error
+	CreateProcessing(scope IdempotencyScope, requestHash string, ttlAt time.Time) (IdempotencyRecord, error)
+	Get(scope IdempotencyScope) (IdempotencyRecord, error)
+	MarkDone(scope IdempotencyScope, responseBody []byte, httpStatus int) error
+	MarkFailed(scope IdempotencyScope, responseBody []byte, httpStatus int) error
 	DeleteExpired(before time.Time, limit int) (int, error)
+	// FailStaleProcessing переводит в failed ключи, зависшие в processing дольше before (например, после
+	// падения процесса), с ответом responseBody/httpStatus для повторов, и возвращает переведённые записи.
+	FailStaleProcessing(before time.Time, limit int, responseBody []byte, httpStatus int) ([]IdempotencyRecord, error)
+}
+
+// IdempotencyRecordDeleter удаляет запись области; отсутствие записи ошибкой не считается.
+type IdempotencyRecordDeleter interface {
+	Delete(scope IdempotencyScope) error
+}
+
+// OrderUnitOfWork атомарно сохраняет новый заказ и помечает idempotency-ключ выполненным.
+// Без него между коммитом заказа и MarkDone остаётся окно, в котором падение процесса
+// оставляет ключ в processing при уже созданном заказе.
+type OrderUnitOfWork interface {
+	CreateOrderWithIdempotency(order Order, scope IdempotencyScope, responseBody []byte, httpStatus int) error
+}
+
+// SagaDispatchRepository хранит намерения запуска саг, чтобы остановка процесса между
+// ответом RPC и выполнением саги не теряла операцию. Намерение арендует реплика, которая
+// выполняет сагу: другие реплики не трогают его, пока claimed_until не истечёт.
+type SagaDispatchRepository interface {
+	// Record сохраняет намерение, арендованное до intent.ClaimedUntil; пустой ID генерируется.
+	Record(intent SagaDispatchIntent) (SagaDispatchIntent, error)
+	// Complete удаляет намерение после выполнения саги; отсутствие записи не ошибка.
+	Complete(id string) error
+	// ClaimPending забирает незавершённые намерения с истёкшей к now арендой от старых к новым
+	// и продлевает их аренду до until; limit <= 0 — без ограничения.
+	ClaimPending(now, until time.Time, limit int) ([]SagaDispatchIntent, error)
+	// Renew продлевает аренду намерения до until; нулевой until снимает аренду. Отсутствие
+	// записи не ошибка.
+	Renew(id string, until time.Time) error
+}
+
+// QuotaRepository учитывает расход дневных квот principal'ов (API-ключей партнёров).
+// Реализации должны проверять и списывать квоту атомарно: параллельные CreateOrder
+// одного principal'а не могут вместе превысить лимит.
+type QuotaRepository interface {
+	// Consume проверяет квоту и учитывает заказ; при превышении возвращает *QuotaExceededError, не меняя расход.
+	Consume(principal string, day time.Time, currency string, amountMinor int64, quota OrderQuota) (QuotaUsage, error)
+	// Release откатывает Consume, если заказ не удалось сохранить.
+	Release(principal string, day time.Time, currency string, amountMinor int64) error
+	// Usage возвращает расход за сутки; отсутствие записей — нулевой расход.
+	Usage(principal string, day time.Time) (QuotaUsage, error)
 }
 
 // SagaStep задаёт константы шагов для метрик/логов.
@@ -58,6 +172,7 @@ const (
 	SagaStepRelease SagaStep = "release"
 	SagaStepCancel  SagaStep = "cancel"
 	SagaStepRefund  SagaStep = "refund"
+	SagaStepCapture SagaStep = "capture"
 )
 
 // OutboxMessage хранит данные для публикуемого события.
@@ -67,6 +182,10 @@ type OutboxMessage struct {
 	AggregateID   string
 	EventType     string
 	Payload       []byte
+	// Headers — контекст RPC, породившего событие (trace, tenant); publisher переносит их в headers сообщения.
+	Headers map[string]string
+	// CreatedAt — время постановки в outbox; репозиторий заполняет его в PullPending.
+	CreatedAt time.Time
 }
 
 // OutboxStats описывает текущее состояние backlog transactional outbox.
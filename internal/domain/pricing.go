@@ -0,0 +1,285 @@
+package domain
+
+import (
+	"fmt"
+	"math/big"
+	"strings"
+)
+
+// AdjustmentType — вид строки корректировки суммы заказа.
+type AdjustmentType string
+
+const (
+	// AdjustmentDiscount уменьшает сумму заказа.
+	AdjustmentDiscount AdjustmentType = "discount"
+	// AdjustmentTax увеличивает сумму заказа.
+	AdjustmentTax AdjustmentType = "tax"
+)
+
+const (
+	// PercentScale — масштаб процента с фиксированной точкой: 7.5% хранится как 75000.
+	PercentScale = 10_000
+	// maxPercentScaled — 100%, больше процента скидки или налога не бывает.
+	maxPercentScaled      = 100 * PercentScale
+	percentFractionDigits = 4
+
+	// RoundingDeltaScale — масштаб дельты округления: дельта хранится в миллионных долях
+	// минимальной единицы, этого хватает, чтобы процентная строка округлялась без остатка.
+	RoundingDeltaScale = 100 * PercentScale
+)
+
+// RoundingMode — правило округления процентных строк до минимальной единицы валюты.
+type RoundingMode string
+
+const (
+	// RoundingHalfUp — округление к ближайшему, половина — вверх.
+	RoundingHalfUp RoundingMode = "half_up"
+)
+
+// PriceAdjustment — скидка или налог: фиксированная сумма либо процент.
+type PriceAdjustment struct {
+	Type AdjustmentType
+	// Code — метка строки (промокод, вид налога), попадает в разбивку как есть.
+	Code string
+	// FixedMinor — фиксированная сумма в минимальных единицах; взаимоисключима с PercentScaled.
+	FixedMinor int64
+	// PercentScaled — процент, умноженный на PercentScale.
+	PercentScaled int64
+	// AppliedMinor — рассчитанная сумма строки; заполняется PriceOrder.
+	AppliedMinor int64
+	// RoundingDeltaScaled — AppliedMinor минус точное значение строки, умноженное на
+	// RoundingDeltaScale; у фиксированных строк всегда 0.
+	RoundingDeltaScaled int64
+}
+
+// IsPercent сообщает, что строка задана процентом.
+func (a PriceAdjustment) IsPercent() bool {
+	return a.PercentScaled > 0
+}
+
+// OrderPricing — разбивка итоговой суммы заказа.
+type OrderPricing struct {
+	SubtotalMinor int64
+	DiscountMinor int64
+	TaxMinor      int64
+	TotalMinor    int64
+	Adjustments   []PriceAdjustment
+	// RoundingMode — правило, по которому округлялись строки; пусто, если расчёта не было.
+	RoundingMode RoundingMode
+}
+
+// IsZero сообщает, что разбивка не задана (заказ без скидок и налогов).
+func (p OrderPricing) IsZero() bool {
+	return len(p.Adjustments) == 0 && p.SubtotalMinor == 0 && p.TotalMinor == 0
+}
+
+// RoundingDeltaScaled возвращает суммарное влияние округления на итог в единицах RoundingDeltaScale:
+// дельты налогов увеличивают итог, дельты скидок — уменьшают.
+func (p OrderPricing) RoundingDeltaScaled() int64 {
+	var delta int64
+	for _, adj := range p.Adjustments {
+		switch adj.Type {
+		case AdjustmentDiscount:
+			delta -= adj.RoundingDeltaScaled
+		case AdjustmentTax:
+			delta += adj.RoundingDeltaScaled
+		}
+	}
+	return delta
+}
+
+// PriceOrder рассчитывает итог по сумме позиций и строкам корректировок.
+// Правила детерминированы и не зависят от порядка строк внутри одного вида:
+//   - скидки считаются от subtotal, налоги — от subtotal за вычетом всех скидок (без сложного процента);
+//   - процентная строка округляется до минимальной единицы отдельно, половина — вверх;
+//   - скидки в сумме не могут превышать subtotal.
+func PriceOrder(subtotalMinor int64, adjustments []PriceAdjustment) (OrderPricing, error) {
+	if subtotalMinor < 0 {
+		return OrderPricing{}, ErrAmountNegative
+	}
+	pricing := OrderPricing{
+		SubtotalMinor: subtotalMinor,
+		Adjustments:   make([]PriceAdjustment, len(adjustments)),
+		RoundingMode:  RoundingHalfUp,
+	}
+	copy(pricing.Adjustments, adjustments)
+
+	for i := range pricing.Adjustments {
+		adj := &pricing.Adjustments[i]
+		if err := adj.validate(); err != nil {
+			return OrderPricing{}, fmt.Errorf("adjustment[%d]: %w", i, err)
+		}
+		if adj.Type != AdjustmentDiscount {
+			continue
+		}
+		adj.AppliedMinor, adj.RoundingDeltaScaled = adj.apply(subtotalMinor)
+		pricing.DiscountMinor += adj.AppliedMinor
+	}
+	if pricing.DiscountMinor > subtotalMinor {
+		return OrderPricing{}, ErrDiscountExceedsSubtotal
+	}
+
+	taxable := subtotalMinor - pricing.DiscountMinor
+	for i := range pricing.Adjustments {
+		adj := &pricing.Adjustments[i]
+		if adj.Type != AdjustmentTax {
+			continue
+		}
+		adj.AppliedMinor, adj.RoundingDeltaScaled = adj.apply(taxable)
+		pricing.TaxMinor += adj.AppliedMinor
+	}
+
+	pricing.TotalMinor = taxable + pricing.TaxMinor
+	return pricing, nil
+}
+
+func (a PriceAdjustment) validate() error {
+	switch a.Type {
+	case AdjustmentDiscount, AdjustmentTax:
+	default:
+		return fmt.Errorf("%w: unknown type %q", ErrAdjustmentInvalid, a.Type)
+	}
+	switch {
+	case a.FixedMinor < 0 || a.PercentScaled < 0:
+		return fmt.Errorf("%w: value must be non-negative", ErrAdjustmentInvalid)
+	case a.FixedMinor > 0 && a.PercentScaled > 0:
+		return fmt.Errorf("%w: fixed amount and percent are mutually exclusive", ErrAdjustmentInvalid)
+	case a.FixedMinor == 0 && a.PercentScaled == 0:
+		return fmt.Errorf("%w: fixed amount or percent is required", ErrAdjustmentInvalid)
+	case a.PercentScaled > maxPercentScaled:
+		return fmt.Errorf("%w: percent must not exceed 100", ErrAdjustmentInvalid)
+	}
+	return nil
+}
+
+// apply возвращает сумму строки от base и дельту округления. Произведение считается в big.Int,
+// чтобы крупные суммы не переполняли int64.
+func (a PriceAdjustment) apply(base int64) (applied, deltaScaled int64) {
+	if !a.IsPercent() {
+		return a.FixedMinor, 0
+	}
+	denominator := big.NewInt(RoundingDeltaScale)
+	exact := new(big.Int).Mul(big.NewInt(base), big.NewInt(a.PercentScaled))
+	value := new(big.Int).Add(exact, new(big.Int).Quo(denominator, big.NewInt(2)))
+	value.Quo(value, denominator)
+
+	delta := new(big.Int).Mul(value, denominator)
+	delta.Sub(delta, exact)
+	return value.Int64(), delta.Int64()
+}
+
+// EffectivePricing возвращает разбивку заказа; для заказа без корректировок она сводится к сумме позиций.
+func (o *Order) EffectivePricing() OrderPricing {
+	if !o.Pricing.IsZero() {
+		return o.Pricing
+	}
+	return OrderPricing{SubtotalMinor: o.AmountMinor, TotalMinor: o.AmountMinor}
+}
+
+// ItemPriceChange — изменение цены позиции при пересчёте заказа.
+type ItemPriceChange struct {
+	ItemID        string
+	SKU           string
+	OldPriceMinor int64
+	NewPriceMinor int64
+}
+
+// Reprice применяет цены позиций по SKU и пересчитывает сумму с прежними скидками и налогами.
+// Возвращает изменённые позиции; при ошибке заказ не меняется.
+func (o *Order) Reprice(prices map[string]int64) ([]ItemPriceChange, error) {
+	items := make([]OrderItem, len(o.Items))
+	copy(items, o.Items)
+
+	var (
+		changes  []ItemPriceChange
+		subtotal int64
+	)
+	for i := range items {
+		item := &items[i]
+		price, ok := prices[item.SKU]
+		if !ok {
+			return nil, fmt.Errorf("%w: %s", ErrCatalogPriceNotFound, item.SKU)
+		}
+		if price < 0 {
+			return nil, fmt.Errorf("%w: %s", ErrItemPriceInvalid, item.SKU)
+		}
+		if price != item.PriceMinor {
+			changes = append(changes, ItemPriceChange{ItemID: item.ID, SKU: item.SKU, OldPriceMinor: item.PriceMinor, NewPriceMinor: price})
+			item.PriceMinor = price
+		}
+		subtotal += int64(item.Qty) * price
+	}
+
+	pricing := OrderPricing{}
+	amount := subtotal
+	if !o.Pricing.IsZero() {
+		var err error
+		if pricing, err = PriceOrder(subtotal, o.Pricing.Adjustments); err != nil {
+			return nil, err
+		}
+		amount = pricing.TotalMinor
+	}
+
+	o.Items = items
+	o.Pricing = pricing
+	o.AmountMinor = amount
+	return changes, nil
+}
+
+// ParsePercent разбирает десятичный процент ("7.5", "20", "0.125") с точностью до
+// percentFractionDigits знаков в значение с фиксированной точкой (PercentScale).
+func ParsePercent(value string) (int64, error) {
+	value = strings.TrimSpace(value)
+	if value == "" {
+		return 0, nil
+	}
+	whole, fraction, _ := strings.Cut(value, ".")
+	if whole == "" || len(fraction) > percentFractionDigits || !isDigits(whole) || !isDigits(fraction) {
+		return 0, fmt.Errorf("%w: invalid percent %q", ErrAdjustmentInvalid, value)
+	}
+	fraction += strings.Repeat("0", percentFractionDigits-len(fraction))
+
+	var scaled int64
+	for _, r := range whole + fraction {
+		scaled = scaled*10 + int64(r-'0')
+		if scaled > maxPercentScaled {
+			return 0, fmt.Errorf("%w: percent must not exceed 100", ErrAdjustmentInvalid)
+		}
+	}
+	return scaled, nil
+}
+
+// FormatPercent возвращает процент в виде десятичной строки без лишних нулей.
+func FormatPercent(scaled int64) string {
+	whole := scaled / PercentScale
+	fraction := scaled % PercentScale
+	if fraction == 0 {
+		return fmt.Sprintf("%d", whole)
+	}
+	return strings.TrimRight(fmt.Sprintf("%d.%04d", whole, fraction), "0")
+}
+
+// FormatRoundingDelta возвращает дельту округления в минимальных единицах десятичной строкой
+// со знаком ("-0.4", "0.25", "0").
+func FormatRoundingDelta(scaled int64) string {
+	sign := ""
+	if scaled < 0 {
+		sign = "-"
+		scaled = -scaled
+	}
+	whole := scaled / RoundingDeltaScale
+	fraction := scaled % RoundingDeltaScale
+	if fraction == 0 {
+		return fmt.Sprintf("%s%d", sign, whole)
+	}
+	return sign + strings.TrimRight(fmt.Sprintf("%d.%06d", whole, fraction), "0")
+}
+
+func isDigits(value string) bool {
+	for _, r := range value {
+		if r < '0' || r > '9' {
+			return false
+		}
+	}
+	return true
+}
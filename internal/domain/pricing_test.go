@@ -0,0 +1,165 @@
+package domain_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/vladislavdragonenkov/oms/internal/domain"
+)
+
+func TestPriceOrder_DiscountsThenTaxWithHalfUpRounding(t *testing.T) {
+	pricing, err := domain.PriceOrder(1999, []domain.PriceAdjustment{
+		{Type: domain.AdjustmentTax, Code: "VAT", PercentScaled: 200_000},
+		{Type: domain.AdjustmentDiscount, Code: "PROMO10", PercentScaled: 100_000},
+		{Type: domain.AdjustmentDiscount, Code: "GIFT", FixedMinor: 100},
+	})
+	if err != nil {
+		t.Fatalf("price order: %v", err)
+	}
+
+	// 10% от 1999 = 199.9 -> 200; налог 20% от 1999-200-100=1699 = 339.8 -> 340.
+	if pricing.DiscountMinor != 300 || pricing.TaxMinor != 340 || pricing.TotalMinor != 2039 {
+		t.Fatalf("unexpected pricing: %+v", pricing)
+	}
+	if pricing.Adjustments[0].AppliedMinor != 340 || pricing.Adjustments[1].AppliedMinor != 200 {
+		t.Fatalf("unexpected applied amounts: %+v", pricing.Adjustments)
+	}
+
+	// Аудит округления: налог +0.2, скидка +0.1 (итог уменьшается на 0.1), фиксированная строка без дельты.
+	if pricing.RoundingMode != domain.RoundingHalfUp {
+		t.Fatalf("unexpected rounding mode: %q", pricing.RoundingMode)
+	}
+	deltas := []int64{pricing.Adjustments[0].RoundingDeltaScaled, pricing.Adjustments[1].RoundingDeltaScaled, pricing.Adjustments[2].RoundingDeltaScaled}
+	if deltas[0] != 200_000 || deltas[1] != 100_000 || deltas[2] != 0 {
+		t.Fatalf("unexpected rounding deltas: %v", deltas)
+	}
+	if got := pricing.RoundingDeltaScaled(); got != 100_000 {
+		t.Fatalf("unexpected net rounding delta: %d", got)
+	}
+}
+
+func TestPriceOrder_RoundsHalfUp(t *testing.T) {
+	// 2.5% от 20 = 0.5 -> 1.
+	pricing, err := domain.PriceOrder(20, []domain.PriceAdjustment{
+		{Type: domain.AdjustmentTax, PercentScaled: 25_000},
+	})
+	if err != nil {
+		t.Fatalf("price order: %v", err)
+	}
+	if pricing.TaxMinor != 1 || pricing.TotalMinor != 21 {
+		t.Fatalf("unexpected pricing: %+v", pricing)
+	}
+}
+
+func TestPriceOrder_Errors(t *testing.T) {
+	if _, err := domain.PriceOrder(100, []domain.PriceAdjustment{
+		{Type: domain.AdjustmentDiscount, FixedMinor: 101},
+	}); !errors.Is(err, domain.ErrDiscountExceedsSubtotal) {
+		t.Fatalf("expected ErrDiscountExceedsSubtotal, got %v", err)
+	}
+
+	for name, adj := range map[string]domain.PriceAdjustment{
+		"unknown type": {Type: "fee", FixedMinor: 1},
+		"both values":  {Type: domain.AdjustmentTax, FixedMinor: 1, PercentScaled: 1},
+		"no value":     {Type: domain.AdjustmentTax},
+		"over 100":     {Type: domain.AdjustmentDiscount, PercentScaled: 1_000_001},
+		"negative sum": {Type: domain.AdjustmentDiscount, FixedMinor: -1},
+	} {
+		if _, err := domain.PriceOrder(100, []domain.PriceAdjustment{adj}); !errors.Is(err, domain.ErrAdjustmentInvalid) {
+			t.Fatalf("%s: expected ErrAdjustmentInvalid, got %v", name, err)
+		}
+	}
+}
+
+func TestOrderValidateInvariants_WithPricing(t *testing.T) {
+	order := makeOrder()
+	pricing, err := domain.PriceOrder(order.AmountMinor, []domain.PriceAdjustment{
+		{Type: domain.AdjustmentDiscount, FixedMinor: 50},
+	})
+	if err != nil {
+		t.Fatalf("price order: %v", err)
+	}
+	order.Pricing = pricing
+	order.AmountMinor = pricing.TotalMinor
+	if errs := order.ValidateInvariants(); len(errs) != 0 {
+		t.Fatalf("expected valid order, got %v", errs)
+	}
+
+	order.AmountMinor = pricing.SubtotalMinor
+	if errs := order.ValidateInvariants(); len(errs) != 1 || !errors.Is(errs[0], domain.ErrAmountMismatch) {
+		t.Fatalf("expected amount mismatch, got %v", errs)
+	}
+}
+
+func TestOrderReprice(t *testing.T) {
+	items := []domain.OrderItem{{ID: "i-1", SKU: "A", Qty: 2, PriceMinor: 500}, {ID: "i-2", SKU: "B", Qty: 1, PriceMinor: 300}}
+	pricing, err := domain.PriceOrder(1300, []domain.PriceAdjustment{{Type: domain.AdjustmentTax, Code: "VAT", PercentScaled: 200_000}})
+	if err != nil {
+		t.Fatalf("price order: %v", err)
+	}
+	order := domain.Order{CustomerID: "c-1", Currency: "RUB", Items: items, AmountMinor: pricing.TotalMinor, Pricing: pricing}
+
+	changes, err := order.Reprice(map[string]int64{"A": 450, "B": 300})
+	if err != nil {
+		t.Fatalf("reprice: %v", err)
+	}
+	if len(changes) != 1 || changes[0] != (domain.ItemPriceChange{ItemID: "i-1", SKU: "A", OldPriceMinor: 500, NewPriceMinor: 450}) {
+		t.Fatalf("unexpected changes: %+v", changes)
+	}
+	// 2*450+300 = 1200, налог 20% = 240.
+	if order.AmountMinor != 1440 || order.Pricing.SubtotalMinor != 1200 || order.Pricing.TaxMinor != 240 {
+		t.Fatalf("unexpected repriced order: amount=%d pricing=%+v", order.AmountMinor, order.Pricing)
+	}
+	if items[0].PriceMinor != 500 {
+		t.Fatal("reprice must not mutate the original items slice")
+	}
+	if errs := order.ValidateInvariants(); len(errs) > 0 {
+		t.Fatalf("unexpected invariant errors: %v", errs)
+	}
+}
+
+func TestOrderReprice_Errors(t *testing.T) {
+	order := domain.Order{
+		Items:       []domain.OrderItem{{ID: "i-1", SKU: "A", Qty: 1, PriceMinor: 500}},
+		AmountMinor: 400,
+		Pricing: domain.OrderPricing{
+			SubtotalMinor: 500, DiscountMinor: 100, TotalMinor: 400,
+			Adjustments: []domain.PriceAdjustment{{Type: domain.AdjustmentDiscount, FixedMinor: 100, AppliedMinor: 100}},
+		},
+	}
+
+	if _, err := order.Reprice(map[string]int64{}); !errors.Is(err, domain.ErrCatalogPriceNotFound) {
+		t.Fatalf("expected ErrCatalogPriceNotFound, got %v", err)
+	}
+	if _, err := order.Reprice(map[string]int64{"A": 50}); !errors.Is(err, domain.ErrDiscountExceedsSubtotal) {
+		t.Fatalf("expected ErrDiscountExceedsSubtotal, got %v", err)
+	}
+	if order.AmountMinor != 400 || order.Items[0].PriceMinor != 500 {
+		t.Fatalf("failed reprice must not change the order: %+v", order)
+	}
+}
+
+func TestParseFormatPercent(t *testing.T) {
+	for input, want := range map[string]int64{"7.5": 75_000, "20": 200_000, "0.125": 1_250, "100": 1_000_000} {
+		got, err := domain.ParsePercent(input)
+		if err != nil || got != want {
+			t.Fatalf("ParsePercent(%q) = %d, %v; want %d", input, got, err, want)
+		}
+		if formatted := domain.FormatPercent(got); formatted != input {
+			t.Fatalf("FormatPercent(%d) = %q, want %q", got, formatted, input)
+		}
+	}
+	for _, input := range []string{"-1", "1.23456", "abc", ".5", "100.01"} {
+		if _, err := domain.ParsePercent(input); !errors.Is(err, domain.ErrAdjustmentInvalid) {
+			t.Fatalf("ParsePercent(%q): expected ErrAdjustmentInvalid, got %v", input, err)
+		}
+	}
+}
+
+func TestFormatRoundingDelta(t *testing.T) {
+	for scaled, want := range map[int64]string{0: "0", 250_000: "0.25", -400_000: "-0.4", 1_500_000: "1.5", -1: "-0.000001"} {
+		if got := domain.FormatRoundingDelta(scaled); got != want {
+			t.Fatalf("FormatRoundingDelta(%d) = %q, want %q", scaled, got, want)
+		}
+	}
+}
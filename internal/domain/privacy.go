@@ -0,0 +1,26 @@
+package domain
+
+import "errors"
+
+// ErrCustomerDataNotFound — у клиента нет данных для обезличивания.
+var ErrCustomerDataNotFound = errors.New("customer data not found")
+
+// CustomerErasure описывает результат обезличивания данных клиента.
+type CustomerErasure struct {
+	// OrderIDs — заказы, в которых customer_id заменён псевдонимом.
+	OrderIDs []string
+	// TimelineEventsScrubbed — события timeline, у которых очищен свободный текст (reason).
+	TimelineEventsScrubbed int
+	// IdempotencyRecordsScrubbed — idempotency-записи, из которых удалены закешированные ответы с данными клиента.
+	IdempotencyRecordsScrubbed int
+	// OutboxMessagesScrubbed — outbox-сообщения заказов, в payload которых customer_id заменён псевдонимом.
+	OutboxMessagesScrubbed int
+}
+
+// CustomerDataEraser обезличивает персональные данные клиента во всех таблицах хранилища (GDPR).
+// Реализация должна заменить customer_id на pseudonym во всех заказах клиента, очистить reason
+// в их timeline, сбросить сохранённые idempotency-ответы, содержащие customer_id, и заменить
+// customer_id псевдонимом в outbox-сообщениях этих заказов (ещё не отправленных и хранимых после отправки).
+type CustomerDataEraser interface {
+	EraseCustomerData(customerID, pseudonym string) (CustomerErasure, error)
+}
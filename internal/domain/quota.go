@@ -0,0 +1,83 @@
+package domain
+
+import (
+	"fmt"
+	"time"
+)
+
+// Лимиты квоты, попадающие в QuotaExceededError.Limit.
+const (
+	QuotaLimitOrders = "orders"
+	QuotaLimitAmount = "amount"
+)
+
+// OrderQuota — дневные лимиты одного principal'а. Нулевой лимит — без ограничения.
+type OrderQuota struct {
+	MaxOrders int64
+	// MaxAmountMinor — лимит суммы заказов за сутки по валютам; валюта без записи не ограничена.
+	MaxAmountMinor map[string]int64
+}
+
+// QuotaUsage — расход квоты principal'а за UTC-сутки.
+type QuotaUsage struct {
+	Principal   string
+	Day         time.Time
+	Orders      int64
+	AmountMinor map[string]int64
+}
+
+// QuotaDay возвращает начало UTC-суток, за которые считается квота.
+func QuotaDay(t time.Time) time.Time {
+	return t.UTC().Truncate(24 * time.Hour)
+}
+
+// Check проверяет, помещается ли ещё один заказ в квоту при текущем расходе.
+func (q OrderQuota) Check(usage QuotaUsage, currency string, amountMinor int64) error {
+	if q.MaxOrders > 0 && usage.Orders+1 > q.MaxOrders {
+		return &QuotaExceededError{
+			Principal: usage.Principal,
+			Limit:     QuotaLimitOrders,
+			Used:      usage.Orders,
+			Requested: 1,
+			Max:       q.MaxOrders,
+		}
+	}
+	if limit := q.MaxAmountMinor[currency]; limit > 0 {
+		used := usage.AmountMinor[currency]
+		if used+amountMinor > limit {
+			return &QuotaExceededError{
+				Principal: usage.Principal,
+				Limit:     QuotaLimitAmount,
+				Currency:  currency,
+				Used:      used,
+				Requested: amountMinor,
+				Max:       limit,
+			}
+		}
+	}
+	return nil
+}
+
+// QuotaExceededError описывает превышенный лимит; errors.Is(err, ErrQuotaExceeded) возвращает true.
+type QuotaExceededError struct {
+	Principal string
+	// Limit — QuotaLimitOrders или QuotaLimitAmount.
+	Limit string
+	// Currency заполняется для лимита суммы.
+	Currency  string
+	Used      int64
+	Requested int64
+	Max       int64
+}
+
+func (e *QuotaExceededError) Error() string {
+	if e.Limit == QuotaLimitAmount {
+		return fmt.Sprintf("daily amount quota exceeded for principal %s: used %d of %d %s, order requires %d",
+			e.Principal, e.Used, e.Max, e.Currency, e.Requested)
+	}
+	return fmt.Sprintf("daily orders quota exceeded for principal %s: used %d of %d orders", e.Principal, e.Used, e.Max)
+}
+
+func (e *QuotaExceededError) Unwrap() error {
+	return ErrQuotaExceeded
+}
@@ -8,6 +8,46 @@ type OrderRepository interface {
 	Get(id string) (Order, error)
 	// ListByCustomer возвращает заказы клиента с опциональным ограничением на количество.
 	ListByCustomer(customerID string, limit int) ([]Order, error)
+	// ListByStatus возвращает заказы в указанном статусе (от старых к новым) с опциональным лимитом.
+	ListByStatus(status OrderStatus, limit int) ([]Order, error)
 	// Save применяет обновления к заказу с учётом optimistic locking.
 	Save(order Order) error
+	// UpdateStatusCAS атомарно переводит заказ из from в to, если его версия равна expectedVersion,
+	// и возвращает новую версию. При выходе из on_hold причина hold'а очищается.
+	// Несовпадение статуса или версии — ErrOrderVersionConflict.
+	UpdateStatusCAS(orderID string, from, to OrderStatus, expectedVersion int64) (int64, error)
+	// Delete удаляет заказ вместе с позициями. Возвращает ErrOrderNotFound, если заказа нет.
+	Delete(id string) error
+}
+
+// OrderScanner — необязательное расширение OrderRepository для полного обхода заказов
+// (фоновые проверки инвариантов). Заказы отдаются по возрастанию ID, начиная после afterID.
+type OrderScanner interface {
+	ListAfter(afterID string, limit int) ([]Order, error)
+}
+
+// OrderPriceUpdater — необязательное расширение OrderRepository для пересчёта заказа:
+// в отличие от Save, сохраняет цены позиций и разбивку суммы. Версия сверяется так же, как в Save.
+type OrderPriceUpdater interface {
+	UpdatePrices(order Order) error
+}
+
+// OrderItemsUpdater — необязательное расширение OrderRepository для правки состава заказа (UpdateOrder):
+// в отличие от Save, заменяет набор позиций (добавленные, удалённые, с новым количеством) вместе
+// с суммой и разбивкой. Версия сверяется так же, как в Save.
+type OrderItemsUpdater interface {
+	UpdateItems(order Order) error
+}
+
+// OrderStreamer — необязательное расширение OrderRepository: отдаёт заказы клиента в fn по одному
+// (в порядке ListByCustomer), не собирая всю выборку в памяти. Ошибка fn прерывает обход.
+type OrderStreamer interface {
+	StreamByCustomer(customerID string, limit int, fn func(Order) error) error
+}
+
+// OrderBatchReader — необязательное расширение OrderRepository: читает заказы по списку ID за одно
+// обращение к хранилищу вместо Get на каждый. Отсутствующие ID пропускаются без ошибки; заказы
+// отдаются по возрастанию ID.
+type OrderBatchReader interface {
+	GetMany(ids []string) ([]Order, error)
 }
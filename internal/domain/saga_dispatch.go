@@ -0,0 +1,27 @@
+package domain
+
+import "time"
+
+// SagaOperation — действие саги, запрошенное через RPC.
+type SagaOperation string
+
+const (
+	SagaOperationStart  SagaOperation = "start"
+	SagaOperationCancel SagaOperation = "cancel"
+	SagaOperationRefund SagaOperation = "refund"
+	// SagaOperationCapture списывает сумму, заблокированную при двухфазной оплате (CaptureOrder).
+	SagaOperationCapture SagaOperation = "capture"
+)
+
+// SagaDispatchIntent — намерение запустить сагу, сохранённое до ответа на RPC.
+// Запись живёт до завершения саги; незавершённые намерения повторяются при старте сервиса.
+type SagaDispatchIntent struct {
+	ID          string
+	OrderID     string
+	Operation   SagaOperation
+	Reason      string
+	AmountMinor int64
+	RequestedAt time.Time
+	// ClaimedUntil — до какого момента намерение закреплено за выполняющей его репликой.
+	ClaimedUntil time.Time
+}
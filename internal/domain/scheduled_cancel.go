@@ -0,0 +1,63 @@
+package domain
+
+import (
+	"errors"
+	"time"
+)
+
+// ScheduledCancelStatus — состояние отложенной отмены заказа.
+type ScheduledCancelStatus string
+
+const (
+	// ScheduledCancelPending — срок ещё не наступил.
+	ScheduledCancelPending ScheduledCancelStatus = "pending"
+	// ScheduledCancelExecuted — к сроку запущена сага отмены.
+	ScheduledCancelExecuted ScheduledCancelStatus = "executed"
+	// ScheduledCancelSkipped — к сроку заказ уже подтверждён, отменён или удалён; отменять нечего.
+	ScheduledCancelSkipped ScheduledCancelStatus = "skipped"
+	// ScheduledCancelCanceled — задачу сняли до срока.
+	ScheduledCancelCanceled ScheduledCancelStatus = "canceled"
+)
+
+var (
+	// ErrScheduledCancelNotFound — задачи с таким ID нет.
+	ErrScheduledCancelNotFound = errors.New("scheduled cancel not found")
+	// ErrScheduledCancelNotPending — задача уже выполнена, пропущена или снята.
+	ErrScheduledCancelNotPending = errors.New("scheduled cancel is not pending")
+)
+
+// ScheduledCancel — отмена заказа, запланированная на момент CancelAt
+// ("отменить, если не отгружен до пятницы").
+type ScheduledCancel struct {
+	ID       string
+	OrderID  string
+	CancelAt time.Time
+	Reason   string
+	Status   ScheduledCancelStatus
+	// Note поясняет итог для skipped (например, "order already confirmed").
+	Note      string
+	CreatedAt time.Time
+	UpdatedAt time.Time
+}
+
+// SkipsScheduledCancel сообщает, что к сроку отложенной отмены заказ в этом статусе
+// отменять уже не нужно: он подтверждён к исполнению или уже отменён/возвращён.
+func (s OrderStatus) SkipsScheduledCancel() bool {
+	return s == OrderStatusConfirmed || s == OrderStatusCanceled || s == OrderStatusRefunded
+}
+
+// ScheduledCancelRepository хранит отложенные отмены заказов.
+type ScheduledCancelRepository interface {
+	// Create сохраняет задачу в статусе pending; пустой ID генерируется.
+	Create(task ScheduledCancel) (ScheduledCancel, error)
+	// Get возвращает задачу или ErrScheduledCancelNotFound.
+	Get(id string) (ScheduledCancel, error)
+	// ListByOrder возвращает задачи заказа по возрастанию CancelAt.
+	ListByOrder(orderID string) ([]ScheduledCancel, error)
+	// ListDue забирает pending-задачи с CancelAt <= now, начиная с самых ранних; limit <= 0 — без ограничения.
+	// Забранные задачи закрепляются арендой: до её истечения повторные вызовы, в том числе
+	// с других реплик, их не возвращают, поэтому одну отмену не выполняют дважды.
+	ListDue(now time.Time, limit int) ([]ScheduledCancel, error)
+	// Finish переводит pending-задачу в итоговый статус; для остальных — ErrScheduledCancelNotPending.
+	Finish(id string, status ScheduledCancelStatus, note string) (ScheduledCancel, error)
+}
@@ -0,0 +1,254 @@
+// Package featureflags хранит типизированные фичефлаги сервиса.
+// Значение флага складывается по приоритету: дефолт из кода → build-time дефолты
+// (-ldflags) → конфиг/env → runtime-переключение (только для dynamic-флагов).
+package featureflags
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+
+	"github.com/vladislavdragonenkov/oms/internal/metrics"
+)
+
+// Flag — имя фичефлага.
+type Flag string
+
+const (
+	// KafkaEnabled разрешает подключение к Kafka, если заданы KAFKA_BROKERS.
+	KafkaEnabled Flag = "kafka_enabled"
+	// ReadCache отдаёт ListOrders из кэша списков, если он настроен (OMS_ORDER_LIST_CACHE_TTL).
+	// Выключение — аварийный обход кэша без рестарта.
+	ReadCache Flag = "read_cache"
+	// Shedding отклоняет запросы сверх лимитов OMS_GRPC_CONCURRENCY_LIMITS; выключенный флаг
+	// снимает лимиты без рестарта.
+	Shedding Flag = "shedding"
+	// Backorders переводит заказ в backordered вместо отмены, если на складе нет товара.
+	Backorders Flag = "backorders"
+	// PaymentEvents включает consumer статусов платежей от PSP (топик payments.events).
+	PaymentEvents Flag = "payment_events"
+)
+
+// Источники значения флага.
+const (
+	SourceDefault = "default"
+	SourceBuild   = "build"
+	SourceConfig  = "config"
+	SourceRuntime = "runtime"
+)
+
+var (
+	// ErrUnknownFlag — флаг не объявлен в Definitions.
+	ErrUnknownFlag = errors.New("featureflags: unknown flag")
+	// ErrNotDynamic — флаг нельзя переключить без рестарта.
+	ErrNotDynamic = errors.New("featureflags: flag is not dynamic")
+)
+
+// buildDefaults задаётся на этапе сборки:
+// -ldflags "-X github.com/vladislavdragonenkov/oms/internal/featureflags.buildDefaults=backorders=true".
+var buildDefaults = ""
+
+func newFlagEnabledGauge(registerer prometheus.Registerer) *prometheus.GaugeVec {
+	return metrics.Register(registerer, prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "oms_feature_flag_enabled",
+		Help: "Current value of feature flags (1 - enabled, 0 - disabled).",
+	}, []string{"flag"}))
+}
+
+// Definition описывает флаг: дефолт и можно ли переключать его на лету.
+type Definition struct {
+	Name        Flag
+	Description string
+	Default     bool
+	Dynamic     bool
+}
+
+// Definitions — все флаги сервиса.
+var Definitions = []Definition{
+	{Name: KafkaEnabled, Description: "Connect to Kafka when KAFKA_BROKERS is set", Default: true},
+	{Name: ReadCache, Description: "Serve ListOrders from the order list cache when it is configured", Default: true, Dynamic: true},
+	{Name: Shedding, Description: "Reject gRPC requests above the configured concurrency limits", Default: true, Dynamic: true},
+	{Name: Backorders, Description: "Backorder orders on insufficient stock and resume them on restock", Default: false},
+	{Name: PaymentEvents, Description: "Advance or compensate sagas from PSP payment status events", Default: false},
+}
+
+// State — текущее значение флага вместе с его происхождением.
+type State struct {
+	Name        Flag      `json:"name"`
+	Description string    `json:"description"`
+	Enabled     bool      `json:"enabled"`
+	Dynamic     bool      `json:"dynamic"`
+	Source      string    `json:"source"`
+	UpdatedAt   time.Time `json:"updated_at"`
+}
+
+// Registry — потокобезопасный реестр значений флагов.
+type Registry struct {
+	mu     sync.RWMutex
+	states map[Flag]State
+
+	registerer prometheus.Registerer
+	enabled    *prometheus.GaugeVec
+}
+
+// Option настраивает Registry.
+type Option func(*Registry)
+
+// WithRegisterer задаёт реестр Prometheus для метрики oms_feature_flag_enabled.
+func WithRegisterer(registerer prometheus.Registerer) Option {
+	return func(r *Registry) {
+		r.registerer = registerer
+	}
+}
+
+// New создаёт реестр с дефолтами, build-time значениями и переопределениями из конфига.
+// Некорректная строка build-time дефолтов игнорируется целиком.
+func New(overrides map[Flag]bool, options ...Option) (*Registry, error) {
+	now := time.Now().UTC()
+	r := &Registry{states: make(map[Flag]State, len(Definitions))}
+	for _, option := range options {
+		option(r)
+	}
+	r.enabled = newFlagEnabledGauge(r.registerer)
+	for _, def := range Definitions {
+		r.states[def.Name] = State{
+			Name:        def.Name,
+			Description: def.Description,
+			Enabled:     def.Default,
+			Dynamic:     def.Dynamic,
+			Source:      SourceDefault,
+			UpdatedAt:   now,
+		}
+	}
+
+	if build, err := Parse(buildDefaults); err == nil {
+		r.apply(build, SourceBuild, now)
+	}
+	for name := range overrides {
+		if _, ok := r.states[name]; !ok {
+			return nil, fmt.Errorf("%w: %s", ErrUnknownFlag, name)
+		}
+	}
+	r.apply(overrides, SourceConfig, now)
+
+	for _, state := range r.states {
+		r.observe(state)
+	}
+	return r, nil
+}
+
+func (r *Registry) apply(values map[Flag]bool, source string, now time.Time) {
+	for name, enabled := range values {
+		state, ok := r.states[name]
+		if !ok {
+			continue
+		}
+		state.Enabled = enabled
+		state.Source = source
+		state.UpdatedAt = now
+		r.states[name] = state
+	}
+}
+
+// Enabled возвращает значение флага; неизвестный флаг и nil-реестр считаются выключенными.
+func (r *Registry) Enabled(name Flag) bool {
+	if r == nil {
+		return false
+	}
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	return r.states[name].Enabled
+}
+
+// Set переключает dynamic-флаг во время работы и возвращает предыдущее значение.
+func (r *Registry) Set(name Flag, enabled bool) (bool, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	state, ok := r.states[name]
+	if !ok {
+		return false, fmt.Errorf("%w: %s", ErrUnknownFlag, name)
+	}
+	if !state.Dynamic {
+		return state.Enabled, fmt.Errorf("%w: %s", ErrNotDynamic, name)
+	}
+
+	previous := state.Enabled
+	state.Enabled = enabled
+	state.Source = SourceRuntime
+	state.UpdatedAt = time.Now().UTC()
+	r.states[name] = state
+	r.observe(state)
+	return previous, nil
+}
+
+// Snapshot возвращает состояние всех флагов, отсортированное по имени.
+func (r *Registry) Snapshot() []State {
+	r.mu.RLock()
+	states := make([]State, 0, len(r.states))
+	for _, state := range r.states {
+		states = append(states, state)
+	}
+	r.mu.RUnlock()
+
+	sort.Slice(states, func(i, j int) bool { return states[i].Name < states[j].Name })
+	return states
+}
+
+// Parse разбирает список вида "read_cache=true,shedding=off" и отклоняет неизвестные флаги.
+func Parse(raw string) (map[Flag]bool, error) {
+	values := make(map[Flag]bool)
+	for _, part := range strings.Split(raw, ",") {
+		part = strings.TrimSpace(part)
+		if part == "" {
+			continue
+		}
+		name, value, found := strings.Cut(part, "=")
+		name = strings.ToLower(strings.TrimSpace(name))
+		if !found || name == "" {
+			return nil, fmt.Errorf("featureflags: expected name=value, got %q", part)
+		}
+		if _, ok := lookupDefinition(Flag(name)); !ok {
+			return nil, fmt.Errorf("%w: %s", ErrUnknownFlag, name)
+		}
+		enabled, err := parseBool(value)
+		if err != nil {
+			return nil, fmt.Errorf("featureflags: flag %s: %w", name, err)
+		}
+		values[Flag(name)] = enabled
+	}
+	return values, nil
+}
+
+func lookupDefinition(name Flag) (Definition, bool) {
+	for _, def := range Definitions {
+		if def.Name == name {
+			return def, true
+		}
+	}
+	return Definition{}, false
+}
+
+func parseBool(value string) (bool, error) {
+	switch strings.ToLower(strings.TrimSpace(value)) {
+	case "1", "true", "yes", "on":
+		return true, nil
+	case "0", "false", "no", "off":
+		return false, nil
+	default:
+		return false, fmt.Errorf("invalid boolean value %q", value)
+	}
+}
+
+func (r *Registry) observe(state State) {
+	value := 0.0
+	if state.Enabled {
+		value = 1
+	}
+	r.enabled.WithLabelValues(string(state.Name)).Set(value)
+}
@@ -0,0 +1,89 @@
+package featureflags
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestNew_AppliesDefaultsBuildAndConfig(t *testing.T) {
+	old := buildDefaults
+	buildDefaults = "backorders=true,read_cache=true"
+	defer func() { buildDefaults = old }()
+
+	registry, err := New(map[Flag]bool{ReadCache: false, KafkaEnabled: false})
+	if err != nil {
+		t.Fatalf("New failed: %v", err)
+	}
+
+	want := map[Flag]struct {
+		enabled bool
+		source  string
+	}{
+		KafkaEnabled:  {false, SourceConfig},
+		ReadCache:     {false, SourceConfig},
+		Shedding:      {true, SourceDefault},
+		Backorders:    {true, SourceBuild},
+		PaymentEvents: {false, SourceDefault},
+	}
+	snapshot := registry.Snapshot()
+	if len(snapshot) != len(want) {
+		t.Fatalf("unexpected snapshot size: %d", len(snapshot))
+	}
+	for _, state := range snapshot {
+		if w := want[state.Name]; state.Enabled != w.enabled || state.Source != w.source {
+			t.Fatalf("unexpected state for %s: %+v", state.Name, state)
+		}
+	}
+}
+
+func TestNew_RejectsUnknownOverride(t *testing.T) {
+	if _, err := New(map[Flag]bool{"missing": true}); !errors.Is(err, ErrUnknownFlag) {
+		t.Fatalf("expected ErrUnknownFlag, got %v", err)
+	}
+}
+
+func TestRegistry_SetOnlyDynamic(t *testing.T) {
+	registry, err := New(nil)
+	if err != nil {
+		t.Fatalf("New failed: %v", err)
+	}
+
+	previous, err := registry.Set(Shedding, false)
+	if err != nil || !previous {
+		t.Fatalf("unexpected toggle result: previous=%v err=%v", previous, err)
+	}
+	if registry.Enabled(Shedding) {
+		t.Fatal("expected shedding to be disabled")
+	}
+
+	if _, err := registry.Set(KafkaEnabled, false); !errors.Is(err, ErrNotDynamic) {
+		t.Fatalf("expected ErrNotDynamic, got %v", err)
+	}
+	if !registry.Enabled(KafkaEnabled) {
+		t.Fatal("static flag must keep its value")
+	}
+	if _, err := registry.Set("missing", true); !errors.Is(err, ErrUnknownFlag) {
+		t.Fatalf("expected ErrUnknownFlag, got %v", err)
+	}
+
+	var nilRegistry *Registry
+	if nilRegistry.Enabled(KafkaEnabled) {
+		t.Fatal("nil registry must report flags as disabled")
+	}
+}
+
+func TestParse(t *testing.T) {
+	values, err := Parse(" Read_Cache = on , shedding=0,, ")
+	if err != nil {
+		t.Fatalf("Parse failed: %v", err)
+	}
+	if len(values) != 2 || !values[ReadCache] || values[Shedding] {
+		t.Fatalf("unexpected values: %v", values)
+	}
+
+	for _, raw := range []string{"read_cache", "read_cache=maybe", "=true", "unknown=true"} {
+		if _, err := Parse(raw); err == nil {
+			t.Fatalf("expected error for %q", raw)
+		}
+	}
+}
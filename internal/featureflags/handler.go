@@ -0,0 +1,63 @@
+package featureflags
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+
+	log "github.com/sirupsen/logrus"
+)
+
+// toggleRequest — тело POST-запроса на переключение флага.
+type toggleRequest struct {
+	Name    Flag  `json:"name"`
+	Enabled *bool `json:"enabled"`
+}
+
+// Handler отдаёт состояние флагов (GET) и переключает dynamic-флаги (POST {"name":..., "enabled":...}).
+func Handler(registry *Registry, logger *log.Entry) http.Handler {
+	if logger == nil {
+		logger = log.WithField("component", "featureflags")
+	}
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method {
+		case http.MethodGet:
+			writeJSON(w, http.StatusOK, registry.Snapshot())
+		case http.MethodPost:
+			var req toggleRequest
+			if err := json.NewDecoder(http.MaxBytesReader(w, r.Body, 4096)).Decode(&req); err != nil || req.Name == "" || req.Enabled == nil {
+				writeError(w, http.StatusBadRequest, "expected JSON body {\"name\": string, \"enabled\": bool}")
+				return
+			}
+			previous, err := registry.Set(req.Name, *req.Enabled)
+			switch {
+			case errors.Is(err, ErrUnknownFlag):
+				writeError(w, http.StatusNotFound, err.Error())
+				return
+			case errors.Is(err, ErrNotDynamic):
+				writeError(w, http.StatusConflict, err.Error())
+				return
+			}
+			logger.WithFields(log.Fields{
+				"flag":     req.Name,
+				"enabled":  *req.Enabled,
+				"previous": previous,
+				"remote":   r.RemoteAddr,
+			}).Warn("feature flag toggled at runtime")
+			writeJSON(w, http.StatusOK, registry.Snapshot())
+		default:
+			w.Header().Set("Allow", "GET, POST")
+			writeError(w, http.StatusMethodNotAllowed, "method not allowed")
+		}
+	})
+}
+
+func writeJSON(w http.ResponseWriter, status int, body any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(body)
+}
+
+func writeError(w http.ResponseWriter, status int, message string) {
+	writeJSON(w, status, map[string]string{"error": message})
+}
@@ -0,0 +1,57 @@
+package featureflags
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestHandler_ListAndToggle(t *testing.T) {
+	registry, err := New(nil)
+	if err != nil {
+		t.Fatalf("New failed: %v", err)
+	}
+	handler := Handler(registry, nil)
+
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/admin/featureflags", nil))
+	if rec.Code != http.StatusOK {
+		t.Fatalf("unexpected list status: %d", rec.Code)
+	}
+	var states []State
+	if err := json.NewDecoder(rec.Body).Decode(&states); err != nil {
+		t.Fatalf("decode list: %v", err)
+	}
+	if len(states) != len(Definitions) {
+		t.Fatalf("expected %d flags, got %d", len(Definitions), len(states))
+	}
+
+	cases := []struct {
+		body string
+		code int
+	}{
+		{`{"name":"read_cache","enabled":false}`, http.StatusOK},
+		{`{"name":"kafka_enabled","enabled":false}`, http.StatusConflict},
+		{`{"name":"missing","enabled":true}`, http.StatusNotFound},
+		{`{"name":"read_cache"}`, http.StatusBadRequest},
+		{`not-json`, http.StatusBadRequest},
+	}
+	for _, tc := range cases {
+		rec := httptest.NewRecorder()
+		handler.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/admin/featureflags", strings.NewReader(tc.body)))
+		if rec.Code != tc.code {
+			t.Fatalf("body %s: expected status %d, got %d", tc.body, tc.code, rec.Code)
+		}
+	}
+	if registry.Enabled(ReadCache) {
+		t.Fatal("expected read_cache to be toggled off")
+	}
+
+	rec = httptest.NewRecorder()
+	handler.ServeHTTP(rec, httptest.NewRequest(http.MethodDelete, "/admin/featureflags", nil))
+	if rec.Code != http.StatusMethodNotAllowed {
+		t.Fatalf("unexpected status for DELETE: %d", rec.Code)
+	}
+}
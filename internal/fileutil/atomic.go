@@ -0,0 +1,134 @@
+// Package fileutil пишет файлы, которые читают другие процессы и автоматизация: отчёты,
+// журналы replay, снимки, golden-файлы. Запись атомарна: читатель видит либо прежний файл,
+// либо новый целиком, но не обрезанный JSON после падения посреди записи.
+package fileutil
+
+import (
+	"bufio"
+	"compress/gzip"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// GzipSuffix — расширение, по которому вызывающие включают сжатие (см. WithGzip).
+const GzipSuffix = ".gz"
+
+const defaultPerm os.FileMode = 0o600
+
+type writeOptions struct {
+	perm os.FileMode
+	gzip bool
+}
+
+// WriteOption настраивает WriteAtomic.
+type WriteOption func(*writeOptions)
+
+// WithPerm задаёт права итогового файла; по умолчанию 0600.
+func WithPerm(perm os.FileMode) WriteOption {
+	return func(opts *writeOptions) {
+		opts.perm = perm
+	}
+}
+
+// WithGzip сжимает содержимое gzip'ом, если enabled. Имя файла не меняется: вызывающий
+// обычно передаёт strings.HasSuffix(path, GzipSuffix), см. HasGzipSuffix.
+func WithGzip(enabled bool) WriteOption {
+	return func(opts *writeOptions) {
+		opts.gzip = enabled
+	}
+}
+
+// HasGzipSuffix сообщает, что path заканчивается на .gz.
+func HasGzipSuffix(path string) bool {
+	return strings.HasSuffix(path, GzipSuffix)
+}
+
+// WriteAtomic пишет содержимое через write во временный файл в каталоге path, делает fsync,
+// переименовывает его в path и синхронизирует каталог. При любой ошибке path не меняется,
+// временный файл удаляется.
+func WriteAtomic(path string, write func(io.Writer) error, options ...WriteOption) error {
+	opts := writeOptions{perm: defaultPerm}
+	for _, option := range options {
+		option(&opts)
+	}
+
+	dir := filepath.Dir(path)
+	tmp, err := os.CreateTemp(dir, filepath.Base(path)+".tmp-*")
+	if err != nil {
+		return fmt.Errorf("create temp file for %s: %w", path, err)
+	}
+	tmpName := tmp.Name()
+	defer func() { _ = os.Remove(tmpName) }()
+
+	if err := writeContent(tmp, write, opts.gzip); err != nil {
+		_ = tmp.Close()
+		return fmt.Errorf("write %s: %w", path, err)
+	}
+	if err := tmp.Chmod(opts.perm); err != nil {
+		_ = tmp.Close()
+		return fmt.Errorf("chmod %s: %w", path, err)
+	}
+	if err := tmp.Sync(); err != nil {
+		_ = tmp.Close()
+		return fmt.Errorf("sync %s: %w", path, err)
+	}
+	if err := tmp.Close(); err != nil {
+		return fmt.Errorf("close %s: %w", path, err)
+	}
+	if err := os.Rename(tmpName, path); err != nil {
+		return fmt.Errorf("replace %s: %w", path, err)
+	}
+	syncDir(dir)
+	return nil
+}
+
+// WriteFileAtomic — WriteAtomic для готовых байтов.
+func WriteFileAtomic(path string, data []byte, options ...WriteOption) error {
+	return WriteAtomic(path, func(w io.Writer) error {
+		_, err := w.Write(data)
+		return err
+	}, options...)
+}
+
+// WriteJSONAtomic пишет value как JSON с отступом в два пробела и переводом строки в конце.
+func WriteJSONAtomic(path string, value any, options ...WriteOption) error {
+	return WriteAtomic(path, func(w io.Writer) error {
+		encoder := json.NewEncoder(w)
+		encoder.SetIndent("", "  ")
+		return encoder.Encode(value)
+	}, options...)
+}
+
+func writeContent(file *os.File, write func(io.Writer) error, compress bool) error {
+	buffered := bufio.NewWriter(file)
+	if !compress {
+		if err := write(buffered); err != nil {
+			return err
+		}
+		return buffered.Flush()
+	}
+
+	zw := gzip.NewWriter(buffered)
+	if err := write(zw); err != nil {
+		return err
+	}
+	if err := zw.Close(); err != nil {
+		return err
+	}
+	return buffered.Flush()
+}
+
+// syncDir фиксирует rename на диске. Ошибка не возвращается: не все ФС поддерживают fsync
+// каталога, а сам файл к этому моменту уже записан и заменён.
+func syncDir(dir string) {
+	d, err := os.Open(dir) // #nosec G304 -- каталог целевого файла, выбранного вызывающим.
+	if err != nil {
+		return
+	}
+	_ = d.Sync()
+	_ = d.Close()
+}
@@ -0,0 +1,104 @@
+package fileutil
+
+import (
+	"compress/gzip"
+	"errors"
+	"io"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestWriteAtomic_ReplacesFile(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "report.json")
+	if err := os.WriteFile(path, []byte("old"), 0o600); err != nil {
+		t.Fatalf("WriteFile failed: %v", err)
+	}
+
+	if err := WriteJSONAtomic(path, map[string]int{"total": 3}, WithPerm(0o644)); err != nil {
+		t.Fatalf("WriteJSONAtomic failed: %v", err)
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("ReadFile failed: %v", err)
+	}
+	if string(data) != "{\n  \"total\": 3\n}\n" {
+		t.Fatalf("unexpected content %q", data)
+	}
+	info, err := os.Stat(path)
+	if err != nil {
+		t.Fatalf("Stat failed: %v", err)
+	}
+	if info.Mode().Perm() != 0o644 {
+		t.Fatalf("expected mode 0644, got %v", info.Mode().Perm())
+	}
+	requireNoTempFiles(t, dir)
+}
+
+func TestWriteAtomic_FailedWriteKeepsPreviousFile(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "state.json")
+	if err := os.WriteFile(path, []byte(`{"ok":true}`), 0o600); err != nil {
+		t.Fatalf("WriteFile failed: %v", err)
+	}
+
+	errEncode := errors.New("encode failed")
+	err := WriteAtomic(path, func(w io.Writer) error {
+		_, _ = w.Write([]byte(`{"partial":`))
+		return errEncode
+	})
+	if !errors.Is(err, errEncode) {
+		t.Fatalf("expected encode error, got %v", err)
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("ReadFile failed: %v", err)
+	}
+	if string(data) != `{"ok":true}` {
+		t.Fatalf("previous file must survive a failed write, got %q", data)
+	}
+	requireNoTempFiles(t, dir)
+
+	if err := WriteFileAtomic(filepath.Join(dir, "missing", "state.json"), []byte("x")); err == nil {
+		t.Fatal("expected error for missing directory")
+	}
+}
+
+func TestWriteAtomic_Gzip(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "errors.json.gz")
+	if !HasGzipSuffix(path) {
+		t.Fatal("expected .gz suffix to be detected")
+	}
+	if err := WriteFileAtomic(path, []byte("payload"), WithGzip(true)); err != nil {
+		t.Fatalf("WriteFileAtomic failed: %v", err)
+	}
+
+	file, err := os.Open(path)
+	if err != nil {
+		t.Fatalf("Open failed: %v", err)
+	}
+	defer file.Close()
+	zr, err := gzip.NewReader(file)
+	if err != nil {
+		t.Fatalf("expected gzip stream: %v", err)
+	}
+	data, err := io.ReadAll(zr)
+	if err != nil {
+		t.Fatalf("ReadAll failed: %v", err)
+	}
+	if string(data) != "payload" {
+		t.Fatalf("unexpected content %q", data)
+	}
+}
+
+func requireNoTempFiles(t *testing.T, dir string) {
+	t.Helper()
+	matches, err := filepath.Glob(filepath.Join(dir, "*.tmp-*"))
+	if err != nil {
+		t.Fatalf("Glob failed: %v", err)
+	}
+	if len(matches) != 0 {
+		t.Fatalf("temporary files left behind: %v", matches)
+	}
+}
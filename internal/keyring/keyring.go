@@ -0,0 +1,277 @@
+// Package keyring хранит набор активных секретов (токенов доступа) с идентификаторами
+// и позволяет ротировать их без простоя: проверка принимает любой активный ключ,
+// набор перечитывается из источника на лету.
+package keyring
+
+import (
+	"context"
+	"crypto/sha256"
+	"crypto/subtle"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	log "github.com/sirupsen/logrus"
+
+	"github.com/vladislavdragonenkov/oms/internal/metrics"
+)
+
+var (
+	// ErrNoKeys — набор ключей пуст.
+	ErrNoKeys = errors.New("keyring: no keys configured")
+	// ErrKeyIDRequired — у ключа не указан идентификатор.
+	ErrKeyIDRequired = errors.New("keyring: key id is required")
+	// ErrKeySecretRequired — у ключа пустой секрет.
+	ErrKeySecretRequired = errors.New("keyring: key secret is required")
+	// ErrDuplicateKeyID — идентификатор ключа повторяется.
+	ErrDuplicateKeyID = errors.New("keyring: duplicate key id")
+	// ErrTokenMismatch — токен не совпал ни с одним активным ключом.
+	ErrTokenMismatch = errors.New("keyring: token mismatch")
+	// ErrDuplicateKeySecret — у двух ключей одинаковый секрет: VerifyToken не различит их kid.
+	ErrDuplicateKeySecret = errors.New("keyring: duplicate key secret")
+)
+
+func newReloadsTotal(registerer prometheus.Registerer) *prometheus.CounterVec {
+	return metrics.Register(registerer, prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: "oms_keyring_reloads_total",
+		Help: "Total number of keyring reload attempts grouped by result.",
+	}, []string{"result"}))
+}
+
+// Key — секрет с идентификатором (kid): для токенов доступа kid — владелец токена.
+type Key struct {
+	ID     string
+	Secret []byte
+}
+
+// Keyring — потокобезопасный набор активных ключей.
+type Keyring struct {
+	mu   sync.RWMutex
+	keys []Key
+
+	registerer prometheus.Registerer
+	reloads    *prometheus.CounterVec
+}
+
+// Option настраивает Keyring.
+type Option func(*Keyring)
+
+// WithRegisterer задает реестр метрик перезагрузки ключей; по умолчанию — глобальный.
+func WithRegisterer(registerer prometheus.Registerer) Option {
+	return func(k *Keyring) {
+		k.registerer = registerer
+	}
+}
+
+// New создает Keyring с набором keys.
+func New(keys []Key, options ...Option) (*Keyring, error) {
+	k := &Keyring{}
+	for _, option := range options {
+		option(k)
+	}
+	k.reloads = newReloadsTotal(k.registerer)
+	if err := k.Replace(keys); err != nil {
+		return nil, err
+	}
+	return k, nil
+}
+
+// Replace атомарно подменяет набор ключей. При ошибке валидации текущий набор сохраняется.
+func (k *Keyring) Replace(keys []Key) error {
+	if err := validateKeys(keys); err != nil {
+		return err
+	}
+
+	copied := make([]Key, len(keys))
+	for i, key := range keys {
+		copied[i] = Key{ID: key.ID, Secret: append([]byte(nil), key.Secret...)}
+	}
+
+	k.mu.Lock()
+	k.keys = copied
+	k.mu.Unlock()
+	return nil
+}
+
+// KeyIDs возвращает идентификаторы активных ключей в порядке набора.
+func (k *Keyring) KeyIDs() []string {
+	k.mu.RLock()
+	defer k.mu.RUnlock()
+
+	ids := make([]string, 0, len(k.keys))
+	for _, key := range k.keys {
+		ids = append(ids, key.ID)
+	}
+	return ids
+}
+
+// VerifyToken сравнивает предъявленный токен (например, admin API token) с активными секретами
+// и возвращает kid совпавшего ключа. Сравниваются SHA-256 от токена и секрета за постоянное
+// время: время ответа не раскрывает ни содержимое секрета, ни его длину.
+func (k *Keyring) VerifyToken(token string) (string, error) {
+	k.mu.RLock()
+	keys := k.keys
+	k.mu.RUnlock()
+
+	presented := sha256.Sum256([]byte(token))
+	for _, key := range keys {
+		secret := sha256.Sum256(key.Secret)
+		if subtle.ConstantTimeCompare(presented[:], secret[:]) == 1 {
+			return key.ID, nil
+		}
+	}
+	return "", ErrTokenMismatch
+}
+
+// ParseKeys разбирает спецификацию "kid1:secret1,kid2:secret2"; порядок записей сохраняется.
+// Формат общий для env-переменных и файлов с секретами (допускаются переводы строк вместо запятых).
+func ParseKeys(spec string) ([]Key, error) {
+	fields := strings.FieldsFunc(spec, func(r rune) bool {
+		return r == ',' || r == '\n' || r == '\r'
+	})
+
+	keys := make([]Key, 0, len(fields))
+	for _, field := range fields {
+		field = strings.TrimSpace(field)
+		if field == "" || strings.HasPrefix(field, "#") {
+			continue
+		}
+		id, secret, ok := strings.Cut(field, ":")
+		if !ok {
+			return nil, fmt.Errorf("keyring: entry must be kid:secret")
+		}
+		keys = append(keys, Key{ID: strings.TrimSpace(id), Secret: []byte(strings.TrimSpace(secret))})
+	}
+
+	if err := validateKeys(keys); err != nil {
+		return nil, err
+	}
+	return keys, nil
+}
+
+// ParseTokens разбирает токены доступа в формате ParseKeys, где kid — владелец токена
+// (оператор, пользователь). VerifyToken возвращает kid первого совпавшего ключа, поэтому
+// одинаковые токены у разных владельцев запрещены. Ошибки не содержат секретов.
+func ParseTokens(spec string) ([]Key, error) {
+	keys, err := ParseKeys(spec)
+	if err != nil {
+		return nil, err
+	}
+	owners := make(map[string]string, len(keys))
+	for _, key := range keys {
+		if owner, ok := owners[string(key.Secret)]; ok {
+			return nil, fmt.Errorf("%w: %s and %s", ErrDuplicateKeySecret, owner, key.ID)
+		}
+		owners[string(key.Secret)] = key.ID
+	}
+	return keys, nil
+}
+
+// Source возвращает актуальный набор ключей (например, из смонтированного secret-файла).
+type Source func() ([]Key, error)
+
+// FileSource читает ключи из файла в формате ParseKeys.
+func FileSource(path string) Source {
+	return func() ([]Key, error) {
+		// #nosec G304 -- путь к файлу секретов задаётся оператором через конфигурацию.
+		data, err := os.ReadFile(filepath.Clean(path))
+		if err != nil {
+			return nil, fmt.Errorf("keyring: read %s: %w", path, err)
+		}
+		return ParseKeys(string(data))
+	}
+}
+
+// TokenFileSource читает токены доступа из файла в формате ParseTokens.
+func TokenFileSource(path string) Source {
+	return func() ([]Key, error) {
+		// #nosec G304 -- путь к файлу секретов задаётся оператором через конфигурацию.
+		data, err := os.ReadFile(filepath.Clean(path))
+		if err != nil {
+			return nil, fmt.Errorf("keyring: read %s: %w", path, err)
+		}
+		return ParseTokens(string(data))
+	}
+}
+
+// Reload перечитывает ключи из source и подменяет набор.
+func (k *Keyring) Reload(source Source) error {
+	keys, err := source()
+	if err == nil {
+		err = k.Replace(keys)
+	}
+	if err != nil {
+		k.reloads.WithLabelValues("error").Inc()
+		return err
+	}
+	k.reloads.WithLabelValues("ok").Inc()
+	return nil
+}
+
+// Watch периодически перезагружает ключи до отмены ctx.
+// Ошибки логируются, а прежний набор ключей остаётся активным.
+func (k *Keyring) Watch(ctx context.Context, source Source, interval time.Duration, logger *log.Entry) {
+	if logger == nil {
+		logger = log.WithField("component", "keyring")
+	}
+	if interval <= 0 {
+		interval = time.Minute
+	}
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			before := k.KeyIDs()
+			if err := k.Reload(source); err != nil {
+				logger.WithError(err).Warn("keyring reload failed, keeping previous keys")
+				continue
+			}
+			if after := k.KeyIDs(); !equalIDs(before, after) {
+				logger.WithField("keys", after).Info("keyring rotated")
+			}
+		}
+	}
+}
+
+func validateKeys(keys []Key) error {
+	if len(keys) == 0 {
+		return ErrNoKeys
+	}
+
+	seen := make(map[string]struct{}, len(keys))
+	for _, key := range keys {
+		if key.ID == "" {
+			return ErrKeyIDRequired
+		}
+		if len(key.Secret) == 0 {
+			return fmt.Errorf("%w: %s", ErrKeySecretRequired, key.ID)
+		}
+		if _, ok := seen[key.ID]; ok {
+			return fmt.Errorf("%w: %s", ErrDuplicateKeyID, key.ID)
+		}
+		seen[key.ID] = struct{}{}
+	}
+	return nil
+}
+
+func equalIDs(a, b []string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,143 @@
+package keyring
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestKeyring_VerifyToken(t *testing.T) {
+	t.Parallel()
+
+	ring, err := New([]Key{{ID: "admin-2", Secret: []byte("token-2")}, {ID: "admin-1", Secret: []byte("token-1")}})
+	if err != nil {
+		t.Fatalf("New failed: %v", err)
+	}
+
+	if kid, err := ring.VerifyToken("token-1"); err != nil || kid != "admin-1" {
+		t.Fatalf("expected admin-1, got %q err=%v", kid, err)
+	}
+	for _, token := range []string{"unknown", "token-", "token-10", ""} {
+		if _, err := ring.VerifyToken(token); !errors.Is(err, ErrTokenMismatch) {
+			t.Fatalf("%q: expected mismatch, got %v", token, err)
+		}
+	}
+
+	// Ротация: новый токен принимается сразу, снятый — больше нет.
+	if err := ring.Replace([]Key{{ID: "admin-1", Secret: []byte("token-1b")}}); err != nil {
+		t.Fatalf("Replace failed: %v", err)
+	}
+	if kid, err := ring.VerifyToken("token-1b"); err != nil || kid != "admin-1" {
+		t.Fatalf("expected rotated token to be accepted, got %q err=%v", kid, err)
+	}
+	if _, err := ring.VerifyToken("token-1"); !errors.Is(err, ErrTokenMismatch) {
+		t.Fatalf("expected retired token to be rejected, got %v", err)
+	}
+}
+
+func TestKeyring_ReplaceKeepsKeysOnInvalidInput(t *testing.T) {
+	t.Parallel()
+
+	ring, err := New([]Key{{ID: "k1", Secret: []byte("s1")}})
+	if err != nil {
+		t.Fatalf("New failed: %v", err)
+	}
+
+	cases := map[string][]Key{
+		"empty":     nil,
+		"no id":     {{Secret: []byte("s")}},
+		"no secret": {{ID: "k2"}},
+		"duplicate": {{ID: "k2", Secret: []byte("a")}, {ID: "k2", Secret: []byte("b")}},
+	}
+	for name, keys := range cases {
+		if err := ring.Replace(keys); err == nil {
+			t.Fatalf("%s: expected validation error", name)
+		}
+	}
+	if ids := ring.KeyIDs(); len(ids) != 1 || ids[0] != "k1" {
+		t.Fatalf("expected previous keys to be kept, got %v", ring.KeyIDs())
+	}
+}
+
+func TestParseKeys(t *testing.T) {
+	t.Parallel()
+
+	keys, err := ParseKeys("k2:secret-2, k1:secret-1\n# retired\n")
+	if err != nil {
+		t.Fatalf("ParseKeys failed: %v", err)
+	}
+	if len(keys) != 2 || keys[0].ID != "k2" || string(keys[1].Secret) != "secret-1" {
+		t.Fatalf("unexpected keys: %+v", keys)
+	}
+
+	if _, err := ParseKeys("no-separator"); err == nil {
+		t.Fatal("expected format error")
+	}
+	if _, err := ParseKeys(""); !errors.Is(err, ErrNoKeys) {
+		t.Fatalf("expected ErrNoKeys, got %v", err)
+	}
+}
+
+func TestParseTokens(t *testing.T) {
+	t.Parallel()
+
+	keys, err := ParseTokens("alice:t0ken-a\nbob:t0ken:b")
+	if err != nil {
+		t.Fatalf("ParseTokens failed: %v", err)
+	}
+	if len(keys) != 2 || keys[1].ID != "bob" || string(keys[1].Secret) != "t0ken:b" {
+		t.Fatalf("unexpected keys: %+v", keys)
+	}
+
+	_, err = ParseTokens("alice:same,bob:same")
+	if !errors.Is(err, ErrDuplicateKeySecret) {
+		t.Fatalf("expected ErrDuplicateKeySecret, got %v", err)
+	}
+	if strings.Contains(err.Error(), "same") {
+		t.Fatalf("error must not leak tokens: %v", err)
+	}
+	if _, err := ParseTokens("alice:t1,alice:t2"); !errors.Is(err, ErrDuplicateKeyID) {
+		t.Fatalf("expected ErrDuplicateKeyID, got %v", err)
+	}
+}
+
+func TestKeyring_ReloadFromFile(t *testing.T) {
+	t.Parallel()
+
+	path := filepath.Join(t.TempDir(), "keys")
+	if err := os.WriteFile(path, []byte("k1:s1\n"), 0o600); err != nil {
+		t.Fatalf("write keys: %v", err)
+	}
+
+	source := FileSource(path)
+	keys, err := source()
+	if err != nil {
+		t.Fatalf("source failed: %v", err)
+	}
+	ring, err := New(keys)
+	if err != nil {
+		t.Fatalf("New failed: %v", err)
+	}
+
+	if err := os.WriteFile(path, []byte("k2:s2\nk1:s1\n"), 0o600); err != nil {
+		t.Fatalf("rewrite keys: %v", err)
+	}
+	if err := ring.Reload(source); err != nil {
+		t.Fatalf("Reload failed: %v", err)
+	}
+	if ids := ring.KeyIDs(); len(ids) != 2 || ids[0] != "k2" {
+		t.Fatalf("expected rotated keys, got %v", ring.KeyIDs())
+	}
+
+	if err := os.WriteFile(path, []byte("broken"), 0o600); err != nil {
+		t.Fatalf("rewrite keys: %v", err)
+	}
+	if err := ring.Reload(source); err == nil {
+		t.Fatal("expected reload error for broken file")
+	}
+	if ids := ring.KeyIDs(); len(ids) != 2 || ids[0] != "k2" {
+		t.Fatalf("expected keys to survive failed reload, got %v", ring.KeyIDs())
+	}
+}
@@ -0,0 +1,193 @@
+// Package logging меняет уровни логирования на лету: общий и по компонентам. Компонент
+// определяется полем "component", которое сервис проставляет во всех логгерах
+// (log.WithField("component", "saga")); отдельная регистрация call site'ов не нужна.
+package logging
+
+import (
+	"errors"
+	"fmt"
+	"maps"
+	"slices"
+	"strings"
+	"sync"
+
+	log "github.com/sirupsen/logrus"
+)
+
+// ComponentField — поле записи, по которому выбирается уровень компонента.
+const ComponentField = "component"
+
+// ErrInvalidLevels — строка уровней задана некорректно.
+var ErrInvalidLevels = errors.New("logging: invalid levels")
+
+// Config — общий уровень и переопределения по компонентам.
+type Config struct {
+	Global     log.Level
+	Components map[string]log.Level
+}
+
+// Parse разбирает уровни в формате "info,saga=debug,kafka=warn" (элементы разделяются запятыми
+// или переводами строк): элемент без "=" задаёт общий уровень (по умолчанию global), остальные —
+// уровни компонентов. Ключ компонента совпадает с полем component целиком или с его префиксом
+// до "-": kafka покрывает kafka-consumer и kafka-producer.
+func Parse(raw string, global log.Level) (Config, error) {
+	cfg := Config{Global: global, Components: make(map[string]log.Level)}
+	globalSet := false
+	for _, entry := range strings.FieldsFunc(raw, func(r rune) bool { return r == ',' || r == '\n' }) {
+		entry = strings.TrimSpace(entry)
+		if entry == "" {
+			continue
+		}
+		component, value, found := strings.Cut(entry, "=")
+		if !found {
+			if globalSet {
+				return Config{}, fmt.Errorf("%w: duplicate global level %q", ErrInvalidLevels, entry)
+			}
+			level, err := log.ParseLevel(strings.ToLower(entry))
+			if err != nil {
+				return Config{}, fmt.Errorf("%w: %v", ErrInvalidLevels, err)
+			}
+			cfg.Global, globalSet = level, true
+			continue
+		}
+		component = strings.TrimSpace(component)
+		if component == "" {
+			return Config{}, fmt.Errorf("%w: expected component=level, got %q", ErrInvalidLevels, entry)
+		}
+		if _, ok := cfg.Components[component]; ok {
+			return Config{}, fmt.Errorf("%w: duplicate level for component %q", ErrInvalidLevels, component)
+		}
+		level, err := log.ParseLevel(strings.ToLower(strings.TrimSpace(value)))
+		if err != nil {
+			return Config{}, fmt.Errorf("%w: component %s: %v", ErrInvalidLevels, component, err)
+		}
+		cfg.Components[component] = level
+	}
+	return cfg, nil
+}
+
+// Merge возвращает cfg, поверх которого применены компоненты override; общий уровень берётся из override.
+func (c Config) Merge(override Config) Config {
+	merged := Config{Global: override.Global, Components: maps.Clone(c.Components)}
+	if merged.Components == nil {
+		merged.Components = make(map[string]log.Level, len(override.Components))
+	}
+	maps.Copy(merged.Components, override.Components)
+	return merged
+}
+
+// String возвращает уровни в формате Parse с компонентами по алфавиту.
+func (c Config) String() string {
+	parts := []string{c.Global.String()}
+	for _, component := range slices.Sorted(maps.Keys(c.Components)) {
+		parts = append(parts, component+"="+c.Components[component].String())
+	}
+	return strings.Join(parts, ",")
+}
+
+// Levels — текущие уровни логгера. Logger пропускает записи самого подробного из заданных
+// уровней, а лишние отбрасывает фильтр в форматтере: logrus не умеет уровни по полям записи.
+type Levels struct {
+	logger *log.Logger
+
+	mu     sync.RWMutex
+	config Config
+}
+
+// Install подключает фильтр по компонентам к logger и применяет cfg. Форматтер logger'а
+// должен быть настроен до вызова; повторный Install заменяет предыдущий фильтр.
+func Install(logger *log.Logger, cfg Config) *Levels {
+	l := &Levels{logger: logger}
+	l.Set(cfg)
+	next := logger.Formatter
+	if installed, ok := next.(*filterFormatter); ok {
+		next = installed.next
+	}
+	logger.SetFormatter(&filterFormatter{next: next, levels: l})
+	return l
+}
+
+// Set заменяет все уровни.
+func (l *Levels) Set(cfg Config) {
+	cfg = Config{}.Merge(cfg)
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.config = cfg
+	l.logger.SetLevel(mostVerbose(cfg))
+}
+
+// SetGlobal меняет общий уровень, сохраняя уровни компонентов.
+func (l *Levels) SetGlobal(level log.Level) {
+	l.update(func(cfg *Config) { cfg.Global = level })
+}
+
+// SetComponent задаёт уровень компонента.
+func (l *Levels) SetComponent(component string, level log.Level) {
+	l.update(func(cfg *Config) { cfg.Components[component] = level })
+}
+
+// ResetComponent убирает переопределение: компонент снова пишет с общим уровнем.
+func (l *Levels) ResetComponent(component string) {
+	l.update(func(cfg *Config) { delete(cfg.Components, component) })
+}
+
+// Config возвращает копию текущих уровней.
+func (l *Levels) Config() Config {
+	l.mu.RLock()
+	defer l.mu.RUnlock()
+	return Config{}.Merge(l.config)
+}
+
+// Enabled сообщает, пишется ли запись уровня level от компонента component.
+func (l *Levels) Enabled(component string, level log.Level) bool {
+	l.mu.RLock()
+	defer l.mu.RUnlock()
+	return level <= l.config.levelFor(component)
+}
+
+func (l *Levels) update(fn func(cfg *Config)) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	cfg := Config{}.Merge(l.config)
+	fn(&cfg)
+	l.config = cfg
+	l.logger.SetLevel(mostVerbose(cfg))
+}
+
+// levelFor ищет уровень по полному имени компонента, затем по префиксам до "-":
+// outbox-cleanup-worker → outbox-cleanup → outbox.
+func (c Config) levelFor(component string) log.Level {
+	for name := component; name != ""; {
+		if level, ok := c.Components[name]; ok {
+			return level
+		}
+		idx := strings.LastIndex(name, "-")
+		if idx < 0 {
+			break
+		}
+		name = name[:idx]
+	}
+	return c.Global
+}
+
+func mostVerbose(cfg Config) log.Level {
+	level := cfg.Global
+	for _, componentLevel := range cfg.Components {
+		level = max(level, componentLevel)
+	}
+	return level
+}
+
+type filterFormatter struct {
+	next   log.Formatter
+	levels *Levels
+}
+
+// Format возвращает пустой результат для отфильтрованной записи: logger пишет его как no-op.
+func (f *filterFormatter) Format(entry *log.Entry) ([]byte, error) {
+	component, _ := entry.Data[ComponentField].(string)
+	if !f.levels.Enabled(component, entry.Level) {
+		return nil, nil
+	}
+	return f.next.Format(entry)
+}
@@ -0,0 +1,88 @@
+package logging
+
+import (
+	"bytes"
+	"errors"
+	"strings"
+	"testing"
+
+	log "github.com/sirupsen/logrus"
+)
+
+func TestParse(t *testing.T) {
+	cfg, err := Parse(" warn, saga=debug ,kafka=ERROR,", log.InfoLevel)
+	if err != nil {
+		t.Fatalf("parse: %v", err)
+	}
+	if cfg.Global != log.WarnLevel || cfg.Components["saga"] != log.DebugLevel || cfg.Components["kafka"] != log.ErrorLevel {
+		t.Fatalf("unexpected config: %+v", cfg)
+	}
+	if got := cfg.String(); got != "warning,kafka=error,saga=debug" {
+		t.Fatalf("unexpected string: %s", got)
+	}
+
+	cfg, err = Parse("saga=debug", log.InfoLevel)
+	if err != nil || cfg.Global != log.InfoLevel {
+		t.Fatalf("expected fallback global level, got %+v (err=%v)", cfg, err)
+	}
+
+	for _, raw := range []string{"loud", "saga=loud", "=debug", "info,warn", "saga=debug,saga=info"} {
+		if _, err := Parse(raw, log.InfoLevel); !errors.Is(err, ErrInvalidLevels) {
+			t.Fatalf("%q: expected ErrInvalidLevels, got %v", raw, err)
+		}
+	}
+}
+
+func TestLevelsFilterByComponent(t *testing.T) {
+	var out bytes.Buffer
+	logger := log.New()
+	logger.SetOutput(&out)
+	logger.SetFormatter(&log.TextFormatter{DisableTimestamp: true})
+
+	levels := Install(logger, Config{Global: log.InfoLevel, Components: map[string]log.Level{
+		"saga":  log.DebugLevel,
+		"kafka": log.WarnLevel,
+	}})
+	if logger.GetLevel() != log.DebugLevel {
+		t.Fatalf("logger must pass the most verbose level, got %s", logger.GetLevel())
+	}
+
+	logger.WithField(ComponentField, "saga").Debug("saga debug")
+	logger.WithField(ComponentField, "saga-noop").Debug("saga-noop debug")
+	logger.WithField(ComponentField, "kafka-consumer").Info("kafka info")
+	logger.WithField(ComponentField, "kafka-consumer").Warn("kafka warn")
+	logger.WithField(ComponentField, "outbox-worker").Debug("outbox debug")
+	logger.WithField(ComponentField, "outbox-worker").Info("outbox info")
+	logger.Debug("no component debug")
+
+	got := out.String()
+	for _, want := range []string{"saga debug", "saga-noop debug", "kafka warn", "outbox info"} {
+		if !strings.Contains(got, want) {
+			t.Fatalf("expected %q in output:\n%s", want, got)
+		}
+	}
+	for _, unwanted := range []string{"kafka info", "outbox debug", "no component debug"} {
+		if strings.Contains(got, unwanted) {
+			t.Fatalf("unexpected %q in output:\n%s", unwanted, got)
+		}
+	}
+
+	out.Reset()
+	levels.SetComponent("outbox", log.DebugLevel)
+	levels.ResetComponent("saga")
+	levels.SetGlobal(log.ErrorLevel)
+	logger.WithField(ComponentField, "outbox-worker").Debug("outbox debug")
+	logger.WithField(ComponentField, "saga").Warn("saga warn")
+	if got := out.String(); !strings.Contains(got, "outbox debug") || strings.Contains(got, "saga warn") {
+		t.Fatalf("runtime changes not applied:\n%s", got)
+	}
+	if got := levels.Config().String(); got != "error,kafka=warning,outbox=debug" {
+		t.Fatalf("unexpected config: %s", got)
+	}
+
+	// Повторная установка не вкладывает фильтры друг в друга.
+	Install(logger, Config{Global: log.InfoLevel})
+	if _, nested := logger.Formatter.(*filterFormatter).next.(*filterFormatter); nested {
+		t.Fatal("expected a single filter layer")
+	}
+}
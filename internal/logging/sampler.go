@@ -0,0 +1,139 @@
+package logging
+
+import (
+	"sync"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	log "github.com/sirupsen/logrus"
+
+	"github.com/vladislavdragonenkov/oms/internal/metrics"
+)
+
+const (
+	// DefaultSampleBurst — сколько одинаковых записей подряд пишется без ограничений.
+	DefaultSampleBurst = 10
+	// DefaultSampleRate — сколько записей в секунду на ключ пишется после исчерпания burst.
+	DefaultSampleRate = 1.0
+)
+
+// SuppressedField — поле записи с числом подавленных повторов с предыдущей записи этого ключа.
+const SuppressedField = "suppressed"
+
+// Sampler ограничивает частоту одинаковых записей в горячих путях: на каждый ключ — свой
+// token bucket. Под нагрузкой повторяющийся warning (version conflict, недоступная Kafka) даёт
+// несколько строк в секунду вместо тысяч; подавленные записи считаются в oms_log_suppressed_total,
+// а следующая пропущенная запись несёт их число в поле suppressed.
+//
+// Ключ — статическое имя call site'а, а не ID заказа: иначе каждая запись получит свой bucket.
+// Nil *Sampler пропускает всё.
+type Sampler struct {
+	component  string
+	rate       float64
+	burst      float64
+	suppressed *prometheus.CounterVec
+	now        func() time.Time
+
+	mu      sync.Mutex
+	buckets map[string]*sampleBucket
+}
+
+type sampleBucket struct {
+	tokens     float64
+	last       time.Time
+	suppressed int
+}
+
+type samplerOptions struct {
+	rate       float64
+	burst      int
+	registerer prometheus.Registerer
+}
+
+// SamplerOption настраивает Sampler.
+type SamplerOption func(*samplerOptions)
+
+// WithSampleRate задаёт burst и скорость пополнения (записей в секунду на ключ).
+// Неположительные значения оставляют значения по умолчанию.
+func WithSampleRate(perSecond float64, burst int) SamplerOption {
+	return func(opts *samplerOptions) {
+		if perSecond > 0 {
+			opts.rate = perSecond
+		}
+		if burst > 0 {
+			opts.burst = burst
+		}
+	}
+}
+
+// WithSamplerRegisterer задаёт реестр метрик.
+func WithSamplerRegisterer(registerer prometheus.Registerer) SamplerOption {
+	return func(opts *samplerOptions) {
+		opts.registerer = registerer
+	}
+}
+
+// NewSampler создаёт Sampler; component попадает в label метрики подавленных записей.
+func NewSampler(component string, options ...SamplerOption) *Sampler {
+	opts := samplerOptions{rate: DefaultSampleRate, burst: DefaultSampleBurst}
+	for _, option := range options {
+		option(&opts)
+	}
+	return &Sampler{
+		component: component,
+		rate:      opts.rate,
+		burst:     float64(opts.burst),
+		suppressed: metrics.Register(opts.registerer, prometheus.NewCounterVec(prometheus.CounterOpts{
+			Name: "oms_log_suppressed_total",
+			Help: "Log records dropped by rate-limited sampling grouped by component and message key.",
+		}, []string{"component", "key"})),
+		now:     time.Now,
+		buckets: make(map[string]*sampleBucket),
+	}
+}
+
+// Allow списывает токен ключа. Если запись разрешена, возвращает число подавленных с прошлой
+// разрешённой записи и сбрасывает его.
+func (s *Sampler) Allow(key string) (allowed bool, suppressed int) {
+	if s == nil {
+		return true, 0
+	}
+	now := s.now()
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	bucket, ok := s.buckets[key]
+	if !ok {
+		bucket = &sampleBucket{tokens: s.burst, last: now}
+		s.buckets[key] = bucket
+	}
+	if elapsed := now.Sub(bucket.last).Seconds(); elapsed > 0 {
+		bucket.tokens = min(s.burst, bucket.tokens+elapsed*s.rate)
+	}
+	bucket.last = now
+
+	if bucket.tokens < 1 {
+		bucket.suppressed++
+		s.suppressed.WithLabelValues(s.component, key).Inc()
+		return false, 0
+	}
+	bucket.tokens--
+	suppressed, bucket.suppressed = bucket.suppressed, 0
+	return true, suppressed
+}
+
+// Log пишет msg с уровнем level через entry, если ключ key не исчерпал лимит. Запись уровня,
+// который logger всё равно не пишет, токен не тратит и подавленной не считается.
+func (s *Sampler) Log(entry *log.Entry, level log.Level, key, msg string) {
+	if !entry.Logger.IsLevelEnabled(level) {
+		return
+	}
+	allowed, suppressed := s.Allow(key)
+	if !allowed {
+		return
+	}
+	if suppressed > 0 {
+		entry = entry.WithField(SuppressedField, suppressed)
+	}
+	entry.Log(level, msg)
+}
@@ -0,0 +1,106 @@
+package logging
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	log "github.com/sirupsen/logrus"
+
+	"github.com/vladislavdragonenkov/oms/internal/metrics/metricstest"
+)
+
+func newTestSampler(registry *prometheus.Registry, now *time.Time, options ...SamplerOption) *Sampler {
+	sampler := NewSampler("test", append([]SamplerOption{WithSamplerRegisterer(registry)}, options...)...)
+	sampler.now = func() time.Time { return *now }
+	return sampler
+}
+
+func TestSampler_AllowBurstAndRefill(t *testing.T) {
+	registry := prometheus.NewRegistry()
+	now := time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)
+	sampler := newTestSampler(registry, &now, WithSampleRate(2, 3))
+
+	for i := 0; i < 3; i++ {
+		if allowed, _ := sampler.Allow("conflict"); !allowed {
+			t.Fatalf("record %d within burst must be allowed", i)
+		}
+	}
+	for i := 0; i < 4; i++ {
+		if allowed, _ := sampler.Allow("conflict"); allowed {
+			t.Fatalf("record %d beyond burst must be suppressed", i)
+		}
+	}
+	// Другой ключ расходует собственный bucket.
+	if allowed, _ := sampler.Allow("publish"); !allowed {
+		t.Fatal("independent key must be allowed")
+	}
+
+	// Через полсекунды при 2 записях/с появляется один токен.
+	now = now.Add(500 * time.Millisecond)
+	allowed, suppressed := sampler.Allow("conflict")
+	if !allowed || suppressed != 4 {
+		t.Fatalf("expected refilled token carrying 4 suppressed, got %v, %d", allowed, suppressed)
+	}
+	if allowed, _ := sampler.Allow("conflict"); allowed {
+		t.Fatal("expected bucket to be empty again")
+	}
+
+	metricstest.RequireValue(t, registry, "oms_log_suppressed_total", metricstest.Labels{"component": "test", "key": "conflict"}, 5)
+	metricstest.RequireAbsent(t, registry, "oms_log_suppressed_total", metricstest.Labels{"key": "publish"})
+}
+
+func TestSampler_LogAddsSuppressedField(t *testing.T) {
+	var out bytes.Buffer
+	logger := log.New()
+	logger.SetOutput(&out)
+	logger.SetFormatter(&log.TextFormatter{DisableTimestamp: true})
+
+	now := time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)
+	sampler := newTestSampler(prometheus.NewRegistry(), &now, WithSampleRate(1, 1))
+	entry := log.NewEntry(logger).WithField("order_id", "order-1")
+
+	for i := 0; i < 5; i++ {
+		sampler.Log(entry, log.WarnLevel, "conflict", "version conflict")
+	}
+	now = now.Add(time.Second)
+	sampler.Log(entry, log.WarnLevel, "conflict", "version conflict")
+
+	lines := strings.Split(strings.TrimSpace(out.String()), "\n")
+	if len(lines) != 2 {
+		t.Fatalf("expected 2 lines, got %d:\n%s", len(lines), out.String())
+	}
+	if strings.Contains(lines[0], SuppressedField) || !strings.Contains(lines[1], "suppressed=4") {
+		t.Fatalf("expected suppressed count only on the second line:\n%s", out.String())
+	}
+}
+
+func TestSampler_DisabledLevelAndNil(t *testing.T) {
+	var out bytes.Buffer
+	logger := log.New()
+	logger.SetOutput(&out)
+	logger.SetLevel(log.ErrorLevel)
+
+	registry := prometheus.NewRegistry()
+	now := time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)
+	sampler := newTestSampler(registry, &now, WithSampleRate(1, 1))
+
+	// Отключённый уровень не тратит токены и не считается подавленным.
+	for i := 0; i < 3; i++ {
+		sampler.Log(log.NewEntry(logger), log.WarnLevel, "conflict", "ignored")
+	}
+	metricstest.RequireAbsent(t, registry, "oms_log_suppressed_total", nil)
+	if allowed, _ := sampler.Allow("conflict"); !allowed {
+		t.Fatal("disabled level must not consume tokens")
+	}
+
+	var nilSampler *Sampler
+	for i := 0; i < 3; i++ {
+		nilSampler.Log(log.NewEntry(logger), log.ErrorLevel, "conflict", "always")
+	}
+	if got := strings.Count(out.String(), "always"); got != 3 {
+		t.Fatalf("nil sampler must log every record, got %d", got)
+	}
+}
@@ -0,0 +1,282 @@
+package kafka
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/IBM/sarama"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/structpb"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	eventsv1 "github.com/vladislavdragonenkov/oms/proto/oms/events/v1"
+)
+
+// HeaderContentType — формат сериализации value. Сообщения без header считаются JSON:
+// так публиковались все события до появления кодеков.
+const HeaderContentType = "content-type"
+
+const (
+	ContentTypeJSON     = "application/json"
+	ContentTypeProtobuf = "application/x-protobuf"
+)
+
+// ErrUnsupportedEvent — кодек не умеет сериализовать значение этого типа.
+var ErrUnsupportedEvent = errors.New("kafka: event type is not supported by codec")
+
+// Codec сериализует события Kafka. Unmarshal принимает указатель на событие.
+type Codec interface {
+	ContentType() string
+	Marshal(event any) ([]byte, error)
+	Unmarshal(data []byte, event any) error
+}
+
+// JSONCodec — формат по умолчанию; сериализует любые значения.
+type JSONCodec struct{}
+
+func (JSONCodec) ContentType() string { return ContentTypeJSON }
+
+func (JSONCodec) Marshal(event any) ([]byte, error) { return json.Marshal(event) }
+
+func (JSONCodec) Unmarshal(data []byte, event any) error { return json.Unmarshal(data, event) }
+
+// ProtobufCodec сериализует события через сообщения proto/oms/events/v1. Поддерживает
+// SagaEvent, OrderEvent, OutboxEnvelope и InventoryRestockEvent; остальные значения
+// (например, служебные DLQ-записи) возвращают ErrUnsupportedEvent.
+type ProtobufCodec struct{}
+
+func (ProtobufCodec) ContentType() string { return ContentTypeProtobuf }
+
+func (ProtobufCodec) Marshal(event any) ([]byte, error) {
+	var msg proto.Message
+	switch e := event.(type) {
+	case *SagaEvent:
+		metadata, err := toStruct(e.Metadata)
+		if err != nil {
+			return nil, err
+		}
+		msg = &eventsv1.SagaEvent{
+			EventType:     string(e.EventType),
+			OrderId:       e.OrderID,
+			Timestamp:     toTimestamp(e.Timestamp),
+			Metadata:      metadata,
+			Encryption:    toEncryptionProto(e.Encryption),
+			CorrelationId: e.CorrelationID,
+			CausationId:   e.CausationID,
+		}
+	case *OrderEvent:
+		metadata, err := toStruct(e.Metadata)
+		if err != nil {
+			return nil, err
+		}
+		msg = &eventsv1.OrderEvent{
+			EventType:  string(e.EventType),
+			OrderId:    e.OrderID,
+			CustomerId: e.CustomerID,
+			Status:     e.Status,
+			Timestamp:  toTimestamp(e.Timestamp),
+			Metadata:   metadata,
+		}
+	case OutboxEnvelope:
+		msg = outboxEnvelopeProto(&e)
+	case *OutboxEnvelope:
+		msg = outboxEnvelopeProto(e)
+	case *InventoryRestockEvent:
+		msg = &eventsv1.InventoryRestockEvent{Sku: e.SKU, Qty: e.Qty, Timestamp: toTimestamp(e.Timestamp)}
+	default:
+		return nil, fmt.Errorf("%w: %T", ErrUnsupportedEvent, event)
+	}
+	return proto.Marshal(msg)
+}
+
+func (ProtobufCodec) Unmarshal(data []byte, event any) error {
+	switch e := event.(type) {
+	case *SagaEvent:
+		var msg eventsv1.SagaEvent
+		if err := proto.Unmarshal(data, &msg); err != nil {
+			return err
+		}
+		*e = SagaEvent{
+			EventType:     EventType(msg.GetEventType()),
+			OrderID:       msg.GetOrderId(),
+			Timestamp:     fromTimestamp(msg.GetTimestamp()),
+			Metadata:      fromStruct(msg.GetMetadata()),
+			Encryption:    fromEncryptionProto(msg.GetEncryption()),
+			CorrelationID: msg.GetCorrelationId(),
+			CausationID:   msg.GetCausationId(),
+		}
+	case *OrderEvent:
+		var msg eventsv1.OrderEvent
+		if err := proto.Unmarshal(data, &msg); err != nil {
+			return err
+		}
+		*e = OrderEvent{
+			EventType:  EventType(msg.GetEventType()),
+			OrderID:    msg.GetOrderId(),
+			CustomerID: msg.GetCustomerId(),
+			Status:     msg.GetStatus(),
+			Timestamp:  fromTimestamp(msg.GetTimestamp()),
+			Metadata:   fromStruct(msg.GetMetadata()),
+		}
+	case *OutboxEnvelope:
+		var msg eventsv1.OutboxEnvelope
+		if err := proto.Unmarshal(data, &msg); err != nil {
+			return err
+		}
+		*e = OutboxEnvelope{
+			ID:            msg.GetId(),
+			AggregateType: msg.GetAggregateType(),
+			AggregateID:   msg.GetAggregateId(),
+			EventType:     msg.GetEventType(),
+			Payload:       json.RawMessage(msg.GetPayload()),
+			PublishedAt:   fromTimestamp(msg.GetPublishedAt()),
+			Encryption:    fromEncryptionProto(msg.GetEncryption()),
+			CorrelationID: msg.GetCorrelationId(),
+			CausationID:   msg.GetCausationId(),
+		}
+	case *InventoryRestockEvent:
+		var msg eventsv1.InventoryRestockEvent
+		if err := proto.Unmarshal(data, &msg); err != nil {
+			return err
+		}
+		*e = InventoryRestockEvent{SKU: msg.GetSku(), Qty: msg.GetQty(), Timestamp: fromTimestamp(msg.GetTimestamp())}
+	default:
+		return fmt.Errorf("%w: %T", ErrUnsupportedEvent, event)
+	}
+	return nil
+}
+
+// ParseCodec возвращает кодек по имени: json (или пусто) и protobuf.
+func ParseCodec(name string) (Codec, error) {
+	switch strings.ToLower(strings.TrimSpace(name)) {
+	case "", "json":
+		return JSONCodec{}, nil
+	case "protobuf", "proto":
+		return ProtobufCodec{}, nil
+	default:
+		return nil, fmt.Errorf("%w: unknown codec %q (allowed: json, protobuf)", ErrInvalidTopicConfig, name)
+	}
+}
+
+// WithCodecs применяет форматы из строки "saga_events=protobuf,order_events=json".
+// Незаданные топики сохраняют текущий формат.
+func (c TopicConfig) WithCodecs(raw string) (TopicConfig, error) {
+	for _, chunk := range strings.Split(raw, ",") {
+		chunk = strings.TrimSpace(chunk)
+		if chunk == "" {
+			continue
+		}
+		field, value, ok := strings.Cut(chunk, "=")
+		if !ok {
+			return TopicConfig{}, fmt.Errorf("%w: codec %q must be topic=codec", ErrInvalidTopicConfig, chunk)
+		}
+		codec, err := ParseCodec(value)
+		if err != nil {
+			return TopicConfig{}, err
+		}
+		switch strings.TrimSpace(field) {
+		case "order_events":
+			c.OrderEventsCodec = codec
+		case "saga_events":
+			c.SagaEventsCodec = codec
+		default:
+			return TopicConfig{}, fmt.Errorf("%w: codec for unknown topic %q (allowed: order_events, saga_events)", ErrInvalidTopicConfig, field)
+		}
+	}
+	return c, nil
+}
+
+// DetectCodec выбирает кодек сообщения: по header content-type, а без него — по содержимому.
+// JSON-события всегда начинаются с '{', а protobuf-сообщения событий — с тега поля 1.
+func DetectCodec(contentType string, value []byte) Codec {
+	switch strings.ToLower(strings.TrimSpace(contentType)) {
+	case ContentTypeProtobuf:
+		return ProtobufCodec{}
+	case ContentTypeJSON:
+		return JSONCodec{}
+	}
+	if trimmed := bytes.TrimLeft(value, " \t\r\n"); len(trimmed) > 0 && trimmed[0] != '{' {
+		return ProtobufCodec{}
+	}
+	return JSONCodec{}
+}
+
+// DecodeMessage разбирает value сообщения в event кодеком, определённым DetectCodec.
+// Consumer'ы читают так JSON и protobuf, пока топик переключается между форматами.
+func DecodeMessage(message *sarama.ConsumerMessage, event any) error {
+	contentType, _ := HeaderValue(message, HeaderContentType)
+	return DetectCodec(contentType, message.Value).Unmarshal(message.Value, event)
+}
+
+func outboxEnvelopeProto(e *OutboxEnvelope) *eventsv1.OutboxEnvelope {
+	return &eventsv1.OutboxEnvelope{
+		Id:            e.ID,
+		AggregateType: e.AggregateType,
+		AggregateId:   e.AggregateID,
+		EventType:     e.EventType,
+		Payload:       e.Payload,
+		PublishedAt:   toTimestamp(e.PublishedAt),
+		Encryption:    toEncryptionProto(e.Encryption),
+		CorrelationId: e.CorrelationID,
+		CausationId:   e.CausationID,
+	}
+}
+
+func toStruct(metadata map[string]interface{}) (*structpb.Struct, error) {
+	if len(metadata) == 0 {
+		return nil, nil
+	}
+	// Через JSON, чтобы значения (числа, вложенные map и slice) приняли тот же вид, что и в JSON-кодеке.
+	raw, err := json.Marshal(metadata)
+	if err != nil {
+		return nil, fmt.Errorf("kafka: encode metadata: %w", err)
+	}
+	var normalized map[string]interface{}
+	if err := json.Unmarshal(raw, &normalized); err != nil {
+		return nil, fmt.Errorf("kafka: encode metadata: %w", err)
+	}
+	result, err := structpb.NewStruct(normalized)
+	if err != nil {
+		return nil, fmt.Errorf("kafka: encode metadata: %w", err)
+	}
+	return result, nil
+}
+
+func fromStruct(metadata *structpb.Struct) map[string]interface{} {
+	if len(metadata.GetFields()) == 0 {
+		return nil
+	}
+	return metadata.AsMap()
+}
+
+func toTimestamp(t time.Time) *timestamppb.Timestamp {
+	if t.IsZero() {
+		return nil
+	}
+	return timestamppb.New(t)
+}
+
+func fromTimestamp(ts *timestamppb.Timestamp) time.Time {
+	if ts == nil {
+		return time.Time{}
+	}
+	return ts.AsTime()
+}
+
+func toEncryptionProto(info *EncryptionInfo) *eventsv1.EncryptionInfo {
+	if info == nil {
+		return nil
+	}
+	return &eventsv1.EncryptionInfo{Alg: info.Algorithm, KeyId: info.KeyID, WrappedKey: info.WrappedKey, Fields: info.Fields}
+}
+
+func fromEncryptionProto(info *eventsv1.EncryptionInfo) *EncryptionInfo {
+	if info == nil {
+		return nil
+	}
+	return &EncryptionInfo{Algorithm: info.GetAlg(), KeyID: info.GetKeyId(), WrappedKey: info.GetWrappedKey(), Fields: info.GetFields()}
+}
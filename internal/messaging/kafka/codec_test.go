@@ -0,0 +1,98 @@
+package kafka
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/IBM/sarama"
+)
+
+func TestProtobufCodec_RoundTrip(t *testing.T) {
+	ts := time.Date(2026, 3, 1, 12, 0, 0, 123, time.UTC)
+	codec := ProtobufCodec{}
+
+	saga := &SagaEvent{
+		EventType:     EventTypeSagaStarted,
+		OrderID:       "order-1",
+		Timestamp:     ts,
+		Metadata:      map[string]interface{}{"customer_id": "c-1", "amount": 1250, "items": []string{"sku-1"}},
+		Encryption:    &EncryptionInfo{Algorithm: "AES-256-GCM", KeyID: "k1", WrappedKey: []byte{1, 2, 3}, Fields: []string{"customer_id"}},
+		CorrelationID: "req-1",
+		CausationID:   "out-0",
+	}
+	data, err := codec.Marshal(saga)
+	if err != nil {
+		t.Fatalf("marshal saga event: %v", err)
+	}
+	var gotSaga SagaEvent
+	if err := codec.Unmarshal(data, &gotSaga); err != nil {
+		t.Fatalf("unmarshal saga event: %v", err)
+	}
+	// Metadata сравнивается с JSON-представлением: числа становятся float64, slice — []interface{}.
+	wantSaga := *saga
+	wantSaga.Metadata = map[string]interface{}{"customer_id": "c-1", "amount": float64(1250), "items": []interface{}{"sku-1"}}
+	if !reflect.DeepEqual(gotSaga, wantSaga) {
+		t.Fatalf("saga round trip:\n got %+v\nwant %+v", gotSaga, wantSaga)
+	}
+
+	envelope := OutboxEnvelope{ID: "out-1", AggregateType: "order", AggregateID: "order-1", EventType: "order.created", Payload: []byte(`{"id":"order-1"}`), PublishedAt: ts, CorrelationID: "req-1", CausationID: "out-0"}
+	if data, err = codec.Marshal(envelope); err != nil {
+		t.Fatalf("marshal envelope: %v", err)
+	}
+	var gotEnvelope OutboxEnvelope
+	if err := codec.Unmarshal(data, &gotEnvelope); err != nil {
+		t.Fatalf("unmarshal envelope: %v", err)
+	}
+	if !reflect.DeepEqual(gotEnvelope, envelope) {
+		t.Fatalf("envelope round trip:\n got %+v\nwant %+v", gotEnvelope, envelope)
+	}
+
+	if _, err := codec.Marshal(map[string]string{"a": "b"}); !errors.Is(err, ErrUnsupportedEvent) {
+		t.Fatalf("expected ErrUnsupportedEvent, got %v", err)
+	}
+}
+
+func TestDecodeMessage_DetectsCodec(t *testing.T) {
+	event := &OrderEvent{EventType: EventTypeOrderCreated, OrderID: "order-1", CustomerID: "c-1", Status: "pending"}
+	jsonValue, err := JSONCodec{}.Marshal(event)
+	if err != nil {
+		t.Fatal(err)
+	}
+	protoValue, err := ProtobufCodec{}.Marshal(event)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	cases := map[string]*sarama.ConsumerMessage{
+		"json without header":     {Value: jsonValue},
+		"json with header":        {Value: jsonValue, Headers: []*sarama.RecordHeader{{Key: []byte(HeaderContentType), Value: []byte(ContentTypeJSON)}}},
+		"protobuf with header":    {Value: protoValue, Headers: []*sarama.RecordHeader{{Key: []byte(HeaderContentType), Value: []byte(ContentTypeProtobuf)}}},
+		"protobuf without header": {Value: protoValue},
+	}
+	for name, message := range cases {
+		got, err := ParseOrderEvent(message)
+		if err != nil {
+			t.Fatalf("%s: %v", name, err)
+		}
+		if !reflect.DeepEqual(got, event) {
+			t.Fatalf("%s: got %+v, want %+v", name, got, event)
+		}
+	}
+}
+
+func TestTopicConfig_WithCodecs(t *testing.T) {
+	cfg, err := DefaultTopicConfig().WithCodecs(" saga_events=Protobuf ")
+	if err != nil {
+		t.Fatalf("WithCodecs: %v", err)
+	}
+	if cfg.SagaEventsCodec != (ProtobufCodec{}) || cfg.OrderEventsCodec != (JSONCodec{}) {
+		t.Fatalf("unexpected codecs: saga=%T order=%T", cfg.SagaEventsCodec, cfg.OrderEventsCodec)
+	}
+	for _, raw := range []string{"saga_events=avro", "saga_events", "dlq=protobuf"} {
+		if _, err := DefaultTopicConfig().WithCodecs(raw); !errors.Is(err, ErrInvalidTopicConfig) {
+			t.Fatalf("%q: expected ErrInvalidTopicConfig, got %v", raw, err)
+		}
+	}
+}
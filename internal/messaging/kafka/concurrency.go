@@ -0,0 +1,69 @@
+package kafka
+
+import (
+	"context"
+	"sync"
+)
+
+// concurrencyLimit ограничивает число сообщений, которые consumer обрабатывает одновременно
+// по всем партициям. sarama вызывает ConsumeClaim в отдельной горутине на партицию, поэтому
+// без лимита параллельность равна числу назначенных партиций. Лимит меняется на лету.
+type concurrencyLimit struct {
+	mu       sync.Mutex
+	limit    int // 0 — без ограничения
+	inFlight int
+	// wake закрывается при освобождении слота или смене лимита, будя ожидающих.
+	wake chan struct{}
+}
+
+func newConcurrencyLimit(limit int) *concurrencyLimit {
+	return &concurrencyLimit{limit: max(limit, 0), wake: make(chan struct{})}
+}
+
+// acquire ждёт свободный слот; отмена ctx (завершение сессии) прерывает ожидание.
+// nil-лимит ничего не ограничивает.
+func (l *concurrencyLimit) acquire(ctx context.Context) error {
+	if l == nil {
+		return nil
+	}
+	for {
+		l.mu.Lock()
+		if l.limit == 0 || l.inFlight < l.limit {
+			l.inFlight++
+			l.mu.Unlock()
+			return nil
+		}
+		wake := l.wake
+		l.mu.Unlock()
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-wake:
+		}
+	}
+}
+
+func (l *concurrencyLimit) release() {
+	if l == nil {
+		return
+	}
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.inFlight--
+	l.broadcast()
+}
+
+// set меняет лимит; уже обрабатываемые сообщения дорабатывают, уменьшение действует
+// по мере их завершения.
+func (l *concurrencyLimit) set(limit int) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.limit = max(limit, 0)
+	l.broadcast()
+}
+
+func (l *concurrencyLimit) broadcast() {
+	close(l.wake)
+	l.wake = make(chan struct{})
+}
@@ -0,0 +1,61 @@
+package kafka
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestConcurrencyLimit_BlocksUntilReleaseOrRaise(t *testing.T) {
+	limit := newConcurrencyLimit(1)
+	ctx := context.Background()
+	if err := limit.acquire(ctx); err != nil {
+		t.Fatalf("first acquire: %v", err)
+	}
+
+	acquired := make(chan struct{})
+	go func() {
+		if err := limit.acquire(ctx); err == nil {
+			close(acquired)
+		}
+	}()
+	select {
+	case <-acquired:
+		t.Fatal("second acquire must wait while the only slot is busy")
+	case <-time.After(20 * time.Millisecond):
+	}
+
+	limit.set(2)
+	select {
+	case <-acquired:
+	case <-time.After(time.Second):
+		t.Fatal("raising the limit must wake the waiter")
+	}
+
+	limit.set(1)
+	limit.release()
+	waitCtx, cancel := context.WithTimeout(ctx, 20*time.Millisecond)
+	defer cancel()
+	if err := limit.acquire(waitCtx); !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("expected wait after lowering the limit, got %v", err)
+	}
+	limit.release()
+	if err := limit.acquire(ctx); err != nil {
+		t.Fatalf("acquire after release: %v", err)
+	}
+}
+
+func TestConcurrencyLimit_ZeroAndNilAreUnlimited(t *testing.T) {
+	limit := newConcurrencyLimit(0)
+	for i := 0; i < 10; i++ {
+		if err := limit.acquire(context.Background()); err != nil {
+			t.Fatalf("acquire %d: %v", i, err)
+		}
+	}
+	var none *concurrencyLimit
+	if err := none.acquire(context.Background()); err != nil {
+		t.Fatalf("nil limit must not block: %v", err)
+	}
+	none.release()
+}
@@ -2,14 +2,19 @@ package kafka
 
 import (
 	"context"
-	"encoding/json"
+	"encoding/base64"
 	"fmt"
-	"strconv"
+	"slices"
 	"sync"
 	"time"
 
 	"github.com/IBM/sarama"
+	"github.com/prometheus/client_golang/prometheus"
 	log "github.com/sirupsen/logrus"
+
+	"github.com/vladislavdragonenkov/oms/internal/ctxutil"
+	"github.com/vladislavdragonenkov/oms/internal/domain"
+	"github.com/vladislavdragonenkov/oms/internal/timeutil"
 )
 
 // MessageHandler обрабатывает сообщение из Kafka
@@ -22,13 +27,46 @@ type Consumer struct {
 	handler     MessageHandler
 	logger      *log.Entry
 	wg          sync.WaitGroup
-	dlqProducer *Producer // Producer для отправки в DLQ
-	maxRetries  int       // Максимальное количество retry попыток
-	retryDelay  time.Duration
+	dlqProducer *Producer // Producer для отправки в retry-топики и DLQ
+	policy      DLQPolicy
+	metrics     *consumerMetrics
+	concurrency *concurrencyLimit
 }
 
 const defaultConsumerRetryDelay = 100 * time.Millisecond
 
+// ConsumerOption настраивает Consumer.
+type ConsumerOption func(*consumerOptions)
+
+type consumerOptions struct {
+	registerer  prometheus.Registerer
+	middlewares []Middleware
+	concurrency int
+}
+
+// WithConsumerRegisterer задаёт Prometheus registerer для метрик consumer'а (nil — глобальный).
+func WithConsumerRegisterer(registerer prometheus.Registerer) ConsumerOption {
+	return func(opts *consumerOptions) {
+		opts.registerer = registerer
+	}
+}
+
+// WithConsumerMiddleware оборачивает handler цепочкой middleware (см. Chain, DefaultMiddleware).
+// Цепочка выполняется на каждой попытке обработки, включая повторы.
+func WithConsumerMiddleware(middlewares ...Middleware) ConsumerOption {
+	return func(opts *consumerOptions) {
+		opts.middlewares = append(opts.middlewares, middlewares...)
+	}
+}
+
+// WithConsumerConcurrency ограничивает число сообщений, обрабатываемых одновременно по всем
+// партициям группы; 0 — без ограничения. Меняется на лету через SetConcurrency.
+func WithConsumerConcurrency(limit int) ConsumerOption {
+	return func(opts *consumerOptions) {
+		opts.concurrency = limit
+	}
+}
+
 // NewConsumer создает новый Kafka consumer
 func NewConsumer(brokers []string, groupID string, topics []string, handler MessageHandler) (*Consumer, error) {
 	return NewConsumerWithDLQ(brokers, groupID, topics, handler, nil, 3)
@@ -36,6 +74,28 @@ func NewConsumer(brokers []string, groupID string, topics []string, handler Mess
 
 // NewConsumerWithDLQ создает consumer с поддержкой Dead Letter Queue
 func NewConsumerWithDLQ(brokers []string, groupID string, topics []string, handler MessageHandler, dlqProducer *Producer, maxRetries int) (*Consumer, error) {
+	if maxRetries <= 0 {
+		maxRetries = 1
+	}
+	policy := DLQPolicyFor(nil, groupID)
+	policy.MaxRetries = maxRetries
+	return NewConsumerWithPolicy(brokers, groupID, topics, handler, dlqProducer, policy)
+}
+
+// NewConsumerWithPolicy создает consumer, повторы и DLQ которого управляются policy.
+// Consumer дополнительно подписывается на policy.RetryTopics.
+func NewConsumerWithPolicy(brokers []string, groupID string, topics []string, handler MessageHandler, dlqProducer *Producer, policy DLQPolicy, options ...ConsumerOption) (*Consumer, error) {
+	if policy.Name == "" {
+		policy.Name = groupID
+	}
+	if err := policy.Validate(); err != nil {
+		return nil, err
+	}
+	opts := consumerOptions{}
+	for _, option := range options {
+		option(&opts)
+	}
+
 	config := sarama.NewConfig()
 	config.Consumer.Group.Rebalance.Strategy = sarama.NewBalanceStrategyRoundRobin()
 	config.Consumer.Offsets.Initial = sarama.OffsetNewest
@@ -46,21 +106,29 @@ func NewConsumerWithDLQ(brokers []string, groupID string, topics []string, handl
 		return nil, fmt.Errorf("failed to create kafka consumer: %w", err)
 	}
 
-	if maxRetries <= 0 {
-		maxRetries = 1
-	}
-
+	consumerMetrics := newConsumerMetrics(opts.registerer)
 	return &Consumer{
 		consumer:    consumer,
-		topics:      topics,
-		handler:     handler,
-		logger:      log.WithField("component", "kafka-consumer"),
+		topics:      withRetryTopics(topics, policy.RetryTopics),
+		handler:     Chain(handler, opts.middlewares...),
+		logger:      log.WithFields(log.Fields{"component": "kafka-consumer", "dlq_policy": policy.Name}),
 		dlqProducer: dlqProducer,
-		maxRetries:  maxRetries,
-		retryDelay:  defaultConsumerRetryDelay,
+		policy:      policy,
+		metrics:     &consumerMetrics,
+		concurrency: newConcurrencyLimit(opts.concurrency),
 	}, nil
 }
 
+func withRetryTopics(topics, retryTopics []string) []string {
+	result := append([]string(nil), topics...)
+	for _, topic := range retryTopics {
+		if !slices.Contains(result, topic) {
+			result = append(result, topic)
+		}
+	}
+	return result
+}
+
 // Start запускает consumer
 func (c *Consumer) Start(ctx context.Context) error {
 	c.wg.Add(1)
@@ -92,6 +160,11 @@ func (c *Consumer) Start(ctx context.Context) error {
 	return nil
 }
 
+// SetConcurrency меняет лимит одновременной обработки без перезапуска consumer'а.
+func (c *Consumer) SetConcurrency(limit int) {
+	c.concurrency.set(limit)
+}
+
 // Stop останавливает consumer
 func (c *Consumer) Stop() error {
 	if err := c.consumer.Close(); err != nil {
@@ -127,8 +200,13 @@ func (c *Consumer) ConsumeClaim(session sarama.ConsumerGroupSession, claim saram
 				"offset":    message.Offset,
 			}).Debug("received message")
 
+			if err := c.concurrency.acquire(session.Context()); err != nil {
+				return nil
+			}
 			// Обрабатываем сообщение с retry и DLQ логикой
-			if err := c.handleMessageWithRetry(session.Context(), message); err != nil {
+			err := c.handleMessageWithRetry(session.Context(), message)
+			c.concurrency.release()
+			if err != nil {
 				c.logger.WithError(err).WithFields(log.Fields{
 					"topic":     message.Topic,
 					"partition": message.Partition,
@@ -147,42 +225,56 @@ func (c *Consumer) ConsumeClaim(session sarama.ConsumerGroupSession, claim saram
 	}
 }
 
-// handleMessageWithRetry обрабатывает сообщение с retry логикой и отправкой в DLQ
+// handleMessageWithRetry обрабатывает сообщение с retry логикой и отправкой в DLQ.
+// x-retry-count — сквозной счётчик попыток: на каждой ступени (основной топик, затем
+// каждый retry-топик) выполняется до policy.MaxRetries попыток.
 func (c *Consumer) handleMessageWithRetry(ctx context.Context, message *sarama.ConsumerMessage) error {
 	// Получаем текущий retry count из headers
 	retryCount := c.getRetryCount(message)
-	maxRetries := c.maxRetries
+	maxRetries := c.policy.MaxRetries
 	if maxRetries <= 0 {
 		maxRetries = 1
 	}
+	stages := len(c.policy.RetryTopics) + 1
 	attempt := retryCount + 1
 
 	// Если upstream уже исчерпал ретраи до нас, уводим сообщение в DLQ.
-	if attempt > maxRetries {
-		return c.handleMaxRetriesExceeded(message, fmt.Errorf("retry_count=%d exceeds max_retries=%d", retryCount, maxRetries))
+	if attempt > maxRetries*stages {
+		return c.handleMaxRetriesExceeded(message, fmt.Errorf("retry_count=%d exceeds max_retries=%d", retryCount, maxRetries*stages))
 	}
-
+	stage := retryCount / maxRetries
+	stageLastAttempt := (stage + 1) * maxRetries
+
+	incoming := ParseHeaders(message)
+	// События outbox, записанные обработчиком (например, сагой после restock), наследуют trace,
+	// tenant и цепочку событий входящего сообщения.
+	handlerCtx := domain.ContextWithEventHeaders(ContextWithHeaders(ctx, incoming), incoming.EventHeaders())
+	handlerCtx = ContextWithEventChain(handlerCtx, incoming)
 	for {
-		err := c.handler(ctx, message)
+		err := c.handler(handlerCtx, message)
 		if err == nil {
 			return nil // Успешно обработано
 		}
 
-		if attempt >= maxRetries {
+		if attempt >= stageLastAttempt {
+			if stage < len(c.policy.RetryTopics) {
+				return c.forwardToRetryTopic(message, c.policy.RetryTopics[stage], attempt, err)
+			}
 			return c.handleMaxRetriesExceeded(message, err)
 		}
 
 		nextAttempt := attempt + 1
+		c.recordDecision(dlqDecisionRetry)
 		c.logger.WithFields(log.Fields{
 			"topic":        message.Topic,
 			"attempt":      attempt,
 			"next_attempt": nextAttempt,
 			"max_retries":  maxRetries,
-			"delay":        c.retryDelay,
+			"delay":        c.policy.RetryDelay,
 			"error":        err,
 		}).Warn("message processing failed, will retry")
 
-		if err := sleepWithContext(ctx, c.retryDelay); err != nil {
+		if err := ctxutil.Sleep(ctx, c.policy.RetryDelay); err != nil {
 			return err
 		}
 
@@ -190,13 +282,45 @@ func (c *Consumer) handleMessageWithRetry(ctx context.Context, message *sarama.C
 	}
 }
 
+// forwardToRetryTopic переносит сообщение на следующую ступень повтора как есть,
+// с обновлённым x-retry-count. Если retry-топик недоступен, сообщение уходит в DLQ.
+func (c *Consumer) forwardToRetryTopic(message *sarama.ConsumerMessage, topic string, attempts int, processingErr error) error {
+	if c.dlqProducer == nil {
+		return c.handleMaxRetriesExceeded(message, processingErr)
+	}
+
+	headers := ParseHeaders(message)
+	headers.RetryCount = attempts
+	extra := make(map[string]string, len(headers.Extra)+2)
+	for key, value := range headers.Extra {
+		extra[key] = value
+	}
+	extra[HeaderOriginalTopic] = originalTopic(message)
+	extra[HeaderErrorMessage] = processingErr.Error()
+	headers.Extra = extra
+
+	if err := c.dlqProducer.publishRaw(topic, string(message.Key), message.Value, headers); err != nil {
+		c.logger.WithError(err).WithField("retry_topic", topic).Warn("failed to forward message to retry topic, sending to DLQ")
+		return c.handleMaxRetriesExceeded(message, processingErr)
+	}
+	c.recordDecision(dlqDecisionRetryTopic)
+	c.logger.WithFields(log.Fields{
+		"topic":       message.Topic,
+		"retry_topic": topic,
+		"retry_count": attempts,
+	}).Info("message forwarded to retry topic")
+	return nil
+}
+
 func (c *Consumer) handleMaxRetriesExceeded(message *sarama.ConsumerMessage, processingErr error) error {
 	// Исчерпаны все попытки - отправляем в DLQ
 	if c.dlqProducer != nil {
 		if dlqErr := c.sendToDLQ(message, processingErr); dlqErr != nil {
+			c.recordDecision(dlqDecisionDeadLetterFailed)
 			c.logger.WithError(dlqErr).Error("failed to send message to DLQ")
 			return fmt.Errorf("failed to send to DLQ: %w", dlqErr)
 		}
+		c.recordDecision(dlqDecisionDeadLetter)
 		c.logger.WithFields(log.Fields{
 			"topic":       message.Topic,
 			"retry_count": c.getRetryCount(message),
@@ -204,73 +328,108 @@ func (c *Consumer) handleMaxRetriesExceeded(message *sarama.ConsumerMessage, pro
 		return nil // Считаем обработанным, так как отправили в DLQ
 	}
 
+	c.recordDecision(dlqDecisionNoDeadLetter)
 	return processingErr
 }
 
-func sleepWithContext(ctx context.Context, delay time.Duration) error {
-	if delay <= 0 {
-		return nil
+func (c *Consumer) recordDecision(decision string) {
+	if c.metrics == nil {
+		return
 	}
+	c.metrics.decisions.WithLabelValues(c.policy.Name, decision).Inc()
+}
 
-	timer := time.NewTimer(delay)
-	defer timer.Stop()
-
-	select {
-	case <-ctx.Done():
-		return ctx.Err()
-	case <-timer.C:
-		return nil
+// originalTopic возвращает топик, в который сообщение попало изначально (до retry-топиков).
+func originalTopic(message *sarama.ConsumerMessage) string {
+	if topic, ok := HeaderValue(message, HeaderOriginalTopic); ok && topic != "" {
+		return topic
 	}
+	return message.Topic
 }
 
 // getRetryCount извлекает retry count из headers сообщения
 func (c *Consumer) getRetryCount(message *sarama.ConsumerMessage) int {
-	for _, header := range message.Headers {
-		if string(header.Key) == HeaderRetryCount {
-			count, err := strconv.Atoi(string(header.Value))
-			if err == nil {
-				return count
-			}
-		}
-	}
-	return 0
+	return RetryCount(message)
 }
 
 // sendToDLQ отправляет failed message в Dead Letter Queue
 func (c *Consumer) sendToDLQ(message *sarama.ConsumerMessage, processingErr error) error {
+	failedAt := timeutil.Format(timeutil.Now())
+	retryCount := c.getRetryCount(message)
+	sourceTopic := originalTopic(message)
+
+	value := message.Value
+	if c.policy.Redact != nil {
+		value = c.policy.Redact(value)
+	}
+
 	// Создаём DLQ message с дополнительными headers
 	dlqMessage := map[string]interface{}{
-		"original_topic":     message.Topic,
+		"original_topic":     sourceTopic,
 		"original_partition": message.Partition,
 		"original_offset":    message.Offset,
 		"original_key":       string(message.Key),
-		"original_value":     string(message.Value),
+		"original_value":     string(value),
 		"error_message":      processingErr.Error(),
-		"failed_at":          time.Now().UTC().Format(time.RFC3339),
-		"retry_count":        c.getRetryCount(message),
+		"failed_at":          failedAt,
+		"retry_count":        retryCount,
+	}
+	// Бинарный protobuf в JSON-строке испортился бы, поэтому он сохраняется в base64
+	// вместе с content-type: cmd/dlq-reprocess вернёт сообщение в исходном формате.
+	// После Redact payload уже заменён текстом, и этот случай не возникает.
+	contentType, _ := HeaderValue(message, HeaderContentType)
+	if _, binary := DetectCodec(contentType, message.Value).(ProtobufCodec); binary && c.policy.Redact == nil {
+		delete(dlqMessage, "original_value")
+		dlqMessage["original_value_base64"] = base64.StdEncoding.EncodeToString(value)
+		dlqMessage["original_content_type"] = ContentTypeProtobuf
 	}
 
-	// Отправляем в DLQ topic
-	return c.dlqProducer.PublishEvent(
-		TopicDeadLetterQueue,
+	headers := MessageHeaders{
+		RetryCount: retryCount,
+		Extra: map[string]string{
+			HeaderOriginalTopic: sourceTopic,
+			HeaderErrorMessage:  processingErr.Error(),
+			HeaderFailedAt:      failedAt,
+		},
+	}
+	// Стандартные headers исходного сообщения сохраняются, чтобы DLQ-запись
+	// можно было связать с исходным событием и трассой.
+	if c.policy.IncludeHeaders {
+		original := ParseHeaders(message)
+		headers.EventID = original.EventID
+		headers.EventType = original.EventType
+		headers.SchemaVersion = original.SchemaVersion
+		headers.OccurredAt = original.OccurredAt
+		headers.TraceParent = original.TraceParent
+		headers.TraceState = original.TraceState
+		headers.TenantID = original.TenantID
+	}
+
+	topic := c.policy.DeadLetterTopic
+	if topic == "" {
+		topic = TopicDeadLetterQueue
+	}
+	return c.dlqProducer.PublishEventWithHeaders(
+		topic,
 		string(message.Key),
 		dlqMessage,
+		headers,
 	)
 }
 
-// ParseSagaEvent парсит SagaEvent из сообщения
+// ParseSagaEvent парсит SagaEvent из сообщения в формате JSON или protobuf (см. DecodeMessage).
 func ParseSagaEvent(message *sarama.ConsumerMessage) (*SagaEvent, error) {
 	var event SagaEvent
-	if err := json.Unmarshal(message.Value, &event); err != nil {
+	if err := DecodeMessage(message, &event); err != nil {
 		return nil, fmt.Errorf("failed to unmarshal saga event: %w", err)
 	}
 	return &event, nil
 }
 
-// ParseOrderEvent парсит OrderEvent из сообщения
+// ParseOrderEvent парсит OrderEvent из сообщения в формате JSON или protobuf.
 func ParseOrderEvent(message *sarama.ConsumerMessage) (*OrderEvent, error) {
 	var event OrderEvent
-	if err := json.Unmarshal(message.Value, &event); err != nil {
+	if err := DecodeMessage(message, &event); err != nil {
 		return nil, fmt.Errorf("failed to unmarshal order event: %w", err)
 	}
 	return &event, nil
@@ -98,11 +98,11 @@ func TestConsumerStartStop(t *testing.T) {
 	}
 
 	consumer := &Consumer{
-		consumer:   group,
-		topics:     []string{"topic-a"},
-		handler:    func(context.Context, *sarama.ConsumerMessage) error { return nil },
-		logger:     log.WithField("test", "consumer"),
-		maxRetries: 2,
+		consumer: group,
+		topics:   []string{"topic-a"},
+		handler:  func(context.Context, *sarama.ConsumerMessage) error { return nil },
+		logger:   log.WithField("test", "consumer"),
+		policy:   DLQPolicy{MaxRetries: 2},
 	}
 
 	errorsCh <- errors.New("background error")
@@ -166,9 +166,9 @@ func TestConsumeClaimFailedHandler(t *testing.T) {
 	defer cancel()
 
 	consumer := &Consumer{
-		handler:    func(context.Context, *sarama.ConsumerMessage) error { return errors.New("failed") },
-		logger:     log.WithField("test", "claim-fail"),
-		maxRetries: 1,
+		handler: func(context.Context, *sarama.ConsumerMessage) error { return errors.New("failed") },
+		logger:  log.WithField("test", "claim-fail"),
+		policy:  DLQPolicy{MaxRetries: 1},
 	}
 
 	session := &mockSession{ctx: ctx}
@@ -189,9 +189,9 @@ func TestHandleMessageWithRetry(t *testing.T) {
 
 	t.Run("success", func(t *testing.T) {
 		consumer := &Consumer{
-			handler:    func(context.Context, *sarama.ConsumerMessage) error { return nil },
-			logger:     log.WithField("test", "retry-success"),
-			maxRetries: 2,
+			handler: func(context.Context, *sarama.ConsumerMessage) error { return nil },
+			logger:  log.WithField("test", "retry-success"),
+			policy:  DLQPolicy{MaxRetries: 2},
 		}
 		if err := consumer.handleMessageWithRetry(context.Background(), msg); err != nil {
 			t.Fatalf("unexpected error: %v", err)
@@ -211,9 +211,8 @@ func TestHandleMessageWithRetry(t *testing.T) {
 				attempts++
 				return errors.New("temporary")
 			},
-			logger:     log.WithField("test", "retry"),
-			maxRetries: 3,
-			retryDelay: 0,
+			logger: log.WithField("test", "retry"),
+			policy: DLQPolicy{MaxRetries: 3},
 		}
 		if err := consumer.handleMessageWithRetry(context.Background(), retryingMessage); err == nil {
 			t.Fatal("expected retry error")
@@ -231,9 +230,9 @@ func TestHandleMessageWithRetry(t *testing.T) {
 			Headers: []*sarama.RecordHeader{{Key: []byte(HeaderRetryCount), Value: []byte("3")}},
 		}
 		consumer := &Consumer{
-			handler:    func(context.Context, *sarama.ConsumerMessage) error { return errors.New("permanent") },
-			logger:     log.WithField("test", "max-no-dlq"),
-			maxRetries: 3,
+			handler: func(context.Context, *sarama.ConsumerMessage) error { return errors.New("permanent") },
+			logger:  log.WithField("test", "max-no-dlq"),
+			policy:  DLQPolicy{MaxRetries: 3},
 		}
 		if err := consumer.handleMessageWithRetry(context.Background(), retryingMessage); err == nil {
 			t.Fatal("expected error when dlq is absent")
@@ -253,7 +252,7 @@ func TestHandleMessageWithRetry(t *testing.T) {
 			handler:     func(context.Context, *sarama.ConsumerMessage) error { return errors.New("permanent") },
 			dlqProducer: &Producer{producer: mockProducer, logger: log.WithField("test", "dlq")},
 			logger:      log.WithField("test", "max-dlq"),
-			maxRetries:  3,
+			policy:      DLQPolicy{MaxRetries: 3},
 		}
 		if err := consumer.handleMessageWithRetry(context.Background(), retryingMessage); err != nil {
 			t.Fatalf("unexpected error after dlq publish: %v", err)
@@ -276,7 +275,7 @@ func TestHandleMessageWithRetry(t *testing.T) {
 			handler:     func(context.Context, *sarama.ConsumerMessage) error { return errors.New("permanent") },
 			dlqProducer: &Producer{producer: mockProducer, logger: log.WithField("test", "dlq")},
 			logger:      log.WithField("test", "max-dlq-fail"),
-			maxRetries:  3,
+			policy:      DLQPolicy{MaxRetries: 3},
 		}
 		if err := consumer.handleMessageWithRetry(context.Background(), retryingMessage); err == nil {
 			t.Fatal("expected dlq failure")
@@ -339,9 +338,9 @@ func TestSendToDLQ(t *testing.T) {
 func TestConsumeClaimStopsOnContextDone(t *testing.T) {
 	ctx, cancel := context.WithCancel(context.Background())
 	consumer := &Consumer{
-		handler:    func(context.Context, *sarama.ConsumerMessage) error { return nil },
-		logger:     log.WithField("test", "claim-stop"),
-		maxRetries: 1,
+		handler: func(context.Context, *sarama.ConsumerMessage) error { return nil },
+		logger:  log.WithField("test", "claim-stop"),
+		policy:  DLQPolicy{MaxRetries: 1},
 	}
 	session := &mockSession{ctx: ctx}
 	claim := &mockClaim{topic: "topic", partition: 0, messages: make(chan *sarama.ConsumerMessage)}
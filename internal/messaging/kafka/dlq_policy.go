@@ -0,0 +1,256 @@
+package kafka
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+
+	"github.com/vladislavdragonenkov/oms/internal/metrics"
+)
+
+// ErrInvalidDLQPolicy — политика DLQ задана некорректно.
+var ErrInvalidDLQPolicy = errors.New("kafka: invalid dlq policy")
+
+// RedactFunc маскирует персональные данные в payload перед записью в DLQ.
+type RedactFunc func(value []byte) []byte
+
+// DLQPolicy — правила повторов и dead-letter для одной consumer group.
+type DLQPolicy struct {
+	// Name — метка политики в метриках; по умолчанию имя consumer group.
+	Name string
+	// MaxRetries — число попыток обработки на каждой ступени (основной топик и каждый retry-топик).
+	MaxRetries int
+	// RetryDelay — пауза между попытками внутри ступени.
+	RetryDelay time.Duration
+	// RetryTopics — ступени отложенного повтора. Исчерпав попытки, сообщение уходит в следующий
+	// retry-топик, а после последнего — в DeadLetterTopic. Consumer подписывается на них сам.
+	RetryTopics []string
+	// DeadLetterTopic — топик DLQ.
+	DeadLetterTopic string
+	// IncludeHeaders переносит в DLQ стандартные headers исходного сообщения (event-id, trace, tenant).
+	IncludeHeaders bool
+	// Redact, если задан, применяется к payload перед записью в DLQ; в retry-топики payload идёт как есть.
+	Redact RedactFunc
+}
+
+// DefaultDLQPolicy возвращает политику, совпадающую с прежним поведением consumer'а.
+func DefaultDLQPolicy() DLQPolicy {
+	return DLQPolicy{
+		MaxRetries:      3,
+		RetryDelay:      defaultConsumerRetryDelay,
+		DeadLetterTopic: TopicDeadLetterQueue,
+		IncludeHeaders:  true,
+	}
+}
+
+// Validate проверяет политику.
+func (p DLQPolicy) Validate() error {
+	if p.MaxRetries <= 0 {
+		return fmt.Errorf("%w: max_retries must be > 0", ErrInvalidDLQPolicy)
+	}
+	if p.RetryDelay < 0 {
+		return fmt.Errorf("%w: retry_delay must be >= 0", ErrInvalidDLQPolicy)
+	}
+	if strings.TrimSpace(p.DeadLetterTopic) == "" {
+		return fmt.Errorf("%w: dlq topic is required", ErrInvalidDLQPolicy)
+	}
+	if err := validateName("dlq topic", p.DeadLetterTopic); err != nil {
+		return fmt.Errorf("%w: %v", ErrInvalidDLQPolicy, err)
+	}
+	seen := make(map[string]struct{}, len(p.RetryTopics))
+	for _, topic := range p.RetryTopics {
+		if strings.TrimSpace(topic) == "" {
+			return fmt.Errorf("%w: retry topic must not be empty", ErrInvalidDLQPolicy)
+		}
+		if err := validateName("retry topic", topic); err != nil {
+			return fmt.Errorf("%w: %v", ErrInvalidDLQPolicy, err)
+		}
+		if topic == p.DeadLetterTopic {
+			return fmt.Errorf("%w: retry topic %q equals dlq topic", ErrInvalidDLQPolicy, topic)
+		}
+		if _, ok := seen[topic]; ok {
+			return fmt.Errorf("%w: duplicate retry topic %q", ErrInvalidDLQPolicy, topic)
+		}
+		seen[topic] = struct{}{}
+	}
+	return nil
+}
+
+// Решения политики, попадающие в метрику oms_kafka_dlq_policy_decisions_total.
+const (
+	dlqDecisionRetry            = "retry"
+	dlqDecisionRetryTopic       = "retry_topic"
+	dlqDecisionDeadLetter       = "dead_letter"
+	dlqDecisionDeadLetterFailed = "dead_letter_failed"
+	dlqDecisionNoDeadLetter     = "no_dead_letter"
+)
+
+type consumerMetrics struct {
+	decisions *prometheus.CounterVec
+}
+
+func newConsumerMetrics(registerer prometheus.Registerer) consumerMetrics {
+	return consumerMetrics{
+		decisions: metrics.Register(registerer, prometheus.NewCounterVec(prometheus.CounterOpts{
+			Name: "oms_kafka_dlq_policy_decisions_total",
+			Help: "Total number of retry/dead-letter decisions made by consumer DLQ policies.",
+		}, []string{"policy", "decision"})),
+	}
+}
+
+// ParseDLQPolicies разбирает политики из конфигурации в формате
+// "group:max_retries=5,retry_delay=1s,retry_topics=a|b,dlq_topic=oms.dlq,include_headers=false,redact=pii;other:...".
+// Незаданные параметры берутся из DefaultDLQPolicy; ключ результата — consumer group.
+func ParseDLQPolicies(raw string) (map[string]DLQPolicy, error) {
+	policies := make(map[string]DLQPolicy)
+	for _, entry := range strings.Split(raw, ";") {
+		entry = strings.TrimSpace(entry)
+		if entry == "" {
+			continue
+		}
+		group, params, found := strings.Cut(entry, ":")
+		group = strings.TrimSpace(group)
+		if !found || group == "" {
+			return nil, fmt.Errorf("%w: expected group:key=value, got %q", ErrInvalidDLQPolicy, entry)
+		}
+		if _, ok := policies[group]; ok {
+			return nil, fmt.Errorf("%w: duplicate policy for group %q", ErrInvalidDLQPolicy, group)
+		}
+
+		policy := DefaultDLQPolicy()
+		policy.Name = group
+		for _, part := range strings.Split(params, ",") {
+			part = strings.TrimSpace(part)
+			if part == "" {
+				continue
+			}
+			key, value, found := strings.Cut(part, "=")
+			if !found {
+				return nil, fmt.Errorf("%w: group %s: expected key=value, got %q", ErrInvalidDLQPolicy, group, part)
+			}
+			if err := policy.set(strings.ToLower(strings.TrimSpace(key)), strings.TrimSpace(value)); err != nil {
+				return nil, fmt.Errorf("%w: group %s: %v", ErrInvalidDLQPolicy, group, err)
+			}
+		}
+		if err := policy.Validate(); err != nil {
+			return nil, fmt.Errorf("group %s: %w", group, err)
+		}
+		policies[group] = policy
+	}
+	return policies, nil
+}
+
+func (p *DLQPolicy) set(key, value string) error {
+	switch key {
+	case "max_retries":
+		n, err := strconv.Atoi(value)
+		if err != nil {
+			return fmt.Errorf("max_retries: %w", err)
+		}
+		p.MaxRetries = n
+	case "retry_delay":
+		d, err := time.ParseDuration(value)
+		if err != nil {
+			return fmt.Errorf("retry_delay: %w", err)
+		}
+		p.RetryDelay = d
+	case "retry_topics":
+		p.RetryTopics = nil
+		for _, topic := range strings.Split(value, "|") {
+			if topic = strings.TrimSpace(topic); topic != "" {
+				p.RetryTopics = append(p.RetryTopics, topic)
+			}
+		}
+	case "dlq_topic":
+		p.DeadLetterTopic = value
+	case "include_headers":
+		switch strings.ToLower(value) {
+		case "1", "true", "yes", "on":
+			p.IncludeHeaders = true
+		case "0", "false", "no", "off":
+			p.IncludeHeaders = false
+		default:
+			return fmt.Errorf("include_headers: invalid boolean value %q", value)
+		}
+	case "redact":
+		redact, ok := redactors[strings.ToLower(value)]
+		if !ok {
+			return fmt.Errorf("redact: unknown redactor %q", value)
+		}
+		p.Redact = redact
+	default:
+		return fmt.Errorf("unknown key %q", key)
+	}
+	return nil
+}
+
+// DLQPolicyFor возвращает политику группы из policies или DefaultDLQPolicy.
+func DLQPolicyFor(policies map[string]DLQPolicy, group string) DLQPolicy {
+	if policy, ok := policies[group]; ok {
+		return policy
+	}
+	policy := DefaultDLQPolicy()
+	policy.Name = group
+	return policy
+}
+
+// PIIFields — поля payload, которые redact=pii маскирует на любой глубине JSON.
+var PIIFields = []string{"customer_id", "email", "phone", "address", "name", "first_name", "last_name"}
+
+const redactedValue = "[REDACTED]"
+
+// redactors — именованные RedactFunc, доступные из конфигурации.
+var redactors = map[string]RedactFunc{
+	"none": nil,
+	"pii":  RedactJSONFields(PIIFields...),
+}
+
+// RedactJSONFields возвращает RedactFunc, заменяющую значения указанных полей JSON
+// (без учёта регистра) на "[REDACTED]". Payload, который не разбирается как JSON,
+// заменяется целиком: иначе PII могла бы утечь в DLQ как есть.
+func RedactJSONFields(fields ...string) RedactFunc {
+	names := make(map[string]struct{}, len(fields))
+	for _, field := range fields {
+		names[strings.ToLower(field)] = struct{}{}
+	}
+	return func(value []byte) []byte {
+		decoder := json.NewDecoder(bytes.NewReader(value))
+		decoder.UseNumber()
+		var payload any
+		if err := decoder.Decode(&payload); err != nil {
+			return []byte(redactedValue)
+		}
+		redacted, err := json.Marshal(redactJSON(payload, names))
+		if err != nil {
+			return []byte(redactedValue)
+		}
+		return redacted
+	}
+}
+
+func redactJSON(value any, names map[string]struct{}) any {
+	switch v := value.(type) {
+	case map[string]any:
+		for key, nested := range v {
+			if _, ok := names[strings.ToLower(key)]; ok {
+				v[key] = redactedValue
+				continue
+			}
+			v[key] = redactJSON(nested, names)
+		}
+		return v
+	case []any:
+		for i := range v {
+			v[i] = redactJSON(v[i], names)
+		}
+		return v
+	default:
+		return value
+	}
+}
@@ -0,0 +1,154 @@
+package kafka
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/IBM/sarama"
+	"github.com/IBM/sarama/mocks"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	log "github.com/sirupsen/logrus"
+)
+
+func TestParseDLQPolicies(t *testing.T) {
+	policies, err := ParseDLQPolicies("oms-backorders:max_retries=5,retry_delay=2s,retry_topics=restock.retry.1|restock.retry.2,include_headers=false,redact=pii; other:dlq_topic=other.dlq")
+	if err != nil {
+		t.Fatalf("parse: %v", err)
+	}
+
+	backorders := policies["oms-backorders"]
+	if backorders.Name != "oms-backorders" || backorders.MaxRetries != 5 || backorders.RetryDelay != 2*time.Second {
+		t.Fatalf("unexpected policy: %+v", backorders)
+	}
+	if len(backorders.RetryTopics) != 2 || backorders.RetryTopics[1] != "restock.retry.2" {
+		t.Fatalf("unexpected retry topics: %v", backorders.RetryTopics)
+	}
+	if backorders.IncludeHeaders || backorders.Redact == nil || backorders.DeadLetterTopic != TopicDeadLetterQueue {
+		t.Fatalf("unexpected policy flags: %+v", backorders)
+	}
+
+	other := DLQPolicyFor(policies, "other")
+	if other.DeadLetterTopic != "other.dlq" || other.MaxRetries != 3 || !other.IncludeHeaders {
+		t.Fatalf("expected defaults for unset keys, got %+v", other)
+	}
+	if fallback := DLQPolicyFor(policies, "unknown"); fallback.Name != "unknown" || fallback.MaxRetries != 3 {
+		t.Fatalf("unexpected fallback policy: %+v", fallback)
+	}
+}
+
+func TestParseDLQPolicies_Invalid(t *testing.T) {
+	for _, raw := range []string{
+		"no-colon",
+		"g:max_retries=0",
+		"g:max_retries=x",
+		"g:retry_topics=oms.dlq",
+		"g:retry_topics=a|a",
+		"g:unknown=1",
+		"g:redact=ssn",
+		"g:include_headers=maybe",
+		"g:max_retries=1;g:max_retries=2",
+	} {
+		if _, err := ParseDLQPolicies(raw); !errors.Is(err, ErrInvalidDLQPolicy) {
+			t.Fatalf("%q: expected ErrInvalidDLQPolicy, got %v", raw, err)
+		}
+	}
+}
+
+func TestRedactJSONFields(t *testing.T) {
+	redact := RedactJSONFields(PIIFields...)
+
+	got := redact([]byte(`{"order_id":"o-1","Customer_ID":"c-1","items":[{"sku":"a","address":{"city":"x"}}],"qty":3}`))
+	var payload map[string]any
+	if err := json.Unmarshal(got, &payload); err != nil {
+		t.Fatalf("redacted payload is not JSON: %v", err)
+	}
+	if payload["order_id"] != "o-1" || payload["Customer_ID"] != redactedValue {
+		t.Fatalf("unexpected redaction: %s", got)
+	}
+	if strings.Contains(string(got), `"city"`) || !strings.Contains(string(got), `"qty":3`) {
+		t.Fatalf("nested fields must be redacted and numbers kept: %s", got)
+	}
+
+	if got := redact([]byte("not json: john@example.com")); string(got) != redactedValue {
+		t.Fatalf("non-JSON payload must be redacted entirely, got %q", got)
+	}
+}
+
+func TestConsumer_RetryTopicsThenDLQWithRedaction(t *testing.T) {
+	mockProducer := mocks.NewSyncProducer(t, nil)
+	var sent []*sarama.ProducerMessage
+	capture := func(msg *sarama.ProducerMessage) error {
+		sent = append(sent, msg)
+		return nil
+	}
+	mockProducer.ExpectSendMessageWithMessageCheckerFunctionAndSucceed(capture)
+	mockProducer.ExpectSendMessageWithMessageCheckerFunctionAndSucceed(capture)
+
+	registry := prometheus.NewRegistry()
+	consumerMetrics := newConsumerMetrics(registry)
+	attempts := 0
+	consumer := &Consumer{
+		handler: func(context.Context, *sarama.ConsumerMessage) error {
+			attempts++
+			return errors.New("boom")
+		},
+		logger:      log.WithField("test", "retry-topics"),
+		dlqProducer: &Producer{producer: mockProducer, logger: log.WithField("test", "retry-topics-producer")},
+		policy: DLQPolicy{
+			Name:            "restock",
+			MaxRetries:      2,
+			RetryTopics:     []string{"restock.retry"},
+			DeadLetterTopic: "restock.dlq",
+			Redact:          RedactJSONFields("customer_id"),
+		},
+		metrics: &consumerMetrics,
+	}
+
+	msg := &sarama.ConsumerMessage{Topic: "restock", Key: []byte("k"), Value: []byte(`{"customer_id":"c-1"}`)}
+	if err := consumer.handleMessageWithRetry(context.Background(), msg); err != nil {
+		t.Fatalf("first stage: %v", err)
+	}
+	if attempts != 2 || len(sent) != 1 || sent[0].Topic != "restock.retry" {
+		t.Fatalf("expected forward to retry topic after 2 attempts, attempts=%d sent=%d", attempts, len(sent))
+	}
+
+	// Сообщение из retry-топика: после своей ступени уходит в DLQ с замаскированным payload.
+	retried := consumerMessageFromRecords(sent[0].Headers)
+	retried.Topic, retried.Key, retried.Value = "restock.retry", []byte("k"), msg.Value
+	if err := consumer.handleMessageWithRetry(context.Background(), retried); err != nil {
+		t.Fatalf("retry stage: %v", err)
+	}
+	if err := mockProducer.Close(); err != nil {
+		t.Fatal(err)
+	}
+	if attempts != 4 || len(sent) != 2 || sent[1].Topic != "restock.dlq" {
+		t.Fatalf("expected DLQ after retry stage, attempts=%d sent=%d", attempts, len(sent))
+	}
+
+	value, err := sent[1].Value.Encode()
+	if err != nil {
+		t.Fatal(err)
+	}
+	var dlq map[string]any
+	if err := json.Unmarshal(value, &dlq); err != nil {
+		t.Fatal(err)
+	}
+	if dlq["original_topic"] != "restock" || strings.Contains(dlq["original_value"].(string), "c-1") {
+		t.Fatalf("unexpected DLQ payload: %v", dlq)
+	}
+
+	for decision, want := range map[string]float64{
+		dlqDecisionRetry:      2,
+		dlqDecisionRetryTopic: 1,
+		dlqDecisionDeadLetter: 1,
+	} {
+		if got := testutil.ToFloat64(consumerMetrics.decisions.WithLabelValues("restock", decision)); got != want {
+			t.Fatalf("decision %s: got %v, want %v", decision, got, want)
+		}
+	}
+}
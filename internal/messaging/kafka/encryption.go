@@ -0,0 +1,391 @@
+package kafka
+
+import (
+	"bytes"
+	"context"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+
+	"github.com/vladislavdragonenkov/oms/internal/keyring"
+)
+
+var (
+	// ErrUnknownEncryptionKey — payload зашифрован ключом, которого нет в KeyWrapper.
+	ErrUnknownEncryptionKey = errors.New("kafka: unknown encryption key")
+	// ErrDecryptFailed — зашифрованное поле не удалось расшифровать (чужой ключ или подмена данных).
+	ErrDecryptFailed = errors.New("kafka: decrypt failed")
+	// ErrEncryptionKeyRequired — payload зашифрован, а KeyWrapper не передан.
+	ErrEncryptionKeyRequired = errors.New("kafka: encrypted payload requires key wrapper")
+)
+
+const (
+	// EncryptionAlgorithm — алгоритм шифрования полей и обёртки data key.
+	EncryptionAlgorithm = "AES-256-GCM"
+	// encryptedValuePrefix отмечает зашифрованное значение поля; версия нужна для смены формата.
+	encryptedValuePrefix = "enc:v1:"
+	dataKeySize          = 32
+)
+
+// KeyWrapper — граница с KMS: оборачивает и разворачивает data key, которым шифруются поля.
+// Сам мастер-ключ за пределы реализации не выходит.
+type KeyWrapper interface {
+	WrapKey(ctx context.Context, dataKey []byte) (keyID string, wrapped []byte, err error)
+	UnwrapKey(ctx context.Context, keyID string, wrapped []byte) ([]byte, error)
+}
+
+// StaticKeyWrapper — KeyWrapper на ключах из конфигурации (env/secret-файл).
+// Оборачивает primary-ключом, разворачивает любым известным — так работает ротация.
+type StaticKeyWrapper struct {
+	primary string
+	keys    map[string]cipher.AEAD
+}
+
+// ParseStaticKeyWrapper разбирает ключи в формате keyring.ParseKeys, где секрет —
+// base64 от 32 случайных байт: "kid1:base64,kid2:base64". Первый ключ — primary.
+func ParseStaticKeyWrapper(spec string) (*StaticKeyWrapper, error) {
+	keys, err := keyring.ParseKeys(spec)
+	if err != nil {
+		return nil, err
+	}
+	return NewStaticKeyWrapper(keys)
+}
+
+// NewStaticKeyWrapper создаёт StaticKeyWrapper; keys[0] используется для новых сообщений.
+func NewStaticKeyWrapper(keys []keyring.Key) (*StaticKeyWrapper, error) {
+	if len(keys) == 0 {
+		return nil, keyring.ErrNoKeys
+	}
+	wrapper := &StaticKeyWrapper{primary: keys[0].ID, keys: make(map[string]cipher.AEAD, len(keys))}
+	for _, key := range keys {
+		secret, err := base64.StdEncoding.DecodeString(string(key.Secret))
+		if err != nil {
+			return nil, fmt.Errorf("kafka: encryption key %s: secret must be base64: %w", key.ID, err)
+		}
+		if len(secret) != dataKeySize {
+			return nil, fmt.Errorf("kafka: encryption key %s: expected %d bytes, got %d", key.ID, dataKeySize, len(secret))
+		}
+		aead, err := newAEAD(secret)
+		if err != nil {
+			return nil, fmt.Errorf("kafka: encryption key %s: %w", key.ID, err)
+		}
+		wrapper.keys[key.ID] = aead
+	}
+	return wrapper, nil
+}
+
+// WrapKey шифрует data key primary-ключом.
+func (w *StaticKeyWrapper) WrapKey(_ context.Context, dataKey []byte) (string, []byte, error) {
+	wrapped, err := seal(w.keys[w.primary], dataKey, []byte(w.primary))
+	if err != nil {
+		return "", nil, err
+	}
+	return w.primary, wrapped, nil
+}
+
+// UnwrapKey расшифровывает data key ключом keyID.
+func (w *StaticKeyWrapper) UnwrapKey(_ context.Context, keyID string, wrapped []byte) ([]byte, error) {
+	aead, ok := w.keys[keyID]
+	if !ok {
+		return nil, fmt.Errorf("%w: %s", ErrUnknownEncryptionKey, keyID)
+	}
+	return open(aead, wrapped, []byte(keyID))
+}
+
+var _ KeyWrapper = (*StaticKeyWrapper)(nil)
+
+// EncryptionInfo описывает шифрование payload и публикуется вместе с ним в envelope,
+// поэтому сообщение остаётся расшифровываемым и после DLQ/replay.
+type EncryptionInfo struct {
+	Algorithm  string `json:"alg"`
+	KeyID      string `json:"key_id"`
+	WrappedKey []byte `json:"wrapped_key"`
+	// Fields — JSON Pointer зашифрованных полей payload.
+	Fields []string `json:"fields"`
+}
+
+// FieldEncryptor шифрует выбранные поля JSON payload (envelope encryption): на каждое
+// сообщение генерируется свой data key, который оборачивается через KeyWrapper.
+type FieldEncryptor struct {
+	wrapper KeyWrapper
+	fields  map[string]struct{}
+}
+
+// NewFieldEncryptor создаёт шифратор полей; имена полей сравниваются без учёта регистра
+// на любой глубине JSON, как в RedactJSONFields.
+func NewFieldEncryptor(wrapper KeyWrapper, fields ...string) (*FieldEncryptor, error) {
+	if wrapper == nil {
+		return nil, ErrEncryptionKeyRequired
+	}
+	names := make(map[string]struct{}, len(fields))
+	for _, field := range fields {
+		if field = strings.ToLower(strings.TrimSpace(field)); field != "" {
+			names[field] = struct{}{}
+		}
+	}
+	if len(names) == 0 {
+		return nil, errors.New("kafka: field encryptor requires at least one field")
+	}
+	return &FieldEncryptor{wrapper: wrapper, fields: names}, nil
+}
+
+// ParseEncryptedFields разбирает список полей через запятую.
+func ParseEncryptedFields(raw string) []string {
+	var fields []string
+	for _, field := range strings.Split(raw, ",") {
+		if field = strings.TrimSpace(field); field != "" {
+			fields = append(fields, field)
+		}
+	}
+	return fields
+}
+
+// Encrypt заменяет значения настроенных полей на "enc:v1:<base64>". eventID входит в
+// associated data, поэтому зашифрованное поле нельзя перенести в другое событие.
+// Если подходящих полей нет, payload возвращается без изменений и info == nil.
+func (e *FieldEncryptor) Encrypt(ctx context.Context, eventID string, payload []byte) ([]byte, *EncryptionInfo, error) {
+	document, err := decodeJSON(payload)
+	if err != nil {
+		// Не-JSON payload не публикуем: иначе поля с PII ушли бы в Kafka открытыми.
+		return nil, nil, fmt.Errorf("kafka: encrypt payload: %w", err)
+	}
+
+	dataKey := make([]byte, dataKeySize)
+	if _, err := io.ReadFull(rand.Reader, dataKey); err != nil {
+		return nil, nil, fmt.Errorf("kafka: generate data key: %w", err)
+	}
+	aead, err := newAEAD(dataKey)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var encrypted []string
+	document, err = transformFields(document, "", func(_, key string) bool {
+		_, ok := e.fields[strings.ToLower(key)]
+		return ok
+	}, func(path string, value any) (any, error) {
+		plain, err := json.Marshal(value)
+		if err != nil {
+			return nil, err
+		}
+		sealed, err := seal(aead, plain, fieldAAD(eventID, path))
+		if err != nil {
+			return nil, err
+		}
+		encrypted = append(encrypted, path)
+		return encryptedValuePrefix + base64.StdEncoding.EncodeToString(sealed), nil
+	})
+	if err != nil {
+		return nil, nil, fmt.Errorf("kafka: encrypt payload: %w", err)
+	}
+	if len(encrypted) == 0 {
+		return payload, nil, nil
+	}
+
+	keyID, wrapped, err := e.wrapper.WrapKey(ctx, dataKey)
+	if err != nil {
+		return nil, nil, fmt.Errorf("kafka: wrap data key: %w", err)
+	}
+	out, err := json.Marshal(document)
+	if err != nil {
+		return nil, nil, fmt.Errorf("kafka: encrypt payload: %w", err)
+	}
+	sort.Strings(encrypted)
+	return out, &EncryptionInfo{Algorithm: EncryptionAlgorithm, KeyID: keyID, WrappedKey: wrapped, Fields: encrypted}, nil
+}
+
+// EncryptSagaEvent возвращает копию события с зашифрованными полями Metadata; исходное
+// событие не меняется. Associated data — order_id и тип события.
+func (e *FieldEncryptor) EncryptSagaEvent(ctx context.Context, event *SagaEvent) (*SagaEvent, error) {
+	if event == nil || len(event.Metadata) == 0 {
+		return event, nil
+	}
+	raw, err := json.Marshal(event.Metadata)
+	if err != nil {
+		return nil, fmt.Errorf("kafka: encrypt saga event: %w", err)
+	}
+	encrypted, info, err := e.Encrypt(ctx, sagaEventAAD(event), raw)
+	if err != nil || info == nil {
+		return event, err
+	}
+	var metadata map[string]interface{}
+	if err := json.Unmarshal(encrypted, &metadata); err != nil {
+		return nil, fmt.Errorf("kafka: encrypt saga event: %w", err)
+	}
+	copied := *event
+	copied.Metadata = metadata
+	copied.Encryption = info
+	return &copied, nil
+}
+
+// DecryptSagaEvent расшифровывает Metadata события, прочитанного из oms.saga.events, на месте.
+func DecryptSagaEvent(ctx context.Context, wrapper KeyWrapper, event *SagaEvent) error {
+	if event == nil || event.Encryption == nil {
+		return nil
+	}
+	raw, err := json.Marshal(event.Metadata)
+	if err != nil {
+		return fmt.Errorf("kafka: decrypt saga event: %w", err)
+	}
+	decrypted, err := DecryptFields(ctx, wrapper, sagaEventAAD(event), raw, event.Encryption)
+	if err != nil {
+		return err
+	}
+	var metadata map[string]interface{}
+	if err := json.Unmarshal(decrypted, &metadata); err != nil {
+		return fmt.Errorf("kafka: decrypt saga event: %w", err)
+	}
+	event.Metadata = metadata
+	event.Encryption = nil
+	return nil
+}
+
+func sagaEventAAD(event *SagaEvent) string {
+	return event.OrderID + "/" + string(event.EventType)
+}
+
+// DecryptFields восстанавливает поля, перечисленные в info. Consumer'ы вызывают его сами
+// или через DecodeOutboxEnvelope; info == nil означает незашифрованный payload.
+func DecryptFields(ctx context.Context, wrapper KeyWrapper, eventID string, payload []byte, info *EncryptionInfo) ([]byte, error) {
+	if info == nil || len(info.Fields) == 0 {
+		return payload, nil
+	}
+	if wrapper == nil {
+		return nil, ErrEncryptionKeyRequired
+	}
+	if info.Algorithm != EncryptionAlgorithm {
+		return nil, fmt.Errorf("%w: unsupported algorithm %q", ErrDecryptFailed, info.Algorithm)
+	}
+
+	dataKey, err := wrapper.UnwrapKey(ctx, info.KeyID, info.WrappedKey)
+	if err != nil {
+		return nil, fmt.Errorf("kafka: unwrap data key: %w", err)
+	}
+	aead, err := newAEAD(dataKey)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrDecryptFailed, err)
+	}
+	document, err := decodeJSON(payload)
+	if err != nil {
+		return nil, fmt.Errorf("kafka: decrypt payload: %w", err)
+	}
+
+	paths := make(map[string]struct{}, len(info.Fields))
+	for _, path := range info.Fields {
+		paths[path] = struct{}{}
+	}
+	decrypted := 0
+	document, err = transformFields(document, "", func(path, _ string) bool {
+		_, ok := paths[path]
+		return ok
+	}, func(path string, value any) (any, error) {
+		text, ok := value.(string)
+		if !ok || !strings.HasPrefix(text, encryptedValuePrefix) {
+			return nil, fmt.Errorf("%w: field %s is not encrypted", ErrDecryptFailed, path)
+		}
+		sealed, err := base64.StdEncoding.DecodeString(strings.TrimPrefix(text, encryptedValuePrefix))
+		if err != nil {
+			return nil, fmt.Errorf("%w: field %s: %v", ErrDecryptFailed, path, err)
+		}
+		plain, err := open(aead, sealed, fieldAAD(eventID, path))
+		if err != nil {
+			return nil, fmt.Errorf("field %s: %w", path, err)
+		}
+		decrypted++
+		return json.RawMessage(plain), nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	if decrypted != len(paths) {
+		return nil, fmt.Errorf("%w: expected %d encrypted fields, found %d", ErrDecryptFailed, len(paths), decrypted)
+	}
+	return json.Marshal(document)
+}
+
+// transformFields обходит JSON и заменяет значения ключей, для которых match вернул true;
+// path — JSON Pointer значения. Внутрь заменённых значений обход не спускается.
+func transformFields(value any, path string, match func(path, key string) bool, replace func(path string, value any) (any, error)) (any, error) {
+	switch v := value.(type) {
+	case map[string]any:
+		for key, nested := range v {
+			nestedPath := path + "/" + escapePointer(key)
+			var err error
+			if match(nestedPath, key) {
+				v[key], err = replace(nestedPath, nested)
+			} else {
+				v[key], err = transformFields(nested, nestedPath, match, replace)
+			}
+			if err != nil {
+				return nil, err
+			}
+		}
+		return v, nil
+	case []any:
+		for i := range v {
+			var err error
+			if v[i], err = transformFields(v[i], fmt.Sprintf("%s/%d", path, i), match, replace); err != nil {
+				return nil, err
+			}
+		}
+		return v, nil
+	default:
+		return value, nil
+	}
+}
+
+var pointerEscaper = strings.NewReplacer("~", "~0", "/", "~1")
+
+func escapePointer(key string) string {
+	return pointerEscaper.Replace(key)
+}
+
+func decodeJSON(payload []byte) (any, error) {
+	decoder := json.NewDecoder(bytes.NewReader(payload))
+	decoder.UseNumber()
+	var document any
+	if err := decoder.Decode(&document); err != nil {
+		return nil, err
+	}
+	return document, nil
+}
+
+func fieldAAD(eventID, path string) []byte {
+	return []byte(eventID + "\x00" + path)
+}
+
+func newAEAD(key []byte) (cipher.AEAD, error) {
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+	return cipher.NewGCM(block)
+}
+
+// seal возвращает nonce||ciphertext.
+func seal(aead cipher.AEAD, plain, aad []byte) ([]byte, error) {
+	nonce := make([]byte, aead.NonceSize(), aead.NonceSize()+len(plain)+aead.Overhead())
+	if _, err := io.ReadFull(rand.Reader, nonce); err != nil {
+		return nil, fmt.Errorf("kafka: generate nonce: %w", err)
+	}
+	return aead.Seal(nonce, nonce, plain, aad), nil
+}
+
+func open(aead cipher.AEAD, sealed, aad []byte) ([]byte, error) {
+	if len(sealed) < aead.NonceSize() {
+		return nil, ErrDecryptFailed
+	}
+	nonce, ciphertext := sealed[:aead.NonceSize()], sealed[aead.NonceSize():]
+	plain, err := aead.Open(nil, nonce, ciphertext, aad)
+	if err != nil {
+		return nil, ErrDecryptFailed
+	}
+	return plain, nil
+}
@@ -0,0 +1,129 @@
+package kafka
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"strings"
+	"testing"
+)
+
+func testKeySpec(ids ...string) string {
+	entries := make([]string, 0, len(ids))
+	for i, id := range ids {
+		entries = append(entries, id+":"+base64.StdEncoding.EncodeToString(bytes.Repeat([]byte{byte(i + 1)}, dataKeySize)))
+	}
+	return strings.Join(entries, ",")
+}
+
+func TestFieldEncryptor_RoundTrip(t *testing.T) {
+	wrapper, err := ParseStaticKeyWrapper(testKeySpec("k1"))
+	if err != nil {
+		t.Fatalf("parse keys: %v", err)
+	}
+	encryptor, err := NewFieldEncryptor(wrapper, "customer_id", "email")
+	if err != nil {
+		t.Fatalf("new encryptor: %v", err)
+	}
+
+	payload := []byte(`{"order_id":"o-1","customer_id":"c-1","contact":{"Email":"a@b.c"},"amount":1500}`)
+	encrypted, info, err := encryptor.Encrypt(context.Background(), "evt-1", payload)
+	if err != nil {
+		t.Fatalf("encrypt: %v", err)
+	}
+	if info == nil || info.KeyID != "k1" || len(info.Fields) != 2 || info.Fields[0] != "/contact/Email" || info.Fields[1] != "/customer_id" {
+		t.Fatalf("unexpected encryption info: %+v", info)
+	}
+	if strings.Contains(string(encrypted), "c-1") || strings.Contains(string(encrypted), "a@b.c") {
+		t.Fatalf("sensitive values leaked: %s", encrypted)
+	}
+	if !strings.Contains(string(encrypted), `"order_id":"o-1"`) || !strings.Contains(string(encrypted), `"amount":1500`) {
+		t.Fatalf("other fields must stay readable: %s", encrypted)
+	}
+
+	decrypted, err := DecryptFields(context.Background(), wrapper, "evt-1", encrypted, info)
+	if err != nil {
+		t.Fatalf("decrypt: %v", err)
+	}
+	var got, want map[string]any
+	if err := json.Unmarshal(decrypted, &got); err != nil {
+		t.Fatal(err)
+	}
+	if err := json.Unmarshal(payload, &want); err != nil {
+		t.Fatal(err)
+	}
+	if got["customer_id"] != want["customer_id"] || got["contact"].(map[string]any)["Email"] != "a@b.c" || got["amount"] != want["amount"] {
+		t.Fatalf("unexpected decrypted payload: %s", decrypted)
+	}
+
+	// Поле, перенесённое в другое событие, не расшифруется: eventID входит в associated data.
+	if _, err := DecryptFields(context.Background(), wrapper, "evt-2", encrypted, info); !errors.Is(err, ErrDecryptFailed) {
+		t.Fatalf("expected ErrDecryptFailed for foreign event id, got %v", err)
+	}
+}
+
+func TestFieldEncryptor_KeyRotation(t *testing.T) {
+	oldWrapper, err := ParseStaticKeyWrapper(testKeySpec("k1"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	encryptor, err := NewFieldEncryptor(oldWrapper, "customer_id")
+	if err != nil {
+		t.Fatal(err)
+	}
+	encrypted, info, err := encryptor.Encrypt(context.Background(), "evt-1", []byte(`{"customer_id":"c-1"}`))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Новый primary k2 первым, старый k1 ещё активен — старые сообщения читаются.
+	rotated, err := ParseStaticKeyWrapper("k2:" + base64.StdEncoding.EncodeToString(bytes.Repeat([]byte{9}, dataKeySize)) + "," + testKeySpec("k1"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := DecryptFields(context.Background(), rotated, "evt-1", encrypted, info); err != nil {
+		t.Fatalf("decrypt with rotated keyring: %v", err)
+	}
+
+	withoutOld, err := ParseStaticKeyWrapper(testKeySpec("k2"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := DecryptFields(context.Background(), withoutOld, "evt-1", encrypted, info); !errors.Is(err, ErrUnknownEncryptionKey) {
+		t.Fatalf("expected ErrUnknownEncryptionKey, got %v", err)
+	}
+}
+
+func TestFieldEncryptor_NoMatchingFieldsAndInvalidPayload(t *testing.T) {
+	wrapper, err := ParseStaticKeyWrapper(testKeySpec("k1"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	encryptor, err := NewFieldEncryptor(wrapper, "customer_id")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	payload := []byte(`{"status":"confirmed"}`)
+	out, info, err := encryptor.Encrypt(context.Background(), "evt-1", payload)
+	if err != nil || info != nil || !bytes.Equal(out, payload) {
+		t.Fatalf("payload without sensitive fields must pass through, got %s %+v %v", out, info, err)
+	}
+	if _, _, err := encryptor.Encrypt(context.Background(), "evt-1", []byte("customer c-1")); err == nil {
+		t.Fatal("expected error for non-JSON payload")
+	}
+}
+
+func TestParseStaticKeyWrapper_Invalid(t *testing.T) {
+	for _, spec := range []string{
+		"",
+		"k1:not-base64!",
+		"k1:" + base64.StdEncoding.EncodeToString([]byte("short")),
+	} {
+		if _, err := ParseStaticKeyWrapper(spec); err == nil {
+			t.Fatalf("%q: expected error", spec)
+		}
+	}
+}
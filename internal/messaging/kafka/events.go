@@ -1,6 +1,10 @@
 package kafka
 
-import "time"
+import (
+	"time"
+
+	"github.com/vladislavdragonenkov/oms/internal/timeutil"
+)
 
 // EventType определяет тип события
 type EventType string
@@ -12,6 +16,8 @@ const (
 	EventTypeSagaFailed    EventType = "saga.failed"
 	EventTypeSagaCanceled  EventType = "saga.canceled"
 	EventTypeSagaRefunded  EventType = "saga.refunded"
+	// EventTypeSagaBackordered — сага приостановлена до пополнения склада.
+	EventTypeSagaBackordered EventType = "saga.backordered"
 
 	// Order события
 	EventTypeOrderCreated   EventType = "order.created"
@@ -22,6 +28,8 @@ const (
 	// Step события
 	EventTypeStepReserved EventType = "step.reserved"
 	EventTypeStepPaid     EventType = "step.paid"
+	// EventTypeStepAuthorized — сумма заблокирована у PSP, заказ ждёт capture.
+	EventTypeStepAuthorized EventType = "step.authorized"
 )
 
 // Topics для Kafka
@@ -29,14 +37,10 @@ const (
 	TopicSagaEvents      = "oms.saga.events"
 	TopicOrderEvents     = "oms.order.events"
 	TopicDeadLetterQueue = "oms.dlq" // Dead Letter Queue для failed messages
-)
-
-// Kafka headers для retry логики
-const (
-	HeaderRetryCount    = "x-retry-count"
-	HeaderOriginalTopic = "x-original-topic"
-	HeaderErrorMessage  = "x-error-message"
-	HeaderFailedAt      = "x-failed-at"
+	// TopicInventoryRestock — события пополнения склада от inventory-сервиса.
+	TopicInventoryRestock = "oms.inventory.restock"
+	// TopicPaymentEvents — статусы платежей от внешнего PSP (capture, отказ, chargeback).
+	TopicPaymentEvents = "payments.events"
 )
 
 // SagaEvent представляет событие саги
@@ -45,6 +49,12 @@ type SagaEvent struct {
 	OrderID   string                 `json:"order_id"`
 	Timestamp time.Time              `json:"timestamp"`
 	Metadata  map[string]interface{} `json:"metadata,omitempty"`
+	// Encryption заполнен, если часть полей Metadata зашифрована (см. FieldEncryptor.EncryptSagaEvent).
+	Encryption *EncryptionInfo `json:"encryption,omitempty"`
+	// CorrelationID — ID запроса, с которого началась цепочка; CausationID — ID outbox-события
+	// (или запроса), после которого произошло это. Пусто для саг, запущенных не запросом.
+	CorrelationID string `json:"correlation_id,omitempty"`
+	CausationID   string `json:"causation_id,omitempty"`
 }
 
 // OrderEvent представляет событие заказа
@@ -62,7 +72,7 @@ func NewSagaEvent(eventType EventType, orderID string, metadata map[string]inter
 	return &SagaEvent{
 		EventType: eventType,
 		OrderID:   orderID,
-		Timestamp: time.Now(),
+		Timestamp: timeutil.Now(),
 		Metadata:  metadata,
 	}
 }
@@ -74,7 +84,38 @@ func NewOrderEvent(eventType EventType, orderID, customerID, status string, meta
 		OrderID:    orderID,
 		CustomerID: customerID,
 		Status:     status,
-		Timestamp:  time.Now(),
+		Timestamp:  timeutil.Now(),
 		Metadata:   metadata,
 	}
 }
+
+// InventoryRestockEvent — товар снова доступен на складе.
+type InventoryRestockEvent struct {
+	SKU       string    `json:"sku"`
+	Qty       int32     `json:"qty"`
+	Timestamp time.Time `json:"timestamp"`
+}
+
+// PaymentEventType — тип события платёжного провайдера.
+type PaymentEventType string
+
+const (
+	// PaymentEventCaptured — деньги списаны.
+	PaymentEventCaptured PaymentEventType = "payment.captured"
+	// PaymentEventFailed — списание отклонено или отменено на стороне PSP.
+	PaymentEventFailed PaymentEventType = "payment.failed"
+	// PaymentEventChargeback — банк клиента вернул списанные деньги.
+	PaymentEventChargeback PaymentEventType = "payment.chargeback"
+)
+
+// PaymentEvent — событие PSP из топика TopicPaymentEvents. Формат задаёт провайдер, поэтому только JSON.
+type PaymentEvent struct {
+	EventID     string           `json:"event_id"`
+	Type        PaymentEventType `json:"type"`
+	OrderID     string           `json:"order_id"`
+	PaymentID   string           `json:"payment_id,omitempty"`
+	AmountMinor int64            `json:"amount_minor,omitempty"`
+	Currency    string           `json:"currency,omitempty"`
+	Reason      string           `json:"reason,omitempty"`
+	OccurredAt  time.Time        `json:"occurred_at"`
+}
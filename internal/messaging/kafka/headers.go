@@ -0,0 +1,256 @@
+package kafka
+
+import (
+	"context"
+	"strconv"
+	"time"
+
+	"github.com/IBM/sarama"
+	"github.com/google/uuid"
+
+	"github.com/vladislavdragonenkov/oms/internal/domain"
+	"github.com/vladislavdragonenkov/oms/internal/timeutil"
+)
+
+// Стандартные headers, которые producer проставляет каждому сообщению.
+const (
+	HeaderEventID       = "x-event-id"
+	HeaderEventType     = "x-event-type"
+	HeaderSchemaVersion = "x-schema-version"
+	HeaderOccurredAt    = "x-occurred-at"
+	HeaderTenantID      = "x-tenant-id"
+	// Trace context передаётся в формате W3C, поэтому имена без x-префикса.
+	HeaderTraceParent = "traceparent"
+	HeaderTraceState  = "tracestate"
+	// Цепочка событий (domain.EventChain): ID исходного запроса и ID события-причины.
+	HeaderCorrelationID = domain.EventHeaderCorrelationID
+	HeaderCausationID   = domain.EventHeaderCausationID
+)
+
+// Kafka headers для retry логики
+const (
+	HeaderRetryCount    = "x-retry-count"
+	HeaderOriginalTopic = "x-original-topic"
+	HeaderErrorMessage  = "x-error-message"
+	HeaderFailedAt      = "x-failed-at"
+)
+
+// HeaderReplay помечает повторную публикацию уже отправленного outbox-события (cmd/outbox-replay);
+// значение — идентификатор запуска replay. Обычные consumer'ы такие сообщения пропускают.
+const HeaderReplay = "x-replay"
+
+// DefaultSchemaVersion — версия схемы payload, если продюсер не указал другую.
+const DefaultSchemaVersion = 1
+
+// MessageHeaders — типизированное представление стандартного набора headers.
+// Пустые поля при публикации не записываются.
+type MessageHeaders struct {
+	EventID       string
+	EventType     string
+	SchemaVersion int
+	OccurredAt    time.Time
+	TraceParent   string
+	TraceState    string
+	TenantID      string
+	CorrelationID string
+	CausationID   string
+	RetryCount    int
+	// ContentType — формат value (HeaderContentType); пустое значение читается как JSON.
+	ContentType string
+	// Extra — нестандартные headers (например, диагностика DLQ).
+	Extra map[string]string
+}
+
+// withDefaults заполняет event-id, occurred-at и schema-version, если они не заданы.
+func (h MessageHeaders) withDefaults(now time.Time) MessageHeaders {
+	if h.EventID == "" {
+		h.EventID = uuid.NewString()
+	}
+	if h.OccurredAt.IsZero() {
+		h.OccurredAt = now
+	}
+	if h.SchemaVersion <= 0 {
+		h.SchemaVersion = DefaultSchemaVersion
+	}
+	return h
+}
+
+// Records преобразует headers в формат sarama.
+func (h MessageHeaders) Records() []sarama.RecordHeader {
+	records := make([]sarama.RecordHeader, 0, 10+len(h.Extra))
+	add := func(key, value string) {
+		if value != "" {
+			records = append(records, sarama.RecordHeader{Key: []byte(key), Value: []byte(value)})
+		}
+	}
+
+	add(HeaderEventID, h.EventID)
+	add(HeaderEventType, h.EventType)
+	if h.SchemaVersion > 0 {
+		add(HeaderSchemaVersion, strconv.Itoa(h.SchemaVersion))
+	}
+	if !h.OccurredAt.IsZero() {
+		add(HeaderOccurredAt, timeutil.Format(h.OccurredAt))
+	}
+	add(HeaderTraceParent, h.TraceParent)
+	add(HeaderTraceState, h.TraceState)
+	add(HeaderTenantID, h.TenantID)
+	add(HeaderCorrelationID, h.CorrelationID)
+	add(HeaderCausationID, h.CausationID)
+	if h.RetryCount > 0 {
+		add(HeaderRetryCount, strconv.Itoa(h.RetryCount))
+	}
+	add(HeaderContentType, h.ContentType)
+	for key, value := range h.Extra {
+		add(key, value)
+	}
+	return records
+}
+
+// ParseHeaders извлекает стандартные headers из сообщения. Некорректные числа и даты
+// игнорируются, чтобы одно битое поле не блокировало обработку. occurred-at приводится
+// к UTC; метка, опережающая локальные часы больше timeutil.MaxFutureSkew, отбрасывается.
+func ParseHeaders(message *sarama.ConsumerMessage) MessageHeaders {
+	var h MessageHeaders
+	if message == nil {
+		return h
+	}
+
+	for _, header := range message.Headers {
+		if header == nil {
+			continue
+		}
+		value := string(header.Value)
+		switch key := string(header.Key); key {
+		case HeaderEventID:
+			h.EventID = value
+		case HeaderEventType:
+			h.EventType = value
+		case HeaderSchemaVersion:
+			if v, err := strconv.Atoi(value); err == nil {
+				h.SchemaVersion = v
+			}
+		case HeaderOccurredAt:
+			if ts, err := timeutil.Parse(value); err == nil && timeutil.CheckSkew(ts, timeutil.Now()) == nil {
+				h.OccurredAt = ts
+			}
+		case HeaderTraceParent:
+			h.TraceParent = value
+		case HeaderTraceState:
+			h.TraceState = value
+		case HeaderTenantID:
+			h.TenantID = value
+		case HeaderCorrelationID:
+			h.CorrelationID = value
+		case HeaderCausationID:
+			h.CausationID = value
+		case HeaderRetryCount:
+			if v, err := strconv.Atoi(value); err == nil {
+				h.RetryCount = v
+			}
+		case HeaderContentType:
+			h.ContentType = value
+		default:
+			if h.Extra == nil {
+				h.Extra = make(map[string]string)
+			}
+			h.Extra[key] = value
+		}
+	}
+	return h
+}
+
+// HeaderValue возвращает значение header по ключу.
+func HeaderValue(message *sarama.ConsumerMessage, key string) (string, bool) {
+	if message == nil {
+		return "", false
+	}
+	for _, header := range message.Headers {
+		if header != nil && string(header.Key) == key {
+			return string(header.Value), true
+		}
+	}
+	return "", false
+}
+
+// IsReplay сообщает, что сообщение опубликовано повторно через outbox-replay.
+func IsReplay(message *sarama.ConsumerMessage) bool {
+	value, ok := HeaderValue(message, HeaderReplay)
+	return ok && value != ""
+}
+
+// RetryCount возвращает значение x-retry-count (0, если header отсутствует или некорректен).
+func RetryCount(message *sarama.ConsumerMessage) int {
+	value, ok := HeaderValue(message, HeaderRetryCount)
+	if !ok {
+		return 0
+	}
+	count, err := strconv.Atoi(value)
+	if err != nil {
+		return 0
+	}
+	return count
+}
+
+type headersContextKey struct{}
+
+// ContextWithHeaders сохраняет headers входящего сообщения в контексте обработчика.
+func ContextWithHeaders(ctx context.Context, headers MessageHeaders) context.Context {
+	return context.WithValue(ctx, headersContextKey{}, headers)
+}
+
+// HeadersFromContext возвращает headers входящего сообщения, если consumer их сохранил.
+func HeadersFromContext(ctx context.Context) (MessageHeaders, bool) {
+	headers, ok := ctx.Value(headersContextKey{}).(MessageHeaders)
+	return headers, ok
+}
+
+// EventHeaders возвращает trace context и tenant в виде headers outbox-события
+// (domain.OutboxMessage.Headers); пустые значения пропускаются.
+func (h MessageHeaders) EventHeaders() map[string]string {
+	headers := make(map[string]string, 3)
+	for key, value := range map[string]string{
+		HeaderTraceParent: h.TraceParent,
+		HeaderTraceState:  h.TraceState,
+		HeaderTenantID:    h.TenantID,
+	} {
+		if value != "" {
+			headers[key] = value
+		}
+	}
+	return headers
+}
+
+// PropagatedHeaders возвращает headers, которые нужно перенести в исходящие сообщения,
+// опубликованные в ходе обработки входящего: trace context, tenant и цепочку событий, в которой
+// входящее сообщение становится причиной исходящего.
+func PropagatedHeaders(ctx context.Context) MessageHeaders {
+	incoming, ok := HeadersFromContext(ctx)
+	if !ok {
+		return MessageHeaders{}
+	}
+	correlationID, causationID := incoming.chainIDs()
+	return MessageHeaders{
+		TraceParent:   incoming.TraceParent,
+		TraceState:    incoming.TraceState,
+		TenantID:      incoming.TenantID,
+		CorrelationID: correlationID,
+		CausationID:   causationID,
+	}
+}
+
+// ContextWithEventChain продолжает в обработчике цепочку входящего сообщения: события, которые
+// он запишет в outbox, получат тот же correlation-id и causation-id = event-id сообщения.
+// Сообщение без correlation-id начинает цепочку со своего event-id.
+func ContextWithEventChain(ctx context.Context, incoming MessageHeaders) context.Context {
+	correlationID, causationID := incoming.chainIDs()
+	return domain.ContextWithEventChain(ctx, correlationID, causationID)
+}
+
+func (h MessageHeaders) chainIDs() (correlationID, causationID string) {
+	correlationID = h.CorrelationID
+	if correlationID == "" {
+		correlationID = h.EventID
+	}
+	return correlationID, h.EventID
+}
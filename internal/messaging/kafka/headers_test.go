@@ -0,0 +1,198 @@
+package kafka
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/IBM/sarama"
+	"github.com/IBM/sarama/mocks"
+	log "github.com/sirupsen/logrus"
+
+	"github.com/vladislavdragonenkov/oms/internal/domain"
+)
+
+func consumerMessageFromRecords(records []sarama.RecordHeader) *sarama.ConsumerMessage {
+	msg := &sarama.ConsumerMessage{}
+	for i := range records {
+		msg.Headers = append(msg.Headers, &records[i])
+	}
+	return msg
+}
+
+func TestMessageHeaders_RoundTrip(t *testing.T) {
+	t.Parallel()
+
+	occurredAt := time.Date(2026, 1, 2, 3, 4, 5, 6000, time.UTC)
+	headers := MessageHeaders{
+		EventID:       "evt-1",
+		EventType:     "OrderStatusChanged",
+		SchemaVersion: 2,
+		OccurredAt:    occurredAt,
+		TraceParent:   "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01",
+		TraceState:    "vendor=1",
+		TenantID:      "tenant-a",
+		CorrelationID: "req-1",
+		CausationID:   "evt-0",
+		RetryCount:    3,
+		Extra:         map[string]string{HeaderOriginalTopic: "orders"},
+	}
+
+	msg := consumerMessageFromRecords(headers.Records())
+	parsed := ParseHeaders(msg)
+	if parsed.EventID != "evt-1" || parsed.EventType != "OrderStatusChanged" || parsed.SchemaVersion != 2 ||
+		parsed.TraceParent != headers.TraceParent || parsed.TraceState != "vendor=1" ||
+		parsed.TenantID != "tenant-a" || parsed.CorrelationID != "req-1" || parsed.CausationID != "evt-0" ||
+		parsed.RetryCount != 3 {
+		t.Fatalf("unexpected parsed headers: %+v", parsed)
+	}
+	if !parsed.OccurredAt.Equal(occurredAt) {
+		t.Fatalf("unexpected occurred-at: %v", parsed.OccurredAt)
+	}
+	if parsed.Extra[HeaderOriginalTopic] != "orders" {
+		t.Fatalf("expected extra header to survive, got %+v", parsed.Extra)
+	}
+	if RetryCount(msg) != 3 {
+		t.Fatalf("unexpected retry count: %d", RetryCount(msg))
+	}
+	if _, ok := HeaderValue(msg, "missing"); ok {
+		t.Fatal("expected missing header")
+	}
+}
+
+func TestMessageHeaders_RecordsSkipEmptyFields(t *testing.T) {
+	t.Parallel()
+
+	records := MessageHeaders{EventID: "evt-1"}.Records()
+	if len(records) != 1 || string(records[0].Key) != HeaderEventID {
+		t.Fatalf("expected only event-id header, got %+v", records)
+	}
+}
+
+func TestMessageHeaders_EventHeadersKeepTraceAndTenant(t *testing.T) {
+	t.Parallel()
+
+	headers := MessageHeaders{EventID: "evt-1", TraceParent: "00-trace-01", TenantID: "tenant-a"}.EventHeaders()
+	if len(headers) != 2 || headers[HeaderTraceParent] != "00-trace-01" || headers[HeaderTenantID] != "tenant-a" {
+		t.Fatalf("unexpected event headers: %v", headers)
+	}
+}
+
+func TestParseHeaders_IgnoresMalformedValues(t *testing.T) {
+	t.Parallel()
+
+	msg := &sarama.ConsumerMessage{Headers: []*sarama.RecordHeader{
+		{Key: []byte(HeaderSchemaVersion), Value: []byte("v2")},
+		{Key: []byte(HeaderOccurredAt), Value: []byte("yesterday")},
+		nil,
+	}}
+	parsed := ParseHeaders(msg)
+	if parsed.SchemaVersion != 0 || !parsed.OccurredAt.IsZero() {
+		t.Fatalf("expected malformed values to be ignored, got %+v", parsed)
+	}
+}
+
+func TestParseHeaders_NormalizesOccurredAtToUTC(t *testing.T) {
+	t.Parallel()
+
+	future := time.Now().Add(time.Hour).UTC().Format(time.RFC3339Nano)
+	msg := &sarama.ConsumerMessage{Headers: []*sarama.RecordHeader{
+		{Key: []byte(HeaderOccurredAt), Value: []byte("2026-01-02T06:04:05+03:00")},
+	}}
+	parsed := ParseHeaders(msg)
+	if parsed.OccurredAt.Location() != time.UTC || parsed.OccurredAt.Hour() != 3 {
+		t.Fatalf("expected occurred-at in UTC, got %v", parsed.OccurredAt)
+	}
+
+	msg.Headers[0].Value = []byte(future)
+	if parsed := ParseHeaders(msg); !parsed.OccurredAt.IsZero() {
+		t.Fatalf("expected future occurred-at to be dropped, got %v", parsed.OccurredAt)
+	}
+}
+
+func TestProducer_PublishEventSetsStandardHeaders(t *testing.T) {
+	mockProducer := mocks.NewSyncProducer(t, nil)
+	producer := &Producer{producer: mockProducer, logger: log.WithField("component", "kafka-producer-test")}
+
+	var sent *sarama.ProducerMessage
+	mockProducer.ExpectSendMessageWithMessageCheckerFunctionAndSucceed(func(msg *sarama.ProducerMessage) error {
+		sent = msg
+		return nil
+	})
+
+	event := NewSagaEvent(EventTypeSagaStarted, "order-1", nil)
+	if err := producer.PublishEvent(TopicSagaEvents, "order-1", event); err != nil {
+		t.Fatalf("PublishEvent failed: %v", err)
+	}
+	if err := mockProducer.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	parsed := ParseHeaders(consumerMessageFromRecords(sent.Headers))
+	if parsed.EventID == "" {
+		t.Fatal("expected generated event-id")
+	}
+	if parsed.EventType != string(EventTypeSagaStarted) || parsed.SchemaVersion != DefaultSchemaVersion {
+		t.Fatalf("unexpected headers: %+v", parsed)
+	}
+	if !parsed.OccurredAt.Equal(event.Timestamp) {
+		t.Fatalf("expected occurred-at from event timestamp, got %v", parsed.OccurredAt)
+	}
+}
+
+func TestConsumer_PropagatesHeadersToHandlerAndDLQ(t *testing.T) {
+	mockProducer := mocks.NewSyncProducer(t, nil)
+	var dlq *sarama.ProducerMessage
+	mockProducer.ExpectSendMessageWithMessageCheckerFunctionAndSucceed(func(msg *sarama.ProducerMessage) error {
+		dlq = msg
+		return nil
+	})
+
+	var seen MessageHeaders
+	var chainCorrelation, chainCausation string
+	consumer := &Consumer{
+		handler: func(ctx context.Context, _ *sarama.ConsumerMessage) error {
+			seen = PropagatedHeaders(ctx)
+			chainCorrelation, chainCausation = domain.EventChainFromContext(ctx).IDs()
+			return errors.New("boom")
+		},
+		logger:      log.WithField("test", "headers"),
+		dlqProducer: &Producer{producer: mockProducer, logger: log.WithField("test", "headers-dlq")},
+		policy:      DLQPolicy{MaxRetries: 1, IncludeHeaders: true},
+	}
+
+	msg := consumerMessageFromRecords(MessageHeaders{
+		EventID:       "evt-9",
+		TraceParent:   "00-trace-span-01",
+		TenantID:      "tenant-b",
+		CorrelationID: "req-1",
+	}.Records())
+	msg.Topic = "orders"
+
+	if err := consumer.handleMessageWithRetry(context.Background(), msg); err != nil {
+		t.Fatalf("expected message to be routed to DLQ, got %v", err)
+	}
+	if err := mockProducer.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	if seen.TraceParent != "00-trace-span-01" || seen.TenantID != "tenant-b" || seen.EventID != "" {
+		t.Fatalf("unexpected propagated headers: %+v", seen)
+	}
+	// Входящее сообщение — причина всего, что опубликует или запишет в outbox обработчик.
+	if seen.CorrelationID != "req-1" || seen.CausationID != "evt-9" {
+		t.Fatalf("unexpected propagated chain: %+v", seen)
+	}
+	if chainCorrelation != "req-1" || chainCausation != "evt-9" {
+		t.Fatalf("unexpected handler event chain %s/%s", chainCorrelation, chainCausation)
+	}
+
+	parsed := ParseHeaders(consumerMessageFromRecords(dlq.Headers))
+	if parsed.EventID != "evt-9" || parsed.TenantID != "tenant-b" {
+		t.Fatalf("expected original headers in DLQ message, got %+v", parsed)
+	}
+	if parsed.Extra[HeaderOriginalTopic] != "orders" || parsed.Extra[HeaderErrorMessage] != "boom" {
+		t.Fatalf("expected DLQ diagnostic headers, got %+v", parsed.Extra)
+	}
+}
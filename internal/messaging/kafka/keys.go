@@ -0,0 +1,111 @@
+package kafka
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/IBM/sarama"
+)
+
+// KeyStrategy определяет ключ сообщения. Kafka выбирает партицию по хэшу ключа, поэтому
+// стратегия задаёт, в пределах чего сохраняется порядок событий: заказа, клиента или tenant.
+type KeyStrategy string
+
+const (
+	// KeyByOrder — ключ order_id: порядок событий одного заказа (по умолчанию).
+	KeyByOrder KeyStrategy = "order"
+	// KeyByCustomer — ключ customer_id: порядок всех событий клиента, в том числе между его заказами.
+	KeyByCustomer KeyStrategy = "customer"
+	// KeyByTenant — ключ tenant (x-tenant-id): порядок в пределах tenant ценой более горячих партиций.
+	KeyByTenant KeyStrategy = "tenant"
+)
+
+// MessageKey — значения, из которых стратегия выбирает ключ.
+type MessageKey struct {
+	OrderID    string
+	CustomerID string
+	TenantID   string
+}
+
+// Key возвращает ключ сообщения. Если нужного значения нет (например, RPC без x-tenant-id),
+// используется order_id: событие остаётся упорядоченным хотя бы в пределах заказа.
+func (s KeyStrategy) Key(key MessageKey) string {
+	switch s {
+	case KeyByCustomer:
+		if key.CustomerID != "" {
+			return key.CustomerID
+		}
+	case KeyByTenant:
+		if key.TenantID != "" {
+			return key.TenantID
+		}
+	}
+	return key.OrderID
+}
+
+// ParseKeyStrategy разбирает имя стратегии; пустое значение — KeyByOrder.
+func ParseKeyStrategy(raw string) (KeyStrategy, error) {
+	strategy := KeyStrategy(strings.ToLower(strings.TrimSpace(raw)))
+	switch strategy {
+	case "":
+		return KeyByOrder, nil
+	case KeyByOrder, KeyByCustomer, KeyByTenant:
+		return strategy, nil
+	default:
+		return "", fmt.Errorf("%w: unknown key strategy %q (allowed: order, customer, tenant)", ErrInvalidTopicConfig, raw)
+	}
+}
+
+// PartitionFor возвращает партицию, которую producer выберет для key при partitions партициях
+// (hash-partitioner sarama по умолчанию). Нужна, чтобы проверить стабильность раскладки.
+func PartitionFor(key string, partitions int32) (int32, error) {
+	return sarama.NewHashPartitioner("").Partition(&sarama.ProducerMessage{Key: sarama.StringEncoder(key)}, partitions)
+}
+
+// allowedKeyStrategies — стратегии, допустимые для топика. В outbox-записи нет customer_id,
+// поэтому oms.order.events нельзя ключевать по клиенту: часть событий ушла бы по order_id.
+var allowedKeyStrategies = map[string][]KeyStrategy{
+	"order_events": {KeyByOrder, KeyByTenant},
+	"saga_events":  {KeyByOrder, KeyByCustomer, KeyByTenant},
+}
+
+// WithKeyStrategies применяет стратегии из строки "saga_events=customer,order_events=tenant".
+// Имена топиков — как в ошибках TopicConfig; незаданные топики сохраняют текущую стратегию.
+func (c TopicConfig) WithKeyStrategies(raw string) (TopicConfig, error) {
+	for _, chunk := range strings.Split(raw, ",") {
+		chunk = strings.TrimSpace(chunk)
+		if chunk == "" {
+			continue
+		}
+		field, value, ok := strings.Cut(chunk, "=")
+		if !ok {
+			return TopicConfig{}, fmt.Errorf("%w: key strategy %q must be topic=strategy", ErrInvalidTopicConfig, chunk)
+		}
+		strategy, err := ParseKeyStrategy(value)
+		if err != nil {
+			return TopicConfig{}, err
+		}
+		switch strings.TrimSpace(field) {
+		case "order_events":
+			c.OrderEventsKey = strategy
+		case "saga_events":
+			c.SagaEventsKey = strategy
+		default:
+			return TopicConfig{}, fmt.Errorf("%w: key strategy for unknown topic %q (allowed: order_events, saga_events)", ErrInvalidTopicConfig, field)
+		}
+	}
+	if err := c.validateKeyStrategies(); err != nil {
+		return TopicConfig{}, err
+	}
+	return c, nil
+}
+
+func (c TopicConfig) validateKeyStrategies() error {
+	for field, strategy := range map[string]KeyStrategy{"order_events": c.OrderEventsKey, "saga_events": c.SagaEventsKey} {
+		if strategy != "" && !slices.Contains(allowedKeyStrategies[field], strategy) {
+			return fmt.Errorf("%w: %s does not support key strategy %q", ErrInvalidTopicConfig, field, strategy)
+		}
+	}
+	return nil
+}
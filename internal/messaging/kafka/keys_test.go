@@ -0,0 +1,104 @@
+package kafka
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/IBM/sarama"
+	"github.com/IBM/sarama/mocks"
+	log "github.com/sirupsen/logrus"
+
+	"github.com/vladislavdragonenkov/oms/internal/domain"
+)
+
+func TestKeyStrategy_Key(t *testing.T) {
+	key := MessageKey{OrderID: "order-1", CustomerID: "customer-1", TenantID: "tenant-a"}
+	for strategy, want := range map[KeyStrategy]string{
+		KeyByOrder:    "order-1",
+		KeyByCustomer: "customer-1",
+		KeyByTenant:   "tenant-a",
+		"":            "order-1",
+	} {
+		if got := strategy.Key(key); got != want {
+			t.Fatalf("%q: expected key %q, got %q", strategy, want, got)
+		}
+	}
+	if got := KeyByTenant.Key(MessageKey{OrderID: "order-1"}); got != "order-1" {
+		t.Fatalf("missing tenant must fall back to order id, got %q", got)
+	}
+}
+
+// Раскладка ключей по партициям — контракт с consumer'ами: при смене partitioner'а или
+// формата ключа события заказа/клиента разъедутся по партициям и потеряют порядок.
+func TestPartitionFor_StableAssignments(t *testing.T) {
+	for key, want := range map[string]int32{"order-1": 1, "order-2": 4, "customer-1": 7, "tenant-a": 1} {
+		for range 3 {
+			got, err := PartitionFor(key, 12)
+			if err != nil {
+				t.Fatalf("partition %q: %v", key, err)
+			}
+			if got != want {
+				t.Fatalf("key %q: expected partition %d, got %d", key, want, got)
+			}
+		}
+	}
+
+	// Все события клиента попадают в одну партицию независимо от заказа.
+	strategy := KeyByCustomer
+	first, _ := PartitionFor(strategy.Key(MessageKey{OrderID: "order-1", CustomerID: "customer-1"}), 12)
+	second, _ := PartitionFor(strategy.Key(MessageKey{OrderID: "order-2", CustomerID: "customer-1"}), 12)
+	if first != second {
+		t.Fatalf("customer events must share a partition, got %d and %d", first, second)
+	}
+}
+
+func TestTopicConfig_WithKeyStrategies(t *testing.T) {
+	cfg, err := DefaultTopicConfig().WithKeyStrategies(" saga_events=Customer , order_events=tenant ")
+	if err != nil {
+		t.Fatalf("WithKeyStrategies: %v", err)
+	}
+	if cfg.SagaEventsKey != KeyByCustomer || cfg.OrderEventsKey != KeyByTenant {
+		t.Fatalf("unexpected strategies: saga=%q order=%q", cfg.SagaEventsKey, cfg.OrderEventsKey)
+	}
+	if cfg, err = DefaultTopicConfig().WithKeyStrategies(""); err != nil || cfg != DefaultTopicConfig() {
+		t.Fatalf("empty value must keep defaults, got %+v (%v)", cfg, err)
+	}
+
+	for _, raw := range []string{"order_events=customer", "saga_events=region", "dead_letter=order", "saga_events"} {
+		if _, err := DefaultTopicConfig().WithKeyStrategies(raw); !errors.Is(err, ErrInvalidTopicConfig) {
+			t.Fatalf("%q: expected ErrInvalidTopicConfig, got %v", raw, err)
+		}
+	}
+
+	invalid := DefaultTopicConfig()
+	invalid.OrderEventsKey = KeyByCustomer
+	if err := invalid.Validate(); !errors.Is(err, ErrInvalidTopicConfig) {
+		t.Fatalf("Validate must enforce per-topic strategies, got %v", err)
+	}
+}
+
+func TestOutboxPublisher_PublishUsesKeyStrategy(t *testing.T) {
+	var keys []string
+	mockProducer := mocks.NewSyncProducer(t, nil)
+	for range 2 {
+		mockProducer.ExpectSendMessageWithMessageCheckerFunctionAndSucceed(func(msg *sarama.ProducerMessage) error {
+			key, err := msg.Key.Encode()
+			keys = append(keys, string(key))
+			return err
+		})
+	}
+
+	producer := &Producer{producer: mockProducer, logger: log.WithField("component", "kafka-keys-test")}
+	publisher := NewOutboxPublisher(producer, TopicOrderEvents, WithKeyStrategy(KeyByTenant))
+	for _, headers := range []map[string]string{{HeaderTenantID: "tenant-a"}, nil} {
+		if err := publisher.Publish(domain.OutboxMessage{ID: "outbox-1", AggregateID: "order-1", EventType: "OrderCreated", Payload: []byte(`{}`), Headers: headers}); err != nil {
+			t.Fatalf("publish: %v", err)
+		}
+	}
+	if err := mockProducer.Close(); err != nil {
+		t.Fatal(err)
+	}
+	if len(keys) != 2 || keys[0] != "tenant-a" || keys[1] != "order-1" {
+		t.Fatalf("expected tenant key with order fallback, got %v", keys)
+	}
+}
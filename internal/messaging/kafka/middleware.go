@@ -0,0 +1,304 @@
+package kafka
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"runtime/debug"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/IBM/sarama"
+	"github.com/prometheus/client_golang/prometheus"
+	log "github.com/sirupsen/logrus"
+
+	"github.com/vladislavdragonenkov/oms/internal/domain"
+	"github.com/vladislavdragonenkov/oms/internal/metrics"
+)
+
+// ErrHandlerPanic — обработчик сообщения запаниковал; RecoveryMiddleware превращает панику
+// в обычную ошибку, и сообщение проходит штатные повторы и DLQ.
+var ErrHandlerPanic = errors.New("kafka message handler panicked")
+
+const defaultDedupCapacity = 10000
+
+// Middleware оборачивает MessageHandler сквозной логикой, как gRPC interceptor.
+type Middleware func(next MessageHandler) MessageHandler
+
+// Chain собирает обработчик из middleware: первая в списке выполняется первой (внешняя).
+func Chain(handler MessageHandler, middlewares ...Middleware) MessageHandler {
+	for i := len(middlewares) - 1; i >= 0; i-- {
+		if middlewares[i] != nil {
+			handler = middlewares[i](handler)
+		}
+	}
+	return handler
+}
+
+// DefaultMiddleware возвращает стандартную цепочку consumer'а группы group:
+// трассировка, логирование, метрики, пропуск replay, дедупликация по x-event-id и recovery.
+// Recovery стоит последней, чтобы паника обработчика была видна логам и метрикам как ошибка.
+func DefaultMiddleware(group string, logger *log.Entry, registerer prometheus.Registerer) []Middleware {
+	if logger == nil {
+		logger = log.WithField("component", "kafka-consumer")
+	}
+	logger = logger.WithField("consumer_group", group)
+	return []Middleware{
+		TracingMiddleware(),
+		LoggingMiddleware(logger),
+		MetricsMiddleware(group, registerer),
+		SkipReplayMiddleware(logger),
+		DedupMiddleware(NewMemoryDedupStore(defaultDedupCapacity), logger),
+		RecoveryMiddleware(logger),
+	}
+}
+
+// RecoveryMiddleware перехватывает панику обработчика и возвращает ошибку ErrHandlerPanic.
+func RecoveryMiddleware(logger *log.Entry) Middleware {
+	if logger == nil {
+		logger = log.WithField("component", "kafka-consumer")
+	}
+	return func(next MessageHandler) MessageHandler {
+		return func(ctx context.Context, message *sarama.ConsumerMessage) (err error) {
+			defer func() {
+				if recovered := recover(); recovered != nil {
+					logger.WithFields(messageLogFields(message)).
+						WithField("panic", recovered).
+						WithField("stack", string(debug.Stack())).
+						Error("kafka message handler panicked")
+					err = fmt.Errorf("%w: %v", ErrHandlerPanic, recovered)
+				}
+			}()
+			return next(ctx, message)
+		}
+	}
+}
+
+// LoggingMiddleware пишет в debug каждую успешную попытку обработки и в warn — неудачную.
+func LoggingMiddleware(logger *log.Entry) Middleware {
+	if logger == nil {
+		logger = log.WithField("component", "kafka-consumer")
+	}
+	return func(next MessageHandler) MessageHandler {
+		return func(ctx context.Context, message *sarama.ConsumerMessage) error {
+			started := time.Now()
+			err := next(ctx, message)
+
+			entry := logger.WithFields(messageLogFields(message)).WithField("duration", time.Since(started))
+			if headers, ok := HeadersFromContext(ctx); ok {
+				if traceID := traceIDFromParent(headers.TraceParent); traceID != "" {
+					entry = entry.WithField("trace_id", traceID)
+				}
+			}
+			if err != nil {
+				entry.WithError(err).Warn("kafka message handler failed")
+				return err
+			}
+			entry.Debug("kafka message handled")
+			return nil
+		}
+	}
+}
+
+// MetricsMiddleware считает попытки обработки и их длительность по топику.
+func MetricsMiddleware(group string, registerer prometheus.Registerer) Middleware {
+	handled := metrics.Register(registerer, prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: "oms_kafka_consumer_messages_total",
+		Help: "Total number of Kafka message handling attempts by consumer group, topic and result.",
+	}, []string{"group", "topic", "result"}))
+	duration := metrics.Register(registerer, prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Name:    "oms_kafka_consumer_handle_duration_seconds",
+		Help:    "Kafka message handler duration by consumer group and topic.",
+		Buckets: prometheus.DefBuckets,
+	}, []string{"group", "topic"}))
+
+	return func(next MessageHandler) MessageHandler {
+		return func(ctx context.Context, message *sarama.ConsumerMessage) error {
+			started := time.Now()
+			err := next(ctx, message)
+
+			topic := messageTopic(message)
+			result := "ok"
+			if err != nil {
+				result = "error"
+			}
+			handled.WithLabelValues(group, topic, result).Inc()
+			duration.WithLabelValues(group, topic).Observe(time.Since(started).Seconds())
+			return err
+		}
+	}
+}
+
+// TracingMiddleware открывает для обработки сообщения новый span в терминах W3C trace context:
+// trace id входящего traceparent сохраняется, span id генерируется заново. Сообщение без
+// корректного traceparent начинает новую трассу. Результат доступен через HeadersFromContext
+// и уходит в события outbox, записанные обработчиком.
+func TracingMiddleware() Middleware {
+	return func(next MessageHandler) MessageHandler {
+		return func(ctx context.Context, message *sarama.ConsumerMessage) error {
+			headers, ok := HeadersFromContext(ctx)
+			if !ok {
+				headers = ParseHeaders(message)
+			}
+			headers.TraceParent = childTraceParent(headers.TraceParent)
+			ctx = domain.ContextWithEventHeaders(ContextWithHeaders(ctx, headers), headers.EventHeaders())
+			if domain.EventChainFromContext(ctx) == nil {
+				ctx = ContextWithEventChain(ctx, headers)
+			}
+			return next(ctx, message)
+		}
+	}
+}
+
+// DedupStore запоминает x-event-id успешно обработанных сообщений.
+type DedupStore interface {
+	Seen(eventID string) bool
+	Remember(eventID string)
+}
+
+// DedupMiddleware пропускает сообщения, чей x-event-id уже был успешно обработан.
+// Сообщения без x-event-id обрабатываются всегда; неудачная попытка ID не запоминает.
+func DedupMiddleware(store DedupStore, logger *log.Entry) Middleware {
+	if logger == nil {
+		logger = log.WithField("component", "kafka-consumer")
+	}
+	return func(next MessageHandler) MessageHandler {
+		return func(ctx context.Context, message *sarama.ConsumerMessage) error {
+			eventID, _ := HeaderValue(message, HeaderEventID)
+			if eventID == "" || store == nil {
+				return next(ctx, message)
+			}
+			if store.Seen(eventID) {
+				logger.WithFields(messageLogFields(message)).WithField("event_id", eventID).Debug("duplicate kafka message skipped")
+				return nil
+			}
+			if err := next(ctx, message); err != nil {
+				return err
+			}
+			store.Remember(eventID)
+			return nil
+		}
+	}
+}
+
+// SkipReplayMiddleware подтверждает без обработки сообщения с header x-replay: повторная
+// публикация предназначена для пересборки проекций и не должна заново запускать бизнес-логику.
+// Consumer'ы, которые читают replay, в цепочку её не включают.
+func SkipReplayMiddleware(logger *log.Entry) Middleware {
+	if logger == nil {
+		logger = log.WithField("component", "kafka-consumer")
+	}
+	return func(next MessageHandler) MessageHandler {
+		return func(ctx context.Context, message *sarama.ConsumerMessage) error {
+			if IsReplay(message) {
+				logger.WithFields(messageLogFields(message)).Debug("replayed kafka message skipped")
+				return nil
+			}
+			return next(ctx, message)
+		}
+	}
+}
+
+// MemoryDedupStore хранит последние capacity идентификаторов в памяти процесса.
+// После rebalance другой инстанс группы дубликаты не распознает — обработчики
+// по-прежнему должны быть идемпотентны, дедупликация лишь снимает лишнюю работу.
+type MemoryDedupStore struct {
+	mu       sync.Mutex
+	capacity int
+	seen     map[string]struct{}
+	order    []string
+	next     int
+}
+
+// NewMemoryDedupStore создаёт хранилище на capacity идентификаторов (<= 0 — значение по умолчанию).
+func NewMemoryDedupStore(capacity int) *MemoryDedupStore {
+	if capacity <= 0 {
+		capacity = defaultDedupCapacity
+	}
+	return &MemoryDedupStore{
+		capacity: capacity,
+		seen:     make(map[string]struct{}, capacity),
+		order:    make([]string, 0, capacity),
+	}
+}
+
+// Seen сообщает, что eventID уже запомнен.
+func (s *MemoryDedupStore) Seen(eventID string) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	_, ok := s.seen[eventID]
+	return ok
+}
+
+// Remember запоминает eventID, вытесняя самый старый при переполнении.
+func (s *MemoryDedupStore) Remember(eventID string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if _, ok := s.seen[eventID]; ok {
+		return
+	}
+	if len(s.order) < s.capacity {
+		s.order = append(s.order, eventID)
+	} else {
+		delete(s.seen, s.order[s.next])
+		s.order[s.next] = eventID
+		s.next = (s.next + 1) % s.capacity
+	}
+	s.seen[eventID] = struct{}{}
+}
+
+func messageLogFields(message *sarama.ConsumerMessage) log.Fields {
+	if message == nil {
+		return log.Fields{}
+	}
+	return log.Fields{
+		"topic":     message.Topic,
+		"partition": message.Partition,
+		"offset":    message.Offset,
+	}
+}
+
+func messageTopic(message *sarama.ConsumerMessage) string {
+	if message == nil {
+		return ""
+	}
+	return message.Topic
+}
+
+// childTraceParent возвращает traceparent дочернего span'а; для пустого или
+// некорректного parent начинается новая трасса с флагом sampled.
+func childTraceParent(parent string) string {
+	parts := strings.Split(strings.TrimSpace(parent), "-")
+	if len(parts) == 4 && len(parts[0]) == 2 && isHexID(parts[1], 32) && isHexID(parts[2], 16) && len(parts[3]) == 2 {
+		return strings.Join([]string{parts[0], parts[1], randomHex(8), parts[3]}, "-")
+	}
+	return "00-" + randomHex(16) + "-" + randomHex(8) + "-01"
+}
+
+func traceIDFromParent(traceParent string) string {
+	parts := strings.Split(traceParent, "-")
+	if len(parts) != 4 || !isHexID(parts[1], 32) {
+		return ""
+	}
+	return parts[1]
+}
+
+// isHexID проверяет длину и алфавит идентификатора; нулевой ID по W3C некорректен.
+func isHexID(value string, length int) bool {
+	if len(value) != length || strings.Trim(value, "0") == "" {
+		return false
+	}
+	_, err := hex.DecodeString(value)
+	return err == nil && strings.ToLower(value) == value
+}
+
+func randomHex(size int) string {
+	buf := make([]byte, size)
+	_, _ = rand.Read(buf)
+	return hex.EncodeToString(buf)
+}
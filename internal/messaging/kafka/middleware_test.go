@@ -0,0 +1,148 @@
+package kafka
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/IBM/sarama"
+	"github.com/prometheus/client_golang/prometheus"
+	log "github.com/sirupsen/logrus"
+
+	"github.com/vladislavdragonenkov/oms/internal/domain"
+	"github.com/vladislavdragonenkov/oms/internal/metrics/metricstest"
+)
+
+func TestChain_OrderAndRecovery(t *testing.T) {
+	var calls []string
+	trace := func(name string) Middleware {
+		return func(next MessageHandler) MessageHandler {
+			return func(ctx context.Context, message *sarama.ConsumerMessage) error {
+				calls = append(calls, name)
+				return next(ctx, message)
+			}
+		}
+	}
+
+	handler := Chain(func(context.Context, *sarama.ConsumerMessage) error {
+		calls = append(calls, "handler")
+		panic("boom")
+	}, trace("outer"), nil, trace("inner"), RecoveryMiddleware(log.NewEntry(log.New())))
+
+	err := handler(context.Background(), &sarama.ConsumerMessage{Topic: "oms.inventory.restock"})
+	if !errors.Is(err, ErrHandlerPanic) {
+		t.Fatalf("expected ErrHandlerPanic, got %v", err)
+	}
+	if got := strings.Join(calls, ","); got != "outer,inner,handler" {
+		t.Fatalf("unexpected call order: %s", got)
+	}
+}
+
+func TestDedupMiddleware_SkipsProcessedEventIDs(t *testing.T) {
+	calls := 0
+	fail := true
+	handler := Chain(func(context.Context, *sarama.ConsumerMessage) error {
+		calls++
+		if fail {
+			return errors.New("temporary")
+		}
+		return nil
+	}, DedupMiddleware(NewMemoryDedupStore(1), nil))
+
+	message := func(eventID string) *sarama.ConsumerMessage {
+		return &sarama.ConsumerMessage{Headers: []*sarama.RecordHeader{{Key: []byte(HeaderEventID), Value: []byte(eventID)}}}
+	}
+
+	if err := handler(context.Background(), message("evt-1")); err == nil {
+		t.Fatal("expected handler error")
+	}
+	fail = false
+	for i := 0; i < 2; i++ {
+		if err := handler(context.Background(), message("evt-1")); err != nil {
+			t.Fatalf("handle evt-1: %v", err)
+		}
+	}
+	if calls != 2 {
+		t.Fatalf("failed attempt must not be remembered and duplicate must be skipped, calls=%d", calls)
+	}
+
+	// Ёмкость 1: evt-2 вытесняет evt-1, и тот снова обрабатывается.
+	_ = handler(context.Background(), message("evt-2"))
+	_ = handler(context.Background(), message("evt-1"))
+	_ = handler(context.Background(), &sarama.ConsumerMessage{})
+	if calls != 5 {
+		t.Fatalf("expected eviction and pass-through without event id, calls=%d", calls)
+	}
+}
+
+func TestTracingMiddleware_StartsChildSpan(t *testing.T) {
+	const parent = "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01"
+
+	var got MessageHeaders
+	var eventHeaders map[string]string
+	handler := Chain(func(ctx context.Context, _ *sarama.ConsumerMessage) error {
+		got, _ = HeadersFromContext(ctx)
+		eventHeaders = domain.EventHeadersFromContext(ctx)
+		return nil
+	}, TracingMiddleware())
+
+	message := &sarama.ConsumerMessage{Headers: []*sarama.RecordHeader{
+		{Key: []byte(HeaderTraceParent), Value: []byte(parent)},
+		{Key: []byte(HeaderTenantID), Value: []byte("tenant-a")},
+	}}
+	if err := handler(context.Background(), message); err != nil {
+		t.Fatalf("handle: %v", err)
+	}
+	if traceIDFromParent(got.TraceParent) != "4bf92f3577b34da6a3ce929d0e0e4736" || got.TraceParent == parent {
+		t.Fatalf("expected child span of the same trace, got %q", got.TraceParent)
+	}
+	if eventHeaders[HeaderTraceParent] != got.TraceParent || eventHeaders[HeaderTenantID] != "tenant-a" {
+		t.Fatalf("outbox headers must follow the new span: %v", eventHeaders)
+	}
+
+	if err := handler(context.Background(), &sarama.ConsumerMessage{}); err != nil {
+		t.Fatalf("handle without trace: %v", err)
+	}
+	if traceIDFromParent(got.TraceParent) == "" || !strings.HasSuffix(got.TraceParent, "-01") {
+		t.Fatalf("expected new sampled trace, got %q", got.TraceParent)
+	}
+}
+
+func TestMetricsMiddleware_CountsResults(t *testing.T) {
+	registry := prometheus.NewRegistry()
+	handler := Chain(func(_ context.Context, message *sarama.ConsumerMessage) error {
+		if message.Offset == 1 {
+			return errors.New("failed")
+		}
+		return nil
+	}, DefaultMiddleware("oms-backorders", log.NewEntry(log.New()), registry)...)
+
+	_ = handler(context.Background(), &sarama.ConsumerMessage{Topic: "oms.inventory.restock", Offset: 0})
+	_ = handler(context.Background(), &sarama.ConsumerMessage{Topic: "oms.inventory.restock", Offset: 1})
+
+	restock := metricstest.Labels{"group": "oms-backorders", "topic": "oms.inventory.restock"}
+	metricstest.RequireValue(t, registry, "oms_kafka_consumer_messages_total", metricstest.Labels{"group": "oms-backorders", "topic": "oms.inventory.restock", "result": "ok"}, 1)
+	metricstest.RequireValue(t, registry, "oms_kafka_consumer_messages_total", metricstest.Labels{"group": "oms-backorders", "topic": "oms.inventory.restock", "result": "error"}, 1)
+	// Длительность пишется на каждую попытку, включая неудачные.
+	metricstest.RequireValue(t, registry, "oms_kafka_consumer_handle_duration_seconds", restock, 2)
+}
+
+func TestSkipReplayMiddleware(t *testing.T) {
+	calls := 0
+	handler := Chain(func(context.Context, *sarama.ConsumerMessage) error {
+		calls++
+		return nil
+	}, SkipReplayMiddleware(nil))
+
+	replayed := &sarama.ConsumerMessage{Headers: []*sarama.RecordHeader{{Key: []byte(HeaderReplay), Value: []byte("replay-1")}}}
+	if err := handler(context.Background(), replayed); err != nil {
+		t.Fatalf("replayed message must be acknowledged: %v", err)
+	}
+	if err := handler(context.Background(), &sarama.ConsumerMessage{}); err != nil {
+		t.Fatalf("handle message: %v", err)
+	}
+	if calls != 1 {
+		t.Fatalf("expected only the regular message to reach the handler, calls=%d", calls)
+	}
+}
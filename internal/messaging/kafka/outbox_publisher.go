@@ -1,6 +1,7 @@
 package kafka
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"time"
@@ -8,21 +9,76 @@ import (
 	"github.com/vladislavdragonenkov/oms/internal/domain"
 )
 
+// OutboxEnvelope — формат сообщения, которое outbox-паблишер пишет в Kafka.
+type OutboxEnvelope struct {
+	ID            string          `json:"id"`
+	AggregateType string          `json:"aggregate_type"`
+	AggregateID   string          `json:"aggregate_id"`
+	EventType     string          `json:"event_type"`
+	Payload       json.RawMessage `json:"payload"`
+	PublishedAt   time.Time       `json:"published_at"`
+	// Encryption заполнен, если часть полей payload зашифрована (см. FieldEncryptor).
+	Encryption *EncryptionInfo `json:"encryption,omitempty"`
+	// CorrelationID и CausationID — цепочка событий (domain.EventChain); дублируются в headers.
+	CorrelationID string `json:"correlation_id,omitempty"`
+	CausationID   string `json:"causation_id,omitempty"`
+}
+
+// DecodeOutboxEnvelope разбирает сообщение outbox-паблишера и, если payload зашифрован,
+// расшифровывает его через wrapper. Для незашифрованных сообщений wrapper может быть nil.
+// Формат (JSON или protobuf) определяется по содержимому value.
+func DecodeOutboxEnvelope(ctx context.Context, value []byte, wrapper KeyWrapper) (OutboxEnvelope, error) {
+	var envelope OutboxEnvelope
+	if err := DetectCodec("", value).Unmarshal(value, &envelope); err != nil {
+		return OutboxEnvelope{}, fmt.Errorf("decode outbox envelope: %w", err)
+	}
+	payload, err := DecryptFields(ctx, wrapper, envelope.ID, envelope.Payload, envelope.Encryption)
+	if err != nil {
+		return OutboxEnvelope{}, err
+	}
+	envelope.Payload = payload
+	envelope.Encryption = nil
+	return envelope, nil
+}
+
 // OutboxTopicPublisher публикует outbox-сообщения в заданный Kafka topic.
 type OutboxTopicPublisher struct {
-	producer *Producer
-	topic    string
+	producer  *Producer
+	topic     string
+	encryptor *FieldEncryptor
+	keys      KeyStrategy
+}
+
+// OutboxPublisherOption настраивает OutboxTopicPublisher.
+type OutboxPublisherOption func(*OutboxTopicPublisher)
+
+// WithFieldEncryptor включает шифрование чувствительных полей payload перед публикацией.
+func WithFieldEncryptor(encryptor *FieldEncryptor) OutboxPublisherOption {
+	return func(p *OutboxTopicPublisher) {
+		p.encryptor = encryptor
+	}
+}
+
+// WithKeyStrategy задаёт стратегию ключа сообщений (TopicConfig.OrderEventsKey); по умолчанию — заказ.
+func WithKeyStrategy(strategy KeyStrategy) OutboxPublisherOption {
+	return func(p *OutboxTopicPublisher) {
+		p.keys = strategy
+	}
 }
 
 // NewOutboxPublisher создаёт Kafka-паблишер для transactional outbox.
-func NewOutboxPublisher(producer *Producer, topic string) domain.OutboxPublisher {
+func NewOutboxPublisher(producer *Producer, topic string, options ...OutboxPublisherOption) domain.OutboxPublisher {
 	if topic == "" {
 		topic = TopicOrderEvents
 	}
-	return &OutboxTopicPublisher{
+	publisher := &OutboxTopicPublisher{
 		producer: producer,
 		topic:    topic,
 	}
+	for _, option := range options {
+		option(publisher)
+	}
+	return publisher
 }
 
 func (p *OutboxTopicPublisher) Publish(event domain.OutboxMessage) error {
@@ -30,28 +86,63 @@ func (p *OutboxTopicPublisher) Publish(event domain.OutboxMessage) error {
 		return fmt.Errorf("kafka outbox publisher is not initialized")
 	}
 
-	key := event.AggregateID
-	if key == "" {
-		key = event.ID
+	orderID := event.AggregateID
+	if orderID == "" {
+		orderID = event.ID
 	}
+	key := p.keys.Key(MessageKey{OrderID: orderID, TenantID: event.Headers[HeaderTenantID]})
 
-	envelope := struct {
-		ID            string          `json:"id"`
-		AggregateType string          `json:"aggregate_type"`
-		AggregateID   string          `json:"aggregate_id"`
-		EventType     string          `json:"event_type"`
-		Payload       json.RawMessage `json:"payload"`
-		PublishedAt   time.Time       `json:"published_at"`
-	}{
+	envelope := OutboxEnvelope{
 		ID:            event.ID,
 		AggregateType: event.AggregateType,
 		AggregateID:   event.AggregateID,
 		EventType:     event.EventType,
 		Payload:       json.RawMessage(event.Payload),
 		PublishedAt:   time.Now().UTC(),
+		CorrelationID: event.Headers[HeaderCorrelationID],
+		CausationID:   event.Headers[HeaderCausationID],
+	}
+	if p.encryptor != nil {
+		payload, info, err := p.encryptor.Encrypt(context.Background(), event.ID, event.Payload)
+		if err != nil {
+			return err
+		}
+		envelope.Payload, envelope.Encryption = payload, info
 	}
 
-	return p.producer.PublishEvent(p.topic, key, envelope)
+	return p.producer.PublishEventWithHeaders(p.topic, key, envelope, outboxHeaders(event, envelope.PublishedAt))
+}
+
+// outboxHeaders собирает headers сообщения из outbox-записи. event-id совпадает с id записи:
+// при повторной публикации потребитель увидит тот же id. Сохранённые headers (trace, tenant)
+// не могут переопределить служебные event-id, event-type, schema-version, occurred-at и retry-count.
+func outboxHeaders(event domain.OutboxMessage, publishedAt time.Time) MessageHeaders {
+	headers := MessageHeaders{
+		EventID:    event.ID,
+		EventType:  event.EventType,
+		OccurredAt: publishedAt,
+	}
+	for key, value := range event.Headers {
+		switch key {
+		case HeaderTraceParent:
+			headers.TraceParent = value
+		case HeaderTraceState:
+			headers.TraceState = value
+		case HeaderTenantID:
+			headers.TenantID = value
+		case HeaderCorrelationID:
+			headers.CorrelationID = value
+		case HeaderCausationID:
+			headers.CausationID = value
+		case HeaderEventID, HeaderEventType, HeaderSchemaVersion, HeaderOccurredAt, HeaderRetryCount:
+		default:
+			if headers.Extra == nil {
+				headers.Extra = make(map[string]string)
+			}
+			headers.Extra[key] = value
+		}
+	}
+	return headers
 }
 
 var _ domain.OutboxPublisher = (*OutboxTopicPublisher)(nil)
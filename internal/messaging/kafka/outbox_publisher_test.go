@@ -1,6 +1,10 @@
 package kafka
 
 import (
+	"context"
+	"encoding/json"
+	"errors"
+	"strings"
 	"testing"
 
 	"github.com/IBM/sarama"
@@ -38,6 +42,67 @@ func TestOutboxPublisher_Publish(t *testing.T) {
 	}
 }
 
+func TestOutboxPublisher_PublishCopiesStoredHeaders(t *testing.T) {
+	t.Parallel()
+
+	got := make(map[string]string)
+	var envelope OutboxEnvelope
+	mockProducer := mocks.NewSyncProducer(t, nil)
+	mockProducer.ExpectSendMessageWithMessageCheckerFunctionAndSucceed(func(msg *sarama.ProducerMessage) error {
+		for _, header := range msg.Headers {
+			got[string(header.Key)] = string(header.Value)
+		}
+		value, err := msg.Value.Encode()
+		if err != nil {
+			return err
+		}
+		return json.Unmarshal(value, &envelope)
+	})
+
+	producer := &Producer{
+		producer: mockProducer,
+		logger:   log.WithField("component", "kafka-outbox-publisher-test"),
+	}
+	publisher := NewOutboxPublisher(producer, TopicOrderEvents)
+
+	err := publisher.Publish(domain.OutboxMessage{
+		ID:          "outbox-1",
+		AggregateID: "order-123",
+		EventType:   "OrderStatusChanged",
+		Payload:     []byte(`{"status":"confirmed"}`),
+		Headers: map[string]string{
+			HeaderTraceParent:   "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01",
+			HeaderTenantID:      "tenant-a",
+			HeaderCorrelationID: "req-1",
+			HeaderCausationID:   "outbox-0",
+			"x-source":          "order-service",
+			HeaderEventID:       "forged-id",
+		},
+	})
+	if err != nil {
+		t.Fatalf("publish failed: %v", err)
+	}
+	if err := mockProducer.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	if got[HeaderTraceParent] != "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01" || got[HeaderTenantID] != "tenant-a" {
+		t.Fatalf("expected trace and tenant headers, got %v", got)
+	}
+	if got["x-source"] != "order-service" {
+		t.Fatalf("expected extra header to be copied, got %v", got)
+	}
+	if got[HeaderEventID] != "outbox-1" {
+		t.Fatalf("stored headers must not override event id, got %q", got[HeaderEventID])
+	}
+	if got[HeaderCorrelationID] != "req-1" || got[HeaderCausationID] != "outbox-0" {
+		t.Fatalf("expected event chain headers, got %v", got)
+	}
+	if envelope.CorrelationID != "req-1" || envelope.CausationID != "outbox-0" {
+		t.Fatalf("expected event chain in envelope, got %+v", envelope)
+	}
+}
+
 func TestOutboxPublisher_PublishProducerError(t *testing.T) {
 	t.Parallel()
 
@@ -74,3 +139,61 @@ func TestOutboxPublisher_PublishNilProducer(t *testing.T) {
 		t.Fatal("expected error for nil producer")
 	}
 }
+
+func TestOutboxPublisher_PublishEncryptsFields(t *testing.T) {
+	t.Parallel()
+
+	var sent *sarama.ProducerMessage
+	mockProducer := mocks.NewSyncProducer(t, nil)
+	mockProducer.ExpectSendMessageWithMessageCheckerFunctionAndSucceed(func(msg *sarama.ProducerMessage) error {
+		sent = msg
+		return nil
+	})
+
+	wrapper, err := ParseStaticKeyWrapper(testKeySpec("k1"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	encryptor, err := NewFieldEncryptor(wrapper, "customer_id")
+	if err != nil {
+		t.Fatal(err)
+	}
+	producer := &Producer{
+		producer: mockProducer,
+		logger:   log.WithField("component", "kafka-outbox-publisher-test"),
+	}
+	publisher := NewOutboxPublisher(producer, TopicOrderEvents, WithFieldEncryptor(encryptor))
+
+	err = publisher.Publish(domain.OutboxMessage{
+		ID:            "outbox-4",
+		AggregateType: "order",
+		AggregateID:   "order-456",
+		EventType:     "OrderCreated",
+		Payload:       []byte(`{"customer_id":"customer-1","status":"pending"}`),
+	})
+	if err != nil {
+		t.Fatalf("publish failed: %v", err)
+	}
+	if err := mockProducer.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	value, err := sent.Value.Encode()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if strings.Contains(string(value), "customer-1") {
+		t.Fatalf("customer_id published in clear text: %s", value)
+	}
+
+	envelope, err := DecodeOutboxEnvelope(context.Background(), value, wrapper)
+	if err != nil {
+		t.Fatalf("decode envelope: %v", err)
+	}
+	if envelope.Encryption != nil || string(envelope.Payload) != `{"customer_id":"customer-1","status":"pending"}` {
+		t.Fatalf("unexpected decoded envelope: %+v payload=%s", envelope, envelope.Payload)
+	}
+	if _, err := DecodeOutboxEnvelope(context.Background(), value, nil); !errors.Is(err, ErrEncryptionKeyRequired) {
+		t.Fatalf("expected ErrEncryptionKeyRequired, got %v", err)
+	}
+}
@@ -1,22 +1,74 @@
 package kafka
 
 import (
-	"encoding/json"
+	"context"
 	"fmt"
-	"time"
 
 	"github.com/IBM/sarama"
+	"github.com/prometheus/client_golang/prometheus"
 	log "github.com/sirupsen/logrus"
+
+	"github.com/vladislavdragonenkov/oms/internal/dependency"
+	"github.com/vladislavdragonenkov/oms/internal/logging"
+	"github.com/vladislavdragonenkov/oms/internal/timeutil"
 )
 
 // Producer представляет Kafka producer для публикации событий
 type Producer struct {
-	producer sarama.SyncProducer
-	logger   *log.Entry
+	producer  sarama.SyncProducer
+	logger    *log.Entry
+	encryptor *FieldEncryptor
+	// codecs — формат сериализации по топикам; для остальных топиков используется JSON.
+	codecs map[string]Codec
+	// breaker — опциональный circuit breaker (WithProducerCircuitBreaker).
+	breaker *producerBreaker
+	// sampler ограничивает повтор одинаковых ошибок отправки, пока брокер недоступен.
+	sampler *logging.Sampler
+	// dependency — метрики отправок как зависимости kafka (WithProducerDependencyMetrics).
+	dependency *dependency.Tracker
+}
+
+// ProducerOption настраивает Producer.
+type ProducerOption func(*Producer)
+
+// WithProducerEncryptor включает шифрование чувствительных полей metadata у SagaEvent.
+func WithProducerEncryptor(encryptor *FieldEncryptor) ProducerOption {
+	return func(p *Producer) {
+		p.encryptor = encryptor
+	}
+}
+
+// WithTopicCodec задаёт формат сериализации событий для topic. Consumer'ы определяют формат
+// по header content-type, поэтому топик можно переключать без остановки читателей.
+func WithTopicCodec(topic string, codec Codec) ProducerOption {
+	return func(p *Producer) {
+		if codec == nil {
+			return
+		}
+		if p.codecs == nil {
+			p.codecs = make(map[string]Codec)
+		}
+		p.codecs[topic] = codec
+	}
+}
+
+// WithProducerLogSampler заменяет ограничитель логов ошибок отправки; nil пишет каждую ошибку.
+func WithProducerLogSampler(sampler *logging.Sampler) ProducerOption {
+	return func(p *Producer) {
+		p.sampler = sampler
+	}
+}
+
+// WithProducerDependencyMetrics учитывает отправки в общих метриках зависимостей (dependency="kafka");
+// состояние WithProducerCircuitBreaker публикуется в oms_dependency_circuit_state.
+func WithProducerDependencyMetrics(registerer prometheus.Registerer) ProducerOption {
+	return func(p *Producer) {
+		p.dependency = dependency.NewTracker(dependency.Kafka, registerer)
+	}
 }
 
 // NewProducer создает новый Kafka producer
-func NewProducer(brokers []string) (*Producer, error) {
+func NewProducer(brokers []string, options ...ProducerOption) (*Producer, error) {
 	config := sarama.NewConfig()
 	config.Producer.RequiredAcks = sarama.WaitForAll // Wait for all in-sync replicas
 	config.Producer.Retry.Max = 5
@@ -29,39 +81,92 @@ func NewProducer(brokers []string) (*Producer, error) {
 	if err != nil {
 		return nil, fmt.Errorf("failed to create kafka producer: %w", err)
 	}
+	return NewProducerFromSync(producer, options...), nil
+}
 
-	return &Producer{
+// NewProducerFromSync создаёт Producer поверх готового sarama.SyncProducer (например, mocks в тестах).
+func NewProducerFromSync(producer sarama.SyncProducer, options ...ProducerOption) *Producer {
+	p := &Producer{
 		producer: producer,
 		logger:   log.WithField("component", "kafka-producer"),
-	}, nil
+		sampler:  logging.NewSampler("kafka-producer"),
+	}
+	for _, option := range options {
+		option(p)
+	}
+	return p
 }
 
-// PublishEvent публикует событие в Kafka
+// PublishEvent публикует событие в Kafka со стандартными headers.
+// Для SagaEvent/OrderEvent тип и время события берутся из самого события.
 func (p *Producer) PublishEvent(topic string, key string, event interface{}) error {
-	eventData, err := json.Marshal(event)
+	if sagaEvent, ok := event.(*SagaEvent); ok && p.encryptor != nil {
+		encrypted, err := p.encryptor.EncryptSagaEvent(context.Background(), sagaEvent)
+		if err != nil {
+			return err
+		}
+		event = encrypted
+	}
+	return p.PublishEventWithHeaders(topic, key, event, headersForEvent(event))
+}
+
+// PublishEventWithHeaders публикует событие с явно заданными headers.
+// Незаполненные event-id, occurred-at и schema-version проставляются автоматически;
+// content-type соответствует кодеку топика (WithTopicCodec).
+func (p *Producer) PublishEventWithHeaders(topic string, key string, event interface{}, headers MessageHeaders) error {
+	codec := p.codecFor(topic)
+	eventData, err := codec.Marshal(event)
 	if err != nil {
 		return fmt.Errorf("failed to marshal event: %w", err)
 	}
+	headers.ContentType = codec.ContentType()
+	return p.publishRaw(topic, key, eventData, headers)
+}
+
+func (p *Producer) codecFor(topic string) Codec {
+	if codec, ok := p.codecs[topic]; ok {
+		return codec
+	}
+	return JSONCodec{}
+}
+
+// publishRaw отправляет уже сериализованный payload без повторного кодирования.
+func (p *Producer) publishRaw(topic string, key string, eventData []byte, headers MessageHeaders) error {
+	now := timeutil.Now()
+	headers = headers.withDefaults(now)
 
 	msg := &sarama.ProducerMessage{
 		Topic:     topic,
 		Key:       sarama.StringEncoder(key),
 		Value:     sarama.ByteEncoder(eventData),
-		Timestamp: time.Now(),
+		Headers:   headers.Records(),
+		Timestamp: now,
+	}
+
+	if p.breaker != nil && !p.breaker.allow() {
+		if p.dependency != nil {
+			p.dependency.RecordRejected()
+		}
+		return ErrProducerCircuitOpen
 	}
+	// allow мог перевести цепь в half-open для пробной отправки.
+	p.reportCircuit()
 
-	partition, offset, err := p.producer.SendMessage(msg)
+	partition, offset, err := p.sendMessage(msg)
 	if err != nil {
-		p.logger.WithError(err).WithFields(log.Fields{
-			"topic": topic,
-			"key":   key,
-		}).Error("failed to send message to kafka")
+		p.logSendFailure(err, topic, key, headers.EventID)
+		p.reportCircuit()
 		return fmt.Errorf("failed to send message: %w", err)
 	}
+	if p.breaker != nil && p.breaker.success() {
+		p.logger.Info("kafka producer circuit closed")
+	}
+	p.reportCircuit()
 
 	p.logger.WithFields(log.Fields{
 		"topic":     topic,
 		"key":       key,
+		"event_id":  headers.EventID,
 		"partition": partition,
 		"offset":    offset,
 	}).Debug("message sent to kafka")
@@ -69,6 +174,61 @@ func (p *Producer) PublishEvent(topic string, key string, event interface{}) err
 	return nil
 }
 
+// sendMessage отправляет msg брокеру, учитывая вызов в метриках зависимости kafka.
+func (p *Producer) sendMessage(msg *sarama.ProducerMessage) (partition int32, offset int64, err error) {
+	if p.dependency == nil {
+		return p.producer.SendMessage(msg)
+	}
+	err = p.dependency.Do("publish", func() error {
+		partition, offset, err = p.producer.SendMessage(msg)
+		return err
+	})
+	return partition, offset, err
+}
+
+// reportCircuit публикует состояние circuit breaker'а в метриках зависимости kafka.
+func (p *Producer) reportCircuit() {
+	if p.dependency == nil || p.breaker == nil {
+		return
+	}
+	p.dependency.SetCircuitState(p.breaker.circuitState())
+}
+
+// logSendFailure пишет ошибку отправки. С circuit breaker после открытия цепи пишется одно
+// предупреждение, а ошибки пробных отправок уходят в debug.
+func (p *Producer) logSendFailure(err error, topic, key, eventID string) {
+	entry := p.logger.WithError(err).WithFields(log.Fields{
+		"topic":    topic,
+		"key":      key,
+		"event_id": eventID,
+	})
+	if p.breaker == nil {
+		p.sampler.Log(entry, log.ErrorLevel, "send-failed", "failed to send message to kafka")
+		return
+	}
+
+	wasOpen := p.breaker.isOpen()
+	switch {
+	case p.breaker.failure():
+		entry.WithField("cooldown", p.breaker.cooldown.String()).Warn("kafka producer circuit opened")
+	case wasOpen:
+		entry.Debug("kafka producer circuit probe failed")
+	default:
+		p.sampler.Log(entry, log.ErrorLevel, "send-failed", "failed to send message to kafka")
+	}
+}
+
+func headersForEvent(event interface{}) MessageHeaders {
+	switch e := event.(type) {
+	case *SagaEvent:
+		return MessageHeaders{EventType: string(e.EventType), OccurredAt: e.Timestamp, CorrelationID: e.CorrelationID, CausationID: e.CausationID}
+	case *OrderEvent:
+		return MessageHeaders{EventType: string(e.EventType), OccurredAt: e.Timestamp}
+	default:
+		return MessageHeaders{}
+	}
+}
+
 // Close закрывает producer
 func (p *Producer) Close() error {
 	if err := p.producer.Close(); err != nil {
@@ -0,0 +1,150 @@
+package kafka
+
+import (
+	"errors"
+	"sync"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+
+	"github.com/vladislavdragonenkov/oms/internal/dependency"
+	"github.com/vladislavdragonenkov/oms/internal/metrics"
+)
+
+// ErrProducerCircuitOpen возвращается без обращения к брокеру, пока circuit breaker producer'а открыт.
+var ErrProducerCircuitOpen = errors.New("kafka producer circuit is open")
+
+// WithProducerCircuitBreaker открывает цепь после threshold подряд неудачных отправок: следующие cooldown
+// публикации сразу получают ErrProducerCircuitOpen, затем одна пробная отправка решает, закрыть ли цепь.
+// Пока цепь открыта, ошибки отдельных сообщений не логируются — только смена состояния.
+// threshold <= 0 выключает breaker.
+func WithProducerCircuitBreaker(threshold int, cooldown time.Duration, registerer prometheus.Registerer) ProducerOption {
+	return func(p *Producer) {
+		if threshold <= 0 {
+			p.breaker = nil
+			return
+		}
+		p.breaker = newProducerBreaker(threshold, cooldown, registerer)
+	}
+}
+
+type breakerState int
+
+const (
+	breakerClosed breakerState = iota
+	breakerOpen
+	breakerHalfOpen
+)
+
+type producerBreaker struct {
+	mu        sync.Mutex
+	threshold int
+	cooldown  time.Duration
+	now       func() time.Time
+
+	state    breakerState
+	failures int
+	openedAt time.Time
+	probing  bool
+
+	open     prometheus.Gauge
+	rejected prometheus.Counter
+}
+
+func newProducerBreaker(threshold int, cooldown time.Duration, registerer prometheus.Registerer) *producerBreaker {
+	return &producerBreaker{
+		threshold: threshold,
+		cooldown:  cooldown,
+		now:       time.Now,
+		open: metrics.Register(registerer, prometheus.NewGauge(prometheus.GaugeOpts{
+			Name: "oms_kafka_producer_circuit_open",
+			Help: "Whether the kafka producer circuit breaker is open (1) or closed (0).",
+		})),
+		rejected: metrics.Register(registerer, prometheus.NewCounter(prometheus.CounterOpts{
+			Name: "oms_kafka_producer_circuit_rejected_total",
+			Help: "Total number of kafka publishes rejected without contacting brokers because the circuit was open.",
+		})),
+	}
+}
+
+// allow решает, можно ли отправлять сообщение. В half-open пропускается одна пробная отправка.
+func (b *producerBreaker) allow() bool {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	switch b.state {
+	case breakerOpen:
+		if b.now().Sub(b.openedAt) < b.cooldown {
+			b.rejected.Inc()
+			return false
+		}
+		b.state = breakerHalfOpen
+		b.probing = true
+		return true
+	case breakerHalfOpen:
+		if b.probing {
+			b.rejected.Inc()
+			return false
+		}
+		b.probing = true
+		return true
+	default:
+		return true
+	}
+}
+
+// success сбрасывает счётчик ошибок; возвращает true, если цепь была не закрыта.
+func (b *producerBreaker) success() bool {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	recovered := b.state != breakerClosed
+	b.state = breakerClosed
+	b.failures = 0
+	b.probing = false
+	b.open.Set(0)
+	return recovered
+}
+
+// failure учитывает ошибку отправки; возвращает true, если цепь только что открылась.
+func (b *producerBreaker) failure() bool {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.probing = false
+	if b.state == breakerHalfOpen {
+		b.state = breakerOpen
+		b.openedAt = b.now()
+		return false
+	}
+	b.failures++
+	if b.state == breakerClosed && b.failures >= b.threshold {
+		b.state = breakerOpen
+		b.openedAt = b.now()
+		b.open.Set(1)
+		return true
+	}
+	return false
+}
+
+// isOpen сообщает, что ошибки сейчас ожидаемы и не стоит логировать каждую.
+func (b *producerBreaker) isOpen() bool {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.state != breakerClosed
+}
+
+// circuitState переводит состояние в шкалу oms_dependency_circuit_state.
+func (b *producerBreaker) circuitState() dependency.CircuitState {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	switch b.state {
+	case breakerOpen:
+		return dependency.CircuitOpen
+	case breakerHalfOpen:
+		return dependency.CircuitHalfOpen
+	default:
+		return dependency.CircuitClosed
+	}
+}
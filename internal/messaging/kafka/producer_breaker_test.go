@@ -0,0 +1,136 @@
+package kafka
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/IBM/sarama"
+	"github.com/IBM/sarama/mocks"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	log "github.com/sirupsen/logrus"
+
+	"github.com/vladislavdragonenkov/oms/internal/dependency"
+	"github.com/vladislavdragonenkov/oms/internal/metrics/metricstest"
+)
+
+func TestProducer_CircuitBreakerOpensAndRecovers(t *testing.T) {
+	mockProducer := mocks.NewSyncProducer(t, nil)
+	producer := &Producer{
+		producer: mockProducer,
+		logger:   log.WithField("component", "kafka-producer-test"),
+	}
+	WithProducerCircuitBreaker(2, time.Minute, prometheus.NewRegistry())(producer)
+
+	now := time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)
+	producer.breaker.now = func() time.Time { return now }
+
+	event := NewSagaEvent(EventTypeSagaStarted, "order-1", nil)
+
+	mockProducer.ExpectSendMessageAndFail(sarama.ErrOutOfBrokers)
+	mockProducer.ExpectSendMessageAndFail(sarama.ErrOutOfBrokers)
+	for i := 0; i < 2; i++ {
+		if err := producer.PublishEvent(TopicSagaEvents, "order-1", event); !errors.Is(err, sarama.ErrOutOfBrokers) {
+			t.Fatalf("attempt %d: expected broker error, got %v", i, err)
+		}
+	}
+	if got := testutil.ToFloat64(producer.breaker.open); got != 1 {
+		t.Fatalf("expected circuit to be open, gauge=%v", got)
+	}
+
+	// Пока идёт cooldown, брокер не вызывается: у mock'а нет ожиданий, лишний вызов провалил бы тест.
+	if err := producer.PublishEvent(TopicSagaEvents, "order-1", event); !errors.Is(err, ErrProducerCircuitOpen) {
+		t.Fatalf("expected ErrProducerCircuitOpen, got %v", err)
+	}
+	if got := testutil.ToFloat64(producer.breaker.rejected); got != 1 {
+		t.Fatalf("expected 1 rejected publish, got %v", got)
+	}
+
+	// Неудачная проба снова открывает цепь на cooldown.
+	now = now.Add(time.Minute)
+	mockProducer.ExpectSendMessageAndFail(sarama.ErrOutOfBrokers)
+	if err := producer.PublishEvent(TopicSagaEvents, "order-1", event); !errors.Is(err, sarama.ErrOutOfBrokers) {
+		t.Fatalf("expected probe to reach broker, got %v", err)
+	}
+	if err := producer.PublishEvent(TopicSagaEvents, "order-1", event); !errors.Is(err, ErrProducerCircuitOpen) {
+		t.Fatalf("expected circuit to reopen after failed probe, got %v", err)
+	}
+
+	now = now.Add(time.Minute)
+	mockProducer.ExpectSendMessageAndSucceed()
+	mockProducer.ExpectSendMessageAndSucceed()
+	if err := producer.PublishEvent(TopicSagaEvents, "order-1", event); err != nil {
+		t.Fatalf("expected probe to succeed, got %v", err)
+	}
+	if err := producer.PublishEvent(TopicSagaEvents, "order-1", event); err != nil {
+		t.Fatalf("expected closed circuit to publish, got %v", err)
+	}
+	if got := testutil.ToFloat64(producer.breaker.open); got != 0 {
+		t.Fatalf("expected circuit to be closed, gauge=%v", got)
+	}
+
+	if err := mockProducer.Close(); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestProducerBreaker_HalfOpenAllowsSingleProbe(t *testing.T) {
+	breaker := newProducerBreaker(1, time.Second, prometheus.NewRegistry())
+	now := time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)
+	breaker.now = func() time.Time { return now }
+
+	if !breaker.failure() {
+		t.Fatal("expected first failure to open the circuit with threshold 1")
+	}
+
+	now = now.Add(time.Second)
+	if !breaker.allow() {
+		t.Fatal("expected probe to be allowed after cooldown")
+	}
+	if breaker.allow() {
+		t.Fatal("expected concurrent publish to be rejected while probe is in flight")
+	}
+	if !breaker.success() {
+		t.Fatal("expected successful probe to report recovery")
+	}
+	if breaker.isOpen() {
+		t.Fatal("expected circuit to be closed after recovery")
+	}
+}
+
+func TestWithProducerCircuitBreaker_DisabledByZeroThreshold(t *testing.T) {
+	producer := &Producer{}
+	WithProducerCircuitBreaker(0, time.Second, prometheus.NewRegistry())(producer)
+	if producer.breaker != nil {
+		t.Fatal("expected threshold 0 to disable the breaker")
+	}
+}
+
+func TestProducer_DependencyMetricsFollowBreaker(t *testing.T) {
+	registry := prometheus.NewRegistry()
+	mockProducer := mocks.NewSyncProducer(t, nil)
+	producer := NewProducerFromSync(mockProducer,
+		WithProducerCircuitBreaker(1, time.Minute, registry),
+		WithProducerDependencyMetrics(registry),
+	)
+	event := NewSagaEvent(EventTypeSagaStarted, "order-1", nil)
+	labels := metricstest.Labels{"dependency": dependency.Kafka}
+
+	mockProducer.ExpectSendMessageAndSucceed()
+	mockProducer.ExpectSendMessageAndFail(sarama.ErrOutOfBrokers)
+	_ = producer.PublishEvent(TopicSagaEvents, "order-1", event)
+	_ = producer.PublishEvent(TopicSagaEvents, "order-1", event)
+	if err := producer.PublishEvent(TopicSagaEvents, "order-1", event); !errors.Is(err, ErrProducerCircuitOpen) {
+		t.Fatalf("expected ErrProducerCircuitOpen, got %v", err)
+	}
+
+	metricstest.RequireValue(t, registry, "oms_dependency_request_duration_seconds", labels, 2)
+	metricstest.RequireValue(t, registry, "oms_dependency_errors_total", labels, 1)
+	metricstest.RequireValue(t, registry, "oms_dependency_circuit_state", labels, float64(dependency.CircuitOpen))
+	metricstest.RequireValue(t, registry, "oms_dependency_circuit_rejected_total", labels, 1)
+
+	if err := mockProducer.Close(); err != nil {
+		t.Fatalf("close mock producer: %v", err)
+	}
+}
@@ -1,12 +1,20 @@
 package kafka
 
 import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"strings"
 	"testing"
 	"time"
 
 	"github.com/IBM/sarama"
 	"github.com/IBM/sarama/mocks"
+	"github.com/prometheus/client_golang/prometheus"
 	log "github.com/sirupsen/logrus"
+
+	"github.com/vladislavdragonenkov/oms/internal/logging"
+	"github.com/vladislavdragonenkov/oms/internal/metrics/metricstest"
 )
 
 func TestProducer_PublishEvent(t *testing.T) {
@@ -42,6 +50,106 @@ func TestProducer_PublishEvent(t *testing.T) {
 	}
 }
 
+func TestProducer_PublishEvent_EncryptsSagaMetadata(t *testing.T) {
+	var sent *sarama.ProducerMessage
+	mockProducer := mocks.NewSyncProducer(t, nil)
+	mockProducer.ExpectSendMessageWithMessageCheckerFunctionAndSucceed(func(msg *sarama.ProducerMessage) error {
+		sent = msg
+		return nil
+	})
+
+	wrapper, err := ParseStaticKeyWrapper(testKeySpec("k1"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	encryptor, err := NewFieldEncryptor(wrapper, "customer_id")
+	if err != nil {
+		t.Fatal(err)
+	}
+	producer := &Producer{
+		producer:  mockProducer,
+		logger:    log.WithField("component", "kafka-producer-test"),
+		encryptor: encryptor,
+	}
+
+	event := NewSagaEvent(EventTypeSagaStarted, "order-1", map[string]interface{}{"customer_id": "cust-1", "amount": 100})
+	if err := producer.PublishEvent(TopicSagaEvents, "order-1", event); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if err := mockProducer.Close(); err != nil {
+		t.Fatal(err)
+	}
+	if event.Encryption != nil || event.Metadata["customer_id"] != "cust-1" {
+		t.Fatal("original event must not be modified")
+	}
+
+	value, err := sent.Value.Encode()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if strings.Contains(string(value), "cust-1") {
+		t.Fatalf("customer_id published in clear text: %s", value)
+	}
+	var received SagaEvent
+	if err := json.Unmarshal(value, &received); err != nil {
+		t.Fatal(err)
+	}
+	if err := DecryptSagaEvent(context.Background(), wrapper, &received); err != nil {
+		t.Fatalf("decrypt saga event: %v", err)
+	}
+	if received.Metadata["customer_id"] != "cust-1" || received.Encryption != nil {
+		t.Fatalf("unexpected decrypted metadata: %+v", received.Metadata)
+	}
+}
+
+func TestProducer_PublishEvent_TopicCodec(t *testing.T) {
+	var sent []*sarama.ProducerMessage
+	mockProducer := mocks.NewSyncProducer(t, nil)
+	for range 2 {
+		mockProducer.ExpectSendMessageWithMessageCheckerFunctionAndSucceed(func(msg *sarama.ProducerMessage) error {
+			sent = append(sent, msg)
+			return nil
+		})
+	}
+	producer := &Producer{producer: mockProducer, logger: log.WithField("component", "kafka-producer-test")}
+	WithTopicCodec(TopicSagaEvents, ProtobufCodec{})(producer)
+
+	event := NewSagaEvent(EventTypeSagaStarted, "order-1", map[string]interface{}{"step": "reserve"})
+	if err := producer.PublishEvent(TopicSagaEvents, "order-1", event); err != nil {
+		t.Fatalf("publish protobuf: %v", err)
+	}
+	if err := producer.PublishEvent(TopicOrderEvents, "order-1", NewOrderEvent(EventTypeOrderCreated, "order-1", "c-1", "pending", nil)); err != nil {
+		t.Fatalf("publish json: %v", err)
+	}
+	if err := mockProducer.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	for i, want := range []string{ContentTypeProtobuf, ContentTypeJSON} {
+		var contentType string
+		for _, header := range sent[i].Headers {
+			if string(header.Key) == HeaderContentType {
+				contentType = string(header.Value)
+			}
+		}
+		if contentType != want {
+			t.Fatalf("message %d: content-type %q, want %q", i, contentType, want)
+		}
+	}
+
+	value, err := sent[0].Value.Encode()
+	if err != nil {
+		t.Fatal(err)
+	}
+	received, err := ParseSagaEvent(&sarama.ConsumerMessage{Value: value})
+	if err != nil {
+		t.Fatalf("parse protobuf saga event: %v", err)
+	}
+	if received.OrderID != "order-1" || received.Metadata["step"] != "reserve" {
+		t.Fatalf("unexpected saga event: %+v", received)
+	}
+}
+
 func TestProducer_PublishEvent_Error(t *testing.T) {
 	// Создаем mock producer с ошибкой
 	mockProducer := mocks.NewSyncProducer(t, nil)
@@ -71,6 +179,34 @@ func TestProducer_PublishEvent_Error(t *testing.T) {
 	}
 }
 
+func TestProducer_SendFailuresAreSampled(t *testing.T) {
+	var out bytes.Buffer
+	logger := log.New()
+	logger.SetOutput(&out)
+
+	registry := prometheus.NewRegistry()
+	mockProducer := mocks.NewSyncProducer(t, nil)
+	producer := NewProducerFromSync(mockProducer,
+		WithProducerLogSampler(logging.NewSampler("kafka-producer", logging.WithSampleRate(1, 2), logging.WithSamplerRegisterer(registry))))
+	producer.logger = log.NewEntry(logger)
+
+	event := NewSagaEvent(EventTypeSagaStarted, "order-1", nil)
+	for i := 0; i < 5; i++ {
+		mockProducer.ExpectSendMessageAndFail(sarama.ErrOutOfBrokers)
+		if err := producer.PublishEvent(TopicSagaEvents, "order-1", event); err == nil {
+			t.Fatal("expected send error")
+		}
+	}
+
+	if got := strings.Count(out.String(), "failed to send message to kafka"); got != 2 {
+		t.Fatalf("expected 2 logged failures within burst, got %d:\n%s", got, out.String())
+	}
+	metricstest.RequireValue(t, registry, "oms_log_suppressed_total", metricstest.Labels{"component": "kafka-producer", "key": "send-failed"}, 3)
+	if err := mockProducer.Close(); err != nil {
+		t.Fatal(err)
+	}
+}
+
 func TestNewSagaEvent(t *testing.T) {
 	orderID := "order-123"
 	metadata := map[string]interface{}{
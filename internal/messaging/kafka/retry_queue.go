@@ -0,0 +1,246 @@
+package kafka
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	log "github.com/sirupsen/logrus"
+
+	"github.com/vladislavdragonenkov/oms/internal/metrics"
+)
+
+// EventPublisher публикует событие в topic; реализуется Producer и RetryQueue.
+type EventPublisher interface {
+	PublishEvent(topic string, key string, event interface{}) error
+}
+
+const (
+	defaultRetryQueueCapacity = 1000
+	defaultRetryQueueInterval = time.Second
+
+	// Причины в oms_kafka_producer_retry_queue_dropped_total.
+	retryDropOverflow = "overflow"
+	retryDropShutdown = "shutdown"
+)
+
+// RetryQueue буферизует события, которые не удалось отправить, и досылает их в исходном порядке,
+// когда брокер снова доступен. Пока в очереди есть события, новые ставятся за ними, а не
+// отправляются напрямую, чтобы не нарушить порядок по ключу. Очередь ограничена: при переполнении
+// вытесняется самое старое событие (oms_kafka_producer_retry_queue_dropped_total{reason="overflow"}).
+type RetryQueue struct {
+	publisher EventPublisher
+	capacity  int
+	interval  time.Duration
+	logger    *log.Entry
+
+	mu      sync.Mutex
+	pending []queuedEvent
+	nextSeq uint64
+	// dropping — в текущем эпизоде недоступности уже было вытеснение (предупреждение пишется один раз).
+	dropping bool
+
+	depth    prometheus.Gauge
+	enqueued prometheus.Counter
+	dropped  *prometheus.CounterVec
+}
+
+type queuedEvent struct {
+	seq   uint64
+	topic string
+	key   string
+	event interface{}
+}
+
+// RetryQueueOption настраивает RetryQueue.
+type RetryQueueOption func(*retryQueueConfig)
+
+type retryQueueConfig struct {
+	capacity   int
+	interval   time.Duration
+	logger     *log.Entry
+	registerer prometheus.Registerer
+}
+
+// WithRetryQueueCapacity задаёт максимальное число событий в очереди (по умолчанию 1000).
+func WithRetryQueueCapacity(capacity int) RetryQueueOption {
+	return func(c *retryQueueConfig) {
+		if capacity > 0 {
+			c.capacity = capacity
+		}
+	}
+}
+
+// WithRetryQueueInterval задаёт паузу между попытками дослать очередь (по умолчанию 1s).
+func WithRetryQueueInterval(interval time.Duration) RetryQueueOption {
+	return func(c *retryQueueConfig) {
+		if interval > 0 {
+			c.interval = interval
+		}
+	}
+}
+
+// WithRetryQueueLogger задаёт логгер очереди.
+func WithRetryQueueLogger(logger *log.Entry) RetryQueueOption {
+	return func(c *retryQueueConfig) {
+		if logger != nil {
+			c.logger = logger
+		}
+	}
+}
+
+// WithRetryQueueRegisterer задаёт реестр метрик очереди.
+func WithRetryQueueRegisterer(registerer prometheus.Registerer) RetryQueueOption {
+	return func(c *retryQueueConfig) {
+		c.registerer = registerer
+	}
+}
+
+// NewRetryQueue создаёт очередь повторной отправки поверх publisher. Досылку выполняет Run.
+func NewRetryQueue(publisher EventPublisher, options ...RetryQueueOption) *RetryQueue {
+	cfg := retryQueueConfig{
+		capacity: defaultRetryQueueCapacity,
+		interval: defaultRetryQueueInterval,
+		logger:   log.WithField("component", "kafka-retry-queue"),
+	}
+	for _, option := range options {
+		option(&cfg)
+	}
+
+	return &RetryQueue{
+		publisher: publisher,
+		capacity:  cfg.capacity,
+		interval:  cfg.interval,
+		logger:    cfg.logger,
+		depth: metrics.Register(cfg.registerer, prometheus.NewGauge(prometheus.GaugeOpts{
+			Name: "oms_kafka_producer_retry_queue_depth",
+			Help: "Number of kafka events waiting in the producer retry queue.",
+		})),
+		enqueued: metrics.Register(cfg.registerer, prometheus.NewCounter(prometheus.CounterOpts{
+			Name: "oms_kafka_producer_retry_queue_enqueued_total",
+			Help: "Total number of kafka events buffered after a failed or deferred publish.",
+		})),
+		dropped: metrics.Register(cfg.registerer, prometheus.NewCounterVec(prometheus.CounterOpts{
+			Name: "oms_kafka_producer_retry_queue_dropped_total",
+			Help: "Total number of buffered kafka events dropped, grouped by reason.",
+		}, []string{"reason"})),
+	}
+}
+
+// PublishEvent отправляет событие сразу, если очередь пуста, иначе ставит его в очередь.
+// Ошибка отправки не возвращается: событие остаётся в очереди до успешной досылки или вытеснения.
+func (q *RetryQueue) PublishEvent(topic string, key string, event interface{}) error {
+	q.mu.Lock()
+	backlog := len(q.pending) > 0
+	q.mu.Unlock()
+
+	if !backlog {
+		err := q.publisher.PublishEvent(topic, key, event)
+		if err == nil {
+			return nil
+		}
+		q.logger.WithError(err).WithField("topic", topic).Warn("kafka publish failed, buffering events for retry")
+	}
+
+	q.enqueue(queuedEvent{topic: topic, key: key, event: event})
+	return nil
+}
+
+// Len возвращает число событий в очереди.
+func (q *RetryQueue) Len() int {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	return len(q.pending)
+}
+
+// Run досылает очередь раз в interval до отмены ctx. При остановке делается последняя попытка,
+// оставшиеся события учитываются как вытесненные с reason="shutdown".
+func (q *RetryQueue) Run(ctx context.Context) {
+	ticker := time.NewTicker(q.interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			q.flush()
+			if lost := q.discard(); lost > 0 {
+				q.logger.WithField("events", lost).Error("kafka retry queue stopped with undelivered events")
+			}
+			return
+		case <-ticker.C:
+			q.flush()
+		}
+	}
+}
+
+func (q *RetryQueue) enqueue(item queuedEvent) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	if len(q.pending) >= q.capacity {
+		q.pending = q.pending[1:]
+		q.dropped.WithLabelValues(retryDropOverflow).Inc()
+		if !q.dropping {
+			q.dropping = true
+			q.logger.WithField("capacity", q.capacity).Warn("kafka retry queue is full, dropping oldest events")
+		}
+	}
+	q.nextSeq++
+	item.seq = q.nextSeq
+	q.pending = append(q.pending, item)
+	q.enqueued.Inc()
+	q.depth.Set(float64(len(q.pending)))
+}
+
+// flush отправляет события с головы очереди, пока не встретится ошибка.
+func (q *RetryQueue) flush() {
+	sent := 0
+	for {
+		q.mu.Lock()
+		if len(q.pending) == 0 {
+			q.mu.Unlock()
+			break
+		}
+		head := q.pending[0]
+		q.mu.Unlock()
+
+		if err := q.publisher.PublishEvent(head.topic, head.key, head.event); err != nil {
+			return
+		}
+
+		q.mu.Lock()
+		// Пока шла отправка, head мог быть вытеснен переполнением — тогда удалять нечего.
+		if len(q.pending) > 0 && q.pending[0].seq == head.seq {
+			q.pending = q.pending[1:]
+		}
+		q.depth.Set(float64(len(q.pending)))
+		q.mu.Unlock()
+		sent++
+	}
+
+	if sent > 0 {
+		q.mu.Lock()
+		q.dropping = false
+		q.mu.Unlock()
+		q.logger.WithField("events", sent).Info("kafka retry queue flushed")
+	}
+}
+
+func (q *RetryQueue) discard() int {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	lost := len(q.pending)
+	if lost > 0 {
+		q.dropped.WithLabelValues(retryDropShutdown).Add(float64(lost))
+	}
+	q.pending = nil
+	q.depth.Set(0)
+	return lost
+}
+
+var (
+	_ EventPublisher = (*Producer)(nil)
+	_ EventPublisher = (*RetryQueue)(nil)
+)
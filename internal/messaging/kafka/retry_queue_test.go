@@ -0,0 +1,152 @@
+package kafka
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+)
+
+type stubPublisher struct {
+	mu   sync.Mutex
+	err  error
+	sent []string
+}
+
+func (p *stubPublisher) PublishEvent(_ string, key string, _ interface{}) error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if p.err != nil {
+		return p.err
+	}
+	p.sent = append(p.sent, key)
+	return nil
+}
+
+func (p *stubPublisher) setErr(err error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.err = err
+}
+
+func (p *stubPublisher) keys() []string {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return append([]string(nil), p.sent...)
+}
+
+func TestRetryQueue_BuffersDuringOutageAndFlushesInOrder(t *testing.T) {
+	publisher := &stubPublisher{}
+	queue := NewRetryQueue(publisher, WithRetryQueueRegisterer(prometheus.NewRegistry()))
+
+	if err := queue.PublishEvent(TopicSagaEvents, "a", nil); err != nil {
+		t.Fatalf("publish a: %v", err)
+	}
+
+	publisher.setErr(ErrProducerCircuitOpen)
+	for _, key := range []string{"b", "c"} {
+		if err := queue.PublishEvent(TopicSagaEvents, key, nil); err != nil {
+			t.Fatalf("publish %s: expected buffered publish to succeed, got %v", key, err)
+		}
+	}
+	if queue.Len() != 2 {
+		t.Fatalf("expected 2 buffered events, got %d", queue.Len())
+	}
+
+	// Брокер вернулся, но очередь ещё не дослана: новое событие встаёт за ней.
+	publisher.setErr(nil)
+	if err := queue.PublishEvent(TopicSagaEvents, "d", nil); err != nil {
+		t.Fatalf("publish d: %v", err)
+	}
+	queue.flush()
+
+	if queue.Len() != 0 {
+		t.Fatalf("expected empty queue after flush, got %d", queue.Len())
+	}
+	want := []string{"a", "b", "c", "d"}
+	got := publisher.keys()
+	if len(got) != len(want) {
+		t.Fatalf("unexpected publish order: %v", got)
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Fatalf("unexpected publish order: %v", got)
+		}
+	}
+	if v := testutil.ToFloat64(queue.enqueued); v != 3 {
+		t.Fatalf("expected 3 enqueued events, got %v", v)
+	}
+	if v := testutil.ToFloat64(queue.depth); v != 0 {
+		t.Fatalf("expected depth 0, got %v", v)
+	}
+}
+
+func TestRetryQueue_OverflowDropsOldest(t *testing.T) {
+	publisher := &stubPublisher{err: errors.New("brokers down")}
+	queue := NewRetryQueue(publisher,
+		WithRetryQueueCapacity(2),
+		WithRetryQueueRegisterer(prometheus.NewRegistry()),
+	)
+
+	for _, key := range []string{"a", "b", "c"} {
+		if err := queue.PublishEvent(TopicSagaEvents, key, nil); err != nil {
+			t.Fatalf("publish %s: %v", key, err)
+		}
+	}
+	if v := testutil.ToFloat64(queue.dropped.WithLabelValues(retryDropOverflow)); v != 1 {
+		t.Fatalf("expected 1 overflow drop, got %v", v)
+	}
+
+	publisher.setErr(nil)
+	queue.flush()
+
+	got := publisher.keys()
+	if len(got) != 2 || got[0] != "b" || got[1] != "c" {
+		t.Fatalf("expected oldest event to be dropped, got %v", got)
+	}
+}
+
+func TestRetryQueue_RunFlushesAndCountsShutdownLoss(t *testing.T) {
+	publisher := &stubPublisher{err: errors.New("brokers down")}
+	queue := NewRetryQueue(publisher,
+		WithRetryQueueInterval(10*time.Millisecond),
+		WithRetryQueueRegisterer(prometheus.NewRegistry()),
+	)
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		queue.Run(ctx)
+	}()
+
+	if err := queue.PublishEvent(TopicSagaEvents, "a", nil); err != nil {
+		t.Fatalf("publish a: %v", err)
+	}
+	publisher.setErr(nil)
+
+	deadline := time.Now().Add(time.Second)
+	for queue.Len() > 0 {
+		if time.Now().After(deadline) {
+			t.Fatal("expected Run to flush the queue")
+		}
+		time.Sleep(5 * time.Millisecond)
+	}
+
+	publisher.setErr(errors.New("brokers down"))
+	if err := queue.PublishEvent(TopicSagaEvents, "b", nil); err != nil {
+		t.Fatalf("publish b: %v", err)
+	}
+	cancel()
+	<-done
+
+	if queue.Len() != 0 {
+		t.Fatalf("expected queue to be discarded on shutdown, got %d", queue.Len())
+	}
+	if v := testutil.ToFloat64(queue.dropped.WithLabelValues(retryDropShutdown)); v != 1 {
+		t.Fatalf("expected 1 shutdown drop, got %v", v)
+	}
+}
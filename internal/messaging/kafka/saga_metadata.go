@@ -0,0 +1,174 @@
+package kafka
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"slices"
+	"strings"
+	"sync"
+)
+
+// SagaMetadata — типизированные метаданные события саги. Тип события задаётся типом структуры,
+// поэтому набор полей каждого события и их JSON-ключи проверяет компилятор, а не ревьюер.
+// Ключи совпадают с прежними map-payload'ами: потребители топика изменений не заметят.
+type SagaMetadata interface {
+	SagaEventType() EventType
+}
+
+// Суммы (amount) сериализуются без omitempty: нулевая сумма — значение, а не отсутствие поля.
+
+// SagaStartedMetadata — saga.started.
+type SagaStartedMetadata struct {
+	CustomerID string `json:"customer_id,omitempty"`
+	Status     string `json:"status,omitempty"`
+}
+
+// StepReservedMetadata — step.reserved.
+type StepReservedMetadata struct {
+	CustomerID string `json:"customer_id,omitempty"`
+	ItemsCount int    `json:"items_count,omitempty"`
+}
+
+// SagaBackorderedMetadata — saga.backordered.
+type SagaBackorderedMetadata struct {
+	CustomerID string `json:"customer_id,omitempty"`
+	ItemsCount int    `json:"items_count,omitempty"`
+}
+
+// StepAuthorizedMetadata — step.authorized.
+type StepAuthorizedMetadata struct {
+	AmountMinor int64  `json:"amount"`
+	Currency    string `json:"currency,omitempty"`
+	// ExpiresAt — срок блокировки у PSP в RFC3339Nano (timeutil.Format).
+	ExpiresAt string `json:"expires_at,omitempty"`
+}
+
+// StepPaidMetadata — step.paid.
+type StepPaidMetadata struct {
+	AmountMinor int64  `json:"amount"`
+	Currency    string `json:"currency,omitempty"`
+	Status      string `json:"status,omitempty"`
+	// Source — кто подтвердил списание после авторизации (capture, payment_event); пусто для Pay.
+	Source string `json:"source,omitempty"`
+}
+
+// SagaCompletedMetadata — saga.completed.
+type SagaCompletedMetadata struct {
+	CustomerID  string `json:"customer_id,omitempty"`
+	AmountMinor int64  `json:"amount"`
+}
+
+// SagaCanceledMetadata — saga.canceled.
+type SagaCanceledMetadata struct {
+	CustomerID string `json:"customer_id,omitempty"`
+	Reason     string `json:"reason,omitempty"`
+}
+
+// SagaRefundedMetadata — saga.refunded.
+type SagaRefundedMetadata struct {
+	CustomerID  string `json:"customer_id,omitempty"`
+	AmountMinor int64  `json:"amount"`
+	Reason      string `json:"reason,omitempty"`
+	// Chargeback — возврат инициировал банк клиента, а не OMS.
+	Chargeback bool `json:"chargeback,omitempty"`
+}
+
+// SagaFailedMetadata — saga.failed.
+type SagaFailedMetadata struct {
+	CustomerID string `json:"customer_id,omitempty"`
+	Reason     string `json:"reason,omitempty"`
+	Status     string `json:"status,omitempty"`
+}
+
+func (SagaStartedMetadata) SagaEventType() EventType     { return EventTypeSagaStarted }
+func (StepReservedMetadata) SagaEventType() EventType    { return EventTypeStepReserved }
+func (SagaBackorderedMetadata) SagaEventType() EventType { return EventTypeSagaBackordered }
+func (StepAuthorizedMetadata) SagaEventType() EventType  { return EventTypeStepAuthorized }
+func (StepPaidMetadata) SagaEventType() EventType        { return EventTypeStepPaid }
+func (SagaCompletedMetadata) SagaEventType() EventType   { return EventTypeSagaCompleted }
+func (SagaCanceledMetadata) SagaEventType() EventType    { return EventTypeSagaCanceled }
+func (SagaRefundedMetadata) SagaEventType() EventType    { return EventTypeSagaRefunded }
+func (SagaFailedMetadata) SagaEventType() EventType      { return EventTypeSagaFailed }
+
+// NewTypedSagaEvent создаёт событие саги, тип которого задан metadata. Metadata события остаётся
+// map'ом: его шифруют FieldEncryptor и кодеки, и так же его видят потребители.
+func NewTypedSagaEvent(orderID string, metadata SagaMetadata) *SagaEvent {
+	return NewSagaEvent(metadata.SagaEventType(), orderID, metadataMap(metadata))
+}
+
+// DecodeMetadata разбирает Metadata события в target. Тип target должен соответствовать EventType.
+func (e *SagaEvent) DecodeMetadata(target SagaMetadata) error {
+	if target.SagaEventType() != e.EventType {
+		return fmt.Errorf("decode %s metadata into %T", e.EventType, target)
+	}
+	raw, err := json.Marshal(e.Metadata)
+	if err != nil {
+		return fmt.Errorf("encode %s metadata: %w", e.EventType, err)
+	}
+	if err := json.Unmarshal(raw, target); err != nil {
+		return fmt.Errorf("decode %s metadata: %w", e.EventType, err)
+	}
+	return nil
+}
+
+// metadataMap переводит структуру в map по json-тегам: ключи и omitempty те же, что у
+// json.Marshal, а числа, как после json.Unmarshal в map, — float64. Раньше перевод шёл через
+// Marshal+Unmarshal; на каждом событии саги это были лишние буферы и разбор JSON.
+func metadataMap(metadata SagaMetadata) map[string]interface{} {
+	value := reflect.Indirect(reflect.ValueOf(metadata))
+	fields := metadataFieldsFor(value.Type())
+	out := make(map[string]interface{}, len(fields)+1) // +1 — test_mode, его дописывает сага.
+	for _, field := range fields {
+		fv := value.Field(field.index)
+		if field.omitEmpty && fv.IsZero() {
+			continue
+		}
+		switch fv.Kind() {
+		case reflect.String:
+			out[field.name] = fv.String()
+		case reflect.Bool:
+			out[field.name] = fv.Bool()
+		case reflect.Int, reflect.Int32, reflect.Int64:
+			out[field.name] = float64(fv.Int())
+		}
+	}
+	return out
+}
+
+// metadataField — поле структуры метаданных и его JSON-ключ.
+type metadataField struct {
+	index     int
+	name      string
+	omitEmpty bool
+}
+
+// metadataFields кэширует разбор тегов по типу метаданных: reflect.Type -> []metadataField.
+var metadataFields sync.Map
+
+func metadataFieldsFor(typ reflect.Type) []metadataField {
+	if cached, ok := metadataFields.Load(typ); ok {
+		return cached.([]metadataField)
+	}
+	fields := make([]metadataField, 0, typ.NumField())
+	for i := 0; i < typ.NumField(); i++ {
+		field := typ.Field(i)
+		tag := field.Tag.Get("json")
+		if !field.IsExported() || tag == "-" {
+			continue
+		}
+		name, opts, _ := strings.Cut(tag, ",")
+		if name == "" {
+			name = field.Name
+		}
+		switch field.Type.Kind() {
+		case reflect.String, reflect.Bool, reflect.Int, reflect.Int32, reflect.Int64:
+		default:
+			// Поля метаданных — строки, числа и bool; иной тип — ошибка в описании события.
+			panic(fmt.Sprintf("metadata %s: field %s has unsupported type %s", typ, field.Name, field.Type))
+		}
+		fields = append(fields, metadataField{index: i, name: name, omitEmpty: slices.Contains(strings.Split(opts, ","), "omitempty")})
+	}
+	metadataFields.Store(typ, fields)
+	return fields
+}
@@ -0,0 +1,96 @@
+package kafka
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+)
+
+func TestNewTypedSagaEvent_KeepsMapKeys(t *testing.T) {
+	event := NewTypedSagaEvent("order-1", StepPaidMetadata{Currency: "USD", Status: "CAPTURED"})
+	if event.EventType != EventTypeStepPaid || event.OrderID != "order-1" {
+		t.Fatalf("unexpected event %+v", event)
+	}
+	// Нулевая сумма остаётся в событии, пустой source — нет.
+	want := map[string]interface{}{"amount": float64(0), "currency": "USD", "status": "CAPTURED"}
+	if !reflect.DeepEqual(event.Metadata, want) {
+		t.Fatalf("unexpected metadata %v", event.Metadata)
+	}
+
+	refund := NewTypedSagaEvent("order-1", SagaRefundedMetadata{CustomerID: "c-1", AmountMinor: 500, Reason: "chargeback", Chargeback: true})
+	want = map[string]interface{}{"customer_id": "c-1", "amount": float64(500), "reason": "chargeback", "chargeback": true}
+	if !reflect.DeepEqual(refund.Metadata, want) {
+		t.Fatalf("unexpected refund metadata %v", refund.Metadata)
+	}
+}
+
+func TestSagaEvent_DecodeMetadata(t *testing.T) {
+	sent := StepAuthorizedMetadata{AmountMinor: 1250, Currency: "EUR", ExpiresAt: "2026-03-08T12:00:00Z"}
+	data, err := ProtobufCodec{}.Marshal(NewTypedSagaEvent("order-1", sent))
+	if err != nil {
+		t.Fatalf("marshal: %v", err)
+	}
+	var received SagaEvent
+	if err := (ProtobufCodec{}).Unmarshal(data, &received); err != nil {
+		t.Fatalf("unmarshal: %v", err)
+	}
+
+	var got StepAuthorizedMetadata
+	if err := received.DecodeMetadata(&got); err != nil {
+		t.Fatalf("decode: %v", err)
+	}
+	if got != sent {
+		t.Fatalf("unexpected metadata %+v", got)
+	}
+	if err := received.DecodeMetadata(&StepPaidMetadata{}); err == nil {
+		t.Fatalf("expected event type mismatch to be rejected")
+	}
+}
+
+// metadataMap обязан давать тот же map, что прежний перевод через json.Marshal+Unmarshal.
+func TestMetadataMap_MatchesJSONRoundTrip(t *testing.T) {
+	cases := []SagaMetadata{
+		SagaStartedMetadata{CustomerID: "c-1", Status: "PENDING"},
+		StepReservedMetadata{ItemsCount: 3},
+		SagaBackorderedMetadata{},
+		StepAuthorizedMetadata{AmountMinor: 1250, Currency: "EUR", ExpiresAt: "2026-03-08T12:00:00Z"},
+		StepPaidMetadata{Status: "CAPTURED", Source: "capture"},
+		SagaCompletedMetadata{CustomerID: "c-1", AmountMinor: 990},
+		SagaCanceledMetadata{Reason: "timeout"},
+		SagaRefundedMetadata{AmountMinor: 500, Chargeback: true},
+		&SagaFailedMetadata{CustomerID: "c-1", Reason: "payment declined", Status: "CANCELED"},
+	}
+	for _, metadata := range cases {
+		raw, err := json.Marshal(metadata)
+		if err != nil {
+			t.Fatalf("marshal %T: %v", metadata, err)
+		}
+		var want map[string]interface{}
+		if err := json.Unmarshal(raw, &want); err != nil {
+			t.Fatalf("unmarshal %T: %v", metadata, err)
+		}
+		if got := metadataMap(metadata); !reflect.DeepEqual(got, want) {
+			t.Fatalf("%T: got %v, want %v", metadata, got, want)
+		}
+	}
+}
+
+func BenchmarkNewTypedSagaEvent(b *testing.B) {
+	metadata := StepPaidMetadata{AmountMinor: 1250, Currency: "USD", Status: "CAPTURED"}
+	b.ReportAllocs()
+	for b.Loop() {
+		NewTypedSagaEvent("order-1", metadata)
+	}
+}
+
+// BenchmarkNewTypedSagaEvent_JSONRoundTrip — прежний способ построения Metadata, для сравнения.
+func BenchmarkNewTypedSagaEvent_JSONRoundTrip(b *testing.B) {
+	metadata := StepPaidMetadata{AmountMinor: 1250, Currency: "USD", Status: "CAPTURED"}
+	b.ReportAllocs()
+	for b.Loop() {
+		raw, _ := json.Marshal(metadata)
+		var out map[string]interface{}
+		_ = json.Unmarshal(raw, &out)
+		NewSagaEvent(metadata.SagaEventType(), "order-1", out)
+	}
+}
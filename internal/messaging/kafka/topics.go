@@ -0,0 +1,156 @@
+package kafka
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// ErrInvalidTopicConfig — имя топика, consumer group или префикс не соответствует правилам.
+var ErrInvalidTopicConfig = errors.New("kafka: invalid topic config")
+
+// ConsumerGroupBackorders — consumer group, возобновляющая backordered-заказы по событиям склада.
+const ConsumerGroupBackorders = "oms-backorders"
+
+// ConsumerGroupPayments — consumer group, применяющая к заказам события PSP.
+const ConsumerGroupPayments = "oms-payments"
+
+// maxTopicNameLen — ограничение Kafka на длину имени топика.
+const maxTopicNameLen = 249
+
+// TopicConfig — единый источник имён топиков и consumer group'ов сервиса. Producer, consumer'ы,
+// outbox-паблишер и dlq-reprocess берут имена отсюда, а не из констант напрямую.
+type TopicConfig struct {
+	// Prefix — префикс окружения без точки на конце (например, "staging"); пусто — без префикса.
+	Prefix           string
+	OrderEvents      string
+	SagaEvents       string
+	DeadLetter       string
+	InventoryRestock string
+	BackordersGroup  string
+	PaymentEvents    string
+	PaymentsGroup    string
+	// OrderEventsKey и SagaEventsKey — стратегии ключей сообщений (см. KeyStrategy).
+	OrderEventsKey KeyStrategy
+	SagaEventsKey  KeyStrategy
+	// OrderEventsCodec и SagaEventsCodec — формат сериализации событий (см. Codec).
+	OrderEventsCodec Codec
+	SagaEventsCodec  Codec
+}
+
+// DefaultTopicConfig возвращает имена без префикса окружения.
+func DefaultTopicConfig() TopicConfig {
+	return TopicConfig{
+		OrderEvents:      TopicOrderEvents,
+		SagaEvents:       TopicSagaEvents,
+		DeadLetter:       TopicDeadLetterQueue,
+		InventoryRestock: TopicInventoryRestock,
+		BackordersGroup:  ConsumerGroupBackorders,
+		PaymentEvents:    TopicPaymentEvents,
+		PaymentsGroup:    ConsumerGroupPayments,
+		OrderEventsKey:   KeyByOrder,
+		SagaEventsKey:    KeyByOrder,
+		OrderEventsCodec: JSONCodec{},
+		SagaEventsCodec:  JSONCodec{},
+	}
+}
+
+// NewTopicConfig добавляет префикс окружения ко всем топикам и группам:
+// "staging" превращает oms.order.events в staging.oms.order.events.
+func NewTopicConfig(prefix string) (TopicConfig, error) {
+	prefix = strings.TrimSuffix(strings.TrimSpace(prefix), ".")
+	cfg := DefaultTopicConfig()
+	if prefix != "" {
+		if err := validateName("prefix", prefix); err != nil {
+			return TopicConfig{}, err
+		}
+		cfg.Prefix = prefix
+		for _, name := range cfg.names() {
+			*name.value = prefix + "." + *name.value
+		}
+	}
+	if err := cfg.Validate(); err != nil {
+		return TopicConfig{}, err
+	}
+	return cfg, nil
+}
+
+// Validate проверяет все имена: допустимые символы, длину и отсутствие совпадений между топиками,
+// а также стратегии ключей, допустимые для каждого топика.
+func (c TopicConfig) Validate() error {
+	seen := make(map[string]string)
+	for _, name := range c.names() {
+		if err := validateName(name.field, *name.value); err != nil {
+			return err
+		}
+		if strings.HasSuffix(name.field, "_group") {
+			continue
+		}
+		if other, ok := seen[*name.value]; ok {
+			return fmt.Errorf("%w: %s and %s share topic %q", ErrInvalidTopicConfig, other, name.field, *name.value)
+		}
+		seen[*name.value] = name.field
+	}
+	return c.validateKeyStrategies()
+}
+
+// ResolveDLQPolicy подставляет DLQ-топик окружения в политику, оставшуюся с топиком по умолчанию.
+func (c TopicConfig) ResolveDLQPolicy(policy DLQPolicy) DLQPolicy {
+	if policy.DeadLetterTopic == "" || policy.DeadLetterTopic == TopicDeadLetterQueue {
+		policy.DeadLetterTopic = c.DeadLetter
+	}
+	return policy
+}
+
+// ConsumerGroups возвращает consumer group'ы сервиса и топики, на которые они подписаны.
+func (c TopicConfig) ConsumerGroups() map[string][]string {
+	return map[string][]string{
+		c.BackordersGroup: {c.InventoryRestock},
+		c.PaymentsGroup:   {c.PaymentEvents},
+	}
+}
+
+type topicConfigName struct {
+	field string
+	value *string
+}
+
+func (c *TopicConfig) names() []topicConfigName {
+	return []topicConfigName{
+		{field: "order_events", value: &c.OrderEvents},
+		{field: "saga_events", value: &c.SagaEvents},
+		{field: "dead_letter", value: &c.DeadLetter},
+		{field: "inventory_restock", value: &c.InventoryRestock},
+		{field: "backorders_group", value: &c.BackordersGroup},
+		{field: "payment_events", value: &c.PaymentEvents},
+		{field: "payments_group", value: &c.PaymentsGroup},
+	}
+}
+
+// ValidateTopicName проверяет имя по правилам Kafka: 1..249 символов из [a-zA-Z0-9._-], не "." и не "..".
+func ValidateTopicName(name string) error {
+	return validateName("topic", name)
+}
+
+func validateName(field, name string) error {
+	switch {
+	case name == "":
+		return fmt.Errorf("%w: %s is empty", ErrInvalidTopicConfig, field)
+	case len(name) > maxTopicNameLen:
+		return fmt.Errorf("%w: %s %q is longer than %d characters", ErrInvalidTopicConfig, field, name, maxTopicNameLen)
+	case name == "." || name == "..":
+		return fmt.Errorf("%w: %s must not be %q", ErrInvalidTopicConfig, field, name)
+	case strings.HasPrefix(name, ".") || strings.HasSuffix(name, ".") || strings.Contains(name, ".."):
+		return fmt.Errorf("%w: %s %q has empty dot-separated segment", ErrInvalidTopicConfig, field, name)
+	}
+	for _, r := range name {
+		if !isTopicRune(r) {
+			return fmt.Errorf("%w: %s %q contains %q (allowed: a-z A-Z 0-9 . _ -)", ErrInvalidTopicConfig, field, name, r)
+		}
+	}
+	return nil
+}
+
+func isTopicRune(r rune) bool {
+	return r >= 'a' && r <= 'z' || r >= 'A' && r <= 'Z' || r >= '0' && r <= '9' || r == '.' || r == '_' || r == '-'
+}
@@ -0,0 +1,104 @@
+package kafka
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestNewTopicConfig_Prefix(t *testing.T) {
+	cfg, err := NewTopicConfig("")
+	if err != nil {
+		t.Fatalf("default config: %v", err)
+	}
+	if cfg != DefaultTopicConfig() {
+		t.Fatalf("empty prefix must keep default names, got %+v", cfg)
+	}
+
+	cfg, err = NewTopicConfig("staging.")
+	if err != nil {
+		t.Fatalf("prefixed config: %v", err)
+	}
+	want := TopicConfig{
+		Prefix:           "staging",
+		OrderEvents:      "staging.oms.order.events",
+		SagaEvents:       "staging.oms.saga.events",
+		DeadLetter:       "staging.oms.dlq",
+		InventoryRestock: "staging.oms.inventory.restock",
+		BackordersGroup:  "staging.oms-backorders",
+		PaymentEvents:    "staging.payments.events",
+		PaymentsGroup:    "staging.oms-payments",
+		OrderEventsKey:   KeyByOrder,
+		SagaEventsKey:    KeyByOrder,
+		OrderEventsCodec: JSONCodec{},
+		SagaEventsCodec:  JSONCodec{},
+	}
+	if cfg != want {
+		t.Fatalf("unexpected config:\n got %+v\nwant %+v", cfg, want)
+	}
+}
+
+func TestNewTopicConfig_InvalidPrefix(t *testing.T) {
+	for _, prefix := range []string{"stag ing", "eu/west", ".staging", "a..b", strings.Repeat("p", maxTopicNameLen)} {
+		if _, err := NewTopicConfig(prefix); !errors.Is(err, ErrInvalidTopicConfig) {
+			t.Fatalf("%q: expected ErrInvalidTopicConfig, got %v", prefix, err)
+		}
+	}
+}
+
+func TestTopicConfig_Validate(t *testing.T) {
+	cfg := DefaultTopicConfig()
+	cfg.SagaEvents = cfg.OrderEvents
+	if err := cfg.Validate(); !errors.Is(err, ErrInvalidTopicConfig) {
+		t.Fatalf("expected duplicate topic error, got %v", err)
+	}
+
+	cfg = DefaultTopicConfig()
+	cfg.DeadLetter = ""
+	if err := cfg.Validate(); !errors.Is(err, ErrInvalidTopicConfig) {
+		t.Fatalf("expected empty topic error, got %v", err)
+	}
+}
+
+func TestValidateTopicName(t *testing.T) {
+	for _, name := range []string{"oms.dlq", "restock_retry-1", "A.b.C"} {
+		if err := ValidateTopicName(name); err != nil {
+			t.Fatalf("%q: unexpected error %v", name, err)
+		}
+	}
+	for _, name := range []string{"", ".", "..", "oms..dlq", "oms.dlq.", "oms dlq", "oms/dlq", "тема"} {
+		if err := ValidateTopicName(name); !errors.Is(err, ErrInvalidTopicConfig) {
+			t.Fatalf("%q: expected ErrInvalidTopicConfig, got %v", name, err)
+		}
+	}
+}
+
+func TestTopicConfig_ResolveDLQPolicy(t *testing.T) {
+	cfg, err := NewTopicConfig("staging")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := cfg.ResolveDLQPolicy(DefaultDLQPolicy()); got.DeadLetterTopic != "staging.oms.dlq" {
+		t.Fatalf("default dlq topic must get prefix, got %q", got.DeadLetterTopic)
+	}
+	custom := DefaultDLQPolicy()
+	custom.DeadLetterTopic = "restock.dlq"
+	if got := cfg.ResolveDLQPolicy(custom); got.DeadLetterTopic != "restock.dlq" {
+		t.Fatalf("explicit dlq topic must be kept, got %q", got.DeadLetterTopic)
+	}
+}
+
+func TestTopicConfig_ConsumerGroups(t *testing.T) {
+	cfg, err := NewTopicConfig("staging")
+	if err != nil {
+		t.Fatalf("NewTopicConfig failed: %v", err)
+	}
+	groups := cfg.ConsumerGroups()
+	topics, ok := groups["staging.oms-backorders"]
+	if len(groups) != 2 || !ok || len(topics) != 1 || topics[0] != "staging."+TopicInventoryRestock {
+		t.Fatalf("unexpected consumer groups: %v", groups)
+	}
+	if topics := groups["staging.oms-payments"]; len(topics) != 1 || topics[0] != "staging."+TopicPaymentEvents {
+		t.Fatalf("unexpected payments group topics: %v", groups)
+	}
+}
@@ -0,0 +1,129 @@
+package metrics
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+	dto "github.com/prometheus/client_model/go"
+	"google.golang.org/protobuf/proto"
+)
+
+// DefaultNamespace — префикс имён метрик сервиса в коде (oms_saga_started_total и т.д.).
+const DefaultNamespace = "oms"
+
+// Const-лейблы развёртывания.
+const (
+	LabelEnvironment = "environment"
+	LabelRegion      = "region"
+)
+
+// ErrInvalidDeployment — некорректный namespace метрик.
+var ErrInvalidDeployment = errors.New("metrics: invalid deployment")
+
+var namespacePattern = regexp.MustCompile(`^[a-zA-Z_][a-zA-Z0-9_]*$`)
+
+// Deployment отличает метрики одного развёртывания от других в общем Prometheus: namespace
+// заменяет префикс oms_, environment и region добавляются ко всем сериям, включая grpc_* и go_*.
+// Коллекторы регистрируются с исходными именами; переименование делается при отдаче /metrics,
+// поэтому внутренние читатели реестра (SLO, saturation) ищут метрики по прежним именам.
+type Deployment struct {
+	Namespace   string
+	Environment string
+	Region      string
+}
+
+// Validate проверяет, что namespace годится как префикс имени метрики.
+func (d Deployment) Validate() error {
+	if d.Namespace != "" && !namespacePattern.MatchString(d.Namespace) {
+		return fmt.Errorf("%w: namespace %q must match %s", ErrInvalidDeployment, d.Namespace, namespacePattern)
+	}
+	return nil
+}
+
+// ConstLabels возвращает лейблы, которые получает каждая серия; пустые значения не добавляются.
+func (d Deployment) ConstLabels() prometheus.Labels {
+	labels := prometheus.Labels{}
+	if v := strings.TrimSpace(d.Environment); v != "" {
+		labels[LabelEnvironment] = v
+	}
+	if v := strings.TrimSpace(d.Region); v != "" {
+		labels[LabelRegion] = v
+	}
+	return labels
+}
+
+func (d Deployment) identity() bool {
+	return (d.Namespace == "" || d.Namespace == DefaultNamespace) && len(d.ConstLabels()) == 0
+}
+
+// Name переводит исходное имя метрики в имя, под которым её видит Prometheus.
+func (d Deployment) Name(name string) string {
+	if d.Namespace == "" || d.Namespace == DefaultNamespace {
+		return name
+	}
+	if rest, ok := strings.CutPrefix(name, DefaultNamespace+"_"); ok {
+		return d.Namespace + "_" + rest
+	}
+	return name
+}
+
+// Gatherer оборачивает inner: метрики oms_* переименовываются под namespace, ко всем сериям
+// добавляются const-лейблы. Лейбл, который серия уже несёт, не перезаписывается.
+func (d Deployment) Gatherer(inner prometheus.Gatherer) prometheus.Gatherer {
+	if d.identity() {
+		return inner
+	}
+	labels := d.labelPairs()
+	return prometheus.GathererFunc(func() ([]*dto.MetricFamily, error) {
+		families, err := inner.Gather()
+		for _, family := range families {
+			family.Name = proto.String(d.Name(family.GetName()))
+			for _, metric := range family.Metric {
+				metric.Label = mergeLabels(metric.Label, labels)
+			}
+		}
+		sort.Slice(families, func(i, j int) bool { return families[i].GetName() < families[j].GetName() })
+		return families, err
+	})
+}
+
+// Handler — обработчик /metrics глобального реестра с учётом развёртывания.
+func (d Deployment) Handler() http.Handler {
+	return promhttp.InstrumentMetricHandler(
+		prometheus.DefaultRegisterer,
+		promhttp.HandlerFor(d.Gatherer(prometheus.DefaultGatherer), promhttp.HandlerOpts{}),
+	)
+}
+
+func (d Deployment) labelPairs() []*dto.LabelPair {
+	labels := d.ConstLabels()
+	pairs := make([]*dto.LabelPair, 0, len(labels))
+	for name, value := range labels {
+		pairs = append(pairs, &dto.LabelPair{Name: proto.String(name), Value: proto.String(value)})
+	}
+	return pairs
+}
+
+func mergeLabels(own, extra []*dto.LabelPair) []*dto.LabelPair {
+	merged := own
+	for _, pair := range extra {
+		present := false
+		for _, existing := range own {
+			if existing.GetName() == pair.GetName() {
+				present = true
+				break
+			}
+		}
+		if !present {
+			merged = append(merged, pair)
+		}
+	}
+	sort.Slice(merged, func(i, j int) bool { return merged[i].GetName() < merged[j].GetName() })
+	return merged
+}
@@ -0,0 +1,83 @@
+package metrics
+
+import (
+	"errors"
+	"io"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+func TestDeployment_Gatherer(t *testing.T) {
+	registry := prometheus.NewRegistry()
+	Register(registry, prometheus.NewCounter(prometheus.CounterOpts{Name: "oms_saga_started_total", Help: "Test"})).Inc()
+	Register(registry, prometheus.NewCounterVec(prometheus.CounterOpts{Name: "grpc_server_handled_total", Help: "Test"}, []string{"region"})).
+		WithLabelValues("local").Inc()
+
+	deployment := Deployment{Namespace: "payments", Environment: "staging", Region: "eu-west-1"}
+	families, err := deployment.Gatherer(registry).Gather()
+	if err != nil {
+		t.Fatalf("gather: %v", err)
+	}
+	got := map[string]map[string]string{}
+	for _, family := range families {
+		labels := map[string]string{}
+		for _, pair := range family.GetMetric()[0].GetLabel() {
+			labels[pair.GetName()] = pair.GetValue()
+		}
+		got[family.GetName()] = labels
+	}
+
+	saga, ok := got["payments_saga_started_total"]
+	if !ok {
+		t.Fatalf("expected oms_ prefix to be replaced by namespace, got %v", got)
+	}
+	if saga[LabelEnvironment] != "staging" || saga[LabelRegion] != "eu-west-1" {
+		t.Fatalf("expected deployment labels, got %v", saga)
+	}
+	grpc, ok := got["grpc_server_handled_total"]
+	if !ok {
+		t.Fatalf("foreign metrics must keep their names, got %v", got)
+	}
+	if grpc[LabelRegion] != "local" || grpc[LabelEnvironment] != "staging" {
+		t.Fatalf("own labels must win over deployment labels, got %v", grpc)
+	}
+
+	// Сам реестр не меняется: внутренние читатели видят исходные имена.
+	if families, _ := registry.Gather(); families[1].GetName() != "oms_saga_started_total" {
+		t.Fatalf("registry must keep original names, got %s", families[1].GetName())
+	}
+}
+
+func TestDeployment_DefaultIsIdentity(t *testing.T) {
+	registry := prometheus.NewRegistry()
+	if gatherer := (Deployment{Namespace: DefaultNamespace}).Gatherer(registry); gatherer != prometheus.Gatherer(registry) {
+		t.Fatal("default deployment must not wrap the gatherer")
+	}
+}
+
+func TestDeployment_Validate(t *testing.T) {
+	for _, namespace := range []string{"", "oms", "eu_oms", "_x"} {
+		if err := (Deployment{Namespace: namespace}).Validate(); err != nil {
+			t.Fatalf("%q: unexpected error %v", namespace, err)
+		}
+	}
+	for _, namespace := range []string{"1oms", "oms-prod", "oms:x", "oms prod"} {
+		if err := (Deployment{Namespace: namespace}).Validate(); !errors.Is(err, ErrInvalidDeployment) {
+			t.Fatalf("%q: expected ErrInvalidDeployment, got %v", namespace, err)
+		}
+	}
+}
+
+func TestDeployment_Handler(t *testing.T) {
+	Register(nil, prometheus.NewCounter(prometheus.CounterOpts{Name: "oms_deployment_handler_test_total", Help: "Test"})).Inc()
+
+	rec := httptest.NewRecorder()
+	Deployment{Namespace: "shop", Environment: "prod"}.Handler().ServeHTTP(rec, httptest.NewRequest("GET", "/metrics", nil))
+	body, _ := io.ReadAll(rec.Body)
+	if !strings.Contains(string(body), `shop_deployment_handler_test_total{environment="prod"} 1`) {
+		t.Fatalf("expected renamed labelled series in exposition, got:\n%s", body)
+	}
+}
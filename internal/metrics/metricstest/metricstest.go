@@ -0,0 +1,153 @@
+// Package metricstest — помощники тестов, проверяющие метрики так, как их отдаёт /metrics:
+// через Gather реестра, по имени серии и меткам, а не через поля компонента.
+package metricstest
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus"
+	dto "github.com/prometheus/client_model/go"
+	"github.com/prometheus/common/expfmt"
+)
+
+// Labels — метки, которые должны быть у серии. Неуказанные метки не сравниваются,
+// значения всех подошедших серий суммируются.
+type Labels map[string]string
+
+// Snapshot — результат одного Gather реестра.
+type Snapshot struct {
+	families map[string]*dto.MetricFamily
+}
+
+// Scrape собирает метрики реестра; ошибка Gather (например, конфликт collectors) валит тест.
+func Scrape(t testing.TB, gatherer prometheus.Gatherer) Snapshot {
+	t.Helper()
+	families, err := gatherer.Gather()
+	if err != nil {
+		t.Fatalf("gather metrics: %v", err)
+	}
+	snapshot := Snapshot{families: make(map[string]*dto.MetricFamily, len(families))}
+	for _, family := range families {
+		snapshot.families[family.GetName()] = family
+	}
+	return snapshot
+}
+
+// Value возвращает сумму значений counter/gauge/untyped-серий name с метками labels.
+// Для histogram и summary возвращается число наблюдений. found=false — ни одна серия не подошла.
+func (s Snapshot) Value(name string, labels Labels) (value float64, found bool) {
+	for _, metric := range s.matching(name, labels) {
+		found = true
+		switch {
+		case metric.Counter != nil:
+			value += metric.GetCounter().GetValue()
+		case metric.Gauge != nil:
+			value += metric.GetGauge().GetValue()
+		case metric.Untyped != nil:
+			value += metric.GetUntyped().GetValue()
+		case metric.Histogram != nil:
+			value += float64(metric.GetHistogram().GetSampleCount())
+		case metric.Summary != nil:
+			value += float64(metric.GetSummary().GetSampleCount())
+		}
+	}
+	return value, found
+}
+
+// Sum возвращает сумму наблюдений histogram/summary-серий name с метками labels.
+func (s Snapshot) Sum(name string, labels Labels) (sum float64, found bool) {
+	for _, metric := range s.matching(name, labels) {
+		found = true
+		sum += metric.GetHistogram().GetSampleSum() + metric.GetSummary().GetSampleSum()
+	}
+	return sum, found
+}
+
+// Series возвращает число серий name с метками labels.
+func (s Snapshot) Series(name string, labels Labels) int {
+	return len(s.matching(name, labels))
+}
+
+// Text возвращает семейство name в формате /metrics (для сообщений об ошибках).
+func (s Snapshot) Text(name string) string {
+	family, ok := s.families[name]
+	if !ok {
+		names := make([]string, 0, len(s.families))
+		for known := range s.families {
+			names = append(names, known)
+		}
+		sort.Strings(names)
+		return fmt.Sprintf("metric %s is not registered; registered: %s", name, strings.Join(names, ", "))
+	}
+	var out strings.Builder
+	if _, err := expfmt.MetricFamilyToText(&out, family); err != nil {
+		return fmt.Sprintf("encode %s: %v", name, err)
+	}
+	return out.String()
+}
+
+func (s Snapshot) matching(name string, labels Labels) []*dto.Metric {
+	family, ok := s.families[name]
+	if !ok {
+		return nil
+	}
+	var result []*dto.Metric
+	for _, metric := range family.GetMetric() {
+		if hasLabels(metric, labels) {
+			result = append(result, metric)
+		}
+	}
+	return result
+}
+
+func hasLabels(metric *dto.Metric, labels Labels) bool {
+	matched := 0
+	for _, pair := range metric.GetLabel() {
+		want, ok := labels[pair.GetName()]
+		if !ok {
+			continue
+		}
+		if want != pair.GetValue() {
+			return false
+		}
+		matched++
+	}
+	return matched == len(labels)
+}
+
+// RequireValue валит тест, если значение серии (см. Snapshot.Value) не равно want
+// или серии нет. Отсутствующая серия не считается нулём: метрику, которая перестала
+// писаться, так не пропустить.
+func RequireValue(t testing.TB, gatherer prometheus.Gatherer, name string, labels Labels, want float64) {
+	t.Helper()
+	snapshot := Scrape(t, gatherer)
+	got, found := snapshot.Value(name, labels)
+	if !found {
+		t.Fatalf("no series %s%v\n%s", name, labels, snapshot.Text(name))
+	}
+	if got != want {
+		t.Fatalf("%s%v = %v, want %v\n%s", name, labels, got, want, snapshot.Text(name))
+	}
+}
+
+// RequireAtLeast — как RequireValue, но для значений, зависящих от времени или повторов.
+func RequireAtLeast(t testing.TB, gatherer prometheus.Gatherer, name string, labels Labels, min float64) {
+	t.Helper()
+	snapshot := Scrape(t, gatherer)
+	got, found := snapshot.Value(name, labels)
+	if !found || got < min {
+		t.Fatalf("%s%v = %v (found=%v), want at least %v\n%s", name, labels, got, found, min, snapshot.Text(name))
+	}
+}
+
+// RequireAbsent валит тест, если у метрики есть серия с метками labels.
+func RequireAbsent(t testing.TB, gatherer prometheus.Gatherer, name string, labels Labels) {
+	t.Helper()
+	snapshot := Scrape(t, gatherer)
+	if n := snapshot.Series(name, labels); n > 0 {
+		t.Fatalf("expected no series %s%v, got %d\n%s", name, labels, n, snapshot.Text(name))
+	}
+}
@@ -0,0 +1,110 @@
+package metricstest
+
+import (
+	"fmt"
+	"runtime"
+	"strings"
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+func newTestRegistry() *prometheus.Registry {
+	registry := prometheus.NewRegistry()
+	attempts := prometheus.NewCounterVec(prometheus.CounterOpts{Name: "test_attempts_total", Help: "attempts"}, []string{"result", "topic"})
+	depth := prometheus.NewGauge(prometheus.GaugeOpts{Name: "test_depth", Help: "depth"})
+	latency := prometheus.NewHistogramVec(prometheus.HistogramOpts{Name: "test_latency_seconds", Help: "latency"}, []string{"topic"})
+	registry.MustRegister(attempts, depth, latency)
+
+	attempts.WithLabelValues("sent", "orders").Add(2)
+	attempts.WithLabelValues("sent", "payments").Add(3)
+	attempts.WithLabelValues("failed", "orders").Inc()
+	depth.Set(7)
+	latency.WithLabelValues("orders").Observe(0.5)
+	latency.WithLabelValues("orders").Observe(1.5)
+	return registry
+}
+
+func TestSnapshot_Value(t *testing.T) {
+	snapshot := Scrape(t, newTestRegistry())
+
+	cases := []struct {
+		name   string
+		labels Labels
+		want   float64
+	}{
+		{name: "test_attempts_total", labels: Labels{"result": "sent", "topic": "orders"}, want: 2},
+		{name: "test_attempts_total", labels: Labels{"result": "sent"}, want: 5},
+		{name: "test_attempts_total", want: 6},
+		{name: "test_depth", want: 7},
+		{name: "test_latency_seconds", labels: Labels{"topic": "orders"}, want: 2},
+	}
+	for _, tc := range cases {
+		got, found := snapshot.Value(tc.name, tc.labels)
+		if !found || got != tc.want {
+			t.Fatalf("Value(%s, %v) = %v, %v; want %v", tc.name, tc.labels, got, found, tc.want)
+		}
+	}
+
+	if _, found := snapshot.Value("test_attempts_total", Labels{"result": "dropped"}); found {
+		t.Fatal("expected no series for unknown label value")
+	}
+	if _, found := snapshot.Value("test_attempts_total", Labels{"partition": "0"}); found {
+		t.Fatal("expected no series for unknown label name")
+	}
+	if sum, _ := snapshot.Sum("test_latency_seconds", nil); sum != 2 {
+		t.Fatalf("expected histogram sum 2, got %v", sum)
+	}
+	if n := snapshot.Series("test_attempts_total", Labels{"topic": "orders"}); n != 2 {
+		t.Fatalf("expected 2 series, got %d", n)
+	}
+	if text := snapshot.Text("test_depth"); !strings.Contains(text, "test_depth 7") {
+		t.Fatalf("expected exposition text, got %q", text)
+	}
+	if text := snapshot.Text("missing"); !strings.Contains(text, "test_depth") {
+		t.Fatalf("expected registered names for missing metric, got %q", text)
+	}
+}
+
+// fatalRecorder перехватывает Fatalf, чтобы проверить, что помощник валит тест.
+type fatalRecorder struct {
+	testing.TB
+	message string
+}
+
+func (r *fatalRecorder) Helper() {}
+
+func (r *fatalRecorder) Fatalf(format string, args ...any) {
+	r.message = fmt.Sprintf(format, args...)
+	runtime.Goexit()
+}
+
+func fails(check func(testing.TB)) string {
+	recorder := &fatalRecorder{}
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		check(recorder)
+	}()
+	<-done
+	return recorder.message
+}
+
+func TestRequireHelpers(t *testing.T) {
+	registry := newTestRegistry()
+
+	RequireValue(t, registry, "test_attempts_total", Labels{"result": "failed"}, 1)
+	RequireAtLeast(t, registry, "test_latency_seconds", nil, 1)
+	RequireAbsent(t, registry, "test_attempts_total", Labels{"result": "dropped"})
+
+	if msg := fails(func(tb testing.TB) { RequireValue(tb, registry, "test_attempts_total", Labels{"result": "failed"}, 2) }); !strings.Contains(msg, "want 2") {
+		t.Fatalf("expected mismatch failure, got %q", msg)
+	}
+	// Отсутствующая серия не равна нулю.
+	if msg := fails(func(tb testing.TB) { RequireValue(tb, registry, "test_attempts_total", Labels{"result": "dropped"}, 0) }); !strings.Contains(msg, "no series") {
+		t.Fatalf("expected missing series failure, got %q", msg)
+	}
+	if msg := fails(func(tb testing.TB) { RequireAbsent(tb, registry, "test_depth", nil) }); msg == "" {
+		t.Fatal("expected RequireAbsent to fail for existing series")
+	}
+}
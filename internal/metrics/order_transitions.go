@@ -0,0 +1,60 @@
+package metrics
+
+import "github.com/prometheus/client_golang/prometheus"
+
+// Результаты перехода в oms_order_status_transitions_total.
+const (
+	// TransitionResultOK — статус сохранён.
+	TransitionResultOK = "ok"
+	// TransitionResultRejected — переход запрещён текущим статусом (терминальный заказ, hold).
+	TransitionResultRejected = "rejected"
+	// TransitionResultFailed — переход разрешён, но не сохранён (ошибка хранилища, конфликт версий).
+	TransitionResultFailed = "failed"
+)
+
+// TransitionFromNew — значение from для созданного заказа, вершина воронки.
+const TransitionFromNew = "new"
+
+// Значения label mode: бизнес-дашборды фильтруют mode="live", чтобы sandbox-заказы партнёров
+// не попадали в воронку и выручку.
+const (
+	OrderModeLive = "live"
+	OrderModeTest = "test"
+)
+
+// OrderMode возвращает значение label mode для заказа.
+func OrderMode(testMode bool) string {
+	if testMode {
+		return OrderModeTest
+	}
+	return OrderModeLive
+}
+
+// OrderTransitionMetrics считает переходы заказов между парами статусов. По ним дашборд строит
+// воронку конверсии и показывает, на каком шаге заказы выпадают, без запросов к БД.
+type OrderTransitionMetrics struct {
+	transitions *prometheus.CounterVec
+}
+
+// NewOrderTransitionMetrics создаёт счётчик переходов в указанном реестре (nil — глобальный).
+func NewOrderTransitionMetrics(registerer prometheus.Registerer) *OrderTransitionMetrics {
+	return &OrderTransitionMetrics{
+		transitions: Register(registerer, prometheus.NewCounterVec(prometheus.CounterOpts{
+			Name: "oms_order_status_transitions_total",
+			Help: "Total number of order status transitions by source status, target status, result and order mode (live or test)",
+		}, []string{"from", "to", "result", "mode"})),
+	}
+}
+
+// Record учитывает переход from→to обычного заказа с результатом result. Безопасен для nil.
+func (m *OrderTransitionMetrics) Record(from, to, result string) {
+	m.RecordMode(OrderModeLive, from, to, result)
+}
+
+// RecordMode учитывает переход заказа с указанным mode (OrderMode). Безопасен для nil.
+func (m *OrderTransitionMetrics) RecordMode(mode, from, to, result string) {
+	if m == nil {
+		return
+	}
+	m.transitions.WithLabelValues(from, to, result, mode).Inc()
+}
@@ -0,0 +1,34 @@
+package metrics
+
+import (
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+)
+
+func TestOrderTransitionMetrics_Record(t *testing.T) {
+	registry := prometheus.NewRegistry()
+	m := NewOrderTransitionMetrics(registry)
+
+	m.Record("pending", "reserved", TransitionResultOK)
+	m.Record("pending", "reserved", TransitionResultOK)
+	m.Record("canceled", "paid", TransitionResultRejected)
+	m.RecordMode(OrderMode(true), "pending", "reserved", TransitionResultOK)
+
+	if got := testutil.ToFloat64(m.transitions.WithLabelValues("pending", "reserved", TransitionResultOK, OrderModeLive)); got != 2 {
+		t.Fatalf("expected 2 pending->reserved transitions, got %v", got)
+	}
+	if got := testutil.ToFloat64(m.transitions.WithLabelValues("canceled", "paid", TransitionResultRejected, OrderModeLive)); got != 1 {
+		t.Fatalf("expected 1 rejected transition, got %v", got)
+	}
+	if got := testutil.ToFloat64(m.transitions.WithLabelValues("pending", "reserved", TransitionResultOK, OrderModeTest)); got != 1 {
+		t.Fatalf("expected test-mode transition to be recorded separately, got %v", got)
+	}
+	if NewOrderTransitionMetrics(registry).transitions != m.transitions {
+		t.Fatal("expected collector to be reused for the same registry")
+	}
+
+	var nilMetrics *OrderTransitionMetrics
+	nilMetrics.Record("pending", "reserved", TransitionResultOK)
+}
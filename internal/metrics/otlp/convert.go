@@ -0,0 +1,270 @@
+package otlp
+
+import (
+	"math"
+	"sort"
+	"strconv"
+	"time"
+
+	dto "github.com/prometheus/client_model/go"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	"github.com/vladislavdragonenkov/oms/internal/version"
+)
+
+// Типы ниже повторяют JSON-представление ExportMetricsServiceRequest (opentelemetry-proto,
+// metrics/v1): 64-битные целые кодируются строками, enum — числами.
+
+// aggregationCumulative — AGGREGATION_TEMPORALITY_CUMULATIVE.
+const aggregationCumulative = 2
+
+const scopeName = "github.com/vladislavdragonenkov/oms"
+
+type exportRequest struct {
+	ResourceMetrics []resourceMetrics `json:"resourceMetrics"`
+}
+
+type resourceMetrics struct {
+	Resource     resource       `json:"resource"`
+	ScopeMetrics []scopeMetrics `json:"scopeMetrics"`
+}
+
+type resource struct {
+	Attributes []keyValue `json:"attributes,omitempty"`
+}
+
+type scopeMetrics struct {
+	Scope   scope    `json:"scope"`
+	Metrics []metric `json:"metrics"`
+}
+
+type scope struct {
+	Name    string `json:"name"`
+	Version string `json:"version,omitempty"`
+}
+
+type keyValue struct {
+	Key   string   `json:"key"`
+	Value anyValue `json:"value"`
+}
+
+type anyValue struct {
+	StringValue string `json:"stringValue"`
+}
+
+type metric struct {
+	Name        string     `json:"name"`
+	Description string     `json:"description,omitempty"`
+	Sum         *sum       `json:"sum,omitempty"`
+	Gauge       *gauge     `json:"gauge,omitempty"`
+	Histogram   *histogram `json:"histogram,omitempty"`
+	Summary     *summary   `json:"summary,omitempty"`
+}
+
+type sum struct {
+	DataPoints             []numberDataPoint `json:"dataPoints"`
+	AggregationTemporality int               `json:"aggregationTemporality"`
+	IsMonotonic            bool              `json:"isMonotonic"`
+}
+
+type gauge struct {
+	DataPoints []numberDataPoint `json:"dataPoints"`
+}
+
+type numberDataPoint struct {
+	Attributes        []keyValue `json:"attributes,omitempty"`
+	StartTimeUnixNano fixed64    `json:"startTimeUnixNano,omitempty"`
+	TimeUnixNano      fixed64    `json:"timeUnixNano"`
+	AsDouble          double     `json:"asDouble"`
+}
+
+type histogram struct {
+	DataPoints             []histogramDataPoint `json:"dataPoints"`
+	AggregationTemporality int                  `json:"aggregationTemporality"`
+}
+
+type histogramDataPoint struct {
+	Attributes        []keyValue `json:"attributes,omitempty"`
+	StartTimeUnixNano fixed64    `json:"startTimeUnixNano"`
+	TimeUnixNano      fixed64    `json:"timeUnixNano"`
+	Count             fixed64    `json:"count"`
+	Sum               double     `json:"sum"`
+	// BucketCounts — счётчики отдельных бакетов (не накопленные), на один больше ExplicitBounds.
+	BucketCounts   []fixed64 `json:"bucketCounts"`
+	ExplicitBounds []double  `json:"explicitBounds"`
+}
+
+type summary struct {
+	DataPoints []summaryDataPoint `json:"dataPoints"`
+}
+
+type summaryDataPoint struct {
+	Attributes        []keyValue        `json:"attributes,omitempty"`
+	StartTimeUnixNano fixed64           `json:"startTimeUnixNano"`
+	TimeUnixNano      fixed64           `json:"timeUnixNano"`
+	Count             fixed64           `json:"count"`
+	Sum               double            `json:"sum"`
+	QuantileValues    []valueAtQuantile `json:"quantileValues"`
+}
+
+type valueAtQuantile struct {
+	Quantile double `json:"quantile"`
+	Value    double `json:"value"`
+}
+
+// fixed64 — uint64 в JSON-строке, как требует proto3 JSON mapping.
+type fixed64 uint64
+
+func (v fixed64) MarshalJSON() ([]byte, error) {
+	return strconv.AppendQuote(nil, strconv.FormatUint(uint64(v), 10)), nil
+}
+
+// double — float64, у которого NaN и бесконечности пишутся строками: обычный encoding/json на них
+// падает, а summary без наблюдений отдаёт NaN в квантилях.
+type double float64
+
+func (v double) MarshalJSON() ([]byte, error) {
+	f := float64(v)
+	switch {
+	case math.IsNaN(f):
+		return []byte(`"NaN"`), nil
+	case math.IsInf(f, 1):
+		return []byte(`"Infinity"`), nil
+	case math.IsInf(f, -1):
+		return []byte(`"-Infinity"`), nil
+	}
+	return strconv.AppendFloat(nil, f, 'g', -1, 64), nil
+}
+
+func newExportRequest(resourceAttrs []keyValue, families []*dto.MetricFamily, startedAt, now time.Time) exportRequest {
+	converted := make([]metric, 0, len(families))
+	for _, family := range families {
+		if m, ok := convertFamily(family, startedAt, now); ok {
+			converted = append(converted, m)
+		}
+	}
+	return exportRequest{ResourceMetrics: []resourceMetrics{{
+		Resource: resource{Attributes: resourceAttrs},
+		ScopeMetrics: []scopeMetrics{{
+			Scope:   scope{Name: scopeName, Version: version.GetVersion()},
+			Metrics: converted,
+		}},
+	}}}
+}
+
+// convertFamily переводит семейство Prometheus в метрику OTLP: counter — монотонный sum,
+// gauge и untyped — gauge. Неизвестные типы (native/gauge histogram) пропускаются.
+func convertFamily(family *dto.MetricFamily, startedAt, now time.Time) (metric, bool) {
+	m := metric{Name: family.GetName(), Description: family.GetHelp()}
+	ts := fixed64(now.UnixNano())
+	switch family.GetType() {
+	case dto.MetricType_COUNTER:
+		m.Sum = &sum{AggregationTemporality: aggregationCumulative, IsMonotonic: true}
+		for _, series := range family.GetMetric() {
+			m.Sum.DataPoints = append(m.Sum.DataPoints, numberDataPoint{
+				Attributes:        labelAttributes(series.GetLabel()),
+				StartTimeUnixNano: startTime(series.GetCounter().GetCreatedTimestamp(), startedAt),
+				TimeUnixNano:      ts,
+				AsDouble:          double(series.GetCounter().GetValue()),
+			})
+		}
+	case dto.MetricType_GAUGE, dto.MetricType_UNTYPED:
+		m.Gauge = &gauge{}
+		for _, series := range family.GetMetric() {
+			value := series.GetGauge().GetValue()
+			if family.GetType() == dto.MetricType_UNTYPED {
+				value = series.GetUntyped().GetValue()
+			}
+			m.Gauge.DataPoints = append(m.Gauge.DataPoints, numberDataPoint{
+				Attributes:   labelAttributes(series.GetLabel()),
+				TimeUnixNano: ts,
+				AsDouble:     double(value),
+			})
+		}
+	case dto.MetricType_HISTOGRAM:
+		m.Histogram = &histogram{AggregationTemporality: aggregationCumulative}
+		for _, series := range family.GetMetric() {
+			m.Histogram.DataPoints = append(m.Histogram.DataPoints, histogramPoint(series, startedAt, ts))
+		}
+	case dto.MetricType_SUMMARY:
+		m.Summary = &summary{}
+		for _, series := range family.GetMetric() {
+			s := series.GetSummary()
+			point := summaryDataPoint{
+				Attributes:        labelAttributes(series.GetLabel()),
+				StartTimeUnixNano: startTime(s.GetCreatedTimestamp(), startedAt),
+				TimeUnixNano:      ts,
+				Count:             fixed64(s.GetSampleCount()),
+				Sum:               double(s.GetSampleSum()),
+				QuantileValues:    make([]valueAtQuantile, 0, len(s.GetQuantile())),
+			}
+			for _, q := range s.GetQuantile() {
+				point.QuantileValues = append(point.QuantileValues, valueAtQuantile{Quantile: double(q.GetQuantile()), Value: double(q.GetValue())})
+			}
+			m.Summary.DataPoints = append(m.Summary.DataPoints, point)
+		}
+	default:
+		return metric{}, false
+	}
+	return m, true
+}
+
+// histogramPoint переводит накопленные бакеты Prometheus в отдельные счётчики OTLP. Бакет +Inf
+// в OTLP неявный: его значение — остаток до общего числа наблюдений.
+func histogramPoint(series *dto.Metric, startedAt time.Time, ts fixed64) histogramDataPoint {
+	h := series.GetHistogram()
+	point := histogramDataPoint{
+		Attributes:        labelAttributes(series.GetLabel()),
+		StartTimeUnixNano: startTime(h.GetCreatedTimestamp(), startedAt),
+		TimeUnixNano:      ts,
+		Count:             fixed64(h.GetSampleCount()),
+		Sum:               double(h.GetSampleSum()),
+		BucketCounts:      make([]fixed64, 0, len(h.GetBucket())+1),
+		ExplicitBounds:    make([]double, 0, len(h.GetBucket())),
+	}
+	var previous uint64
+	for _, bucket := range h.GetBucket() {
+		if math.IsInf(bucket.GetUpperBound(), 1) {
+			continue
+		}
+		point.ExplicitBounds = append(point.ExplicitBounds, double(bucket.GetUpperBound()))
+		point.BucketCounts = append(point.BucketCounts, fixed64(bucket.GetCumulativeCount()-previous))
+		previous = bucket.GetCumulativeCount()
+	}
+	point.BucketCounts = append(point.BucketCounts, fixed64(h.GetSampleCount()-previous))
+	return point
+}
+
+func startTime(created *timestamppb.Timestamp, fallback time.Time) fixed64 {
+	if created.IsValid() && created.AsTime().After(time.Unix(0, 0)) {
+		return fixed64(created.AsTime().UnixNano())
+	}
+	return fixed64(fallback.UnixNano())
+}
+
+func labelAttributes(labels []*dto.LabelPair) []keyValue {
+	if len(labels) == 0 {
+		return nil
+	}
+	out := make([]keyValue, 0, len(labels))
+	for _, label := range labels {
+		out = append(out, keyValue{Key: label.GetName(), Value: anyValue{StringValue: label.GetValue()}})
+	}
+	return out
+}
+
+// attributes переводит map в атрибуты в стабильном порядке; пустые значения пропускаются.
+func attributes(values map[string]string) []keyValue {
+	keys := make([]string, 0, len(values))
+	for key, value := range values {
+		if value != "" {
+			keys = append(keys, key)
+		}
+	}
+	sort.Strings(keys)
+	out := make([]keyValue, 0, len(keys))
+	for _, key := range keys {
+		out = append(out, keyValue{Key: key, Value: anyValue{StringValue: values[key]}})
+	}
+	return out
+}
@@ -0,0 +1,262 @@
+// Package otlp отправляет метрики реестра Prometheus в OTLP-совместимый backend (OTLP/HTTP, JSON)
+// для окружений без Prometheus, который скрейпит /metrics. Серии те же, что на /metrics:
+// экспортёр читает тот же Gatherer и переводит семейства в OTLP при каждой отправке.
+package otlp
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	log "github.com/sirupsen/logrus"
+
+	"github.com/vladislavdragonenkov/oms/internal/metrics"
+)
+
+const (
+	// DefaultInterval — период отправки метрик.
+	DefaultInterval = time.Minute
+	// metricsPath добавляется к endpoint без пути, как у OTEL_EXPORTER_OTLP_ENDPOINT.
+	metricsPath    = "/v1/metrics"
+	defaultTimeout = 10 * time.Second
+	// maxErrorBody — сколько байт ответа с ошибкой попадает в текст ошибки.
+	maxErrorBody = 512
+)
+
+var (
+	// ErrInvalidConfig — некорректный endpoint или заголовки экспортёра.
+	ErrInvalidConfig = errors.New("otlp: invalid exporter config")
+	// ErrExport — backend не принял метрики.
+	ErrExport = errors.New("otlp: export failed")
+)
+
+// ExporterOptions задаёт параметры Exporter.
+type ExporterOptions struct {
+	Logger   *log.Entry
+	Interval time.Duration
+	// Headers добавляются к каждому запросу (обычно ключ API backend'а).
+	Headers map[string]string
+	// Resource — атрибуты ресурса OTLP (service.name, deployment.environment, ...).
+	Resource map[string]string
+	// Gatherer — откуда читаются метрики; nil — глобальный реестр Prometheus.
+	Gatherer prometheus.Gatherer
+	// Registerer — куда регистрируется oms_otlp_metrics_exports_total.
+	Registerer prometheus.Registerer
+	HTTPClient *http.Client
+}
+
+// ExporterOption настраивает Exporter.
+type ExporterOption func(*ExporterOptions)
+
+// WithLogger задаёт logger.
+func WithLogger(logger *log.Entry) ExporterOption {
+	return func(opts *ExporterOptions) {
+		opts.Logger = logger
+	}
+}
+
+// WithInterval задаёт период отправки.
+func WithInterval(interval time.Duration) ExporterOption {
+	return func(opts *ExporterOptions) {
+		opts.Interval = interval
+	}
+}
+
+// WithHeaders задаёт заголовки запросов.
+func WithHeaders(headers map[string]string) ExporterOption {
+	return func(opts *ExporterOptions) {
+		opts.Headers = headers
+	}
+}
+
+// WithResource задаёт атрибуты ресурса.
+func WithResource(attributes map[string]string) ExporterOption {
+	return func(opts *ExporterOptions) {
+		opts.Resource = attributes
+	}
+}
+
+// WithGatherer задаёт реестр, метрики которого отправляются.
+func WithGatherer(gatherer prometheus.Gatherer) ExporterOption {
+	return func(opts *ExporterOptions) {
+		opts.Gatherer = gatherer
+	}
+}
+
+// WithRegisterer задаёт реестр для метрики самого экспортёра.
+func WithRegisterer(registerer prometheus.Registerer) ExporterOption {
+	return func(opts *ExporterOptions) {
+		opts.Registerer = registerer
+	}
+}
+
+// WithHTTPClient задаёт HTTP-клиент (прокси, TLS); по умолчанию — клиент с таймаутом 10s.
+func WithHTTPClient(client *http.Client) ExporterOption {
+	return func(opts *ExporterOptions) {
+		opts.HTTPClient = client
+	}
+}
+
+// Exporter периодически отправляет снимок реестра на endpoint. Счётчики, гистограммы и summary
+// уходят как cumulative: backend считает rate так же, как Prometheus.
+type Exporter struct {
+	endpoint string
+	logger   *log.Entry
+	interval time.Duration
+	headers  map[string]string
+	resource []keyValue
+	gatherer prometheus.Gatherer
+	client   *http.Client
+	exports  *prometheus.CounterVec
+	// startedAt — начало cumulative-интервала для серий без created timestamp.
+	startedAt time.Time
+	now       func() time.Time
+}
+
+// NewExporter создаёт экспортёр. endpoint без пути дополняется /v1/metrics.
+func NewExporter(endpoint string, options ...ExporterOption) (*Exporter, error) {
+	target, err := MetricsURL(endpoint)
+	if err != nil {
+		return nil, err
+	}
+	opts := ExporterOptions{Interval: DefaultInterval}
+	for _, option := range options {
+		option(&opts)
+	}
+
+	logger := opts.Logger
+	if logger == nil {
+		logger = log.WithField("component", "otlp-metrics")
+	}
+	if opts.Interval <= 0 {
+		opts.Interval = DefaultInterval
+	}
+	gatherer := opts.Gatherer
+	if gatherer == nil {
+		gatherer = prometheus.DefaultGatherer
+	}
+	client := opts.HTTPClient
+	if client == nil {
+		client = &http.Client{Timeout: defaultTimeout}
+	}
+
+	return &Exporter{
+		endpoint: target,
+		logger:   logger,
+		interval: opts.Interval,
+		headers:  opts.Headers,
+		resource: attributes(opts.Resource),
+		gatherer: gatherer,
+		client:   client,
+		exports: metrics.Register(opts.Registerer, prometheus.NewCounterVec(prometheus.CounterOpts{
+			Name: "oms_otlp_metrics_exports_total",
+			Help: "Total number of OTLP metric exports grouped by result.",
+		}, []string{"result"})),
+		startedAt: time.Now(),
+		now:       time.Now,
+	}, nil
+}
+
+// Run отправляет метрики каждые interval до отмены ctx; напоследок отправляет последний снимок,
+// чтобы backend увидел счётчики на момент остановки.
+func (e *Exporter) Run(ctx context.Context) {
+	ticker := time.NewTicker(e.interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			flushCtx, cancel := context.WithTimeout(context.WithoutCancel(ctx), defaultTimeout)
+			e.export(flushCtx)
+			cancel()
+			return
+		case <-ticker.C:
+			e.export(ctx)
+		}
+	}
+}
+
+func (e *Exporter) export(ctx context.Context) {
+	if err := e.Push(ctx); err != nil {
+		e.exports.WithLabelValues("error").Inc()
+		e.logger.WithError(err).Warn("export metrics via otlp")
+		return
+	}
+	e.exports.WithLabelValues("success").Inc()
+}
+
+// Push отправляет текущий снимок реестра одним запросом.
+func (e *Exporter) Push(ctx context.Context) error {
+	families, err := e.gatherer.Gather()
+	if err != nil {
+		// Gather возвращает всё, что удалось собрать: отправляем остальное.
+		e.logger.WithError(err).Warn("gather metrics for otlp")
+	}
+	body, err := json.Marshal(newExportRequest(e.resource, families, e.startedAt, e.now()))
+	if err != nil {
+		return fmt.Errorf("%w: encode: %v", ErrExport, err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, e.endpoint, bytes.NewReader(body))
+	if err != nil {
+		return fmt.Errorf("%w: %v", ErrExport, err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	for name, value := range e.headers {
+		req.Header.Set(name, value)
+	}
+	resp, err := e.client.Do(req)
+	if err != nil {
+		return fmt.Errorf("%w: %v", ErrExport, err)
+	}
+	defer func() { _ = resp.Body.Close() }()
+	if resp.StatusCode >= 200 && resp.StatusCode < 300 {
+		_, _ = io.Copy(io.Discard, resp.Body)
+		return nil
+	}
+	msg, _ := io.ReadAll(io.LimitReader(resp.Body, maxErrorBody))
+	return fmt.Errorf("%w: status %d: %s", ErrExport, resp.StatusCode, strings.TrimSpace(string(msg)))
+}
+
+// MetricsURL проверяет endpoint и возвращает адрес, на который уходят метрики.
+func MetricsURL(endpoint string) (string, error) {
+	u, err := url.Parse(strings.TrimSpace(endpoint))
+	if err != nil || u.Host == "" || (u.Scheme != "http" && u.Scheme != "https") {
+		return "", fmt.Errorf("%w: endpoint %q must be an http(s) url", ErrInvalidConfig, endpoint)
+	}
+	if u.Path == "" || u.Path == "/" {
+		u.Path = metricsPath
+	}
+	return u.String(), nil
+}
+
+// ParseHeaders разбирает заголовки в формате OTEL_EXPORTER_OTLP_HEADERS:
+// "api-key=secret,x-tenant=oms"; значения URL-декодируются.
+func ParseHeaders(raw string) (map[string]string, error) {
+	headers := make(map[string]string)
+	for _, part := range strings.Split(raw, ",") {
+		part = strings.TrimSpace(part)
+		if part == "" {
+			continue
+		}
+		name, value, found := strings.Cut(part, "=")
+		name = strings.TrimSpace(name)
+		if !found || name == "" {
+			return nil, fmt.Errorf("%w: expected header=value, got %q", ErrInvalidConfig, part)
+		}
+		decoded, err := url.QueryUnescape(strings.TrimSpace(value))
+		if err != nil {
+			return nil, fmt.Errorf("%w: header %s: %v", ErrInvalidConfig, name, err)
+		}
+		headers[name] = decoded
+	}
+	return headers, nil
+}
@@ -0,0 +1,189 @@
+package otlp
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"math"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+
+	"github.com/vladislavdragonenkov/oms/internal/metrics/metricstest"
+)
+
+// receiver — OTLP/HTTP backend, запоминающий последний запрос.
+type receiver struct {
+	server  *httptest.Server
+	status  int
+	headers http.Header
+	body    map[string]any
+	calls   int
+}
+
+func newReceiver(t *testing.T) *receiver {
+	t.Helper()
+	r := &receiver{status: http.StatusOK}
+	r.server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		r.calls++
+		r.headers = req.Header.Clone()
+		if req.URL.Path != "/v1/metrics" || req.Method != http.MethodPost {
+			t.Errorf("unexpected request %s %s", req.Method, req.URL.Path)
+		}
+		data, _ := io.ReadAll(req.Body)
+		r.body = nil
+		if err := json.Unmarshal(data, &r.body); err != nil {
+			t.Errorf("request is not json: %v (%s)", err, data)
+		}
+		w.WriteHeader(r.status)
+	}))
+	t.Cleanup(r.server.Close)
+	return r
+}
+
+// metric возвращает метрику name из последнего запроса.
+func (r *receiver) metric(t *testing.T, name string) map[string]any {
+	t.Helper()
+	resourceMetrics := r.body["resourceMetrics"].([]any)[0].(map[string]any)
+	for _, m := range resourceMetrics["scopeMetrics"].([]any)[0].(map[string]any)["metrics"].([]any) {
+		if m := m.(map[string]any); m["name"] == name {
+			return m
+		}
+	}
+	t.Fatalf("metric %s was not exported", name)
+	return nil
+}
+
+func firstPoint(t *testing.T, m map[string]any, kind string) map[string]any {
+	t.Helper()
+	data, ok := m[kind].(map[string]any)
+	if !ok {
+		t.Fatalf("metric %s is not a %s: %v", m["name"], kind, m)
+	}
+	return data["dataPoints"].([]any)[0].(map[string]any)
+}
+
+func TestExporter_Push(t *testing.T) {
+	rcv := newReceiver(t)
+	source := prometheus.NewRegistry()
+	requests := prometheus.NewCounterVec(prometheus.CounterOpts{Name: "oms_requests_total", Help: "Requests."}, []string{"method"})
+	inFlight := prometheus.NewGauge(prometheus.GaugeOpts{Name: "oms_in_flight", Help: "In flight."})
+	latency := prometheus.NewHistogram(prometheus.HistogramOpts{Name: "oms_latency_seconds", Help: "Latency.", Buckets: []float64{0.1, 1}})
+	empty := prometheus.NewSummary(prometheus.SummaryOpts{Name: "oms_empty_summary", Help: "Empty.", Objectives: map[float64]float64{0.5: 0.05}})
+	source.MustRegister(requests, inFlight, latency, empty)
+	requests.WithLabelValues("Create").Add(3)
+	inFlight.Set(2)
+	latency.Observe(0.05)
+	latency.Observe(0.5)
+	latency.Observe(5)
+
+	exporter, err := NewExporter(rcv.server.URL,
+		WithGatherer(source),
+		WithRegisterer(prometheus.NewRegistry()),
+		WithHeaders(map[string]string{"Api-Key": "secret"}),
+		WithResource(map[string]string{"service.name": "order-service", "cloud.region": ""}),
+	)
+	if err != nil {
+		t.Fatalf("new exporter: %v", err)
+	}
+	if err := exporter.Push(context.Background()); err != nil {
+		t.Fatalf("push: %v", err)
+	}
+	if rcv.headers.Get("Api-Key") != "secret" || rcv.headers.Get("Content-Type") != "application/json" {
+		t.Fatalf("unexpected headers %v", rcv.headers)
+	}
+
+	resource := rcv.body["resourceMetrics"].([]any)[0].(map[string]any)["resource"].(map[string]any)
+	wantResource := []any{map[string]any{"key": "service.name", "value": map[string]any{"stringValue": "order-service"}}}
+	if !reflect.DeepEqual(resource["attributes"], wantResource) {
+		t.Fatalf("unexpected resource %v", resource)
+	}
+
+	counter := rcv.metric(t, "oms_requests_total")
+	if sum := counter["sum"].(map[string]any); sum["isMonotonic"] != true || sum["aggregationTemporality"] != float64(2) {
+		t.Fatalf("counter must be a cumulative monotonic sum: %v", counter)
+	}
+	point := firstPoint(t, counter, "sum")
+	if point["asDouble"] != float64(3) || point["startTimeUnixNano"] == nil {
+		t.Fatalf("unexpected counter point %v", point)
+	}
+	if attrs := point["attributes"].([]any); len(attrs) != 1 || attrs[0].(map[string]any)["key"] != "method" {
+		t.Fatalf("labels must become attributes: %v", attrs)
+	}
+	if point := firstPoint(t, rcv.metric(t, "oms_in_flight"), "gauge"); point["asDouble"] != float64(2) {
+		t.Fatalf("unexpected gauge point %v", point)
+	}
+
+	point = firstPoint(t, rcv.metric(t, "oms_latency_seconds"), "histogram")
+	if point["count"] != "3" || !reflect.DeepEqual(point["bucketCounts"], []any{"1", "1", "1"}) || !reflect.DeepEqual(point["explicitBounds"], []any{0.1, float64(1)}) {
+		t.Fatalf("unexpected histogram point %v", point)
+	}
+	point = firstPoint(t, rcv.metric(t, "oms_empty_summary"), "summary")
+	if quantiles := point["quantileValues"].([]any); quantiles[0].(map[string]any)["value"] != "NaN" {
+		t.Fatalf("NaN quantile must be encoded as a string: %v", point)
+	}
+}
+
+func TestExporter_PushRejected(t *testing.T) {
+	rcv := newReceiver(t)
+	rcv.status = http.StatusUnauthorized
+	registry := prometheus.NewRegistry()
+	exporter, err := NewExporter(rcv.server.URL+"/", WithGatherer(registry), WithRegisterer(registry))
+	if err != nil {
+		t.Fatalf("new exporter: %v", err)
+	}
+	if err := exporter.Push(context.Background()); !errors.Is(err, ErrExport) {
+		t.Fatalf("expected ErrExport, got %v", err)
+	}
+
+	// Run отправляет последний снимок и после отмены контекста.
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	exporter.Run(ctx)
+	metricstest.RequireValue(t, registry, "oms_otlp_metrics_exports_total", metricstest.Labels{"result": "error"}, 1)
+	if rcv.calls != 2 {
+		t.Fatalf("expected final export on shutdown, got %d calls", rcv.calls)
+	}
+}
+
+func TestNewExporter_InvalidEndpoint(t *testing.T) {
+	for _, endpoint := range []string{"", "collector:4318", "grpc://collector:4317"} {
+		if _, err := NewExporter(endpoint); !errors.Is(err, ErrInvalidConfig) {
+			t.Fatalf("%q: expected ErrInvalidConfig, got %v", endpoint, err)
+		}
+	}
+	exporter, err := NewExporter("https://otlp.example.com/otlp/v1/metrics", WithRegisterer(prometheus.NewRegistry()), WithInterval(-time.Second))
+	if err != nil {
+		t.Fatalf("new exporter: %v", err)
+	}
+	if exporter.endpoint != "https://otlp.example.com/otlp/v1/metrics" || exporter.interval != DefaultInterval {
+		t.Fatalf("explicit path must be kept, got %s every %s", exporter.endpoint, exporter.interval)
+	}
+}
+
+func TestParseHeaders(t *testing.T) {
+	headers, err := ParseHeaders(" api-key = se%3Dcret , x-tenant=oms,")
+	if err != nil {
+		t.Fatalf("parse: %v", err)
+	}
+	if !reflect.DeepEqual(headers, map[string]string{"api-key": "se=cret", "x-tenant": "oms"}) {
+		t.Fatalf("unexpected headers %v", headers)
+	}
+	for _, raw := range []string{"api-key", "=value", "api-key=%zz"} {
+		if _, err := ParseHeaders(raw); !errors.Is(err, ErrInvalidConfig) {
+			t.Fatalf("%q: expected ErrInvalidConfig, got %v", raw, err)
+		}
+	}
+}
+
+func TestDouble_MarshalJSON(t *testing.T) {
+	data, err := json.Marshal([]double{1.5, double(math.Inf(1)), double(math.Inf(-1))})
+	if err != nil || string(data) != `[1.5,"Infinity","-Infinity"]` {
+		t.Fatalf("unexpected encoding %s (%v)", data, err)
+	}
+}
@@ -0,0 +1,31 @@
+package metrics
+
+import (
+	"fmt"
+
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// Register регистрирует collector в registerer и возвращает его. nil — глобальный реестр
+// Prometheus; так же nil понимают все опции и поля Registerer компонентов, которые регистрируют
+// метрики через Register. Если collector с тем же описанием уже зарегистрирован, возвращается
+// существующий: так компоненты можно создавать повторно (в тестах, при перезапуске воркеров)
+// без паники.
+func Register[C prometheus.Collector](registerer prometheus.Registerer, collector C) C {
+	if registerer == nil {
+		registerer = prometheus.DefaultRegisterer
+	}
+	err := registerer.Register(collector)
+	if err == nil {
+		return collector
+	}
+	alreadyRegistered, ok := err.(prometheus.AlreadyRegisteredError)
+	if !ok {
+		panic(fmt.Sprintf("register collector: %v", err))
+	}
+	existing, ok := alreadyRegistered.ExistingCollector.(C)
+	if !ok {
+		panic(fmt.Sprintf("collector already registered with unexpected type %T", alreadyRegistered.ExistingCollector))
+	}
+	return existing
+}
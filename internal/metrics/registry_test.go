@@ -0,0 +1,50 @@
+package metrics
+
+import (
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+func TestRegister_ReusesExistingCollector(t *testing.T) {
+	registry := prometheus.NewRegistry()
+	opts := prometheus.CounterOpts{Name: "test_register_total", Help: "Test counter"}
+
+	first := Register(registry, prometheus.NewCounter(opts))
+	second := Register(registry, prometheus.NewCounter(opts))
+	if first != second {
+		t.Fatal("expected second registration to return existing collector")
+	}
+}
+
+func TestRegister_PanicsOnTypeMismatch(t *testing.T) {
+	registry := prometheus.NewRegistry()
+	Register(registry, prometheus.NewCounter(prometheus.CounterOpts{Name: "test_mismatch", Help: "Test"}))
+
+	defer func() {
+		if recover() == nil {
+			t.Fatal("expected panic when existing collector has another type")
+		}
+	}()
+	Register(registry, prometheus.NewCounterVec(prometheus.CounterOpts{Name: "test_mismatch", Help: "Test"}, nil))
+}
+
+func TestNewSagaMetricsWithRegistry_IsolatedFromGlobalRegistry(t *testing.T) {
+	registry := prometheus.NewRegistry()
+	metrics := NewSagaMetricsWithRegistry(registry)
+	metrics.RecordSagaStarted()
+
+	families, err := registry.Gather()
+	if err != nil {
+		t.Fatalf("gather: %v", err)
+	}
+	found := false
+	for _, family := range families {
+		if family.GetName() == "oms_saga_started_total" {
+			found = family.GetMetric()[0].GetCounter().GetValue() == 1
+		}
+	}
+	if !found {
+		t.Fatal("expected oms_saga_started_total=1 in custom registry")
+	}
+}
@@ -1,7 +1,6 @@
 package metrics
 
 import (
-	"fmt"
 	"time"
 
 	"github.com/prometheus/client_golang/prometheus"
@@ -15,6 +14,10 @@ type SagaMetrics struct {
 	sagaRefunded  prometheus.Counter
 	sagaCompleted prometheus.Counter
 	sagaFailed    prometheus.Counter
+	// sagaDeadline — саги, остановленные по истечении контекста
+	sagaDeadline prometheus.Counter
+	// sagaBackordered — заказы, ушедшие в ожидание пополнения склада
+	sagaBackordered prometheus.Counter
 
 	// Гистограммы времени выполнения
 	sagaDuration prometheus.Histogram
@@ -26,124 +29,73 @@ type SagaMetrics struct {
 
 	// Gauge для активных саг
 	activeSagas prometheus.Gauge
+
+	transitions *OrderTransitionMetrics
 }
 
-// NewSagaMetrics создаёт новый экземпляр метрик saga.
+// NewSagaMetrics создаёт метрики saga в глобальном реестре Prometheus.
 func NewSagaMetrics() *SagaMetrics {
-	return newSagaMetricsWithRegisterer(prometheus.DefaultRegisterer)
+	return NewSagaMetricsWithRegistry(prometheus.DefaultRegisterer)
 }
 
-func newSagaMetricsWithRegisterer(registerer prometheus.Registerer) *SagaMetrics {
-	if registerer == nil {
-		registerer = prometheus.DefaultRegisterer
-	}
-
+// NewSagaMetricsWithRegistry создаёт метрики saga в указанном реестре (nil — глобальный).
+// Повторный вызов с тем же реестром переиспользует уже зарегистрированные collectors.
+func NewSagaMetricsWithRegistry(registerer prometheus.Registerer) *SagaMetrics {
 	return &SagaMetrics{
-		sagaStarted: registerCounter(registerer, prometheus.CounterOpts{
+		sagaStarted: Register(registerer, prometheus.NewCounter(prometheus.CounterOpts{
 			Name: "oms_saga_started_total",
 			Help: "Total number of saga operations started",
-		}),
-		sagaCanceled: registerCounter(registerer, prometheus.CounterOpts{
+		})),
+		sagaCanceled: Register(registerer, prometheus.NewCounter(prometheus.CounterOpts{
 			Name: "oms_saga_canceled_total",
 			Help: "Total number of saga operations canceled",
-		}),
-		sagaRefunded: registerCounter(registerer, prometheus.CounterOpts{
+		})),
+		sagaRefunded: Register(registerer, prometheus.NewCounter(prometheus.CounterOpts{
 			Name: "oms_saga_refunded_total",
 			Help: "Total number of saga operations refunded",
-		}),
-		sagaCompleted: registerCounter(registerer, prometheus.CounterOpts{
+		})),
+		sagaCompleted: Register(registerer, prometheus.NewCounter(prometheus.CounterOpts{
 			Name: "oms_saga_completed_total",
 			Help: "Total number of saga operations completed successfully",
-		}),
-		sagaFailed: registerCounter(registerer, prometheus.CounterOpts{
+		})),
+		sagaFailed: Register(registerer, prometheus.NewCounter(prometheus.CounterOpts{
 			Name: "oms_saga_failed_total",
 			Help: "Total number of saga operations failed",
-		}),
-		sagaDuration: registerHistogram(registerer, prometheus.HistogramOpts{
+		})),
+		sagaDeadline: Register(registerer, prometheus.NewCounter(prometheus.CounterOpts{
+			Name: "oms_saga_deadline_exceeded_total",
+			Help: "Total number of saga operations stopped because their context deadline expired",
+		})),
+		sagaBackordered: Register(registerer, prometheus.NewCounter(prometheus.CounterOpts{
+			Name: "oms_saga_backordered_total",
+			Help: "Total number of orders moved to backordered status due to insufficient stock",
+		})),
+		sagaDuration: Register(registerer, prometheus.NewHistogram(prometheus.HistogramOpts{
 			Name:    "oms_saga_duration_seconds",
 			Help:    "Duration of saga operations in seconds",
 			Buckets: prometheus.DefBuckets,
-		}),
-		stepDuration: registerHistogramVec(registerer, prometheus.HistogramOpts{
+		})),
+		stepDuration: Register(registerer, prometheus.NewHistogramVec(prometheus.HistogramOpts{
 			Name:    "oms_saga_step_duration_seconds",
 			Help:    "Duration of individual saga steps in seconds",
 			Buckets: []float64{0.001, 0.005, 0.01, 0.025, 0.05, 0.1, 0.25, 0.5, 1.0, 2.5, 5.0},
-		}, []string{"step"}),
-		timelineEvents: registerCounter(registerer, prometheus.CounterOpts{
+		}, []string{"step"})),
+		timelineEvents: Register(registerer, prometheus.NewCounter(prometheus.CounterOpts{
 			Name: "oms_timeline_events_total",
 			Help: "Total number of timeline events recorded",
-		}),
-		outboxEvents: registerCounter(registerer, prometheus.CounterOpts{
+		})),
+		outboxEvents: Register(registerer, prometheus.NewCounter(prometheus.CounterOpts{
 			Name: "oms_outbox_events_total",
 			Help: "Total number of outbox events published",
-		}),
-		activeSagas: registerGauge(registerer, prometheus.GaugeOpts{
+		})),
+		activeSagas: Register(registerer, prometheus.NewGauge(prometheus.GaugeOpts{
 			Name: "oms_active_sagas",
 			Help: "Number of currently active saga operations",
-		}),
+		})),
+		transitions: NewOrderTransitionMetrics(registerer),
 	}
 }
 
-func registerCounter(registerer prometheus.Registerer, opts prometheus.CounterOpts) prometheus.Counter {
-	collector := prometheus.NewCounter(opts)
-	if err := registerer.Register(collector); err != nil {
-		if alreadyRegistered, ok := err.(prometheus.AlreadyRegisteredError); ok {
-			existing, ok := alreadyRegistered.ExistingCollector.(prometheus.Counter)
-			if !ok {
-				panic(fmt.Sprintf("collector %q already registered with unexpected type", opts.Name))
-			}
-			return existing
-		}
-		panic(fmt.Sprintf("register counter %q: %v", opts.Name, err))
-	}
-	return collector
-}
-
-func registerGauge(registerer prometheus.Registerer, opts prometheus.GaugeOpts) prometheus.Gauge {
-	collector := prometheus.NewGauge(opts)
-	if err := registerer.Register(collector); err != nil {
-		if alreadyRegistered, ok := err.(prometheus.AlreadyRegisteredError); ok {
-			existing, ok := alreadyRegistered.ExistingCollector.(prometheus.Gauge)
-			if !ok {
-				panic(fmt.Sprintf("collector %q already registered with unexpected type", opts.Name))
-			}
-			return existing
-		}
-		panic(fmt.Sprintf("register gauge %q: %v", opts.Name, err))
-	}
-	return collector
-}
-
-func registerHistogram(registerer prometheus.Registerer, opts prometheus.HistogramOpts) prometheus.Histogram {
-	collector := prometheus.NewHistogram(opts)
-	if err := registerer.Register(collector); err != nil {
-		if alreadyRegistered, ok := err.(prometheus.AlreadyRegisteredError); ok {
-			existing, ok := alreadyRegistered.ExistingCollector.(prometheus.Histogram)
-			if !ok {
-				panic(fmt.Sprintf("collector %q already registered with unexpected type", opts.Name))
-			}
-			return existing
-		}
-		panic(fmt.Sprintf("register histogram %q: %v", opts.Name, err))
-	}
-	return collector
-}
-
-func registerHistogramVec(registerer prometheus.Registerer, opts prometheus.HistogramOpts, labels []string) *prometheus.HistogramVec {
-	collector := prometheus.NewHistogramVec(opts, labels)
-	if err := registerer.Register(collector); err != nil {
-		if alreadyRegistered, ok := err.(prometheus.AlreadyRegisteredError); ok {
-			existing, ok := alreadyRegistered.ExistingCollector.(*prometheus.HistogramVec)
-			if !ok {
-				panic(fmt.Sprintf("collector %q already registered with unexpected type", opts.Name))
-			}
-			return existing
-		}
-		panic(fmt.Sprintf("register histogram vec %q: %v", opts.Name, err))
-	}
-	return collector
-}
-
 // RecordSagaStarted увеличивает счётчик запущенных саг.
 func (m *SagaMetrics) RecordSagaStarted() {
 	m.sagaStarted.Inc()
@@ -170,6 +122,22 @@ func (m *SagaMetrics) RecordSagaFailed() {
 	m.sagaFailed.Inc()
 }
 
+// RecordSagaDeadlineExceeded увеличивает счётчик саг, прерванных по дедлайну.
+func (m *SagaMetrics) RecordSagaDeadlineExceeded() {
+	m.sagaDeadline.Inc()
+}
+
+// RecordSagaBackordered увеличивает счётчик заказов, ожидающих пополнения склада.
+func (m *SagaMetrics) RecordSagaBackordered() {
+	m.sagaBackordered.Inc()
+}
+
+// RecordStatusTransition учитывает переход заказа между статусами, выполненный сагой;
+// mode — metrics.OrderMode заказа.
+func (m *SagaMetrics) RecordStatusTransition(mode, from, to, result string) {
+	m.transitions.RecordMode(mode, from, to, result)
+}
+
 // RecordSagaInFlightStarted увеличивает количество активных саг.
 func (m *SagaMetrics) RecordSagaInFlightStarted() {
 	m.activeSagas.Inc()
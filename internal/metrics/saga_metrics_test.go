@@ -35,6 +35,14 @@ func TestNewSagaMetrics(t *testing.T) {
 		t.Error("sagaFailed counter should not be nil")
 	}
 
+	if metrics.sagaDeadline == nil {
+		t.Error("sagaDeadline counter should not be nil")
+	}
+
+	if metrics.sagaBackordered == nil {
+		t.Error("sagaBackordered counter should not be nil")
+	}
+
 	if metrics.sagaDuration == nil {
 		t.Error("sagaDuration histogram should not be nil")
 	}
@@ -61,12 +69,12 @@ func TestNewSagaMetrics_IsIdempotentForRegistry(t *testing.T) {
 
 	defer func() {
 		if recovered := recover(); recovered != nil {
-			t.Fatalf("newSagaMetricsWithRegisterer should not panic on duplicate registration: %v", recovered)
+			t.Fatalf("NewSagaMetricsWithRegistry should not panic on duplicate registration: %v", recovered)
 		}
 	}()
 
-	first := newSagaMetricsWithRegisterer(registry)
-	second := newSagaMetricsWithRegisterer(registry)
+	first := NewSagaMetricsWithRegistry(registry)
+	second := NewSagaMetricsWithRegistry(registry)
 
 	if first == nil || second == nil {
 		t.Fatal("expected non-nil metrics instances")
@@ -0,0 +1,151 @@
+// Package notify раздаёт in-process уведомления подписчикам по ключу (обычно ID заказа).
+// Hub используется потоковыми RPC и SSE, чтобы UI не опрашивал сервис: публикация
+// неблокирующая, отстающий подписчик отключается и должен переподписаться.
+package notify
+
+import (
+	"errors"
+	"sync"
+)
+
+const defaultBuffer = 64
+
+// AnyKey — ключ подписки на события всех ключей (например, для инвалидации кэша).
+// Публикация под самим AnyKey доходит только до его подписчиков.
+const AnyKey = "*"
+
+// ErrSubscriberLagged — подписчик не успевал вычитывать события и был отключён.
+var ErrSubscriberLagged = errors.New("notify: subscriber lagged behind")
+
+// Hub — потокобезопасный fan-out событий T по ключу.
+type Hub[T any] struct {
+	mu     sync.Mutex
+	buffer int
+	subs   map[string]map[*Subscription[T]]struct{}
+}
+
+// HubOption настраивает Hub.
+type HubOption func(*hubOptions)
+
+type hubOptions struct {
+	buffer int
+}
+
+// WithBuffer задаёт размер буфера канала каждого подписчика.
+func WithBuffer(size int) HubOption {
+	return func(opts *hubOptions) {
+		opts.buffer = size
+	}
+}
+
+// NewHub создаёт пустой Hub.
+func NewHub[T any](options ...HubOption) *Hub[T] {
+	opts := hubOptions{buffer: defaultBuffer}
+	for _, option := range options {
+		option(&opts)
+	}
+	if opts.buffer <= 0 {
+		opts.buffer = defaultBuffer
+	}
+	return &Hub[T]{
+		buffer: opts.buffer,
+		subs:   make(map[string]map[*Subscription[T]]struct{}),
+	}
+}
+
+// Subscribe подписывает на события ключа (AnyKey — всех ключей). Подписку нужно закрыть через Close.
+func (h *Hub[T]) Subscribe(key string) *Subscription[T] {
+	sub := &Subscription[T]{
+		hub: h,
+		key: key,
+		ch:  make(chan T, h.buffer),
+	}
+
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	if h.subs[key] == nil {
+		h.subs[key] = make(map[*Subscription[T]]struct{})
+	}
+	h.subs[key][sub] = struct{}{}
+	return sub
+}
+
+// Publish доставляет value всем подписчикам ключа и возвращает число получателей.
+// Подписчик с заполненным буфером отключается с ErrSubscriberLagged, публикация не ждёт.
+func (h *Hub[T]) Publish(key string, value T) int {
+	if h == nil {
+		return 0
+	}
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	delivered := h.deliverLocked(key, value)
+	if key != AnyKey {
+		delivered += h.deliverLocked(AnyKey, value)
+	}
+	return delivered
+}
+
+func (h *Hub[T]) deliverLocked(key string, value T) int {
+	delivered := 0
+	for sub := range h.subs[key] {
+		select {
+		case sub.ch <- value:
+			delivered++
+		default:
+			h.removeLocked(sub, ErrSubscriberLagged)
+		}
+	}
+	return delivered
+}
+
+// Subscribers возвращает число активных подписчиков ключа.
+func (h *Hub[T]) Subscribers(key string) int {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	return len(h.subs[key])
+}
+
+func (h *Hub[T]) removeLocked(sub *Subscription[T], err error) {
+	subs, ok := h.subs[sub.key]
+	if !ok {
+		return
+	}
+	if _, ok := subs[sub]; !ok {
+		return
+	}
+	delete(subs, sub)
+	if len(subs) == 0 {
+		delete(h.subs, sub.key)
+	}
+	sub.err = err
+	close(sub.ch)
+}
+
+// Subscription — подписка на события одного ключа.
+type Subscription[T any] struct {
+	hub *Hub[T]
+	key string
+	ch  chan T
+	err error
+}
+
+// C возвращает канал событий. Канал закрывается после Close или при отставании подписчика.
+func (s *Subscription[T]) C() <-chan T {
+	return s.ch
+}
+
+// Err возвращает причину закрытия канала: ErrSubscriberLagged или nil после Close.
+// Вызывать после того, как канал C закрыт.
+func (s *Subscription[T]) Err() error {
+	s.hub.mu.Lock()
+	defer s.hub.mu.Unlock()
+	return s.err
+}
+
+// Close отписывает подписчика; повторный вызов безопасен.
+func (s *Subscription[T]) Close() {
+	s.hub.mu.Lock()
+	defer s.hub.mu.Unlock()
+	s.hub.removeLocked(s, nil)
+}
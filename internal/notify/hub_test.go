@@ -0,0 +1,91 @@
+package notify
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestHub_PublishDeliversToKeySubscribers(t *testing.T) {
+	hub := NewHub[int]()
+	first := hub.Subscribe("order-1")
+	second := hub.Subscribe("order-1")
+	other := hub.Subscribe("order-2")
+	defer first.Close()
+	defer second.Close()
+	defer other.Close()
+
+	if delivered := hub.Publish("order-1", 42); delivered != 2 {
+		t.Fatalf("expected 2 deliveries, got %d", delivered)
+	}
+	for _, sub := range []*Subscription[int]{first, second} {
+		if got := <-sub.C(); got != 42 {
+			t.Fatalf("expected 42, got %d", got)
+		}
+	}
+	select {
+	case got := <-other.C():
+		t.Fatalf("unexpected event for other key: %d", got)
+	default:
+	}
+}
+
+func TestHub_AnyKeySubscriberReceivesEveryKey(t *testing.T) {
+	hub := NewHub[int]()
+	all := hub.Subscribe(AnyKey)
+	defer all.Close()
+
+	if delivered := hub.Publish("order-1", 1); delivered != 1 {
+		t.Fatalf("expected delivery to the wildcard subscriber, got %d", delivered)
+	}
+	hub.Publish("order-2", 2)
+	hub.Publish(AnyKey, 3)
+	for _, want := range []int{1, 2, 3} {
+		if got := <-all.C(); got != want {
+			t.Fatalf("expected %d, got %d", want, got)
+		}
+	}
+	select {
+	case got := <-all.C():
+		t.Fatalf("AnyKey publish must be delivered once, got extra %d", got)
+	default:
+	}
+}
+
+func TestHub_LaggedSubscriberIsDropped(t *testing.T) {
+	hub := NewHub[int](WithBuffer(1))
+	sub := hub.Subscribe("order-1")
+
+	hub.Publish("order-1", 1)
+	hub.Publish("order-1", 2)
+
+	if got := <-sub.C(); got != 1 {
+		t.Fatalf("expected buffered event 1, got %d", got)
+	}
+	if _, ok := <-sub.C(); ok {
+		t.Fatal("expected channel to be closed after lag")
+	}
+	if !errors.Is(sub.Err(), ErrSubscriberLagged) {
+		t.Fatalf("expected ErrSubscriberLagged, got %v", sub.Err())
+	}
+	if n := hub.Subscribers("order-1"); n != 0 {
+		t.Fatalf("expected lagged subscriber removed, got %d", n)
+	}
+	sub.Close()
+}
+
+func TestSubscription_CloseIsIdempotent(t *testing.T) {
+	hub := NewHub[string]()
+	sub := hub.Subscribe("order-1")
+	sub.Close()
+	sub.Close()
+
+	if _, ok := <-sub.C(); ok {
+		t.Fatal("expected closed channel")
+	}
+	if sub.Err() != nil {
+		t.Fatalf("expected nil error after Close, got %v", sub.Err())
+	}
+	if delivered := hub.Publish("order-1", "x"); delivered != 0 {
+		t.Fatalf("expected no deliveries after Close, got %d", delivered)
+	}
+}
@@ -0,0 +1,125 @@
+package notify
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+
+	"github.com/vladislavdragonenkov/oms/internal/domain"
+	"github.com/vladislavdragonenkov/oms/internal/timeutil"
+)
+
+const sseHeartbeatInterval = 15 * time.Second
+
+// sseTimelineEvent — JSON события timeline в SSE-потоке.
+type sseTimelineEvent struct {
+	Type       string `json:"type"`
+	Reason     string `json:"reason,omitempty"`
+	OccurredAt string `json:"occurred_at"`
+	Historical bool   `json:"historical,omitempty"`
+}
+
+// TimelineSSEHandler — SSE-мост к timeline заказа для UI:
+// GET ?order_id=...&history=true отдаёт события как `event: timeline`.
+// Отставший клиент получает `event: lagged` и должен переподключиться.
+func TimelineSSEHandler(timeline *TimelineRepository, orders domain.OrderRepository, logger *log.Entry) http.Handler {
+	if logger == nil {
+		logger = log.WithField("component", "timeline-sse")
+	}
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet {
+			w.Header().Set("Allow", "GET")
+			http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+			return
+		}
+		orderID := strings.TrimSpace(r.URL.Query().Get("order_id"))
+		if orderID == "" {
+			http.Error(w, "order_id is required", http.StatusBadRequest)
+			return
+		}
+		flusher, ok := w.(http.Flusher)
+		if !ok {
+			http.Error(w, "streaming is not supported", http.StatusInternalServerError)
+			return
+		}
+		if _, err := orders.Get(orderID); err != nil {
+			if errors.Is(err, domain.ErrOrderNotFound) {
+				http.Error(w, domain.ErrOrderNotFound.Error(), http.StatusNotFound)
+				return
+			}
+			http.Error(w, "failed to load order", http.StatusInternalServerError)
+			return
+		}
+
+		watch, err := timeline.Watch(orderID, r.URL.Query().Get("history") == "true")
+		if err != nil {
+			logger.WithError(err).WithField("order_id", orderID).Error("failed to watch timeline")
+			http.Error(w, "failed to watch timeline", http.StatusInternalServerError)
+			return
+		}
+		defer watch.Close()
+
+		w.Header().Set("Content-Type", "text/event-stream")
+		w.Header().Set("Cache-Control", "no-cache")
+		w.Header().Set("Connection", "keep-alive")
+		w.WriteHeader(http.StatusOK)
+
+		for _, event := range watch.History {
+			if err := writeSSE(w, "timeline", toSSEEvent(event, true)); err != nil {
+				return
+			}
+		}
+		flusher.Flush()
+
+		heartbeat := time.NewTicker(sseHeartbeatInterval)
+		defer heartbeat.Stop()
+		for {
+			select {
+			case <-r.Context().Done():
+				return
+			case <-heartbeat.C:
+				if _, err := fmt.Fprint(w, ": heartbeat\n\n"); err != nil {
+					return
+				}
+			case event, ok := <-watch.Events():
+				if !ok {
+					if errors.Is(watch.Err(), ErrSubscriberLagged) {
+						_ = writeSSE(w, "lagged", map[string]string{"order_id": orderID})
+						flusher.Flush()
+					}
+					return
+				}
+				if watch.Duplicate(event) {
+					continue
+				}
+				if err := writeSSE(w, "timeline", toSSEEvent(event, false)); err != nil {
+					return
+				}
+			}
+			flusher.Flush()
+		}
+	})
+}
+
+func toSSEEvent(event domain.TimelineEvent, historical bool) sseTimelineEvent {
+	return sseTimelineEvent{
+		Type:       event.Type,
+		Reason:     event.Reason,
+		OccurredAt: timeutil.Format(event.Occurred),
+		Historical: historical,
+	}
+}
+
+func writeSSE(w http.ResponseWriter, name string, payload any) error {
+	data, err := json.Marshal(payload)
+	if err != nil {
+		return err
+	}
+	_, err = fmt.Fprintf(w, "event: %s\ndata: %s\n\n", name, data)
+	return err
+}
@@ -0,0 +1,101 @@
+package notify
+
+import (
+	"fmt"
+
+	"github.com/vladislavdragonenkov/oms/internal/domain"
+	"github.com/vladislavdragonenkov/oms/internal/timeutil"
+)
+
+// TimelineRepository оборачивает domain.TimelineRepository и публикует каждое
+// успешно записанное событие в Hub под ключом ID заказа.
+// Уведомления локальны для процесса: подписчик видит события, записанные этим инстансом.
+type TimelineRepository struct {
+	domain.TimelineRepository
+	hub *Hub[domain.TimelineEvent]
+}
+
+// NewTimelineRepository создаёт декоратор timeline с публикацией в hub.
+func NewTimelineRepository(repo domain.TimelineRepository, hub *Hub[domain.TimelineEvent]) *TimelineRepository {
+	return &TimelineRepository{TimelineRepository: repo, hub: hub}
+}
+
+// Append сохраняет событие и уведомляет подписчиков заказа.
+func (r *TimelineRepository) Append(event domain.TimelineEvent) error {
+	// Нормализуем до записи, чтобы подписчики получили тот же момент, что и хранилище.
+	occurred, err := timeutil.Normalize(event.Occurred)
+	if err != nil {
+		return fmt.Errorf("append timeline event: %w", err)
+	}
+	event.Occurred = occurred
+
+	if err := r.TimelineRepository.Append(event); err != nil {
+		return err
+	}
+	r.hub.Publish(event.OrderID, event)
+	return nil
+}
+
+// Watch подписывается на новые события заказа. При withHistory в History попадают уже
+// записанные события; подписка оформляется до чтения истории, поэтому событие не теряется
+// между ними, а повтор отсекает TimelineStream.Duplicate.
+func (r *TimelineRepository) Watch(orderID string, withHistory bool) (*TimelineStream, error) {
+	stream := &TimelineStream{
+		sub:  r.hub.Subscribe(orderID),
+		seen: make(map[timelineKey]struct{}),
+	}
+	if !withHistory {
+		return stream, nil
+	}
+
+	history, err := r.TimelineRepository.List(orderID)
+	if err != nil {
+		stream.Close()
+		return nil, fmt.Errorf("list timeline events: %w", err)
+	}
+	stream.History = history
+	for _, event := range history {
+		stream.seen[keyOf(event)] = struct{}{}
+	}
+	return stream, nil
+}
+
+// TimelineStream — подписка на timeline одного заказа.
+type TimelineStream struct {
+	// History — события, записанные до подписки (если запрошены).
+	History []domain.TimelineEvent
+
+	sub  *Subscription[domain.TimelineEvent]
+	seen map[timelineKey]struct{}
+}
+
+type timelineKey struct {
+	eventType string
+	reason    string
+	occurred  int64
+}
+
+func keyOf(event domain.TimelineEvent) timelineKey {
+	return timelineKey{eventType: event.Type, reason: event.Reason, occurred: event.Occurred.UnixNano()}
+}
+
+// Events возвращает канал новых событий; он закрывается при отставании подписчика или Close.
+func (s *TimelineStream) Events() <-chan domain.TimelineEvent {
+	return s.sub.C()
+}
+
+// Duplicate сообщает, что событие уже было отдано в History.
+func (s *TimelineStream) Duplicate(event domain.TimelineEvent) bool {
+	_, ok := s.seen[keyOf(event)]
+	return ok
+}
+
+// Err возвращает причину закрытия канала Events.
+func (s *TimelineStream) Err() error {
+	return s.sub.Err()
+}
+
+// Close отписывает поток.
+func (s *TimelineStream) Close() {
+	s.sub.Close()
+}
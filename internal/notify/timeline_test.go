@@ -0,0 +1,120 @@
+package notify
+
+import (
+	"bufio"
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/vladislavdragonenkov/oms/internal/domain"
+	"github.com/vladislavdragonenkov/oms/internal/storage/memory"
+)
+
+func TestTimelineRepository_WatchHistoryAndDuplicates(t *testing.T) {
+	repo := NewTimelineRepository(memory.NewTimelineRepository(), NewHub[domain.TimelineEvent]())
+	created := domain.TimelineEvent{OrderID: "order-1", Type: "OrderCreated", Occurred: time.Now().UTC()}
+	if err := repo.Append(created); err != nil {
+		t.Fatalf("append: %v", err)
+	}
+
+	stream, err := repo.Watch("order-1", true)
+	if err != nil {
+		t.Fatalf("watch: %v", err)
+	}
+	defer stream.Close()
+	if len(stream.History) != 1 || stream.History[0].Type != "OrderCreated" {
+		t.Fatalf("unexpected history: %+v", stream.History)
+	}
+	if !stream.Duplicate(stream.History[0]) {
+		t.Fatal("history event must be reported as duplicate")
+	}
+
+	if err := repo.Append(domain.TimelineEvent{OrderID: "order-1", Type: "OrderPaid"}); err != nil {
+		t.Fatalf("append: %v", err)
+	}
+	event := <-stream.Events()
+	if event.Type != "OrderPaid" || event.Occurred.IsZero() || event.Occurred.Location() != time.UTC {
+		t.Fatalf("unexpected live event: %+v", event)
+	}
+	if stream.Duplicate(event) {
+		t.Fatal("live event must not be reported as duplicate")
+	}
+}
+
+func TestTimelineRepository_AppendRejectsFutureTimestamp(t *testing.T) {
+	hub := NewHub[domain.TimelineEvent]()
+	repo := NewTimelineRepository(memory.NewTimelineRepository(), hub)
+	sub := hub.Subscribe("order-1")
+	defer sub.Close()
+
+	err := repo.Append(domain.TimelineEvent{OrderID: "order-1", Type: "OrderPaid", Occurred: time.Now().Add(time.Hour)})
+	if err == nil {
+		t.Fatal("expected error for future timestamp")
+	}
+	select {
+	case event := <-sub.C():
+		t.Fatalf("rejected event must not be published: %+v", event)
+	default:
+	}
+}
+
+func TestTimelineSSEHandler_StreamsEvents(t *testing.T) {
+	orders := memory.NewOrderRepository()
+	if err := orders.Create(domain.Order{ID: "order-1", CustomerID: "customer-1", Status: domain.OrderStatusPending, Currency: "USD"}); err != nil {
+		t.Fatalf("create order: %v", err)
+	}
+	timeline := NewTimelineRepository(memory.NewTimelineRepository(), NewHub[domain.TimelineEvent]())
+	if err := timeline.Append(domain.TimelineEvent{OrderID: "order-1", Type: "OrderCreated"}); err != nil {
+		t.Fatalf("append: %v", err)
+	}
+
+	srv := httptest.NewServer(TimelineSSEHandler(timeline, orders, nil))
+	defer srv.Close()
+
+	missing, err := http.Get(srv.URL + "?order_id=missing")
+	if err != nil {
+		t.Fatalf("request: %v", err)
+	}
+	_ = missing.Body.Close()
+	if missing.StatusCode != http.StatusNotFound {
+		t.Fatalf("expected 404 for missing order, got %d", missing.StatusCode)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	req, _ := http.NewRequestWithContext(ctx, http.MethodGet, srv.URL+"?order_id=order-1&history=true", nil)
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		t.Fatalf("request: %v", err)
+	}
+	defer func() { _ = resp.Body.Close() }()
+	if ct := resp.Header.Get("Content-Type"); ct != "text/event-stream" {
+		t.Fatalf("unexpected content type %q", ct)
+	}
+
+	reader := bufio.NewReader(resp.Body)
+	readData := func() string {
+		for {
+			line, err := reader.ReadString('\n')
+			if err != nil {
+				t.Fatalf("read: %v", err)
+			}
+			if strings.HasPrefix(line, "data: ") {
+				return line
+			}
+		}
+	}
+
+	if data := readData(); !strings.Contains(data, `"type":"OrderCreated"`) || !strings.Contains(data, `"historical":true`) {
+		t.Fatalf("unexpected history event: %s", data)
+	}
+	if err := timeline.Append(domain.TimelineEvent{OrderID: "order-1", Type: "OrderPaid"}); err != nil {
+		t.Fatalf("append: %v", err)
+	}
+	if data := readData(); !strings.Contains(data, `"type":"OrderPaid"`) || strings.Contains(data, "historical") {
+		t.Fatalf("unexpected live event: %s", data)
+	}
+}
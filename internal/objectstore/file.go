@@ -0,0 +1,43 @@
+package objectstore
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/vladislavdragonenkov/oms/internal/fileutil"
+)
+
+// fileUploader складывает объекты в каталог; теги пишутся рядом в <key>.tags.json.
+type fileUploader struct {
+	dir string
+}
+
+func (f *fileUploader) Put(ctx context.Context, obj Object) (string, error) {
+	if err := ctx.Err(); err != nil {
+		return "", err
+	}
+	key, err := objectKey("", obj.Key)
+	if err != nil {
+		return "", err
+	}
+	target := filepath.Join(f.dir, filepath.FromSlash(key))
+	if err := os.MkdirAll(filepath.Dir(target), 0o750); err != nil {
+		return "", fmt.Errorf("%w: %v", ErrUpload, err)
+	}
+	if err := fileutil.WriteFileAtomic(target, obj.Body, fileutil.WithPerm(0o644)); err != nil {
+		return "", fmt.Errorf("%w: %v", ErrUpload, err)
+	}
+	if len(obj.Tags) > 0 {
+		tags, err := json.Marshal(obj.Tags)
+		if err != nil {
+			return "", fmt.Errorf("%w: encode tags: %v", ErrUpload, err)
+		}
+		if err := fileutil.WriteFileAtomic(target+".tags.json", tags, fileutil.WithPerm(0o644)); err != nil {
+			return "", fmt.Errorf("%w: %v", ErrUpload, err)
+		}
+	}
+	return "file://" + filepath.ToSlash(target), nil
+}
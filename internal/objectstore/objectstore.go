@@ -0,0 +1,103 @@
+// Package objectstore выгружает артефакты (JSON-отчёты, архивы) в объектное хранилище, чтобы они
+// не терялись вместе с эфемерным подом. Поставщик задаётся URL: s3:// — AWS S3 и совместимые
+// (MinIO, Ceph), gs:// — Google Cloud Storage через XML API с HMAC-ключами, file:// — каталог на
+// диске для разработки и тестов. Срок хранения передаётся тегами объекта: удаляет объекты
+// lifecycle-правило бакета, сам пакет ничего не удаляет.
+package objectstore
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/url"
+	"path"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// TagRetention — тег со сроком хранения объекта ("30d"); на него ссылается lifecycle-правило бакета.
+const TagRetention = "retention"
+
+// TagArtifact — тег с видом артефакта (loadtest-report, archive и т.п.).
+const TagArtifact = "artifact"
+
+var (
+	// ErrInvalidURL — URL хранилища не разобран или схема не поддерживается.
+	ErrInvalidURL = errors.New("objectstore: invalid url")
+	// ErrUpload — хранилище отклонило объект.
+	ErrUpload = errors.New("objectstore: upload failed")
+)
+
+// Object — выгружаемый артефакт.
+type Object struct {
+	// Key — путь внутри префикса хранилища, например "run-42/report.json".
+	Key             string
+	Body            []byte
+	ContentType     string
+	ContentEncoding string
+	// Tags — теги объекта (TagRetention, TagArtifact); у gs:// пишутся как метаданные.
+	Tags map[string]string
+}
+
+// Uploader сохраняет объекты в хранилище.
+type Uploader interface {
+	// Put сохраняет объект и возвращает его адрес (s3://bucket/key, file:///path).
+	Put(ctx context.Context, obj Object) (string, error)
+}
+
+// Open создаёт Uploader по URL:
+//
+//	s3://bucket/prefix?region=eu-west-1
+//	s3://bucket/prefix?endpoint=http://minio:9000&region=us-east-1
+//	gs://bucket/prefix
+//	file:///var/lib/oms/artifacts
+//
+// Ключи s3:// и gs:// берутся из AWS_ACCESS_KEY_ID, AWS_SECRET_ACCESS_KEY и AWS_SESSION_TOKEN,
+// если не заданы через WithCredentials.
+func Open(rawURL string, opts ...Option) (Uploader, error) {
+	u, err := url.Parse(strings.TrimSpace(rawURL))
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidURL, err)
+	}
+	settings := newSettings(opts)
+
+	switch u.Scheme {
+	case "file":
+		if u.Path == "" {
+			return nil, fmt.Errorf("%w: file url needs an absolute path", ErrInvalidURL)
+		}
+		return &fileUploader{dir: u.Path}, nil
+	case "s3", "gs":
+		if u.Host == "" {
+			return nil, fmt.Errorf("%w: bucket is required", ErrInvalidURL)
+		}
+		return newS3Uploader(u, settings)
+	default:
+		return nil, fmt.Errorf("%w: unsupported scheme %q", ErrInvalidURL, u.Scheme)
+	}
+}
+
+// RetentionTags возвращает теги артефакта с его сроком хранения. Срок округляется вверх до суток:
+// lifecycle-правила бакетов работают в днях.
+func RetentionTags(artifact string, retention time.Duration) map[string]string {
+	tags := map[string]string{TagArtifact: artifact}
+	if retention > 0 {
+		days := int((retention + 24*time.Hour - 1) / (24 * time.Hour))
+		tags[TagRetention] = strconv.Itoa(days) + "d"
+	}
+	return tags
+}
+
+// objectKey склеивает префикс хранилища и ключ объекта без ведущего слеша.
+func objectKey(prefix, key string) (string, error) {
+	key = strings.TrimLeft(key, "/")
+	if key == "" {
+		return "", fmt.Errorf("%w: object key is required", ErrUpload)
+	}
+	joined := path.Join(strings.Trim(prefix, "/"), key)
+	if joined == ".." || strings.HasPrefix(joined, "../") {
+		return "", fmt.Errorf("%w: object key %q escapes the prefix", ErrUpload, key)
+	}
+	return joined, nil
+}
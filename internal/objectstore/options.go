@@ -0,0 +1,81 @@
+package objectstore
+
+import (
+	"net/http"
+	"os"
+	"time"
+)
+
+const (
+	defaultTimeout  = 30 * time.Second
+	defaultAttempts = 3
+	defaultBackoff  = 500 * time.Millisecond
+)
+
+// Credentials — ключи доступа S3-совместимого хранилища (у GCS — HMAC-ключи сервисного аккаунта).
+type Credentials struct {
+	AccessKeyID     string
+	SecretAccessKey string
+	SessionToken    string
+}
+
+// CredentialsFromEnv читает ключи из стандартных переменных AWS_*.
+func CredentialsFromEnv() Credentials {
+	return Credentials{
+		AccessKeyID:     os.Getenv("AWS_ACCESS_KEY_ID"),
+		SecretAccessKey: os.Getenv("AWS_SECRET_ACCESS_KEY"),
+		SessionToken:    os.Getenv("AWS_SESSION_TOKEN"),
+	}
+}
+
+type settings struct {
+	client      *http.Client
+	credentials *Credentials
+	attempts    int
+	backoff     time.Duration
+}
+
+// Option настраивает Open.
+type Option func(*settings)
+
+// WithHTTPClient задаёт HTTP-клиент; по умолчанию — клиент с таймаутом 30s.
+func WithHTTPClient(client *http.Client) Option {
+	return func(s *settings) {
+		s.client = client
+	}
+}
+
+// WithCredentials задаёт ключи вместо переменных окружения.
+func WithCredentials(credentials Credentials) Option {
+	return func(s *settings) {
+		s.credentials = &credentials
+	}
+}
+
+// WithRetry задаёт число попыток на 5xx и сетевые ошибки и паузу перед первым повтором.
+func WithRetry(attempts int, backoff time.Duration) Option {
+	return func(s *settings) {
+		s.attempts = attempts
+		s.backoff = backoff
+	}
+}
+
+func newSettings(opts []Option) settings {
+	s := settings{attempts: defaultAttempts, backoff: defaultBackoff}
+	for _, opt := range opts {
+		if opt != nil {
+			opt(&s)
+		}
+	}
+	if s.client == nil {
+		s.client = &http.Client{Timeout: defaultTimeout}
+	}
+	if s.attempts <= 0 {
+		s.attempts = 1
+	}
+	if s.credentials == nil {
+		credentials := CredentialsFromEnv()
+		s.credentials = &credentials
+	}
+	return s
+}
@@ -0,0 +1,182 @@
+package objectstore
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"sort"
+	"strings"
+	"time"
+)
+
+const (
+	defaultS3Region = "us-east-1"
+	gcsEndpoint     = "https://storage.googleapis.com"
+	gcsRegion       = "auto"
+	// maxErrorBody — сколько байт ответа с ошибкой попадает в текст ошибки.
+	maxErrorBody = 512
+)
+
+// s3Uploader выгружает объекты PUT-запросом по протоколу S3. Тот же протокол понимают MinIO и
+// XML API GCS, поэтому отдельных SDK не нужно.
+type s3Uploader struct {
+	scheme   string
+	bucket   string
+	prefix   string
+	endpoint *url.URL
+	// pathStyle — адрес вида endpoint/bucket/key; иначе bucket.endpoint/key (AWS по умолчанию).
+	pathStyle bool
+	region    string
+	// tagsAsMetadata — GCS не поддерживает теги объектов S3, теги пишутся в x-amz-meta-*.
+	tagsAsMetadata bool
+
+	settings settings
+	now      func() time.Time
+}
+
+func newS3Uploader(u *url.URL, s settings) (*s3Uploader, error) {
+	query := u.Query()
+	up := &s3Uploader{
+		scheme:   u.Scheme,
+		bucket:   u.Host,
+		prefix:   strings.Trim(u.Path, "/"),
+		region:   query.Get("region"),
+		settings: s,
+		now:      time.Now,
+	}
+
+	endpoint := query.Get("endpoint")
+	switch {
+	case u.Scheme == "gs":
+		up.tagsAsMetadata = true
+		up.pathStyle = true
+		if endpoint == "" {
+			endpoint = gcsEndpoint
+		}
+		if up.region == "" {
+			up.region = gcsRegion
+		}
+	case endpoint != "":
+		// Свой endpoint — почти всегда MinIO или Ceph без wildcard-DNS для бакетов.
+		up.pathStyle = true
+	}
+	if up.region == "" {
+		up.region = defaultS3Region
+	}
+	if endpoint == "" {
+		endpoint = "https://s3." + up.region + ".amazonaws.com"
+	}
+
+	parsed, err := url.Parse(endpoint)
+	if err != nil || parsed.Host == "" || (parsed.Scheme != "http" && parsed.Scheme != "https") {
+		return nil, fmt.Errorf("%w: endpoint %q must be an http(s) url", ErrInvalidURL, endpoint)
+	}
+	up.endpoint = parsed
+	if s.credentials.AccessKeyID == "" || s.credentials.SecretAccessKey == "" {
+		return nil, fmt.Errorf("%w: credentials are required for %s:// (AWS_ACCESS_KEY_ID, AWS_SECRET_ACCESS_KEY)", ErrInvalidURL, u.Scheme)
+	}
+	return up, nil
+}
+
+func (s *s3Uploader) Put(ctx context.Context, obj Object) (string, error) {
+	key, err := objectKey(s.prefix, obj.Key)
+	if err != nil {
+		return "", err
+	}
+	location := s.scheme + "://" + s.bucket + "/" + key
+
+	var lastErr error
+	delay := s.settings.backoff
+	for attempt := 1; attempt <= s.settings.attempts; attempt++ {
+		retry, err := s.put(ctx, key, obj)
+		if err == nil {
+			return location, nil
+		}
+		lastErr = err
+		if !retry || attempt == s.settings.attempts {
+			break
+		}
+		select {
+		case <-ctx.Done():
+			return "", ctx.Err()
+		case <-time.After(delay):
+		}
+		delay *= 2
+	}
+	return "", fmt.Errorf("put %s: %w", location, lastErr)
+}
+
+// put выполняет одну попытку; retry — ошибка временная (сеть, 5xx, 429).
+func (s *s3Uploader) put(ctx context.Context, key string, obj Object) (retry bool, err error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPut, "", bytes.NewReader(obj.Body))
+	if err != nil {
+		return false, fmt.Errorf("%w: %v", ErrUpload, err)
+	}
+	req.URL = s.objectURL(key)
+	req.Host = req.URL.Host
+	req.ContentLength = int64(len(obj.Body))
+	if obj.ContentType != "" {
+		req.Header.Set("Content-Type", obj.ContentType)
+	}
+	if obj.ContentEncoding != "" {
+		req.Header.Set("Content-Encoding", obj.ContentEncoding)
+	}
+	s.setTags(req.Header, obj.Tags)
+	signV4(req, *s.settings.credentials, s.region, sha256Hex(obj.Body), s.now())
+
+	resp, err := s.settings.client.Do(req)
+	if err != nil {
+		return ctx.Err() == nil, fmt.Errorf("%w: %v", ErrUpload, err)
+	}
+	defer func() { _ = resp.Body.Close() }()
+	if resp.StatusCode >= 200 && resp.StatusCode < 300 {
+		_, _ = io.Copy(io.Discard, resp.Body)
+		return false, nil
+	}
+	body, _ := io.ReadAll(io.LimitReader(resp.Body, maxErrorBody))
+	retry = resp.StatusCode >= 500 || resp.StatusCode == http.StatusTooManyRequests
+	return retry, fmt.Errorf("%w: status %d: %s", ErrUpload, resp.StatusCode, strings.TrimSpace(string(body)))
+}
+
+func (s *s3Uploader) objectURL(key string) *url.URL {
+	u := *s.endpoint
+	segments := strings.Split(key, "/")
+	if s.pathStyle {
+		segments = append([]string{s.bucket}, segments...)
+	} else {
+		u.Host = s.bucket + "." + u.Host
+	}
+	base := strings.TrimRight(u.Path, "/")
+	escaped := make([]string, len(segments))
+	for i, segment := range segments {
+		escaped[i] = awsEscape(segment)
+	}
+	u.Path = base + "/" + strings.Join(segments, "/")
+	u.RawPath = base + "/" + strings.Join(escaped, "/")
+	return &u
+}
+
+func (s *s3Uploader) setTags(header http.Header, tags map[string]string) {
+	if len(tags) == 0 {
+		return
+	}
+	names := make([]string, 0, len(tags))
+	for name := range tags {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	if s.tagsAsMetadata {
+		for _, name := range names {
+			header.Set("X-Amz-Meta-"+name, tags[name])
+		}
+		return
+	}
+	values := url.Values{}
+	for _, name := range names {
+		values.Set(name, tags[name])
+	}
+	header.Set("X-Amz-Tagging", values.Encode())
+}
@@ -0,0 +1,337 @@
+// Package openapi строит OpenAPI v3 документ REST-фасада из google.api.http аннотаций proto.
+package openapi
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"regexp"
+	"sort"
+	"strings"
+
+	"google.golang.org/genproto/googleapis/api/annotations"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+)
+
+// Version — версия спецификации OpenAPI в документе.
+const Version = "3.0.3"
+
+// statusSchemaName — схема ошибки, которую gRPC-Gateway отдаёт вместо google.rpc.Status.
+const statusSchemaName = "google.rpc.Status"
+
+// Document — OpenAPI v3 документ; заполняется только то, что выводится из proto.
+type Document struct {
+	OpenAPI    string              `json:"openapi"`
+	Info       Info                `json:"info"`
+	Paths      map[string]PathItem `json:"paths"`
+	Components Components          `json:"components"`
+}
+
+// Info — заголовок документа.
+type Info struct {
+	Title   string `json:"title"`
+	Version string `json:"version"`
+}
+
+// PathItem — операции одного пути по HTTP-методу в нижнем регистре.
+type PathItem map[string]*Operation
+
+// Operation — HTTP-маршрут одного RPC.
+type Operation struct {
+	OperationID string              `json:"operationId"`
+	Tags        []string            `json:"tags,omitempty"`
+	Parameters  []Parameter         `json:"parameters,omitempty"`
+	RequestBody *RequestBody        `json:"requestBody,omitempty"`
+	Responses   map[string]Response `json:"responses"`
+}
+
+// Parameter — параметр пути или query string.
+type Parameter struct {
+	Name     string  `json:"name"`
+	In       string  `json:"in"`
+	Required bool    `json:"required,omitempty"`
+	Schema   *Schema `json:"schema"`
+}
+
+// RequestBody — тело запроса.
+type RequestBody struct {
+	Required bool                 `json:"required"`
+	Content  map[string]MediaType `json:"content"`
+}
+
+// Response — ответ операции.
+type Response struct {
+	Description string               `json:"description"`
+	Content     map[string]MediaType `json:"content,omitempty"`
+}
+
+// MediaType — схема содержимого конкретного Content-Type.
+type MediaType struct {
+	Schema *Schema `json:"schema"`
+}
+
+// Components — переиспользуемые схемы сообщений.
+type Components struct {
+	Schemas map[string]*Schema `json:"schemas"`
+}
+
+// Schema — подмножество JSON Schema из OpenAPI v3.
+type Schema struct {
+	Ref                  string             `json:"$ref,omitempty"`
+	Type                 string             `json:"type,omitempty"`
+	Format               string             `json:"format,omitempty"`
+	Enum                 []string           `json:"enum,omitempty"`
+	Items                *Schema            `json:"items,omitempty"`
+	Properties           map[string]*Schema `json:"properties,omitempty"`
+	AdditionalProperties *Schema            `json:"additionalProperties,omitempty"`
+}
+
+// Build собирает документ по сервисам. Методы без google.api.http в документ не попадают:
+// так AdminService остаётся только в gRPC.
+func Build(info Info, services ...protoreflect.ServiceDescriptor) (*Document, error) {
+	b := &builder{schemas: make(map[string]*Schema)}
+	b.schemas[statusSchemaName] = &Schema{
+		Type: "object",
+		Properties: map[string]*Schema{
+			"code":    {Type: "integer", Format: "int32"},
+			"message": {Type: "string"},
+			"details": {Type: "array", Items: &Schema{Type: "object"}},
+		},
+	}
+
+	doc := &Document{OpenAPI: Version, Info: info, Paths: make(map[string]PathItem)}
+	for _, service := range services {
+		methods := service.Methods()
+		for i := 0; i < methods.Len(); i++ {
+			method := methods.Get(i)
+			rule := httpRule(method)
+			if rule == nil {
+				continue
+			}
+			httpMethod, template := rulePattern(rule)
+			if httpMethod == "" {
+				return nil, fmt.Errorf("openapi: %s: unsupported http pattern", method.FullName())
+			}
+			operation, err := b.operation(service, method, rule, template)
+			if err != nil {
+				return nil, err
+			}
+			path := openAPIPath(template)
+			item := doc.Paths[path]
+			if item == nil {
+				item = make(PathItem)
+				doc.Paths[path] = item
+			}
+			key := strings.ToLower(httpMethod)
+			if _, exists := item[key]; exists {
+				return nil, fmt.Errorf("openapi: %s: duplicate route %s %s", method.FullName(), httpMethod, path)
+			}
+			item[key] = operation
+		}
+	}
+	doc.Components.Schemas = b.schemas
+	return doc, nil
+}
+
+// Handler отдаёт документ как JSON; документ сериализуется один раз.
+func Handler(doc *Document) (http.Handler, error) {
+	body, err := json.MarshalIndent(doc, "", "  ")
+	if err != nil {
+		return nil, fmt.Errorf("openapi: encode document: %w", err)
+	}
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet && r.Method != http.MethodHead {
+			w.Header().Set("Allow", "GET, HEAD")
+			http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write(body)
+	}), nil
+}
+
+type builder struct {
+	schemas map[string]*Schema
+}
+
+func (b *builder) operation(service protoreflect.ServiceDescriptor, method protoreflect.MethodDescriptor, rule *annotations.HttpRule, template string) (*Operation, error) {
+	input := method.Input()
+	operation := &Operation{
+		OperationID: string(service.Name()) + "_" + string(method.Name()),
+		Tags:        []string{string(service.Name())},
+		Responses: map[string]Response{
+			"default": {
+				Description: "Ошибка в формате google.rpc.Status.",
+				Content:     jsonContent(refSchema(statusSchemaName)),
+			},
+		},
+	}
+
+	pathFields := make(map[string]struct{})
+	for _, name := range pathParams(template) {
+		field := input.Fields().ByName(protoreflect.Name(name))
+		if field == nil {
+			return nil, fmt.Errorf("openapi: %s: path parameter %q is not a field of %s", method.FullName(), name, input.FullName())
+		}
+		pathFields[name] = struct{}{}
+		operation.Parameters = append(operation.Parameters, Parameter{
+			Name:     name,
+			In:       "path",
+			Required: true,
+			Schema:   b.fieldSchema(field),
+		})
+	}
+
+	switch body := rule.GetBody(); body {
+	case "":
+		// Без body поля запроса, кроме параметров пути, передаются в query string.
+		fields := input.Fields()
+		for i := 0; i < fields.Len(); i++ {
+			field := fields.Get(i)
+			if _, ok := pathFields[string(field.Name())]; ok || field.Kind() == protoreflect.MessageKind || field.IsMap() {
+				continue
+			}
+			operation.Parameters = append(operation.Parameters, Parameter{
+				Name:   field.JSONName(),
+				In:     "query",
+				Schema: b.fieldSchema(field),
+			})
+		}
+	case "*":
+		operation.RequestBody = &RequestBody{Required: true, Content: jsonContent(b.messageRef(input))}
+	default:
+		field := input.Fields().ByName(protoreflect.Name(body))
+		if field == nil {
+			return nil, fmt.Errorf("openapi: %s: body field %q is not a field of %s", method.FullName(), body, input.FullName())
+		}
+		operation.RequestBody = &RequestBody{Required: true, Content: jsonContent(b.fieldSchema(field))}
+	}
+
+	output := b.messageRef(method.Output())
+	if method.IsStreamingServer() {
+		// gRPC-Gateway отдаёт стрим как newline-delimited JSON с сообщениями в поле result.
+		operation.Responses["200"] = Response{
+			Description: "Поток сообщений " + string(method.Output().FullName()) + ".",
+			Content: map[string]MediaType{"application/x-ndjson": {Schema: &Schema{
+				Type:       "object",
+				Properties: map[string]*Schema{"result": output, "error": refSchema(statusSchemaName)},
+			}}},
+		}
+	} else {
+		operation.Responses["200"] = Response{Description: "OK", Content: jsonContent(output)}
+	}
+	return operation, nil
+}
+
+// messageRef регистрирует схему сообщения (рекурсивно) и возвращает ссылку на неё.
+func (b *builder) messageRef(message protoreflect.MessageDescriptor) *Schema {
+	name := string(message.FullName())
+	if _, ok := b.schemas[name]; !ok {
+		schema := &Schema{Type: "object", Properties: make(map[string]*Schema)}
+		b.schemas[name] = schema
+		fields := message.Fields()
+		for i := 0; i < fields.Len(); i++ {
+			field := fields.Get(i)
+			schema.Properties[field.JSONName()] = b.fieldSchema(field)
+		}
+	}
+	return refSchema(name)
+}
+
+func (b *builder) fieldSchema(field protoreflect.FieldDescriptor) *Schema {
+	if field.IsMap() {
+		return &Schema{Type: "object", AdditionalProperties: b.singularSchema(field.MapValue())}
+	}
+	schema := b.singularSchema(field)
+	if field.IsList() {
+		return &Schema{Type: "array", Items: schema}
+	}
+	return schema
+}
+
+// singularSchema повторяет кодирование protojson: 64-битные числа — строки, enum — имена значений.
+func (b *builder) singularSchema(field protoreflect.FieldDescriptor) *Schema {
+	switch field.Kind() {
+	case protoreflect.BoolKind:
+		return &Schema{Type: "boolean"}
+	case protoreflect.Int32Kind, protoreflect.Sint32Kind, protoreflect.Sfixed32Kind:
+		return &Schema{Type: "integer", Format: "int32"}
+	case protoreflect.Uint32Kind, protoreflect.Fixed32Kind:
+		return &Schema{Type: "integer", Format: "int64"}
+	case protoreflect.Int64Kind, protoreflect.Sint64Kind, protoreflect.Sfixed64Kind:
+		return &Schema{Type: "string", Format: "int64"}
+	case protoreflect.Uint64Kind, protoreflect.Fixed64Kind:
+		return &Schema{Type: "string", Format: "uint64"}
+	case protoreflect.FloatKind:
+		return &Schema{Type: "number", Format: "float"}
+	case protoreflect.DoubleKind:
+		return &Schema{Type: "number", Format: "double"}
+	case protoreflect.BytesKind:
+		return &Schema{Type: "string", Format: "byte"}
+	case protoreflect.EnumKind:
+		values := field.Enum().Values()
+		schema := &Schema{Type: "string", Enum: make([]string, 0, values.Len())}
+		for i := 0; i < values.Len(); i++ {
+			schema.Enum = append(schema.Enum, string(values.Get(i).Name()))
+		}
+		return schema
+	case protoreflect.MessageKind, protoreflect.GroupKind:
+		return b.messageRef(field.Message())
+	default:
+		return &Schema{Type: "string"}
+	}
+}
+
+func httpRule(method protoreflect.MethodDescriptor) *annotations.HttpRule {
+	opts := method.Options()
+	if opts == nil || !proto.HasExtension(opts, annotations.E_Http) {
+		return nil
+	}
+	rule, _ := proto.GetExtension(opts, annotations.E_Http).(*annotations.HttpRule)
+	return rule
+}
+
+func rulePattern(rule *annotations.HttpRule) (string, string) {
+	switch pattern := rule.GetPattern().(type) {
+	case *annotations.HttpRule_Get:
+		return http.MethodGet, pattern.Get
+	case *annotations.HttpRule_Post:
+		return http.MethodPost, pattern.Post
+	case *annotations.HttpRule_Put:
+		return http.MethodPut, pattern.Put
+	case *annotations.HttpRule_Delete:
+		return http.MethodDelete, pattern.Delete
+	case *annotations.HttpRule_Patch:
+		return http.MethodPatch, pattern.Patch
+	case *annotations.HttpRule_Custom:
+		return strings.ToUpper(pattern.Custom.GetKind()), pattern.Custom.GetPath()
+	default:
+		return "", ""
+	}
+}
+
+// pathParamPattern находит переменные шаблона: {order_id} или {name=segments/*}.
+var pathParamPattern = regexp.MustCompile(`\{([^}=]+)(=[^}]*)?\}`)
+
+func pathParams(template string) []string {
+	var names []string
+	for _, match := range pathParamPattern.FindAllStringSubmatch(template, -1) {
+		names = append(names, match[1])
+	}
+	sort.Strings(names)
+	return names
+}
+
+// openAPIPath убирает из переменных шаблона сегменты после "=", которых нет в OpenAPI.
+func openAPIPath(template string) string {
+	return pathParamPattern.ReplaceAllString(template, "{$1}")
+}
+
+func refSchema(name string) *Schema {
+	return &Schema{Ref: "#/components/schemas/" + name}
+}
+
+func jsonContent(schema *Schema) map[string]MediaType {
+	return map[string]MediaType{"application/json": {Schema: schema}}
+}
@@ -0,0 +1,154 @@
+package openapi
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"google.golang.org/protobuf/reflect/protoreflect"
+
+	omsv1 "github.com/vladislavdragonenkov/oms/proto/oms/v1"
+)
+
+func omsServices() []protoreflect.ServiceDescriptor {
+	services := omsv1.File_proto_oms_v1_order_service_proto.Services()
+	return []protoreflect.ServiceDescriptor{
+		services.ByName("OrderService"),
+		services.ByName("CourierService"),
+		services.ByName("AdminService"),
+	}
+}
+
+func buildTestDocument(t *testing.T) *Document {
+	t.Helper()
+	doc, err := Build(Info{Title: "OMS API", Version: "test"}, omsServices()...)
+	if err != nil {
+		t.Fatalf("build document: %v", err)
+	}
+	return doc
+}
+
+func TestBuild_CoversEveryAnnotatedRPC(t *testing.T) {
+	doc := buildTestDocument(t)
+
+	annotated := 0
+	for _, service := range omsServices() {
+		methods := service.Methods()
+		for i := 0; i < methods.Len(); i++ {
+			method := methods.Get(i)
+			rule := httpRule(method)
+			if rule == nil {
+				if service.Name() != "AdminService" {
+					t.Fatalf("%s has no google.api.http annotation", method.FullName())
+				}
+				continue
+			}
+			annotated++
+			httpMethod, template := rulePattern(rule)
+			operation := doc.Paths[openAPIPath(template)][strings.ToLower(httpMethod)]
+			if operation == nil {
+				t.Fatalf("route %s %s for %s is missing", httpMethod, template, method.FullName())
+			}
+			if want := string(service.Name()) + "_" + string(method.Name()); operation.OperationID != want {
+				t.Fatalf("expected operationId %s, got %s", want, operation.OperationID)
+			}
+		}
+	}
+
+	operations := 0
+	for _, item := range doc.Paths {
+		operations += len(item)
+	}
+	if operations != annotated {
+		t.Fatalf("expected %d operations, got %d", annotated, operations)
+	}
+}
+
+func TestBuild_ParametersAndBodies(t *testing.T) {
+	doc := buildTestDocument(t)
+
+	get := doc.Paths["/v1/orders/{order_id}"]["get"]
+	if get == nil || len(get.Parameters) != 1 || get.Parameters[0].In != "path" || get.Parameters[0].Name != "order_id" || !get.Parameters[0].Required {
+		t.Fatalf("unexpected GetOrder parameters: %+v", get)
+	}
+	if get.RequestBody != nil {
+		t.Fatal("GetOrder must not have a request body")
+	}
+
+	list := doc.Paths["/v1/orders"]["get"]
+	query := make(map[string]bool)
+	for _, param := range list.Parameters {
+		query[param.Name] = param.In == "query"
+	}
+	if !query["customerId"] || !query["pageSize"] {
+		t.Fatalf("expected ListOrders query parameters customerId and pageSize, got %+v", list.Parameters)
+	}
+
+	create := doc.Paths["/v1/orders"]["post"]
+	if create.RequestBody == nil || create.RequestBody.Content["application/json"].Schema.Ref != "#/components/schemas/oms.v1.CreateOrderRequest" {
+		t.Fatalf("unexpected CreateOrder body: %+v", create.RequestBody)
+	}
+
+	stream := doc.Paths["/v1/orders/{order_id}/timeline:stream"]["get"]
+	if _, ok := stream.Responses["200"].Content["application/x-ndjson"]; !ok {
+		t.Fatalf("expected ndjson response for timeline stream, got %+v", stream.Responses["200"])
+	}
+}
+
+func TestBuild_SchemaReferencesResolve(t *testing.T) {
+	doc := buildTestDocument(t)
+	raw, err := json.Marshal(doc)
+	if err != nil {
+		t.Fatalf("marshal: %v", err)
+	}
+
+	const prefix = `"$ref":"#/components/schemas/`
+	text := string(raw)
+	for {
+		idx := strings.Index(text, prefix)
+		if idx < 0 {
+			break
+		}
+		text = text[idx+len(prefix):]
+		name := text[:strings.IndexByte(text, '"')]
+		if _, ok := doc.Components.Schemas[name]; !ok {
+			t.Fatalf("unresolved schema reference %s", name)
+		}
+	}
+
+	order := doc.Components.Schemas["oms.v1.Order"]
+	if order == nil || order.Properties["status"] == nil || len(order.Properties["status"].Enum) == 0 {
+		t.Fatalf("expected oms.v1.Order with enum status, got %+v", order)
+	}
+}
+
+func TestHandler_ServesDocument(t *testing.T) {
+	handler, err := Handler(buildTestDocument(t))
+	if err != nil {
+		t.Fatalf("handler: %v", err)
+	}
+
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/openapi.json", nil))
+	if rec.Code != http.StatusOK || rec.Header().Get("Content-Type") != "application/json" {
+		t.Fatalf("unexpected response: %d %s", rec.Code, rec.Header().Get("Content-Type"))
+	}
+	var served struct {
+		OpenAPI string                     `json:"openapi"`
+		Paths   map[string]json.RawMessage `json:"paths"`
+	}
+	if err := json.Unmarshal(rec.Body.Bytes(), &served); err != nil {
+		t.Fatalf("decode: %v", err)
+	}
+	if served.OpenAPI != Version || len(served.Paths) == 0 {
+		t.Fatalf("unexpected document: openapi=%q paths=%d", served.OpenAPI, len(served.Paths))
+	}
+
+	rec = httptest.NewRecorder()
+	handler.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/openapi.json", nil))
+	if rec.Code != http.StatusMethodNotAllowed {
+		t.Fatalf("expected 405 for POST, got %d", rec.Code)
+	}
+}
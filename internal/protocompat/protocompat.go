@@ -0,0 +1,206 @@
+// Package protocompat проверяет обратную совместимость protobuf-схемы по снимку дескрипторов.
+// Снимок хранится в testdata рядом со сгенерированным кодом; сравнение со снимком ловит
+// изменения, после которых старые payload'ы (Kafka, сохранённые сообщения) читаются неверно.
+package protocompat
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+
+	"google.golang.org/protobuf/reflect/protoreflect"
+
+	"github.com/vladislavdragonenkov/oms/internal/fileutil"
+)
+
+// Schema — снимок сообщений и enum'ов proto-файла, ключи — полные имена.
+type Schema struct {
+	Messages map[string]Message `json:"messages"`
+	Enums    map[string]Enum    `json:"enums"`
+}
+
+// Message — поля сообщения по номеру и зарезервированные номера/имена.
+type Message struct {
+	Fields        map[string]Field `json:"fields"`
+	ReservedNums  []int32          `json:"reserved_numbers,omitempty"`
+	ReservedNames []string         `json:"reserved_names,omitempty"`
+}
+
+// Field описывает то, что определяет wire- и JSON-формат поля.
+type Field struct {
+	Name        string `json:"name"`
+	Kind        string `json:"kind"`
+	Cardinality string `json:"cardinality"`
+	// TypeName — полное имя типа для message/enum полей.
+	TypeName string `json:"type_name,omitempty"`
+}
+
+// Enum — значения enum по номеру.
+type Enum struct {
+	Values map[string]string `json:"values"`
+}
+
+// Snapshot строит снимок всех сообщений (включая вложенные) и enum'ов файла.
+func Snapshot(file protoreflect.FileDescriptor) Schema {
+	schema := Schema{Messages: map[string]Message{}, Enums: map[string]Enum{}}
+	addEnums(schema, file.Enums())
+	addMessages(schema, file.Messages())
+	return schema
+}
+
+func addMessages(schema Schema, messages protoreflect.MessageDescriptors) {
+	for i := 0; i < messages.Len(); i++ {
+		desc := messages.Get(i)
+		msg := Message{Fields: map[string]Field{}}
+		fields := desc.Fields()
+		for j := 0; j < fields.Len(); j++ {
+			field := fields.Get(j)
+			entry := Field{
+				Name:        string(field.Name()),
+				Kind:        field.Kind().String(),
+				Cardinality: field.Cardinality().String(),
+			}
+			switch {
+			case field.Message() != nil:
+				entry.TypeName = string(field.Message().FullName())
+			case field.Enum() != nil:
+				entry.TypeName = string(field.Enum().FullName())
+			}
+			msg.Fields[strconv.Itoa(int(field.Number()))] = entry
+		}
+		ranges := desc.ReservedRanges()
+		for j := 0; j < ranges.Len(); j++ {
+			r := ranges.Get(j)
+			for n := r[0]; n < r[1]; n++ {
+				msg.ReservedNums = append(msg.ReservedNums, int32(n))
+			}
+		}
+		names := desc.ReservedNames()
+		for j := 0; j < names.Len(); j++ {
+			msg.ReservedNames = append(msg.ReservedNames, string(names.Get(j)))
+		}
+		schema.Messages[string(desc.FullName())] = msg
+
+		addEnums(schema, desc.Enums())
+		addMessages(schema, desc.Messages())
+	}
+}
+
+func addEnums(schema Schema, enums protoreflect.EnumDescriptors) {
+	for i := 0; i < enums.Len(); i++ {
+		desc := enums.Get(i)
+		enum := Enum{Values: map[string]string{}}
+		values := desc.Values()
+		for j := 0; j < values.Len(); j++ {
+			value := values.Get(j)
+			enum.Values[strconv.Itoa(int(value.Number()))] = string(value.Name())
+		}
+		schema.Enums[string(desc.FullName())] = enum
+	}
+}
+
+// Breaking возвращает несовместимые изменения current относительно baseline:
+// удалённые сообщения/enum'ы/значения, удалённые без reserved поля, смену имени, типа
+// или cardinality поля. Отсортированы для стабильного вывода.
+func Breaking(baseline, current Schema) []string {
+	var violations []string
+	for name, old := range baseline.Messages {
+		msg, ok := current.Messages[name]
+		if !ok {
+			violations = append(violations, fmt.Sprintf("message %s removed", name))
+			continue
+		}
+		for number, oldField := range old.Fields {
+			field, ok := msg.Fields[number]
+			if !ok {
+				if !msg.reserves(number, oldField.Name) {
+					violations = append(violations, fmt.Sprintf("%s: field %d (%s) removed without reserving number and name", name, mustAtoi(number), oldField.Name))
+				}
+				continue
+			}
+			if field.Name != oldField.Name {
+				violations = append(violations, fmt.Sprintf("%s: field %d renamed %s -> %s (breaks JSON payloads)", name, mustAtoi(number), oldField.Name, field.Name))
+			}
+			if field.Kind != oldField.Kind || field.TypeName != oldField.TypeName {
+				violations = append(violations, fmt.Sprintf("%s: field %d (%s) type changed %s -> %s", name, mustAtoi(number), oldField.Name, oldField.describeType(), field.describeType()))
+			}
+			if field.Cardinality != oldField.Cardinality {
+				violations = append(violations, fmt.Sprintf("%s: field %d (%s) cardinality changed %s -> %s", name, mustAtoi(number), oldField.Name, oldField.Cardinality, field.Cardinality))
+			}
+		}
+	}
+	for name, old := range baseline.Enums {
+		enum, ok := current.Enums[name]
+		if !ok {
+			violations = append(violations, fmt.Sprintf("enum %s removed", name))
+			continue
+		}
+		for number, oldValue := range old.Values {
+			value, ok := enum.Values[number]
+			switch {
+			case !ok:
+				violations = append(violations, fmt.Sprintf("%s: value %d (%s) removed", name, mustAtoi(number), oldValue))
+			case value != oldValue:
+				violations = append(violations, fmt.Sprintf("%s: value %d renamed %s -> %s", name, mustAtoi(number), oldValue, value))
+			}
+		}
+	}
+	sort.Strings(violations)
+	return violations
+}
+
+func (m Message) reserves(number, name string) bool {
+	n := int32(mustAtoi(number))
+	numberReserved, nameReserved := false, false
+	for _, reserved := range m.ReservedNums {
+		if reserved == n {
+			numberReserved = true
+		}
+	}
+	for _, reserved := range m.ReservedNames {
+		if reserved == name {
+			nameReserved = true
+		}
+	}
+	return numberReserved && nameReserved
+}
+
+func (f Field) describeType() string {
+	if f.TypeName != "" {
+		return f.Kind + " " + f.TypeName
+	}
+	return f.Kind
+}
+
+func mustAtoi(value string) int {
+	n, _ := strconv.Atoi(value)
+	return n
+}
+
+// Load читает снимок из JSON-файла.
+func Load(path string) (Schema, error) {
+	data, err := os.ReadFile(path) // #nosec G304 -- путь к снимку задаётся тестом.
+	if err != nil {
+		return Schema{}, fmt.Errorf("read schema snapshot: %w", err)
+	}
+	var schema Schema
+	if err := json.Unmarshal(data, &schema); err != nil {
+		return Schema{}, fmt.Errorf("decode schema snapshot: %w", err)
+	}
+	return schema, nil
+}
+
+// Save записывает снимок в JSON-файл в стабильном порядке ключей.
+func Save(path string, schema Schema) error {
+	data, err := json.MarshalIndent(schema, "", "  ")
+	if err != nil {
+		return fmt.Errorf("encode schema snapshot: %w", err)
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0o750); err != nil {
+		return fmt.Errorf("create snapshot dir: %w", err)
+	}
+	return fileutil.WriteFileAtomic(path, append(data, '\n'))
+}
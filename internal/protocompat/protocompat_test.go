@@ -0,0 +1,87 @@
+package protocompat
+
+import (
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func baselineSchema() Schema {
+	return Schema{
+		Messages: map[string]Message{
+			"oms.v1.Order": {Fields: map[string]Field{
+				"1": {Name: "id", Kind: "string", Cardinality: "optional"},
+				"3": {Name: "status", Kind: "enum", Cardinality: "optional", TypeName: "oms.v1.OrderStatus"},
+				"5": {Name: "items", Kind: "message", Cardinality: "repeated", TypeName: "oms.v1.OrderItem"},
+			}},
+		},
+		Enums: map[string]Enum{
+			"oms.v1.OrderStatus": {Values: map[string]string{"0": "ORDER_STATUS_UNSPECIFIED", "1": "ORDER_STATUS_PENDING"}},
+		},
+	}
+}
+
+func TestBreaking_AllowsAdditions(t *testing.T) {
+	current := baselineSchema()
+	current.Messages["oms.v1.Order"].Fields["9"] = Field{Name: "note", Kind: "string", Cardinality: "optional"}
+	current.Enums["oms.v1.OrderStatus"].Values["2"] = "ORDER_STATUS_PAID"
+	current.Messages["oms.v1.Refund"] = Message{Fields: map[string]Field{}}
+
+	if violations := Breaking(baselineSchema(), current); len(violations) != 0 {
+		t.Fatalf("expected no violations, got %v", violations)
+	}
+}
+
+func TestBreaking_DetectsIncompatibleChanges(t *testing.T) {
+	current := baselineSchema()
+	fields := current.Messages["oms.v1.Order"].Fields
+	fields["1"] = Field{Name: "id", Kind: "int64", Cardinality: "optional"}
+	fields["3"] = Field{Name: "state", Kind: "enum", Cardinality: "optional", TypeName: "oms.v1.OrderStatus"}
+	fields["5"] = Field{Name: "items", Kind: "message", Cardinality: "optional", TypeName: "oms.v1.OrderItem"}
+	delete(current.Enums["oms.v1.OrderStatus"].Values, "1")
+
+	violations := Breaking(baselineSchema(), current)
+	joined := strings.Join(violations, "\n")
+	for _, want := range []string{
+		"field 1 (id) type changed string -> int64",
+		"field 3 renamed status -> state",
+		"field 5 (items) cardinality changed repeated -> optional",
+		"value 1 (ORDER_STATUS_PENDING) removed",
+	} {
+		if !strings.Contains(joined, want) {
+			t.Fatalf("expected violation %q, got:\n%s", want, joined)
+		}
+	}
+}
+
+func TestBreaking_RemovedFieldRequiresReservation(t *testing.T) {
+	current := baselineSchema()
+	order := current.Messages["oms.v1.Order"]
+	delete(order.Fields, "5")
+	current.Messages["oms.v1.Order"] = order
+
+	if violations := Breaking(baselineSchema(), current); len(violations) != 1 {
+		t.Fatalf("expected removed field violation, got %v", violations)
+	}
+
+	order.ReservedNums = []int32{5}
+	order.ReservedNames = []string{"items"}
+	current.Messages["oms.v1.Order"] = order
+	if violations := Breaking(baselineSchema(), current); len(violations) != 0 {
+		t.Fatalf("reserved field must be allowed, got %v", violations)
+	}
+}
+
+func TestSaveLoadRoundTrip(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "schema.json")
+	if err := Save(path, baselineSchema()); err != nil {
+		t.Fatalf("save: %v", err)
+	}
+	loaded, err := Load(path)
+	if err != nil {
+		t.Fatalf("load: %v", err)
+	}
+	if violations := Breaking(baselineSchema(), loaded); len(violations) != 0 {
+		t.Fatalf("round trip changed schema: %v", violations)
+	}
+}
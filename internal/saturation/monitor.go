@@ -0,0 +1,241 @@
+// Package saturation публикует сводный сигнал загрузки сервиса для HPA и внешних автоскейлеров.
+package saturation
+
+import (
+	"context"
+	"math"
+	"sync/atomic"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	dto "github.com/prometheus/client_model/go"
+	log "github.com/sirupsen/logrus"
+
+	"github.com/vladislavdragonenkov/oms/internal/metrics"
+)
+
+// Экспортируемые серии. На эти имена ссылаются правила prometheus-adapter и HPA,
+// поэтому переименование — ломающее изменение для деплоя.
+const (
+	// MetricRatio — oms_saturation_ratio: максимум из нормированных компонентов.
+	// 1 — хотя бы один компонент достиг лимита; значение может быть больше 1.
+	MetricRatio = "oms_saturation_ratio"
+	// MetricComponentRatio — oms_saturation_component_ratio{component}: загрузка отдельного компонента.
+	MetricComponentRatio = "oms_saturation_component_ratio"
+)
+
+// Значения label component у MetricComponentRatio.
+const (
+	// ComponentSagas — саги, выполняющиеся прямо сейчас (oms_active_sagas).
+	ComponentSagas = "sagas"
+	// ComponentOutbox — backlog pending-записей outbox (oms_outbox_pending_records).
+	ComponentOutbox = "outbox"
+	// ComponentInFlightRPC — незавершённые unary RPC: grpc_server_started_total - grpc_server_handled_total.
+	ComponentInFlightRPC = "inflight_rpc"
+)
+
+// DefaultInterval — как часто пересчитывается сигнал.
+const DefaultInterval = 10 * time.Second
+
+const (
+	activeSagasMetric   = "oms_active_sagas"
+	outboxPendingMetric = "oms_outbox_pending_records"
+	grpcStartedMetric   = "grpc_server_started_total"
+	grpcHandledMetric   = "grpc_server_handled_total"
+)
+
+// Limits — значения компонентов, соответствующие полной загрузке. Компонент с лимитом <= 0
+// в сигнал не входит.
+type Limits struct {
+	Sagas       float64
+	Outbox      float64
+	InFlightRPC float64
+}
+
+// MonitorOptions задаёт параметры Monitor.
+type MonitorOptions struct {
+	Logger   *log.Entry
+	Interval time.Duration
+	// Gatherer — откуда читаются исходные серии; nil — глобальный реестр Prometheus.
+	Gatherer prometheus.Gatherer
+	// Registerer — куда регистрируются серии насыщения.
+	Registerer prometheus.Registerer
+}
+
+// MonitorOption настраивает Monitor.
+type MonitorOption func(*MonitorOptions)
+
+// WithLogger задаёт logger.
+func WithLogger(logger *log.Entry) MonitorOption {
+	return func(opts *MonitorOptions) {
+		opts.Logger = logger
+	}
+}
+
+// WithInterval задаёт период пересчёта.
+func WithInterval(interval time.Duration) MonitorOption {
+	return func(opts *MonitorOptions) {
+		opts.Interval = interval
+	}
+}
+
+// WithGatherer задаёт реестр с исходными сериями.
+func WithGatherer(gatherer prometheus.Gatherer) MonitorOption {
+	return func(opts *MonitorOptions) {
+		opts.Gatherer = gatherer
+	}
+}
+
+// WithRegisterer задаёт реестр для серий насыщения.
+func WithRegisterer(registerer prometheus.Registerer) MonitorOption {
+	return func(opts *MonitorOptions) {
+		opts.Registerer = registerer
+	}
+}
+
+// Monitor периодически читает уже экспортируемые метрики сервиса и сводит их в
+// oms_saturation_ratio. Сигнал растёт раньше, чем срабатывают health-check outbox и
+// сброс нагрузки, поэтому по нему можно масштабироваться заранее.
+type Monitor struct {
+	limits    Limits
+	logger    *log.Entry
+	interval  time.Duration
+	gatherer  prometheus.Gatherer
+	ratio     prometheus.Gauge
+	component *prometheus.GaugeVec
+	last      atomic.Uint64
+}
+
+// NewMonitor создаёт монитор насыщения.
+func NewMonitor(limits Limits, options ...MonitorOption) *Monitor {
+	opts := MonitorOptions{Interval: DefaultInterval}
+	for _, option := range options {
+		option(&opts)
+	}
+
+	logger := opts.Logger
+	if logger == nil {
+		logger = log.WithField("component", "saturation-monitor")
+	}
+	if opts.Interval <= 0 {
+		opts.Interval = DefaultInterval
+	}
+	gatherer := opts.Gatherer
+	if gatherer == nil {
+		gatherer = prometheus.DefaultGatherer
+	}
+
+	return &Monitor{
+		limits:   limits,
+		logger:   logger,
+		interval: opts.Interval,
+		gatherer: gatherer,
+		ratio: metrics.Register(opts.Registerer, prometheus.NewGauge(prometheus.GaugeOpts{
+			Name: MetricRatio,
+			Help: "Service saturation for autoscaling: the highest component load normalized by its limit (1 = at limit).",
+		})),
+		component: metrics.Register(opts.Registerer, prometheus.NewGaugeVec(prometheus.GaugeOpts{
+			Name: MetricComponentRatio,
+			Help: "Saturation of a single component normalized by its limit.",
+		}, []string{"component"})),
+	}
+}
+
+// Run пересчитывает сигнал до отмены ctx.
+func (m *Monitor) Run(ctx context.Context) {
+	m.collect()
+
+	ticker := time.NewTicker(m.interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			m.collect()
+		}
+	}
+}
+
+func (m *Monitor) collect() {
+	families, err := m.gatherer.Gather()
+	if err != nil {
+		m.logger.WithError(err).Warn("gather metrics for saturation")
+	}
+	m.observe(families)
+}
+
+// observe обновляет серии по снимку метрик и возвращает сводное значение.
+func (m *Monitor) observe(families []*dto.MetricFamily) float64 {
+	components := []struct {
+		name  string
+		value float64
+		limit float64
+	}{
+		{ComponentSagas, gaugeSum(families, activeSagasMetric), m.limits.Sagas},
+		{ComponentOutbox, gaugeSum(families, outboxPendingMetric), m.limits.Outbox},
+		{ComponentInFlightRPC, inFlightUnary(families), m.limits.InFlightRPC},
+	}
+
+	overall := 0.0
+	for _, c := range components {
+		if c.limit <= 0 {
+			continue
+		}
+		ratio := max(c.value, 0) / c.limit
+		m.component.WithLabelValues(c.name).Set(ratio)
+		overall = max(overall, ratio)
+	}
+	m.ratio.Set(overall)
+	m.last.Store(math.Float64bits(overall))
+	return overall
+}
+
+// Ratio возвращает последнее вычисленное значение oms_saturation_ratio (0 до первого пересчёта).
+func (m *Monitor) Ratio() float64 {
+	return math.Float64frombits(m.last.Load())
+}
+
+func gaugeSum(families []*dto.MetricFamily, name string) float64 {
+	var sum float64
+	for _, metric := range findFamily(families, name) {
+		sum += metric.GetGauge().GetValue()
+	}
+	return sum
+}
+
+// inFlightUnary — число начатых, но ещё не завершённых unary RPC. Стримы исключены:
+// подписка на timeline живёт долго и не отражает нагрузку.
+func inFlightUnary(families []*dto.MetricFamily) float64 {
+	var started, handled float64
+	for _, metric := range findFamily(families, grpcStartedMetric) {
+		if isUnary(metric) {
+			started += metric.GetCounter().GetValue()
+		}
+	}
+	for _, metric := range findFamily(families, grpcHandledMetric) {
+		if isUnary(metric) {
+			handled += metric.GetCounter().GetValue()
+		}
+	}
+	return started - handled
+}
+
+func isUnary(metric *dto.Metric) bool {
+	for _, pair := range metric.GetLabel() {
+		if pair.GetName() == "grpc_type" {
+			return pair.GetValue() == "unary"
+		}
+	}
+	return false
+}
+
+func findFamily(families []*dto.MetricFamily, name string) []*dto.Metric {
+	for _, family := range families {
+		if family.GetName() == name {
+			return family.GetMetric()
+		}
+	}
+	return nil
+}
@@ -0,0 +1,47 @@
+package saturation
+
+import (
+	"math"
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+)
+
+func TestMonitor_ObserveTakesMostSaturatedComponent(t *testing.T) {
+	source := prometheus.NewRegistry()
+	sagas := prometheus.NewGauge(prometheus.GaugeOpts{Name: activeSagasMetric, Help: "test"})
+	outbox := prometheus.NewGauge(prometheus.GaugeOpts{Name: outboxPendingMetric, Help: "test"})
+	started := prometheus.NewCounterVec(prometheus.CounterOpts{Name: grpcStartedMetric, Help: "test"}, []string{"grpc_type", "grpc_method"})
+	handled := prometheus.NewCounterVec(prometheus.CounterOpts{Name: grpcHandledMetric, Help: "test"}, []string{"grpc_type", "grpc_method", "grpc_code"})
+	source.MustRegister(sagas, outbox, started, handled)
+
+	sagas.Set(50)
+	outbox.Set(9000)
+	started.WithLabelValues("unary", "CreateOrder").Add(30)
+	handled.WithLabelValues("unary", "CreateOrder", "OK").Add(20)
+	started.WithLabelValues("server_stream", "StreamOrderTimeline").Add(500)
+
+	target := prometheus.NewRegistry()
+	monitor := NewMonitor(Limits{Sagas: 100, Outbox: 10000, InFlightRPC: 40}, WithGatherer(source), WithRegisterer(target))
+	monitor.collect()
+
+	if got := testutil.ToFloat64(monitor.ratio); math.Abs(got-0.9) > 1e-9 {
+		t.Fatalf("expected outbox to dominate with 0.9, got %v", got)
+	}
+	for component, want := range map[string]float64{ComponentSagas: 0.5, ComponentOutbox: 0.9, ComponentInFlightRPC: 0.25} {
+		if got := testutil.ToFloat64(monitor.component.WithLabelValues(component)); math.Abs(got-want) > 1e-9 {
+			t.Fatalf("component %s: expected %v, got %v", component, want, got)
+		}
+	}
+
+	// Компонент без лимита не учитывается, перегрузка не обрезается до 1.
+	disabled := NewMonitor(Limits{InFlightRPC: 5}, WithGatherer(source), WithRegisterer(prometheus.NewRegistry()))
+	families, err := source.Gather()
+	if err != nil {
+		t.Fatalf("gather: %v", err)
+	}
+	if got := disabled.observe(families); got != 2 {
+		t.Fatalf("expected in-flight saturation 2, got %v", got)
+	}
+}
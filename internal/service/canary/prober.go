@@ -0,0 +1,344 @@
+package canary
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/prometheus/client_golang/prometheus"
+	log "github.com/sirupsen/logrus"
+	"google.golang.org/grpc/metadata"
+
+	"github.com/vladislavdragonenkov/oms/internal/domain"
+	"github.com/vladislavdragonenkov/oms/internal/metrics"
+	omsv1 "github.com/vladislavdragonenkov/oms/proto/oms/v1"
+)
+
+const (
+	// DefaultCustomerID помечает синтетические заказы, чтобы их можно было отфильтровать в аналитике и событиях.
+	DefaultCustomerID = "oms-canary-synthetic"
+
+	defaultProbeInterval = time.Minute
+	defaultProbeTimeout  = 30 * time.Second
+	defaultPollInterval  = 100 * time.Millisecond
+	canaryCurrency       = "RUB"
+	canarySKU            = "CANARY-SKU"
+	canaryCancelReason   = "canary probe cleanup"
+	// syntheticSource — значение domain.EventHeaderSynthetic у событий проверки.
+	syntheticSource = "canary"
+)
+
+// proberMetrics — метрики canary-проверок.
+type proberMetrics struct {
+	runsTotal   *prometheus.CounterVec
+	duration    prometheus.Histogram
+	up          prometheus.Gauge
+	lastSuccess prometheus.Gauge
+}
+
+func newProberMetrics(registerer prometheus.Registerer) proberMetrics {
+	return proberMetrics{
+		runsTotal: metrics.Register(registerer, prometheus.NewCounterVec(prometheus.CounterOpts{
+			Name: "oms_canary_runs_total",
+			Help: "Total number of synthetic canary probes grouped by result.",
+		}, []string{"result"})),
+		duration: metrics.Register(registerer, prometheus.NewHistogram(prometheus.HistogramOpts{
+			Name:    "oms_canary_duration_seconds",
+			Help:    "End-to-end duration of a successful canary probe (create, pay, cancel).",
+			Buckets: prometheus.DefBuckets,
+		})),
+		up: metrics.Register(registerer, prometheus.NewGauge(prometheus.GaugeOpts{
+			Name: "oms_canary_up",
+			Help: "Result of the last canary probe (1 - success, 0 - failure).",
+		})),
+		lastSuccess: metrics.Register(registerer, prometheus.NewGauge(prometheus.GaugeOpts{
+			Name: "oms_canary_last_success_timestamp_seconds",
+			Help: "Unix timestamp of the last successful canary probe.",
+		})),
+	}
+}
+
+// SyntheticOrder — следы одной проверки в хранилище.
+type SyntheticOrder struct {
+	OrderID string
+	// IdempotencyScopes — области idempotency-ключей мутирующих запросов проверки.
+	IdempotencyScopes []domain.IdempotencyScope
+}
+
+// CleanupFunc удаляет данные синтетического заказа после проверки: сам заказ, timeline,
+// outbox-сообщения и idempotency-записи.
+type CleanupFunc func(order SyntheticOrder) error
+
+// ProberOptions задает параметры canary-проверки.
+type ProberOptions struct {
+	Logger       *log.Entry
+	Interval     time.Duration
+	Timeout      time.Duration
+	PollInterval time.Duration
+	CustomerID   string
+	Cleanup      CleanupFunc
+	Registerer   prometheus.Registerer
+}
+
+// ProberOption настраивает Prober.
+type ProberOption func(*ProberOptions)
+
+// WithLogger задает logger для canary.
+func WithLogger(logger *log.Entry) ProberOption {
+	return func(opts *ProberOptions) {
+		opts.Logger = logger
+	}
+}
+
+// WithInterval задает интервал между проверками.
+func WithInterval(interval time.Duration) ProberOption {
+	return func(opts *ProberOptions) {
+		opts.Interval = interval
+	}
+}
+
+// WithTimeout ограничивает длительность одной проверки.
+func WithTimeout(timeout time.Duration) ProberOption {
+	return func(opts *ProberOptions) {
+		opts.Timeout = timeout
+	}
+}
+
+// WithPollInterval задает частоту опроса статуса заказа.
+func WithPollInterval(interval time.Duration) ProberOption {
+	return func(opts *ProberOptions) {
+		opts.PollInterval = interval
+	}
+}
+
+// WithCustomerID переопределяет customer_id синтетических заказов.
+func WithCustomerID(customerID string) ProberOption {
+	return func(opts *ProberOptions) {
+		opts.CustomerID = customerID
+	}
+}
+
+// WithCleanup задает функцию удаления синтетического заказа из хранилища.
+func WithCleanup(cleanup CleanupFunc) ProberOption {
+	return func(opts *ProberOptions) {
+		opts.Cleanup = cleanup
+	}
+}
+
+// WithRegisterer задает реестр метрик canary.
+func WithRegisterer(registerer prometheus.Registerer) ProberOption {
+	return func(opts *ProberOptions) {
+		opts.Registerer = registerer
+	}
+}
+
+// Prober периодически прогоняет синтетический заказ через собственный gRPC endpoint.
+type Prober struct {
+	client       omsv1.OrderServiceClient
+	logger       *log.Entry
+	interval     time.Duration
+	timeout      time.Duration
+	pollInterval time.Duration
+	customerID   string
+	cleanup      CleanupFunc
+	metrics      proberMetrics
+}
+
+// NewProber создает canary-проверку поверх gRPC клиента.
+func NewProber(client omsv1.OrderServiceClient, options ...ProberOption) *Prober {
+	opts := ProberOptions{
+		Interval:     defaultProbeInterval,
+		Timeout:      defaultProbeTimeout,
+		PollInterval: defaultPollInterval,
+		CustomerID:   DefaultCustomerID,
+	}
+	for _, option := range options {
+		option(&opts)
+	}
+
+	logger := opts.Logger
+	if logger == nil {
+		logger = log.WithField("component", "canary")
+	}
+
+	if opts.Interval <= 0 {
+		opts.Interval = defaultProbeInterval
+	}
+	if opts.Timeout <= 0 {
+		opts.Timeout = defaultProbeTimeout
+	}
+	if opts.PollInterval <= 0 {
+		opts.PollInterval = defaultPollInterval
+	}
+	if opts.CustomerID == "" {
+		opts.CustomerID = DefaultCustomerID
+	}
+
+	return &Prober{
+		client:       client,
+		logger:       logger,
+		interval:     opts.Interval,
+		timeout:      opts.Timeout,
+		pollInterval: opts.PollInterval,
+		customerID:   opts.CustomerID,
+		cleanup:      opts.Cleanup,
+		metrics:      newProberMetrics(opts.Registerer),
+	}
+}
+
+// Run запускает периодические проверки до отмены ctx.
+func (p *Prober) Run(ctx context.Context) {
+	if p.client == nil {
+		p.logger.Warn("canary prober is disabled: client is nil")
+		return
+	}
+
+	ticker := time.NewTicker(p.interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			p.runOnce(ctx)
+		}
+	}
+}
+
+func (p *Prober) runOnce(ctx context.Context) {
+	start := time.Now()
+	orderID, err := p.Probe(ctx)
+	if err != nil {
+		if ctx.Err() != nil {
+			return
+		}
+		p.metrics.runsTotal.WithLabelValues("error").Inc()
+		p.metrics.up.Set(0)
+		p.logger.WithError(err).WithField("order_id", orderID).Warn("canary probe failed")
+		return
+	}
+
+	p.metrics.runsTotal.WithLabelValues("ok").Inc()
+	p.metrics.up.Set(1)
+	p.metrics.duration.Observe(time.Since(start).Seconds())
+	p.metrics.lastSuccess.Set(float64(time.Now().Unix()))
+	p.logger.WithField("order_id", orderID).Debug("canary probe succeeded")
+}
+
+// Probe выполняет одну проверку: create → pay → confirmed → cancel → canceled → cleanup.
+// Возвращает ID синтетического заказа (если он успел создаться) и ошибку проверки.
+func (p *Prober) Probe(ctx context.Context) (string, error) {
+	probeCtx, cancel := context.WithTimeout(ctx, p.timeout)
+	defer cancel()
+
+	runID := uuid.NewString()
+
+	createResp, err := p.client.CreateOrder(withProbeMetadata(probeCtx, runID, "create"), &omsv1.CreateOrderRequest{
+		CustomerId: p.customerID,
+		Currency:   canaryCurrency,
+		Items: []*omsv1.OrderItem{{
+			Sku:   canarySKU,
+			Qty:   1,
+			Price: &omsv1.Money{Currency: canaryCurrency, AmountMinor: 100},
+		}},
+	})
+	if err != nil {
+		return "", fmt.Errorf("create order: %w", err)
+	}
+	orderID := createResp.GetOrder().GetId()
+	if orderID == "" {
+		return "", errors.New("create order: empty order id in response")
+	}
+
+	probeErr := p.payAndCancel(probeCtx, runID, orderID)
+
+	if p.cleanup != nil {
+		if err := p.cleanup(p.syntheticOrder(runID, orderID)); err != nil {
+			cleanupErr := fmt.Errorf("cleanup order: %w", err)
+			if probeErr == nil {
+				return orderID, cleanupErr
+			}
+			p.logger.WithError(cleanupErr).WithField("order_id", orderID).Warn("canary cleanup failed")
+		}
+	}
+
+	return orderID, probeErr
+}
+
+func (p *Prober) payAndCancel(ctx context.Context, runID, orderID string) error {
+	if _, err := p.client.PayOrder(withProbeMetadata(ctx, runID, "pay"), &omsv1.PayOrderRequest{OrderId: orderID}); err != nil {
+		return fmt.Errorf("pay order: %w", err)
+	}
+	if err := p.waitForStatus(ctx, orderID, omsv1.OrderStatus_ORDER_STATUS_CONFIRMED); err != nil {
+		return err
+	}
+
+	if _, err := p.client.CancelOrder(withProbeMetadata(ctx, runID, "cancel"), &omsv1.CancelOrderRequest{
+		OrderId: orderID,
+		Reason:  canaryCancelReason,
+	}); err != nil {
+		return fmt.Errorf("cancel order: %w", err)
+	}
+	return p.waitForStatus(ctx, orderID, omsv1.OrderStatus_ORDER_STATUS_CANCELED)
+}
+
+// waitForStatus опрашивает GetOrder, пока заказ не перейдёт в ожидаемый статус.
+func (p *Prober) waitForStatus(ctx context.Context, orderID string, want omsv1.OrderStatus) error {
+	ticker := time.NewTicker(p.pollInterval)
+	defer ticker.Stop()
+
+	for {
+		resp, err := p.client.GetOrder(ctx, &omsv1.GetOrderRequest{OrderId: orderID})
+		if err != nil {
+			return fmt.Errorf("get order: %w", err)
+		}
+		last := resp.GetOrder().GetStatus()
+		if last == want {
+			return nil
+		}
+		if want != omsv1.OrderStatus_ORDER_STATUS_CANCELED && last == omsv1.OrderStatus_ORDER_STATUS_CANCELED {
+			return fmt.Errorf("order canceled while waiting for %s", want)
+		}
+
+		select {
+		case <-ctx.Done():
+			return fmt.Errorf("wait for %s (last status %s): %w", want, last, ctx.Err())
+		case <-ticker.C:
+		}
+	}
+}
+
+// syntheticOrder перечисляет idempotency-ключи всех шагов проверки: клиент у них один — клиент
+// заказа. Ключи шагов, до которых проверка не дошла, удаляются без ошибки.
+func (p *Prober) syntheticOrder(runID, orderID string) SyntheticOrder {
+	steps := []struct{ method, step string }{
+		{omsv1.OrderService_CreateOrder_FullMethodName, "create"},
+		{omsv1.OrderService_PayOrder_FullMethodName, "pay"},
+		{omsv1.OrderService_CancelOrder_FullMethodName, "cancel"},
+	}
+	order := SyntheticOrder{OrderID: orderID, IdempotencyScopes: make([]domain.IdempotencyScope, 0, len(steps))}
+	for _, step := range steps {
+		order.IdempotencyScopes = append(order.IdempotencyScopes, domain.IdempotencyScope{
+			Method:     step.method,
+			CustomerID: p.customerID,
+			Key:        idempotencyKey(runID, step.step),
+		})
+	}
+	return order
+}
+
+// withProbeMetadata задаёт idempotency-key шага и помечает запрос синтетическим: события
+// заказа в outbox получают header domain.EventHeaderSynthetic.
+func withProbeMetadata(ctx context.Context, runID, step string) context.Context {
+	return metadata.AppendToOutgoingContext(ctx,
+		"idempotency-key", idempotencyKey(runID, step),
+		domain.EventHeaderSynthetic, syntheticSource,
+	)
+}
+
+func idempotencyKey(runID, step string) string {
+	return "canary-" + runID + "-" + step
+}
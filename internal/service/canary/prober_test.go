@@ -0,0 +1,147 @@
+package canary
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+
+	"github.com/vladislavdragonenkov/oms/internal/domain"
+	omsv1 "github.com/vladislavdragonenkov/oms/proto/oms/v1"
+)
+
+// stubOrderClient эмулирует сервис: pay сразу подтверждает заказ, cancel отменяет.
+type stubOrderClient struct {
+	omsv1.OrderServiceClient
+
+	mu              sync.Mutex
+	status          omsv1.OrderStatus
+	customerID      string
+	idempotencyKeys []string
+	synthetic       []string
+	payErr          error
+}
+
+func (s *stubOrderClient) recordKey(ctx context.Context) {
+	md, _ := metadata.FromOutgoingContext(ctx)
+	s.idempotencyKeys = append(s.idempotencyKeys, md.Get("idempotency-key")...)
+	s.synthetic = append(s.synthetic, md.Get(domain.EventHeaderSynthetic)...)
+}
+
+func (s *stubOrderClient) CreateOrder(ctx context.Context, in *omsv1.CreateOrderRequest, _ ...grpc.CallOption) (*omsv1.CreateOrderResponse, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.recordKey(ctx)
+	s.customerID = in.CustomerId
+	s.status = omsv1.OrderStatus_ORDER_STATUS_PENDING
+	return &omsv1.CreateOrderResponse{Order: &omsv1.Order{Id: "canary-order", Status: s.status}}, nil
+}
+
+func (s *stubOrderClient) PayOrder(ctx context.Context, in *omsv1.PayOrderRequest, _ ...grpc.CallOption) (*omsv1.PayOrderResponse, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.recordKey(ctx)
+	if s.payErr != nil {
+		return nil, s.payErr
+	}
+	s.status = omsv1.OrderStatus_ORDER_STATUS_CONFIRMED
+	return &omsv1.PayOrderResponse{OrderId: in.OrderId, Status: omsv1.OrderStatus_ORDER_STATUS_PENDING}, nil
+}
+
+func (s *stubOrderClient) CancelOrder(ctx context.Context, in *omsv1.CancelOrderRequest, _ ...grpc.CallOption) (*omsv1.CancelOrderResponse, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.recordKey(ctx)
+	s.status = omsv1.OrderStatus_ORDER_STATUS_CANCELED
+	return &omsv1.CancelOrderResponse{OrderId: in.OrderId, Status: s.status}, nil
+}
+
+func (s *stubOrderClient) GetOrder(_ context.Context, in *omsv1.GetOrderRequest, _ ...grpc.CallOption) (*omsv1.GetOrderResponse, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return &omsv1.GetOrderResponse{Order: &omsv1.Order{Id: in.OrderId, Status: s.status}}, nil
+}
+
+func TestProber_Probe_SuccessCleansUp(t *testing.T) {
+	t.Parallel()
+
+	client := &stubOrderClient{}
+	var cleaned []SyntheticOrder
+	prober := NewProber(client,
+		WithPollInterval(time.Millisecond),
+		WithCleanup(func(order SyntheticOrder) error {
+			cleaned = append(cleaned, order)
+			return nil
+		}),
+	)
+
+	orderID, err := prober.Probe(context.Background())
+	if err != nil {
+		t.Fatalf("Probe failed: %v", err)
+	}
+	if orderID != "canary-order" {
+		t.Fatalf("unexpected order id: %s", orderID)
+	}
+	if client.customerID != DefaultCustomerID {
+		t.Fatalf("expected canary customer id, got %q", client.customerID)
+	}
+	if len(client.idempotencyKeys) != 3 {
+		t.Fatalf("expected idempotency key per mutating call, got %v", client.idempotencyKeys)
+	}
+	if len(client.synthetic) != 3 || client.synthetic[0] != "canary" {
+		t.Fatalf("expected every mutating call marked synthetic, got %v", client.synthetic)
+	}
+	if len(cleaned) != 1 || cleaned[0].OrderID != orderID || len(cleaned[0].IdempotencyScopes) != 3 {
+		t.Fatalf("expected cleanup of %s with its idempotency keys, got %+v", orderID, cleaned)
+	}
+	for i, scope := range cleaned[0].IdempotencyScopes {
+		if scope.Key != client.idempotencyKeys[i] || scope.CustomerID != DefaultCustomerID {
+			t.Fatalf("cleanup scope %d does not match request key %q: %+v", i, client.idempotencyKeys[i], scope)
+		}
+	}
+	if cleaned[0].IdempotencyScopes[1].Method != omsv1.OrderService_PayOrder_FullMethodName {
+		t.Fatalf("unexpected pay scope: %+v", cleaned[0].IdempotencyScopes[1])
+	}
+}
+
+func TestProber_Probe_FailureStillCleansUp(t *testing.T) {
+	t.Parallel()
+
+	client := &stubOrderClient{payErr: errors.New("unavailable")}
+	cleanupCalls := 0
+	prober := NewProber(client, WithCleanup(func(SyntheticOrder) error {
+		cleanupCalls++
+		return nil
+	}))
+
+	if _, err := prober.Probe(context.Background()); err == nil {
+		t.Fatal("expected probe error")
+	}
+	if cleanupCalls != 1 {
+		t.Fatalf("expected cleanup after failed probe, got %d calls", cleanupCalls)
+	}
+}
+
+func TestProber_Probe_TimeoutWaitingForStatus(t *testing.T) {
+	t.Parallel()
+
+	// PayOrder не двигает статус: заказ остаётся pending и не доходит до confirmed.
+	client := &stuckOrderClient{stubOrderClient: &stubOrderClient{}}
+	prober := NewProber(client, WithTimeout(20*time.Millisecond), WithPollInterval(time.Millisecond))
+
+	if _, err := prober.Probe(context.Background()); !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("expected deadline exceeded, got %v", err)
+	}
+}
+
+type stuckOrderClient struct {
+	*stubOrderClient
+}
+
+func (s *stuckOrderClient) PayOrder(_ context.Context, in *omsv1.PayOrderRequest, _ ...grpc.CallOption) (*omsv1.PayOrderResponse, error) {
+	return &omsv1.PayOrderResponse{OrderId: in.OrderId, Status: omsv1.OrderStatus_ORDER_STATUS_PENDING}, nil
+}
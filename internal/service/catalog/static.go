@@ -0,0 +1,68 @@
+// Package catalog содержит адаптеры domain.CatalogService.
+package catalog
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// ErrInvalidPrices — прайс-лист задан некорректно.
+var ErrInvalidPrices = errors.New("catalog: invalid prices")
+
+// StaticCatalog — каталог с фиксированным прайс-листом из конфигурации. Используется, пока
+// сервис каталога не подключён: цены правятся через OMS_CATALOG_PRICES и рестарт.
+type StaticCatalog struct {
+	prices map[priceKey]int64
+}
+
+type priceKey struct {
+	sku      string
+	currency string
+}
+
+// ParseStaticCatalog разбирает прайс-лист в формате "SKU-1:RUB=129900,SKU-2:USD=1500":
+// SKU, валюта и цена за единицу в минимальных единицах.
+func ParseStaticCatalog(raw string) (*StaticCatalog, error) {
+	catalog := &StaticCatalog{prices: make(map[priceKey]int64)}
+	for _, entry := range strings.Split(raw, ",") {
+		entry = strings.TrimSpace(entry)
+		if entry == "" {
+			continue
+		}
+		item, value, found := strings.Cut(entry, "=")
+		sku, currency, hasCurrency := strings.Cut(strings.TrimSpace(item), ":")
+		sku, currency = strings.TrimSpace(sku), strings.ToUpper(strings.TrimSpace(currency))
+		if !found || !hasCurrency || sku == "" || currency == "" {
+			return nil, fmt.Errorf("%w: expected sku:currency=price, got %q", ErrInvalidPrices, entry)
+		}
+		price, err := strconv.ParseInt(strings.TrimSpace(value), 10, 64)
+		if err != nil || price < 0 {
+			return nil, fmt.Errorf("%w: %s: price must be a non-negative integer, got %q", ErrInvalidPrices, item, value)
+		}
+		key := priceKey{sku: sku, currency: currency}
+		if _, ok := catalog.prices[key]; ok {
+			return nil, fmt.Errorf("%w: duplicate price for %s:%s", ErrInvalidPrices, sku, currency)
+		}
+		catalog.prices[key] = price
+	}
+	return catalog, nil
+}
+
+// Len возвращает число цен в прайс-листе.
+func (c *StaticCatalog) Len() int {
+	return len(c.prices)
+}
+
+// Prices возвращает известные цены SKU в валюте currency.
+func (c *StaticCatalog) Prices(currency string, skus []string) (map[string]int64, error) {
+	result := make(map[string]int64, len(skus))
+	currency = strings.ToUpper(currency)
+	for _, sku := range skus {
+		if price, ok := c.prices[priceKey{sku: sku, currency: currency}]; ok {
+			result[sku] = price
+		}
+	}
+	return result, nil
+}
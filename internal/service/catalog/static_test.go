@@ -0,0 +1,32 @@
+package catalog
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestParseStaticCatalog(t *testing.T) {
+	catalog, err := ParseStaticCatalog(" SKU-1:rub=129900, SKU-1:USD=1500,SKU-2:RUB=0 ")
+	if err != nil {
+		t.Fatalf("ParseStaticCatalog failed: %v", err)
+	}
+	if catalog.Len() != 3 {
+		t.Fatalf("expected 3 prices, got %d", catalog.Len())
+	}
+
+	prices, err := catalog.Prices("RUB", []string{"SKU-1", "SKU-2", "SKU-3"})
+	if err != nil {
+		t.Fatalf("Prices failed: %v", err)
+	}
+	if len(prices) != 2 || prices["SKU-1"] != 129900 || prices["SKU-2"] != 0 {
+		t.Fatalf("unexpected prices: %v", prices)
+	}
+}
+
+func TestParseStaticCatalog_Invalid(t *testing.T) {
+	for _, raw := range []string{"SKU-1=100", "SKU-1:RUB", ":RUB=1", "SKU-1:RUB=-1", "SKU-1:RUB=1.5", "SKU-1:RUB=1,SKU-1:rub=2"} {
+		if _, err := ParseStaticCatalog(raw); !errors.Is(err, ErrInvalidPrices) {
+			t.Fatalf("%q: expected ErrInvalidPrices, got %v", raw, err)
+		}
+	}
+}
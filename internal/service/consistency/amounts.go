@@ -0,0 +1,321 @@
+// Package consistency содержит фоновые проверки инвариантов хранимых заказов.
+package consistency
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	log "github.com/sirupsen/logrus"
+
+	"github.com/vladislavdragonenkov/oms/internal/domain"
+	"github.com/vladislavdragonenkov/oms/internal/metrics"
+	"github.com/vladislavdragonenkov/oms/internal/timeutil"
+)
+
+const (
+	defaultCheckInterval  = time.Hour
+	defaultCheckBatchSize = 500
+)
+
+// MismatchType — вид расхождения сумм заказа.
+type MismatchType string
+
+const (
+	// MismatchAmount — orders.amount_minor не равен итогу по позициям (или по разбивке).
+	MismatchAmount MismatchType = "amount"
+	// MismatchSubtotal — subtotal разбивки не равен sum(qty*price) позиций.
+	MismatchSubtotal MismatchType = "subtotal"
+	// MismatchBreakdown — в разбивке subtotal - discount + tax не равно total.
+	MismatchBreakdown MismatchType = "breakdown"
+)
+
+var mismatchTypes = []MismatchType{MismatchAmount, MismatchSubtotal, MismatchBreakdown}
+
+// Mismatch описывает одно расхождение: ожидаемое значение пересчитано, фактическое — из хранилища.
+type Mismatch struct {
+	OrderID       string
+	Type          MismatchType
+	ExpectedMinor int64
+	ActualMinor   int64
+}
+
+// CheckOrder пересчитывает суммы заказа и возвращает расхождения; пустой результат — заказ согласован.
+func CheckOrder(order domain.Order) []Mismatch {
+	var itemsMinor int64
+	for _, item := range order.Items {
+		itemsMinor += int64(item.Qty) * item.PriceMinor
+	}
+
+	var result []Mismatch
+	add := func(kind MismatchType, expected, actual int64) {
+		result = append(result, Mismatch{OrderID: order.ID, Type: kind, ExpectedMinor: expected, ActualMinor: actual})
+	}
+
+	if order.Pricing.IsZero() {
+		if itemsMinor != order.AmountMinor {
+			add(MismatchAmount, itemsMinor, order.AmountMinor)
+		}
+		return result
+	}
+
+	p := order.Pricing
+	if itemsMinor != p.SubtotalMinor {
+		add(MismatchSubtotal, itemsMinor, p.SubtotalMinor)
+	}
+	if breakdown := p.SubtotalMinor - p.DiscountMinor + p.TaxMinor; breakdown != p.TotalMinor {
+		add(MismatchBreakdown, breakdown, p.TotalMinor)
+	}
+	if p.TotalMinor != order.AmountMinor {
+		add(MismatchAmount, p.TotalMinor, order.AmountMinor)
+	}
+	return result
+}
+
+// repairable сообщает, что расхождение ограничено orders.amount_minor: позиции и разбивка
+// согласованы между собой, и сумму можно восстановить по ним.
+func repairable(mismatches []Mismatch) bool {
+	return len(mismatches) == 1 && mismatches[0].Type == MismatchAmount
+}
+
+type checkerMetrics struct {
+	runsTotal       *prometheus.CounterVec
+	mismatchesTotal *prometheus.CounterVec
+	lastMismatches  *prometheus.GaugeVec
+}
+
+func newCheckerMetrics(registerer prometheus.Registerer) checkerMetrics {
+	return checkerMetrics{
+		runsTotal: metrics.Register(registerer, prometheus.NewCounterVec(prometheus.CounterOpts{
+			Name: "oms_amount_consistency_runs_total",
+			Help: "Total number of order amount consistency checks grouped by result.",
+		}, []string{"result"})),
+		mismatchesTotal: metrics.Register(registerer, prometheus.NewCounterVec(prometheus.CounterOpts{
+			Name: "oms_amount_consistency_mismatches_total",
+			Help: "Total number of order amount mismatches grouped by type and action (reported, repaired, repair_failed).",
+		}, []string{"type", "action"})),
+		lastMismatches: metrics.Register(registerer, prometheus.NewGaugeVec(prometheus.GaugeOpts{
+			Name: "oms_amount_consistency_last_mismatches",
+			Help: "Number of order amount mismatches found during the last check grouped by type.",
+		}, []string{"type"})),
+	}
+}
+
+// CheckerOptions задает параметры проверки сумм.
+type CheckerOptions struct {
+	Logger    *log.Entry
+	Interval  time.Duration
+	BatchSize int
+	// Repair разрешает исправлять amount_minor, если позиции и разбивка согласованы между собой.
+	Repair     bool
+	Registerer prometheus.Registerer
+}
+
+// CheckerOption настраивает Checker.
+type CheckerOption func(*CheckerOptions)
+
+// WithLogger задает logger проверки.
+func WithLogger(logger *log.Entry) CheckerOption {
+	return func(opts *CheckerOptions) {
+		opts.Logger = logger
+	}
+}
+
+// WithInterval задает интервал между полными проходами.
+func WithInterval(interval time.Duration) CheckerOption {
+	return func(opts *CheckerOptions) {
+		opts.Interval = interval
+	}
+}
+
+// WithBatchSize задает размер страницы при обходе заказов.
+func WithBatchSize(batchSize int) CheckerOption {
+	return func(opts *CheckerOptions) {
+		opts.BatchSize = batchSize
+	}
+}
+
+// WithRepair включает исправление amount_minor.
+func WithRepair(repair bool) CheckerOption {
+	return func(opts *CheckerOptions) {
+		opts.Repair = repair
+	}
+}
+
+// WithRegisterer задает реестр метрик проверки.
+func WithRegisterer(registerer prometheus.Registerer) CheckerOption {
+	return func(opts *CheckerOptions) {
+		opts.Registerer = registerer
+	}
+}
+
+// CheckResult описывает итог одного прохода.
+type CheckResult struct {
+	Checked    int
+	Mismatches []Mismatch
+	// Repaired — заказы, у которых amount_minor исправлен.
+	Repaired []string
+}
+
+// Checker периодически обходит все заказы и сверяет их суммы с позициями.
+type Checker struct {
+	orders    domain.OrderRepository
+	scanner   domain.OrderScanner
+	logger    *log.Entry
+	interval  time.Duration
+	batchSize int
+	repair    bool
+	metrics   checkerMetrics
+}
+
+// NewChecker создает проверку сумм; orders должен реализовывать domain.OrderScanner,
+// иначе Run только предупреждает и завершается.
+func NewChecker(orders domain.OrderRepository, options ...CheckerOption) *Checker {
+	opts := CheckerOptions{
+		Interval:  defaultCheckInterval,
+		BatchSize: defaultCheckBatchSize,
+	}
+	for _, option := range options {
+		option(&opts)
+	}
+
+	logger := opts.Logger
+	if logger == nil {
+		logger = log.WithField("component", "amount-consistency")
+	}
+	if opts.Interval <= 0 {
+		opts.Interval = defaultCheckInterval
+	}
+	if opts.BatchSize <= 0 {
+		opts.BatchSize = defaultCheckBatchSize
+	}
+
+	scanner, _ := orders.(domain.OrderScanner)
+	return &Checker{
+		orders:    orders,
+		scanner:   scanner,
+		logger:    logger,
+		interval:  opts.Interval,
+		batchSize: opts.BatchSize,
+		repair:    opts.Repair,
+		metrics:   newCheckerMetrics(opts.Registerer),
+	}
+}
+
+// Run запускает периодическую проверку до отмены ctx.
+func (c *Checker) Run(ctx context.Context) {
+	if c.scanner == nil {
+		c.logger.Warn("amount consistency check is disabled: order repository does not support scanning")
+		return
+	}
+
+	c.runOnce(ctx)
+
+	ticker := time.NewTicker(c.interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			c.runOnce(ctx)
+		}
+	}
+}
+
+func (c *Checker) runOnce(ctx context.Context) {
+	result, err := c.Check(ctx)
+	if err != nil {
+		if errors.Is(err, context.Canceled) {
+			return
+		}
+		c.metrics.runsTotal.WithLabelValues("error").Inc()
+		c.logger.WithError(err).Warn("amount consistency check failed")
+		return
+	}
+
+	c.metrics.runsTotal.WithLabelValues("ok").Inc()
+	counts := make(map[MismatchType]int, len(mismatchTypes))
+	for _, mismatch := range result.Mismatches {
+		counts[mismatch.Type]++
+	}
+	for _, kind := range mismatchTypes {
+		c.metrics.lastMismatches.WithLabelValues(string(kind)).Set(float64(counts[kind]))
+	}
+	if len(result.Mismatches) > 0 {
+		c.logger.WithFields(log.Fields{
+			"checked":    result.Checked,
+			"mismatches": len(result.Mismatches),
+			"repaired":   len(result.Repaired),
+			"repair":     c.repair,
+		}).Info("amount consistency check completed")
+	}
+}
+
+// Check выполняет один полный проход по заказам.
+func (c *Checker) Check(ctx context.Context) (CheckResult, error) {
+	var result CheckResult
+	if c.scanner == nil {
+		return result, errors.New("order repository does not support scanning")
+	}
+
+	afterID := ""
+	for {
+		if err := ctx.Err(); err != nil {
+			return result, err
+		}
+		page, err := c.scanner.ListAfter(afterID, c.batchSize)
+		if err != nil {
+			return result, fmt.Errorf("list orders after %q: %w", afterID, err)
+		}
+		for _, order := range page {
+			result.Checked++
+			c.checkOrder(order, &result)
+		}
+		if len(page) < c.batchSize {
+			return result, nil
+		}
+		afterID = page[len(page)-1].ID
+	}
+}
+
+func (c *Checker) checkOrder(order domain.Order, result *CheckResult) {
+	mismatches := CheckOrder(order)
+	if len(mismatches) == 0 {
+		return
+	}
+	result.Mismatches = append(result.Mismatches, mismatches...)
+
+	action := "reported"
+	if c.repair && repairable(mismatches) {
+		action = "repaired"
+		if err := c.repairAmount(order, mismatches[0].ExpectedMinor); err != nil {
+			action = "repair_failed"
+			c.logger.WithError(err).WithField("order_id", order.ID).Warn("failed to repair order amount")
+		} else {
+			result.Repaired = append(result.Repaired, order.ID)
+		}
+	}
+
+	for _, mismatch := range mismatches {
+		c.metrics.mismatchesTotal.WithLabelValues(string(mismatch.Type), action).Inc()
+		c.logger.WithFields(log.Fields{
+			"order_id": order.ID,
+			"type":     mismatch.Type,
+			"expected": mismatch.ExpectedMinor,
+			"actual":   mismatch.ActualMinor,
+			"action":   action,
+		}).Warn("order amount mismatch")
+	}
+}
+
+// repairAmount перезаписывает amount_minor через Save: конкурентное изменение заказа
+// даст ErrOrderVersionConflict, и заказ будет перепроверен в следующем проходе.
+func (c *Checker) repairAmount(order domain.Order, amountMinor int64) error {
+	order.AmountMinor = amountMinor
+	order.UpdatedAt = timeutil.Now()
+	return c.orders.Save(order)
+}
@@ -0,0 +1,99 @@
+package consistency
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+
+	"github.com/vladislavdragonenkov/oms/internal/domain"
+	"github.com/vladislavdragonenkov/oms/internal/storage/memory"
+)
+
+func amountOrder(id string, amountMinor int64) domain.Order {
+	now := time.Now().UTC()
+	return domain.Order{
+		ID:          id,
+		CustomerID:  "customer-1",
+		Status:      domain.OrderStatusPaid,
+		Currency:    "RUB",
+		AmountMinor: amountMinor,
+		Items:       []domain.OrderItem{{ID: id + "-item", SKU: "sku-1", Qty: 2, PriceMinor: 150, CreatedAt: now}},
+		CreatedAt:   now,
+		UpdatedAt:   now,
+	}
+}
+
+func TestCheckOrder(t *testing.T) {
+	if got := CheckOrder(amountOrder("order-ok", 300)); len(got) != 0 {
+		t.Fatalf("expected consistent order, got %+v", got)
+	}
+
+	got := CheckOrder(amountOrder("order-drift", 250))
+	if len(got) != 1 || got[0].Type != MismatchAmount || got[0].ExpectedMinor != 300 || got[0].ActualMinor != 250 {
+		t.Fatalf("unexpected amount mismatch: %+v", got)
+	}
+
+	priced := amountOrder("order-priced", 280)
+	priced.Pricing = domain.OrderPricing{SubtotalMinor: 310, DiscountMinor: 50, TaxMinor: 10, TotalMinor: 280}
+	got = CheckOrder(priced)
+	if len(got) != 2 || got[0].Type != MismatchSubtotal || got[1].Type != MismatchBreakdown {
+		t.Fatalf("expected subtotal and breakdown mismatches, got %+v", got)
+	}
+	if repairable(got) {
+		t.Fatal("subtotal drift must not be repaired automatically")
+	}
+}
+
+func TestChecker_ReportsAndRepairsAmountDrift(t *testing.T) {
+	repo := memory.NewOrderRepository()
+	priced := amountOrder("order-priced", 0)
+	priced.Pricing = domain.OrderPricing{SubtotalMinor: 300, DiscountMinor: 30, TaxMinor: 0, TotalMinor: 270}
+	for _, order := range []domain.Order{
+		amountOrder("order-a", 300),
+		amountOrder("order-b", 250),
+		priced,
+	} {
+		if err := repo.Create(order); err != nil {
+			t.Fatalf("create order %s: %v", order.ID, err)
+		}
+	}
+
+	registry := prometheus.NewRegistry()
+	dryRun := NewChecker(repo, WithBatchSize(2), WithRegisterer(registry))
+	result, err := dryRun.Check(context.Background())
+	if err != nil {
+		t.Fatalf("Check failed: %v", err)
+	}
+	if result.Checked != 3 || len(result.Mismatches) != 2 || len(result.Repaired) != 0 {
+		t.Fatalf("unexpected dry-run result: %+v", result)
+	}
+	if got := testutil.ToFloat64(dryRun.metrics.mismatchesTotal.WithLabelValues("amount", "reported")); got != 2 {
+		t.Fatalf("expected 2 reported amount mismatches, got %v", got)
+	}
+
+	repair := NewChecker(repo, WithBatchSize(2), WithRepair(true), WithRegisterer(registry))
+	result, err = repair.Check(context.Background())
+	if err != nil {
+		t.Fatalf("Check failed: %v", err)
+	}
+	if len(result.Repaired) != 2 {
+		t.Fatalf("expected both orders repaired, got %+v", result.Repaired)
+	}
+	for id, want := range map[string]int64{"order-b": 300, "order-priced": 270} {
+		order, err := repo.Get(id)
+		if err != nil {
+			t.Fatalf("get %s: %v", id, err)
+		}
+		if order.AmountMinor != want {
+			t.Fatalf("expected %s amount %d, got %d", id, want, order.AmountMinor)
+		}
+	}
+
+	result, err = repair.Check(context.Background())
+	if err != nil || len(result.Mismatches) != 0 {
+		t.Fatalf("expected clean pass after repair, got %+v (%v)", result, err)
+	}
+}
@@ -0,0 +1,96 @@
+package grpcsvc
+
+import (
+	"context"
+	"strings"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+const (
+	// AdminAuthMetadataKey — metadata с токеном оператора: "authorization: Bearer <token>".
+	AdminAuthMetadataKey = "authorization"
+
+	adminServicePrefix = "/oms.v1.AdminService/"
+	bearerPrefix       = "bearer "
+)
+
+// AdminTokenVerifier находит оператора по предъявленному токену. Реализуется *keyring.Keyring
+// с ключами keyring.ParseTokens: kid — имя оператора, секрет — токен; набор можно ротировать
+// без рестарта (keyring.Watch).
+type AdminTokenVerifier interface {
+	VerifyToken(token string) (string, error)
+}
+
+type adminOperatorContextKey struct{}
+
+// operatorMethods — методы OrderService, которые, как и AdminService, вызывают только операторы:
+// клиент не должен сам принимать решения по своему заказу.
+var operatorMethods = map[string]struct{}{
+	grpcMethodHoldOrder:    {},
+	grpcMethodReleaseOrder: {},
+	grpcMethodApproveOrder: {},
+	grpcMethodRejectOrder:  {},
+}
+
+// requiresAdminAuth сообщает, нужен ли методу токен оператора.
+func requiresAdminAuth(method string) bool {
+	if strings.HasPrefix(method, adminServicePrefix) {
+		return true
+	}
+	_, ok := operatorMethods[method]
+	return ok
+}
+
+// AdminOperatorFromContext возвращает оператора, аутентифицированного UnaryAdminAuthInterceptor;
+// пусто — запрос прошёл без проверки токена.
+func AdminOperatorFromContext(ctx context.Context) string {
+	operator, _ := ctx.Value(adminOperatorContextKey{}).(string)
+	return operator
+}
+
+// UnaryAdminAuthInterceptor требует токен оператора для всех методов AdminService и операторских
+// методов OrderService (HoldOrder, ReleaseOrder, ApproveOrder, RejectOrder): без metadata authorization — Unauthenticated,
+// с неизвестным токеном — тоже Unauthenticated. Оператор передаётся обработчику через контекст
+// (AdminOperatorFromContext) и как principal. Без verifier такие методы отклоняются все: открытым
+// административный API не бывает. Остальные методы interceptor не трогает.
+func UnaryAdminAuthInterceptor(verifier AdminTokenVerifier) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+		if !requiresAdminAuth(info.FullMethod) {
+			return handler(ctx, req)
+		}
+		if verifier == nil {
+			return nil, status.Error(codes.Unauthenticated, "admin authentication is not configured")
+		}
+
+		token := adminTokenFromMetadata(ctx)
+		if token == "" {
+			return nil, status.Error(codes.Unauthenticated, "admin token is required")
+		}
+		operator, err := verifier.VerifyToken(token)
+		if err != nil || operator == "" {
+			return nil, status.Error(codes.Unauthenticated, "invalid admin token")
+		}
+		ctx = context.WithValue(ctx, adminOperatorContextKey{}, operator)
+		return handler(ContextWithPrincipal(ctx, operator), req)
+	}
+}
+
+func adminTokenFromMetadata(ctx context.Context) string {
+	md, ok := metadata.FromIncomingContext(ctx)
+	if !ok {
+		return ""
+	}
+	values := md.Get(AdminAuthMetadataKey)
+	if len(values) == 0 {
+		return ""
+	}
+	value := strings.TrimSpace(values[0])
+	if len(value) < len(bearerPrefix) || !strings.EqualFold(value[:len(bearerPrefix)], bearerPrefix) {
+		return ""
+	}
+	return strings.TrimSpace(value[len(bearerPrefix):])
+}
@@ -0,0 +1,96 @@
+package grpcsvc
+
+import (
+	"context"
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+
+	"github.com/vladislavdragonenkov/oms/internal/keyring"
+)
+
+// newAdminKeyring — токены операторов в формате OMS_ADMIN_TOKENS.
+func newAdminKeyring(t *testing.T, spec string) *keyring.Keyring {
+	t.Helper()
+	keys, err := keyring.ParseTokens(spec)
+	if err != nil {
+		t.Fatalf("parse admin tokens: %v", err)
+	}
+	ring, err := keyring.New(keys, keyring.WithRegisterer(prometheus.NewRegistry()))
+	if err != nil {
+		t.Fatalf("new keyring: %v", err)
+	}
+	return ring
+}
+
+// operatorContext — контекст запроса, который UnaryAdminAuthInterceptor пропустил с токеном operator.
+func operatorContext(operator string) context.Context {
+	ctx := context.WithValue(context.Background(), adminOperatorContextKey{}, operator)
+	return ContextWithPrincipal(ctx, operator)
+}
+
+func TestUnaryAdminAuthInterceptor(t *testing.T) {
+	tokens := newAdminKeyring(t, "alice:s3cr3t")
+	interceptor := UnaryAdminAuthInterceptor(tokens)
+	adminInfo := &grpc.UnaryServerInfo{FullMethod: adminServicePrefix + "ForceTransition"}
+	call := func(ctx context.Context, info *grpc.UnaryServerInfo) (string, error) {
+		resp, err := interceptor(ctx, nil, info, func(ctx context.Context, _ any) (any, error) {
+			if AdminOperatorFromContext(ctx) != PrincipalFromContext(ctx) {
+				t.Fatalf("operator and principal differ")
+			}
+			return AdminOperatorFromContext(ctx), nil
+		})
+		operator, _ := resp.(string)
+		return operator, err
+	}
+	withAuth := func(value string) context.Context {
+		return metadata.NewIncomingContext(context.Background(), metadata.Pairs(AdminAuthMetadataKey, value))
+	}
+
+	operator, err := call(withAuth("Bearer s3cr3t"), adminInfo)
+	if err != nil || operator != "alice" {
+		t.Fatalf("expected alice, got operator=%q err=%v", operator, err)
+	}
+	if operator, _ = call(withAuth("bearer  s3cr3t "), adminInfo); operator != "alice" {
+		t.Fatalf("bearer prefix must be case-insensitive, got %q", operator)
+	}
+
+	_, err = call(context.Background(), adminInfo)
+	mustStatusCode(t, err, codes.Unauthenticated)
+	_, err = call(withAuth("Bearer wrong"), adminInfo)
+	mustStatusCode(t, err, codes.Unauthenticated)
+	_, err = call(withAuth("s3cr3t"), adminInfo)
+	mustStatusCode(t, err, codes.Unauthenticated)
+
+	// Операторские методы OrderService требуют токен так же, как AdminService.
+	approveInfo := &grpc.UnaryServerInfo{FullMethod: grpcMethodApproveOrder}
+	_, err = call(context.Background(), approveInfo)
+	mustStatusCode(t, err, codes.Unauthenticated)
+	if operator, _ = call(withAuth("Bearer s3cr3t"), approveInfo); operator != "alice" {
+		t.Fatalf("expected alice for ApproveOrder, got %q", operator)
+	}
+
+	// Остальные методы токен не требуют.
+	if _, err := call(context.Background(), &grpc.UnaryServerInfo{FullMethod: grpcMethodCreateOrder}); err != nil {
+		t.Fatalf("order service call must pass without admin token: %v", err)
+	}
+	// Ротация: новый токен принимается, снятый с ключами — нет.
+	if err := tokens.Replace([]keyring.Key{{ID: "alice", Secret: []byte("r0tated")}}); err != nil {
+		t.Fatalf("replace tokens: %v", err)
+	}
+	if operator, _ = call(withAuth("Bearer r0tated"), adminInfo); operator != "alice" {
+		t.Fatalf("rotated token must be accepted, got %q", operator)
+	}
+	_, err = call(withAuth("Bearer s3cr3t"), adminInfo)
+	mustStatusCode(t, err, codes.Unauthenticated)
+
+	// Без токенов AdminService закрыт целиком.
+	_, err = UnaryAdminAuthInterceptor(nil)(withAuth("Bearer s3cr3t"), nil, adminInfo, func(context.Context, any) (any, error) {
+		t.Fatal("handler must not be called without admin authentication")
+		return nil, nil
+	})
+	mustStatusCode(t, err, codes.Unauthenticated)
+}
@@ -0,0 +1,275 @@
+package grpcsvc
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/prometheus/client_golang/prometheus"
+	log "github.com/sirupsen/logrus"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/vladislavdragonenkov/oms/internal/domain"
+	"github.com/vladislavdragonenkov/oms/internal/logging"
+	"github.com/vladislavdragonenkov/oms/internal/metrics"
+	"github.com/vladislavdragonenkov/oms/internal/timeutil"
+	omsv1 "github.com/vladislavdragonenkov/oms/proto/oms/v1"
+)
+
+const (
+	// EventCustomerDataErased публикуется после обезличивания, чтобы downstream-сервисы удалили свои копии.
+	EventCustomerDataErased = "CustomerDataErased"
+
+	customerAggregateType = "customer"
+	erasedCustomerPrefix  = "erased-"
+)
+
+func newCustomerDataErasuresTotal(registerer prometheus.Registerer) *prometheus.CounterVec {
+	return metrics.Register(registerer, prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: "oms_customer_data_erasures_total",
+		Help: "Total number of customer data erasure requests grouped by result.",
+	}, []string{"result"}))
+}
+
+// AdminService реализует административный gRPC API (операции legal/поддержки).
+type AdminService struct {
+	omsv1.UnimplementedAdminServiceServer
+
+	eraser   domain.CustomerDataEraser
+	timeline domain.TimelineRepository
+	outbox   domain.OutboxRepository
+	logger   *log.Entry
+
+	quotaRepo domain.QuotaRepository
+	quotas    OrderQuotas
+
+	feedSettle time.Duration
+	// orders нужен RecalculateOrder (вместе с catalog) и ForceTransition; без него RPC недоступны.
+	orders  domain.OrderRepository
+	catalog domain.CatalogService
+	// forceTransitions включает ForceTransition (WithOrderTransitions).
+	forceTransitions bool
+	// logLevels меняет уровни логирования через SetLogLevel; nil — RPC недоступны.
+	logLevels *logging.Levels
+
+	registerer        prometheus.Registerer
+	erasures          *prometheus.CounterVec
+	forcedTransitions *prometheus.CounterVec
+}
+
+// AdminServiceOption настраивает AdminService.
+type AdminServiceOption func(*AdminService)
+
+// WithAdminRegisterer задаёт реестр метрик AdminService; по умолчанию — глобальный.
+func WithAdminRegisterer(registerer prometheus.Registerer) AdminServiceOption {
+	return func(s *AdminService) {
+		s.registerer = registerer
+	}
+}
+
+// WithAdminQuotas включает GetQuotaUsage; repo и quotas должны совпадать с переданными в WithOrderQuotas.
+func WithAdminQuotas(repo domain.QuotaRepository, quotas OrderQuotas) AdminServiceOption {
+	return func(s *AdminService) {
+		s.quotaRepo = repo
+		s.quotas = quotas
+	}
+}
+
+// NewAdminService конструирует AdminService с зависимостями.
+func NewAdminService(
+	eraser domain.CustomerDataEraser,
+	timeline domain.TimelineRepository,
+	outbox domain.OutboxRepository,
+	logger *log.Entry,
+	options ...AdminServiceOption,
+) *AdminService {
+	if logger == nil {
+		logger = log.New().WithField("component", "admin-service")
+	}
+
+	s := &AdminService{
+		eraser:     eraser,
+		timeline:   timeline,
+		outbox:     outbox,
+		logger:     logger,
+		feedSettle: defaultEventFeedSettle,
+	}
+	for _, option := range options {
+		option(s)
+	}
+	s.erasures = newCustomerDataErasuresTotal(s.registerer)
+	s.forcedTransitions = newOrderForcedTransitionsTotal(s.registerer)
+	return s
+}
+
+// DeleteCustomerData обезличивает данные клиента, пишет аудит в timeline заказов и публикует CustomerDataErased.
+func (s *AdminService) DeleteCustomerData(ctx context.Context, req *omsv1.DeleteCustomerDataRequest) (*omsv1.DeleteCustomerDataResponse, error) {
+	if req == nil {
+		return nil, status.Error(codes.InvalidArgument, "request is required")
+	}
+	customerID, err := parseCustomerIDArg(strings.TrimSpace(req.CustomerId))
+	if err != nil {
+		return nil, err
+	}
+	reason := strings.TrimSpace(req.Reason)
+	if reason == "" {
+		return nil, status.Error(codes.InvalidArgument, "reason is required")
+	}
+	if strings.HasPrefix(customerID.String(), erasedCustomerPrefix) {
+		return nil, status.Error(codes.InvalidArgument, "customer_id is already a pseudonym")
+	}
+	if s.eraser == nil {
+		return nil, status.Error(codes.Unimplemented, "customer data erasure is not configured")
+	}
+
+	pseudonym := erasedCustomerPrefix + uuid.NewString()
+	erasure, err := s.eraser.EraseCustomerData(customerID.String(), pseudonym)
+	if err != nil {
+		if errors.Is(err, domain.ErrCustomerDataNotFound) {
+			s.erasures.WithLabelValues("not_found").Inc()
+			return nil, status.Error(codes.NotFound, "customer data not found")
+		}
+		s.erasures.WithLabelValues("error").Inc()
+		s.logger.WithError(err).WithField("pseudonym", pseudonym).Error("failed to erase customer data")
+		return nil, status.Error(codes.Internal, "failed to erase customer data")
+	}
+	s.erasures.WithLabelValues("ok").Inc()
+
+	erasedAt := time.Now().UTC()
+	s.appendErasureTimeline(erasure.OrderIDs, reason, erasedAt)
+	s.enqueueErasureEvent(ctx, pseudonym, reason, erasure, erasedAt)
+
+	// Аудит-запись: исходный customer_id не сохраняется ни в логе, ни в событии — после ответа
+	// связь с псевдонимом есть только у вызывающего.
+	s.logger.WithFields(log.Fields{
+		"audit":                        true,
+		"action":                       "delete_customer_data",
+		"pseudonym":                    pseudonym,
+		"reason":                       reason,
+		"orders_anonymized":            len(erasure.OrderIDs),
+		"timeline_events_scrubbed":     erasure.TimelineEventsScrubbed,
+		"idempotency_records_scrubbed": erasure.IdempotencyRecordsScrubbed,
+		"outbox_messages_scrubbed":     erasure.OutboxMessagesScrubbed,
+	}).Info("customer data erased")
+
+	return &omsv1.DeleteCustomerDataResponse{
+		Pseudonym:                  pseudonym,
+		OrderIds:                   erasure.OrderIDs,
+		TimelineEventsScrubbed:     toProtoInt32(erasure.TimelineEventsScrubbed),
+		IdempotencyRecordsScrubbed: toProtoInt32(erasure.IdempotencyRecordsScrubbed),
+		ErasedAtUnix:               erasedAt.Unix(),
+	}, nil
+}
+
+func (s *AdminService) appendErasureTimeline(orderIDs []string, reason string, erasedAt time.Time) {
+	if s.timeline == nil {
+		return
+	}
+	for _, orderID := range orderIDs {
+		if err := s.timeline.Append(domain.TimelineEvent{
+			OrderID:  orderID,
+			Type:     EventCustomerDataErased,
+			Reason:   reason,
+			Occurred: erasedAt,
+		}); err != nil {
+			s.logger.WithError(err).WithField("order_id", orderID).Warn("failed to append erasure audit event")
+		}
+	}
+}
+
+// enqueueErasureEvent публикует CustomerDataErased без исходного customer_id: событие лежит в outbox
+// и Kafka дольше, чем длится обезличивание. Downstream-сервисы находят свои копии по order_ids.
+func (s *AdminService) enqueueErasureEvent(ctx context.Context, pseudonym, reason string, erasure domain.CustomerErasure, erasedAt time.Time) {
+	if s.outbox == nil {
+		return
+	}
+
+	payload, err := json.Marshal(map[string]interface{}{
+		"pseudonym": pseudonym,
+		"order_ids": erasure.OrderIDs,
+		"reason":    reason,
+		"erased_at": timeutil.Format(erasedAt),
+	})
+	if err != nil {
+		s.logger.WithError(err).WithField("pseudonym", pseudonym).Error("marshal erasure event failed")
+		return
+	}
+
+	stored, err := s.outbox.Enqueue(domain.OutboxMessage{
+		AggregateType: customerAggregateType,
+		AggregateID:   pseudonym,
+		EventType:     EventCustomerDataErased,
+		Payload:       payload,
+		Headers:       domain.OutboxHeadersFromContext(ctx),
+	})
+	if err != nil {
+		s.logger.WithError(err).WithField("pseudonym", pseudonym).Error("enqueue erasure event failed")
+		return
+	}
+	domain.EventChainFromContext(ctx).Advance(stored.ID)
+}
+
+// GetQuotaUsage отдаёт расход дневной квоты principal'а, чтобы партнёр и поддержка видели остаток до лимита.
+func (s *AdminService) GetQuotaUsage(_ context.Context, req *omsv1.GetQuotaUsageRequest) (*omsv1.GetQuotaUsageResponse, error) {
+	if req == nil {
+		return nil, status.Error(codes.InvalidArgument, "request is required")
+	}
+	principal := strings.TrimSpace(req.Principal)
+	if principal == "" {
+		return nil, status.Error(codes.InvalidArgument, "principal is required")
+	}
+	if s.quotaRepo == nil {
+		return nil, status.Error(codes.Unimplemented, "order quotas are not configured")
+	}
+
+	day := domain.QuotaDay(time.Now())
+	if raw := strings.TrimSpace(req.Day); raw != "" {
+		parsed, err := time.Parse(time.DateOnly, raw)
+		if err != nil {
+			return nil, status.Error(codes.InvalidArgument, "day must be in YYYY-MM-DD format")
+		}
+		day = parsed
+	}
+
+	usage, err := s.quotaRepo.Usage(principal, day)
+	if err != nil {
+		s.logger.WithError(err).WithField("principal", principal).Error("failed to load quota usage")
+		return nil, status.Error(codes.Internal, "failed to load quota usage")
+	}
+	quota, configured := s.quotas.For(principal)
+
+	currencies := make([]string, 0, len(usage.AmountMinor)+len(quota.MaxAmountMinor))
+	for currency := range usage.AmountMinor {
+		currencies = append(currencies, currency)
+	}
+	for currency := range quota.MaxAmountMinor {
+		if _, ok := usage.AmountMinor[currency]; !ok {
+			currencies = append(currencies, currency)
+		}
+	}
+	sort.Strings(currencies)
+
+	amounts := make([]*omsv1.QuotaAmountUsage, 0, len(currencies))
+	for _, currency := range currencies {
+		amounts = append(amounts, &omsv1.QuotaAmountUsage{
+			Currency:   currency,
+			UsedMinor:  usage.AmountMinor[currency],
+			LimitMinor: quota.MaxAmountMinor[currency],
+		})
+	}
+
+	return &omsv1.GetQuotaUsageResponse{
+		Principal:       principal,
+		Day:             day.Format(time.DateOnly),
+		QuotaConfigured: configured,
+		OrdersUsed:      usage.Orders,
+		OrdersLimit:     quota.MaxOrders,
+		Amounts:         amounts,
+		ResetsAtUnix:    day.Add(24 * time.Hour).Unix(),
+	}, nil
+}
@@ -0,0 +1,109 @@
+package grpcsvc
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"google.golang.org/grpc/codes"
+
+	"github.com/vladislavdragonenkov/oms/internal/domain"
+	"github.com/vladislavdragonenkov/oms/internal/storage/memory"
+	omsv1 "github.com/vladislavdragonenkov/oms/proto/oms/v1"
+)
+
+type stubCustomerDataEraser struct {
+	erasure domain.CustomerErasure
+	err     error
+}
+
+func (s *stubCustomerDataEraser) EraseCustomerData(string, string) (domain.CustomerErasure, error) {
+	return s.erasure, s.err
+}
+
+func TestAdminService_DeleteCustomerData(t *testing.T) {
+	orders := memory.NewOrderRepository()
+	timeline := memory.NewTimelineRepository()
+	outbox := memory.NewOutboxRepository()
+	eraser, err := memory.NewCustomerDataEraser(orders, timeline, nil, outbox)
+	if err != nil {
+		t.Fatalf("NewCustomerDataEraser failed: %v", err)
+	}
+	if err := orders.Create(domain.Order{ID: "o-1", CustomerID: "alice", Status: domain.OrderStatusPending, Currency: "USD", CreatedAt: time.Now().UTC()}); err != nil {
+		t.Fatalf("Create failed: %v", err)
+	}
+
+	service := NewAdminService(eraser, timeline, outbox, nil)
+	resp, err := service.DeleteCustomerData(context.Background(), &omsv1.DeleteCustomerDataRequest{CustomerId: "alice", Reason: "LEGAL-42"})
+	if err != nil {
+		t.Fatalf("DeleteCustomerData failed: %v", err)
+	}
+	if !strings.HasPrefix(resp.GetPseudonym(), erasedCustomerPrefix) || len(resp.GetOrderIds()) != 1 {
+		t.Fatalf("unexpected response: %+v", resp)
+	}
+
+	order, _ := orders.Get("o-1")
+	if order.CustomerID != resp.GetPseudonym() {
+		t.Fatalf("expected order to be pseudonymized, got %s", order.CustomerID)
+	}
+
+	events, _ := timeline.List("o-1")
+	if len(events) != 1 || events[0].Type != EventCustomerDataErased || events[0].Reason != "LEGAL-42" {
+		t.Fatalf("expected erasure audit event, got %+v", events)
+	}
+
+	pending, err := outbox.PullPending(10)
+	if err != nil {
+		t.Fatalf("PullPending failed: %v", err)
+	}
+	if len(pending) != 1 || pending[0].EventType != EventCustomerDataErased || pending[0].AggregateID != resp.GetPseudonym() {
+		t.Fatalf("expected CustomerDataErased event, got %+v", pending)
+	}
+	var payload map[string]interface{}
+	if err := json.Unmarshal(pending[0].Payload, &payload); err != nil {
+		t.Fatalf("decode payload: %v", err)
+	}
+	if _, ok := payload["customer_id"]; ok || strings.Contains(string(pending[0].Payload), "alice") {
+		t.Fatalf("event must not carry the original customer id, got %s", pending[0].Payload)
+	}
+	if ids, _ := payload["order_ids"].([]interface{}); len(ids) != 1 || ids[0] != "o-1" {
+		t.Fatalf("event must carry anonymized order ids, got %v", payload["order_ids"])
+	}
+}
+
+func TestAdminService_DeleteCustomerData_Errors(t *testing.T) {
+	tests := []struct {
+		name    string
+		service *AdminService
+		req     *omsv1.DeleteCustomerDataRequest
+		code    codes.Code
+	}{
+		{name: "nil request", service: NewAdminService(&stubCustomerDataEraser{}, nil, nil, nil), code: codes.InvalidArgument},
+		{name: "empty customer", service: NewAdminService(&stubCustomerDataEraser{}, nil, nil, nil), req: &omsv1.DeleteCustomerDataRequest{Reason: "r"}, code: codes.InvalidArgument},
+		{name: "empty reason", service: NewAdminService(&stubCustomerDataEraser{}, nil, nil, nil), req: &omsv1.DeleteCustomerDataRequest{CustomerId: "c"}, code: codes.InvalidArgument},
+		{name: "pseudonym", service: NewAdminService(&stubCustomerDataEraser{}, nil, nil, nil), req: &omsv1.DeleteCustomerDataRequest{CustomerId: "erased-1", Reason: "r"}, code: codes.InvalidArgument},
+		{name: "not configured", service: NewAdminService(nil, nil, nil, nil), req: &omsv1.DeleteCustomerDataRequest{CustomerId: "c", Reason: "r"}, code: codes.Unimplemented},
+		{
+			name:    "not found",
+			service: NewAdminService(&stubCustomerDataEraser{err: domain.ErrCustomerDataNotFound}, nil, nil, nil),
+			req:     &omsv1.DeleteCustomerDataRequest{CustomerId: "c", Reason: "r"},
+			code:    codes.NotFound,
+		},
+		{
+			name:    "storage error",
+			service: NewAdminService(&stubCustomerDataEraser{err: errors.New("db down")}, nil, nil, nil),
+			req:     &omsv1.DeleteCustomerDataRequest{CustomerId: "c", Reason: "r"},
+			code:    codes.Internal,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := tt.service.DeleteCustomerData(context.Background(), tt.req)
+			mustStatusCode(t, err, tt.code)
+		})
+	}
+}
@@ -0,0 +1,207 @@
+package grpcsvc
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/vladislavdragonenkov/oms/internal/domain"
+	omsv1 "github.com/vladislavdragonenkov/oms/proto/oms/v1"
+)
+
+const (
+	grpcMethodApproveOrder = "/oms.v1.OrderService/ApproveOrder"
+	grpcMethodRejectOrder  = "/oms.v1.OrderService/RejectOrder"
+
+	// События согласования; те же имена у событий в outbox.
+	timelineEventOrderApprovalRequested = "OrderApprovalRequested"
+	timelineEventOrderApproved          = "OrderApproved"
+	timelineEventOrderRejected          = "OrderRejected"
+)
+
+// ErrInvalidApprovalCustomers — список клиентов с согласованием задан некорректно.
+var ErrInvalidApprovalCustomers = errors.New("grpcsvc: invalid approval customers")
+
+// ApprovalCustomers — клиенты (B2B-аккаунты), чьи заказы ждут согласования перед обработкой.
+type ApprovalCustomers map[string]struct{}
+
+// Requires сообщает, нужно ли согласование заказам клиента.
+func (c ApprovalCustomers) Requires(customerID string) bool {
+	_, ok := c[customerID]
+	return ok
+}
+
+// ParseApprovalCustomers разбирает список customer_id через запятую: "b2b-acme,b2b-globex".
+func ParseApprovalCustomers(raw string) (ApprovalCustomers, error) {
+	customers := make(ApprovalCustomers)
+	for _, entry := range strings.Split(raw, ",") {
+		entry = strings.TrimSpace(entry)
+		if entry == "" {
+			continue
+		}
+		id, err := domain.ParseCustomerID(entry)
+		if err != nil {
+			return nil, fmt.Errorf("%w: %q: %v", ErrInvalidApprovalCustomers, entry, err)
+		}
+		customers[id.String()] = struct{}{}
+	}
+	return customers, nil
+}
+
+// WithApprovalCustomers включает согласование: CreateOrder оставляет заказы этих клиентов
+// в awaiting_approval до ApproveOrder или RejectOrder.
+func WithApprovalCustomers(customers ApprovalCustomers) OrderServiceOption {
+	return func(s *OrderService) {
+		s.approvals = customers
+	}
+}
+
+// requestApproval ставит новый заказ на согласование, если клиент этого требует.
+func (s *OrderService) requestApproval(order *domain.Order) {
+	if s.approvals.Requires(order.CustomerID) {
+		order.Status = domain.OrderStatusAwaitingApproval
+	}
+}
+
+// ApproveOrder согласует заказ. Заказ становится pending и дальше обрабатывается как обычный:
+// сагу запускает PayOrder. Согласующий — оператор, аутентифицированный UnaryAdminAuthInterceptor;
+// req.Approver не используется, иначе клиент мог бы согласовать свой заказ сам.
+func (s *OrderService) ApproveOrder(ctx context.Context, req *omsv1.ApproveOrderRequest) (*omsv1.ApproveOrderResponse, error) {
+	if _, err := parseOrderIDArg(req.GetOrderId()); err != nil {
+		return nil, err
+	}
+	approver := AdminOperatorFromContext(ctx)
+	if approver == "" {
+		return nil, status.Error(codes.Unauthenticated, "order approval requires operator authentication")
+	}
+
+	return withIdempotency(
+		s,
+		ctx,
+		grpcMethodApproveOrder,
+		req,
+		s.orderCustomer(req.OrderId, "ApproveOrder"),
+		func() *omsv1.ApproveOrderResponse { return &omsv1.ApproveOrderResponse{} },
+		func(ctx context.Context) (*omsv1.ApproveOrderResponse, error) {
+			return s.approveOrderInternal(ctx, req, approver)
+		},
+	)
+}
+
+func (s *OrderService) approveOrderInternal(ctx context.Context, req *omsv1.ApproveOrderRequest, approver string) (*omsv1.ApproveOrderResponse, error) {
+	comment := strings.TrimSpace(req.Comment)
+
+	order, err := s.decideApproval(req.OrderId, "ApproveOrder", (*domain.Order).Approve)
+	if err != nil {
+		return nil, err
+	}
+	s.appendTimelineEvent(order.ID, timelineEventOrderApproved, approvalReason(approver, comment))
+	s.enqueueOrderEvent(ctx, order, timelineEventOrderApproved, map[string]interface{}{
+		"status":   order.Status,
+		"approver": approver,
+		"comment":  comment,
+	})
+
+	s.logger.WithFields(log.Fields{
+		"order_id": order.ID,
+		"approver": approver,
+	}).Info("order approved")
+
+	return &omsv1.ApproveOrderResponse{OrderId: order.ID, Status: toProtoStatus(order.Status)}, nil
+}
+
+// RejectOrder отклоняет заказ на согласовании. Резерва и оплаты не было, поэтому заказ
+// отменяется без саги и компенсаций. Отклоняющий, как и в ApproveOrder, — оператор из токена.
+func (s *OrderService) RejectOrder(ctx context.Context, req *omsv1.RejectOrderRequest) (*omsv1.RejectOrderResponse, error) {
+	if _, err := parseOrderIDArg(req.GetOrderId()); err != nil {
+		return nil, err
+	}
+	approver := AdminOperatorFromContext(ctx)
+	if approver == "" {
+		return nil, status.Error(codes.Unauthenticated, "order approval requires operator authentication")
+	}
+
+	return withIdempotency(
+		s,
+		ctx,
+		grpcMethodRejectOrder,
+		req,
+		s.orderCustomer(req.OrderId, "RejectOrder"),
+		func() *omsv1.RejectOrderResponse { return &omsv1.RejectOrderResponse{} },
+		func(ctx context.Context) (*omsv1.RejectOrderResponse, error) {
+			return s.rejectOrderInternal(ctx, req, approver)
+		},
+	)
+}
+
+func (s *OrderService) rejectOrderInternal(ctx context.Context, req *omsv1.RejectOrderRequest, approver string) (*omsv1.RejectOrderResponse, error) {
+	reason := strings.TrimSpace(req.Reason)
+	if reason == "" {
+		return nil, status.Error(codes.InvalidArgument, "reason is required")
+	}
+
+	order, err := s.decideApproval(req.OrderId, "RejectOrder", (*domain.Order).Reject)
+	if err != nil {
+		return nil, err
+	}
+	s.appendTimelineEvent(order.ID, timelineEventOrderRejected, approvalReason(approver, reason))
+	s.enqueueOrderEvent(ctx, order, timelineEventOrderRejected, map[string]interface{}{
+		"status":   order.Status,
+		"approver": approver,
+		"reason":   reason,
+	})
+
+	s.logger.WithFields(log.Fields{
+		"order_id": order.ID,
+		"approver": approver,
+		"reason":   reason,
+	}).Info("order rejected")
+
+	return &omsv1.RejectOrderResponse{OrderId: order.ID, Status: toProtoStatus(order.Status)}, nil
+}
+
+// decideApproval применяет решение по согласованию к заказу и сохраняет его.
+func (s *OrderService) decideApproval(orderID, operation string, decide func(*domain.Order) error) (domain.Order, error) {
+	order, err := s.loadOrder(orderID, operation)
+	if err != nil {
+		return domain.Order{}, err
+	}
+	if err := decide(&order); err != nil {
+		return domain.Order{}, status.Errorf(codes.FailedPrecondition, "order %s status=%s: %s", order.ID, order.Status, err.Error())
+	}
+	order.UpdatedAt = time.Now().UTC()
+	err = s.saveOrder(order, operation, "failed to save order decision")
+	s.recordTransition(order, string(domain.OrderStatusAwaitingApproval), err)
+	if err != nil {
+		return domain.Order{}, err
+	}
+	order.Version++
+
+	s.invalidateCustomerOrders(order.CustomerID)
+	s.appendStatusTimeline(order.ID, order.Status, order.UpdatedAt)
+	return order, nil
+}
+
+// recordApprovalRequested сообщает о новом заказе, который ждёт согласования.
+func (s *OrderService) recordApprovalRequested(ctx context.Context, order domain.Order) {
+	s.appendTimelineEvent(order.ID, timelineEventOrderApprovalRequested, "")
+	s.enqueueOrderEvent(ctx, order, timelineEventOrderApprovalRequested, map[string]interface{}{
+		"customer_id":  order.CustomerID,
+		"currency":     order.Currency,
+		"amount_minor": order.AmountMinor,
+		"items_count":  len(order.Items),
+	})
+}
+
+func approvalReason(approver, note string) string {
+	if note == "" {
+		return approver
+	}
+	return approver + ": " + note
+}
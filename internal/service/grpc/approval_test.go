@@ -0,0 +1,171 @@
+package grpcsvc
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+
+	"github.com/vladislavdragonenkov/oms/internal/domain"
+	"github.com/vladislavdragonenkov/oms/internal/service/saga"
+	"github.com/vladislavdragonenkov/oms/internal/storage/memory"
+	omsv1 "github.com/vladislavdragonenkov/oms/proto/oms/v1"
+)
+
+func createApprovalTestOrder(t *testing.T, service *OrderService, customerID string) *omsv1.Order {
+	t.Helper()
+	resp, err := service.CreateOrder(context.Background(), &omsv1.CreateOrderRequest{
+		CustomerId: customerID,
+		Currency:   "USD",
+		Items:      []*omsv1.OrderItem{{Sku: "SKU-1", Qty: 2, Price: &omsv1.Money{Currency: "USD", AmountMinor: 500}}},
+	})
+	if err != nil {
+		t.Fatalf("CreateOrder failed: %v", err)
+	}
+	return resp.Order
+}
+
+// outboxPayloads забирает pending-события outbox и раскладывает их payload по типу события.
+func outboxPayloads(t *testing.T, outbox domain.OutboxRepository) map[string]map[string]interface{} {
+	t.Helper()
+	msgs, err := outbox.PullPending(10)
+	if err != nil {
+		t.Fatalf("pull outbox: %v", err)
+	}
+	events := make(map[string]map[string]interface{}, len(msgs))
+	for _, msg := range msgs {
+		var payload map[string]interface{}
+		if err := json.Unmarshal(msg.Payload, &payload); err != nil {
+			t.Fatalf("decode %s payload: %v", msg.EventType, err)
+		}
+		events[msg.EventType] = payload
+	}
+	return events
+}
+
+func TestParseApprovalCustomers(t *testing.T) {
+	customers, err := ParseApprovalCustomers(" b2b-acme,,b2b-globex ")
+	if err != nil {
+		t.Fatalf("parse: %v", err)
+	}
+	if len(customers) != 2 || !customers.Requires("b2b-acme") || customers.Requires("retail-1") {
+		t.Fatalf("unexpected customers: %v", customers)
+	}
+	if _, err := ParseApprovalCustomers("b2b acme"); !errors.Is(err, ErrInvalidApprovalCustomers) {
+		t.Fatalf("expected ErrInvalidApprovalCustomers, got %v", err)
+	}
+}
+
+func TestOrderService_ApprovalFlow(t *testing.T) {
+	ctx := operatorContext("jane.doe")
+	customers, err := ParseApprovalCustomers("b2b-acme, b2b-globex")
+	if err != nil {
+		t.Fatalf("parse approval customers: %v", err)
+	}
+	orders := memory.NewOrderRepository()
+	timeline := memory.NewTimelineRepository()
+	outbox := memory.NewOutboxRepository()
+	service := NewOrderService(orders, timeline, nil, saga.NewNoop(nil), nil,
+		WithOrderEvents(outbox), WithApprovalCustomers(customers))
+	defer service.Shutdown(context.Background())
+
+	if retail := createApprovalTestOrder(t, service, "retail-1"); retail.Status != omsv1.OrderStatus_ORDER_STATUS_PENDING {
+		t.Fatalf("orders of other customers must not wait for approval, got %s", retail.Status)
+	}
+	order := createApprovalTestOrder(t, service, "b2b-acme")
+	if order.Status != omsv1.OrderStatus_ORDER_STATUS_AWAITING_APPROVAL {
+		t.Fatalf("expected awaiting approval, got %s", order.Status)
+	}
+	if _, err := service.PayOrder(ctx, &omsv1.PayOrderRequest{OrderId: order.Id}); status.Code(err) != codes.FailedPrecondition {
+		t.Fatalf("expected PayOrder to wait for approval, got %v", err)
+	}
+	// До решения состав можно поправить.
+	if _, err := service.UpdateOrder(ctx, &omsv1.UpdateOrderRequest{OrderId: order.Id, Items: []*omsv1.OrderItem{{Id: order.Items[0].Id, Qty: 3}}}); err != nil {
+		t.Fatalf("UpdateOrder before approval failed: %v", err)
+	}
+
+	// Клиент не может согласовать заказ сам: ни approver в запросе, ни x-principal-id не заменяют оператора.
+	customerCtx := metadata.NewIncomingContext(context.Background(), metadata.Pairs(PrincipalMetadataKey, "b2b-acme"))
+	if _, err := service.ApproveOrder(customerCtx, &omsv1.ApproveOrderRequest{OrderId: order.Id, Approver: "b2b-acme"}); status.Code(err) != codes.Unauthenticated {
+		t.Fatalf("expected Unauthenticated without operator, got %v", err)
+	}
+	resp, err := service.ApproveOrder(ctx, &omsv1.ApproveOrderRequest{OrderId: order.Id, Comment: "budget ok"})
+	if err != nil {
+		t.Fatalf("ApproveOrder failed: %v", err)
+	}
+	if resp.Status != omsv1.OrderStatus_ORDER_STATUS_PENDING {
+		t.Fatalf("expected pending after approval, got %s", resp.Status)
+	}
+	if stored, _ := orders.Get(order.Id); stored.Status != domain.OrderStatusPending || stored.Version != 2 {
+		t.Fatalf("unexpected stored order: %+v", stored)
+	}
+
+	if _, err := service.ApproveOrder(ctx, &omsv1.ApproveOrderRequest{OrderId: order.Id}); status.Code(err) != codes.FailedPrecondition {
+		t.Fatalf("expected FailedPrecondition for repeated approval, got %v", err)
+	}
+	if _, err := service.UpdateOrder(ctx, &omsv1.UpdateOrderRequest{OrderId: order.Id, ExpectedVersion: 2, Items: []*omsv1.OrderItem{{Id: order.Items[0].Id, Qty: 1}}}); status.Code(err) != codes.FailedPrecondition {
+		t.Fatalf("approved order must not change, got %v", err)
+	}
+	if _, err := service.PayOrder(ctx, &omsv1.PayOrderRequest{OrderId: order.Id}); err != nil {
+		t.Fatalf("PayOrder after approval failed: %v", err)
+	}
+
+	events, err := timeline.List(order.Id)
+	if err != nil {
+		t.Fatalf("list timeline: %v", err)
+	}
+	var approved *domain.TimelineEvent
+	for i := range events {
+		if events[i].Type == timelineEventOrderApproved {
+			approved = &events[i]
+		}
+	}
+	if approved == nil || approved.Reason != "jane.doe: budget ok" {
+		t.Fatalf("expected OrderApproved in timeline, got %+v", events)
+	}
+
+	published := outboxPayloads(t, outbox)
+	if requested := published[timelineEventOrderApprovalRequested]; requested == nil || requested["customer_id"] != "b2b-acme" || requested["amount_minor"] != float64(1000) {
+		t.Fatalf("unexpected OrderApprovalRequested: %v", requested)
+	}
+	if event := published[timelineEventOrderApproved]; event == nil || event["approver"] != "jane.doe" || event["status"] != "pending" {
+		t.Fatalf("unexpected OrderApproved: %v", event)
+	}
+}
+
+func TestOrderService_RejectOrder(t *testing.T) {
+	ctx := operatorContext("jane.doe")
+	customers, err := ParseApprovalCustomers("b2b-acme, b2b-globex")
+	if err != nil {
+		t.Fatalf("parse approval customers: %v", err)
+	}
+	outbox := memory.NewOutboxRepository()
+	service := NewOrderService(memory.NewOrderRepository(), memory.NewTimelineRepository(), nil, saga.NewNoop(nil), nil,
+		WithOrderEvents(outbox), WithApprovalCustomers(customers))
+	defer service.Shutdown(context.Background())
+	order := createApprovalTestOrder(t, service, "b2b-globex")
+
+	if _, err := service.RejectOrder(context.Background(), &omsv1.RejectOrderRequest{OrderId: order.Id, Reason: "over budget"}); status.Code(err) != codes.Unauthenticated {
+		t.Fatalf("expected Unauthenticated without operator, got %v", err)
+	}
+	if _, err := service.RejectOrder(ctx, &omsv1.RejectOrderRequest{OrderId: order.Id}); status.Code(err) != codes.InvalidArgument {
+		t.Fatalf("expected InvalidArgument without reason, got %v", err)
+	}
+	resp, err := service.RejectOrder(ctx, &omsv1.RejectOrderRequest{OrderId: order.Id, Reason: "over budget"})
+	if err != nil {
+		t.Fatalf("RejectOrder failed: %v", err)
+	}
+	if resp.Status != omsv1.OrderStatus_ORDER_STATUS_CANCELED {
+		t.Fatalf("expected canceled after rejection, got %s", resp.Status)
+	}
+	if _, err := service.ApproveOrder(ctx, &omsv1.ApproveOrderRequest{OrderId: order.Id}); status.Code(err) != codes.FailedPrecondition {
+		t.Fatalf("expected FailedPrecondition for rejected order, got %v", err)
+	}
+	if event := outboxPayloads(t, outbox)[timelineEventOrderRejected]; event == nil || event["reason"] != "over budget" || event["status"] != "canceled" {
+		t.Fatalf("unexpected OrderRejected: %v", event)
+	}
+}
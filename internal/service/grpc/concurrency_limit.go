@@ -0,0 +1,136 @@
+package grpcsvc
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/vladislavdragonenkov/oms/internal/metrics"
+)
+
+// ErrInvalidConcurrencyLimit — лимиты одновременных запросов заданы некорректно.
+var ErrInvalidConcurrencyLimit = errors.New("grpcsvc: invalid concurrency limit")
+
+// ConcurrencyLimits — максимальное число одновременных запросов по методам. Ключ — короткое
+// имя метода ("CreateOrder") или полное ("/oms.v1.OrderService/CreateOrder"); полное имя
+// приоритетнее короткого.
+type ConcurrencyLimits map[string]int
+
+// ParseConcurrencyLimits разбирает лимиты в формате "CreateOrder=200,RefundOrder=50".
+func ParseConcurrencyLimits(raw string) (ConcurrencyLimits, error) {
+	limits := make(ConcurrencyLimits)
+	for _, entry := range strings.Split(raw, ",") {
+		entry = strings.TrimSpace(entry)
+		if entry == "" {
+			continue
+		}
+		method, value, found := strings.Cut(entry, "=")
+		method = strings.TrimSpace(method)
+		if !found || method == "" {
+			return nil, fmt.Errorf("%w: expected method=limit, got %q", ErrInvalidConcurrencyLimit, entry)
+		}
+		limit, err := strconv.Atoi(strings.TrimSpace(value))
+		if err != nil || limit <= 0 {
+			return nil, fmt.Errorf("%w: %s: limit must be a positive integer, got %q", ErrInvalidConcurrencyLimit, method, value)
+		}
+		if _, ok := limits[method]; ok {
+			return nil, fmt.Errorf("%w: duplicate limit for method %q", ErrInvalidConcurrencyLimit, method)
+		}
+		limits[method] = limit
+	}
+	return limits, nil
+}
+
+type concurrencyMetrics struct {
+	inFlight *prometheus.GaugeVec
+	limit    *prometheus.GaugeVec
+	rejected *prometheus.CounterVec
+}
+
+func newConcurrencyMetrics(registerer prometheus.Registerer) *concurrencyMetrics {
+	return &concurrencyMetrics{
+		inFlight: metrics.Register(registerer, prometheus.NewGaugeVec(prometheus.GaugeOpts{
+			Name: "oms_grpc_inflight_requests",
+			Help: "Number of unary gRPC requests currently being handled by method.",
+		}, []string{"method"})),
+		limit: metrics.Register(registerer, prometheus.NewGaugeVec(prometheus.GaugeOpts{
+			Name: "oms_grpc_concurrency_limit",
+			Help: "Configured maximum of concurrent unary gRPC requests by method.",
+		}, []string{"method"})),
+		rejected: metrics.Register(registerer, prometheus.NewCounterVec(prometheus.CounterOpts{
+			Name: "oms_grpc_concurrency_rejected_total",
+			Help: "Total number of unary gRPC requests rejected by the concurrency limit.",
+		}, []string{"method"})),
+	}
+}
+
+// ConcurrencyLimitOption настраивает UnaryConcurrencyLimitInterceptor.
+type ConcurrencyLimitOption func(*concurrencyLimitOptions)
+
+type concurrencyLimitOptions struct {
+	enabled func() bool
+}
+
+// WithConcurrencyLimitSwitch переключает лимиты без рестарта (фичефлаг shedding): пока enabled
+// возвращает false, запросы сверх лимита не отклоняются. In-flight считается в любом случае.
+func WithConcurrencyLimitSwitch(enabled func() bool) ConcurrencyLimitOption {
+	return func(opts *concurrencyLimitOptions) {
+		opts.enabled = enabled
+	}
+}
+
+// UnaryConcurrencyLimitInterceptor ограничивает число одновременно обрабатываемых запросов
+// по методам. Запрос сверх лимита не ждёт в очереди, а сразу получает ResourceExhausted:
+// ожидание держало бы соединение и дедлайн клиента, а повтор по RetryInfo разгружает сервис.
+// In-flight считается для всех методов, в том числе без лимита.
+func UnaryConcurrencyLimitInterceptor(limits ConcurrencyLimits, registerer prometheus.Registerer, opts ...ConcurrencyLimitOption) grpc.UnaryServerInterceptor {
+	var cfg concurrencyLimitOptions
+	for _, opt := range opts {
+		opt(&cfg)
+	}
+	m := newConcurrencyMetrics(registerer)
+	// Семафоры создаются по ключам конфигурации, а не по методам: разные полные имена
+	// с одним коротким ключом делят общий лимит.
+	semaphores := make(map[string]chan struct{}, len(limits))
+	for method, limit := range limits {
+		semaphores[method] = make(chan struct{}, limit)
+	}
+
+	return func(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+		inFlight := m.inFlight.WithLabelValues(info.FullMethod)
+		semaphore := semaphoreFor(semaphores, info.FullMethod)
+		if semaphore == nil || (cfg.enabled != nil && !cfg.enabled()) {
+			inFlight.Inc()
+			defer inFlight.Dec()
+			return handler(ctx, req)
+		}
+
+		m.limit.WithLabelValues(info.FullMethod).Set(float64(cap(semaphore)))
+		select {
+		case semaphore <- struct{}{}:
+		default:
+			m.rejected.WithLabelValues(info.FullMethod).Inc()
+			return nil, status.Errorf(codes.ResourceExhausted, "too many concurrent %s requests (limit %d)", info.FullMethod, cap(semaphore))
+		}
+		inFlight.Inc()
+		defer func() {
+			inFlight.Dec()
+			<-semaphore
+		}()
+		return handler(ctx, req)
+	}
+}
+
+func semaphoreFor(semaphores map[string]chan struct{}, fullMethod string) chan struct{} {
+	if semaphore, ok := semaphores[fullMethod]; ok {
+		return semaphore
+	}
+	return semaphores[fullMethod[strings.LastIndex(fullMethod, "/")+1:]]
+}
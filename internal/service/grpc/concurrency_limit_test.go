@@ -0,0 +1,109 @@
+package grpcsvc
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+)
+
+func TestParseConcurrencyLimits(t *testing.T) {
+	limits, err := ParseConcurrencyLimits(" CreateOrder=200, /oms.v1.OrderService/RefundOrder=50 ,")
+	if err != nil {
+		t.Fatalf("parse: %v", err)
+	}
+	if len(limits) != 2 || limits["CreateOrder"] != 200 || limits["/oms.v1.OrderService/RefundOrder"] != 50 {
+		t.Fatalf("unexpected limits: %v", limits)
+	}
+
+	for _, raw := range []string{"CreateOrder", "=5", "CreateOrder=0", "CreateOrder=abc", "CreateOrder=1,CreateOrder=2"} {
+		if _, err := ParseConcurrencyLimits(raw); !errors.Is(err, ErrInvalidConcurrencyLimit) {
+			t.Fatalf("%q: expected ErrInvalidConcurrencyLimit, got %v", raw, err)
+		}
+	}
+}
+
+func TestUnaryConcurrencyLimitInterceptor(t *testing.T) {
+	registry := prometheus.NewRegistry()
+	interceptor := UnaryConcurrencyLimitInterceptor(ConcurrencyLimits{"CreateOrder": 2}, registry)
+	m := newConcurrencyMetrics(registry)
+
+	createInfo := &grpc.UnaryServerInfo{FullMethod: grpcMethodCreateOrder}
+	entered := make(chan struct{})
+	release := make(chan struct{})
+	blocking := func(context.Context, any) (any, error) {
+		entered <- struct{}{}
+		<-release
+		return "ok", nil
+	}
+
+	done := make(chan error, 2)
+	for range 2 {
+		go func() {
+			_, err := interceptor(context.Background(), nil, createInfo, blocking)
+			done <- err
+		}()
+		<-entered
+	}
+
+	if got := testutil.ToFloat64(m.inFlight.WithLabelValues(grpcMethodCreateOrder)); got != 2 {
+		t.Fatalf("expected 2 in-flight requests, got %v", got)
+	}
+	_, err := interceptor(context.Background(), nil, createInfo, blocking)
+	mustStatusCode(t, err, codes.ResourceExhausted)
+	if got := testutil.ToFloat64(m.rejected.WithLabelValues(grpcMethodCreateOrder)); got != 1 {
+		t.Fatalf("expected 1 rejected request, got %v", got)
+	}
+	if got := testutil.ToFloat64(m.limit.WithLabelValues(grpcMethodCreateOrder)); got != 2 {
+		t.Fatalf("expected limit gauge 2, got %v", got)
+	}
+
+	// Методы без лимита не ограничиваются, но попадают в in-flight.
+	getInfo := &grpc.UnaryServerInfo{FullMethod: "/oms.v1.OrderService/GetOrder"}
+	if _, err := interceptor(context.Background(), nil, getInfo, func(context.Context, any) (any, error) {
+		if got := testutil.ToFloat64(m.inFlight.WithLabelValues(getInfo.FullMethod)); got != 1 {
+			t.Errorf("expected GetOrder in-flight 1, got %v", got)
+		}
+		return "ok", nil
+	}); err != nil {
+		t.Fatalf("unlimited method: %v", err)
+	}
+
+	close(release)
+	for range 2 {
+		if err := <-done; err != nil {
+			t.Fatalf("limited call: %v", err)
+		}
+	}
+	if got := testutil.ToFloat64(m.inFlight.WithLabelValues(grpcMethodCreateOrder)); got != 0 {
+		t.Fatalf("expected in-flight to drop to 0, got %v", got)
+	}
+
+	// Освободившийся слот снова доступен.
+	if _, err := interceptor(context.Background(), nil, createInfo, func(context.Context, any) (any, error) { return "ok", nil }); err != nil {
+		t.Fatalf("call after release: %v", err)
+	}
+}
+
+func TestUnaryConcurrencyLimitInterceptor_Switch(t *testing.T) {
+	enabled := false
+	interceptor := UnaryConcurrencyLimitInterceptor(ConcurrencyLimits{"CreateOrder": 1}, prometheus.NewRegistry(),
+		WithConcurrencyLimitSwitch(func() bool { return enabled }))
+	createInfo := &grpc.UnaryServerInfo{FullMethod: grpcMethodCreateOrder}
+	ok := func(context.Context, any) (any, error) { return "ok", nil }
+
+	// Вложенный вызов занимает второй слот при лимите 1: проходит, только пока лимиты сняты.
+	nested := func(ctx context.Context, _ any) (any, error) {
+		return interceptor(ctx, nil, createInfo, ok)
+	}
+	if _, err := interceptor(context.Background(), nil, createInfo, nested); err != nil {
+		t.Fatalf("disabled limits must not reject: %v", err)
+	}
+	enabled = true
+	_, err := interceptor(context.Background(), nil, createInfo, nested)
+	mustStatusCode(t, err, codes.ResourceExhausted)
+}
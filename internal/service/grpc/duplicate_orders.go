@@ -0,0 +1,173 @@
+package grpcsvc
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	log "github.com/sirupsen/logrus"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/vladislavdragonenkov/oms/internal/domain"
+	"github.com/vladislavdragonenkov/oms/internal/metrics"
+)
+
+// duplicateOrderScanLimit — сколько последних заказов покупателя сверяется с новым.
+const duplicateOrderScanLimit = 20
+
+// DuplicateOrderResourceType — ResourceInfo.resource_type в деталях AlreadyExists от CreateOrder.
+const DuplicateOrderResourceType = "oms.v1.Order"
+
+// duplicateOrders ищет недавний заказ с тем же покупателем и составом, чтобы повтор CreateOrder
+// без idempotency-key (клиент потерял ключ после таймаута) не создал двойника.
+type duplicateOrders struct {
+	window   time.Duration
+	rejected prometheus.Counter
+
+	mu sync.Mutex
+	// locks сериализует проверку и создание одинаковых заказов внутри процесса.
+	locks map[string]*fingerprintLock
+}
+
+type fingerprintLock struct {
+	mu   sync.Mutex
+	refs int
+}
+
+// WithDuplicateOrderWindow включает защиту от двойников: CreateOrder с тем же покупателем, валютой,
+// позициями и скидками, что у заказа, созданного не раньше window назад, возвращает AlreadyExists
+// с ID исходного заказа в errdetails.ResourceInfo вместо нового заказа. 0 — защита выключена.
+func WithDuplicateOrderWindow(window time.Duration, registerer prometheus.Registerer) OrderServiceOption {
+	return func(s *OrderService) {
+		if window <= 0 {
+			s.duplicates = nil
+			return
+		}
+		s.duplicates = &duplicateOrders{
+			window: window,
+			rejected: metrics.Register(registerer, prometheus.NewCounter(prometheus.CounterOpts{
+				Name: "oms_orders_duplicate_rejected_total",
+				Help: "CreateOrder calls rejected because an identical order of the customer was created within the duplicate window.",
+			})),
+			locks: make(map[string]*fingerprintLock),
+		}
+	}
+}
+
+// orderFingerprint — хэш покупателя и состава заказа. Порядок позиций не важен, ID и время — тоже.
+func orderFingerprint(order domain.Order) string {
+	items := make([]string, 0, len(order.Items))
+	for _, item := range order.Items {
+		items = append(items, item.SKU+"\x00"+strconv.FormatInt(int64(item.Qty), 10)+"\x00"+strconv.FormatInt(item.PriceMinor, 10))
+	}
+	slices.Sort(items)
+
+	var b strings.Builder
+	b.WriteString(order.CustomerID)
+	b.WriteString("\x01")
+	b.WriteString(order.Currency)
+	b.WriteString("\x01")
+	b.WriteString(strconv.FormatBool(order.TestMode))
+	b.WriteString("\x01")
+	b.WriteString(strings.Join(items, "\x01"))
+	for _, adj := range order.Pricing.Adjustments {
+		fmt.Fprintf(&b, "\x02%s\x00%s\x00%d\x00%d", adj.Type, adj.Code, adj.FixedMinor, adj.PercentScaled)
+	}
+	sum := sha256.Sum256([]byte(b.String()))
+	return hex.EncodeToString(sum[:])
+}
+
+// lock удерживает fingerprint до вызова возвращённой функции.
+func (d *duplicateOrders) lock(fingerprint string) func() {
+	d.mu.Lock()
+	l, ok := d.locks[fingerprint]
+	if !ok {
+		l = &fingerprintLock{}
+		d.locks[fingerprint] = l
+	}
+	l.refs++
+	d.mu.Unlock()
+
+	l.mu.Lock()
+	return func() {
+		l.mu.Unlock()
+		d.mu.Lock()
+		l.refs--
+		if l.refs == 0 {
+			delete(d.locks, fingerprint)
+		}
+		d.mu.Unlock()
+	}
+}
+
+// guardDuplicate возвращает ошибку AlreadyExists, если у покупателя есть такой же заказ моложе окна.
+// Иначе возвращает release, которую надо вызвать после сохранения нового заказа: до неё
+// одинаковый запрос этого инстанса ждёт и затем найдёт сохранённый заказ.
+func (s *OrderService) guardDuplicate(order domain.Order) (func(), error) {
+	if s.duplicates == nil {
+		return func() {}, nil
+	}
+	fingerprint := orderFingerprint(order)
+	release := s.duplicates.lock(fingerprint)
+
+	recent, err := s.repo.ListByCustomer(order.CustomerID, duplicateOrderScanLimit)
+	if err != nil {
+		// Проверка — страховка поверх idempotency-key; недоступность списка не должна ронять создание.
+		s.logger.WithError(err).WithField("customer_id", order.CustomerID).Warn("failed to check for duplicate orders")
+		return release, nil
+	}
+	since := order.CreatedAt.Add(-s.duplicates.window)
+	for _, existing := range recent {
+		if existing.CreatedAt.Before(since) {
+			break
+		}
+		if orderFingerprint(existing) != fingerprint {
+			continue
+		}
+		release()
+		s.duplicates.rejected.Inc()
+		s.logger.WithFields(log.Fields{
+			"customer_id":    order.CustomerID,
+			"original_order": existing.ID,
+		}).Info("identical order created recently, returning original")
+		return nil, duplicateOrderError(existing, order.CreatedAt)
+	}
+	return release, nil
+}
+
+// duplicateOrderError — AlreadyExists с ID исходного заказа в ResourceInfo.resource_name.
+func duplicateOrderError(original domain.Order, now time.Time) error {
+	age := now.Sub(original.CreatedAt).Truncate(time.Second)
+	st := status.New(codes.AlreadyExists, fmt.Sprintf("identical order %s was created %s ago", original.ID, age))
+	detailed, err := st.WithDetails(&errdetails.ResourceInfo{
+		ResourceType: DuplicateOrderResourceType,
+		ResourceName: original.ID,
+		Description:  "order with the same customer and items already exists; use it instead of creating a new one",
+	})
+	if err != nil {
+		return st.Err()
+	}
+	return detailed.Err()
+}
+
+// DuplicateOrderID извлекает ID исходного заказа из ошибки CreateOrder; false — ошибка не про двойника.
+func DuplicateOrderID(err error) (string, bool) {
+	st, ok := status.FromError(err)
+	if !ok || st.Code() != codes.AlreadyExists {
+		return "", false
+	}
+	for _, detail := range st.Details() {
+		if info, ok := detail.(*errdetails.ResourceInfo); ok && info.GetResourceType() == DuplicateOrderResourceType {
+			return info.GetResourceName(), true
+		}
+	}
+	return "", false
+}
@@ -0,0 +1,125 @@
+package grpcsvc
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/vladislavdragonenkov/oms/internal/metrics/metricstest"
+	"github.com/vladislavdragonenkov/oms/internal/service/saga"
+	"github.com/vladislavdragonenkov/oms/internal/storage/memory"
+	omsv1 "github.com/vladislavdragonenkov/oms/proto/oms/v1"
+)
+
+func duplicateOrderRequest(customer string, items ...*omsv1.OrderItem) *omsv1.CreateOrderRequest {
+	return &omsv1.CreateOrderRequest{CustomerId: customer, Currency: "USD", Items: items}
+}
+
+func duplicateOrderItem(sku string, qty int32) *omsv1.OrderItem {
+	return &omsv1.OrderItem{Sku: sku, Qty: qty, Price: &omsv1.Money{Currency: "USD", AmountMinor: 100}}
+}
+
+func TestOrderService_CreateOrder_ReturnsRecentDuplicate(t *testing.T) {
+	registry := prometheus.NewRegistry()
+	repo := memory.NewOrderRepository()
+	service := NewOrderService(repo, nil, nil, saga.NewNoop(nil), nil, WithDuplicateOrderWindow(time.Minute, registry))
+	defer service.Shutdown(context.Background())
+	ctx := context.Background()
+
+	first, err := service.CreateOrder(ctx, duplicateOrderRequest("c-1", duplicateOrderItem("A", 1), duplicateOrderItem("B", 2)))
+	if err != nil {
+		t.Fatalf("first order: %v", err)
+	}
+
+	// Тот же состав в другом порядке — тот же заказ.
+	_, err = service.CreateOrder(ctx, duplicateOrderRequest("c-1", duplicateOrderItem("B", 2), duplicateOrderItem("A", 1)))
+	if status.Code(err) != codes.AlreadyExists {
+		t.Fatalf("expected AlreadyExists, got %v", err)
+	}
+	if id, ok := DuplicateOrderID(err); !ok || id != first.GetOrder().GetId() {
+		t.Fatalf("expected original order id %s in details, got %q (%v)", first.GetOrder().GetId(), id, ok)
+	}
+	metricstest.RequireValue(t, registry, "oms_orders_duplicate_rejected_total", nil, 1)
+
+	for name, req := range map[string]*omsv1.CreateOrderRequest{
+		"other customer": duplicateOrderRequest("c-2", duplicateOrderItem("A", 1), duplicateOrderItem("B", 2)),
+		"other qty":      duplicateOrderRequest("c-1", duplicateOrderItem("A", 1), duplicateOrderItem("B", 3)),
+	} {
+		if _, err := service.CreateOrder(ctx, req); err != nil {
+			t.Fatalf("%s: expected new order, got %v", name, err)
+		}
+	}
+}
+
+func TestOrderService_CreateOrder_DuplicateWindowExpires(t *testing.T) {
+	repo := memory.NewOrderRepository()
+	service := NewOrderService(repo, nil, nil, saga.NewNoop(nil), nil, WithDuplicateOrderWindow(time.Minute, prometheus.NewRegistry()))
+	defer service.Shutdown(context.Background())
+
+	created, err := service.CreateOrder(context.Background(), duplicateOrderRequest("c-1", duplicateOrderItem("A", 1)))
+	if err != nil {
+		t.Fatalf("create: %v", err)
+	}
+	old, err := repo.Get(created.GetOrder().GetId())
+	if err != nil {
+		t.Fatalf("get: %v", err)
+	}
+	old.CreatedAt = old.CreatedAt.Add(-2 * time.Minute)
+	if err := repo.Save(old); err != nil {
+		t.Fatalf("save: %v", err)
+	}
+
+	if _, err := service.CreateOrder(context.Background(), duplicateOrderRequest("c-1", duplicateOrderItem("A", 1))); err != nil {
+		t.Fatalf("order outside the window must be created, got %v", err)
+	}
+}
+
+func TestOrderService_CreateOrder_ConcurrentDuplicates(t *testing.T) {
+	repo := memory.NewOrderRepository()
+	service := NewOrderService(repo, nil, nil, saga.NewNoop(nil), nil, WithDuplicateOrderWindow(time.Minute, prometheus.NewRegistry()))
+	defer service.Shutdown(context.Background())
+
+	const callers = 8
+	var wg sync.WaitGroup
+	errs := make(chan error, callers)
+	for range callers {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			_, err := service.CreateOrder(context.Background(), duplicateOrderRequest("c-1", duplicateOrderItem("A", 1)))
+			errs <- err
+		}()
+	}
+	wg.Wait()
+	close(errs)
+
+	created := 0
+	for err := range errs {
+		switch status.Code(err) {
+		case codes.OK:
+			created++
+		case codes.AlreadyExists:
+		default:
+			t.Fatalf("unexpected error: %v", err)
+		}
+	}
+	if created != 1 {
+		t.Fatalf("expected exactly one order, got %d", created)
+	}
+}
+
+func TestOrderService_CreateOrder_DuplicatesAllowedByDefault(t *testing.T) {
+	service := NewOrderService(memory.NewOrderRepository(), nil, nil, saga.NewNoop(nil), nil)
+	defer service.Shutdown(context.Background())
+
+	for i := range 2 {
+		if _, err := service.CreateOrder(context.Background(), duplicateOrderRequest("c-1", duplicateOrderItem("A", 1))); err != nil {
+			t.Fatalf("order %d: %v", i, err)
+		}
+	}
+}
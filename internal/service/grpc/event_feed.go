@@ -0,0 +1,114 @@
+package grpcsvc
+
+import (
+	"context"
+	"encoding/base64"
+	"strconv"
+	"strings"
+	"time"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/vladislavdragonenkov/oms/internal/domain"
+	omsv1 "github.com/vladislavdragonenkov/oms/proto/oms/v1"
+)
+
+const (
+	defaultEventFeedLimit = 100
+	maxEventFeedLimit     = 1000
+	// defaultEventFeedSettle — насколько лента отстаёт от текущего времени. created_at назначается
+	// до коммита, и запись с меньшим created_at может стать видимой позже соседней; без задержки
+	// курсор перешагнул бы её и событие было бы потеряно.
+	defaultEventFeedSettle = 5 * time.Second
+)
+
+// WithEventFeedSettle задаёт отставание ленты GetEventsSince от текущего времени.
+func WithEventFeedSettle(settle time.Duration) AdminServiceOption {
+	return func(s *AdminService) {
+		s.feedSettle = settle
+	}
+}
+
+// GetEventsSince отдаёт события outbox после курсора в порядке (created_at, id). Клиент сохраняет
+// next_cursor после обработки пачки: при сбое пачка придёт повторно (at-least-once), дубликаты
+// отсекаются по id. Опубликованные события хранятся, пока их не удалит cleanup outbox.
+func (s *AdminService) GetEventsSince(_ context.Context, req *omsv1.GetEventsSinceRequest) (*omsv1.GetEventsSinceResponse, error) {
+	if req == nil {
+		return nil, status.Error(codes.InvalidArgument, "request is required")
+	}
+	feed, ok := s.outbox.(domain.OutboxFeedSource)
+	if !ok {
+		return nil, status.Error(codes.Unimplemented, "event feed is not supported by outbox storage")
+	}
+
+	limit := int(req.Limit)
+	switch {
+	case limit < 0:
+		return nil, status.Error(codes.InvalidArgument, "limit must be >= 0")
+	case limit == 0:
+		limit = defaultEventFeedLimit
+	case limit > maxEventFeedLimit:
+		limit = maxEventFeedLimit
+	}
+
+	afterAt, afterID, err := decodeFeedCursor(req.Cursor)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, "invalid cursor")
+	}
+
+	messages, err := feed.ListEvents(domain.OutboxReplayFilter{
+		To:             time.Now().UTC().Add(-s.feedSettle),
+		AggregateType:  strings.TrimSpace(req.AggregateType),
+		AfterCreatedAt: afterAt,
+		AfterID:        afterID,
+		Limit:          limit,
+	})
+	if err != nil {
+		s.logger.WithError(err).Error("failed to list outbox events")
+		return nil, status.Error(codes.Internal, "failed to list events")
+	}
+
+	resp := &omsv1.GetEventsSinceResponse{
+		Events:     make([]*omsv1.FeedEvent, 0, len(messages)),
+		NextCursor: req.Cursor,
+	}
+	for _, msg := range messages {
+		resp.Events = append(resp.Events, &omsv1.FeedEvent{
+			Id:                msg.ID,
+			AggregateType:     msg.AggregateType,
+			AggregateId:       msg.AggregateID,
+			EventType:         msg.EventType,
+			Payload:           msg.Payload,
+			CreatedAtUnixNano: msg.CreatedAt.UnixNano(),
+		})
+	}
+	if n := len(messages); n > 0 {
+		resp.NextCursor = encodeFeedCursor(messages[n-1].CreatedAt, messages[n-1].ID)
+	}
+	return resp, nil
+}
+
+// Курсор непрозрачен для клиента: base64url("<unix nano>:<id>").
+func encodeFeedCursor(createdAt time.Time, id string) string {
+	return base64.RawURLEncoding.EncodeToString([]byte(strconv.FormatInt(createdAt.UnixNano(), 10) + ":" + id))
+}
+
+func decodeFeedCursor(cursor string) (time.Time, string, error) {
+	if cursor == "" {
+		return time.Time{}, "", nil
+	}
+	raw, err := base64.RawURLEncoding.DecodeString(cursor)
+	if err != nil {
+		return time.Time{}, "", err
+	}
+	nanos, id, ok := strings.Cut(string(raw), ":")
+	if !ok || id == "" {
+		return time.Time{}, "", strconv.ErrSyntax
+	}
+	unixNano, err := strconv.ParseInt(nanos, 10, 64)
+	if err != nil {
+		return time.Time{}, "", err
+	}
+	return time.Unix(0, unixNano).UTC(), id, nil
+}
@@ -0,0 +1,112 @@
+package grpcsvc
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/vladislavdragonenkov/oms/internal/domain"
+	"github.com/vladislavdragonenkov/oms/internal/storage/memory"
+	omsv1 "github.com/vladislavdragonenkov/oms/proto/oms/v1"
+)
+
+func TestAdminService_GetEventsSince(t *testing.T) {
+	outbox := memory.NewOutboxRepository()
+	for _, msg := range []domain.OutboxMessage{
+		{ID: "evt-1", AggregateType: "order", AggregateID: "order-1", EventType: "OrderCreated", Payload: []byte(`{"id":"order-1"}`)},
+		{ID: "evt-2", AggregateType: "customer", AggregateID: "c-1", EventType: EventCustomerDataErased, Payload: []byte(`{}`)},
+		{ID: "evt-3", AggregateType: "order", AggregateID: "order-2", EventType: "OrderCreated", Payload: []byte(`{"id":"order-2"}`)},
+	} {
+		if _, err := outbox.Enqueue(msg); err != nil {
+			t.Fatalf("enqueue: %v", err)
+		}
+		time.Sleep(time.Millisecond)
+	}
+	// Отправленные и ожидающие события одинаково попадают в ленту.
+	if err := outbox.MarkSent("evt-1"); err != nil {
+		t.Fatal(err)
+	}
+	service := NewAdminService(nil, nil, outbox, nil, WithEventFeedSettle(0))
+	ctx := context.Background()
+
+	first, err := service.GetEventsSince(ctx, &omsv1.GetEventsSinceRequest{Limit: 2})
+	if err != nil {
+		t.Fatalf("first page: %v", err)
+	}
+	if got := feedEventIDs(first); len(got) != 2 || got[0] != "evt-1" || got[1] != "evt-2" {
+		t.Fatalf("unexpected first page: %v", got)
+	}
+	if string(first.Events[0].Payload) != `{"id":"order-1"}` || first.Events[0].CreatedAtUnixNano == 0 {
+		t.Fatalf("unexpected event: %+v", first.Events[0])
+	}
+
+	second, err := service.GetEventsSince(ctx, &omsv1.GetEventsSinceRequest{Cursor: first.NextCursor, Limit: 2})
+	if err != nil {
+		t.Fatalf("second page: %v", err)
+	}
+	if got := feedEventIDs(second); len(got) != 1 || got[0] != "evt-3" {
+		t.Fatalf("unexpected second page: %v", got)
+	}
+
+	// На конце ленты курсор не меняется: клиент опрашивает с ним же.
+	empty, err := service.GetEventsSince(ctx, &omsv1.GetEventsSinceRequest{Cursor: second.NextCursor})
+	if err != nil {
+		t.Fatalf("empty page: %v", err)
+	}
+	if len(empty.Events) != 0 || empty.NextCursor != second.NextCursor {
+		t.Fatalf("unexpected empty page: %+v", empty)
+	}
+
+	orders, err := service.GetEventsSince(ctx, &omsv1.GetEventsSinceRequest{AggregateType: "order"})
+	if err != nil {
+		t.Fatalf("filtered page: %v", err)
+	}
+	if got := feedEventIDs(orders); len(got) != 2 || got[0] != "evt-1" || got[1] != "evt-3" {
+		t.Fatalf("unexpected filtered page: %v", got)
+	}
+
+	settled := NewAdminService(nil, nil, outbox, nil, WithEventFeedSettle(time.Hour))
+	recent, err := settled.GetEventsSince(ctx, &omsv1.GetEventsSinceRequest{})
+	if err != nil {
+		t.Fatalf("settled page: %v", err)
+	}
+	if len(recent.Events) != 0 {
+		t.Fatalf("events newer than settle window must not be returned: %v", feedEventIDs(recent))
+	}
+}
+
+func TestAdminService_GetEventsSince_Errors(t *testing.T) {
+	ctx := context.Background()
+	service := NewAdminService(nil, nil, memory.NewOutboxRepository(), nil)
+	tests := []struct {
+		name    string
+		service *AdminService
+		req     *omsv1.GetEventsSinceRequest
+		code    codes.Code
+	}{
+		{name: "nil request", service: service, code: codes.InvalidArgument},
+		{name: "negative limit", service: service, req: &omsv1.GetEventsSinceRequest{Limit: -1}, code: codes.InvalidArgument},
+		{name: "malformed cursor", service: service, req: &omsv1.GetEventsSinceRequest{Cursor: "%%%"}, code: codes.InvalidArgument},
+		{name: "cursor without id", service: service, req: &omsv1.GetEventsSinceRequest{Cursor: "MTIz"}, code: codes.InvalidArgument},
+		{name: "not configured", service: NewAdminService(nil, nil, nil, nil), req: &omsv1.GetEventsSinceRequest{}, code: codes.Unimplemented},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := tt.service.GetEventsSince(ctx, tt.req)
+			if code := status.Code(err); code != tt.code {
+				t.Fatalf("expected %s, got %s (%v)", tt.code, code, err)
+			}
+		})
+	}
+}
+
+func feedEventIDs(resp *omsv1.GetEventsSinceResponse) []string {
+	ids := make([]string, 0, len(resp.Events))
+	for _, event := range resp.Events {
+		ids = append(ids, event.Id)
+	}
+	return ids
+}
@@ -0,0 +1,88 @@
+package grpcsvc
+
+import (
+	"context"
+	"strings"
+
+	"github.com/google/uuid"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+
+	"github.com/vladislavdragonenkov/oms/internal/domain"
+	"github.com/vladislavdragonenkov/oms/internal/messaging/kafka"
+)
+
+// eventHeaderKeys — metadata RPC, которая переносится в headers событий outbox.
+// Имена совпадают с headers Kafka-сообщений, поэтому копируются без преобразования.
+var eventHeaderKeys = []string{kafka.HeaderTraceParent, kafka.HeaderTraceState, kafka.HeaderTenantID, domain.EventHeaderSynthetic}
+
+// requestIDHeader — ID запроса от gateway; используется как correlation, если клиент не передал
+// x-correlation-id.
+const requestIDHeader = "x-request-id"
+
+// UnaryEventHeadersInterceptor кладёт trace context и tenant из входящей metadata в контекст
+// (domain.ContextWithEventHeaders), чтобы события, записанные в outbox по этому RPC и его саге,
+// дошли до Kafka с теми же значениями. Там же начинается цепочка событий запроса
+// (domain.ContextWithEventChain); её correlation ID возвращается клиенту в header x-correlation-id.
+func UnaryEventHeadersInterceptor() grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req any, _ *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+		ctx = contextWithEventChain(contextWithEventHeaders(ctx))
+		if correlationID, _ := domain.EventChainFromContext(ctx).IDs(); correlationID != "" {
+			// Вне gRPC-сервера (тесты, прямой вызов) header отправить некуда — это не ошибка.
+			_ = grpc.SetHeader(ctx, metadata.Pairs(domain.EventHeaderCorrelationID, correlationID))
+		}
+		return handler(ctx, req)
+	}
+}
+
+// contextWithEventChain берёт correlation из x-correlation-id, затем x-request-id, иначе создаёт
+// новый; causation — из x-causation-id, если запрос сам вызван событием другой системы.
+func contextWithEventChain(ctx context.Context) context.Context {
+	correlationID := firstIncoming(ctx, domain.EventHeaderCorrelationID, requestIDHeader)
+	if correlationID == "" {
+		correlationID = uuid.NewString()
+	}
+	return domain.ContextWithEventChain(ctx, correlationID, firstIncoming(ctx, domain.EventHeaderCausationID))
+}
+
+// AppendEventChainMetadata добавляет correlation и causation цепочки контекста в исходящую
+// metadata, чтобы команды в другие сервисы продолжили ту же цепочку событий.
+func AppendEventChainMetadata(ctx context.Context) context.Context {
+	correlationID, causationID := domain.EventChainFromContext(ctx).IDs()
+	if correlationID == "" {
+		return ctx
+	}
+	return metadata.AppendToOutgoingContext(ctx,
+		domain.EventHeaderCorrelationID, correlationID,
+		domain.EventHeaderCausationID, causationID,
+	)
+}
+
+func firstIncoming(ctx context.Context, keys ...string) string {
+	md, ok := metadata.FromIncomingContext(ctx)
+	if !ok {
+		return ""
+	}
+	for _, key := range keys {
+		if values := md.Get(key); len(values) > 0 {
+			if value := strings.TrimSpace(values[0]); value != "" {
+				return value
+			}
+		}
+	}
+	return ""
+}
+
+func contextWithEventHeaders(ctx context.Context) context.Context {
+	md, ok := metadata.FromIncomingContext(ctx)
+	if !ok {
+		return ctx
+	}
+	headers := make(map[string]string, len(eventHeaderKeys))
+	for _, key := range eventHeaderKeys {
+		if values := md.Get(key); len(values) > 0 {
+			headers[key] = strings.TrimSpace(values[0])
+		}
+	}
+	return domain.ContextWithEventHeaders(ctx, headers)
+}
@@ -0,0 +1,80 @@
+package grpcsvc
+
+import (
+	"context"
+	"testing"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+
+	"github.com/vladislavdragonenkov/oms/internal/domain"
+)
+
+func TestUnaryEventHeadersInterceptor_CopiesTraceAndTenant(t *testing.T) {
+	ctx := metadata.NewIncomingContext(context.Background(), metadata.Pairs(
+		"traceparent", "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01",
+		"x-tenant-id", " tenant-a ",
+		"x-synthetic", "canary",
+		"authorization", "Bearer secret",
+	))
+
+	var got map[string]string
+	_, err := UnaryEventHeadersInterceptor()(ctx, nil, &grpc.UnaryServerInfo{}, func(ctx context.Context, _ any) (any, error) {
+		got = domain.EventHeadersFromContext(ctx)
+		return nil, nil
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if len(got) != 3 || got["traceparent"] != "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01" || got["x-tenant-id"] != "tenant-a" || got["x-synthetic"] != "canary" {
+		t.Fatalf("unexpected event headers: %v", got)
+	}
+}
+
+func TestUnaryEventHeadersInterceptor_NoMetadata(t *testing.T) {
+	_, _ = UnaryEventHeadersInterceptor()(context.Background(), nil, &grpc.UnaryServerInfo{}, func(ctx context.Context, _ any) (any, error) {
+		if headers := domain.EventHeadersFromContext(ctx); headers != nil {
+			t.Fatalf("expected no event headers, got %v", headers)
+		}
+		return nil, nil
+	})
+}
+
+func TestUnaryEventHeadersInterceptor_EventChain(t *testing.T) {
+	run := func(ctx context.Context) (correlationID, causationID string, outgoing metadata.MD) {
+		_, err := UnaryEventHeadersInterceptor()(ctx, nil, &grpc.UnaryServerInfo{}, func(ctx context.Context, _ any) (any, error) {
+			correlationID, causationID = domain.EventChainFromContext(ctx).IDs()
+			outgoing, _ = metadata.FromOutgoingContext(AppendEventChainMetadata(ctx))
+			return nil, nil
+		})
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		return correlationID, causationID, outgoing
+	}
+
+	correlationID, causationID, outgoing := run(metadata.NewIncomingContext(context.Background(), metadata.Pairs(
+		"x-request-id", "req-1",
+		"x-causation-id", "evt-0",
+	)))
+	if correlationID != "req-1" || causationID != "evt-0" {
+		t.Fatalf("expected chain req-1/evt-0, got %s/%s", correlationID, causationID)
+	}
+	if outgoing.Get("x-correlation-id")[0] != "req-1" || outgoing.Get("x-causation-id")[0] != "evt-0" {
+		t.Fatalf("chain must be appended to outgoing metadata: %v", outgoing)
+	}
+
+	// x-correlation-id важнее x-request-id; без causation первым событием цепочки управляет запрос.
+	correlationID, causationID, _ = run(metadata.NewIncomingContext(context.Background(), metadata.Pairs(
+		"x-request-id", "req-1",
+		"x-correlation-id", "corr-1",
+	)))
+	if correlationID != "corr-1" || causationID != "corr-1" {
+		t.Fatalf("expected chain corr-1/corr-1, got %s/%s", correlationID, causationID)
+	}
+
+	if correlationID, _, _ = run(context.Background()); correlationID == "" {
+		t.Fatalf("correlation id must be generated for requests without one")
+	}
+}
@@ -0,0 +1,131 @@
+package grpcsvc
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	log "github.com/sirupsen/logrus"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/vladislavdragonenkov/oms/internal/domain"
+	"github.com/vladislavdragonenkov/oms/internal/metrics"
+	omsv1 "github.com/vladislavdragonenkov/oms/proto/oms/v1"
+)
+
+// timelineEventOrderStatusForced — статус заказа изменён оператором в обход саги; то же имя
+// у события в outbox.
+const timelineEventOrderStatusForced = "OrderStatusForced"
+
+func newOrderForcedTransitionsTotal(registerer prometheus.Registerer) *prometheus.CounterVec {
+	return metrics.Register(registerer, prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: "oms_order_forced_transitions_total",
+		Help: "Total number of order status transitions forced by operators via AdminService, by source and target status.",
+	}, []string{"from", "to"}))
+}
+
+// WithOrderTransitions включает ForceTransition. RPC дополнительно требует оператора,
+// аутентифицированного UnaryAdminAuthInterceptor.
+func WithOrderTransitions(orders domain.OrderRepository) AdminServiceOption {
+	return func(s *AdminService) {
+		s.orders = orders
+		s.forceTransitions = true
+	}
+}
+
+// ForceTransition переводит зависший заказ в target_status без саги и проверок переходов:
+// резервы, платежи и компенсации не трогаются, их оператор исправляет отдельно. Смена статуса
+// пишется в timeline (OrderStatusChanged и OrderStatusForced с оператором и причиной) и в outbox.
+// Сага, которая ещё ведёт заказ, может перезаписать статус, поэтому RPC предназначен для заказов,
+// чья сага остановилась.
+func (s *AdminService) ForceTransition(ctx context.Context, req *omsv1.ForceTransitionRequest) (*omsv1.ForceTransitionResponse, error) {
+	if req == nil {
+		return nil, status.Error(codes.InvalidArgument, "request is required")
+	}
+	orderID, err := parseOrderIDArg(strings.TrimSpace(req.OrderId))
+	if err != nil {
+		return nil, err
+	}
+	target, ok := fromProtoStatus(req.TargetStatus)
+	if !ok {
+		return nil, status.Error(codes.InvalidArgument, "target_status is required")
+	}
+	reason := strings.TrimSpace(req.Reason)
+	if reason == "" {
+		return nil, status.Error(codes.InvalidArgument, "reason is required")
+	}
+	if !s.forceTransitions || s.orders == nil {
+		return nil, status.Error(codes.Unimplemented, "forced transitions are not configured")
+	}
+	operator := AdminOperatorFromContext(ctx)
+	if operator == "" {
+		return nil, status.Error(codes.PermissionDenied, "forced transitions require admin authentication")
+	}
+
+	order, err := s.orders.Get(orderID.String())
+	if err != nil {
+		if errors.Is(err, domain.ErrOrderNotFound) {
+			return nil, status.Error(codes.NotFound, "order not found")
+		}
+		s.logger.WithError(err).WithField("order_id", orderID).Error("failed to load order for forced transition")
+		return nil, status.Error(codes.Internal, "failed to load order")
+	}
+	from := order.Status
+	if err := order.ForceStatus(target, reason); err != nil {
+		return nil, status.Errorf(codes.FailedPrecondition, "order %s status=%s: %s", order.ID, order.Status, err.Error())
+	}
+	order.UpdatedAt = time.Now().UTC()
+	if err := s.orders.Save(order); err != nil {
+		if errors.Is(err, domain.ErrOrderVersionConflict) {
+			return nil, status.Error(codes.Aborted, "order was modified concurrently, retry")
+		}
+		s.logger.WithError(err).WithField("order_id", orderID).Error("failed to save forced transition")
+		return nil, status.Error(codes.Internal, "failed to save order")
+	}
+	order.Version++
+	s.forcedTransitions.WithLabelValues(string(from), string(target)).Inc()
+
+	s.appendForcedTransitionTimeline(order, from, operator, reason)
+	writeOrderEvent(ctx, s.outbox, s.logger, order, timelineEventOrderStatusForced, map[string]interface{}{
+		"status":          order.Status,
+		"previous_status": from,
+		"operator":        operator,
+		"reason":          reason,
+	})
+
+	s.logger.WithFields(log.Fields{
+		"audit":    true,
+		"action":   "force_transition",
+		"order_id": order.ID,
+		"from":     from,
+		"to":       order.Status,
+		"operator": operator,
+		"reason":   reason,
+	}).Warn("order status forced")
+
+	return &omsv1.ForceTransitionResponse{Order: toProtoOrder(order), PreviousStatus: toProtoStatus(from)}, nil
+}
+
+// appendForcedTransitionTimeline пишет смену статуса и аудит: "pending -> canceled by alice: INC-42".
+func (s *AdminService) appendForcedTransitionTimeline(order domain.Order, from domain.OrderStatus, operator, reason string) {
+	if s.timeline == nil {
+		return
+	}
+	events := []domain.TimelineEvent{
+		{OrderID: order.ID, Type: timelineEventOrderStatusChanged, Reason: string(order.Status), Occurred: order.UpdatedAt},
+		{
+			OrderID:  order.ID,
+			Type:     timelineEventOrderStatusForced,
+			Reason:   string(from) + " -> " + string(order.Status) + " by " + operator + ": " + reason,
+			Occurred: order.UpdatedAt,
+		},
+	}
+	for _, event := range events {
+		if err := s.timeline.Append(event); err != nil {
+			s.logger.WithError(err).WithField("order_id", order.ID).Warn("failed to append forced transition timeline event")
+		}
+	}
+}
@@ -0,0 +1,112 @@
+package grpcsvc
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+
+	"github.com/vladislavdragonenkov/oms/internal/domain"
+	"github.com/vladislavdragonenkov/oms/internal/storage/memory"
+	omsv1 "github.com/vladislavdragonenkov/oms/proto/oms/v1"
+)
+
+func TestAdminService_ForceTransition(t *testing.T) {
+	orders := memory.NewOrderRepository()
+	timeline := memory.NewTimelineRepository()
+	outbox := memory.NewOutboxRepository()
+	if err := orders.Create(domain.Order{
+		ID:          "o-1",
+		CustomerID:  "alice",
+		Status:      domain.OrderStatusReserved,
+		Currency:    "RUB",
+		AmountMinor: 500,
+		Items:       []domain.OrderItem{{ID: "i-1", SKU: "SKU-1", Qty: 1, PriceMinor: 500}},
+		CreatedAt:   time.Now().UTC(),
+	}); err != nil {
+		t.Fatalf("Create failed: %v", err)
+	}
+	registry := prometheus.NewRegistry()
+	service := NewAdminService(nil, timeline, outbox, nil, WithOrderTransitions(orders), WithAdminRegisterer(registry))
+	interceptor := UnaryAdminAuthInterceptor(newAdminKeyring(t, "oncall-bob:s3cr3t"))
+	info := &grpc.UnaryServerInfo{FullMethod: omsv1.AdminService_ForceTransition_FullMethodName}
+	force := func(ctx context.Context, req *omsv1.ForceTransitionRequest) (*omsv1.ForceTransitionResponse, error) {
+		resp, err := interceptor(ctx, req, info, func(ctx context.Context, req any) (any, error) {
+			return service.ForceTransition(ctx, req.(*omsv1.ForceTransitionRequest))
+		})
+		if err != nil {
+			return nil, err
+		}
+		return resp.(*omsv1.ForceTransitionResponse), nil
+	}
+	authCtx := metadata.NewIncomingContext(context.Background(), metadata.Pairs(AdminAuthMetadataKey, "Bearer s3cr3t"))
+	req := &omsv1.ForceTransitionRequest{OrderId: "o-1", TargetStatus: omsv1.OrderStatus_ORDER_STATUS_CANCELED, Reason: "INC-42 stuck reserve"}
+
+	_, err := force(context.Background(), req)
+	mustStatusCode(t, err, codes.Unauthenticated)
+	// В обход interceptor'а оператора нет — RPC отказывает сам.
+	_, err = service.ForceTransition(context.Background(), req)
+	mustStatusCode(t, err, codes.PermissionDenied)
+	_, err = force(authCtx, &omsv1.ForceTransitionRequest{OrderId: "o-1", Reason: "no target"})
+	mustStatusCode(t, err, codes.InvalidArgument)
+	_, err = force(authCtx, &omsv1.ForceTransitionRequest{OrderId: "o-1", TargetStatus: omsv1.OrderStatus_ORDER_STATUS_CANCELED, Reason: "  "})
+	mustStatusCode(t, err, codes.InvalidArgument)
+	_, err = force(authCtx, &omsv1.ForceTransitionRequest{OrderId: "missing", TargetStatus: omsv1.OrderStatus_ORDER_STATUS_CANCELED, Reason: "x"})
+	mustStatusCode(t, err, codes.NotFound)
+
+	resp, err := force(authCtx, req)
+	if err != nil {
+		t.Fatalf("ForceTransition failed: %v", err)
+	}
+	if resp.PreviousStatus != omsv1.OrderStatus_ORDER_STATUS_RESERVED || resp.Order.Status != omsv1.OrderStatus_ORDER_STATUS_CANCELED {
+		t.Fatalf("unexpected response: %v", resp)
+	}
+	if stored, _ := orders.Get("o-1"); stored.Status != domain.OrderStatusCanceled || stored.Version != 1 {
+		t.Fatalf("unexpected stored order: %+v", stored)
+	}
+	_, err = force(authCtx, req)
+	mustStatusCode(t, err, codes.FailedPrecondition)
+
+	events, err := timeline.List("o-1")
+	if err != nil {
+		t.Fatalf("list timeline: %v", err)
+	}
+	var audit string
+	for _, event := range events {
+		if event.Type == timelineEventOrderStatusForced {
+			audit = event.Reason
+		}
+	}
+	if audit != "reserved -> canceled by oncall-bob: INC-42 stuck reserve" {
+		t.Fatalf("unexpected audit timeline: %+v", events)
+	}
+
+	msgs, err := outbox.PullPending(10)
+	if err != nil || len(msgs) != 1 || msgs[0].EventType != timelineEventOrderStatusForced || msgs[0].AggregateID != "o-1" {
+		t.Fatalf("expected one OrderStatusForced event, got %+v err=%v", msgs, err)
+	}
+	var payload map[string]interface{}
+	if err := json.Unmarshal(msgs[0].Payload, &payload); err != nil {
+		t.Fatalf("decode payload: %v", err)
+	}
+	if payload["operator"] != "oncall-bob" || payload["previous_status"] != "reserved" || payload["status"] != "canceled" {
+		t.Fatalf("unexpected payload: %v", payload)
+	}
+	if got := testutil.ToFloat64(service.forcedTransitions.WithLabelValues("reserved", "canceled")); got != 1 {
+		t.Fatalf("expected 1 forced transition, got %v", got)
+	}
+}
+
+func TestAdminService_ForceTransition_NotConfigured(t *testing.T) {
+	service := NewAdminService(nil, nil, nil, nil)
+	_, err := service.ForceTransition(context.Background(), &omsv1.ForceTransitionRequest{
+		OrderId: "o-1", TargetStatus: omsv1.OrderStatus_ORDER_STATUS_CANCELED, Reason: "x",
+	})
+	mustStatusCode(t, err, codes.Unimplemented)
+}
@@ -0,0 +1,88 @@
+package grpcsvc
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/vladislavdragonenkov/oms/internal/domain"
+	omsv1 "github.com/vladislavdragonenkov/oms/proto/oms/v1"
+)
+
+// GetOrders возвращает заказы по списку ID: найденные и отсутствующие отдельно. Хранилище
+// с domain.OrderBatchReader читает их одним запросом, остальные — по одному через Get.
+// Timeline не отдаётся: он читается отдельным запросом на каждый заказ.
+func (s *OrderService) GetOrders(_ context.Context, req *omsv1.GetOrdersRequest) (*omsv1.GetOrdersResponse, error) {
+	ids, err := s.batchOrderIDs(req.GetOrderIds())
+	if err != nil {
+		return nil, err
+	}
+
+	orders, err := s.getOrders(ids)
+	if err != nil {
+		s.logger.WithError(err).WithField("orders", len(ids)).Warn("failed to load orders batch")
+		return nil, status.Error(codes.Internal, "failed to load orders")
+	}
+
+	resp := &omsv1.GetOrdersResponse{Orders: make([]*omsv1.Order, 0, len(orders))}
+	found := make(map[string]struct{}, len(orders))
+	for _, order := range orders {
+		found[order.ID] = struct{}{}
+		resp.Orders = append(resp.Orders, toProtoOrder(order))
+	}
+	for _, id := range ids {
+		if _, ok := found[id]; !ok {
+			resp.MissingOrderIds = append(resp.MissingOrderIds, id)
+		}
+	}
+	return resp, nil
+}
+
+// batchOrderIDs проверяет ID и убирает повторы, сохраняя порядок запроса. Размер пакета
+// ограничен тем же максимумом, что и страница ListOrders.
+func (s *OrderService) batchOrderIDs(raw []string) ([]string, error) {
+	if len(raw) == 0 {
+		return nil, status.Error(codes.InvalidArgument, "order_ids is required")
+	}
+	ids := make([]string, 0, len(raw))
+	seen := make(map[string]struct{}, len(raw))
+	for _, value := range raw {
+		id, err := parseOrderIDArg(value)
+		if err != nil {
+			return nil, err
+		}
+		if _, ok := seen[id.String()]; ok {
+			continue
+		}
+		seen[id.String()] = struct{}{}
+		ids = append(ids, id.String())
+	}
+	if len(ids) > s.pageLimits.Max {
+		return nil, status.Errorf(codes.InvalidArgument, "order_ids has %d ids, maximum is %d", len(ids), s.pageLimits.Max)
+	}
+	return ids, nil
+}
+
+func (s *OrderService) getOrders(ids []string) ([]domain.Order, error) {
+	if reader, ok := s.repo.(domain.OrderBatchReader); ok {
+		return reader.GetMany(ids)
+	}
+
+	orders := make([]domain.Order, 0, len(ids))
+	for _, id := range ids {
+		order, err := s.repo.Get(id)
+		if errors.Is(err, domain.ErrOrderNotFound) {
+			continue
+		}
+		if err != nil {
+			return nil, fmt.Errorf("get order %s: %w", id, err)
+		}
+		orders = append(orders, order)
+	}
+	sort.Slice(orders, func(i, j int) bool { return orders[i].ID < orders[j].ID })
+	return orders, nil
+}
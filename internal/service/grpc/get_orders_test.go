@@ -0,0 +1,87 @@
+package grpcsvc
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+
+	log "github.com/sirupsen/logrus"
+	"google.golang.org/grpc/codes"
+
+	"github.com/vladislavdragonenkov/oms/internal/domain"
+	"github.com/vladislavdragonenkov/oms/internal/storage/memory"
+	omsv1 "github.com/vladislavdragonenkov/oms/proto/oms/v1"
+)
+
+func orderIDs(orders []*omsv1.Order) []string {
+	ids := make([]string, 0, len(orders))
+	for _, order := range orders {
+		ids = append(ids, order.GetId())
+	}
+	return ids
+}
+
+func TestGetOrders_FoundAndMissing(t *testing.T) {
+	repo := memory.NewOrderRepository()
+	for _, id := range []string{"order-2", "order-1"} {
+		if err := repo.Create(domain.Order{ID: id, CustomerID: "customer-1", Status: domain.OrderStatusPending, Currency: "USD"}); err != nil {
+			t.Fatalf("create %s: %v", id, err)
+		}
+	}
+	service := NewOrderService(repo, memory.NewTimelineRepository(), nil, nil, log.New().WithField("test", "get-orders"))
+
+	resp, err := service.GetOrders(context.Background(), &omsv1.GetOrdersRequest{
+		OrderIds: []string{"order-2", "order-9", "order-1", "order-2", "order-8"},
+	})
+	if err != nil {
+		t.Fatalf("GetOrders failed: %v", err)
+	}
+	if got := orderIDs(resp.GetOrders()); !reflect.DeepEqual(got, []string{"order-1", "order-2"}) {
+		t.Fatalf("unexpected orders %v", got)
+	}
+	if !reflect.DeepEqual(resp.GetMissingOrderIds(), []string{"order-9", "order-8"}) {
+		t.Fatalf("unexpected missing ids %v", resp.GetMissingOrderIds())
+	}
+}
+
+func TestGetOrders_FallsBackToGet(t *testing.T) {
+	var calls int
+	repo := &stubOrderRepository{getFn: func(id string) (domain.Order, error) {
+		calls++
+		if id == "order-1" {
+			return domain.Order{ID: id, Status: domain.OrderStatusPaid}, nil
+		}
+		return domain.Order{}, domain.ErrOrderNotFound
+	}}
+	service := NewOrderService(repo, &stubTimelineRepository{}, nil, nil, log.New().WithField("test", "get-orders"))
+
+	resp, err := service.GetOrders(context.Background(), &omsv1.GetOrdersRequest{OrderIds: []string{"order-2", "order-1"}})
+	if err != nil {
+		t.Fatalf("GetOrders failed: %v", err)
+	}
+	if calls != 2 || !reflect.DeepEqual(orderIDs(resp.GetOrders()), []string{"order-1"}) || !reflect.DeepEqual(resp.GetMissingOrderIds(), []string{"order-2"}) {
+		t.Fatalf("unexpected response after %d gets: %v", calls, resp)
+	}
+
+	repo.getFn = func(string) (domain.Order, error) { return domain.Order{}, errors.New("db down") }
+	_, err = service.GetOrders(context.Background(), &omsv1.GetOrdersRequest{OrderIds: []string{"order-1"}})
+	mustStatusCode(t, err, codes.Internal)
+}
+
+func TestGetOrders_ValidatesIDs(t *testing.T) {
+	service := NewOrderService(&stubOrderRepository{}, &stubTimelineRepository{}, nil, nil, log.New().WithField("test", "get-orders"),
+		WithPageLimits(PageLimits{Default: 1, Max: 2}))
+	ctx := context.Background()
+
+	_, err := service.GetOrders(ctx, &omsv1.GetOrdersRequest{})
+	mustStatusCode(t, err, codes.InvalidArgument)
+	_, err = service.GetOrders(ctx, &omsv1.GetOrdersRequest{OrderIds: []string{"order-1", "bad id"}})
+	mustStatusCode(t, err, codes.InvalidArgument)
+	_, err = service.GetOrders(ctx, &omsv1.GetOrdersRequest{OrderIds: []string{"order-1", "order-2", "order-3"}})
+	mustStatusCode(t, err, codes.InvalidArgument)
+	// Повторы не считаются в лимит.
+	if _, err := service.GetOrders(ctx, &omsv1.GetOrdersRequest{OrderIds: []string{"order-1", "order-2", "order-1"}}); err != nil {
+		t.Fatalf("duplicates must collapse before the limit check: %v", err)
+	}
+}
@@ -0,0 +1,59 @@
+package grpcsvc
+
+import (
+	"context"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"google.golang.org/grpc"
+
+	"github.com/vladislavdragonenkov/oms/internal/metrics"
+)
+
+// Исходы запроса с idempotency-key в oms_idempotency_requests_total.
+const (
+	// idempotencyOutcomeFirst — ключ увиден впервые, запрос выполнен.
+	idempotencyOutcomeFirst = "first"
+	// idempotencyOutcomeReplay — возвращён сохранённый ответ (успех или ошибка) без повторного выполнения.
+	idempotencyOutcomeReplay = "replay"
+	// idempotencyOutcomeInProgress — запрос с тем же ключом ещё выполняется.
+	idempotencyOutcomeInProgress = "in_progress"
+	// idempotencyOutcomeMismatch — ключ уже использован с другим payload.
+	idempotencyOutcomeMismatch = "payload_mismatch"
+)
+
+func newIdempotencyRequestsTotal(registerer prometheus.Registerer) *prometheus.CounterVec {
+	return metrics.Register(registerer, prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: "oms_idempotency_requests_total",
+		Help: "Total number of requests with idempotency-key grouped by gRPC method and outcome.",
+	}, []string{"method", "outcome"}))
+}
+
+type idempotencyOutcomeContextKey struct{}
+
+// idempotencyOutcome заполняет withIdempotency, читает UnaryIdempotencyMetricsInterceptor.
+type idempotencyOutcome struct {
+	value string
+}
+
+func recordIdempotencyOutcome(ctx context.Context, outcome string) {
+	if holder, ok := ctx.Value(idempotencyOutcomeContextKey{}).(*idempotencyOutcome); ok {
+		holder.value = outcome
+	}
+}
+
+// UnaryIdempotencyMetricsInterceptor считает запросы с idempotency-key по методу и исходу:
+// первый запрос, повтор, повтор во время выполнения, повтор с другим payload. Рост replay
+// показывает, как часто клиенты повторяют вызовы; payload_mismatch — интеграции, которые
+// переиспользуют ключи. Запросы, не прошедшие через idempotency, не учитываются.
+func UnaryIdempotencyMetricsInterceptor(registerer prometheus.Registerer) grpc.UnaryServerInterceptor {
+	requests := newIdempotencyRequestsTotal(registerer)
+
+	return func(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+		holder := &idempotencyOutcome{}
+		resp, err := handler(context.WithValue(ctx, idempotencyOutcomeContextKey{}, holder), req)
+		if holder.value != "" {
+			requests.WithLabelValues(info.FullMethod, holder.value).Inc()
+		}
+		return resp, err
+	}
+}
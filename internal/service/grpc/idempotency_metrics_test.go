@@ -0,0 +1,61 @@
+package grpcsvc
+
+import (
+	"context"
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	log "github.com/sirupsen/logrus"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+
+	"github.com/vladislavdragonenkov/oms/internal/storage/memory"
+	omsv1 "github.com/vladislavdragonenkov/oms/proto/oms/v1"
+)
+
+func TestUnaryIdempotencyMetricsInterceptor(t *testing.T) {
+	registry := prometheus.NewRegistry()
+	service := NewOrderService(memory.NewOrderRepository(), memory.NewTimelineRepository(), memory.NewIdempotencyRepository(), nil, log.New().WithField("test", "idempotency-metrics"))
+	interceptor := UnaryIdempotencyMetricsInterceptor(registry)
+	info := &grpc.UnaryServerInfo{FullMethod: grpcMethodCreateOrder}
+	createOrder := func(ctx context.Context, req any) (any, error) {
+		return service.CreateOrder(ctx, req.(*omsv1.CreateOrderRequest))
+	}
+	call := func(key string, req *omsv1.CreateOrderRequest) error {
+		ctx := metadata.NewIncomingContext(context.Background(), metadata.Pairs(idempotencyKeyHeader, key))
+		_, err := interceptor(ctx, req, info, createOrder)
+		return err
+	}
+
+	if err := call("key-1", validCreateRequest()); err != nil {
+		t.Fatalf("first call: %v", err)
+	}
+	for range 2 {
+		if err := call("key-1", validCreateRequest()); err != nil {
+			t.Fatalf("replay: %v", err)
+		}
+	}
+	changed := validCreateRequest()
+	changed.Items[0].Qty++
+	mustStatusCode(t, call("key-1", changed), codes.AlreadyExists)
+	// Без ключа запрос отклоняется до idempotency и в метрику не попадает.
+	_, err := interceptor(context.Background(), validCreateRequest(), info, createOrder)
+	mustStatusCode(t, err, codes.InvalidArgument)
+
+	requests := newIdempotencyRequestsTotal(registry)
+	if got := testutil.CollectAndCount(requests); got != 3 {
+		t.Fatalf("expected 3 label combinations, got %d", got)
+	}
+	for outcome, want := range map[string]float64{
+		idempotencyOutcomeFirst:      1,
+		idempotencyOutcomeReplay:     2,
+		idempotencyOutcomeMismatch:   1,
+		idempotencyOutcomeInProgress: 0,
+	} {
+		if got := testutil.ToFloat64(requests.WithLabelValues(grpcMethodCreateOrder, outcome)); got != want {
+			t.Fatalf("outcome %s: got %v, want %v", outcome, got, want)
+		}
+	}
+}
@@ -0,0 +1,27 @@
+package grpcsvc
+
+import (
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/vladislavdragonenkov/oms/internal/domain"
+)
+
+// parseOrderIDArg проверяет order_id из запроса: пустой или некорректный ID — InvalidArgument
+// до обращения к хранилищу и idempotency-слою.
+func parseOrderIDArg(raw string) (domain.OrderID, error) {
+	id, err := domain.ParseOrderID(raw)
+	if err != nil {
+		return "", status.Error(codes.InvalidArgument, err.Error())
+	}
+	return id, nil
+}
+
+// parseCustomerIDArg — то же для customer_id.
+func parseCustomerIDArg(raw string) (domain.CustomerID, error) {
+	id, err := domain.ParseCustomerID(raw)
+	if err != nil {
+		return "", status.Error(codes.InvalidArgument, err.Error())
+	}
+	return id, nil
+}
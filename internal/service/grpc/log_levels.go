@@ -0,0 +1,75 @@
+package grpcsvc
+
+import (
+	"context"
+	"strings"
+
+	log "github.com/sirupsen/logrus"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/vladislavdragonenkov/oms/internal/logging"
+	omsv1 "github.com/vladislavdragonenkov/oms/proto/oms/v1"
+)
+
+// WithLogLevels включает GetLogLevels и SetLogLevel поверх уровней, установленных logging.Install.
+func WithLogLevels(levels *logging.Levels) AdminServiceOption {
+	return func(s *AdminService) {
+		s.logLevels = levels
+	}
+}
+
+// GetLogLevels возвращает текущие уровни логирования.
+func (s *AdminService) GetLogLevels(context.Context, *omsv1.GetLogLevelsRequest) (*omsv1.LogLevels, error) {
+	if s.logLevels == nil {
+		return nil, status.Error(codes.Unimplemented, "runtime log levels are not enabled")
+	}
+	return toProtoLogLevels(s.logLevels.Config()), nil
+}
+
+// SetLogLevel меняет общий уровень или уровень компонента. Изменение живёт до SIGHUP
+// (перечитывание конфигурации) или рестарта.
+func (s *AdminService) SetLogLevel(_ context.Context, req *omsv1.SetLogLevelRequest) (*omsv1.LogLevels, error) {
+	if req == nil {
+		return nil, status.Error(codes.InvalidArgument, "request is required")
+	}
+	if s.logLevels == nil {
+		return nil, status.Error(codes.Unimplemented, "runtime log levels are not enabled")
+	}
+
+	component := strings.TrimSpace(req.Component)
+	rawLevel := strings.ToLower(strings.TrimSpace(req.Level))
+	if rawLevel == "" {
+		if component == "" {
+			return nil, status.Error(codes.InvalidArgument, "level is required for the global log level")
+		}
+		s.logLevels.ResetComponent(component)
+	} else {
+		level, err := log.ParseLevel(rawLevel)
+		if err != nil {
+			return nil, status.Errorf(codes.InvalidArgument, "invalid level %q", req.Level)
+		}
+		if component == "" {
+			s.logLevels.SetGlobal(level)
+		} else {
+			s.logLevels.SetComponent(component, level)
+		}
+	}
+
+	cfg := s.logLevels.Config()
+	// Пишем на warn, чтобы запись об изменении не отфильтровалась только что заданным уровнем.
+	s.logger.WithFields(log.Fields{
+		"target_component": component,
+		"level":            rawLevel,
+		"levels":           cfg.String(),
+	}).Warn("log level changed")
+	return toProtoLogLevels(cfg), nil
+}
+
+func toProtoLogLevels(cfg logging.Config) *omsv1.LogLevels {
+	resp := &omsv1.LogLevels{Global: cfg.Global.String(), Components: make(map[string]string, len(cfg.Components))}
+	for component, level := range cfg.Components {
+		resp.Components[component] = level.String()
+	}
+	return resp
+}
@@ -0,0 +1,71 @@
+package grpcsvc
+
+import (
+	"context"
+	"io"
+	"testing"
+
+	log "github.com/sirupsen/logrus"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/vladislavdragonenkov/oms/internal/logging"
+	"github.com/vladislavdragonenkov/oms/internal/storage/memory"
+	omsv1 "github.com/vladislavdragonenkov/oms/proto/oms/v1"
+)
+
+func TestAdminService_SetLogLevel(t *testing.T) {
+	logger := log.New()
+	logger.SetOutput(io.Discard)
+	levels := logging.Install(logger, logging.Config{Global: log.InfoLevel})
+	service := NewAdminService(nil, memory.NewTimelineRepository(), memory.NewOutboxRepository(), nil, WithLogLevels(levels))
+	ctx := context.Background()
+
+	resp, err := service.SetLogLevel(ctx, &omsv1.SetLogLevelRequest{Component: "saga", Level: "DEBUG"})
+	if err != nil {
+		t.Fatalf("SetLogLevel failed: %v", err)
+	}
+	if resp.Global != "info" || resp.Components["saga"] != "debug" {
+		t.Fatalf("unexpected levels: %v", resp)
+	}
+	if !levels.Enabled("saga", log.DebugLevel) || levels.Enabled("kafka", log.DebugLevel) {
+		t.Fatal("expected debug only for saga")
+	}
+
+	if _, err := service.SetLogLevel(ctx, &omsv1.SetLogLevelRequest{Level: "warn"}); err != nil {
+		t.Fatalf("SetLogLevel global failed: %v", err)
+	}
+	if _, err := service.SetLogLevel(ctx, &omsv1.SetLogLevelRequest{Component: "saga"}); err != nil {
+		t.Fatalf("SetLogLevel reset failed: %v", err)
+	}
+
+	got, err := service.GetLogLevels(ctx, &omsv1.GetLogLevelsRequest{})
+	if err != nil {
+		t.Fatalf("GetLogLevels failed: %v", err)
+	}
+	if got.Global != "warning" || len(got.Components) != 0 {
+		t.Fatalf("unexpected levels after reset: %v", got)
+	}
+}
+
+func TestAdminService_SetLogLevel_Errors(t *testing.T) {
+	ctx := context.Background()
+	disabled := NewAdminService(nil, memory.NewTimelineRepository(), memory.NewOutboxRepository(), nil)
+	if _, err := disabled.GetLogLevels(ctx, &omsv1.GetLogLevelsRequest{}); status.Code(err) != codes.Unimplemented {
+		t.Fatalf("expected Unimplemented, got %v", err)
+	}
+
+	logger := log.New()
+	logger.SetOutput(io.Discard)
+	service := NewAdminService(nil, memory.NewTimelineRepository(), memory.NewOutboxRepository(), nil,
+		WithLogLevels(logging.Install(logger, logging.Config{Global: log.InfoLevel})))
+	for name, req := range map[string]*omsv1.SetLogLevelRequest{
+		"unknown level":      {Component: "saga", Level: "loud"},
+		"global reset":       {},
+		"blank global level": {Level: "  "},
+	} {
+		if _, err := service.SetLogLevel(ctx, req); status.Code(err) != codes.InvalidArgument {
+			t.Fatalf("%s: expected InvalidArgument, got %v", name, err)
+		}
+	}
+}
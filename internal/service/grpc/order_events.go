@@ -0,0 +1,57 @@
+package grpcsvc
+
+import (
+	"context"
+	"encoding/json"
+
+	log "github.com/sirupsen/logrus"
+
+	"github.com/vladislavdragonenkov/oms/internal/domain"
+	"github.com/vladislavdragonenkov/oms/internal/timeutil"
+)
+
+// WithOrderEvents задаёт outbox для событий, которые сервис публикует сам, без саги
+// (OrderUpdated, события согласования). Без него такие изменения попадают только в timeline.
+func WithOrderEvents(outbox domain.OutboxRepository) OrderServiceOption {
+	return func(s *OrderService) {
+		s.events = outbox
+	}
+}
+
+// enqueueOrderEvent пишет событие заказа в outbox. payload дополняется order_id, ts и, для
+// sandbox-заказов, test_mode — как у событий саги. Сбой outbox только логируется: заказ уже
+// сохранён, а изменение видно в timeline.
+func (s *OrderService) enqueueOrderEvent(ctx context.Context, order domain.Order, eventType string, payload map[string]interface{}) {
+	writeOrderEvent(ctx, s.events, s.logger, order, eventType, payload)
+}
+
+// writeOrderEvent — общая часть enqueueOrderEvent для сервисов, меняющих заказ в обход саги.
+func writeOrderEvent(ctx context.Context, outbox domain.OutboxRepository, logger *log.Entry, order domain.Order, eventType string, payload map[string]interface{}) {
+	if outbox == nil {
+		return
+	}
+	payload["order_id"] = order.ID
+	payload["ts"] = timeutil.Format(order.UpdatedAt)
+	if order.TestMode {
+		payload["test_mode"] = true
+	}
+	logger = logger.WithFields(log.Fields{"order_id": order.ID, "event": eventType})
+	data, err := json.Marshal(payload)
+	if err != nil {
+		logger.WithError(err).Error("marshal order event failed")
+		return
+	}
+
+	stored, err := outbox.Enqueue(domain.OutboxMessage{
+		AggregateType: "order",
+		AggregateID:   order.ID,
+		EventType:     eventType,
+		Payload:       data,
+		Headers:       domain.OutboxHeadersFromContext(ctx),
+	})
+	if err != nil {
+		logger.WithError(err).Error("enqueue order event failed")
+		return
+	}
+	domain.EventChainFromContext(ctx).Advance(stored.ID)
+}
@@ -0,0 +1,50 @@
+package grpcsvc
+
+import (
+	"github.com/vladislavdragonenkov/oms/internal/domain"
+	"github.com/vladislavdragonenkov/oms/internal/service/ordercache"
+	omsv1 "github.com/vladislavdragonenkov/oms/proto/oms/v1"
+)
+
+// WithOrderListCache отдаёт ListOrders из кэша. Созданный заказ сбрасывает список своего
+// покупателя сразу; остальные изменения кэш узнаёт из timeline (ordercache.ListCache.WatchTimeline).
+func WithOrderListCache(cache *ordercache.ListCache) OrderServiceOption {
+	return func(s *OrderService) {
+		s.listCache = cache
+	}
+}
+
+// WithOrderListCacheSwitch переключает кэш ListOrders без рестарта (фичефлаг read_cache):
+// пока enabled возвращает false, списки читаются из хранилища. Инвалидация продолжается,
+// поэтому после включения кэш не отдаёт устаревшие списки.
+func WithOrderListCacheSwitch(enabled func() bool) OrderServiceOption {
+	return func(s *OrderService) {
+		s.listCacheEnabled = enabled
+	}
+}
+
+func (s *OrderService) useListCache() bool {
+	return s.listCache != nil && (s.listCacheEnabled == nil || s.listCacheEnabled())
+}
+
+// cachedCustomerOrders читает список через кэш. Загрузка идёт целиком через ListByCustomer:
+// кэшу нужен доменный список, поэтому стриминг хранилища здесь не используется.
+func (s *OrderService) cachedCustomerOrders(customerID domain.CustomerID, limit int) ([]*omsv1.Order, error) {
+	orders, err := s.listCache.Get(customerID.String(), limit, func() ([]domain.Order, error) {
+		return s.repo.ListByCustomer(customerID.String(), limit)
+	})
+	if err != nil {
+		return nil, err
+	}
+	result := make([]*omsv1.Order, 0, len(orders))
+	for _, order := range orders {
+		result = append(result, toProtoOrder(order))
+	}
+	return result, nil
+}
+
+func (s *OrderService) invalidateCustomerOrders(customerID string) {
+	if s.listCache != nil {
+		s.listCache.Invalidate(customerID, ordercache.SourceWrite)
+	}
+}
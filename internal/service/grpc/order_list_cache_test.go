@@ -0,0 +1,89 @@
+package grpcsvc
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	log "github.com/sirupsen/logrus"
+
+	"github.com/vladislavdragonenkov/oms/internal/domain"
+	"github.com/vladislavdragonenkov/oms/internal/service/ordercache"
+	omsv1 "github.com/vladislavdragonenkov/oms/proto/oms/v1"
+)
+
+func TestListOrders_ServedFromCacheUntilCreate(t *testing.T) {
+	var stored []domain.Order
+	lists := 0
+	repo := &stubOrderRepository{
+		createFn: func(order domain.Order) error {
+			stored = append(stored, order)
+			return nil
+		},
+		listFn: func(string, int) ([]domain.Order, error) {
+			lists++
+			return append([]domain.Order(nil), stored...), nil
+		},
+	}
+	cache := ordercache.NewListCache(time.Minute, ordercache.WithRegisterer(prometheus.NewRegistry()))
+	service := NewOrderService(repo, &stubTimelineRepository{}, nil, nil,
+		log.New().WithField("test", "order-list-cache"), WithOrderListCache(cache))
+	ctx := context.Background()
+	list := func() int {
+		t.Helper()
+		resp, err := service.ListOrders(ctx, &omsv1.ListOrdersRequest{CustomerId: "customer-1"})
+		if err != nil {
+			t.Fatalf("ListOrders failed: %v", err)
+		}
+		return len(resp.Orders)
+	}
+
+	if got := list(); got != 0 {
+		t.Fatalf("expected empty list, got %d", got)
+	}
+	list()
+	if lists != 1 {
+		t.Fatalf("repeated ListOrders must hit the cache, repository reads=%d", lists)
+	}
+
+	if _, err := service.CreateOrder(ctx, validCreateRequest()); err != nil {
+		t.Fatalf("CreateOrder failed: %v", err)
+	}
+	if got := list(); got != 1 || lists != 2 {
+		t.Fatalf("created order must invalidate the customer list, got %d orders after %d reads", got, lists)
+	}
+}
+
+func TestListOrders_CacheSwitchBypassesCache(t *testing.T) {
+	lists := 0
+	repo := &stubOrderRepository{
+		listFn: func(string, int) ([]domain.Order, error) {
+			lists++
+			return nil, nil
+		},
+	}
+	enabled := false
+	cache := ordercache.NewListCache(time.Minute, ordercache.WithRegisterer(prometheus.NewRegistry()))
+	service := NewOrderService(repo, &stubTimelineRepository{}, nil, nil,
+		log.New().WithField("test", "order-list-cache"),
+		WithOrderListCache(cache), WithOrderListCacheSwitch(func() bool { return enabled }))
+	list := func() {
+		t.Helper()
+		if _, err := service.ListOrders(context.Background(), &omsv1.ListOrdersRequest{CustomerId: "customer-1"}); err != nil {
+			t.Fatalf("ListOrders failed: %v", err)
+		}
+	}
+
+	list()
+	list()
+	if lists != 2 {
+		t.Fatalf("disabled cache must read the repository every time, reads=%d", lists)
+	}
+	enabled = true
+	list()
+	list()
+	if lists != 3 {
+		t.Fatalf("enabled cache must serve repeated reads, reads=%d", lists)
+	}
+}
@@ -9,9 +9,11 @@ import (
 	"fmt"
 	"strings"
 	"sync"
+	"sync/atomic"
 	"time"
 
 	"github.com/google/uuid"
+	"github.com/prometheus/client_golang/prometheus"
 	log "github.com/sirupsen/logrus"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/metadata"
@@ -20,7 +22,10 @@ import (
 	"google.golang.org/protobuf/proto"
 
 	"github.com/vladislavdragonenkov/oms/internal/domain"
+	"github.com/vladislavdragonenkov/oms/internal/metrics"
+	"github.com/vladislavdragonenkov/oms/internal/service/ordercache"
 	"github.com/vladislavdragonenkov/oms/internal/service/saga"
+	"github.com/vladislavdragonenkov/oms/internal/timeutil"
 	omsv1 "github.com/vladislavdragonenkov/oms/proto/oms/v1"
 )
 
@@ -31,29 +36,103 @@ type OrderService struct {
 	repo     domain.OrderRepository
 	timeline domain.TimelineRepository
 	idemRepo domain.IdempotencyRepository
-	logger   *log.Entry
-	saga     saga.Orchestrator
-
-	sagaMu     sync.Mutex
-	sagaClosed bool
-	sagaWG     sync.WaitGroup
+	orderUoW domain.OrderUnitOfWork
+	// dispatchRepo сохраняет намерения запуска саг; nil — саги запускаются без записи.
+	dispatchRepo domain.SagaDispatchRepository
+	logger       *log.Entry
+	saga         saga.Orchestrator
+	watcher      TimelineWatcher
+	// listCache кэширует ListOrders; nil — каждый запрос читает хранилище.
+	listCache *ordercache.ListCache
+	// listCacheEnabled переключает кэш на лету; nil — кэш включён всегда.
+	listCacheEnabled func() bool
+
+	// quotaRepo и quotas ограничивают CreateOrder дневными квотами principal'ов; nil — без квот.
+	quotaRepo domain.QuotaRepository
+	quotas    OrderQuotas
+	// duplicates возвращает недавний такой же заказ вместо создания двойника; nil — проверка выключена.
+	duplicates *duplicateOrders
+	// scheduledCancels хранит отложенные отмены; nil — ScheduleCancel недоступен.
+	scheduledCancels domain.ScheduledCancelRepository
+	// returns — возвраты позиций (CreateReturn); nil — RPC возвратов недоступны.
+	returns *orderReturns
+	// events — outbox для событий, которые сервис пишет без саги (OrderUpdated); nil — только timeline.
+	events domain.OutboxRepository
+	// approvals — клиенты, чьи заказы ждут ApproveOrder перед обработкой; пусто — согласование выключено.
+	approvals ApprovalCustomers
+	// transitions считает смены статуса, которые сервис делает сам, без саги.
+	transitions *metrics.OrderTransitionMetrics
+	pageLimits  PageLimits
+
+	sagaTimeout atomic.Int64
+	sagaMu      sync.Mutex
+	sagaClosed  bool
+	sagaWG      sync.WaitGroup
+	// sagaActive — фоновые задачи саг в sagaWG; по нему отчёт об остановке считает недождавшиеся саги.
+	sagaActive atomic.Int64
 
 	idempotencyWarnOnce sync.Once
 }
 
 const (
-	grpcMethodCreateOrder = "/oms.v1.OrderService/CreateOrder"
-	grpcMethodPayOrder    = "/oms.v1.OrderService/PayOrder"
-	grpcMethodCancelOrder = "/oms.v1.OrderService/CancelOrder"
-	grpcMethodRefundOrder = "/oms.v1.OrderService/RefundOrder"
-
-	defaultListOrdersLimit = 100
+	grpcMethodCreateOrder  = "/oms.v1.OrderService/CreateOrder"
+	grpcMethodPayOrder     = "/oms.v1.OrderService/PayOrder"
+	grpcMethodCaptureOrder = "/oms.v1.OrderService/CaptureOrder"
+	grpcMethodCancelOrder  = "/oms.v1.OrderService/CancelOrder"
+	grpcMethodRefundOrder  = "/oms.v1.OrderService/RefundOrder"
+	grpcMethodHoldOrder    = "/oms.v1.OrderService/HoldOrder"
+	grpcMethodReleaseOrder = "/oms.v1.OrderService/ReleaseOrder"
 
 	timelineEventOrderStatusChanged = "OrderStatusChanged"
 	timelineEventOrderCanceled      = "OrderCanceled"
 	timelineEventOrderRefunded      = "OrderRefunded"
+	timelineEventOrderHeld          = "OrderHeld"
+	timelineEventOrderReleased      = "OrderReleased"
 )
 
+// sagaDispatchLease — на сколько реплика закрепляет за собой намерение саги. Пока сага идёт,
+// аренда продлевается; намерение с истёкшей арендой осталось от остановленной реплики.
+const sagaDispatchLease = time.Minute
+
+// OrderServiceOption настраивает OrderService.
+type OrderServiceOption func(*OrderService)
+
+// WithOrderUnitOfWork включает запись заказа и MarkDone одной транзакцией в CreateOrder.
+func WithOrderUnitOfWork(uow domain.OrderUnitOfWork) OrderServiceOption {
+	return func(s *OrderService) {
+		s.orderUoW = uow
+	}
+}
+
+// WithSagaDispatchRepository сохраняет намерения запуска саг до ответа клиенту,
+// чтобы их можно было повторить через ReplaySagaIntents после рестарта.
+func WithSagaDispatchRepository(repo domain.SagaDispatchRepository) OrderServiceOption {
+	return func(s *OrderService) {
+		s.dispatchRepo = repo
+	}
+}
+
+// WithOrderRegisterer задаёт реестр метрик OrderService; по умолчанию — глобальный.
+func WithOrderRegisterer(registerer prometheus.Registerer) OrderServiceOption {
+	return func(s *OrderService) {
+		s.transitions = metrics.NewOrderTransitionMetrics(registerer)
+	}
+}
+
+// WithSagaTimeout ограничивает время фонового выполнения саги после ответа клиенту.
+func WithSagaTimeout(timeout time.Duration) OrderServiceOption {
+	return func(s *OrderService) {
+		s.SetSagaTimeout(timeout)
+	}
+}
+
+// SetSagaTimeout меняет ограничение для саг, запущенных после вызова; неположительное значение игнорируется.
+func (s *OrderService) SetSagaTimeout(timeout time.Duration) {
+	if timeout > 0 {
+		s.sagaTimeout.Store(int64(timeout))
+	}
+}
+
 // NewOrderService конструирует сервис с зависимостями.
 func NewOrderService(
 	repo domain.OrderRepository,
@@ -61,17 +140,26 @@ func NewOrderService(
 	idemRepo domain.IdempotencyRepository,
 	orchestrator saga.Orchestrator,
 	logger *log.Entry,
+	options ...OrderServiceOption,
 ) *OrderService {
 	if logger == nil {
 		logger = log.New().WithField("component", "order-service")
 	}
-	return &OrderService{
+	s := &OrderService{
 		repo:     repo,
 		timeline: timeline,
 		idemRepo: idemRepo,
 		saga:     orchestrator,
 		logger:   logger,
+
+		transitions: metrics.NewOrderTransitionMetrics(nil),
+		pageLimits:  DefaultPageLimits(),
+	}
+	s.sagaTimeout.Store(int64(saga.DefaultTimeout))
+	for _, option := range options {
+		option(s)
 	}
+	return s
 }
 
 // CreateOrder создаёт заказ и запускает обработку.
@@ -85,6 +173,7 @@ func (s *OrderService) CreateOrder(ctx context.Context, req *omsv1.CreateOrderRe
 		ctx,
 		grpcMethodCreateOrder,
 		req,
+		requestCustomer(req.CustomerId),
 		func() *omsv1.CreateOrderResponse { return &omsv1.CreateOrderResponse{} },
 		func(ctx context.Context) (*omsv1.CreateOrderResponse, error) {
 			return s.createOrderInternal(ctx, req)
@@ -92,12 +181,18 @@ func (s *OrderService) CreateOrder(ctx context.Context, req *omsv1.CreateOrderRe
 	)
 }
 
-func (s *OrderService) createOrderInternal(_ context.Context, req *omsv1.CreateOrderRequest) (*omsv1.CreateOrderResponse, error) {
+func (s *OrderService) createOrderInternal(ctx context.Context, req *omsv1.CreateOrderRequest) (*omsv1.CreateOrderResponse, error) {
 	if req == nil {
 		return nil, status.Error(codes.InvalidArgument, "request is required")
 	}
-	if req.CustomerId == "" {
-		return nil, status.Error(codes.InvalidArgument, "customer_id is required")
+	// Полные правила полей проверяет UnaryValidationInterceptor по buf.validate в proto.
+	// Проверки ниже намеренно повторяют ключевые из них для вызовов в обход interceptor'а
+	// (внутренние вызовы, тесты, сервер без цепочки): заказ без цены или с чужой валютой
+	// не должен дойти до хранилища. Меняя правила CreateOrderRequest в proto, поправьте и
+	// их; TestCreateOrderInternal_ValidationErrors проверяет, что обе копии отклоняют одно и то же.
+	customerID, err := parseCustomerIDArg(req.CustomerId)
+	if err != nil {
+		return nil, err
 	}
 	if req.Currency == "" {
 		return nil, status.Error(codes.InvalidArgument, "currency is required")
@@ -137,8 +232,8 @@ func (s *OrderService) createOrderInternal(_ context.Context, req *omsv1.CreateO
 	}
 
 	order := domain.Order{
-		ID:          uuid.NewString(),
-		CustomerID:  req.CustomerId,
+		ID:          domain.NewOrderID().String(),
+		CustomerID:  customerID.String(),
 		Status:      domain.OrderStatusPending,
 		Currency:    req.Currency,
 		AmountMinor: amountSum,
@@ -146,13 +241,42 @@ func (s *OrderService) createOrderInternal(_ context.Context, req *omsv1.CreateO
 		Version:     0,
 		CreatedAt:   now,
 		UpdatedAt:   now,
+		TestMode:    req.TestMode,
+	}
+	s.requestApproval(&order)
+
+	if len(req.Adjustments) > 0 {
+		adjustments, err := fromProtoAdjustments(req.Adjustments, req.Currency)
+		if err != nil {
+			return nil, status.Error(codes.InvalidArgument, err.Error())
+		}
+		pricing, err := domain.PriceOrder(amountSum, adjustments)
+		if err != nil {
+			return nil, status.Error(codes.InvalidArgument, err.Error())
+		}
+		order.Pricing = pricing
+		order.AmountMinor = pricing.TotalMinor
 	}
 
 	if errs := order.ValidateInvariants(); len(errs) > 0 {
 		return nil, status.Error(codes.InvalidArgument, joinErrors(errs))
 	}
 
-	if err := s.repo.Create(order); err != nil {
+	releaseDuplicate, err := s.guardDuplicate(order)
+	if err != nil {
+		return nil, err
+	}
+	defer releaseDuplicate()
+
+	releaseQuota, err := s.consumeQuota(ctx, order)
+	if err != nil {
+		return nil, err
+	}
+
+	resp := &omsv1.CreateOrderResponse{Order: toProtoOrder(order)}
+	if err := s.persistNewOrder(ctx, order, resp); err != nil {
+		releaseQuota()
+		s.recordTransition(order, metrics.TransitionFromNew, err)
 		s.logger.WithError(err).Error("failed to create order")
 		switch {
 		case errors.Is(err, domain.ErrOrderVersionConflict):
@@ -162,16 +286,40 @@ func (s *OrderService) createOrderInternal(_ context.Context, req *omsv1.CreateO
 		}
 	}
 
+	s.recordTransition(order, metrics.TransitionFromNew, nil)
+	s.invalidateCustomerOrders(order.CustomerID)
 	// Запишем начальное событие статуса в timeline
 	s.appendStatusTimeline(order.ID, order.Status, order.UpdatedAt)
+	if order.Status == domain.OrderStatusAwaitingApproval {
+		s.recordApprovalRequested(ctx, order)
+	}
+
+	return resp, nil
+}
+
+// persistNewOrder сохраняет заказ. Если настроен OrderUnitOfWork и запрос идёт с idempotency-key,
+// ответ фиксируется в той же транзакции, что и заказ.
+func (s *OrderService) persistNewOrder(ctx context.Context, order domain.Order, resp *omsv1.CreateOrderResponse) error {
+	scope := idempotencyScopeFromContext(ctx)
+	if s.orderUoW == nil || scope == nil {
+		return s.repo.Create(order)
+	}
 
-	return &omsv1.CreateOrderResponse{Order: toProtoOrder(order)}, nil
+	data, err := protojson.Marshal(resp)
+	if err != nil {
+		return fmt.Errorf("encode idempotent response: %w", err)
+	}
+	if err := s.orderUoW.CreateOrderWithIdempotency(order, scope.scope, data, int(codes.OK)); err != nil {
+		return err
+	}
+	scope.committed = true
+	return nil
 }
 
 // PayOrder инициирует платежную стадию.
 func (s *OrderService) PayOrder(ctx context.Context, req *omsv1.PayOrderRequest) (*omsv1.PayOrderResponse, error) {
-	if req == nil || req.OrderId == "" {
-		return nil, status.Error(codes.InvalidArgument, "order_id is required")
+	if _, err := parseOrderIDArg(req.GetOrderId()); err != nil {
+		return nil, err
 	}
 
 	return withIdempotency(
@@ -179,6 +327,7 @@ func (s *OrderService) PayOrder(ctx context.Context, req *omsv1.PayOrderRequest)
 		ctx,
 		grpcMethodPayOrder,
 		req,
+		s.orderCustomer(req.OrderId, "PayOrder"),
 		func() *omsv1.PayOrderResponse { return &omsv1.PayOrderResponse{} },
 		func(ctx context.Context) (*omsv1.PayOrderResponse, error) {
 			return s.payOrderInternal(ctx, req)
@@ -186,9 +335,9 @@ func (s *OrderService) PayOrder(ctx context.Context, req *omsv1.PayOrderRequest)
 	)
 }
 
-func (s *OrderService) payOrderInternal(_ context.Context, req *omsv1.PayOrderRequest) (*omsv1.PayOrderResponse, error) {
-	if req == nil || req.OrderId == "" {
-		return nil, status.Error(codes.InvalidArgument, "order_id is required")
+func (s *OrderService) payOrderInternal(ctx context.Context, req *omsv1.PayOrderRequest) (*omsv1.PayOrderResponse, error) {
+	if _, err := parseOrderIDArg(req.GetOrderId()); err != nil {
+		return nil, err
 	}
 
 	order, err := s.loadOrder(req.OrderId, "PayOrder")
@@ -200,18 +349,58 @@ func (s *OrderService) payOrderInternal(_ context.Context, req *omsv1.PayOrderRe
 	}
 
 	if s.saga != nil {
-		s.runSagaAsync(order.ID, func() {
-			s.saga.Start(order.ID)
-		})
+		if err := s.dispatchSaga(ctx, domain.SagaDispatchIntent{OrderID: order.ID, Operation: domain.SagaOperationStart}); err != nil {
+			return nil, err
+		}
 	}
 
 	return &omsv1.PayOrderResponse{OrderId: order.ID, Status: toProtoStatus(order.Status)}, nil
 }
 
+// CaptureOrder списывает сумму, заблокированную при двухфазной оплате. Вызывается системой
+// исполнения при отгрузке; как и PayOrder, результат приходит асинхронно.
+func (s *OrderService) CaptureOrder(ctx context.Context, req *omsv1.CaptureOrderRequest) (*omsv1.CaptureOrderResponse, error) {
+	if _, err := parseOrderIDArg(req.GetOrderId()); err != nil {
+		return nil, err
+	}
+
+	return withIdempotency(
+		s,
+		ctx,
+		grpcMethodCaptureOrder,
+		req,
+		s.orderCustomer(req.OrderId, "CaptureOrder"),
+		func() *omsv1.CaptureOrderResponse { return &omsv1.CaptureOrderResponse{} },
+		func(ctx context.Context) (*omsv1.CaptureOrderResponse, error) {
+			return s.captureOrderInternal(ctx, req)
+		},
+	)
+}
+
+func (s *OrderService) captureOrderInternal(ctx context.Context, req *omsv1.CaptureOrderRequest) (*omsv1.CaptureOrderResponse, error) {
+	if _, ok := s.saga.(saga.AuthorizationHandler); !ok {
+		return nil, status.Error(codes.Unimplemented, "two-phase payments are not enabled")
+	}
+
+	order, err := s.loadOrder(req.OrderId, "CaptureOrder")
+	if err != nil {
+		return nil, err
+	}
+	if order.Status != domain.OrderStatusAuthorized {
+		return nil, status.Errorf(codes.FailedPrecondition, "order %s status=%s, expected=%s", order.ID, order.Status, domain.OrderStatusAuthorized)
+	}
+
+	if err := s.dispatchSaga(ctx, domain.SagaDispatchIntent{OrderID: order.ID, Operation: domain.SagaOperationCapture}); err != nil {
+		return nil, err
+	}
+
+	return &omsv1.CaptureOrderResponse{OrderId: order.ID, Status: toProtoStatus(order.Status)}, nil
+}
+
 // CancelOrder отменяет заказ или запускает компенсирующие действия.
 func (s *OrderService) CancelOrder(ctx context.Context, req *omsv1.CancelOrderRequest) (*omsv1.CancelOrderResponse, error) {
-	if req == nil || req.OrderId == "" {
-		return nil, status.Error(codes.InvalidArgument, "order_id is required")
+	if _, err := parseOrderIDArg(req.GetOrderId()); err != nil {
+		return nil, err
 	}
 
 	return withIdempotency(
@@ -219,6 +408,7 @@ func (s *OrderService) CancelOrder(ctx context.Context, req *omsv1.CancelOrderRe
 		ctx,
 		grpcMethodCancelOrder,
 		req,
+		s.orderCustomer(req.OrderId, "CancelOrder"),
 		func() *omsv1.CancelOrderResponse { return &omsv1.CancelOrderResponse{} },
 		func(ctx context.Context) (*omsv1.CancelOrderResponse, error) {
 			return s.cancelOrderInternal(ctx, req)
@@ -226,9 +416,9 @@ func (s *OrderService) CancelOrder(ctx context.Context, req *omsv1.CancelOrderRe
 	)
 }
 
-func (s *OrderService) cancelOrderInternal(_ context.Context, req *omsv1.CancelOrderRequest) (*omsv1.CancelOrderResponse, error) {
-	if req == nil || req.OrderId == "" {
-		return nil, status.Error(codes.InvalidArgument, "order_id is required")
+func (s *OrderService) cancelOrderInternal(ctx context.Context, req *omsv1.CancelOrderRequest) (*omsv1.CancelOrderResponse, error) {
+	if _, err := parseOrderIDArg(req.GetOrderId()); err != nil {
+		return nil, err
 	}
 
 	order, err := s.loadOrder(req.OrderId, "CancelOrder")
@@ -237,13 +427,18 @@ func (s *OrderService) cancelOrderInternal(_ context.Context, req *omsv1.CancelO
 	}
 
 	if s.saga != nil {
-		s.runSagaAsync(order.ID, func() {
-			s.saga.Cancel(order.ID, req.Reason)
-		})
+		if err := s.dispatchSaga(ctx, domain.SagaDispatchIntent{OrderID: order.ID, Operation: domain.SagaOperationCancel, Reason: req.Reason}); err != nil {
+			return nil, err
+		}
 	} else if order.Status != domain.OrderStatusCanceled {
+		from := order.Status
 		order.Status = domain.OrderStatusCanceled
+		order.HoldReason = ""
+		order.HeldFromStatus = ""
 		order.UpdatedAt = time.Now().UTC()
-		if err := s.saveOrder(order, "CancelOrder", "failed to cancel order"); err != nil {
+		err := s.saveOrder(order, "CancelOrder", "failed to cancel order")
+		s.recordTransition(order, string(from), err)
+		if err != nil {
 			return nil, err
 		}
 		s.appendStatusTimeline(order.ID, order.Status, order.UpdatedAt)
@@ -260,8 +455,8 @@ func (s *OrderService) cancelOrderInternal(_ context.Context, req *omsv1.CancelO
 
 // RefundOrder инициирует возврат средств.
 func (s *OrderService) RefundOrder(ctx context.Context, req *omsv1.RefundOrderRequest) (*omsv1.RefundOrderResponse, error) {
-	if req == nil || req.OrderId == "" {
-		return nil, status.Error(codes.InvalidArgument, "order_id is required")
+	if _, err := parseOrderIDArg(req.GetOrderId()); err != nil {
+		return nil, err
 	}
 
 	return withIdempotency(
@@ -269,6 +464,7 @@ func (s *OrderService) RefundOrder(ctx context.Context, req *omsv1.RefundOrderRe
 		ctx,
 		grpcMethodRefundOrder,
 		req,
+		s.orderCustomer(req.OrderId, "RefundOrder"),
 		func() *omsv1.RefundOrderResponse { return &omsv1.RefundOrderResponse{} },
 		func(ctx context.Context) (*omsv1.RefundOrderResponse, error) {
 			return s.refundOrderInternal(ctx, req)
@@ -276,9 +472,9 @@ func (s *OrderService) RefundOrder(ctx context.Context, req *omsv1.RefundOrderRe
 	)
 }
 
-func (s *OrderService) refundOrderInternal(_ context.Context, req *omsv1.RefundOrderRequest) (*omsv1.RefundOrderResponse, error) {
-	if req == nil || req.OrderId == "" {
-		return nil, status.Error(codes.InvalidArgument, "order_id is required")
+func (s *OrderService) refundOrderInternal(ctx context.Context, req *omsv1.RefundOrderRequest) (*omsv1.RefundOrderResponse, error) {
+	if _, err := parseOrderIDArg(req.GetOrderId()); err != nil {
+		return nil, err
 	}
 
 	order, err := s.loadOrder(req.OrderId, "RefundOrder")
@@ -300,16 +496,23 @@ func (s *OrderService) refundOrderInternal(_ context.Context, req *omsv1.RefundO
 	if order.Status != domain.OrderStatusPaid && order.Status != domain.OrderStatusConfirmed {
 		return nil, status.Error(codes.FailedPrecondition, "order is not eligible for refund")
 	}
+	if amountMinor, err = s.refundableAfterReturns(order, amountMinor); err != nil {
+		return nil, err
+	}
 
 	if s.saga != nil {
-		s.runSagaAsync(order.ID, func() {
-			s.saga.Refund(order.ID, amountMinor, req.Reason)
-		})
+		intent := domain.SagaDispatchIntent{OrderID: order.ID, Operation: domain.SagaOperationRefund, Reason: req.Reason, AmountMinor: amountMinor}
+		if err := s.dispatchSaga(ctx, intent); err != nil {
+			return nil, err
+		}
 	} else {
 		// Без saga просто меняем статус
+		from := order.Status
 		order.Status = domain.OrderStatusRefunded
 		order.UpdatedAt = time.Now().UTC()
-		if err := s.saveOrder(order, "RefundOrder", "failed to refund order"); err != nil {
+		err := s.saveOrder(order, "RefundOrder", "failed to refund order")
+		s.recordTransition(order, string(from), err)
+		if err != nil {
 			return nil, err
 		}
 		s.appendStatusTimeline(order.ID, order.Status, order.UpdatedAt)
@@ -324,10 +527,141 @@ func (s *OrderService) refundOrderInternal(_ context.Context, req *omsv1.RefundO
 	return &omsv1.RefundOrderResponse{OrderId: updated.ID, Status: toProtoStatus(updated.Status)}, nil
 }
 
+// HoldOrder останавливает заказ для проверки (антифрод); сага не продвигает заказ до ReleaseOrder.
+// Hold и release доступны только операторам (UnaryAdminAuthInterceptor): иначе клиент снял бы
+// антифрод-hold со своего заказа сам.
+func (s *OrderService) HoldOrder(ctx context.Context, req *omsv1.HoldOrderRequest) (*omsv1.HoldOrderResponse, error) {
+	if _, err := parseOrderIDArg(req.GetOrderId()); err != nil {
+		return nil, err
+	}
+	operator := AdminOperatorFromContext(ctx)
+	if operator == "" {
+		return nil, status.Error(codes.Unauthenticated, "order hold requires operator authentication")
+	}
+
+	return withIdempotency(
+		s,
+		ctx,
+		grpcMethodHoldOrder,
+		req,
+		s.orderCustomer(req.OrderId, "HoldOrder"),
+		func() *omsv1.HoldOrderResponse { return &omsv1.HoldOrderResponse{} },
+		func(ctx context.Context) (*omsv1.HoldOrderResponse, error) {
+			return s.holdOrderInternal(ctx, req, operator)
+		},
+	)
+}
+
+func (s *OrderService) holdOrderInternal(_ context.Context, req *omsv1.HoldOrderRequest, operator string) (*omsv1.HoldOrderResponse, error) {
+	reason := strings.TrimSpace(req.Reason)
+	if reason == "" {
+		return nil, status.Error(codes.InvalidArgument, "reason is required")
+	}
+
+	order, err := s.loadOrder(req.OrderId, "HoldOrder")
+	if err != nil {
+		return nil, err
+	}
+	if err := order.Hold(reason); err != nil {
+		s.transitions.RecordMode(metrics.OrderMode(order.TestMode), string(order.Status), string(domain.OrderStatusOnHold), metrics.TransitionResultRejected)
+		return nil, holdErrorToStatus(order, err)
+	}
+	order.UpdatedAt = time.Now().UTC()
+	err = s.saveOrder(order, "HoldOrder", "failed to hold order")
+	s.recordTransition(order, string(order.HeldFromStatus), err)
+	if err != nil {
+		return nil, err
+	}
+	s.appendStatusTimeline(order.ID, order.Status, order.UpdatedAt)
+	s.appendTimelineEvent(order.ID, timelineEventOrderHeld, reason)
+
+	s.logger.WithFields(log.Fields{
+		"order_id":  order.ID,
+		"held_from": order.HeldFromStatus,
+		"reason":    reason,
+		"operator":  operator,
+	}).Info("order put on hold")
+
+	return &omsv1.HoldOrderResponse{
+		OrderId:    order.ID,
+		Status:     toProtoStatus(order.Status),
+		HoldReason: order.HoldReason,
+	}, nil
+}
+
+// ReleaseOrder снимает hold и возобновляет сагу с того шага, на котором заказ был остановлен.
+func (s *OrderService) ReleaseOrder(ctx context.Context, req *omsv1.ReleaseOrderRequest) (*omsv1.ReleaseOrderResponse, error) {
+	if _, err := parseOrderIDArg(req.GetOrderId()); err != nil {
+		return nil, err
+	}
+	operator := AdminOperatorFromContext(ctx)
+	if operator == "" {
+		return nil, status.Error(codes.Unauthenticated, "order release requires operator authentication")
+	}
+
+	return withIdempotency(
+		s,
+		ctx,
+		grpcMethodReleaseOrder,
+		req,
+		s.orderCustomer(req.OrderId, "ReleaseOrder"),
+		func() *omsv1.ReleaseOrderResponse { return &omsv1.ReleaseOrderResponse{} },
+		func(ctx context.Context) (*omsv1.ReleaseOrderResponse, error) {
+			return s.releaseOrderInternal(ctx, req, operator)
+		},
+	)
+}
+
+func (s *OrderService) releaseOrderInternal(ctx context.Context, req *omsv1.ReleaseOrderRequest, operator string) (*omsv1.ReleaseOrderResponse, error) {
+	order, err := s.loadOrder(req.OrderId, "ReleaseOrder")
+	if err != nil {
+		return nil, err
+	}
+	if err := order.Release(); err != nil {
+		return nil, holdErrorToStatus(order, err)
+	}
+	order.UpdatedAt = time.Now().UTC()
+	err = s.saveOrder(order, "ReleaseOrder", "failed to release order")
+	s.recordTransition(order, string(domain.OrderStatusOnHold), err)
+	if err != nil {
+		return nil, err
+	}
+	s.appendStatusTimeline(order.ID, order.Status, order.UpdatedAt)
+	s.appendTimelineEvent(order.ID, timelineEventOrderReleased, strings.TrimSpace(req.Reason))
+
+	s.logger.WithFields(log.Fields{
+		"order_id": order.ID,
+		"status":   order.Status,
+		"operator": operator,
+	}).Info("order released from hold")
+
+	// Заказ, остановленный в pending, ждёт PayOrder; начатую сагу продолжаем сразу.
+	if s.saga != nil && order.Status != domain.OrderStatusPending {
+		if err := s.dispatchSaga(ctx, domain.SagaDispatchIntent{OrderID: order.ID, Operation: domain.SagaOperationStart}); err != nil {
+			return nil, err
+		}
+	}
+
+	return &omsv1.ReleaseOrderResponse{OrderId: order.ID, Status: toProtoStatus(order.Status)}, nil
+}
+
+func holdErrorToStatus(order domain.Order, err error) error {
+	switch {
+	case errors.Is(err, domain.ErrHoldReasonRequired):
+		return status.Error(codes.InvalidArgument, err.Error())
+	case errors.Is(err, domain.ErrOrderAlreadyOnHold),
+		errors.Is(err, domain.ErrOrderNotOnHold),
+		errors.Is(err, domain.ErrOrderNotHoldable):
+		return status.Errorf(codes.FailedPrecondition, "order %s status=%s: %s", order.ID, order.Status, err.Error())
+	default:
+		return status.Error(codes.Internal, err.Error())
+	}
+}
+
 // GetOrder возвращает состояние заказа и таймлайн событий.
 func (s *OrderService) GetOrder(_ context.Context, req *omsv1.GetOrderRequest) (*omsv1.GetOrderResponse, error) {
-	if req == nil || req.OrderId == "" {
-		return nil, status.Error(codes.InvalidArgument, "order_id is required")
+	if _, err := parseOrderIDArg(req.GetOrderId()); err != nil {
+		return nil, err
 	}
 
 	order, err := s.loadOrder(req.OrderId, "GetOrder")
@@ -343,46 +677,83 @@ func (s *OrderService) GetOrder(_ context.Context, req *omsv1.GetOrderRequest) (
 
 // ListOrders возвращает заказы клиента.
 func (s *OrderService) ListOrders(_ context.Context, req *omsv1.ListOrdersRequest) (*omsv1.ListOrdersResponse, error) {
-	if req == nil || req.CustomerId == "" {
-		return nil, status.Error(codes.InvalidArgument, "customer_id is required")
+	customerID, err := parseCustomerIDArg(req.GetCustomerId())
+	if err != nil {
+		return nil, err
 	}
 
-	limit := int(req.PageSize)
-	if limit <= 0 {
-		limit = defaultListOrdersLimit
+	limit, err := s.pageLimits.resolve(req.GetPageSize())
+	if err != nil {
+		return nil, err
 	}
 
-	orders, err := s.repo.ListByCustomer(req.CustomerId, limit)
+	result, err := s.listCustomerOrders(customerID, limit)
 	if err != nil {
 		s.logger.WithError(err).Error("failed to list orders")
 		return nil, status.Error(codes.Internal, "failed to list orders")
 	}
 
-	result := make([]*omsv1.Order, 0, len(orders))
-	for _, order := range orders {
-		result = append(result, toProtoOrder(order))
+	return &omsv1.ListOrdersResponse{Orders: result}, nil
+}
+
+// listCustomerOrders конвертирует заказы в proto по мере чтения, если хранилище умеет их стримить,
+// чтобы в памяти не держались одновременно доменные заказы и ответ.
+func (s *OrderService) listCustomerOrders(customerID domain.CustomerID, limit int) ([]*omsv1.Order, error) {
+	if s.useListCache() {
+		return s.cachedCustomerOrders(customerID, limit)
+	}
+	streamer, ok := s.repo.(domain.OrderStreamer)
+	if !ok {
+		orders, err := s.repo.ListByCustomer(customerID.String(), limit)
+		if err != nil {
+			return nil, err
+		}
+		result := make([]*omsv1.Order, 0, len(orders))
+		for _, order := range orders {
+			result = append(result, toProtoOrder(order))
+		}
+		return result, nil
 	}
 
-	return &omsv1.ListOrdersResponse{Orders: result}, nil
+	result := make([]*omsv1.Order, 0)
+	err := streamer.StreamByCustomer(customerID.String(), limit, func(order domain.Order) error {
+		result = append(result, toProtoOrder(order))
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return result, nil
 }
 
-func (s *OrderService) loadOrder(orderID, operation string) (domain.Order, error) {
-	order, err := s.repo.Get(orderID)
+func (s *OrderService) loadOrder(rawID, operation string) (domain.Order, error) {
+	orderID, err := parseOrderIDArg(rawID)
+	if err != nil {
+		return domain.Order{}, err
+	}
+	order, err := s.repo.Get(orderID.String())
 	if err == nil {
 		return order, nil
 	}
 
+	// NotFound — обычный ответ клиенту, его фиксирует interceptor логирования запросов.
+	if errors.Is(err, domain.ErrOrderNotFound) {
+		return domain.Order{}, status.Error(codes.NotFound, domain.ErrOrderNotFound.Error())
+	}
 	s.logger.WithError(err).WithFields(log.Fields{
 		"operation": operation,
 		"order_id":  orderID,
 	}).Warn("failed to load order")
+	return domain.Order{}, status.Error(codes.Internal, "failed to load order")
+}
 
-	switch {
-	case errors.Is(err, domain.ErrOrderNotFound):
-		return domain.Order{}, status.Error(codes.NotFound, domain.ErrOrderNotFound.Error())
-	default:
-		return domain.Order{}, status.Error(codes.Internal, "failed to load order")
+// recordTransition учитывает смену статуса from→order.Status: ok, если err == nil, иначе failed.
+func (s *OrderService) recordTransition(order domain.Order, from string, err error) {
+	result := metrics.TransitionResultOK
+	if err != nil {
+		result = metrics.TransitionResultFailed
 	}
+	s.transitions.RecordMode(metrics.OrderMode(order.TestMode), from, string(order.Status), result)
 }
 
 func (s *OrderService) saveOrder(order domain.Order, operation, internalMsg string) error {
@@ -410,6 +781,40 @@ const (
 	idempotencyTTL       = 24 * time.Hour
 )
 
+// idempotencyScope передаёт область idempotency-key обработчику и позволяет ему сообщить,
+// что успешный ответ уже сохранён транзакционно.
+type idempotencyScope struct {
+	scope     domain.IdempotencyScope
+	committed bool
+}
+
+type idempotencyScopeContextKey struct{}
+
+func idempotencyScopeFromContext(ctx context.Context) *idempotencyScope {
+	scope, _ := ctx.Value(idempotencyScopeContextKey{}).(*idempotencyScope)
+	return scope
+}
+
+// idempotencyCustomerFunc определяет клиента, в пределах которого действует idempotency-key.
+// Вызывается только при включённой идемпотентности.
+type idempotencyCustomerFunc func() (string, error)
+
+// requestCustomer — клиент передан в самом запросе.
+func requestCustomer(customerID string) idempotencyCustomerFunc {
+	return func() (string, error) { return customerID, nil }
+}
+
+// orderCustomer — клиент берётся из заказа, над которым выполняется операция.
+func (s *OrderService) orderCustomer(orderID, operation string) idempotencyCustomerFunc {
+	return func() (string, error) {
+		order, err := s.loadOrder(orderID, operation)
+		if err != nil {
+			return "", err
+		}
+		return order.CustomerID, nil
+	}
+}
+
 type idempotencyErrorPayload struct {
 	Code    int32  `json:"code"`
 	Message string `json:"message"`
@@ -420,6 +825,7 @@ func withIdempotency[T proto.Message](
 	ctx context.Context,
 	method string,
 	req proto.Message,
+	customer idempotencyCustomerFunc,
 	newResp func() T,
 	handler func(context.Context) (T, error),
 ) (T, error) {
@@ -441,18 +847,29 @@ func withIdempotency[T proto.Message](
 		return zero, status.Error(codes.Internal, "failed to initialize idempotency request")
 	}
 
-	record, err := s.idemRepo.CreateProcessing(idemKey, reqHash, time.Now().UTC().Add(idempotencyTTL))
+	customerID, err := customer()
+	if err != nil {
+		return zero, err
+	}
+	idemScope := domain.IdempotencyScope{Method: method, CustomerID: customerID, Key: idemKey}
+
+	record, err := s.idemRepo.CreateProcessing(idemScope, reqHash, time.Now().UTC().Add(idempotencyTTL))
 	if err != nil {
-		return replayIdempotency(s, err, record, newResp)
+		return replayIdempotency(s, ctx, err, record, newResp)
 	}
+	recordIdempotencyOutcome(ctx, idempotencyOutcomeFirst)
 
-	resp, runErr := handler(ctx)
+	scope := &idempotencyScope{scope: idemScope}
+	resp, runErr := handler(context.WithValue(ctx, idempotencyScopeContextKey{}, scope))
 	if runErr != nil {
-		s.cacheIdempotencyFailure(idemKey, runErr)
+		s.cacheIdempotencyFailure(idemScope, runErr)
 		return resp, runErr
 	}
+	if scope.committed {
+		return resp, nil
+	}
 
-	if cacheErr := s.cacheIdempotencySuccess(idemKey, resp); cacheErr != nil {
+	if cacheErr := s.cacheIdempotencySuccess(idemScope, resp); cacheErr != nil {
 		s.logger.WithError(cacheErr).WithField("idempotency_key", idemKey).Warn("failed to store idempotent success response")
 	}
 
@@ -467,6 +884,7 @@ func (s *OrderService) warnIdempotencyDisabled(method string) {
 
 func replayIdempotency[T proto.Message](
 	s *OrderService,
+	ctx context.Context,
 	createErr error,
 	record domain.IdempotencyRecord,
 	newResp func() T,
@@ -475,8 +893,14 @@ func replayIdempotency[T proto.Message](
 
 	switch {
 	case errors.Is(createErr, domain.ErrIdempotencyHashMismatch):
+		recordIdempotencyOutcome(ctx, idempotencyOutcomeMismatch)
 		return zero, status.Error(codes.AlreadyExists, "idempotency key is already used with different request payload")
 	case errors.Is(createErr, domain.ErrIdempotencyKeyAlreadyExists):
+		outcome := idempotencyOutcomeReplay
+		if record.Status == domain.IdempotencyStatusProcessing {
+			outcome = idempotencyOutcomeInProgress
+		}
+		recordIdempotencyOutcome(ctx, outcome)
 		switch record.Status {
 		case domain.IdempotencyStatusDone:
 			if len(record.ResponseBody) == 0 {
@@ -501,19 +925,19 @@ func replayIdempotency[T proto.Message](
 	}
 }
 
-func (s *OrderService) cacheIdempotencySuccess(key string, resp proto.Message) error {
+func (s *OrderService) cacheIdempotencySuccess(scope domain.IdempotencyScope, resp proto.Message) error {
 	if resp == nil {
-		return s.idemRepo.MarkDone(key, nil, int(codes.OK))
+		return s.idemRepo.MarkDone(scope, nil, int(codes.OK))
 	}
 
 	data, err := protojson.Marshal(resp)
 	if err != nil {
 		return err
 	}
-	return s.idemRepo.MarkDone(key, data, int(codes.OK))
+	return s.idemRepo.MarkDone(scope, data, int(codes.OK))
 }
 
-func (s *OrderService) cacheIdempotencyFailure(key string, runErr error) {
+func (s *OrderService) cacheIdempotencyFailure(scope domain.IdempotencyScope, runErr error) {
 	st := status.Convert(runErr)
 	code := st.Code()
 	if code == codes.OK {
@@ -525,12 +949,12 @@ func (s *OrderService) cacheIdempotencyFailure(key string, runErr error) {
 		Message: st.Message(),
 	})
 	if err != nil {
-		s.logger.WithError(err).WithField("idempotency_key", key).Warn("failed to encode idempotency failure payload")
+		s.logger.WithError(err).WithField("idempotency_key", scope.Key).Warn("failed to encode idempotency failure payload")
 		payload = nil
 	}
 
-	if err := s.idemRepo.MarkFailed(key, payload, int(code)); err != nil {
-		s.logger.WithError(err).WithField("idempotency_key", key).Warn("failed to store idempotency failure response")
+	if err := s.idemRepo.MarkFailed(scope, payload, int(code)); err != nil {
+		s.logger.WithError(err).WithField("idempotency_key", scope.Key).Warn("failed to store idempotency failure response")
 	}
 }
 
@@ -688,14 +1112,19 @@ func buildIdempotencyRequestHash(method string, req proto.Message) (string, erro
 func toProtoOrder(order domain.Order) *omsv1.Order {
 	items := make([]*omsv1.OrderItem, 0, len(order.Items))
 	for _, item := range order.Items {
-		items = append(items, &omsv1.OrderItem{
+		protoItem := &omsv1.OrderItem{
+			Id:  item.ID,
 			Sku: item.SKU,
 			Qty: item.Qty,
 			Price: &omsv1.Money{
 				Currency:    order.Currency,
 				AmountMinor: item.PriceMinor,
 			},
-		})
+		}
+		if !item.CreatedAt.IsZero() {
+			protoItem.CreatedAtUnix = item.CreatedAt.Unix()
+		}
+		items = append(items, protoItem)
 	}
 
 	return &omsv1.Order{
@@ -706,9 +1135,12 @@ func toProtoOrder(order domain.Order) *omsv1.Order {
 			Currency:    order.Currency,
 			AmountMinor: order.AmountMinor,
 		},
-		Items:    items,
-		Version:  order.Version,
-		Currency: order.Currency,
+		Items:      items,
+		Version:    order.Version,
+		Currency:   order.Currency,
+		HoldReason: order.HoldReason,
+		Amounts:    toProtoAmounts(order),
+		TestMode:   order.TestMode,
 	}
 }
 
@@ -726,11 +1158,47 @@ func toProtoStatus(status domain.OrderStatus) omsv1.OrderStatus {
 		return omsv1.OrderStatus_ORDER_STATUS_CANCELED
 	case domain.OrderStatusRefunded:
 		return omsv1.OrderStatus_ORDER_STATUS_REFUNDED
+	case domain.OrderStatusOnHold:
+		return omsv1.OrderStatus_ORDER_STATUS_ON_HOLD
+	case domain.OrderStatusBackordered:
+		return omsv1.OrderStatus_ORDER_STATUS_BACKORDERED
+	case domain.OrderStatusAuthorized:
+		return omsv1.OrderStatus_ORDER_STATUS_AUTHORIZED
+	case domain.OrderStatusAwaitingApproval:
+		return omsv1.OrderStatus_ORDER_STATUS_AWAITING_APPROVAL
 	default:
 		return omsv1.OrderStatus_ORDER_STATUS_UNSPECIFIED
 	}
 }
 
+// fromProtoStatus — обратное toProtoStatus; false для UNSPECIFIED и неизвестных значений.
+func fromProtoStatus(status omsv1.OrderStatus) (domain.OrderStatus, bool) {
+	switch status {
+	case omsv1.OrderStatus_ORDER_STATUS_PENDING:
+		return domain.OrderStatusPending, true
+	case omsv1.OrderStatus_ORDER_STATUS_RESERVED:
+		return domain.OrderStatusReserved, true
+	case omsv1.OrderStatus_ORDER_STATUS_PAID:
+		return domain.OrderStatusPaid, true
+	case omsv1.OrderStatus_ORDER_STATUS_CONFIRMED:
+		return domain.OrderStatusConfirmed, true
+	case omsv1.OrderStatus_ORDER_STATUS_CANCELED:
+		return domain.OrderStatusCanceled, true
+	case omsv1.OrderStatus_ORDER_STATUS_REFUNDED:
+		return domain.OrderStatusRefunded, true
+	case omsv1.OrderStatus_ORDER_STATUS_ON_HOLD:
+		return domain.OrderStatusOnHold, true
+	case omsv1.OrderStatus_ORDER_STATUS_BACKORDERED:
+		return domain.OrderStatusBackordered, true
+	case omsv1.OrderStatus_ORDER_STATUS_AUTHORIZED:
+		return domain.OrderStatusAuthorized, true
+	case omsv1.OrderStatus_ORDER_STATUS_AWAITING_APPROVAL:
+		return domain.OrderStatusAwaitingApproval, true
+	default:
+		return "", false
+	}
+}
+
 func joinErrors(errs []error) string {
 	builder := strings.Builder{}
 	for i, err := range errs {
@@ -750,7 +1218,7 @@ func (s *OrderService) appendTimelineEvent(orderID, eventType, reason string) {
 		OrderID:  orderID,
 		Type:     eventType,
 		Reason:   reason,
-		Occurred: time.Now().UTC(),
+		Occurred: timeutil.Now(),
 	}
 	if err := s.timeline.Append(event); err != nil {
 		s.logger.WithError(err).WithFields(log.Fields{
@@ -765,7 +1233,7 @@ func (s *OrderService) appendStatusTimeline(orderID string, status domain.OrderS
 		return
 	}
 	if occurred.IsZero() {
-		occurred = time.Now().UTC()
+		occurred = timeutil.Now()
 	}
 	event := domain.TimelineEvent{
 		OrderID:  orderID,
@@ -789,11 +1257,7 @@ func (s *OrderService) buildTimeline(orderID string) []*omsv1.TimelineEvent {
 	}
 	result := make([]*omsv1.TimelineEvent, 0, len(events))
 	for _, event := range events {
-		result = append(result, &omsv1.TimelineEvent{
-			Type:     event.Type,
-			Reason:   event.Reason,
-			UnixTime: event.Occurred.Unix(),
-		})
+		result = append(result, toProtoTimelineEvent(event))
 	}
 	return result
 }
@@ -818,18 +1282,194 @@ func (s *OrderService) Shutdown(ctx context.Context) error {
 	}
 }
 
-func (s *OrderService) runSagaAsync(orderID string, fn func()) {
+// dispatchSaga сохраняет намерение (если настроен SagaDispatchRepository) и запускает сагу в фоне.
+// Ошибка сохранения возвращается клиенту: ответ OK без записанного намерения снова открыл бы
+// окно, в котором остановка процесса теряет операцию.
+func (s *OrderService) dispatchSaga(ctx context.Context, intent domain.SagaDispatchIntent) error {
+	if s.dispatchRepo != nil {
+		intent.ClaimedUntil = time.Now().UTC().Add(sagaDispatchLease)
+		recorded, err := s.dispatchRepo.Record(intent)
+		if err != nil {
+			s.logger.WithError(err).WithFields(log.Fields{
+				"order_id":  intent.OrderID,
+				"operation": intent.Operation,
+			}).Error("failed to persist saga dispatch intent")
+			return status.Error(codes.Unavailable, "failed to schedule saga")
+		}
+		intent = recorded
+	}
+	s.runSagaAsync(ctx, intent)
+	return nil
+}
+
+// runSagaAsync запускает сагу в фоне с контекстом, отвязанным от отмены RPC,
+// но сохраняющим его значения и ограниченным sagaTimeout.
+func (s *OrderService) runSagaAsync(ctx context.Context, intent domain.SagaDispatchIntent) {
+	if !s.acquireSagaSlot(intent) {
+		s.releaseSagaClaim(intent)
+		return
+	}
+
+	go func() {
+		defer s.releaseSagaSlot()
+		s.runSaga(ctx, intent)
+	}()
+}
+
+// ReplaySagaIntents по одному забирает намерения с истёкшей арендой — оставшиеся от
+// остановленных или упавших процессов — и выполняет их в порядке requested_at. Намерения,
+// которые ещё выполняет живая реплика, пропускаются: она продлевает их аренду. Возвращает
+// число выполненных намерений.
+func (s *OrderService) ReplaySagaIntents(ctx context.Context) (int, error) {
+	if s.dispatchRepo == nil || s.saga == nil {
+		return 0, nil
+	}
+	replayed := 0
+	for ctx.Err() == nil {
+		now := time.Now().UTC()
+		intents, err := s.dispatchRepo.ClaimPending(now, now.Add(sagaDispatchLease), 1)
+		if err != nil {
+			return replayed, fmt.Errorf("claim pending saga intents: %w", err)
+		}
+		if len(intents) == 0 {
+			return replayed, nil
+		}
+		intent := intents[0]
+		if !s.acquireSagaSlot(intent) {
+			s.releaseSagaClaim(intent)
+			return replayed, nil
+		}
+		s.runSaga(ctx, intent)
+		s.releaseSagaSlot()
+		replayed++
+	}
+	return replayed, nil
+}
+
+// RunSagaReplay вызывает ReplaySagaIntents при старте и затем раз в sagaDispatchLease до отмены
+// ctx: аренда намерений упавшей реплики истекает уже после старта остальных.
+func (s *OrderService) RunSagaReplay(ctx context.Context) {
+	ticker := time.NewTicker(sagaDispatchLease)
+	defer ticker.Stop()
+
+	for {
+		if replayed, err := s.ReplaySagaIntents(ctx); err != nil {
+			s.logger.WithError(err).Warn("failed to replay pending saga intents")
+		} else if replayed > 0 {
+			s.logger.WithField("intents", replayed).Info("replayed saga intents left by stopped replicas")
+		}
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// acquireSagaSlot регистрирует фоновую сагу в sagaWG; во время остановки возвращает false.
+func (s *OrderService) acquireSagaSlot(intent domain.SagaDispatchIntent) bool {
 	s.sagaMu.Lock()
+	defer s.sagaMu.Unlock()
+
 	if s.sagaClosed {
-		s.sagaMu.Unlock()
-		s.logger.WithField("order_id", orderID).Warn("saga dispatch skipped during shutdown")
-		return
+		entry := s.logger.WithFields(log.Fields{"order_id": intent.OrderID, "operation": intent.Operation})
+		if intent.ID != "" {
+			entry.Warn("saga dispatch deferred to next startup")
+		} else {
+			entry.Warn("saga dispatch skipped during shutdown")
+		}
+		return false
 	}
 	s.sagaWG.Add(1)
-	s.sagaMu.Unlock()
+	s.sagaActive.Add(1)
+	return true
+}
+
+func (s *OrderService) releaseSagaSlot() {
+	s.sagaActive.Add(-1)
+	s.sagaWG.Done()
+}
+
+// InFlightSagas возвращает число фоновых задач саг, которые ещё выполняются.
+func (s *OrderService) InFlightSagas() int {
+	return int(s.sagaActive.Load())
+}
+
+// runSaga синхронно выполняет намерение, продлевая его аренду, и удаляет его запись.
+func (s *OrderService) runSaga(ctx context.Context, intent domain.SagaDispatchIntent) {
+	sagaCtx, cancel := saga.DetachedContext(ctx, time.Duration(s.sagaTimeout.Load()))
+	defer cancel()
+	stopRenew := s.renewSagaClaim(intent)
+
+	switch intent.Operation {
+	case domain.SagaOperationStart:
+		s.saga.Start(sagaCtx, intent.OrderID)
+	case domain.SagaOperationCancel:
+		s.saga.Cancel(sagaCtx, intent.OrderID, intent.Reason)
+	case domain.SagaOperationRefund:
+		s.saga.Refund(sagaCtx, intent.OrderID, intent.AmountMinor, intent.Reason)
+	case domain.SagaOperationCapture:
+		handler, ok := s.saga.(saga.AuthorizationHandler)
+		if !ok {
+			s.logger.WithField("order_id", intent.OrderID).Warn("orchestrator does not support capture, intent dropped")
+			break
+		}
+		if err := handler.Capture(sagaCtx, intent.OrderID); err != nil {
+			s.logger.WithError(err).WithField("order_id", intent.OrderID).Warn("capture failed")
+		}
+	default:
+		s.logger.WithFields(log.Fields{
+			"order_id":  intent.OrderID,
+			"operation": intent.Operation,
+		}).Warn("unknown saga operation, intent dropped")
+	}
+
+	stopRenew()
+	if s.dispatchRepo == nil || intent.ID == "" {
+		return
+	}
+	if err := s.dispatchRepo.Complete(intent.ID); err != nil {
+		// Запись останется и будет повторена при старте: операции саги проверяют статус заказа.
+		s.logger.WithError(err).WithField("order_id", intent.OrderID).Warn("failed to complete saga dispatch intent")
+	}
+}
 
+// renewSagaClaim продлевает аренду намерения каждые sagaDispatchLease/3, пока сага не
+// закончится; возвращает функцию, которая останавливает продление.
+func (s *OrderService) renewSagaClaim(intent domain.SagaDispatchIntent) func() {
+	if s.dispatchRepo == nil || intent.ID == "" {
+		return func() {}
+	}
+	done := make(chan struct{})
+	stopped := make(chan struct{})
 	go func() {
-		defer s.sagaWG.Done()
-		fn()
+		defer close(stopped)
+		ticker := time.NewTicker(sagaDispatchLease / 3)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-done:
+				return
+			case <-ticker.C:
+				if err := s.dispatchRepo.Renew(intent.ID, time.Now().UTC().Add(sagaDispatchLease)); err != nil {
+					s.logger.WithError(err).WithField("order_id", intent.OrderID).Warn("failed to renew saga dispatch intent")
+				}
+			}
+		}
 	}()
+	return func() {
+		close(done)
+		<-stopped
+	}
+}
+
+// releaseSagaClaim снимает аренду с намерения, которое процесс не стал выполнять из-за
+// остановки, чтобы другая реплика подхватила его сразу, не дожидаясь истечения аренды.
+func (s *OrderService) releaseSagaClaim(intent domain.SagaDispatchIntent) {
+	if s.dispatchRepo == nil || intent.ID == "" {
+		return
+	}
+	if err := s.dispatchRepo.Renew(intent.ID, time.Time{}); err != nil {
+		s.logger.WithError(err).WithField("order_id", intent.OrderID).Warn("failed to release saga dispatch intent")
+	}
 }
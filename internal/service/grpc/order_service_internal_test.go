@@ -4,14 +4,21 @@ import (
 	"context"
 	"errors"
 	"math"
+	"strings"
+	"sync"
 	"testing"
 	"time"
 
+	"github.com/prometheus/client_golang/prometheus"
 	log "github.com/sirupsen/logrus"
+	"google.golang.org/grpc"
 	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
 	"google.golang.org/grpc/status"
 
 	"github.com/vladislavdragonenkov/oms/internal/domain"
+	idempotencysvc "github.com/vladislavdragonenkov/oms/internal/service/idempotency"
+	"github.com/vladislavdragonenkov/oms/internal/storage/memory"
 	omsv1 "github.com/vladislavdragonenkov/oms/proto/oms/v1"
 )
 
@@ -19,7 +26,9 @@ type stubOrderRepository struct {
 	createFn func(domain.Order) error
 	getFn    func(string) (domain.Order, error)
 	listFn   func(string, int) ([]domain.Order, error)
+	statusFn func(domain.OrderStatus, int) ([]domain.Order, error)
 	saveFn   func(domain.Order) error
+	deleteFn func(string) error
 }
 
 func (s *stubOrderRepository) Create(order domain.Order) error {
@@ -43,6 +52,13 @@ func (s *stubOrderRepository) ListByCustomer(customerID string, limit int) ([]do
 	return nil, nil
 }
 
+func (s *stubOrderRepository) ListByStatus(status domain.OrderStatus, limit int) ([]domain.Order, error) {
+	if s.statusFn != nil {
+		return s.statusFn(status, limit)
+	}
+	return nil, nil
+}
+
 func (s *stubOrderRepository) Save(order domain.Order) error {
 	if s.saveFn != nil {
 		return s.saveFn(order)
@@ -50,6 +66,17 @@ func (s *stubOrderRepository) Save(order domain.Order) error {
 	return nil
 }
 
+func (s *stubOrderRepository) UpdateStatusCAS(orderID string, from, to domain.OrderStatus, expectedVersion int64) (int64, error) {
+	return expectedVersion + 1, nil
+}
+
+func (s *stubOrderRepository) Delete(id string) error {
+	if s.deleteFn != nil {
+		return s.deleteFn(id)
+	}
+	return nil
+}
+
 type stubTimelineRepository struct {
 	appendFn func(domain.TimelineEvent) error
 	listFn   func(string) ([]domain.TimelineEvent, error)
@@ -69,29 +96,33 @@ func (s *stubTimelineRepository) List(orderID string) ([]domain.TimelineEvent, e
 	return nil, nil
 }
 
+func (s *stubTimelineRepository) DeleteByOrder(string) error {
+	return nil
+}
+
 type stubIdempotencyRepository struct {
-	markDoneFn   func(string, []byte, int) error
-	markFailedFn func(string, []byte, int) error
+	markDoneFn   func(domain.IdempotencyScope, []byte, int) error
+	markFailedFn func(domain.IdempotencyScope, []byte, int) error
 }
 
-func (s *stubIdempotencyRepository) CreateProcessing(string, string, time.Time) (domain.IdempotencyRecord, error) {
+func (s *stubIdempotencyRepository) CreateProcessing(domain.IdempotencyScope, string, time.Time) (domain.IdempotencyRecord, error) {
 	return domain.IdempotencyRecord{}, errors.New("not implemented")
 }
 
-func (s *stubIdempotencyRepository) Get(string) (domain.IdempotencyRecord, error) {
+func (s *stubIdempotencyRepository) Get(domain.IdempotencyScope) (domain.IdempotencyRecord, error) {
 	return domain.IdempotencyRecord{}, errors.New("not implemented")
 }
 
-func (s *stubIdempotencyRepository) MarkDone(key string, body []byte, code int) error {
+func (s *stubIdempotencyRepository) MarkDone(scope domain.IdempotencyScope, body []byte, code int) error {
 	if s.markDoneFn != nil {
-		return s.markDoneFn(key, body, code)
+		return s.markDoneFn(scope, body, code)
 	}
 	return nil
 }
 
-func (s *stubIdempotencyRepository) MarkFailed(key string, body []byte, code int) error {
+func (s *stubIdempotencyRepository) MarkFailed(scope domain.IdempotencyScope, body []byte, code int) error {
 	if s.markFailedFn != nil {
-		return s.markFailedFn(key, body, code)
+		return s.markFailedFn(scope, body, code)
 	}
 	return nil
 }
@@ -100,6 +131,10 @@ func (s *stubIdempotencyRepository) DeleteExpired(time.Time, int) (int, error) {
 	return 0, nil
 }
 
+func (s *stubIdempotencyRepository) FailStaleProcessing(time.Time, int, []byte, int) ([]domain.IdempotencyRecord, error) {
+	return nil, nil
+}
+
 func newInternalTestService(repo domain.OrderRepository) *OrderService {
 	return NewOrderService(
 		repo,
@@ -110,6 +145,30 @@ func newInternalTestService(repo domain.OrderRepository) *OrderService {
 	)
 }
 
+// seedTestOrder создаёт заказ order-1 клиента c-1 на 1300 USD: две единицы SKU-1 по 500 (i-1)
+// и одна SKU-2 за 300 (i-2).
+func seedTestOrder(t *testing.T, repo domain.OrderRepository, status domain.OrderStatus) domain.Order {
+	t.Helper()
+	now := time.Now().UTC()
+	order := domain.Order{
+		ID:          "order-1",
+		CustomerID:  "c-1",
+		Status:      status,
+		Currency:    "USD",
+		AmountMinor: 1300,
+		Items: []domain.OrderItem{
+			{ID: "i-1", SKU: "SKU-1", Qty: 2, PriceMinor: 500, CreatedAt: now},
+			{ID: "i-2", SKU: "SKU-2", Qty: 1, PriceMinor: 300, CreatedAt: now},
+		},
+		CreatedAt: now,
+		UpdatedAt: now,
+	}
+	if err := repo.Create(order); err != nil {
+		t.Fatalf("create order: %v", err)
+	}
+	return order
+}
+
 func mustStatusCode(t *testing.T, err error, expected codes.Code) {
 	t.Helper()
 	if status.Code(err) != expected {
@@ -141,8 +200,13 @@ func TestNewOrderService_NilLogger(t *testing.T) {
 	}
 }
 
+// Полные правила полей проверяет UnaryValidationInterceptor (validation_test.go); здесь —
+// проверки, которые обработчик намеренно дублирует для вызовов в обход interceptor'а. Каждый
+// случай прогоняется и через interceptor, чтобы копии правил не разошлись.
 func TestCreateOrderInternal_ValidationErrors(t *testing.T) {
 	service := newInternalTestService(&stubOrderRepository{})
+	interceptor := UnaryValidationInterceptor(nil)
+	info := &grpc.UnaryServerInfo{FullMethod: grpcMethodCreateOrder}
 
 	tests := []struct {
 		name string
@@ -166,6 +230,15 @@ func TestCreateOrderInternal_ValidationErrors(t *testing.T) {
 				t.Fatal("expected validation error")
 			}
 			mustStatusCode(t, err, codes.InvalidArgument)
+
+			if tt.req == nil {
+				return
+			}
+			_, err = interceptor(context.Background(), tt.req, info, func(context.Context, any) (any, error) {
+				t.Fatal("validation interceptor must reject the request before the handler")
+				return nil, nil
+			})
+			mustStatusCode(t, err, codes.InvalidArgument)
 		})
 	}
 }
@@ -204,6 +277,81 @@ func TestCreateOrderInternal_CreateErrorMapping(t *testing.T) {
 	}
 }
 
+type stubOrderUnitOfWork struct {
+	orders []domain.Order
+	scopes []domain.IdempotencyScope
+	bodies [][]byte
+	err    error
+}
+
+func (s *stubOrderUnitOfWork) CreateOrderWithIdempotency(order domain.Order, scope domain.IdempotencyScope, body []byte, _ int) error {
+	if s.err != nil {
+		return s.err
+	}
+	s.orders = append(s.orders, order)
+	s.scopes = append(s.scopes, scope)
+	s.bodies = append(s.bodies, body)
+	return nil
+}
+
+func TestCreateOrder_UsesUnitOfWorkWithIdempotencyKey(t *testing.T) {
+	repo := &stubOrderRepository{createFn: func(domain.Order) error {
+		t.Fatal("repo.Create must not be called when unit of work handles the request")
+		return nil
+	}}
+	idem := memory.NewIdempotencyRepository()
+	uow := &stubOrderUnitOfWork{}
+	service := NewOrderService(repo, &stubTimelineRepository{}, idem, nil, log.New().WithField("test", "internal"), WithOrderUnitOfWork(uow))
+
+	ctx := metadata.NewIncomingContext(context.Background(), metadata.Pairs(idempotencyKeyHeader, "uow-key"))
+	resp, err := service.CreateOrder(ctx, validCreateRequest())
+	if err != nil {
+		t.Fatalf("CreateOrder failed: %v", err)
+	}
+	wantScope := domain.IdempotencyScope{Method: grpcMethodCreateOrder, CustomerID: "customer-1", Key: "uow-key"}
+	if len(uow.scopes) != 1 || uow.scopes[0] != wantScope || len(uow.bodies[0]) == 0 {
+		t.Fatalf("expected unit of work call with idempotency scope and response, got scopes=%v", uow.scopes)
+	}
+	if uow.orders[0].ID != resp.GetOrder().GetId() {
+		t.Fatalf("unit of work persisted %s, response has %s", uow.orders[0].ID, resp.GetOrder().GetId())
+	}
+
+	// Stub UoW не трогает запись: если бы сервис вызвал MarkDone отдельно, статус стал бы done.
+	record, err := idem.Get(wantScope)
+	if err != nil {
+		t.Fatalf("Get failed: %v", err)
+	}
+	if record.Status != domain.IdempotencyStatusProcessing {
+		t.Fatalf("MarkDone must be skipped after transactional commit, got status %s", record.Status)
+	}
+
+	uow.err = errors.New("tx aborted")
+	ctx = metadata.NewIncomingContext(context.Background(), metadata.Pairs(idempotencyKeyHeader, "uow-key-2"))
+	_, err = service.CreateOrder(ctx, validCreateRequest())
+	mustStatusCode(t, err, codes.Internal)
+	wantScope.Key = "uow-key-2"
+	if record, _ := idem.Get(wantScope); record.Status != domain.IdempotencyStatusFailed {
+		t.Fatalf("expected failed idempotency record after unit of work error, got %s", record.Status)
+	}
+}
+
+func TestCreateOrderInternal_WithoutIdempotencyScopeUsesRepository(t *testing.T) {
+	created := 0
+	repo := &stubOrderRepository{createFn: func(domain.Order) error {
+		created++
+		return nil
+	}}
+	uow := &stubOrderUnitOfWork{}
+	service := NewOrderService(repo, &stubTimelineRepository{}, nil, nil, nil, WithOrderUnitOfWork(uow))
+
+	if _, err := service.CreateOrder(context.Background(), validCreateRequest()); err != nil {
+		t.Fatalf("CreateOrder failed: %v", err)
+	}
+	if created != 1 || len(uow.orders) != 0 {
+		t.Fatalf("expected repository create without idempotency, got repo=%d uow=%d", created, len(uow.orders))
+	}
+}
+
 func TestListOrders_Branches(t *testing.T) {
 	service := newInternalTestService(&stubOrderRepository{})
 
@@ -236,8 +384,8 @@ func TestListOrders_Branches(t *testing.T) {
 	if err != nil {
 		t.Fatalf("ListOrders returned error: %v", err)
 	}
-	if gotLimit != defaultListOrdersLimit {
-		t.Fatalf("expected default limit %d, got %d", defaultListOrdersLimit, gotLimit)
+	if gotLimit != DefaultListPageSize {
+		t.Fatalf("expected default limit %d, got %d", DefaultListPageSize, gotLimit)
 	}
 	if len(resp.Orders) != 1 {
 		t.Fatalf("expected one order, got %d", len(resp.Orders))
@@ -303,13 +451,13 @@ func TestLoadOrderAndSaveOrder_ErrorMappings(t *testing.T) {
 }
 
 func TestIdempotencyFailureHelpers(t *testing.T) {
-	var gotKey string
+	var gotScope domain.IdempotencyScope
 	var gotPayload []byte
 	var gotStatus int
 
 	idem := &stubIdempotencyRepository{
-		markFailedFn: func(key string, payload []byte, statusCode int) error {
-			gotKey = key
+		markFailedFn: func(scope domain.IdempotencyScope, payload []byte, statusCode int) error {
+			gotScope = scope
 			gotPayload = append([]byte(nil), payload...)
 			gotStatus = statusCode
 			return nil
@@ -324,9 +472,10 @@ func TestIdempotencyFailureHelpers(t *testing.T) {
 		log.New().WithField("test", "idempotency"),
 	)
 
-	service.cacheIdempotencyFailure("idem-1", status.Error(codes.FailedPrecondition, "failed before commit"))
-	if gotKey != "idem-1" {
-		t.Fatalf("expected key idem-1, got %s", gotKey)
+	scope := domain.IdempotencyScope{Method: grpcMethodPayOrder, CustomerID: "customer-1", Key: "idem-1"}
+	service.cacheIdempotencyFailure(scope, status.Error(codes.FailedPrecondition, "failed before commit"))
+	if gotScope != scope {
+		t.Fatalf("expected scope %+v, got %+v", scope, gotScope)
 	}
 	if gotStatus != int(codes.FailedPrecondition) {
 		t.Fatalf("expected code %d, got %d", int(codes.FailedPrecondition), gotStatus)
@@ -336,9 +485,9 @@ func TestIdempotencyFailureHelpers(t *testing.T) {
 	}
 
 	service.idemRepo = &stubIdempotencyRepository{
-		markFailedFn: func(string, []byte, int) error { return errors.New("store failed") },
+		markFailedFn: func(domain.IdempotencyScope, []byte, int) error { return errors.New("store failed") },
 	}
-	service.cacheIdempotencyFailure("idem-2", nil)
+	service.cacheIdempotencyFailure(domain.IdempotencyScope{Key: "idem-2"}, nil)
 }
 
 func TestDecodeIdempotencyFailure_Branches(t *testing.T) {
@@ -368,6 +517,35 @@ func TestDecodeIdempotencyFailure_Branches(t *testing.T) {
 	mustStatusCode(t, err, codes.Internal)
 }
 
+func TestCreateOrder_ReplaysStaleProcessingAsAborted(t *testing.T) {
+	idem := memory.NewIdempotencyRepository()
+	service := NewOrderService(memory.NewOrderRepository(), &stubTimelineRepository{}, idem, nil, log.New().WithField("test", "stale"))
+
+	// Запрос упал между CreateProcessing и MarkDone: ключ остался в processing.
+	hash, err := buildIdempotencyRequestHash(grpcMethodCreateOrder, validCreateRequest())
+	if err != nil {
+		t.Fatalf("build hash failed: %v", err)
+	}
+	scope := domain.IdempotencyScope{Method: grpcMethodCreateOrder, CustomerID: "customer-1", Key: "stale-key"}
+	if _, err := idem.CreateProcessing(scope, hash, time.Now().Add(time.Hour)); err != nil {
+		t.Fatalf("CreateProcessing failed: %v", err)
+	}
+	ctx := metadata.NewIncomingContext(context.Background(), metadata.Pairs(idempotencyKeyHeader, "stale-key"))
+	_, err = service.CreateOrder(ctx, validCreateRequest())
+	mustStatusCode(t, err, codes.Aborted)
+
+	worker := idempotencysvc.NewCleanupWorker(idem, idempotencysvc.WithRegisterer(prometheus.NewRegistry()))
+	if failed, err := worker.FailStaleProcessing(context.Background(), time.Now().Add(time.Minute)); err != nil || failed != 1 {
+		t.Fatalf("expected one stale key failed, got %d (%v)", failed, err)
+	}
+
+	_, err = service.CreateOrder(ctx, validCreateRequest())
+	mustStatusCode(t, err, codes.Aborted)
+	if msg := status.Convert(err).Message(); !strings.Contains(msg, "interrupted") {
+		t.Fatalf("expected stale processing message, got %q", msg)
+	}
+}
+
 func TestUtilityHelpers(t *testing.T) {
 	hash, err := buildIdempotencyRequestHash(grpcMethodCreateOrder, validCreateRequest())
 	if err != nil {
@@ -438,10 +616,182 @@ func TestTimelineHelpersAndRunSagaAsync(t *testing.T) {
 		t.Fatalf("unexpected timeline response: %+v", tl)
 	}
 
-	service.sagaClosed = true
 	called := false
-	service.runSagaAsync("order-1", func() { called = true })
+	service.saga = &funcOrchestrator{startFn: func(context.Context, string) { called = true }}
+	service.sagaClosed = true
+	service.runSagaAsync(context.Background(), domain.SagaDispatchIntent{OrderID: "order-1", Operation: domain.SagaOperationStart})
 	if called {
 		t.Fatal("saga must not start after shutdown")
 	}
 }
+
+// funcOrchestrator — saga.Orchestrator, делегирующий вызовы функциям теста.
+type funcOrchestrator struct {
+	startFn  func(ctx context.Context, orderID string)
+	cancelFn func(ctx context.Context, orderID, reason string)
+}
+
+func (o *funcOrchestrator) Start(ctx context.Context, orderID string) {
+	if o.startFn != nil {
+		o.startFn(ctx, orderID)
+	}
+}
+
+func (o *funcOrchestrator) Cancel(ctx context.Context, orderID, reason string) {
+	if o.cancelFn != nil {
+		o.cancelFn(ctx, orderID, reason)
+	}
+}
+
+func (o *funcOrchestrator) Refund(context.Context, string, int64, string) {}
+
+type sagaCtxKey struct{}
+
+func TestRunSagaAsync_DetachesFromRequestButKeepsDeadline(t *testing.T) {
+	type observed struct {
+		err      error
+		value    any
+		deadline time.Time
+		ok       bool
+	}
+	result := make(chan observed, 1)
+	orchestrator := &funcOrchestrator{startFn: func(ctx context.Context, _ string) {
+		deadline, ok := ctx.Deadline()
+		result <- observed{err: ctx.Err(), value: ctx.Value(sagaCtxKey{}), deadline: deadline, ok: ok}
+	}}
+	service := NewOrderService(&stubOrderRepository{}, nil, nil, orchestrator, log.New().WithField("test", "saga-ctx"), WithSagaTimeout(time.Minute))
+
+	reqCtx, cancelReq := context.WithCancel(context.WithValue(context.Background(), sagaCtxKey{}, "trace-1"))
+	cancelReq()
+
+	service.runSagaAsync(reqCtx, domain.SagaDispatchIntent{OrderID: "order-1", Operation: domain.SagaOperationStart})
+
+	got := <-result
+	if got.err != nil {
+		t.Fatalf("saga context must not inherit request cancellation, got %v", got.err)
+	}
+	if got.value != "trace-1" {
+		t.Fatalf("expected request values in saga context, got %v", got.value)
+	}
+	if !got.ok || time.Until(got.deadline) > time.Minute {
+		t.Fatalf("expected saga deadline within timeout, got %v (ok=%v)", got.deadline, got.ok)
+	}
+	if err := service.Shutdown(context.Background()); err != nil {
+		t.Fatalf("shutdown: %v", err)
+	}
+}
+
+type failingSagaDispatchRepository struct {
+	domain.SagaDispatchRepository
+}
+
+func (failingSagaDispatchRepository) Record(domain.SagaDispatchIntent) (domain.SagaDispatchIntent, error) {
+	return domain.SagaDispatchIntent{}, errors.New("db down")
+}
+
+func TestDispatchSaga_PersistsIntentUntilSagaCompletes(t *testing.T) {
+	dispatchRepo := memory.NewSagaDispatchRepository()
+	release := make(chan struct{})
+	canceled := make(chan struct{}, 1)
+	var calls []string
+	var mu sync.Mutex
+	orchestrator := &funcOrchestrator{
+		startFn: func(_ context.Context, orderID string) {
+			<-release
+			mu.Lock()
+			calls = append(calls, "start:"+orderID)
+			mu.Unlock()
+		},
+		cancelFn: func(_ context.Context, orderID, reason string) {
+			mu.Lock()
+			calls = append(calls, "cancel:"+orderID+":"+reason)
+			mu.Unlock()
+			canceled <- struct{}{}
+		},
+	}
+	service := NewOrderService(&stubOrderRepository{}, nil, nil, orchestrator, log.New().WithField("test", "dispatch"), WithSagaDispatchRepository(dispatchRepo))
+
+	if err := service.dispatchSaga(context.Background(), domain.SagaDispatchIntent{OrderID: "order-1", Operation: domain.SagaOperationStart}); err != nil {
+		t.Fatalf("dispatch: %v", err)
+	}
+	// Намерение арендовано выполняющим процессом: другая реплика его не забирает.
+	now := time.Now().UTC()
+	if claimed, _ := dispatchRepo.ClaimPending(now, now, 0); len(claimed) != 0 {
+		t.Fatalf("running intent must stay claimed, got %+v", claimed)
+	}
+	if claimed, _ := dispatchRepo.ClaimPending(now.Add(sagaDispatchLease), now, 0); len(claimed) != 1 {
+		t.Fatalf("expected intent persisted while saga runs, got %d", len(claimed))
+	}
+	close(release)
+	if err := service.Shutdown(context.Background()); err != nil {
+		t.Fatalf("shutdown: %v", err)
+	}
+	if pending, _ := dispatchRepo.ClaimPending(time.Now().Add(sagaDispatchLease), time.Time{}, 0); len(pending) != 0 {
+		t.Fatalf("expected intent completed after saga, got %+v", pending)
+	}
+
+	// После Shutdown намерение сохраняется без аренды и не выполняется — его сразу подхватит
+	// следующий процесс.
+	if err := service.dispatchSaga(context.Background(), domain.SagaDispatchIntent{OrderID: "order-2", Operation: domain.SagaOperationCancel, Reason: "late"}); err != nil {
+		t.Fatalf("dispatch after shutdown: %v", err)
+	}
+
+	restarted := NewOrderService(&stubOrderRepository{}, nil, nil, orchestrator, log.New().WithField("test", "dispatch-replay"), WithSagaDispatchRepository(dispatchRepo))
+	replayed, err := restarted.ReplaySagaIntents(context.Background())
+	if err != nil || replayed != 1 {
+		t.Fatalf("replay: replayed=%d err=%v", replayed, err)
+	}
+	<-canceled
+	if err := restarted.Shutdown(context.Background()); err != nil {
+		t.Fatalf("shutdown: %v", err)
+	}
+
+	mu.Lock()
+	defer mu.Unlock()
+	if len(calls) != 2 || calls[1] != "cancel:order-2:late" {
+		t.Fatalf("unexpected saga calls: %v", calls)
+	}
+	if pending, _ := dispatchRepo.ClaimPending(time.Now().Add(sagaDispatchLease), time.Time{}, 0); len(pending) != 0 {
+		t.Fatalf("expected replayed intent completed, got %+v", pending)
+	}
+}
+
+func TestReplaySagaIntents_SkipsIntentsClaimedByLiveReplica(t *testing.T) {
+	dispatchRepo := memory.NewSagaDispatchRepository()
+	now := time.Now().UTC()
+	if _, err := dispatchRepo.Record(domain.SagaDispatchIntent{OrderID: "order-live", Operation: domain.SagaOperationStart, ClaimedUntil: now.Add(time.Minute)}); err != nil {
+		t.Fatalf("record live intent: %v", err)
+	}
+	if _, err := dispatchRepo.Record(domain.SagaDispatchIntent{OrderID: "order-stale", Operation: domain.SagaOperationStart, ClaimedUntil: now.Add(-time.Second)}); err != nil {
+		t.Fatalf("record stale intent: %v", err)
+	}
+	var started []string
+	orchestrator := &funcOrchestrator{startFn: func(_ context.Context, orderID string) { started = append(started, orderID) }}
+	service := NewOrderService(&stubOrderRepository{}, nil, nil, orchestrator, log.New().WithField("test", "dispatch-claim"), WithSagaDispatchRepository(dispatchRepo))
+
+	replayed, err := service.ReplaySagaIntents(context.Background())
+	if err != nil || replayed != 1 || len(started) != 1 || started[0] != "order-stale" {
+		t.Fatalf("expected only the expired intent replayed, got replayed=%d started=%v err=%v", replayed, started, err)
+	}
+	if claimed, _ := dispatchRepo.ClaimPending(now.Add(2*time.Minute), now, 0); len(claimed) != 1 || claimed[0].OrderID != "order-live" {
+		t.Fatalf("expected the live intent to stay for its owner, got %+v", claimed)
+	}
+}
+
+func TestDispatchSaga_RecordErrorFailsRPC(t *testing.T) {
+	called := false
+	orchestrator := &funcOrchestrator{startFn: func(context.Context, string) { called = true }}
+	service := NewOrderService(&stubOrderRepository{}, nil, nil, orchestrator, log.New().WithField("test", "dispatch-error"),
+		WithSagaDispatchRepository(failingSagaDispatchRepository{}))
+
+	err := service.dispatchSaga(context.Background(), domain.SagaDispatchIntent{OrderID: "order-1", Operation: domain.SagaOperationStart})
+	if status.Code(err) != codes.Unavailable {
+		t.Fatalf("expected Unavailable, got %v", err)
+	}
+	if err := service.Shutdown(context.Background()); err != nil {
+		t.Fatalf("shutdown: %v", err)
+	}
+	if called {
+		t.Fatal("saga must not run without persisted intent")
+	}
+}
@@ -3,10 +3,13 @@ package grpcsvc_test
 import (
 	"context"
 	"net"
+	"strings"
 	"sync"
 	"testing"
 	"time"
 
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
 	"github.com/sirupsen/logrus"
 	"github.com/stretchr/testify/require"
 	"google.golang.org/grpc"
@@ -17,6 +20,7 @@ import (
 	"google.golang.org/grpc/test/bufconn"
 
 	"github.com/vladislavdragonenkov/oms/internal/domain"
+	"github.com/vladislavdragonenkov/oms/internal/keyring"
 	grpcsvc "github.com/vladislavdragonenkov/oms/internal/service/grpc"
 	"github.com/vladislavdragonenkov/oms/internal/service/saga"
 	"github.com/vladislavdragonenkov/oms/internal/storage/memory"
@@ -29,6 +33,25 @@ func idemCtx(key string) context.Context {
 	return metadata.AppendToOutgoingContext(context.Background(), "idempotency-key", key)
 }
 
+// operatorCtx пропускает ctx через UnaryAdminAuthInterceptor с токеном оператора fraud-bot
+// и возвращает контекст, который получил бы обработчик method.
+func operatorCtx(t *testing.T, ctx context.Context, method string) context.Context {
+	t.Helper()
+	keys, err := keyring.ParseTokens("fraud-bot:s3cr3t")
+	require.NoError(t, err)
+	tokens, err := keyring.New(keys, keyring.WithRegisterer(prometheus.NewRegistry()))
+	require.NoError(t, err)
+
+	ctx = metadata.NewIncomingContext(ctx, metadata.Pairs(grpcsvc.AdminAuthMetadataKey, "Bearer s3cr3t"))
+	var authed context.Context
+	_, err = grpcsvc.UnaryAdminAuthInterceptor(tokens)(ctx, nil, &grpc.UnaryServerInfo{FullMethod: method}, func(ctx context.Context, _ any) (any, error) {
+		authed = ctx
+		return nil, nil
+	})
+	require.NoError(t, err)
+	return authed
+}
+
 func newTestServer() (*grpc.ClientConn, func(), error) {
 	listener := bufconn.Listen(bufSize)
 	repo := memory.NewOrderRepository()
@@ -36,7 +59,7 @@ func newTestServer() (*grpc.ClientConn, func(), error) {
 	orchestrator := saga.NewNoop(logger.WithField("layer", "saga"))
 	service := grpcsvc.NewOrderService(repo, memory.NewTimelineRepository(), memory.NewIdempotencyRepository(), orchestrator, logger)
 
-	server := grpc.NewServer()
+	server := grpc.NewServer(grpc.UnaryInterceptor(grpcsvc.UnaryValidationInterceptor(nil)))
 	omsv1.RegisterOrderServiceServer(server, service)
 
 	go func() {
@@ -82,19 +105,19 @@ type stubOrchestrator struct {
 	}
 }
 
-func (s *stubOrchestrator) Start(orderID string) {
+func (s *stubOrchestrator) Start(_ context.Context, orderID string) {
 	s.mu.Lock()
 	defer s.mu.Unlock()
 	s.started = append(s.started, orderID)
 }
 
-func (s *stubOrchestrator) Cancel(orderID, _ string) {
+func (s *stubOrchestrator) Cancel(_ context.Context, orderID, _ string) {
 	s.mu.Lock()
 	defer s.mu.Unlock()
 	s.canceled = append(s.canceled, orderID)
 }
 
-func (s *stubOrchestrator) Refund(orderID string, amountMinor int64, reason string) {
+func (s *stubOrchestrator) Refund(_ context.Context, orderID string, amountMinor int64, reason string) {
 	s.mu.Lock()
 	defer s.mu.Unlock()
 	s.refunds = append(s.refunds, struct {
@@ -149,7 +172,7 @@ func newBlockingOrchestrator() *blockingOrchestrator {
 	}
 }
 
-func (b *blockingOrchestrator) Start(string) {
+func (b *blockingOrchestrator) Start(context.Context, string) {
 	select {
 	case b.startCalled <- struct{}{}:
 	default:
@@ -157,9 +180,9 @@ func (b *blockingOrchestrator) Start(string) {
 	<-b.release
 }
 
-func (b *blockingOrchestrator) Cancel(string, string) {}
+func (b *blockingOrchestrator) Cancel(context.Context, string, string) {}
 
-func (b *blockingOrchestrator) Refund(string, int64, string) {}
+func (b *blockingOrchestrator) Refund(context.Context, string, int64, string) {}
 
 func seedOrder(t *testing.T, repo domain.OrderRepository, status domain.OrderStatus) domain.Order {
 	t.Helper()
@@ -220,6 +243,92 @@ func TestOrderService_CreateAndGet(t *testing.T) {
 	require.NotNil(t, getResp)
 	require.Equal(t, resp.Order.Id, getResp.Order.Id)
 	require.Equal(t, resp.Order.Amount.AmountMinor, getResp.Order.Amount.AmountMinor)
+
+	require.Len(t, resp.Order.Items, 1)
+	require.NotEmpty(t, resp.Order.Items[0].Id)
+	require.Positive(t, resp.Order.Items[0].CreatedAtUnix)
+	require.Len(t, getResp.Order.Items, 1)
+	require.Equal(t, resp.Order.Items[0].Id, getResp.Order.Items[0].Id)
+	require.Equal(t, resp.Order.Items[0].CreatedAtUnix, getResp.Order.Items[0].CreatedAtUnix)
+}
+
+func TestOrderService_CreateOrder_WithAdjustments(t *testing.T) {
+	conn, cleanup, err := newTestServer()
+	require.NoError(t, err)
+	defer cleanup()
+
+	client := omsv1.NewOrderServiceClient(conn)
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	resp, err := client.CreateOrder(metadata.AppendToOutgoingContext(ctx, "idempotency-key", "create-order-adj"), &omsv1.CreateOrderRequest{
+		CustomerId: "customer-1",
+		Currency:   "USD",
+		Items: []*omsv1.OrderItem{
+			{Sku: "sku-1", Qty: 1, Price: &omsv1.Money{Currency: "USD", AmountMinor: 1999}},
+		},
+		Adjustments: []*omsv1.PriceAdjustment{
+			{Type: omsv1.AdjustmentType_ADJUSTMENT_TYPE_DISCOUNT, Code: "PROMO10", Percent: "10"},
+			{Type: omsv1.AdjustmentType_ADJUSTMENT_TYPE_TAX, Code: "VAT", Percent: "20"},
+		},
+	})
+	require.NoError(t, err)
+	// Скидка 199.9 -> 200, налог 20% от 1799 = 359.8 -> 360.
+	require.Equal(t, int64(2159), resp.Order.Amount.AmountMinor)
+
+	getResp, err := client.GetOrder(ctx, &omsv1.GetOrderRequest{OrderId: resp.Order.Id})
+	require.NoError(t, err)
+	amounts := getResp.Order.Amounts
+	require.NotNil(t, amounts)
+	require.Equal(t, int64(1999), amounts.Subtotal.AmountMinor)
+	require.Equal(t, int64(200), amounts.Discount.AmountMinor)
+	require.Equal(t, int64(360), amounts.Tax.AmountMinor)
+	require.Equal(t, int64(2159), amounts.Total.AmountMinor)
+	require.Len(t, amounts.Adjustments, 2)
+	require.Equal(t, "10", amounts.Adjustments[0].Percent)
+	require.Equal(t, int64(200), amounts.Adjustments[0].Applied.AmountMinor)
+	// Аудит округления: скидка +0.1 уменьшает итог, налог +0.2 увеличивает.
+	require.Equal(t, omsv1.RoundingMode_ROUNDING_MODE_HALF_UP, amounts.RoundingMode)
+	require.Equal(t, "0.1", amounts.Adjustments[0].RoundingDelta)
+	require.Equal(t, "0.2", amounts.Adjustments[1].RoundingDelta)
+	require.Equal(t, "0.1", amounts.RoundingDelta)
+
+	_, err = client.CreateOrder(metadata.AppendToOutgoingContext(ctx, "idempotency-key", "create-order-adj-2"), &omsv1.CreateOrderRequest{
+		CustomerId: "customer-1",
+		Currency:   "USD",
+		Items: []*omsv1.OrderItem{
+			{Sku: "sku-1", Qty: 1, Price: &omsv1.Money{Currency: "USD", AmountMinor: 100}},
+		},
+		Adjustments: []*omsv1.PriceAdjustment{
+			{Type: omsv1.AdjustmentType_ADJUSTMENT_TYPE_DISCOUNT, Fixed: &omsv1.Money{Currency: "USD", AmountMinor: 150}},
+		},
+	})
+	require.Equal(t, codes.InvalidArgument, status.Code(err))
+}
+
+func TestOrderService_CreateOrder_TestMode(t *testing.T) {
+	conn, cleanup, err := newTestServer()
+	require.NoError(t, err)
+	defer cleanup()
+
+	client := omsv1.NewOrderServiceClient(conn)
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	resp, err := client.CreateOrder(metadata.AppendToOutgoingContext(ctx, "idempotency-key", "create-order-sandbox"), &omsv1.CreateOrderRequest{
+		CustomerId: "partner-1",
+		Currency:   "USD",
+		Items: []*omsv1.OrderItem{
+			{Sku: "sku-1", Qty: 1, Price: &omsv1.Money{Currency: "USD", AmountMinor: 100}},
+		},
+		TestMode: true,
+	})
+	require.NoError(t, err)
+	require.True(t, resp.Order.TestMode)
+
+	getResp, err := client.GetOrder(ctx, &omsv1.GetOrderRequest{OrderId: resp.Order.Id})
+	require.NoError(t, err)
+	require.True(t, getResp.Order.TestMode)
 }
 
 func TestOrderService_CreateOrder_RequiresIdempotencyKey(t *testing.T) {
@@ -313,6 +422,54 @@ func TestOrderService_CreateOrder_IdempotencyHashMismatch(t *testing.T) {
 	require.Equal(t, codes.AlreadyExists, status.Code(err))
 }
 
+func TestOrderService_CreateOrder_IdempotencyKeyScopedByCustomer(t *testing.T) {
+	repo := memory.NewOrderRepository()
+	service := grpcsvc.NewOrderService(repo, memory.NewTimelineRepository(), memory.NewIdempotencyRepository(), nil, loggerForTests())
+
+	newRequest := func(customerID string) *omsv1.CreateOrderRequest {
+		return &omsv1.CreateOrderRequest{
+			CustomerId: customerID,
+			Currency:   "USD",
+			Items: []*omsv1.OrderItem{
+				{Sku: "sku-1", Qty: 1, Price: &omsv1.Money{Currency: "USD", AmountMinor: 100}},
+			},
+		}
+	}
+
+	alice, err := service.CreateOrder(idemCtx("shared-key"), newRequest("alice"))
+	require.NoError(t, err)
+	bob, err := service.CreateOrder(idemCtx("shared-key"), newRequest("bob"))
+	require.NoError(t, err)
+	require.NotEqual(t, alice.Order.Id, bob.Order.Id)
+	require.Equal(t, "bob", bob.Order.CustomerId)
+
+	replay, err := service.CreateOrder(idemCtx("shared-key"), newRequest("alice"))
+	require.NoError(t, err)
+	require.Equal(t, alice.Order.Id, replay.Order.Id)
+}
+
+func TestOrderService_PayOrder_IdempotencyKeyScopedByOrderCustomer(t *testing.T) {
+	repo := memory.NewOrderRepository()
+	now := time.Now().UTC()
+	for _, order := range []domain.Order{
+		{ID: "order-alice", CustomerID: "alice", Status: domain.OrderStatusPending, Currency: "USD", AmountMinor: 100, CreatedAt: now, UpdatedAt: now},
+		{ID: "order-bob", CustomerID: "bob", Status: domain.OrderStatusPending, Currency: "USD", AmountMinor: 100, CreatedAt: now, UpdatedAt: now},
+	} {
+		require.NoError(t, repo.Create(order))
+	}
+	service := grpcsvc.NewOrderService(repo, memory.NewTimelineRepository(), memory.NewIdempotencyRepository(), &stubOrchestrator{}, loggerForTests())
+
+	resp, err := service.PayOrder(idemCtx("pay-shared"), &omsv1.PayOrderRequest{OrderId: "order-alice"})
+	require.NoError(t, err)
+	require.Equal(t, "order-alice", resp.OrderId)
+	resp, err = service.PayOrder(idemCtx("pay-shared"), &omsv1.PayOrderRequest{OrderId: "order-bob"})
+	require.NoError(t, err)
+	require.Equal(t, "order-bob", resp.OrderId)
+
+	_, err = service.PayOrder(idemCtx("pay-unknown"), &omsv1.PayOrderRequest{OrderId: "order-missing"})
+	require.Equal(t, codes.NotFound, status.Code(err))
+}
+
 func TestOrderService_PayOrder(t *testing.T) {
 	repo := memory.NewOrderRepository()
 	seedOrder(t, repo, domain.OrderStatusPending)
@@ -404,6 +561,51 @@ func TestOrderService_RefundOrder_TriggersSaga(t *testing.T) {
 	require.Equal(t, int64(50), refunds[0].amount)
 }
 
+type captureOrchestrator struct {
+	stubOrchestrator
+	captured chan string
+}
+
+func (c *captureOrchestrator) Capture(_ context.Context, orderID string) error {
+	c.captured <- orderID
+	return nil
+}
+
+func (c *captureOrchestrator) Reauthorize(context.Context, string) error { return nil }
+
+func TestOrderService_CaptureOrder(t *testing.T) {
+	repo := memory.NewOrderRepository()
+	seedOrder(t, repo, domain.OrderStatusAuthorized)
+	orch := &captureOrchestrator{captured: make(chan string, 1)}
+	service := grpcsvc.NewOrderService(repo, memory.NewTimelineRepository(), memory.NewIdempotencyRepository(), orch, loggerForTests())
+
+	resp, err := service.CaptureOrder(idemCtx("capture-order-1"), &omsv1.CaptureOrderRequest{OrderId: "order-1"})
+	require.NoError(t, err)
+	require.Equal(t, omsv1.OrderStatus_ORDER_STATUS_AUTHORIZED, resp.Status)
+
+	select {
+	case id := <-orch.captured:
+		require.Equal(t, "order-1", id)
+	case <-time.After(time.Second):
+		t.Fatal("capture was not dispatched")
+	}
+}
+
+func TestOrderService_CaptureOrder_Rejections(t *testing.T) {
+	repo := memory.NewOrderRepository()
+	seedOrder(t, repo, domain.OrderStatusReserved)
+
+	withCapture := grpcsvc.NewOrderService(repo, memory.NewTimelineRepository(), memory.NewIdempotencyRepository(),
+		&captureOrchestrator{captured: make(chan string, 1)}, loggerForTests())
+	_, err := withCapture.CaptureOrder(idemCtx("capture-reserved"), &omsv1.CaptureOrderRequest{OrderId: "order-1"})
+	require.Equal(t, codes.FailedPrecondition, status.Code(err))
+
+	withoutCapture := grpcsvc.NewOrderService(repo, memory.NewTimelineRepository(), memory.NewIdempotencyRepository(),
+		&stubOrchestrator{}, loggerForTests())
+	_, err = withoutCapture.CaptureOrder(idemCtx("capture-unsupported"), &omsv1.CaptureOrderRequest{OrderId: "order-1"})
+	require.Equal(t, codes.Unimplemented, status.Code(err))
+}
+
 func TestOrderService_GetOrder_WithTimeline(t *testing.T) {
 	repo := memory.NewOrderRepository()
 	timeline := memory.NewTimelineRepository()
@@ -494,3 +696,106 @@ func TestOrderService_Shutdown_SkipsNewSagaDispatch(t *testing.T) {
 	time.Sleep(20 * time.Millisecond)
 	require.Empty(t, stub.getStarted())
 }
+
+func TestOrderService_HoldAndReleaseOrder(t *testing.T) {
+	repo := memory.NewOrderRepository()
+	timeline := memory.NewTimelineRepository()
+	seedOrder(t, repo, domain.OrderStatusReserved)
+	stub := &stubOrchestrator{}
+	registry := prometheus.NewRegistry()
+	service := grpcsvc.NewOrderService(repo, timeline, memory.NewIdempotencyRepository(), stub, loggerForTests(), grpcsvc.WithOrderRegisterer(registry))
+
+	// Без оператора hold и release недоступны: клиент не снимает антифрод-hold сам.
+	_, err := service.HoldOrder(idemCtx("hold-anonymous"), &omsv1.HoldOrderRequest{OrderId: "order-1", Reason: "fraud score 0.93"})
+	require.Equal(t, codes.Unauthenticated, status.Code(err))
+
+	holdResp, err := service.HoldOrder(operatorCtx(t, idemCtx("hold-order-1"), omsv1.OrderService_HoldOrder_FullMethodName), &omsv1.HoldOrderRequest{OrderId: "order-1", Reason: "fraud score 0.93"})
+	require.NoError(t, err)
+	require.Equal(t, omsv1.OrderStatus_ORDER_STATUS_ON_HOLD, holdResp.Status)
+	require.Equal(t, "fraud score 0.93", holdResp.HoldReason)
+
+	getResp, err := service.GetOrder(context.Background(), &omsv1.GetOrderRequest{OrderId: "order-1"})
+	require.NoError(t, err)
+	require.Equal(t, omsv1.OrderStatus_ORDER_STATUS_ON_HOLD, getResp.Order.Status)
+	require.Equal(t, "fraud score 0.93", getResp.Order.HoldReason)
+
+	_, err = service.HoldOrder(operatorCtx(t, idemCtx("hold-order-2"), omsv1.OrderService_HoldOrder_FullMethodName), &omsv1.HoldOrderRequest{OrderId: "order-1", Reason: "again"})
+	require.Equal(t, codes.FailedPrecondition, status.Code(err))
+
+	_, err = service.ReleaseOrder(idemCtx("release-anonymous"), &omsv1.ReleaseOrderRequest{OrderId: "order-1", Reason: "manual review passed"})
+	require.Equal(t, codes.Unauthenticated, status.Code(err))
+
+	releaseResp, err := service.ReleaseOrder(operatorCtx(t, idemCtx("release-order-1"), omsv1.OrderService_ReleaseOrder_FullMethodName), &omsv1.ReleaseOrderRequest{OrderId: "order-1", Reason: "manual review passed"})
+	require.NoError(t, err)
+	require.Equal(t, omsv1.OrderStatus_ORDER_STATUS_RESERVED, releaseResp.Status)
+
+	stored, err := repo.Get("order-1")
+	require.NoError(t, err)
+	require.Equal(t, domain.OrderStatusReserved, stored.Status)
+	require.Empty(t, stored.HoldReason)
+
+	events, err := timeline.List("order-1")
+	require.NoError(t, err)
+	var types []string
+	for _, event := range events {
+		types = append(types, event.Type)
+	}
+	require.Contains(t, types, "OrderHeld")
+	require.Contains(t, types, "OrderReleased")
+
+	expected := `
+# HELP oms_order_status_transitions_total Total number of order status transitions by source status, target status, result and order mode (live or test)
+# TYPE oms_order_status_transitions_total counter
+oms_order_status_transitions_total{from="on_hold",mode="live",result="ok",to="reserved"} 1
+oms_order_status_transitions_total{from="on_hold",mode="live",result="rejected",to="on_hold"} 1
+oms_order_status_transitions_total{from="reserved",mode="live",result="ok",to="on_hold"} 1
+`
+	require.NoError(t, testutil.GatherAndCompare(registry, strings.NewReader(expected), "oms_order_status_transitions_total"))
+
+	// Сага продолжается после снятия hold.
+	time.Sleep(10 * time.Millisecond)
+	require.Equal(t, []string{"order-1"}, stub.getStarted())
+}
+
+func TestOrderService_HoldOrder_Validation(t *testing.T) {
+	repo := memory.NewOrderRepository()
+	seedOrder(t, repo, domain.OrderStatusConfirmed)
+	service := grpcsvc.NewOrderService(repo, memory.NewTimelineRepository(), memory.NewIdempotencyRepository(), nil, loggerForTests())
+
+	_, err := service.HoldOrder(operatorCtx(t, idemCtx("hold-no-reason"), omsv1.OrderService_HoldOrder_FullMethodName), &omsv1.HoldOrderRequest{OrderId: "order-1"})
+	require.Equal(t, codes.InvalidArgument, status.Code(err))
+
+	_, err = service.HoldOrder(operatorCtx(t, idemCtx("hold-confirmed"), omsv1.OrderService_HoldOrder_FullMethodName), &omsv1.HoldOrderRequest{OrderId: "order-1", Reason: "fraud"})
+	require.Equal(t, codes.FailedPrecondition, status.Code(err))
+
+	_, err = service.ReleaseOrder(operatorCtx(t, idemCtx("release-not-held"), omsv1.OrderService_ReleaseOrder_FullMethodName), &omsv1.ReleaseOrderRequest{OrderId: "order-1"})
+	require.Equal(t, codes.FailedPrecondition, status.Code(err))
+}
+
+func TestOrderService_RejectsMalformedIDs(t *testing.T) {
+	repo := memory.NewOrderRepository()
+	seedOrder(t, repo, domain.OrderStatusPending)
+	service := grpcsvc.NewOrderService(repo, memory.NewTimelineRepository(), memory.NewIdempotencyRepository(), nil, loggerForTests())
+
+	_, err := service.GetOrder(context.Background(), &omsv1.GetOrderRequest{OrderId: "order-1' OR '1'='1"})
+	require.Equal(t, codes.InvalidArgument, status.Code(err))
+	require.Contains(t, status.Convert(err).Message(), domain.ErrOrderIDInvalid.Error())
+
+	_, err = service.PayOrder(idemCtx("pay-malformed"), &omsv1.PayOrderRequest{OrderId: strings.Repeat("o", domain.MaxIDLength+1)})
+	require.Equal(t, codes.InvalidArgument, status.Code(err))
+
+	_, err = service.ListOrders(context.Background(), &omsv1.ListOrdersRequest{CustomerId: "customer\t1"})
+	require.Equal(t, codes.InvalidArgument, status.Code(err))
+
+	_, err = service.CreateOrder(idemCtx("create-malformed"), &omsv1.CreateOrderRequest{
+		CustomerId: "customer 1",
+		Currency:   "USD",
+		Items:      []*omsv1.OrderItem{{Sku: "sku-1", Qty: 1, Price: &omsv1.Money{Currency: "USD", AmountMinor: 100}}},
+	})
+	require.Equal(t, codes.InvalidArgument, status.Code(err))
+	require.Contains(t, status.Convert(err).Message(), domain.ErrCustomerIDInvalid.Error())
+
+	_, err = service.GetOrder(context.Background(), &omsv1.GetOrderRequest{})
+	require.Equal(t, codes.InvalidArgument, status.Code(err))
+	require.Equal(t, "order_id is required", status.Convert(err).Message())
+}
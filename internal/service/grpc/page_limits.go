@@ -0,0 +1,76 @@
+package grpcsvc
+
+import (
+	"context"
+	"fmt"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	omsv1 "github.com/vladislavdragonenkov/oms/proto/oms/v1"
+)
+
+const (
+	// DefaultListPageSize — размер страницы ListOrders, если клиент не задал page_size.
+	DefaultListPageSize = 100
+	// DefaultMaxListPageSize — максимальный page_size по умолчанию.
+	DefaultMaxListPageSize = 1000
+	// PageSizeCeiling — верхняя граница настраиваемого Max: страница целиком собирается в памяти.
+	PageSizeCeiling = 10000
+)
+
+// PageLimits — размеры страниц списочных RPC. Общие для ListOrders и последующих списков,
+// клиенты узнают их через GetServiceInfo.
+type PageLimits struct {
+	Default int
+	Max     int
+}
+
+// DefaultPageLimits возвращает лимиты, с которыми сервис работает без настройки.
+func DefaultPageLimits() PageLimits {
+	return PageLimits{Default: DefaultListPageSize, Max: DefaultMaxListPageSize}
+}
+
+// Validate проверяет, что оба лимита положительны, Default не больше Max, а Max не больше 10000.
+func (l PageLimits) Validate() error {
+	switch {
+	case l.Default <= 0:
+		return fmt.Errorf("default page size must be > 0, got %d", l.Default)
+	case l.Max <= 0:
+		return fmt.Errorf("max page size must be > 0, got %d", l.Max)
+	case l.Max > PageSizeCeiling:
+		return fmt.Errorf("max page size must be <= %d, got %d", PageSizeCeiling, l.Max)
+	case l.Default > l.Max:
+		return fmt.Errorf("default page size %d exceeds max page size %d", l.Default, l.Max)
+	}
+	return nil
+}
+
+// resolve возвращает размер страницы для запроса: незаданный — Default, больше Max — InvalidArgument.
+// Запрос не урезается молча, чтобы клиент не принял неполную выдачу за полную.
+func (l PageLimits) resolve(pageSize int32) (int, error) {
+	switch {
+	case pageSize <= 0:
+		return l.Default, nil
+	case int(pageSize) > l.Max:
+		return 0, status.Errorf(codes.InvalidArgument, "page_size %d exceeds maximum %d", pageSize, l.Max)
+	}
+	return int(pageSize), nil
+}
+
+// WithPageLimits задаёт размеры страниц списков; лимиты должны пройти Validate.
+func WithPageLimits(limits PageLimits) OrderServiceOption {
+	return func(s *OrderService) {
+		s.pageLimits = limits
+	}
+}
+
+// GetServiceInfo сообщает клиентам параметры сервиса, влияющие на запросы.
+func (s *OrderService) GetServiceInfo(context.Context, *omsv1.GetServiceInfoRequest) (*omsv1.GetServiceInfoResponse, error) {
+	return &omsv1.GetServiceInfoResponse{
+		PageLimits: &omsv1.PageLimits{
+			DefaultPageSize: int32(s.pageLimits.Default), // #nosec G115 -- Validate ограничивает лимиты PageSizeCeiling.
+			MaxPageSize:     int32(s.pageLimits.Max),     // #nosec G115 -- Validate ограничивает лимиты PageSizeCeiling.
+		},
+	}, nil
+}
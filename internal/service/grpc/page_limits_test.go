@@ -0,0 +1,69 @@
+package grpcsvc
+
+import (
+	"context"
+	"testing"
+
+	log "github.com/sirupsen/logrus"
+	"google.golang.org/grpc/codes"
+
+	"github.com/vladislavdragonenkov/oms/internal/domain"
+	omsv1 "github.com/vladislavdragonenkov/oms/proto/oms/v1"
+)
+
+func TestPageLimits_Validate(t *testing.T) {
+	if err := DefaultPageLimits().Validate(); err != nil {
+		t.Fatalf("default limits must be valid: %v", err)
+	}
+	for name, limits := range map[string]PageLimits{
+		"zero default":      {Default: 0, Max: 10},
+		"zero max":          {Default: 10, Max: 0},
+		"default above max": {Default: 20, Max: 10},
+		"max above ceiling": {Default: 10, Max: PageSizeCeiling + 1},
+	} {
+		if err := limits.Validate(); err == nil {
+			t.Fatalf("%s: expected validation error for %+v", name, limits)
+		}
+	}
+}
+
+func TestListOrders_EnforcesPageLimits(t *testing.T) {
+	var gotLimit int
+	service := NewOrderService(
+		&stubOrderRepository{listFn: func(_ string, limit int) ([]domain.Order, error) {
+			gotLimit = limit
+			return nil, nil
+		}},
+		&stubTimelineRepository{},
+		nil,
+		nil,
+		log.New().WithField("test", "page-limits"),
+		WithPageLimits(PageLimits{Default: 20, Max: 50}),
+	)
+	ctx := context.Background()
+
+	if _, err := service.ListOrders(ctx, &omsv1.ListOrdersRequest{CustomerId: "customer-1"}); err != nil {
+		t.Fatalf("ListOrders failed: %v", err)
+	}
+	if gotLimit != 20 {
+		t.Fatalf("expected configured default 20, got %d", gotLimit)
+	}
+	if _, err := service.ListOrders(ctx, &omsv1.ListOrdersRequest{CustomerId: "customer-1", PageSize: 50}); err != nil || gotLimit != 50 {
+		t.Fatalf("page_size equal to max must pass: limit=%d err=%v", gotLimit, err)
+	}
+
+	gotLimit = 0
+	_, err := service.ListOrders(ctx, &omsv1.ListOrdersRequest{CustomerId: "customer-1", PageSize: 1_000_000})
+	mustStatusCode(t, err, codes.InvalidArgument)
+	if gotLimit != 0 {
+		t.Fatal("repository must not be queried for an oversized page")
+	}
+
+	info, err := service.GetServiceInfo(ctx, &omsv1.GetServiceInfoRequest{})
+	if err != nil {
+		t.Fatalf("GetServiceInfo failed: %v", err)
+	}
+	if info.GetPageLimits().GetDefaultPageSize() != 20 || info.GetPageLimits().GetMaxPageSize() != 50 {
+		t.Fatalf("unexpected page limits: %v", info.GetPageLimits())
+	}
+}
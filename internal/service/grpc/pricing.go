@@ -0,0 +1,101 @@
+package grpcsvc
+
+import (
+	"fmt"
+
+	"github.com/vladislavdragonenkov/oms/internal/domain"
+	omsv1 "github.com/vladislavdragonenkov/oms/proto/oms/v1"
+)
+
+// fromProtoAdjustments переводит строки скидок и налогов запроса в доменные.
+// Сумма строки (applied) из запроса игнорируется: её считает сервер.
+func fromProtoAdjustments(adjustments []*omsv1.PriceAdjustment, currency string) ([]domain.PriceAdjustment, error) {
+	result := make([]domain.PriceAdjustment, 0, len(adjustments))
+	for idx, adj := range adjustments {
+		if adj == nil {
+			return nil, fmt.Errorf("adjustments[%d] is nil", idx)
+		}
+		var adjType domain.AdjustmentType
+		switch adj.Type {
+		case omsv1.AdjustmentType_ADJUSTMENT_TYPE_DISCOUNT:
+			adjType = domain.AdjustmentDiscount
+		case omsv1.AdjustmentType_ADJUSTMENT_TYPE_TAX:
+			adjType = domain.AdjustmentTax
+		default:
+			return nil, fmt.Errorf("adjustments[%d].type is required", idx)
+		}
+
+		var fixed int64
+		if adj.Fixed != nil {
+			if adj.Fixed.Currency != currency {
+				return nil, fmt.Errorf("adjustments[%d].fixed.currency mismatch", idx)
+			}
+			fixed = adj.Fixed.AmountMinor
+		}
+		percent, err := domain.ParsePercent(adj.Percent)
+		if err != nil {
+			return nil, fmt.Errorf("adjustments[%d].percent: %w", idx, err)
+		}
+
+		result = append(result, domain.PriceAdjustment{
+			Type:          adjType,
+			Code:          adj.Code,
+			FixedMinor:    fixed,
+			PercentScaled: percent,
+		})
+	}
+	return result, nil
+}
+
+func toProtoAmounts(order domain.Order) *omsv1.OrderAmounts {
+	pricing := order.EffectivePricing()
+	money := func(amount int64) *omsv1.Money {
+		return &omsv1.Money{Currency: order.Currency, AmountMinor: amount}
+	}
+
+	adjustments := make([]*omsv1.PriceAdjustment, 0, len(pricing.Adjustments))
+	for _, adj := range pricing.Adjustments {
+		protoAdj := &omsv1.PriceAdjustment{
+			Type:          toProtoAdjustmentType(adj.Type),
+			Code:          adj.Code,
+			Applied:       money(adj.AppliedMinor),
+			RoundingDelta: domain.FormatRoundingDelta(adj.RoundingDeltaScaled),
+		}
+		if adj.IsPercent() {
+			protoAdj.Percent = domain.FormatPercent(adj.PercentScaled)
+		} else {
+			protoAdj.Fixed = money(adj.FixedMinor)
+		}
+		adjustments = append(adjustments, protoAdj)
+	}
+
+	return &omsv1.OrderAmounts{
+		Subtotal:      money(pricing.SubtotalMinor),
+		Discount:      money(pricing.DiscountMinor),
+		Tax:           money(pricing.TaxMinor),
+		Total:         money(pricing.TotalMinor),
+		Adjustments:   adjustments,
+		RoundingMode:  toProtoRoundingMode(pricing.RoundingMode),
+		RoundingDelta: domain.FormatRoundingDelta(pricing.RoundingDeltaScaled()),
+	}
+}
+
+func toProtoRoundingMode(mode domain.RoundingMode) omsv1.RoundingMode {
+	switch mode {
+	case domain.RoundingHalfUp:
+		return omsv1.RoundingMode_ROUNDING_MODE_HALF_UP
+	default:
+		return omsv1.RoundingMode_ROUNDING_MODE_UNSPECIFIED
+	}
+}
+
+func toProtoAdjustmentType(adjType domain.AdjustmentType) omsv1.AdjustmentType {
+	switch adjType {
+	case domain.AdjustmentDiscount:
+		return omsv1.AdjustmentType_ADJUSTMENT_TYPE_DISCOUNT
+	case domain.AdjustmentTax:
+		return omsv1.AdjustmentType_ADJUSTMENT_TYPE_TAX
+	default:
+		return omsv1.AdjustmentType_ADJUSTMENT_TYPE_UNSPECIFIED
+	}
+}
@@ -0,0 +1,182 @@
+package grpcsvc
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+
+	"github.com/vladislavdragonenkov/oms/internal/domain"
+)
+
+const (
+	// PrincipalMetadataKey — metadata с идентификатором principal'а (API-ключа партнёра).
+	// Сервис не аутентифицирует клиентов сам: заголовок проставляет gateway после проверки ключа
+	// и обязан перезаписывать значение, пришедшее от клиента.
+	PrincipalMetadataKey = "x-principal-id"
+
+	// DefaultQuotaPrincipal — ключ квоты для principal'ов без собственной записи.
+	DefaultQuotaPrincipal = "*"
+)
+
+// ErrInvalidOrderQuota — квоты заданы некорректно.
+var ErrInvalidOrderQuota = errors.New("grpcsvc: invalid order quota")
+
+type principalContextKey struct{}
+
+// ContextWithPrincipal кладёт principal в контекст; так его передаёт interceptor аутентификации
+// или внутренний вызов без gRPC metadata.
+func ContextWithPrincipal(ctx context.Context, principal string) context.Context {
+	return context.WithValue(ctx, principalContextKey{}, principal)
+}
+
+// PrincipalFromContext возвращает principal из контекста или входящей metadata; пусто — запрос без principal'а.
+func PrincipalFromContext(ctx context.Context) string {
+	if principal, ok := ctx.Value(principalContextKey{}).(string); ok && principal != "" {
+		return principal
+	}
+	if md, ok := metadata.FromIncomingContext(ctx); ok {
+		if values := md.Get(PrincipalMetadataKey); len(values) > 0 {
+			return strings.TrimSpace(values[0])
+		}
+	}
+	return ""
+}
+
+// OrderQuotas — дневные квоты по principal'ам; запись DefaultQuotaPrincipal применяется к остальным.
+type OrderQuotas map[string]domain.OrderQuota
+
+// For возвращает квоту principal'а; false — квота не задана и заказы не ограничиваются.
+// Запрос без principal'а получает квоту DefaultQuotaPrincipal: без заголовка квоту не обойти.
+func (q OrderQuotas) For(principal string) (domain.OrderQuota, bool) {
+	if quota, ok := q[principal]; ok {
+		return quota, true
+	}
+	quota, ok := q[DefaultQuotaPrincipal]
+	return quota, ok
+}
+
+// ParseOrderQuotas разбирает квоты в формате
+// "partner-a:orders=1000,amount=RUB:5000000|USD:100000;*:orders=100".
+// amount задаётся в minor units отдельно для каждой валюты.
+func ParseOrderQuotas(raw string) (OrderQuotas, error) {
+	quotas := make(OrderQuotas)
+	for _, entry := range strings.Split(raw, ";") {
+		entry = strings.TrimSpace(entry)
+		if entry == "" {
+			continue
+		}
+		principal, params, found := strings.Cut(entry, ":")
+		principal = strings.TrimSpace(principal)
+		if !found || principal == "" {
+			return nil, fmt.Errorf("%w: expected principal:key=value, got %q", ErrInvalidOrderQuota, entry)
+		}
+		if _, ok := quotas[principal]; ok {
+			return nil, fmt.Errorf("%w: duplicate quota for principal %q", ErrInvalidOrderQuota, principal)
+		}
+
+		var quota domain.OrderQuota
+		for _, part := range strings.Split(params, ",") {
+			part = strings.TrimSpace(part)
+			if part == "" {
+				continue
+			}
+			key, value, found := strings.Cut(part, "=")
+			if !found {
+				return nil, fmt.Errorf("%w: principal %s: expected key=value, got %q", ErrInvalidOrderQuota, principal, part)
+			}
+			if err := setOrderQuota(&quota, strings.ToLower(strings.TrimSpace(key)), strings.TrimSpace(value)); err != nil {
+				return nil, fmt.Errorf("%w: principal %s: %v", ErrInvalidOrderQuota, principal, err)
+			}
+		}
+		if quota.MaxOrders == 0 && len(quota.MaxAmountMinor) == 0 {
+			return nil, fmt.Errorf("%w: principal %s: no limits set", ErrInvalidOrderQuota, principal)
+		}
+		quotas[principal] = quota
+	}
+	return quotas, nil
+}
+
+func setOrderQuota(quota *domain.OrderQuota, key, value string) error {
+	switch key {
+	case "orders":
+		n, err := strconv.ParseInt(value, 10, 64)
+		if err != nil || n <= 0 {
+			return fmt.Errorf("orders must be a positive integer, got %q", value)
+		}
+		quota.MaxOrders = n
+	case "amount":
+		quota.MaxAmountMinor = make(map[string]int64)
+		for _, limit := range strings.Split(value, "|") {
+			currency, rawAmount, found := strings.Cut(strings.TrimSpace(limit), ":")
+			currency = strings.ToUpper(strings.TrimSpace(currency))
+			if !found || currency == "" {
+				return fmt.Errorf("amount: expected CURRENCY:minor, got %q", limit)
+			}
+			amount, err := strconv.ParseInt(strings.TrimSpace(rawAmount), 10, 64)
+			if err != nil || amount <= 0 {
+				return fmt.Errorf("amount %s must be a positive integer, got %q", currency, rawAmount)
+			}
+			if _, ok := quota.MaxAmountMinor[currency]; ok {
+				return fmt.Errorf("amount: duplicate currency %s", currency)
+			}
+			quota.MaxAmountMinor[currency] = amount
+		}
+	default:
+		return fmt.Errorf("unknown key %q", key)
+	}
+	return nil
+}
+
+// WithOrderQuotas включает дневные квоты CreateOrder по principal'у из PrincipalFromContext.
+func WithOrderQuotas(repo domain.QuotaRepository, quotas OrderQuotas) OrderServiceOption {
+	return func(s *OrderService) {
+		if repo != nil && len(quotas) > 0 {
+			s.quotaRepo = repo
+			s.quotas = quotas
+		}
+	}
+}
+
+// consumeQuota списывает заказ с квоты principal'а. Возвращаемая release откатывает списание,
+// если заказ не удалось сохранить; без квоты это no-op.
+func (s *OrderService) consumeQuota(ctx context.Context, order domain.Order) (func(), error) {
+	noop := func() {}
+	if s.quotaRepo == nil {
+		return noop, nil
+	}
+	principal := PrincipalFromContext(ctx)
+	quota, ok := s.quotas.For(principal)
+	if !ok {
+		return noop, nil
+	}
+	if principal == "" {
+		// Запросы без principal'а расходуют одну общую квоту; её видно в GetQuotaUsage("*").
+		principal = DefaultQuotaPrincipal
+	}
+
+	_, err := s.quotaRepo.Consume(principal, order.CreatedAt, order.Currency, order.AmountMinor, quota)
+	if err != nil {
+		var exceeded *domain.QuotaExceededError
+		if errors.As(err, &exceeded) {
+			resetsAt := domain.QuotaDay(order.CreatedAt).Add(24 * time.Hour)
+			msg := fmt.Sprintf("%s; resets at %s", exceeded.Error(), resetsAt.Format(time.RFC3339))
+			// Повтор раньше сброса квоты бессмыслен, поэтому пауза — ровно до него.
+			return nil, RetryInfoError(codes.ResourceExhausted, msg, max(time.Until(resetsAt), time.Second))
+		}
+		s.logger.WithError(err).WithField("principal", principal).Error("failed to consume order quota")
+		return nil, status.Error(codes.Unavailable, "failed to check order quota")
+	}
+
+	return func() {
+		if err := s.quotaRepo.Release(principal, order.CreatedAt, order.Currency, order.AmountMinor); err != nil {
+			s.logger.WithError(err).WithField("principal", principal).Warn("failed to release order quota")
+		}
+	}, nil
+}
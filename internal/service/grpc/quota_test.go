@@ -0,0 +1,188 @@
+package grpcsvc
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+
+	"github.com/vladislavdragonenkov/oms/internal/domain"
+	"github.com/vladislavdragonenkov/oms/internal/service/saga"
+	"github.com/vladislavdragonenkov/oms/internal/storage/memory"
+	omsv1 "github.com/vladislavdragonenkov/oms/proto/oms/v1"
+)
+
+func TestParseOrderQuotas(t *testing.T) {
+	quotas, err := ParseOrderQuotas("partner-a:orders=2,amount=rub:1000|USD:50; *:orders=10")
+	if err != nil {
+		t.Fatalf("parse: %v", err)
+	}
+	partner, ok := quotas.For("partner-a")
+	if !ok || partner.MaxOrders != 2 || partner.MaxAmountMinor["RUB"] != 1000 || partner.MaxAmountMinor["USD"] != 50 {
+		t.Fatalf("unexpected partner quota: %+v", partner)
+	}
+	if fallback, ok := quotas.For("partner-b"); !ok || fallback.MaxOrders != 10 {
+		t.Fatalf("expected default quota for unknown principal, got %+v", fallback)
+	}
+	if anonymous, ok := quotas.For(""); !ok || anonymous.MaxOrders != 10 {
+		t.Fatalf("expected default quota for request without principal, got %+v", anonymous)
+	}
+
+	for _, raw := range []string{
+		"no-colon",
+		"p:orders=0",
+		"p:orders=x",
+		"p:amount=1000",
+		"p:amount=RUB:-1",
+		"p:amount=RUB:1|RUB:2",
+		"p:limit=1",
+		"p:",
+		"p:orders=1;p:orders=2",
+	} {
+		if _, err := ParseOrderQuotas(raw); !errors.Is(err, ErrInvalidOrderQuota) {
+			t.Fatalf("%q: expected ErrInvalidOrderQuota, got %v", raw, err)
+		}
+	}
+}
+
+func TestPrincipalFromContext(t *testing.T) {
+	if got := PrincipalFromContext(context.Background()); got != "" {
+		t.Fatalf("expected empty principal, got %q", got)
+	}
+	incoming := metadata.NewIncomingContext(context.Background(), metadata.Pairs(PrincipalMetadataKey, " partner-a "))
+	if got := PrincipalFromContext(incoming); got != "partner-a" {
+		t.Fatalf("expected principal from metadata, got %q", got)
+	}
+	if got := PrincipalFromContext(ContextWithPrincipal(incoming, "partner-b")); got != "partner-b" {
+		t.Fatalf("context principal must win over metadata, got %q", got)
+	}
+}
+
+func TestOrderService_CreateOrder_EnforcesQuota(t *testing.T) {
+	quotas, err := ParseOrderQuotas("partner-a:orders=2,amount=USD:300")
+	if err != nil {
+		t.Fatal(err)
+	}
+	quotaRepo := memory.NewQuotaRepository()
+	repo := &stubOrderRepository{}
+	service := NewOrderService(repo, nil, nil, saga.NewNoop(nil), nil, WithOrderQuotas(quotaRepo, quotas))
+	defer service.Shutdown(context.Background())
+
+	ctx := ContextWithPrincipal(context.Background(), "partner-a")
+	newRequest := func(price int64) *omsv1.CreateOrderRequest {
+		return &omsv1.CreateOrderRequest{
+			CustomerId: "c-1",
+			Currency:   "USD",
+			Items:      []*omsv1.OrderItem{{Sku: "sku", Qty: 1, Price: &omsv1.Money{Currency: "USD", AmountMinor: price}}},
+		}
+	}
+
+	if _, err := service.CreateOrder(ctx, newRequest(200)); err != nil {
+		t.Fatalf("first order: %v", err)
+	}
+	_, err = service.CreateOrder(ctx, newRequest(200))
+	if status.Code(err) != codes.ResourceExhausted || !strings.Contains(status.Convert(err).Message(), "used 200 of 300 USD") {
+		t.Fatalf("expected amount quota error, got %v", err)
+	}
+	if delay, ok := retryDelayFromStatus(status.Convert(err)); !ok || delay <= 0 || delay > 24*time.Hour {
+		t.Fatalf("expected RetryInfo until quota reset, got %v (%v)", delay, ok)
+	}
+
+	// Заказ, который не удалось сохранить, не расходует квоту.
+	repo.createFn = func(domain.Order) error { return errors.New("db down") }
+	if _, err := service.CreateOrder(ctx, newRequest(50)); status.Code(err) != codes.Internal {
+		t.Fatalf("expected persist error, got %v", err)
+	}
+	repo.createFn = nil
+
+	if _, err := service.CreateOrder(ctx, newRequest(100)); err != nil {
+		t.Fatalf("second order: %v", err)
+	}
+	if _, err := service.CreateOrder(ctx, newRequest(0)); status.Code(err) != codes.ResourceExhausted {
+		t.Fatalf("expected orders quota error, got %v", err)
+	}
+	if _, err := service.CreateOrder(context.Background(), newRequest(1000)); err != nil {
+		t.Fatalf("request without principal must not be limited without default quota: %v", err)
+	}
+
+	admin := NewAdminService(nil, nil, nil, nil, WithAdminQuotas(quotaRepo, quotas))
+	usage, err := admin.GetQuotaUsage(context.Background(), &omsv1.GetQuotaUsageRequest{Principal: "partner-a"})
+	if err != nil {
+		t.Fatalf("GetQuotaUsage: %v", err)
+	}
+	if !usage.GetQuotaConfigured() || usage.GetOrdersUsed() != 2 || usage.GetOrdersLimit() != 2 {
+		t.Fatalf("unexpected usage: %+v", usage)
+	}
+	if len(usage.GetAmounts()) != 1 || usage.GetAmounts()[0].GetUsedMinor() != 300 || usage.GetAmounts()[0].GetLimitMinor() != 300 {
+		t.Fatalf("unexpected amount usage: %+v", usage.GetAmounts())
+	}
+}
+
+func TestOrderService_CreateOrder_DefaultQuotaWithoutPrincipal(t *testing.T) {
+	quotas, err := ParseOrderQuotas("partner-a:orders=5;*:orders=1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	quotaRepo := memory.NewQuotaRepository()
+	service := NewOrderService(&stubOrderRepository{}, nil, nil, saga.NewNoop(nil), nil, WithOrderQuotas(quotaRepo, quotas))
+	defer service.Shutdown(context.Background())
+	req := &omsv1.CreateOrderRequest{
+		CustomerId: "c-1",
+		Currency:   "USD",
+		Items:      []*omsv1.OrderItem{{Sku: "sku", Qty: 1, Price: &omsv1.Money{Currency: "USD", AmountMinor: 100}}},
+	}
+
+	// Запрос без x-principal-id получает квоту "*", а не обходит квоты.
+	noPrincipal := metadata.NewIncomingContext(context.Background(), metadata.Pairs("x-request-id", "r-1"))
+	if _, err := service.CreateOrder(noPrincipal, req); err != nil {
+		t.Fatalf("first order without principal: %v", err)
+	}
+	if _, err := service.CreateOrder(noPrincipal, req); status.Code(err) != codes.ResourceExhausted {
+		t.Fatalf("expected default quota for missing principal header, got %v", err)
+	}
+	if _, err := service.CreateOrder(ContextWithPrincipal(context.Background(), "partner-a"), req); err != nil {
+		t.Fatalf("own quota of partner-a must not be affected: %v", err)
+	}
+
+	usage, err := quotaRepo.Usage(DefaultQuotaPrincipal, domain.QuotaDay(time.Now()))
+	if err != nil {
+		t.Fatalf("usage: %v", err)
+	}
+	if usage.Orders != 1 {
+		t.Fatalf("expected anonymous usage under %q, got %+v", DefaultQuotaPrincipal, usage)
+	}
+}
+
+func TestAdminService_GetQuotaUsage_Errors(t *testing.T) {
+	configured := NewAdminService(nil, nil, nil, nil, WithAdminQuotas(memory.NewQuotaRepository(), OrderQuotas{}))
+	tests := []struct {
+		name    string
+		service *AdminService
+		req     *omsv1.GetQuotaUsageRequest
+		code    codes.Code
+	}{
+		{name: "nil request", service: configured, code: codes.InvalidArgument},
+		{name: "empty principal", service: configured, req: &omsv1.GetQuotaUsageRequest{}, code: codes.InvalidArgument},
+		{name: "bad day", service: configured, req: &omsv1.GetQuotaUsageRequest{Principal: "p", Day: "16.10.2026"}, code: codes.InvalidArgument},
+		{name: "not configured", service: NewAdminService(nil, nil, nil, nil), req: &omsv1.GetQuotaUsageRequest{Principal: "p"}, code: codes.Unimplemented},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := tt.service.GetQuotaUsage(context.Background(), tt.req)
+			mustStatusCode(t, err, tt.code)
+		})
+	}
+
+	resp, err := configured.GetQuotaUsage(context.Background(), &omsv1.GetQuotaUsageRequest{Principal: "p", Day: "2026-10-16"})
+	if err != nil {
+		t.Fatalf("GetQuotaUsage: %v", err)
+	}
+	if resp.GetQuotaConfigured() || resp.GetDay() != "2026-10-16" || resp.GetOrdersUsed() != 0 {
+		t.Fatalf("unexpected response for principal without quota: %+v", resp)
+	}
+}
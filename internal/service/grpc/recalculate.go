@@ -0,0 +1,144 @@
+package grpcsvc
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/vladislavdragonenkov/oms/internal/domain"
+	omsv1 "github.com/vladislavdragonenkov/oms/proto/oms/v1"
+)
+
+// timelineEventOrderRecalculated — цены позиций заказа пересчитаны по каталогу.
+const timelineEventOrderRecalculated = "OrderRecalculated"
+
+// WithOrderRecalculation включает RecalculateOrder. orders должен реализовывать
+// domain.OrderPriceUpdater, иначе RPC возвращает Unimplemented.
+func WithOrderRecalculation(orders domain.OrderRepository, catalog domain.CatalogService) AdminServiceOption {
+	return func(s *AdminService) {
+		s.orders = orders
+		s.catalog = catalog
+	}
+}
+
+// RecalculateOrder обновляет цены позиций pending-заказа по каталогу и пересчитывает сумму с прежними
+// скидками и налогами. После резерва сумма уже ушла в платёж, поэтому остальные статусы отклоняются.
+func (s *AdminService) RecalculateOrder(_ context.Context, req *omsv1.RecalculateOrderRequest) (*omsv1.RecalculateOrderResponse, error) {
+	if req == nil {
+		return nil, status.Error(codes.InvalidArgument, "request is required")
+	}
+	orderID, err := parseOrderIDArg(strings.TrimSpace(req.OrderId))
+	if err != nil {
+		return nil, err
+	}
+	updater, ok := s.orders.(domain.OrderPriceUpdater)
+	if !ok || s.catalog == nil {
+		return nil, status.Error(codes.Unimplemented, "order recalculation is not configured")
+	}
+
+	order, err := s.orders.Get(orderID.String())
+	if err != nil {
+		if errors.Is(err, domain.ErrOrderNotFound) {
+			return nil, status.Error(codes.NotFound, "order not found")
+		}
+		s.logger.WithError(err).WithField("order_id", orderID).Error("failed to load order for recalculation")
+		return nil, status.Error(codes.Internal, "failed to load order")
+	}
+	if order.Status != domain.OrderStatusPending {
+		return nil, status.Errorf(codes.FailedPrecondition, "order in status %s cannot be recalculated", order.Status)
+	}
+
+	prices, err := s.catalog.Prices(order.Currency, orderSKUs(order))
+	if err != nil {
+		s.logger.WithError(err).WithField("order_id", orderID).Error("failed to fetch catalog prices")
+		return nil, status.Error(codes.Unavailable, "failed to fetch catalog prices")
+	}
+
+	previousTotal := order.AmountMinor
+	changes, err := order.Reprice(prices)
+	switch {
+	case errors.Is(err, domain.ErrCatalogPriceNotFound), errors.Is(err, domain.ErrDiscountExceedsSubtotal):
+		return nil, status.Error(codes.FailedPrecondition, err.Error())
+	case err != nil:
+		s.logger.WithError(err).WithField("order_id", orderID).Error("failed to reprice order")
+		return nil, status.Error(codes.Internal, "failed to reprice order")
+	}
+
+	if len(changes) > 0 {
+		order.UpdatedAt = time.Now().UTC()
+		if err := updater.UpdatePrices(order); err != nil {
+			if errors.Is(err, domain.ErrOrderVersionConflict) {
+				return nil, status.Error(codes.Aborted, "order was modified concurrently, retry")
+			}
+			s.logger.WithError(err).WithField("order_id", orderID).Error("failed to save recalculated order")
+			return nil, status.Error(codes.Internal, "failed to save order")
+		}
+		order.Version++
+		s.appendRecalculationTimeline(order, previousTotal, strings.TrimSpace(req.Reason))
+	}
+
+	delta := order.AmountMinor - previousTotal
+	s.logger.WithFields(log.Fields{
+		"audit":          true,
+		"action":         "recalculate_order",
+		"order_id":       orderID,
+		"changed_items":  len(changes),
+		"previous_total": previousTotal,
+		"total":          order.AmountMinor,
+		"reason":         strings.TrimSpace(req.Reason),
+	}).Info("order recalculated")
+
+	resp := &omsv1.RecalculateOrderResponse{
+		Order:         toProtoOrder(order),
+		PreviousTotal: &omsv1.Money{Currency: order.Currency, AmountMinor: previousTotal},
+		DeltaMinor:    delta,
+		Changes:       make([]*omsv1.ItemPriceChange, 0, len(changes)),
+	}
+	for _, change := range changes {
+		resp.Changes = append(resp.Changes, &omsv1.ItemPriceChange{
+			ItemId:   change.ItemID,
+			Sku:      change.SKU,
+			OldPrice: &omsv1.Money{Currency: order.Currency, AmountMinor: change.OldPriceMinor},
+			NewPrice: &omsv1.Money{Currency: order.Currency, AmountMinor: change.NewPriceMinor},
+		})
+	}
+	return resp, nil
+}
+
+// appendRecalculationTimeline пишет в timeline прежний и новый итог с разницей: "total 1500 -> 1440 RUB (-60)".
+func (s *AdminService) appendRecalculationTimeline(order domain.Order, previousTotal int64, reason string) {
+	if s.timeline == nil {
+		return
+	}
+	text := fmt.Sprintf("total %d -> %d %s (%+d)", previousTotal, order.AmountMinor, order.Currency, order.AmountMinor-previousTotal)
+	if reason != "" {
+		text += ": " + reason
+	}
+	if err := s.timeline.Append(domain.TimelineEvent{
+		OrderID:  order.ID,
+		Type:     timelineEventOrderRecalculated,
+		Reason:   text,
+		Occurred: order.UpdatedAt,
+	}); err != nil {
+		s.logger.WithError(err).WithField("order_id", order.ID).Warn("failed to append recalculation timeline event")
+	}
+}
+
+func orderSKUs(order domain.Order) []string {
+	skus := make([]string, 0, len(order.Items))
+	seen := make(map[string]struct{}, len(order.Items))
+	for _, item := range order.Items {
+		if _, ok := seen[item.SKU]; ok {
+			continue
+		}
+		seen[item.SKU] = struct{}{}
+		skus = append(skus, item.SKU)
+	}
+	return skus
+}
@@ -0,0 +1,94 @@
+package grpcsvc
+
+import (
+	"context"
+	"testing"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/vladislavdragonenkov/oms/internal/domain"
+	"github.com/vladislavdragonenkov/oms/internal/service/catalog"
+	"github.com/vladislavdragonenkov/oms/internal/storage/memory"
+	omsv1 "github.com/vladislavdragonenkov/oms/proto/oms/v1"
+)
+
+func TestAdminService_RecalculateOrder(t *testing.T) {
+	orders := memory.NewOrderRepository()
+	seedTestOrder(t, orders, domain.OrderStatusPending)
+	prices, err := catalog.ParseStaticCatalog("SKU-1:USD=450,SKU-2:USD=300")
+	if err != nil {
+		t.Fatalf("ParseStaticCatalog failed: %v", err)
+	}
+	timeline := memory.NewTimelineRepository()
+	service := NewAdminService(nil, timeline, memory.NewOutboxRepository(), nil, WithOrderRecalculation(orders, prices))
+
+	resp, err := service.RecalculateOrder(context.Background(), &omsv1.RecalculateOrderRequest{OrderId: "order-1", Reason: "PRICE-7"})
+	if err != nil {
+		t.Fatalf("RecalculateOrder failed: %v", err)
+	}
+	if resp.DeltaMinor != -100 || resp.PreviousTotal.AmountMinor != 1300 || resp.Order.Amount.AmountMinor != 1200 {
+		t.Fatalf("unexpected totals: %v", resp)
+	}
+	if len(resp.Changes) != 1 || resp.Changes[0].ItemId != "i-1" || resp.Changes[0].NewPrice.AmountMinor != 450 {
+		t.Fatalf("unexpected changes: %v", resp.Changes)
+	}
+
+	stored, err := orders.Get("order-1")
+	if err != nil {
+		t.Fatalf("Get failed: %v", err)
+	}
+	if stored.AmountMinor != 1200 || stored.Version != resp.Order.Version {
+		t.Fatalf("unexpected stored order: %+v", stored)
+	}
+	events, err := timeline.List("order-1")
+	if err != nil {
+		t.Fatalf("List failed: %v", err)
+	}
+	if len(events) != 1 || events[0].Type != timelineEventOrderRecalculated || events[0].Reason != "total 1300 -> 1200 USD (-100): PRICE-7" {
+		t.Fatalf("unexpected timeline: %+v", events)
+	}
+
+	// Повторный пересчёт ничего не меняет: цены уже совпадают с каталогом.
+	again, err := service.RecalculateOrder(context.Background(), &omsv1.RecalculateOrderRequest{OrderId: "order-1"})
+	if err != nil {
+		t.Fatalf("second RecalculateOrder failed: %v", err)
+	}
+	if len(again.Changes) != 0 || again.DeltaMinor != 0 || again.Order.Version != stored.Version {
+		t.Fatalf("expected no-op recalculation, got %v", again)
+	}
+}
+
+func TestAdminService_RecalculateOrder_Errors(t *testing.T) {
+	ctx := context.Background()
+
+	disabled := NewAdminService(nil, memory.NewTimelineRepository(), memory.NewOutboxRepository(), nil)
+	if _, err := disabled.RecalculateOrder(ctx, &omsv1.RecalculateOrderRequest{OrderId: "o-1"}); status.Code(err) != codes.Unimplemented {
+		t.Fatalf("expected Unimplemented, got %v", err)
+	}
+
+	paid := memory.NewOrderRepository()
+	seedTestOrder(t, paid, domain.OrderStatusPaid)
+	prices, _ := catalog.ParseStaticCatalog("SKU-1:USD=450,SKU-2:USD=300")
+	service := NewAdminService(nil, memory.NewTimelineRepository(), memory.NewOutboxRepository(), nil, WithOrderRecalculation(paid, prices))
+	if _, err := service.RecalculateOrder(ctx, &omsv1.RecalculateOrderRequest{}); status.Code(err) != codes.InvalidArgument {
+		t.Fatalf("expected InvalidArgument, got %v", err)
+	}
+	if _, err := service.RecalculateOrder(ctx, &omsv1.RecalculateOrderRequest{OrderId: "missing"}); status.Code(err) != codes.NotFound {
+		t.Fatalf("expected NotFound, got %v", err)
+	}
+	if _, err := service.RecalculateOrder(ctx, &omsv1.RecalculateOrderRequest{OrderId: "order-1"}); status.Code(err) != codes.FailedPrecondition {
+		t.Fatalf("expected FailedPrecondition for paid order, got %v", err)
+	}
+
+	orders := memory.NewOrderRepository()
+	if err := orders.Create(domain.Order{ID: "o-2", CustomerID: "bob", Status: domain.OrderStatusPending, Currency: "USD", AmountMinor: 100,
+		Items: []domain.OrderItem{{ID: "i-1", SKU: "SKU-1", Qty: 1, PriceMinor: 100}}}); err != nil {
+		t.Fatalf("Create failed: %v", err)
+	}
+	prices, _ = catalog.ParseStaticCatalog("SKU-1:RUB=450")
+	noPrice := NewAdminService(nil, nil, nil, nil, WithOrderRecalculation(orders, prices))
+	if _, err := noPrice.RecalculateOrder(ctx, &omsv1.RecalculateOrderRequest{OrderId: "o-2"}); status.Code(err) != codes.FailedPrecondition {
+		t.Fatalf("expected FailedPrecondition for missing price, got %v", err)
+	}
+}
@@ -0,0 +1,132 @@
+package grpcsvc
+
+import (
+	"context"
+	"math/rand/v2"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/status"
+
+	omsv1 "github.com/vladislavdragonenkov/oms/proto/oms/v1"
+)
+
+const (
+	// DefaultRequestLogSampleRate — по умолчанию в debug-лог попадает каждый RPC.
+	DefaultRequestLogSampleRate = 1.0
+	// DefaultSlowRequestThreshold — целевой p99 unary RPC; запросы дольше логируются всегда.
+	DefaultSlowRequestThreshold = 500 * time.Millisecond
+)
+
+// RequestLogging — настройки interceptor'а, который пишет по строке лога на каждый RPC.
+type RequestLogging struct {
+	// SampleRate — доля RPC (0..1), попадающих в debug-лог.
+	SampleRate float64
+	// SlowThreshold — unary RPC не быстрее порога логируются на warn без сэмплирования; 0 — выключено.
+	SlowThreshold time.Duration
+}
+
+// DefaultRequestLogging возвращает настройки по умолчанию.
+func DefaultRequestLogging() RequestLogging {
+	return RequestLogging{
+		SampleRate:    DefaultRequestLogSampleRate,
+		SlowThreshold: DefaultSlowRequestThreshold,
+	}
+}
+
+type requestLogger struct {
+	logger *log.Entry
+	cfg    RequestLogging
+	sample func() float64
+}
+
+func newRequestLogger(logger *log.Entry, cfg RequestLogging) *requestLogger {
+	return &requestLogger{logger: logger, cfg: cfg, sample: rand.Float64}
+}
+
+// UnaryRequestLoggingInterceptor логирует метод, длительность, код ответа и order_id каждого unary RPC.
+func UnaryRequestLoggingInterceptor(logger *log.Entry, cfg RequestLogging) grpc.UnaryServerInterceptor {
+	return newRequestLogger(logger, cfg).unary
+}
+
+// StreamRequestLoggingInterceptor — то же для stream RPC. Порог медленных запросов к стримам
+// не применяется: подписка на timeline живёт столько, сколько нужно клиенту.
+func StreamRequestLoggingInterceptor(logger *log.Entry, cfg RequestLogging) grpc.StreamServerInterceptor {
+	return newRequestLogger(logger, cfg).stream
+}
+
+func (l *requestLogger) unary(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+	started := time.Now()
+	resp, err := handler(ctx, req)
+	duration := time.Since(started)
+
+	orderID := requestOrderID(req)
+	if orderID == "" {
+		orderID = requestOrderID(resp)
+	}
+	slow := l.cfg.SlowThreshold > 0 && duration >= l.cfg.SlowThreshold
+	l.log(info.FullMethod, duration, err, orderID, slow)
+	return resp, err
+}
+
+func (l *requestLogger) stream(srv any, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+	started := time.Now()
+	wrapped := &orderIDServerStream{ServerStream: ss}
+	err := handler(srv, wrapped)
+	l.log(info.FullMethod, time.Since(started), err, wrapped.orderID, false)
+	return err
+}
+
+func (l *requestLogger) log(method string, duration time.Duration, err error, orderID string, slow bool) {
+	if !slow && !l.sampled() {
+		return
+	}
+	fields := log.Fields{
+		"method":      method,
+		"duration_ms": float64(duration.Microseconds()) / 1000,
+		"code":        status.Code(err).String(),
+	}
+	if orderID != "" {
+		fields["order_id"] = orderID
+	}
+	entry := l.logger.WithFields(fields)
+	if slow {
+		entry.Warn("slow grpc request")
+		return
+	}
+	entry.Debug("grpc request")
+}
+
+func (l *requestLogger) sampled() bool {
+	if !l.logger.Logger.IsLevelEnabled(log.DebugLevel) || l.cfg.SampleRate <= 0 {
+		return false
+	}
+	return l.cfg.SampleRate >= 1 || l.sample() < l.cfg.SampleRate
+}
+
+// requestOrderID достаёт order_id из запроса или ответа; CreateOrder узнаёт его только из ответа.
+func requestOrderID(msg any) string {
+	switch m := msg.(type) {
+	case interface{ GetOrderId() string }:
+		return m.GetOrderId()
+	case interface{ GetOrder() *omsv1.Order }:
+		return m.GetOrder().GetId()
+	default:
+		return ""
+	}
+}
+
+// orderIDServerStream запоминает order_id из первого сообщения клиента.
+type orderIDServerStream struct {
+	grpc.ServerStream
+	orderID string
+}
+
+func (s *orderIDServerStream) RecvMsg(m any) error {
+	err := s.ServerStream.RecvMsg(m)
+	if err == nil && s.orderID == "" {
+		s.orderID = requestOrderID(m)
+	}
+	return err
+}
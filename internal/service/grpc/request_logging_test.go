@@ -0,0 +1,88 @@
+package grpcsvc
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+	logtest "github.com/sirupsen/logrus/hooks/test"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	omsv1 "github.com/vladislavdragonenkov/oms/proto/oms/v1"
+)
+
+func newTestRequestLogger(level log.Level, cfg RequestLogging) (*requestLogger, *logtest.Hook) {
+	logger, hook := logtest.NewNullLogger()
+	logger.SetLevel(level)
+	return newRequestLogger(logger.WithField("test", "request-logging"), cfg), hook
+}
+
+func TestRequestLogging_LogsMethodCodeAndOrderID(t *testing.T) {
+	l, hook := newTestRequestLogger(log.DebugLevel, RequestLogging{SampleRate: 1})
+	info := &grpc.UnaryServerInfo{FullMethod: omsv1.OrderService_GetOrder_FullMethodName}
+
+	_, err := l.unary(context.Background(), &omsv1.GetOrderRequest{OrderId: "order-1"}, info, func(context.Context, any) (any, error) {
+		return nil, status.Error(codes.NotFound, "order not found")
+	})
+	if status.Code(err) != codes.NotFound {
+		t.Fatalf("expected handler error to pass through, got %v", err)
+	}
+
+	entry := hook.LastEntry()
+	if entry == nil || entry.Level != log.DebugLevel {
+		t.Fatalf("expected debug entry, got %+v", entry)
+	}
+	if entry.Data["method"] != omsv1.OrderService_GetOrder_FullMethodName || entry.Data["code"] != "NotFound" || entry.Data["order_id"] != "order-1" {
+		t.Fatalf("unexpected fields: %v", entry.Data)
+	}
+}
+
+func TestRequestLogging_OrderIDFromCreateResponse(t *testing.T) {
+	l, hook := newTestRequestLogger(log.DebugLevel, RequestLogging{SampleRate: 1})
+	info := &grpc.UnaryServerInfo{FullMethod: omsv1.OrderService_CreateOrder_FullMethodName}
+
+	_, _ = l.unary(context.Background(), &omsv1.CreateOrderRequest{}, info, func(context.Context, any) (any, error) {
+		return &omsv1.CreateOrderResponse{Order: &omsv1.Order{Id: "order-new"}}, nil
+	})
+
+	if entry := hook.LastEntry(); entry == nil || entry.Data["order_id"] != "order-new" || entry.Data["code"] != "OK" {
+		t.Fatalf("unexpected entry: %+v", entry)
+	}
+}
+
+func TestRequestLogging_SamplingAndSlowRequests(t *testing.T) {
+	l, hook := newTestRequestLogger(log.DebugLevel, RequestLogging{SampleRate: 0.5, SlowThreshold: 20 * time.Millisecond})
+	l.sample = func() float64 { return 0.9 }
+	info := &grpc.UnaryServerInfo{FullMethod: omsv1.OrderService_ListOrders_FullMethodName}
+
+	_, _ = l.unary(context.Background(), &omsv1.ListOrdersRequest{}, info, func(context.Context, any) (any, error) {
+		return &omsv1.ListOrdersResponse{}, nil
+	})
+	if len(hook.AllEntries()) != 0 {
+		t.Fatalf("expected request to be sampled out, got %d entries", len(hook.AllEntries()))
+	}
+
+	_, _ = l.unary(context.Background(), &omsv1.ListOrdersRequest{}, info, func(context.Context, any) (any, error) {
+		time.Sleep(25 * time.Millisecond)
+		return &omsv1.ListOrdersResponse{}, nil
+	})
+	entry := hook.LastEntry()
+	if entry == nil || entry.Level != log.WarnLevel || entry.Message != "slow grpc request" {
+		t.Fatalf("expected slow request warning, got %+v", entry)
+	}
+}
+
+func TestRequestLogging_SkipsDebugWhenLevelDisabled(t *testing.T) {
+	l, hook := newTestRequestLogger(log.InfoLevel, DefaultRequestLogging())
+	info := &grpc.UnaryServerInfo{FullMethod: omsv1.OrderService_GetOrder_FullMethodName}
+
+	_, _ = l.unary(context.Background(), &omsv1.GetOrderRequest{OrderId: "order-1"}, info, func(context.Context, any) (any, error) {
+		return &omsv1.GetOrderResponse{}, nil
+	})
+	if len(hook.AllEntries()) != 0 {
+		t.Fatalf("expected no entries at info level, got %d", len(hook.AllEntries()))
+	}
+}
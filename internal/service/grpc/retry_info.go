@@ -0,0 +1,202 @@
+package grpcsvc
+
+import (
+	"context"
+	"math/rand/v2"
+	"strconv"
+	"sync"
+	"time"
+
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/durationpb"
+)
+
+// RetryAfterHeader — header ответа с рекомендуемой паузой в секундах (как HTTP Retry-After);
+// дублирует google.rpc.RetryInfo для клиентов и прокси, которые не читают details.
+const RetryAfterHeader = "retry-after"
+
+const (
+	defaultConflictRetryDelay    = 100 * time.Millisecond
+	defaultUnavailableRetryDelay = time.Second
+	defaultMaxRetryDelay         = 10 * time.Second
+	conflictWindow               = 30 * time.Second
+	maxTrackedConflictKeys       = 10000
+	retryJitterFraction          = 0.2
+)
+
+// RetryAdvisor вычисляет паузу перед повтором для ответов Aborted/ResourceExhausted/Unavailable.
+// Для Aborted пауза удваивается с каждым недавним конфликтом по тому же заказу, для
+// Unavailable и ResourceExhausted — растёт с текущей загрузкой сервиса. Jitter ±20% разводит
+// повторы клиентов, получивших ответ одновременно.
+type RetryAdvisor struct {
+	load     func() float64
+	jitter   func(time.Duration) time.Duration
+	now      func() time.Time
+	maxDelay time.Duration
+
+	mu        sync.Mutex
+	conflicts map[string][]time.Time
+}
+
+// RetryAdvisorOption настраивает RetryAdvisor.
+type RetryAdvisorOption func(*RetryAdvisor)
+
+// WithRetryLoad задаёт источник текущей загрузки (1 — на пределе), например saturation.Monitor.Ratio.
+func WithRetryLoad(load func() float64) RetryAdvisorOption {
+	return func(a *RetryAdvisor) {
+		a.load = load
+	}
+}
+
+// WithRetryJitter подменяет jitter; в тестах — identity для детерминированных пауз.
+func WithRetryJitter(jitter func(time.Duration) time.Duration) RetryAdvisorOption {
+	return func(a *RetryAdvisor) {
+		a.jitter = jitter
+	}
+}
+
+// WithMaxRetryDelay ограничивает рекомендуемую паузу сверху.
+func WithMaxRetryDelay(delay time.Duration) RetryAdvisorOption {
+	return func(a *RetryAdvisor) {
+		a.maxDelay = delay
+	}
+}
+
+// NewRetryAdvisor создаёт советчик пауз.
+func NewRetryAdvisor(opts ...RetryAdvisorOption) *RetryAdvisor {
+	a := &RetryAdvisor{
+		jitter:    proportionalJitter,
+		now:       time.Now,
+		maxDelay:  defaultMaxRetryDelay,
+		conflicts: make(map[string][]time.Time),
+	}
+	for _, opt := range opts {
+		if opt != nil {
+			opt(a)
+		}
+	}
+	if a.maxDelay <= 0 {
+		a.maxDelay = defaultMaxRetryDelay
+	}
+	return a
+}
+
+// Delay возвращает паузу для ответа с кодом code; key — ресурс, за который идёт конкуренция
+// (обычно order_id). Для остальных кодов — 0.
+func (a *RetryAdvisor) Delay(code codes.Code, key string) time.Duration {
+	var delay time.Duration
+	switch code {
+	case codes.Aborted:
+		delay = defaultConflictRetryDelay << (a.recordConflict(key) - 1)
+	case codes.Unavailable, codes.ResourceExhausted:
+		delay = time.Duration(float64(defaultUnavailableRetryDelay) * (1 + a.currentLoad()))
+	default:
+		return 0
+	}
+	return min(a.jitter(min(delay, a.maxDelay)), a.maxDelay)
+}
+
+// recordConflict учитывает конфликт по key и возвращает число конфликтов за последние
+// conflictWindow (включая текущий), не больше 6 — дальше пауза упирается в maxDelay.
+func (a *RetryAdvisor) recordConflict(key string) int {
+	now := a.now()
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	if _, ok := a.conflicts[key]; !ok && len(a.conflicts) >= maxTrackedConflictKeys {
+		a.conflicts = make(map[string][]time.Time)
+	}
+	recent := a.conflicts[key][:0]
+	for _, at := range a.conflicts[key] {
+		if now.Sub(at) < conflictWindow {
+			recent = append(recent, at)
+		}
+	}
+	recent = append(recent, now)
+	a.conflicts[key] = recent
+	return min(len(recent), 6)
+}
+
+func (a *RetryAdvisor) currentLoad() float64 {
+	if a.load == nil {
+		return 0
+	}
+	return max(a.load(), 0)
+}
+
+func proportionalJitter(delay time.Duration) time.Duration {
+	spread := float64(delay) * retryJitterFraction
+	return delay + time.Duration((rand.Float64()*2-1)*spread)
+}
+
+// UnaryRetryInfoInterceptor дополняет ответы Aborted/ResourceExhausted/Unavailable деталью
+// google.rpc.RetryInfo и header retry-after. Если обработчик уже приложил RetryInfo
+// (например, квота с известным временем сброса), используется его значение.
+func UnaryRetryInfoInterceptor(advisor *RetryAdvisor) grpc.UnaryServerInterceptor {
+	if advisor == nil {
+		advisor = NewRetryAdvisor()
+	}
+	return func(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+		resp, err := handler(ctx, req)
+		if err == nil {
+			return resp, nil
+		}
+		st, ok := status.FromError(err)
+		if !ok {
+			return resp, err
+		}
+
+		delay, attached := retryDelayFromStatus(st)
+		if !attached {
+			delay = advisor.Delay(st.Code(), retryKey(req, info))
+			if delay <= 0 {
+				return resp, err
+			}
+			st = withRetryInfo(st, delay)
+		}
+		_ = grpc.SetHeader(ctx, metadata.Pairs(RetryAfterHeader, retryAfterSeconds(delay)))
+		return resp, st.Err()
+	}
+}
+
+// RetryInfoError возвращает ошибку с кодом code и явной паузой перед повтором.
+func RetryInfoError(code codes.Code, msg string, delay time.Duration) error {
+	return withRetryInfo(status.New(code, msg), delay).Err()
+}
+
+func withRetryInfo(st *status.Status, delay time.Duration) *status.Status {
+	detailed, err := st.WithDetails(&errdetails.RetryInfo{RetryDelay: durationpb.New(delay)})
+	if err != nil {
+		return st
+	}
+	return detailed
+}
+
+func retryDelayFromStatus(st *status.Status) (time.Duration, bool) {
+	for _, detail := range st.Details() {
+		if info, ok := detail.(*errdetails.RetryInfo); ok && info.GetRetryDelay() != nil {
+			return info.GetRetryDelay().AsDuration(), true
+		}
+	}
+	return 0, false
+}
+
+func retryKey(req any, info *grpc.UnaryServerInfo) string {
+	if withOrder, ok := req.(interface{ GetOrderId() string }); ok && withOrder.GetOrderId() != "" {
+		return "order:" + withOrder.GetOrderId()
+	}
+	if info != nil {
+		return info.FullMethod
+	}
+	return ""
+}
+
+// retryAfterSeconds округляет вверх: Retry-After в целых секундах, и 0 означал бы «сразу».
+func retryAfterSeconds(delay time.Duration) string {
+	seconds := int64((delay + time.Second - 1) / time.Second)
+	return strconv.FormatInt(max(seconds, 1), 10)
+}
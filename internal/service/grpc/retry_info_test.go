@@ -0,0 +1,82 @@
+package grpcsvc
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	omsv1 "github.com/vladislavdragonenkov/oms/proto/oms/v1"
+)
+
+func TestRetryAdvisor_Delay(t *testing.T) {
+	now := time.Date(2026, 3, 6, 12, 0, 0, 0, time.UTC)
+	load := 0.0
+	advisor := NewRetryAdvisor(
+		WithRetryLoad(func() float64 { return load }),
+		WithRetryJitter(func(d time.Duration) time.Duration { return d }),
+		WithMaxRetryDelay(time.Second),
+	)
+	advisor.now = func() time.Time { return now }
+
+	for i, want := range []time.Duration{100 * time.Millisecond, 200 * time.Millisecond, 400 * time.Millisecond, 800 * time.Millisecond, time.Second} {
+		if got := advisor.Delay(codes.Aborted, "order:order-1"); got != want {
+			t.Fatalf("conflict %d: expected %s, got %s", i+1, want, got)
+		}
+	}
+	if got := advisor.Delay(codes.Aborted, "order:order-2"); got != 100*time.Millisecond {
+		t.Fatalf("conflicts must be tracked per order, got %s", got)
+	}
+	now = now.Add(conflictWindow)
+	if got := advisor.Delay(codes.Aborted, "order:order-1"); got != 100*time.Millisecond {
+		t.Fatalf("old conflicts must expire, got %s", got)
+	}
+
+	advisor.maxDelay = 10 * time.Second
+	if got := advisor.Delay(codes.Unavailable, ""); got != time.Second {
+		t.Fatalf("expected base delay without load, got %s", got)
+	}
+	load = 1.5
+	if got := advisor.Delay(codes.ResourceExhausted, ""); got != 2500*time.Millisecond {
+		t.Fatalf("expected delay to grow with load, got %s", got)
+	}
+	if got := advisor.Delay(codes.NotFound, ""); got != 0 {
+		t.Fatalf("expected no delay for NotFound, got %s", got)
+	}
+}
+
+func TestUnaryRetryInfoInterceptor(t *testing.T) {
+	interceptor := UnaryRetryInfoInterceptor(NewRetryAdvisor(WithRetryJitter(func(d time.Duration) time.Duration { return d })))
+	info := &grpc.UnaryServerInfo{FullMethod: "/oms.v1.OrderService/PayOrder"}
+	call := func(err error) error {
+		_, got := interceptor(context.Background(), &omsv1.PayOrderRequest{OrderId: "order-1"}, info, func(context.Context, any) (any, error) {
+			return nil, err
+		})
+		return got
+	}
+
+	err := call(status.Error(codes.Aborted, "order version conflict"))
+	if status.Code(err) != codes.Aborted || status.Convert(err).Message() != "order version conflict" {
+		t.Fatalf("code and message must be preserved, got %v", err)
+	}
+	if delay, ok := retryDelayFromStatus(status.Convert(err)); !ok || delay != defaultConflictRetryDelay {
+		t.Fatalf("expected RetryInfo %s, got %s (%v)", defaultConflictRetryDelay, delay, ok)
+	}
+
+	explicit := call(RetryInfoError(codes.ResourceExhausted, "quota exceeded", time.Hour))
+	if delay, _ := retryDelayFromStatus(status.Convert(explicit)); delay != time.Hour {
+		t.Fatalf("handler RetryInfo must win, got %s", delay)
+	}
+
+	notFound := call(status.Error(codes.NotFound, "order not found"))
+	if len(status.Convert(notFound).Details()) != 0 {
+		t.Fatalf("NotFound must not carry RetryInfo: %v", status.Convert(notFound).Details())
+	}
+
+	if got := retryAfterSeconds(100 * time.Millisecond); got != "1" {
+		t.Fatalf("retry-after must round up to a whole second, got %s", got)
+	}
+}
@@ -0,0 +1,431 @@
+package grpcsvc
+
+import (
+	"context"
+	"errors"
+	"strconv"
+	"strings"
+
+	log "github.com/sirupsen/logrus"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/vladislavdragonenkov/oms/internal/domain"
+	"github.com/vladislavdragonenkov/oms/internal/timeutil"
+	omsv1 "github.com/vladislavdragonenkov/oms/proto/oms/v1"
+)
+
+const (
+	grpcMethodCreateReturn  = "/oms.v1.OrderService/CreateReturn"
+	grpcMethodAdvanceReturn = "/oms.v1.OrderService/AdvanceReturn"
+
+	timelineEventReturnRequested = "ReturnRequested"
+	timelineEventReturnApproved  = "ReturnApproved"
+	timelineEventReturnReceived  = "ReturnReceived"
+	timelineEventReturnRefunded  = "ReturnRefunded"
+)
+
+// orderReturns — зависимости возвратов позиций.
+type orderReturns struct {
+	repo      domain.ReturnRepository
+	inventory domain.InventoryService
+	payments  domain.PaymentService
+}
+
+// WithReturns включает CreateReturn, GetReturn и AdvanceReturn. При приёмке товар возвращается
+// в остатки, если inventory реализует domain.InventoryRestocker; деньги за позиции возвращает payments.
+func WithReturns(repo domain.ReturnRepository, inventory domain.InventoryService, payments domain.PaymentService) OrderServiceOption {
+	return func(s *OrderService) {
+		if repo == nil {
+			s.returns = nil
+			return
+		}
+		s.returns = &orderReturns{repo: repo, inventory: inventory, payments: payments}
+	}
+}
+
+// CreateReturn заявляет возврат части позиций оплаченного заказа.
+func (s *OrderService) CreateReturn(ctx context.Context, req *omsv1.CreateReturnRequest) (*omsv1.CreateReturnResponse, error) {
+	if _, err := parseOrderIDArg(req.GetOrderId()); err != nil {
+		return nil, err
+	}
+
+	return withIdempotency(
+		s,
+		ctx,
+		grpcMethodCreateReturn,
+		req,
+		s.orderCustomer(req.OrderId, "CreateReturn"),
+		func() *omsv1.CreateReturnResponse { return &omsv1.CreateReturnResponse{} },
+		func(ctx context.Context) (*omsv1.CreateReturnResponse, error) {
+			return s.createReturnInternal(ctx, req)
+		},
+	)
+}
+
+func (s *OrderService) createReturnInternal(_ context.Context, req *omsv1.CreateReturnRequest) (*omsv1.CreateReturnResponse, error) {
+	if s.returns == nil {
+		return nil, status.Error(codes.Unimplemented, "order returns are not configured")
+	}
+	items := make([]domain.ReturnItem, 0, len(req.Items))
+	for _, item := range req.Items {
+		items = append(items, domain.ReturnItem{ItemID: strings.TrimSpace(item.GetItemId()), Qty: item.GetQty()})
+	}
+
+	order, err := s.loadOrder(req.OrderId, "CreateReturn")
+	if err != nil {
+		return nil, err
+	}
+	if order.Status != domain.OrderStatusPaid && order.Status != domain.OrderStatusConfirmed {
+		return nil, status.Error(codes.FailedPrecondition, "order is not eligible for return")
+	}
+
+	previous, err := s.listOrderReturns(order.ID)
+	if err != nil {
+		return nil, err
+	}
+	ret, err := domain.PlanReturn(order, previous, items)
+	switch {
+	case errors.Is(err, domain.ErrReturnQtyExceeded), errors.Is(err, domain.ErrReturnNotRefundable):
+		return nil, status.Error(codes.FailedPrecondition, err.Error())
+	case err != nil:
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+	ret.Reason = strings.TrimSpace(req.Reason)
+
+	// Repo сохраняет возврат, только если с момента ListByOrder у заказа не появилось других:
+	// иначе параллельные CreateReturn (в том числе с разных реплик) вернули бы позицию дважды.
+	created, err := s.returns.repo.Create(ret, len(previous))
+	if errors.Is(err, domain.ErrReturnsChanged) {
+		return nil, status.Error(codes.Aborted, "order returns changed concurrently, retry")
+	}
+	if err != nil {
+		s.logger.WithError(err).WithField("order_id", order.ID).Error("failed to create order return")
+		return nil, status.Error(codes.Internal, "failed to create order return")
+	}
+	s.appendTimelineEvent(order.ID, timelineEventReturnRequested, returnTimelineReason(created))
+
+	s.logger.WithFields(log.Fields{
+		"order_id":     order.ID,
+		"return_id":    created.ID,
+		"refund_minor": created.RefundMinor,
+	}).Info("order return requested")
+
+	return &omsv1.CreateReturnResponse{OrderReturn: toProtoOrderReturn(created)}, nil
+}
+
+// GetReturn возвращает возврат позиций заказа.
+func (s *OrderService) GetReturn(_ context.Context, req *omsv1.GetReturnRequest) (*omsv1.GetReturnResponse, error) {
+	if _, err := parseOrderIDArg(req.GetOrderId()); err != nil {
+		return nil, err
+	}
+	if req.ReturnId == "" {
+		return nil, status.Error(codes.InvalidArgument, "return_id is required")
+	}
+	if s.returns == nil {
+		return nil, status.Error(codes.Unimplemented, "order returns are not configured")
+	}
+
+	ret, err := s.loadOrderReturn(req.OrderId, req.ReturnId)
+	if err != nil {
+		return nil, err
+	}
+	return &omsv1.GetReturnResponse{OrderReturn: toProtoOrderReturn(ret)}, nil
+}
+
+// AdvanceReturn переводит возврат на следующий этап. Приёмка (RECEIVED) возвращает товар
+// в остатки склада, REFUNDED — деньги за позиции; при ошибке склада или PSP статус не меняется.
+// Перед складом и PSP возврат захватывается промежуточным статусом, поэтому параллельные
+// AdvanceReturn (в том числе с разных реплик) не вернут деньги или товар дважды.
+func (s *OrderService) AdvanceReturn(ctx context.Context, req *omsv1.AdvanceReturnRequest) (*omsv1.AdvanceReturnResponse, error) {
+	if _, err := parseOrderIDArg(req.GetOrderId()); err != nil {
+		return nil, err
+	}
+
+	return withIdempotency(
+		s,
+		ctx,
+		grpcMethodAdvanceReturn,
+		req,
+		s.orderCustomer(req.OrderId, "AdvanceReturn"),
+		func() *omsv1.AdvanceReturnResponse { return &omsv1.AdvanceReturnResponse{} },
+		func(ctx context.Context) (*omsv1.AdvanceReturnResponse, error) {
+			return s.advanceReturnInternal(ctx, req)
+		},
+	)
+}
+
+func (s *OrderService) advanceReturnInternal(_ context.Context, req *omsv1.AdvanceReturnRequest) (*omsv1.AdvanceReturnResponse, error) {
+	if req.ReturnId == "" {
+		return nil, status.Error(codes.InvalidArgument, "return_id is required")
+	}
+	target := fromProtoReturnStatus(req.Status)
+	if target == "" || target == domain.ReturnStatusRequested {
+		return nil, status.Error(codes.InvalidArgument, "status must be APPROVED, RECEIVED or REFUNDED")
+	}
+	if s.returns == nil {
+		return nil, status.Error(codes.Unimplemented, "order returns are not configured")
+	}
+
+	ret, err := s.loadOrderReturn(req.OrderId, req.ReturnId)
+	if err != nil {
+		return nil, err
+	}
+	if ret.Status == target {
+		return &omsv1.AdvanceReturnResponse{OrderReturn: toProtoOrderReturn(ret)}, nil
+	}
+	if ret.Status.Claimed() {
+		return nil, status.Errorf(codes.Aborted, "return is %s", ret.Status)
+	}
+	if next, ok := ret.Status.Next(); !ok || next != target {
+		return nil, status.Errorf(codes.FailedPrecondition, "return is %s", ret.Status)
+	}
+
+	order, err := s.loadOrder(req.OrderId, "AdvanceReturn")
+	if err != nil {
+		return nil, err
+	}
+	var (
+		eventType string
+		effect    func(domain.Order, domain.OrderReturn) error
+	)
+	switch target {
+	case domain.ReturnStatusApproved:
+		eventType = timelineEventReturnApproved
+	case domain.ReturnStatusReceived:
+		eventType = timelineEventReturnReceived
+		effect = s.restockReturn
+	case domain.ReturnStatusRefunded:
+		eventType = timelineEventReturnRefunded
+		effect = s.refundReturn
+	}
+
+	from := ret.Status
+	if claim, ok := target.Claim(); ok {
+		if _, err := s.advanceReturnStatus(ret, from, claim); err != nil {
+			return nil, err
+		}
+		if err := effect(order, ret); err != nil {
+			// Склад или PSP отказали — снимаем захват, чтобы переход можно было повторить.
+			if _, releaseErr := s.returns.repo.Advance(ret.ID, claim, from); releaseErr != nil {
+				s.logger.WithError(releaseErr).WithFields(log.Fields{
+					"order_id":  order.ID,
+					"return_id": ret.ID,
+					"status":    claim,
+				}).Error("failed to release order return claim")
+			}
+			return nil, err
+		}
+		from = claim
+	}
+
+	// Сбой между побочным эффектом и этой записью оставляет возврат в промежуточном статусе:
+	// повторять Restock или Refund вслепую нельзя, такой возврат разбирает оператор.
+	advanced, err := s.advanceReturnStatus(ret, from, target)
+	if err != nil {
+		return nil, err
+	}
+	s.appendTimelineEvent(order.ID, eventType, "return "+advanced.ID)
+
+	s.logger.WithFields(log.Fields{
+		"order_id":  order.ID,
+		"return_id": advanced.ID,
+		"status":    advanced.Status,
+	}).Info("order return advanced")
+
+	return &omsv1.AdvanceReturnResponse{OrderReturn: toProtoOrderReturn(advanced)}, nil
+}
+
+func (s *OrderService) advanceReturnStatus(ret domain.OrderReturn, from, to domain.ReturnStatus) (domain.OrderReturn, error) {
+	advanced, err := s.returns.repo.Advance(ret.ID, from, to)
+	switch {
+	case err == nil:
+		return advanced, nil
+	case errors.Is(err, domain.ErrReturnStatusConflict):
+		return domain.OrderReturn{}, status.Error(codes.Aborted, domain.ErrReturnStatusConflict.Error())
+	default:
+		s.logger.WithError(err).WithFields(log.Fields{
+			"order_id":  ret.OrderID,
+			"return_id": ret.ID,
+			"status":    to,
+		}).Error("failed to advance order return")
+		return domain.OrderReturn{}, status.Error(codes.Internal, "failed to advance order return")
+	}
+}
+
+// restockReturn возвращает принятые позиции в остатки. Sandbox-заказ склад не затрагивает,
+// а склад без InventoryRestocker принимает возврат без пополнения остатков.
+func (s *OrderService) restockReturn(order domain.Order, ret domain.OrderReturn) error {
+	restocker, ok := s.returns.inventory.(domain.InventoryRestocker)
+	if order.TestMode || !ok {
+		return nil
+	}
+	items := make([]domain.OrderItem, 0, len(ret.Items))
+	for _, item := range ret.Items {
+		items = append(items, domain.OrderItem{ID: item.ItemID, SKU: item.SKU, Qty: item.Qty})
+	}
+	if err := restocker.Restock(order.ID, items); err != nil {
+		s.logger.WithError(err).WithFields(log.Fields{
+			"order_id":  order.ID,
+			"return_id": ret.ID,
+		}).Warn("failed to restock returned items")
+		return status.Error(codes.Unavailable, "failed to restock returned items")
+	}
+	return nil
+}
+
+// refundReturn возвращает деньги за позиции возврата. Заказ, уже возвращённый целиком через
+// RefundOrder, повторно не возвращается.
+func (s *OrderService) refundReturn(order domain.Order, ret domain.OrderReturn) error {
+	if order.Status == domain.OrderStatusRefunded {
+		return status.Error(codes.FailedPrecondition, "order is already refunded")
+	}
+	if order.TestMode || s.returns.payments == nil || ret.RefundMinor == 0 {
+		return nil
+	}
+	paymentStatus, err := s.returns.payments.Refund(order.ID, ret.RefundMinor, ret.Currency)
+	if err != nil {
+		s.logger.WithError(err).WithFields(log.Fields{
+			"order_id":  order.ID,
+			"return_id": ret.ID,
+		}).Warn("return refund failed")
+		return status.Error(codes.Unavailable, "return refund failed")
+	}
+	if paymentStatus != domain.PaymentStatusRefunded {
+		s.logger.WithFields(log.Fields{
+			"order_id":  order.ID,
+			"return_id": ret.ID,
+			"status":    paymentStatus,
+		}).Warn("unexpected return refund status")
+		return status.Errorf(codes.FailedPrecondition, "refund is %s", paymentStatus)
+	}
+	return nil
+}
+
+// refundableAfterReturns ограничивает возврат всего заказа суммой, не возвращённой через
+// возвраты позиций: amountMinor == 0 — весь остаток.
+func (s *OrderService) refundableAfterReturns(order domain.Order, amountMinor int64) (int64, error) {
+	if s.returns == nil {
+		return amountMinor, nil
+	}
+	returns, err := s.listOrderReturns(order.ID)
+	if err != nil {
+		return 0, err
+	}
+	refunded := domain.RefundedByReturns(returns)
+	if refunded == 0 {
+		return amountMinor, nil
+	}
+	remaining := order.AmountMinor - refunded
+	switch {
+	case remaining <= 0:
+		return 0, status.Error(codes.FailedPrecondition, "order is already refunded through item returns")
+	case amountMinor == 0:
+		return remaining, nil
+	case amountMinor > remaining:
+		return 0, status.Errorf(codes.InvalidArgument, "amount.amount_minor exceeds %d left after item returns", remaining)
+	default:
+		return amountMinor, nil
+	}
+}
+
+func (s *OrderService) listOrderReturns(orderID string) ([]domain.OrderReturn, error) {
+	returns, err := s.returns.repo.ListByOrder(orderID)
+	if err != nil {
+		s.logger.WithError(err).WithField("order_id", orderID).Error("failed to list order returns")
+		return nil, status.Error(codes.Internal, "failed to list order returns")
+	}
+	return returns, nil
+}
+
+func (s *OrderService) loadOrderReturn(orderID, returnID string) (domain.OrderReturn, error) {
+	ret, err := s.returns.repo.Get(returnID)
+	if err == nil && ret.OrderID != orderID {
+		err = domain.ErrReturnNotFound
+	}
+	switch {
+	case err == nil:
+		return ret, nil
+	case errors.Is(err, domain.ErrReturnNotFound):
+		return domain.OrderReturn{}, status.Error(codes.NotFound, domain.ErrReturnNotFound.Error())
+	default:
+		s.logger.WithError(err).WithField("return_id", returnID).Error("failed to load order return")
+		return domain.OrderReturn{}, status.Error(codes.Internal, "failed to load order return")
+	}
+}
+
+// returnTimelineReason описывает возврат в timeline: "return <id>: SKU-1 x2, SKU-2 x1: <причина>".
+func returnTimelineReason(ret domain.OrderReturn) string {
+	var builder strings.Builder
+	builder.WriteString("return ")
+	builder.WriteString(ret.ID)
+	for i, item := range ret.Items {
+		if i == 0 {
+			builder.WriteString(": ")
+		} else {
+			builder.WriteString(", ")
+		}
+		builder.WriteString(item.SKU)
+		builder.WriteString(" x")
+		builder.WriteString(strconv.Itoa(int(item.Qty)))
+	}
+	if ret.Reason != "" {
+		builder.WriteString(": ")
+		builder.WriteString(ret.Reason)
+	}
+	return builder.String()
+}
+
+func toProtoOrderReturn(ret domain.OrderReturn) *omsv1.OrderReturn {
+	items := make([]*omsv1.ReturnItem, 0, len(ret.Items))
+	for _, item := range ret.Items {
+		items = append(items, &omsv1.ReturnItem{
+			ItemId: item.ItemID,
+			Qty:    item.Qty,
+			Sku:    item.SKU,
+			Refund: &omsv1.Money{Currency: ret.Currency, AmountMinor: item.RefundMinor},
+		})
+	}
+	return &omsv1.OrderReturn{
+		Id:        ret.ID,
+		OrderId:   ret.OrderID,
+		Status:    toProtoReturnStatus(ret.Status),
+		Items:     items,
+		Reason:    ret.Reason,
+		Refund:    &omsv1.Money{Currency: ret.Currency, AmountMinor: ret.RefundMinor},
+		CreatedAt: timeutil.Format(ret.CreatedAt),
+		UpdatedAt: timeutil.Format(ret.UpdatedAt),
+	}
+}
+
+// toProtoReturnStatus отдаёт захваченный возврат как ещё не перешедший этап: в API промежуточных
+// статусов нет.
+func toProtoReturnStatus(status domain.ReturnStatus) omsv1.ReturnStatus {
+	switch status {
+	case domain.ReturnStatusRequested:
+		return omsv1.ReturnStatus_RETURN_STATUS_REQUESTED
+	case domain.ReturnStatusApproved, domain.ReturnStatusReceiving:
+		return omsv1.ReturnStatus_RETURN_STATUS_APPROVED
+	case domain.ReturnStatusReceived, domain.ReturnStatusRefunding:
+		return omsv1.ReturnStatus_RETURN_STATUS_RECEIVED
+	case domain.ReturnStatusRefunded:
+		return omsv1.ReturnStatus_RETURN_STATUS_REFUNDED
+	default:
+		return omsv1.ReturnStatus_RETURN_STATUS_UNSPECIFIED
+	}
+}
+
+func fromProtoReturnStatus(status omsv1.ReturnStatus) domain.ReturnStatus {
+	switch status {
+	case omsv1.ReturnStatus_RETURN_STATUS_REQUESTED:
+		return domain.ReturnStatusRequested
+	case omsv1.ReturnStatus_RETURN_STATUS_APPROVED:
+		return domain.ReturnStatusApproved
+	case omsv1.ReturnStatus_RETURN_STATUS_RECEIVED:
+		return domain.ReturnStatusReceived
+	case omsv1.ReturnStatus_RETURN_STATUS_REFUNDED:
+		return domain.ReturnStatusRefunded
+	default:
+		return ""
+	}
+}
@@ -0,0 +1,243 @@
+package grpcsvc
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/vladislavdragonenkov/oms/internal/domain"
+	"github.com/vladislavdragonenkov/oms/internal/service/inventory"
+	"github.com/vladislavdragonenkov/oms/internal/service/payment"
+	"github.com/vladislavdragonenkov/oms/internal/storage/memory"
+	omsv1 "github.com/vladislavdragonenkov/oms/proto/oms/v1"
+)
+
+func advanceTestReturn(t *testing.T, service *OrderService, returnID string, target omsv1.ReturnStatus) *omsv1.OrderReturn {
+	t.Helper()
+	resp, err := service.AdvanceReturn(context.Background(), &omsv1.AdvanceReturnRequest{OrderId: "order-1", ReturnId: returnID, Status: target})
+	if err != nil {
+		t.Fatalf("AdvanceReturn to %s: %v", target, err)
+	}
+	return resp.GetOrderReturn()
+}
+
+func TestOrderService_ReturnLifecycle(t *testing.T) {
+	orders := memory.NewOrderRepository()
+	seedTestOrder(t, orders, domain.OrderStatusConfirmed)
+	timeline := memory.NewTimelineRepository()
+	stock := inventory.NewMockService()
+	payments := payment.NewMockService()
+	service := NewOrderService(orders, timeline, nil, nil, nil, WithReturns(memory.NewReturnRepository(), stock, payments))
+	defer service.Shutdown(context.Background())
+	ctx := context.Background()
+
+	created, err := service.CreateReturn(ctx, &omsv1.CreateReturnRequest{
+		OrderId: "order-1",
+		Items:   []*omsv1.ReturnItem{{ItemId: "i-1", Qty: 1}},
+		Reason:  "wrong size",
+	})
+	if err != nil {
+		t.Fatalf("CreateReturn: %v", err)
+	}
+	ret := created.GetOrderReturn()
+	if ret.GetStatus() != omsv1.ReturnStatus_RETURN_STATUS_REQUESTED || ret.GetRefund().GetAmountMinor() != 500 ||
+		len(ret.GetItems()) != 1 || ret.GetItems()[0].GetSku() != "SKU-1" {
+		t.Fatalf("unexpected return: %+v", ret)
+	}
+
+	if _, err := service.AdvanceReturn(ctx, &omsv1.AdvanceReturnRequest{OrderId: "order-1", ReturnId: ret.GetId(), Status: omsv1.ReturnStatus_RETURN_STATUS_RECEIVED}); status.Code(err) != codes.FailedPrecondition {
+		t.Fatalf("expected FailedPrecondition when skipping approval, got %v", err)
+	}
+
+	advanceTestReturn(t, service, ret.GetId(), omsv1.ReturnStatus_RETURN_STATUS_APPROVED)
+	if stock.RestockCalls != 0 || payments.RefundCalls != 0 {
+		t.Fatal("approval must not touch inventory or payments")
+	}
+	advanceTestReturn(t, service, ret.GetId(), omsv1.ReturnStatus_RETURN_STATUS_RECEIVED)
+	if stock.Restocked["SKU-1"] != 1 {
+		t.Fatalf("expected returned unit restocked, got %v", stock.Restocked)
+	}
+	refunded := advanceTestReturn(t, service, ret.GetId(), omsv1.ReturnStatus_RETURN_STATUS_REFUNDED)
+	if refunded.GetStatus() != omsv1.ReturnStatus_RETURN_STATUS_REFUNDED || payments.RefundCalls != 1 {
+		t.Fatalf("expected one refund, got %+v (calls=%d)", refunded, payments.RefundCalls)
+	}
+
+	// Повтор перехода возвращает возврат без повторного возврата денег.
+	advanceTestReturn(t, service, ret.GetId(), omsv1.ReturnStatus_RETURN_STATUS_REFUNDED)
+	if payments.RefundCalls != 1 {
+		t.Fatalf("repeated advance must not refund again, calls=%d", payments.RefundCalls)
+	}
+
+	got, err := service.GetReturn(ctx, &omsv1.GetReturnRequest{OrderId: "order-1", ReturnId: ret.GetId()})
+	if err != nil || got.GetOrderReturn().GetStatus() != omsv1.ReturnStatus_RETURN_STATUS_REFUNDED {
+		t.Fatalf("GetReturn: %+v, %v", got, err)
+	}
+	order, _ := orders.Get("order-1")
+	if order.Status != domain.OrderStatusConfirmed {
+		t.Fatalf("partial return must not change order status, got %s", order.Status)
+	}
+
+	events, _ := timeline.List("order-1")
+	var types []string
+	for _, event := range events {
+		types = append(types, event.Type)
+	}
+	want := []string{timelineEventReturnRequested, timelineEventReturnApproved, timelineEventReturnReceived, timelineEventReturnRefunded}
+	if len(types) != len(want) {
+		t.Fatalf("unexpected timeline: %v", types)
+	}
+	for i := range want {
+		if types[i] != want[i] {
+			t.Fatalf("unexpected timeline: %v", types)
+		}
+	}
+	if events[0].Reason != "return "+ret.GetId()+": SKU-1 x1: wrong size" {
+		t.Fatalf("unexpected timeline reason: %q", events[0].Reason)
+	}
+}
+
+func TestOrderService_CreateReturnValidation(t *testing.T) {
+	tests := []struct {
+		name   string
+		status domain.OrderStatus
+		items  []*omsv1.ReturnItem
+		code   codes.Code
+	}{
+		{name: "unpaid order", status: domain.OrderStatusReserved, items: []*omsv1.ReturnItem{{ItemId: "i-1", Qty: 1}}, code: codes.FailedPrecondition},
+		{name: "no items", status: domain.OrderStatusPaid, code: codes.InvalidArgument},
+		{name: "unknown item", status: domain.OrderStatusPaid, items: []*omsv1.ReturnItem{{ItemId: "i-9", Qty: 1}}, code: codes.InvalidArgument},
+		{name: "qty over ordered", status: domain.OrderStatusPaid, items: []*omsv1.ReturnItem{{ItemId: "i-1", Qty: 3}}, code: codes.FailedPrecondition},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			orders := memory.NewOrderRepository()
+			seedTestOrder(t, orders, tt.status)
+			service := NewOrderService(orders, memory.NewTimelineRepository(), nil, nil, nil, WithReturns(memory.NewReturnRepository(), inventory.NewMockService(), payment.NewMockService()))
+			defer service.Shutdown(context.Background())
+			_, err := service.CreateReturn(context.Background(), &omsv1.CreateReturnRequest{OrderId: "order-1", Items: tt.items})
+			if status.Code(err) != tt.code {
+				t.Fatalf("expected %s, got %v", tt.code, err)
+			}
+		})
+	}
+
+	unconfigured := NewOrderService(memory.NewOrderRepository(), nil, nil, nil, nil)
+	defer unconfigured.Shutdown(context.Background())
+	if _, err := unconfigured.GetReturn(context.Background(), &omsv1.GetReturnRequest{OrderId: "order-1", ReturnId: "r-1"}); status.Code(err) != codes.Unimplemented {
+		t.Fatalf("expected Unimplemented without returns, got %v", err)
+	}
+}
+
+func TestOrderService_ReturnFailuresKeepStatus(t *testing.T) {
+	orders := memory.NewOrderRepository()
+	seedTestOrder(t, orders, domain.OrderStatusPaid)
+	stock := inventory.NewMockService()
+	payments := payment.NewMockService()
+	service := NewOrderService(orders, memory.NewTimelineRepository(), nil, nil, nil, WithReturns(memory.NewReturnRepository(), stock, payments))
+	defer service.Shutdown(context.Background())
+	ctx := context.Background()
+	created, err := service.CreateReturn(ctx, &omsv1.CreateReturnRequest{OrderId: "order-1", Items: []*omsv1.ReturnItem{{ItemId: "i-2", Qty: 1}}})
+	if err != nil {
+		t.Fatalf("CreateReturn: %v", err)
+	}
+	id := created.GetOrderReturn().GetId()
+	advanceTestReturn(t, service, id, omsv1.ReturnStatus_RETURN_STATUS_APPROVED)
+
+	stock.RestockErr = errors.New("warehouse offline")
+	if _, err := service.AdvanceReturn(ctx, &omsv1.AdvanceReturnRequest{OrderId: "order-1", ReturnId: id, Status: omsv1.ReturnStatus_RETURN_STATUS_RECEIVED}); status.Code(err) != codes.Unavailable {
+		t.Fatalf("expected Unavailable on restock failure, got %v", err)
+	}
+	stock.RestockErr = nil
+	advanceTestReturn(t, service, id, omsv1.ReturnStatus_RETURN_STATUS_RECEIVED)
+
+	payments.RefundErr = errors.New("psp timeout")
+	if _, err := service.AdvanceReturn(ctx, &omsv1.AdvanceReturnRequest{OrderId: "order-1", ReturnId: id, Status: omsv1.ReturnStatus_RETURN_STATUS_REFUNDED}); status.Code(err) != codes.Unavailable {
+		t.Fatalf("expected Unavailable on refund failure, got %v", err)
+	}
+	got, _ := service.GetReturn(ctx, &omsv1.GetReturnRequest{OrderId: "order-1", ReturnId: id})
+	if got.GetOrderReturn().GetStatus() != omsv1.ReturnStatus_RETURN_STATUS_RECEIVED {
+		t.Fatalf("failed refund must keep return received, got %s", got.GetOrderReturn().GetStatus())
+	}
+}
+
+// reentrantPayments вызывает during посреди Refund — так тест воспроизводит параллельный AdvanceReturn.
+type reentrantPayments struct {
+	*payment.MockService
+	during func()
+}
+
+func (p reentrantPayments) Refund(orderID string, amountMinor int64, currency string) (domain.PaymentStatus, error) {
+	p.during()
+	return p.MockService.Refund(orderID, amountMinor, currency)
+}
+
+func TestOrderService_AdvanceReturnClaimsRefund(t *testing.T) {
+	orders := memory.NewOrderRepository()
+	seedTestOrder(t, orders, domain.OrderStatusPaid)
+	payments := payment.NewMockService()
+	service := NewOrderService(orders, memory.NewTimelineRepository(), nil, nil, nil, WithReturns(memory.NewReturnRepository(), inventory.NewMockService(), payments))
+	defer service.Shutdown(context.Background())
+	ctx := context.Background()
+	created, err := service.CreateReturn(ctx, &omsv1.CreateReturnRequest{OrderId: "order-1", Items: []*omsv1.ReturnItem{{ItemId: "i-2", Qty: 1}}})
+	if err != nil {
+		t.Fatalf("CreateReturn: %v", err)
+	}
+	id := created.GetOrderReturn().GetId()
+	advanceTestReturn(t, service, id, omsv1.ReturnStatus_RETURN_STATUS_APPROVED)
+	advanceTestReturn(t, service, id, omsv1.ReturnStatus_RETURN_STATUS_RECEIVED)
+
+	var concurrent error
+	service.returns.payments = reentrantPayments{MockService: payments, during: func() {
+		got, _ := service.GetReturn(ctx, &omsv1.GetReturnRequest{OrderId: "order-1", ReturnId: id})
+		if got.GetOrderReturn().GetStatus() != omsv1.ReturnStatus_RETURN_STATUS_RECEIVED {
+			t.Errorf("claimed return must be reported as received, got %s", got.GetOrderReturn().GetStatus())
+		}
+		_, concurrent = service.AdvanceReturn(ctx, &omsv1.AdvanceReturnRequest{OrderId: "order-1", ReturnId: id, Status: omsv1.ReturnStatus_RETURN_STATUS_REFUNDED})
+	}}
+	if got := advanceTestReturn(t, service, id, omsv1.ReturnStatus_RETURN_STATUS_REFUNDED); got.GetStatus() != omsv1.ReturnStatus_RETURN_STATUS_REFUNDED {
+		t.Fatalf("expected refunded return, got %s", got.GetStatus())
+	}
+	if status.Code(concurrent) != codes.Aborted {
+		t.Fatalf("expected Aborted for concurrent refund, got %v", concurrent)
+	}
+	if payments.RefundCalls != 1 {
+		t.Fatalf("expected a single PSP refund, got %d", payments.RefundCalls)
+	}
+}
+
+func TestOrderService_RefundOrderAccountsForReturns(t *testing.T) {
+	orders := memory.NewOrderRepository()
+	seedTestOrder(t, orders, domain.OrderStatusConfirmed)
+	service := NewOrderService(orders, memory.NewTimelineRepository(), nil, nil, nil, WithReturns(memory.NewReturnRepository(), inventory.NewMockService(), payment.NewMockService()))
+	defer service.Shutdown(context.Background())
+	ctx := context.Background()
+
+	created, err := service.CreateReturn(ctx, &omsv1.CreateReturnRequest{OrderId: "order-1", Items: []*omsv1.ReturnItem{{ItemId: "i-2", Qty: 1}}})
+	if err != nil {
+		t.Fatalf("CreateReturn: %v", err)
+	}
+	id := created.GetOrderReturn().GetId()
+	for _, target := range []omsv1.ReturnStatus{
+		omsv1.ReturnStatus_RETURN_STATUS_APPROVED,
+		omsv1.ReturnStatus_RETURN_STATUS_RECEIVED,
+		omsv1.ReturnStatus_RETURN_STATUS_REFUNDED,
+	} {
+		advanceTestReturn(t, service, id, target)
+	}
+
+	// Из 1300 через возврат позиции уже вернули 300.
+	_, err = service.RefundOrder(ctx, &omsv1.RefundOrderRequest{OrderId: "order-1", Amount: &omsv1.Money{AmountMinor: 1100}})
+	if status.Code(err) != codes.InvalidArgument {
+		t.Fatalf("expected InvalidArgument for refund above remainder, got %v", err)
+	}
+	if _, err := service.RefundOrder(ctx, &omsv1.RefundOrderRequest{OrderId: "order-1"}); err != nil {
+		t.Fatalf("RefundOrder of remainder: %v", err)
+	}
+
+	if _, err := service.CreateReturn(ctx, &omsv1.CreateReturnRequest{OrderId: "order-1", Items: []*omsv1.ReturnItem{{ItemId: "i-1", Qty: 1}}}); status.Code(err) != codes.FailedPrecondition {
+		t.Fatalf("expected FailedPrecondition for return of refunded order, got %v", err)
+	}
+}
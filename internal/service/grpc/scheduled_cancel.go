@@ -0,0 +1,175 @@
+package grpcsvc
+
+import (
+	"context"
+	"errors"
+	"strings"
+
+	log "github.com/sirupsen/logrus"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/vladislavdragonenkov/oms/internal/domain"
+	"github.com/vladislavdragonenkov/oms/internal/timeutil"
+	omsv1 "github.com/vladislavdragonenkov/oms/proto/oms/v1"
+)
+
+const (
+	grpcMethodScheduleCancel = "/oms.v1.OrderService/ScheduleCancel"
+
+	timelineEventOrderCancelScheduled = "OrderCancelScheduled"
+)
+
+// WithScheduledCancels включает ScheduleCancel и связанные RPC; задачи выполняет saga.CancelScheduler.
+func WithScheduledCancels(repo domain.ScheduledCancelRepository) OrderServiceOption {
+	return func(s *OrderService) {
+		s.scheduledCancels = repo
+	}
+}
+
+// ScheduleCancel планирует отмену заказа на момент cancel_at.
+func (s *OrderService) ScheduleCancel(ctx context.Context, req *omsv1.ScheduleCancelRequest) (*omsv1.ScheduleCancelResponse, error) {
+	if _, err := parseOrderIDArg(req.GetOrderId()); err != nil {
+		return nil, err
+	}
+
+	return withIdempotency(
+		s,
+		ctx,
+		grpcMethodScheduleCancel,
+		req,
+		s.orderCustomer(req.OrderId, "ScheduleCancel"),
+		func() *omsv1.ScheduleCancelResponse { return &omsv1.ScheduleCancelResponse{} },
+		func(ctx context.Context) (*omsv1.ScheduleCancelResponse, error) {
+			return s.scheduleCancelInternal(ctx, req)
+		},
+	)
+}
+
+func (s *OrderService) scheduleCancelInternal(_ context.Context, req *omsv1.ScheduleCancelRequest) (*omsv1.ScheduleCancelResponse, error) {
+	if s.scheduledCancels == nil {
+		return nil, status.Error(codes.Unimplemented, "scheduled cancellation is not configured")
+	}
+	cancelAt, err := timeutil.Parse(strings.TrimSpace(req.CancelAt))
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, "cancel_at must be an RFC3339 timestamp")
+	}
+	if !cancelAt.After(timeutil.Now()) {
+		return nil, status.Error(codes.InvalidArgument, "cancel_at must be in the future")
+	}
+
+	order, err := s.loadOrder(req.OrderId, "ScheduleCancel")
+	if err != nil {
+		return nil, err
+	}
+	if order.Status.SkipsScheduledCancel() {
+		return nil, status.Errorf(codes.FailedPrecondition, "order is already %s", order.Status)
+	}
+
+	reason := strings.TrimSpace(req.Reason)
+	task, err := s.scheduledCancels.Create(domain.ScheduledCancel{OrderID: order.ID, CancelAt: cancelAt, Reason: reason})
+	if err != nil {
+		s.logger.WithError(err).WithField("order_id", order.ID).Error("failed to schedule cancel")
+		return nil, status.Error(codes.Internal, "failed to schedule cancel")
+	}
+	s.appendTimelineEvent(order.ID, timelineEventOrderCancelScheduled, "cancel at "+timeutil.Format(cancelAt)+scheduledCancelReasonSuffix(reason))
+
+	s.logger.WithFields(log.Fields{
+		"order_id":  order.ID,
+		"task_id":   task.ID,
+		"cancel_at": timeutil.Format(cancelAt),
+	}).Info("order cancel scheduled")
+
+	return &omsv1.ScheduleCancelResponse{ScheduledCancel: toProtoScheduledCancel(task)}, nil
+}
+
+// ListScheduledCancels возвращает отложенные отмены заказа.
+func (s *OrderService) ListScheduledCancels(_ context.Context, req *omsv1.ListScheduledCancelsRequest) (*omsv1.ListScheduledCancelsResponse, error) {
+	if _, err := parseOrderIDArg(req.GetOrderId()); err != nil {
+		return nil, err
+	}
+	if s.scheduledCancels == nil {
+		return nil, status.Error(codes.Unimplemented, "scheduled cancellation is not configured")
+	}
+	if _, err := s.loadOrder(req.OrderId, "ListScheduledCancels"); err != nil {
+		return nil, err
+	}
+
+	tasks, err := s.scheduledCancels.ListByOrder(req.OrderId)
+	if err != nil {
+		s.logger.WithError(err).WithField("order_id", req.OrderId).Error("failed to list scheduled cancels")
+		return nil, status.Error(codes.Internal, "failed to list scheduled cancels")
+	}
+	result := make([]*omsv1.ScheduledCancel, 0, len(tasks))
+	for _, task := range tasks {
+		result = append(result, toProtoScheduledCancel(task))
+	}
+	return &omsv1.ListScheduledCancelsResponse{ScheduledCancels: result}, nil
+}
+
+// DeleteScheduledCancel снимает отложенную отмену, пока её срок не наступил.
+// Повторный вызов для уже снятой задачи возвращает её без ошибки.
+func (s *OrderService) DeleteScheduledCancel(_ context.Context, req *omsv1.DeleteScheduledCancelRequest) (*omsv1.DeleteScheduledCancelResponse, error) {
+	if _, err := parseOrderIDArg(req.GetOrderId()); err != nil {
+		return nil, err
+	}
+	if req.ScheduledCancelId == "" {
+		return nil, status.Error(codes.InvalidArgument, "scheduled_cancel_id is required")
+	}
+	if s.scheduledCancels == nil {
+		return nil, status.Error(codes.Unimplemented, "scheduled cancellation is not configured")
+	}
+
+	task, err := s.scheduledCancels.Get(req.ScheduledCancelId)
+	if err == nil && task.OrderID != req.OrderId {
+		err = domain.ErrScheduledCancelNotFound
+	}
+	if err == nil && task.Status != domain.ScheduledCancelCanceled {
+		task, err = s.scheduledCancels.Finish(task.ID, domain.ScheduledCancelCanceled, "")
+	}
+	switch {
+	case err == nil:
+		return &omsv1.DeleteScheduledCancelResponse{ScheduledCancel: toProtoScheduledCancel(task)}, nil
+	case errors.Is(err, domain.ErrScheduledCancelNotFound):
+		return nil, status.Error(codes.NotFound, domain.ErrScheduledCancelNotFound.Error())
+	case errors.Is(err, domain.ErrScheduledCancelNotPending):
+		return nil, status.Error(codes.FailedPrecondition, "scheduled cancel has already run")
+	default:
+		s.logger.WithError(err).WithField("task_id", req.ScheduledCancelId).Error("failed to delete scheduled cancel")
+		return nil, status.Error(codes.Internal, "failed to delete scheduled cancel")
+	}
+}
+
+func scheduledCancelReasonSuffix(reason string) string {
+	if reason == "" {
+		return ""
+	}
+	return ": " + reason
+}
+
+func toProtoScheduledCancel(task domain.ScheduledCancel) *omsv1.ScheduledCancel {
+	return &omsv1.ScheduledCancel{
+		Id:        task.ID,
+		OrderId:   task.OrderID,
+		CancelAt:  timeutil.Format(task.CancelAt),
+		Reason:    task.Reason,
+		Status:    toProtoScheduledCancelStatus(task.Status),
+		Note:      task.Note,
+		CreatedAt: timeutil.Format(task.CreatedAt),
+	}
+}
+
+func toProtoScheduledCancelStatus(status domain.ScheduledCancelStatus) omsv1.ScheduledCancelStatus {
+	switch status {
+	case domain.ScheduledCancelPending:
+		return omsv1.ScheduledCancelStatus_SCHEDULED_CANCEL_STATUS_PENDING
+	case domain.ScheduledCancelExecuted:
+		return omsv1.ScheduledCancelStatus_SCHEDULED_CANCEL_STATUS_EXECUTED
+	case domain.ScheduledCancelSkipped:
+		return omsv1.ScheduledCancelStatus_SCHEDULED_CANCEL_STATUS_SKIPPED
+	case domain.ScheduledCancelCanceled:
+		return omsv1.ScheduledCancelStatus_SCHEDULED_CANCEL_STATUS_CANCELED
+	default:
+		return omsv1.ScheduledCancelStatus_SCHEDULED_CANCEL_STATUS_UNSPECIFIED
+	}
+}
@@ -0,0 +1,96 @@
+package grpcsvc
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/vladislavdragonenkov/oms/internal/domain"
+	"github.com/vladislavdragonenkov/oms/internal/service/saga"
+	"github.com/vladislavdragonenkov/oms/internal/storage/memory"
+	omsv1 "github.com/vladislavdragonenkov/oms/proto/oms/v1"
+)
+
+func TestOrderService_ScheduleCancelLifecycle(t *testing.T) {
+	orders := memory.NewOrderRepository()
+	seedTestOrder(t, orders, domain.OrderStatusReserved)
+	timeline := memory.NewTimelineRepository()
+	service := NewOrderService(orders, timeline, nil, saga.NewNoop(nil), nil, WithScheduledCancels(memory.NewScheduledCancelRepository()))
+	defer service.Shutdown(context.Background())
+	ctx := context.Background()
+
+	cancelAt := time.Now().Add(48 * time.Hour).Truncate(time.Second).In(time.FixedZone("MSK", 3*60*60))
+	scheduled, err := service.ScheduleCancel(ctx, &omsv1.ScheduleCancelRequest{
+		OrderId:  "order-1",
+		CancelAt: cancelAt.Format(time.RFC3339),
+		Reason:   "not shipped by friday",
+	})
+	if err != nil {
+		t.Fatalf("ScheduleCancel: %v", err)
+	}
+	task := scheduled.GetScheduledCancel()
+	if task.GetStatus() != omsv1.ScheduledCancelStatus_SCHEDULED_CANCEL_STATUS_PENDING || task.GetCancelAt() != cancelAt.UTC().Format(time.RFC3339) {
+		t.Fatalf("unexpected scheduled cancel: %+v", task)
+	}
+	events, err := timeline.List("order-1")
+	if err != nil || len(events) != 1 || events[0].Type != timelineEventOrderCancelScheduled {
+		t.Fatalf("expected %s in timeline, got %+v (%v)", timelineEventOrderCancelScheduled, events, err)
+	}
+
+	list, err := service.ListScheduledCancels(ctx, &omsv1.ListScheduledCancelsRequest{OrderId: "order-1"})
+	if err != nil {
+		t.Fatalf("ListScheduledCancels: %v", err)
+	}
+	if len(list.GetScheduledCancels()) != 1 || list.GetScheduledCancels()[0].GetId() != task.GetId() {
+		t.Fatalf("unexpected list: %+v", list.GetScheduledCancels())
+	}
+
+	if _, err := service.DeleteScheduledCancel(ctx, &omsv1.DeleteScheduledCancelRequest{OrderId: "order-2", ScheduledCancelId: task.GetId()}); status.Code(err) != codes.NotFound {
+		t.Fatalf("expected NotFound for task of another order, got %v", err)
+	}
+	for i := 0; i < 2; i++ {
+		deleted, err := service.DeleteScheduledCancel(ctx, &omsv1.DeleteScheduledCancelRequest{OrderId: "order-1", ScheduledCancelId: task.GetId()})
+		if err != nil {
+			t.Fatalf("DeleteScheduledCancel #%d: %v", i+1, err)
+		}
+		if deleted.GetScheduledCancel().GetStatus() != omsv1.ScheduledCancelStatus_SCHEDULED_CANCEL_STATUS_CANCELED {
+			t.Fatalf("expected canceled task, got %+v", deleted.GetScheduledCancel())
+		}
+	}
+}
+
+func TestOrderService_ScheduleCancelValidation(t *testing.T) {
+	future := time.Now().Add(time.Hour).UTC().Format(time.RFC3339)
+	tests := []struct {
+		name   string
+		status domain.OrderStatus
+		req    *omsv1.ScheduleCancelRequest
+		code   codes.Code
+	}{
+		{"missing order id", domain.OrderStatusPending, &omsv1.ScheduleCancelRequest{CancelAt: future}, codes.InvalidArgument},
+		{"not rfc3339", domain.OrderStatusPending, &omsv1.ScheduleCancelRequest{OrderId: "order-1", CancelAt: "friday 18:00"}, codes.InvalidArgument},
+		{"in the past", domain.OrderStatusPending, &omsv1.ScheduleCancelRequest{OrderId: "order-1", CancelAt: "2020-01-01T00:00:00Z"}, codes.InvalidArgument},
+		{"unknown order", domain.OrderStatusPending, &omsv1.ScheduleCancelRequest{OrderId: "order-x", CancelAt: future}, codes.NotFound},
+		{"already confirmed", domain.OrderStatusConfirmed, &omsv1.ScheduleCancelRequest{OrderId: "order-1", CancelAt: future}, codes.FailedPrecondition},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			orders := memory.NewOrderRepository()
+			seedTestOrder(t, orders, tc.status)
+			service := NewOrderService(orders, memory.NewTimelineRepository(), nil, saga.NewNoop(nil), nil, WithScheduledCancels(memory.NewScheduledCancelRepository()))
+			defer service.Shutdown(context.Background())
+			if _, err := service.ScheduleCancel(context.Background(), tc.req); status.Code(err) != tc.code {
+				t.Fatalf("expected %s, got %v", tc.code, err)
+			}
+		})
+	}
+
+	unconfigured := NewOrderService(&stubOrderRepository{}, nil, nil, saga.NewNoop(nil), nil)
+	defer unconfigured.Shutdown(context.Background())
+	if _, err := unconfigured.ListScheduledCancels(context.Background(), &omsv1.ListScheduledCancelsRequest{OrderId: "order-1"}); status.Code(err) != codes.Unimplemented {
+		t.Fatalf("expected Unimplemented without repository, got %v", err)
+	}
+}
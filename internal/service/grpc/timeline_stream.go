@@ -0,0 +1,86 @@
+package grpcsvc
+
+import (
+	"errors"
+
+	log "github.com/sirupsen/logrus"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/vladislavdragonenkov/oms/internal/domain"
+	"github.com/vladislavdragonenkov/oms/internal/notify"
+	omsv1 "github.com/vladislavdragonenkov/oms/proto/oms/v1"
+)
+
+// TimelineWatcher отдаёт подписку на новые события timeline заказа (см. notify.TimelineRepository).
+type TimelineWatcher interface {
+	Watch(orderID string, withHistory bool) (*notify.TimelineStream, error)
+}
+
+// WithTimelineWatcher включает StreamOrderTimeline и WatchOrder.
+func WithTimelineWatcher(watcher TimelineWatcher) OrderServiceOption {
+	return func(s *OrderService) {
+		s.watcher = watcher
+	}
+}
+
+// StreamOrderTimeline отправляет события timeline заказа по мере их записи до отмены
+// клиентом. Отстающий клиент отключается с codes.Aborted и должен переподключиться.
+func (s *OrderService) StreamOrderTimeline(req *omsv1.StreamOrderTimelineRequest, stream omsv1.OrderService_StreamOrderTimelineServer) error {
+	if _, err := parseOrderIDArg(req.GetOrderId()); err != nil {
+		return err
+	}
+	if s.watcher == nil {
+		return status.Error(codes.Unimplemented, "timeline streaming is not configured")
+	}
+	if _, err := s.loadOrder(req.OrderId, "StreamOrderTimeline"); err != nil {
+		return err
+	}
+
+	watch, err := s.watcher.Watch(req.OrderId, req.IncludeHistory)
+	if err != nil {
+		s.logger.WithError(err).WithField("order_id", req.OrderId).Error("failed to watch timeline")
+		return status.Error(codes.Internal, "failed to watch timeline")
+	}
+	defer watch.Close()
+
+	for _, event := range watch.History {
+		if err := stream.Send(&omsv1.StreamOrderTimelineResponse{Event: toProtoTimelineEvent(event), Historical: true}); err != nil {
+			return err
+		}
+	}
+
+	ctx := stream.Context()
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case event, ok := <-watch.Events():
+			if !ok {
+				if errors.Is(watch.Err(), notify.ErrSubscriberLagged) {
+					s.logger.WithField("order_id", req.OrderId).Warn("timeline stream subscriber lagged")
+					return status.Error(codes.Aborted, "timeline stream lagged, resubscribe")
+				}
+				return nil
+			}
+			if watch.Duplicate(event) {
+				continue
+			}
+			if err := stream.Send(&omsv1.StreamOrderTimelineResponse{Event: toProtoTimelineEvent(event)}); err != nil {
+				s.logger.WithError(err).WithFields(log.Fields{
+					"order_id": req.OrderId,
+					"event":    event.Type,
+				}).Debug("timeline stream closed by client")
+				return err
+			}
+		}
+	}
+}
+
+func toProtoTimelineEvent(event domain.TimelineEvent) *omsv1.TimelineEvent {
+	return &omsv1.TimelineEvent{
+		Type:     event.Type,
+		Reason:   event.Reason,
+		UnixTime: event.Occurred.Unix(),
+	}
+}
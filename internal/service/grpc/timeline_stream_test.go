@@ -0,0 +1,96 @@
+package grpcsvc_test
+
+import (
+	"context"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+
+	"github.com/vladislavdragonenkov/oms/internal/domain"
+	"github.com/vladislavdragonenkov/oms/internal/notify"
+	grpcsvc "github.com/vladislavdragonenkov/oms/internal/service/grpc"
+	"github.com/vladislavdragonenkov/oms/internal/storage/memory"
+	omsv1 "github.com/vladislavdragonenkov/oms/proto/oms/v1"
+)
+
+func newTimelineStreamClient(t *testing.T, service *grpcsvc.OrderService) omsv1.OrderServiceClient {
+	t.Helper()
+	listener := bufconn.Listen(bufSize)
+	server := grpc.NewServer()
+	omsv1.RegisterOrderServiceServer(server, service)
+	go func() { _ = server.Serve(listener) }()
+
+	conn, err := grpc.NewClient("passthrough:///bufnet",
+		grpc.WithContextDialer(func(context.Context, string) (net.Conn, error) { return listener.Dial() }),
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+	)
+	require.NoError(t, err)
+	t.Cleanup(func() {
+		_ = conn.Close()
+		server.Stop()
+	})
+	return omsv1.NewOrderServiceClient(conn)
+}
+
+func TestOrderService_StreamOrderTimeline_HistoryThenLive(t *testing.T) {
+	repo := memory.NewOrderRepository()
+	require.NoError(t, repo.Create(domain.Order{ID: "order-1", CustomerID: "customer-1", Status: domain.OrderStatusPending, Currency: "USD"}))
+	timeline := notify.NewTimelineRepository(memory.NewTimelineRepository(), notify.NewHub[domain.TimelineEvent]())
+	require.NoError(t, timeline.Append(domain.TimelineEvent{OrderID: "order-1", Type: "OrderCreated"}))
+
+	service := grpcsvc.NewOrderService(repo, timeline, memory.NewIdempotencyRepository(), nil, loggerForTests(), grpcsvc.WithTimelineWatcher(timeline))
+	client := newTimelineStreamClient(t, service)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	stream, err := client.StreamOrderTimeline(ctx, &omsv1.StreamOrderTimelineRequest{OrderId: "order-1", IncludeHistory: true})
+	require.NoError(t, err)
+
+	first, err := stream.Recv()
+	require.NoError(t, err)
+	require.True(t, first.Historical)
+	require.Equal(t, "OrderCreated", first.Event.Type)
+
+	// История отдаётся после подписки, поэтому новое событие уже дойдёт до стрима.
+	require.NoError(t, timeline.Append(domain.TimelineEvent{OrderID: "order-1", Type: "OrderPaid", Reason: "card"}))
+
+	live, err := stream.Recv()
+	require.NoError(t, err)
+	require.False(t, live.Historical)
+	require.Equal(t, "OrderPaid", live.Event.Type)
+	require.Equal(t, "card", live.Event.Reason)
+}
+
+func TestOrderService_StreamOrderTimeline_Errors(t *testing.T) {
+	repo := memory.NewOrderRepository()
+	timeline := notify.NewTimelineRepository(memory.NewTimelineRepository(), notify.NewHub[domain.TimelineEvent]())
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	withoutWatcher := newTimelineStreamClient(t, grpcsvc.NewOrderService(repo, timeline, memory.NewIdempotencyRepository(), nil, loggerForTests()))
+	stream, err := withoutWatcher.StreamOrderTimeline(ctx, &omsv1.StreamOrderTimelineRequest{OrderId: "order-1"})
+	require.NoError(t, err)
+	_, err = stream.Recv()
+	require.Equal(t, codes.Unimplemented, status.Code(err))
+
+	client := newTimelineStreamClient(t, grpcsvc.NewOrderService(repo, timeline, memory.NewIdempotencyRepository(), nil, loggerForTests(), grpcsvc.WithTimelineWatcher(timeline)))
+	for name, tc := range map[string]struct {
+		req  *omsv1.StreamOrderTimelineRequest
+		code codes.Code
+	}{
+		"empty order id": {req: &omsv1.StreamOrderTimelineRequest{}, code: codes.InvalidArgument},
+		"missing order":  {req: &omsv1.StreamOrderTimelineRequest{OrderId: "missing"}, code: codes.NotFound},
+	} {
+		stream, err := client.StreamOrderTimeline(ctx, tc.req)
+		require.NoError(t, err, name)
+		_, err = stream.Recv()
+		require.Equal(t, tc.code, status.Code(err), name)
+	}
+}
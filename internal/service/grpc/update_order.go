@@ -0,0 +1,215 @@
+package grpcsvc
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	log "github.com/sirupsen/logrus"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/vladislavdragonenkov/oms/internal/domain"
+	omsv1 "github.com/vladislavdragonenkov/oms/proto/oms/v1"
+)
+
+const (
+	grpcMethodUpdateOrder = "/oms.v1.OrderService/UpdateOrder"
+
+	// timelineEventOrderUpdated — состав pending-заказа изменён; то же имя у события в outbox.
+	timelineEventOrderUpdated = "OrderUpdated"
+)
+
+// UpdateOrder меняет состав pending-заказа или заказа, ждущего согласования. expected_version
+// защищает от гонки с другими изменениями и с запуском саги: заказ, прочитанный клиентом,
+// мог уже уйти в резерв.
+func (s *OrderService) UpdateOrder(ctx context.Context, req *omsv1.UpdateOrderRequest) (*omsv1.UpdateOrderResponse, error) {
+	if _, err := parseOrderIDArg(req.GetOrderId()); err != nil {
+		return nil, err
+	}
+
+	return withIdempotency(
+		s,
+		ctx,
+		grpcMethodUpdateOrder,
+		req,
+		s.orderCustomer(req.OrderId, "UpdateOrder"),
+		func() *omsv1.UpdateOrderResponse { return &omsv1.UpdateOrderResponse{} },
+		func(ctx context.Context) (*omsv1.UpdateOrderResponse, error) {
+			return s.updateOrderInternal(ctx, req)
+		},
+	)
+}
+
+func (s *OrderService) updateOrderInternal(ctx context.Context, req *omsv1.UpdateOrderRequest) (*omsv1.UpdateOrderResponse, error) {
+	updater, ok := s.repo.(domain.OrderItemsUpdater)
+	if !ok {
+		return nil, status.Error(codes.Unimplemented, "order updates are not supported by the order repository")
+	}
+	if len(req.Items) == 0 {
+		return nil, status.Error(codes.InvalidArgument, "order must contain at least one item")
+	}
+
+	order, err := s.loadOrder(req.OrderId, "UpdateOrder")
+	if err != nil {
+		return nil, err
+	}
+	if order.Version != req.ExpectedVersion {
+		return nil, status.Errorf(codes.Aborted, "order version is %d, expected %d", order.Version, req.ExpectedVersion)
+	}
+	if !s.updatable(order) {
+		return nil, status.Errorf(codes.FailedPrecondition, "order in status %s cannot be updated", order.Status)
+	}
+
+	now := time.Now().UTC()
+	items, changed, err := applyItemUpdates(order, req.Items, now)
+	if err != nil {
+		return nil, err
+	}
+	if !changed {
+		return &omsv1.UpdateOrderResponse{Order: toProtoOrder(order)}, nil
+	}
+
+	previous := order
+	var subtotal int64
+	for _, item := range items {
+		subtotal += int64(item.Qty) * item.PriceMinor
+	}
+	order.Items = items
+	order.AmountMinor = subtotal
+	order.UpdatedAt = now
+	if !previous.Pricing.IsZero() {
+		// Скидки и налоги заказа сохраняются и пересчитываются от нового subtotal.
+		pricing, err := domain.PriceOrder(subtotal, previous.Pricing.Adjustments)
+		if err != nil {
+			return nil, status.Error(codes.InvalidArgument, err.Error())
+		}
+		order.Pricing = pricing
+		order.AmountMinor = pricing.TotalMinor
+	}
+	if errs := order.ValidateInvariants(); len(errs) > 0 {
+		return nil, status.Error(codes.InvalidArgument, joinErrors(errs))
+	}
+
+	if err := updater.UpdateItems(order); err != nil {
+		switch {
+		case errors.Is(err, domain.ErrOrderVersionConflict):
+			return nil, status.Error(codes.Aborted, "order was modified concurrently, retry")
+		case errors.Is(err, domain.ErrOrderNotFound):
+			return nil, status.Error(codes.NotFound, domain.ErrOrderNotFound.Error())
+		}
+		s.logger.WithError(err).WithField("order_id", order.ID).Error("failed to save updated order")
+		return nil, status.Error(codes.Internal, "failed to save order")
+	}
+	order.Version++
+
+	s.invalidateCustomerOrders(order.CustomerID)
+	s.appendTimelineEvent(order.ID, timelineEventOrderUpdated, fmt.Sprintf("items %d -> %d, total %d -> %d %s (%+d)",
+		len(previous.Items), len(order.Items), previous.AmountMinor, order.AmountMinor, order.Currency, order.AmountMinor-previous.AmountMinor))
+	s.enqueueOrderUpdated(ctx, previous, order)
+
+	s.logger.WithFields(log.Fields{
+		"order_id":       order.ID,
+		"version":        order.Version,
+		"items":          len(order.Items),
+		"previous_total": previous.AmountMinor,
+		"total":          order.AmountMinor,
+	}).Info("order updated")
+
+	return &omsv1.UpdateOrderResponse{Order: toProtoOrder(order)}, nil
+}
+
+// updatable сообщает, можно ли менять состав заказа. Заказ клиента с согласованием меняется только
+// до решения: согласованный состав не должен измениться до оплаты.
+func (s *OrderService) updatable(order domain.Order) bool {
+	switch order.Status {
+	case domain.OrderStatusAwaitingApproval:
+		return true
+	case domain.OrderStatusPending:
+		return !s.approvals.Requires(order.CustomerID)
+	default:
+		return false
+	}
+}
+
+// applyItemUpdates собирает новый состав заказа: позиции с id берутся из заказа с новым qty,
+// позиции без id добавляются, остальные позиции заказа удаляются. changed=false — состав
+// совпал с текущим (с точностью до порядка позиций).
+func applyItemUpdates(order domain.Order, reqItems []*omsv1.OrderItem, now time.Time) ([]domain.OrderItem, bool, error) {
+	items := make([]domain.OrderItem, 0, len(reqItems))
+	seen := make(map[string]struct{}, len(reqItems))
+	changed := false
+	for idx, item := range reqItems {
+		if item == nil {
+			return nil, false, status.Errorf(codes.InvalidArgument, "item[%d] is nil", idx)
+		}
+		if item.Qty <= 0 {
+			return nil, false, status.Errorf(codes.InvalidArgument, "item[%d].qty must be > 0", idx)
+		}
+
+		if id := strings.TrimSpace(item.Id); id != "" {
+			if _, dup := seen[id]; dup {
+				return nil, false, status.Errorf(codes.InvalidArgument, "item[%d].id %q is duplicated", idx, id)
+			}
+			seen[id] = struct{}{}
+			existing, err := order.ItemByID(id)
+			if err != nil {
+				return nil, false, status.Errorf(codes.InvalidArgument, "item[%d].id %q is not in the order", idx, id)
+			}
+			if existing.Qty != item.Qty {
+				existing.Qty = item.Qty
+				changed = true
+			}
+			items = append(items, existing)
+			continue
+		}
+
+		if item.Price == nil {
+			return nil, false, status.Errorf(codes.InvalidArgument, "item[%d].price is required", idx)
+		}
+		if item.Price.Currency != order.Currency {
+			return nil, false, status.Errorf(codes.InvalidArgument, "item[%d].price.currency mismatch", idx)
+		}
+		if item.Price.AmountMinor < 0 {
+			return nil, false, status.Errorf(codes.InvalidArgument, "item[%d].price.amount must be >= 0", idx)
+		}
+		items = append(items, domain.OrderItem{
+			ID:         uuid.NewString(),
+			SKU:        item.Sku,
+			Qty:        item.Qty,
+			PriceMinor: item.Price.AmountMinor,
+			CreatedAt:  now,
+		})
+		changed = true
+	}
+	if len(seen) != len(order.Items) {
+		changed = true
+	}
+	return items, changed, nil
+}
+
+// orderUpdatedItem — позиция в событии OrderUpdated.
+type orderUpdatedItem struct {
+	ID         string `json:"id"`
+	SKU        string `json:"sku"`
+	Qty        int32  `json:"qty"`
+	PriceMinor int64  `json:"price_minor"`
+}
+
+// enqueueOrderUpdated публикует новый состав заказа.
+func (s *OrderService) enqueueOrderUpdated(ctx context.Context, previous, order domain.Order) {
+	items := make([]orderUpdatedItem, 0, len(order.Items))
+	for _, item := range order.Items {
+		items = append(items, orderUpdatedItem{ID: item.ID, SKU: item.SKU, Qty: item.Qty, PriceMinor: item.PriceMinor})
+	}
+	s.enqueueOrderEvent(ctx, order, timelineEventOrderUpdated, map[string]interface{}{
+		"version":               order.Version,
+		"currency":              order.Currency,
+		"amount_minor":          order.AmountMinor,
+		"previous_amount_minor": previous.AmountMinor,
+		"items":                 items,
+	})
+}
@@ -0,0 +1,172 @@
+package grpcsvc
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/vladislavdragonenkov/oms/internal/domain"
+	"github.com/vladislavdragonenkov/oms/internal/service/saga"
+	"github.com/vladislavdragonenkov/oms/internal/storage/memory"
+	omsv1 "github.com/vladislavdragonenkov/oms/proto/oms/v1"
+)
+
+func TestOrderService_UpdateOrder(t *testing.T) {
+	orders := memory.NewOrderRepository()
+	seedTestOrder(t, orders, domain.OrderStatusPending)
+	timeline := memory.NewTimelineRepository()
+	outbox := memory.NewOutboxRepository()
+	service := NewOrderService(orders, timeline, nil, saga.NewNoop(nil), nil, WithOrderEvents(outbox))
+	defer service.Shutdown(context.Background())
+
+	resp, err := service.UpdateOrder(context.Background(), &omsv1.UpdateOrderRequest{
+		OrderId: "order-1",
+		Items: []*omsv1.OrderItem{
+			{Id: "i-1", Qty: 3, Sku: "ignored"},
+			{Sku: "SKU-3", Qty: 1, Price: &omsv1.Money{Currency: "USD", AmountMinor: 250}},
+		},
+	})
+	if err != nil {
+		t.Fatalf("UpdateOrder failed: %v", err)
+	}
+	if resp.Order.Version != 1 || resp.Order.Amount.AmountMinor != 1750 || len(resp.Order.Items) != 2 {
+		t.Fatalf("unexpected order: %v", resp.Order)
+	}
+	if resp.Order.Items[0].Sku != "SKU-1" || resp.Order.Items[1].Id == "" {
+		t.Fatalf("expected kept sku and assigned id, got %v", resp.Order.Items)
+	}
+
+	stored, err := orders.Get("order-1")
+	if err != nil {
+		t.Fatalf("get order: %v", err)
+	}
+	if stored.Version != 1 || stored.AmountMinor != 1750 || len(stored.Items) != 2 {
+		t.Fatalf("unexpected stored order: %+v", stored)
+	}
+
+	events, err := timeline.List("order-1")
+	if err != nil {
+		t.Fatalf("list timeline: %v", err)
+	}
+	if len(events) != 1 || events[0].Type != timelineEventOrderUpdated || events[0].Reason != "items 2 -> 2, total 1300 -> 1750 USD (+450)" {
+		t.Fatalf("unexpected timeline: %+v", events)
+	}
+
+	msgs, err := outbox.PullPending(10)
+	if err != nil {
+		t.Fatalf("pull outbox: %v", err)
+	}
+	if len(msgs) != 1 || msgs[0].EventType != timelineEventOrderUpdated || msgs[0].AggregateID != "order-1" {
+		t.Fatalf("unexpected outbox: %+v", msgs)
+	}
+	var payload struct {
+		Version             int64              `json:"version"`
+		AmountMinor         int64              `json:"amount_minor"`
+		PreviousAmountMinor int64              `json:"previous_amount_minor"`
+		Items               []orderUpdatedItem `json:"items"`
+	}
+	if err := json.Unmarshal(msgs[0].Payload, &payload); err != nil {
+		t.Fatalf("decode payload: %v", err)
+	}
+	if payload.Version != 1 || payload.AmountMinor != 1750 || payload.PreviousAmountMinor != 1300 || len(payload.Items) != 2 {
+		t.Fatalf("unexpected payload: %+v", payload)
+	}
+
+	// Тот же состав — no-op: версия не растёт, событий нет.
+	again, err := service.UpdateOrder(context.Background(), &omsv1.UpdateOrderRequest{
+		OrderId:         "order-1",
+		ExpectedVersion: 1,
+		Items:           []*omsv1.OrderItem{{Id: resp.Order.Items[1].Id, Qty: 1}, {Id: "i-1", Qty: 3}},
+	})
+	if err != nil {
+		t.Fatalf("second UpdateOrder failed: %v", err)
+	}
+	if again.Order.Version != 1 {
+		t.Fatalf("expected no-op update, got version %d", again.Order.Version)
+	}
+	if events, _ := timeline.List("order-1"); len(events) != 1 {
+		t.Fatalf("expected no new timeline events, got %+v", events)
+	}
+}
+
+func TestOrderService_UpdateOrder_RepricesAdjustments(t *testing.T) {
+	pricing, err := domain.PriceOrder(1300, []domain.PriceAdjustment{{Type: domain.AdjustmentDiscount, Code: "WELCOME", FixedMinor: 400}})
+	if err != nil {
+		t.Fatalf("price order: %v", err)
+	}
+	orders := memory.NewOrderRepository()
+	order := seedTestOrder(t, orders, domain.OrderStatusPending)
+	order.Pricing, order.AmountMinor = pricing, pricing.TotalMinor
+	if err := orders.Save(order); err != nil {
+		t.Fatalf("save pricing: %v", err)
+	}
+	service := NewOrderService(orders, memory.NewTimelineRepository(), nil, saga.NewNoop(nil), nil)
+	defer service.Shutdown(context.Background())
+
+	resp, err := service.UpdateOrder(context.Background(), &omsv1.UpdateOrderRequest{
+		OrderId:         "order-1",
+		ExpectedVersion: 1,
+		Items:           []*omsv1.OrderItem{{Id: "i-1", Qty: 1}},
+	})
+	if err != nil {
+		t.Fatalf("UpdateOrder failed: %v", err)
+	}
+	if resp.Order.Amount.AmountMinor != 100 || resp.Order.Amounts.Subtotal.AmountMinor != 500 || len(resp.Order.Items) != 1 {
+		t.Fatalf("expected discount kept on new subtotal, got %v", resp.Order)
+	}
+
+	// Скидка больше нового subtotal — заказ не меняется.
+	_, err = service.UpdateOrder(context.Background(), &omsv1.UpdateOrderRequest{
+		OrderId:         "order-1",
+		ExpectedVersion: 2,
+		Items:           []*omsv1.OrderItem{{Sku: "SKU-9", Qty: 1, Price: &omsv1.Money{Currency: "USD", AmountMinor: 10}}},
+	})
+	if status.Code(err) != codes.InvalidArgument {
+		t.Fatalf("expected InvalidArgument for discount above subtotal, got %v", err)
+	}
+	if stored, _ := orders.Get("order-1"); stored.Version != 2 || stored.AmountMinor != 100 {
+		t.Fatalf("rejected update must not change the order: %+v", stored)
+	}
+}
+
+func TestOrderService_UpdateOrder_Errors(t *testing.T) {
+	ctx := context.Background()
+	orders := memory.NewOrderRepository()
+	seedTestOrder(t, orders, domain.OrderStatusPending)
+	service := NewOrderService(orders, memory.NewTimelineRepository(), nil, saga.NewNoop(nil), nil)
+	defer service.Shutdown(context.Background())
+	keep := []*omsv1.OrderItem{{Id: "i-1", Qty: 2}}
+
+	cases := map[string]struct {
+		req  *omsv1.UpdateOrderRequest
+		code codes.Code
+	}{
+		"missing order id":   {&omsv1.UpdateOrderRequest{Items: keep}, codes.InvalidArgument},
+		"empty items":        {&omsv1.UpdateOrderRequest{OrderId: "order-1"}, codes.InvalidArgument},
+		"unknown order":      {&omsv1.UpdateOrderRequest{OrderId: "order-2", Items: keep}, codes.NotFound},
+		"stale version":      {&omsv1.UpdateOrderRequest{OrderId: "order-1", ExpectedVersion: 3, Items: keep}, codes.Aborted},
+		"zero qty":           {&omsv1.UpdateOrderRequest{OrderId: "order-1", Items: []*omsv1.OrderItem{{Id: "i-1"}}}, codes.InvalidArgument},
+		"foreign item id":    {&omsv1.UpdateOrderRequest{OrderId: "order-1", Items: []*omsv1.OrderItem{{Id: "i-9", Qty: 1}}}, codes.InvalidArgument},
+		"duplicated item id": {&omsv1.UpdateOrderRequest{OrderId: "order-1", Items: []*omsv1.OrderItem{{Id: "i-1", Qty: 1}, {Id: "i-1", Qty: 2}}}, codes.InvalidArgument},
+		"new item without price": {&omsv1.UpdateOrderRequest{OrderId: "order-1", Items: []*omsv1.OrderItem{{Sku: "SKU-3", Qty: 1}}},
+			codes.InvalidArgument},
+		"new item in other currency": {&omsv1.UpdateOrderRequest{OrderId: "order-1", Items: []*omsv1.OrderItem{
+			{Sku: "SKU-3", Qty: 1, Price: &omsv1.Money{Currency: "EUR", AmountMinor: 10}}}}, codes.InvalidArgument},
+	}
+	for name, tc := range cases {
+		if _, err := service.UpdateOrder(ctx, tc.req); status.Code(err) != tc.code {
+			t.Fatalf("%s: expected %s, got %v", name, tc.code, err)
+		}
+	}
+
+	paidOrders := memory.NewOrderRepository()
+	seedTestOrder(t, paidOrders, domain.OrderStatusPaid)
+	paid := NewOrderService(paidOrders, memory.NewTimelineRepository(), nil, saga.NewNoop(nil), nil)
+	defer paid.Shutdown(context.Background())
+	if _, err := paid.UpdateOrder(ctx, &omsv1.UpdateOrderRequest{OrderId: "order-1", Items: keep}); status.Code(err) != codes.FailedPrecondition {
+		t.Fatalf("expected FailedPrecondition for paid order, got %v", err)
+	}
+}
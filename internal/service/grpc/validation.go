@@ -0,0 +1,57 @@
+package grpcsvc
+
+import (
+	"context"
+	"errors"
+	"strings"
+
+	"buf.build/go/protovalidate"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+)
+
+// UnaryValidationInterceptor проверяет запрос по правилам buf.validate из proto до вызова
+// обработчика. Нарушения возвращаются одним InvalidArgument: в сообщении — все поля через "; ",
+// в details — google.rpc.BadRequest с путём поля для каждого нарушения. Сообщения без правил
+// проходят без изменений. validator nil — protovalidate.GlobalValidator.
+func UnaryValidationInterceptor(validator protovalidate.Validator) grpc.UnaryServerInterceptor {
+	if validator == nil {
+		validator = protovalidate.GlobalValidator
+	}
+
+	return func(ctx context.Context, req any, _ *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+		if msg, ok := req.(proto.Message); ok {
+			if err := validator.Validate(msg); err != nil {
+				return nil, validationErrorToStatus(err)
+			}
+		}
+		return handler(ctx, req)
+	}
+}
+
+func validationErrorToStatus(err error) error {
+	var verr *protovalidate.ValidationError
+	if !errors.As(err, &verr) {
+		// Ошибка компиляции или выполнения правил — дефект proto, а не запроса клиента.
+		return status.Error(codes.Internal, "request validation failed")
+	}
+
+	messages := make([]string, 0, len(verr.Violations))
+	badRequest := &errdetails.BadRequest{FieldViolations: make([]*errdetails.BadRequest_FieldViolation, 0, len(verr.Violations))}
+	for _, violation := range verr.Violations {
+		messages = append(messages, violation.String())
+		badRequest.FieldViolations = append(badRequest.FieldViolations, &errdetails.BadRequest_FieldViolation{
+			Field:       protovalidate.FieldPathString(violation.Proto.GetField()),
+			Description: violation.Proto.GetMessage(),
+		})
+	}
+
+	st := status.New(codes.InvalidArgument, strings.Join(messages, "; "))
+	if detailed, err := st.WithDetails(badRequest); err == nil {
+		st = detailed
+	}
+	return st.Err()
+}
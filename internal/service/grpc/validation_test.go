@@ -0,0 +1,89 @@
+package grpcsvc
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+
+	omsv1 "github.com/vladislavdragonenkov/oms/proto/oms/v1"
+)
+
+func TestUnaryValidationInterceptor(t *testing.T) {
+	interceptor := UnaryValidationInterceptor(nil)
+	info := &grpc.UnaryServerInfo{FullMethod: grpcMethodCreateOrder}
+	item := func(qty int32, price *omsv1.Money) []*omsv1.OrderItem {
+		return []*omsv1.OrderItem{{Sku: "sku-1", Qty: qty, Price: price}}
+	}
+	usd := &omsv1.Money{Currency: "USD", AmountMinor: 100}
+
+	tests := []struct {
+		name  string
+		req   proto.Message
+		field string // путь поля первого нарушения в BadRequest
+	}{
+		{name: "customer required", req: &omsv1.CreateOrderRequest{Currency: "USD", Items: item(1, usd)}, field: "customer_id"},
+		{name: "customer malformed", req: &omsv1.CreateOrderRequest{CustomerId: "customer 1", Currency: "USD", Items: item(1, usd)}, field: "customer_id"},
+		{name: "currency required", req: &omsv1.CreateOrderRequest{CustomerId: "c", Items: item(1, usd)}, field: "currency"},
+		{name: "items required", req: &omsv1.CreateOrderRequest{CustomerId: "c", Currency: "USD"}, field: "items"},
+		{name: "price required", req: &omsv1.CreateOrderRequest{CustomerId: "c", Currency: "USD", Items: item(1, nil)}, field: "items[0]"},
+		{name: "qty invalid", req: &omsv1.CreateOrderRequest{CustomerId: "c", Currency: "USD", Items: item(0, usd)}, field: "items[0]"},
+		{name: "price invalid", req: &omsv1.CreateOrderRequest{CustomerId: "c", Currency: "USD", Items: item(1, &omsv1.Money{Currency: "USD", AmountMinor: -1})}, field: "items[0]"},
+		{name: "currency mismatch", req: &omsv1.CreateOrderRequest{CustomerId: "c", Currency: "USD", Items: item(1, &omsv1.Money{Currency: "EUR", AmountMinor: 1})}},
+		{name: "get order id required", req: &omsv1.GetOrderRequest{}, field: "order_id"},
+		{name: "list customer malformed", req: &omsv1.ListOrdersRequest{CustomerId: "customer\t1"}, field: "customer_id"},
+		{name: "pay order id too long", req: &omsv1.PayOrderRequest{OrderId: strings.Repeat("o", 129)}, field: "order_id"},
+		{name: "cancel order id malformed", req: &omsv1.CancelOrderRequest{OrderId: "order-1' OR '1'='1"}, field: "order_id"},
+		{name: "refund amount negative", req: &omsv1.RefundOrderRequest{OrderId: "order-1", Amount: &omsv1.Money{AmountMinor: -5}}, field: "amount"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			called := false
+			_, err := interceptor(context.Background(), tt.req, info, func(context.Context, any) (any, error) {
+				called = true
+				return nil, nil
+			})
+			if called {
+				t.Fatal("handler must not be called for an invalid request")
+			}
+			mustStatusCode(t, err, codes.InvalidArgument)
+
+			var badRequest *errdetails.BadRequest
+			for _, detail := range status.Convert(err).Details() {
+				if br, ok := detail.(*errdetails.BadRequest); ok {
+					badRequest = br
+				}
+			}
+			if badRequest == nil || len(badRequest.FieldViolations) == 0 {
+				t.Fatalf("expected BadRequest details, got %v", err)
+			}
+			if got := badRequest.FieldViolations[0].Field; got != tt.field {
+				t.Fatalf("violation field: got %q, want %q (%v)", got, tt.field, err)
+			}
+		})
+	}
+}
+
+func TestUnaryValidationInterceptor_PassesValidRequests(t *testing.T) {
+	interceptor := UnaryValidationInterceptor(nil)
+	info := &grpc.UnaryServerInfo{FullMethod: grpcMethodCreateOrder}
+	for _, req := range []any{
+		validCreateRequest(),
+		&omsv1.RefundOrderRequest{OrderId: "order-1"},
+		&omsv1.ListOrdersRequest{CustomerId: "customer-1", PageSize: -1}, // незаданный размер страницы — Default
+		"not a proto message",
+	} {
+		resp, err := interceptor(context.Background(), req, info, func(_ context.Context, req any) (any, error) {
+			return req, nil
+		})
+		if err != nil || resp != req {
+			t.Fatalf("request %v: expected handler call, got resp=%v err=%v", req, resp, err)
+		}
+	}
+}
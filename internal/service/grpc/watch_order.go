@@ -0,0 +1,68 @@
+package grpcsvc
+
+import (
+	"errors"
+
+	log "github.com/sirupsen/logrus"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/vladislavdragonenkov/oms/internal/notify"
+	omsv1 "github.com/vladislavdragonenkov/oms/proto/oms/v1"
+)
+
+// WatchOrder отправляет снимок заказа при подписке, затем заказ, перечитанный после каждого
+// события его timeline, до отмены клиентом. Как и StreamOrderTimeline, работает через
+// TimelineWatcher; отстающий клиент отключается с codes.Aborted.
+func (s *OrderService) WatchOrder(req *omsv1.WatchOrderRequest, stream omsv1.OrderService_WatchOrderServer) error {
+	orderID, err := parseOrderIDArg(req.GetOrderId())
+	if err != nil {
+		return err
+	}
+	if s.watcher == nil {
+		return status.Error(codes.Unimplemented, "order watching is not configured")
+	}
+
+	// Подписка раньше снимка: событие между ними не теряется, а лишь ещё раз перечитывает заказ.
+	watch, err := s.watcher.Watch(orderID.String(), false)
+	if err != nil {
+		s.logger.WithError(err).WithField("order_id", orderID).Error("failed to watch order")
+		return status.Error(codes.Internal, "failed to watch order")
+	}
+	defer watch.Close()
+
+	order, err := s.loadOrder(orderID.String(), "WatchOrder")
+	if err != nil {
+		return err
+	}
+	if err := stream.Send(&omsv1.WatchOrderResponse{Order: toProtoOrder(order)}); err != nil {
+		return err
+	}
+
+	ctx := stream.Context()
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case event, ok := <-watch.Events():
+			if !ok {
+				if errors.Is(watch.Err(), notify.ErrSubscriberLagged) {
+					s.logger.WithField("order_id", orderID).Warn("order watch subscriber lagged")
+					return status.Error(codes.Aborted, "order watch lagged, resubscribe")
+				}
+				return nil
+			}
+			order, err := s.loadOrder(orderID.String(), "WatchOrder")
+			if err != nil {
+				return err
+			}
+			if err := stream.Send(&omsv1.WatchOrderResponse{Order: toProtoOrder(order), Event: toProtoTimelineEvent(event)}); err != nil {
+				s.logger.WithError(err).WithFields(log.Fields{
+					"order_id": orderID,
+					"event":    event.Type,
+				}).Debug("order watch closed by client")
+				return err
+			}
+		}
+	}
+}
@@ -0,0 +1,75 @@
+package grpcsvc_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/vladislavdragonenkov/oms/internal/domain"
+	"github.com/vladislavdragonenkov/oms/internal/notify"
+	grpcsvc "github.com/vladislavdragonenkov/oms/internal/service/grpc"
+	"github.com/vladislavdragonenkov/oms/internal/storage/memory"
+	omsv1 "github.com/vladislavdragonenkov/oms/proto/oms/v1"
+)
+
+func TestOrderService_WatchOrder_SnapshotThenChanges(t *testing.T) {
+	repo := memory.NewOrderRepository()
+	require.NoError(t, repo.Create(domain.Order{ID: "order-1", CustomerID: "customer-1", Status: domain.OrderStatusPending, Currency: "USD"}))
+	timeline := notify.NewTimelineRepository(memory.NewTimelineRepository(), notify.NewHub[domain.TimelineEvent]())
+
+	service := grpcsvc.NewOrderService(repo, timeline, memory.NewIdempotencyRepository(), nil, loggerForTests(), grpcsvc.WithTimelineWatcher(timeline))
+	client := newTimelineStreamClient(t, service)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	stream, err := client.WatchOrder(ctx, &omsv1.WatchOrderRequest{OrderId: "order-1"})
+	require.NoError(t, err)
+
+	snapshot, err := stream.Recv()
+	require.NoError(t, err)
+	require.Nil(t, snapshot.Event)
+	require.Equal(t, omsv1.OrderStatus_ORDER_STATUS_PENDING, snapshot.Order.Status)
+
+	// Снимок отправлен после подписки: смена статуса саги дойдёт до стрима.
+	order, err := repo.Get("order-1")
+	require.NoError(t, err)
+	_, err = repo.UpdateStatusCAS(order.ID, order.Status, domain.OrderStatusReserved, order.Version)
+	require.NoError(t, err)
+	require.NoError(t, timeline.Append(domain.TimelineEvent{OrderID: "order-1", Type: "OrderStatusChanged"}))
+
+	update, err := stream.Recv()
+	require.NoError(t, err)
+	require.Equal(t, "OrderStatusChanged", update.Event.Type)
+	require.Equal(t, omsv1.OrderStatus_ORDER_STATUS_RESERVED, update.Order.Status)
+}
+
+func TestOrderService_WatchOrder_Errors(t *testing.T) {
+	repo := memory.NewOrderRepository()
+	timeline := notify.NewTimelineRepository(memory.NewTimelineRepository(), notify.NewHub[domain.TimelineEvent]())
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	withoutWatcher := newTimelineStreamClient(t, grpcsvc.NewOrderService(repo, timeline, memory.NewIdempotencyRepository(), nil, loggerForTests()))
+	stream, err := withoutWatcher.WatchOrder(ctx, &omsv1.WatchOrderRequest{OrderId: "order-1"})
+	require.NoError(t, err)
+	_, err = stream.Recv()
+	require.Equal(t, codes.Unimplemented, status.Code(err))
+
+	client := newTimelineStreamClient(t, grpcsvc.NewOrderService(repo, timeline, memory.NewIdempotencyRepository(), nil, loggerForTests(), grpcsvc.WithTimelineWatcher(timeline)))
+	for name, tc := range map[string]struct {
+		req  *omsv1.WatchOrderRequest
+		code codes.Code
+	}{
+		"empty order id": {req: &omsv1.WatchOrderRequest{}, code: codes.InvalidArgument},
+		"missing order":  {req: &omsv1.WatchOrderRequest{OrderId: "missing"}, code: codes.NotFound},
+	} {
+		stream, err := client.WatchOrder(ctx, tc.req)
+		require.NoError(t, err, name)
+		_, err = stream.Recv()
+		require.Equal(t, tc.code, status.Code(err), name)
+	}
+}
@@ -2,14 +2,17 @@ package idempotency
 
 import (
 	"context"
+	"encoding/json"
 	"errors"
+	"fmt"
 	"time"
 
 	"github.com/prometheus/client_golang/prometheus"
-	"github.com/prometheus/client_golang/prometheus/promauto"
 	log "github.com/sirupsen/logrus"
+	"google.golang.org/grpc/codes"
 
 	"github.com/vladislavdragonenkov/oms/internal/domain"
+	"github.com/vladislavdragonenkov/oms/internal/metrics"
 )
 
 const (
@@ -17,26 +20,44 @@ const (
 	defaultCleanupBatchSize = 500
 )
 
-var (
-	idempotencyCleanupRunsTotal = promauto.NewCounterVec(prometheus.CounterOpts{
-		Name: "oms_idempotency_cleanup_runs_total",
-		Help: "Total number of idempotency cleanup runs grouped by result.",
-	}, []string{"result"})
-	idempotencyCleanupDeletedTotal = promauto.NewCounter(prometheus.CounterOpts{
-		Name: "oms_idempotency_cleanup_deleted_total",
-		Help: "Total number of deleted expired idempotency records.",
-	})
-	idempotencyCleanupLastDeleted = promauto.NewGauge(prometheus.GaugeOpts{
-		Name: "oms_idempotency_cleanup_last_deleted",
-		Help: "Number of deleted records during the last cleanup run.",
-	})
-)
+// cleanupMetrics — метрики очистки idempotency ключей.
+type cleanupMetrics struct {
+	runsTotal                  *prometheus.CounterVec
+	deletedTotal               prometheus.Counter
+	lastDeleted                prometheus.Gauge
+	staleProcessingFailedTotal *prometheus.CounterVec
+}
+
+func newCleanupMetrics(registerer prometheus.Registerer) cleanupMetrics {
+	return cleanupMetrics{
+		runsTotal: metrics.Register(registerer, prometheus.NewCounterVec(prometheus.CounterOpts{
+			Name: "oms_idempotency_cleanup_runs_total",
+			Help: "Total number of idempotency cleanup runs grouped by result.",
+		}, []string{"result"})),
+		deletedTotal: metrics.Register(registerer, prometheus.NewCounter(prometheus.CounterOpts{
+			Name: "oms_idempotency_cleanup_deleted_total",
+			Help: "Total number of deleted expired idempotency records.",
+		})),
+		lastDeleted: metrics.Register(registerer, prometheus.NewGauge(prometheus.GaugeOpts{
+			Name: "oms_idempotency_cleanup_last_deleted",
+			Help: "Number of deleted records during the last cleanup run.",
+		})),
+		staleProcessingFailedTotal: metrics.Register(registerer, prometheus.NewCounterVec(prometheus.CounterOpts{
+			Name: "oms_idempotency_stale_processing_failed_total",
+			Help: "Total number of idempotency records marked failed after being stuck in processing status grouped by method.",
+		}, []string{"method"})),
+	}
+}
 
 // CleanupOptions задает параметры воркера очистки idempotency ключей.
 type CleanupOptions struct {
 	Logger    *log.Entry
 	Interval  time.Duration
 	BatchSize int
+	// StaleProcessingAfter — через сколько ключ в processing считается зависшим и переводится
+	// в failed; 0 отключает sweeper.
+	StaleProcessingAfter time.Duration
+	Registerer           prometheus.Registerer
 }
 
 // CleanupOption настраивает CleanupWorker.
@@ -63,12 +84,28 @@ func WithBatchSize(batchSize int) CleanupOption {
 	}
 }
 
+// WithStaleProcessingAfter включает перевод в failed ключей, зависших в processing дольше after.
+func WithStaleProcessingAfter(after time.Duration) CleanupOption {
+	return func(opts *CleanupOptions) {
+		opts.StaleProcessingAfter = after
+	}
+}
+
+// WithRegisterer задает реестр метрик воркера.
+func WithRegisterer(registerer prometheus.Registerer) CleanupOption {
+	return func(opts *CleanupOptions) {
+		opts.Registerer = registerer
+	}
+}
+
 // CleanupWorker периодически удаляет просроченные idempotency записи.
 type CleanupWorker struct {
-	repo      domain.IdempotencyRepository
-	logger    *log.Entry
-	interval  time.Duration
-	batchSize int
+	repo       domain.IdempotencyRepository
+	logger     *log.Entry
+	interval   time.Duration
+	batchSize  int
+	staleAfter time.Duration
+	metrics    cleanupMetrics
 }
 
 // NewCleanupWorker создает воркер очистки idempotency ключей.
@@ -93,11 +130,17 @@ func NewCleanupWorker(repo domain.IdempotencyRepository, options ...CleanupOptio
 		opts.BatchSize = defaultCleanupBatchSize
 	}
 
+	if opts.StaleProcessingAfter < 0 {
+		opts.StaleProcessingAfter = 0
+	}
+
 	return &CleanupWorker{
-		repo:      repo,
-		logger:    logger,
-		interval:  opts.Interval,
-		batchSize: opts.BatchSize,
+		repo:       repo,
+		logger:     logger,
+		interval:   opts.Interval,
+		batchSize:  opts.BatchSize,
+		staleAfter: opts.StaleProcessingAfter,
+		metrics:    newCleanupMetrics(opts.Registerer),
 	}
 }
 
@@ -129,13 +172,24 @@ func (w *CleanupWorker) cleanup(ctx context.Context, before time.Time) {
 		if errors.Is(err, context.Canceled) {
 			return
 		}
-		idempotencyCleanupRunsTotal.WithLabelValues("error").Inc()
+		w.metrics.runsTotal.WithLabelValues("error").Inc()
 		w.logger.WithError(err).Warn("idempotency cleanup run failed")
 		return
 	}
 
-	idempotencyCleanupRunsTotal.WithLabelValues("ok").Inc()
-	idempotencyCleanupLastDeleted.Set(float64(deleted))
+	if w.staleAfter > 0 {
+		if _, err := w.FailStaleProcessing(ctx, before.Add(-w.staleAfter)); err != nil {
+			if errors.Is(err, context.Canceled) {
+				return
+			}
+			w.metrics.runsTotal.WithLabelValues("error").Inc()
+			w.logger.WithError(err).Warn("idempotency stale processing sweep failed")
+			return
+		}
+	}
+
+	w.metrics.runsTotal.WithLabelValues("ok").Inc()
+	w.metrics.lastDeleted.Set(float64(deleted))
 	if deleted > 0 {
 		w.logger.WithField("deleted", deleted).Info("idempotency cleanup completed")
 	}
@@ -160,7 +214,7 @@ func (w *CleanupWorker) DeleteExpired(ctx context.Context, before time.Time) (in
 
 		totalDeleted += deleted
 		if deleted > 0 {
-			idempotencyCleanupDeletedTotal.Add(float64(deleted))
+			w.metrics.deletedTotal.Add(float64(deleted))
 		}
 
 		if deleted < w.batchSize {
@@ -170,3 +224,55 @@ func (w *CleanupWorker) DeleteExpired(ctx context.Context, before time.Time) (in
 
 	return totalDeleted, nil
 }
+
+// staleProcessingPayload — ответ, который получат повторы запроса с зависшим ключом; поля code и message
+// совпадают с ошибками, которые сохраняет gRPC-сервис.
+type staleProcessingPayload struct {
+	Code    int32  `json:"code"`
+	Message string `json:"message"`
+	Reason  string `json:"reason"`
+}
+
+// FailStaleProcessing переводит в failed ключи в processing, не обновлявшиеся с момента before, порциями
+// batchSize. Исход прерванного запроса неизвестен (заказ мог успеть измениться), поэтому повтор не
+// выполняется заново, а получает Aborted: клиент проверяет состояние заказа и повторяет с новым ключом.
+// Каждый ключ пишется в лог для сверки оператором.
+func (w *CleanupWorker) FailStaleProcessing(ctx context.Context, before time.Time) (int, error) {
+	payload, err := json.Marshal(staleProcessingPayload{
+		Code:    int32(codes.Aborted),
+		Message: fmt.Sprintf("previous request with the same idempotency key was interrupted (processing for more than %s); check the order state and retry with a new idempotency key", w.staleAfter),
+		Reason:  domain.IdempotencyReasonStaleProcessing,
+	})
+	if err != nil {
+		return 0, fmt.Errorf("encode stale processing payload: %w", err)
+	}
+
+	totalFailed := 0
+	for {
+		if err := ctx.Err(); err != nil {
+			return totalFailed, err
+		}
+
+		failed, err := w.repo.FailStaleProcessing(before, w.batchSize, payload, int(codes.Aborted))
+		if err != nil {
+			return totalFailed, err
+		}
+
+		totalFailed += len(failed)
+		for _, record := range failed {
+			w.metrics.staleProcessingFailedTotal.WithLabelValues(record.Method).Inc()
+			w.logger.WithFields(log.Fields{
+				"method":           record.Method,
+				"customer_id":      record.CustomerID,
+				"idempotency_key":  record.Key,
+				"processing_since": record.CreatedAt,
+			}).Warn("idempotency key stuck in processing marked failed")
+		}
+
+		if len(failed) < w.batchSize {
+			break
+		}
+	}
+
+	return totalFailed, nil
+}
@@ -3,13 +3,16 @@ package idempotency
 import (
 	"context"
 	"errors"
+	"fmt"
 	"sync"
 	"testing"
 	"time"
 
+	"github.com/prometheus/client_golang/prometheus"
 	log "github.com/sirupsen/logrus"
 
 	"github.com/vladislavdragonenkov/oms/internal/domain"
+	"github.com/vladislavdragonenkov/oms/internal/metrics/metricstest"
 )
 
 var _ domain.IdempotencyRepository = (*stubCleanupRepo)(nil)
@@ -173,27 +176,55 @@ func TestCleanupWorker_DeleteExpired_ContextCanceled(t *testing.T) {
 	}
 }
 
+func TestCleanupWorker_Cleanup_SweepsStaleProcessing(t *testing.T) {
+	t.Parallel()
+
+	repo := &stubCleanupRepo{staleResults: []int{2, 1}}
+	registry := prometheus.NewRegistry()
+	worker := NewCleanupWorker(repo, WithBatchSize(2), WithStaleProcessingAfter(10*time.Minute), WithRegisterer(registry))
+
+	now := time.Now().UTC()
+	worker.cleanup(context.Background(), now)
+
+	if len(repo.staleBefore) != 2 {
+		t.Fatalf("expected 2 stale sweep batches, got %d", len(repo.staleBefore))
+	}
+	if want := now.Add(-10 * time.Minute); !repo.staleBefore[0].Equal(want) {
+		t.Fatalf("unexpected stale threshold: got %s want %s", repo.staleBefore[0], want)
+	}
+	metricstest.RequireValue(t, registry, "oms_idempotency_stale_processing_failed_total", metricstest.Labels{"method": "CreateOrder"}, 3)
+
+	disabledRepo := &stubCleanupRepo{}
+	NewCleanupWorker(disabledRepo).cleanup(context.Background(), now)
+	if len(disabledRepo.staleBefore) != 0 {
+		t.Fatal("stale sweep must be disabled by default")
+	}
+}
+
 type stubCleanupRepo struct {
 	mu sync.Mutex
 
 	deleteResults []int
 	deleteErrors  []error
 	callCount     int
+
+	staleResults []int
+	staleBefore  []time.Time
 }
 
-func (s *stubCleanupRepo) CreateProcessing(string, string, time.Time) (domain.IdempotencyRecord, error) {
+func (s *stubCleanupRepo) CreateProcessing(domain.IdempotencyScope, string, time.Time) (domain.IdempotencyRecord, error) {
 	panic("not implemented")
 }
 
-func (s *stubCleanupRepo) Get(string) (domain.IdempotencyRecord, error) {
+func (s *stubCleanupRepo) Get(domain.IdempotencyScope) (domain.IdempotencyRecord, error) {
 	panic("not implemented")
 }
 
-func (s *stubCleanupRepo) MarkDone(string, []byte, int) error {
+func (s *stubCleanupRepo) MarkDone(domain.IdempotencyScope, []byte, int) error {
 	panic("not implemented")
 }
 
-func (s *stubCleanupRepo) MarkFailed(string, []byte, int) error {
+func (s *stubCleanupRepo) MarkFailed(domain.IdempotencyScope, []byte, int) error {
 	panic("not implemented")
 }
 
@@ -219,6 +250,22 @@ func (s *stubCleanupRepo) DeleteExpired(_ time.Time, _ int) (int, error) {
 	return result, nil
 }
 
+func (s *stubCleanupRepo) FailStaleProcessing(before time.Time, _ int, _ []byte, _ int) ([]domain.IdempotencyRecord, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.staleBefore = append(s.staleBefore, before)
+	if len(s.staleResults) == 0 {
+		return nil, nil
+	}
+	result := make([]domain.IdempotencyRecord, s.staleResults[0])
+	for i := range result {
+		result[i] = domain.IdempotencyRecord{Method: "CreateOrder", Key: fmt.Sprintf("stale-%d", i)}
+	}
+	s.staleResults = s.staleResults[1:]
+	return result, nil
+}
+
 func (s *stubCleanupRepo) calls() int {
 	s.mu.Lock()
 	defer s.mu.Unlock()
@@ -0,0 +1,77 @@
+package idempotency
+
+import (
+	"errors"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+
+	"github.com/vladislavdragonenkov/oms/internal/domain"
+	"github.com/vladislavdragonenkov/oms/internal/metrics"
+)
+
+// Результаты CreateProcessing в oms_idempotency_store_operations_total.
+const (
+	resultCreated  = "created"
+	resultExists   = "exists"
+	resultMismatch = "mismatch"
+	resultOK       = "ok"
+	resultError    = "error"
+)
+
+type instrumentedRepository struct {
+	domain.IdempotencyRepository
+	operations *prometheus.CounterVec
+}
+
+// InstrumentRepository оборачивает repo счётчиком oms_idempotency_store_operations_total{operation,result}.
+// Для CreateProcessing result различает новый ключ (created), повтор (exists) и повтор с другим
+// payload (mismatch) — так видно долю повторов на уровне хранилища, в том числе вне gRPC.
+// nil-репозиторий возвращается как есть: идемпотентность остаётся выключенной.
+func InstrumentRepository(repo domain.IdempotencyRepository, registerer prometheus.Registerer) domain.IdempotencyRepository {
+	if repo == nil {
+		return nil
+	}
+	return &instrumentedRepository{
+		IdempotencyRepository: repo,
+		operations: metrics.Register(registerer, prometheus.NewCounterVec(prometheus.CounterOpts{
+			Name: "oms_idempotency_store_operations_total",
+			Help: "Total number of idempotency store operations grouped by operation and result.",
+		}, []string{"operation", "result"})),
+	}
+}
+
+func (r *instrumentedRepository) CreateProcessing(scope domain.IdempotencyScope, requestHash string, ttlAt time.Time) (domain.IdempotencyRecord, error) {
+	record, err := r.IdempotencyRepository.CreateProcessing(scope, requestHash, ttlAt)
+	result := resultCreated
+	switch {
+	case errors.Is(err, domain.ErrIdempotencyHashMismatch):
+		result = resultMismatch
+	case errors.Is(err, domain.ErrIdempotencyKeyAlreadyExists):
+		result = resultExists
+	case err != nil:
+		result = resultError
+	}
+	r.operations.WithLabelValues("create_processing", result).Inc()
+	return record, err
+}
+
+func (r *instrumentedRepository) MarkDone(scope domain.IdempotencyScope, responseBody []byte, httpStatus int) error {
+	err := r.IdempotencyRepository.MarkDone(scope, responseBody, httpStatus)
+	r.observe("mark_done", err)
+	return err
+}
+
+func (r *instrumentedRepository) MarkFailed(scope domain.IdempotencyScope, responseBody []byte, httpStatus int) error {
+	err := r.IdempotencyRepository.MarkFailed(scope, responseBody, httpStatus)
+	r.observe("mark_failed", err)
+	return err
+}
+
+func (r *instrumentedRepository) observe(operation string, err error) {
+	result := resultOK
+	if err != nil {
+		result = resultError
+	}
+	r.operations.WithLabelValues(operation, result).Inc()
+}
@@ -0,0 +1,50 @@
+package idempotency
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+
+	"github.com/vladislavdragonenkov/oms/internal/domain"
+	"github.com/vladislavdragonenkov/oms/internal/storage/memory"
+)
+
+func TestInstrumentRepository(t *testing.T) {
+	if InstrumentRepository(nil, prometheus.NewRegistry()) != nil {
+		t.Fatal("nil repository must stay nil")
+	}
+
+	repo := InstrumentRepository(memory.NewIdempotencyRepository(), prometheus.NewRegistry())
+	ttl := time.Now().Add(time.Hour)
+	if _, err := repo.CreateProcessing(domain.IdempotencyScope{Key: "key-1"}, "hash-1", ttl); err != nil {
+		t.Fatalf("create: %v", err)
+	}
+	if _, err := repo.CreateProcessing(domain.IdempotencyScope{Key: "key-1"}, "hash-1", ttl); !errors.Is(err, domain.ErrIdempotencyKeyAlreadyExists) {
+		t.Fatalf("expected ErrIdempotencyKeyAlreadyExists, got %v", err)
+	}
+	if _, err := repo.CreateProcessing(domain.IdempotencyScope{Key: "key-1"}, "hash-2", ttl); !errors.Is(err, domain.ErrIdempotencyHashMismatch) {
+		t.Fatalf("expected ErrIdempotencyHashMismatch, got %v", err)
+	}
+	if err := repo.MarkDone(domain.IdempotencyScope{Key: "key-1"}, []byte(`{}`), 0); err != nil {
+		t.Fatalf("mark done: %v", err)
+	}
+	_ = repo.MarkFailed(domain.IdempotencyScope{Key: "missing"}, nil, 13)
+
+	operations := repo.(*instrumentedRepository).operations
+	for labels, want := range map[[2]string]float64{
+		{"create_processing", resultCreated}:  1,
+		{"create_processing", resultExists}:   1,
+		{"create_processing", resultMismatch}: 1,
+		{"mark_done", resultOK}:               1,
+	} {
+		if got := testutil.ToFloat64(operations.WithLabelValues(labels[0], labels[1])); got != want {
+			t.Fatalf("%v: got %v, want %v", labels, got, want)
+		}
+	}
+	if got := testutil.ToFloat64(operations.WithLabelValues("mark_failed", resultOK)) + testutil.ToFloat64(operations.WithLabelValues("mark_failed", resultError)); got != 1 {
+		t.Fatalf("mark_failed must be counted once, got %v", got)
+	}
+}
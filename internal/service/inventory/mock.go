@@ -1,14 +1,27 @@
 package inventory
 
-import "github.com/vladislavdragonenkov/oms/internal/domain"
+import (
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/vladislavdragonenkov/oms/internal/domain"
+)
 
 // MockService — конфигурируемая заглушка InventoryService для тестов.
 type MockService struct {
 	ReserveErr error
 	ReleaseErr error
+	RestockErr error
 
 	ReserveCalls int
 	ReleaseCalls int
+	RestockCalls int
+	// Restocked — количество единиц, возвращённых в остатки, по SKU.
+	Restocked map[string]int32
+
+	mu           sync.Mutex
+	reservations map[string][]domain.Reservation
 }
 
 // NewMockService возвращает mock с успешным сценарием по умолчанию.
@@ -17,15 +30,88 @@ func NewMockService() *MockService {
 }
 
 // Reserve возвращает заранее настроенную ошибку и считает вызовы.
+// При успехе запоминает резерв, чтобы его можно было увидеть через ListReservations.
 func (m *MockService) Reserve(orderID string, items []domain.OrderItem) error {
 	m.ReserveCalls++
-	return m.ReserveErr
+	if m.ReserveErr != nil {
+		return m.ReserveErr
+	}
+
+	now := time.Now().UTC()
+	reservations := make([]domain.Reservation, 0, len(items))
+	for _, item := range items {
+		reservations = append(reservations, domain.Reservation{
+			ID:        orderID + ":" + item.SKU,
+			OrderID:   orderID,
+			SKU:       item.SKU,
+			Qty:       item.Qty,
+			Status:    domain.ReservationStatusReserved,
+			CreatedAt: now,
+			UpdatedAt: now,
+		})
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if m.reservations == nil {
+		m.reservations = make(map[string][]domain.Reservation)
+	}
+	m.reservations[orderID] = reservations
+	return nil
 }
 
 // Release возвращает заранее настроенную ошибку и считает вызовы.
 func (m *MockService) Release(orderID string, items []domain.OrderItem) error {
 	m.ReleaseCalls++
-	return m.ReleaseErr
+	if m.ReleaseErr != nil {
+		return m.ReleaseErr
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	delete(m.reservations, orderID)
+	return nil
+}
+
+// Restock возвращает заранее настроенную ошибку и считает вызовы; при успехе учитывает единицы в Restocked.
+func (m *MockService) Restock(orderID string, items []domain.OrderItem) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.RestockCalls++
+	if m.RestockErr != nil {
+		return m.RestockErr
+	}
+	if m.Restocked == nil {
+		m.Restocked = make(map[string]int32)
+	}
+	for _, item := range items {
+		m.Restocked[item.SKU] += item.Qty
+	}
+	return nil
+}
+
+// ListReservations возвращает активные резервы, упорядоченные по заказу и SKU.
+func (m *MockService) ListReservations() ([]domain.Reservation, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	result := make([]domain.Reservation, 0, len(m.reservations))
+	for _, reservations := range m.reservations {
+		result = append(result, reservations...)
+	}
+
+	sort.Slice(result, func(i, j int) bool {
+		if result[i].OrderID != result[j].OrderID {
+			return result[i].OrderID < result[j].OrderID
+		}
+		return result[i].SKU < result[j].SKU
+	})
+
+	return result, nil
 }
 
-var _ domain.InventoryService = (*MockService)(nil)
+var (
+	_ domain.InventoryService   = (*MockService)(nil)
+	_ domain.ReservationLister  = (*MockService)(nil)
+	_ domain.InventoryRestocker = (*MockService)(nil)
+)
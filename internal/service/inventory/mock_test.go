@@ -33,3 +33,54 @@ func TestMockService(t *testing.T) {
 		t.Fatal("expected release error")
 	}
 }
+
+func TestMockService_ListReservations(t *testing.T) {
+	mock := NewMockService()
+
+	items := []domain.OrderItem{{SKU: "SKU-B", Qty: 2}, {SKU: "SKU-A", Qty: 1}}
+	if err := mock.Reserve("o-1", items); err != nil {
+		t.Fatalf("unexpected reserve error: %v", err)
+	}
+
+	reservations, err := mock.ListReservations()
+	if err != nil {
+		t.Fatalf("unexpected list error: %v", err)
+	}
+	if len(reservations) != 2 || reservations[0].SKU != "SKU-A" || reservations[1].Qty != 2 {
+		t.Fatalf("unexpected reservations: %+v", reservations)
+	}
+	if reservations[0].Status != domain.ReservationStatusReserved {
+		t.Fatalf("unexpected reservation status: %s", reservations[0].Status)
+	}
+
+	if err := mock.Release("o-1", items); err != nil {
+		t.Fatalf("unexpected release error: %v", err)
+	}
+	reservations, _ = mock.ListReservations()
+	if len(reservations) != 0 {
+		t.Fatalf("expected no reservations after release, got %+v", reservations)
+	}
+}
+
+func TestMockService_Restock(t *testing.T) {
+	mock := NewMockService()
+
+	items := []domain.OrderItem{{SKU: "SKU-A", Qty: 2}, {SKU: "SKU-B", Qty: 1}}
+	if err := mock.Restock("o-1", items); err != nil {
+		t.Fatalf("unexpected restock error: %v", err)
+	}
+	if err := mock.Restock("o-2", items[:1]); err != nil {
+		t.Fatalf("unexpected restock error: %v", err)
+	}
+	if mock.RestockCalls != 2 || mock.Restocked["SKU-A"] != 4 || mock.Restocked["SKU-B"] != 1 {
+		t.Fatalf("unexpected restock state: calls=%d restocked=%v", mock.RestockCalls, mock.Restocked)
+	}
+
+	mock.RestockErr = errors.New("restock failed")
+	if err := mock.Restock("o-3", items); err == nil {
+		t.Fatal("expected restock error")
+	}
+	if mock.Restocked["SKU-A"] != 4 {
+		t.Fatalf("failed restock must not change stock, got %v", mock.Restocked)
+	}
+}
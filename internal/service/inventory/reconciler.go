@@ -0,0 +1,313 @@
+package inventory
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	log "github.com/sirupsen/logrus"
+
+	"github.com/vladislavdragonenkov/oms/internal/domain"
+	"github.com/vladislavdragonenkov/oms/internal/metrics"
+)
+
+const (
+	defaultReconcileInterval  = 5 * time.Minute
+	defaultReconcileBatchSize = 500
+)
+
+// reconcilerMetrics — метрики сверки резервов.
+type reconcilerMetrics struct {
+	runsTotal    *prometheus.CounterVec
+	orphansTotal *prometheus.CounterVec
+	missingTotal prometheus.Counter
+	lastOrphans  prometheus.Gauge
+	lastMissing  prometheus.Gauge
+}
+
+func newReconcilerMetrics(registerer prometheus.Registerer) reconcilerMetrics {
+	return reconcilerMetrics{
+		runsTotal: metrics.Register(registerer, prometheus.NewCounterVec(prometheus.CounterOpts{
+			Name: "oms_inventory_reconcile_runs_total",
+			Help: "Total number of inventory reconciliation runs grouped by result.",
+		}, []string{"result"})),
+		orphansTotal: metrics.Register(registerer, prometheus.NewCounterVec(prometheus.CounterOpts{
+			Name: "oms_inventory_reconcile_orphans_total",
+			Help: "Total number of orphaned reservations grouped by action (released, dry_run, error).",
+		}, []string{"action"})),
+		missingTotal: metrics.Register(registerer, prometheus.NewCounter(prometheus.CounterOpts{
+			Name: "oms_inventory_reconcile_missing_total",
+			Help: "Total number of reserved orders without an active inventory reservation.",
+		})),
+		lastOrphans: metrics.Register(registerer, prometheus.NewGauge(prometheus.GaugeOpts{
+			Name: "oms_inventory_reconcile_last_orphans",
+			Help: "Number of orphaned reservations found during the last reconciliation run.",
+		})),
+		lastMissing: metrics.Register(registerer, prometheus.NewGauge(prometheus.GaugeOpts{
+			Name: "oms_inventory_reconcile_last_missing",
+			Help: "Number of missing reservations found during the last reconciliation run.",
+		})),
+	}
+}
+
+// ReconcilerOptions задает параметры сверки резервов со статусами заказов.
+type ReconcilerOptions struct {
+	Logger     *log.Entry
+	Interval   time.Duration
+	BatchSize  int
+	DryRun     bool
+	Registerer prometheus.Registerer
+}
+
+// ReconcilerOption настраивает Reconciler.
+type ReconcilerOption func(*ReconcilerOptions)
+
+// WithLogger задает logger для сверки.
+func WithLogger(logger *log.Entry) ReconcilerOption {
+	return func(opts *ReconcilerOptions) {
+		opts.Logger = logger
+	}
+}
+
+// WithInterval задает интервал между циклами сверки.
+func WithInterval(interval time.Duration) ReconcilerOption {
+	return func(opts *ReconcilerOptions) {
+		opts.Interval = interval
+	}
+}
+
+// WithBatchSize ограничивает число заказов в статусе reserved, проверяемых за цикл.
+func WithBatchSize(batchSize int) ReconcilerOption {
+	return func(opts *ReconcilerOptions) {
+		opts.BatchSize = batchSize
+	}
+}
+
+// WithDryRun включает режим, в котором расхождения только логируются, а резервы не снимаются.
+func WithDryRun(dryRun bool) ReconcilerOption {
+	return func(opts *ReconcilerOptions) {
+		opts.DryRun = dryRun
+	}
+}
+
+// WithRegisterer задает реестр метрик сверки.
+func WithRegisterer(registerer prometheus.Registerer) ReconcilerOption {
+	return func(opts *ReconcilerOptions) {
+		opts.Registerer = registerer
+	}
+}
+
+// ReconcileResult описывает итог одного цикла сверки.
+type ReconcileResult struct {
+	// Orphaned — заказы, по которым найден живой резерв, хотя заказ отменён/возвращён или отсутствует.
+	Orphaned []string
+	// Released — сиротские резервы, которые удалось снять.
+	Released []string
+	// Missing — заказы в статусе reserved без активного резерва на складе.
+	Missing []string
+}
+
+// Reconciler периодически сверяет резервы склада со статусами заказов.
+type Reconciler struct {
+	orders       domain.OrderRepository
+	inventory    domain.InventoryService
+	reservations domain.ReservationLister
+	logger       *log.Entry
+	interval     time.Duration
+	batchSize    int
+	dryRun       bool
+	metrics      reconcilerMetrics
+}
+
+// NewReconciler создает воркер сверки резервов.
+func NewReconciler(
+	orders domain.OrderRepository,
+	inventory domain.InventoryService,
+	reservations domain.ReservationLister,
+	options ...ReconcilerOption,
+) *Reconciler {
+	opts := ReconcilerOptions{
+		Interval:  defaultReconcileInterval,
+		BatchSize: defaultReconcileBatchSize,
+	}
+	for _, option := range options {
+		option(&opts)
+	}
+
+	logger := opts.Logger
+	if logger == nil {
+		logger = log.WithField("component", "inventory-reconciler")
+	}
+
+	if opts.Interval <= 0 {
+		opts.Interval = defaultReconcileInterval
+	}
+	if opts.BatchSize <= 0 {
+		opts.BatchSize = defaultReconcileBatchSize
+	}
+
+	return &Reconciler{
+		orders:       orders,
+		inventory:    inventory,
+		reservations: reservations,
+		logger:       logger,
+		interval:     opts.Interval,
+		batchSize:    opts.BatchSize,
+		dryRun:       opts.DryRun,
+		metrics:      newReconcilerMetrics(opts.Registerer),
+	}
+}
+
+// Run запускает периодическую сверку до отмены ctx.
+func (r *Reconciler) Run(ctx context.Context) {
+	if r.orders == nil || r.inventory == nil || r.reservations == nil {
+		r.logger.Warn("inventory reconciler is disabled: dependencies are not configured")
+		return
+	}
+
+	r.runOnce(ctx)
+
+	ticker := time.NewTicker(r.interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			r.runOnce(ctx)
+		}
+	}
+}
+
+func (r *Reconciler) runOnce(ctx context.Context) {
+	result, err := r.Reconcile(ctx)
+	if err != nil {
+		if errors.Is(err, context.Canceled) {
+			return
+		}
+		r.metrics.runsTotal.WithLabelValues("error").Inc()
+		r.logger.WithError(err).Warn("inventory reconciliation run failed")
+		return
+	}
+
+	r.metrics.runsTotal.WithLabelValues("ok").Inc()
+	r.metrics.lastOrphans.Set(float64(len(result.Orphaned)))
+	r.metrics.lastMissing.Set(float64(len(result.Missing)))
+	if len(result.Orphaned) > 0 || len(result.Missing) > 0 {
+		r.logger.WithFields(log.Fields{
+			"orphaned": len(result.Orphaned),
+			"released": len(result.Released),
+			"missing":  len(result.Missing),
+			"dry_run":  r.dryRun,
+		}).Info("inventory reconciliation completed")
+	}
+}
+
+// Reconcile выполняет один цикл сверки.
+// Сначала читаются заказы в статусе reserved, затем снимок резервов: так резерв,
+// созданный до перевода заказа в reserved, гарантированно попадёт в снимок.
+func (r *Reconciler) Reconcile(ctx context.Context) (ReconcileResult, error) {
+	var result ReconcileResult
+
+	reserved, err := r.orders.ListByStatus(domain.OrderStatusReserved, r.batchSize)
+	if err != nil {
+		return result, fmt.Errorf("list reserved orders: %w", err)
+	}
+
+	reservations, err := r.reservations.ListReservations()
+	if err != nil {
+		return result, fmt.Errorf("list reservations: %w", err)
+	}
+
+	held := make(map[string][]domain.Reservation)
+	orderIDs := make([]string, 0)
+	for _, reservation := range reservations {
+		if reservation.Status != domain.ReservationStatusReserved {
+			continue
+		}
+		if _, seen := held[reservation.OrderID]; !seen {
+			orderIDs = append(orderIDs, reservation.OrderID)
+		}
+		held[reservation.OrderID] = append(held[reservation.OrderID], reservation)
+	}
+
+	for _, order := range reserved {
+		if _, ok := held[order.ID]; ok {
+			continue
+		}
+		// Sandbox-заказ резервирует через заглушку, на реальном складе его резерва нет.
+		if order.TestMode {
+			continue
+		}
+		result.Missing = append(result.Missing, order.ID)
+		r.metrics.missingTotal.Inc()
+		r.logger.WithField("order_id", order.ID).Warn("reserved order has no active inventory reservation")
+	}
+
+	for _, orderID := range orderIDs {
+		if err := ctx.Err(); err != nil {
+			return result, err
+		}
+
+		orphaned, err := r.isOrphaned(orderID)
+		if err != nil {
+			return result, err
+		}
+		if !orphaned {
+			continue
+		}
+
+		result.Orphaned = append(result.Orphaned, orderID)
+		entry := r.logger.WithField("order_id", orderID)
+
+		if r.dryRun {
+			r.metrics.orphansTotal.WithLabelValues("dry_run").Inc()
+			entry.Warn("orphaned inventory reservation found (dry-run, not released)")
+			continue
+		}
+
+		if err := r.inventory.Release(orderID, reservationItems(held[orderID])); err != nil {
+			r.metrics.orphansTotal.WithLabelValues("error").Inc()
+			entry.WithError(err).Warn("failed to release orphaned inventory reservation")
+			continue
+		}
+
+		r.metrics.orphansTotal.WithLabelValues("released").Inc()
+		result.Released = append(result.Released, orderID)
+		entry.Info("orphaned inventory reservation released")
+	}
+
+	return result, nil
+}
+
+// isOrphaned проверяет, что резерв больше не нужен заказу.
+func (r *Reconciler) isOrphaned(orderID string) (bool, error) {
+	order, err := r.orders.Get(orderID)
+	if err != nil {
+		if errors.Is(err, domain.ErrOrderNotFound) {
+			return true, nil
+		}
+		return false, fmt.Errorf("get order %s: %w", orderID, err)
+	}
+
+	switch order.Status {
+	case domain.OrderStatusCanceled, domain.OrderStatusRefunded:
+		return true, nil
+	default:
+		return false, nil
+	}
+}
+
+func reservationItems(reservations []domain.Reservation) []domain.OrderItem {
+	items := make([]domain.OrderItem, 0, len(reservations))
+	for _, reservation := range reservations {
+		items = append(items, domain.OrderItem{
+			SKU: reservation.SKU,
+			Qty: reservation.Qty,
+		})
+	}
+	return items
+}
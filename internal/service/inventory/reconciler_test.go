@@ -0,0 +1,143 @@
+package inventory
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/vladislavdragonenkov/oms/internal/domain"
+	"github.com/vladislavdragonenkov/oms/internal/storage/memory"
+)
+
+func reconcileOrder(id string, status domain.OrderStatus) domain.Order {
+	now := time.Now().UTC()
+	return domain.Order{
+		ID:          id,
+		CustomerID:  "customer-1",
+		Status:      status,
+		Currency:    "RUB",
+		AmountMinor: 100,
+		Items:       []domain.OrderItem{{ID: id + "-item", SKU: "sku-1", Qty: 1, PriceMinor: 100, CreatedAt: now}},
+		CreatedAt:   now,
+		UpdatedAt:   now,
+	}
+}
+
+func seedReconcileFixture(t *testing.T) (domain.OrderRepository, *MockService) {
+	t.Helper()
+
+	repo := memory.NewOrderRepository()
+	inv := NewMockService()
+
+	// Sandbox-заказ резервирует через отдельную заглушку и не должен считаться missing.
+	sandbox := reconcileOrder("order-sandbox", domain.OrderStatusReserved)
+	sandbox.TestMode = true
+
+	for _, order := range []domain.Order{
+		reconcileOrder("order-ok", domain.OrderStatusReserved),
+		reconcileOrder("order-canceled", domain.OrderStatusCanceled),
+		reconcileOrder("order-missing", domain.OrderStatusReserved),
+		sandbox,
+	} {
+		if err := repo.Create(order); err != nil {
+			t.Fatalf("create order %s: %v", order.ID, err)
+		}
+	}
+
+	for _, orderID := range []string{"order-ok", "order-canceled", "order-unknown"} {
+		if err := inv.Reserve(orderID, []domain.OrderItem{{SKU: "sku-1", Qty: 1}}); err != nil {
+			t.Fatalf("reserve %s: %v", orderID, err)
+		}
+	}
+
+	return repo, inv
+}
+
+func TestReconciler_ReleasesOrphansAndFlagsMissing(t *testing.T) {
+	repo, inv := seedReconcileFixture(t)
+
+	reconciler := NewReconciler(repo, inv, inv)
+	result, err := reconciler.Reconcile(context.Background())
+	if err != nil {
+		t.Fatalf("Reconcile failed: %v", err)
+	}
+
+	if len(result.Orphaned) != 2 || result.Orphaned[0] != "order-canceled" || result.Orphaned[1] != "order-unknown" {
+		t.Fatalf("unexpected orphaned orders: %v", result.Orphaned)
+	}
+	if len(result.Released) != 2 {
+		t.Fatalf("expected 2 released reservations, got %v", result.Released)
+	}
+	if len(result.Missing) != 1 || result.Missing[0] != "order-missing" {
+		t.Fatalf("unexpected missing orders: %v", result.Missing)
+	}
+
+	left, err := inv.ListReservations()
+	if err != nil {
+		t.Fatalf("ListReservations failed: %v", err)
+	}
+	if len(left) != 1 || left[0].OrderID != "order-ok" {
+		t.Fatalf("unexpected reservations after reconcile: %+v", left)
+	}
+}
+
+func TestReconciler_DryRunKeepsReservations(t *testing.T) {
+	repo, inv := seedReconcileFixture(t)
+
+	reconciler := NewReconciler(repo, inv, inv, WithDryRun(true))
+	result, err := reconciler.Reconcile(context.Background())
+	if err != nil {
+		t.Fatalf("Reconcile failed: %v", err)
+	}
+
+	if len(result.Orphaned) != 2 {
+		t.Fatalf("expected 2 orphaned orders, got %v", result.Orphaned)
+	}
+	if len(result.Released) != 0 {
+		t.Fatalf("dry-run must not release reservations, got %v", result.Released)
+	}
+	if inv.ReleaseCalls != 0 {
+		t.Fatalf("dry-run must not call Release, got %d calls", inv.ReleaseCalls)
+	}
+}
+
+func TestReconciler_ReleaseErrorDoesNotAbortRun(t *testing.T) {
+	repo, inv := seedReconcileFixture(t)
+	inv.ReleaseErr = errors.New("inventory unavailable")
+
+	reconciler := NewReconciler(repo, inv, inv)
+	result, err := reconciler.Reconcile(context.Background())
+	if err != nil {
+		t.Fatalf("Reconcile failed: %v", err)
+	}
+
+	if len(result.Orphaned) != 2 || len(result.Released) != 0 {
+		t.Fatalf("unexpected result: %+v", result)
+	}
+	if inv.ReleaseCalls != 2 {
+		t.Fatalf("expected release attempt for each orphan, got %d", inv.ReleaseCalls)
+	}
+}
+
+func TestReconciler_Run_StopsOnContextCancel(t *testing.T) {
+	repo, inv := seedReconcileFixture(t)
+
+	reconciler := NewReconciler(repo, inv, inv, WithInterval(5*time.Millisecond), WithDryRun(true))
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		reconciler.Run(ctx)
+	}()
+
+	time.Sleep(20 * time.Millisecond)
+	cancel()
+
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		t.Fatal("reconciler did not stop after context cancel")
+	}
+}
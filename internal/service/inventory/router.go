@@ -0,0 +1,375 @@
+package inventory
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	log "github.com/sirupsen/logrus"
+
+	"github.com/vladislavdragonenkov/oms/internal/domain"
+	"github.com/vladislavdragonenkov/oms/internal/metrics"
+)
+
+// ErrInvalidRoutes — ошибка разбора таблицы маршрутов склада.
+var ErrInvalidRoutes = errors.New("invalid inventory routes")
+
+// RouteKind — по чему маршрут выбирает склад.
+type RouteKind string
+
+const (
+	// RouteBySKU сравнивает префикс SKU позиции: цифровые товары и физический склад в одном заказе
+	// уходят в разные backend'ы.
+	RouteBySKU RouteKind = "sku"
+	// RouteByTenant сравнивает префикс customer_id заказа и направляет весь заказ партнёра в его склад.
+	RouteByTenant RouteKind = "tenant"
+)
+
+// Route — правило выбора склада. Fallback, если задан, получает запрос, когда Backend
+// ответил не бизнес-отказом (domain.ErrInventoryUnavailable), а сбоем.
+type Route struct {
+	Kind     RouteKind
+	Prefix   string
+	Backend  string
+	Fallback string
+}
+
+// RoutingTable — маршруты в порядке объявления и маршрут по умолчанию для позиций,
+// не подошедших ни под одно правило.
+type RoutingTable struct {
+	Routes  []Route
+	Default Route
+}
+
+// ParseRoutes разбирает таблицу в формате
+// "sku:DIG-=digital;tenant:acme-=acme,fallback=main;default=main".
+// Правила проверяются по порядку, выигрывает первое подошедшее; default обязателен.
+func ParseRoutes(raw string) (RoutingTable, error) {
+	var (
+		table      RoutingTable
+		hasDefault bool
+	)
+	for _, entry := range strings.Split(raw, ";") {
+		entry = strings.TrimSpace(entry)
+		if entry == "" {
+			continue
+		}
+		match, target, found := strings.Cut(entry, "=")
+		if !found {
+			return RoutingTable{}, fmt.Errorf("%w: expected match=backend, got %q", ErrInvalidRoutes, entry)
+		}
+		route, err := parseRouteTarget(target)
+		if err != nil {
+			return RoutingTable{}, fmt.Errorf("%w: %s: %v", ErrInvalidRoutes, entry, err)
+		}
+
+		match = strings.TrimSpace(match)
+		if match == "default" {
+			if hasDefault {
+				return RoutingTable{}, fmt.Errorf("%w: duplicate default route", ErrInvalidRoutes)
+			}
+			table.Default, hasDefault = route, true
+			continue
+		}
+		kind, prefix, found := strings.Cut(match, ":")
+		route.Kind, route.Prefix = RouteKind(strings.TrimSpace(kind)), strings.TrimSpace(prefix)
+		if !found || (route.Kind != RouteBySKU && route.Kind != RouteByTenant) {
+			return RoutingTable{}, fmt.Errorf("%w: expected sku:<prefix>, tenant:<prefix> or default, got %q", ErrInvalidRoutes, match)
+		}
+		if route.Prefix == "" {
+			return RoutingTable{}, fmt.Errorf("%w: %s: empty prefix", ErrInvalidRoutes, entry)
+		}
+		table.Routes = append(table.Routes, route)
+	}
+	if !hasDefault {
+		return RoutingTable{}, fmt.Errorf("%w: default route is required", ErrInvalidRoutes)
+	}
+	return table, nil
+}
+
+func parseRouteTarget(raw string) (Route, error) {
+	parts := strings.Split(raw, ",")
+	route := Route{Backend: strings.TrimSpace(parts[0])}
+	if route.Backend == "" {
+		return Route{}, errors.New("empty backend")
+	}
+	for _, part := range parts[1:] {
+		key, value, found := strings.Cut(strings.TrimSpace(part), "=")
+		if !found || strings.TrimSpace(key) != "fallback" || strings.TrimSpace(value) == "" {
+			return Route{}, fmt.Errorf("expected fallback=<backend>, got %q", part)
+		}
+		route.Fallback = strings.TrimSpace(value)
+	}
+	if route.Fallback == route.Backend {
+		return Route{}, errors.New("fallback must differ from backend")
+	}
+	return route, nil
+}
+
+// Backends возвращает имена всех backend'ов таблицы, включая fallback, по алфавиту.
+func (t RoutingTable) Backends() []string {
+	var names []string
+	for _, route := range append(slices.Clone(t.Routes), t.Default) {
+		names = append(names, route.Backend)
+		if route.Fallback != "" {
+			names = append(names, route.Fallback)
+		}
+	}
+	slices.Sort(names)
+	return slices.Compact(names)
+}
+
+// resolve выбирает маршрут позиции: первое подошедшее правило или Default.
+func (t RoutingTable) resolve(customerID, sku string) Route {
+	for _, route := range t.Routes {
+		switch {
+		case route.Kind == RouteByTenant && customerID != "" && strings.HasPrefix(customerID, route.Prefix):
+			return route
+		case route.Kind == RouteBySKU && strings.HasPrefix(sku, route.Prefix):
+			return route
+		}
+	}
+	return t.Default
+}
+
+type routerMetrics struct {
+	requests  *prometheus.CounterVec
+	duration  *prometheus.HistogramVec
+	fallbacks *prometheus.CounterVec
+}
+
+func newRouterMetrics(registerer prometheus.Registerer) routerMetrics {
+	return routerMetrics{
+		requests: metrics.Register(registerer, prometheus.NewCounterVec(prometheus.CounterOpts{
+			Name: "oms_inventory_backend_requests_total",
+			Help: "Inventory backend calls made by the router grouped by backend, operation and result (ok, unavailable, error).",
+		}, []string{"backend", "operation", "result"})),
+		duration: metrics.Register(registerer, prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Name:    "oms_inventory_backend_request_duration_seconds",
+			Help:    "Latency of inventory backend calls made by the router.",
+			Buckets: prometheus.DefBuckets,
+		}, []string{"backend", "operation"})),
+		fallbacks: metrics.Register(registerer, prometheus.NewCounterVec(prometheus.CounterOpts{
+			Name: "oms_inventory_backend_fallbacks_total",
+			Help: "Reservations retried on the fallback backend after the primary backend failed.",
+		}, []string{"backend", "fallback"})),
+	}
+}
+
+type routerOptions struct {
+	logger     *log.Entry
+	registerer prometheus.Registerer
+}
+
+// RouterOption настраивает Router.
+type RouterOption func(*routerOptions)
+
+// WithRouterLogger задаёт logger маршрутизатора.
+func WithRouterLogger(logger *log.Entry) RouterOption {
+	return func(opts *routerOptions) {
+		opts.logger = logger
+	}
+}
+
+// WithRouterRegisterer задаёт реестр метрик.
+func WithRouterRegisterer(registerer prometheus.Registerer) RouterOption {
+	return func(opts *routerOptions) {
+		opts.registerer = registerer
+	}
+}
+
+// Router — InventoryService, раскладывающий позиции заказа по нескольким складам согласно
+// RoutingTable. Заказ с позициями разных складов резервируется в каждом; если один из складов
+// отказал, уже сделанные резервы снимаются, чтобы заказ не остался зарезервированным частично.
+type Router struct {
+	table    RoutingTable
+	backends map[string]domain.InventoryService
+	logger   *log.Entry
+	metrics  routerMetrics
+}
+
+// NewRouter проверяет, что для каждого backend'а таблицы передана реализация.
+func NewRouter(table RoutingTable, backends map[string]domain.InventoryService, options ...RouterOption) (*Router, error) {
+	opts := routerOptions{}
+	for _, option := range options {
+		option(&opts)
+	}
+	if opts.logger == nil {
+		opts.logger = log.WithField("component", "inventory-router")
+	}
+	for _, name := range table.Backends() {
+		if backends[name] == nil {
+			return nil, fmt.Errorf("%w: backend %q is not configured", ErrInvalidRoutes, name)
+		}
+	}
+	return &Router{
+		table:    table,
+		backends: backends,
+		logger:   opts.logger,
+		metrics:  newRouterMetrics(opts.registerer),
+	}, nil
+}
+
+// Reserve резервирует позиции без учёта tenant-правил: покупатель заказа здесь неизвестен.
+// Сага вызывает склад через ForCustomer.
+func (r *Router) Reserve(orderID string, items []domain.OrderItem) error {
+	return r.reserve("", orderID, items)
+}
+
+// Release снимает резерв во всех складах, куда могли попасть позиции.
+func (r *Router) Release(orderID string, items []domain.OrderItem) error {
+	return r.release("", orderID, items)
+}
+
+// ForCustomer возвращает склад с учётом tenant-правил для заказов покупателя customerID.
+func (r *Router) ForCustomer(customerID string) domain.InventoryService {
+	return customerInventory{router: r, customerID: customerID}
+}
+
+// ListReservations объединяет резервы backend'ов, умеющих их отдавать (для сверки).
+func (r *Router) ListReservations() ([]domain.Reservation, error) {
+	var result []domain.Reservation
+	for _, name := range r.table.Backends() {
+		lister, ok := r.backends[name].(domain.ReservationLister)
+		if !ok {
+			continue
+		}
+		reservations, err := lister.ListReservations()
+		if err != nil {
+			return nil, fmt.Errorf("list reservations from %s: %w", name, err)
+		}
+		result = append(result, reservations...)
+	}
+	return result, nil
+}
+
+type customerInventory struct {
+	router     *Router
+	customerID string
+}
+
+func (c customerInventory) Reserve(orderID string, items []domain.OrderItem) error {
+	return c.router.reserve(c.customerID, orderID, items)
+}
+
+func (c customerInventory) Release(orderID string, items []domain.OrderItem) error {
+	return c.router.release(c.customerID, orderID, items)
+}
+
+// routeGroup — позиции заказа, ушедшие по одному маршруту.
+type routeGroup struct {
+	route Route
+	items []domain.OrderItem
+}
+
+func (r *Router) group(customerID string, items []domain.OrderItem) []routeGroup {
+	var groups []routeGroup
+	for _, item := range items {
+		route := r.table.resolve(customerID, item.SKU)
+		idx := slices.IndexFunc(groups, func(g routeGroup) bool { return g.route == route })
+		if idx < 0 {
+			groups = append(groups, routeGroup{route: route})
+			idx = len(groups) - 1
+		}
+		groups[idx].items = append(groups[idx].items, item)
+	}
+	return groups
+}
+
+type placement struct {
+	backend string
+	items   []domain.OrderItem
+}
+
+func (r *Router) reserve(customerID, orderID string, items []domain.OrderItem) error {
+	var placed []placement
+	for _, group := range r.group(customerID, items) {
+		backend, err := r.reserveGroup(orderID, group)
+		if err != nil {
+			r.rollback(orderID, placed)
+			return err
+		}
+		placed = append(placed, placement{backend: backend, items: group.items})
+	}
+	return nil
+}
+
+// reserveGroup резервирует позиции маршрута в основном складе, а при сбое — в fallback.
+// Бизнес-отказ (нет стока) в fallback не переносится: резерв не должен зависеть от доступности.
+func (r *Router) reserveGroup(orderID string, group routeGroup) (string, error) {
+	route := group.route
+	err := r.call(route.Backend, "reserve", func(svc domain.InventoryService) error {
+		return svc.Reserve(orderID, group.items)
+	})
+	if err == nil {
+		return route.Backend, nil
+	}
+	if route.Fallback == "" || errors.Is(err, domain.ErrInventoryUnavailable) {
+		return "", fmt.Errorf("inventory backend %s: %w", route.Backend, err)
+	}
+
+	r.metrics.fallbacks.WithLabelValues(route.Backend, route.Fallback).Inc()
+	r.logger.WithError(err).WithFields(log.Fields{
+		"order_id": orderID,
+		"backend":  route.Backend,
+		"fallback": route.Fallback,
+	}).Warn("inventory backend failed, reserving on fallback")
+	if err := r.call(route.Fallback, "reserve", func(svc domain.InventoryService) error {
+		return svc.Reserve(orderID, group.items)
+	}); err != nil {
+		return "", fmt.Errorf("inventory fallback backend %s: %w", route.Fallback, err)
+	}
+	return route.Fallback, nil
+}
+
+func (r *Router) rollback(orderID string, placed []placement) {
+	for _, p := range placed {
+		err := r.call(p.backend, "release", func(svc domain.InventoryService) error {
+			return svc.Release(orderID, p.items)
+		})
+		if err != nil {
+			// Резерв останется висеть до сверки (Reconciler), заказ всё равно будет отменён.
+			r.logger.WithError(err).WithFields(log.Fields{"order_id": orderID, "backend": p.backend}).
+				Warn("failed to roll back partial reservation")
+		}
+	}
+}
+
+// release снимает резерв и в основном складе, и в fallback: где именно лежит резерв, роутер
+// не хранит, а снятие несуществующего резерва для склада — no-op.
+func (r *Router) release(customerID, orderID string, items []domain.OrderItem) error {
+	var errs []error
+	for _, group := range r.group(customerID, items) {
+		for _, backend := range []string{group.route.Backend, group.route.Fallback} {
+			if backend == "" {
+				continue
+			}
+			err := r.call(backend, "release", func(svc domain.InventoryService) error {
+				return svc.Release(orderID, group.items)
+			})
+			if err != nil {
+				errs = append(errs, fmt.Errorf("inventory backend %s: %w", backend, err))
+			}
+		}
+	}
+	return errors.Join(errs...)
+}
+
+func (r *Router) call(backend, operation string, fn func(domain.InventoryService) error) error {
+	started := time.Now()
+	err := fn(r.backends[backend])
+	r.metrics.duration.WithLabelValues(backend, operation).Observe(time.Since(started).Seconds())
+
+	result := "ok"
+	switch {
+	case errors.Is(err, domain.ErrInventoryUnavailable):
+		result = "unavailable"
+	case err != nil:
+		result = "error"
+	}
+	r.metrics.requests.WithLabelValues(backend, operation, result).Inc()
+	return err
+}
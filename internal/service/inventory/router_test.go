@@ -0,0 +1,180 @@
+package inventory
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+
+	"github.com/vladislavdragonenkov/oms/internal/domain"
+)
+
+func TestParseRoutes(t *testing.T) {
+	table, err := ParseRoutes(" sku:DIG-=digital ; tenant:acme-=acme,fallback=main; default=main ")
+	if err != nil {
+		t.Fatalf("ParseRoutes failed: %v", err)
+	}
+	want := []Route{
+		{Kind: RouteBySKU, Prefix: "DIG-", Backend: "digital"},
+		{Kind: RouteByTenant, Prefix: "acme-", Backend: "acme", Fallback: "main"},
+	}
+	if len(table.Routes) != len(want) || table.Routes[0] != want[0] || table.Routes[1] != want[1] {
+		t.Fatalf("unexpected routes: %+v", table.Routes)
+	}
+	if table.Default.Backend != "main" {
+		t.Fatalf("unexpected default: %+v", table.Default)
+	}
+	if got := strings.Join(table.Backends(), ","); got != "acme,digital,main" {
+		t.Fatalf("unexpected backends: %s", got)
+	}
+}
+
+func TestParseRoutes_Errors(t *testing.T) {
+	for _, raw := range []string{
+		"",
+		"sku:DIG-=digital",
+		"default=main;default=other",
+		"sku:DIG-digital;default=main",
+		"region:eu=eu;default=main",
+		"sku:=digital;default=main",
+		"sku:DIG-=;default=main",
+		"sku:DIG-=digital,retry=3;default=main",
+		"sku:DIG-=digital,fallback=digital;default=main",
+	} {
+		if _, err := ParseRoutes(raw); !errors.Is(err, ErrInvalidRoutes) {
+			t.Fatalf("ParseRoutes(%q) expected ErrInvalidRoutes, got %v", raw, err)
+		}
+	}
+}
+
+func newTestRouter(t *testing.T, raw string, backends map[string]domain.InventoryService) (*Router, *prometheus.Registry) {
+	t.Helper()
+	table, err := ParseRoutes(raw)
+	if err != nil {
+		t.Fatalf("ParseRoutes failed: %v", err)
+	}
+	registry := prometheus.NewRegistry()
+	router, err := NewRouter(table, backends, WithRouterRegisterer(registry))
+	if err != nil {
+		t.Fatalf("NewRouter failed: %v", err)
+	}
+	return router, registry
+}
+
+func TestNewRouter_RequiresAllBackends(t *testing.T) {
+	table, err := ParseRoutes("sku:DIG-=digital,fallback=spare;default=main")
+	if err != nil {
+		t.Fatalf("ParseRoutes failed: %v", err)
+	}
+	backends := map[string]domain.InventoryService{"digital": NewMockService(), "main": NewMockService()}
+	if _, err := NewRouter(table, backends, WithRouterRegisterer(prometheus.NewRegistry())); !errors.Is(err, ErrInvalidRoutes) {
+		t.Fatalf("expected missing fallback backend error, got %v", err)
+	}
+}
+
+func TestRouter_SplitsItemsBySKUAndTenant(t *testing.T) {
+	digital, acme, main := NewMockService(), NewMockService(), NewMockService()
+	router, registry := newTestRouter(t, "sku:DIG-=digital;tenant:acme-=acme;default=main",
+		map[string]domain.InventoryService{"digital": digital, "acme": acme, "main": main})
+
+	items := []domain.OrderItem{{SKU: "DIG-ebook", Qty: 1}, {SKU: "box-1", Qty: 2}}
+	if err := router.ForCustomer("retail-1").Reserve("order-1", items); err != nil {
+		t.Fatalf("Reserve failed: %v", err)
+	}
+	if digital.ReserveCalls != 1 || main.ReserveCalls != 1 || acme.ReserveCalls != 0 {
+		t.Fatalf("unexpected calls: digital=%d main=%d acme=%d", digital.ReserveCalls, main.ReserveCalls, acme.ReserveCalls)
+	}
+
+	// Правила проверяются по порядку: sku-правило объявлено раньше tenant-правила.
+	if err := router.ForCustomer("acme-42").Reserve("order-2", items); err != nil {
+		t.Fatalf("Reserve failed: %v", err)
+	}
+	if digital.ReserveCalls != 2 || acme.ReserveCalls != 1 || main.ReserveCalls != 1 {
+		t.Fatalf("unexpected calls: digital=%d main=%d acme=%d", digital.ReserveCalls, main.ReserveCalls, acme.ReserveCalls)
+	}
+
+	reservations, err := router.ListReservations()
+	if err != nil {
+		t.Fatalf("ListReservations failed: %v", err)
+	}
+	if len(reservations) != 4 {
+		t.Fatalf("expected reservations from all backends, got %+v", reservations)
+	}
+
+	expected := `
+# HELP oms_inventory_backend_requests_total Inventory backend calls made by the router grouped by backend, operation and result (ok, unavailable, error).
+# TYPE oms_inventory_backend_requests_total counter
+oms_inventory_backend_requests_total{backend="acme",operation="reserve",result="ok"} 1
+oms_inventory_backend_requests_total{backend="digital",operation="reserve",result="ok"} 2
+oms_inventory_backend_requests_total{backend="main",operation="reserve",result="ok"} 1
+`
+	if err := testutil.GatherAndCompare(registry, strings.NewReader(expected), "oms_inventory_backend_requests_total"); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestRouter_FallsBackOnTemporaryFailure(t *testing.T) {
+	primary, spare := NewMockService(), NewMockService()
+	primary.ReserveErr = domain.ErrInventoryTemporary
+	router, registry := newTestRouter(t, "default=primary,fallback=spare",
+		map[string]domain.InventoryService{"primary": primary, "spare": spare})
+
+	items := []domain.OrderItem{{SKU: "sku-1", Qty: 1}}
+	if err := router.Reserve("order-1", items); err != nil {
+		t.Fatalf("expected fallback to succeed, got %v", err)
+	}
+	if spare.ReserveCalls != 1 {
+		t.Fatalf("expected fallback reserve, got %d", spare.ReserveCalls)
+	}
+	if got := testutil.ToFloat64(router.metrics.fallbacks.WithLabelValues("primary", "spare")); got != 1 {
+		t.Fatalf("expected fallback metric 1, got %v", got)
+	}
+
+	if err := router.Release("order-1", items); err != nil {
+		t.Fatalf("Release failed: %v", err)
+	}
+	if primary.ReleaseCalls != 1 || spare.ReleaseCalls != 1 {
+		t.Fatalf("release must reach primary and fallback: primary=%d spare=%d", primary.ReleaseCalls, spare.ReleaseCalls)
+	}
+	if n, _ := testutil.GatherAndCount(registry, "oms_inventory_backend_request_duration_seconds"); n == 0 {
+		t.Fatal("expected duration histogram to be recorded")
+	}
+}
+
+func TestRouter_OutOfStockIsNotRetriedOnFallback(t *testing.T) {
+	primary, spare := NewMockService(), NewMockService()
+	primary.ReserveErr = domain.ErrInventoryUnavailable
+	router, _ := newTestRouter(t, "default=primary,fallback=spare",
+		map[string]domain.InventoryService{"primary": primary, "spare": spare})
+
+	err := router.Reserve("order-1", []domain.OrderItem{{SKU: "sku-1", Qty: 1}})
+	if !errors.Is(err, domain.ErrInventoryUnavailable) {
+		t.Fatalf("expected ErrInventoryUnavailable, got %v", err)
+	}
+	if spare.ReserveCalls != 0 {
+		t.Fatalf("out of stock must not be retried on fallback, got %d calls", spare.ReserveCalls)
+	}
+	if got := testutil.ToFloat64(router.metrics.requests.WithLabelValues("primary", "reserve", "unavailable")); got != 1 {
+		t.Fatalf("expected unavailable result metric, got %v", got)
+	}
+}
+
+func TestRouter_RollsBackPartialReservation(t *testing.T) {
+	digital, main := NewMockService(), NewMockService()
+	main.ReserveErr = domain.ErrInventoryTemporary
+	router, _ := newTestRouter(t, "sku:DIG-=digital;default=main",
+		map[string]domain.InventoryService{"digital": digital, "main": main})
+
+	err := router.Reserve("order-1", []domain.OrderItem{{SKU: "DIG-ebook", Qty: 1}, {SKU: "box-1", Qty: 1}})
+	if !errors.Is(err, domain.ErrInventoryTemporary) {
+		t.Fatalf("expected ErrInventoryTemporary, got %v", err)
+	}
+	if digital.ReleaseCalls != 1 {
+		t.Fatalf("expected reservation in digital backend to be rolled back, got %d releases", digital.ReleaseCalls)
+	}
+	if reservations, _ := digital.ListReservations(); len(reservations) != 0 {
+		t.Fatalf("expected no dangling reservations, got %+v", reservations)
+	}
+}
@@ -0,0 +1,342 @@
+// Package ordercache кэширует чтения заказов, которые под нагрузкой повторяются чаще, чем меняются данные.
+package ordercache
+
+import (
+	"container/list"
+	"context"
+	"errors"
+	"sync"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	log "github.com/sirupsen/logrus"
+
+	"github.com/vladislavdragonenkov/oms/internal/domain"
+	"github.com/vladislavdragonenkov/oms/internal/metrics"
+	"github.com/vladislavdragonenkov/oms/internal/notify"
+)
+
+const (
+	// DefaultMaxCustomers — сколько покупателей держит кэш до вытеснения давно не читавшихся.
+	DefaultMaxCustomers = 10000
+
+	resubscribeDelay = time.Second
+)
+
+// Значения label result у oms_order_list_cache_requests_total.
+const (
+	resultHit       = "hit"
+	resultMiss      = "miss"
+	resultCoalesced = "coalesced"
+)
+
+// Источники инвалидации (label source у oms_order_list_cache_invalidations_total).
+const (
+	// SourceTimeline — событие timeline заказа из in-process hub'а.
+	SourceTimeline = "timeline"
+	// SourceWrite — запись, о которой вызывающий код сообщил явно (например, создание заказа).
+	SourceWrite = "write"
+	// SourceResync — подписка на timeline отстала или переподключалась: часть событий могла потеряться.
+	SourceResync = "resync"
+)
+
+// TimelineWatcher — источник событий timeline; *notify.TimelineRepository.
+type TimelineWatcher interface {
+	Watch(orderID string, withHistory bool) (*notify.TimelineStream, error)
+}
+
+// ListCache — кэш списков заказов покупателя (ListOrders). Запись сбрасывается событием timeline
+// любого заказа из закэшированного списка, явным Invalidate при создании заказа и по TTL.
+// TTL ограничивает устаревание от записей других инстансов: in-process hub их не видит.
+//
+// Одновременные промахи по одному списку выполняют одну загрузку (защита от stampede); если
+// во время загрузки список инвалидировали, результат отдаётся ждущим, но не сохраняется.
+// Возвращаемые срезы общие для всех читателей и не должны изменяться.
+type ListCache struct {
+	ttl          time.Duration
+	maxCustomers int
+	logger       *log.Entry
+	now          func() time.Time
+
+	mu        sync.Mutex
+	customers map[string]*customerLists
+	// recent — покупатели от недавно читавшихся к давно читавшимся; хвост вытесняется первым.
+	recent *list.List
+	// owners — ID заказа из закэшированных списков -> покупатель.
+	owners   map[string]string
+	inflight map[listKey]*loadCall
+
+	requests      *prometheus.CounterVec
+	invalidations *prometheus.CounterVec
+	cached        prometheus.Gauge
+}
+
+type listKey struct {
+	customerID string
+	limit      int
+}
+
+type customerLists struct {
+	customerID string
+	lists      map[int]cachedList
+	element    *list.Element
+}
+
+type cachedList struct {
+	orders    []domain.Order
+	expiresAt time.Time
+}
+
+type loadCall struct {
+	done   chan struct{}
+	orders []domain.Order
+	err    error
+	// stale — список инвалидировали во время загрузки.
+	stale bool
+}
+
+type options struct {
+	maxCustomers int
+	logger       *log.Entry
+	registerer   prometheus.Registerer
+}
+
+// Option настраивает ListCache.
+type Option func(*options)
+
+// WithMaxCustomers ограничивает число покупателей в кэше; <= 0 — DefaultMaxCustomers.
+func WithMaxCustomers(limit int) Option {
+	return func(opts *options) {
+		opts.maxCustomers = limit
+	}
+}
+
+// WithLogger задаёт logger.
+func WithLogger(logger *log.Entry) Option {
+	return func(opts *options) {
+		opts.logger = logger
+	}
+}
+
+// WithRegisterer задаёт реестр метрик.
+func WithRegisterer(registerer prometheus.Registerer) Option {
+	return func(opts *options) {
+		opts.registerer = registerer
+	}
+}
+
+// NewListCache создаёт кэш со сроком жизни записи ttl.
+func NewListCache(ttl time.Duration, opts ...Option) *ListCache {
+	cfg := options{maxCustomers: DefaultMaxCustomers}
+	for _, opt := range opts {
+		if opt != nil {
+			opt(&cfg)
+		}
+	}
+	if cfg.maxCustomers <= 0 {
+		cfg.maxCustomers = DefaultMaxCustomers
+	}
+	if cfg.logger == nil {
+		cfg.logger = log.WithField("component", "order-list-cache")
+	}
+	return &ListCache{
+		ttl:          ttl,
+		maxCustomers: cfg.maxCustomers,
+		logger:       cfg.logger,
+		now:          time.Now,
+		customers:    make(map[string]*customerLists),
+		recent:       list.New(),
+		owners:       make(map[string]string),
+		inflight:     make(map[listKey]*loadCall),
+		requests: metrics.Register(cfg.registerer, prometheus.NewCounterVec(prometheus.CounterOpts{
+			Name: "oms_order_list_cache_requests_total",
+			Help: "Customer order list reads grouped by cache result (hit, miss, coalesced).",
+		}, []string{"result"})),
+		invalidations: metrics.Register(cfg.registerer, prometheus.NewCounterVec(prometheus.CounterOpts{
+			Name: "oms_order_list_cache_invalidations_total",
+			Help: "Customer order list cache invalidations grouped by source (timeline, write, resync).",
+		}, []string{"source"})),
+		cached: metrics.Register(cfg.registerer, prometheus.NewGauge(prometheus.GaugeOpts{
+			Name: "oms_order_list_cache_customers",
+			Help: "Customers with cached order lists.",
+		})),
+	}
+}
+
+// Get возвращает список заказов покупателя из кэша или загружает его через load.
+func (c *ListCache) Get(customerID string, limit int, load func() ([]domain.Order, error)) ([]domain.Order, error) {
+	key := listKey{customerID: customerID, limit: limit}
+
+	c.mu.Lock()
+	if orders, ok := c.lookupLocked(key); ok {
+		c.mu.Unlock()
+		c.requests.WithLabelValues(resultHit).Inc()
+		return orders, nil
+	}
+	if call, ok := c.inflight[key]; ok {
+		c.mu.Unlock()
+		c.requests.WithLabelValues(resultCoalesced).Inc()
+		<-call.done
+		return call.orders, call.err
+	}
+	call := &loadCall{done: make(chan struct{})}
+	c.inflight[key] = call
+	c.mu.Unlock()
+	c.requests.WithLabelValues(resultMiss).Inc()
+
+	defer close(call.done)
+	call.orders, call.err = load()
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	delete(c.inflight, key)
+	if call.err == nil && !call.stale {
+		c.storeLocked(key, call.orders)
+	}
+	return call.orders, call.err
+}
+
+// Invalidate сбрасывает списки покупателя.
+func (c *ListCache) Invalidate(customerID, source string) {
+	c.mu.Lock()
+	c.invalidateLocked(customerID)
+	c.mu.Unlock()
+	c.invalidations.WithLabelValues(source).Inc()
+}
+
+// InvalidateOrder сбрасывает списки покупателя, в которых есть заказ. Заказ, которого нет ни
+// в одном списке, на закэшированные ответы не влияет.
+func (c *ListCache) InvalidateOrder(orderID, source string) {
+	c.mu.Lock()
+	customerID, ok := c.owners[orderID]
+	if ok {
+		c.invalidateLocked(customerID)
+	}
+	c.mu.Unlock()
+	if ok {
+		c.invalidations.WithLabelValues(source).Inc()
+	}
+}
+
+// InvalidateAll очищает кэш.
+func (c *ListCache) InvalidateAll(source string) {
+	c.mu.Lock()
+	clear(c.customers)
+	clear(c.owners)
+	c.recent.Init()
+	for _, call := range c.inflight {
+		call.stale = true
+	}
+	c.cached.Set(0)
+	c.mu.Unlock()
+	c.invalidations.WithLabelValues(source).Inc()
+}
+
+// WatchTimeline сбрасывает списки по событиям timeline до отмены ctx. Если подписка отстала
+// или не оформилась, кэш очищается целиком и подписка повторяется.
+func (c *ListCache) WatchTimeline(ctx context.Context, watcher TimelineWatcher) {
+	for ctx.Err() == nil {
+		stream, err := watcher.Watch(notify.AnyKey, false)
+		if err != nil {
+			c.logger.WithError(err).Warn("failed to watch timeline, retrying")
+			c.InvalidateAll(SourceResync)
+			if !sleep(ctx, resubscribeDelay) {
+				return
+			}
+			continue
+		}
+		c.consume(ctx, stream)
+		stream.Close()
+	}
+}
+
+func (c *ListCache) consume(ctx context.Context, stream *notify.TimelineStream) {
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case event, ok := <-stream.Events():
+			if !ok {
+				if errors.Is(stream.Err(), notify.ErrSubscriberLagged) {
+					c.logger.Warn("timeline subscription lagged, dropping cached order lists")
+				}
+				c.InvalidateAll(SourceResync)
+				return
+			}
+			c.InvalidateOrder(event.OrderID, SourceTimeline)
+		}
+	}
+}
+
+func (c *ListCache) lookupLocked(key listKey) ([]domain.Order, bool) {
+	entry, ok := c.customers[key.customerID]
+	if !ok {
+		return nil, false
+	}
+	cached, ok := entry.lists[key.limit]
+	if !ok {
+		return nil, false
+	}
+	if c.ttl > 0 && !c.now().Before(cached.expiresAt) {
+		delete(entry.lists, key.limit)
+		return nil, false
+	}
+	c.recent.MoveToFront(entry.element)
+	return cached.orders, true
+}
+
+func (c *ListCache) storeLocked(key listKey, orders []domain.Order) {
+	entry, ok := c.customers[key.customerID]
+	if !ok {
+		entry = &customerLists{customerID: key.customerID, lists: make(map[int]cachedList)}
+		entry.element = c.recent.PushFront(entry)
+		c.customers[key.customerID] = entry
+	} else {
+		c.recent.MoveToFront(entry.element)
+	}
+	entry.lists[key.limit] = cachedList{orders: orders, expiresAt: c.now().Add(c.ttl)}
+	for _, order := range orders {
+		c.owners[order.ID] = key.customerID
+	}
+
+	for len(c.customers) > c.maxCustomers {
+		oldest := c.recent.Back().Value.(*customerLists)
+		c.removeLocked(oldest)
+	}
+	c.cached.Set(float64(len(c.customers)))
+}
+
+func (c *ListCache) invalidateLocked(customerID string) {
+	if entry, ok := c.customers[customerID]; ok {
+		c.removeLocked(entry)
+		c.cached.Set(float64(len(c.customers)))
+	}
+	for key, call := range c.inflight {
+		if key.customerID == customerID {
+			call.stale = true
+		}
+	}
+}
+
+func (c *ListCache) removeLocked(entry *customerLists) {
+	for _, cached := range entry.lists {
+		for _, order := range cached.orders {
+			if c.owners[order.ID] == entry.customerID {
+				delete(c.owners, order.ID)
+			}
+		}
+	}
+	c.recent.Remove(entry.element)
+	delete(c.customers, entry.customerID)
+}
+
+func sleep(ctx context.Context, delay time.Duration) bool {
+	timer := time.NewTimer(delay)
+	defer timer.Stop()
+	select {
+	case <-ctx.Done():
+		return false
+	case <-timer.C:
+		return true
+	}
+}
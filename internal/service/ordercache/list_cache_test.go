@@ -0,0 +1,278 @@
+package ordercache
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+
+	"github.com/vladislavdragonenkov/oms/internal/domain"
+	"github.com/vladislavdragonenkov/oms/internal/metrics/metricstest"
+	"github.com/vladislavdragonenkov/oms/internal/notify"
+	"github.com/vladislavdragonenkov/oms/internal/storage/memory"
+)
+
+func newTestCache(ttl time.Duration, opts ...Option) (*ListCache, *prometheus.Registry) {
+	registry := prometheus.NewRegistry()
+	return NewListCache(ttl, append(opts, WithRegisterer(registry))...), registry
+}
+
+func loader(calls *int, orders ...domain.Order) func() ([]domain.Order, error) {
+	return func() ([]domain.Order, error) {
+		*calls++
+		return orders, nil
+	}
+}
+
+func TestListCache_HitMissAndTTL(t *testing.T) {
+	cache, registry := newTestCache(time.Minute)
+	now := time.Now()
+	cache.now = func() time.Time { return now }
+
+	calls := 0
+	load := loader(&calls, domain.Order{ID: "order-1", CustomerID: "c1"})
+	for range 3 {
+		orders, err := cache.Get("c1", 10, load)
+		if err != nil || len(orders) != 1 {
+			t.Fatalf("get: %v (%d orders)", err, len(orders))
+		}
+	}
+	if calls != 1 {
+		t.Fatalf("expected one load, got %d", calls)
+	}
+	if _, err := cache.Get("c1", 5, load); err != nil || calls != 2 {
+		t.Fatalf("other limit must be cached separately, loads=%d err=%v", calls, err)
+	}
+
+	now = now.Add(time.Minute)
+	if _, err := cache.Get("c1", 10, load); err != nil || calls != 3 {
+		t.Fatalf("expired list must be reloaded, loads=%d err=%v", calls, err)
+	}
+
+	metricstest.RequireValue(t, registry, "oms_order_list_cache_requests_total", metricstest.Labels{"result": "hit"}, 2)
+	metricstest.RequireValue(t, registry, "oms_order_list_cache_requests_total", metricstest.Labels{"result": "miss"}, 3)
+	metricstest.RequireValue(t, registry, "oms_order_list_cache_customers", nil, 1)
+}
+
+func TestListCache_LoadErrorIsNotCached(t *testing.T) {
+	cache, _ := newTestCache(time.Minute)
+	calls := 0
+	failing := func() ([]domain.Order, error) {
+		calls++
+		return nil, errors.New("db down")
+	}
+	for range 2 {
+		if _, err := cache.Get("c1", 10, failing); err == nil {
+			t.Fatal("expected load error")
+		}
+	}
+	if calls != 2 {
+		t.Fatalf("failed load must not be cached, loads=%d", calls)
+	}
+}
+
+func TestListCache_CoalescesConcurrentMisses(t *testing.T) {
+	cache, registry := newTestCache(time.Minute)
+	release := make(chan struct{})
+	started := make(chan struct{})
+	var mu sync.Mutex
+	calls := 0
+	load := func() ([]domain.Order, error) {
+		mu.Lock()
+		calls++
+		mu.Unlock()
+		close(started)
+		<-release
+		return []domain.Order{{ID: "order-1", CustomerID: "c1"}}, nil
+	}
+
+	const readers = 5
+	var wg sync.WaitGroup
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		_, _ = cache.Get("c1", 10, load)
+	}()
+	<-started
+	results := make(chan int, readers)
+	for range readers {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			orders, _ := cache.Get("c1", 10, load)
+			results <- len(orders)
+		}()
+	}
+	waitFor(t, func() bool {
+		return metricValue(t, registry, "oms_order_list_cache_requests_total", metricstest.Labels{"result": "coalesced"}) == readers
+	})
+	close(release)
+	wg.Wait()
+	close(results)
+
+	for got := range results {
+		if got != 1 {
+			t.Fatalf("coalesced reader must get the loaded list, got %d orders", got)
+		}
+	}
+	if calls != 1 {
+		t.Fatalf("expected a single load, got %d", calls)
+	}
+}
+
+func TestListCache_InvalidationDuringLoadIsNotStored(t *testing.T) {
+	cache, _ := newTestCache(time.Minute)
+	calls := 0
+	load := func() ([]domain.Order, error) {
+		calls++
+		if calls == 1 {
+			cache.Invalidate("c1", SourceWrite)
+		}
+		return []domain.Order{{ID: "order-1", CustomerID: "c1"}}, nil
+	}
+
+	if orders, err := cache.Get("c1", 10, load); err != nil || len(orders) != 1 {
+		t.Fatalf("get: %v", err)
+	}
+	if _, err := cache.Get("c1", 10, load); err != nil || calls != 2 {
+		t.Fatalf("list loaded before invalidation must not be cached, loads=%d", calls)
+	}
+}
+
+func TestListCache_InvalidateOrderUsesCachedLists(t *testing.T) {
+	cache, registry := newTestCache(time.Minute)
+	calls := 0
+	load := loader(&calls, domain.Order{ID: "order-1", CustomerID: "c1"})
+	if _, err := cache.Get("c1", 10, load); err != nil {
+		t.Fatalf("get: %v", err)
+	}
+
+	cache.InvalidateOrder("order-unknown", SourceTimeline)
+	if _, _ = cache.Get("c1", 10, load); calls != 1 {
+		t.Fatalf("unrelated order must not invalidate the list, loads=%d", calls)
+	}
+	cache.InvalidateOrder("order-1", SourceTimeline)
+	if _, _ = cache.Get("c1", 10, load); calls != 2 {
+		t.Fatalf("order event must invalidate its customer, loads=%d", calls)
+	}
+	metricstest.RequireValue(t, registry, "oms_order_list_cache_invalidations_total", metricstest.Labels{"source": "timeline"}, 1)
+}
+
+func TestListCache_EvictsLeastRecentlyReadCustomer(t *testing.T) {
+	cache, registry := newTestCache(time.Minute, WithMaxCustomers(2))
+	calls := map[string]int{}
+	get := func(customerID string) {
+		t.Helper()
+		_, err := cache.Get(customerID, 10, func() ([]domain.Order, error) {
+			calls[customerID]++
+			return []domain.Order{{ID: "order-" + customerID, CustomerID: customerID}}, nil
+		})
+		if err != nil {
+			t.Fatalf("get %s: %v", customerID, err)
+		}
+	}
+
+	get("c1")
+	get("c2")
+	get("c1") // c1 становится недавно прочитанным.
+	get("c3") // вытесняет c2.
+	get("c1")
+	get("c2")
+	if calls["c1"] != 1 || calls["c2"] != 2 {
+		t.Fatalf("expected c2 to be evicted, loads=%v", calls)
+	}
+	metricstest.RequireValue(t, registry, "oms_order_list_cache_customers", nil, 2)
+
+	// Заказ вытесненного покупателя больше не числится в индексе.
+	cache.InvalidateOrder("order-c3", SourceTimeline)
+	metricstest.RequireAbsent(t, registry, "oms_order_list_cache_invalidations_total", metricstest.Labels{"source": "timeline"})
+}
+
+func TestListCache_WatchTimeline(t *testing.T) {
+	cache, registry := newTestCache(time.Minute)
+	hub := notify.NewHub[domain.TimelineEvent](notify.WithBuffer(1))
+	timeline := notify.NewTimelineRepository(memory.NewTimelineRepository(), hub)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		cache.WatchTimeline(ctx, timeline)
+	}()
+	waitFor(t, func() bool { return hub.Subscribers(notify.AnyKey) == 1 })
+
+	calls := 0
+	load := loader(&calls, domain.Order{ID: "order-1", CustomerID: "c1"})
+	if _, err := cache.Get("c1", 10, load); err != nil {
+		t.Fatalf("get: %v", err)
+	}
+	if err := timeline.Append(domain.TimelineEvent{OrderID: "order-1", Type: "StatusChanged", Occurred: time.Now()}); err != nil {
+		t.Fatalf("append: %v", err)
+	}
+	waitFor(t, func() bool {
+		return metricValue(t, registry, "oms_order_list_cache_invalidations_total", metricstest.Labels{"source": "timeline"}) == 1
+	})
+	if _, _ = cache.Get("c1", 10, load); calls != 2 {
+		t.Fatalf("timeline event must invalidate the list, loads=%d", calls)
+	}
+
+	cancel()
+	<-done
+	if hub.Subscribers(notify.AnyKey) != 0 {
+		t.Fatal("watcher must unsubscribe on shutdown")
+	}
+}
+
+func TestListCache_WatchTimelineResyncsAfterLag(t *testing.T) {
+	cache, registry := newTestCache(time.Minute)
+	hub := notify.NewHub[domain.TimelineEvent](notify.WithBuffer(1))
+	watcher := &blockingWatcher{timeline: notify.NewTimelineRepository(memory.NewTimelineRepository(), hub), gate: make(chan struct{})}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go cache.WatchTimeline(ctx, watcher)
+	waitFor(t, func() bool { return hub.Subscribers(notify.AnyKey) == 1 })
+
+	// Пока наблюдатель держит подписку, не читая её, второе событие переполняет буфер.
+	hub.Publish("order-1", domain.TimelineEvent{OrderID: "order-1"})
+	hub.Publish("order-2", domain.TimelineEvent{OrderID: "order-2"})
+	close(watcher.gate)
+
+	waitFor(t, func() bool {
+		return metricValue(t, registry, "oms_order_list_cache_invalidations_total", metricstest.Labels{"source": "resync"}) == 1
+	})
+	waitFor(t, func() bool { return hub.Subscribers(notify.AnyKey) == 1 })
+}
+
+// blockingWatcher отдаёт первую подписку только после закрытия gate, чтобы тест успел
+// переполнить её буфер.
+type blockingWatcher struct {
+	timeline *notify.TimelineRepository
+	gate     chan struct{}
+}
+
+func (w *blockingWatcher) Watch(orderID string, withHistory bool) (*notify.TimelineStream, error) {
+	stream, err := w.timeline.Watch(orderID, withHistory)
+	<-w.gate
+	return stream, err
+}
+
+func metricValue(t *testing.T, registry *prometheus.Registry, name string, labels metricstest.Labels) float64 {
+	t.Helper()
+	value, _ := metricstest.Scrape(t, registry).Value(name, labels)
+	return value
+}
+
+func waitFor(t *testing.T, cond func() bool) {
+	t.Helper()
+	deadline := time.Now().Add(2 * time.Second)
+	for !cond() {
+		if time.Now().After(deadline) {
+			t.Fatal("condition not met in time")
+		}
+		time.Sleep(5 * time.Millisecond)
+	}
+}
@@ -0,0 +1,211 @@
+package outbox
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	log "github.com/sirupsen/logrus"
+
+	"github.com/vladislavdragonenkov/oms/internal/domain"
+	"github.com/vladislavdragonenkov/oms/internal/metrics"
+)
+
+const (
+	defaultCleanupInterval  = time.Hour
+	defaultCleanupBatchSize = 1000
+	defaultSentRetention    = 7 * 24 * time.Hour
+	// minSentRetention защищает от конфигурации, при которой только что отправленные события
+	// удаляются раньше, чем их успеют разобрать при инциденте.
+	minSentRetention = time.Hour
+)
+
+// cleanupMetrics — метрики очистки outbox.
+type cleanupMetrics struct {
+	runsTotal    *prometheus.CounterVec
+	deletedTotal prometheus.Counter
+	lastDeleted  prometheus.Gauge
+}
+
+func newCleanupMetrics(registerer prometheus.Registerer) cleanupMetrics {
+	return cleanupMetrics{
+		runsTotal: metrics.Register(registerer, prometheus.NewCounterVec(prometheus.CounterOpts{
+			Name: "oms_outbox_cleanup_runs_total",
+			Help: "Total number of outbox cleanup runs grouped by result.",
+		}, []string{"result"})),
+		deletedTotal: metrics.Register(registerer, prometheus.NewCounter(prometheus.CounterOpts{
+			Name: "oms_outbox_cleanup_deleted_total",
+			Help: "Total number of sent outbox messages reclaimed by the cleanup worker.",
+		})),
+		lastDeleted: metrics.Register(registerer, prometheus.NewGauge(prometheus.GaugeOpts{
+			Name: "oms_outbox_cleanup_last_deleted",
+			Help: "Number of sent outbox messages deleted during the last cleanup run.",
+		})),
+	}
+}
+
+// CleanupOptions задает параметры воркера очистки outbox.
+type CleanupOptions struct {
+	Logger    *log.Entry
+	Interval  time.Duration
+	BatchSize int
+	// Retention — сколько хранить sent-сообщения после публикации.
+	Retention  time.Duration
+	Registerer prometheus.Registerer
+}
+
+// CleanupOption настраивает CleanupWorker.
+type CleanupOption func(*CleanupOptions)
+
+// WithCleanupLogger задает logger для воркера очистки.
+func WithCleanupLogger(logger *log.Entry) CleanupOption {
+	return func(opts *CleanupOptions) {
+		opts.Logger = logger
+	}
+}
+
+// WithCleanupInterval задает интервал между cleanup-циклами.
+func WithCleanupInterval(interval time.Duration) CleanupOption {
+	return func(opts *CleanupOptions) {
+		opts.Interval = interval
+	}
+}
+
+// WithCleanupBatchSize задает размер batch для одного удаления.
+func WithCleanupBatchSize(batchSize int) CleanupOption {
+	return func(opts *CleanupOptions) {
+		opts.BatchSize = batchSize
+	}
+}
+
+// WithSentRetention задает срок хранения опубликованных сообщений.
+func WithSentRetention(retention time.Duration) CleanupOption {
+	return func(opts *CleanupOptions) {
+		opts.Retention = retention
+	}
+}
+
+// WithCleanupRegisterer задает реестр метрик воркера очистки.
+func WithCleanupRegisterer(registerer prometheus.Registerer) CleanupOption {
+	return func(opts *CleanupOptions) {
+		opts.Registerer = registerer
+	}
+}
+
+// CleanupWorker периодически удаляет опубликованные outbox-сообщения старше retention.
+// Неотправленные и failed-сообщения остаются для публикации и разбора.
+type CleanupWorker struct {
+	repo      domain.OutboxRepository
+	logger    *log.Entry
+	interval  time.Duration
+	batchSize int
+	retention time.Duration
+	metrics   cleanupMetrics
+}
+
+// NewCleanupWorker создает воркер очистки outbox.
+func NewCleanupWorker(repo domain.OutboxRepository, options ...CleanupOption) *CleanupWorker {
+	opts := CleanupOptions{
+		Interval:  defaultCleanupInterval,
+		BatchSize: defaultCleanupBatchSize,
+		Retention: defaultSentRetention,
+	}
+	for _, option := range options {
+		option(&opts)
+	}
+
+	logger := opts.Logger
+	if logger == nil {
+		logger = log.WithField("component", "outbox-cleanup-worker")
+	}
+
+	if opts.Interval <= 0 {
+		opts.Interval = defaultCleanupInterval
+	}
+	if opts.BatchSize <= 0 {
+		opts.BatchSize = defaultCleanupBatchSize
+	}
+	if opts.Retention < minSentRetention {
+		logger.WithFields(log.Fields{
+			"retention": opts.Retention,
+			"minimum":   minSentRetention,
+		}).Warn("outbox sent retention is below minimum, using minimum")
+		opts.Retention = minSentRetention
+	}
+
+	return &CleanupWorker{
+		repo:      repo,
+		logger:    logger,
+		interval:  opts.Interval,
+		batchSize: opts.BatchSize,
+		retention: opts.Retention,
+		metrics:   newCleanupMetrics(opts.Registerer),
+	}
+}
+
+// Run запускает периодическую очистку до отмены ctx.
+func (w *CleanupWorker) Run(ctx context.Context) {
+	if w.repo == nil {
+		w.logger.Warn("outbox cleanup worker is disabled: repo is nil")
+		return
+	}
+
+	w.cleanup(ctx, time.Now().UTC())
+
+	ticker := time.NewTicker(w.interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			w.cleanup(ctx, time.Now().UTC())
+		}
+	}
+}
+
+func (w *CleanupWorker) cleanup(ctx context.Context, now time.Time) {
+	deleted, err := w.DeleteSent(ctx, now.Add(-w.retention))
+	if err != nil {
+		if errors.Is(err, context.Canceled) {
+			return
+		}
+		w.metrics.runsTotal.WithLabelValues("error").Inc()
+		w.logger.WithError(err).WithField("deleted", deleted).Warn("outbox cleanup run failed")
+		return
+	}
+
+	w.metrics.runsTotal.WithLabelValues("ok").Inc()
+	w.metrics.lastDeleted.Set(float64(deleted))
+	if deleted > 0 {
+		w.logger.WithField("deleted", deleted).Info("outbox cleanup completed")
+	}
+}
+
+// DeleteSent удаляет sent-сообщения, опубликованные не позже before, порциями batchSize.
+func (w *CleanupWorker) DeleteSent(ctx context.Context, before time.Time) (int, error) {
+	totalDeleted := 0
+	for {
+		if err := ctx.Err(); err != nil {
+			return totalDeleted, err
+		}
+
+		deleted, err := w.repo.DeleteSent(before, w.batchSize)
+		if err != nil {
+			return totalDeleted, err
+		}
+
+		totalDeleted += deleted
+		if deleted > 0 {
+			w.metrics.deletedTotal.Add(float64(deleted))
+		}
+
+		if deleted < w.batchSize {
+			break
+		}
+	}
+
+	return totalDeleted, nil
+}
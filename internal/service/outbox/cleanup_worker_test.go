@@ -0,0 +1,92 @@
+package outbox
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+)
+
+func TestCleanupWorker_DeleteSent_Batches(t *testing.T) {
+	t.Parallel()
+
+	repo := &stubOutboxRepo{deleteResults: []int{2, 2, 1}}
+	worker := NewCleanupWorker(repo, WithCleanupBatchSize(2))
+
+	deleted, err := worker.DeleteSent(context.Background(), time.Now().UTC())
+	if err != nil {
+		t.Fatalf("DeleteSent failed: %v", err)
+	}
+	if deleted != 5 {
+		t.Fatalf("unexpected deleted total: got=%d want=5", deleted)
+	}
+	if repo.deleteCalls != 3 {
+		t.Fatalf("unexpected delete calls: got=%d want=3", repo.deleteCalls)
+	}
+}
+
+func TestCleanupWorker_DeleteSent_Error(t *testing.T) {
+	t.Parallel()
+
+	repo := &stubOutboxRepo{deleteErr: errors.New("boom")}
+	worker := NewCleanupWorker(repo, WithCleanupBatchSize(10))
+
+	deleted, err := worker.DeleteSent(context.Background(), time.Now().UTC())
+	if err == nil {
+		t.Fatal("expected DeleteSent error")
+	}
+	if deleted != 0 {
+		t.Fatalf("unexpected deleted total: got=%d want=0", deleted)
+	}
+}
+
+func TestCleanupWorker_Run_StopsOnContextCancel(t *testing.T) {
+	t.Parallel()
+
+	repo := &stubOutboxRepo{}
+	worker := NewCleanupWorker(repo, WithCleanupInterval(5*time.Millisecond))
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		worker.Run(ctx)
+	}()
+
+	time.Sleep(20 * time.Millisecond)
+	cancel()
+
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		t.Fatal("cleanup worker did not stop on context cancel")
+	}
+}
+
+func TestNewCleanupWorker_OptionsAndNormalization(t *testing.T) {
+	t.Parallel()
+
+	logger := log.WithField("test", "outbox-cleanup-worker")
+	worker := NewCleanupWorker(
+		&stubOutboxRepo{},
+		WithCleanupLogger(logger),
+		WithCleanupInterval(0),
+		WithCleanupBatchSize(0),
+		WithSentRetention(time.Minute),
+	)
+
+	if worker.logger != logger {
+		t.Fatal("expected custom logger to be used")
+	}
+	if worker.interval != defaultCleanupInterval {
+		t.Fatalf("expected default interval %s, got %s", defaultCleanupInterval, worker.interval)
+	}
+	if worker.batchSize != defaultCleanupBatchSize {
+		t.Fatalf("expected default batch size %d, got %d", defaultCleanupBatchSize, worker.batchSize)
+	}
+	if worker.retention != minSentRetention {
+		t.Fatalf("expected retention to be raised to %s, got %s", minSentRetention, worker.retention)
+	}
+}
@@ -4,13 +4,15 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"sync"
 	"time"
 
 	"github.com/prometheus/client_golang/prometheus"
-	"github.com/prometheus/client_golang/prometheus/promauto"
 	log "github.com/sirupsen/logrus"
 
+	"github.com/vladislavdragonenkov/oms/internal/ctxutil"
 	"github.com/vladislavdragonenkov/oms/internal/domain"
+	"github.com/vladislavdragonenkov/oms/internal/metrics"
 )
 
 const (
@@ -20,20 +22,44 @@ const (
 	defaultRetryBaseDelay = 50 * time.Millisecond
 )
 
-var (
-	outboxPublishAttempts = promauto.NewCounterVec(prometheus.CounterOpts{
-		Name: "oms_outbox_publish_attempts_total",
-		Help: "Total number of outbox publish attempts grouped by result.",
-	}, []string{"result"})
-	outboxPendingRecords = promauto.NewGauge(prometheus.GaugeOpts{
-		Name: "oms_outbox_pending_records",
-		Help: "Current number of pending records in transactional outbox.",
-	})
-	outboxOldestPendingAge = promauto.NewGauge(prometheus.GaugeOpts{
-		Name: "oms_outbox_oldest_pending_age_seconds",
-		Help: "Age in seconds of the oldest pending outbox record.",
-	})
-)
+// publishLatencyBuckets покрывают путь от мгновенной публикации до backlog'а в несколько минут.
+var publishLatencyBuckets = []float64{0.01, 0.05, 0.1, 0.25, 0.5, 1, 2.5, 5, 10, 30, 60, 120, 300}
+
+// workerMetrics — метрики публикации outbox.
+type workerMetrics struct {
+	publishAttempts  *prometheus.CounterVec
+	pendingRecords   prometheus.Gauge
+	oldestPendingAge prometheus.Gauge
+	// events и publishLatency размечены event_type: алерт нужен на задержку конкретного класса событий.
+	events         *prometheus.CounterVec
+	publishLatency *prometheus.HistogramVec
+}
+
+func newWorkerMetrics(registerer prometheus.Registerer) workerMetrics {
+	return workerMetrics{
+		publishAttempts: metrics.Register(registerer, prometheus.NewCounterVec(prometheus.CounterOpts{
+			Name: "oms_outbox_publish_attempts_total",
+			Help: "Total number of outbox publish attempts grouped by result.",
+		}, []string{"result"})),
+		pendingRecords: metrics.Register(registerer, prometheus.NewGauge(prometheus.GaugeOpts{
+			Name: "oms_outbox_pending_records",
+			Help: "Current number of pending records in transactional outbox.",
+		})),
+		oldestPendingAge: metrics.Register(registerer, prometheus.NewGauge(prometheus.GaugeOpts{
+			Name: "oms_outbox_oldest_pending_age_seconds",
+			Help: "Age in seconds of the oldest pending outbox record.",
+		})),
+		events: metrics.Register(registerer, prometheus.NewCounterVec(prometheus.CounterOpts{
+			Name: "oms_outbox_publish_events_total",
+			Help: "Total number of outbox events processed grouped by event type and final result.",
+		}, []string{"event_type", "result"})),
+		publishLatency: metrics.Register(registerer, prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Name:    "oms_outbox_publish_latency_seconds",
+			Help:    "Latency from outbox enqueue to successful publish grouped by event type.",
+			Buckets: publishLatencyBuckets,
+		}, []string{"event_type"})),
+	}
+}
 
 // WorkerOptions задаёт параметры outbox worker.
 type WorkerOptions struct {
@@ -43,6 +69,7 @@ type WorkerOptions struct {
 	BatchSize      int
 	MaxAttempts    int
 	RetryBaseDelay time.Duration
+	Registerer     prometheus.Registerer
 }
 
 // Option настраивает Worker.
@@ -90,12 +117,27 @@ func WithRetryBaseDelay(delay time.Duration) Option {
 	}
 }
 
+// WithRegisterer задаёт реестр метрик воркера.
+func WithRegisterer(registerer prometheus.Registerer) Option {
+	return func(opts *WorkerOptions) {
+		opts.Registerer = registerer
+	}
+}
+
 // Worker публикует pending-сообщения из outbox в брокер.
 type Worker struct {
-	repo           domain.OutboxRepository
-	publisher      domain.OutboxPublisher
-	dlqPublisher   domain.OutboxPublisher
-	logger         *log.Entry
+	repo         domain.OutboxRepository
+	publisher    domain.OutboxPublisher
+	dlqPublisher domain.OutboxPublisher
+	logger       *log.Entry
+	metrics      workerMetrics
+
+	mu     sync.RWMutex
+	tuning workerTuning
+}
+
+// workerTuning — параметры, которые Reconfigure меняет на лету.
+type workerTuning struct {
 	pollInterval   time.Duration
 	batchSize      int
 	maxAttempts    int
@@ -119,6 +161,17 @@ func NewWorker(repo domain.OutboxRepository, publisher domain.OutboxPublisher, o
 		logger = log.WithField("component", "outbox-worker")
 	}
 
+	return &Worker{
+		repo:         repo,
+		publisher:    publisher,
+		dlqPublisher: opts.DLQPublisher,
+		logger:       logger,
+		metrics:      newWorkerMetrics(opts.Registerer),
+		tuning:       normalizeTuning(opts),
+	}
+}
+
+func normalizeTuning(opts WorkerOptions) workerTuning {
 	if opts.PollInterval <= 0 {
 		opts.PollInterval = defaultPollInterval
 	}
@@ -131,12 +184,7 @@ func NewWorker(repo domain.OutboxRepository, publisher domain.OutboxPublisher, o
 	if opts.RetryBaseDelay < 0 {
 		opts.RetryBaseDelay = 0
 	}
-
-	return &Worker{
-		repo:           repo,
-		publisher:      publisher,
-		dlqPublisher:   opts.DLQPublisher,
-		logger:         logger,
+	return workerTuning{
 		pollInterval:   opts.PollInterval,
 		batchSize:      opts.BatchSize,
 		maxAttempts:    opts.MaxAttempts,
@@ -144,6 +192,30 @@ func NewWorker(repo domain.OutboxRepository, publisher domain.OutboxPublisher, o
 	}
 }
 
+// Reconfigure меняет частоту опроса, размер батча и параметры повторов работающего воркера;
+// остальные опции игнорируются. Новый интервал действует со следующего тика, батч и повторы —
+// со следующего цикла.
+func (w *Worker) Reconfigure(options ...Option) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	opts := WorkerOptions{
+		PollInterval:   w.tuning.pollInterval,
+		BatchSize:      w.tuning.batchSize,
+		MaxAttempts:    w.tuning.maxAttempts,
+		RetryBaseDelay: w.tuning.retryBaseDelay,
+	}
+	for _, option := range options {
+		option(&opts)
+	}
+	w.tuning = normalizeTuning(opts)
+}
+
+func (w *Worker) settings() workerTuning {
+	w.mu.RLock()
+	defer w.mu.RUnlock()
+	return w.tuning
+}
+
 // Run запускает периодический polling outbox до отмены ctx.
 func (w *Worker) Run(ctx context.Context) {
 	if w.repo == nil || w.publisher == nil {
@@ -151,7 +223,8 @@ func (w *Worker) Run(ctx context.Context) {
 		return
 	}
 
-	ticker := time.NewTicker(w.pollInterval)
+	interval := w.settings().pollInterval
+	ticker := time.NewTicker(interval)
 	defer ticker.Stop()
 
 	w.ProcessOnce(ctx)
@@ -161,48 +234,79 @@ func (w *Worker) Run(ctx context.Context) {
 			return
 		case <-ticker.C:
 			w.ProcessOnce(ctx)
+			if next := w.settings().pollInterval; next != interval {
+				interval = next
+				ticker.Reset(interval)
+			}
 		}
 	}
 }
 
 // ProcessOnce выполняет один polling-цикл.
 func (w *Worker) ProcessOnce(ctx context.Context) {
+	w.processBatch(ctx)
+}
+
+// Flush публикует накопившиеся записи батчами, пока outbox не опустеет или не истечёт ctx,
+// и возвращает число опубликованных. Вызывается при остановке, после того как дождались саг:
+// их события уходят в Kafka сейчас, а не при следующем запуске.
+func (w *Worker) Flush(ctx context.Context) int {
+	if w.repo == nil || w.publisher == nil {
+		return 0
+	}
+	flushed := 0
+	for ctx.Err() == nil {
+		published, pulled := w.processBatch(ctx)
+		flushed += published
+		if pulled < w.settings().batchSize || published == 0 {
+			break
+		}
+	}
+	return flushed
+}
+
+// processBatch публикует один батч и возвращает число опубликованных и выбранных записей.
+func (w *Worker) processBatch(ctx context.Context) (published, pulled int) {
 	if ctx.Err() != nil {
-		return
+		return 0, 0
 	}
 
 	w.refreshBacklogMetrics()
 
-	events, err := w.repo.PullPending(w.batchSize)
+	tuning := w.settings()
+	events, err := w.repo.PullPending(tuning.batchSize)
 	if err != nil {
 		w.logger.WithError(err).Warn("failed to pull pending outbox messages")
-		return
+		return 0, 0
 	}
 	if len(events) == 0 {
-		return
+		return 0, 0
 	}
 
 	for _, event := range events {
 		if ctx.Err() != nil {
-			return
+			return published, len(events)
 		}
 
-		if err := w.publishWithRetry(ctx, event); err != nil {
+		if err := w.publishWithRetry(ctx, event, tuning); err != nil {
 			w.logger.WithError(err).WithFields(log.Fields{
 				"outbox_id":  event.ID,
 				"event_type": event.EventType,
 			}).Error("outbox publish failed after retries")
-			outboxPublishAttempts.WithLabelValues("failed").Inc()
+			w.metrics.publishAttempts.WithLabelValues("failed").Inc()
+			w.metrics.events.WithLabelValues(event.EventType, "failed").Inc()
 
 			if dlqErr := w.publishToDLQ(event, err); dlqErr != nil {
 				w.logger.WithError(dlqErr).WithField("outbox_id", event.ID).Warn("failed to publish to DLQ")
-				outboxPublishAttempts.WithLabelValues("dlq_failed").Inc()
+				w.metrics.publishAttempts.WithLabelValues("dlq_failed").Inc()
 			}
 			if markErr := w.repo.MarkFailed(event.ID); markErr != nil {
 				w.logger.WithError(markErr).WithField("outbox_id", event.ID).Warn("failed to mark outbox as failed")
 			}
 			continue
 		}
+		w.observePublished(event)
+		published++
 
 		if err := w.repo.MarkSent(event.ID); err != nil {
 			w.logger.WithError(err).WithField("outbox_id", event.ID).Warn("failed to mark outbox as sent")
@@ -210,37 +314,45 @@ func (w *Worker) ProcessOnce(ctx context.Context) {
 	}
 
 	w.refreshBacklogMetrics()
+	return published, len(events)
 }
 
-func (w *Worker) publishWithRetry(ctx context.Context, event domain.OutboxMessage) error {
+func (w *Worker) publishWithRetry(ctx context.Context, event domain.OutboxMessage, tuning workerTuning) error {
 	var lastErr error
 
-	for attempt := 1; attempt <= w.maxAttempts; attempt++ {
+	for attempt := 1; attempt <= tuning.maxAttempts; attempt++ {
 		err := w.publisher.Publish(event)
 		if err == nil {
-			outboxPublishAttempts.WithLabelValues("sent").Inc()
+			w.metrics.publishAttempts.WithLabelValues("sent").Inc()
 			return nil
 		}
 		lastErr = err
-		outboxPublishAttempts.WithLabelValues("retry_error").Inc()
+		w.metrics.publishAttempts.WithLabelValues("retry_error").Inc()
 
-		if attempt >= w.maxAttempts {
+		if attempt >= tuning.maxAttempts {
 			break
 		}
 
-		delay := w.retryBackoff(attempt)
-		if delay <= 0 {
-			continue
-		}
-
-		select {
-		case <-ctx.Done():
-			return ctx.Err()
-		case <-time.After(delay):
+		if err := ctxutil.Sleep(ctx, tuning.retryBackoff(attempt)); err != nil {
+			return err
 		}
 	}
 
-	return fmt.Errorf("publish failed after %d attempts: %w", w.maxAttempts, lastErr)
+	return fmt.Errorf("publish failed after %d attempts: %w", tuning.maxAttempts, lastErr)
+}
+
+// observePublished учитывает успешную публикацию; задержка считается от постановки в outbox,
+// поэтому включает ожидание в backlog и повторы.
+func (w *Worker) observePublished(event domain.OutboxMessage) {
+	w.metrics.events.WithLabelValues(event.EventType, "sent").Inc()
+	if event.CreatedAt.IsZero() {
+		return
+	}
+	latency := time.Since(event.CreatedAt).Seconds()
+	if latency < 0 {
+		latency = 0
+	}
+	w.metrics.publishLatency.WithLabelValues(event.EventType).Observe(latency)
 }
 
 func (w *Worker) refreshBacklogMetrics() {
@@ -250,9 +362,9 @@ func (w *Worker) refreshBacklogMetrics() {
 		return
 	}
 
-	outboxPendingRecords.Set(float64(stats.PendingCount))
+	w.metrics.pendingRecords.Set(float64(stats.PendingCount))
 	if stats.PendingCount == 0 || stats.OldestPendingAt.IsZero() {
-		outboxOldestPendingAge.Set(0)
+		w.metrics.oldestPendingAge.Set(0)
 		return
 	}
 
@@ -260,19 +372,19 @@ func (w *Worker) refreshBacklogMetrics() {
 	if age < 0 {
 		age = 0
 	}
-	outboxOldestPendingAge.Set(age)
+	w.metrics.oldestPendingAge.Set(age)
 }
 
-func (w *Worker) retryBackoff(attempt int) time.Duration {
-	if w.retryBaseDelay <= 0 {
+func (t workerTuning) retryBackoff(attempt int) time.Duration {
+	if t.retryBaseDelay <= 0 {
 		return 0
 	}
 	if attempt <= 1 {
-		return w.retryBaseDelay
+		return t.retryBaseDelay
 	}
 
 	const maxDuration = time.Duration(1<<63 - 1)
-	delay := w.retryBaseDelay
+	delay := t.retryBaseDelay
 	for i := 1; i < attempt; i++ {
 		if delay > maxDuration/2 {
 			return maxDuration
@@ -306,6 +418,7 @@ func (w *Worker) publishToDLQ(event domain.OutboxMessage, publishErr error) erro
 		AggregateID:   event.AggregateID,
 		EventType:     event.EventType,
 		Payload:       payload,
+		Headers:       event.Headers,
 	}
 	if err := w.dlqPublisher.Publish(dlqEvent); err != nil {
 		return fmt.Errorf("publish to dlq: %w", err)
@@ -3,14 +3,18 @@ package outbox
 import (
 	"context"
 	"errors"
+	"fmt"
 	"strings"
 	"sync"
 	"testing"
 	"time"
 
+	"github.com/prometheus/client_golang/prometheus"
 	log "github.com/sirupsen/logrus"
 
 	"github.com/vladislavdragonenkov/oms/internal/domain"
+	"github.com/vladislavdragonenkov/oms/internal/metrics/metricstest"
+	"github.com/vladislavdragonenkov/oms/internal/storage/memory"
 )
 
 func TestWorker_ProcessOnce_MarkSent(t *testing.T) {
@@ -68,6 +72,7 @@ func TestWorker_ProcessOnce_MarkFailedAndDLQAfterRetries(t *testing.T) {
 	}
 	publisher := &stubPublisher{err: errors.New("publish failed")}
 	dlqPublisher := &stubPublisher{}
+	registry := prometheus.NewRegistry()
 
 	worker := NewWorker(
 		repo,
@@ -75,6 +80,7 @@ func TestWorker_ProcessOnce_MarkFailedAndDLQAfterRetries(t *testing.T) {
 		WithDLQPublisher(dlqPublisher),
 		WithRetryBaseDelay(0),
 		WithMaxAttempts(3),
+		WithRegisterer(registry),
 	)
 
 	worker.ProcessOnce(context.Background())
@@ -94,6 +100,14 @@ func TestWorker_ProcessOnce_MarkFailedAndDLQAfterRetries(t *testing.T) {
 	if got := dlqPublisher.calls(); got != 1 {
 		t.Fatalf("expected 1 DLQ publish, got %d", got)
 	}
+
+	metricstest.RequireValue(t, registry, "oms_outbox_publish_attempts_total", metricstest.Labels{"result": "retry_error"}, 3)
+	metricstest.RequireValue(t, registry, "oms_outbox_publish_attempts_total", metricstest.Labels{"result": "failed"}, 1)
+	metricstest.RequireAbsent(t, registry, "oms_outbox_publish_attempts_total", metricstest.Labels{"result": "dlq_failed"})
+	metricstest.RequireValue(t, registry, "oms_outbox_publish_events_total", metricstest.Labels{"event_type": "OrderStatusChanged", "result": "failed"}, 1)
+	// Stats заглушки по-прежнему видит сообщение в pending.
+	metricstest.RequireValue(t, registry, "oms_outbox_pending_records", nil, 1)
+	metricstest.RequireAtLeast(t, registry, "oms_outbox_oldest_pending_age_seconds", nil, 1)
 }
 
 func TestWorker_ProcessOnce_SuccessAfterRetry(t *testing.T) {
@@ -118,11 +132,14 @@ func TestWorker_ProcessOnce_SuccessAfterRetry(t *testing.T) {
 		},
 	}
 
+	registry := prometheus.NewRegistry()
+
 	worker := NewWorker(
 		repo,
 		publisher,
 		WithRetryBaseDelay(0),
 		WithMaxAttempts(3),
+		WithRegisterer(registry),
 	)
 
 	worker.ProcessOnce(context.Background())
@@ -136,6 +153,11 @@ func TestWorker_ProcessOnce_SuccessAfterRetry(t *testing.T) {
 	if got := len(repo.failedIDs); got != 0 {
 		t.Fatalf("expected 0 failed marks, got %d", got)
 	}
+
+	metricstest.RequireValue(t, registry, "oms_outbox_publish_attempts_total", metricstest.Labels{"result": "retry_error"}, 2)
+	metricstest.RequireValue(t, registry, "oms_outbox_publish_attempts_total", metricstest.Labels{"result": "sent"}, 1)
+	metricstest.RequireAbsent(t, registry, "oms_outbox_publish_attempts_total", metricstest.Labels{"result": "failed"})
+	metricstest.RequireValue(t, registry, "oms_outbox_publish_events_total", metricstest.Labels{"event_type": "OrderStatusChanged", "result": "sent"}, 1)
 }
 
 func TestNewWorker_OptionsAndNormalization(t *testing.T) {
@@ -155,17 +177,36 @@ func TestNewWorker_OptionsAndNormalization(t *testing.T) {
 	if worker.logger != logger {
 		t.Fatal("expected custom logger to be used")
 	}
-	if worker.batchSize != 7 {
-		t.Fatalf("expected batch size 7, got %d", worker.batchSize)
+	if worker.settings().batchSize != 7 {
+		t.Fatalf("expected batch size 7, got %d", worker.settings().batchSize)
+	}
+	if worker.settings().pollInterval != defaultPollInterval {
+		t.Fatalf("expected default poll interval %s, got %s", defaultPollInterval, worker.settings().pollInterval)
 	}
-	if worker.pollInterval != defaultPollInterval {
-		t.Fatalf("expected default poll interval %s, got %s", defaultPollInterval, worker.pollInterval)
+	if worker.settings().maxAttempts != defaultMaxAttempts {
+		t.Fatalf("expected default max attempts %d, got %d", defaultMaxAttempts, worker.settings().maxAttempts)
+	}
+	if worker.settings().retryBaseDelay != 0 {
+		t.Fatalf("expected retry base delay 0 after normalization, got %s", worker.settings().retryBaseDelay)
+	}
+}
+
+func TestWorker_Reconfigure(t *testing.T) {
+	t.Parallel()
+
+	repo := &stubOutboxRepo{}
+	worker := NewWorker(repo, &stubPublisher{}, WithBatchSize(10), WithMaxAttempts(2), WithRetryBaseDelay(time.Millisecond))
+	worker.Reconfigure(WithBatchSize(50), WithPollInterval(5*time.Second), WithMaxAttempts(0), WithLogger(nil))
+
+	got := worker.settings()
+	if got.batchSize != 50 || got.pollInterval != 5*time.Second {
+		t.Fatalf("expected new batch size and interval, got %+v", got)
 	}
-	if worker.maxAttempts != defaultMaxAttempts {
-		t.Fatalf("expected default max attempts %d, got %d", defaultMaxAttempts, worker.maxAttempts)
+	if got.maxAttempts != defaultMaxAttempts || got.retryBaseDelay != time.Millisecond {
+		t.Fatalf("expected invalid attempts normalized and delay kept, got %+v", got)
 	}
-	if worker.retryBaseDelay != 0 {
-		t.Fatalf("expected retry base delay 0 after normalization, got %s", worker.retryBaseDelay)
+	if worker.logger == nil {
+		t.Fatal("Reconfigure must not touch logger")
 	}
 }
 
@@ -323,15 +364,15 @@ func TestWorker_RetryBackoff(t *testing.T) {
 	t.Parallel()
 
 	noDelayWorker := NewWorker(&stubOutboxRepo{}, &stubPublisher{}, WithRetryBaseDelay(0))
-	if got := noDelayWorker.retryBackoff(3); got != 0 {
+	if got := noDelayWorker.settings().retryBackoff(3); got != 0 {
 		t.Fatalf("expected zero delay when retry base delay is 0, got %s", got)
 	}
 
 	worker := NewWorker(&stubOutboxRepo{}, &stubPublisher{}, WithRetryBaseDelay(10*time.Millisecond))
-	if got := worker.retryBackoff(1); got != 10*time.Millisecond {
+	if got := worker.settings().retryBackoff(1); got != 10*time.Millisecond {
 		t.Fatalf("expected first attempt delay to equal base delay, got %s", got)
 	}
-	if got := worker.retryBackoff(3); got != 40*time.Millisecond {
+	if got := worker.settings().retryBackoff(3); got != 40*time.Millisecond {
 		t.Fatalf("expected exponential delay 40ms, got %s", got)
 	}
 
@@ -341,7 +382,7 @@ func TestWorker_RetryBackoff(t *testing.T) {
 		&stubPublisher{},
 		WithRetryBaseDelay(maxDuration/2+1),
 	)
-	if got := overflowWorker.retryBackoff(2); got != maxDuration {
+	if got := overflowWorker.settings().retryBackoff(2); got != maxDuration {
 		t.Fatalf("expected overflow guard to cap delay at max duration, got %s", got)
 	}
 }
@@ -350,11 +391,15 @@ func TestWorker_RefreshBacklogMetrics_StatsErrorAndFutureTimestamp(t *testing.T)
 	t.Parallel()
 
 	errorRepo := &stubOutboxRepo{statsErr: errors.New("stats error")}
-	errorWorker := NewWorker(errorRepo, &stubPublisher{})
+	errorRegistry := prometheus.NewRegistry()
+	errorWorker := NewWorker(errorRepo, &stubPublisher{}, WithRegisterer(errorRegistry))
+	errorWorker.metrics.pendingRecords.Set(4)
 	errorWorker.refreshBacklogMetrics()
 	if errorRepo.statsCalls != 1 {
 		t.Fatalf("expected stats to be called once on error path, got %d", errorRepo.statsCalls)
 	}
+	// Ошибка Stats не должна обнулять backlog: иначе алерт на рост очереди замолчит.
+	metricstest.RequireValue(t, errorRegistry, "oms_outbox_pending_records", nil, 4)
 
 	futureRepo := &stubOutboxRepo{
 		stats: domain.OutboxStats{
@@ -362,11 +407,43 @@ func TestWorker_RefreshBacklogMetrics_StatsErrorAndFutureTimestamp(t *testing.T)
 			OldestPendingAt: time.Now().UTC().Add(5 * time.Second),
 		},
 	}
-	futureWorker := NewWorker(futureRepo, &stubPublisher{})
+	futureRegistry := prometheus.NewRegistry()
+	futureWorker := NewWorker(futureRepo, &stubPublisher{}, WithRegisterer(futureRegistry))
 	futureWorker.refreshBacklogMetrics()
 	if futureRepo.statsCalls != 1 {
 		t.Fatalf("expected stats to be called once for future timestamp path, got %d", futureRepo.statsCalls)
 	}
+	metricstest.RequireValue(t, futureRegistry, "oms_outbox_pending_records", nil, 1)
+	metricstest.RequireValue(t, futureRegistry, "oms_outbox_oldest_pending_age_seconds", nil, 0)
+}
+
+func TestWorker_FlushDrainsBacklog(t *testing.T) {
+	t.Parallel()
+
+	repo := memory.NewOutboxRepository()
+	for i := range 5 {
+		if _, err := repo.Enqueue(domain.OutboxMessage{AggregateType: "order", AggregateID: fmt.Sprintf("order-%d", i), EventType: "OrderStatusChanged"}); err != nil {
+			t.Fatalf("enqueue: %v", err)
+		}
+	}
+	publisher := &stubPublisher{}
+	worker := NewWorker(repo, publisher, WithBatchSize(2), WithRetryBaseDelay(0), WithRegisterer(prometheus.NewRegistry()))
+
+	if flushed := worker.Flush(context.Background()); flushed != 5 {
+		t.Fatalf("expected 5 flushed records, got %d", flushed)
+	}
+	if stats, _ := repo.Stats(); stats.PendingCount != 0 {
+		t.Fatalf("expected empty outbox, got %d pending", stats.PendingCount)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if _, err := repo.Enqueue(domain.OutboxMessage{AggregateType: "order", AggregateID: "order-late", EventType: "OrderStatusChanged"}); err != nil {
+		t.Fatalf("enqueue: %v", err)
+	}
+	if flushed := worker.Flush(ctx); flushed != 0 || publisher.calls() != 5 {
+		t.Fatalf("expired context must stop the flush, flushed=%d calls=%d", flushed, publisher.calls())
+	}
 }
 
 type stubOutboxRepo struct {
@@ -378,14 +455,21 @@ type stubOutboxRepo struct {
 	statsErr      error
 	markSentErr   error
 	markFailedErr error
+	deleteResults []int
+	deleteErr     error
 	pullCalls     int
 	statsCalls    int
+	deleteCalls   int
 }
 
 func (s *stubOutboxRepo) Enqueue(msg domain.OutboxMessage) (domain.OutboxMessage, error) {
 	return msg, nil
 }
 
+func (s *stubOutboxRepo) EnqueueBatch(msgs []domain.OutboxMessage) ([]domain.OutboxMessage, error) {
+	return msgs, nil
+}
+
 func (s *stubOutboxRepo) PullPending(limit int) ([]domain.OutboxMessage, error) {
 	s.pullCalls++
 	if s.pullErr != nil {
@@ -426,6 +510,19 @@ func (s *stubOutboxRepo) MarkFailed(id string) error {
 	return s.markFailedErr
 }
 
+func (s *stubOutboxRepo) DeleteSent(time.Time, int) (int, error) {
+	s.deleteCalls++
+	if s.deleteErr != nil {
+		return 0, s.deleteErr
+	}
+	if len(s.deleteResults) == 0 {
+		return 0, nil
+	}
+	deleted := s.deleteResults[0]
+	s.deleteResults = s.deleteResults[1:]
+	return deleted, nil
+}
+
 type stubPublisher struct {
 	mu             sync.Mutex
 	err            error
@@ -485,3 +582,44 @@ func TestWorker_Run_StopsOnContextCancel(t *testing.T) {
 		t.Fatal("worker did not stop on context cancel")
 	}
 }
+
+func TestNewWorker_WithRegisterer(t *testing.T) {
+	t.Parallel()
+
+	registry := prometheus.NewRegistry()
+	repo := &stubOutboxRepo{
+		pending: []domain.OutboxMessage{{ID: "msg-1", AggregateType: "order", AggregateID: "order-1", EventType: "OrderStatusChanged"}},
+	}
+	worker := NewWorker(repo, &stubPublisher{}, WithRetryBaseDelay(0), WithRegisterer(registry))
+	worker.ProcessOnce(context.Background())
+
+	metricstest.RequireValue(t, registry, "oms_outbox_publish_attempts_total", metricstest.Labels{"result": "sent"}, 1)
+}
+
+func TestWorker_PublishLatencyAndEventTypeMetrics(t *testing.T) {
+	t.Parallel()
+
+	registry := prometheus.NewRegistry()
+	repo := &stubOutboxRepo{
+		pending: []domain.OutboxMessage{
+			{ID: "msg-1", EventType: "OrderCanceled", CreatedAt: time.Now().Add(-3 * time.Second)},
+			{ID: "msg-2", EventType: "OrderStatusChanged"},
+		},
+	}
+	publisher := &stubPublisher{sequenceErrors: []error{nil, errors.New("broker down")}}
+	worker := NewWorker(repo, publisher, WithRetryBaseDelay(0), WithMaxAttempts(1), WithRegisterer(registry))
+	worker.ProcessOnce(context.Background())
+
+	metricstest.RequireValue(t, registry, "oms_outbox_publish_events_total", metricstest.Labels{"event_type": "OrderCanceled", "result": "sent"}, 1)
+	metricstest.RequireValue(t, registry, "oms_outbox_publish_events_total", metricstest.Labels{"event_type": "OrderStatusChanged", "result": "failed"}, 1)
+
+	snapshot := metricstest.Scrape(t, registry)
+	if n := snapshot.Series("oms_outbox_publish_latency_seconds", nil); n != 1 {
+		t.Fatalf("latency must be observed only for published events, got %d series", n)
+	}
+	count, _ := snapshot.Value("oms_outbox_publish_latency_seconds", metricstest.Labels{"event_type": "OrderCanceled"})
+	sum, _ := snapshot.Sum("oms_outbox_publish_latency_seconds", metricstest.Labels{"event_type": "OrderCanceled"})
+	if count != 1 || sum < 3 {
+		t.Fatalf("expected one sample of at least 3s, got count=%v sum=%v", count, sum)
+	}
+}
@@ -2,22 +2,31 @@ package payment
 
 import "github.com/vladislavdragonenkov/oms/internal/domain"
 
-// MockService — конфигурируемая заглушка PaymentService для тестов.
+// MockService — конфигурируемая заглушка PaymentService для тестов. PayStatus=authorized
+// включает двухфазную оплату: сага ждёт Capture.
 type MockService struct {
-	PayStatus    domain.PaymentStatus
-	PayErr       error
-	RefundStatus domain.PaymentStatus
-	RefundErr    error
+	PayStatus     domain.PaymentStatus
+	PayErr        error
+	RefundStatus  domain.PaymentStatus
+	RefundErr     error
+	CaptureStatus domain.PaymentStatus
+	CaptureErr    error
+	VoidStatus    domain.PaymentStatus
+	VoidErr       error
 
-	PayCalls    int
-	RefundCalls int
+	PayCalls     int
+	RefundCalls  int
+	CaptureCalls int
+	VoidCalls    int
 }
 
 // NewMockService возвращает mock с успешным сценарием по умолчанию.
 func NewMockService() *MockService {
 	return &MockService{
-		PayStatus:    domain.PaymentStatusCaptured,
-		RefundStatus: domain.PaymentStatusRefunded,
+		PayStatus:     domain.PaymentStatusCaptured,
+		RefundStatus:  domain.PaymentStatusRefunded,
+		CaptureStatus: domain.PaymentStatusCaptured,
+		VoidStatus:    domain.PaymentStatusVoided,
 	}
 }
 
@@ -33,4 +42,19 @@ func (m *MockService) Refund(orderID string, amountMinor int64, currency string)
 	return m.RefundStatus, m.RefundErr
 }
 
-var _ domain.PaymentService = (*MockService)(nil)
+// Capture возвращает настроенный результат и считает вызовы.
+func (m *MockService) Capture(orderID string, amountMinor int64, currency string) (domain.PaymentStatus, error) {
+	m.CaptureCalls++
+	return m.CaptureStatus, m.CaptureErr
+}
+
+// Void возвращает настроенный результат и считает вызовы.
+func (m *MockService) Void(orderID string, amountMinor int64, currency string) (domain.PaymentStatus, error) {
+	m.VoidCalls++
+	return m.VoidStatus, m.VoidErr
+}
+
+var (
+	_ domain.PaymentService  = (*MockService)(nil)
+	_ domain.PaymentCapturer = (*MockService)(nil)
+)
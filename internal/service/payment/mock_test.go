@@ -29,6 +29,13 @@ func TestMockService(t *testing.T) {
 		t.Fatalf("unexpected refund status: %s", refundStatus)
 	}
 
+	if captured, err := mock.Capture("o-1", 100, "USD"); err != nil || captured != domain.PaymentStatusCaptured {
+		t.Fatalf("unexpected capture result: %s, %v", captured, err)
+	}
+	if voided, err := mock.Void("o-1", 100, "USD"); err != nil || voided != domain.PaymentStatusVoided {
+		t.Fatalf("unexpected void result: %s, %v", voided, err)
+	}
+
 	mock.PayStatus = domain.PaymentStatusFailed
 	mock.PayErr = errors.New("pay failed")
 	mock.RefundStatus = domain.PaymentStatusFailed
@@ -41,7 +48,7 @@ func TestMockService(t *testing.T) {
 		t.Fatal("expected refund error")
 	}
 
-	if mock.PayCalls != 2 || mock.RefundCalls != 2 {
-		t.Fatalf("unexpected call counters: pay=%d refund=%d", mock.PayCalls, mock.RefundCalls)
+	if mock.PayCalls != 2 || mock.RefundCalls != 2 || mock.CaptureCalls != 1 || mock.VoidCalls != 1 {
+		t.Fatalf("unexpected call counters: pay=%d refund=%d capture=%d void=%d", mock.PayCalls, mock.RefundCalls, mock.CaptureCalls, mock.VoidCalls)
 	}
 }
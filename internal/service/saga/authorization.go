@@ -0,0 +1,434 @@
+package saga
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	log "github.com/sirupsen/logrus"
+
+	"github.com/vladislavdragonenkov/oms/internal/domain"
+	"github.com/vladislavdragonenkov/oms/internal/messaging/kafka"
+	"github.com/vladislavdragonenkov/oms/internal/metrics"
+	"github.com/vladislavdragonenkov/oms/internal/timeutil"
+)
+
+const (
+	// DefaultAuthorizationTTL — типичный срок hold'а у карточных PSP.
+	DefaultAuthorizationTTL = 7 * 24 * time.Hour
+
+	defaultAuthorizationCheckInterval = time.Minute
+	defaultAuthorizationBatchSize     = 100
+	defaultAuthorizationRenewBefore   = time.Hour
+)
+
+// Исходы обработки истекающей блокировки (label action у oms_payment_authorization_expirations_total).
+const (
+	authorizationActionReauthorized = "reauthorized"
+	authorizationActionCanceled     = "canceled"
+	authorizationActionSkipped      = "skipped"
+	authorizationActionFailed       = "failed"
+)
+
+var (
+	// ErrOrderNotAuthorized — у заказа нет заблокированной суммы, списывать нечего.
+	ErrOrderNotAuthorized = errors.New("order is not authorized")
+	// ErrCaptureUnsupported — платёжный сервис заказа не поддерживает двухфазную оплату.
+	ErrCaptureUnsupported = errors.New("payment service does not support capture")
+
+	errSagaAwaitingCapture = errors.New("saga paused: payment authorized, awaiting capture")
+)
+
+// AuthorizationHandler — необязательное расширение Orchestrator для двухфазной оплаты:
+// CaptureOrder и AuthorizationExpiryWorker обращаются к нему через type assertion.
+type AuthorizationHandler interface {
+	// Capture списывает заблокированную сумму authorized-заказа и доводит сагу до подтверждения.
+	// Для paid/confirmed заказа ничего не делает.
+	Capture(ctx context.Context, orderID string) error
+	// Reauthorize заменяет истекающую блокировку новой. Если PSP отказал, заказ отменяется
+	// с освобождением резерва.
+	Reauthorize(ctx context.Context, orderID string) error
+}
+
+// WithPaymentAuthorizations задаёт хранилище блокировок и их срок (<= 0 — DefaultAuthorizationTTL).
+// Если Pay вернул PaymentStatusAuthorized, а платёжный сервис реализует domain.PaymentCapturer,
+// сага останавливается в authorized и ждёт Capture. Без этой опции authorized считается оплатой.
+func WithPaymentAuthorizations(repo domain.PaymentAuthorizationRepository, ttl time.Duration) OrchestratorOption {
+	return func(o *orchestrator) {
+		if ttl <= 0 {
+			ttl = DefaultAuthorizationTTL
+		}
+		o.authorizations = repo
+		o.authorizationTTL = ttl
+	}
+}
+
+// capturerFor возвращает двухфазный PSP заказа, если двухфазная оплата включена.
+func (o *orchestrator) capturerFor(order *domain.Order) (domain.PaymentCapturer, bool) {
+	if o.authorizations == nil {
+		return nil, false
+	}
+	capturer, ok := o.paymentsFor(order).(domain.PaymentCapturer)
+	return capturer, ok
+}
+
+// awaitCapture фиксирует блокировку суммы: заказ переходит в authorized, сага останавливается.
+func (o *orchestrator) awaitCapture(ctx context.Context, order *domain.Order) error {
+	authorizedAt := timeutil.Now()
+	auth := domain.PaymentAuthorization{
+		OrderID:      order.ID,
+		AmountMinor:  order.AmountMinor,
+		Currency:     order.Currency,
+		AuthorizedAt: authorizedAt,
+		ExpiresAt:    authorizedAt.Add(o.authorizationTTL),
+	}
+	// Запись о блокировке появляется раньше статуса: authorized-заказ без срока воркер бы не увидел.
+	if err := o.authorizations.Save(auth); err != nil {
+		o.logger.WithError(err).WithField("order_id", order.ID).Warn("failed to track payment authorization")
+		o.voidAuthorization(order)
+		o.releaseInventory(order)
+		o.failOrder(ctx, order, domain.OrderStatusCanceled, err)
+		return err
+	}
+	authorized := sagaEvent{
+		eventType:  "PaymentAuthorized",
+		payload:    map[string]interface{}{"expires_at": timeutil.Format(auth.ExpiresAt)},
+		occurredAt: authorizedAt,
+	}
+	if err := o.updateStatus(ctx, order, domain.OrderStatusAuthorized, authorized); err != nil {
+		// Заказ отменили или поставили на hold, пока шла авторизация: после release сага авторизует заново.
+		o.voidAuthorization(order)
+		return err
+	}
+
+	o.publishSagaEvent(ctx, order, kafka.StepAuthorizedMetadata{
+		AmountMinor: order.AmountMinor,
+		Currency:    order.Currency,
+		ExpiresAt:   timeutil.Format(auth.ExpiresAt),
+	})
+	o.logger.WithFields(log.Fields{
+		"order_id":   order.ID,
+		"expires_at": timeutil.Format(auth.ExpiresAt),
+	}).Info("payment authorized, order awaits capture")
+	return errSagaAwaitingCapture
+}
+
+// Capture реализует AuthorizationHandler. Ошибка PSP оставляет заказ в authorized: capture можно
+// повторить, пока блокировка не истекла. Явный отказ снимает блокировку и отменяет заказ.
+func (o *orchestrator) Capture(ctx context.Context, orderID string) error {
+	if o.metrics != nil {
+		o.metrics.RecordSagaInFlightStarted()
+		defer o.metrics.RecordSagaInFlightFinished()
+	}
+	if o.deadlineExceeded(ctx, orderID, "capture") {
+		return ctx.Err()
+	}
+
+	order, err := o.loadOrder(orderID)
+	if err != nil {
+		return err
+	}
+	switch order.Status {
+	case domain.OrderStatusPaid, domain.OrderStatusConfirmed:
+		return nil
+	case domain.OrderStatusAuthorized:
+	default:
+		return fmt.Errorf("%w: status %s", ErrOrderNotAuthorized, order.Status)
+	}
+	capturer, ok := o.capturerFor(&order)
+	if !ok {
+		return ErrCaptureUnsupported
+	}
+
+	stepStart := time.Now()
+	status, err := capturer.Capture(order.ID, order.AmountMinor, order.Currency)
+	o.recordStep(domain.SagaStepCapture, stepStart)
+	if err != nil {
+		o.logger.WithError(err).WithField("order_id", order.ID).Warn("capture failed, order stays authorized")
+		return fmt.Errorf("capture payment: %w", err)
+	}
+	if status != domain.PaymentStatusCaptured {
+		declineErr := fmt.Errorf("%w: capture returned %s", domain.ErrPaymentDeclined, status)
+		o.logger.WithField("status", status).WithField("order_id", order.ID).Warn("capture declined")
+		o.voidAuthorization(&order)
+		o.releaseInventory(&order)
+		o.failOrder(ctx, &order, domain.OrderStatusCanceled, declineErr)
+		return declineErr
+	}
+	return o.completeCapture(ctx, &order, "capture")
+}
+
+// completeCapture фиксирует списание: заказ переходит в paid и подтверждается, блокировка забывается.
+func (o *orchestrator) completeCapture(ctx context.Context, order *domain.Order, source string) error {
+	o.forgetAuthorization(order.ID)
+	if err := o.updateStatus(ctx, order, domain.OrderStatusPaid); err != nil {
+		return err
+	}
+	o.publishSagaEvent(ctx, order, kafka.StepPaidMetadata{
+		AmountMinor: order.AmountMinor,
+		Currency:    order.Currency,
+		Status:      string(domain.PaymentStatusCaptured),
+		Source:      source,
+	})
+	o.runHooks(ctx, HookAfterPay, order, Compensation{})
+	o.handleConfirm(ctx, order)
+	return nil
+}
+
+// Reauthorize реализует AuthorizationHandler. Старая блокировка снимается до новой, чтобы
+// у покупателя не висели две суммы; заказ, уже вышедший из authorized, только забывается.
+func (o *orchestrator) Reauthorize(ctx context.Context, orderID string) error {
+	order, err := o.loadOrder(orderID)
+	if err != nil {
+		return err
+	}
+	if order.EffectiveStatus() != domain.OrderStatusAuthorized {
+		o.forgetAuthorization(order.ID)
+		return nil
+	}
+	capturer, ok := o.capturerFor(&order)
+	if !ok {
+		return ErrCaptureUnsupported
+	}
+	auth, err := o.authorizations.Get(order.ID)
+	if errors.Is(err, domain.ErrPaymentAuthorizationNotFound) {
+		auth = domain.PaymentAuthorization{OrderID: order.ID, AmountMinor: order.AmountMinor, Currency: order.Currency}
+	} else if err != nil {
+		return fmt.Errorf("load payment authorization: %w", err)
+	}
+
+	if _, err := capturer.Void(order.ID, order.AmountMinor, order.Currency); err != nil {
+		o.logger.WithError(err).WithField("order_id", order.ID).Warn("void before reauthorization failed")
+	}
+	status, err := o.paymentsFor(&order).Pay(order.ID, order.AmountMinor, order.Currency)
+	switch {
+	case err == nil && status == domain.PaymentStatusAuthorized:
+		now := timeutil.Now()
+		auth.AuthorizedAt, auth.ExpiresAt = now, now.Add(o.authorizationTTL)
+		auth.Reauthorizations++
+		if err := o.authorizations.Save(auth); err != nil {
+			return fmt.Errorf("save payment authorization: %w", err)
+		}
+		o.emitEvent(ctx, &order, "PaymentReauthorized", map[string]interface{}{
+			"expires_at":       timeutil.Format(auth.ExpiresAt),
+			"reauthorizations": auth.Reauthorizations,
+			"ts":               timeutil.Format(now),
+		}, now)
+		return nil
+	case err == nil && status == domain.PaymentStatusCaptured:
+		// PSP списал сразу: блокировка больше не нужна.
+		return o.completeCapture(ctx, &order, "reauthorize")
+	case err == nil:
+		err = fmt.Errorf("%w: reauthorization returned %s", domain.ErrPaymentDeclined, status)
+	}
+
+	o.logger.WithError(err).WithField("order_id", order.ID).Warn("reauthorization failed, canceling order")
+	o.forgetAuthorization(order.ID)
+	o.releaseInventory(&order)
+	o.failOrder(ctx, &order, domain.OrderStatusCanceled, err)
+	return err
+}
+
+// voidAuthorization снимает блокировку у PSP и перестаёт её отслеживать. Ошибка Void не
+// мешает компенсации: hold истечёт у PSP сам.
+func (o *orchestrator) voidAuthorization(order *domain.Order) {
+	if capturer, ok := o.capturerFor(order); ok {
+		if _, err := capturer.Void(order.ID, order.AmountMinor, order.Currency); err != nil {
+			o.logger.WithError(err).WithField("order_id", order.ID).Warn("void authorization failed")
+		}
+	}
+	o.forgetAuthorization(order.ID)
+}
+
+func (o *orchestrator) forgetAuthorization(orderID string) {
+	if o.authorizations == nil {
+		return
+	}
+	if err := o.authorizations.Delete(orderID); err != nil {
+		o.logger.WithError(err).WithField("order_id", orderID).Warn("failed to delete payment authorization")
+	}
+}
+
+// AuthorizationExpiryWorker следит за сроками блокировок: за renewBefore до ExpiresAt
+// заказ авторизуется повторно (не больше maxReauthorizations раз), затем отменяется.
+// Записи заказов, которые уже списаны или отменены, удаляются.
+type AuthorizationExpiryWorker struct {
+	authorizations      domain.PaymentAuthorizationRepository
+	orders              domain.OrderRepository
+	saga                Orchestrator
+	logger              *log.Entry
+	interval            time.Duration
+	batchSize           int
+	renewBefore         time.Duration
+	maxReauthorizations int
+	sagaTimeout         runtimeTimeout
+	expirations         *prometheus.CounterVec
+	registerer          prometheus.Registerer
+}
+
+// AuthorizationExpiryOption настраивает AuthorizationExpiryWorker.
+type AuthorizationExpiryOption func(*AuthorizationExpiryWorker)
+
+// WithAuthorizationExpiryLogger задаёт logger.
+func WithAuthorizationExpiryLogger(logger *log.Entry) AuthorizationExpiryOption {
+	return func(w *AuthorizationExpiryWorker) {
+		w.logger = logger
+	}
+}
+
+// WithAuthorizationExpiryInterval задаёт период проверки сроков.
+func WithAuthorizationExpiryInterval(interval time.Duration) AuthorizationExpiryOption {
+	return func(w *AuthorizationExpiryWorker) {
+		w.interval = interval
+	}
+}
+
+// WithAuthorizationExpiryBatchSize ограничивает число блокировок за один проход.
+func WithAuthorizationExpiryBatchSize(batchSize int) AuthorizationExpiryOption {
+	return func(w *AuthorizationExpiryWorker) {
+		w.batchSize = batchSize
+	}
+}
+
+// WithAuthorizationRenewBefore задаёт, за сколько до истечения блокировка обрабатывается.
+func WithAuthorizationRenewBefore(margin time.Duration) AuthorizationExpiryOption {
+	return func(w *AuthorizationExpiryWorker) {
+		w.renewBefore = margin
+	}
+}
+
+// WithMaxReauthorizations задаёт число повторных авторизаций заказа; 0 — отменять сразу.
+func WithMaxReauthorizations(limit int) AuthorizationExpiryOption {
+	return func(w *AuthorizationExpiryWorker) {
+		w.maxReauthorizations = max(limit, 0)
+	}
+}
+
+// WithAuthorizationExpirySagaTimeout задаёт дедлайн повторной авторизации или отмены.
+func WithAuthorizationExpirySagaTimeout(timeout time.Duration) AuthorizationExpiryOption {
+	return func(w *AuthorizationExpiryWorker) {
+		w.sagaTimeout.set(timeout)
+	}
+}
+
+// WithAuthorizationExpiryRegisterer задаёт реестр метрик.
+func WithAuthorizationExpiryRegisterer(registerer prometheus.Registerer) AuthorizationExpiryOption {
+	return func(w *AuthorizationExpiryWorker) {
+		w.registerer = registerer
+	}
+}
+
+// NewAuthorizationExpiryWorker создаёт воркер истекающих блокировок.
+func NewAuthorizationExpiryWorker(authorizations domain.PaymentAuthorizationRepository, orders domain.OrderRepository, orchestrator Orchestrator, opts ...AuthorizationExpiryOption) *AuthorizationExpiryWorker {
+	w := &AuthorizationExpiryWorker{
+		authorizations: authorizations,
+		orders:         orders,
+		saga:           orchestrator,
+		interval:       defaultAuthorizationCheckInterval,
+		batchSize:      defaultAuthorizationBatchSize,
+		renewBefore:    defaultAuthorizationRenewBefore,
+	}
+	for _, opt := range opts {
+		if opt != nil {
+			opt(w)
+		}
+	}
+	if w.logger == nil {
+		w.logger = log.WithField("component", "authorization-expiry")
+	}
+	if w.interval <= 0 {
+		w.interval = defaultAuthorizationCheckInterval
+	}
+	if w.batchSize <= 0 {
+		w.batchSize = defaultAuthorizationBatchSize
+	}
+	w.expirations = metrics.Register(w.registerer, prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: "oms_payment_authorization_expirations_total",
+		Help: "Expiring payment authorizations grouped by action (reauthorized, canceled, skipped, failed).",
+	}, []string{"action"}))
+	return w
+}
+
+// SetSagaTimeout меняет дедлайн операций, запущенных после этого вызова.
+func (w *AuthorizationExpiryWorker) SetSagaTimeout(timeout time.Duration) {
+	w.sagaTimeout.set(timeout)
+}
+
+// Run проверяет сроки блокировок до отмены ctx.
+func (w *AuthorizationExpiryWorker) Run(ctx context.Context) {
+	ticker := time.NewTicker(w.interval)
+	defer ticker.Stop()
+
+	for {
+		if _, err := w.RunOnce(ctx); err != nil {
+			w.logger.WithError(err).Warn("payment authorization expiry pass failed")
+		}
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// RunOnce обрабатывает блокировки, истекающие в ближайшие renewBefore, и возвращает их число.
+func (w *AuthorizationExpiryWorker) RunOnce(ctx context.Context) (int, error) {
+	expiring, err := w.authorizations.ListExpiring(timeutil.Now().Add(w.renewBefore), w.batchSize)
+	if err != nil {
+		return 0, fmt.Errorf("list expiring payment authorizations: %w", err)
+	}
+
+	for i, auth := range expiring {
+		if ctx.Err() != nil {
+			return i, ctx.Err()
+		}
+		action := w.handle(ctx, auth)
+		w.expirations.WithLabelValues(action).Inc()
+	}
+	return len(expiring), nil
+}
+
+func (w *AuthorizationExpiryWorker) handle(ctx context.Context, auth domain.PaymentAuthorization) string {
+	logger := w.logger.WithFields(log.Fields{
+		"order_id":   auth.OrderID,
+		"expires_at": timeutil.Format(auth.ExpiresAt),
+	})
+
+	order, err := w.orders.Get(auth.OrderID)
+	switch {
+	case errors.Is(err, domain.ErrOrderNotFound):
+		return w.forget(logger, auth.OrderID)
+	case err != nil:
+		logger.WithError(err).Warn("failed to load order for expiring authorization")
+		return authorizationActionFailed
+	case order.EffectiveStatus() != domain.OrderStatusAuthorized:
+		return w.forget(logger, auth.OrderID)
+	}
+
+	sagaCtx, cancel := DetachedContext(ctx, w.sagaTimeout.get())
+	defer cancel()
+
+	handler, ok := w.saga.(AuthorizationHandler)
+	if ok && auth.Reauthorizations < w.maxReauthorizations {
+		if err := handler.Reauthorize(sagaCtx, auth.OrderID); err != nil {
+			logger.WithError(err).Warn("payment reauthorization failed")
+			return authorizationActionFailed
+		}
+		logger.WithField("reauthorizations", auth.Reauthorizations+1).Info("payment authorization renewed")
+		return authorizationActionReauthorized
+	}
+
+	w.saga.Cancel(sagaCtx, auth.OrderID, "payment authorization expired")
+	logger.Info("order canceled: payment authorization expired without capture")
+	return authorizationActionCanceled
+}
+
+func (w *AuthorizationExpiryWorker) forget(logger *log.Entry, orderID string) string {
+	if err := w.authorizations.Delete(orderID); err != nil {
+		logger.WithError(err).Warn("failed to delete stale payment authorization")
+		return authorizationActionFailed
+	}
+	return authorizationActionSkipped
+}
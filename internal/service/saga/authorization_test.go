@@ -0,0 +1,291 @@
+package saga
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+
+	"github.com/vladislavdragonenkov/oms/internal/domain"
+	"github.com/vladislavdragonenkov/oms/internal/metrics/metricstest"
+	"github.com/vladislavdragonenkov/oms/internal/service/payment"
+	"github.com/vladislavdragonenkov/oms/internal/storage/memory"
+)
+
+func trackedAuthorization(t *testing.T, authorizations domain.PaymentAuthorizationRepository) (domain.PaymentAuthorization, bool) {
+	t.Helper()
+	auth, err := authorizations.Get("order-1")
+	if errors.Is(err, domain.ErrPaymentAuthorizationNotFound) {
+		return domain.PaymentAuthorization{}, false
+	}
+	if err != nil {
+		t.Fatalf("get authorization: %v", err)
+	}
+	return auth, true
+}
+
+func TestOrchestrator_AuthorizedPaymentAwaitsCapture(t *testing.T) {
+	repo := memory.NewOrderRepository()
+	timeline := memory.NewTimelineRepository()
+	authorizations := memory.NewPaymentAuthorizationRepository()
+	payments := payment.NewMockService()
+	payments.PayStatus = domain.PaymentStatusAuthorized
+	seedOrder(t, repo, domain.OrderStatusPending)
+	orch := NewOrchestratorWithoutMetrics(repo, memory.NewOutboxRepository(), timeline, &stubInventory{}, payments, nil,
+		WithPaymentAuthorizations(authorizations, time.Hour))
+
+	before := time.Now()
+	orch.Start(context.Background(), "order-1")
+	if got := orderStatus(t, repo, "order-1"); got != domain.OrderStatusAuthorized {
+		t.Fatalf("expected authorized, got %s", got)
+	}
+	auth, ok := trackedAuthorization(t, authorizations)
+	if !ok || auth.AmountMinor != 100 || auth.ExpiresAt.Before(before.Add(time.Hour)) {
+		t.Fatalf("expected tracked authorization expiring in an hour, got %+v (found=%v)", auth, ok)
+	}
+	events, _ := timeline.List("order-1")
+	if !hasTimelineEvent(events, "PaymentAuthorized") {
+		t.Fatalf("expected PaymentAuthorized timeline event, got %+v", events)
+	}
+
+	// Повторный Start не двигает заказ без capture.
+	orch.Start(context.Background(), "order-1")
+	if got := orderStatus(t, repo, "order-1"); got != domain.OrderStatusAuthorized || payments.PayCalls != 1 {
+		t.Fatalf("expected saga to wait for capture, got %s after %d Pay calls", got, payments.PayCalls)
+	}
+
+	handler := orch.(AuthorizationHandler)
+	if err := handler.Capture(context.Background(), "order-1"); err != nil {
+		t.Fatalf("capture: %v", err)
+	}
+	if got := orderStatus(t, repo, "order-1"); got != domain.OrderStatusConfirmed {
+		t.Fatalf("expected confirmed after capture, got %s", got)
+	}
+	if _, ok := trackedAuthorization(t, authorizations); ok {
+		t.Fatal("captured authorization must be forgotten")
+	}
+	if err := handler.Capture(context.Background(), "order-1"); err != nil || payments.CaptureCalls != 1 {
+		t.Fatalf("repeated capture must be a no-op, got %v after %d calls", err, payments.CaptureCalls)
+	}
+}
+
+func TestOrchestrator_AuthorizedWithoutCapturerCountsAsPaid(t *testing.T) {
+	repo := memory.NewOrderRepository()
+	seedOrder(t, repo, domain.OrderStatusPending)
+	orch := NewOrchestratorWithoutMetrics(repo, memory.NewOutboxRepository(), memory.NewTimelineRepository(),
+		&stubInventory{}, &stubPayment{payStatus: domain.PaymentStatusAuthorized}, nil,
+		WithPaymentAuthorizations(memory.NewPaymentAuthorizationRepository(), 0))
+
+	orch.Start(context.Background(), "order-1")
+	if order, _ := repo.Get("order-1"); order.Status != domain.OrderStatusConfirmed {
+		t.Fatalf("expected confirmed, got %s", order.Status)
+	}
+}
+
+func TestOrchestrator_CaptureFailures(t *testing.T) {
+	t.Run("psp error keeps order authorized", func(t *testing.T) {
+		repo := memory.NewOrderRepository()
+		authorizations := memory.NewPaymentAuthorizationRepository()
+		payments := payment.NewMockService()
+		payments.PayStatus = domain.PaymentStatusAuthorized
+		seedOrder(t, repo, domain.OrderStatusPending)
+		orch := NewOrchestratorWithoutMetrics(repo, memory.NewOutboxRepository(), memory.NewTimelineRepository(), &stubInventory{}, payments, nil,
+			WithPaymentAuthorizations(authorizations, time.Hour))
+		orch.Start(context.Background(), "order-1")
+		payments.CaptureErr = errors.New("psp timeout")
+
+		if err := orch.(AuthorizationHandler).Capture(context.Background(), "order-1"); err == nil {
+			t.Fatal("expected capture error")
+		}
+		if got := orderStatus(t, repo, "order-1"); got != domain.OrderStatusAuthorized {
+			t.Fatalf("expected authorized, got %s", got)
+		}
+		if _, ok := trackedAuthorization(t, authorizations); !ok {
+			t.Fatal("authorization must stay tracked for a retry")
+		}
+	})
+
+	t.Run("decline voids and cancels", func(t *testing.T) {
+		repo := memory.NewOrderRepository()
+		inventory := &stubInventory{}
+		payments := payment.NewMockService()
+		payments.PayStatus = domain.PaymentStatusAuthorized
+		seedOrder(t, repo, domain.OrderStatusPending)
+		orch := NewOrchestratorWithoutMetrics(repo, memory.NewOutboxRepository(), memory.NewTimelineRepository(), inventory, payments, nil,
+			WithPaymentAuthorizations(memory.NewPaymentAuthorizationRepository(), time.Hour))
+		orch.Start(context.Background(), "order-1")
+		payments.CaptureStatus = domain.PaymentStatusFailed
+
+		err := orch.(AuthorizationHandler).Capture(context.Background(), "order-1")
+		if !errors.Is(err, domain.ErrPaymentDeclined) {
+			t.Fatalf("expected ErrPaymentDeclined, got %v", err)
+		}
+		if got := orderStatus(t, repo, "order-1"); got != domain.OrderStatusCanceled {
+			t.Fatalf("expected canceled, got %s", got)
+		}
+		if payments.VoidCalls != 1 || inventory.releaseCnt != 1 {
+			t.Fatalf("expected void and release, got void=%d release=%d", payments.VoidCalls, inventory.releaseCnt)
+		}
+	})
+
+	t.Run("not authorized", func(t *testing.T) {
+		repo := memory.NewOrderRepository()
+		seedOrder(t, repo, domain.OrderStatusReserved)
+		payments := payment.NewMockService()
+		payments.PayStatus = domain.PaymentStatusAuthorized
+		orch := NewOrchestratorWithoutMetrics(repo, memory.NewOutboxRepository(), memory.NewTimelineRepository(), &stubInventory{}, payments, nil,
+			WithPaymentAuthorizations(memory.NewPaymentAuthorizationRepository(), time.Hour))
+		if err := orch.(AuthorizationHandler).Capture(context.Background(), "order-1"); !errors.Is(err, ErrOrderNotAuthorized) {
+			t.Fatalf("expected ErrOrderNotAuthorized, got %v", err)
+		}
+	})
+}
+
+func TestOrchestrator_CancelAuthorizedVoidsInsteadOfRefund(t *testing.T) {
+	repo := memory.NewOrderRepository()
+	authorizations := memory.NewPaymentAuthorizationRepository()
+	inventory := &stubInventory{}
+	payments := payment.NewMockService()
+	payments.PayStatus = domain.PaymentStatusAuthorized
+	seedOrder(t, repo, domain.OrderStatusPending)
+	orch := NewOrchestratorWithoutMetrics(repo, memory.NewOutboxRepository(), memory.NewTimelineRepository(), inventory, payments, nil,
+		WithPaymentAuthorizations(authorizations, time.Hour))
+	orch.Start(context.Background(), "order-1")
+
+	orch.Cancel(context.Background(), "order-1", "customer request")
+	if got := orderStatus(t, repo, "order-1"); got != domain.OrderStatusCanceled {
+		t.Fatalf("expected canceled, got %s", got)
+	}
+	if payments.VoidCalls != 1 || payments.RefundCalls != 0 || inventory.releaseCnt != 1 {
+		t.Fatalf("expected void+release without refund, got void=%d refund=%d release=%d",
+			payments.VoidCalls, payments.RefundCalls, inventory.releaseCnt)
+	}
+	if _, ok := trackedAuthorization(t, authorizations); ok {
+		t.Fatal("canceled authorization must be forgotten")
+	}
+}
+
+func TestOrchestrator_Reauthorize(t *testing.T) {
+	t.Run("renews hold", func(t *testing.T) {
+		repo := memory.NewOrderRepository()
+		authorizations := memory.NewPaymentAuthorizationRepository()
+		payments := payment.NewMockService()
+		payments.PayStatus = domain.PaymentStatusAuthorized
+		seedOrder(t, repo, domain.OrderStatusPending)
+		orch := NewOrchestratorWithoutMetrics(repo, memory.NewOutboxRepository(), memory.NewTimelineRepository(), &stubInventory{}, payments, nil,
+			WithPaymentAuthorizations(authorizations, time.Hour))
+		orch.Start(context.Background(), "order-1")
+		first, _ := trackedAuthorization(t, authorizations)
+
+		if err := orch.(AuthorizationHandler).Reauthorize(context.Background(), "order-1"); err != nil {
+			t.Fatalf("reauthorize: %v", err)
+		}
+		renewed, ok := trackedAuthorization(t, authorizations)
+		if !ok || renewed.Reauthorizations != 1 || renewed.ExpiresAt.Before(first.ExpiresAt) {
+			t.Fatalf("expected renewed authorization, got %+v (was %+v)", renewed, first)
+		}
+		if payments.VoidCalls != 1 || payments.PayCalls != 2 || orderStatus(t, repo, "order-1") != domain.OrderStatusAuthorized {
+			t.Fatalf("expected void + new authorization, got void=%d pay=%d", payments.VoidCalls, payments.PayCalls)
+		}
+	})
+
+	t.Run("decline cancels order", func(t *testing.T) {
+		repo := memory.NewOrderRepository()
+		authorizations := memory.NewPaymentAuthorizationRepository()
+		inventory := &stubInventory{}
+		payments := payment.NewMockService()
+		payments.PayStatus = domain.PaymentStatusAuthorized
+		seedOrder(t, repo, domain.OrderStatusPending)
+		orch := NewOrchestratorWithoutMetrics(repo, memory.NewOutboxRepository(), memory.NewTimelineRepository(), inventory, payments, nil,
+			WithPaymentAuthorizations(authorizations, time.Hour))
+		orch.Start(context.Background(), "order-1")
+		payments.PayStatus = domain.PaymentStatusFailed
+
+		if err := orch.(AuthorizationHandler).Reauthorize(context.Background(), "order-1"); !errors.Is(err, domain.ErrPaymentDeclined) {
+			t.Fatalf("expected ErrPaymentDeclined, got %v", err)
+		}
+		if got := orderStatus(t, repo, "order-1"); got != domain.OrderStatusCanceled {
+			t.Fatalf("expected canceled, got %s", got)
+		}
+		if _, ok := trackedAuthorization(t, authorizations); ok || inventory.releaseCnt != 1 {
+			t.Fatalf("expected forgotten authorization and released stock, release=%d", inventory.releaseCnt)
+		}
+	})
+}
+
+func TestAuthorizationExpiryWorker_RunOnce(t *testing.T) {
+	registry := prometheus.NewRegistry()
+	orders := memory.NewOrderRepository()
+	authorizations := memory.NewPaymentAuthorizationRepository()
+	now := time.Now().UTC()
+	for id, status := range map[string]domain.OrderStatus{
+		"order-renew":    domain.OrderStatusAuthorized,
+		"order-exhaust":  domain.OrderStatusAuthorized,
+		"order-captured": domain.OrderStatusConfirmed,
+		"order-later":    domain.OrderStatusAuthorized,
+	} {
+		if err := orders.Create(domain.Order{ID: id, CustomerID: "c", Status: status, Currency: "USD", CreatedAt: now, UpdatedAt: now}); err != nil {
+			t.Fatalf("create order: %v", err)
+		}
+	}
+	for _, auth := range []domain.PaymentAuthorization{
+		{OrderID: "order-renew", ExpiresAt: now.Add(10 * time.Minute)},
+		{OrderID: "order-exhaust", ExpiresAt: now.Add(20 * time.Minute), Reauthorizations: 1},
+		{OrderID: "order-captured", ExpiresAt: now.Add(30 * time.Minute)},
+		{OrderID: "order-missing", ExpiresAt: now.Add(40 * time.Minute)},
+		{OrderID: "order-later", ExpiresAt: now.Add(24 * time.Hour)},
+	} {
+		if err := authorizations.Save(auth); err != nil {
+			t.Fatalf("save authorization: %v", err)
+		}
+	}
+
+	orch := &stubAuthorizationHandler{}
+	worker := NewAuthorizationExpiryWorker(authorizations, orders, orch,
+		WithMaxReauthorizations(1), WithAuthorizationExpiryRegisterer(registry))
+	processed, err := worker.RunOnce(context.Background())
+	if err != nil {
+		t.Fatalf("run once: %v", err)
+	}
+	if processed != 4 {
+		t.Fatalf("expected 4 expiring authorizations, got %d", processed)
+	}
+	if len(orch.reauthorized) != 1 || orch.reauthorized[0] != "order-renew" || orch.cancelCalls != 1 {
+		t.Fatalf("expected renew of order-renew and one cancel, got %+v", orch)
+	}
+	for _, id := range []string{"order-captured", "order-missing"} {
+		if _, err := authorizations.Get(id); !errors.Is(err, domain.ErrPaymentAuthorizationNotFound) {
+			t.Fatalf("%s: stale authorization must be deleted, got %v", id, err)
+		}
+	}
+	if _, err := authorizations.Get("order-later"); err != nil {
+		t.Fatalf("authorization outside the renew window must stay: %v", err)
+	}
+
+	metricstest.RequireValue(t, registry, "oms_payment_authorization_expirations_total", metricstest.Labels{"action": "reauthorized"}, 1)
+	metricstest.RequireValue(t, registry, "oms_payment_authorization_expirations_total", metricstest.Labels{"action": "canceled"}, 1)
+	metricstest.RequireValue(t, registry, "oms_payment_authorization_expirations_total", metricstest.Labels{"action": "skipped"}, 2)
+}
+
+type stubAuthorizationHandler struct {
+	stubOrchestrator
+	reauthorized []string
+}
+
+func (s *stubAuthorizationHandler) Capture(context.Context, string) error { return nil }
+
+func (s *stubAuthorizationHandler) Reauthorize(_ context.Context, orderID string) error {
+	s.reauthorized = append(s.reauthorized, orderID)
+	return nil
+}
+
+func hasTimelineEvent(events []domain.TimelineEvent, eventType string) bool {
+	for _, event := range events {
+		if event.Type == eventType {
+			return true
+		}
+	}
+	return false
+}
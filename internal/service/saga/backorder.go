@@ -0,0 +1,118 @@
+package saga
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/IBM/sarama"
+	log "github.com/sirupsen/logrus"
+
+	"github.com/vladislavdragonenkov/oms/internal/domain"
+	"github.com/vladislavdragonenkov/oms/internal/messaging/kafka"
+)
+
+const defaultBackorderBatchSize = 500
+
+// BackorderResumer перезапускает сагу для backordered-заказов, когда на склад пришёл товар.
+// Заказы возобновляются от старых к новым, чтобы пополнение доставалось им в порядке очереди.
+type BackorderResumer struct {
+	orders      domain.OrderRepository
+	saga        Orchestrator
+	logger      *log.Entry
+	batchSize   int
+	sagaTimeout runtimeTimeout
+}
+
+// BackorderOption настраивает BackorderResumer.
+type BackorderOption func(*BackorderResumer)
+
+// WithBackorderLogger задаёт logger.
+func WithBackorderLogger(logger *log.Entry) BackorderOption {
+	return func(r *BackorderResumer) {
+		r.logger = logger
+	}
+}
+
+// WithBackorderBatchSize ограничивает число backordered-заказов, просматриваемых за одно событие.
+func WithBackorderBatchSize(batchSize int) BackorderOption {
+	return func(r *BackorderResumer) {
+		r.batchSize = batchSize
+	}
+}
+
+// WithBackorderSagaTimeout задаёт дедлайн каждой возобновлённой саги.
+func WithBackorderSagaTimeout(timeout time.Duration) BackorderOption {
+	return func(r *BackorderResumer) {
+		r.sagaTimeout.set(timeout)
+	}
+}
+
+// NewBackorderResumer создаёт обработчик событий пополнения склада.
+func NewBackorderResumer(orders domain.OrderRepository, orchestrator Orchestrator, opts ...BackorderOption) *BackorderResumer {
+	r := &BackorderResumer{
+		orders: orders,
+		saga:   orchestrator,
+	}
+	for _, opt := range opts {
+		if opt != nil {
+			opt(r)
+		}
+	}
+	if r.logger == nil {
+		r.logger = log.WithField("component", "backorder-resumer")
+	}
+	if r.batchSize <= 0 {
+		r.batchSize = defaultBackorderBatchSize
+	}
+	return r
+}
+
+// SetSagaTimeout меняет дедлайн саг, возобновлённых после этого вызова.
+func (r *BackorderResumer) SetSagaTimeout(timeout time.Duration) {
+	r.sagaTimeout.set(timeout)
+}
+
+// Restocked возобновляет сагу для backordered-заказов с указанным SKU и возвращает их число.
+// Если стока хватит не всем, оставшиеся заказы снова останутся в backordered.
+func (r *BackorderResumer) Restocked(ctx context.Context, sku string) (int, error) {
+	orders, err := r.orders.ListByStatus(domain.OrderStatusBackordered, r.batchSize)
+	if err != nil {
+		return 0, fmt.Errorf("list backordered orders: %w", err)
+	}
+
+	resumed := 0
+	for i := range orders {
+		if !orders[i].HasSKU(sku) {
+			continue
+		}
+		if ctx.Err() != nil {
+			return resumed, ctx.Err()
+		}
+		sagaCtx, cancel := DetachedContext(ctx, r.sagaTimeout.get())
+		r.saga.Start(sagaCtx, orders[i].ID)
+		cancel()
+		resumed++
+	}
+
+	r.logger.WithFields(log.Fields{
+		"sku":     sku,
+		"resumed": resumed,
+	}).Info("backordered orders resumed after restock")
+	return resumed, nil
+}
+
+// HandleMessage — kafka.MessageHandler для топика kafka.TopicInventoryRestock.
+func (r *BackorderResumer) HandleMessage(ctx context.Context, message *sarama.ConsumerMessage) error {
+	var event kafka.InventoryRestockEvent
+	if err := kafka.DecodeMessage(message, &event); err != nil {
+		return fmt.Errorf("decode restock event: %w", err)
+	}
+	sku := strings.TrimSpace(event.SKU)
+	if sku == "" {
+		return fmt.Errorf("restock event without sku")
+	}
+	_, err := r.Restocked(ctx, sku)
+	return err
+}
@@ -0,0 +1,142 @@
+package saga
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/IBM/sarama"
+	log "github.com/sirupsen/logrus"
+
+	"github.com/vladislavdragonenkov/oms/internal/domain"
+	"github.com/vladislavdragonenkov/oms/internal/storage/memory"
+)
+
+func newBackorderOrchestrator(repo domain.OrderRepository, timeline domain.TimelineRepository, inventory domain.InventoryService) Orchestrator {
+	return NewOrchestratorWithoutMetrics(
+		repo,
+		memory.NewOutboxRepository(),
+		timeline,
+		inventory,
+		&stubPayment{payStatus: domain.PaymentStatusCaptured},
+		log.New().WithField("test", "backorder"),
+		WithBackorders(true),
+	)
+}
+
+func timelineTypes(t *testing.T, timeline domain.TimelineRepository, orderID string) map[string]int {
+	t.Helper()
+	events, err := timeline.List(orderID)
+	if err != nil {
+		t.Fatalf("list timeline: %v", err)
+	}
+	types := make(map[string]int, len(events))
+	for _, event := range events {
+		types[event.Type]++
+	}
+	return types
+}
+
+func TestOrchestrator_BackorderOnInsufficientStock(t *testing.T) {
+	repo := memory.NewOrderRepository()
+	timeline := memory.NewTimelineRepository()
+	inventory := &stubInventory{reserveErr: fmt.Errorf("sku-1: %w", domain.ErrInventoryUnavailable)}
+
+	seedOrder(t, repo, domain.OrderStatusPending)
+	orch := newBackorderOrchestrator(repo, timeline, inventory)
+
+	orch.Start(context.Background(), "order-1")
+	order, _ := repo.Get("order-1")
+	if order.Status != domain.OrderStatusBackordered {
+		t.Fatalf("expected backordered, got %s", order.Status)
+	}
+	if inventory.releaseCnt != 0 {
+		t.Fatalf("nothing reserved, release must not be called, got %d", inventory.releaseCnt)
+	}
+
+	// Повторная нехватка стока не плодит события в timeline.
+	orch.Start(context.Background(), "order-1")
+	types := timelineTypes(t, timeline, "order-1")
+	if types["OrderBackordered"] != 1 || types["OrderSagaFailed"] != 0 {
+		t.Fatalf("expected single OrderBackordered event, got %v", types)
+	}
+
+	inventory.reserveErr = nil
+	orch.Start(context.Background(), "order-1")
+	order, _ = repo.Get("order-1")
+	if order.Status != domain.OrderStatusConfirmed {
+		t.Fatalf("expected confirmed after restock, got %s", order.Status)
+	}
+}
+
+func TestOrchestrator_BackorderKeepsCancelForTemporaryErrors(t *testing.T) {
+	repo := memory.NewOrderRepository()
+	seedOrder(t, repo, domain.OrderStatusPending)
+	orch := newBackorderOrchestrator(repo, memory.NewTimelineRepository(), &stubInventory{reserveErr: domain.ErrInventoryTemporary})
+
+	orch.Start(context.Background(), "order-1")
+	order, _ := repo.Get("order-1")
+	if order.Status != domain.OrderStatusCanceled {
+		t.Fatalf("only insufficient stock leads to backorder, got %s", order.Status)
+	}
+}
+
+type recordingOrchestrator struct {
+	started []string
+}
+
+func (r *recordingOrchestrator) Start(ctx context.Context, orderID string) {
+	if _, ok := ctx.Deadline(); !ok {
+		panic("resumed saga must have a deadline")
+	}
+	r.started = append(r.started, orderID)
+}
+
+func (r *recordingOrchestrator) Cancel(context.Context, string, string)        {}
+func (r *recordingOrchestrator) Refund(context.Context, string, int64, string) {}
+
+func TestBackorderResumer_ResumesMatchingOrdersOldestFirst(t *testing.T) {
+	repo := memory.NewOrderRepository()
+	base := time.Now().UTC().Add(-time.Hour)
+	seed := []struct {
+		id     string
+		sku    string
+		status domain.OrderStatus
+	}{
+		{"order-new", "sku-1", domain.OrderStatusBackordered},
+		{"order-old", "sku-1", domain.OrderStatusBackordered},
+		{"order-other-sku", "sku-2", domain.OrderStatusBackordered},
+		{"order-pending", "sku-1", domain.OrderStatusPending},
+	}
+	for i, s := range seed {
+		createdAt := base.Add(time.Duration(len(seed)-i) * time.Minute)
+		if err := repo.Create(domain.Order{
+			ID: s.id, CustomerID: "c", Status: s.status, Currency: "USD", AmountMinor: 10,
+			Items:     []domain.OrderItem{{ID: s.id + "-item", SKU: s.sku, Qty: 1, PriceMinor: 10, CreatedAt: createdAt}},
+			CreatedAt: createdAt, UpdatedAt: createdAt,
+		}); err != nil {
+			t.Fatalf("create order: %v", err)
+		}
+	}
+
+	orch := &recordingOrchestrator{}
+	resumer := NewBackorderResumer(repo, orch)
+
+	if err := resumer.HandleMessage(context.Background(), &sarama.ConsumerMessage{Value: []byte(`{"sku":"sku-1","qty":5}`)}); err != nil {
+		t.Fatalf("handle restock: %v", err)
+	}
+	if len(orch.started) != 2 || orch.started[0] != "order-old" || orch.started[1] != "order-new" {
+		t.Fatalf("expected backordered sku-1 orders oldest first, got %v", orch.started)
+	}
+}
+
+func TestBackorderResumer_RejectsMalformedEvent(t *testing.T) {
+	resumer := NewBackorderResumer(memory.NewOrderRepository(), &recordingOrchestrator{})
+
+	for _, payload := range []string{`not-json`, `{"qty":1}`} {
+		if err := resumer.HandleMessage(context.Background(), &sarama.ConsumerMessage{Value: []byte(payload)}); err == nil {
+			t.Fatalf("expected error for payload %s", payload)
+		}
+	}
+}
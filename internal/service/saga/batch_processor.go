@@ -2,13 +2,160 @@ package saga
 
 import (
 	"context"
+	"fmt"
+	"slices"
+	"sort"
 	"sync"
+	"sync/atomic"
 	"time"
 
+	"github.com/prometheus/client_golang/prometheus"
 	log "github.com/sirupsen/logrus"
+
+	"github.com/vladislavdragonenkov/oms/internal/metrics"
+)
+
+// Значения label operation у метрик батч-процессора.
+const (
+	batchOpStart  = "start"
+	batchOpCancel = "cancel"
+	batchOpRefund = "refund"
+)
+
+// Значения label path у oms_saga_batch_operations_total.
+const (
+	batchPathBatched = "batched"
+	batchPathSync    = "sync"
+	batchPathBypass  = "bypass"
 )
 
+// BatchOperation — тип операции батч-процессора, для которого задаётся BatchPolicy.
+type BatchOperation string
+
+// Типы операций батч-процессора.
+const (
+	BatchOperationStart  BatchOperation = batchOpStart
+	BatchOperationCancel BatchOperation = batchOpCancel
+	BatchOperationRefund BatchOperation = batchOpRefund
+)
+
+// BatchPolicy — политика планирования одного типа операций. У каждого типа свой батч и свой
+// таймер сброса, поэтому отмены не ждут, пока наберётся большой батч запусков.
+type BatchPolicy struct {
+	// Size — размер батча, при котором он сбрасывается сразу; 0 — размер процессора по умолчанию.
+	Size int
+	// FlushTimeout — максимальное время ожидания неполного батча; 0 — таймаут процессора по умолчанию.
+	FlushTimeout time.Duration
+	// Priority — при сбросе операции с большим приоритетом первыми получают свободный слот
+	// из общего лимита параллельности.
+	Priority int
+	// BypassBelow — пока в процессоре ожидают меньше BypassBelow операций всех типов, операция
+	// выполняется сразу в вызывающей горутине, минуя батч. 0 — всегда батчить.
+	BypassBelow int
+}
+
+// defaultBatchPolicies: компенсирующие операции важнее запуска новых саг.
+var defaultBatchPolicies = map[string]BatchPolicy{
+	batchOpStart:  {Priority: 0},
+	batchOpCancel: {Priority: 2},
+	batchOpRefund: {Priority: 1},
+}
+
+// Причины сброса батча (label reason).
+const (
+	flushReasonSize     = "size"
+	flushReasonTimeout  = "timeout"
+	flushReasonShutdown = "shutdown"
+)
+
+var batchSizeBuckets = []float64{1, 2, 3, 5, 8, 10, 15, 20, 50, 100}
+
+// batchMetrics — метрики BatchProcessor: по ним подбираются batchSize и flushTimeout.
+type batchMetrics struct {
+	// operations: path=batched — операция ушла в очередь, path=sync — канал был полон и
+	// операция выполнена синхронно в вызывающей горутине, path=bypass — нагрузка была ниже
+	// порога BatchPolicy.BypassBelow и операция выполнена сразу.
+	operations *prometheus.CounterVec
+	batchSize  *prometheus.HistogramVec
+	flushes    *prometheus.CounterVec
+	dropped    *prometheus.CounterVec
+	panics     *prometheus.CounterVec
+	pending    *prometheus.GaugeVec
+	// queueWait — время от приёма операции до начала выполнения; customer_load=bulk — операции
+	// покупателя, у которого в очереди уже ждал полный батч.
+	queueWait *prometheus.HistogramVec
+}
+
+func newBatchMetrics(registerer prometheus.Registerer) batchMetrics {
+	return batchMetrics{
+		operations: metrics.Register(registerer, prometheus.NewCounterVec(prometheus.CounterOpts{
+			Name: "oms_saga_batch_operations_total",
+			Help: "Total number of saga operations submitted to the batch processor grouped by operation and path (batched, sync, bypass).",
+		}, []string{"operation", "path"})),
+		batchSize: metrics.Register(registerer, prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Name:    "oms_saga_batch_size",
+			Help:    "Number of operations in a flushed saga batch.",
+			Buckets: batchSizeBuckets,
+		}, []string{"operation"})),
+		flushes: metrics.Register(registerer, prometheus.NewCounterVec(prometheus.CounterOpts{
+			Name: "oms_saga_batch_flushes_total",
+			Help: "Total number of non-empty saga batch flushes grouped by operation and reason (size, timeout, shutdown).",
+		}, []string{"operation", "reason"})),
+		dropped: metrics.Register(registerer, prometheus.NewCounterVec(prometheus.CounterOpts{
+			Name: "oms_saga_batch_dropped_total",
+			Help: "Total number of queued saga operations dropped because the batch processor was stopped.",
+		}, []string{"operation"})),
+		panics: metrics.Register(registerer, prometheus.NewCounterVec(prometheus.CounterOpts{
+			Name: "oms_saga_batch_panics_total",
+			Help: "Total number of saga operations that panicked inside the batch processor.",
+		}, []string{"operation"})),
+		pending: metrics.Register(registerer, prometheus.NewGaugeVec(prometheus.GaugeOpts{
+			Name: "oms_saga_batch_pending_operations",
+			Help: "Saga operations queued or buffered in the batch processor and not yet executed.",
+		}, []string{"operation"})),
+		queueWait: metrics.Register(registerer, prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Name:    "oms_saga_batch_queue_wait_seconds",
+			Help:    "Time a batched saga operation waited in its customer queue grouped by operation and customer load (interactive, bulk).",
+			Buckets: prometheus.DefBuckets,
+		}, []string{"operation", "customer_load"})),
+	}
+}
+
+// BatchProcessorOption настраивает BatchProcessor.
+type BatchProcessorOption func(*BatchProcessor)
+
+// WithBatchRegisterer задаёт реестр метрик батч-процессора.
+func WithBatchRegisterer(registerer prometheus.Registerer) BatchProcessorOption {
+	return func(bp *BatchProcessor) {
+		bp.metrics = newBatchMetrics(registerer)
+	}
+}
+
+// WithBatchPolicy задаёт политику планирования для типа операций целиком, заменяя политику по умолчанию
+// (по умолчанию приоритет cancel > refund > start, размер и таймаут общие, bypass выключен).
+func WithBatchPolicy(operation BatchOperation, policy BatchPolicy) BatchProcessorOption {
+	return func(bp *BatchProcessor) {
+		if _, ok := bp.policies[string(operation)]; !ok {
+			return
+		}
+		if policy.Size < 0 {
+			policy.Size = 0
+		}
+		if policy.FlushTimeout < 0 {
+			policy.FlushTimeout = 0
+		}
+		if policy.BypassBelow < 0 {
+			policy.BypassBelow = 0
+		}
+		bp.policies[string(operation)] = policy
+	}
+}
+
 // BatchProcessor обрабатывает saga операции пакетами для повышения производительности.
+//
+// Операции каждого типа ждут в очередях по покупателям (ContextWithCustomer), батч собирается
+// из них по кругу: массовый импорт одного покупателя не задерживает заказы остальных дольше,
+// чем на один слот за круг.
 type BatchProcessor struct {
 	orchestrator Orchestrator
 	logger       *log.Entry
@@ -17,49 +164,110 @@ type BatchProcessor struct {
 	batchSize      int
 	flushTimeout   time.Duration
 	maxParallelOps int
+	policies       map[string]BatchPolicy
 
-	// Внутренние каналы и состояние
-	startCh  chan string
-	cancelCh chan cancelRequest
-	refundCh chan refundRequest
-	stopCh   chan struct{}
-	wg       sync.WaitGroup
+	// limiter — общий для всех типов операций лимит maxParallelOps.
+	limiter *priorityLimiter
+	// load — операции всех типов, принятые и ещё не выполненные; по нему решается bypass.
+	load atomic.Int64
 
-	// Буферы для батчинга
-	startBatch  []string
-	cancelBatch []cancelRequest
-	refundBatch []refundRequest
-	mu          sync.Mutex
+	// Очереди по типам операций; очередью покупателей владеет горутина своего типа.
+	starts  *batchLane[startRequest]
+	cancels *batchLane[cancelRequest]
+	refunds *batchLane[refundRequest]
+	stopCh  chan struct{}
+	wg      sync.WaitGroup
+
+	metrics batchMetrics
+}
+
+// batchHeader — общие поля операции в очереди. Контекст запроса едет вместе с операцией,
+// чтобы дедлайн действовал и после батчинга.
+type batchHeader struct {
+	ctx      context.Context
+	customer string
+	queuedAt time.Time
+}
+
+func (h batchHeader) header() batchHeader { return h }
+
+// batchRequest — операция, которую можно поставить в batchLane.
+type batchRequest interface {
+	header() batchHeader
+}
+
+type startRequest struct {
+	batchHeader
+	orderID string
 }
 
 type cancelRequest struct {
+	batchHeader
 	orderID string
 	reason  string
 }
 
 type refundRequest struct {
+	batchHeader
 	orderID     string
 	amountMinor int64
 	reason      string
 }
 
+// batchLane — канал приёма и очередь покупателей одного типа операций.
+type batchLane[T batchRequest] struct {
+	operation string
+	ch        chan T
+	queue     *fairQueue[T]
+	execute   func(T)
+}
+
+func newBatchLane[T batchRequest](operation string, execute func(T)) *batchLane[T] {
+	return &batchLane[T]{
+		operation: operation,
+		ch:        make(chan T, 100),
+		queue:     newFairQueue[T](),
+		execute:   execute,
+	}
+}
+
 // NewBatchProcessor создаёт новый батч-процессор.
-func NewBatchProcessor(orchestrator Orchestrator, logger *log.Entry) *BatchProcessor {
+func NewBatchProcessor(orchestrator Orchestrator, logger *log.Entry, opts ...BatchProcessorOption) *BatchProcessor {
 	if logger == nil {
 		logger = log.New().WithField("component", "batch-processor")
 	}
 
-	return &BatchProcessor{
+	bp := &BatchProcessor{
 		orchestrator:   orchestrator,
 		logger:         logger,
 		batchSize:      10,                     // Обрабатываем по 10 операций за раз
 		flushTimeout:   100 * time.Millisecond, // Или каждые 100мс
 		maxParallelOps: 8,
-		startCh:        make(chan string, 100),
-		cancelCh:       make(chan cancelRequest, 100),
-		refundCh:       make(chan refundRequest, 100),
-		stopCh:         make(chan struct{}),
+		starts: newBatchLane(batchOpStart, func(req startRequest) {
+			orchestrator.Start(req.ctx, req.orderID)
+		}),
+		cancels: newBatchLane(batchOpCancel, func(req cancelRequest) {
+			orchestrator.Cancel(req.ctx, req.orderID, req.reason)
+		}),
+		refunds: newBatchLane(batchOpRefund, func(req refundRequest) {
+			orchestrator.Refund(req.ctx, req.orderID, req.amountMinor, req.reason)
+		}),
+		stopCh:   make(chan struct{}),
+		policies: make(map[string]BatchPolicy, len(defaultBatchPolicies)),
+	}
+	for operation, policy := range defaultBatchPolicies {
+		bp.policies[operation] = policy
+	}
+	for _, opt := range opts {
+		if opt != nil {
+			opt(bp)
+		}
+	}
+	if bp.metrics.operations == nil {
+		bp.metrics = newBatchMetrics(nil)
 	}
+	bp.limiter = newPriorityLimiter(bp.maxParallelOps)
+	return bp
 }
 
 // Start запускает батч-процессор.
@@ -67,9 +275,9 @@ func (bp *BatchProcessor) Start(ctx context.Context) {
 	bp.wg.Add(3)
 
 	// Запускаем воркеры для каждого типа операций
-	go bp.processStartBatch(ctx)
-	go bp.processCancelBatch(ctx)
-	go bp.processRefundBatch(ctx)
+	go runBatchLane(ctx, bp, bp.starts)
+	go runBatchLane(ctx, bp, bp.cancels)
+	go runBatchLane(ctx, bp, bp.refunds)
 
 	bp.logger.Info("Batch processor started")
 }
@@ -82,200 +290,299 @@ func (bp *BatchProcessor) Stop() {
 }
 
 // StartOrder добавляет заказ в очередь на обработку.
-func (bp *BatchProcessor) StartOrder(orderID string) {
+func (bp *BatchProcessor) StartOrder(ctx context.Context, orderID string) {
+	if bp.dropIfStopped(batchOpStart, orderID) {
+		return
+	}
+	if bp.bypass(batchOpStart) {
+		bp.orchestrator.Start(ctx, orderID)
+		return
+	}
+	bp.addPending(batchOpStart, 1)
 	select {
-	case bp.startCh <- orderID:
+	case bp.starts.ch <- startRequest{batchHeader: newBatchHeader(ctx), orderID: orderID}:
+		bp.metrics.operations.WithLabelValues(batchOpStart, batchPathBatched).Inc()
 	default:
 		// Если канал переполнен, обрабатываем синхронно
+		bp.addPending(batchOpStart, -1)
+		bp.metrics.operations.WithLabelValues(batchOpStart, batchPathSync).Inc()
 		bp.logger.WithField("order_id", orderID).Warn("Start channel full, processing synchronously")
-		bp.orchestrator.Start(orderID)
+		bp.orchestrator.Start(ctx, orderID)
 	}
 }
 
 // CancelOrder добавляет заказ в очередь на отмену.
-func (bp *BatchProcessor) CancelOrder(orderID, reason string) {
+func (bp *BatchProcessor) CancelOrder(ctx context.Context, orderID, reason string) {
+	if bp.dropIfStopped(batchOpCancel, orderID) {
+		return
+	}
+	if bp.bypass(batchOpCancel) {
+		bp.orchestrator.Cancel(ctx, orderID, reason)
+		return
+	}
+	bp.addPending(batchOpCancel, 1)
 	select {
-	case bp.cancelCh <- cancelRequest{orderID: orderID, reason: reason}:
+	case bp.cancels.ch <- cancelRequest{batchHeader: newBatchHeader(ctx), orderID: orderID, reason: reason}:
+		bp.metrics.operations.WithLabelValues(batchOpCancel, batchPathBatched).Inc()
 	default:
+		bp.addPending(batchOpCancel, -1)
+		bp.metrics.operations.WithLabelValues(batchOpCancel, batchPathSync).Inc()
 		bp.logger.WithField("order_id", orderID).Warn("Cancel channel full, processing synchronously")
-		bp.orchestrator.Cancel(orderID, reason)
+		bp.orchestrator.Cancel(ctx, orderID, reason)
 	}
 }
 
 // RefundOrder добавляет заказ в очередь на возврат.
-func (bp *BatchProcessor) RefundOrder(orderID string, amountMinor int64, reason string) {
+func (bp *BatchProcessor) RefundOrder(ctx context.Context, orderID string, amountMinor int64, reason string) {
+	if bp.dropIfStopped(batchOpRefund, orderID) {
+		return
+	}
+	if bp.bypass(batchOpRefund) {
+		bp.orchestrator.Refund(ctx, orderID, amountMinor, reason)
+		return
+	}
+	bp.addPending(batchOpRefund, 1)
 	select {
-	case bp.refundCh <- refundRequest{orderID: orderID, amountMinor: amountMinor, reason: reason}:
+	case bp.refunds.ch <- refundRequest{batchHeader: newBatchHeader(ctx), orderID: orderID, amountMinor: amountMinor, reason: reason}:
+		bp.metrics.operations.WithLabelValues(batchOpRefund, batchPathBatched).Inc()
 	default:
+		bp.addPending(batchOpRefund, -1)
+		bp.metrics.operations.WithLabelValues(batchOpRefund, batchPathSync).Inc()
 		bp.logger.WithField("order_id", orderID).Warn("Refund channel full, processing synchronously")
-		bp.orchestrator.Refund(orderID, amountMinor, reason)
+		bp.orchestrator.Refund(ctx, orderID, amountMinor, reason)
 	}
 }
 
-func (bp *BatchProcessor) processStartBatch(ctx context.Context) {
+func newBatchHeader(ctx context.Context) batchHeader {
+	return batchHeader{ctx: ctx, customer: CustomerFromContext(ctx), queuedAt: time.Now()}
+}
+
+// runBatchLane принимает операции одного типа и сбрасывает батч по размеру или таймеру.
+func runBatchLane[T batchRequest](ctx context.Context, bp *BatchProcessor, lane *batchLane[T]) {
 	defer bp.wg.Done()
 
-	ticker := time.NewTicker(bp.flushTimeout)
+	batchSize, flushTimeout := bp.batchLimits(lane.operation)
+	ticker := time.NewTicker(flushTimeout)
 	defer ticker.Stop()
 
 	for {
 		select {
 		case <-ctx.Done():
-			bp.flushStartBatch()
+			shutdownBatchLane(bp, lane, batchSize)
 			return
 		case <-bp.stopCh:
-			bp.flushStartBatch()
+			shutdownBatchLane(bp, lane, batchSize)
 			return
-		case orderID := <-bp.startCh:
-			bp.mu.Lock()
-			bp.startBatch = append(bp.startBatch, orderID)
-			shouldFlush := len(bp.startBatch) >= bp.batchSize
-			bp.mu.Unlock()
-
-			if shouldFlush {
-				bp.flushStartBatch()
+		case req := <-lane.ch:
+			lane.queue.push(req.header().customer, req, batchSize)
+			if lane.queue.len() >= batchSize {
+				flushBatchLane(ctx, bp, lane, batchSize, flushReasonSize)
 			}
 		case <-ticker.C:
-			bp.flushStartBatch()
+			flushBatchLane(ctx, bp, lane, batchSize, flushReasonTimeout)
 		}
 	}
 }
 
-func (bp *BatchProcessor) processCancelBatch(ctx context.Context) {
-	defer bp.wg.Done()
-
-	ticker := time.NewTicker(bp.flushTimeout)
-	defer ticker.Stop()
-
-	for {
-		select {
-		case <-ctx.Done():
-			bp.flushCancelBatch()
+// flushBatchLane сбрасывает батч и продолжает, пока в очереди набирается полный батч: за время
+// выполнения предыдущего батча канал успевает заполниться. Перед каждым батчем канал вычитывается
+// в очереди покупателей, чтобы ждущие в нём операции участвовали в круге.
+func flushBatchLane[T batchRequest](ctx context.Context, bp *BatchProcessor, lane *batchLane[T], batchSize int, reason string) {
+	for first := true; ; first = false {
+		if !first && bp.stopping(ctx) {
 			return
-		case <-bp.stopCh:
-			bp.flushCancelBatch()
+		}
+		drainBatchLane(lane, batchSize)
+		if !first && lane.queue.len() < batchSize {
 			return
-		case req := <-bp.cancelCh:
-			bp.mu.Lock()
-			bp.cancelBatch = append(bp.cancelBatch, req)
-			shouldFlush := len(bp.cancelBatch) >= bp.batchSize
-			bp.mu.Unlock()
-
-			if shouldFlush {
-				bp.flushCancelBatch()
-			}
-		case <-ticker.C:
-			bp.flushCancelBatch()
 		}
+		batch := lane.queue.take(batchSize)
+		if len(batch) == 0 {
+			return
+		}
+		bp.processBatch(lane.operation, reason, len(batch), func(index int) {
+			item := batch[index]
+			bp.metrics.queueWait.WithLabelValues(lane.operation, item.load).Observe(time.Since(item.req.header().queuedAt).Seconds())
+			lane.execute(item.req)
+		})
+		reason = flushReasonSize
 	}
 }
 
-func (bp *BatchProcessor) processRefundBatch(ctx context.Context) {
-	defer bp.wg.Done()
-
-	ticker := time.NewTicker(bp.flushTimeout)
-	defer ticker.Stop()
+// shutdownBatchLane выполняет последний батч и отбрасывает операции, которые в него не попали.
+func shutdownBatchLane[T batchRequest](bp *BatchProcessor, lane *batchLane[T], batchSize int) {
+	drainBatchLane(lane, batchSize)
+	if batch := lane.queue.take(batchSize); len(batch) > 0 {
+		bp.processBatch(lane.operation, flushReasonShutdown, len(batch), func(index int) {
+			lane.execute(batch[index].req)
+		})
+	}
+	drainBatchLane(lane, batchSize)
+	bp.dropQueued(lane.operation, lane.queue.clear())
+}
 
+// drainBatchLane без блокировки переносит операции из канала в очереди покупателей.
+func drainBatchLane[T batchRequest](lane *batchLane[T], batchSize int) {
 	for {
 		select {
-		case <-ctx.Done():
-			bp.flushRefundBatch()
+		case req := <-lane.ch:
+			lane.queue.push(req.header().customer, req, batchSize)
+		default:
 			return
-		case <-bp.stopCh:
-			bp.flushRefundBatch()
-			return
-		case req := <-bp.refundCh:
-			bp.mu.Lock()
-			bp.refundBatch = append(bp.refundBatch, req)
-			shouldFlush := len(bp.refundBatch) >= bp.batchSize
-			bp.mu.Unlock()
-
-			if shouldFlush {
-				bp.flushRefundBatch()
-			}
-		case <-ticker.C:
-			bp.flushRefundBatch()
 		}
 	}
 }
 
-func (bp *BatchProcessor) flushStartBatch() {
-	bp.mu.Lock()
-	batch := bp.startBatch
-	bp.startBatch = nil
-	bp.mu.Unlock()
+func (bp *BatchProcessor) processBatch(operation, reason string, size int, processFn func(index int)) {
+	bp.logger.WithFields(log.Fields{"operation": operation, "batch_size": size, "reason": reason}).Debug("Processing saga batch")
+	bp.observeFlush(operation, reason, size)
+	bp.processInParallel(operation, size, processFn)
+}
 
-	if len(batch) == 0 {
-		return
+// stopping сообщает, что процессор останавливается и новые батчи начинать не нужно.
+func (bp *BatchProcessor) stopping(ctx context.Context) bool {
+	select {
+	case <-ctx.Done():
+		return true
+	case <-bp.stopCh:
+		return true
+	default:
+		return false
 	}
-
-	bp.logger.WithField("batch_size", len(batch)).Debug("Processing start batch")
-
-	bp.processInParallel(len(batch), func(index int) {
-		bp.orchestrator.Start(batch[index])
-	})
 }
 
-func (bp *BatchProcessor) flushCancelBatch() {
-	bp.mu.Lock()
-	batch := bp.cancelBatch
-	bp.cancelBatch = nil
-	bp.mu.Unlock()
+func (bp *BatchProcessor) observeFlush(operation, reason string, size int) {
+	bp.metrics.flushes.WithLabelValues(operation, reason).Inc()
+	bp.metrics.batchSize.WithLabelValues(operation).Observe(float64(size))
+}
 
-	if len(batch) == 0 {
-		return
+// dropIfStopped отбрасывает операцию, поступившую после Stop: очередь уже никто не читает.
+func (bp *BatchProcessor) dropIfStopped(operation, orderID string) bool {
+	select {
+	case <-bp.stopCh:
+		bp.metrics.dropped.WithLabelValues(operation).Inc()
+		bp.logger.WithFields(log.Fields{"order_id": orderID, "operation": operation}).Warn("Batch processor stopped, operation dropped")
+		return true
+	default:
+		return false
 	}
-
-	bp.logger.WithField("batch_size", len(batch)).Debug("Processing cancel batch")
-
-	bp.processInParallel(len(batch), func(index int) {
-		req := batch[index]
-		bp.orchestrator.Cancel(req.orderID, req.reason)
-	})
 }
 
-func (bp *BatchProcessor) flushRefundBatch() {
-	bp.mu.Lock()
-	batch := bp.refundBatch
-	bp.refundBatch = nil
-	bp.mu.Unlock()
-
-	if len(batch) == 0 {
+// dropQueued учитывает как потерянные операции, не попавшие в батч до остановки.
+func (bp *BatchProcessor) dropQueued(operation string, dropped int) {
+	if dropped <= 0 {
 		return
 	}
-
-	bp.logger.WithField("batch_size", len(batch)).Debug("Processing refund batch")
-
-	bp.processInParallel(len(batch), func(index int) {
-		req := batch[index]
-		bp.orchestrator.Refund(req.orderID, req.amountMinor, req.reason)
-	})
+	bp.metrics.dropped.WithLabelValues(operation).Add(float64(dropped))
+	bp.addPending(operation, -dropped)
+	bp.logger.WithFields(log.Fields{"operation": operation, "dropped": dropped}).Warn("Queued saga operations dropped on shutdown")
 }
 
-func (bp *BatchProcessor) processInParallel(size int, processFn func(index int)) {
+// processInParallel выполняет операции батча в слотах общего лимита maxParallelOps: пока идёт
+// большой батч одного типа, операции с большим приоритетом получают освободившиеся слоты первыми.
+// Паника одной операции не роняет процесс и не мешает остальным операциям батча.
+func (bp *BatchProcessor) processInParallel(operation string, size int, processFn func(index int)) {
 	if size == 0 {
 		return
 	}
 
-	limit := bp.maxParallelOps
-	if limit <= 0 {
-		limit = 1
-	}
-	if limit > size {
-		limit = size
-	}
-
-	semaphore := make(chan struct{}, limit)
+	priority := bp.policies[operation].Priority
 	var wg sync.WaitGroup
 	for idx := 0; idx < size; idx++ {
 		wg.Add(1)
-		semaphore <- struct{}{}
+		bp.limiter.acquire(priority)
 		go func(index int) {
 			defer wg.Done()
-			defer func() { <-semaphore }()
+			defer bp.limiter.release()
+			defer bp.addPending(operation, -1)
+			defer func() {
+				if recovered := recover(); recovered != nil {
+					bp.metrics.panics.WithLabelValues(operation).Inc()
+					bp.logger.WithError(fmt.Errorf("panic: %v", recovered)).WithField("operation", operation).Error("Saga batch operation panicked")
+				}
+			}()
 			processFn(index)
 		}(idx)
 	}
 
 	wg.Wait()
 }
+
+// batchLimits возвращает размер батча и таймаут сброса для типа операций с учётом политики.
+func (bp *BatchProcessor) batchLimits(operation string) (int, time.Duration) {
+	policy := bp.policies[operation]
+	size, timeout := bp.batchSize, bp.flushTimeout
+	if policy.Size > 0 {
+		size = policy.Size
+	}
+	if policy.FlushTimeout > 0 {
+		timeout = policy.FlushTimeout
+	}
+	return size, timeout
+}
+
+// bypass сообщает, что операцию выгоднее выполнить сразу: процессор почти пуст, и ожидание
+// сброса батча только добавило бы задержку.
+func (bp *BatchProcessor) bypass(operation string) bool {
+	threshold := bp.policies[operation].BypassBelow
+	if threshold <= 0 || bp.load.Load() >= int64(threshold) {
+		return false
+	}
+	bp.metrics.operations.WithLabelValues(operation, batchPathBypass).Inc()
+	return true
+}
+
+func (bp *BatchProcessor) addPending(operation string, delta int) {
+	bp.metrics.pending.WithLabelValues(operation).Add(float64(delta))
+	bp.load.Add(int64(delta))
+}
+
+// priorityLimiter — семафор, отдающий освободившийся слот ожидающему с наибольшим приоритетом
+// (при равном приоритете — в порядке очереди).
+type priorityLimiter struct {
+	mu      sync.Mutex
+	free    int
+	waiters []limiterWaiter
+}
+
+type limiterWaiter struct {
+	priority int
+	ready    chan struct{}
+}
+
+func newPriorityLimiter(slots int) *priorityLimiter {
+	if slots <= 0 {
+		slots = 1
+	}
+	return &priorityLimiter{free: slots}
+}
+
+func (l *priorityLimiter) acquire(priority int) {
+	l.mu.Lock()
+	if l.free > 0 {
+		l.free--
+		l.mu.Unlock()
+		return
+	}
+	waiter := limiterWaiter{priority: priority, ready: make(chan struct{})}
+	idx := sort.Search(len(l.waiters), func(i int) bool { return l.waiters[i].priority < priority })
+	l.waiters = slices.Insert(l.waiters, idx, waiter)
+	l.mu.Unlock()
+
+	<-waiter.ready
+}
+
+// release передаёт слот первому ожидающему, не возвращая его в free, чтобы слот не перехватил
+// новый вызов acquire с меньшим приоритетом.
+func (l *priorityLimiter) release() {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if len(l.waiters) == 0 {
+		l.free++
+		return
+	}
+	next := l.waiters[0]
+	l.waiters = l.waiters[1:]
+	close(next.ready)
+}
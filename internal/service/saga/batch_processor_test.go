@@ -2,12 +2,19 @@ package saga
 
 import (
 	"context"
+	"slices"
+	"strconv"
+	"sync"
+	"sync/atomic"
 	"testing"
 	"time"
 
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
 	log "github.com/sirupsen/logrus"
 
 	"github.com/vladislavdragonenkov/oms/internal/domain"
+	"github.com/vladislavdragonenkov/oms/internal/metrics/metricstest"
 	"github.com/vladislavdragonenkov/oms/internal/storage/memory"
 )
 
@@ -108,7 +115,7 @@ func TestBatchProcessor_StartOrder(t *testing.T) {
 	defer bp.Stop()
 
 	// Отправляем в обработку
-	bp.StartOrder(order.ID)
+	bp.StartOrder(context.Background(), order.ID)
 
 	// Даём время на обработку
 	time.Sleep(200 * time.Millisecond)
@@ -147,7 +154,7 @@ func TestBatchProcessor_CancelOrder(t *testing.T) {
 	defer bp.Stop()
 
 	// Отменяем заказ
-	bp.CancelOrder(order.ID, "test cancellation")
+	bp.CancelOrder(context.Background(), order.ID, "test cancellation")
 
 	// Даём время на обработку
 	time.Sleep(200 * time.Millisecond)
@@ -185,7 +192,7 @@ func TestBatchProcessor_RefundOrder(t *testing.T) {
 	defer bp.Stop()
 
 	// Возвращаем заказ
-	bp.RefundOrder(order.ID, order.AmountMinor, "test refund")
+	bp.RefundOrder(context.Background(), order.ID, order.AmountMinor, "test refund")
 
 	// Даём время на обработку
 	time.Sleep(200 * time.Millisecond)
@@ -225,7 +232,7 @@ func TestBatchProcessor_MultipleBatches(t *testing.T) {
 	for i := 0; i < orderCount; i++ {
 		order := seedOrderWithID(t, repo, domain.OrderStatusPending, i)
 		orderIDs[i] = order.ID
-		bp.StartOrder(order.ID)
+		bp.StartOrder(context.Background(), order.ID)
 	}
 
 	// Даём время на обработку всех батчей
@@ -270,7 +277,7 @@ func TestBatchProcessor_StopWhileProcessing(t *testing.T) {
 
 	// Отправляем операции
 	order := seedOrder(t, repo, domain.OrderStatusPending)
-	bp.StartOrder(order.ID)
+	bp.StartOrder(context.Background(), order.ID)
 
 	// Сразу останавливаем
 	bp.Stop()
@@ -278,13 +285,254 @@ func TestBatchProcessor_StopWhileProcessing(t *testing.T) {
 	// Не должно паниковать
 }
 
+// panickyOrchestrator паникует на заказе "boom" и считает остальные вызовы Start.
+type panickyOrchestrator struct {
+	started atomic.Int32
+}
+
+func (o *panickyOrchestrator) Start(_ context.Context, orderID string) {
+	if orderID == "boom" {
+		panic("orchestrator bug")
+	}
+	o.started.Add(1)
+}
+
+func (o *panickyOrchestrator) Cancel(context.Context, string, string) {}
+
+func (o *panickyOrchestrator) Refund(context.Context, string, int64, string) {}
+
+func TestBatchProcessor_MetricsAndPanicSafety(t *testing.T) {
+	orch := &panickyOrchestrator{}
+	registry := prometheus.NewRegistry()
+	bp := NewBatchProcessor(orch, log.WithField("test", "batch-metrics"), WithBatchRegisterer(registry))
+	bp.batchSize = 3
+	bp.flushTimeout = time.Hour
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	bp.Start(ctx)
+
+	for _, id := range []string{"order-1", "boom", "order-2"} {
+		bp.StartOrder(context.Background(), id)
+	}
+	deadline := time.Now().Add(2 * time.Second)
+	for testutil.ToFloat64(bp.metrics.pending.WithLabelValues(batchOpStart)) != 0 && time.Now().Before(deadline) {
+		time.Sleep(5 * time.Millisecond)
+	}
+
+	if got := orch.started.Load(); got != 2 {
+		t.Fatalf("panic must not prevent other operations of the batch, started=%d", got)
+	}
+	if got := testutil.ToFloat64(bp.metrics.panics.WithLabelValues(batchOpStart)); got != 1 {
+		t.Fatalf("expected 1 panic, got %v", got)
+	}
+	if got := testutil.ToFloat64(bp.metrics.flushes.WithLabelValues(batchOpStart, flushReasonSize)); got != 1 {
+		t.Fatalf("expected size-triggered flush, got %v", got)
+	}
+	if got := testutil.ToFloat64(bp.metrics.operations.WithLabelValues(batchOpStart, "batched")); got != 3 {
+		t.Fatalf("expected 3 batched operations, got %v", got)
+	}
+
+	bp.StartOrder(context.Background(), "order-3")
+	bp.Stop()
+	bp.StartOrder(context.Background(), "order-4")
+
+	flushed := testutil.ToFloat64(bp.metrics.flushes.WithLabelValues(batchOpStart, flushReasonShutdown))
+	dropped := testutil.ToFloat64(bp.metrics.dropped.WithLabelValues(batchOpStart))
+	// order-3 либо успел попасть в батч и сброшен при остановке, либо остался в канале и потерян.
+	if flushed+dropped != 2 || dropped < 1 {
+		t.Fatalf("expected shutdown flush or drop for order-3 and drop for order-4, flushed=%v dropped=%v", flushed, dropped)
+	}
+	if got := testutil.ToFloat64(bp.metrics.pending.WithLabelValues(batchOpStart)); got != 0 {
+		t.Fatalf("expected no pending operations after stop, got %v", got)
+	}
+}
+
+// countingOrchestrator считает вызовы Start и Cancel.
+type countingOrchestrator struct {
+	started  atomic.Int32
+	canceled atomic.Int32
+}
+
+func (o *countingOrchestrator) Start(context.Context, string) { o.started.Add(1) }
+
+func (o *countingOrchestrator) Cancel(context.Context, string, string) { o.canceled.Add(1) }
+
+func (o *countingOrchestrator) Refund(context.Context, string, int64, string) {}
+
+func TestBatchProcessor_PerOperationPolicies(t *testing.T) {
+	orch := &countingOrchestrator{}
+	bp := NewBatchProcessor(orch, log.WithField("test", "batch-policies"),
+		WithBatchRegisterer(prometheus.NewRegistry()),
+		WithBatchPolicy(BatchOperationStart, BatchPolicy{Size: 100, FlushTimeout: time.Hour}),
+		WithBatchPolicy(BatchOperationCancel, BatchPolicy{Size: 1, Priority: 2}),
+	)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	bp.Start(ctx)
+	defer bp.Stop()
+
+	for _, id := range []string{"order-1", "order-2", "order-3"} {
+		bp.StartOrder(context.Background(), id)
+	}
+	bp.CancelOrder(context.Background(), "order-4", "customer request")
+
+	deadline := time.Now().Add(2 * time.Second)
+	for orch.canceled.Load() == 0 && time.Now().Before(deadline) {
+		time.Sleep(5 * time.Millisecond)
+	}
+	if got := orch.canceled.Load(); got != 1 {
+		t.Fatalf("cancel must not wait for the start batch, canceled=%d", got)
+	}
+	if got := orch.started.Load(); got != 0 {
+		t.Fatalf("start batch must wait for its own size or timeout, started=%d", got)
+	}
+	if got := testutil.ToFloat64(bp.metrics.flushes.WithLabelValues(batchOpCancel, flushReasonSize)); got != 1 {
+		t.Fatalf("expected size-triggered cancel flush, got %v", got)
+	}
+}
+
+func TestBatchProcessor_BypassBelowLoadThreshold(t *testing.T) {
+	orch := &countingOrchestrator{}
+	bp := NewBatchProcessor(orch, log.WithField("test", "batch-bypass"),
+		WithBatchRegisterer(prometheus.NewRegistry()),
+		WithBatchPolicy(BatchOperationStart, BatchPolicy{Size: 100, FlushTimeout: time.Hour}),
+		WithBatchPolicy(BatchOperationCancel, BatchPolicy{Size: 100, FlushTimeout: time.Hour, BypassBelow: 2}),
+	)
+
+	// Процессор пуст: отмена выполняется сразу, даже без запущенных воркеров.
+	bp.CancelOrder(context.Background(), "order-1", "customer request")
+	if got := orch.canceled.Load(); got != 1 {
+		t.Fatalf("expected cancel to bypass the batch, canceled=%d", got)
+	}
+
+	bp.StartOrder(context.Background(), "order-2")
+	bp.StartOrder(context.Background(), "order-3")
+	bp.CancelOrder(context.Background(), "order-4", "customer request")
+	if got := orch.canceled.Load(); got != 1 {
+		t.Fatalf("expected cancel to be batched under load, canceled=%d", got)
+	}
+
+	if got := testutil.ToFloat64(bp.metrics.operations.WithLabelValues(batchOpCancel, batchPathBypass)); got != 1 {
+		t.Fatalf("expected 1 bypassed cancel, got %v", got)
+	}
+	if got := testutil.ToFloat64(bp.metrics.operations.WithLabelValues(batchOpCancel, batchPathBatched)); got != 1 {
+		t.Fatalf("expected 1 batched cancel, got %v", got)
+	}
+	if got := bp.load.Load(); got != 3 {
+		t.Fatalf("expected load 3, got %d", got)
+	}
+}
+
+// startLogOrchestrator запоминает порядок вызовов Start.
+type startLogOrchestrator struct {
+	mu      sync.Mutex
+	started []string
+}
+
+func (o *startLogOrchestrator) Start(_ context.Context, orderID string) {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	o.started = append(o.started, orderID)
+}
+
+func (o *startLogOrchestrator) Cancel(context.Context, string, string) {}
+
+func (o *startLogOrchestrator) Refund(context.Context, string, int64, string) {}
+
+func (o *startLogOrchestrator) snapshot() []string {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	return slices.Clone(o.started)
+}
+
+func TestBatchProcessor_FairQueuingAcrossCustomers(t *testing.T) {
+	orch := &startLogOrchestrator{}
+	registry := prometheus.NewRegistry()
+	bp := NewBatchProcessor(orch, log.WithField("test", "batch-fairness"),
+		WithBatchRegisterer(registry),
+		WithBatchPolicy(BatchOperationStart, BatchPolicy{Size: 5, FlushTimeout: time.Hour}),
+	)
+
+	// Массовый импорт попадает в очередь раньше заказа другого покупателя.
+	bulkCtx := ContextWithCustomer(context.Background(), "importer")
+	for i := range 20 {
+		bp.StartOrder(bulkCtx, "bulk-"+strconv.Itoa(i))
+	}
+	bp.StartOrder(ContextWithCustomer(context.Background(), "customer-1"), "interactive")
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	bp.Start(ctx)
+
+	deadline := time.Now().Add(2 * time.Second)
+	for len(orch.snapshot()) < 20 && time.Now().Before(deadline) {
+		time.Sleep(5 * time.Millisecond)
+	}
+	started := orch.snapshot()
+	if len(started) != 20 {
+		t.Fatalf("expected 4 full batches, started=%d", len(started))
+	}
+	if idx := slices.Index(started, "interactive"); idx < 0 || idx >= 5 {
+		t.Fatalf("interactive order must get a slot in the first batch, position=%d", idx)
+	}
+
+	metricstest.RequireValue(t, registry, "oms_saga_batch_queue_wait_seconds", metricstest.Labels{"operation": batchOpStart, "customer_load": customerLoadInteractive}, 6)
+	metricstest.RequireValue(t, registry, "oms_saga_batch_queue_wait_seconds", metricstest.Labels{"operation": batchOpStart, "customer_load": customerLoadBulk}, 14)
+
+	// Неполный остаток ждёт таймера и выполняется последним батчем при остановке.
+	bp.Stop()
+	metricstest.RequireValue(t, registry, "oms_saga_batch_flushes_total", metricstest.Labels{"operation": batchOpStart, "reason": flushReasonShutdown}, 1)
+	if got := len(orch.snapshot()); got != 21 {
+		t.Fatalf("expected the remaining operation to run in the shutdown batch, started=%d", got)
+	}
+}
+
+func TestPriorityLimiter_GrantsHigherPriorityFirst(t *testing.T) {
+	limiter := newPriorityLimiter(1)
+	limiter.acquire(0)
+
+	granted := make(chan int, 3)
+	waitQueued := func(n int) {
+		deadline := time.Now().Add(time.Second)
+		for {
+			limiter.mu.Lock()
+			queued := len(limiter.waiters)
+			limiter.mu.Unlock()
+			if queued == n {
+				return
+			}
+			if time.Now().After(deadline) {
+				t.Fatalf("expected %d waiters, got %d", n, queued)
+			}
+			time.Sleep(time.Millisecond)
+		}
+	}
+	for i, priority := range []int{0, 2, 1} {
+		go func() {
+			limiter.acquire(priority)
+			granted <- priority
+			limiter.release()
+		}()
+		waitQueued(i + 1)
+	}
+
+	limiter.release()
+	for _, want := range []int{2, 1, 0} {
+		if got := <-granted; got != want {
+			t.Fatalf("expected priority %d to be granted, got %d", want, got)
+		}
+	}
+}
+
 // seedOrderWithID создаёт заказ с определённым ID для тестов
 func seedOrderWithID(t *testing.T, repo domain.OrderRepository, status domain.OrderStatus, idx int) domain.Order {
 	t.Helper()
 
 	now := time.Now().UTC()
 	order := domain.Order{
-		ID:          "order-" + time.Now().Format("20060102150405") + "-" + string(rune(idx)),
+		ID:          "order-" + time.Now().Format("20060102150405") + "-" + strconv.Itoa(idx),
 		CustomerID:  "customer-1",
 		Status:      status,
 		Currency:    "USD",
@@ -1,6 +1,7 @@
 package saga
 
 import (
+	"context"
 	"errors"
 	"testing"
 
@@ -19,7 +20,7 @@ func TestOrchestrator_Cancel_FromReserved(t *testing.T) {
 	order := seedOrder(t, repo, domain.OrderStatusReserved)
 
 	orch := NewOrchestratorWithoutMetrics(repo, outbox, timeline, inv, pay, nil)
-	orch.Cancel(order.ID, "customer request")
+	orch.Cancel(context.Background(), order.ID, "customer request")
 
 	// Check order status
 	updated, err := repo.Get(order.ID)
@@ -53,7 +54,7 @@ func TestOrchestrator_Cancel_FromPaid(t *testing.T) {
 	order := seedOrder(t, repo, domain.OrderStatusPaid)
 
 	orch := NewOrchestratorWithoutMetrics(repo, outbox, timeline, inv, pay, nil)
-	orch.Cancel(order.ID, "customer request")
+	orch.Cancel(context.Background(), order.ID, "customer request")
 
 	// Check order status
 	updated, err := repo.Get(order.ID)
@@ -87,7 +88,7 @@ func TestOrchestrator_Cancel_FromConfirmed(t *testing.T) {
 	order := seedOrder(t, repo, domain.OrderStatusConfirmed)
 
 	orch := NewOrchestratorWithoutMetrics(repo, outbox, timeline, inv, pay, nil)
-	orch.Cancel(order.ID, "customer request")
+	orch.Cancel(context.Background(), order.ID, "customer request")
 
 	// Check order status
 	updated, err := repo.Get(order.ID)
@@ -120,7 +121,7 @@ func TestOrchestrator_Cancel_AlreadyCanceled(t *testing.T) {
 	order := seedOrder(t, repo, domain.OrderStatusCanceled)
 
 	orch := NewOrchestratorWithoutMetrics(repo, outbox, timeline, inv, pay, nil)
-	orch.Cancel(order.ID, "customer request")
+	orch.Cancel(context.Background(), order.ID, "customer request")
 
 	// Should not do anything
 	if inv.releaseCnt != 0 {
@@ -142,7 +143,7 @@ func TestOrchestrator_Cancel_OrderNotFound(t *testing.T) {
 	orch := NewOrchestratorWithoutMetrics(repo, outbox, timeline, inv, pay, nil)
 
 	// Try to cancel non-existent order
-	orch.Cancel("non-existent", "test")
+	orch.Cancel(context.Background(), "non-existent", "test")
 
 	// Should not panic, just log warning
 	if inv.releaseCnt != 0 {
@@ -161,7 +162,7 @@ func TestOrchestrator_Cancel_RefundFails(t *testing.T) {
 	order := seedOrder(t, repo, domain.OrderStatusPaid)
 
 	orch := NewOrchestratorWithoutMetrics(repo, outbox, timeline, inv, pay, nil)
-	orch.Cancel(order.ID, "customer request")
+	orch.Cancel(context.Background(), order.ID, "customer request")
 
 	// Order should remain in Paid status if refund fails
 	updated, err := repo.Get(order.ID)
@@ -185,7 +186,7 @@ func TestOrchestrator_Refund_Success(t *testing.T) {
 	order := seedOrder(t, repo, domain.OrderStatusConfirmed)
 
 	orch := NewOrchestratorWithoutMetrics(repo, outbox, timeline, inv, pay, nil)
-	orch.Refund(order.ID, 100, "customer request")
+	orch.Refund(context.Background(), order.ID, 100, "customer request")
 
 	// Check order status
 	updated, err := repo.Get(order.ID)
@@ -219,7 +220,7 @@ func TestOrchestrator_Refund_AlreadyRefunded(t *testing.T) {
 	order := seedOrder(t, repo, domain.OrderStatusRefunded)
 
 	orch := NewOrchestratorWithoutMetrics(repo, outbox, timeline, inv, pay, nil)
-	orch.Refund(order.ID, 100, "customer request")
+	orch.Refund(context.Background(), order.ID, 100, "customer request")
 
 	// Should not do anything
 	if pay.refundCnt != 0 {
@@ -242,7 +243,7 @@ func TestOrchestrator_Refund_WrongStatus(t *testing.T) {
 	order := seedOrder(t, repo, domain.OrderStatusPending)
 
 	orch := NewOrchestratorWithoutMetrics(repo, outbox, timeline, inv, pay, nil)
-	orch.Refund(order.ID, 100, "customer request")
+	orch.Refund(context.Background(), order.ID, 100, "customer request")
 
 	// Should not refund order that's not paid
 	if pay.refundCnt != 0 {
@@ -270,7 +271,7 @@ func TestOrchestrator_Refund_OrderNotFound(t *testing.T) {
 	orch := NewOrchestratorWithoutMetrics(repo, outbox, timeline, inv, pay, nil)
 
 	// Try to refund non-existent order
-	orch.Refund("non-existent", 100, "test")
+	orch.Refund(context.Background(), "non-existent", 100, "test")
 
 	// Should not panic, just log warning
 	if pay.refundCnt != 0 {
@@ -291,7 +292,7 @@ func TestOrchestrator_Refund_PartialAmount(t *testing.T) {
 	orch := NewOrchestratorWithoutMetrics(repo, outbox, timeline, inv, pay, nil)
 
 	// Refund partial amount (50 out of 100)
-	orch.Refund(order.ID, 50, "partial refund")
+	orch.Refund(context.Background(), order.ID, 50, "partial refund")
 
 	// Check refund was called
 	if pay.refundCnt != 1 {
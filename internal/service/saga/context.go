@@ -0,0 +1,47 @@
+package saga
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+	"time"
+)
+
+// DefaultTimeout — максимальная длительность одной операции саги по умолчанию.
+const DefaultTimeout = 30 * time.Second
+
+// ErrDeadlineExceeded возвращается, когда сага остановлена из-за истёкшего контекста.
+var ErrDeadlineExceeded = errors.New("saga deadline exceeded")
+
+// DetachedContext возвращает контекст для фонового выполнения саги.
+// Он не отменяется вместе с родительским RPC, но сохраняет его значения (trace, tenant)
+// и ограничен maxDuration, чтобы сага не выполнялась бесконечно после ответа клиенту.
+func DetachedContext(parent context.Context, maxDuration time.Duration) (context.Context, context.CancelFunc) {
+	if parent == nil {
+		parent = context.Background()
+	}
+	if maxDuration <= 0 {
+		maxDuration = DefaultTimeout
+	}
+	return context.WithTimeout(context.WithoutCancel(parent), maxDuration)
+}
+
+// runtimeTimeout — таймаут саги у компонентов, запускающих саги в фоне; меняется на лету
+// через их SetSagaTimeout. Нулевое значение означает DefaultTimeout.
+type runtimeTimeout struct {
+	nanos atomic.Int64
+}
+
+func (t *runtimeTimeout) get() time.Duration {
+	if d := time.Duration(t.nanos.Load()); d > 0 {
+		return d
+	}
+	return DefaultTimeout
+}
+
+// set игнорирует неположительные значения, как и опции With*SagaTimeout.
+func (t *runtimeTimeout) set(timeout time.Duration) {
+	if timeout > 0 {
+		t.nanos.Store(int64(timeout))
+	}
+}
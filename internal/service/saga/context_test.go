@@ -0,0 +1,44 @@
+package saga
+
+import (
+	"context"
+	"testing"
+	"time"
+)
+
+type ctxKey struct{}
+
+func TestDetachedContext_KeepsValuesAndIgnoresParentCancel(t *testing.T) {
+	parent, cancelParent := context.WithCancel(context.WithValue(context.Background(), ctxKey{}, "trace-1"))
+
+	ctx, cancel := DetachedContext(parent, time.Minute)
+	defer cancel()
+	cancelParent()
+
+	if ctx.Err() != nil {
+		t.Fatalf("detached context must survive parent cancel, got %v", ctx.Err())
+	}
+	if got := ctx.Value(ctxKey{}); got != "trace-1" {
+		t.Fatalf("expected parent values to be kept, got %v", got)
+	}
+	deadline, ok := ctx.Deadline()
+	if !ok || time.Until(deadline) > time.Minute {
+		t.Fatalf("expected deadline within a minute, got %v (ok=%v)", deadline, ok)
+	}
+}
+
+func TestDetachedContext_DefaultsAndExpiry(t *testing.T) {
+	ctx, cancel := DetachedContext(context.Background(), 0)
+	defer cancel()
+	if deadline, ok := ctx.Deadline(); !ok || time.Until(deadline) > DefaultTimeout {
+		t.Fatalf("expected default timeout %s, got %v (ok=%v)", DefaultTimeout, deadline, ok)
+	}
+
+	short, cancelShort := DetachedContext(context.Background(), time.Millisecond)
+	defer cancelShort()
+	select {
+	case <-short.Done():
+	case <-time.After(time.Second):
+		t.Fatal("detached context did not expire")
+	}
+}
@@ -0,0 +1,98 @@
+package saga
+
+import "context"
+
+type customerContextKey struct{}
+
+// ContextWithCustomer помечает операцию саги покупателем: BatchProcessor ставит операции
+// одного покупателя в общую очередь и чередует очереди разных покупателей.
+func ContextWithCustomer(ctx context.Context, customerID string) context.Context {
+	return context.WithValue(ctx, customerContextKey{}, customerID)
+}
+
+// CustomerFromContext возвращает покупателя операции; пусто — покупатель не указан,
+// такие операции делят одну общую очередь.
+func CustomerFromContext(ctx context.Context) string {
+	if ctx == nil {
+		return ""
+	}
+	customerID, _ := ctx.Value(customerContextKey{}).(string)
+	return customerID
+}
+
+// Значения label customer_load у oms_saga_batch_queue_wait_seconds.
+const (
+	customerLoadInteractive = "interactive"
+	customerLoadBulk        = "bulk"
+)
+
+// fairQueue — очереди операций по покупателям с выдачей round-robin: за один take каждый
+// покупатель получает не больше одного слота за круг, поэтому массовый импорт одного покупателя
+// не вытесняет из батча заказы остальных. Не потокобезопасна: ею владеет горутина батча.
+type fairQueue[T any] struct {
+	queues map[string][]fairItem[T]
+	// ring — покупатели с непустой очередью в порядке обслуживания; обход продолжается
+	// между вызовами take.
+	ring []string
+	size int
+}
+
+type fairItem[T any] struct {
+	req  T
+	load string
+}
+
+func newFairQueue[T any]() *fairQueue[T] {
+	return &fairQueue[T]{queues: make(map[string][]fairItem[T])}
+}
+
+// push ставит операцию в очередь покупателя. Операция считается bulk, если у покупателя уже
+// ждёт bulkAfter операций, то есть он один способен заполнить батч.
+func (q *fairQueue[T]) push(customer string, req T, bulkAfter int) {
+	queued, ok := q.queues[customer]
+	if !ok {
+		q.ring = append(q.ring, customer)
+	}
+	load := customerLoadInteractive
+	if bulkAfter > 0 && len(queued) >= bulkAfter {
+		load = customerLoadBulk
+	}
+	q.queues[customer] = append(queued, fairItem[T]{req: req, load: load})
+	q.size++
+}
+
+// take снимает до limit операций, по одной от покупателя за круг.
+func (q *fairQueue[T]) take(limit int) []fairItem[T] {
+	if limit <= 0 || q.size == 0 {
+		return nil
+	}
+	batch := make([]fairItem[T], 0, min(limit, q.size))
+	for len(batch) < limit && len(q.ring) > 0 {
+		customer := q.ring[0]
+		q.ring = q.ring[1:]
+		queued := q.queues[customer]
+		batch = append(batch, queued[0])
+		queued[0] = fairItem[T]{}
+		if len(queued) == 1 {
+			delete(q.queues, customer)
+			continue
+		}
+		q.queues[customer] = queued[1:]
+		q.ring = append(q.ring, customer)
+	}
+	q.size -= len(batch)
+	return batch
+}
+
+// clear очищает очередь и возвращает число снятых операций.
+func (q *fairQueue[T]) clear() int {
+	dropped := q.size
+	clear(q.queues)
+	q.ring = nil
+	q.size = 0
+	return dropped
+}
+
+func (q *fairQueue[T]) len() int {
+	return q.size
+}
@@ -0,0 +1,72 @@
+package saga
+
+import (
+	"context"
+	"slices"
+	"testing"
+)
+
+func TestFairQueue_RoundRobinAcrossCustomers(t *testing.T) {
+	q := newFairQueue[string]()
+	for _, id := range []string{"bulk-1", "bulk-2", "bulk-3", "bulk-4"} {
+		q.push("bulk", id, 2)
+	}
+	q.push("alice", "alice-1", 2)
+	q.push("bob", "bob-1", 2)
+	q.push("bob", "bob-2", 2)
+
+	take := func(limit int) []string {
+		var ids []string
+		for _, item := range q.take(limit) {
+			ids = append(ids, item.req)
+		}
+		return ids
+	}
+	if got := take(4); !slices.Equal(got, []string{"bulk-1", "alice-1", "bob-1", "bulk-2"}) {
+		t.Fatalf("unexpected first batch: %v", got)
+	}
+	// Круг продолжается с того места, где остановился предыдущий take.
+	if got := take(10); !slices.Equal(got, []string{"bob-2", "bulk-3", "bulk-4"}) {
+		t.Fatalf("unexpected second batch: %v", got)
+	}
+	if q.len() != 0 || len(q.ring) != 0 || len(q.queues) != 0 {
+		t.Fatalf("expected empty queue, got size=%d ring=%v", q.len(), q.ring)
+	}
+}
+
+func TestFairQueue_BulkLoadAndClear(t *testing.T) {
+	q := newFairQueue[int]()
+	for i := range 3 {
+		q.push("bulk", i, 2)
+	}
+	q.push("alice", 10, 2)
+
+	loads := map[int]string{}
+	for _, item := range q.take(2) {
+		loads[item.req] = item.load
+	}
+	if loads[0] != customerLoadInteractive || loads[10] != customerLoadInteractive {
+		t.Fatalf("first operations of a customer must be interactive, got %v", loads)
+	}
+	if item := q.take(1)[0]; item.req != 1 || item.load != customerLoadInteractive {
+		t.Fatalf("unexpected item %+v", item)
+	}
+	if item := q.take(1)[0]; item.req != 2 || item.load != customerLoadBulk {
+		t.Fatalf("operation queued behind a full batch must be bulk, got %+v", item)
+	}
+
+	q.push("alice", 11, 2)
+	q.push("bob", 12, 2)
+	if dropped := q.clear(); dropped != 2 || q.take(10) != nil {
+		t.Fatalf("expected clear to drop 2 operations, got %d", dropped)
+	}
+}
+
+func TestCustomerFromContext(t *testing.T) {
+	if got := CustomerFromContext(context.Background()); got != "" {
+		t.Fatalf("expected empty customer, got %q", got)
+	}
+	if got := CustomerFromContext(ContextWithCustomer(context.Background(), "customer-1")); got != "customer-1" {
+		t.Fatalf("expected customer-1, got %q", got)
+	}
+}
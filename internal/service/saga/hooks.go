@@ -0,0 +1,266 @@
+package saga
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	log "github.com/sirupsen/logrus"
+
+	"github.com/vladislavdragonenkov/oms/internal/domain"
+	"github.com/vladislavdragonenkov/oms/internal/metrics"
+)
+
+const defaultHookTimeout = 2 * time.Second
+
+// errHookPanic помечает хук, завершившийся паникой.
+var errHookPanic = errors.New("saga hook panicked")
+
+// HookPoint — точка саги, в которой вызываются хуки плагинов (label point у oms_saga_hook_calls_total).
+type HookPoint string
+
+// Точки хуков в порядке шагов саги; HookCompensation — при любом откате заказа.
+const (
+	HookBeforeReserve HookPoint = "before_reserve"
+	HookAfterReserve  HookPoint = "after_reserve"
+	HookBeforePay     HookPoint = "before_pay"
+	HookAfterPay      HookPoint = "after_pay"
+	HookAfterConfirm  HookPoint = "after_confirm"
+	HookCompensation  HookPoint = "compensation"
+)
+
+// Исходы вызова хука (label result у oms_saga_hook_calls_total).
+const (
+	hookResultOK      = "ok"
+	hookResultError   = "error"
+	hookResultPanic   = "panic"
+	hookResultTimeout = "timeout"
+)
+
+// Виды компенсации в Compensation.Kind.
+const (
+	CompensationCanceled = "canceled"
+	CompensationRefunded = "refunded"
+	CompensationFailed   = "failed"
+)
+
+// Plugin — расширение саги (начисление баллов, уведомления и т.п.). Точки, в которых вызывается
+// плагин, определяются тем, какие интерфейсы *Hook он реализует. Name попадает в логи и метрики
+// и должен быть уникален среди плагинов оркестратора.
+type Plugin interface {
+	Name() string
+}
+
+// BeforeReserveHook вызывается перед каждой попыткой резерва склада, в том числе после backorder.
+type BeforeReserveHook interface {
+	OnBeforeReserve(ctx context.Context, order domain.Order) error
+}
+
+// AfterReserveHook вызывается после перехода заказа в reserved.
+type AfterReserveHook interface {
+	OnAfterReserve(ctx context.Context, order domain.Order) error
+}
+
+// BeforePayHook вызывается перед каждым вызовом PSP, в том числе перед отложенными повторами.
+type BeforePayHook interface {
+	OnBeforePay(ctx context.Context, order domain.Order) error
+}
+
+// AfterPayHook вызывается после перехода заказа в paid (списание сразу или capture).
+type AfterPayHook interface {
+	OnAfterPay(ctx context.Context, order domain.Order) error
+}
+
+// AfterConfirmHook вызывается после успешного завершения саги (confirmed).
+type AfterConfirmHook interface {
+	OnAfterConfirm(ctx context.Context, order domain.Order) error
+}
+
+// CompensationHook вызывается после отката заказа: отмены, возврата или провала саги.
+type CompensationHook interface {
+	OnCompensation(ctx context.Context, order domain.Order, compensation Compensation) error
+}
+
+// Compensation описывает откат, о котором сообщает CompensationHook.
+type Compensation struct {
+	// Kind — CompensationCanceled, CompensationRefunded или CompensationFailed (сага отменила заказ сама).
+	Kind   string
+	Reason string
+	// AmountMinor — сумма возврата; 0 для отмены без списания.
+	AmountMinor int64
+}
+
+// WithPlugins подключает плагины саги. Гарантии:
+//   - хуки вызываются синхронно в горутине саги, в порядке подключения плагинов, и видят точки
+//     одного заказа в порядке шагов саги;
+//   - After*-хуки и CompensationHook вызываются после того, как статус сохранён и событие ушло в outbox;
+//   - ошибка, паника или таймаут хука логируются и считаются в метриках, но не меняют ход саги;
+//   - заказ передаётся копией: изменения в хуке не попадают в сагу.
+func WithPlugins(plugins ...Plugin) OrchestratorOption {
+	return func(o *orchestrator) {
+		for _, plugin := range plugins {
+			if plugin != nil {
+				o.plugins = append(o.plugins, plugin)
+			}
+		}
+		if len(o.plugins) > 0 && o.hookMetrics == nil {
+			o.hookMetrics = newHookMetrics(nil)
+		}
+	}
+}
+
+var (
+	registryMu sync.Mutex
+	registry   []Plugin
+)
+
+// RegisterPlugin регистрирует плагин для оркестратора сервиса: пакет плагина вызывает его из init,
+// а сборка сервиса импортирует пакет (import _). Порядок регистрации — порядок вызова хуков.
+// Пустое или повторное имя — паника, как у sql.Register.
+func RegisterPlugin(plugin Plugin) {
+	registryMu.Lock()
+	defer registryMu.Unlock()
+	if plugin == nil || plugin.Name() == "" {
+		panic("saga: RegisterPlugin plugin is nil or unnamed")
+	}
+	for _, existing := range registry {
+		if existing.Name() == plugin.Name() {
+			panic("saga: RegisterPlugin called twice for plugin " + plugin.Name())
+		}
+	}
+	registry = append(registry, plugin)
+}
+
+// RegisteredPlugins возвращает зарегистрированные плагины в порядке регистрации.
+func RegisteredPlugins() []Plugin {
+	registryMu.Lock()
+	defer registryMu.Unlock()
+	return append([]Plugin(nil), registry...)
+}
+
+// WithHookTimeout ограничивает время одного вызова хука (по умолчанию 2s). Хук получает контекст
+// с этим дедлайном; сага не ждёт хук дольше, даже если он контекст игнорирует, и такой хук
+// может продолжать работу параллельно со следующими шагами.
+func WithHookTimeout(timeout time.Duration) OrchestratorOption {
+	return func(o *orchestrator) {
+		if timeout > 0 {
+			o.hookTimeout = timeout
+		}
+	}
+}
+
+type hookMetrics struct {
+	calls    *prometheus.CounterVec
+	duration *prometheus.HistogramVec
+}
+
+func newHookMetrics(registerer prometheus.Registerer) *hookMetrics {
+	return &hookMetrics{
+		calls: metrics.Register(registerer, prometheus.NewCounterVec(prometheus.CounterOpts{
+			Name: "oms_saga_hook_calls_total",
+			Help: "Total number of saga plugin hook calls by plugin, point and result.",
+		}, []string{"plugin", "point", "result"})),
+		duration: metrics.Register(registerer, prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Name:    "oms_saga_hook_duration_seconds",
+			Help:    "Duration of saga plugin hook calls.",
+			Buckets: prometheus.DefBuckets,
+		}, []string{"plugin", "point"})),
+	}
+}
+
+// runHooks вызывает хуки точки point у всех плагинов по порядку подключения.
+func (o *orchestrator) runHooks(ctx context.Context, point HookPoint, order *domain.Order, compensation Compensation) {
+	if len(o.plugins) == 0 {
+		return
+	}
+	snapshot := *order
+	snapshot.Items = append([]domain.OrderItem(nil), order.Items...)
+	for _, plugin := range o.plugins {
+		if call := hookCall(plugin, point, snapshot, compensation); call != nil {
+			o.callHook(ctx, plugin.Name(), point, order.ID, call)
+		}
+	}
+}
+
+// hookCall возвращает вызов хука point, если плагин его реализует.
+func hookCall(plugin Plugin, point HookPoint, order domain.Order, compensation Compensation) func(context.Context) error {
+	switch point {
+	case HookBeforeReserve:
+		if hook, ok := plugin.(BeforeReserveHook); ok {
+			return func(ctx context.Context) error { return hook.OnBeforeReserve(ctx, order) }
+		}
+	case HookAfterReserve:
+		if hook, ok := plugin.(AfterReserveHook); ok {
+			return func(ctx context.Context) error { return hook.OnAfterReserve(ctx, order) }
+		}
+	case HookBeforePay:
+		if hook, ok := plugin.(BeforePayHook); ok {
+			return func(ctx context.Context) error { return hook.OnBeforePay(ctx, order) }
+		}
+	case HookAfterPay:
+		if hook, ok := plugin.(AfterPayHook); ok {
+			return func(ctx context.Context) error { return hook.OnAfterPay(ctx, order) }
+		}
+	case HookAfterConfirm:
+		if hook, ok := plugin.(AfterConfirmHook); ok {
+			return func(ctx context.Context) error { return hook.OnAfterConfirm(ctx, order) }
+		}
+	case HookCompensation:
+		if hook, ok := plugin.(CompensationHook); ok {
+			return func(ctx context.Context) error { return hook.OnCompensation(ctx, order, compensation) }
+		}
+	}
+	return nil
+}
+
+// callHook изолирует хук от саги: контекст не наследует отмену саги (компенсации уведомляются
+// и после её дедлайна), паника перехватывается, ожидание ограничено hookTimeout.
+func (o *orchestrator) callHook(ctx context.Context, plugin string, point HookPoint, orderID string, call func(context.Context) error) {
+	timeout := o.hookTimeout
+	if timeout <= 0 {
+		timeout = defaultHookTimeout
+	}
+	hookCtx, cancel := context.WithTimeout(context.WithoutCancel(ctx), timeout)
+	defer cancel()
+
+	started := time.Now()
+	done := make(chan error, 1)
+	go func() {
+		defer func() {
+			if recovered := recover(); recovered != nil {
+				done <- fmt.Errorf("%w: %v", errHookPanic, recovered)
+			}
+		}()
+		done <- call(hookCtx)
+	}()
+
+	var err error
+	result := hookResultOK
+	select {
+	case err = <-done:
+		switch {
+		case errors.Is(err, errHookPanic):
+			result = hookResultPanic
+		case err != nil:
+			result = hookResultError
+		}
+	case <-hookCtx.Done():
+		err, result = hookCtx.Err(), hookResultTimeout
+	}
+
+	if o.hookMetrics != nil {
+		o.hookMetrics.calls.WithLabelValues(plugin, string(point), result).Inc()
+		o.hookMetrics.duration.WithLabelValues(plugin, string(point)).Observe(time.Since(started).Seconds())
+	}
+	if err != nil {
+		o.logger.WithError(err).WithFields(log.Fields{
+			"order_id": orderID,
+			"plugin":   plugin,
+			"point":    point,
+			"result":   result,
+		}).Warn("saga hook failed")
+	}
+}
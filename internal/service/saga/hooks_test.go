@@ -0,0 +1,214 @@
+package saga
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	log "github.com/sirupsen/logrus"
+
+	"github.com/vladislavdragonenkov/oms/internal/domain"
+	"github.com/vladislavdragonenkov/oms/internal/storage/memory"
+)
+
+// recordingPlugin реализует все хуки и пишет вызовы в общий журнал.
+type recordingPlugin struct {
+	name    string
+	mu      *sync.Mutex
+	journal *[]string
+	fail    HookPoint
+	panicAt HookPoint
+	block   HookPoint
+
+	compensations []Compensation
+}
+
+func (p *recordingPlugin) Name() string { return p.name }
+
+func (p *recordingPlugin) record(ctx context.Context, point HookPoint, order domain.Order) error {
+	p.mu.Lock()
+	*p.journal = append(*p.journal, p.name+":"+string(point)+":"+string(order.Status))
+	p.mu.Unlock()
+	switch point {
+	case p.panicAt:
+		panic("boom")
+	case p.fail:
+		return errors.New("hook failed")
+	case p.block:
+		<-ctx.Done()
+		return ctx.Err()
+	}
+	return nil
+}
+
+func (p *recordingPlugin) OnBeforeReserve(ctx context.Context, order domain.Order) error {
+	return p.record(ctx, HookBeforeReserve, order)
+}
+
+func (p *recordingPlugin) OnAfterReserve(ctx context.Context, order domain.Order) error {
+	return p.record(ctx, HookAfterReserve, order)
+}
+
+func (p *recordingPlugin) OnBeforePay(ctx context.Context, order domain.Order) error {
+	return p.record(ctx, HookBeforePay, order)
+}
+
+func (p *recordingPlugin) OnAfterPay(ctx context.Context, order domain.Order) error {
+	order.Items[0].Qty = 100 // изменение копии не должно попасть в сагу
+	return p.record(ctx, HookAfterPay, order)
+}
+
+func (p *recordingPlugin) OnAfterConfirm(ctx context.Context, order domain.Order) error {
+	return p.record(ctx, HookAfterConfirm, order)
+}
+
+func (p *recordingPlugin) OnCompensation(ctx context.Context, order domain.Order, compensation Compensation) error {
+	p.mu.Lock()
+	p.compensations = append(p.compensations, compensation)
+	p.mu.Unlock()
+	return p.record(ctx, HookCompensation, order)
+}
+
+// confirmOnly реализует только один хук.
+type confirmOnly struct{ calls int }
+
+func (p *confirmOnly) Name() string { return "hooks-test-confirm-only" }
+
+func (p *confirmOnly) OnAfterConfirm(context.Context, domain.Order) error {
+	p.calls++
+	return nil
+}
+
+func newPlugins(names ...string) ([]*recordingPlugin, *[]string) {
+	var (
+		mu      sync.Mutex
+		journal []string
+	)
+	plugins := make([]*recordingPlugin, 0, len(names))
+	for _, name := range names {
+		plugins = append(plugins, &recordingPlugin{name: name, mu: &mu, journal: &journal})
+	}
+	return plugins, &journal
+}
+
+func TestHooks_CalledInStepAndRegistrationOrder(t *testing.T) {
+	repo := memory.NewOrderRepository()
+	seedOrder(t, repo, domain.OrderStatusPending)
+	plugins, journal := newPlugins("hooks-test-loyalty", "hooks-test-notify")
+	only := &confirmOnly{}
+
+	orch := NewOrchestratorWithoutMetrics(repo, memory.NewOutboxRepository(), memory.NewTimelineRepository(),
+		&stubInventory{}, &stubPayment{payStatus: domain.PaymentStatusCaptured}, log.New().WithField("test", "hooks"),
+		WithPlugins(plugins[0], only, nil, plugins[1]))
+	orch.Start(context.Background(), "order-1")
+
+	want := []string{
+		"hooks-test-loyalty:before_reserve:pending", "hooks-test-notify:before_reserve:pending",
+		"hooks-test-loyalty:after_reserve:reserved", "hooks-test-notify:after_reserve:reserved",
+		"hooks-test-loyalty:before_pay:reserved", "hooks-test-notify:before_pay:reserved",
+		"hooks-test-loyalty:after_pay:paid", "hooks-test-notify:after_pay:paid",
+		"hooks-test-loyalty:after_confirm:confirmed", "hooks-test-notify:after_confirm:confirmed",
+	}
+	if !reflect.DeepEqual(*journal, want) {
+		t.Fatalf("unexpected hook order:\n got %v\nwant %v", *journal, want)
+	}
+	if only.calls != 1 {
+		t.Fatalf("expected single AfterConfirm call, got %d", only.calls)
+	}
+	updated, err := repo.Get("order-1")
+	if err != nil {
+		t.Fatalf("get order: %v", err)
+	}
+	if updated.Items[0].Qty != 1 {
+		t.Fatalf("hook mutated saga order: qty %d", updated.Items[0].Qty)
+	}
+}
+
+func TestHooks_FailuresAreIsolated(t *testing.T) {
+	repo := memory.NewOrderRepository()
+	seedOrder(t, repo, domain.OrderStatusPending)
+	plugins, journal := newPlugins("hooks-test-failing", "hooks-test-panicking", "hooks-test-slow", "hooks-test-healthy")
+	plugins[0].fail = HookBeforeReserve
+	plugins[1].panicAt = HookBeforePay
+	plugins[2].block = HookAfterReserve
+
+	orch := NewOrchestratorWithoutMetrics(repo, memory.NewOutboxRepository(), memory.NewTimelineRepository(),
+		&stubInventory{}, &stubPayment{payStatus: domain.PaymentStatusCaptured}, log.New().WithField("test", "hooks"),
+		WithPlugins(plugins[0], plugins[1], plugins[2], plugins[3]), WithHookTimeout(20*time.Millisecond))
+	orch.Start(context.Background(), "order-1")
+
+	updated, err := repo.Get("order-1")
+	if err != nil {
+		t.Fatalf("get order: %v", err)
+	}
+	if updated.Status != domain.OrderStatusConfirmed {
+		t.Fatalf("expected saga to complete despite hook failures, got %s", updated.Status)
+	}
+	if len(*journal) != 20 {
+		t.Fatalf("expected every hook to be called, got %v", *journal)
+	}
+
+	calls := orch.(*orchestrator).hookMetrics.calls
+	for _, tc := range []struct {
+		plugin string
+		point  HookPoint
+		result string
+	}{
+		{"hooks-test-failing", HookBeforeReserve, hookResultError},
+		{"hooks-test-panicking", HookBeforePay, hookResultPanic},
+		{"hooks-test-slow", HookAfterReserve, hookResultTimeout},
+		{"hooks-test-healthy", HookAfterConfirm, hookResultOK},
+	} {
+		if got := testutil.ToFloat64(calls.WithLabelValues(tc.plugin, string(tc.point), tc.result)); got != 1 {
+			t.Fatalf("%s/%s: expected one %s call, got %v", tc.plugin, tc.point, tc.result, got)
+		}
+	}
+}
+
+func TestHooks_Compensation(t *testing.T) {
+	repo := memory.NewOrderRepository()
+	seedOrder(t, repo, domain.OrderStatusPending)
+	plugins, _ := newPlugins("hooks-test-compensation")
+
+	orch := NewOrchestratorWithoutMetrics(repo, memory.NewOutboxRepository(), memory.NewTimelineRepository(),
+		&stubInventory{}, &stubPayment{payErr: domain.ErrPaymentDeclined, refundStatus: domain.PaymentStatusRefunded}, log.New().WithField("test", "hooks"),
+		WithPlugins(plugins[0]))
+	orch.Start(context.Background(), "order-1")
+
+	if len(plugins[0].compensations) != 1 || plugins[0].compensations[0].Kind != CompensationFailed {
+		t.Fatalf("expected failed compensation, got %+v", plugins[0].compensations)
+	}
+
+	paid := memory.NewOrderRepository()
+	seedOrder(t, paid, domain.OrderStatusPaid)
+	orch = NewOrchestratorWithoutMetrics(paid, memory.NewOutboxRepository(), memory.NewTimelineRepository(),
+		&stubInventory{}, &stubPayment{refundStatus: domain.PaymentStatusRefunded}, log.New().WithField("test", "hooks"),
+		WithPlugins(plugins[0]))
+	orch.Refund(context.Background(), "order-1", 40, "damaged")
+
+	want := Compensation{Kind: CompensationRefunded, Reason: "damaged", AmountMinor: 40}
+	if len(plugins[0].compensations) != 2 || plugins[0].compensations[1] != want {
+		t.Fatalf("expected refund compensation %+v, got %+v", want, plugins[0].compensations)
+	}
+}
+
+func TestRegisterPlugin(t *testing.T) {
+	t.Cleanup(func() { registry = nil })
+	plugins, _ := newPlugins("hooks-test-registered-a", "hooks-test-registered-b")
+	RegisterPlugin(plugins[0])
+	RegisterPlugin(plugins[1])
+
+	if got := RegisteredPlugins(); len(got) != 2 || got[0] != plugins[0] || got[1] != plugins[1] {
+		t.Fatalf("expected plugins in registration order, got %v", got)
+	}
+	defer func() {
+		if recover() == nil {
+			t.Fatal("expected panic on duplicate plugin name")
+		}
+	}()
+	RegisterPlugin(plugins[0])
+}
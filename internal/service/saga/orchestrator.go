@@ -1,27 +1,39 @@
 package saga
 
 import (
-	"encoding/json"
+	"context"
 	"errors"
+	"fmt"
 	"time"
 
+	"github.com/google/uuid"
 	log "github.com/sirupsen/logrus"
 
 	"github.com/vladislavdragonenkov/oms/internal/domain"
+	"github.com/vladislavdragonenkov/oms/internal/logging"
 	"github.com/vladislavdragonenkov/oms/internal/messaging/kafka"
 	"github.com/vladislavdragonenkov/oms/internal/metrics"
+	"github.com/vladislavdragonenkov/oms/internal/timeutil"
 )
 
-var errSagaTerminated = errors.New("saga terminated due to terminal order status")
+var (
+	errSagaTerminated = errors.New("saga terminated due to terminal order status")
+	errSagaOnHold     = errors.New("saga paused: order is on hold")
+	errSagaBackorder  = errors.New("saga paused: order is backordered")
+	// errSagaPaymentRetry — оплата не прошла из-за временной ошибки, повтор запланирован.
+	errSagaPaymentRetry = errors.New("saga paused: payment retry scheduled")
+)
 
 // Orchestrator описывает интерфейс управления сагой.
+// Контекст ограничивает время выполнения: истёкший контекст останавливает сагу до следующего шага.
 type Orchestrator interface {
-	Start(orderID string)
-	Cancel(orderID, reason string)
-	Refund(orderID string, amountMinor int64, reason string)
+	Start(ctx context.Context, orderID string)
+	Cancel(ctx context.Context, orderID, reason string)
+	Refund(ctx context.Context, orderID string, amountMinor int64, reason string)
 }
 
-// orchestrator реализует последовательность шагов саги: Reserve → Pay → Confirm.
+// orchestrator реализует последовательность шагов саги: Reserve → Pay → Confirm
+// (при двухфазной оплате — Reserve → Authorize, затем Capture → Confirm).
 type orchestrator struct {
 	orders        domain.OrderRepository
 	outbox        domain.OutboxRepository
@@ -30,7 +42,90 @@ type orchestrator struct {
 	payments      domain.PaymentService
 	logger        *log.Entry
 	metrics       *metrics.SagaMetrics
-	kafkaProducer *kafka.Producer // опциональный Kafka producer для event-driven архитектуры
+	kafkaProducer kafka.EventPublisher // опциональный Kafka producer (или kafka.RetryQueue поверх него)
+	// eventsTopic — топик событий саги; по умолчанию kafka.TopicSagaEvents.
+	eventsTopic string
+	// eventsKey — стратегия ключа событий саги; по умолчанию kafka.KeyByOrder.
+	eventsKey kafka.KeyStrategy
+	// backorders: при нехватке стока заказ ждёт пополнения склада вместо отмены.
+	backorders bool
+	// testInventory/testPayments обслуживают sandbox-заказы (Order.TestMode).
+	testInventory domain.InventoryService
+	testPayments  domain.PaymentService
+	// logSampler ограничивает повторяющиеся предупреждения горячих путей; nil пишет всё.
+	logSampler *logging.Sampler
+	// authorizations включает двухфазную оплату: блокировки ждут Capture и истекают через authorizationTTL.
+	authorizations   domain.PaymentAuthorizationRepository
+	authorizationTTL time.Duration
+	// paymentRetries включает отложенные повторы оплаты после временных ошибок PSP (WithPaymentRetries).
+	paymentRetries     domain.PaymentRetryRepository
+	paymentRetryPolicy PaymentRetryPolicy
+	// plugins — расширения саги с хуками вокруг шагов (WithPlugins), вызываются по порядку.
+	plugins     []Plugin
+	hookTimeout time.Duration
+	hookMetrics *hookMetrics
+}
+
+// OrchestratorOption настраивает orchestrator.
+type OrchestratorOption func(*orchestrator)
+
+// WithBackorders включает backorder: если Reserve вернул domain.ErrInventoryUnavailable,
+// заказ переходит в backordered и возобновляется по событию пополнения склада (см. BackorderResumer).
+func WithBackorders(enabled bool) OrchestratorOption {
+	return func(o *orchestrator) {
+		o.backorders = enabled
+	}
+}
+
+// WithEventsTopic задаёт топик событий саги, например из kafka.TopicConfig с префиксом окружения.
+func WithEventsTopic(topic string) OrchestratorOption {
+	return func(o *orchestrator) {
+		if topic != "" {
+			o.eventsTopic = topic
+		}
+	}
+}
+
+// WithEventsKeyStrategy задаёт ключ событий саги (kafka.TopicConfig.SagaEventsKey): например,
+// kafka.KeyByCustomer сохраняет порядок событий клиента для проекций по клиенту.
+func WithEventsKeyStrategy(strategy kafka.KeyStrategy) OrchestratorOption {
+	return func(o *orchestrator) {
+		o.eventsKey = strategy
+	}
+}
+
+// WithMetrics задаёт метрики саги, например созданные metrics.NewSagaMetricsWithRegistry
+// с отдельным реестром; nil отключает метрики.
+func WithMetrics(sagaMetrics *metrics.SagaMetrics) OrchestratorOption {
+	return func(o *orchestrator) {
+		o.metrics = sagaMetrics
+	}
+}
+
+// WithTestModeServices задаёт заглушки склада и оплаты для sandbox-заказов. Без них sandbox-заказ
+// отменяется с domain.ErrTestModeUnavailable: реальные сервисы для него не вызываются никогда.
+func WithTestModeServices(inventory domain.InventoryService, payments domain.PaymentService) OrchestratorOption {
+	return func(o *orchestrator) {
+		o.testInventory = inventory
+		o.testPayments = payments
+	}
+}
+
+// WithLogSampler задаёт ограничитель повторяющихся предупреждений (version conflict, ошибки
+// публикации в Kafka); nil пишет каждую запись.
+func WithLogSampler(sampler *logging.Sampler) OrchestratorOption {
+	return func(o *orchestrator) {
+		o.logSampler = sampler
+	}
+}
+
+func (o *orchestrator) apply(opts []OrchestratorOption) Orchestrator {
+	for _, opt := range opts {
+		if opt != nil {
+			opt(o)
+		}
+	}
+	return o
 }
 
 // NewOrchestrator создаёт рабочий экземпляр оркестратора.
@@ -41,35 +136,40 @@ func NewOrchestrator(
 	inventory domain.InventoryService,
 	payments domain.PaymentService,
 	logger *log.Entry,
+	opts ...OrchestratorOption,
 ) Orchestrator {
 	if logger == nil {
 		logger = log.New().WithField("component", "saga")
 	}
-	return &orchestrator{
-		orders:    orders,
-		outbox:    outbox,
-		timeline:  timeline,
-		inventory: inventory,
-		payments:  payments,
-		logger:    logger,
-		metrics:   metrics.NewSagaMetrics(),
-	}
+	o := &orchestrator{
+		orders:     orders,
+		outbox:     outbox,
+		timeline:   timeline,
+		inventory:  inventory,
+		payments:   payments,
+		logger:     logger,
+		metrics:    metrics.NewSagaMetrics(),
+		logSampler: logging.NewSampler("saga"),
+	}
+	return o.apply(opts)
 }
 
 // NewOrchestratorWithKafka создаёт оркестратор с Kafka producer для event-driven архитектуры.
+// Чтобы кратковременная недоступность брокера не теряла события саги, передайте kafka.RetryQueue.
 func NewOrchestratorWithKafka(
 	orders domain.OrderRepository,
 	outbox domain.OutboxRepository,
 	timeline domain.TimelineRepository,
 	inventory domain.InventoryService,
 	payments domain.PaymentService,
-	kafkaProducer *kafka.Producer,
+	kafkaProducer kafka.EventPublisher,
 	logger *log.Entry,
+	opts ...OrchestratorOption,
 ) Orchestrator {
 	if logger == nil {
 		logger = log.New().WithField("component", "saga")
 	}
-	return &orchestrator{
+	o := &orchestrator{
 		orders:        orders,
 		outbox:        outbox,
 		timeline:      timeline,
@@ -78,7 +178,9 @@ func NewOrchestratorWithKafka(
 		logger:        logger,
 		metrics:       metrics.NewSagaMetrics(),
 		kafkaProducer: kafkaProducer,
+		logSampler:    logging.NewSampler("saga"),
 	}
+	return o.apply(opts)
 }
 
 // NewOrchestratorWithoutMetrics создаёт оркестратор без метрик (для тестов).
@@ -89,11 +191,12 @@ func NewOrchestratorWithoutMetrics(
 	inventory domain.InventoryService,
 	payments domain.PaymentService,
 	logger *log.Entry,
+	opts ...OrchestratorOption,
 ) Orchestrator {
 	if logger == nil {
 		logger = log.New().WithField("component", "saga")
 	}
-	return &orchestrator{
+	o := &orchestrator{
 		orders:    orders,
 		outbox:    outbox,
 		timeline:  timeline,
@@ -102,10 +205,13 @@ func NewOrchestratorWithoutMetrics(
 		logger:    logger,
 		metrics:   nil, // Отключаем метрики для тестов
 	}
+	return o.apply(opts)
 }
 
 // Start запускает обработку заказа. Метод идемпотентен относительно конечных статусов.
-func (o *orchestrator) Start(orderID string) {
+// Если контекст истёк до списания денег, зарезервированный заказ отменяется;
+// после успешной оплаты сага всегда доводится до подтверждения.
+func (o *orchestrator) Start(ctx context.Context, orderID string) {
 	start := time.Now()
 	if o.metrics != nil {
 		o.metrics.RecordSagaStarted()
@@ -117,7 +223,7 @@ func (o *orchestrator) Start(orderID string) {
 		}
 	}()
 
-	order, err := o.orders.Get(orderID)
+	order, err := o.loadOrder(orderID)
 	if err != nil {
 		o.logger.WithError(err).WithField("order_id", orderID).Warn("order not found for saga")
 		if o.metrics != nil {
@@ -127,24 +233,46 @@ func (o *orchestrator) Start(orderID string) {
 	}
 
 	// Публикуем событие начала саги
-	o.publishSagaEvent(kafka.EventTypeSagaStarted, orderID, map[string]interface{}{
-		"customer_id": order.CustomerID,
-		"status":      string(order.Status),
+	o.publishSagaEvent(ctx, &order, kafka.SagaStartedMetadata{
+		CustomerID: order.CustomerID,
+		Status:     string(order.Status),
 	})
 
 	switch order.Status {
-	case domain.OrderStatusPending:
-		if err := o.handleReserve(&order); err != nil {
+	case domain.OrderStatusPending, domain.OrderStatusBackordered:
+		// Заказ остаётся pending/backordered: резерв ещё не сделан, компенсировать нечего.
+		if o.deadlineExceeded(ctx, order.ID, "reserve") {
+			return
+		}
+		o.runHooks(ctx, HookBeforeReserve, &order, Compensation{})
+		stepStart := time.Now()
+		err := o.handleReserve(ctx, &order)
+		o.recordStep(domain.SagaStepReserve, stepStart)
+		if err != nil {
 			return
 		}
 		fallthrough
 	case domain.OrderStatusReserved:
-		if err := o.handlePayment(&order); err != nil {
+		if o.deadlineExceeded(ctx, order.ID, "payment") {
+			o.releaseInventory(&order)
+			o.failOrder(ctx, &order, domain.OrderStatusCanceled, fmt.Errorf("%w: %v", ErrDeadlineExceeded, ctx.Err()))
+			return
+		}
+		stepStart := time.Now()
+		err := o.handlePayment(ctx, &order)
+		o.recordStep(domain.SagaStepPay, stepStart)
+		if err != nil {
 			return
 		}
 		fallthrough
 	case domain.OrderStatusPaid:
-		o.handleConfirm(&order)
+		stepStart := time.Now()
+		o.handleConfirm(ctx, &order)
+		o.recordStep(domain.SagaStepConfirm, stepStart)
+	case domain.OrderStatusAuthorized:
+		o.logger.WithField("order_id", order.ID).Debug("payment authorized, saga waits for capture")
+	case domain.OrderStatusOnHold:
+		o.logOnHold(&order)
 	default:
 		o.logger.WithFields(log.Fields{
 			"order_id": order.ID,
@@ -152,56 +280,116 @@ func (o *orchestrator) Start(orderID string) {
 	}
 }
 
-func (o *orchestrator) handleReserve(order *domain.Order) error {
-	if err := o.inventory.Reserve(order.ID, order.Items); err != nil {
+// recordStep пишет длительность шага саги; шаг считается и при неудаче — медленный отказ склада
+// или PSP виден на тех же панелях.
+func (o *orchestrator) recordStep(step domain.SagaStep, started time.Time) {
+	if o.metrics != nil {
+		o.metrics.RecordStepDuration(string(step), time.Since(started))
+	}
+}
+
+func (o *orchestrator) handleReserve(ctx context.Context, order *domain.Order) error {
+	if err := o.inventoryFor(order).Reserve(order.ID, order.Items); err != nil {
+		if o.backorders && errors.Is(err, domain.ErrInventoryUnavailable) {
+			o.backorder(ctx, order, err)
+			return errSagaBackorder
+		}
 		o.logger.WithError(err).WithField("order_id", order.ID).Warn("reserve failed")
-		o.failOrder(order, domain.OrderStatusCanceled, err)
+		o.failOrder(ctx, order, domain.OrderStatusCanceled, err)
 		return err
 	}
-	if err := o.updateStatus(order, domain.OrderStatusReserved); err != nil {
+	if err := o.updateStatus(ctx, order, domain.OrderStatusReserved); err != nil {
 		return err
 	}
 	// Публикуем событие в Kafka
-	o.publishSagaEvent(kafka.EventTypeStepReserved, order.ID, map[string]interface{}{
-		"customer_id": order.CustomerID,
-		"items_count": len(order.Items),
+	o.publishSagaEvent(ctx, order, kafka.StepReservedMetadata{
+		CustomerID: order.CustomerID,
+		ItemsCount: len(order.Items),
 	})
+	o.runHooks(ctx, HookAfterReserve, order, Compensation{})
 	return nil
 }
 
-func (o *orchestrator) handlePayment(order *domain.Order) error {
-	status, err := o.payments.Pay(order.ID, order.AmountMinor, order.Currency)
+// backorder переводит заказ в ожидание пополнения склада. Повторная нехватка стока
+// при возобновлении оставляет заказ в backordered без новых событий.
+func (o *orchestrator) backorder(ctx context.Context, order *domain.Order, reserveErr error) {
+	logger := o.logger.WithError(reserveErr).WithField("order_id", order.ID)
+	if order.Status == domain.OrderStatusBackordered {
+		logger.Info("stock is still unavailable, order stays backordered")
+		return
+	}
+	backordered := sagaEvent{eventType: "OrderBackordered", payload: map[string]interface{}{"reason": reserveErr.Error()}}
+	if err := o.updateStatus(ctx, order, domain.OrderStatusBackordered, backordered); err != nil {
+		return
+	}
+	logger.Info("order backordered until restock")
+	if o.metrics != nil {
+		o.metrics.RecordSagaBackordered()
+	}
+
+	o.publishSagaEvent(ctx, order, kafka.SagaBackorderedMetadata{
+		CustomerID: order.CustomerID,
+		ItemsCount: len(order.Items),
+	})
+}
+
+func (o *orchestrator) handlePayment(ctx context.Context, order *domain.Order) error {
+	// Hold мог быть поставлен, пока шёл резерв: перед списанием денег перечитываем заказ.
+	if err := o.checkHold(order); err != nil {
+		return err
+	}
+
+	o.runHooks(ctx, HookBeforePay, order, Compensation{})
+	status, err := o.paymentsFor(order).Pay(order.ID, order.AmountMinor, order.Currency)
 	if err != nil {
 		o.logger.WithError(err).WithField("order_id", order.ID).Warn("payment failed")
+		if o.schedulePaymentRetry(ctx, order, err) {
+			return errSagaPaymentRetry
+		}
 		o.releaseInventory(order)
-		o.failOrder(order, domain.OrderStatusCanceled, err)
+		o.failOrder(ctx, order, domain.OrderStatusCanceled, err)
 		return err
 	}
 	if status != domain.PaymentStatusCaptured && status != domain.PaymentStatusAuthorized {
 		o.logger.WithField("status", status).WithField("order_id", order.ID).Warn("unexpected payment status")
+		// pending — PSP ещё не решил; failed и прочие статусы — окончательный отказ.
+		if status == domain.PaymentStatusPending && o.schedulePaymentRetry(ctx, order, domain.ErrPaymentIndeterminate) {
+			return errSagaPaymentRetry
+		}
 		o.releaseInventory(order)
-		o.failOrder(order, domain.OrderStatusCanceled, domain.ErrPaymentIndeterminate)
+		o.failOrder(ctx, order, domain.OrderStatusCanceled, domain.ErrPaymentIndeterminate)
 		return domain.ErrPaymentIndeterminate
 	}
-	if err := o.updateStatus(order, domain.OrderStatusPaid); err != nil {
+	o.forgetPaymentRetry(order.ID)
+	if status == domain.PaymentStatusAuthorized {
+		if _, ok := o.capturerFor(order); ok {
+			return o.awaitCapture(ctx, order)
+		}
+	}
+	if err := o.updateStatus(ctx, order, domain.OrderStatusPaid); err != nil {
 		return err
 	}
 	// Публикуем событие в Kafka
-	o.publishSagaEvent(kafka.EventTypeStepPaid, order.ID, map[string]interface{}{
-		"amount":   order.AmountMinor,
-		"currency": order.Currency,
-		"status":   string(status),
+	o.publishSagaEvent(ctx, order, kafka.StepPaidMetadata{
+		AmountMinor: order.AmountMinor,
+		Currency:    order.Currency,
+		Status:      string(status),
 	})
+	o.runHooks(ctx, HookAfterPay, order, Compensation{})
 	return nil
 }
 
-func (o *orchestrator) handleConfirm(order *domain.Order) {
+func (o *orchestrator) handleConfirm(ctx context.Context, order *domain.Order) {
 	o.logger.WithField("order_id", order.ID).Debug("handleConfirm called")
-	if err := o.updateStatus(order, domain.OrderStatusConfirmed); err != nil {
+	if err := o.updateStatus(ctx, order, domain.OrderStatusConfirmed); err != nil {
 		if errors.Is(err, errSagaTerminated) {
 			o.logger.WithField("order_id", order.ID).Info("confirm skipped: order reached terminal state")
 			return
 		}
+		if errors.Is(err, errSagaOnHold) {
+			o.logOnHold(order)
+			return
+		}
 		o.logger.WithError(err).WithField("order_id", order.ID).Error("confirm failed")
 		if o.metrics != nil {
 			o.metrics.RecordSagaFailed()
@@ -214,19 +402,24 @@ func (o *orchestrator) handleConfirm(order *domain.Order) {
 		o.logger.WithField("order_id", order.ID).Debug("RecordSagaCompleted called")
 	}
 	// Публикуем событие успешного завершения саги
-	o.publishSagaEvent(kafka.EventTypeSagaCompleted, order.ID, map[string]interface{}{
-		"customer_id": order.CustomerID,
-		"amount":      order.AmountMinor,
+	o.publishSagaEvent(ctx, order, kafka.SagaCompletedMetadata{
+		CustomerID:  order.CustomerID,
+		AmountMinor: order.AmountMinor,
 	})
+	o.runHooks(ctx, HookAfterConfirm, order, Compensation{})
 }
 
-func (o *orchestrator) Cancel(orderID, reason string) {
+// Cancel отменяет заказ с компенсациями. Начатые компенсации доводятся до конца даже после дедлайна.
+func (o *orchestrator) Cancel(ctx context.Context, orderID, reason string) {
 	if o.metrics != nil {
 		o.metrics.RecordSagaInFlightStarted()
 		defer o.metrics.RecordSagaInFlightFinished()
 	}
+	if o.deadlineExceeded(ctx, orderID, "cancel") {
+		return
+	}
 
-	order, err := o.orders.Get(orderID)
+	order, err := o.loadOrder(orderID)
 	if err != nil {
 		o.logger.WithError(err).WithField("order_id", orderID).Warn("order not found for cancel")
 		if o.metrics != nil {
@@ -242,13 +435,20 @@ func (o *orchestrator) Cancel(orderID, reason string) {
 		}).Debug("order already canceled or refunded")
 		return
 	}
-	if order.Status == domain.OrderStatusReserved || order.Status == domain.OrderStatusPaid || order.Status == domain.OrderStatusConfirmed {
+	// Для заказа на hold компенсации определяются статусом, в котором его остановили.
+	effective := order.EffectiveStatus()
+	switch effective {
+	case domain.OrderStatusReserved, domain.OrderStatusAuthorized, domain.OrderStatusPaid, domain.OrderStatusConfirmed:
 		// Освобождаем резерв инвентаря
 		o.releaseInventory(&order)
 	}
-	if order.Status == domain.OrderStatusPaid || order.Status == domain.OrderStatusConfirmed {
+	if effective == domain.OrderStatusAuthorized {
+		// Деньги не списаны: вместо возврата снимаем блокировку
+		o.voidAuthorization(&order)
+	}
+	if effective == domain.OrderStatusPaid || effective == domain.OrderStatusConfirmed {
 		// Возвращаем средства
-		if _, err := o.payments.Refund(order.ID, order.AmountMinor, order.Currency); err != nil {
+		if _, err := o.paymentsFor(&order).Refund(order.ID, order.AmountMinor, order.Currency); err != nil {
 			o.logger.WithError(err).WithField("order_id", order.ID).Warn("refund during cancel failed")
 			if o.metrics != nil {
 				o.metrics.RecordSagaFailed()
@@ -256,38 +456,36 @@ func (o *orchestrator) Cancel(orderID, reason string) {
 			return
 		}
 	}
-	if err := o.updateStatus(&order, domain.OrderStatusCanceled); err != nil {
-		return
+	payload := make(map[string]interface{}, 1+eventPayloadExtraKeys)
+	if reason != "" {
+		payload["reason"] = reason
 	}
-
-	payload := map[string]interface{}{
-		"reason": reason,
-	}
-	occurredAt := time.Now().UTC()
-	payload["ts"] = occurredAt.Format(time.RFC3339Nano)
-	if reason == "" {
-		delete(payload, "reason")
+	if err := o.updateStatus(ctx, &order, domain.OrderStatusCanceled, sagaEvent{eventType: "OrderCanceled", payload: payload}); err != nil {
+		return
 	}
-	o.emitEvent(&order, "OrderCanceled", payload, occurredAt)
 
 	// Публикуем событие отмены саги в Kafka
-	o.publishSagaEvent(kafka.EventTypeSagaCanceled, order.ID, map[string]interface{}{
-		"reason":      reason,
-		"customer_id": order.CustomerID,
+	o.publishSagaEvent(ctx, &order, kafka.SagaCanceledMetadata{
+		CustomerID: order.CustomerID,
+		Reason:     reason,
 	})
 	if o.metrics != nil {
 		o.metrics.RecordSagaCanceled()
 	}
+	o.runHooks(ctx, HookCompensation, &order, Compensation{Kind: CompensationCanceled, Reason: reason})
 }
 
 // Refund инициирует возврат средств и переводит заказ в статус refunded.
-func (o *orchestrator) Refund(orderID string, amountMinor int64, reason string) {
+func (o *orchestrator) Refund(ctx context.Context, orderID string, amountMinor int64, reason string) {
 	if o.metrics != nil {
 		o.metrics.RecordSagaInFlightStarted()
 		defer o.metrics.RecordSagaInFlightFinished()
 	}
+	if o.deadlineExceeded(ctx, orderID, "refund") {
+		return
+	}
 
-	order, err := o.orders.Get(orderID)
+	order, err := o.loadOrder(orderID)
 	if err != nil {
 		o.logger.WithError(err).WithField("order_id", orderID).Warn("order not found for refund")
 		if o.metrics != nil {
@@ -313,7 +511,7 @@ func (o *orchestrator) Refund(orderID string, amountMinor int64, reason string)
 		amountMinor = order.AmountMinor
 	}
 
-	status, payErr := o.payments.Refund(order.ID, amountMinor, order.Currency)
+	status, payErr := o.paymentsFor(&order).Refund(order.ID, amountMinor, order.Currency)
 	if payErr != nil {
 		o.logger.WithError(payErr).WithField("order_id", order.ID).Warn("refund failed")
 		if o.metrics != nil {
@@ -333,72 +531,155 @@ func (o *orchestrator) Refund(orderID string, amountMinor int64, reason string)
 	}
 
 	o.releaseInventory(&order)
-	if err := o.updateStatus(&order, domain.OrderStatusRefunded); err != nil {
-		return
-	}
-
-	payload := map[string]interface{}{
-		"amount_minor": amountMinor,
-		"reason":       reason,
+	payload := make(map[string]interface{}, 2+eventPayloadExtraKeys)
+	payload["amount_minor"] = amountMinor
+	if reason != "" {
+		payload["reason"] = reason
 	}
-	occurredAt := time.Now().UTC()
-	payload["ts"] = occurredAt.Format(time.RFC3339Nano)
-	if reason == "" {
-		delete(payload, "reason")
+	if err := o.updateStatus(ctx, &order, domain.OrderStatusRefunded, sagaEvent{eventType: "OrderRefunded", payload: payload}); err != nil {
+		return
 	}
-	o.emitEvent(&order, "OrderRefunded", payload, occurredAt)
 
 	// Публикуем событие возврата в Kafka
-	o.publishSagaEvent(kafka.EventTypeSagaRefunded, order.ID, map[string]interface{}{
-		"amount":      amountMinor,
-		"reason":      reason,
-		"customer_id": order.CustomerID,
+	o.publishSagaEvent(ctx, &order, kafka.SagaRefundedMetadata{
+		CustomerID:  order.CustomerID,
+		AmountMinor: amountMinor,
+		Reason:      reason,
 	})
 	if o.metrics != nil {
 		o.metrics.RecordSagaRefunded()
 	}
+	o.runHooks(ctx, HookCompensation, &order, Compensation{Kind: CompensationRefunded, Reason: reason, AmountMinor: amountMinor})
+}
+
+// loadOrder читает заказ по ID из команды саги; некорректный ID отклоняется до обращения к хранилищу.
+func (o *orchestrator) loadOrder(orderID string) (domain.Order, error) {
+	id, err := domain.ParseOrderID(orderID)
+	if err != nil {
+		return domain.Order{}, err
+	}
+	return o.orders.Get(id.String())
 }
 
-func (o *orchestrator) failOrder(order *domain.Order, status domain.OrderStatus, rootErr error) {
+func (o *orchestrator) failOrder(ctx context.Context, order *domain.Order, status domain.OrderStatus, rootErr error) {
 	if o.metrics != nil {
 		o.metrics.RecordSagaFailed()
 	}
-	if err := o.updateStatus(order, status); err != nil {
+	failed := sagaEvent{eventType: "OrderSagaFailed", payload: map[string]interface{}{"reason": rootErr.Error()}}
+	if err := o.updateStatus(ctx, order, status, failed); err != nil {
 		return
 	}
 
-	payload := map[string]interface{}{
-		"reason": rootErr.Error(),
-	}
-	occurredAt := time.Now().UTC()
-	payload["ts"] = occurredAt.Format(time.RFC3339Nano)
-	o.emitEvent(order, "OrderSagaFailed", payload, occurredAt)
-
 	// Публикуем событие провала саги в Kafka
-	o.publishSagaEvent(kafka.EventTypeSagaFailed, order.ID, map[string]interface{}{
-		"reason":      rootErr.Error(),
-		"customer_id": order.CustomerID,
-		"status":      string(status),
+	o.publishSagaEvent(ctx, order, kafka.SagaFailedMetadata{
+		CustomerID: order.CustomerID,
+		Reason:     rootErr.Error(),
+		Status:     string(status),
 	})
+	o.runHooks(ctx, HookCompensation, order, Compensation{Kind: CompensationFailed, Reason: rootErr.Error()})
+}
+
+// deadlineExceeded сообщает, истёк ли контекст саги перед шагом step, и фиксирует остановку.
+func (o *orchestrator) deadlineExceeded(ctx context.Context, orderID, step string) bool {
+	if ctx.Err() == nil {
+		return false
+	}
+	o.logger.WithError(ctx.Err()).WithFields(log.Fields{
+		"order_id": orderID,
+		"step":     step,
+	}).Warn("saga context expired, step skipped")
+	if o.metrics != nil {
+		o.metrics.RecordSagaDeadlineExceeded()
+	}
+	return true
+}
+
+// checkHold перечитывает заказ и останавливает сагу, если он поставлен на hold.
+func (o *orchestrator) checkHold(order *domain.Order) error {
+	fresh, err := o.orders.Get(order.ID)
+	if err != nil {
+		o.logger.WithError(err).WithField("order_id", order.ID).Warn("failed to check order hold")
+		return err
+	}
+	if fresh.Status != domain.OrderStatusOnHold {
+		return nil
+	}
+	*order = fresh
+	o.logOnHold(order)
+	return errSagaOnHold
+}
+
+func (o *orchestrator) logOnHold(order *domain.Order) {
+	o.logger.WithFields(log.Fields{
+		"order_id":    order.ID,
+		"held_from":   order.HeldFromStatus,
+		"hold_reason": order.HoldReason,
+	}).Info("order is on hold, saga paused until release")
+}
+
+// inventoryFor возвращает склад, через который идёт заказ: sandbox-заказ — только через заглушку,
+// а маршрутизирующий склад получает покупателя заказа.
+func (o *orchestrator) inventoryFor(order *domain.Order) domain.InventoryService {
+	if !order.TestMode {
+		if scoped, ok := o.inventory.(domain.CustomerScopedInventory); ok {
+			return scoped.ForCustomer(order.CustomerID)
+		}
+		return o.inventory
+	}
+	if o.testInventory == nil {
+		return sandboxUnavailable{}
+	}
+	return o.testInventory
+}
+
+// paymentsFor возвращает платёжный сервис заказа по тому же правилу, что и inventoryFor.
+func (o *orchestrator) paymentsFor(order *domain.Order) domain.PaymentService {
+	if !order.TestMode {
+		return o.payments
+	}
+	if o.testPayments == nil {
+		return sandboxUnavailable{}
+	}
+	return o.testPayments
+}
+
+// sandboxUnavailable отказывает sandbox-заказам, если заглушки не настроены.
+type sandboxUnavailable struct{}
+
+func (sandboxUnavailable) Reserve(string, []domain.OrderItem) error {
+	return domain.ErrTestModeUnavailable
+}
+
+func (sandboxUnavailable) Release(string, []domain.OrderItem) error {
+	return domain.ErrTestModeUnavailable
+}
+
+func (sandboxUnavailable) Pay(string, int64, string) (domain.PaymentStatus, error) {
+	return "", domain.ErrTestModeUnavailable
+}
+
+func (sandboxUnavailable) Refund(string, int64, string) (domain.PaymentStatus, error) {
+	return "", domain.ErrTestModeUnavailable
 }
 
 func (o *orchestrator) releaseInventory(order *domain.Order) {
-	if err := o.inventory.Release(order.ID, order.Items); err != nil {
+	if err := o.inventoryFor(order).Release(order.ID, order.Items); err != nil {
 		o.logger.WithError(err).WithField("order_id", order.ID).Warn("release failed")
 	}
 }
 
-// updateStatus меняет статус заказа и эмитит событие в timeline через emitStatusEvent.
-// Реализует retry логику с exponential backoff для обработки version conflicts.
-func (o *orchestrator) updateStatus(order *domain.Order, newStatus domain.OrderStatus) error {
+// updateStatus меняет статус заказа через UpdateStatusCAS и эмитит OrderStatusChanged.
+// events — события того же перехода: они пишутся в outbox одним пакетом вслед за OrderStatusChanged.
+// На version conflict заказ перечитывается и CAS повторяется без пауз.
+func (o *orchestrator) updateStatus(ctx context.Context, order *domain.Order, newStatus domain.OrderStatus, events ...sagaEvent) error {
 	if order.Status == newStatus {
+		o.emitEvents(ctx, order, events...)
 		return nil
 	}
 
-	const maxRetries = 3
-	const baseDelay = 10 * time.Millisecond
+	const maxAttempts = 3
 
-	for attempt := 0; attempt < maxRetries; attempt++ {
+	for attempt := 0; attempt < maxAttempts; attempt++ {
 		if (order.Status == domain.OrderStatusCanceled || order.Status == domain.OrderStatusRefunded) &&
 			newStatus != order.Status {
 			o.logger.WithFields(log.Fields{
@@ -406,135 +687,236 @@ func (o *orchestrator) updateStatus(order *domain.Order, newStatus domain.OrderS
 				"order_status": order.Status,
 				"next_status":  newStatus,
 			}).Info("skip status transition for terminal order state")
+			o.recordTransition(order, newStatus, metrics.TransitionResultRejected)
 			return errSagaTerminated
 		}
+		// Заказ на hold сага не продвигает; отмена и возврат остаются доступны.
+		if order.Status == domain.OrderStatusOnHold &&
+			newStatus != domain.OrderStatusCanceled && newStatus != domain.OrderStatusRefunded {
+			o.recordTransition(order, newStatus, metrics.TransitionResultRejected)
+			return errSagaOnHold
+		}
 
-		previousStatus := order.Status
-		order.Status = newStatus
-		order.UpdatedAt = time.Now().UTC()
-		prevVersion := order.Version
-
-		if err := o.orders.Save(*order); err != nil {
-			// Проверяем, является ли ошибка version conflict
-			if domain.IsVersionConflict(err) && attempt < maxRetries-1 {
-				o.logger.WithFields(log.Fields{
-					"order_id": order.ID,
-					"attempt":  attempt + 1,
-					"version":  order.Version,
-				}).Warn("version conflict detected, retrying")
-
-				// Перезагружаем свежую версию заказа
-				fresh, loadErr := o.orders.Get(order.ID)
-				if loadErr != nil {
-					o.logger.WithError(loadErr).WithField("order_id", order.ID).Error("failed to reload order after conflict")
-					return loadErr
-				}
-
-				// Обновляем order с новыми данными
-				*order = fresh
-
-				// Exponential backoff
-				delay := baseDelay * time.Duration(1<<uint(attempt))
-				time.Sleep(delay)
-				continue
+		version, err := o.orders.UpdateStatusCAS(order.ID, order.Status, newStatus, order.Version)
+		if err == nil {
+			o.recordTransition(order, newStatus, metrics.TransitionResultOK)
+			if order.Status == domain.OrderStatusOnHold {
+				order.HoldReason = ""
+				order.HeldFromStatus = ""
 			}
-
-			// Если это не version conflict или исчерпаны попытки
-			order.Status = previousStatus
+			order.Status = newStatus
+			order.UpdatedAt = timeutil.Now()
+			order.Version = version
+			o.emitEvents(ctx, order, append([]sagaEvent{statusEvent(order)}, events...)...)
+			return nil
+		}
+		if !domain.IsVersionConflict(err) || attempt == maxAttempts-1 {
 			o.logger.WithError(err).WithFields(log.Fields{
 				"order_id": order.ID,
 				"attempt":  attempt + 1,
 			}).Error("failed to persist status")
+			o.recordTransition(order, newStatus, metrics.TransitionResultFailed)
 			return err
 		}
 
-		// Успешно сохранили
-		order.Version = prevVersion + 1
-		o.emitStatusEvent(order)
-		return nil
+		// Заказ изменили параллельно: перечитываем его и повторяем CAS от свежего состояния.
+		o.logSampler.Log(o.logger.WithFields(log.Fields{
+			"order_id": order.ID,
+			"attempt":  attempt + 1,
+			"version":  order.Version,
+		}), log.WarnLevel, "version-conflict", "version conflict detected, retrying")
+		fresh, loadErr := o.orders.Get(order.ID)
+		if loadErr != nil {
+			o.logger.WithError(loadErr).WithField("order_id", order.ID).Error("failed to reload order after conflict")
+			return loadErr
+		}
+		*order = fresh
+		if order.Status == newStatus {
+			// Переход уже выполнил другой обработчик, OrderStatusChanged он тоже записал.
+			o.emitEvents(ctx, order, events...)
+			return nil
+		}
 	}
 
-	// Если дошли сюда - все попытки исчерпаны
 	return domain.ErrOrderVersionConflict
 }
 
-func (o *orchestrator) emitStatusEvent(order *domain.Order) {
-	payload := map[string]interface{}{
-		"status":     order.Status,
-		"updated_at": order.UpdatedAt.Format(time.RFC3339Nano),
-		"ts":         order.UpdatedAt.Format(time.RFC3339Nano),
+// recordTransition учитывает переход из текущего статуса order в to.
+func (o *orchestrator) recordTransition(order *domain.Order, to domain.OrderStatus, result string) {
+	if o.metrics != nil {
+		o.metrics.RecordStatusTransition(metrics.OrderMode(order.TestMode), string(order.Status), string(to), result)
 	}
-	o.emitEvent(order, "OrderStatusChanged", payload, order.UpdatedAt)
 }
 
-func (o *orchestrator) emitEvent(order *domain.Order, eventType string, payload map[string]interface{}, occurredAt time.Time) {
-	if payload == nil {
-		payload = make(map[string]interface{})
-	}
-	if occurredAt.IsZero() {
-		occurredAt = time.Now().UTC()
+// sagaEvent — событие заказа для outbox и timeline. Пустой occurredAt заполняется временем
+// записи, payload["ts"] по умолчанию равен occurredAt.
+type sagaEvent struct {
+	eventType  string
+	payload    map[string]interface{}
+	occurredAt time.Time
+}
+
+// eventPayloadExtraKeys — ключи, которые emitEvents дописывает в payload: ts, order_id, test_mode.
+// Payload'ы горячих событий создаются с запасом под них, чтобы map не перестраивался.
+const eventPayloadExtraKeys = 3
+
+func statusEvent(order *domain.Order) sagaEvent {
+	payload := make(map[string]interface{}, 2+eventPayloadExtraKeys)
+	payload["status"] = order.Status
+	payload["updated_at"] = timeutil.Format(order.UpdatedAt)
+	return sagaEvent{
+		eventType:  "OrderStatusChanged",
+		payload:    payload,
+		occurredAt: order.UpdatedAt,
 	}
+}
 
-	payload["order_id"] = order.ID
-	data, err := json.Marshal(payload)
-	if err != nil {
-		o.logger.WithError(err).WithFields(log.Fields{
-			"order_id": order.ID,
-			"event":    eventType,
-		}).Error("marshal event failed")
+func (o *orchestrator) emitEvent(ctx context.Context, order *domain.Order, eventType string, payload map[string]interface{}, occurredAt time.Time) {
+	o.emitEvents(ctx, order, sagaEvent{eventType: eventType, payload: payload, occurredAt: occurredAt})
+}
+
+// emitEvents пишет события в outbox (несколько — одним EnqueueBatch) и добавляет их в timeline.
+func (o *orchestrator) emitEvents(ctx context.Context, order *domain.Order, events ...sagaEvent) {
+	msgs := make([]domain.OutboxMessage, 0, len(events))
+	written := make([]sagaEvent, 0, len(events))
+	for _, event := range events {
+		if event.payload == nil {
+			event.payload = make(map[string]interface{}, eventPayloadExtraKeys)
+		}
+		if event.occurredAt.IsZero() {
+			event.occurredAt = timeutil.Now()
+		}
+		if _, ok := event.payload["ts"]; !ok {
+			event.payload["ts"] = timeutil.Format(event.occurredAt)
+		}
+		event.payload["order_id"] = order.ID
+		if order.TestMode {
+			// Потребители событий (аналитика, финансы) отфильтровывают sandbox-заказы по этому полю.
+			event.payload["test_mode"] = true
+		}
+		data, err := marshalPayload(event.payload)
+		if err != nil {
+			o.logger.WithError(err).WithFields(log.Fields{
+				"order_id": order.ID,
+				"event":    event.eventType,
+			}).Error("marshal event failed")
+			continue
+		}
+		msgs = append(msgs, domain.OutboxMessage{
+			AggregateType: "order",
+			AggregateID:   order.ID,
+			EventType:     event.eventType,
+			Payload:       data,
+			Headers:       domain.OutboxHeadersFromContext(ctx),
+		})
+		written = append(written, event)
+	}
+	if len(msgs) == 0 {
 		return
 	}
 
-	msg := domain.OutboxMessage{
-		AggregateType: "order",
-		AggregateID:   order.ID,
-		EventType:     eventType,
-		Payload:       data,
+	o.enqueue(ctx, order, msgs)
+	for _, event := range written {
+		o.appendTimeline(order, event)
 	}
-	if _, err := o.outbox.Enqueue(msg); err != nil {
+}
+
+// enqueue записывает сообщения в outbox и продвигает цепочку событий контекста: следующий шаг
+// саги будет вызван последним из них.
+func (o *orchestrator) enqueue(ctx context.Context, order *domain.Order, msgs []domain.OutboxMessage) {
+	var (
+		stored []domain.OutboxMessage
+		err    error
+	)
+	if len(msgs) == 1 {
+		var msg domain.OutboxMessage
+		msg, err = o.outbox.Enqueue(msgs[0])
+		stored = []domain.OutboxMessage{msg}
+	} else {
+		chainBatch(msgs)
+		stored, err = o.outbox.EnqueueBatch(msgs)
+	}
+	if err != nil {
+		eventTypes := make([]string, 0, len(msgs))
+		for _, msg := range msgs {
+			eventTypes = append(eventTypes, msg.EventType)
+		}
 		o.logger.WithError(err).WithFields(log.Fields{
 			"order_id": order.ID,
-			"event":    eventType,
+			"events":   eventTypes,
 		}).Error("enqueue event failed")
-	} else if o.metrics != nil {
-		o.metrics.RecordOutboxEvent()
+		return
 	}
 
-	if o.timeline != nil {
-		var reason string
-		if r, ok := payload["reason"].(string); ok {
-			reason = r
+	domain.EventChainFromContext(ctx).Advance(stored[len(stored)-1].ID)
+	if o.metrics != nil {
+		for range stored {
+			o.metrics.RecordOutboxEvent()
 		}
-		event := domain.TimelineEvent{
-			OrderID:  order.ID,
-			Type:     eventType,
-			Reason:   reason,
-			Occurred: occurredAt,
+	}
+}
+
+// chainBatch выдаёт сообщениям пакета ID заранее и делает каждое причиной следующего —
+// так же, как при записи по одному.
+func chainBatch(msgs []domain.OutboxMessage) {
+	for i := range msgs {
+		if msgs[i].ID == "" {
+			msgs[i].ID = uuid.NewString()
 		}
-		if err := o.timeline.Append(event); err != nil {
-			o.logger.WithError(err).WithFields(log.Fields{
-				"order_id": order.ID,
-				"event":    eventType,
-			}).Warn("append timeline event failed")
-		} else if o.metrics != nil {
-			o.metrics.RecordTimelineEvent()
+		if i > 0 && msgs[i].Headers[domain.EventHeaderCausationID] != "" {
+			msgs[i].Headers[domain.EventHeaderCausationID] = msgs[i-1].ID
 		}
 	}
 }
 
-// publishSagaEvent публикует событие саги в Kafka (если producer настроен)
-func (o *orchestrator) publishSagaEvent(eventType kafka.EventType, orderID string, metadata map[string]interface{}) {
+func (o *orchestrator) appendTimeline(order *domain.Order, event sagaEvent) {
+	if o.timeline == nil {
+		return
+	}
+	reason, _ := event.payload["reason"].(string)
+	if err := o.timeline.Append(domain.TimelineEvent{
+		OrderID:  order.ID,
+		Type:     event.eventType,
+		Reason:   reason,
+		Occurred: event.occurredAt,
+	}); err != nil {
+		o.logger.WithError(err).WithFields(log.Fields{
+			"order_id": order.ID,
+			"event":    event.eventType,
+		}).Warn("append timeline event failed")
+	} else if o.metrics != nil {
+		o.metrics.RecordTimelineEvent()
+	}
+}
+
+// publishSagaEvent публикует событие саги в Kafka (если producer настроен); тип события задаёт metadata.
+func (o *orchestrator) publishSagaEvent(ctx context.Context, order *domain.Order, metadata kafka.SagaMetadata) {
 	if o.kafkaProducer == nil {
 		return // Kafka не настроен, пропускаем
 	}
 
-	event := kafka.NewSagaEvent(eventType, orderID, metadata)
-	if err := o.kafkaProducer.PublishEvent(kafka.TopicSagaEvents, orderID, event); err != nil {
+	orderID := order.ID
+	eventType := metadata.SagaEventType()
+	event := kafka.NewTypedSagaEvent(orderID, metadata)
+	if order.TestMode {
+		event.Metadata["test_mode"] = true
+	}
+	event.CorrelationID, event.CausationID = domain.EventChainFromContext(ctx).IDs()
+	topic := o.eventsTopic
+	if topic == "" {
+		topic = kafka.TopicSagaEvents
+	}
+	key := o.eventsKey.Key(kafka.MessageKey{
+		OrderID:    orderID,
+		CustomerID: order.CustomerID,
+		TenantID:   domain.EventHeadersFromContext(ctx)[kafka.HeaderTenantID],
+	})
+	if err := o.kafkaProducer.PublishEvent(topic, key, event); err != nil {
 		// Логируем ошибку, но не прерываем saga - Kafka опциональный
-		o.logger.WithError(err).WithFields(log.Fields{
+		o.logSampler.Log(o.logger.WithError(err).WithFields(log.Fields{
 			"event_type": eventType,
 			"order_id":   orderID,
-		}).Warn("failed to publish saga event to kafka")
+		}), log.WarnLevel, "kafka-publish-failed", "failed to publish saga event to kafka")
 	}
 }
 
@@ -549,27 +931,27 @@ func NewNoop(logger *log.Entry) Orchestrator {
 	return &noopOrchestrator{logger: logger}
 }
 
-func (n *noopOrchestrator) Start(orderID string) {
+func (n *noopOrchestrator) Start(_ context.Context, orderID string) {
 	n.logger.WithFields(log.Fields{
 		"order_id": orderID,
-		"ts":       time.Now().UTC().Format(time.RFC3339Nano),
+		"ts":       timeutil.Format(timeutil.Now()),
 	}).Info("Saga orchestrator noop invoked")
 }
 
-func (n *noopOrchestrator) Cancel(orderID, reason string) {
+func (n *noopOrchestrator) Cancel(_ context.Context, orderID, reason string) {
 	n.logger.WithFields(log.Fields{
 		"order_id": orderID,
 		"reason":   reason,
-		"ts":       time.Now().UTC().Format(time.RFC3339Nano),
+		"ts":       timeutil.Format(timeutil.Now()),
 	}).Info("Saga orchestrator noop cancel")
 }
 
-func (n *noopOrchestrator) Refund(orderID string, amountMinor int64, reason string) {
+func (n *noopOrchestrator) Refund(_ context.Context, orderID string, amountMinor int64, reason string) {
 	n.logger.WithFields(log.Fields{
 		"order_id":     orderID,
 		"amount_minor": amountMinor,
 		"reason":       reason,
-		"ts":           time.Now().UTC().Format(time.RFC3339Nano),
+		"ts":           timeutil.Format(timeutil.Now()),
 	}).Info("Saga orchestrator noop refund")
 }
 
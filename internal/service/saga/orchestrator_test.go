@@ -1,13 +1,22 @@
 package saga
 
 import (
+	"bytes"
+	"context"
+	"reflect"
+	"strings"
 	"sync"
 	"testing"
 	"time"
 
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
 	log "github.com/sirupsen/logrus"
 
 	"github.com/vladislavdragonenkov/oms/internal/domain"
+	"github.com/vladislavdragonenkov/oms/internal/logging"
+	"github.com/vladislavdragonenkov/oms/internal/metrics"
+	"github.com/vladislavdragonenkov/oms/internal/metrics/metricstest"
 	"github.com/vladislavdragonenkov/oms/internal/storage/memory"
 )
 
@@ -82,10 +91,15 @@ func (s *stubPayment) Refund(_ string, _ int64, _ string) (domain.PaymentStatus,
 
 func seedOrder(t *testing.T, repo domain.OrderRepository, status domain.OrderStatus) domain.Order {
 	t.Helper()
+	return seedOrderAt(t, repo, "order-1", status, time.Now().UTC())
+}
+
+// seedOrderAt создаёт заказ id, последний раз обновлённый в updated.
+func seedOrderAt(t *testing.T, repo domain.OrderRepository, id string, status domain.OrderStatus, updated time.Time) domain.Order {
+	t.Helper()
 
-	now := time.Now().UTC()
 	order := domain.Order{
-		ID:          "order-1",
+		ID:          id,
 		CustomerID:  "customer-1",
 		Status:      status,
 		Currency:    "USD",
@@ -95,11 +109,11 @@ func seedOrder(t *testing.T, repo domain.OrderRepository, status domain.OrderSta
 			SKU:        "sku-1",
 			Qty:        1,
 			PriceMinor: 100,
-			CreatedAt:  now,
+			CreatedAt:  updated,
 		}},
 		Version:   0,
-		CreatedAt: now,
-		UpdatedAt: now,
+		CreatedAt: updated,
+		UpdatedAt: updated,
 	}
 
 	if err := repo.Create(order); err != nil {
@@ -109,6 +123,17 @@ func seedOrder(t *testing.T, repo domain.OrderRepository, status domain.OrderSta
 	return order
 }
 
+func orderStatus(t *testing.T, repo domain.OrderRepository, id string) domain.OrderStatus {
+	t.Helper()
+
+	order, err := repo.Get(id)
+	if err != nil {
+		t.Fatalf("get order: %v", err)
+	}
+
+	return order.Status
+}
+
 func collectOutbox(t *testing.T, outbox domain.OutboxRepository) []domain.OutboxMessage {
 	t.Helper()
 
@@ -134,7 +159,7 @@ func TestOrchestrator_SuccessFlow(t *testing.T) {
 	seedOrder(t, repo, domain.OrderStatusPending)
 
 	orch := NewOrchestratorWithoutMetrics(repo, outbox, timeline, inventory, payments, log.New().WithField("test", "success"))
-	orch.Start("order-1")
+	orch.Start(context.Background(), "order-1")
 
 	updated, err := repo.Get("order-1")
 	if err != nil {
@@ -179,7 +204,7 @@ func TestOrchestrator_ReserveFailure(t *testing.T) {
 	seedOrder(t, repo, domain.OrderStatusPending)
 
 	orch := NewOrchestratorWithoutMetrics(repo, outbox, timeline, inventory, payments, log.New().WithField("test", "reserve_failure"))
-	orch.Start("order-1")
+	orch.Start(context.Background(), "order-1")
 
 	updated, err := repo.Get("order-1")
 	if err != nil {
@@ -226,7 +251,7 @@ func TestOrchestrator_PaymentFailure(t *testing.T) {
 	seedOrder(t, repo, domain.OrderStatusPending)
 
 	orch := NewOrchestratorWithoutMetrics(repo, outbox, timeline, inventory, payments, log.New().WithField("test", "payment_failure"))
-	orch.Start("order-1")
+	orch.Start(context.Background(), "order-1")
 
 	updated, err := repo.Get("order-1")
 	if err != nil {
@@ -273,7 +298,7 @@ func TestOrchestrator_CancelDuringStart_DoesNotReconfirmOrder(t *testing.T) {
 	done := make(chan struct{})
 	go func() {
 		defer close(done)
-		orch.Start("order-1")
+		orch.Start(context.Background(), "order-1")
 	}()
 
 	select {
@@ -282,7 +307,7 @@ func TestOrchestrator_CancelDuringStart_DoesNotReconfirmOrder(t *testing.T) {
 		t.Fatal("reserve step did not start in time")
 	}
 
-	orch.Cancel("order-1", "race")
+	orch.Cancel(context.Background(), "order-1", "race")
 	close(inventory.release)
 
 	select {
@@ -299,3 +324,508 @@ func TestOrchestrator_CancelDuringStart_DoesNotReconfirmOrder(t *testing.T) {
 		t.Fatalf("expected status %s, got %s", domain.OrderStatusCanceled, updated.Status)
 	}
 }
+
+// holdingInventory ставит заказ на hold во время резерва, имитируя сигнал антифрода.
+type holdingInventory struct {
+	stubInventory
+	orders domain.OrderRepository
+}
+
+func (h *holdingInventory) Reserve(orderID string, items []domain.OrderItem) error {
+	order, err := h.orders.Get(orderID)
+	if err != nil {
+		return err
+	}
+	if err := order.Hold("fraud review"); err != nil {
+		return err
+	}
+	if err := h.orders.Save(order); err != nil {
+		return err
+	}
+	return h.stubInventory.Reserve(orderID, items)
+}
+
+func TestOrchestrator_RecordsStatusTransitions(t *testing.T) {
+	repo := memory.NewOrderRepository()
+	registry := prometheus.NewRegistry()
+	seedOrder(t, repo, domain.OrderStatusPending)
+
+	orch := NewOrchestratorWithoutMetrics(repo, memory.NewOutboxRepository(), memory.NewTimelineRepository(), &stubInventory{}, &stubPayment{payStatus: domain.PaymentStatusCaptured}, log.New().WithField("test", "transitions"),
+		WithMetrics(metrics.NewSagaMetricsWithRegistry(registry)))
+	orch.Start(context.Background(), "order-1")
+	orch.Cancel(context.Background(), "order-1", "customer request")
+
+	expected := `
+# HELP oms_order_status_transitions_total Total number of order status transitions by source status, target status, result and order mode (live or test)
+# TYPE oms_order_status_transitions_total counter
+oms_order_status_transitions_total{from="confirmed",mode="live",result="ok",to="canceled"} 1
+oms_order_status_transitions_total{from="paid",mode="live",result="ok",to="confirmed"} 1
+oms_order_status_transitions_total{from="pending",mode="live",result="ok",to="reserved"} 1
+oms_order_status_transitions_total{from="reserved",mode="live",result="ok",to="paid"} 1
+`
+	if err := testutil.GatherAndCompare(registry, strings.NewReader(expected), "oms_order_status_transitions_total"); err != nil {
+		t.Fatal(err)
+	}
+
+	metricstest.RequireValue(t, registry, "oms_saga_started_total", nil, 1)
+	metricstest.RequireValue(t, registry, "oms_saga_completed_total", nil, 1)
+	metricstest.RequireValue(t, registry, "oms_saga_canceled_total", nil, 1)
+	metricstest.RequireValue(t, registry, "oms_saga_failed_total", nil, 0)
+	metricstest.RequireValue(t, registry, "oms_active_sagas", nil, 0)
+	metricstest.RequireValue(t, registry, "oms_saga_duration_seconds", nil, 1)
+	for _, step := range []domain.SagaStep{domain.SagaStepReserve, domain.SagaStepPay, domain.SagaStepConfirm} {
+		metricstest.RequireValue(t, registry, "oms_saga_step_duration_seconds", metricstest.Labels{"step": string(step)}, 1)
+	}
+	metricstest.RequireAtLeast(t, registry, "oms_timeline_events_total", nil, 4)
+	metricstest.RequireAtLeast(t, registry, "oms_outbox_events_total", nil, 4)
+}
+
+func seedTestModeOrder(t *testing.T, repo domain.OrderRepository) {
+	t.Helper()
+
+	now := time.Now().UTC()
+	order := domain.Order{
+		ID:          "order-sandbox",
+		CustomerID:  "partner-1",
+		Status:      domain.OrderStatusPending,
+		Currency:    "USD",
+		AmountMinor: 100,
+		Items:       []domain.OrderItem{{ID: "item-1", SKU: "sku-1", Qty: 1, PriceMinor: 100, CreatedAt: now}},
+		CreatedAt:   now,
+		UpdatedAt:   now,
+		TestMode:    true,
+	}
+	if err := repo.Create(order); err != nil {
+		t.Fatalf("create order: %v", err)
+	}
+}
+
+func TestOrchestrator_TestModeOrderUsesSandboxServices(t *testing.T) {
+	repo := memory.NewOrderRepository()
+	registry := prometheus.NewRegistry()
+	seedTestModeOrder(t, repo)
+
+	liveInventory, livePayments := &stubInventory{}, &stubPayment{payStatus: domain.PaymentStatusCaptured}
+	sandboxInventory := &stubInventory{}
+	sandboxPayments := &stubPayment{payStatus: domain.PaymentStatusCaptured, refundStatus: domain.PaymentStatusRefunded}
+	outbox := memory.NewOutboxRepository()
+
+	orch := NewOrchestratorWithoutMetrics(repo, outbox, memory.NewTimelineRepository(), liveInventory, livePayments, log.New().WithField("test", "sandbox"),
+		WithTestModeServices(sandboxInventory, sandboxPayments),
+		WithMetrics(metrics.NewSagaMetricsWithRegistry(registry)))
+	orch.Start(context.Background(), "order-sandbox")
+	orch.Refund(context.Background(), "order-sandbox", 0, "partner test")
+
+	order, err := repo.Get("order-sandbox")
+	if err != nil {
+		t.Fatalf("get order: %v", err)
+	}
+	if order.Status != domain.OrderStatusRefunded {
+		t.Fatalf("expected sandbox order to be refunded, got %s", order.Status)
+	}
+	if liveInventory.reserveCnt != 0 || liveInventory.releaseCnt != 0 || livePayments.payCnt != 0 || livePayments.refundCnt != 0 {
+		t.Fatalf("live services must not be called for sandbox orders: inventory=%+v payments=%+v", liveInventory, livePayments)
+	}
+	if sandboxInventory.reserveCnt != 1 || sandboxPayments.payCnt != 1 || sandboxPayments.refundCnt != 1 {
+		t.Fatalf("expected sandbox services to handle the order: inventory=%+v payments=%+v", sandboxInventory, sandboxPayments)
+	}
+
+	expected := `
+# HELP oms_order_status_transitions_total Total number of order status transitions by source status, target status, result and order mode (live or test)
+# TYPE oms_order_status_transitions_total counter
+oms_order_status_transitions_total{from="confirmed",mode="test",result="ok",to="refunded"} 1
+oms_order_status_transitions_total{from="paid",mode="test",result="ok",to="confirmed"} 1
+oms_order_status_transitions_total{from="pending",mode="test",result="ok",to="reserved"} 1
+oms_order_status_transitions_total{from="reserved",mode="test",result="ok",to="paid"} 1
+`
+	if err := testutil.GatherAndCompare(registry, strings.NewReader(expected), "oms_order_status_transitions_total"); err != nil {
+		t.Fatal(err)
+	}
+
+	messages, err := outbox.PullPending(10)
+	if err != nil {
+		t.Fatalf("pull outbox: %v", err)
+	}
+	for _, msg := range messages {
+		if !strings.Contains(string(msg.Payload), `"test_mode":true`) {
+			t.Fatalf("expected sandbox event to be tagged, got %s", msg.Payload)
+		}
+	}
+}
+
+func TestOrchestrator_TestModeOrderWithoutSandboxServicesIsCanceled(t *testing.T) {
+	repo := memory.NewOrderRepository()
+	seedTestModeOrder(t, repo)
+	inventory, payments := &stubInventory{}, &stubPayment{payStatus: domain.PaymentStatusCaptured}
+
+	orch := NewOrchestratorWithoutMetrics(repo, memory.NewOutboxRepository(), memory.NewTimelineRepository(), inventory, payments, log.New().WithField("test", "sandbox"))
+	orch.Start(context.Background(), "order-sandbox")
+
+	order, err := repo.Get("order-sandbox")
+	if err != nil {
+		t.Fatalf("get order: %v", err)
+	}
+	if order.Status != domain.OrderStatusCanceled {
+		t.Fatalf("expected sandbox order to be canceled, got %s", order.Status)
+	}
+	if inventory.reserveCnt != 0 || payments.payCnt != 0 {
+		t.Fatal("live services must not be called for sandbox orders")
+	}
+}
+
+// customerScopedInventory запоминает покупателя, для которого сага запросила склад.
+type customerScopedInventory struct {
+	stubInventory
+	customers []string
+}
+
+func (c *customerScopedInventory) ForCustomer(customerID string) domain.InventoryService {
+	c.customers = append(c.customers, customerID)
+	return &c.stubInventory
+}
+
+func TestOrchestrator_CustomerScopedInventoryReceivesCustomer(t *testing.T) {
+	repo := memory.NewOrderRepository()
+	seedOrder(t, repo, domain.OrderStatusPending)
+	inventory := &customerScopedInventory{}
+	payments := &stubPayment{payStatus: domain.PaymentStatusCaptured}
+
+	orch := NewOrchestratorWithoutMetrics(repo, memory.NewOutboxRepository(), memory.NewTimelineRepository(), inventory, payments, log.New().WithField("test", "scoped"))
+	orch.Start(context.Background(), "order-1")
+
+	if inventory.reserveCnt != 1 {
+		t.Fatalf("expected reserve through scoped inventory, got %d", inventory.reserveCnt)
+	}
+	if len(inventory.customers) == 0 || inventory.customers[0] != "customer-1" {
+		t.Fatalf("expected inventory scoped to order customer, got %v", inventory.customers)
+	}
+}
+
+func TestOrchestrator_StartSkipsOrderOnHold(t *testing.T) {
+	repo := memory.NewOrderRepository()
+	inventory := &stubInventory{}
+	payments := &stubPayment{payStatus: domain.PaymentStatusCaptured}
+
+	order := seedOrder(t, repo, domain.OrderStatusReserved)
+	if err := order.Hold("fraud review"); err != nil {
+		t.Fatalf("hold: %v", err)
+	}
+	if err := repo.Save(order); err != nil {
+		t.Fatalf("save: %v", err)
+	}
+
+	orch := NewOrchestratorWithoutMetrics(repo, memory.NewOutboxRepository(), memory.NewTimelineRepository(), inventory, payments, log.New().WithField("test", "hold"))
+	orch.Start(context.Background(), "order-1")
+
+	updated, _ := repo.Get("order-1")
+	if updated.Status != domain.OrderStatusOnHold || payments.payCnt != 0 {
+		t.Fatalf("expected saga to stay paused, status=%s payCnt=%d", updated.Status, payments.payCnt)
+	}
+}
+
+func TestOrchestrator_HoldDuringReserve_BlocksPayment(t *testing.T) {
+	repo := memory.NewOrderRepository()
+	inventory := &holdingInventory{orders: repo}
+	payments := &stubPayment{payStatus: domain.PaymentStatusCaptured}
+
+	seedOrder(t, repo, domain.OrderStatusPending)
+
+	orch := NewOrchestratorWithoutMetrics(repo, memory.NewOutboxRepository(), memory.NewTimelineRepository(), inventory, payments, log.New().WithField("test", "hold-race"))
+	orch.Start(context.Background(), "order-1")
+
+	updated, _ := repo.Get("order-1")
+	if updated.Status != domain.OrderStatusOnHold {
+		t.Fatalf("expected hold to win over saga progression, got %s", updated.Status)
+	}
+	if payments.payCnt != 0 {
+		t.Fatalf("payment must not run for held order, got %d calls", payments.payCnt)
+	}
+}
+
+func TestOrchestrator_CancelHeldOrder_UsesStatusBeforeHold(t *testing.T) {
+	repo := memory.NewOrderRepository()
+	inventory := &stubInventory{}
+	payments := &stubPayment{refundStatus: domain.PaymentStatusRefunded}
+
+	order := seedOrder(t, repo, domain.OrderStatusPaid)
+	if err := order.Hold("fraud review"); err != nil {
+		t.Fatalf("hold: %v", err)
+	}
+	if err := repo.Save(order); err != nil {
+		t.Fatalf("save: %v", err)
+	}
+
+	orch := NewOrchestratorWithoutMetrics(repo, memory.NewOutboxRepository(), memory.NewTimelineRepository(), inventory, payments, log.New().WithField("test", "hold-cancel"))
+	orch.Cancel(context.Background(), "order-1", "fraud confirmed")
+
+	updated, _ := repo.Get("order-1")
+	if updated.Status != domain.OrderStatusCanceled || updated.HoldReason != "" {
+		t.Fatalf("unexpected order after cancel: %+v", updated)
+	}
+	if inventory.releaseCnt != 1 || payments.refundCnt != 1 {
+		t.Fatalf("expected release and refund, got release=%d refund=%d", inventory.releaseCnt, payments.refundCnt)
+	}
+}
+
+// cancelOnReserveInventory отменяет контекст саги во время резерва, имитируя истёкший дедлайн.
+type cancelOnReserveInventory struct {
+	stubInventory
+	cancel context.CancelFunc
+}
+
+func (c *cancelOnReserveInventory) Reserve(orderID string, items []domain.OrderItem) error {
+	c.cancel()
+	return c.stubInventory.Reserve(orderID, items)
+}
+
+func TestOrchestrator_Start_ExpiredContextLeavesOrderPending(t *testing.T) {
+	repo := memory.NewOrderRepository()
+	inventory := &stubInventory{}
+	payments := &stubPayment{payStatus: domain.PaymentStatusCaptured}
+	seedOrder(t, repo, domain.OrderStatusPending)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	orch := NewOrchestratorWithoutMetrics(repo, memory.NewOutboxRepository(), memory.NewTimelineRepository(), inventory, payments, log.New().WithField("test", "deadline-pending"))
+	orch.Start(ctx, "order-1")
+
+	updated, _ := repo.Get("order-1")
+	if updated.Status != domain.OrderStatusPending {
+		t.Fatalf("expected order to stay pending, got %s", updated.Status)
+	}
+	if inventory.reserveCnt != 0 || payments.payCnt != 0 {
+		t.Fatalf("expected no side effects, got reserve=%d pay=%d", inventory.reserveCnt, payments.payCnt)
+	}
+}
+
+func TestOrchestrator_Start_DeadlineBeforePaymentCancelsOrder(t *testing.T) {
+	repo := memory.NewOrderRepository()
+	outbox := memory.NewOutboxRepository()
+	payments := &stubPayment{payStatus: domain.PaymentStatusCaptured}
+	seedOrder(t, repo, domain.OrderStatusPending)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	inventory := &cancelOnReserveInventory{cancel: cancel}
+
+	orch := NewOrchestratorWithoutMetrics(repo, outbox, memory.NewTimelineRepository(), inventory, payments, log.New().WithField("test", "deadline-payment"))
+	orch.Start(ctx, "order-1")
+
+	updated, _ := repo.Get("order-1")
+	if updated.Status != domain.OrderStatusCanceled {
+		t.Fatalf("expected order to be canceled, got %s", updated.Status)
+	}
+	if payments.payCnt != 0 {
+		t.Fatalf("payment must not run after deadline, got %d calls", payments.payCnt)
+	}
+	if inventory.releaseCnt != 1 {
+		t.Fatalf("expected reserved inventory to be released, got %d", inventory.releaseCnt)
+	}
+
+	var failed bool
+	for _, msg := range collectOutbox(t, outbox) {
+		failed = failed || msg.EventType == "OrderSagaFailed"
+	}
+	if !failed {
+		t.Fatal("expected OrderSagaFailed event")
+	}
+}
+
+func TestOrchestrator_CancelAndRefund_SkipOnExpiredContext(t *testing.T) {
+	repo := memory.NewOrderRepository()
+	inventory := &stubInventory{}
+	payments := &stubPayment{refundStatus: domain.PaymentStatusRefunded}
+	seedOrder(t, repo, domain.OrderStatusPaid)
+
+	ctx, cancel := context.WithTimeout(context.Background(), -time.Second)
+	defer cancel()
+
+	orch := NewOrchestratorWithoutMetrics(repo, memory.NewOutboxRepository(), memory.NewTimelineRepository(), inventory, payments, log.New().WithField("test", "deadline-compensation"))
+	orch.Cancel(ctx, "order-1", "late")
+	orch.Refund(ctx, "order-1", 0, "late")
+
+	updated, _ := repo.Get("order-1")
+	if updated.Status != domain.OrderStatusPaid {
+		t.Fatalf("expected order to stay paid, got %s", updated.Status)
+	}
+	if inventory.releaseCnt != 0 || payments.refundCnt != 0 {
+		t.Fatalf("expected no compensations, got release=%d refund=%d", inventory.releaseCnt, payments.refundCnt)
+	}
+}
+
+// racingOrderRepository перед первым CAS сохраняет заказ «параллельным» писателем,
+// чтобы версия в руках саги устарела.
+type racingOrderRepository struct {
+	domain.OrderRepository
+	raced    bool
+	casCalls int
+}
+
+func (r *racingOrderRepository) UpdateStatusCAS(orderID string, from, to domain.OrderStatus, expectedVersion int64) (int64, error) {
+	r.casCalls++
+	if !r.raced {
+		r.raced = true
+		current, err := r.OrderRepository.Get(orderID)
+		if err != nil {
+			return 0, err
+		}
+		if err := r.OrderRepository.Save(current); err != nil {
+			return 0, err
+		}
+	}
+	return r.OrderRepository.UpdateStatusCAS(orderID, from, to, expectedVersion)
+}
+
+func TestOrchestrator_UpdateStatus_RetriesCASAfterConcurrentWrite(t *testing.T) {
+	repo := &racingOrderRepository{OrderRepository: memory.NewOrderRepository()}
+	inventory := &stubInventory{}
+	payments := &stubPayment{payStatus: domain.PaymentStatusCaptured}
+
+	seedOrder(t, repo, domain.OrderStatusPending)
+
+	orch := NewOrchestratorWithoutMetrics(repo, memory.NewOutboxRepository(), memory.NewTimelineRepository(), inventory, payments, log.New().WithField("test", "cas"))
+	orch.Start(context.Background(), "order-1")
+
+	updated, err := repo.Get("order-1")
+	if err != nil {
+		t.Fatalf("get order: %v", err)
+	}
+	if updated.Status != domain.OrderStatusConfirmed {
+		t.Fatalf("expected status confirmed, got %s", updated.Status)
+	}
+	// reserved (конфликт + повтор), paid, confirmed.
+	if repo.casCalls != 4 {
+		t.Fatalf("expected 4 CAS calls, got %d", repo.casCalls)
+	}
+	// Сохранение «параллельного» писателя и три перехода статуса.
+	if updated.Version != 4 {
+		t.Fatalf("expected version 4, got %d", updated.Version)
+	}
+}
+
+func TestOrchestrator_VersionConflictWarningsAreSampled(t *testing.T) {
+	var out bytes.Buffer
+	logger := log.New()
+	logger.SetOutput(&out)
+
+	registry := prometheus.NewRegistry()
+	sampler := logging.NewSampler("saga", logging.WithSampleRate(1, 1), logging.WithSamplerRegisterer(registry))
+
+	// Каждый прогон ловит один конфликт версии; в лог попадает только первый.
+	for i := 0; i < 3; i++ {
+		repo := &racingOrderRepository{OrderRepository: memory.NewOrderRepository()}
+		seedOrder(t, repo, domain.OrderStatusPending)
+		orch := NewOrchestratorWithoutMetrics(repo, memory.NewOutboxRepository(), memory.NewTimelineRepository(),
+			&stubInventory{}, &stubPayment{payStatus: domain.PaymentStatusCaptured}, log.NewEntry(logger), WithLogSampler(sampler))
+		orch.Start(context.Background(), "order-1")
+	}
+
+	if got := strings.Count(out.String(), "version conflict detected"); got != 1 {
+		t.Fatalf("expected 1 logged conflict, got %d:\n%s", got, out.String())
+	}
+	metricstest.RequireValue(t, registry, "oms_log_suppressed_total", metricstest.Labels{"component": "saga", "key": "version-conflict"}, 2)
+}
+
+func TestOrchestrator_OutboxEventsCarryContextHeaders(t *testing.T) {
+	repo := memory.NewOrderRepository()
+	outbox := memory.NewOutboxRepository()
+	seedOrder(t, repo, domain.OrderStatusPending)
+
+	orch := NewOrchestratorWithoutMetrics(repo, outbox, memory.NewTimelineRepository(), &stubInventory{}, &stubPayment{payStatus: domain.PaymentStatusCaptured}, log.New().WithField("test", "headers"))
+	ctx := domain.ContextWithEventHeaders(context.Background(), map[string]string{"traceparent": "00-trace-span-01", "x-tenant-id": "tenant-a"})
+	sagaCtx, cancel := DetachedContext(ctx, time.Minute)
+	defer cancel()
+	orch.Start(sagaCtx, "order-1")
+
+	events := collectOutbox(t, outbox)
+	if len(events) == 0 {
+		t.Fatal("expected outbox events")
+	}
+	for _, event := range events {
+		if event.Headers["traceparent"] != "00-trace-span-01" || event.Headers["x-tenant-id"] != "tenant-a" {
+			t.Fatalf("event %s lost context headers: %v", event.EventType, event.Headers)
+		}
+	}
+}
+
+func TestOrchestrator_OutboxEventsFormCausationChain(t *testing.T) {
+	repo := memory.NewOrderRepository()
+	outbox := memory.NewOutboxRepository()
+	seedOrder(t, repo, domain.OrderStatusPending)
+
+	orch := NewOrchestratorWithoutMetrics(repo, outbox, memory.NewTimelineRepository(), &stubInventory{}, &stubPayment{payStatus: domain.PaymentStatusCaptured}, log.New().WithField("test", "chain"))
+	sagaCtx, cancel := DetachedContext(domain.ContextWithEventChain(context.Background(), "req-1", ""), time.Minute)
+	defer cancel()
+	orch.Start(sagaCtx, "order-1")
+
+	events := collectOutbox(t, outbox)
+	if len(events) < 2 {
+		t.Fatalf("expected several outbox events, got %d", len(events))
+	}
+	// Цепочка линейна: первое событие вызвано запросом, каждое следующее — предыдущим.
+	byCause := make(map[string]domain.OutboxMessage, len(events))
+	for _, event := range events {
+		if event.Headers[domain.EventHeaderCorrelationID] != "req-1" {
+			t.Fatalf("event %s lost correlation id: %v", event.EventType, event.Headers)
+		}
+		cause := event.Headers[domain.EventHeaderCausationID]
+		if other, ok := byCause[cause]; ok {
+			t.Fatalf("events %s and %s share causation %q", other.EventType, event.EventType, cause)
+		}
+		byCause[cause] = event
+	}
+	cause, walked := "req-1", 0
+	for {
+		event, ok := byCause[cause]
+		if !ok {
+			break
+		}
+		cause, walked = event.ID, walked+1
+	}
+	if walked != len(events) {
+		t.Fatalf("expected chain through all %d events, walked %d", len(events), walked)
+	}
+}
+
+// batchCountingOutbox считает вызовы записи в outbox.
+type batchCountingOutbox struct {
+	domain.OutboxRepository
+	single  int
+	batches [][]string
+}
+
+func (o *batchCountingOutbox) Enqueue(msg domain.OutboxMessage) (domain.OutboxMessage, error) {
+	o.single++
+	return o.OutboxRepository.Enqueue(msg)
+}
+
+func (o *batchCountingOutbox) EnqueueBatch(msgs []domain.OutboxMessage) ([]domain.OutboxMessage, error) {
+	types := make([]string, 0, len(msgs))
+	for _, msg := range msgs {
+		types = append(types, msg.EventType)
+	}
+	o.batches = append(o.batches, types)
+	return o.OutboxRepository.EnqueueBatch(msgs)
+}
+
+func TestOrchestrator_CancelEnqueuesTransitionEventsInOneBatch(t *testing.T) {
+	repo := memory.NewOrderRepository()
+	store := memory.NewOutboxRepository()
+	outbox := &batchCountingOutbox{OutboxRepository: store}
+	seedOrder(t, repo, domain.OrderStatusReserved)
+
+	orch := NewOrchestratorWithoutMetrics(repo, outbox, memory.NewTimelineRepository(), &stubInventory{}, &stubPayment{}, log.New().WithField("test", "batch"))
+	orch.Cancel(domain.ContextWithEventChain(context.Background(), "req-1", ""), "order-1", "customer request")
+
+	if outbox.single != 0 || len(outbox.batches) != 1 || !reflect.DeepEqual(outbox.batches[0], []string{"OrderStatusChanged", "OrderCanceled"}) {
+		t.Fatalf("expected one batch with status and cancel events, got single=%d batches=%v", outbox.single, outbox.batches)
+	}
+	events := collectOutbox(t, store)
+	if len(events) != 2 || events[0].EventType != "OrderStatusChanged" {
+		t.Fatalf("unexpected outbox order %+v", events)
+	}
+	if events[0].Headers[domain.EventHeaderCausationID] != "req-1" || events[1].Headers[domain.EventHeaderCausationID] != events[0].ID {
+		t.Fatalf("batch must keep the causation chain: %v, %v", events[0].Headers, events[1].Headers)
+	}
+}
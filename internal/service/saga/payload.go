@@ -0,0 +1,161 @@
+package saga
+
+import (
+	"bytes"
+	"encoding/json"
+	"slices"
+	"strconv"
+	"sync"
+	"unicode/utf8"
+
+	"github.com/vladislavdragonenkov/oms/internal/domain"
+)
+
+// maxPooledPayloadBuffer — буферы крупнее не возвращаются в пул: один большой payload
+// не должен навсегда удерживать память в каждом P.
+const maxPooledPayloadBuffer = 64 << 10
+
+// payloadEncoder — буфер, json.Encoder над ним и срез для сортировки ключей;
+// переиспользуется через payloadEncoders.
+type payloadEncoder struct {
+	buf  bytes.Buffer
+	enc  *json.Encoder
+	keys []string
+}
+
+var payloadEncoders = sync.Pool{
+	New: func() any {
+		e := &payloadEncoder{keys: make([]string, 0, 8)}
+		e.buf.Grow(256)
+		e.enc = json.NewEncoder(&e.buf)
+		return e
+	},
+}
+
+// marshalPayload сериализует payload события байт в байт как json.Marshal. Плоские payload'ы
+// саги (строки, целые, bool) пишутся напрямую в буфер из пула, без reflect; остальные — через
+// json.Encoder над тем же буфером. Результат копируется: OutboxMessage владеет Payload дольше,
+// чем живёт буфер.
+func marshalPayload(payload map[string]interface{}) ([]byte, error) {
+	e := payloadEncoders.Get().(*payloadEncoder)
+	defer func() {
+		if e.buf.Cap() <= maxPooledPayloadBuffer {
+			e.buf.Reset()
+			clear(e.keys)
+			e.keys = e.keys[:0]
+			payloadEncoders.Put(e)
+		}
+	}()
+
+	if e.appendFlat(payload) {
+		return bytes.Clone(e.buf.Bytes()), nil
+	}
+	e.buf.Reset()
+	if err := e.enc.Encode(payload); err != nil {
+		return nil, err
+	}
+	// Encode дописывает перевод строки, json.Marshal — нет.
+	return bytes.Clone(bytes.TrimSuffix(e.buf.Bytes(), []byte{'\n'})), nil
+}
+
+// appendFlat пишет payload в e.buf, если все значения — простые типы. false — в payload есть
+// значение, которое умеет сериализовать только encoding/json; буфер тогда нужно сбросить.
+func (e *payloadEncoder) appendFlat(payload map[string]interface{}) bool {
+	if payload == nil {
+		e.buf.WriteString("null")
+		return true
+	}
+	for key := range payload {
+		e.keys = append(e.keys, key)
+	}
+	slices.Sort(e.keys) // encoding/json пишет ключи map в отсортированном порядке
+
+	var scratch [32]byte
+	e.buf.WriteByte('{')
+	for i, key := range e.keys {
+		if i > 0 {
+			e.buf.WriteByte(',')
+		}
+		appendJSONString(&e.buf, key)
+		e.buf.WriteByte(':')
+		switch v := payload[key].(type) {
+		case string:
+			appendJSONString(&e.buf, v)
+		case domain.OrderStatus:
+			appendJSONString(&e.buf, string(v))
+		case bool:
+			e.buf.Write(strconv.AppendBool(scratch[:0], v))
+		case int:
+			e.buf.Write(strconv.AppendInt(scratch[:0], int64(v), 10))
+		case int32:
+			e.buf.Write(strconv.AppendInt(scratch[:0], int64(v), 10))
+		case int64:
+			e.buf.Write(strconv.AppendInt(scratch[:0], v, 10))
+		case nil:
+			e.buf.WriteString("null")
+		default:
+			return false
+		}
+	}
+	e.buf.WriteByte('}')
+	return true
+}
+
+const hexDigits = "0123456789abcdef"
+
+// appendJSONString пишет s как JSON-строку с теми же экранированиями, что у json.Marshal:
+// HTML-символы, U+2028/U+2029 и невалидный UTF-8 (заменяется на U+FFFD).
+func appendJSONString(buf *bytes.Buffer, s string) {
+	buf.WriteByte('"')
+	start := 0
+	for i := 0; i < len(s); {
+		if c := s[i]; c < utf8.RuneSelf {
+			if c >= 0x20 && c != '"' && c != '\\' && c != '<' && c != '>' && c != '&' {
+				i++
+				continue
+			}
+			buf.WriteString(s[start:i])
+			switch c {
+			case '"', '\\':
+				buf.WriteByte('\\')
+				buf.WriteByte(c)
+			case '\b':
+				buf.WriteString(`\b`)
+			case '\f':
+				buf.WriteString(`\f`)
+			case '\n':
+				buf.WriteString(`\n`)
+			case '\r':
+				buf.WriteString(`\r`)
+			case '\t':
+				buf.WriteString(`\t`)
+			default:
+				buf.WriteString(`\u00`)
+				buf.WriteByte(hexDigits[c>>4])
+				buf.WriteByte(hexDigits[c&0xF])
+			}
+			i++
+			start = i
+			continue
+		}
+		r, size := utf8.DecodeRuneInString(s[i:])
+		if r == utf8.RuneError && size == 1 {
+			buf.WriteString(s[start:i])
+			buf.WriteString("\ufffd")
+			i += size
+			start = i
+			continue
+		}
+		if r == '\u2028' || r == '\u2029' {
+			buf.WriteString(s[start:i])
+			buf.WriteString(`\u202`)
+			buf.WriteByte(hexDigits[r&0xF])
+			i += size
+			start = i
+			continue
+		}
+		i += size
+	}
+	buf.WriteString(s[start:])
+	buf.WriteByte('"')
+}
@@ -0,0 +1,127 @@
+package saga
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"testing"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+
+	"github.com/vladislavdragonenkov/oms/internal/domain"
+)
+
+func TestMarshalPayload_MatchesJSONMarshal(t *testing.T) {
+	payload := map[string]interface{}{
+		"order_id":     "order-1",
+		"status":       domain.OrderStatusPaid,
+		"amount_minor": int64(1250),
+		"reason":       "<script>&",
+		"test_mode":    true,
+	}
+	want, err := json.Marshal(payload)
+	if err != nil {
+		t.Fatalf("json.Marshal: %v", err)
+	}
+	first, err := marshalPayload(payload)
+	if err != nil {
+		t.Fatalf("marshalPayload: %v", err)
+	}
+	if !bytes.Equal(first, want) {
+		t.Fatalf("got %s, want %s", first, want)
+	}
+
+	// Результат не делит память с буфером пула: следующий вызов его не портит.
+	if _, err := marshalPayload(map[string]interface{}{"order_id": "order-2"}); err != nil {
+		t.Fatalf("marshalPayload: %v", err)
+	}
+	if !bytes.Equal(first, want) {
+		t.Fatalf("payload changed after buffer reuse: %s", first)
+	}
+	if _, err := marshalPayload(map[string]interface{}{"bad": make(chan int)}); err == nil {
+		t.Fatal("expected error for unsupported value")
+	}
+}
+
+func TestMarshalPayload_MatchesJSONMarshalForAllValues(t *testing.T) {
+	strs := []string{"", "plain", "quote\" back\\slash", "<a href=\"x\">&</a>", "ctrl\b\f\n\r\t\x00\x1f",
+		"юникод ✓", "line\u2028sep\u2029", "bad\xff\xfeutf8", "\xe2\x82"}
+	for _, s := range strs {
+		payloads := []map[string]interface{}{
+			{s: s},
+			{"reason": s, "amount_minor": int64(-42), "qty": int32(3), "items": 7, "ok": false, "none": nil},
+			// Вложенные значения идут через json.Encoder.
+			{"reason": s, "items": []string{s}, "amount": 1.5},
+		}
+		for _, payload := range payloads {
+			want, err := json.Marshal(payload)
+			if err != nil {
+				t.Fatalf("json.Marshal: %v", err)
+			}
+			got, err := marshalPayload(payload)
+			if err != nil {
+				t.Fatalf("marshalPayload: %v", err)
+			}
+			if !bytes.Equal(got, want) {
+				t.Fatalf("got %s, want %s", got, want)
+			}
+		}
+	}
+}
+
+// discardOutbox принимает сообщения и ничего не хранит: бенчмарк измеряет только emitEvents.
+type discardOutbox struct {
+	domain.OutboxRepository
+}
+
+func (discardOutbox) Enqueue(msg domain.OutboxMessage) (domain.OutboxMessage, error) {
+	return msg, nil
+}
+
+func (discardOutbox) EnqueueBatch(msgs []domain.OutboxMessage) ([]domain.OutboxMessage, error) {
+	return msgs, nil
+}
+
+func BenchmarkEmitEvents_StatusChanged(b *testing.B) {
+	logger := log.New()
+	logger.SetLevel(log.PanicLevel)
+	orch := NewOrchestratorWithoutMetrics(nil, discardOutbox{}, nil, nil, nil, log.NewEntry(logger)).(*orchestrator)
+	order := &domain.Order{ID: "order-1", Status: domain.OrderStatusPaid, UpdatedAt: time.Now().UTC()}
+	ctx := context.Background()
+
+	b.ReportAllocs()
+	for b.Loop() {
+		orch.emitEvents(ctx, order, statusEvent(order))
+	}
+}
+
+func BenchmarkMarshalPayload(b *testing.B) {
+	payload := benchmarkPayload()
+	b.ReportAllocs()
+	for b.Loop() {
+		if _, err := marshalPayload(payload); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+// BenchmarkMarshalPayload_JSONMarshal — прежняя сериализация payload, для сравнения.
+func BenchmarkMarshalPayload_JSONMarshal(b *testing.B) {
+	payload := benchmarkPayload()
+	b.ReportAllocs()
+	for b.Loop() {
+		if _, err := json.Marshal(payload); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func benchmarkPayload() map[string]interface{} {
+	return map[string]interface{}{
+		"order_id":   "0b9a2c4e-4f52-4f7e-9a57-3c1b8d1e2f60",
+		"status":     domain.OrderStatusPaid,
+		"updated_at": "2026-03-08T12:00:00.123456789Z",
+		"ts":         "2026-03-08T12:00:00.123456789Z",
+	}
+}
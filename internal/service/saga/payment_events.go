@@ -0,0 +1,438 @@
+package saga
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/IBM/sarama"
+	"github.com/prometheus/client_golang/prometheus"
+	log "github.com/sirupsen/logrus"
+
+	"github.com/vladislavdragonenkov/oms/internal/domain"
+	"github.com/vladislavdragonenkov/oms/internal/messaging/kafka"
+	"github.com/vladislavdragonenkov/oms/internal/metrics"
+	"github.com/vladislavdragonenkov/oms/internal/timeutil"
+)
+
+const (
+	defaultPaymentParkTTL       = 15 * time.Minute
+	defaultPaymentRetryInterval = 10 * time.Second
+	defaultPaymentParkCapacity  = 10000
+)
+
+// Исходы обработки события PSP (label result у oms_payment_events_total).
+const (
+	paymentResultApplied   = "applied"
+	paymentResultDuplicate = "duplicate"
+	paymentResultStale     = "stale"
+	paymentResultParked    = "parked"
+	paymentResultConflict  = "conflict"
+	paymentResultExpired   = "expired"
+)
+
+// ErrPaymentParkingFull — отложенных событий PSP больше ёмкости; сообщение уходит в retry/DLQ consumer'а.
+var ErrPaymentParkingFull = errors.New("payment events parking is full")
+
+// PaymentEventApplier — необязательное расширение Orchestrator для событий PSP, которые меняют
+// заказ без повторного обращения к платёжному провайдеру.
+type PaymentEventApplier interface {
+	// ApplyPaymentCaptured переводит reserved- или authorized-заказ в paid и доводит сагу до подтверждения.
+	ApplyPaymentCaptured(ctx context.Context, orderID string) error
+	// ApplyChargeback переводит оплаченный заказ в refunded: деньги уже вернул банк, Refund у PSP не вызывается.
+	ApplyChargeback(ctx context.Context, orderID string, amountMinor int64, reason string) error
+}
+
+type paymentEventMetrics struct {
+	events *prometheus.CounterVec
+	parked prometheus.Gauge
+}
+
+func newPaymentEventMetrics(registerer prometheus.Registerer) paymentEventMetrics {
+	return paymentEventMetrics{
+		events: metrics.Register(registerer, prometheus.NewCounterVec(prometheus.CounterOpts{
+			Name: "oms_payment_events_total",
+			Help: "Total number of PSP payment events grouped by type and result (applied, duplicate, stale, parked, conflict, expired).",
+		}, []string{"type", "result"})),
+		parked: metrics.Register(registerer, prometheus.NewGauge(prometheus.GaugeOpts{
+			Name: "oms_payment_events_parked",
+			Help: "PSP payment events waiting for their order to appear or to reach the expected status.",
+		})),
+	}
+}
+
+// parkedPaymentEvent — событие, пришедшее раньше заказа или раньше нужного шага саги.
+type parkedPaymentEvent struct {
+	event    kafka.PaymentEvent
+	parkedAt time.Time
+	attempts int
+}
+
+// PaymentEventHandler сопоставляет события PSP из топика kafka.TopicPaymentEvents с заказами:
+// capture продвигает сагу, отказ и chargeback её компенсируют.
+//
+// Порядок событий PSP не гарантирован, поэтому решение принимается по текущему статусу заказа:
+// capture применяется к reserved-заказу только после дедлайна саги, которая могла сама ждать ответа Pay;
+// событие, которое заказ уже «перерос» (отказ после capture, повтор), пропускается, а событие,
+// до которого заказ ещё не дошёл (capture до резерва, неизвестный заказ), откладывается в память
+// и повторяется раз в retryInterval до parkTTL. Отложенные события не переживают рестарт —
+// offset к этому моменту уже закоммичен, поэтому истёкшие и потерянные события видны по метрике.
+type PaymentEventHandler struct {
+	orders        domain.OrderRepository
+	saga          Orchestrator
+	logger        *log.Entry
+	metrics       paymentEventMetrics
+	sagaTimeout   runtimeTimeout
+	parkTTL       time.Duration
+	retryInterval time.Duration
+	capacity      int
+	now           func() time.Time
+
+	mu     sync.Mutex
+	parked map[string]*parkedPaymentEvent
+}
+
+// PaymentEventOption настраивает PaymentEventHandler.
+type PaymentEventOption func(*PaymentEventHandler)
+
+// WithPaymentEventsLogger задаёт logger.
+func WithPaymentEventsLogger(logger *log.Entry) PaymentEventOption {
+	return func(h *PaymentEventHandler) {
+		h.logger = logger
+	}
+}
+
+// WithPaymentEventsRegisterer задаёт реестр метрик.
+func WithPaymentEventsRegisterer(registerer prometheus.Registerer) PaymentEventOption {
+	return func(h *PaymentEventHandler) {
+		h.metrics = newPaymentEventMetrics(registerer)
+	}
+}
+
+// WithPaymentEventsSagaTimeout задаёт дедлайн операций саги, запущенных событием.
+func WithPaymentEventsSagaTimeout(timeout time.Duration) PaymentEventOption {
+	return func(h *PaymentEventHandler) {
+		h.sagaTimeout.set(timeout)
+	}
+}
+
+// WithPaymentEventsParking задаёт срок хранения отложенного события, период повторов и ёмкость.
+// Нулевые значения оставляют значения по умолчанию (15m, 10s, 10000).
+func WithPaymentEventsParking(ttl, retryInterval time.Duration, capacity int) PaymentEventOption {
+	return func(h *PaymentEventHandler) {
+		if ttl > 0 {
+			h.parkTTL = ttl
+		}
+		if retryInterval > 0 {
+			h.retryInterval = retryInterval
+		}
+		if capacity > 0 {
+			h.capacity = capacity
+		}
+	}
+}
+
+// NewPaymentEventHandler создаёт обработчик событий PSP.
+func NewPaymentEventHandler(orders domain.OrderRepository, orchestrator Orchestrator, opts ...PaymentEventOption) *PaymentEventHandler {
+	h := &PaymentEventHandler{
+		orders:        orders,
+		saga:          orchestrator,
+		parkTTL:       defaultPaymentParkTTL,
+		retryInterval: defaultPaymentRetryInterval,
+		capacity:      defaultPaymentParkCapacity,
+		now:           timeutil.Now,
+		parked:        make(map[string]*parkedPaymentEvent),
+	}
+	for _, opt := range opts {
+		if opt != nil {
+			opt(h)
+		}
+	}
+	if h.logger == nil {
+		h.logger = log.WithField("component", "payment-events")
+	}
+	if h.metrics.events == nil {
+		h.metrics = newPaymentEventMetrics(nil)
+	}
+	return h
+}
+
+// SetSagaTimeout меняет дедлайн саг и окно, в течение которого capture для reserved-заказа
+// откладывается, чтобы не обогнать собственный Pay саги.
+func (h *PaymentEventHandler) SetSagaTimeout(timeout time.Duration) {
+	h.sagaTimeout.set(timeout)
+}
+
+// HandleMessage — kafka.MessageHandler для топика kafka.TopicPaymentEvents.
+func (h *PaymentEventHandler) HandleMessage(ctx context.Context, message *sarama.ConsumerMessage) error {
+	var event kafka.PaymentEvent
+	if err := kafka.DecodeMessage(message, &event); err != nil {
+		return fmt.Errorf("decode payment event: %w", err)
+	}
+	if err := validatePaymentEvent(&event); err != nil {
+		return err
+	}
+
+	result, err := h.apply(ctx, event)
+	if err != nil {
+		return err
+	}
+	if result == paymentResultParked {
+		return h.park(event)
+	}
+	h.record(event, result)
+	return nil
+}
+
+func validatePaymentEvent(event *kafka.PaymentEvent) error {
+	event.OrderID = strings.TrimSpace(event.OrderID)
+	if _, err := domain.ParseOrderID(event.OrderID); err != nil {
+		return fmt.Errorf("payment event %s: %w", event.EventID, err)
+	}
+	switch event.Type {
+	case kafka.PaymentEventCaptured, kafka.PaymentEventFailed, kafka.PaymentEventChargeback:
+		return nil
+	default:
+		return fmt.Errorf("payment event %s: unsupported type %q", event.EventID, event.Type)
+	}
+}
+
+// apply сопоставляет событие со статусом заказа. Для hold решение принимается по статусу,
+// в котором заказ остановили: отказ оплаты отменяет и такой заказ.
+func (h *PaymentEventHandler) apply(ctx context.Context, event kafka.PaymentEvent) (string, error) {
+	order, err := h.orders.Get(event.OrderID)
+	if errors.Is(err, domain.ErrOrderNotFound) {
+		return paymentResultParked, nil
+	}
+	if err != nil {
+		return "", fmt.Errorf("load order %s: %w", event.OrderID, err)
+	}
+
+	status := order.EffectiveStatus()
+	switch event.Type {
+	case kafka.PaymentEventCaptured:
+		switch {
+		case order.Status == domain.OrderStatusOnHold, status == domain.OrderStatusPending, status == domain.OrderStatusBackordered:
+			return paymentResultParked, nil
+		case status == domain.OrderStatusReserved && h.now().Sub(order.UpdatedAt) < h.sagaTimeout.get():
+			// Сага ещё может быть внутри Pay: её собственный ответ PSP приоритетнее, ждём дедлайна саги.
+			return paymentResultParked, nil
+		case status == domain.OrderStatusReserved, status == domain.OrderStatusAuthorized:
+			// Authorized-заказ сага не трогает до Capture: списание по инициативе PSP применяется сразу.
+			return h.applyWith(ctx, event, func(applier PaymentEventApplier, ctx context.Context) error {
+				return applier.ApplyPaymentCaptured(ctx, event.OrderID)
+			})
+		case status == domain.OrderStatusCanceled:
+			// Деньги списаны по уже отменённому заказу — автоматически не возвращаем, нужен разбор.
+			h.logger.WithFields(h.fields(event)).Error("payment captured for canceled order, manual refund required")
+			return paymentResultConflict, nil
+		default:
+			return paymentResultDuplicate, nil
+		}
+	case kafka.PaymentEventFailed:
+		switch status {
+		case domain.OrderStatusPending, domain.OrderStatusReserved, domain.OrderStatusBackordered, domain.OrderStatusAuthorized:
+			sagaCtx, cancel := DetachedContext(ctx, h.sagaTimeout.get())
+			defer cancel()
+			h.saga.Cancel(sagaCtx, event.OrderID, paymentEventReason("payment failed", event.Reason))
+			return paymentResultApplied, nil
+		case domain.OrderStatusPaid, domain.OrderStatusConfirmed:
+			// Отказ предыдущей попытки пришёл позже успешного capture.
+			return paymentResultStale, nil
+		default:
+			return paymentResultDuplicate, nil
+		}
+	default: // kafka.PaymentEventChargeback
+		switch status {
+		case domain.OrderStatusPaid, domain.OrderStatusConfirmed:
+			return h.applyWith(ctx, event, func(applier PaymentEventApplier, ctx context.Context) error {
+				return applier.ApplyChargeback(ctx, event.OrderID, event.AmountMinor, paymentEventReason("chargeback", event.Reason))
+			})
+		case domain.OrderStatusPending, domain.OrderStatusReserved, domain.OrderStatusBackordered, domain.OrderStatusAuthorized:
+			// Chargeback обогнал capture — ждём, пока заказ станет оплаченным.
+			return paymentResultParked, nil
+		case domain.OrderStatusCanceled:
+			h.logger.WithFields(h.fields(event)).Error("chargeback for canceled order")
+			return paymentResultConflict, nil
+		default:
+			return paymentResultDuplicate, nil
+		}
+	}
+}
+
+func (h *PaymentEventHandler) applyWith(ctx context.Context, event kafka.PaymentEvent, fn func(PaymentEventApplier, context.Context) error) (string, error) {
+	applier, ok := h.saga.(PaymentEventApplier)
+	if !ok {
+		return "", fmt.Errorf("orchestrator does not support %s events", event.Type)
+	}
+	sagaCtx, cancel := DetachedContext(ctx, h.sagaTimeout.get())
+	defer cancel()
+	if err := fn(applier, sagaCtx); err != nil {
+		return "", fmt.Errorf("apply %s to order %s: %w", event.Type, event.OrderID, err)
+	}
+	return paymentResultApplied, nil
+}
+
+func (h *PaymentEventHandler) park(event kafka.PaymentEvent) error {
+	key := paymentEventKey(event)
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	if _, ok := h.parked[key]; ok {
+		h.metrics.events.WithLabelValues(string(event.Type), paymentResultDuplicate).Inc()
+		return nil
+	}
+	if len(h.parked) >= h.capacity {
+		return fmt.Errorf("%w: %d events", ErrPaymentParkingFull, len(h.parked))
+	}
+	h.parked[key] = &parkedPaymentEvent{event: event, parkedAt: h.now()}
+	h.metrics.parked.Set(float64(len(h.parked)))
+	h.record(event, paymentResultParked)
+	return nil
+}
+
+// Run повторяет отложенные события раз в retryInterval до отмены ctx.
+func (h *PaymentEventHandler) Run(ctx context.Context) {
+	ticker := time.NewTicker(h.retryInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			if n := h.Parked(); n > 0 {
+				h.logger.WithField("events", n).Warn("payment events consumer stopped with parked events")
+			}
+			return
+		case <-ticker.C:
+			h.retryParked(ctx)
+		}
+	}
+}
+
+// Parked возвращает число отложенных событий.
+func (h *PaymentEventHandler) Parked() int {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	return len(h.parked)
+}
+
+func (h *PaymentEventHandler) retryParked(ctx context.Context) {
+	h.mu.Lock()
+	pending := make(map[string]*parkedPaymentEvent, len(h.parked))
+	for key, parked := range h.parked {
+		pending[key] = parked
+	}
+	h.mu.Unlock()
+
+	now := h.now()
+	for key, parked := range pending {
+		if ctx.Err() != nil {
+			return
+		}
+		result, err := h.apply(ctx, parked.event)
+		switch {
+		case err != nil:
+			h.logger.WithError(err).WithFields(h.fields(parked.event)).Warn("parked payment event retry failed")
+			result = paymentResultParked
+		case result != paymentResultParked:
+			h.record(parked.event, result)
+		}
+		if result == paymentResultParked {
+			parked.attempts++
+			if now.Sub(parked.parkedAt) < h.parkTTL {
+				continue
+			}
+			h.logger.WithFields(h.fields(parked.event)).WithField("attempts", parked.attempts).
+				Error("parked payment event expired without a matching order")
+			h.record(parked.event, paymentResultExpired)
+		}
+		h.mu.Lock()
+		delete(h.parked, key)
+		h.metrics.parked.Set(float64(len(h.parked)))
+		h.mu.Unlock()
+	}
+}
+
+func (h *PaymentEventHandler) record(event kafka.PaymentEvent, result string) {
+	h.metrics.events.WithLabelValues(string(event.Type), result).Inc()
+	h.logger.WithFields(h.fields(event)).WithField("result", result).Debug("payment event processed")
+}
+
+func (h *PaymentEventHandler) fields(event kafka.PaymentEvent) log.Fields {
+	return log.Fields{
+		"order_id":   event.OrderID,
+		"event_id":   event.EventID,
+		"event_type": event.Type,
+		"payment_id": event.PaymentID,
+	}
+}
+
+// paymentEventKey — ключ дедупликации отложенных событий; PSP без event_id различаем по заказу и типу.
+func paymentEventKey(event kafka.PaymentEvent) string {
+	if event.EventID != "" {
+		return event.EventID
+	}
+	return event.OrderID + "/" + string(event.Type)
+}
+
+func paymentEventReason(prefix, reason string) string {
+	if reason = strings.TrimSpace(reason); reason != "" {
+		return prefix + ": " + reason
+	}
+	return prefix
+}
+
+// ApplyPaymentCaptured реализует PaymentEventApplier: PSP подтвердил списание, которое сага
+// ещё не зафиксировала. Повторный вызов для paid/confirmed заказа ничего не делает.
+func (o *orchestrator) ApplyPaymentCaptured(ctx context.Context, orderID string) error {
+	order, err := o.loadOrder(orderID)
+	if err != nil {
+		return err
+	}
+	switch order.Status {
+	case domain.OrderStatusPaid, domain.OrderStatusConfirmed:
+		return nil
+	case domain.OrderStatusReserved, domain.OrderStatusAuthorized:
+	default:
+		return fmt.Errorf("order in status %s cannot be marked paid", order.Status)
+	}
+	return o.completeCapture(ctx, &order, "psp_event")
+}
+
+// ApplyChargeback реализует PaymentEventApplier. Резерв не снимается: товар к этому моменту
+// обычно уже отгружен, возврат остатков — отдельный процесс.
+func (o *orchestrator) ApplyChargeback(ctx context.Context, orderID string, amountMinor int64, reason string) error {
+	order, err := o.loadOrder(orderID)
+	if err != nil {
+		return err
+	}
+	if order.Status == domain.OrderStatusRefunded {
+		return nil
+	}
+	if effective := order.EffectiveStatus(); effective != domain.OrderStatusPaid && effective != domain.OrderStatusConfirmed {
+		return fmt.Errorf("order in status %s has no payment to charge back", order.Status)
+	}
+	if amountMinor <= 0 || amountMinor > order.AmountMinor {
+		amountMinor = order.AmountMinor
+	}
+
+	chargeback := sagaEvent{eventType: "OrderChargeback", payload: map[string]interface{}{
+		"amount_minor": amountMinor,
+		"reason":       reason,
+	}}
+	if err := o.updateStatus(ctx, &order, domain.OrderStatusRefunded, chargeback); err != nil {
+		return err
+	}
+	o.publishSagaEvent(ctx, &order, kafka.SagaRefundedMetadata{
+		CustomerID:  order.CustomerID,
+		AmountMinor: amountMinor,
+		Reason:      reason,
+		Chargeback:  true,
+	})
+	if o.metrics != nil {
+		o.metrics.RecordSagaRefunded()
+	}
+	return nil
+}
@@ -0,0 +1,233 @@
+package saga
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/IBM/sarama"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+
+	"github.com/vladislavdragonenkov/oms/internal/domain"
+	"github.com/vladislavdragonenkov/oms/internal/metrics/metricstest"
+	"github.com/vladislavdragonenkov/oms/internal/storage/memory"
+)
+
+// newTestPaymentEventHandler собирает обработчик с дедлайном саги в минуту и парковкой на час
+// не больше чем для двух событий; часы обработчика — *now.
+func newTestPaymentEventHandler(repo domain.OrderRepository, orch Orchestrator, registry prometheus.Registerer, now *time.Time) *PaymentEventHandler {
+	handler := NewPaymentEventHandler(repo, orch,
+		WithPaymentEventsRegisterer(registry),
+		WithPaymentEventsSagaTimeout(time.Minute),
+		WithPaymentEventsParking(time.Hour, time.Second, 2))
+	handler.now = func() time.Time { return *now }
+	return handler
+}
+
+func handlePaymentEvent(t *testing.T, handler *PaymentEventHandler, payload string) {
+	t.Helper()
+	if err := handler.HandleMessage(context.Background(), &sarama.ConsumerMessage{Value: []byte(payload)}); err != nil {
+		t.Fatalf("handle %s: %v", payload, err)
+	}
+}
+
+func paymentEventCount(handler *PaymentEventHandler, eventType, result string) float64 {
+	return testutil.ToFloat64(handler.metrics.events.WithLabelValues(eventType, result))
+}
+
+func TestPaymentEvents_CapturedAdvancesReservedOrderAfterSagaDeadline(t *testing.T) {
+	repo := memory.NewOrderRepository()
+	payments := &stubPayment{payStatus: domain.PaymentStatusCaptured, refundStatus: domain.PaymentStatusRefunded}
+	orch := NewOrchestratorWithoutMetrics(repo, memory.NewOutboxRepository(), memory.NewTimelineRepository(), &stubInventory{}, payments, nil)
+	now := time.Now().UTC()
+	handler := newTestPaymentEventHandler(repo, orch, prometheus.NewRegistry(), &now)
+	seedOrderAt(t, repo, "order-stuck", domain.OrderStatusReserved, now.Add(-2*time.Minute))
+	seedOrderAt(t, repo, "order-fresh", domain.OrderStatusReserved, now.Add(-time.Second))
+
+	handlePaymentEvent(t, handler, `{"event_id":"evt-1","type":"payment.captured","order_id":"order-stuck"}`)
+	if got := orderStatus(t, repo, "order-stuck"); got != domain.OrderStatusConfirmed {
+		t.Fatalf("expected confirmed, got %s", got)
+	}
+	if payments.payCnt != 0 {
+		t.Fatalf("capture event must not call PSP again, pay calls=%d", payments.payCnt)
+	}
+
+	// Сага по свежему заказу может ещё ждать ответа Pay — событие откладывается до её дедлайна.
+	handlePaymentEvent(t, handler, `{"event_id":"evt-2","type":"payment.captured","order_id":"order-fresh"}`)
+	if got := orderStatus(t, repo, "order-fresh"); got != domain.OrderStatusReserved || handler.Parked() != 1 {
+		t.Fatalf("expected parked capture, status=%s parked=%d", got, handler.Parked())
+	}
+	now = now.Add(time.Minute)
+	handler.retryParked(context.Background())
+	if got := orderStatus(t, repo, "order-fresh"); got != domain.OrderStatusConfirmed || handler.Parked() != 0 {
+		t.Fatalf("expected capture applied on retry, status=%s parked=%d", got, handler.Parked())
+	}
+
+	handlePaymentEvent(t, handler, `{"event_id":"evt-1","type":"payment.captured","order_id":"order-stuck"}`)
+	if got := paymentEventCount(handler, "payment.captured", paymentResultApplied); got != 2 {
+		t.Fatalf("expected 2 applied captures, got %v", got)
+	}
+	if got := paymentEventCount(handler, "payment.captured", paymentResultDuplicate); got != 1 {
+		t.Fatalf("expected redelivered capture to be a duplicate, got %v", got)
+	}
+}
+
+func TestPaymentEvents_FailedCompensatesAndLateFailureIsStale(t *testing.T) {
+	repo := memory.NewOrderRepository()
+	timeline := memory.NewTimelineRepository()
+	inventory := &stubInventory{}
+	orch := NewOrchestratorWithoutMetrics(repo, memory.NewOutboxRepository(), timeline, inventory, &stubPayment{payStatus: domain.PaymentStatusCaptured, refundStatus: domain.PaymentStatusRefunded}, nil)
+	now := time.Now().UTC()
+	handler := newTestPaymentEventHandler(repo, orch, prometheus.NewRegistry(), &now)
+	seedOrderAt(t, repo, "order-reserved", domain.OrderStatusReserved, now)
+	seedOrderAt(t, repo, "order-paid", domain.OrderStatusPaid, now)
+
+	handlePaymentEvent(t, handler, `{"event_id":"evt-1","type":"payment.failed","order_id":"order-reserved","reason":"insufficient funds"}`)
+	if got := orderStatus(t, repo, "order-reserved"); got != domain.OrderStatusCanceled {
+		t.Fatalf("expected canceled, got %s", got)
+	}
+	if inventory.releaseCnt != 1 {
+		t.Fatalf("expected reservation to be released, got %d", inventory.releaseCnt)
+	}
+	if types := timelineTypes(t, timeline, "order-reserved"); types["OrderCanceled"] != 1 {
+		t.Fatalf("expected OrderCanceled in timeline, got %v", types)
+	}
+
+	// Отказ предыдущей попытки пришёл после capture.
+	handlePaymentEvent(t, handler, `{"event_id":"evt-2","type":"payment.failed","order_id":"order-paid"}`)
+	if got := orderStatus(t, repo, "order-paid"); got != domain.OrderStatusPaid {
+		t.Fatalf("stale failure must not touch paid order, got %s", got)
+	}
+	if got := paymentEventCount(handler, "payment.failed", paymentResultStale); got != 1 {
+		t.Fatalf("expected 1 stale failure, got %v", got)
+	}
+}
+
+func TestPaymentEvents_ChargebackRefundsWithoutPSP(t *testing.T) {
+	repo := memory.NewOrderRepository()
+	timeline := memory.NewTimelineRepository()
+	inventory := &stubInventory{}
+	payments := &stubPayment{payStatus: domain.PaymentStatusCaptured, refundStatus: domain.PaymentStatusRefunded}
+	orch := NewOrchestratorWithoutMetrics(repo, memory.NewOutboxRepository(), timeline, inventory, payments, nil)
+	now := time.Now().UTC()
+	handler := newTestPaymentEventHandler(repo, orch, prometheus.NewRegistry(), &now)
+	seedOrderAt(t, repo, "order-1", domain.OrderStatusConfirmed, now)
+	seedOrderAt(t, repo, "order-2", domain.OrderStatusReserved, now)
+
+	handlePaymentEvent(t, handler, `{"event_id":"evt-1","type":"payment.chargeback","order_id":"order-1","amount_minor":100,"reason":"fraud"}`)
+	if got := orderStatus(t, repo, "order-1"); got != domain.OrderStatusRefunded {
+		t.Fatalf("expected refunded, got %s", got)
+	}
+	if payments.refundCnt != 0 || inventory.releaseCnt != 0 {
+		t.Fatalf("chargeback must not call PSP refund or release stock: refunds=%d releases=%d", payments.refundCnt, inventory.releaseCnt)
+	}
+	if types := timelineTypes(t, timeline, "order-1"); types["OrderChargeback"] != 1 {
+		t.Fatalf("expected OrderChargeback in timeline, got %v", types)
+	}
+
+	// Chargeback обогнал capture: ждёт, пока заказ станет оплаченным.
+	handlePaymentEvent(t, handler, `{"event_id":"evt-2","type":"payment.chargeback","order_id":"order-2"}`)
+	if got := orderStatus(t, repo, "order-2"); got != domain.OrderStatusReserved || handler.Parked() != 1 {
+		t.Fatalf("expected parked chargeback, status=%s parked=%d", got, handler.Parked())
+	}
+}
+
+func TestPaymentEvents_UnknownOrderParkingAndExpiry(t *testing.T) {
+	repo := memory.NewOrderRepository()
+	orch := NewOrchestratorWithoutMetrics(repo, memory.NewOutboxRepository(), memory.NewTimelineRepository(), &stubInventory{}, &stubPayment{}, nil)
+	registry := prometheus.NewRegistry()
+	now := time.Now().UTC()
+	handler := newTestPaymentEventHandler(repo, orch, registry, &now)
+
+	handlePaymentEvent(t, handler, `{"event_id":"evt-1","type":"payment.failed","order_id":"order-late"}`)
+	handlePaymentEvent(t, handler, `{"event_id":"evt-1","type":"payment.failed","order_id":"order-late"}`)
+	handlePaymentEvent(t, handler, `{"event_id":"evt-2","type":"payment.failed","order_id":"order-ghost"}`)
+	if handler.Parked() != 2 {
+		t.Fatalf("expected 2 parked events, got %d", handler.Parked())
+	}
+	metricstest.RequireValue(t, registry, "oms_payment_events_parked", nil, 2)
+	metricstest.RequireValue(t, registry, "oms_payment_events_total", metricstest.Labels{"type": "payment.failed", "result": paymentResultDuplicate}, 1)
+	err := handler.HandleMessage(context.Background(), &sarama.ConsumerMessage{Value: []byte(`{"event_id":"evt-3","type":"payment.failed","order_id":"order-x"}`)})
+	if !errors.Is(err, ErrPaymentParkingFull) {
+		t.Fatalf("expected ErrPaymentParkingFull to hand the message back to the consumer, got %v", err)
+	}
+
+	seedOrderAt(t, repo, "order-late", domain.OrderStatusPending, now)
+	handler.retryParked(context.Background())
+	if got := orderStatus(t, repo, "order-late"); got != domain.OrderStatusCanceled {
+		t.Fatalf("expected parked failure to cancel the order, got %s", got)
+	}
+	if handler.Parked() != 1 {
+		t.Fatalf("expected only the unknown order to stay parked, got %d", handler.Parked())
+	}
+
+	now = now.Add(time.Hour)
+	handler.retryParked(context.Background())
+	if handler.Parked() != 0 || paymentEventCount(handler, "payment.failed", paymentResultExpired) != 1 {
+		t.Fatalf("expected parked event to expire, parked=%d", handler.Parked())
+	}
+	metricstest.RequireValue(t, registry, "oms_payment_events_parked", nil, 0)
+}
+
+func TestPaymentEvents_RejectsInvalidMessages(t *testing.T) {
+	repo := memory.NewOrderRepository()
+	orch := NewOrchestratorWithoutMetrics(repo, memory.NewOutboxRepository(), memory.NewTimelineRepository(), &stubInventory{}, &stubPayment{}, nil)
+	now := time.Now().UTC()
+	handler := newTestPaymentEventHandler(repo, orch, prometheus.NewRegistry(), &now)
+	for _, payload := range []string{
+		`not-json`,
+		`{"type":"payment.captured"}`,
+		`{"type":"payment.captured","order_id":"order 1"}`,
+		`{"type":"payment.voided","order_id":"order-1"}`,
+	} {
+		if err := handler.HandleMessage(context.Background(), &sarama.ConsumerMessage{Value: []byte(payload)}); err == nil {
+			t.Fatalf("expected error for payload %s", payload)
+		}
+	}
+}
+
+func TestPaymentEvents_SetSagaTimeoutShrinksCaptureWindow(t *testing.T) {
+	repo := memory.NewOrderRepository()
+	orch := NewOrchestratorWithoutMetrics(repo, memory.NewOutboxRepository(), memory.NewTimelineRepository(), &stubInventory{}, &stubPayment{}, nil)
+	now := time.Now().UTC()
+	handler := newTestPaymentEventHandler(repo, orch, prometheus.NewRegistry(), &now)
+	seedOrderAt(t, repo, "order-1", domain.OrderStatusReserved, now.Add(-10*time.Second))
+
+	handlePaymentEvent(t, handler, `{"event_id":"evt-1","type":"payment.captured","order_id":"order-1"}`)
+	if handler.Parked() != 1 {
+		t.Fatalf("expected capture parked within saga timeout, parked=%d", handler.Parked())
+	}
+
+	handler.SetSagaTimeout(5 * time.Second)
+	handler.retryParked(context.Background())
+	if got := orderStatus(t, repo, "order-1"); got != domain.OrderStatusConfirmed {
+		t.Fatalf("expected capture applied after timeout reload, got %s", got)
+	}
+}
+
+func TestPaymentEvents_AuthorizedOrder(t *testing.T) {
+	repo := memory.NewOrderRepository()
+	inventory := &stubInventory{}
+	payments := &stubPayment{payStatus: domain.PaymentStatusCaptured, refundStatus: domain.PaymentStatusRefunded}
+	orch := NewOrchestratorWithoutMetrics(repo, memory.NewOutboxRepository(), memory.NewTimelineRepository(), inventory, payments, nil)
+	now := time.Now().UTC()
+	handler := newTestPaymentEventHandler(repo, orch, prometheus.NewRegistry(), &now)
+	// Authorized-заказ сага не трогает до Capture, поэтому дедлайн саги не ждём.
+	seedOrderAt(t, repo, "order-captured", domain.OrderStatusAuthorized, now.Add(-time.Second))
+	seedOrderAt(t, repo, "order-failed", domain.OrderStatusAuthorized, now.Add(-time.Second))
+
+	handlePaymentEvent(t, handler, `{"event_id":"evt-1","type":"payment.captured","order_id":"order-captured"}`)
+	if got := orderStatus(t, repo, "order-captured"); got != domain.OrderStatusConfirmed {
+		t.Fatalf("expected confirmed, got %s", got)
+	}
+
+	handlePaymentEvent(t, handler, `{"event_id":"evt-2","type":"payment.failed","order_id":"order-failed"}`)
+	if got := orderStatus(t, repo, "order-failed"); got != domain.OrderStatusCanceled {
+		t.Fatalf("expected canceled, got %s", got)
+	}
+	if inventory.releaseCnt != 1 || payments.refundCnt != 0 {
+		t.Fatalf("expected release without refund, release=%d refund=%d", inventory.releaseCnt, payments.refundCnt)
+	}
+}
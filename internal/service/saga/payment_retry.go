@@ -0,0 +1,285 @@
+package saga
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	log "github.com/sirupsen/logrus"
+
+	"github.com/vladislavdragonenkov/oms/internal/domain"
+	"github.com/vladislavdragonenkov/oms/internal/metrics"
+	"github.com/vladislavdragonenkov/oms/internal/timeutil"
+)
+
+const (
+	defaultPaymentRetrySchedulerInterval = 10 * time.Second
+	defaultPaymentRetryBatchSize         = 100
+)
+
+// Исходы обработки наступившего повтора (label result у oms_payment_retries_total).
+const (
+	paymentRetryResultStarted = "started"
+	paymentRetryResultSkipped = "skipped"
+	paymentRetryResultFailed  = "failed"
+)
+
+// PaymentRetryPolicy — сколько раз повторять оплату после временной ошибки PSP и с какой паузой.
+// Пауза перед повтором n — BaseDelay·2^(n-1), но не больше MaxDelay.
+type PaymentRetryPolicy struct {
+	MaxAttempts int
+	BaseDelay   time.Duration
+	MaxDelay    time.Duration
+}
+
+// Delay возвращает паузу перед повтором attempt (с 1).
+func (p PaymentRetryPolicy) Delay(attempt int) time.Duration {
+	delay := p.BaseDelay
+	for i := 1; i < attempt; i++ {
+		if p.MaxDelay > 0 && delay >= p.MaxDelay {
+			break
+		}
+		delay *= 2
+	}
+	if p.MaxDelay > 0 && delay > p.MaxDelay {
+		return p.MaxDelay
+	}
+	return delay
+}
+
+// WithPaymentRetries включает отложенные повторы оплаты: если Pay вернул ErrPaymentTemporary,
+// ErrPaymentIndeterminate или статус pending, заказ остаётся в reserved с резервом склада,
+// а повтор записывается в repo для PaymentRetryScheduler. Отмена — только когда попытки policy
+// исчерпаны. MaxAttempts <= 0 или nil repo оставляют прежнее поведение: отмену сразу.
+func WithPaymentRetries(repo domain.PaymentRetryRepository, policy PaymentRetryPolicy) OrchestratorOption {
+	return func(o *orchestrator) {
+		if repo == nil || policy.MaxAttempts <= 0 {
+			o.paymentRetries = nil
+			return
+		}
+		o.paymentRetries = repo
+		o.paymentRetryPolicy = policy
+	}
+}
+
+// retryablePayment сообщает, что ошибка оплаты временная и оплату стоит повторить позже.
+func retryablePayment(err error) bool {
+	return errors.Is(err, domain.ErrPaymentTemporary) || errors.Is(err, domain.ErrPaymentIndeterminate)
+}
+
+// schedulePaymentRetry планирует следующий повтор оплаты и пишет его в timeline заказа.
+// false — повтор не запланирован (выключен, ошибка не временная, попытки исчерпаны или запись
+// не сохранилась), и сага отменяет заказ как раньше.
+func (o *orchestrator) schedulePaymentRetry(ctx context.Context, order *domain.Order, payErr error) bool {
+	if o.paymentRetries == nil || !retryablePayment(payErr) {
+		return false
+	}
+	logger := o.logger.WithError(payErr).WithField("order_id", order.ID)
+
+	attempt := 1
+	previous, err := o.paymentRetries.Get(order.ID)
+	switch {
+	case err == nil:
+		attempt = previous.Attempt + 1
+	case !errors.Is(err, domain.ErrPaymentRetryNotFound):
+		logger.WithField("retry_error", err.Error()).Warn("failed to load payment retry, order will be canceled")
+		return false
+	}
+	if attempt > o.paymentRetryPolicy.MaxAttempts {
+		o.forgetPaymentRetry(order.ID)
+		logger.WithField("attempts", o.paymentRetryPolicy.MaxAttempts).Warn("payment retries exhausted")
+		return false
+	}
+
+	now := timeutil.Now()
+	retry := domain.PaymentRetry{
+		OrderID:   order.ID,
+		Attempt:   attempt,
+		RunAt:     now.Add(o.paymentRetryPolicy.Delay(attempt)),
+		LastError: payErr.Error(),
+		UpdatedAt: now,
+	}
+	if err := o.paymentRetries.Save(retry); err != nil {
+		logger.WithField("retry_error", err.Error()).Warn("failed to schedule payment retry, order will be canceled")
+		return false
+	}
+
+	o.emitEvent(ctx, order, "PaymentRetryScheduled", map[string]interface{}{
+		"attempt":      attempt,
+		"max_attempts": o.paymentRetryPolicy.MaxAttempts,
+		"retry_at":     timeutil.Format(retry.RunAt),
+		"reason":       fmt.Sprintf("payment attempt failed, retry %d of %d at %s", attempt, o.paymentRetryPolicy.MaxAttempts, timeutil.Format(retry.RunAt)),
+		"error":        payErr.Error(),
+		"ts":           timeutil.Format(now),
+	}, now)
+	logger.WithFields(log.Fields{
+		"attempt":  attempt,
+		"retry_at": timeutil.Format(retry.RunAt),
+	}).Info("payment failed temporarily, retry scheduled")
+	return true
+}
+
+// forgetPaymentRetry удаляет повтор заказа: оплата прошла или заказ больше не ждёт оплаты.
+func (o *orchestrator) forgetPaymentRetry(orderID string) {
+	if o.paymentRetries == nil {
+		return
+	}
+	if err := o.paymentRetries.Delete(orderID); err != nil {
+		o.logger.WithError(err).WithField("order_id", orderID).Warn("failed to delete payment retry")
+	}
+}
+
+// PaymentRetryScheduler запускает сагу для заказов, повтор оплаты которых наступил. Сага
+// продолжает reserved-заказ с шага оплаты; повтор, чей заказ уже не в reserved, удаляется.
+type PaymentRetryScheduler struct {
+	retries     domain.PaymentRetryRepository
+	orders      domain.OrderRepository
+	saga        Orchestrator
+	logger      *log.Entry
+	interval    time.Duration
+	batchSize   int
+	sagaTimeout runtimeTimeout
+	results     *prometheus.CounterVec
+	registerer  prometheus.Registerer
+}
+
+// PaymentRetryOption настраивает PaymentRetryScheduler.
+type PaymentRetryOption func(*PaymentRetryScheduler)
+
+// WithPaymentRetryLogger задаёт logger.
+func WithPaymentRetryLogger(logger *log.Entry) PaymentRetryOption {
+	return func(s *PaymentRetryScheduler) {
+		s.logger = logger
+	}
+}
+
+// WithPaymentRetryInterval задаёт период опроса наступивших повторов.
+func WithPaymentRetryInterval(interval time.Duration) PaymentRetryOption {
+	return func(s *PaymentRetryScheduler) {
+		s.interval = interval
+	}
+}
+
+// WithPaymentRetryBatchSize ограничивает число повторов за один проход.
+func WithPaymentRetryBatchSize(batchSize int) PaymentRetryOption {
+	return func(s *PaymentRetryScheduler) {
+		s.batchSize = batchSize
+	}
+}
+
+// WithPaymentRetrySagaTimeout задаёт дедлайн каждой повторной саги.
+func WithPaymentRetrySagaTimeout(timeout time.Duration) PaymentRetryOption {
+	return func(s *PaymentRetryScheduler) {
+		s.sagaTimeout.set(timeout)
+	}
+}
+
+// WithPaymentRetryRegisterer задаёт реестр метрик.
+func WithPaymentRetryRegisterer(registerer prometheus.Registerer) PaymentRetryOption {
+	return func(s *PaymentRetryScheduler) {
+		s.registerer = registerer
+	}
+}
+
+// NewPaymentRetryScheduler создаёт исполнитель повторов оплаты.
+func NewPaymentRetryScheduler(retries domain.PaymentRetryRepository, orders domain.OrderRepository, orchestrator Orchestrator, opts ...PaymentRetryOption) *PaymentRetryScheduler {
+	s := &PaymentRetryScheduler{
+		retries: retries,
+		orders:  orders,
+		saga:    orchestrator,
+	}
+	for _, opt := range opts {
+		if opt != nil {
+			opt(s)
+		}
+	}
+	if s.logger == nil {
+		s.logger = log.WithField("component", "payment-retry")
+	}
+	if s.interval <= 0 {
+		s.interval = defaultPaymentRetrySchedulerInterval
+	}
+	if s.batchSize <= 0 {
+		s.batchSize = defaultPaymentRetryBatchSize
+	}
+	s.results = metrics.Register(s.registerer, prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: "oms_payment_retries_total",
+		Help: "Due payment retries grouped by result (started, skipped, failed).",
+	}, []string{"result"}))
+	return s
+}
+
+// SetSagaTimeout меняет дедлайн повторных саг, запущенных после этого вызова.
+func (s *PaymentRetryScheduler) SetSagaTimeout(timeout time.Duration) {
+	s.sagaTimeout.set(timeout)
+}
+
+// Run выполняет наступившие повторы до отмены ctx.
+func (s *PaymentRetryScheduler) Run(ctx context.Context) {
+	ticker := time.NewTicker(s.interval)
+	defer ticker.Stop()
+
+	for {
+		if _, err := s.RunOnce(ctx); err != nil {
+			s.logger.WithError(err).Warn("payment retry pass failed")
+		}
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// RunOnce запускает повторы со сроком не позже текущего момента и возвращает число запущенных саг.
+func (s *PaymentRetryScheduler) RunOnce(ctx context.Context) (int, error) {
+	due, err := s.retries.ListDue(timeutil.Now(), s.batchSize)
+	if err != nil {
+		return 0, fmt.Errorf("list due payment retries: %w", err)
+	}
+
+	started := 0
+	for _, retry := range due {
+		if ctx.Err() != nil {
+			return started, ctx.Err()
+		}
+		result := s.execute(ctx, retry)
+		s.results.WithLabelValues(result).Inc()
+		if result == paymentRetryResultStarted {
+			started++
+		}
+	}
+	return started, nil
+}
+
+func (s *PaymentRetryScheduler) execute(ctx context.Context, retry domain.PaymentRetry) string {
+	logger := s.logger.WithFields(log.Fields{"order_id": retry.OrderID, "attempt": retry.Attempt})
+
+	order, err := s.orders.Get(retry.OrderID)
+	switch {
+	case errors.Is(err, domain.ErrOrderNotFound):
+		return s.forget(logger, retry.OrderID)
+	case err != nil:
+		logger.WithError(err).Warn("failed to load order for payment retry")
+		return paymentRetryResultFailed
+	case order.Status != domain.OrderStatusReserved:
+		// Заказ отменили, поставили на hold или оплатили другим путём: снятие hold'а продолжит сагу само.
+		return s.forget(logger, retry.OrderID)
+	}
+
+	sagaCtx, cancel := DetachedContext(ctx, s.sagaTimeout.get())
+	s.saga.Start(sagaCtx, retry.OrderID)
+	cancel()
+	logger.Info("payment retry started")
+	return paymentRetryResultStarted
+}
+
+func (s *PaymentRetryScheduler) forget(logger *log.Entry, orderID string) string {
+	if err := s.retries.Delete(orderID); err != nil {
+		logger.WithError(err).Warn("failed to delete stale payment retry")
+		return paymentRetryResultFailed
+	}
+	return paymentRetryResultSkipped
+}
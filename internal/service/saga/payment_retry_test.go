@@ -0,0 +1,165 @@
+package saga
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+
+	"github.com/vladislavdragonenkov/oms/internal/domain"
+	"github.com/vladislavdragonenkov/oms/internal/metrics/metricstest"
+	"github.com/vladislavdragonenkov/oms/internal/service/payment"
+	"github.com/vladislavdragonenkov/oms/internal/storage/memory"
+)
+
+func TestOrchestrator_TransientPaymentErrorSchedulesRetry(t *testing.T) {
+	repo := memory.NewOrderRepository()
+	timeline := memory.NewTimelineRepository()
+	retries := memory.NewPaymentRetryRepository()
+	inventory := &stubInventory{}
+	payments := payment.NewMockService()
+	seedOrder(t, repo, domain.OrderStatusPending)
+	orch := NewOrchestratorWithoutMetrics(repo, memory.NewOutboxRepository(), timeline, inventory, payments, nil,
+		WithPaymentRetries(retries, PaymentRetryPolicy{MaxAttempts: 2, BaseDelay: time.Minute, MaxDelay: time.Hour}))
+	payments.PayErr = domain.ErrPaymentTemporary
+
+	before := time.Now()
+	orch.Start(context.Background(), "order-1")
+	if got := orderStatus(t, repo, "order-1"); got != domain.OrderStatusReserved {
+		t.Fatalf("expected order to stay reserved, got %s", got)
+	}
+	if inventory.releaseCnt != 0 {
+		t.Fatal("reservation must be kept until the retry")
+	}
+	retry, err := retries.Get("order-1")
+	if err != nil || retry.Attempt != 1 || retry.RunAt.Before(before.Add(time.Minute)) {
+		t.Fatalf("expected first retry in a minute, got %+v, %v", retry, err)
+	}
+
+	// Второй сбой планирует повтор с удвоенной паузой.
+	orch.Start(context.Background(), "order-1")
+	if retry, _ := retries.Get("order-1"); retry.Attempt != 2 || retry.RunAt.Before(before.Add(2*time.Minute)) {
+		t.Fatalf("expected second retry in two minutes, got %+v", retry)
+	}
+
+	// Попытки исчерпаны: заказ отменяется с освобождением резерва.
+	orch.Start(context.Background(), "order-1")
+	if got := orderStatus(t, repo, "order-1"); got != domain.OrderStatusCanceled || inventory.releaseCnt != 1 {
+		t.Fatalf("expected cancel after retries, got %s (releases=%d)", got, inventory.releaseCnt)
+	}
+	if _, err := retries.Get("order-1"); !errors.Is(err, domain.ErrPaymentRetryNotFound) {
+		t.Fatalf("expected retry to be forgotten, got %v", err)
+	}
+
+	events, _ := timeline.List("order-1")
+	scheduled := 0
+	for _, event := range events {
+		if event.Type == "PaymentRetryScheduled" {
+			scheduled++
+			if event.Reason == "" {
+				t.Fatalf("retry timeline event must explain itself: %+v", event)
+			}
+		}
+	}
+	if scheduled != 2 {
+		t.Fatalf("expected timeline entry per retry, got %d in %+v", scheduled, events)
+	}
+}
+
+func TestOrchestrator_PaymentRetrySucceeds(t *testing.T) {
+	repo := memory.NewOrderRepository()
+	retries := memory.NewPaymentRetryRepository()
+	payments := payment.NewMockService()
+	seedOrder(t, repo, domain.OrderStatusPending)
+	orch := NewOrchestratorWithoutMetrics(repo, memory.NewOutboxRepository(), memory.NewTimelineRepository(), &stubInventory{}, payments, nil,
+		WithPaymentRetries(retries, PaymentRetryPolicy{MaxAttempts: 2, BaseDelay: time.Minute, MaxDelay: time.Hour}))
+	payments.PayStatus = domain.PaymentStatusPending
+
+	orch.Start(context.Background(), "order-1")
+	if _, err := retries.Get("order-1"); err != nil {
+		t.Fatalf("expected pending payment to be retried, got %v", err)
+	}
+
+	payments.PayStatus = domain.PaymentStatusCaptured
+	orch.Start(context.Background(), "order-1")
+	if got := orderStatus(t, repo, "order-1"); got != domain.OrderStatusConfirmed {
+		t.Fatalf("expected confirmed after retry, got %s", got)
+	}
+	if _, err := retries.Get("order-1"); !errors.Is(err, domain.ErrPaymentRetryNotFound) {
+		t.Fatalf("expected retry to be forgotten after payment, got %v", err)
+	}
+}
+
+func TestOrchestrator_DeclinedPaymentIsNotRetried(t *testing.T) {
+	repo := memory.NewOrderRepository()
+	retries := memory.NewPaymentRetryRepository()
+	payments := payment.NewMockService()
+	seedOrder(t, repo, domain.OrderStatusPending)
+	orch := NewOrchestratorWithoutMetrics(repo, memory.NewOutboxRepository(), memory.NewTimelineRepository(), &stubInventory{}, payments, nil,
+		WithPaymentRetries(retries, PaymentRetryPolicy{MaxAttempts: 2, BaseDelay: time.Minute, MaxDelay: time.Hour}))
+	payments.PayErr = domain.ErrPaymentDeclined
+
+	orch.Start(context.Background(), "order-1")
+	if got := orderStatus(t, repo, "order-1"); got != domain.OrderStatusCanceled {
+		t.Fatalf("expected declined payment to cancel, got %s", got)
+	}
+	if _, err := retries.Get("order-1"); !errors.Is(err, domain.ErrPaymentRetryNotFound) {
+		t.Fatalf("declined payment must not be retried, got %v", err)
+	}
+}
+
+func TestPaymentRetryPolicy_Delay(t *testing.T) {
+	policy := PaymentRetryPolicy{MaxAttempts: 10, BaseDelay: time.Second, MaxDelay: 5 * time.Second}
+	for attempt, want := range map[int]time.Duration{1: time.Second, 2: 2 * time.Second, 3: 4 * time.Second, 4: 5 * time.Second, 60: 5 * time.Second} {
+		if got := policy.Delay(attempt); got != want {
+			t.Fatalf("attempt %d: got %s, want %s", attempt, got, want)
+		}
+	}
+}
+
+func TestPaymentRetryScheduler_RunOnce(t *testing.T) {
+	orders := memory.NewOrderRepository()
+	retries := memory.NewPaymentRetryRepository()
+	now := time.Now().UTC()
+	for id, status := range map[string]domain.OrderStatus{
+		"order-reserved": domain.OrderStatusReserved,
+		"order-later":    domain.OrderStatusReserved,
+		"order-canceled": domain.OrderStatusCanceled,
+	} {
+		if err := orders.Create(domain.Order{ID: id, CustomerID: "c", Status: status, Currency: "USD", CreatedAt: now, UpdatedAt: now}); err != nil {
+			t.Fatalf("create order: %v", err)
+		}
+	}
+	for id, runAt := range map[string]time.Time{
+		"order-reserved": now.Add(-time.Second),
+		"order-later":    now.Add(time.Hour),
+		"order-canceled": now.Add(-time.Second),
+		"order-missing":  now.Add(-time.Second),
+	} {
+		if err := retries.Save(domain.PaymentRetry{OrderID: id, Attempt: 1, RunAt: runAt}); err != nil {
+			t.Fatalf("save retry: %v", err)
+		}
+	}
+
+	registry := prometheus.NewRegistry()
+	orch := &stubOrchestrator{}
+	started, err := NewPaymentRetryScheduler(retries, orders, orch, WithPaymentRetryRegisterer(registry)).RunOnce(context.Background())
+	if err != nil {
+		t.Fatalf("run once: %v", err)
+	}
+	if started != 1 || orch.startCalls != 1 {
+		t.Fatalf("expected one retried saga, got started=%d calls=%d", started, orch.startCalls)
+	}
+	for _, id := range []string{"order-canceled", "order-missing"} {
+		if _, err := retries.Get(id); !errors.Is(err, domain.ErrPaymentRetryNotFound) {
+			t.Fatalf("%s: expected stale retry to be deleted, got %v", id, err)
+		}
+	}
+	if _, err := retries.Get("order-later"); err != nil {
+		t.Fatalf("future retry must stay, got %v", err)
+	}
+	metricstest.RequireValue(t, registry, "oms_payment_retries_total", metricstest.Labels{"result": "started"}, 1)
+	metricstest.RequireValue(t, registry, "oms_payment_retries_total", metricstest.Labels{"result": "skipped"}, 2)
+}
@@ -1,12 +1,14 @@
 package saga
 
 import (
+	"context"
 	"errors"
 	"sync"
 	"time"
 
 	log "github.com/sirupsen/logrus"
 
+	"github.com/vladislavdragonenkov/oms/internal/ctxutil"
 	"github.com/vladislavdragonenkov/oms/internal/domain"
 )
 
@@ -50,21 +52,21 @@ func NewRetryableOrchestrator(orchestrator Orchestrator, config RetryConfig, log
 }
 
 // Start запускает обработку заказа с retry логикой.
-func (ro *RetryableOrchestrator) Start(orderID string) {
+func (ro *RetryableOrchestrator) Start(ctx context.Context, orderID string) {
 	ro.warnRetryUnsupported()
-	ro.orchestrator.Start(orderID)
+	ro.orchestrator.Start(ctx, orderID)
 }
 
 // Cancel отменяет заказ с retry логикой.
-func (ro *RetryableOrchestrator) Cancel(orderID, reason string) {
+func (ro *RetryableOrchestrator) Cancel(ctx context.Context, orderID, reason string) {
 	ro.warnRetryUnsupported()
-	ro.orchestrator.Cancel(orderID, reason)
+	ro.orchestrator.Cancel(ctx, orderID, reason)
 }
 
 // Refund возвращает средства с retry логикой.
-func (ro *RetryableOrchestrator) Refund(orderID string, amountMinor int64, reason string) {
+func (ro *RetryableOrchestrator) Refund(ctx context.Context, orderID string, amountMinor int64, reason string) {
 	ro.warnRetryUnsupported()
-	ro.orchestrator.Refund(orderID, amountMinor, reason)
+	ro.orchestrator.Refund(ctx, orderID, amountMinor, reason)
 }
 
 func (ro *RetryableOrchestrator) warnRetryUnsupported() {
@@ -73,7 +75,9 @@ func (ro *RetryableOrchestrator) warnRetryUnsupported() {
 	})
 }
 
-func (ro *RetryableOrchestrator) executeWithRetry(operation, orderID string, fn func() error) {
+// executeWithRetry повторяет fn с экспоненциальной задержкой; отмена ctx прерывает ожидание
+// между попытками, чтобы graceful shutdown не ждал оставшийся backoff.
+func (ro *RetryableOrchestrator) executeWithRetry(ctx context.Context, operation, orderID string, fn func() error) {
 	var lastErr error
 	delay := ro.config.InitialDelay
 
@@ -111,7 +115,14 @@ func (ro *RetryableOrchestrator) executeWithRetry(operation, orderID string, fn
 				"error":     err,
 			}).Warn("Operation failed, retrying")
 
-			time.Sleep(delay)
+			if err := ctxutil.Sleep(ctx, delay); err != nil {
+				ro.logger.WithFields(log.Fields{
+					"operation": operation,
+					"order_id":  orderID,
+					"attempt":   attempt,
+				}).Warn("Retry aborted: context done")
+				return
+			}
 
 			// Экспоненциальная задержка с ограничением
 			delay = time.Duration(float64(delay) * ro.config.BackoffFactor)
@@ -248,9 +259,9 @@ func NewCircuitBreakerOrchestrator(orchestrator Orchestrator, breaker *CircuitBr
 }
 
 // Start запускает обработку через circuit breaker.
-func (cbo *CircuitBreakerOrchestrator) Start(orderID string) {
+func (cbo *CircuitBreakerOrchestrator) Start(ctx context.Context, orderID string) {
 	err := cbo.breaker.Execute("Start", func() error {
-		cbo.orchestrator.Start(orderID)
+		cbo.orchestrator.Start(ctx, orderID)
 		return nil
 	})
 
@@ -263,9 +274,9 @@ func (cbo *CircuitBreakerOrchestrator) Start(orderID string) {
 }
 
 // Cancel отменяет заказ через circuit breaker.
-func (cbo *CircuitBreakerOrchestrator) Cancel(orderID, reason string) {
+func (cbo *CircuitBreakerOrchestrator) Cancel(ctx context.Context, orderID, reason string) {
 	err := cbo.breaker.Execute("Cancel", func() error {
-		cbo.orchestrator.Cancel(orderID, reason)
+		cbo.orchestrator.Cancel(ctx, orderID, reason)
 		return nil
 	})
 
@@ -279,9 +290,9 @@ func (cbo *CircuitBreakerOrchestrator) Cancel(orderID, reason string) {
 }
 
 // Refund возвращает средства через circuit breaker.
-func (cbo *CircuitBreakerOrchestrator) Refund(orderID string, amountMinor int64, reason string) {
+func (cbo *CircuitBreakerOrchestrator) Refund(ctx context.Context, orderID string, amountMinor int64, reason string) {
 	err := cbo.breaker.Execute("Refund", func() error {
-		cbo.orchestrator.Refund(orderID, amountMinor, reason)
+		cbo.orchestrator.Refund(ctx, orderID, amountMinor, reason)
 		return nil
 	})
 
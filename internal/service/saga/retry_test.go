@@ -1,6 +1,7 @@
 package saga
 
 import (
+	"context"
 	"errors"
 	"testing"
 	"time"
@@ -16,15 +17,15 @@ type stubOrchestrator struct {
 	refundCalls int
 }
 
-func (s *stubOrchestrator) Start(string) {
+func (s *stubOrchestrator) Start(context.Context, string) {
 	s.startCalls++
 }
 
-func (s *stubOrchestrator) Cancel(string, string) {
+func (s *stubOrchestrator) Cancel(context.Context, string, string) {
 	s.cancelCalls++
 }
 
-func (s *stubOrchestrator) Refund(string, int64, string) {
+func (s *stubOrchestrator) Refund(context.Context, string, int64, string) {
 	s.refundCalls++
 }
 
@@ -48,9 +49,9 @@ func TestRetryableOrchestratorHelpers(t *testing.T) {
 		t.Fatal("expected default logger")
 	}
 
-	ro.Start("o-1")
-	ro.Cancel("o-1", "r")
-	ro.Refund("o-1", 10, "refund")
+	ro.Start(context.Background(), "o-1")
+	ro.Cancel(context.Background(), "o-1", "r")
+	ro.Refund(context.Background(), "o-1", 10, "refund")
 
 	if stub.startCalls != 1 || stub.cancelCalls != 1 || stub.refundCalls != 1 {
 		t.Fatalf("unexpected delegate calls: %+v", stub)
@@ -63,7 +64,7 @@ func TestRetryableOrchestratorExecuteWithRetry(t *testing.T) {
 
 	t.Run("retry then success", func(t *testing.T) {
 		attempts := 0
-		ro.executeWithRetry("op", "order-1", func() error {
+		ro.executeWithRetry(context.Background(), "op", "order-1", func() error {
 			attempts++
 			if attempts < 3 {
 				return domain.ErrInventoryTemporary
@@ -77,7 +78,7 @@ func TestRetryableOrchestratorExecuteWithRetry(t *testing.T) {
 
 	t.Run("non-retryable", func(t *testing.T) {
 		attempts := 0
-		ro.executeWithRetry("op", "order-2", func() error {
+		ro.executeWithRetry(context.Background(), "op", "order-2", func() error {
 			attempts++
 			return domain.ErrOrderNotFound
 		})
@@ -88,7 +89,7 @@ func TestRetryableOrchestratorExecuteWithRetry(t *testing.T) {
 
 	t.Run("exhausted retries", func(t *testing.T) {
 		attempts := 0
-		ro.executeWithRetry("op", "order-3", func() error {
+		ro.executeWithRetry(context.Background(), "op", "order-3", func() error {
 			attempts++
 			return errors.New("temporary")
 		})
@@ -96,6 +97,29 @@ func TestRetryableOrchestratorExecuteWithRetry(t *testing.T) {
 			t.Fatalf("expected %d attempts, got %d", cfg.MaxAttempts, attempts)
 		}
 	})
+
+	t.Run("canceled context stops backoff", func(t *testing.T) {
+		slow := NewRetryableOrchestrator(&stubOrchestrator{}, RetryConfig{MaxAttempts: 3, InitialDelay: time.Minute, MaxDelay: time.Minute, BackoffFactor: 2}, log.New().WithField("test", "retry"))
+		ctx, cancel := context.WithCancel(context.Background())
+		attempts := 0
+		done := make(chan struct{})
+		go func() {
+			defer close(done)
+			slow.executeWithRetry(ctx, "op", "order-4", func() error {
+				attempts++
+				return domain.ErrInventoryTemporary
+			})
+		}()
+		cancel()
+		select {
+		case <-done:
+		case <-time.After(5 * time.Second):
+			t.Fatal("executeWithRetry did not stop on context cancel")
+		}
+		if attempts != 1 {
+			t.Fatalf("expected single attempt before cancel, got %d", attempts)
+		}
+	})
 }
 
 func TestRetryableOrchestratorShouldRetry(t *testing.T) {
@@ -190,9 +214,9 @@ func TestCircuitBreakerOrchestrator(t *testing.T) {
 	cbo := NewCircuitBreakerOrchestrator(stub, breaker, logger)
 
 	// Closed breaker delegates calls.
-	cbo.Start("o-1")
-	cbo.Cancel("o-1", "reason")
-	cbo.Refund("o-1", 10, "refund")
+	cbo.Start(context.Background(), "o-1")
+	cbo.Cancel(context.Background(), "o-1", "reason")
+	cbo.Refund(context.Background(), "o-1", 10, "refund")
 	if stub.startCalls != 1 || stub.cancelCalls != 1 || stub.refundCalls != 1 {
 		t.Fatalf("unexpected delegate calls in closed state: %+v", stub)
 	}
@@ -200,9 +224,9 @@ func TestCircuitBreakerOrchestrator(t *testing.T) {
 	// Open breaker blocks calls before fn executes.
 	breaker.state = CircuitOpen
 	breaker.lastFailure = time.Now()
-	cbo.Start("o-2")
-	cbo.Cancel("o-2", "reason")
-	cbo.Refund("o-2", 10, "refund")
+	cbo.Start(context.Background(), "o-2")
+	cbo.Cancel(context.Background(), "o-2", "reason")
+	cbo.Refund(context.Background(), "o-2", 10, "refund")
 	if stub.startCalls != 1 || stub.cancelCalls != 1 || stub.refundCalls != 1 {
 		t.Fatalf("calls should be blocked in open state: %+v", stub)
 	}
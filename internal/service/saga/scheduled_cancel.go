@@ -0,0 +1,164 @@
+package saga
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+
+	"github.com/vladislavdragonenkov/oms/internal/domain"
+	"github.com/vladislavdragonenkov/oms/internal/timeutil"
+)
+
+const (
+	defaultScheduledCancelInterval  = 30 * time.Second
+	defaultScheduledCancelBatchSize = 100
+)
+
+// CancelScheduler выполняет отложенные отмены заказов, срок которых наступил.
+// Сага отмены запускается до того, как задача помечается executed: при падении процесса
+// между этими шагами задача выполнится повторно, а Cancel для уже отменённого заказа — no-op.
+type CancelScheduler struct {
+	tasks       domain.ScheduledCancelRepository
+	orders      domain.OrderRepository
+	saga        Orchestrator
+	logger      *log.Entry
+	interval    time.Duration
+	batchSize   int
+	sagaTimeout runtimeTimeout
+}
+
+// ScheduledCancelOption настраивает CancelScheduler.
+type ScheduledCancelOption func(*CancelScheduler)
+
+// WithScheduledCancelLogger задаёт logger.
+func WithScheduledCancelLogger(logger *log.Entry) ScheduledCancelOption {
+	return func(s *CancelScheduler) {
+		s.logger = logger
+	}
+}
+
+// WithScheduledCancelInterval задаёт период опроса задач.
+func WithScheduledCancelInterval(interval time.Duration) ScheduledCancelOption {
+	return func(s *CancelScheduler) {
+		s.interval = interval
+	}
+}
+
+// WithScheduledCancelBatchSize ограничивает число задач, выполняемых за один проход.
+func WithScheduledCancelBatchSize(batchSize int) ScheduledCancelOption {
+	return func(s *CancelScheduler) {
+		s.batchSize = batchSize
+	}
+}
+
+// WithScheduledCancelSagaTimeout задаёт дедлайн каждой саги отмены.
+func WithScheduledCancelSagaTimeout(timeout time.Duration) ScheduledCancelOption {
+	return func(s *CancelScheduler) {
+		s.sagaTimeout.set(timeout)
+	}
+}
+
+// NewCancelScheduler создаёт исполнитель отложенных отмен.
+func NewCancelScheduler(tasks domain.ScheduledCancelRepository, orders domain.OrderRepository, orchestrator Orchestrator, opts ...ScheduledCancelOption) *CancelScheduler {
+	s := &CancelScheduler{
+		tasks:  tasks,
+		orders: orders,
+		saga:   orchestrator,
+	}
+	for _, opt := range opts {
+		if opt != nil {
+			opt(s)
+		}
+	}
+	if s.logger == nil {
+		s.logger = log.WithField("component", "cancel-scheduler")
+	}
+	if s.interval <= 0 {
+		s.interval = defaultScheduledCancelInterval
+	}
+	if s.batchSize <= 0 {
+		s.batchSize = defaultScheduledCancelBatchSize
+	}
+	return s
+}
+
+// SetSagaTimeout меняет дедлайн саг отмены, запущенных после этого вызова.
+func (s *CancelScheduler) SetSagaTimeout(timeout time.Duration) {
+	s.sagaTimeout.set(timeout)
+}
+
+// Run выполняет наступившие отмены до отмены ctx.
+func (s *CancelScheduler) Run(ctx context.Context) {
+	ticker := time.NewTicker(s.interval)
+	defer ticker.Stop()
+
+	for {
+		if _, err := s.RunOnce(ctx); err != nil {
+			s.logger.WithError(err).Warn("scheduled cancel pass failed")
+		}
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// RunOnce выполняет задачи со сроком не позже текущего момента и возвращает число запущенных отмен.
+func (s *CancelScheduler) RunOnce(ctx context.Context) (int, error) {
+	due, err := s.tasks.ListDue(timeutil.Now(), s.batchSize)
+	if err != nil {
+		return 0, fmt.Errorf("list due scheduled cancels: %w", err)
+	}
+
+	executed := 0
+	for _, task := range due {
+		if ctx.Err() != nil {
+			return executed, ctx.Err()
+		}
+		if s.execute(ctx, task) {
+			executed++
+		}
+	}
+	return executed, nil
+}
+
+func (s *CancelScheduler) execute(ctx context.Context, task domain.ScheduledCancel) bool {
+	logger := s.logger.WithFields(log.Fields{"task_id": task.ID, "order_id": task.OrderID})
+
+	// Задачу могли снять после ListDue; повторное чтение сужает окно гонки с оператором.
+	current, err := s.tasks.Get(task.ID)
+	if err != nil || current.Status != domain.ScheduledCancelPending {
+		return false
+	}
+
+	order, err := s.orders.Get(task.OrderID)
+	switch {
+	case errors.Is(err, domain.ErrOrderNotFound):
+		s.finish(logger, task.ID, domain.ScheduledCancelSkipped, "order not found")
+		return false
+	case err != nil:
+		logger.WithError(err).Warn("failed to load order for scheduled cancel")
+		return false
+	case order.Status.SkipsScheduledCancel():
+		s.finish(logger, task.ID, domain.ScheduledCancelSkipped, "order already "+string(order.Status))
+		return false
+	}
+
+	sagaCtx, cancel := DetachedContext(ctx, s.sagaTimeout.get())
+	s.saga.Cancel(sagaCtx, task.OrderID, task.Reason)
+	cancel()
+
+	s.finish(logger, task.ID, domain.ScheduledCancelExecuted, "")
+	logger.WithField("cancel_at", timeutil.Format(task.CancelAt)).Info("scheduled cancel executed")
+	return true
+}
+
+func (s *CancelScheduler) finish(logger *log.Entry, id string, status domain.ScheduledCancelStatus, note string) {
+	if _, err := s.tasks.Finish(id, status, note); err != nil {
+		logger.WithError(err).WithField("status", status).Warn("failed to finish scheduled cancel")
+	}
+}
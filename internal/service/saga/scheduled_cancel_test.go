@@ -0,0 +1,69 @@
+package saga
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/vladislavdragonenkov/oms/internal/domain"
+	"github.com/vladislavdragonenkov/oms/internal/storage/memory"
+)
+
+func TestCancelScheduler_RunOnce(t *testing.T) {
+	orders := memory.NewOrderRepository()
+	tasks := memory.NewScheduledCancelRepository()
+	now := time.Now().UTC()
+	for id, status := range map[string]domain.OrderStatus{
+		"order-reserved":  domain.OrderStatusReserved,
+		"order-confirmed": domain.OrderStatusConfirmed,
+	} {
+		if err := orders.Create(domain.Order{ID: id, CustomerID: "c", Status: status, Currency: "USD", CreatedAt: now, UpdatedAt: now}); err != nil {
+			t.Fatalf("create order: %v", err)
+		}
+	}
+
+	schedule := func(orderID string, cancelAt time.Time) domain.ScheduledCancel {
+		t.Helper()
+		task, err := tasks.Create(domain.ScheduledCancel{OrderID: orderID, CancelAt: cancelAt, Reason: "not shipped in time"})
+		if err != nil {
+			t.Fatalf("schedule: %v", err)
+		}
+		return task
+	}
+	due := schedule("order-reserved", now.Add(-time.Minute))
+	future := schedule("order-reserved", now.Add(time.Hour))
+	shipped := schedule("order-confirmed", now.Add(-time.Minute))
+	missing := schedule("order-missing", now.Add(-time.Minute))
+
+	orch := &stubOrchestrator{}
+	executed, err := NewCancelScheduler(tasks, orders, orch).RunOnce(context.Background())
+	if err != nil {
+		t.Fatalf("run once: %v", err)
+	}
+	if executed != 1 || orch.cancelCalls != 1 {
+		t.Fatalf("expected one cancel, got executed=%d calls=%d", executed, orch.cancelCalls)
+	}
+
+	for _, tc := range []struct {
+		task   domain.ScheduledCancel
+		status domain.ScheduledCancelStatus
+		note   string
+	}{
+		{due, domain.ScheduledCancelExecuted, ""},
+		{future, domain.ScheduledCancelPending, ""},
+		{shipped, domain.ScheduledCancelSkipped, "order already confirmed"},
+		{missing, domain.ScheduledCancelSkipped, "order not found"},
+	} {
+		got, err := tasks.Get(tc.task.ID)
+		if err != nil {
+			t.Fatalf("get task: %v", err)
+		}
+		if got.Status != tc.status || got.Note != tc.note {
+			t.Fatalf("task for %s: expected %s %q, got %s %q", tc.task.OrderID, tc.status, tc.note, got.Status, got.Note)
+		}
+	}
+
+	if executed, _ = NewCancelScheduler(tasks, orders, orch).RunOnce(context.Background()); executed != 0 || orch.cancelCalls != 1 {
+		t.Fatalf("finished tasks must not run again, got executed=%d calls=%d", executed, orch.cancelCalls)
+	}
+}
@@ -0,0 +1,299 @@
+package slo
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	dto "github.com/prometheus/client_model/go"
+	log "github.com/sirupsen/logrus"
+
+	"github.com/vladislavdragonenkov/oms/internal/metrics"
+)
+
+const (
+	// DefaultInterval — как часто пересчитывается burn rate.
+	DefaultInterval = 30 * time.Second
+
+	grpcHandledMetric  = "grpc_server_handled_total"
+	grpcHandlingMetric = "grpc_server_handling_seconds"
+)
+
+// DefaultWindows — окна multi-window алертов из SRE Workbook: пары 1h/5m и 6h/30m.
+var DefaultWindows = []time.Duration{5 * time.Minute, 30 * time.Minute, time.Hour, 6 * time.Hour}
+
+// serverErrorCodes — коды, которые расходуют бюджет доступности. Ошибки клиента
+// (InvalidArgument, NotFound, FailedPrecondition и т.п.) на SLO не влияют.
+var serverErrorCodes = map[string]struct{}{
+	"Unknown":          {},
+	"DeadlineExceeded": {},
+	"Internal":         {},
+	"Unavailable":      {},
+	"DataLoss":         {},
+}
+
+// ExporterOptions задаёт параметры Exporter.
+type ExporterOptions struct {
+	Logger   *log.Entry
+	Interval time.Duration
+	Windows  []time.Duration
+	// Gatherer — откуда читаются счётчики gRPC; nil — глобальный реестр Prometheus.
+	Gatherer prometheus.Gatherer
+	// Registerer — куда регистрируется oms_slo_error_budget_burn.
+	Registerer prometheus.Registerer
+}
+
+// ExporterOption настраивает Exporter.
+type ExporterOption func(*ExporterOptions)
+
+// WithLogger задаёт logger.
+func WithLogger(logger *log.Entry) ExporterOption {
+	return func(opts *ExporterOptions) {
+		opts.Logger = logger
+	}
+}
+
+// WithInterval задаёт период пересчёта.
+func WithInterval(interval time.Duration) ExporterOption {
+	return func(opts *ExporterOptions) {
+		opts.Interval = interval
+	}
+}
+
+// WithWindows переопределяет окна, по которым считается burn rate.
+func WithWindows(windows ...time.Duration) ExporterOption {
+	return func(opts *ExporterOptions) {
+		opts.Windows = windows
+	}
+}
+
+// WithGatherer задаёт реестр, из которого читаются счётчики gRPC.
+func WithGatherer(gatherer prometheus.Gatherer) ExporterOption {
+	return func(opts *ExporterOptions) {
+		opts.Gatherer = gatherer
+	}
+}
+
+// WithRegisterer задаёт реестр для метрики burn rate.
+func WithRegisterer(registerer prometheus.Registerer) ExporterOption {
+	return func(opts *ExporterOptions) {
+		opts.Registerer = registerer
+	}
+}
+
+// counts — накопленные значения счётчиков одного SLO.
+type counts struct {
+	total float64
+	bad   float64
+}
+
+type sample struct {
+	at     time.Time
+	counts []counts
+}
+
+// Exporter периодически снимает счётчики gRPC и публикует burn rate бюджета ошибок
+// каждого SLO по каждому окну: oms_slo_error_budget_burn{slo, window}.
+// Значение 1 — бюджет расходуется ровно с той скоростью, при которой он кончится к концу периода SLO.
+type Exporter struct {
+	objectives []Objective
+	logger     *log.Entry
+	interval   time.Duration
+	windows    []time.Duration
+	gatherer   prometheus.Gatherer
+	burn       *prometheus.GaugeVec
+
+	samples []sample
+}
+
+// NewExporter создаёт экспортёр для заданных SLO.
+func NewExporter(objectives []Objective, options ...ExporterOption) *Exporter {
+	opts := ExporterOptions{
+		Interval: DefaultInterval,
+		Windows:  DefaultWindows,
+	}
+	for _, option := range options {
+		option(&opts)
+	}
+
+	logger := opts.Logger
+	if logger == nil {
+		logger = log.WithField("component", "slo-exporter")
+	}
+	if opts.Interval <= 0 {
+		opts.Interval = DefaultInterval
+	}
+	if len(opts.Windows) == 0 {
+		opts.Windows = DefaultWindows
+	}
+	gatherer := opts.Gatherer
+	if gatherer == nil {
+		gatherer = prometheus.DefaultGatherer
+	}
+
+	return &Exporter{
+		objectives: objectives,
+		logger:     logger,
+		interval:   opts.Interval,
+		windows:    opts.Windows,
+		gatherer:   gatherer,
+		burn: metrics.Register(opts.Registerer, prometheus.NewGaugeVec(prometheus.GaugeOpts{
+			Name: "oms_slo_error_budget_burn",
+			Help: "Error budget burn rate of an SLO over a sliding window (1 - budget spent exactly by the end of the SLO period).",
+		}, []string{"slo", "window"})),
+	}
+}
+
+// Run пересчитывает burn rate до отмены ctx. Первый снимок берётся сразу: окна, которые
+// ещё не накопили историю, считаются по тому, что есть с момента старта.
+func (e *Exporter) Run(ctx context.Context) {
+	if len(e.objectives) == 0 {
+		return
+	}
+	e.collect(time.Now())
+
+	ticker := time.NewTicker(e.interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			e.collect(time.Now())
+		}
+	}
+}
+
+func (e *Exporter) collect(now time.Time) {
+	families, err := e.gatherer.Gather()
+	if err != nil {
+		// Gather возвращает всё, что удалось собрать; ошибка одного collector'а не повод пропускать SLO.
+		e.logger.WithError(err).Warn("gather metrics for slo")
+	}
+	e.observe(now, families)
+}
+
+// observe добавляет снимок счётчиков и обновляет gauge по каждому окну.
+func (e *Exporter) observe(now time.Time, families []*dto.MetricFamily) {
+	current := sample{at: now, counts: make([]counts, len(e.objectives))}
+	for i, objective := range e.objectives {
+		current.counts[i] = objectiveCounts(objective, families)
+	}
+	e.samples = append(e.samples, current)
+	e.trim(now)
+
+	for _, window := range e.windows {
+		base := e.baseline(now.Add(-window))
+		for i, objective := range e.objectives {
+			total := current.counts[i].total - base.counts[i].total
+			bad := current.counts[i].bad - base.counts[i].bad
+			burn := 0.0
+			// Отрицательная дельта — счётчики обнулились (метрики пересоздали), окно пропускаем.
+			if total > 0 && bad >= 0 {
+				burn = bad / total / objective.ErrorBudget()
+			}
+			e.burn.WithLabelValues(objective.Name, formatWindow(window)).Set(burn)
+		}
+	}
+}
+
+// baseline — последний снимок не позже since; если истории меньше окна — самый старый.
+func (e *Exporter) baseline(since time.Time) sample {
+	base := e.samples[0]
+	for _, s := range e.samples {
+		if s.at.After(since) {
+			break
+		}
+		base = s
+	}
+	return base
+}
+
+// trim выбрасывает снимки, которые уже не нужны как базовые ни одному окну.
+func (e *Exporter) trim(now time.Time) {
+	var longest time.Duration
+	for _, window := range e.windows {
+		longest = max(longest, window)
+	}
+	since := now.Add(-longest)
+	keepFrom := 0
+	for i := 1; i < len(e.samples) && !e.samples[i].at.After(since); i++ {
+		keepFrom = i
+	}
+	e.samples = e.samples[keepFrom:]
+}
+
+func objectiveCounts(objective Objective, families []*dto.MetricFamily) counts {
+	var result counts
+	switch objective.Kind {
+	case KindAvailability:
+		for _, metric := range findFamily(families, grpcHandledMetric) {
+			labels := labelMap(metric)
+			if !matchesMethod(objective.Method, labels) {
+				continue
+			}
+			value := metric.GetCounter().GetValue()
+			result.total += value
+			if _, ok := serverErrorCodes[labels["grpc_code"]]; ok {
+				result.bad += value
+			}
+		}
+	case KindLatency:
+		threshold := objective.Threshold.Seconds()
+		for _, metric := range findFamily(families, grpcHandlingMetric) {
+			labels := labelMap(metric)
+			// Стримы живут сколько нужно клиенту и в latency-SLO не учитываются.
+			if labels["grpc_type"] != "unary" || !matchesMethod(objective.Method, labels) {
+				continue
+			}
+			histogram := metric.GetHistogram()
+			total := float64(histogram.GetSampleCount())
+			fast := total
+			for _, bucket := range histogram.GetBucket() {
+				if math.Abs(bucket.GetUpperBound()-threshold) < 1e-9 {
+					fast = float64(bucket.GetCumulativeCount())
+					break
+				}
+			}
+			result.total += total
+			result.bad += total - fast
+		}
+	}
+	return result
+}
+
+func findFamily(families []*dto.MetricFamily, name string) []*dto.Metric {
+	for _, family := range families {
+		if family.GetName() == name {
+			return family.GetMetric()
+		}
+	}
+	return nil
+}
+
+func labelMap(metric *dto.Metric) map[string]string {
+	labels := make(map[string]string, len(metric.GetLabel()))
+	for _, pair := range metric.GetLabel() {
+		labels[pair.GetName()] = pair.GetValue()
+	}
+	return labels
+}
+
+func matchesMethod(method string, labels map[string]string) bool {
+	return method == "" || method == "/"+labels["grpc_service"]+"/"+labels["grpc_method"]
+}
+
+// formatWindow записывает окно так же, как в PromQL: 5m, 1h, 6h.
+func formatWindow(window time.Duration) string {
+	switch {
+	case window%time.Hour == 0:
+		return fmt.Sprintf("%dh", window/time.Hour)
+	case window%time.Minute == 0:
+		return fmt.Sprintf("%dm", window/time.Minute)
+	default:
+		return fmt.Sprintf("%ds", window/time.Second)
+	}
+}
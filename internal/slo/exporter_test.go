@@ -0,0 +1,177 @@
+package slo
+
+import (
+	"errors"
+	"math"
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+)
+
+type grpcCounters struct {
+	registry *prometheus.Registry
+	handled  *prometheus.CounterVec
+	handling *prometheus.HistogramVec
+}
+
+// newGRPCCounters повторяет имена и метки метрик go-grpc-prometheus.
+func newGRPCCounters(buckets []float64) grpcCounters {
+	registry := prometheus.NewRegistry()
+	c := grpcCounters{
+		registry: registry,
+		handled: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Name: grpcHandledMetric,
+		}, []string{"grpc_type", "grpc_service", "grpc_method", "grpc_code"}),
+		handling: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Name:    grpcHandlingMetric,
+			Buckets: buckets,
+		}, []string{"grpc_type", "grpc_service", "grpc_method"}),
+	}
+	registry.MustRegister(c.handled, c.handling)
+	return c
+}
+
+func (c grpcCounters) handle(method, code string, n int) {
+	c.handled.WithLabelValues("unary", "oms.v1.OrderService", method, code).Add(float64(n))
+}
+
+func (c grpcCounters) observe(method string, duration time.Duration, n int) {
+	for i := 0; i < n; i++ {
+		c.handling.WithLabelValues("unary", "oms.v1.OrderService", method).Observe(duration.Seconds())
+	}
+}
+
+func (c grpcCounters) exporter(objectives []Objective, windows ...time.Duration) *Exporter {
+	return NewExporter(objectives, WithGatherer(c.registry), WithRegisterer(c.registry), WithWindows(windows...))
+}
+
+func (c grpcCounters) collect(t *testing.T, e *Exporter, now time.Time) {
+	t.Helper()
+	families, err := c.registry.Gather()
+	if err != nil {
+		t.Fatalf("gather: %v", err)
+	}
+	e.observe(now, families)
+}
+
+func assertBurn(t *testing.T, e *Exporter, slo, window string, want float64) {
+	t.Helper()
+	if got := testutil.ToFloat64(e.burn.WithLabelValues(slo, window)); math.Abs(got-want) > 1e-6 {
+		t.Fatalf("burn %s/%s: expected %v, got %v", slo, window, want, got)
+	}
+}
+
+func TestParseObjectives(t *testing.T) {
+	objectives, err := ParseObjectives("api:kind=availability,target=0.999; create:kind=latency,method=/oms.v1.OrderService/CreateOrder,threshold=300ms,target=0.99")
+	if err != nil {
+		t.Fatalf("parse: %v", err)
+	}
+	if len(objectives) != 2 {
+		t.Fatalf("expected 2 objectives, got %d", len(objectives))
+	}
+	create := objectives[1]
+	if create.Kind != KindLatency || create.Threshold != 300*time.Millisecond || create.Method != "/oms.v1.OrderService/CreateOrder" {
+		t.Fatalf("unexpected latency objective: %+v", create)
+	}
+
+	for _, raw := range []string{
+		"api:kind=availability",
+		"api:kind=availability,target=1",
+		"api:kind=latency,target=0.99",
+		"api:kind=availability,target=0.99,threshold=1s",
+		"api:kind=availability,target=0.99,method=CreateOrder",
+		"api:kind=errors,target=0.99",
+		"api:kind=availability,target=0.99;api:kind=availability,target=0.9",
+		"kind=availability,target=0.99",
+	} {
+		if _, err := ParseObjectives(raw); !errors.Is(err, ErrInvalidObjective) {
+			t.Fatalf("expected ErrInvalidObjective for %q, got %v", raw, err)
+		}
+	}
+}
+
+func TestHistogramBuckets_IncludeLatencyThresholds(t *testing.T) {
+	buckets := HistogramBuckets([]Objective{
+		{Kind: KindLatency, Threshold: 300 * time.Millisecond},
+		{Kind: KindLatency, Threshold: 500 * time.Millisecond},
+	})
+	var found bool
+	for i, bucket := range buckets {
+		if i > 0 && bucket <= buckets[i-1] {
+			t.Fatalf("buckets must be strictly increasing: %v", buckets)
+		}
+		found = found || bucket == 0.3
+	}
+	if !found || len(buckets) != len(prometheus.DefBuckets)+1 {
+		t.Fatalf("expected default buckets plus 0.3, got %v", buckets)
+	}
+}
+
+func TestExporter_AvailabilityBurnPerWindow(t *testing.T) {
+	counters := newGRPCCounters(prometheus.DefBuckets)
+	e := counters.exporter([]Objective{{Name: "api", Kind: KindAvailability, Target: 0.99}}, 5*time.Minute, time.Hour)
+	start := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+
+	counters.handle("GetOrder", "OK", 1000)
+	counters.collect(t, e, start)
+
+	// За первые 30 минут 1% серверных ошибок: ровно бюджет. Ошибки клиента не считаются.
+	counters.handle("GetOrder", "OK", 990)
+	counters.handle("GetOrder", "Internal", 10)
+	counters.handle("GetOrder", "NotFound", 50)
+	counters.collect(t, e, start.Add(30*time.Minute))
+
+	// Следующие 5 минут — 10% ошибок.
+	counters.handle("GetOrder", "OK", 90)
+	counters.handle("GetOrder", "Unavailable", 10)
+	counters.collect(t, e, start.Add(35*time.Minute))
+
+	assertBurn(t, e, "api", "5m", 10)
+	// Час ещё не накоплен: окно считается от первого снимка, 20 ошибок на 1150 запросов.
+	assertBurn(t, e, "api", "1h", 20.0/1150/0.01)
+}
+
+func TestExporter_LatencyBurnUsesThresholdBucket(t *testing.T) {
+	objectives := []Objective{{Name: "create", Kind: KindLatency, Method: "/oms.v1.OrderService/CreateOrder", Target: 0.9, Threshold: 300 * time.Millisecond}}
+	counters := newGRPCCounters(HistogramBuckets(objectives))
+	e := counters.exporter(objectives, 5*time.Minute)
+	start := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+
+	counters.collect(t, e, start)
+	counters.observe("CreateOrder", 100*time.Millisecond, 60)
+	counters.observe("CreateOrder", 400*time.Millisecond, 40)
+	counters.observe("GetOrder", 2*time.Second, 100)
+	counters.collect(t, e, start.Add(time.Minute))
+
+	assertBurn(t, e, "create", "5m", 0.4/0.1)
+}
+
+func TestExporter_TrimKeepsBaselineForLongestWindow(t *testing.T) {
+	counters := newGRPCCounters(prometheus.DefBuckets)
+	e := counters.exporter([]Objective{{Name: "api", Kind: KindAvailability, Target: 0.99}}, 5*time.Minute)
+	start := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+
+	for i := 0; i <= 20; i++ {
+		counters.handle("GetOrder", "OK", 100)
+		counters.collect(t, e, start.Add(time.Duration(i)*time.Minute))
+	}
+	if len(e.samples) != 6 || !e.samples[0].at.Equal(start.Add(15*time.Minute)) {
+		t.Fatalf("expected samples from 12:15 to 12:20, got %d starting at %v", len(e.samples), e.samples[0].at)
+	}
+	assertBurn(t, e, "api", "5m", 0)
+}
+
+func TestFormatWindow(t *testing.T) {
+	for window, want := range map[time.Duration]string{
+		5 * time.Minute:  "5m",
+		time.Hour:        "1h",
+		6 * time.Hour:    "6h",
+		90 * time.Second: "90s",
+	} {
+		if got := formatWindow(window); got != want {
+			t.Fatalf("formatWindow(%v): expected %s, got %s", window, want, got)
+		}
+	}
+}
@@ -0,0 +1,165 @@
+// Package slo считает burn rate бюджета ошибок SLO по счётчикам gRPC из реестра Prometheus,
+// чтобы правила алертинга сравнивали готовую метрику с порогом, а не повторяли формулы.
+package slo
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// ErrInvalidObjective — SLO заданы некорректно.
+var ErrInvalidObjective = errors.New("slo: invalid objective")
+
+// Kind — что измеряет SLO.
+type Kind string
+
+const (
+	// KindAvailability — доля RPC без серверной ошибки.
+	KindAvailability Kind = "availability"
+	// KindLatency — доля unary RPC, обработанных не дольше порога.
+	KindLatency Kind = "latency"
+)
+
+// Objective — одно SLO.
+type Objective struct {
+	Name string
+	Kind Kind
+	// Method — полное имя RPC ("/oms.v1.OrderService/CreateOrder"); пусто — все RPC сервиса.
+	Method string
+	// Target — целевая доля хороших запросов, например 0.999.
+	Target float64
+	// Threshold — порог длительности для KindLatency.
+	Threshold time.Duration
+}
+
+// ErrorBudget — допустимая доля плохих запросов.
+func (o Objective) ErrorBudget() float64 {
+	return 1 - o.Target
+}
+
+// ParseObjectives разбирает SLO в формате
+// "api:kind=availability,target=0.999;create-p99:kind=latency,method=/oms.v1.OrderService/CreateOrder,threshold=300ms,target=0.99".
+// Пустая строка — SLO не заданы.
+func ParseObjectives(raw string) ([]Objective, error) {
+	var objectives []Objective
+	seen := make(map[string]struct{})
+	for _, entry := range strings.Split(raw, ";") {
+		entry = strings.TrimSpace(entry)
+		if entry == "" {
+			continue
+		}
+		name, params, found := strings.Cut(entry, ":")
+		name = strings.TrimSpace(name)
+		if !found || name == "" {
+			return nil, fmt.Errorf("%w: expected name:key=value, got %q", ErrInvalidObjective, entry)
+		}
+		if _, ok := seen[name]; ok {
+			return nil, fmt.Errorf("%w: duplicate objective %q", ErrInvalidObjective, name)
+		}
+		seen[name] = struct{}{}
+
+		objective := Objective{Name: name}
+		for _, part := range strings.Split(params, ",") {
+			part = strings.TrimSpace(part)
+			if part == "" {
+				continue
+			}
+			key, value, found := strings.Cut(part, "=")
+			if !found {
+				return nil, fmt.Errorf("%w: objective %s: expected key=value, got %q", ErrInvalidObjective, name, part)
+			}
+			if err := setObjectiveParam(&objective, strings.ToLower(strings.TrimSpace(key)), strings.TrimSpace(value)); err != nil {
+				return nil, fmt.Errorf("%w: objective %s: %v", ErrInvalidObjective, name, err)
+			}
+		}
+		if err := objective.validate(); err != nil {
+			return nil, fmt.Errorf("%w: objective %s: %v", ErrInvalidObjective, name, err)
+		}
+		objectives = append(objectives, objective)
+	}
+	return objectives, nil
+}
+
+func setObjectiveParam(objective *Objective, key, value string) error {
+	switch key {
+	case "kind":
+		objective.Kind = Kind(strings.ToLower(value))
+	case "method":
+		objective.Method = value
+	case "target":
+		target, err := strconv.ParseFloat(value, 64)
+		if err != nil {
+			return fmt.Errorf("target must be a number, got %q", value)
+		}
+		objective.Target = target
+	case "threshold":
+		threshold, err := time.ParseDuration(value)
+		if err != nil {
+			return fmt.Errorf("threshold must be a duration, got %q", value)
+		}
+		objective.Threshold = threshold
+	default:
+		return fmt.Errorf("unknown key %q", key)
+	}
+	return nil
+}
+
+func (o Objective) validate() error {
+	switch o.Kind {
+	case KindAvailability:
+		if o.Threshold != 0 {
+			return errors.New("threshold applies only to latency objectives")
+		}
+	case KindLatency:
+		if o.Threshold <= 0 {
+			return errors.New("latency objective requires threshold > 0")
+		}
+	default:
+		return fmt.Errorf("kind must be %s or %s, got %q", KindAvailability, KindLatency, o.Kind)
+	}
+	if o.Target <= 0 || o.Target >= 1 {
+		return fmt.Errorf("target must be in (0, 1), got %v", o.Target)
+	}
+	if o.Method != "" {
+		service, method, found := strings.Cut(strings.TrimPrefix(o.Method, "/"), "/")
+		if !strings.HasPrefix(o.Method, "/") || !found || service == "" || method == "" {
+			return fmt.Errorf("method must look like /package.Service/Method, got %q", o.Method)
+		}
+	}
+	return nil
+}
+
+// HistogramBuckets возвращает границы гистограммы длительности gRPC: стандартные плюс пороги
+// latency-SLO. Доля быстрых запросов берётся из бакета, поэтому порог обязан быть его границей.
+func HistogramBuckets(objectives []Objective) []float64 {
+	buckets := append([]float64(nil), prometheus.DefBuckets...)
+	for _, objective := range objectives {
+		if objective.Kind == KindLatency {
+			buckets = append(buckets, objective.Threshold.Seconds())
+		}
+	}
+	sort.Float64s(buckets)
+	unique := buckets[:0]
+	for i, bucket := range buckets {
+		if i == 0 || bucket != buckets[i-1] {
+			unique = append(unique, bucket)
+		}
+	}
+	return unique
+}
+
+// HasLatency сообщает, есть ли среди SLO latency-цели (им нужна гистограмма длительности gRPC).
+func HasLatency(objectives []Objective) bool {
+	for _, objective := range objectives {
+		if objective.Kind == KindLatency {
+			return true
+		}
+	}
+	return false
+}
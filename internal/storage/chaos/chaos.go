@@ -0,0 +1,165 @@
+// Package chaos — внедрение отказов в хранилище для проверки устойчивости саги: обёртки
+// репозиториев с заданной вероятностью возвращают ошибку или конфликт версии и добавляют
+// задержку. Включается только вне production (см. OMS_STORAGE_CHAOS).
+package chaos
+
+import (
+	"errors"
+	"fmt"
+	"math/rand/v2"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+
+	"github.com/vladislavdragonenkov/oms/internal/domain"
+	"github.com/vladislavdragonenkov/oms/internal/metrics"
+)
+
+var (
+	// ErrInjected — отказ, внедрённый обёрткой; внедрённый конфликт версии дополнительно
+	// оборачивает domain.ErrOrderVersionConflict.
+	ErrInjected = errors.New("storage chaos: injected fault")
+	// ErrInvalidRules возвращается ParseRules для некорректной спецификации.
+	ErrInvalidRules = errors.New("invalid storage chaos rules")
+)
+
+// anyOperation — правило по умолчанию для операций без собственного правила.
+const anyOperation = "*"
+
+// Значения метки fault.
+const (
+	faultError    = "error"
+	faultConflict = "conflict"
+	faultLatency  = "latency"
+)
+
+// Rule — отказы одной операции. Операции называются так же, как в метриках зависимости db
+// (order_save, outbox_enqueue, ...).
+type Rule struct {
+	// ErrorRate — доля вызовов, завершающихся ErrInjected без обращения к хранилищу.
+	ErrorRate float64
+	// ConflictRate — доля вызовов, получающих конфликт версии; действует только для операций,
+	// которые сверяют версию заказа (order_save, order_update_status).
+	ConflictRate float64
+	// Latency — задержка перед каждым вызовом.
+	Latency time.Duration
+}
+
+// Rules — правила по операциям; ключ "*" применяется к операциям без своего правила
+// (правило операции заменяет "*" целиком, а не дополняет).
+type Rules map[string]Rule
+
+// ParseRules разбирает правила в формате "*:latency=20ms;order_save:error=0.05,conflict=0.1".
+// Пустая строка — без правил.
+func ParseRules(raw string) (Rules, error) {
+	rules := make(Rules)
+	for _, entry := range strings.Split(raw, ";") {
+		entry = strings.TrimSpace(entry)
+		if entry == "" {
+			continue
+		}
+		operation, params, found := strings.Cut(entry, ":")
+		operation = strings.TrimSpace(operation)
+		if !found || operation == "" {
+			return nil, fmt.Errorf("%w: expected operation:key=value, got %q", ErrInvalidRules, entry)
+		}
+		if _, ok := rules[operation]; ok {
+			return nil, fmt.Errorf("%w: duplicate rule for operation %q", ErrInvalidRules, operation)
+		}
+
+		var rule Rule
+		for _, part := range strings.Split(params, ",") {
+			part = strings.TrimSpace(part)
+			if part == "" {
+				continue
+			}
+			key, value, found := strings.Cut(part, "=")
+			if !found {
+				return nil, fmt.Errorf("%w: operation %s: expected key=value, got %q", ErrInvalidRules, operation, part)
+			}
+			if err := setRule(&rule, strings.ToLower(strings.TrimSpace(key)), strings.TrimSpace(value)); err != nil {
+				return nil, fmt.Errorf("%w: operation %s: %v", ErrInvalidRules, operation, err)
+			}
+		}
+		if rule == (Rule{}) {
+			return nil, fmt.Errorf("%w: operation %s: no faults set", ErrInvalidRules, operation)
+		}
+		rules[operation] = rule
+	}
+	return rules, nil
+}
+
+func setRule(rule *Rule, key, value string) error {
+	switch key {
+	case "error", "conflict":
+		rate, err := strconv.ParseFloat(value, 64)
+		if err != nil || rate < 0 || rate > 1 {
+			return fmt.Errorf("%s must be a rate in [0, 1], got %q", key, value)
+		}
+		if key == "error" {
+			rule.ErrorRate = rate
+		} else {
+			rule.ConflictRate = rate
+		}
+	case "latency":
+		latency, err := time.ParseDuration(value)
+		if err != nil || latency < 0 {
+			return fmt.Errorf("latency must be a non-negative duration, got %q", value)
+		}
+		rule.Latency = latency
+	default:
+		return fmt.Errorf("unknown key %q (expected error, conflict, latency)", key)
+	}
+	return nil
+}
+
+// Injector решает, какой отказ получит вызов, и считает внедрённые отказы в
+// oms_storage_chaos_faults_total.
+type Injector struct {
+	rules  Rules
+	faults *prometheus.CounterVec
+	// random и sleep подменяются в тестах.
+	random func() float64
+	sleep  func(time.Duration)
+}
+
+// NewInjector создаёт Injector; nil registerer — prometheus.DefaultRegisterer.
+func NewInjector(rules Rules, registerer prometheus.Registerer) *Injector {
+	return &Injector{
+		rules: rules,
+		faults: metrics.Register(registerer, prometheus.NewCounterVec(prometheus.CounterOpts{
+			Name: "oms_storage_chaos_faults_total",
+			Help: "Total number of storage faults injected by the chaos decorator grouped by operation and fault.",
+		}, []string{"operation", "fault"})),
+		random: rand.Float64,
+		sleep:  time.Sleep,
+	}
+}
+
+// Do выполняет fn, если правило операции не внедрило отказ. versioned — операция сверяет
+// версию заказа и может получить внедрённый конфликт.
+func (i *Injector) Do(operation string, versioned bool, fn func() error) error {
+	rule, ok := i.rules[operation]
+	if !ok {
+		rule, ok = i.rules[anyOperation]
+	}
+	if !ok {
+		return fn()
+	}
+
+	if rule.Latency > 0 {
+		i.faults.WithLabelValues(operation, faultLatency).Inc()
+		i.sleep(rule.Latency)
+	}
+	if rule.ErrorRate > 0 && i.random() < rule.ErrorRate {
+		i.faults.WithLabelValues(operation, faultError).Inc()
+		return fmt.Errorf("%s: %w", operation, ErrInjected)
+	}
+	if versioned && rule.ConflictRate > 0 && i.random() < rule.ConflictRate {
+		i.faults.WithLabelValues(operation, faultConflict).Inc()
+		return fmt.Errorf("%s: %w: %w", operation, domain.ErrOrderVersionConflict, ErrInjected)
+	}
+	return fn()
+}
@@ -0,0 +1,98 @@
+package chaos
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+
+	"github.com/vladislavdragonenkov/oms/internal/domain"
+	"github.com/vladislavdragonenkov/oms/internal/metrics/metricstest"
+	"github.com/vladislavdragonenkov/oms/internal/storage/memory"
+)
+
+func TestParseRules(t *testing.T) {
+	rules, err := ParseRules(" *:latency=20ms ; order_save:error=0.05,conflict=0.1 ;")
+	if err != nil {
+		t.Fatalf("parse: %v", err)
+	}
+	if rules[anyOperation] != (Rule{Latency: 20 * time.Millisecond}) || rules["order_save"] != (Rule{ErrorRate: 0.05, ConflictRate: 0.1}) {
+		t.Fatalf("unexpected rules %+v", rules)
+	}
+	if rules, err := ParseRules(""); err != nil || len(rules) != 0 {
+		t.Fatalf("expected empty rules, got %v (%v)", rules, err)
+	}
+
+	for _, raw := range []string{
+		"order_save",
+		":error=0.1",
+		"order_save:error=1.5",
+		"order_save:latency=-1s",
+		"order_save:timeout=1s",
+		"order_save:error=0",
+		"order_save:error=0.1;order_save:conflict=0.1",
+	} {
+		if _, err := ParseRules(raw); !errors.Is(err, ErrInvalidRules) {
+			t.Fatalf("%q: expected ErrInvalidRules, got %v", raw, err)
+		}
+	}
+}
+
+func newTestInjector(t *testing.T, raw string, random float64) (*Injector, *prometheus.Registry, *[]time.Duration) {
+	t.Helper()
+	rules, err := ParseRules(raw)
+	if err != nil {
+		t.Fatalf("parse: %v", err)
+	}
+	registry := prometheus.NewRegistry()
+	injector := NewInjector(rules, registry)
+	injector.random = func() float64 { return random }
+	var slept []time.Duration
+	injector.sleep = func(d time.Duration) { slept = append(slept, d) }
+	return injector, registry, &slept
+}
+
+func TestOrders_InjectsFaults(t *testing.T) {
+	injector, registry, slept := newTestInjector(t, "*:latency=5ms;order_create:error=0.5;order_save:conflict=0.5", 0.1)
+	repo := Orders(memory.NewOrderRepository(), injector)
+	order := domain.Order{ID: "order-1", CustomerID: "c-1", Status: domain.OrderStatusPending, Currency: "USD"}
+
+	if err := repo.Create(order); !errors.Is(err, ErrInjected) || errors.Is(err, domain.ErrOrderVersionConflict) {
+		t.Fatalf("expected injected error, got %v", err)
+	}
+	if _, err := repo.Get(order.ID); !errors.Is(err, domain.ErrOrderNotFound) {
+		t.Fatalf("injected create must not reach the repository, got %v", err)
+	}
+	if err := repo.Save(order); !errors.Is(err, domain.ErrOrderVersionConflict) || !errors.Is(err, ErrInjected) {
+		t.Fatalf("expected injected version conflict, got %v", err)
+	}
+	// Своё правило операции заменяет "*": задержка досталась только Get.
+	if len(*slept) != 1 || (*slept)[0] != 5*time.Millisecond {
+		t.Fatalf("expected default latency for get only, got %v", *slept)
+	}
+
+	metricstest.RequireValue(t, registry, "oms_storage_chaos_faults_total", metricstest.Labels{"operation": "order_create", "fault": "error"}, 1)
+	metricstest.RequireValue(t, registry, "oms_storage_chaos_faults_total", metricstest.Labels{"operation": "order_save", "fault": "conflict"}, 1)
+	metricstest.RequireValue(t, registry, "oms_storage_chaos_faults_total", metricstest.Labels{"operation": "order_get", "fault": "latency"}, 1)
+}
+
+func TestInjector_PassesThrough(t *testing.T) {
+	// Выпавшее значение выше вероятности: вызов доходит до хранилища.
+	injector, registry, _ := newTestInjector(t, "order_create:error=0.5;timeline_append:conflict=1", 0.9)
+	orders := Orders(memory.NewOrderRepository(), injector)
+	if err := orders.Create(domain.Order{ID: "order-1", CustomerID: "c-1", Status: domain.OrderStatusPending, Currency: "USD"}); err != nil {
+		t.Fatalf("create: %v", err)
+	}
+
+	// Конфликт версии не внедряется в операции, которые версию не сверяют.
+	injector.random = func() float64 { return 0 }
+	timeline := Timeline(memory.NewTimelineRepository(), injector)
+	if err := timeline.Append(domain.TimelineEvent{OrderID: "order-1", Type: "created", Occurred: time.Now()}); err != nil {
+		t.Fatalf("append: %v", err)
+	}
+	if _, err := Outbox(memory.NewOutboxRepository(), injector).Stats(); err != nil {
+		t.Fatalf("operation without rule must pass through: %v", err)
+	}
+	metricstest.RequireAbsent(t, registry, "oms_storage_chaos_faults_total", nil)
+}
@@ -0,0 +1,146 @@
+package chaos
+
+import (
+	"time"
+
+	"github.com/vladislavdragonenkov/oms/internal/domain"
+)
+
+type faultyOrders struct {
+	next     domain.OrderRepository
+	injector *Injector
+}
+
+// Orders оборачивает репозиторий заказов отказами injector. Как и обёртки метрик зависимости db,
+// расширения репозитория (OrderScanner, OrderStreamer и др.) не пробрасываются.
+func Orders(repo domain.OrderRepository, injector *Injector) domain.OrderRepository {
+	return &faultyOrders{next: repo, injector: injector}
+}
+
+func (r *faultyOrders) Create(order domain.Order) error {
+	return r.injector.Do("order_create", false, func() error { return r.next.Create(order) })
+}
+
+func (r *faultyOrders) Get(id string) (order domain.Order, err error) {
+	err = r.injector.Do("order_get", false, func() error {
+		order, err = r.next.Get(id)
+		return err
+	})
+	return order, err
+}
+
+func (r *faultyOrders) ListByCustomer(customerID string, limit int) (orders []domain.Order, err error) {
+	err = r.injector.Do("order_list_by_customer", false, func() error {
+		orders, err = r.next.ListByCustomer(customerID, limit)
+		return err
+	})
+	return orders, err
+}
+
+func (r *faultyOrders) ListByStatus(status domain.OrderStatus, limit int) (orders []domain.Order, err error) {
+	err = r.injector.Do("order_list_by_status", false, func() error {
+		orders, err = r.next.ListByStatus(status, limit)
+		return err
+	})
+	return orders, err
+}
+
+func (r *faultyOrders) Save(order domain.Order) error {
+	return r.injector.Do("order_save", true, func() error { return r.next.Save(order) })
+}
+
+func (r *faultyOrders) UpdateStatusCAS(orderID string, from, to domain.OrderStatus, expectedVersion int64) (version int64, err error) {
+	err = r.injector.Do("order_update_status", true, func() error {
+		version, err = r.next.UpdateStatusCAS(orderID, from, to, expectedVersion)
+		return err
+	})
+	return version, err
+}
+
+func (r *faultyOrders) Delete(id string) error {
+	return r.injector.Do("order_delete", false, func() error { return r.next.Delete(id) })
+}
+
+type faultyOutbox struct {
+	next     domain.OutboxRepository
+	injector *Injector
+}
+
+// Outbox оборачивает outbox отказами injector.
+func Outbox(repo domain.OutboxRepository, injector *Injector) domain.OutboxRepository {
+	return &faultyOutbox{next: repo, injector: injector}
+}
+
+func (r *faultyOutbox) Enqueue(msg domain.OutboxMessage) (stored domain.OutboxMessage, err error) {
+	err = r.injector.Do("outbox_enqueue", false, func() error {
+		stored, err = r.next.Enqueue(msg)
+		return err
+	})
+	return stored, err
+}
+
+func (r *faultyOutbox) EnqueueBatch(msgs []domain.OutboxMessage) (stored []domain.OutboxMessage, err error) {
+	err = r.injector.Do("outbox_enqueue_batch", false, func() error {
+		stored, err = r.next.EnqueueBatch(msgs)
+		return err
+	})
+	return stored, err
+}
+
+func (r *faultyOutbox) PullPending(limit int) (msgs []domain.OutboxMessage, err error) {
+	err = r.injector.Do("outbox_pull_pending", false, func() error {
+		msgs, err = r.next.PullPending(limit)
+		return err
+	})
+	return msgs, err
+}
+
+func (r *faultyOutbox) Stats() (stats domain.OutboxStats, err error) {
+	err = r.injector.Do("outbox_stats", false, func() error {
+		stats, err = r.next.Stats()
+		return err
+	})
+	return stats, err
+}
+
+func (r *faultyOutbox) MarkSent(id string) error {
+	return r.injector.Do("outbox_mark_sent", false, func() error { return r.next.MarkSent(id) })
+}
+
+func (r *faultyOutbox) MarkFailed(id string) error {
+	return r.injector.Do("outbox_mark_failed", false, func() error { return r.next.MarkFailed(id) })
+}
+
+func (r *faultyOutbox) DeleteSent(before time.Time, limit int) (deleted int, err error) {
+	err = r.injector.Do("outbox_delete_sent", false, func() error {
+		deleted, err = r.next.DeleteSent(before, limit)
+		return err
+	})
+	return deleted, err
+}
+
+type faultyTimeline struct {
+	next     domain.TimelineRepository
+	injector *Injector
+}
+
+// Timeline оборачивает timeline заказов отказами injector.
+func Timeline(repo domain.TimelineRepository, injector *Injector) domain.TimelineRepository {
+	return &faultyTimeline{next: repo, injector: injector}
+}
+
+func (r *faultyTimeline) Append(event domain.TimelineEvent) error {
+	return r.injector.Do("timeline_append", false, func() error { return r.next.Append(event) })
+}
+
+func (r *faultyTimeline) List(orderID string) (events []domain.TimelineEvent, err error) {
+	err = r.injector.Do("timeline_list", false, func() error {
+		events, err = r.next.List(orderID)
+		return err
+	})
+	return events, err
+}
+
+func (r *faultyTimeline) DeleteByOrder(orderID string) error {
+	return r.injector.Do("timeline_delete", false, func() error { return r.next.DeleteByOrder(orderID) })
+}
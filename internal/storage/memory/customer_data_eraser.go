@@ -0,0 +1,155 @@
+package memory
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/vladislavdragonenkov/oms/internal/domain"
+)
+
+// customerDataEraser обезличивает данные клиента в in-memory репозиториях этого пакета.
+type customerDataEraser struct {
+	orders   *orderRepositoryInMemory
+	timeline *timelineRepositoryInMemory
+	idem     *idempotencyRepositoryInMemory
+	outbox   *outboxRepositoryInMemory
+}
+
+// NewCustomerDataEraser создаёт in-memory CustomerDataEraser. Репозитории должны быть созданы этим пакетом;
+// timeline, idem и outbox можно не передавать (nil), тогда соответствующие данные не затрагиваются.
+func NewCustomerDataEraser(
+	orders domain.OrderRepository,
+	timeline domain.TimelineRepository,
+	idem domain.IdempotencyRepository,
+	outbox domain.OutboxRepository,
+) (domain.CustomerDataEraser, error) {
+	eraser := &customerDataEraser{}
+
+	orderRepo, ok := orders.(*orderRepositoryInMemory)
+	if !ok {
+		return nil, fmt.Errorf("memory customer data eraser: unsupported order repository %T", orders)
+	}
+	eraser.orders = orderRepo
+
+	if timeline != nil {
+		timelineRepo, ok := timeline.(*timelineRepositoryInMemory)
+		if !ok {
+			return nil, fmt.Errorf("memory customer data eraser: unsupported timeline repository %T", timeline)
+		}
+		eraser.timeline = timelineRepo
+	}
+	if idem != nil {
+		idemRepo, ok := idem.(*idempotencyRepositoryInMemory)
+		if !ok {
+			return nil, fmt.Errorf("memory customer data eraser: unsupported idempotency repository %T", idem)
+		}
+		eraser.idem = idemRepo
+	}
+	if outbox != nil {
+		outboxRepo, ok := outbox.(*outboxRepositoryInMemory)
+		if !ok {
+			return nil, fmt.Errorf("memory customer data eraser: unsupported outbox repository %T", outbox)
+		}
+		eraser.outbox = outboxRepo
+	}
+
+	return eraser, nil
+}
+
+func (e *customerDataEraser) EraseCustomerData(customerID, pseudonym string) (domain.CustomerErasure, error) {
+	customerID = strings.TrimSpace(customerID)
+	if customerID == "" {
+		return domain.CustomerErasure{}, domain.ErrCustomerRequired
+	}
+
+	var result domain.CustomerErasure
+	now := time.Now().UTC()
+
+	e.orders.mu.Lock()
+	for id, order := range e.orders.items {
+		if order.CustomerID != customerID {
+			continue
+		}
+		order.CustomerID = pseudonym
+		order.Version++
+		order.UpdatedAt = now
+		e.orders.items[id] = order
+		result.OrderIDs = append(result.OrderIDs, id)
+	}
+	e.orders.mu.Unlock()
+
+	if len(result.OrderIDs) == 0 {
+		return domain.CustomerErasure{}, domain.ErrCustomerDataNotFound
+	}
+
+	if e.timeline != nil {
+		e.timeline.mu.Lock()
+		for _, orderID := range result.OrderIDs {
+			events := e.timeline.events[orderID]
+			for i := range events {
+				if events[i].Reason == "" {
+					continue
+				}
+				events[i].Reason = ""
+				result.TimelineEventsScrubbed++
+			}
+		}
+		e.timeline.mu.Unlock()
+	}
+
+	needle, err := json.Marshal(customerID)
+	if err != nil {
+		return domain.CustomerErasure{}, fmt.Errorf("encode customer id: %w", err)
+	}
+
+	if e.idem != nil {
+		e.idem.mu.Lock()
+		for scope, record := range e.idem.items {
+			if record.CustomerID == customerID {
+				// Область ключа переезжает на псевдоним вместе с заказами.
+				delete(e.idem.items, scope)
+				record.CustomerID = pseudonym
+				scope = record.Scope()
+				e.idem.items[scope] = record
+			}
+			if !bytes.Contains(record.ResponseBody, needle) {
+				continue
+			}
+			record.ResponseBody = nil
+			record.Status = domain.IdempotencyStatusFailed
+			record.UpdatedAt = now
+			e.idem.items[scope] = record
+			result.IdempotencyRecordsScrubbed++
+		}
+		e.idem.mu.Unlock()
+	}
+
+	if e.outbox != nil {
+		replacement, err := json.Marshal(pseudonym)
+		if err != nil {
+			return domain.CustomerErasure{}, fmt.Errorf("encode pseudonym: %w", err)
+		}
+		orderIDs := make(map[string]struct{}, len(result.OrderIDs))
+		for _, id := range result.OrderIDs {
+			orderIDs[id] = struct{}{}
+		}
+
+		e.outbox.mu.Lock()
+		for _, record := range e.outbox.records {
+			if _, ok := orderIDs[record.msg.AggregateID]; !ok || !bytes.Contains(record.msg.Payload, needle) {
+				continue
+			}
+			record.msg.Payload = bytes.ReplaceAll(record.msg.Payload, needle, replacement)
+			record.updatedAt = now
+			result.OutboxMessagesScrubbed++
+		}
+		e.outbox.mu.Unlock()
+	}
+
+	return result, nil
+}
+
+var _ domain.CustomerDataEraser = (*customerDataEraser)(nil)
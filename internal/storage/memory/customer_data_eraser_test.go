@@ -0,0 +1,112 @@
+package memory_test
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/vladislavdragonenkov/oms/internal/domain"
+	"github.com/vladislavdragonenkov/oms/internal/storage/memory"
+)
+
+func TestCustomerDataEraser_EraseCustomerData(t *testing.T) {
+	orders := memory.NewOrderRepository()
+	timeline := memory.NewTimelineRepository()
+	idem := memory.NewIdempotencyRepository()
+	outbox := memory.NewOutboxRepository()
+
+	eraser, err := memory.NewCustomerDataEraser(orders, timeline, idem, outbox)
+	if err != nil {
+		t.Fatalf("NewCustomerDataEraser failed: %v", err)
+	}
+
+	now := time.Now().UTC()
+	for _, order := range []domain.Order{
+		{ID: "o-1", CustomerID: "alice", Status: domain.OrderStatusPending, Currency: "USD", CreatedAt: now},
+		{ID: "o-2", CustomerID: "alice-2", Status: domain.OrderStatusPending, Currency: "USD", CreatedAt: now},
+	} {
+		if err := orders.Create(order); err != nil {
+			t.Fatalf("Create failed: %v", err)
+		}
+	}
+	if err := timeline.Append(domain.TimelineEvent{OrderID: "o-1", Type: "OrderCanceled", Reason: "call me at +7 900", Occurred: now}); err != nil {
+		t.Fatalf("Append failed: %v", err)
+	}
+	aliceScope := domain.IdempotencyScope{Method: "CreateOrder", CustomerID: "alice", Key: "key-alice"}
+	if _, err := idem.CreateProcessing(aliceScope, "h1", now.Add(time.Hour)); err != nil {
+		t.Fatalf("CreateProcessing failed: %v", err)
+	}
+	if err := idem.MarkDone(aliceScope, []byte(`{"order":{"customerId":"alice"}}`), 0); err != nil {
+		t.Fatalf("MarkDone failed: %v", err)
+	}
+	if _, err := idem.CreateProcessing(domain.IdempotencyScope{Key: "key-other"}, "h2", now.Add(time.Hour)); err != nil {
+		t.Fatalf("CreateProcessing failed: %v", err)
+	}
+	if err := idem.MarkDone(domain.IdempotencyScope{Key: "key-other"}, []byte(`{"order":{"customerId":"alice-2"}}`), 0); err != nil {
+		t.Fatalf("MarkDone failed: %v", err)
+	}
+
+	for _, msg := range []domain.OutboxMessage{
+		{AggregateType: "order", AggregateID: "o-1", EventType: "OrderCreated", Payload: []byte(`{"metadata":{"customer_id":"alice"}}`)},
+		{AggregateType: "order", AggregateID: "o-2", EventType: "OrderCreated", Payload: []byte(`{"metadata":{"customer_id":"alice-2"}}`)},
+	} {
+		if _, err := outbox.Enqueue(msg); err != nil {
+			t.Fatalf("Enqueue failed: %v", err)
+		}
+	}
+
+	erasure, err := eraser.EraseCustomerData("alice", "erased-1")
+	if err != nil {
+		t.Fatalf("EraseCustomerData failed: %v", err)
+	}
+	if len(erasure.OrderIDs) != 1 || erasure.OrderIDs[0] != "o-1" {
+		t.Fatalf("unexpected anonymized orders: %v", erasure.OrderIDs)
+	}
+	if erasure.TimelineEventsScrubbed != 1 || erasure.IdempotencyRecordsScrubbed != 1 || erasure.OutboxMessagesScrubbed != 1 {
+		t.Fatalf("unexpected erasure counters: %+v", erasure)
+	}
+
+	order, _ := orders.Get("o-1")
+	if order.CustomerID != "erased-1" || order.Version != 1 {
+		t.Fatalf("expected pseudonymized order with bumped version, got %+v", order)
+	}
+	if other, _ := orders.Get("o-2"); other.CustomerID != "alice-2" {
+		t.Fatalf("other customer must not be touched, got %s", other.CustomerID)
+	}
+	events, _ := timeline.List("o-1")
+	if events[0].Reason != "" {
+		t.Fatalf("expected scrubbed timeline reason, got %q", events[0].Reason)
+	}
+	if _, err := idem.Get(aliceScope); !errors.Is(err, domain.ErrIdempotencyKeyNotFound) {
+		t.Fatalf("idempotency record must move to the pseudonym scope, got %v", err)
+	}
+	aliceScope.CustomerID = "erased-1"
+	record, _ := idem.Get(aliceScope)
+	if record.Status != domain.IdempotencyStatusFailed || len(record.ResponseBody) != 0 {
+		t.Fatalf("expected scrubbed idempotency record, got %+v", record)
+	}
+	if record, _ := idem.Get(domain.IdempotencyScope{Key: "key-other"}); record.Status != domain.IdempotencyStatusDone {
+		t.Fatalf("other customer's idempotency record must be kept, got %s", record.Status)
+	}
+
+	pending, _ := outbox.PullPending(10)
+	for _, msg := range pending {
+		want := `{"metadata":{"customer_id":"erased-1"}}`
+		if msg.AggregateID == "o-2" {
+			want = `{"metadata":{"customer_id":"alice-2"}}`
+		}
+		if string(msg.Payload) != want {
+			t.Fatalf("unexpected outbox payload for %s: %s", msg.AggregateID, msg.Payload)
+		}
+	}
+
+	if _, err := eraser.EraseCustomerData("alice", "erased-2"); !errors.Is(err, domain.ErrCustomerDataNotFound) {
+		t.Fatalf("expected ErrCustomerDataNotFound on repeated erase, got %v", err)
+	}
+}
+
+func TestNewCustomerDataEraser_RejectsForeignRepositories(t *testing.T) {
+	if _, err := memory.NewCustomerDataEraser(nil, nil, nil, nil); err == nil {
+		t.Fatal("expected error for unsupported order repository")
+	}
+}
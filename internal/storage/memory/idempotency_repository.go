@@ -10,21 +10,21 @@ import (
 
 type idempotencyRepositoryInMemory struct {
 	mu    sync.RWMutex
-	items map[string]domain.IdempotencyRecord
+	items map[domain.IdempotencyScope]domain.IdempotencyRecord
 }
 
 // NewIdempotencyRepository создаёт in-memory реализацию IdempotencyRepository.
 func NewIdempotencyRepository() domain.IdempotencyRepository {
 	return &idempotencyRepositoryInMemory{
-		items: make(map[string]domain.IdempotencyRecord),
+		items: make(map[domain.IdempotencyScope]domain.IdempotencyRecord),
 	}
 }
 
-func (r *idempotencyRepositoryInMemory) CreateProcessing(key, requestHash string, ttlAt time.Time) (domain.IdempotencyRecord, error) {
-	key = strings.TrimSpace(key)
+func (r *idempotencyRepositoryInMemory) CreateProcessing(scope domain.IdempotencyScope, requestHash string, ttlAt time.Time) (domain.IdempotencyRecord, error) {
+	scope = scope.Normalize()
 	requestHash = strings.TrimSpace(requestHash)
 
-	if key == "" {
+	if scope.Key == "" {
 		return domain.IdempotencyRecord{}, domain.ErrIdempotencyKeyRequired
 	}
 	if requestHash == "" {
@@ -39,15 +39,20 @@ func (r *idempotencyRepositoryInMemory) CreateProcessing(key, requestHash string
 	r.mu.Lock()
 	defer r.mu.Unlock()
 
-	if existing, ok := r.items[key]; ok {
+	if existing, ok := r.items[scope]; ok {
 		if existing.RequestHash != requestHash {
-			return existing, domain.ErrIdempotencyHashMismatch
+			return cloneIdempotencyRecord(existing), domain.ErrIdempotencyHashMismatch
 		}
-		return existing, domain.ErrIdempotencyKeyAlreadyExists
+		return cloneIdempotencyRecord(existing), domain.ErrIdempotencyKeyAlreadyExists
+	}
+	if legacy, ok := r.items[scope.Legacy()]; ok && legacy.RequestHash == requestHash {
+		return cloneIdempotencyRecord(legacy), domain.ErrIdempotencyKeyAlreadyExists
 	}
 
 	record := domain.IdempotencyRecord{
-		Key:          key,
+		Method:       scope.Method,
+		CustomerID:   scope.CustomerID,
+		Key:          scope.Key,
 		RequestHash:  requestHash,
 		Status:       domain.IdempotencyStatusProcessing,
 		TTLAt:        ttlAt,
@@ -57,20 +62,20 @@ func (r *idempotencyRepositoryInMemory) CreateProcessing(key, requestHash string
 		HTTPStatus:   0,
 	}
 
-	r.items[key] = cloneIdempotencyRecord(record)
+	r.items[scope] = cloneIdempotencyRecord(record)
 	return cloneIdempotencyRecord(record), nil
 }
 
-func (r *idempotencyRepositoryInMemory) Get(key string) (domain.IdempotencyRecord, error) {
-	key = strings.TrimSpace(key)
-	if key == "" {
+func (r *idempotencyRepositoryInMemory) Get(scope domain.IdempotencyScope) (domain.IdempotencyRecord, error) {
+	scope = scope.Normalize()
+	if scope.Key == "" {
 		return domain.IdempotencyRecord{}, domain.ErrIdempotencyKeyRequired
 	}
 
 	r.mu.RLock()
 	defer r.mu.RUnlock()
 
-	record, ok := r.items[key]
+	record, ok := r.items[scope]
 	if !ok {
 		return domain.IdempotencyRecord{}, domain.ErrIdempotencyKeyNotFound
 	}
@@ -78,12 +83,20 @@ func (r *idempotencyRepositoryInMemory) Get(key string) (domain.IdempotencyRecor
 	return cloneIdempotencyRecord(record), nil
 }
 
-func (r *idempotencyRepositoryInMemory) MarkDone(key string, responseBody []byte, httpStatus int) error {
-	return r.markStatus(key, domain.IdempotencyStatusDone, responseBody, httpStatus)
+func (r *idempotencyRepositoryInMemory) MarkDone(scope domain.IdempotencyScope, responseBody []byte, httpStatus int) error {
+	return r.markStatus(scope, domain.IdempotencyStatusDone, responseBody, httpStatus)
+}
+
+func (r *idempotencyRepositoryInMemory) MarkFailed(scope domain.IdempotencyScope, responseBody []byte, httpStatus int) error {
+	return r.markStatus(scope, domain.IdempotencyStatusFailed, responseBody, httpStatus)
 }
 
-func (r *idempotencyRepositoryInMemory) MarkFailed(key string, responseBody []byte, httpStatus int) error {
-	return r.markStatus(key, domain.IdempotencyStatusFailed, responseBody, httpStatus)
+func (r *idempotencyRepositoryInMemory) Delete(scope domain.IdempotencyScope) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	delete(r.items, scope.Normalize())
+	return nil
 }
 
 func (r *idempotencyRepositoryInMemory) DeleteExpired(before time.Time, limit int) (int, error) {
@@ -110,16 +123,45 @@ func (r *idempotencyRepositoryInMemory) DeleteExpired(before time.Time, limit in
 	return removed, nil
 }
 
-func (r *idempotencyRepositoryInMemory) markStatus(key string, status domain.IdempotencyStatus, responseBody []byte, httpStatus int) error {
-	key = strings.TrimSpace(key)
-	if key == "" {
+func (r *idempotencyRepositoryInMemory) FailStaleProcessing(before time.Time, limit int, responseBody []byte, httpStatus int) ([]domain.IdempotencyRecord, error) {
+	if before.IsZero() {
+		before = time.Now().UTC()
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	now := time.Now().UTC()
+	failed := make([]domain.IdempotencyRecord, 0)
+	for key, record := range r.items {
+		if record.Status != domain.IdempotencyStatusProcessing || record.UpdatedAt.After(before) {
+			continue
+		}
+
+		record.Status = domain.IdempotencyStatusFailed
+		record.ResponseBody = append([]byte(nil), responseBody...)
+		record.HTTPStatus = httpStatus
+		record.UpdatedAt = now
+		r.items[key] = record
+		failed = append(failed, cloneIdempotencyRecord(record))
+		if limit > 0 && len(failed) >= limit {
+			break
+		}
+	}
+
+	return failed, nil
+}
+
+func (r *idempotencyRepositoryInMemory) markStatus(scope domain.IdempotencyScope, status domain.IdempotencyStatus, responseBody []byte, httpStatus int) error {
+	scope = scope.Normalize()
+	if scope.Key == "" {
 		return domain.ErrIdempotencyKeyRequired
 	}
 
 	r.mu.Lock()
 	defer r.mu.Unlock()
 
-	record, ok := r.items[key]
+	record, ok := r.items[scope]
 	if !ok {
 		return domain.ErrIdempotencyKeyNotFound
 	}
@@ -128,7 +170,7 @@ func (r *idempotencyRepositoryInMemory) markStatus(key string, status domain.Ide
 	record.ResponseBody = append([]byte(nil), responseBody...)
 	record.HTTPStatus = httpStatus
 	record.UpdatedAt = time.Now().UTC()
-	r.items[key] = record
+	r.items[scope] = record
 
 	return nil
 }
@@ -139,4 +181,7 @@ func cloneIdempotencyRecord(src domain.IdempotencyRecord) domain.IdempotencyReco
 	return dst
 }
 
-var _ domain.IdempotencyRepository = (*idempotencyRepositoryInMemory)(nil)
+var (
+	_ domain.IdempotencyRepository    = (*idempotencyRepositoryInMemory)(nil)
+	_ domain.IdempotencyRecordDeleter = (*idempotencyRepositoryInMemory)(nil)
+)
@@ -13,7 +13,7 @@ func TestIdempotencyRepository_CreateAndGet(t *testing.T) {
 	repo := memory.NewIdempotencyRepository()
 	ttl := time.Now().UTC().Add(2 * time.Hour).Round(time.Second)
 
-	created, err := repo.CreateProcessing("idem-key-1", "hash-1", ttl)
+	created, err := repo.CreateProcessing(domain.IdempotencyScope{Key: "idem-key-1"}, "hash-1", ttl)
 	if err != nil {
 		t.Fatalf("CreateProcessing failed: %v", err)
 	}
@@ -21,7 +21,7 @@ func TestIdempotencyRepository_CreateAndGet(t *testing.T) {
 		t.Fatalf("expected status %s, got %s", domain.IdempotencyStatusProcessing, created.Status)
 	}
 
-	got, err := repo.Get("idem-key-1")
+	got, err := repo.Get(domain.IdempotencyScope{Key: "idem-key-1"})
 	if err != nil {
 		t.Fatalf("Get failed: %v", err)
 	}
@@ -37,37 +37,85 @@ func TestIdempotencyRepository_ConflictAndHashMismatch(t *testing.T) {
 	repo := memory.NewIdempotencyRepository()
 	ttl := time.Now().UTC().Add(time.Hour)
 
-	if _, err := repo.CreateProcessing("idem-key-2", "hash-a", ttl); err != nil {
+	if _, err := repo.CreateProcessing(domain.IdempotencyScope{Key: "idem-key-2"}, "hash-a", ttl); err != nil {
 		t.Fatalf("CreateProcessing failed: %v", err)
 	}
 
-	if _, err := repo.CreateProcessing("idem-key-2", "hash-a", ttl); !errors.Is(err, domain.ErrIdempotencyKeyAlreadyExists) {
+	if _, err := repo.CreateProcessing(domain.IdempotencyScope{Key: "idem-key-2"}, "hash-a", ttl); !errors.Is(err, domain.ErrIdempotencyKeyAlreadyExists) {
 		t.Fatalf("expected ErrIdempotencyKeyAlreadyExists, got %v", err)
 	}
 
-	if _, err := repo.CreateProcessing("idem-key-2", "hash-b", ttl); !errors.Is(err, domain.ErrIdempotencyHashMismatch) {
+	if _, err := repo.CreateProcessing(domain.IdempotencyScope{Key: "idem-key-2"}, "hash-b", ttl); !errors.Is(err, domain.ErrIdempotencyHashMismatch) {
 		t.Fatalf("expected ErrIdempotencyHashMismatch, got %v", err)
 	}
 }
 
+func TestIdempotencyRepository_ScopesKeyByMethodAndCustomer(t *testing.T) {
+	repo := memory.NewIdempotencyRepository()
+	ttl := time.Now().UTC().Add(time.Hour)
+
+	alice := domain.IdempotencyScope{Method: "CreateOrder", CustomerID: "alice", Key: "shared-key"}
+	bob := domain.IdempotencyScope{Method: "CreateOrder", CustomerID: "bob", Key: "shared-key"}
+	alicePay := domain.IdempotencyScope{Method: "PayOrder", CustomerID: "alice", Key: "shared-key"}
+
+	for _, scope := range []domain.IdempotencyScope{alice, bob, alicePay} {
+		if _, err := repo.CreateProcessing(scope, "hash-"+scope.Method+scope.CustomerID, ttl); err != nil {
+			t.Fatalf("CreateProcessing %+v failed: %v", scope, err)
+		}
+	}
+
+	if err := repo.MarkDone(bob, []byte(`{"who":"bob"}`), 0); err != nil {
+		t.Fatalf("MarkDone failed: %v", err)
+	}
+	got, err := repo.Get(alice)
+	if err != nil {
+		t.Fatalf("Get failed: %v", err)
+	}
+	if got.Status != domain.IdempotencyStatusProcessing || got.CustomerID != "alice" {
+		t.Fatalf("alice's record must not be affected by bob's key, got %+v", got)
+	}
+}
+
+func TestIdempotencyRepository_ReplaysLegacyRecordWithSameHash(t *testing.T) {
+	repo := memory.NewIdempotencyRepository()
+	ttl := time.Now().UTC().Add(time.Hour)
+
+	legacy := domain.IdempotencyScope{Key: "legacy-key"}
+	if _, err := repo.CreateProcessing(legacy, "hash-legacy", ttl); err != nil {
+		t.Fatalf("CreateProcessing failed: %v", err)
+	}
+	if err := repo.MarkDone(legacy, []byte(`{"legacy":true}`), 0); err != nil {
+		t.Fatalf("MarkDone failed: %v", err)
+	}
+
+	scoped := domain.IdempotencyScope{Method: "CreateOrder", CustomerID: "alice", Key: "legacy-key"}
+	record, err := repo.CreateProcessing(scoped, "hash-legacy", ttl)
+	if !errors.Is(err, domain.ErrIdempotencyKeyAlreadyExists) || string(record.ResponseBody) != `{"legacy":true}` {
+		t.Fatalf("expected replay of legacy record, got record=%+v err=%v", record, err)
+	}
+	if _, err := repo.CreateProcessing(scoped, "hash-new", ttl); err != nil {
+		t.Fatalf("different request must not match legacy record, got %v", err)
+	}
+}
+
 func TestIdempotencyRepository_MarkDoneAndDeleteExpired(t *testing.T) {
 	repo := memory.NewIdempotencyRepository()
 
 	expiredTTL := time.Now().UTC().Add(-time.Minute)
 	activeTTL := time.Now().UTC().Add(time.Hour)
 
-	if _, err := repo.CreateProcessing("idem-expired", "hash-expired", expiredTTL); err != nil {
+	if _, err := repo.CreateProcessing(domain.IdempotencyScope{Key: "idem-expired"}, "hash-expired", expiredTTL); err != nil {
 		t.Fatalf("CreateProcessing expired failed: %v", err)
 	}
-	if _, err := repo.CreateProcessing("idem-active", "hash-active", activeTTL); err != nil {
+	if _, err := repo.CreateProcessing(domain.IdempotencyScope{Key: "idem-active"}, "hash-active", activeTTL); err != nil {
 		t.Fatalf("CreateProcessing active failed: %v", err)
 	}
 
-	if err := repo.MarkDone("idem-active", []byte(`{"ok":true}`), 200); err != nil {
+	if err := repo.MarkDone(domain.IdempotencyScope{Key: "idem-active"}, []byte(`{"ok":true}`), 200); err != nil {
 		t.Fatalf("MarkDone failed: %v", err)
 	}
 
-	active, err := repo.Get("idem-active")
+	active, err := repo.Get(domain.IdempotencyScope{Key: "idem-active"})
 	if err != nil {
 		t.Fatalf("Get active failed: %v", err)
 	}
@@ -86,7 +134,7 @@ func TestIdempotencyRepository_MarkDoneAndDeleteExpired(t *testing.T) {
 		t.Fatalf("expected removed=1, got %d", removed)
 	}
 
-	if _, err := repo.Get("idem-expired"); !errors.Is(err, domain.ErrIdempotencyKeyNotFound) {
+	if _, err := repo.Get(domain.IdempotencyScope{Key: "idem-expired"}); !errors.Is(err, domain.ErrIdempotencyKeyNotFound) {
 		t.Fatalf("expected expired key to be deleted, got %v", err)
 	}
 }
@@ -95,15 +143,15 @@ func TestIdempotencyRepository_MarkFailed(t *testing.T) {
 	repo := memory.NewIdempotencyRepository()
 	ttl := time.Now().UTC().Add(time.Hour)
 
-	if _, err := repo.CreateProcessing("idem-failed", "hash-failed", ttl); err != nil {
+	if _, err := repo.CreateProcessing(domain.IdempotencyScope{Key: "idem-failed"}, "hash-failed", ttl); err != nil {
 		t.Fatalf("CreateProcessing failed: %v", err)
 	}
 
-	if err := repo.MarkFailed("idem-failed", []byte(`{"error":"boom"}`), 409); err != nil {
+	if err := repo.MarkFailed(domain.IdempotencyScope{Key: "idem-failed"}, []byte(`{"error":"boom"}`), 409); err != nil {
 		t.Fatalf("MarkFailed failed: %v", err)
 	}
 
-	got, err := repo.Get("idem-failed")
+	got, err := repo.Get(domain.IdempotencyScope{Key: "idem-failed"})
 	if err != nil {
 		t.Fatalf("Get failed: %v", err)
 	}
@@ -117,11 +165,40 @@ func TestIdempotencyRepository_MarkFailed(t *testing.T) {
 		t.Fatalf("unexpected response body: %s", string(got.ResponseBody))
 	}
 
-	if err := repo.MarkFailed("missing-idem", []byte("x"), 500); !errors.Is(err, domain.ErrIdempotencyKeyNotFound) {
+	if err := repo.MarkFailed(domain.IdempotencyScope{Key: "missing-idem"}, []byte("x"), 500); !errors.Is(err, domain.ErrIdempotencyKeyNotFound) {
 		t.Fatalf("expected ErrIdempotencyKeyNotFound, got %v", err)
 	}
 
-	if err := repo.MarkFailed("   ", []byte("x"), 500); !errors.Is(err, domain.ErrIdempotencyKeyRequired) {
+	if err := repo.MarkFailed(domain.IdempotencyScope{Key: "   "}, []byte("x"), 500); !errors.Is(err, domain.ErrIdempotencyKeyRequired) {
 		t.Fatalf("expected ErrIdempotencyKeyRequired, got %v", err)
 	}
 }
+
+func TestIdempotencyRepository_FailStaleProcessing(t *testing.T) {
+	repo := memory.NewIdempotencyRepository()
+	ttl := time.Now().UTC().Add(time.Hour)
+
+	for _, key := range []string{"idem-stuck", "idem-done"} {
+		if _, err := repo.CreateProcessing(domain.IdempotencyScope{Key: key}, "hash", ttl); err != nil {
+			t.Fatalf("CreateProcessing failed: %v", err)
+		}
+	}
+	if err := repo.MarkDone(domain.IdempotencyScope{Key: "idem-done"}, []byte(`{}`), 0); err != nil {
+		t.Fatalf("MarkDone failed: %v", err)
+	}
+
+	failed, err := repo.FailStaleProcessing(time.Now().UTC().Add(time.Second), 0, []byte(`{"code":10}`), 10)
+	if err != nil {
+		t.Fatalf("FailStaleProcessing failed: %v", err)
+	}
+	if len(failed) != 1 || failed[0].Key != "idem-stuck" {
+		t.Fatalf("expected only the stuck key to be failed, got %+v", failed)
+	}
+	stuck, err := repo.Get(domain.IdempotencyScope{Key: "idem-stuck"})
+	if err != nil || stuck.Status != domain.IdempotencyStatusFailed || string(stuck.ResponseBody) != `{"code":10}` || stuck.HTTPStatus != 10 {
+		t.Fatalf("expected stuck key to become failed with the response, got %+v (%v)", stuck, err)
+	}
+	if done, err := repo.Get(domain.IdempotencyScope{Key: "idem-done"}); err != nil || done.Status != domain.IdempotencyStatusDone {
+		t.Fatalf("done key must be kept: %+v (%v)", done, err)
+	}
+}
@@ -5,6 +5,7 @@ import (
 	"sync"
 
 	"github.com/vladislavdragonenkov/oms/internal/domain"
+	"github.com/vladislavdragonenkov/oms/internal/timeutil"
 )
 
 // orderRepositoryInMemory — простая in-memory реализация OrderRepository.
@@ -22,6 +23,10 @@ func NewOrderRepository() domain.OrderRepository {
 
 // Create сохраняет новый заказ, если ID ещё не занят.
 func (r *orderRepositoryInMemory) Create(order domain.Order) error {
+	if err := order.ValidateIDs(); err != nil {
+		return err
+	}
+
 	r.mu.Lock()
 	defer r.mu.Unlock()
 
@@ -45,6 +50,25 @@ func (r *orderRepositoryInMemory) Get(id string) (domain.Order, error) {
 	return order, nil
 }
 
+// GetMany возвращает найденные заказы из ids по возрастанию ID.
+func (r *orderRepositoryInMemory) GetMany(ids []string) ([]domain.Order, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	result := make([]domain.Order, 0, len(ids))
+	seen := make(map[string]struct{}, len(ids))
+	for _, id := range ids {
+		order, ok := r.items[id]
+		if _, dup := seen[id]; !ok || dup {
+			continue
+		}
+		seen[id] = struct{}{}
+		result = append(result, order)
+	}
+	sort.Slice(result, func(i, j int) bool { return result[i].ID < result[j].ID })
+	return result, nil
+}
+
 // ListByCustomer возвращает заказы клиента, ограничивая выборку limit (если >0).
 func (r *orderRepositoryInMemory) ListByCustomer(customerID string, limit int) ([]domain.Order, error) {
 	r.mu.RLock()
@@ -72,6 +96,66 @@ func (r *orderRepositoryInMemory) ListByCustomer(customerID string, limit int) (
 	return result, nil
 }
 
+// StreamByCustomer отдаёт заказы клиента в fn; fn вызывается без удержания блокировки.
+func (r *orderRepositoryInMemory) StreamByCustomer(customerID string, limit int, fn func(domain.Order) error) error {
+	orders, err := r.ListByCustomer(customerID, limit)
+	if err != nil {
+		return err
+	}
+	for _, order := range orders {
+		if err := fn(order); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// ListByStatus возвращает заказы в статусе status от старых к новым, ограничивая выборку limit (если >0).
+func (r *orderRepositoryInMemory) ListByStatus(status domain.OrderStatus, limit int) ([]domain.Order, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	result := make([]domain.Order, 0)
+	for _, order := range r.items {
+		if order.Status != status {
+			continue
+		}
+		result = append(result, order)
+	}
+
+	sort.Slice(result, func(i, j int) bool {
+		if !result[i].CreatedAt.Equal(result[j].CreatedAt) {
+			return result[i].CreatedAt.Before(result[j].CreatedAt)
+		}
+		return result[i].ID < result[j].ID
+	})
+
+	if limit > 0 && len(result) > limit {
+		result = result[:limit]
+	}
+
+	return result, nil
+}
+
+// ListAfter возвращает заказы с ID больше afterID по возрастанию ID.
+func (r *orderRepositoryInMemory) ListAfter(afterID string, limit int) ([]domain.Order, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	result := make([]domain.Order, 0)
+	for id, order := range r.items {
+		if id > afterID {
+			result = append(result, order)
+		}
+	}
+	sort.Slice(result, func(i, j int) bool { return result[i].ID < result[j].ID })
+
+	if limit > 0 && len(result) > limit {
+		result = result[:limit]
+	}
+	return result, nil
+}
+
 // Save перезаписывает заказ, проверяя версию (optimistic locking).
 func (r *orderRepositoryInMemory) Save(order domain.Order) error {
 	r.mu.Lock()
@@ -86,8 +170,77 @@ func (r *orderRepositoryInMemory) Save(order domain.Order) error {
 	}
 	// Инкрементируем версию перед сохранением.
 	order.Version++
+	// Sandbox-флаг задаётся при создании заказа и не меняется, как и в postgres.
+	order.TestMode = current.TestMode
 	r.items[order.ID] = order
 	return nil
 }
 
-var _ domain.OrderRepository = (*orderRepositoryInMemory)(nil)
+// UpdatePrices сохраняет цены позиций и суммы заказа; остальные поля не меняются.
+func (r *orderRepositoryInMemory) UpdatePrices(order domain.Order) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	current, ok := r.items[order.ID]
+	if !ok {
+		return domain.ErrOrderNotFound
+	}
+	if current.Version != order.Version {
+		return domain.ErrOrderVersionConflict
+	}
+	current.Items = append([]domain.OrderItem(nil), order.Items...)
+	current.AmountMinor = order.AmountMinor
+	current.Pricing = order.Pricing
+	current.UpdatedAt = order.UpdatedAt
+	current.Version++
+	r.items[order.ID] = current
+	return nil
+}
+
+// UpdateItems заменяет позиции и суммы заказа; остальные поля не меняются.
+func (r *orderRepositoryInMemory) UpdateItems(order domain.Order) error {
+	return r.UpdatePrices(order)
+}
+
+// UpdateStatusCAS меняет статус под блокировкой, сверяя текущие статус и версию.
+func (r *orderRepositoryInMemory) UpdateStatusCAS(orderID string, from, to domain.OrderStatus, expectedVersion int64) (int64, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	current, ok := r.items[orderID]
+	if !ok {
+		return 0, domain.ErrOrderNotFound
+	}
+	if current.Status != from || current.Version != expectedVersion {
+		return 0, domain.ErrOrderVersionConflict
+	}
+	if current.Status == domain.OrderStatusOnHold {
+		current.HoldReason = ""
+		current.HeldFromStatus = ""
+	}
+	current.Status = to
+	current.UpdatedAt = timeutil.Now()
+	current.Version++
+	r.items[orderID] = current
+	return current.Version, nil
+}
+
+// Delete удаляет заказ, если он существует.
+func (r *orderRepositoryInMemory) Delete(id string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, ok := r.items[id]; !ok {
+		return domain.ErrOrderNotFound
+	}
+	delete(r.items, id)
+	return nil
+}
+
+var (
+	_ domain.OrderRepository   = (*orderRepositoryInMemory)(nil)
+	_ domain.OrderPriceUpdater = (*orderRepositoryInMemory)(nil)
+	_ domain.OrderItemsUpdater = (*orderRepositoryInMemory)(nil)
+	_ domain.OrderStreamer     = (*orderRepositoryInMemory)(nil)
+	_ domain.OrderBatchReader  = (*orderRepositoryInMemory)(nil)
+)
@@ -1,6 +1,8 @@
 package memory_test
 
 import (
+	"errors"
+	"fmt"
 	"testing"
 	"time"
 
@@ -42,6 +44,41 @@ func TestOrderRepository_CreateGet(t *testing.T) {
 	}
 }
 
+func TestOrderRepository_CreateRejectsMalformedIDs(t *testing.T) {
+	repo := memory.NewOrderRepository()
+
+	order := newOrder()
+	order.ID = "order\x00-1"
+	if err := repo.Create(order); !errors.Is(err, domain.ErrOrderIDInvalid) {
+		t.Fatalf("expected ErrOrderIDInvalid, got %v", err)
+	}
+
+	order = newOrder()
+	order.CustomerID = ""
+	if err := repo.Create(order); !errors.Is(err, domain.ErrCustomerRequired) {
+		t.Fatalf("expected ErrCustomerRequired, got %v", err)
+	}
+}
+
+func TestOrderRepository_GetMany(t *testing.T) {
+	repo := memory.NewOrderRepository()
+	for _, id := range []string{"order-2", "order-1"} {
+		order := newOrder()
+		order.ID = id
+		if err := repo.Create(order); err != nil {
+			t.Fatalf("create %s: %v", id, err)
+		}
+	}
+
+	orders, err := repo.(domain.OrderBatchReader).GetMany([]string{"order-2", "missing", "order-1", "order-2"})
+	if err != nil {
+		t.Fatalf("get many: %v", err)
+	}
+	if len(orders) != 2 || orders[0].ID != "order-1" || orders[1].ID != "order-2" {
+		t.Fatalf("expected order-1 and order-2 once each, got %+v", orders)
+	}
+}
+
 func TestOrderRepository_ListByCustomer(t *testing.T) {
 	repo := memory.NewOrderRepository()
 	order := newOrder()
@@ -116,6 +153,44 @@ func TestOrderRepository_ListByCustomer_SortedAndLimited(t *testing.T) {
 	}
 }
 
+func TestOrderRepository_StreamByCustomer(t *testing.T) {
+	repo := memory.NewOrderRepository()
+	base := time.Now().UTC()
+	for i := range 3 {
+		order := newOrder()
+		order.ID = fmt.Sprintf("order-%d", i)
+		order.CreatedAt = base.Add(time.Duration(i) * time.Minute)
+		if err := repo.Create(order); err != nil {
+			t.Fatalf("Create failed: %v", err)
+		}
+	}
+
+	streamer, ok := repo.(domain.OrderStreamer)
+	if !ok {
+		t.Fatal("memory repository must implement domain.OrderStreamer")
+	}
+	var ids []string
+	if err := streamer.StreamByCustomer("customer-1", 2, func(order domain.Order) error {
+		ids = append(ids, order.ID)
+		return nil
+	}); err != nil {
+		t.Fatalf("StreamByCustomer failed: %v", err)
+	}
+	if len(ids) != 2 || ids[0] != "order-2" || ids[1] != "order-1" {
+		t.Fatalf("expected newest orders first within limit, got %v", ids)
+	}
+
+	stop := errors.New("stop")
+	calls := 0
+	err := streamer.StreamByCustomer("customer-1", 0, func(domain.Order) error {
+		calls++
+		return stop
+	})
+	if !errors.Is(err, stop) || calls != 1 {
+		t.Fatalf("expected callback error to stop the stream, calls=%d err=%v", calls, err)
+	}
+}
+
 func TestOrderRepository_Save(t *testing.T) {
 	repo := memory.NewOrderRepository()
 	order := newOrder()
@@ -129,6 +204,7 @@ func TestOrderRepository_Save(t *testing.T) {
 	}
 
 	stored.AmountMinor = 600
+	stored.TestMode = true
 	if err := repo.Save(stored); err != nil {
 		t.Fatalf("save failed: %v", err)
 	}
@@ -144,6 +220,9 @@ func TestOrderRepository_Save(t *testing.T) {
 	if updated.Version != stored.Version+1 {
 		t.Fatalf("expected version increment, got %d", updated.Version)
 	}
+	if updated.TestMode {
+		t.Fatal("Save must not change test_mode of an existing order")
+	}
 }
 
 func TestOrderRepository_SaveVersionConflict(t *testing.T) {
@@ -158,3 +237,184 @@ func TestOrderRepository_SaveVersionConflict(t *testing.T) {
 		t.Fatal("expected version conflict error")
 	}
 }
+
+func TestOrderRepository_UpdateStatusCAS(t *testing.T) {
+	repo := memory.NewOrderRepository()
+	order := newOrder()
+	order.Status = domain.OrderStatusReserved
+	if err := order.Hold("fraud review"); err != nil {
+		t.Fatalf("hold: %v", err)
+	}
+	if err := repo.Create(order); err != nil {
+		t.Fatalf("create failed: %v", err)
+	}
+
+	if _, err := repo.UpdateStatusCAS(order.ID, domain.OrderStatusOnHold, domain.OrderStatusCanceled, 7); !errors.Is(err, domain.ErrOrderVersionConflict) {
+		t.Fatalf("expected version conflict on stale version, got %v", err)
+	}
+	if _, err := repo.UpdateStatusCAS(order.ID, domain.OrderStatusPaid, domain.OrderStatusCanceled, order.Version); !errors.Is(err, domain.ErrOrderVersionConflict) {
+		t.Fatalf("expected version conflict on status mismatch, got %v", err)
+	}
+	if _, err := repo.UpdateStatusCAS("missing", domain.OrderStatusPending, domain.OrderStatusCanceled, 0); !errors.Is(err, domain.ErrOrderNotFound) {
+		t.Fatalf("expected ErrOrderNotFound, got %v", err)
+	}
+
+	version, err := repo.UpdateStatusCAS(order.ID, domain.OrderStatusOnHold, domain.OrderStatusCanceled, order.Version)
+	if err != nil {
+		t.Fatalf("cas failed: %v", err)
+	}
+	if version != order.Version+1 {
+		t.Fatalf("expected version %d, got %d", order.Version+1, version)
+	}
+
+	updated, err := repo.Get(order.ID)
+	if err != nil {
+		t.Fatalf("get failed: %v", err)
+	}
+	if updated.Status != domain.OrderStatusCanceled || updated.Version != version {
+		t.Fatalf("unexpected order after cas: status=%s version=%d", updated.Status, updated.Version)
+	}
+	if updated.HoldReason != "" || updated.HeldFromStatus != "" {
+		t.Fatalf("expected hold fields cleared, got %q/%q", updated.HoldReason, updated.HeldFromStatus)
+	}
+}
+
+func TestOrderRepository_UpdatePrices(t *testing.T) {
+	repo := memory.NewOrderRepository()
+	order := newOrder()
+	if err := repo.Create(order); err != nil {
+		t.Fatalf("create failed: %v", err)
+	}
+
+	repriced := newOrder()
+	repriced.Status = domain.OrderStatusCanceled
+	repriced.Items[0].PriceMinor = 80
+	repriced.AmountMinor = 400
+	updater := repo.(domain.OrderPriceUpdater)
+	if err := updater.UpdatePrices(repriced); err != nil {
+		t.Fatalf("update prices failed: %v", err)
+	}
+	if err := updater.UpdatePrices(repriced); !errors.Is(err, domain.ErrOrderVersionConflict) {
+		t.Fatalf("expected version conflict, got %v", err)
+	}
+
+	got, err := repo.Get(order.ID)
+	if err != nil {
+		t.Fatalf("get failed: %v", err)
+	}
+	if got.AmountMinor != 400 || got.Items[0].PriceMinor != 80 || got.Version != 1 {
+		t.Fatalf("unexpected order after update: %+v", got)
+	}
+	if got.Status != domain.OrderStatusPending {
+		t.Fatalf("update prices must not change status, got %s", got.Status)
+	}
+}
+
+func TestOrderRepository_UpdateItems(t *testing.T) {
+	repo := memory.NewOrderRepository()
+	order := newOrder()
+	if err := repo.Create(order); err != nil {
+		t.Fatalf("create failed: %v", err)
+	}
+
+	edited := newOrder()
+	edited.Items = []domain.OrderItem{
+		{ID: "item-2", SKU: "sku-2", Qty: 2, PriceMinor: 150},
+		{ID: "item-3", SKU: "sku-3", Qty: 1, PriceMinor: 40},
+	}
+	edited.AmountMinor = 340
+	updater := repo.(domain.OrderItemsUpdater)
+	if err := updater.UpdateItems(edited); err != nil {
+		t.Fatalf("update items failed: %v", err)
+	}
+	if err := updater.UpdateItems(edited); !errors.Is(err, domain.ErrOrderVersionConflict) {
+		t.Fatalf("expected version conflict, got %v", err)
+	}
+
+	got, err := repo.Get(order.ID)
+	if err != nil {
+		t.Fatalf("get failed: %v", err)
+	}
+	if len(got.Items) != 2 || got.Items[0].ID != "item-2" || got.AmountMinor != 340 || got.Version != 1 {
+		t.Fatalf("unexpected order after update: %+v", got)
+	}
+}
+
+func TestOrderRepository_ListByStatus_OldestFirstAndLimited(t *testing.T) {
+	repo := memory.NewOrderRepository()
+	base := time.Now().UTC()
+
+	for i, status := range []domain.OrderStatus{
+		domain.OrderStatusReserved,
+		domain.OrderStatusPending,
+		domain.OrderStatusReserved,
+		domain.OrderStatusReserved,
+	} {
+		order := newOrder()
+		order.ID = fmt.Sprintf("order-%d", i)
+		order.Status = status
+		order.CreatedAt = base.Add(-time.Duration(i) * time.Minute)
+		if err := repo.Create(order); err != nil {
+			t.Fatalf("create failed: %v", err)
+		}
+	}
+
+	orders, err := repo.ListByStatus(domain.OrderStatusReserved, 2)
+	if err != nil {
+		t.Fatalf("list failed: %v", err)
+	}
+	if len(orders) != 2 {
+		t.Fatalf("expected 2 orders, got %d", len(orders))
+	}
+	if orders[0].ID != "order-3" || orders[1].ID != "order-2" {
+		t.Fatalf("unexpected order sequence: %s, %s", orders[0].ID, orders[1].ID)
+	}
+}
+
+func TestOrderRepository_ListAfter_PagesByID(t *testing.T) {
+	repo := memory.NewOrderRepository()
+	for _, id := range []string{"order-c", "order-a", "order-b"} {
+		order := newOrder()
+		order.ID = id
+		if err := repo.Create(order); err != nil {
+			t.Fatalf("create failed: %v", err)
+		}
+	}
+
+	scanner, ok := repo.(domain.OrderScanner)
+	if !ok {
+		t.Fatal("in-memory repository must implement domain.OrderScanner")
+	}
+	page, err := scanner.ListAfter("", 2)
+	if err != nil {
+		t.Fatalf("list failed: %v", err)
+	}
+	if len(page) != 2 || page[0].ID != "order-a" || page[1].ID != "order-b" {
+		t.Fatalf("unexpected first page: %+v", page)
+	}
+	page, err = scanner.ListAfter(page[1].ID, 2)
+	if err != nil {
+		t.Fatalf("list failed: %v", err)
+	}
+	if len(page) != 1 || page[0].ID != "order-c" {
+		t.Fatalf("unexpected second page: %+v", page)
+	}
+}
+
+func TestOrderRepository_Delete(t *testing.T) {
+	repo := memory.NewOrderRepository()
+	order := newOrder()
+	if err := repo.Create(order); err != nil {
+		t.Fatalf("create failed: %v", err)
+	}
+
+	if err := repo.Delete(order.ID); err != nil {
+		t.Fatalf("delete failed: %v", err)
+	}
+	if _, err := repo.Get(order.ID); !errors.Is(err, domain.ErrOrderNotFound) {
+		t.Fatalf("expected ErrOrderNotFound after delete, got %v", err)
+	}
+	if err := repo.Delete(order.ID); !errors.Is(err, domain.ErrOrderNotFound) {
+		t.Fatalf("expected ErrOrderNotFound on repeated delete, got %v", err)
+	}
+}
@@ -0,0 +1,103 @@
+package memory
+
+import (
+	"slices"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/vladislavdragonenkov/oms/internal/domain"
+)
+
+// returnRepositoryInMemory хранит возвраты позиций в памяти процесса.
+type returnRepositoryInMemory struct {
+	mu      sync.Mutex
+	returns map[string]domain.OrderReturn
+}
+
+// NewReturnRepository создаёт in-memory реализацию ReturnRepository.
+func NewReturnRepository() domain.ReturnRepository {
+	return &returnRepositoryInMemory{returns: make(map[string]domain.OrderReturn)}
+}
+
+func (r *returnRepositoryInMemory) Create(ret domain.OrderReturn, previous int) (domain.OrderReturn, error) {
+	if ret.ID == "" {
+		ret.ID = uuid.NewString()
+	}
+	now := time.Now().UTC()
+	ret.Status = domain.ReturnStatusRequested
+	ret.Items = slices.Clone(ret.Items)
+	ret.CreatedAt = now
+	ret.UpdatedAt = now
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	existing := 0
+	for _, other := range r.returns {
+		if other.OrderID == ret.OrderID {
+			existing++
+		}
+	}
+	if existing != previous {
+		return domain.OrderReturn{}, domain.ErrReturnsChanged
+	}
+	r.returns[ret.ID] = ret
+	return cloneReturn(ret), nil
+}
+
+func (r *returnRepositoryInMemory) Get(id string) (domain.OrderReturn, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	ret, ok := r.returns[id]
+	if !ok {
+		return domain.OrderReturn{}, domain.ErrReturnNotFound
+	}
+	return cloneReturn(ret), nil
+}
+
+func (r *returnRepositoryInMemory) ListByOrder(orderID string) ([]domain.OrderReturn, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	result := make([]domain.OrderReturn, 0)
+	for _, ret := range r.returns {
+		if ret.OrderID == orderID {
+			result = append(result, cloneReturn(ret))
+		}
+	}
+	sort.Slice(result, func(i, j int) bool {
+		if result[i].CreatedAt.Equal(result[j].CreatedAt) {
+			return result[i].ID < result[j].ID
+		}
+		return result[i].CreatedAt.Before(result[j].CreatedAt)
+	})
+	return result, nil
+}
+
+func (r *returnRepositoryInMemory) Advance(id string, from, to domain.ReturnStatus) (domain.OrderReturn, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	ret, ok := r.returns[id]
+	if !ok {
+		return domain.OrderReturn{}, domain.ErrReturnNotFound
+	}
+	if ret.Status != from {
+		return domain.OrderReturn{}, domain.ErrReturnStatusConflict
+	}
+	ret.Status = to
+	ret.UpdatedAt = time.Now().UTC()
+	r.returns[id] = ret
+	return cloneReturn(ret), nil
+}
+
+func cloneReturn(ret domain.OrderReturn) domain.OrderReturn {
+	ret.Items = slices.Clone(ret.Items)
+	return ret
+}
+
+var _ domain.ReturnRepository = (*returnRepositoryInMemory)(nil)
@@ -0,0 +1,65 @@
+package memory
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/vladislavdragonenkov/oms/internal/domain"
+)
+
+func TestReturnRepository_CreateAndAdvance(t *testing.T) {
+	repo := NewReturnRepository()
+
+	first, err := repo.Create(domain.OrderReturn{
+		OrderID:     "order-1",
+		Status:      domain.ReturnStatusRefunded,
+		Items:       []domain.ReturnItem{{ItemID: "item-1", SKU: "sku-1", Qty: 1, RefundMinor: 100}},
+		RefundMinor: 100,
+		Currency:    "USD",
+	}, 0)
+	if err != nil {
+		t.Fatalf("create: %v", err)
+	}
+	if first.ID == "" || first.Status != domain.ReturnStatusRequested {
+		t.Fatalf("expected generated id and requested status, got %+v", first)
+	}
+	second, err := repo.Create(domain.OrderReturn{OrderID: "order-1", Items: []domain.ReturnItem{{ItemID: "item-2", Qty: 1}}}, 1)
+	if err != nil {
+		t.Fatalf("create: %v", err)
+	}
+	// Возврат, проверенный против устаревшего списка, не сохраняется.
+	if _, err := repo.Create(domain.OrderReturn{OrderID: "order-1", Items: []domain.ReturnItem{{ItemID: "item-2", Qty: 1}}}, 1); !errors.Is(err, domain.ErrReturnsChanged) {
+		t.Fatalf("expected ErrReturnsChanged, got %v", err)
+	}
+
+	first.Items[0].Qty = 5
+	got, err := repo.Get(first.ID)
+	if err != nil {
+		t.Fatalf("get: %v", err)
+	}
+	if got.Items[0].Qty != 1 {
+		t.Fatalf("stored items must not alias caller slice, got %+v", got.Items)
+	}
+
+	approved, err := repo.Advance(first.ID, domain.ReturnStatusRequested, domain.ReturnStatusApproved)
+	if err != nil || approved.Status != domain.ReturnStatusApproved {
+		t.Fatalf("advance: %+v, %v", approved, err)
+	}
+	if _, err := repo.Advance(first.ID, domain.ReturnStatusRequested, domain.ReturnStatusApproved); !errors.Is(err, domain.ErrReturnStatusConflict) {
+		t.Fatalf("expected ErrReturnStatusConflict, got %v", err)
+	}
+	if _, err := repo.Advance("missing", domain.ReturnStatusRequested, domain.ReturnStatusApproved); !errors.Is(err, domain.ErrReturnNotFound) {
+		t.Fatalf("expected ErrReturnNotFound, got %v", err)
+	}
+
+	list, err := repo.ListByOrder("order-1")
+	if err != nil {
+		t.Fatalf("list by order: %v", err)
+	}
+	if len(list) != 2 || list[0].ID != first.ID || list[1].ID != second.ID || list[0].Status != domain.ReturnStatusApproved {
+		t.Fatalf("unexpected returns of order: %+v", list)
+	}
+	if other, _ := repo.ListByOrder("order-2"); len(other) != 0 {
+		t.Fatalf("expected no returns for other order, got %+v", other)
+	}
+}
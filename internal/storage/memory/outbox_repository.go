@@ -1,6 +1,7 @@
 package memory
 
 import (
+	"maps"
 	"sort"
 	"sync"
 	"time"
@@ -40,6 +41,7 @@ func (r *outboxRepositoryInMemory) Enqueue(msg domain.OutboxMessage) (domain.Out
 	if msg.ID == "" {
 		msg.ID = uuid.NewString()
 	}
+	msg.Headers = maps.Clone(msg.Headers)
 	now := time.Now().UTC()
 	record := &outboxRecord{
 		msg:       msg,
@@ -51,6 +53,31 @@ func (r *outboxRepositoryInMemory) Enqueue(msg domain.OutboxMessage) (domain.Out
 	return msg, nil
 }
 
+// EnqueueBatch сохраняет сообщения под одной блокировкой. createdAt сдвигается на 1µs на
+// сообщение: порядок (created_at, id) в PullPending совпадает с порядком в msgs.
+func (r *outboxRepositoryInMemory) EnqueueBatch(msgs []domain.OutboxMessage) ([]domain.OutboxMessage, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	now := time.Now().UTC()
+	stored := make([]domain.OutboxMessage, 0, len(msgs))
+	for i, msg := range msgs {
+		if msg.ID == "" {
+			msg.ID = uuid.NewString()
+		}
+		msg.Headers = maps.Clone(msg.Headers)
+		createdAt := now.Add(time.Duration(i) * time.Microsecond)
+		r.records[msg.ID] = &outboxRecord{
+			msg:       msg,
+			status:    "pending",
+			createdAt: createdAt,
+			updatedAt: createdAt,
+		}
+		stored = append(stored, msg)
+	}
+	return stored, nil
+}
+
 // PullPending атомарно claim'ит сообщения в обработку и возвращает до limit записей backlog.
 // Также повторно выдаёт "зависшие" processing-записи после истечения lease.
 func (r *outboxRepositoryInMemory) PullPending(limit int) ([]domain.OutboxMessage, error) {
@@ -84,7 +111,9 @@ func (r *outboxRepositoryInMemory) PullPending(limit int) ([]domain.OutboxMessag
 		}
 		rec.status = "processing"
 		rec.updatedAt = now
-		result = append(result, rec.msg)
+		msg := rec.msg
+		msg.CreatedAt = rec.createdAt
+		result = append(result, msg)
 		if len(result) >= limit {
 			break
 		}
@@ -142,18 +171,135 @@ func (r *outboxRepositoryInMemory) MarkFailed(id string) error {
 	return nil
 }
 
-// AllPending возвращает копию всех сообщений со статусом `pending` (используется в тестах).
+// DeleteSent удаляет опубликованные сообщения, отправленные не позже before (старые первыми).
+func (r *outboxRepositoryInMemory) DeleteSent(before time.Time, limit int) (int, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if before.IsZero() {
+		before = time.Now().UTC()
+	}
+
+	ids := make([]string, 0)
+	for id, rec := range r.records {
+		if rec.status == "sent" && !rec.updatedAt.After(before) {
+			ids = append(ids, id)
+		}
+	}
+	sort.Slice(ids, func(i, j int) bool {
+		return r.records[ids[i]].updatedAt.Before(r.records[ids[j]].updatedAt)
+	})
+	if limit > 0 && len(ids) > limit {
+		ids = ids[:limit]
+	}
+
+	for _, id := range ids {
+		delete(r.records, id)
+	}
+	return len(ids), nil
+}
+
+// ListSent возвращает опубликованные сообщения по фильтру в порядке (createdAt, id).
+func (r *outboxRepositoryInMemory) ListSent(filter domain.OutboxReplayFilter) ([]domain.OutboxMessage, error) {
+	return r.list(filter, true), nil
+}
+
+// ListEvents возвращает сообщения в любом статусе по фильтру в порядке (createdAt, id).
+func (r *outboxRepositoryInMemory) ListEvents(filter domain.OutboxReplayFilter) ([]domain.OutboxMessage, error) {
+	return r.list(filter, false), nil
+}
+
+func (r *outboxRepositoryInMemory) list(filter domain.OutboxReplayFilter, onlySent bool) []domain.OutboxMessage {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	limit := filter.Limit
+	if limit <= 0 {
+		limit = 100
+	}
+
+	matched := make([]domain.OutboxMessage, 0)
+	for _, rec := range r.records {
+		if (onlySent && rec.status != "sent") || !matchesReplayFilter(rec, filter) {
+			continue
+		}
+		msg := rec.msg
+		msg.Headers = maps.Clone(msg.Headers)
+		msg.CreatedAt = rec.createdAt
+		matched = append(matched, msg)
+	}
+	sort.Slice(matched, func(i, j int) bool {
+		return outboxBefore(matched[i].CreatedAt, matched[i].ID, matched[j].CreatedAt, matched[j].ID)
+	})
+	if len(matched) > limit {
+		matched = matched[:limit]
+	}
+	return matched
+}
+
+func matchesReplayFilter(rec *outboxRecord, filter domain.OutboxReplayFilter) bool {
+	switch {
+	case !filter.From.IsZero() && rec.createdAt.Before(filter.From):
+		return false
+	case !filter.To.IsZero() && !rec.createdAt.Before(filter.To):
+		return false
+	case filter.AggregateType != "" && rec.msg.AggregateType != filter.AggregateType:
+		return false
+	case filter.AggregateID != "" && rec.msg.AggregateID != filter.AggregateID:
+		return false
+	case !filter.AfterCreatedAt.IsZero() && !outboxBefore(filter.AfterCreatedAt, filter.AfterID, rec.createdAt, rec.msg.ID):
+		return false
+	}
+	return true
+}
+
+func outboxBefore(leftAt time.Time, leftID string, rightAt time.Time, rightID string) bool {
+	if !leftAt.Equal(rightAt) {
+		return leftAt.Before(rightAt)
+	}
+	return leftID < rightID
+}
+
+// DeleteByAggregate удаляет сообщения агрегата в любом статусе.
+func (r *outboxRepositoryInMemory) DeleteByAggregate(aggregateType, aggregateID string) (int, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	removed := 0
+	for id, rec := range r.records {
+		if rec.msg.AggregateType == aggregateType && rec.msg.AggregateID == aggregateID {
+			delete(r.records, id)
+			removed++
+		}
+	}
+	return removed, nil
+}
+
+// AllPending возвращает копию всех сообщений со статусом `pending` в порядке (createdAt, id),
+// как их выдаёт PullPending (используется в тестах).
 func (r *outboxRepositoryInMemory) AllPending() []domain.OutboxMessage {
 	r.mu.RLock()
 	defer r.mu.RUnlock()
 
-	result := make([]domain.OutboxMessage, 0, len(r.records))
+	pending := make([]*outboxRecord, 0, len(r.records))
 	for _, rec := range r.records {
 		if rec.status == "pending" {
-			result = append(result, rec.msg)
+			pending = append(pending, rec)
 		}
 	}
+	sort.Slice(pending, func(i, j int) bool {
+		return outboxBefore(pending[i].createdAt, pending[i].msg.ID, pending[j].createdAt, pending[j].msg.ID)
+	})
+	result := make([]domain.OutboxMessage, 0, len(pending))
+	for _, rec := range pending {
+		result = append(result, rec.msg)
+	}
 	return result
 }
 
-var _ domain.OutboxRepository = (*outboxRepositoryInMemory)(nil)
+var (
+	_ domain.OutboxRepository       = (*outboxRepositoryInMemory)(nil)
+	_ domain.OutboxReplaySource     = (*outboxRepositoryInMemory)(nil)
+	_ domain.OutboxFeedSource       = (*outboxRepositoryInMemory)(nil)
+	_ domain.OutboxAggregateDeleter = (*outboxRepositoryInMemory)(nil)
+)
@@ -15,6 +15,7 @@ func TestOutboxRepository_EnqueueAndPull(t *testing.T) {
 		AggregateID:   "order-1",
 		EventType:     "OrderStatusChanged",
 		Payload:       []byte(`{"status":"pending"}`),
+		Headers:       map[string]string{"traceparent": "00-trace-span-01"},
 	}
 
 	saved, err := repo.Enqueue(msg)
@@ -35,6 +36,45 @@ func TestOutboxRepository_EnqueueAndPull(t *testing.T) {
 	if pending[0].ID != saved.ID {
 		t.Fatalf("expected same message id, got %s", pending[0].ID)
 	}
+	if pending[0].Headers["traceparent"] != "00-trace-span-01" {
+		t.Fatalf("expected headers to round-trip, got %v", pending[0].Headers)
+	}
+}
+
+func TestOutboxRepository_EnqueueBatchKeepsOrder(t *testing.T) {
+	repo := NewOutboxRepository()
+
+	headers := map[string]string{"traceparent": "00-trace-span-01"}
+	msgs := []domain.OutboxMessage{
+		{ID: "z-first", AggregateID: "order-1", EventType: "OrderStatusChanged", Headers: headers},
+		{AggregateID: "order-1", EventType: "OrderCanceled", Headers: headers},
+		{ID: "a-last", AggregateID: "order-1", EventType: "OrderSagaFailed"},
+	}
+	stored, err := repo.EnqueueBatch(msgs)
+	if err != nil {
+		t.Fatalf("enqueue batch failed: %v", err)
+	}
+	if len(stored) != 3 || stored[0].ID != "z-first" || stored[1].ID == "" || stored[2].ID != "a-last" {
+		t.Fatalf("unexpected stored messages %+v", stored)
+	}
+	headers["traceparent"] = "changed"
+
+	pending, err := repo.PullPending(10)
+	if err != nil {
+		t.Fatalf("pull pending failed: %v", err)
+	}
+	if len(pending) != 3 {
+		t.Fatalf("expected 3 pending messages, got %d", len(pending))
+	}
+	// ID не влияют на порядок: сообщения пакета выдаются так, как были переданы.
+	for i, msg := range pending {
+		if msg.ID != stored[i].ID {
+			t.Fatalf("message %d: expected %s, got %s", i, stored[i].ID, msg.ID)
+		}
+	}
+	if pending[0].Headers["traceparent"] != "00-trace-span-01" {
+		t.Fatalf("headers must be copied on enqueue, got %v", pending[0].Headers)
+	}
 }
 
 func TestOutboxRepository_MarkSentAndFailed(t *testing.T) {
@@ -202,3 +242,97 @@ func TestOutboxRepository_AllPending(t *testing.T) {
 		t.Fatalf("claimed message must not stay pending: %+v", remaining[0])
 	}
 }
+
+func TestOutboxRepository_DeleteSentKeepsUnsentAndFailed(t *testing.T) {
+	repo := NewOutboxRepository()
+
+	ids := make([]string, 0, 4)
+	for _, aggregateID := range []string{"order-sent-1", "order-sent-2", "order-failed", "order-pending"} {
+		saved, err := repo.Enqueue(domain.OutboxMessage{
+			AggregateType: "order",
+			AggregateID:   aggregateID,
+			EventType:     "OrderCreated",
+		})
+		if err != nil {
+			t.Fatalf("enqueue failed: %v", err)
+		}
+		ids = append(ids, saved.ID)
+	}
+
+	if err := repo.MarkSent(ids[0]); err != nil {
+		t.Fatalf("mark sent failed: %v", err)
+	}
+	if err := repo.MarkSent(ids[1]); err != nil {
+		t.Fatalf("mark sent failed: %v", err)
+	}
+	if err := repo.MarkFailed(ids[2]); err != nil {
+		t.Fatalf("mark failed failed: %v", err)
+	}
+
+	if deleted, err := repo.DeleteSent(time.Now().UTC().Add(-time.Hour), 10); err != nil || deleted != 0 {
+		t.Fatalf("expected recent sent messages to be kept, got deleted=%d err=%v", deleted, err)
+	}
+
+	deleted, err := repo.DeleteSent(time.Now().UTC().Add(time.Second), 1)
+	if err != nil {
+		t.Fatalf("delete sent failed: %v", err)
+	}
+	if deleted != 1 {
+		t.Fatalf("expected limit to be respected, got %d", deleted)
+	}
+	if deleted, err = repo.DeleteSent(time.Now().UTC().Add(time.Second), 0); err != nil || deleted != 1 {
+		t.Fatalf("expected remaining sent message to be deleted, got deleted=%d err=%v", deleted, err)
+	}
+
+	if len(repo.records) != 2 {
+		t.Fatalf("expected failed and pending messages to survive, got %d records", len(repo.records))
+	}
+	if repo.records[ids[2]].status != "failed" || repo.records[ids[3]].status != "pending" {
+		t.Fatal("unexpected surviving records")
+	}
+}
+
+func TestOutboxRepository_ListSentFiltersAndPages(t *testing.T) {
+	repo := NewOutboxRepository()
+	base := time.Date(2026, 5, 1, 10, 0, 0, 0, time.UTC)
+
+	ids := make([]string, 0, 4)
+	for i, aggregateID := range []string{"order-1", "order-2", "order-1", "order-pending"} {
+		saved, err := repo.Enqueue(domain.OutboxMessage{
+			AggregateType: "order",
+			AggregateID:   aggregateID,
+			EventType:     "OrderCreated",
+			Headers:       map[string]string{"traceparent": "trace"},
+		})
+		if err != nil {
+			t.Fatalf("enqueue failed: %v", err)
+		}
+		repo.records[saved.ID].createdAt = base.Add(time.Duration(i) * time.Minute)
+		ids = append(ids, saved.ID)
+	}
+	for _, id := range ids[:3] {
+		if err := repo.MarkSent(id); err != nil {
+			t.Fatalf("mark sent failed: %v", err)
+		}
+	}
+
+	page, err := repo.ListSent(domain.OutboxReplayFilter{Limit: 2})
+	if err != nil {
+		t.Fatalf("list sent failed: %v", err)
+	}
+	if len(page) != 2 || page[0].ID != ids[0] || page[1].ID != ids[1] || page[0].Headers["traceparent"] != "trace" {
+		t.Fatalf("unexpected first page: %+v", page)
+	}
+	next, err := repo.ListSent(domain.OutboxReplayFilter{Limit: 2, AfterCreatedAt: page[1].CreatedAt, AfterID: page[1].ID})
+	if err != nil || len(next) != 1 || next[0].ID != ids[2] {
+		t.Fatalf("expected only the last sent message on the next page, got %+v (%v)", next, err)
+	}
+
+	byAggregate, err := repo.ListSent(domain.OutboxReplayFilter{AggregateID: "order-1", From: base.Add(time.Minute)})
+	if err != nil || len(byAggregate) != 1 || byAggregate[0].ID != ids[2] {
+		t.Fatalf("expected aggregate and time filters to apply, got %+v (%v)", byAggregate, err)
+	}
+	if window, _ := repo.ListSent(domain.OutboxReplayFilter{From: base, To: base.Add(time.Minute)}); len(window) != 1 || window[0].ID != ids[0] {
+		t.Fatalf("expected To to be exclusive, got %+v", window)
+	}
+}
@@ -0,0 +1,74 @@
+package memory
+
+import (
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/vladislavdragonenkov/oms/internal/domain"
+)
+
+// paymentAuthorizationRepositoryInMemory хранит блокировки сумм в памяти процесса.
+type paymentAuthorizationRepositoryInMemory struct {
+	mu    sync.Mutex
+	auths map[string]domain.PaymentAuthorization
+}
+
+// NewPaymentAuthorizationRepository создаёт in-memory реализацию PaymentAuthorizationRepository.
+func NewPaymentAuthorizationRepository() domain.PaymentAuthorizationRepository {
+	return &paymentAuthorizationRepositoryInMemory{auths: make(map[string]domain.PaymentAuthorization)}
+}
+
+func (r *paymentAuthorizationRepositoryInMemory) Save(auth domain.PaymentAuthorization) error {
+	auth.AuthorizedAt = auth.AuthorizedAt.UTC()
+	auth.ExpiresAt = auth.ExpiresAt.UTC()
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.auths[auth.OrderID] = auth
+	return nil
+}
+
+func (r *paymentAuthorizationRepositoryInMemory) Get(orderID string) (domain.PaymentAuthorization, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	auth, ok := r.auths[orderID]
+	if !ok {
+		return domain.PaymentAuthorization{}, domain.ErrPaymentAuthorizationNotFound
+	}
+	return auth, nil
+}
+
+func (r *paymentAuthorizationRepositoryInMemory) Delete(orderID string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	delete(r.auths, orderID)
+	return nil
+}
+
+func (r *paymentAuthorizationRepositoryInMemory) ListExpiring(before time.Time, limit int) ([]domain.PaymentAuthorization, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	result := make([]domain.PaymentAuthorization, 0)
+	for _, auth := range r.auths {
+		if !auth.ExpiresAt.After(before) {
+			result = append(result, auth)
+		}
+	}
+	sort.Slice(result, func(i, j int) bool {
+		if result[i].ExpiresAt.Equal(result[j].ExpiresAt) {
+			return result[i].OrderID < result[j].OrderID
+		}
+		return result[i].ExpiresAt.Before(result[j].ExpiresAt)
+	})
+	if limit > 0 && len(result) > limit {
+		result = result[:limit]
+	}
+	return result, nil
+}
+
+var _ domain.PaymentAuthorizationRepository = (*paymentAuthorizationRepositoryInMemory)(nil)
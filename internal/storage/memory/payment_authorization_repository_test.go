@@ -0,0 +1,54 @@
+package memory
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/vladislavdragonenkov/oms/internal/domain"
+)
+
+func TestPaymentAuthorizationRepository_SaveAndListExpiring(t *testing.T) {
+	repo := NewPaymentAuthorizationRepository()
+	now := time.Now().UTC()
+
+	for _, auth := range []domain.PaymentAuthorization{
+		{OrderID: "order-2", AmountMinor: 200, Currency: "USD", AuthorizedAt: now, ExpiresAt: now.Add(time.Hour)},
+		{OrderID: "order-1", AmountMinor: 100, Currency: "USD", AuthorizedAt: now, ExpiresAt: now.Add(-time.Minute)},
+		{OrderID: "order-3", AmountMinor: 300, Currency: "USD", AuthorizedAt: now, ExpiresAt: now.Add(48 * time.Hour)},
+	} {
+		if err := repo.Save(auth); err != nil {
+			t.Fatalf("save %s: %v", auth.OrderID, err)
+		}
+	}
+
+	expiring, err := repo.ListExpiring(now.Add(2*time.Hour), 0)
+	if err != nil {
+		t.Fatalf("list expiring: %v", err)
+	}
+	if len(expiring) != 2 || expiring[0].OrderID != "order-1" || expiring[1].OrderID != "order-2" {
+		t.Fatalf("expected authorizations ordered by expiry, got %+v", expiring)
+	}
+	if limited, _ := repo.ListExpiring(now.Add(2*time.Hour), 1); len(limited) != 1 || limited[0].OrderID != "order-1" {
+		t.Fatalf("expected limit to keep the earliest, got %+v", limited)
+	}
+
+	// Повторная авторизация заменяет запись заказа.
+	if err := repo.Save(domain.PaymentAuthorization{OrderID: "order-1", ExpiresAt: now.Add(72 * time.Hour), Reauthorizations: 1}); err != nil {
+		t.Fatalf("resave: %v", err)
+	}
+	got, err := repo.Get("order-1")
+	if err != nil || got.Reauthorizations != 1 || !got.ExpiresAt.Equal(now.Add(72*time.Hour)) {
+		t.Fatalf("expected replaced authorization, got %+v, %v", got, err)
+	}
+
+	if err := repo.Delete("order-1"); err != nil {
+		t.Fatalf("delete: %v", err)
+	}
+	if err := repo.Delete("order-1"); err != nil {
+		t.Fatalf("delete must be idempotent: %v", err)
+	}
+	if _, err := repo.Get("order-1"); !errors.Is(err, domain.ErrPaymentAuthorizationNotFound) {
+		t.Fatalf("expected ErrPaymentAuthorizationNotFound, got %v", err)
+	}
+}
@@ -0,0 +1,83 @@
+package memory
+
+import (
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/vladislavdragonenkov/oms/internal/domain"
+)
+
+// paymentRetryRepositoryInMemory хранит повторы оплаты в памяти процесса.
+type paymentRetryRepositoryInMemory struct {
+	mu      sync.Mutex
+	retries map[string]domain.PaymentRetry
+	claimed map[string]time.Time
+}
+
+// NewPaymentRetryRepository создаёт in-memory реализацию PaymentRetryRepository.
+func NewPaymentRetryRepository() domain.PaymentRetryRepository {
+	return &paymentRetryRepositoryInMemory{
+		retries: make(map[string]domain.PaymentRetry),
+		claimed: make(map[string]time.Time),
+	}
+}
+
+func (r *paymentRetryRepositoryInMemory) Save(retry domain.PaymentRetry) error {
+	retry.RunAt = retry.RunAt.UTC()
+	retry.UpdatedAt = retry.UpdatedAt.UTC()
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.retries[retry.OrderID] = retry
+	delete(r.claimed, retry.OrderID)
+	return nil
+}
+
+func (r *paymentRetryRepositoryInMemory) Get(orderID string) (domain.PaymentRetry, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	retry, ok := r.retries[orderID]
+	if !ok {
+		return domain.PaymentRetry{}, domain.ErrPaymentRetryNotFound
+	}
+	return retry, nil
+}
+
+func (r *paymentRetryRepositoryInMemory) Delete(orderID string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	delete(r.retries, orderID)
+	delete(r.claimed, orderID)
+	return nil
+}
+
+func (r *paymentRetryRepositoryInMemory) ListDue(now time.Time, limit int) ([]domain.PaymentRetry, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	result := make([]domain.PaymentRetry, 0)
+	for _, retry := range r.retries {
+		if !retry.RunAt.After(now) && !r.claimed[retry.OrderID].After(now) {
+			result = append(result, retry)
+		}
+	}
+	sort.Slice(result, func(i, j int) bool {
+		if result[i].RunAt.Equal(result[j].RunAt) {
+			return result[i].OrderID < result[j].OrderID
+		}
+		return result[i].RunAt.Before(result[j].RunAt)
+	})
+	if limit > 0 && len(result) > limit {
+		result = result[:limit]
+	}
+	for _, retry := range result {
+		r.claimed[retry.OrderID] = now.Add(dueTaskLease)
+	}
+	return result, nil
+}
+
+var _ domain.PaymentRetryRepository = (*paymentRetryRepositoryInMemory)(nil)
@@ -0,0 +1,60 @@
+package memory
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/vladislavdragonenkov/oms/internal/domain"
+)
+
+func TestPaymentRetryRepository_SaveAndListDue(t *testing.T) {
+	repo := NewPaymentRetryRepository()
+	now := time.Now().UTC()
+
+	for _, retry := range []domain.PaymentRetry{
+		{OrderID: "order-2", Attempt: 1, RunAt: now.Add(-time.Second)},
+		{OrderID: "order-1", Attempt: 2, RunAt: now.Add(-time.Minute)},
+		{OrderID: "order-3", Attempt: 1, RunAt: now.Add(time.Hour)},
+	} {
+		if err := repo.Save(retry); err != nil {
+			t.Fatalf("save %s: %v", retry.OrderID, err)
+		}
+	}
+
+	if limited, _ := repo.ListDue(now, 1); len(limited) != 1 || limited[0].OrderID != "order-1" {
+		t.Fatalf("expected limit to keep the earliest, got %+v", limited)
+	}
+	due, err := repo.ListDue(now, 0)
+	if err != nil {
+		t.Fatalf("list due: %v", err)
+	}
+	if len(due) != 1 || due[0].OrderID != "order-2" {
+		t.Fatalf("expected claimed retry to be skipped, got %+v", due)
+	}
+	if again, _ := repo.ListDue(now, 0); len(again) != 0 {
+		t.Fatalf("claimed retries must not be listed again, got %+v", again)
+	}
+	if expired, _ := repo.ListDue(now.Add(3*time.Minute), 0); len(expired) != 2 || expired[0].OrderID != "order-1" {
+		t.Fatalf("expected retries ordered by run time after the claim expires, got %+v", expired)
+	}
+
+	// Следующая попытка заменяет запись заказа.
+	if err := repo.Save(domain.PaymentRetry{OrderID: "order-1", Attempt: 3, RunAt: now.Add(time.Hour), LastError: "psp timeout"}); err != nil {
+		t.Fatalf("resave: %v", err)
+	}
+	got, err := repo.Get("order-1")
+	if err != nil || got.Attempt != 3 || got.LastError != "psp timeout" {
+		t.Fatalf("expected replaced retry, got %+v, %v", got, err)
+	}
+
+	if err := repo.Delete("order-1"); err != nil {
+		t.Fatalf("delete: %v", err)
+	}
+	if err := repo.Delete("order-1"); err != nil {
+		t.Fatalf("delete must be idempotent: %v", err)
+	}
+	if _, err := repo.Get("order-1"); !errors.Is(err, domain.ErrPaymentRetryNotFound) {
+		t.Fatalf("expected ErrPaymentRetryNotFound, got %v", err)
+	}
+}
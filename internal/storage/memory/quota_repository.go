@@ -0,0 +1,84 @@
+package memory
+
+import (
+	"sync"
+	"time"
+
+	"github.com/vladislavdragonenkov/oms/internal/domain"
+)
+
+type quotaUsageKey struct {
+	principal string
+	day       time.Time
+}
+
+// quotaRepositoryInMemory считает расход квот в памяти процесса; при нескольких репликах
+// каждая видит только свой расход, поэтому жёсткие лимиты требуют postgres.
+type quotaRepositoryInMemory struct {
+	mu    sync.Mutex
+	usage map[quotaUsageKey]domain.QuotaUsage
+}
+
+// NewQuotaRepository создаёт in-memory реализацию QuotaRepository.
+func NewQuotaRepository() domain.QuotaRepository {
+	return &quotaRepositoryInMemory{usage: make(map[quotaUsageKey]domain.QuotaUsage)}
+}
+
+func (r *quotaRepositoryInMemory) Consume(principal string, day time.Time, currency string, amountMinor int64, quota domain.OrderQuota) (domain.QuotaUsage, error) {
+	key := quotaUsageKey{principal: principal, day: domain.QuotaDay(day)}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	usage := r.load(key)
+	if err := quota.Check(usage, currency, amountMinor); err != nil {
+		return usage, err
+	}
+	usage.Orders++
+	usage.AmountMinor[currency] += amountMinor
+	r.usage[key] = usage
+	return copyQuotaUsage(usage), nil
+}
+
+func (r *quotaRepositoryInMemory) Release(principal string, day time.Time, currency string, amountMinor int64) error {
+	key := quotaUsageKey{principal: principal, day: domain.QuotaDay(day)}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	usage, ok := r.usage[key]
+	if !ok {
+		return nil
+	}
+	usage.Orders = max(usage.Orders-1, 0)
+	usage.AmountMinor[currency] = max(usage.AmountMinor[currency]-amountMinor, 0)
+	r.usage[key] = usage
+	return nil
+}
+
+func (r *quotaRepositoryInMemory) Usage(principal string, day time.Time) (domain.QuotaUsage, error) {
+	key := quotaUsageKey{principal: principal, day: domain.QuotaDay(day)}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	return copyQuotaUsage(r.load(key)), nil
+}
+
+func (r *quotaRepositoryInMemory) load(key quotaUsageKey) domain.QuotaUsage {
+	if usage, ok := r.usage[key]; ok {
+		return usage
+	}
+	return domain.QuotaUsage{Principal: key.principal, Day: key.day, AmountMinor: make(map[string]int64)}
+}
+
+func copyQuotaUsage(usage domain.QuotaUsage) domain.QuotaUsage {
+	amounts := make(map[string]int64, len(usage.AmountMinor))
+	for currency, amount := range usage.AmountMinor {
+		amounts[currency] = amount
+	}
+	usage.AmountMinor = amounts
+	return usage
+}
+
+var _ domain.QuotaRepository = (*quotaRepositoryInMemory)(nil)
@@ -0,0 +1,85 @@
+package memory
+
+import (
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/vladislavdragonenkov/oms/internal/domain"
+)
+
+func TestQuotaRepository_ConsumeReleaseUsage(t *testing.T) {
+	repo := NewQuotaRepository()
+	day := time.Date(2026, 10, 16, 15, 30, 0, 0, time.UTC)
+	quota := domain.OrderQuota{MaxOrders: 3, MaxAmountMinor: map[string]int64{"RUB": 1000}}
+
+	if _, err := repo.Consume("partner-a", day, "RUB", 600, quota); err != nil {
+		t.Fatalf("consume: %v", err)
+	}
+	_, err := repo.Consume("partner-a", day, "RUB", 500, quota)
+	var exceeded *domain.QuotaExceededError
+	if !errors.As(err, &exceeded) || !errors.Is(err, domain.ErrQuotaExceeded) {
+		t.Fatalf("expected amount quota error, got %v", err)
+	}
+	if exceeded.Limit != domain.QuotaLimitAmount || exceeded.Used != 600 || exceeded.Max != 1000 || exceeded.Currency != "RUB" {
+		t.Fatalf("unexpected quota error: %+v", exceeded)
+	}
+
+	// Валюта без лимита суммы ограничена только числом заказов.
+	if _, err := repo.Consume("partner-a", day, "USD", 1_000_000, quota); err != nil {
+		t.Fatalf("consume usd: %v", err)
+	}
+	if _, err := repo.Consume("partner-a", day.Add(time.Hour), "RUB", 400, quota); err != nil {
+		t.Fatalf("consume up to amount limit: %v", err)
+	}
+	if _, err := repo.Consume("partner-a", day, "RUB", 0, quota); !errors.As(err, &exceeded) || exceeded.Limit != domain.QuotaLimitOrders {
+		t.Fatalf("expected orders quota error, got %v", err)
+	}
+
+	if err := repo.Release("partner-a", day, "RUB", 400); err != nil {
+		t.Fatalf("release: %v", err)
+	}
+	usage, err := repo.Usage("partner-a", day)
+	if err != nil {
+		t.Fatalf("usage: %v", err)
+	}
+	if usage.Orders != 2 || usage.AmountMinor["RUB"] != 600 || usage.AmountMinor["USD"] != 1_000_000 {
+		t.Fatalf("unexpected usage: %+v", usage)
+	}
+	if !usage.Day.Equal(domain.QuotaDay(day)) {
+		t.Fatalf("usage day must be truncated to UTC midnight, got %s", usage.Day)
+	}
+
+	next, _ := repo.Usage("partner-a", day.Add(24*time.Hour))
+	if next.Orders != 0 {
+		t.Fatalf("quota must reset on the next day, got %+v", next)
+	}
+}
+
+func TestQuotaRepository_ConcurrentConsumeRespectsLimit(t *testing.T) {
+	repo := NewQuotaRepository()
+	day := time.Now()
+	quota := domain.OrderQuota{MaxOrders: 5}
+
+	var (
+		wg       sync.WaitGroup
+		mu       sync.Mutex
+		accepted int
+	)
+	for range 20 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if _, err := repo.Consume("partner-b", day, "RUB", 1, quota); err == nil {
+				mu.Lock()
+				accepted++
+				mu.Unlock()
+			}
+		}()
+	}
+	wg.Wait()
+	if accepted != 5 {
+		t.Fatalf("expected exactly 5 accepted orders, got %d", accepted)
+	}
+}
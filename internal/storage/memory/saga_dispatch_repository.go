@@ -0,0 +1,86 @@
+package memory
+
+import (
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/vladislavdragonenkov/oms/internal/domain"
+)
+
+// sagaDispatchRepositoryInMemory хранит намерения запуска саг в памяти процесса.
+// Переживает только Shutdown без рестарта; для настоящей защиты нужен postgres.
+type sagaDispatchRepositoryInMemory struct {
+	mu      sync.Mutex
+	intents map[string]domain.SagaDispatchIntent
+}
+
+// NewSagaDispatchRepository создаёт in-memory реализацию SagaDispatchRepository.
+func NewSagaDispatchRepository() domain.SagaDispatchRepository {
+	return &sagaDispatchRepositoryInMemory{intents: make(map[string]domain.SagaDispatchIntent)}
+}
+
+func (r *sagaDispatchRepositoryInMemory) Record(intent domain.SagaDispatchIntent) (domain.SagaDispatchIntent, error) {
+	if intent.ID == "" {
+		intent.ID = uuid.NewString()
+	}
+	if intent.RequestedAt.IsZero() {
+		intent.RequestedAt = time.Now().UTC()
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.intents[intent.ID] = intent
+	return intent, nil
+}
+
+func (r *sagaDispatchRepositoryInMemory) Complete(id string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	delete(r.intents, id)
+	return nil
+}
+
+func (r *sagaDispatchRepositoryInMemory) ClaimPending(now, until time.Time, limit int) ([]domain.SagaDispatchIntent, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	result := make([]domain.SagaDispatchIntent, 0, len(r.intents))
+	for _, intent := range r.intents {
+		if intent.ClaimedUntil.After(now) {
+			continue
+		}
+		result = append(result, intent)
+	}
+	sort.Slice(result, func(i, j int) bool {
+		if result[i].RequestedAt.Equal(result[j].RequestedAt) {
+			return result[i].ID < result[j].ID
+		}
+		return result[i].RequestedAt.Before(result[j].RequestedAt)
+	})
+	if limit > 0 && len(result) > limit {
+		result = result[:limit]
+	}
+	for i := range result {
+		result[i].ClaimedUntil = until
+		r.intents[result[i].ID] = result[i]
+	}
+	return result, nil
+}
+
+func (r *sagaDispatchRepositoryInMemory) Renew(id string, until time.Time) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if intent, ok := r.intents[id]; ok {
+		intent.ClaimedUntil = until
+		r.intents[id] = intent
+	}
+	return nil
+}
+
+var _ domain.SagaDispatchRepository = (*sagaDispatchRepositoryInMemory)(nil)
@@ -0,0 +1,57 @@
+package memory
+
+import (
+	"testing"
+	"time"
+
+	"github.com/vladislavdragonenkov/oms/internal/domain"
+)
+
+func TestSagaDispatchRepository_RecordClaimComplete(t *testing.T) {
+	repo := NewSagaDispatchRepository()
+	base := time.Now().UTC()
+
+	second, err := repo.Record(domain.SagaDispatchIntent{OrderID: "order-2", Operation: domain.SagaOperationCancel, RequestedAt: base.Add(time.Second)})
+	if err != nil {
+		t.Fatalf("record: %v", err)
+	}
+	first, err := repo.Record(domain.SagaDispatchIntent{OrderID: "order-1", Operation: domain.SagaOperationStart, RequestedAt: base})
+	if err != nil {
+		t.Fatalf("record: %v", err)
+	}
+	if first.ID == "" || second.ID == "" {
+		t.Fatal("expected generated ids")
+	}
+
+	if limited, _ := repo.ClaimPending(base, base.Add(time.Minute), 1); len(limited) != 1 || limited[0].OrderID != "order-1" {
+		t.Fatalf("expected limit to apply, got %+v", limited)
+	}
+	pending, err := repo.ClaimPending(base, base.Add(time.Minute), 0)
+	if err != nil {
+		t.Fatalf("claim pending: %v", err)
+	}
+	if len(pending) != 1 || pending[0].OrderID != "order-2" {
+		t.Fatalf("claimed intent must be skipped until its lease expires, got %+v", pending)
+	}
+	if err := repo.Renew(first.ID, base.Add(2*time.Minute)); err != nil {
+		t.Fatalf("renew: %v", err)
+	}
+	pending, _ = repo.ClaimPending(base.Add(time.Minute), base.Add(2*time.Minute), 0)
+	if len(pending) != 1 || pending[0].ID != second.ID {
+		t.Fatalf("renewed intent must stay claimed, got %+v", pending)
+	}
+
+	if err := repo.Complete(first.ID); err != nil {
+		t.Fatalf("complete: %v", err)
+	}
+	if err := repo.Complete("missing"); err != nil {
+		t.Fatalf("complete missing intent must not fail: %v", err)
+	}
+	if err := repo.Renew(second.ID, time.Time{}); err != nil {
+		t.Fatalf("release: %v", err)
+	}
+	pending, _ = repo.ClaimPending(base, base.Add(time.Minute), 0)
+	if len(pending) != 1 || pending[0].ID != second.ID {
+		t.Fatalf("unexpected pending after complete: %+v", pending)
+	}
+}
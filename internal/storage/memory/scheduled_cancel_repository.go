@@ -0,0 +1,122 @@
+package memory
+
+import (
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/vladislavdragonenkov/oms/internal/domain"
+)
+
+// dueTaskLease — на сколько ListDue закрепляет задачу за забравшим её планировщиком.
+const dueTaskLease = 2 * time.Minute
+
+// scheduledCancelRepositoryInMemory хранит отложенные отмены в памяти процесса.
+type scheduledCancelRepositoryInMemory struct {
+	mu      sync.Mutex
+	tasks   map[string]domain.ScheduledCancel
+	claimed map[string]time.Time
+}
+
+// NewScheduledCancelRepository создаёт in-memory реализацию ScheduledCancelRepository.
+func NewScheduledCancelRepository() domain.ScheduledCancelRepository {
+	return &scheduledCancelRepositoryInMemory{
+		tasks:   make(map[string]domain.ScheduledCancel),
+		claimed: make(map[string]time.Time),
+	}
+}
+
+func (r *scheduledCancelRepositoryInMemory) Create(task domain.ScheduledCancel) (domain.ScheduledCancel, error) {
+	if task.ID == "" {
+		task.ID = uuid.NewString()
+	}
+	now := time.Now().UTC()
+	task.CancelAt = task.CancelAt.UTC()
+	task.Status = domain.ScheduledCancelPending
+	task.Note = ""
+	task.CreatedAt = now
+	task.UpdatedAt = now
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.tasks[task.ID] = task
+	return task, nil
+}
+
+func (r *scheduledCancelRepositoryInMemory) Get(id string) (domain.ScheduledCancel, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	task, ok := r.tasks[id]
+	if !ok {
+		return domain.ScheduledCancel{}, domain.ErrScheduledCancelNotFound
+	}
+	return task, nil
+}
+
+func (r *scheduledCancelRepositoryInMemory) ListByOrder(orderID string) ([]domain.ScheduledCancel, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	result := make([]domain.ScheduledCancel, 0)
+	for _, task := range r.tasks {
+		if task.OrderID == orderID {
+			result = append(result, task)
+		}
+	}
+	sortScheduledCancels(result)
+	return result, nil
+}
+
+func (r *scheduledCancelRepositoryInMemory) ListDue(now time.Time, limit int) ([]domain.ScheduledCancel, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	result := make([]domain.ScheduledCancel, 0)
+	for _, task := range r.tasks {
+		if task.Status == domain.ScheduledCancelPending && !task.CancelAt.After(now) && !r.claimed[task.ID].After(now) {
+			result = append(result, task)
+		}
+	}
+	sortScheduledCancels(result)
+	if limit > 0 && len(result) > limit {
+		result = result[:limit]
+	}
+	for _, task := range result {
+		r.claimed[task.ID] = now.Add(dueTaskLease)
+	}
+	return result, nil
+}
+
+func (r *scheduledCancelRepositoryInMemory) Finish(id string, status domain.ScheduledCancelStatus, note string) (domain.ScheduledCancel, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	task, ok := r.tasks[id]
+	if !ok {
+		return domain.ScheduledCancel{}, domain.ErrScheduledCancelNotFound
+	}
+	if task.Status != domain.ScheduledCancelPending {
+		return domain.ScheduledCancel{}, domain.ErrScheduledCancelNotPending
+	}
+	task.Status = status
+	task.Note = note
+	task.UpdatedAt = time.Now().UTC()
+	r.tasks[id] = task
+	delete(r.claimed, id)
+	return task, nil
+}
+
+func sortScheduledCancels(tasks []domain.ScheduledCancel) {
+	sort.Slice(tasks, func(i, j int) bool {
+		if tasks[i].CancelAt.Equal(tasks[j].CancelAt) {
+			return tasks[i].ID < tasks[j].ID
+		}
+		return tasks[i].CancelAt.Before(tasks[j].CancelAt)
+	})
+}
+
+var _ domain.ScheduledCancelRepository = (*scheduledCancelRepositoryInMemory)(nil)
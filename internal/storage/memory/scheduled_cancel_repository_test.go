@@ -0,0 +1,65 @@
+package memory
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/vladislavdragonenkov/oms/internal/domain"
+)
+
+func TestScheduledCancelRepository_DueAndFinish(t *testing.T) {
+	repo := NewScheduledCancelRepository()
+	now := time.Now().UTC()
+
+	later, err := repo.Create(domain.ScheduledCancel{OrderID: "order-1", CancelAt: now.Add(time.Hour), Reason: "not shipped by friday"})
+	if err != nil {
+		t.Fatalf("create: %v", err)
+	}
+	due, err := repo.Create(domain.ScheduledCancel{OrderID: "order-1", CancelAt: now.Add(-time.Minute)})
+	if err != nil {
+		t.Fatalf("create: %v", err)
+	}
+	if later.ID == "" || later.Status != domain.ScheduledCancelPending {
+		t.Fatalf("expected generated id and pending status, got %+v", later)
+	}
+
+	list, err := repo.ListByOrder("order-1")
+	if err != nil {
+		t.Fatalf("list by order: %v", err)
+	}
+	if len(list) != 2 || list[0].ID != due.ID || list[1].ID != later.ID {
+		t.Fatalf("expected tasks ordered by cancel_at, got %+v", list)
+	}
+
+	dueTasks, err := repo.ListDue(now, 0)
+	if err != nil {
+		t.Fatalf("list due: %v", err)
+	}
+	if len(dueTasks) != 1 || dueTasks[0].ID != due.ID {
+		t.Fatalf("expected only the overdue task, got %+v", dueTasks)
+	}
+	if again, _ := repo.ListDue(now, 0); len(again) != 0 {
+		t.Fatalf("claimed task must not be listed again, got %+v", again)
+	}
+	if again, _ := repo.ListDue(now.Add(3*time.Minute), 0); len(again) != 1 || again[0].ID != due.ID {
+		t.Fatalf("task must be listed again after the claim expires, got %+v", again)
+	}
+
+	finished, err := repo.Finish(due.ID, domain.ScheduledCancelSkipped, "order already confirmed")
+	if err != nil {
+		t.Fatalf("finish: %v", err)
+	}
+	if finished.Status != domain.ScheduledCancelSkipped || finished.Note != "order already confirmed" {
+		t.Fatalf("unexpected finished task: %+v", finished)
+	}
+	if _, err := repo.Finish(due.ID, domain.ScheduledCancelExecuted, ""); !errors.Is(err, domain.ErrScheduledCancelNotPending) {
+		t.Fatalf("expected ErrScheduledCancelNotPending, got %v", err)
+	}
+	if _, err := repo.Finish("missing", domain.ScheduledCancelCanceled, ""); !errors.Is(err, domain.ErrScheduledCancelNotFound) {
+		t.Fatalf("expected ErrScheduledCancelNotFound, got %v", err)
+	}
+	if dueTasks, _ = repo.ListDue(now.Add(2*time.Hour), 0); len(dueTasks) != 1 || dueTasks[0].ID != later.ID {
+		t.Fatalf("finished tasks must not be due again, got %+v", dueTasks)
+	}
+}
@@ -0,0 +1,231 @@
+package memory
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"sort"
+	"time"
+
+	"github.com/vladislavdragonenkov/oms/internal/domain"
+	"github.com/vladislavdragonenkov/oms/internal/fileutil"
+)
+
+// snapshotVersion — версия формата файла; файл другой версии не загружается.
+const snapshotVersion = 1
+
+// ErrSnapshotUnsupported — репозиторий не in-memory или файл снимка другой версии.
+var ErrSnapshotUnsupported = errors.New("memory: snapshot unsupported")
+
+// SnapshotRepositories — репозитории, состояние которых сохраняется в снимок dev-режима.
+// Все поля должны быть созданы конструкторами этого пакета.
+type SnapshotRepositories struct {
+	Orders      domain.OrderRepository
+	Timeline    domain.TimelineRepository
+	Outbox      domain.OutboxRepository
+	Idempotency domain.IdempotencyRepository
+}
+
+// Snapshot — содержимое in-memory репозиториев в виде, пригодном для JSON.
+type Snapshot struct {
+	Version     int                        `json:"version"`
+	TakenAt     time.Time                  `json:"taken_at"`
+	Orders      []domain.Order             `json:"orders"`
+	Timeline    []domain.TimelineEvent     `json:"timeline"`
+	Outbox      []OutboxSnapshotRecord     `json:"outbox"`
+	Idempotency []domain.IdempotencyRecord `json:"idempotency"`
+}
+
+// OutboxSnapshotRecord — запись outbox вместе со статусом доставки.
+type OutboxSnapshotRecord struct {
+	Message   domain.OutboxMessage `json:"message"`
+	Status    string               `json:"status"`
+	Attempts  int                  `json:"attempts"`
+	CreatedAt time.Time            `json:"created_at"`
+	UpdatedAt time.Time            `json:"updated_at"`
+}
+
+type snapshotTargets struct {
+	orders      *orderRepositoryInMemory
+	timeline    *timelineRepositoryInMemory
+	outbox      *outboxRepositoryInMemory
+	idempotency *idempotencyRepositoryInMemory
+}
+
+func (r SnapshotRepositories) targets() (snapshotTargets, error) {
+	var (
+		t  snapshotTargets
+		ok bool
+	)
+	if t.orders, ok = r.Orders.(*orderRepositoryInMemory); !ok {
+		return snapshotTargets{}, fmt.Errorf("%w: orders repository %T", ErrSnapshotUnsupported, r.Orders)
+	}
+	if t.timeline, ok = r.Timeline.(*timelineRepositoryInMemory); !ok {
+		return snapshotTargets{}, fmt.Errorf("%w: timeline repository %T", ErrSnapshotUnsupported, r.Timeline)
+	}
+	if t.outbox, ok = r.Outbox.(*outboxRepositoryInMemory); !ok {
+		return snapshotTargets{}, fmt.Errorf("%w: outbox repository %T", ErrSnapshotUnsupported, r.Outbox)
+	}
+	if t.idempotency, ok = r.Idempotency.(*idempotencyRepositoryInMemory); !ok {
+		return snapshotTargets{}, fmt.Errorf("%w: idempotency repository %T", ErrSnapshotUnsupported, r.Idempotency)
+	}
+	return t, nil
+}
+
+// TakeSnapshot копирует состояние репозиториев. Репозитории блокируются по очереди,
+// поэтому снимок согласован только при остановленной записи (на shutdown).
+func TakeSnapshot(repos SnapshotRepositories) (Snapshot, error) {
+	t, err := repos.targets()
+	if err != nil {
+		return Snapshot{}, err
+	}
+	snapshot := Snapshot{Version: snapshotVersion, TakenAt: time.Now().UTC()}
+
+	t.orders.mu.RLock()
+	for _, order := range t.orders.items {
+		snapshot.Orders = append(snapshot.Orders, order)
+	}
+	t.orders.mu.RUnlock()
+	sort.Slice(snapshot.Orders, func(i, j int) bool { return snapshot.Orders[i].ID < snapshot.Orders[j].ID })
+
+	t.timeline.mu.RLock()
+	orderIDs := make([]string, 0, len(t.timeline.events))
+	for orderID := range t.timeline.events {
+		orderIDs = append(orderIDs, orderID)
+	}
+	sort.Strings(orderIDs)
+	for _, orderID := range orderIDs {
+		snapshot.Timeline = append(snapshot.Timeline, t.timeline.events[orderID]...)
+	}
+	t.timeline.mu.RUnlock()
+
+	t.outbox.mu.RLock()
+	for _, record := range t.outbox.records {
+		snapshot.Outbox = append(snapshot.Outbox, OutboxSnapshotRecord{
+			Message:   record.msg,
+			Status:    record.status,
+			Attempts:  record.attemptCnt,
+			CreatedAt: record.createdAt,
+			UpdatedAt: record.updatedAt,
+		})
+	}
+	t.outbox.mu.RUnlock()
+	sort.Slice(snapshot.Outbox, func(i, j int) bool {
+		if snapshot.Outbox[i].CreatedAt.Equal(snapshot.Outbox[j].CreatedAt) {
+			return snapshot.Outbox[i].Message.ID < snapshot.Outbox[j].Message.ID
+		}
+		return snapshot.Outbox[i].CreatedAt.Before(snapshot.Outbox[j].CreatedAt)
+	})
+
+	t.idempotency.mu.RLock()
+	for _, record := range t.idempotency.items {
+		snapshot.Idempotency = append(snapshot.Idempotency, record)
+	}
+	t.idempotency.mu.RUnlock()
+	sort.Slice(snapshot.Idempotency, func(i, j int) bool {
+		a, b := snapshot.Idempotency[i], snapshot.Idempotency[j]
+		if a.Key != b.Key {
+			return a.Key < b.Key
+		}
+		if a.Method != b.Method {
+			return a.Method < b.Method
+		}
+		return a.CustomerID < b.CustomerID
+	})
+
+	return snapshot, nil
+}
+
+// RestoreSnapshot заменяет содержимое репозиториев данными снимка. Outbox-записи в processing
+// возвращаются в pending: воркер, который их взял, остался в прошлом процессе.
+func RestoreSnapshot(repos SnapshotRepositories, snapshot Snapshot) error {
+	if snapshot.Version != snapshotVersion {
+		return fmt.Errorf("%w: version %d, expected %d", ErrSnapshotUnsupported, snapshot.Version, snapshotVersion)
+	}
+	t, err := repos.targets()
+	if err != nil {
+		return err
+	}
+
+	orders := make(map[string]domain.Order, len(snapshot.Orders))
+	for _, order := range snapshot.Orders {
+		orders[order.ID] = order
+	}
+	t.orders.mu.Lock()
+	t.orders.items = orders
+	t.orders.mu.Unlock()
+
+	events := make(map[string][]domain.TimelineEvent)
+	for _, event := range snapshot.Timeline {
+		events[event.OrderID] = append(events[event.OrderID], event)
+	}
+	t.timeline.mu.Lock()
+	t.timeline.events = events
+	t.timeline.mu.Unlock()
+
+	records := make(map[string]*outboxRecord, len(snapshot.Outbox))
+	for _, record := range snapshot.Outbox {
+		status := record.Status
+		if status == "processing" {
+			status = "pending"
+		}
+		records[record.Message.ID] = &outboxRecord{
+			msg:        record.Message,
+			status:     status,
+			attemptCnt: record.Attempts,
+			createdAt:  record.CreatedAt,
+			updatedAt:  record.UpdatedAt,
+		}
+	}
+	t.outbox.mu.Lock()
+	t.outbox.records = records
+	t.outbox.mu.Unlock()
+
+	items := make(map[domain.IdempotencyScope]domain.IdempotencyRecord, len(snapshot.Idempotency))
+	for _, record := range snapshot.Idempotency {
+		items[record.Scope()] = record
+	}
+	t.idempotency.mu.Lock()
+	t.idempotency.items = items
+	t.idempotency.mu.Unlock()
+
+	return nil
+}
+
+// SaveSnapshotFile пишет снимок атомарно, чтобы падение посреди записи не испортило предыдущий снимок.
+func SaveSnapshotFile(path string, repos SnapshotRepositories) error {
+	snapshot, err := TakeSnapshot(repos)
+	if err != nil {
+		return err
+	}
+	data, err := json.MarshalIndent(snapshot, "", "  ")
+	if err != nil {
+		return fmt.Errorf("encode snapshot: %w", err)
+	}
+
+	if err := fileutil.WriteFileAtomic(path, data); err != nil {
+		return fmt.Errorf("save snapshot: %w", err)
+	}
+	return nil
+}
+
+// LoadSnapshotFile восстанавливает репозитории из файла; отсутствие файла — не ошибка (false).
+func LoadSnapshotFile(path string, repos SnapshotRepositories) (bool, error) {
+	data, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return false, nil
+	}
+	if err != nil {
+		return false, fmt.Errorf("read snapshot file: %w", err)
+	}
+
+	var snapshot Snapshot
+	if err := json.Unmarshal(data, &snapshot); err != nil {
+		return false, fmt.Errorf("decode snapshot file: %w", err)
+	}
+	if err := RestoreSnapshot(repos, snapshot); err != nil {
+		return false, err
+	}
+	return true, nil
+}
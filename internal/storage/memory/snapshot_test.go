@@ -0,0 +1,111 @@
+package memory
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/vladislavdragonenkov/oms/internal/domain"
+)
+
+func newSnapshotRepositories() SnapshotRepositories {
+	return SnapshotRepositories{
+		Orders:      NewOrderRepository(),
+		Timeline:    NewTimelineRepository(),
+		Outbox:      NewOutboxRepository(),
+		Idempotency: NewIdempotencyRepository(),
+	}
+}
+
+func TestSnapshotFile_SaveAndLoad(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "oms-dev.json")
+	source := newSnapshotRepositories()
+	now := time.Now().UTC().Truncate(time.Second)
+
+	order := domain.Order{
+		ID:          "o-1",
+		CustomerID:  "c-1",
+		Status:      domain.OrderStatusPaid,
+		Currency:    "RUB",
+		AmountMinor: 500,
+		Items:       []domain.OrderItem{{ID: "i-1", SKU: "sku", Qty: 1, PriceMinor: 500, CreatedAt: now}},
+		Version:     3,
+		CreatedAt:   now,
+		UpdatedAt:   now,
+	}
+	if err := source.Orders.Create(order); err != nil {
+		t.Fatal(err)
+	}
+	if err := source.Timeline.Append(domain.TimelineEvent{OrderID: "o-1", Type: "OrderStatusChanged", Reason: "paid", Occurred: now}); err != nil {
+		t.Fatal(err)
+	}
+	pending, err := source.Outbox.Enqueue(domain.OutboxMessage{AggregateType: "order", AggregateID: "o-1", EventType: "OrderPaid", Payload: []byte(`{"x":1}`)})
+	if err != nil {
+		t.Fatal(err)
+	}
+	// Запись, взятая воркером, после рестарта должна снова стать pending.
+	if claimed, err := source.Outbox.PullPending(10); err != nil || len(claimed) != 1 {
+		t.Fatalf("claim outbox: %v %v", claimed, err)
+	}
+	if _, err := source.Idempotency.CreateProcessing(domain.IdempotencyScope{Key: "key-1"}, "hash", now.Add(time.Hour)); err != nil {
+		t.Fatal(err)
+	}
+	if err := source.Idempotency.MarkDone(domain.IdempotencyScope{Key: "key-1"}, []byte(`{"ok":true}`), 200); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := SaveSnapshotFile(path, source); err != nil {
+		t.Fatalf("save: %v", err)
+	}
+	entries, _ := os.ReadDir(filepath.Dir(path))
+	if len(entries) != 1 {
+		t.Fatalf("temporary files must be cleaned up, got %d entries", len(entries))
+	}
+
+	target := newSnapshotRepositories()
+	restored, err := LoadSnapshotFile(path, target)
+	if err != nil || !restored {
+		t.Fatalf("load: restored=%v err=%v", restored, err)
+	}
+
+	got, err := target.Orders.Get("o-1")
+	if err != nil || got.Version != 3 || len(got.Items) != 1 || got.Items[0].PriceMinor != 500 || !got.CreatedAt.Equal(now) {
+		t.Fatalf("unexpected restored order: %+v, err=%v", got, err)
+	}
+	events, _ := target.Timeline.List("o-1")
+	if len(events) != 1 || events[0].Reason != "paid" {
+		t.Fatalf("unexpected restored timeline: %+v", events)
+	}
+	messages, err := target.Outbox.PullPending(10)
+	if err != nil || len(messages) != 1 || messages[0].ID != pending.ID || string(messages[0].Payload) != `{"x":1}` {
+		t.Fatalf("processing outbox record must be pending after restore: %+v, err=%v", messages, err)
+	}
+	record, err := target.Idempotency.Get(domain.IdempotencyScope{Key: "key-1"})
+	if err != nil || record.Status != domain.IdempotencyStatusDone || string(record.ResponseBody) != `{"ok":true}` {
+		t.Fatalf("unexpected restored idempotency record: %+v, err=%v", record, err)
+	}
+}
+
+func TestLoadSnapshotFile_MissingAndInvalid(t *testing.T) {
+	dir := t.TempDir()
+	restored, err := LoadSnapshotFile(filepath.Join(dir, "missing.json"), newSnapshotRepositories())
+	if err != nil || restored {
+		t.Fatalf("missing file must not be an error: restored=%v err=%v", restored, err)
+	}
+
+	future := filepath.Join(dir, "future.json")
+	if err := os.WriteFile(future, []byte(`{"version":99}`), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := LoadSnapshotFile(future, newSnapshotRepositories()); !errors.Is(err, ErrSnapshotUnsupported) {
+		t.Fatalf("expected ErrSnapshotUnsupported for unknown version, got %v", err)
+	}
+
+	repos := newSnapshotRepositories()
+	repos.Orders = nil
+	if _, err := TakeSnapshot(repos); !errors.Is(err, ErrSnapshotUnsupported) {
+		t.Fatalf("expected ErrSnapshotUnsupported for foreign repository, got %v", err)
+	}
+}
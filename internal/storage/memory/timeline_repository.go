@@ -1,10 +1,12 @@
 package memory
 
 import (
+	"fmt"
 	"sort"
 	"sync"
 
 	"github.com/vladislavdragonenkov/oms/internal/domain"
+	"github.com/vladislavdragonenkov/oms/internal/timeutil"
 )
 
 // timelineRepositoryInMemory хранит события в памяти (для разработки/тестов).
@@ -18,8 +20,15 @@ func NewTimelineRepository() domain.TimelineRepository {
 	return &timelineRepositoryInMemory{events: make(map[string][]domain.TimelineEvent)}
 }
 
-// Append добавляет событие в хранилище.
+// Append добавляет событие в хранилище. Время события приводится к UTC,
+// метка из далёкого будущего отклоняется.
 func (r *timelineRepositoryInMemory) Append(event domain.TimelineEvent) error {
+	occurred, err := timeutil.Normalize(event.Occurred)
+	if err != nil {
+		return fmt.Errorf("append timeline event: %w", err)
+	}
+	event.Occurred = occurred
+
 	r.mu.Lock()
 	defer r.mu.Unlock()
 
@@ -43,4 +52,13 @@ func (r *timelineRepositoryInMemory) List(orderID string) ([]domain.TimelineEven
 	return result, nil
 }
 
+// DeleteByOrder удаляет все события заказа.
+func (r *timelineRepositoryInMemory) DeleteByOrder(orderID string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	delete(r.events, orderID)
+	return nil
+}
+
 var _ domain.TimelineRepository = (*timelineRepositoryInMemory)(nil)
@@ -1,10 +1,12 @@
 package memory
 
 import (
+	"errors"
 	"testing"
 	"time"
 
 	"github.com/vladislavdragonenkov/oms/internal/domain"
+	"github.com/vladislavdragonenkov/oms/internal/timeutil"
 )
 
 func TestTimelineRepository_AppendAndListSorted(t *testing.T) {
@@ -70,3 +72,36 @@ func TestTimelineRepository_ListReturnsCopyAndMissingOrder(t *testing.T) {
 		t.Fatalf("expected no events for missing order, got %d", len(empty))
 	}
 }
+
+func TestTimelineRepository_AppendNormalizesTimestamps(t *testing.T) {
+	repo := NewTimelineRepository()
+
+	local := time.Now().In(time.FixedZone("MSK", 3*60*60)).Add(-time.Minute)
+	if err := repo.Append(domain.TimelineEvent{OrderID: "order-utc", Type: "created", Occurred: local}); err != nil {
+		t.Fatalf("append event failed: %v", err)
+	}
+	if err := repo.Append(domain.TimelineEvent{OrderID: "order-utc", Type: "paid"}); err != nil {
+		t.Fatalf("append event without timestamp failed: %v", err)
+	}
+
+	err := repo.Append(domain.TimelineEvent{OrderID: "order-utc", Type: "shipped", Occurred: time.Now().Add(time.Hour)})
+	if !errors.Is(err, timeutil.ErrTimestampInFuture) {
+		t.Fatalf("expected ErrTimestampInFuture, got %v", err)
+	}
+
+	listed, err := repo.List("order-utc")
+	if err != nil {
+		t.Fatalf("list failed: %v", err)
+	}
+	if len(listed) != 2 {
+		t.Fatalf("expected future event to be rejected, got %+v", listed)
+	}
+	for _, event := range listed {
+		if event.Occurred.Location() != time.UTC || event.Occurred.IsZero() {
+			t.Fatalf("expected UTC timestamp, got %+v", event)
+		}
+	}
+	if !listed[0].Occurred.Equal(local) {
+		t.Fatalf("expected same instant after normalization, got %v", listed[0].Occurred)
+	}
+}
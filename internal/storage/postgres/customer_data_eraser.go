@@ -0,0 +1,156 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/vladislavdragonenkov/oms/internal/domain"
+)
+
+type customerDataEraser struct {
+	db *sql.DB
+}
+
+// NewCustomerDataEraser создаёт PostgreSQL-реализацию CustomerDataEraser.
+func NewCustomerDataEraser(store *Store) domain.CustomerDataEraser {
+	return &customerDataEraser{db: store.DB()}
+}
+
+// EraseCustomerData обезличивает заказы, timeline, idempotency-ответы и outbox-сообщения клиента
+// одной транзакцией.
+// Версия заказов увеличивается, чтобы параллельный Save со старым customer_id получил конфликт версий.
+func (e *customerDataEraser) EraseCustomerData(customerID, pseudonym string) (domain.CustomerErasure, error) {
+	customerID = strings.TrimSpace(customerID)
+	if customerID == "" {
+		return domain.CustomerErasure{}, domain.ErrCustomerRequired
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), opTimeout)
+	defer cancel()
+
+	tx, err := e.db.BeginTx(ctx, nil)
+	if err != nil {
+		return domain.CustomerErasure{}, fmt.Errorf("begin tx: %w", err)
+	}
+
+	defer func() {
+		if err != nil {
+			_ = tx.Rollback()
+		}
+	}()
+
+	var result domain.CustomerErasure
+	result.OrderIDs, err = anonymizeOrders(ctx, tx, customerID, pseudonym)
+	if err != nil {
+		return domain.CustomerErasure{}, err
+	}
+	if len(result.OrderIDs) == 0 {
+		err = domain.ErrCustomerDataNotFound
+		return domain.CustomerErasure{}, err
+	}
+
+	res, err := tx.ExecContext(ctx, `
+		UPDATE timeline_events
+		SET reason = ''
+		WHERE order_id = ANY($1) AND reason <> ''
+	`, result.OrderIDs)
+	if err != nil {
+		return domain.CustomerErasure{}, fmt.Errorf("scrub timeline events: %w", err)
+	}
+	scrubbed, err := res.RowsAffected()
+	if err != nil {
+		return domain.CustomerErasure{}, fmt.Errorf("timeline rows affected: %w", err)
+	}
+	result.TimelineEventsScrubbed = int(scrubbed)
+
+	// Область idempotency-ключей переезжает на псевдоним вместе с заказами.
+	if _, err = tx.ExecContext(ctx, `
+		UPDATE idempotency_keys
+		SET customer_id = $1
+		WHERE customer_id = $2
+	`, pseudonym, customerID); err != nil {
+		return domain.CustomerErasure{}, fmt.Errorf("rescope idempotency records: %w", err)
+	}
+
+	needle, err := json.Marshal(customerID)
+	if err != nil {
+		return domain.CustomerErasure{}, fmt.Errorf("encode customer id: %w", err)
+	}
+	res, err = tx.ExecContext(ctx, `
+		UPDATE idempotency_keys
+		SET response_body = NULL,
+		    status = $1,
+		    updated_at = $2
+		WHERE response_body IS NOT NULL AND position($3::bytea IN response_body) > 0
+	`, string(domain.IdempotencyStatusFailed), time.Now().UTC(), needle)
+	if err != nil {
+		return domain.CustomerErasure{}, fmt.Errorf("scrub idempotency records: %w", err)
+	}
+	scrubbed, err = res.RowsAffected()
+	if err != nil {
+		return domain.CustomerErasure{}, fmt.Errorf("idempotency rows affected: %w", err)
+	}
+	result.IdempotencyRecordsScrubbed = int(scrubbed)
+
+	// Metadata саги несёт customer_id открытым текстом (если он не зашифрован FieldEncryptor'ом);
+	// pending-сообщения уйдут в Kafka уже с псевдонимом, отправленные не достанутся replay.
+	replacement, err := json.Marshal(pseudonym)
+	if err != nil {
+		return domain.CustomerErasure{}, fmt.Errorf("encode pseudonym: %w", err)
+	}
+	res, err = tx.ExecContext(ctx, `
+		UPDATE outbox_messages
+		SET payload = convert_to(replace(convert_from(payload, 'UTF8'), $2, $3), 'UTF8'),
+		    updated_at = $4
+		WHERE aggregate_id = ANY($1) AND position($5::bytea IN payload) > 0
+	`, result.OrderIDs, string(needle), string(replacement), time.Now().UTC(), needle)
+	if err != nil {
+		return domain.CustomerErasure{}, fmt.Errorf("scrub outbox messages: %w", err)
+	}
+	scrubbed, err = res.RowsAffected()
+	if err != nil {
+		return domain.CustomerErasure{}, fmt.Errorf("outbox rows affected: %w", err)
+	}
+	result.OutboxMessagesScrubbed = int(scrubbed)
+
+	if err = tx.Commit(); err != nil {
+		return domain.CustomerErasure{}, fmt.Errorf("commit erase customer data: %w", err)
+	}
+
+	return result, nil
+}
+
+func anonymizeOrders(ctx context.Context, tx *sql.Tx, customerID, pseudonym string) ([]string, error) {
+	rows, err := tx.QueryContext(ctx, `
+		UPDATE orders
+		SET customer_id = $1,
+		    version = version + 1,
+		    updated_at = $2
+		WHERE customer_id = $3
+		RETURNING id
+	`, pseudonym, time.Now().UTC(), customerID)
+	if err != nil {
+		return nil, fmt.Errorf("anonymize orders: %w", err)
+	}
+	defer rows.Close()
+
+	orderIDs := make([]string, 0)
+	for rows.Next() {
+		var id string
+		if err := rows.Scan(&id); err != nil {
+			return nil, fmt.Errorf("scan anonymized order id: %w", err)
+		}
+		orderIDs = append(orderIDs, id)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("iterate anonymized orders: %w", err)
+	}
+
+	return orderIDs, nil
+}
+
+var _ domain.CustomerDataEraser = (*customerDataEraser)(nil)
@@ -20,11 +20,11 @@ func NewIdempotencyRepository(store *Store) domain.IdempotencyRepository {
 	return &idempotencyRepository{db: store.DB()}
 }
 
-func (r *idempotencyRepository) CreateProcessing(key, requestHash string, ttlAt time.Time) (domain.IdempotencyRecord, error) {
-	key = strings.TrimSpace(key)
+func (r *idempotencyRepository) CreateProcessing(scope domain.IdempotencyScope, requestHash string, ttlAt time.Time) (domain.IdempotencyRecord, error) {
+	scope = scope.Normalize()
 	requestHash = strings.TrimSpace(requestHash)
 
-	if key == "" {
+	if scope.Key == "" {
 		return domain.IdempotencyRecord{}, domain.ErrIdempotencyKeyRequired
 	}
 	if requestHash == "" {
@@ -39,23 +39,29 @@ func (r *idempotencyRepository) CreateProcessing(key, requestHash string, ttlAt
 	ctx, cancel := context.WithTimeout(context.Background(), opTimeout)
 	defer cancel()
 
-	_, err := r.db.ExecContext(ctx, `
+	// Запись без метода и клиента с тем же хешем — тот же запрос, принятый до миграции 0014.
+	res, err := r.db.ExecContext(ctx, `
 		INSERT INTO idempotency_keys (
-			key, request_hash, response_body, http_status, status, ttl_at, created_at, updated_at
-		) VALUES ($1,$2,$3,$4,$5,$6,$7,$8)
+			method, customer_id, key, request_hash, status, ttl_at, created_at, updated_at
+		)
+		SELECT $1::text, $2::text, $3::text, $4::text, $5::text, $6::timestamptz, $7::timestamptz, $7::timestamptz
+		WHERE NOT EXISTS (
+			SELECT 1
+			FROM idempotency_keys
+			WHERE method = '' AND customer_id = '' AND key = $3 AND request_hash = $4
+		)
 	`,
-		key,
+		scope.Method,
+		scope.CustomerID,
+		scope.Key,
 		requestHash,
-		nil,
-		nil,
 		string(domain.IdempotencyStatusProcessing),
 		ttlAt,
 		now,
-		now,
 	)
 	if err != nil {
 		if isUniqueViolation(err) {
-			existing, getErr := r.Get(key)
+			existing, getErr := r.Get(scope)
 			if getErr != nil {
 				return domain.IdempotencyRecord{}, domain.ErrIdempotencyKeyAlreadyExists
 			}
@@ -67,8 +73,22 @@ func (r *idempotencyRepository) CreateProcessing(key, requestHash string, ttlAt
 		return domain.IdempotencyRecord{}, fmt.Errorf("create idempotency record: %w", err)
 	}
 
+	affected, err := res.RowsAffected()
+	if err != nil {
+		return domain.IdempotencyRecord{}, fmt.Errorf("idempotency rows affected: %w", err)
+	}
+	if affected == 0 {
+		legacy, getErr := r.Get(scope.Legacy())
+		if getErr != nil {
+			return domain.IdempotencyRecord{}, domain.ErrIdempotencyKeyAlreadyExists
+		}
+		return legacy, domain.ErrIdempotencyKeyAlreadyExists
+	}
+
 	return domain.IdempotencyRecord{
-		Key:          key,
+		Method:       scope.Method,
+		CustomerID:   scope.CustomerID,
+		Key:          scope.Key,
 		RequestHash:  requestHash,
 		Status:       domain.IdempotencyStatusProcessing,
 		TTLAt:        ttlAt,
@@ -79,9 +99,9 @@ func (r *idempotencyRepository) CreateProcessing(key, requestHash string, ttlAt
 	}, nil
 }
 
-func (r *idempotencyRepository) Get(key string) (domain.IdempotencyRecord, error) {
-	key = strings.TrimSpace(key)
-	if key == "" {
+func (r *idempotencyRepository) Get(scope domain.IdempotencyScope) (domain.IdempotencyRecord, error) {
+	scope = scope.Normalize()
+	if scope.Key == "" {
 		return domain.IdempotencyRecord{}, domain.ErrIdempotencyKeyRequired
 	}
 
@@ -96,10 +116,12 @@ func (r *idempotencyRepository) Get(key string) (domain.IdempotencyRecord, error
 	)
 
 	err := r.db.QueryRowContext(ctx, `
-		SELECT key, request_hash, response_body, http_status, status, ttl_at, created_at, updated_at
+		SELECT method, customer_id, key, request_hash, response_body, http_status, status, ttl_at, created_at, updated_at
 		FROM idempotency_keys
-		WHERE key = $1
-	`, key).Scan(
+		WHERE method = $1 AND customer_id = $2 AND key = $3
+	`, scope.Method, scope.CustomerID, scope.Key).Scan(
+		&record.Method,
+		&record.CustomerID,
 		&record.Key,
 		&record.RequestHash,
 		&responseBody,
@@ -118,7 +140,7 @@ func (r *idempotencyRepository) Get(key string) (domain.IdempotencyRecord, error
 
 	record.Status = domain.IdempotencyStatus(statusRaw)
 	if !record.Status.Valid() {
-		return domain.IdempotencyRecord{}, fmt.Errorf("invalid idempotency status %q for key %s", statusRaw, key)
+		return domain.IdempotencyRecord{}, fmt.Errorf("invalid idempotency status %q for key %s", statusRaw, scope.Key)
 	}
 
 	record.ResponseBody = append([]byte(nil), responseBody...)
@@ -129,12 +151,27 @@ func (r *idempotencyRepository) Get(key string) (domain.IdempotencyRecord, error
 	return record, nil
 }
 
-func (r *idempotencyRepository) MarkDone(key string, responseBody []byte, httpStatus int) error {
-	return r.markStatus(key, domain.IdempotencyStatusDone, responseBody, httpStatus)
+func (r *idempotencyRepository) MarkDone(scope domain.IdempotencyScope, responseBody []byte, httpStatus int) error {
+	return r.markStatus(scope, domain.IdempotencyStatusDone, responseBody, httpStatus)
 }
 
-func (r *idempotencyRepository) MarkFailed(key string, responseBody []byte, httpStatus int) error {
-	return r.markStatus(key, domain.IdempotencyStatusFailed, responseBody, httpStatus)
+func (r *idempotencyRepository) MarkFailed(scope domain.IdempotencyScope, responseBody []byte, httpStatus int) error {
+	return r.markStatus(scope, domain.IdempotencyStatusFailed, responseBody, httpStatus)
+}
+
+func (r *idempotencyRepository) Delete(scope domain.IdempotencyScope) error {
+	scope = scope.Normalize()
+
+	ctx, cancel := context.WithTimeout(context.Background(), opTimeout)
+	defer cancel()
+
+	if _, err := r.db.ExecContext(ctx, `
+		DELETE FROM idempotency_keys
+		WHERE method = $1 AND customer_id = $2 AND key = $3
+	`, scope.Method, scope.CustomerID, scope.Key); err != nil {
+		return fmt.Errorf("delete idempotency record: %w", err)
+	}
+	return nil
 }
 
 func (r *idempotencyRepository) DeleteExpired(before time.Time, limit int) (int, error) {
@@ -153,8 +190,8 @@ func (r *idempotencyRepository) DeleteExpired(before time.Time, limit int) (int,
 	if limit > 0 {
 		res, err = r.db.ExecContext(ctx, `
 			DELETE FROM idempotency_keys
-			WHERE key IN (
-				SELECT key
+			WHERE (method, customer_id, key) IN (
+				SELECT method, customer_id, key
 				FROM idempotency_keys
 				WHERE ttl_at <= $1
 				ORDER BY ttl_at ASC
@@ -179,28 +216,110 @@ func (r *idempotencyRepository) DeleteExpired(before time.Time, limit int) (int,
 	return int(affected), nil
 }
 
-func (r *idempotencyRepository) markStatus(key string, status domain.IdempotencyStatus, responseBody []byte, httpStatus int) error {
-	key = strings.TrimSpace(key)
-	if key == "" {
-		return domain.ErrIdempotencyKeyRequired
+// FailStaleProcessing переводит зависшие ключи в failed одним UPDATE ... RETURNING. Условие на статус
+// повторяется во внешнем запросе: ключ, который успел завершиться между выборкой и обновлением, не трогается.
+func (r *idempotencyRepository) FailStaleProcessing(before time.Time, limit int, responseBody []byte, httpStatus int) ([]domain.IdempotencyRecord, error) {
+	if before.IsZero() {
+		before = time.Now().UTC()
 	}
 
 	ctx, cancel := context.WithTimeout(context.Background(), opTimeout)
 	defer cancel()
 
-	res, err := r.db.ExecContext(ctx, `
+	limitClause := ""
+	args := []any{
+		string(domain.IdempotencyStatusProcessing),
+		before,
+		string(domain.IdempotencyStatusFailed),
+		responseBody,
+		httpStatus,
+		time.Now().UTC(),
+	}
+	if limit > 0 {
+		limitClause = "LIMIT $7"
+		args = append(args, limit)
+	}
+
+	rows, err := r.db.QueryContext(ctx, `
+		UPDATE idempotency_keys
+		SET status = $3,
+		    response_body = $4,
+		    http_status = $5,
+		    updated_at = $6
+		WHERE status = $1 AND (method, customer_id, key) IN (
+			SELECT method, customer_id, key
+			FROM idempotency_keys
+			WHERE status = $1 AND updated_at <= $2
+			ORDER BY updated_at ASC
+			`+limitClause+`
+			FOR UPDATE SKIP LOCKED
+		)
+		RETURNING method, customer_id, key, request_hash, ttl_at, created_at, updated_at
+	`, args...)
+	if err != nil {
+		return nil, fmt.Errorf("fail stale processing idempotency records: %w", err)
+	}
+	defer rows.Close()
+
+	failed := make([]domain.IdempotencyRecord, 0)
+	for rows.Next() {
+		record := domain.IdempotencyRecord{
+			Status:       domain.IdempotencyStatusFailed,
+			ResponseBody: append([]byte(nil), responseBody...),
+			HTTPStatus:   httpStatus,
+		}
+		if err := rows.Scan(&record.Method, &record.CustomerID, &record.Key, &record.RequestHash,
+			&record.TTLAt, &record.CreatedAt, &record.UpdatedAt); err != nil {
+			return nil, fmt.Errorf("scan stale idempotency record: %w", err)
+		}
+		failed = append(failed, record)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("iterate stale idempotency records: %w", err)
+	}
+	return failed, nil
+}
+
+func (r *idempotencyRepository) markStatus(scope domain.IdempotencyScope, status domain.IdempotencyStatus, responseBody []byte, httpStatus int) error {
+	ctx, cancel := context.WithTimeout(context.Background(), opTimeout)
+	defer cancel()
+
+	return markIdempotencyStatus(ctx, r.db, scope, status, responseBody, httpStatus)
+}
+
+// sqlExecer — общий интерфейс *sql.DB и *sql.Tx для запросов, которые выполняются и внутри транзакции.
+type sqlExecer interface {
+	ExecContext(ctx context.Context, query string, args ...any) (sql.Result, error)
+}
+
+func markIdempotencyStatus(
+	ctx context.Context,
+	db sqlExecer,
+	scope domain.IdempotencyScope,
+	status domain.IdempotencyStatus,
+	responseBody []byte,
+	httpStatus int,
+) error {
+	scope = scope.Normalize()
+	if scope.Key == "" {
+		return domain.ErrIdempotencyKeyRequired
+	}
+
+	res, err := db.ExecContext(ctx, `
 		UPDATE idempotency_keys
 		SET response_body = $1,
 		    http_status = $2,
 		    status = $3,
 		    updated_at = $4
-		WHERE key = $5
+		WHERE method = $5 AND customer_id = $6 AND key = $7
 	`,
 		responseBody,
 		httpStatus,
 		string(status),
 		time.Now().UTC(),
-		key,
+		scope.Method,
+		scope.CustomerID,
+		scope.Key,
 	)
 	if err != nil {
 		return fmt.Errorf("mark idempotency key status: %w", err)
@@ -217,4 +336,7 @@ func (r *idempotencyRepository) markStatus(key string, status domain.Idempotency
 	return nil
 }
 
-var _ domain.IdempotencyRepository = (*idempotencyRepository)(nil)
+var (
+	_ domain.IdempotencyRepository    = (*idempotencyRepository)(nil)
+	_ domain.IdempotencyRecordDeleter = (*idempotencyRepository)(nil)
+)
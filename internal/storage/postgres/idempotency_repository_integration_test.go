@@ -15,18 +15,18 @@ func TestIdempotencyRepository_PostgresCreateGetAndMarkDone(t *testing.T) {
 	store := openPostgresStoreForIdempotencyTest(t)
 	repo := NewIdempotencyRepository(store)
 
-	key := "idem-test-key-done"
+	scope := domain.IdempotencyScope{Method: "CreateOrder", CustomerID: "customer-1", Key: "idem-test-key-done"}
 	hash := "req-hash-1"
 	ttl := time.Now().UTC().Add(2 * time.Hour).Round(time.Second)
 
-	created, err := repo.CreateProcessing(key, hash, ttl)
+	created, err := repo.CreateProcessing(scope, hash, ttl)
 	require.NoError(t, err)
 	require.Equal(t, domain.IdempotencyStatusProcessing, created.Status)
 
-	err = repo.MarkDone(key, []byte(`{"result":"ok"}`), 200)
+	err = repo.MarkDone(scope, []byte(`{"result":"ok"}`), 200)
 	require.NoError(t, err)
 
-	got, err := repo.Get(key)
+	got, err := repo.Get(scope)
 	require.NoError(t, err)
 	require.Equal(t, hash, got.RequestHash)
 	require.Equal(t, domain.IdempotencyStatusDone, got.Status)
@@ -40,14 +40,14 @@ func TestIdempotencyRepository_PostgresConflictAndHashMismatch(t *testing.T) {
 	repo := NewIdempotencyRepository(store)
 
 	ttl := time.Now().UTC().Add(time.Hour)
-	_, err := repo.CreateProcessing("idem-test-key-conflict", "req-hash-a", ttl)
+	_, err := repo.CreateProcessing(domain.IdempotencyScope{Key: "idem-test-key-conflict"}, "req-hash-a", ttl)
 	require.NoError(t, err)
 
-	_, err = repo.CreateProcessing("idem-test-key-conflict", "req-hash-a", ttl)
+	_, err = repo.CreateProcessing(domain.IdempotencyScope{Key: "idem-test-key-conflict"}, "req-hash-a", ttl)
 	require.Error(t, err)
 	require.True(t, errors.Is(err, domain.ErrIdempotencyKeyAlreadyExists))
 
-	_, err = repo.CreateProcessing("idem-test-key-conflict", "req-hash-b", ttl)
+	_, err = repo.CreateProcessing(domain.IdempotencyScope{Key: "idem-test-key-conflict"}, "req-hash-b", ttl)
 	require.Error(t, err)
 	require.True(t, errors.Is(err, domain.ErrIdempotencyHashMismatch))
 }
@@ -57,13 +57,13 @@ func TestIdempotencyRepository_PostgresDeleteExpired(t *testing.T) {
 	repo := NewIdempotencyRepository(store)
 
 	now := time.Now().UTC()
-	_, err := repo.CreateProcessing("idem-expired-1", "h1", now.Add(-5*time.Minute))
+	_, err := repo.CreateProcessing(domain.IdempotencyScope{Key: "idem-expired-1"}, "h1", now.Add(-5*time.Minute))
 	require.NoError(t, err)
-	_, err = repo.CreateProcessing("idem-expired-2", "h2", now.Add(-4*time.Minute))
+	_, err = repo.CreateProcessing(domain.IdempotencyScope{Key: "idem-expired-2"}, "h2", now.Add(-4*time.Minute))
 	require.NoError(t, err)
-	_, err = repo.CreateProcessing("idem-expired-3", "h3", now.Add(-3*time.Minute))
+	_, err = repo.CreateProcessing(domain.IdempotencyScope{Key: "idem-expired-3"}, "h3", now.Add(-3*time.Minute))
 	require.NoError(t, err)
-	_, err = repo.CreateProcessing("idem-active-1", "h4", now.Add(time.Hour))
+	_, err = repo.CreateProcessing(domain.IdempotencyScope{Key: "idem-active-1"}, "h4", now.Add(time.Hour))
 	require.NoError(t, err)
 
 	removed, err := repo.DeleteExpired(now, 2)
@@ -74,10 +74,185 @@ func TestIdempotencyRepository_PostgresDeleteExpired(t *testing.T) {
 	require.NoError(t, err)
 	require.Equal(t, 1, removed)
 
-	_, err = repo.Get("idem-active-1")
+	_, err = repo.Get(domain.IdempotencyScope{Key: "idem-active-1"})
 	require.NoError(t, err)
 }
 
+func TestIdempotencyRepository_PostgresFailStaleProcessing(t *testing.T) {
+	store := openPostgresStoreForIdempotencyTest(t)
+	repo := NewIdempotencyRepository(store)
+
+	ttl := time.Now().UTC().Add(time.Hour)
+	_, err := repo.CreateProcessing(domain.IdempotencyScope{Key: "idem-stuck"}, "h1", ttl)
+	require.NoError(t, err)
+	_, err = repo.CreateProcessing(domain.IdempotencyScope{Key: "idem-finished"}, "h2", ttl)
+	require.NoError(t, err)
+	require.NoError(t, repo.MarkFailed(domain.IdempotencyScope{Key: "idem-finished"}, nil, 13))
+
+	failed, err := repo.FailStaleProcessing(time.Now().UTC().Add(time.Second), 10, []byte(`{"code":10}`), 10)
+	require.NoError(t, err)
+	require.Len(t, failed, 1)
+	require.Equal(t, "idem-stuck", failed[0].Key)
+	require.Equal(t, "h1", failed[0].RequestHash)
+
+	stuck, err := repo.Get(domain.IdempotencyScope{Key: "idem-stuck"})
+	require.NoError(t, err)
+	require.Equal(t, domain.IdempotencyStatusFailed, stuck.Status)
+	require.Equal(t, 10, stuck.HTTPStatus)
+	require.JSONEq(t, `{"code":10}`, string(stuck.ResponseBody))
+	finished, err := repo.Get(domain.IdempotencyScope{Key: "idem-finished"})
+	require.NoError(t, err)
+	require.Equal(t, 13, finished.HTTPStatus)
+}
+
+func TestIdempotencyRepository_PostgresScopesKeyByMethodAndCustomer(t *testing.T) {
+	store := openPostgresStoreForIdempotencyTest(t)
+	repo := NewIdempotencyRepository(store)
+	ttl := time.Now().UTC().Add(time.Hour)
+
+	alice := domain.IdempotencyScope{Method: "CreateOrder", CustomerID: "alice", Key: "shared-key"}
+	bob := domain.IdempotencyScope{Method: "CreateOrder", CustomerID: "bob", Key: "shared-key"}
+	alicePay := domain.IdempotencyScope{Method: "PayOrder", CustomerID: "alice", Key: "shared-key"}
+
+	_, err := repo.CreateProcessing(alice, "hash-alice", ttl)
+	require.NoError(t, err)
+	_, err = repo.CreateProcessing(bob, "hash-bob", ttl)
+	require.NoError(t, err)
+	_, err = repo.CreateProcessing(alicePay, "hash-pay", ttl)
+	require.NoError(t, err)
+
+	require.NoError(t, repo.MarkDone(bob, []byte(`{"who":"bob"}`), 0))
+	got, err := repo.Get(alice)
+	require.NoError(t, err)
+	require.Equal(t, domain.IdempotencyStatusProcessing, got.Status)
+	require.Equal(t, "alice", got.CustomerID)
+
+	_, err = repo.CreateProcessing(bob, "hash-other", ttl)
+	require.ErrorIs(t, err, domain.ErrIdempotencyHashMismatch)
+}
+
+func TestIdempotencyRepository_PostgresDelete(t *testing.T) {
+	store := openPostgresStoreForIdempotencyTest(t)
+	repo := NewIdempotencyRepository(store)
+	ttl := time.Now().UTC().Add(time.Hour)
+
+	create := domain.IdempotencyScope{Method: "CreateOrder", CustomerID: "canary", Key: "canary-run-create"}
+	pay := domain.IdempotencyScope{Method: "PayOrder", CustomerID: "canary", Key: "canary-run-create"}
+	_, err := repo.CreateProcessing(create, "hash-create", ttl)
+	require.NoError(t, err)
+	_, err = repo.CreateProcessing(pay, "hash-pay", ttl)
+	require.NoError(t, err)
+
+	deleter := repo.(domain.IdempotencyRecordDeleter)
+	require.NoError(t, deleter.Delete(create))
+	require.NoError(t, deleter.Delete(create), "delete must be idempotent")
+	_, err = repo.Get(create)
+	require.ErrorIs(t, err, domain.ErrIdempotencyKeyNotFound)
+	_, err = repo.Get(pay)
+	require.NoError(t, err)
+}
+
+func TestIdempotencyRepository_PostgresReplaysLegacyRecordWithSameHash(t *testing.T) {
+	store := openPostgresStoreForIdempotencyTest(t)
+	repo := NewIdempotencyRepository(store)
+	ttl := time.Now().UTC().Add(time.Hour)
+
+	legacy := domain.IdempotencyScope{Key: "legacy-key"}
+	_, err := repo.CreateProcessing(legacy, "hash-legacy", ttl)
+	require.NoError(t, err)
+	require.NoError(t, repo.MarkDone(legacy, []byte(`{"legacy":true}`), 0))
+
+	scoped := domain.IdempotencyScope{Method: "CreateOrder", CustomerID: "alice", Key: "legacy-key"}
+	record, err := repo.CreateProcessing(scoped, "hash-legacy", ttl)
+	require.ErrorIs(t, err, domain.ErrIdempotencyKeyAlreadyExists)
+	require.Equal(t, `{"legacy":true}`, string(record.ResponseBody))
+
+	// Другой запрос с тем же ключом со старой записью не связан.
+	_, err = repo.CreateProcessing(scoped, "hash-new", ttl)
+	require.NoError(t, err)
+}
+
+func TestOrderUnitOfWork_PostgresCreateOrderWithIdempotency(t *testing.T) {
+	store := openPostgresStoreForIntegrationTest(t)
+	idemRepo := NewIdempotencyRepository(store)
+	orderRepo := NewOrderRepository(store)
+	uow := NewOrderUnitOfWork(store)
+
+	scope := domain.IdempotencyScope{Method: "CreateOrder", CustomerID: "customer-uow", Key: "uow-key"}
+	_, err := idemRepo.CreateProcessing(scope, "h1", time.Now().UTC().Add(time.Hour))
+	require.NoError(t, err)
+
+	order := sampleOrder("order-uow-1", "customer-uow", time.Now().UTC().Truncate(time.Microsecond))
+	require.NoError(t, uow.CreateOrderWithIdempotency(order, scope, []byte(`{"order":{}}`), 0))
+
+	_, err = orderRepo.Get(order.ID)
+	require.NoError(t, err)
+	record, err := idemRepo.Get(scope)
+	require.NoError(t, err)
+	require.Equal(t, domain.IdempotencyStatusDone, record.Status)
+
+	// Без записи ключа транзакция откатывается и заказ не появляется.
+	missing := sampleOrder("order-uow-2", "customer-uow", time.Now().UTC().Truncate(time.Microsecond))
+	scope.Key = "missing-key"
+	err = uow.CreateOrderWithIdempotency(missing, scope, nil, 0)
+	require.ErrorIs(t, err, domain.ErrIdempotencyKeyNotFound)
+	_, err = orderRepo.Get(missing.ID)
+	require.ErrorIs(t, err, domain.ErrOrderNotFound)
+}
+
+func TestCustomerDataEraser_PostgresEraseCustomerData(t *testing.T) {
+	store := openPostgresStoreForIntegrationTest(t)
+	orderRepo := NewOrderRepository(store)
+	timelineRepo := NewTimelineRepository(store)
+	idemRepo := NewIdempotencyRepository(store)
+	eraser := NewCustomerDataEraser(store)
+
+	now := time.Now().UTC().Truncate(time.Microsecond)
+	order := sampleOrder("order-gdpr-1", "customer-gdpr", now)
+	require.NoError(t, orderRepo.Create(order))
+	require.NoError(t, timelineRepo.Append(domain.TimelineEvent{OrderID: order.ID, Type: "OrderCanceled", Reason: "personal note", Occurred: now}))
+	scope := domain.IdempotencyScope{Method: "CreateOrder", CustomerID: "customer-gdpr", Key: "gdpr-key"}
+	_, err := idemRepo.CreateProcessing(scope, "h1", now.Add(time.Hour))
+	require.NoError(t, err)
+	require.NoError(t, idemRepo.MarkDone(scope, []byte(`{"order":{"customerId":"customer-gdpr"}}`), 0))
+	outboxRepo := NewOutboxRepository(store)
+	_, err = outboxRepo.Enqueue(domain.OutboxMessage{
+		AggregateType: "order",
+		AggregateID:   order.ID,
+		EventType:     "OrderCreated",
+		Payload:       []byte(`{"order_id":"order-gdpr-1","metadata":{"customer_id":"customer-gdpr"}}`),
+	})
+	require.NoError(t, err)
+
+	erasure, err := eraser.EraseCustomerData("customer-gdpr", "erased-test")
+	require.NoError(t, err)
+	require.Equal(t, []string{order.ID}, erasure.OrderIDs)
+	require.Equal(t, 1, erasure.TimelineEventsScrubbed)
+	require.Equal(t, 1, erasure.IdempotencyRecordsScrubbed)
+	require.Equal(t, 1, erasure.OutboxMessagesScrubbed)
+
+	got, err := orderRepo.Get(order.ID)
+	require.NoError(t, err)
+	require.Equal(t, "erased-test", got.CustomerID)
+	events, err := timelineRepo.List(order.ID)
+	require.NoError(t, err)
+	require.Empty(t, events[0].Reason)
+	_, err = idemRepo.Get(scope)
+	require.ErrorIs(t, err, domain.ErrIdempotencyKeyNotFound)
+	scope.CustomerID = "erased-test"
+	record, err := idemRepo.Get(scope)
+	require.NoError(t, err)
+	require.Equal(t, domain.IdempotencyStatusFailed, record.Status)
+	require.Empty(t, record.ResponseBody)
+	pending, err := outboxRepo.PullPending(10)
+	require.NoError(t, err)
+	require.Len(t, pending, 1)
+	require.JSONEq(t, `{"order_id":"order-gdpr-1","metadata":{"customer_id":"erased-test"}}`, string(pending[0].Payload))
+
+	_, err = eraser.EraseCustomerData("customer-gdpr", "erased-again")
+	require.ErrorIs(t, err, domain.ErrCustomerDataNotFound)
+}
+
 func openPostgresStoreForIdempotencyTest(t *testing.T) *Store {
 	t.Helper()
 
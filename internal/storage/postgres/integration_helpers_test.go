@@ -73,6 +73,12 @@ func truncateAllTablesForIntegrationTest(t *testing.T, store *Store) {
 	_, err := store.DB().ExecContext(ctx, `
 		TRUNCATE TABLE
 			idempotency_keys,
+			order_quota_usage,
+			saga_dispatch_intents,
+			scheduled_order_cancels,
+			payment_authorizations,
+			order_returns,
+			payment_retries,
 			outbox_messages,
 			timeline_events,
 			order_items,
@@ -9,6 +9,7 @@ import (
 	"io/fs"
 	"path/filepath"
 	"regexp"
+	"slices"
 	"sort"
 	"strconv"
 	"strings"
@@ -22,10 +23,17 @@ const (
 CREATE TABLE IF NOT EXISTS schema_migrations (
     version BIGINT PRIMARY KEY,
     name TEXT NOT NULL,
-    applied_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+    applied_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    dirty BOOLEAN NOT NULL DEFAULT FALSE
 )`
+	// Таблицы, созданные до появления dirty, получают колонку при первом обращении.
+	migrationDirtyColumnDDL = `ALTER TABLE schema_migrations ADD COLUMN IF NOT EXISTS dirty BOOLEAN NOT NULL DEFAULT FALSE`
 )
 
+// ErrMigrationDirty — предыдущая миграция упала, и схема может быть применена частично.
+// Новые миграции блокируются, пока оператор не проверит схему и не выполнит ForceVersion.
+var ErrMigrationDirty = errors.New("database schema is dirty")
+
 var (
 	//go:embed sql/migrations/*.sql
 	migrationsFS embed.FS
@@ -78,8 +86,8 @@ func (s *Store) MigrationStatus(ctx context.Context) (int64, int, error) {
 	queryCtx, cancel := context.WithTimeout(ctx, 5*time.Second)
 	defer cancel()
 
-	if _, err := s.db.ExecContext(queryCtx, migrationTableDDL); err != nil {
-		return 0, 0, fmt.Errorf("ensure migration table: %w", err)
+	if err := ensureMigrationTable(queryCtx, s.db); err != nil {
+		return 0, 0, err
 	}
 
 	var (
@@ -96,6 +104,45 @@ func (s *Store) MigrationStatus(ctx context.Context) (int64, int, error) {
 	return version, count, nil
 }
 
+// DirtyMigration возвращает версию, на которой упала миграция, если схема помечена dirty.
+func (s *Store) DirtyMigration(ctx context.Context) (int64, bool, error) {
+	if s == nil || s.db == nil {
+		return 0, false, fmt.Errorf("postgres store is not initialized")
+	}
+
+	queryCtx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	if err := ensureMigrationTable(queryCtx, s.db); err != nil {
+		return 0, false, err
+	}
+	return loadDirtyVersion(queryCtx, s.db)
+}
+
+// ForceVersion приводит schema_migrations к версии version без выполнения SQL миграций:
+// версии до version включительно считаются применёнными, более поздние удаляются, флаг dirty
+// сбрасывается. Аналог golang-migrate force; version=0 очищает таблицу.
+func (s *Store) ForceVersion(ctx context.Context, version int64) error {
+	if s == nil || s.db == nil {
+		return fmt.Errorf("postgres store is not initialized")
+	}
+	if version < 0 {
+		return fmt.Errorf("force version must be >= 0, got %d", version)
+	}
+
+	migrations, err := loadMigrationsFromFS(migrationsFS)
+	if err != nil {
+		return err
+	}
+	if version > 0 && !slices.ContainsFunc(migrations, func(m migration) bool { return m.Version == version }) {
+		return fmt.Errorf("cannot force unknown migration version %d", version)
+	}
+
+	return s.withMigrationLock(ctx, func(conn *sql.Conn) error {
+		return forceVersion(ctx, conn, migrations, version)
+	})
+}
+
 func (s *Store) migrate(ctx context.Context, direction migrationDirection, steps int) error {
 	if s == nil || s.db == nil {
 		return fmt.Errorf("postgres store is not initialized")
@@ -106,6 +153,27 @@ func (s *Store) migrate(ctx context.Context, direction migrationDirection, steps
 		return err
 	}
 
+	return s.withMigrationLock(ctx, func(conn *sql.Conn) error {
+		if version, dirty, err := loadDirtyVersion(ctx, conn); err != nil {
+			return err
+		} else if dirty {
+			return fmt.Errorf("%w at version %d: verify the schema and run force-version", ErrMigrationDirty, version)
+		}
+
+		switch direction {
+		case migrationUp:
+			return applyUp(ctx, conn, migrations, steps)
+		case migrationDown:
+			return applyDown(ctx, conn, migrations, steps)
+		default:
+			return fmt.Errorf("unsupported migration direction: %s", direction)
+		}
+	})
+}
+
+// withMigrationLock выполняет fn под advisory lock, чтобы параллельные запуски migrate
+// (несколько реплик на старте) не применяли одну миграцию дважды.
+func (s *Store) withMigrationLock(ctx context.Context, fn func(conn *sql.Conn) error) error {
 	conn, err := s.db.Conn(ctx)
 	if err != nil {
 		return fmt.Errorf("acquire db connection: %w", err)
@@ -121,18 +189,21 @@ func (s *Store) migrate(ctx context.Context, direction migrationDirection, steps
 		_, _ = conn.ExecContext(context.Background(), "SELECT pg_advisory_unlock($1)", migrationLockKey)
 	}()
 
-	if _, err := conn.ExecContext(ctx, migrationTableDDL); err != nil {
-		return fmt.Errorf("ensure migration table: %w", err)
+	if err := ensureMigrationTable(ctx, conn); err != nil {
+		return err
 	}
 
-	switch direction {
-	case migrationUp:
-		return applyUp(ctx, conn, migrations, steps)
-	case migrationDown:
-		return applyDown(ctx, conn, migrations, steps)
-	default:
-		return fmt.Errorf("unsupported migration direction: %s", direction)
+	return fn(conn)
+}
+
+func ensureMigrationTable(ctx context.Context, db sqlExecer) error {
+	if _, err := db.ExecContext(ctx, migrationTableDDL); err != nil {
+		return fmt.Errorf("ensure migration table: %w", err)
+	}
+	if _, err := db.ExecContext(ctx, migrationDirtyColumnDDL); err != nil {
+		return fmt.Errorf("ensure migration dirty column: %w", err)
 	}
+	return nil
 }
 
 func applyUp(ctx context.Context, conn *sql.Conn, migrations []migration, steps int) error {
@@ -193,7 +264,7 @@ func applyOneUp(ctx context.Context, conn *sql.Conn, m migration) error {
 
 	if _, err := tx.ExecContext(ctx, m.UpSQL); err != nil {
 		_ = tx.Rollback()
-		return fmt.Errorf("execute up migration %d_%s: %w", m.Version, m.Name, err)
+		return markDirty(ctx, conn, m, migrationUp, fmt.Errorf("execute up migration %d_%s: %w", m.Version, m.Name, err))
 	}
 
 	if _, err := tx.ExecContext(ctx, `
@@ -205,7 +276,7 @@ func applyOneUp(ctx context.Context, conn *sql.Conn, m migration) error {
 	}
 
 	if err := tx.Commit(); err != nil {
-		return fmt.Errorf("commit up migration %d_%s: %w", m.Version, m.Name, err)
+		return markDirty(ctx, conn, m, migrationUp, fmt.Errorf("commit up migration %d_%s: %w", m.Version, m.Name, err))
 	}
 
 	return nil
@@ -219,7 +290,7 @@ func applyOneDown(ctx context.Context, conn *sql.Conn, m migration) error {
 
 	if _, err := tx.ExecContext(ctx, m.DownSQL); err != nil {
 		_ = tx.Rollback()
-		return fmt.Errorf("execute down migration %d_%s: %w", m.Version, m.Name, err)
+		return markDirty(ctx, conn, m, migrationDown, fmt.Errorf("execute down migration %d_%s: %w", m.Version, m.Name, err))
 	}
 
 	if _, err := tx.ExecContext(ctx, `DELETE FROM schema_migrations WHERE version = $1`, m.Version); err != nil {
@@ -228,9 +299,84 @@ func applyOneDown(ctx context.Context, conn *sql.Conn, m migration) error {
 	}
 
 	if err := tx.Commit(); err != nil {
-		return fmt.Errorf("commit down migration %d_%s: %w", m.Version, m.Name, err)
+		return markDirty(ctx, conn, m, migrationDown, fmt.Errorf("commit down migration %d_%s: %w", m.Version, m.Name, err))
+	}
+
+	return nil
+}
+
+// markDirty помечает версию m после упавшей миграции и возвращает cause. Транзакция уже
+// откатилась, но часть SQL (например, CREATE INDEX CONCURRENTLY) в транзакцию не входит,
+// а ошибка commit не говорит, применились ли изменения, поэтому решение остаётся за оператором.
+func markDirty(ctx context.Context, conn *sql.Conn, m migration, direction migrationDirection, cause error) error {
+	// Исходный ctx может быть уже отменён — пометка не должна потеряться вместе с ним.
+	markCtx, cancel := context.WithTimeout(context.WithoutCancel(ctx), 5*time.Second)
+	defer cancel()
+
+	var err error
+	if direction == migrationUp {
+		_, err = conn.ExecContext(markCtx, `
+			INSERT INTO schema_migrations (version, name, applied_at, dirty)
+			VALUES ($1, $2, NOW(), TRUE)
+			ON CONFLICT (version) DO UPDATE SET dirty = TRUE
+		`, m.Version, m.Name)
+	} else {
+		_, err = conn.ExecContext(markCtx, `UPDATE schema_migrations SET dirty = TRUE WHERE version = $1`, m.Version)
+	}
+	if err != nil {
+		return errors.Join(cause, fmt.Errorf("mark migration %d_%s dirty: %w", m.Version, m.Name, err))
 	}
+	return cause
+}
 
+type sqlQueryRower interface {
+	QueryRowContext(ctx context.Context, query string, args ...any) *sql.Row
+}
+
+func loadDirtyVersion(ctx context.Context, db sqlQueryRower) (int64, bool, error) {
+	var version int64
+	err := db.QueryRowContext(ctx, `
+		SELECT version
+		FROM schema_migrations
+		WHERE dirty
+		ORDER BY version
+		LIMIT 1
+	`).Scan(&version)
+	if errors.Is(err, sql.ErrNoRows) {
+		return 0, false, nil
+	}
+	if err != nil {
+		return 0, false, fmt.Errorf("query dirty migration: %w", err)
+	}
+	return version, true, nil
+}
+
+func forceVersion(ctx context.Context, conn *sql.Conn, migrations []migration, version int64) error {
+	tx, err := conn.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("begin force version tx: %w", err)
+	}
+	defer func() { _ = tx.Rollback() }()
+
+	if _, err := tx.ExecContext(ctx, `DELETE FROM schema_migrations WHERE version > $1`, version); err != nil {
+		return fmt.Errorf("delete migrations above %d: %w", version, err)
+	}
+	for _, m := range migrations {
+		if m.Version > version {
+			break
+		}
+		if _, err := tx.ExecContext(ctx, `
+			INSERT INTO schema_migrations (version, name, applied_at, dirty)
+			VALUES ($1, $2, NOW(), FALSE)
+			ON CONFLICT (version) DO UPDATE SET dirty = FALSE
+		`, m.Version, m.Name); err != nil {
+			return fmt.Errorf("force migration %d_%s: %w", m.Version, m.Name, err)
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("commit force version %d: %w", version, err)
+	}
 	return nil
 }
 
@@ -2,6 +2,8 @@ package postgres
 
 import (
 	"context"
+	"database/sql"
+	"errors"
 	"testing"
 	"time"
 )
@@ -12,6 +14,13 @@ func TestMigrator_PostgresLifecycle(t *testing.T) {
 	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Second)
 	defer cancel()
 
+	migrations, err := loadMigrationsFromFS(migrationsFS)
+	if err != nil {
+		t.Fatalf("load migrations: %v", err)
+	}
+	latest := migrations[len(migrations)-1].Version
+	total := len(migrations)
+
 	// Reset migration state first.
 	if err := store.MigrateDown(ctx, 100); err != nil {
 		t.Fatalf("migrate down reset: %v", err)
@@ -32,7 +41,7 @@ func TestMigrator_PostgresLifecycle(t *testing.T) {
 	if err != nil {
 		t.Fatalf("migration status after up all: %v", err)
 	}
-	if version != 4 || count != 4 {
+	if version != latest || count != total {
 		t.Fatalf("unexpected status after up all: version=%d count=%d", version, count)
 	}
 
@@ -44,7 +53,7 @@ func TestMigrator_PostgresLifecycle(t *testing.T) {
 	if err != nil {
 		t.Fatalf("migration status after idempotent up: %v", err)
 	}
-	if version != 4 || count != 4 {
+	if version != latest || count != total {
 		t.Fatalf("unexpected status after idempotent up: version=%d count=%d", version, count)
 	}
 
@@ -55,7 +64,7 @@ func TestMigrator_PostgresLifecycle(t *testing.T) {
 	if err != nil {
 		t.Fatalf("migration status after down 1: %v", err)
 	}
-	if version != 3 || count != 3 {
+	if version != migrations[total-2].Version || count != total-1 {
 		t.Fatalf("unexpected status after down 1: version=%d count=%d", version, count)
 	}
 
@@ -66,23 +75,12 @@ func TestMigrator_PostgresLifecycle(t *testing.T) {
 	if err != nil {
 		t.Fatalf("migration status after down default: %v", err)
 	}
-	if version != 2 || count != 2 {
+	if version != migrations[total-3].Version || count != total-2 {
 		t.Fatalf("unexpected status after down default: version=%d count=%d", version, count)
 	}
 
-	if err := store.MigrateDown(ctx, 1); err != nil {
-		t.Fatalf("migrate down next step: %v", err)
-	}
-	version, count, err = store.MigrationStatus(ctx)
-	if err != nil {
-		t.Fatalf("migration status after down next step: %v", err)
-	}
-	if version != 1 || count != 1 {
-		t.Fatalf("unexpected status after down next step: version=%d count=%d", version, count)
-	}
-
-	if err := store.MigrateDown(ctx, 1); err != nil {
-		t.Fatalf("migrate down final step: %v", err)
+	if err := store.MigrateDown(ctx, total); err != nil {
+		t.Fatalf("migrate down remaining steps: %v", err)
 	}
 	version, count, err = store.MigrationStatus(ctx)
 	if err != nil {
@@ -112,9 +110,72 @@ func TestMigrator_GuardsAndUnsupportedDirection(t *testing.T) {
 	if _, _, err := nilStore.MigrationStatus(ctx); err == nil {
 		t.Fatal("expected error for nil store MigrationStatus")
 	}
+	if err := nilStore.ForceVersion(ctx, 1); err == nil {
+		t.Fatal("expected error for nil store ForceVersion")
+	}
 
 	store := openRawPostgresStoreForIntegrationTest(t)
 	if err := store.migrate(ctx, migrationDirection("invalid"), 0); err == nil {
 		t.Fatal("expected unsupported direction error")
 	}
 }
+
+func TestMigrator_DirtyStateBlocksUntilForced(t *testing.T) {
+	store := openRawPostgresStoreForIntegrationTest(t)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Second)
+	defer cancel()
+
+	if err := store.MigrateUp(ctx, 0); err != nil {
+		t.Fatalf("migrate up: %v", err)
+	}
+	latest, _, err := store.MigrationStatus(ctx)
+	if err != nil {
+		t.Fatalf("migration status: %v", err)
+	}
+	t.Cleanup(func() {
+		_ = store.ForceVersion(context.Background(), latest)
+	})
+
+	broken := migration{Version: latest + 1, Name: "broken", UpSQL: "SELECT missing_column FROM schema_migrations", DownSQL: "SELECT 1"}
+	err = store.withMigrationLock(ctx, func(conn *sql.Conn) error {
+		return applyOneUp(ctx, conn, broken)
+	})
+	if err == nil {
+		t.Fatal("expected broken migration to fail")
+	}
+
+	version, dirty, err := store.DirtyMigration(ctx)
+	if err != nil {
+		t.Fatalf("dirty migration: %v", err)
+	}
+	if !dirty || version != broken.Version {
+		t.Fatalf("expected dirty version %d, got dirty=%v version=%d", broken.Version, dirty, version)
+	}
+	if err := store.MigrateUp(ctx, 0); !errors.Is(err, ErrMigrationDirty) {
+		t.Fatalf("expected ErrMigrationDirty on up, got %v", err)
+	}
+	if err := store.MigrateDown(ctx, 1); !errors.Is(err, ErrMigrationDirty) {
+		t.Fatalf("expected ErrMigrationDirty on down, got %v", err)
+	}
+
+	if err := store.ForceVersion(ctx, broken.Version); err == nil {
+		t.Fatal("expected error for unknown force version")
+	}
+	if err := store.ForceVersion(ctx, latest); err != nil {
+		t.Fatalf("force version: %v", err)
+	}
+	if _, dirty, err := store.DirtyMigration(ctx); err != nil || dirty {
+		t.Fatalf("expected clean state after force, dirty=%v err=%v", dirty, err)
+	}
+	version, _, err = store.MigrationStatus(ctx)
+	if err != nil {
+		t.Fatalf("migration status after force: %v", err)
+	}
+	if version != latest {
+		t.Fatalf("expected version %d after force, got %d", latest, version)
+	}
+	if err := store.MigrateUp(ctx, 0); err != nil {
+		t.Fatalf("migrate up after force: %v", err)
+	}
+}
@@ -0,0 +1,197 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/vladislavdragonenkov/oms/internal/domain"
+)
+
+// streamTimeout ограничивает потоковую выборку: она может занимать больше одного opTimeout.
+const streamTimeout = time.Minute
+
+// joinedOrdersQuery дополняет выборку заказов позициями и разбивкой суммы одним запросом.
+// Строки одного заказа идут подряд, поэтому заказы собираются без буферизации всей выборки.
+const joinedOrdersQuery = `
+	WITH selected AS (%s)
+	SELECT o.id, o.customer_id, o.status, o.currency, o.amount_minor, o.version, o.created_at, o.updated_at,
+	       o.hold_reason, o.held_from_status, o.test_mode,
+	       i.id, i.sku, i.qty, i.price_minor, i.created_at,
+	       a.subtotal_minor, a.discount_minor, a.tax_minor, a.total_minor, a.adjustments,
+	       a.rounding_mode
+	FROM selected o
+	LEFT JOIN order_items i ON i.order_id = o.id
+	LEFT JOIN order_amounts a ON a.order_id = o.id
+	ORDER BY %s, i.created_at ASC, i.id ASC
+`
+
+// orderSelection — выборка заказов: запрос по таблице orders и порядок, в котором её нужно отдать
+// (в терминах алиаса o).
+type orderSelection struct {
+	query   string
+	orderBy string
+	args    []any
+}
+
+// StreamByCustomer передаёт заказы клиента в fn по одному, от новых к старым.
+// Ошибка fn прекращает чтение и возвращается как есть.
+func (r *orderRepository) StreamByCustomer(customerID string, limit int, fn func(domain.Order) error) error {
+	ctx, cancel := context.WithTimeout(context.Background(), streamTimeout)
+	defer cancel()
+
+	return r.streamOrders(ctx, customerSelection(customerID, limit), fn)
+}
+
+const orderColumns = `id, customer_id, status, currency, amount_minor, version, created_at, updated_at,
+	hold_reason, held_from_status, test_mode`
+
+func customerSelection(customerID string, limit int) orderSelection {
+	return orderSelection{
+		query:   `SELECT ` + orderColumns + ` FROM orders WHERE customer_id = $1 ORDER BY created_at DESC, id DESC`,
+		orderBy: "o.created_at DESC, o.id DESC",
+		args:    []any{customerID},
+	}.withLimit(limit)
+}
+
+func statusSelection(status domain.OrderStatus, limit int) orderSelection {
+	return orderSelection{
+		query:   `SELECT ` + orderColumns + ` FROM orders WHERE status = $1 ORDER BY created_at ASC, id ASC`,
+		orderBy: "o.created_at ASC, o.id ASC",
+		args:    []any{string(status)},
+	}.withLimit(limit)
+}
+
+func afterIDSelection(afterID string, limit int) orderSelection {
+	return orderSelection{
+		query:   `SELECT ` + orderColumns + ` FROM orders WHERE id > $1 ORDER BY id ASC`,
+		orderBy: "o.id ASC",
+		args:    []any{afterID},
+	}.withLimit(limit)
+}
+
+func idsSelection(ids []string) orderSelection {
+	return orderSelection{
+		query:   `SELECT ` + orderColumns + ` FROM orders WHERE id = ANY($1)`,
+		orderBy: "o.id ASC",
+		args:    []any{ids},
+	}
+}
+
+func (s orderSelection) withLimit(limit int) orderSelection {
+	if limit > 0 {
+		s.query += fmt.Sprintf(" LIMIT $%d", len(s.args)+1)
+		s.args = append(s.args, limit)
+	}
+	return s
+}
+
+func (r *orderRepository) listOrders(ctx context.Context, selection orderSelection) ([]domain.Order, error) {
+	orders := make([]domain.Order, 0)
+	err := r.streamOrders(ctx, selection, func(order domain.Order) error {
+		orders = append(orders, order)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return orders, nil
+}
+
+func (r *orderRepository) streamOrders(ctx context.Context, selection orderSelection, fn func(domain.Order) error) error {
+	rows, err := r.db.QueryContext(ctx, fmt.Sprintf(joinedOrdersQuery, selection.query, selection.orderBy), selection.args...)
+	if err != nil {
+		return fmt.Errorf("query orders: %w", err)
+	}
+	defer rows.Close()
+
+	var (
+		current domain.Order
+		started bool
+	)
+	for rows.Next() {
+		var (
+			order          domain.Order
+			status, held   string
+			itemID, sku    sql.NullString
+			qty, price     sql.NullInt64
+			itemCreatedAt  sql.NullTime
+			subtotal, disc sql.NullInt64
+			tax, total     sql.NullInt64
+			adjustments    []byte
+			roundingMode   sql.NullString
+		)
+		if err := rows.Scan(
+			&order.ID, &order.CustomerID, &status, &order.Currency,
+			&order.AmountMinor, &order.Version, &order.CreatedAt, &order.UpdatedAt,
+			&order.HoldReason, &held, &order.TestMode,
+			&itemID, &sku, &qty, &price, &itemCreatedAt,
+			&subtotal, &disc, &tax, &total, &adjustments, &roundingMode,
+		); err != nil {
+			return fmt.Errorf("scan order row: %w", err)
+		}
+
+		if !started || current.ID != order.ID {
+			if started {
+				if err := fn(current); err != nil {
+					return err
+				}
+			}
+			order.Status = domain.OrderStatus(status)
+			order.HeldFromStatus = domain.OrderStatus(held)
+			order.Items = make([]domain.OrderItem, 0)
+			if subtotal.Valid {
+				order.Pricing = domain.OrderPricing{
+					SubtotalMinor: subtotal.Int64,
+					DiscountMinor: disc.Int64,
+					TaxMinor:      tax.Int64,
+					TotalMinor:    total.Int64,
+					RoundingMode:  domain.RoundingMode(roundingMode.String),
+				}
+				if order.Pricing.Adjustments, err = decodeAdjustments(adjustments); err != nil {
+					return err
+				}
+			}
+			current, started = order, true
+		}
+
+		if itemID.Valid {
+			current.Items = append(current.Items, domain.OrderItem{
+				ID:         itemID.String,
+				SKU:        sku.String,
+				Qty:        int32(qty.Int64),
+				PriceMinor: price.Int64,
+				CreatedAt:  itemCreatedAt.Time,
+			})
+		}
+	}
+	if err := rows.Err(); err != nil {
+		return fmt.Errorf("iterate order rows: %w", err)
+	}
+	if started {
+		return fn(current)
+	}
+	return nil
+}
+
+// decodeAdjustments разбирает JSONB-колонку order_amounts.adjustments.
+func decodeAdjustments(raw []byte) ([]domain.PriceAdjustment, error) {
+	var rows []amountAdjustmentRow
+	if err := json.Unmarshal(raw, &rows); err != nil {
+		return nil, fmt.Errorf("decode order adjustments: %w", err)
+	}
+	adjustments := make([]domain.PriceAdjustment, 0, len(rows))
+	for _, row := range rows {
+		adjustments = append(adjustments, domain.PriceAdjustment{
+			Type:                domain.AdjustmentType(row.Type),
+			Code:                row.Code,
+			FixedMinor:          row.FixedMinor,
+			PercentScaled:       row.PercentScaled,
+			AppliedMinor:        row.AppliedMinor,
+			RoundingDeltaScaled: row.RoundingDeltaScaled,
+		})
+	}
+	return adjustments, nil
+}
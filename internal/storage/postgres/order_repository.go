@@ -3,6 +3,7 @@ package postgres
 import (
 	"context"
 	"database/sql"
+	"encoding/json"
 	"errors"
 	"fmt"
 	"time"
@@ -10,6 +11,7 @@ import (
 	"github.com/jackc/pgx/v5/pgconn"
 
 	"github.com/vladislavdragonenkov/oms/internal/domain"
+	"github.com/vladislavdragonenkov/oms/internal/timeutil"
 )
 
 const (
@@ -25,7 +27,20 @@ func NewOrderRepository(store *Store) domain.OrderRepository {
 	return &orderRepository{db: store.DB()}
 }
 
+// checkOrderID отсекает некорректный ID без запроса в БД: Create такой заказ не пропустил бы,
+// поэтому для вызывающего это обычный ErrOrderNotFound (с причиной в тексте ошибки).
+func checkOrderID(id string) error {
+	if _, err := domain.ParseOrderID(id); err != nil {
+		return fmt.Errorf("%w: %w", domain.ErrOrderNotFound, err)
+	}
+	return nil
+}
+
 func (r *orderRepository) Create(order domain.Order) error {
+	if err := order.ValidateIDs(); err != nil {
+		return err
+	}
+
 	ctx, cancel := context.WithTimeout(context.Background(), opTimeout)
 	defer cancel()
 
@@ -40,13 +55,28 @@ func (r *orderRepository) Create(order domain.Order) error {
 		}
 	}()
 
-	_, err = tx.ExecContext(ctx, `
+	if err = insertOrder(ctx, tx, order); err != nil {
+		return err
+	}
+
+	if err = tx.Commit(); err != nil {
+		return fmt.Errorf("commit create order: %w", err)
+	}
+
+	return nil
+}
+
+// insertOrder записывает заказ и его позиции в рамках переданной транзакции.
+func insertOrder(ctx context.Context, tx *sql.Tx, order domain.Order) error {
+	_, err := tx.ExecContext(ctx, `
 		INSERT INTO orders (
-			id, customer_id, status, currency, amount_minor, version, created_at, updated_at
-		) VALUES ($1,$2,$3,$4,$5,$6,$7,$8)
+			id, customer_id, status, currency, amount_minor, version, created_at, updated_at,
+			hold_reason, held_from_status, test_mode
+		) VALUES ($1,$2,$3,$4,$5,$6,$7,$8,$9,$10,$11)
 	`,
 		order.ID, order.CustomerID, string(order.Status), order.Currency,
 		order.AmountMinor, order.Version, order.CreatedAt, order.UpdatedAt,
+		order.HoldReason, string(order.HeldFromStatus), order.TestMode,
 	)
 	if err != nil {
 		if isUniqueViolation(err) {
@@ -56,7 +86,7 @@ func (r *orderRepository) Create(order domain.Order) error {
 	}
 
 	for _, item := range order.Items {
-		if _, err = tx.ExecContext(ctx, `
+		if _, err := tx.ExecContext(ctx, `
 			INSERT INTO order_items (
 				id, order_id, sku, qty, price_minor, created_at
 			) VALUES ($1,$2,$3,$4,$5,$6)
@@ -67,27 +97,76 @@ func (r *orderRepository) Create(order domain.Order) error {
 		}
 	}
 
-	if err = tx.Commit(); err != nil {
-		return fmt.Errorf("commit create order: %w", err)
+	return insertAmounts(ctx, tx, order)
+}
+
+// amountAdjustmentRow — строка корректировки в JSONB-колонке order_amounts.adjustments.
+type amountAdjustmentRow struct {
+	Type          string `json:"type"`
+	Code          string `json:"code,omitempty"`
+	FixedMinor    int64  `json:"fixed_minor,omitempty"`
+	PercentScaled int64  `json:"percent_scaled,omitempty"`
+	AppliedMinor  int64  `json:"applied_minor"`
+	// RoundingDeltaScaled — дельта округления строки (domain.RoundingDeltaScale); до миграции
+	// 0015 не сохранялась, миграция досчитывает её для старых строк.
+	RoundingDeltaScaled int64 `json:"rounding_delta_scaled,omitempty"`
+}
+
+// insertAmounts сохраняет разбивку суммы; заказы без скидок и налогов строки не получают.
+func insertAmounts(ctx context.Context, tx *sql.Tx, order domain.Order) error {
+	if order.Pricing.IsZero() {
+		return nil
+	}
+	rows := make([]amountAdjustmentRow, 0, len(order.Pricing.Adjustments))
+	for _, adj := range order.Pricing.Adjustments {
+		rows = append(rows, amountAdjustmentRow{
+			Type:                string(adj.Type),
+			Code:                adj.Code,
+			FixedMinor:          adj.FixedMinor,
+			PercentScaled:       adj.PercentScaled,
+			AppliedMinor:        adj.AppliedMinor,
+			RoundingDeltaScaled: adj.RoundingDeltaScaled,
+		})
+	}
+	adjustments, err := json.Marshal(rows)
+	if err != nil {
+		return fmt.Errorf("encode order adjustments: %w", err)
 	}
 
+	pricing := order.Pricing
+	if _, err := tx.ExecContext(ctx, `
+		INSERT INTO order_amounts (
+			order_id, subtotal_minor, discount_minor, tax_minor, total_minor, adjustments, rounding_mode
+		) VALUES ($1,$2,$3,$4,$5,$6,$7)
+	`,
+		order.ID, pricing.SubtotalMinor, pricing.DiscountMinor, pricing.TaxMinor, pricing.TotalMinor, adjustments,
+		string(pricing.RoundingMode),
+	); err != nil {
+		return fmt.Errorf("insert order amounts: %w", err)
+	}
 	return nil
 }
 
 func (r *orderRepository) Get(id string) (domain.Order, error) {
+	if err := checkOrderID(id); err != nil {
+		return domain.Order{}, err
+	}
+
 	ctx, cancel := context.WithTimeout(context.Background(), opTimeout)
 	defer cancel()
 
 	var order domain.Order
-	var status string
+	var status, heldFrom string
 
 	err := r.db.QueryRowContext(ctx, `
-		SELECT id, customer_id, status, currency, amount_minor, version, created_at, updated_at
+		SELECT id, customer_id, status, currency, amount_minor, version, created_at, updated_at,
+		       hold_reason, held_from_status, test_mode
 		FROM orders
 		WHERE id = $1
 	`, id).Scan(
 		&order.ID, &order.CustomerID, &status, &order.Currency,
 		&order.AmountMinor, &order.Version, &order.CreatedAt, &order.UpdatedAt,
+		&order.HoldReason, &heldFrom, &order.TestMode,
 	)
 	if err != nil {
 		if errors.Is(err, sql.ErrNoRows) {
@@ -96,6 +175,7 @@ func (r *orderRepository) Get(id string) (domain.Order, error) {
 		return domain.Order{}, fmt.Errorf("select order: %w", err)
 	}
 	order.Status = domain.OrderStatus(status)
+	order.HeldFromStatus = domain.OrderStatus(heldFrom)
 
 	items, err := r.loadItems(ctx, order.ID)
 	if err != nil {
@@ -103,58 +183,62 @@ func (r *orderRepository) Get(id string) (domain.Order, error) {
 	}
 	order.Items = items
 
+	if order.Pricing, err = r.loadAmounts(ctx, order.ID); err != nil {
+		return domain.Order{}, err
+	}
+
 	return order, nil
 }
 
+// GetMany читает заказы одним запросом WHERE id = ANY($1). Некорректные ID пропускаются так же,
+// как отсутствующие: Create такие заказы не пропустил бы.
+func (r *orderRepository) GetMany(ids []string) ([]domain.Order, error) {
+	valid := make([]string, 0, len(ids))
+	for _, id := range ids {
+		if checkOrderID(id) == nil {
+			valid = append(valid, id)
+		}
+	}
+	if len(valid) == 0 {
+		return []domain.Order{}, nil
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), opTimeout)
+	defer cancel()
+
+	orders, err := r.listOrders(ctx, idsSelection(valid))
+	if err != nil {
+		return nil, fmt.Errorf("get orders by ids: %w", err)
+	}
+	return orders, nil
+}
+
 func (r *orderRepository) ListByCustomer(customerID string, limit int) ([]domain.Order, error) {
 	ctx, cancel := context.WithTimeout(context.Background(), opTimeout)
 	defer cancel()
 
-	query := `
-		SELECT id, customer_id, status, currency, amount_minor, version, created_at, updated_at
-		FROM orders
-		WHERE customer_id = $1
-		ORDER BY created_at DESC, id DESC
-	`
+	return r.listOrders(ctx, customerSelection(customerID, limit))
+}
 
-	var (
-		rows *sql.Rows
-		err  error
-	)
+func (r *orderRepository) ListByStatus(status domain.OrderStatus, limit int) ([]domain.Order, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), opTimeout)
+	defer cancel()
 
-	if limit > 0 {
-		rows, err = r.db.QueryContext(ctx, query+" LIMIT $2", customerID, limit)
-	} else {
-		rows, err = r.db.QueryContext(ctx, query, customerID)
-	}
+	orders, err := r.listOrders(ctx, statusSelection(status, limit))
 	if err != nil {
-		return nil, fmt.Errorf("list orders: %w", err)
+		return nil, fmt.Errorf("list orders by status: %w", err)
 	}
-	defer rows.Close()
+	return orders, nil
+}
 
-	orders := make([]domain.Order, 0)
-	for rows.Next() {
-		var order domain.Order
-		var status string
-		if err := rows.Scan(
-			&order.ID, &order.CustomerID, &status, &order.Currency,
-			&order.AmountMinor, &order.Version, &order.CreatedAt, &order.UpdatedAt,
-		); err != nil {
-			return nil, fmt.Errorf("scan order row: %w", err)
-		}
-		order.Status = domain.OrderStatus(status)
+func (r *orderRepository) ListAfter(afterID string, limit int) ([]domain.Order, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), opTimeout)
+	defer cancel()
 
-		items, err := r.loadItems(ctx, order.ID)
-		if err != nil {
-			return nil, err
-		}
-		order.Items = items
-		orders = append(orders, order)
-	}
-	if err := rows.Err(); err != nil {
-		return nil, fmt.Errorf("iterate order rows: %w", err)
+	orders, err := r.listOrders(ctx, afterIDSelection(afterID, limit))
+	if err != nil {
+		return nil, fmt.Errorf("list orders after id: %w", err)
 	}
-
 	return orders, nil
 }
 
@@ -180,15 +264,19 @@ func (r *orderRepository) Save(order domain.Order) error {
 		    currency = $3,
 		    amount_minor = $4,
 		    version = version + 1,
-		    updated_at = $5
-		WHERE id = $6
-		  AND version = $7
+		    updated_at = $5,
+		    hold_reason = $6,
+		    held_from_status = $7
+		WHERE id = $8
+		  AND version = $9
 	`,
 		order.CustomerID,
 		string(order.Status),
 		order.Currency,
 		order.AmountMinor,
 		order.UpdatedAt,
+		order.HoldReason,
+		string(order.HeldFromStatus),
 		order.ID,
 		order.Version,
 	)
@@ -218,6 +306,177 @@ func (r *orderRepository) Save(order domain.Order) error {
 	return nil
 }
 
+// UpdatePrices обновляет цены позиций, amount_minor и order_amounts в одной транзакции с проверкой версии.
+func (r *orderRepository) UpdatePrices(order domain.Order) error {
+	return r.updateContents(order, "update prices", updateItemPrices)
+}
+
+// UpdateItems заменяет позиции заказа, amount_minor и order_amounts в одной транзакции с проверкой версии.
+func (r *orderRepository) UpdateItems(order domain.Order) error {
+	return r.updateContents(order, "update items", replaceItems)
+}
+
+// updateContents поднимает версию заказа с новой суммой, переписывает позиции через writeItems
+// и разбивку суммы; операция попадает в текст ошибки коммита.
+func (r *orderRepository) updateContents(order domain.Order, operation string, writeItems func(context.Context, *sql.Tx, domain.Order) error) error {
+	ctx, cancel := context.WithTimeout(context.Background(), opTimeout)
+	defer cancel()
+
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("begin tx: %w", err)
+	}
+
+	defer func() {
+		if err != nil {
+			_ = tx.Rollback()
+		}
+	}()
+
+	res, err := tx.ExecContext(ctx, `
+		UPDATE orders
+		SET amount_minor = $1,
+		    version = version + 1,
+		    updated_at = $2
+		WHERE id = $3
+		  AND version = $4
+	`, order.AmountMinor, order.UpdatedAt, order.ID, order.Version)
+	if err != nil {
+		return fmt.Errorf("update order amount: %w", err)
+	}
+	affected, err := res.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("rows affected: %w", err)
+	}
+	if affected == 0 {
+		exists, err := r.orderExistsTx(ctx, tx, order.ID)
+		if err != nil {
+			return err
+		}
+		if !exists {
+			return domain.ErrOrderNotFound
+		}
+		return domain.ErrOrderVersionConflict
+	}
+
+	if err = writeItems(ctx, tx, order); err != nil {
+		return err
+	}
+	if _, err = tx.ExecContext(ctx, `DELETE FROM order_amounts WHERE order_id = $1`, order.ID); err != nil {
+		return fmt.Errorf("delete order amounts: %w", err)
+	}
+	if err = insertAmounts(ctx, tx, order); err != nil {
+		return err
+	}
+
+	if err = tx.Commit(); err != nil {
+		return fmt.Errorf("commit %s: %w", operation, err)
+	}
+	return nil
+}
+
+func updateItemPrices(ctx context.Context, tx *sql.Tx, order domain.Order) error {
+	for _, item := range order.Items {
+		if _, err := tx.ExecContext(ctx, `
+			UPDATE order_items SET price_minor = $1 WHERE order_id = $2 AND id = $3
+		`, item.PriceMinor, order.ID, item.ID); err != nil {
+			return fmt.Errorf("update order item price: %w", err)
+		}
+	}
+	return nil
+}
+
+// replaceItems удаляет позиции заказа и записывает order.Items заново: ID и created_at
+// сохранённых позиций приходят в order.Items без изменений.
+func replaceItems(ctx context.Context, tx *sql.Tx, order domain.Order) error {
+	if _, err := tx.ExecContext(ctx, `DELETE FROM order_items WHERE order_id = $1`, order.ID); err != nil {
+		return fmt.Errorf("delete order items: %w", err)
+	}
+	for _, item := range order.Items {
+		if _, err := tx.ExecContext(ctx, `
+			INSERT INTO order_items (
+				id, order_id, sku, qty, price_minor, created_at
+			) VALUES ($1,$2,$3,$4,$5,$6)
+		`,
+			item.ID, order.ID, item.SKU, item.Qty, item.PriceMinor, item.CreatedAt,
+		); err != nil {
+			return fmt.Errorf("insert order item: %w", err)
+		}
+	}
+	return nil
+}
+
+// UpdateStatusCAS обновляет только статус одним UPDATE ... WHERE version = $n; позиции и суммы не трогаются.
+func (r *orderRepository) UpdateStatusCAS(orderID string, from, to domain.OrderStatus, expectedVersion int64) (int64, error) {
+	if err := checkOrderID(orderID); err != nil {
+		return 0, err
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), opTimeout)
+	defer cancel()
+
+	var version int64
+	err := r.db.QueryRowContext(ctx, `
+		UPDATE orders
+		SET status = $1,
+		    version = version + 1,
+		    updated_at = $2,
+		    hold_reason = CASE WHEN status = $3 THEN '' ELSE hold_reason END,
+		    held_from_status = CASE WHEN status = $3 THEN '' ELSE held_from_status END
+		WHERE id = $4
+		  AND status = $5
+		  AND version = $6
+		RETURNING version
+	`,
+		string(to),
+		timeutil.Now(),
+		string(domain.OrderStatusOnHold),
+		orderID,
+		string(from),
+		expectedVersion,
+	).Scan(&version)
+	if errors.Is(err, sql.ErrNoRows) {
+		var exists bool
+		if err := r.db.QueryRowContext(ctx, `SELECT EXISTS(SELECT 1 FROM orders WHERE id = $1)`, orderID).Scan(&exists); err != nil {
+			return 0, fmt.Errorf("check order exists: %w", err)
+		}
+		if !exists {
+			return 0, domain.ErrOrderNotFound
+		}
+		return 0, domain.ErrOrderVersionConflict
+	}
+	if err != nil {
+		return 0, fmt.Errorf("update order status: %w", err)
+	}
+
+	return version, nil
+}
+
+func (r *orderRepository) Delete(id string) error {
+	if err := checkOrderID(id); err != nil {
+		return err
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), opTimeout)
+	defer cancel()
+
+	// order_items и timeline_events удаляются каскадно.
+	res, err := r.db.ExecContext(ctx, `DELETE FROM orders WHERE id = $1`, id)
+	if err != nil {
+		return fmt.Errorf("delete order: %w", err)
+	}
+
+	affected, err := res.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("rows affected: %w", err)
+	}
+	if affected == 0 {
+		return domain.ErrOrderNotFound
+	}
+
+	return nil
+}
+
 func (r *orderRepository) loadItems(ctx context.Context, orderID string) ([]domain.OrderItem, error) {
 	rows, err := r.db.QueryContext(ctx, `
 		SELECT id, sku, qty, price_minor, created_at
@@ -245,6 +504,30 @@ func (r *orderRepository) loadItems(ctx context.Context, orderID string) ([]doma
 	return items, nil
 }
 
+// loadAmounts читает разбивку суммы; для заказа без строки в order_amounts она пустая.
+func (r *orderRepository) loadAmounts(ctx context.Context, orderID string) (domain.OrderPricing, error) {
+	var pricing domain.OrderPricing
+	var raw []byte
+	var mode string
+	err := r.db.QueryRowContext(ctx, `
+		SELECT subtotal_minor, discount_minor, tax_minor, total_minor, adjustments, rounding_mode
+		FROM order_amounts
+		WHERE order_id = $1
+	`, orderID).Scan(&pricing.SubtotalMinor, &pricing.DiscountMinor, &pricing.TaxMinor, &pricing.TotalMinor, &raw, &mode)
+	if errors.Is(err, sql.ErrNoRows) {
+		return domain.OrderPricing{}, nil
+	}
+	if err != nil {
+		return domain.OrderPricing{}, fmt.Errorf("load order amounts: %w", err)
+	}
+
+	if pricing.Adjustments, err = decodeAdjustments(raw); err != nil {
+		return domain.OrderPricing{}, err
+	}
+	pricing.RoundingMode = domain.RoundingMode(mode)
+	return pricing, nil
+}
+
 func (r *orderRepository) orderExistsTx(ctx context.Context, tx *sql.Tx, orderID string) (bool, error) {
 	var id string
 	err := tx.QueryRowContext(ctx, `SELECT id FROM orders WHERE id = $1`, orderID).Scan(&id)
@@ -265,4 +548,10 @@ func isUniqueViolation(err error) bool {
 	return false
 }
 
-var _ domain.OrderRepository = (*orderRepository)(nil)
+var (
+	_ domain.OrderRepository   = (*orderRepository)(nil)
+	_ domain.OrderPriceUpdater = (*orderRepository)(nil)
+	_ domain.OrderItemsUpdater = (*orderRepository)(nil)
+	_ domain.OrderStreamer     = (*orderRepository)(nil)
+	_ domain.OrderBatchReader  = (*orderRepository)(nil)
+)
@@ -2,6 +2,7 @@ package postgres
 
 import (
 	"errors"
+	"reflect"
 	"testing"
 	"time"
 
@@ -68,6 +69,24 @@ func TestOrderRepository_PostgresCreateGetListAndSave(t *testing.T) {
 	if updated.Version != got.Version+1 {
 		t.Fatalf("unexpected version after save: got=%d want=%d", updated.Version, got.Version+1)
 	}
+
+	paid, err := repo.ListByStatus(domain.OrderStatusPaid, 0)
+	if err != nil {
+		t.Fatalf("list by status: %v", err)
+	}
+	if len(paid) != 1 || paid[0].ID != order1.ID || len(paid[0].Items) != len(order1.Items) {
+		t.Fatalf("unexpected list by status result: %+v", paid)
+	}
+
+	if err := repo.Delete(order2.ID); err != nil {
+		t.Fatalf("delete order2: %v", err)
+	}
+	if _, err := repo.Get(order2.ID); !errors.Is(err, domain.ErrOrderNotFound) {
+		t.Fatalf("expected ErrOrderNotFound after delete, got %v", err)
+	}
+	if err := repo.Delete(order2.ID); !errors.Is(err, domain.ErrOrderNotFound) {
+		t.Fatalf("expected ErrOrderNotFound on repeated delete, got %v", err)
+	}
 }
 
 func TestOrderRepository_PostgresErrors(t *testing.T) {
@@ -101,6 +120,309 @@ func TestOrderRepository_PostgresErrors(t *testing.T) {
 	}
 }
 
+func TestOrderRepository_PostgresHoldRoundTrip(t *testing.T) {
+	store := openPostgresStoreForIntegrationTest(t)
+	repo := NewOrderRepository(store)
+
+	now := time.Now().UTC().Round(time.Microsecond)
+	order := sampleOrder("order-hold", "customer-hold", now)
+	order.Status = domain.OrderStatusReserved
+	if err := repo.Create(order); err != nil {
+		t.Fatalf("create order: %v", err)
+	}
+
+	if err := order.Hold("fraud review"); err != nil {
+		t.Fatalf("hold: %v", err)
+	}
+	if err := repo.Save(order); err != nil {
+		t.Fatalf("save held order: %v", err)
+	}
+
+	held, err := repo.Get(order.ID)
+	if err != nil {
+		t.Fatalf("get held order: %v", err)
+	}
+	if held.Status != domain.OrderStatusOnHold || held.HoldReason != "fraud review" || held.HeldFromStatus != domain.OrderStatusReserved {
+		t.Fatalf("unexpected held order: %+v", held)
+	}
+
+	if err := held.Release(); err != nil {
+		t.Fatalf("release: %v", err)
+	}
+	if err := repo.Save(held); err != nil {
+		t.Fatalf("save released order: %v", err)
+	}
+	released, err := repo.Get(order.ID)
+	if err != nil {
+		t.Fatalf("get released order: %v", err)
+	}
+	if released.Status != domain.OrderStatusReserved || released.HoldReason != "" || released.HeldFromStatus != "" {
+		t.Fatalf("unexpected released order: %+v", released)
+	}
+}
+
+func TestOrderRepository_PostgresUpdateStatusCAS(t *testing.T) {
+	store := openPostgresStoreForIntegrationTest(t)
+	repo := NewOrderRepository(store)
+
+	now := time.Now().UTC().Round(time.Microsecond)
+	order := sampleOrder("order-cas", "customer-cas", now)
+	order.Status = domain.OrderStatusReserved
+	if err := order.Hold("fraud review"); err != nil {
+		t.Fatalf("hold: %v", err)
+	}
+	if err := repo.Create(order); err != nil {
+		t.Fatalf("create order: %v", err)
+	}
+
+	if _, err := repo.UpdateStatusCAS(order.ID, domain.OrderStatusOnHold, domain.OrderStatusCanceled, order.Version+5); !errors.Is(err, domain.ErrOrderVersionConflict) {
+		t.Fatalf("expected ErrOrderVersionConflict on stale version, got %v", err)
+	}
+	if _, err := repo.UpdateStatusCAS(order.ID, domain.OrderStatusPaid, domain.OrderStatusCanceled, order.Version); !errors.Is(err, domain.ErrOrderVersionConflict) {
+		t.Fatalf("expected ErrOrderVersionConflict on status mismatch, got %v", err)
+	}
+	if _, err := repo.UpdateStatusCAS("missing-order", domain.OrderStatusPending, domain.OrderStatusCanceled, 0); !errors.Is(err, domain.ErrOrderNotFound) {
+		t.Fatalf("expected ErrOrderNotFound, got %v", err)
+	}
+
+	version, err := repo.UpdateStatusCAS(order.ID, domain.OrderStatusOnHold, domain.OrderStatusCanceled, order.Version)
+	if err != nil {
+		t.Fatalf("update status cas: %v", err)
+	}
+	if version != order.Version+1 {
+		t.Fatalf("expected version %d, got %d", order.Version+1, version)
+	}
+
+	got, err := repo.Get(order.ID)
+	if err != nil {
+		t.Fatalf("get order: %v", err)
+	}
+	if got.Status != domain.OrderStatusCanceled || got.Version != version || got.HoldReason != "" || got.HeldFromStatus != "" {
+		t.Fatalf("unexpected order after cas: %+v", got)
+	}
+	if len(got.Items) != len(order.Items) {
+		t.Fatalf("expected items untouched, got %d", len(got.Items))
+	}
+}
+
+func TestOrderRepository_PostgresTestModeRoundTrip(t *testing.T) {
+	store := openPostgresStoreForIntegrationTest(t)
+	repo := NewOrderRepository(store)
+
+	order := sampleOrder("order-sandbox", "customer-sandbox", time.Now().UTC().Round(time.Microsecond))
+	order.TestMode = true
+	if err := repo.Create(order); err != nil {
+		t.Fatalf("create order: %v", err)
+	}
+
+	got, err := repo.Get(order.ID)
+	if err != nil {
+		t.Fatalf("get order: %v", err)
+	}
+	if !got.TestMode {
+		t.Fatal("expected test_mode to round-trip through Get")
+	}
+
+	listed, err := repo.ListByCustomer("customer-sandbox", 10)
+	if err != nil {
+		t.Fatalf("list orders: %v", err)
+	}
+	if len(listed) != 1 || !listed[0].TestMode {
+		t.Fatalf("expected test_mode to round-trip through ListByCustomer: %+v", listed)
+	}
+}
+
+func TestOrderRepository_PostgresAmountsRoundTrip(t *testing.T) {
+	store := openPostgresStoreForIntegrationTest(t)
+	repo := NewOrderRepository(store)
+
+	order := sampleOrder("order-amounts", "customer-amounts", time.Now().UTC().Round(time.Microsecond))
+	pricing, err := domain.PriceOrder(order.AmountMinor, []domain.PriceAdjustment{
+		{Type: domain.AdjustmentDiscount, Code: "PROMO", FixedMinor: 50},
+		{Type: domain.AdjustmentTax, Code: "VAT", PercentScaled: 75_000},
+	})
+	if err != nil {
+		t.Fatalf("price order: %v", err)
+	}
+	order.Pricing = pricing
+	order.AmountMinor = pricing.TotalMinor
+	if err := repo.Create(order); err != nil {
+		t.Fatalf("create order: %v", err)
+	}
+
+	got, err := repo.Get(order.ID)
+	if err != nil {
+		t.Fatalf("get order: %v", err)
+	}
+	// 7.5% от 250 = 18.75 -> 19: дельта округления +0.25 сохраняется вместе с правилом.
+	if !reflect.DeepEqual(got.Pricing, pricing) || got.AmountMinor != 269 {
+		t.Fatalf("unexpected pricing: %+v", got.Pricing)
+	}
+	if got.Pricing.RoundingMode != domain.RoundingHalfUp || got.Pricing.Adjustments[1].RoundingDeltaScaled != 250_000 {
+		t.Fatalf("unexpected rounding audit: %+v", got.Pricing)
+	}
+}
+
+func TestOrderRepository_PostgresUpdatePrices(t *testing.T) {
+	store := openPostgresStoreForIntegrationTest(t)
+	repo := NewOrderRepository(store)
+
+	order := sampleOrder("order-reprice", "customer-reprice", time.Now().UTC().Round(time.Microsecond))
+	if err := repo.Create(order); err != nil {
+		t.Fatalf("create order: %v", err)
+	}
+
+	order.Pricing = domain.OrderPricing{
+		SubtotalMinor: 400, TaxMinor: 80, TotalMinor: 480,
+		Adjustments: []domain.PriceAdjustment{{Type: domain.AdjustmentTax, Code: "VAT", PercentScaled: 200_000, AppliedMinor: 80}},
+	}
+	order.Items[0].PriceMinor = 200
+	order.AmountMinor = 480
+	updater := repo.(domain.OrderPriceUpdater)
+	if err := updater.UpdatePrices(order); err != nil {
+		t.Fatalf("update prices: %v", err)
+	}
+	if err := updater.UpdatePrices(order); !errors.Is(err, domain.ErrOrderVersionConflict) {
+		t.Fatalf("expected version conflict, got %v", err)
+	}
+
+	got, err := repo.Get(order.ID)
+	if err != nil {
+		t.Fatalf("get order: %v", err)
+	}
+	if got.Version != 1 || got.AmountMinor != 480 || got.Items[0].PriceMinor != 200 || !reflect.DeepEqual(got.Pricing, order.Pricing) {
+		t.Fatalf("unexpected order after update: %+v", got)
+	}
+}
+
+func TestOrderRepository_PostgresUpdateItems(t *testing.T) {
+	store := openPostgresStoreForIntegrationTest(t)
+	repo := NewOrderRepository(store)
+
+	now := time.Now().UTC().Round(time.Microsecond)
+	order := sampleOrder("order-edit-items", "customer-edit-items", now)
+	if err := repo.Create(order); err != nil {
+		t.Fatalf("create order: %v", err)
+	}
+
+	order.Items = []domain.OrderItem{{ID: "item-edit-added", SKU: "SKU-ADDED", Qty: 3, PriceMinor: 70, CreatedAt: now}}
+	order.AmountMinor = 210
+	updater := repo.(domain.OrderItemsUpdater)
+	if err := updater.UpdateItems(order); err != nil {
+		t.Fatalf("update items: %v", err)
+	}
+	if err := updater.UpdateItems(order); !errors.Is(err, domain.ErrOrderVersionConflict) {
+		t.Fatalf("expected version conflict, got %v", err)
+	}
+
+	got, err := repo.Get(order.ID)
+	if err != nil {
+		t.Fatalf("get order: %v", err)
+	}
+	if got.Version != 1 || got.AmountMinor != 210 || len(got.Items) != 1 || got.Items[0].ID != "item-edit-added" || got.Items[0].Qty != 3 {
+		t.Fatalf("unexpected order after update: %+v", got)
+	}
+}
+
+func TestOrderRepository_PostgresListAfter(t *testing.T) {
+	store := openPostgresStoreForIntegrationTest(t)
+	repo := NewOrderRepository(store)
+
+	now := time.Now().UTC().Round(time.Microsecond)
+	for _, id := range []string{"order-scan-b", "order-scan-a", "order-scan-c"} {
+		if err := repo.Create(sampleOrder(id, "customer-scan", now)); err != nil {
+			t.Fatalf("create order %s: %v", id, err)
+		}
+	}
+
+	scanner := repo.(domain.OrderScanner)
+	page, err := scanner.ListAfter("order-scan-a", 1)
+	if err != nil {
+		t.Fatalf("list after: %v", err)
+	}
+	if len(page) != 1 || page[0].ID != "order-scan-b" || len(page[0].Items) == 0 {
+		t.Fatalf("unexpected page: %+v", page)
+	}
+}
+
+func TestOrderRepository_PostgresGetMany(t *testing.T) {
+	store := openPostgresStoreForIntegrationTest(t)
+	repo := NewOrderRepository(store)
+
+	now := time.Now().UTC().Round(time.Microsecond)
+	for _, id := range []string{"order-many-b", "order-many-a"} {
+		if err := repo.Create(sampleOrder(id, "customer-many", now)); err != nil {
+			t.Fatalf("create order %s: %v", id, err)
+		}
+	}
+
+	orders, err := repo.(domain.OrderBatchReader).GetMany([]string{"order-many-b", "order-many-missing", "order-many-a", "bad id"})
+	if err != nil {
+		t.Fatalf("get many: %v", err)
+	}
+	if len(orders) != 2 || orders[0].ID != "order-many-a" || orders[1].ID != "order-many-b" || len(orders[0].Items) == 0 {
+		t.Fatalf("unexpected orders: %+v", orders)
+	}
+}
+
+func TestOrderRepository_PostgresStreamByCustomer(t *testing.T) {
+	store := openPostgresStoreForIntegrationTest(t)
+	repo := NewOrderRepository(store)
+
+	now := time.Now().UTC().Round(time.Microsecond)
+	older := sampleOrder("order-stream-old", "customer-stream", now.Add(-time.Minute))
+	older.Items = append(older.Items, domain.OrderItem{ID: "order-stream-old-item-2", SKU: "SKU-2", Qty: 1, PriceMinor: 50, CreatedAt: now})
+	older.AmountMinor = 350
+	pricing, err := domain.PriceOrder(older.AmountMinor, []domain.PriceAdjustment{{Type: domain.AdjustmentDiscount, Code: "PROMO", FixedMinor: 50}})
+	if err != nil {
+		t.Fatalf("price order: %v", err)
+	}
+	older.Pricing = pricing
+	newer := sampleOrder("order-stream-new", "customer-stream", now)
+	newer.Items = nil
+	for _, order := range []domain.Order{older, newer} {
+		if err := repo.Create(order); err != nil {
+			t.Fatalf("create order %s: %v", order.ID, err)
+		}
+	}
+
+	var streamed []domain.Order
+	err = repo.(domain.OrderStreamer).StreamByCustomer("customer-stream", 0, func(order domain.Order) error {
+		streamed = append(streamed, order)
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("stream orders: %v", err)
+	}
+	if len(streamed) != 2 || streamed[0].ID != newer.ID || streamed[1].ID != older.ID {
+		t.Fatalf("unexpected stream order: %+v", streamed)
+	}
+	if len(streamed[0].Items) != 0 || !streamed[0].Pricing.IsZero() {
+		t.Fatalf("order without items or amounts must stay empty: %+v", streamed[0])
+	}
+	if len(streamed[1].Items) != 2 || streamed[1].Items[1].SKU != "SKU-2" || !reflect.DeepEqual(streamed[1].Pricing, pricing) {
+		t.Fatalf("unexpected joined order: %+v", streamed[1])
+	}
+
+	listed, err := repo.ListByCustomer("customer-stream", 1)
+	if err != nil {
+		t.Fatalf("list orders: %v", err)
+	}
+	if len(listed) != 1 || listed[0].ID != newer.ID {
+		t.Fatalf("limit must apply to orders, not joined rows: %+v", listed)
+	}
+
+	stop := errors.New("stop")
+	calls := 0
+	err = repo.(domain.OrderStreamer).StreamByCustomer("customer-stream", 0, func(domain.Order) error {
+		calls++
+		return stop
+	})
+	if !errors.Is(err, stop) || calls != 1 {
+		t.Fatalf("expected stream to stop after callback error, calls=%d err=%v", calls, err)
+	}
+}
+
 func TestIsUniqueViolation(t *testing.T) {
 	if !isUniqueViolation(&pgconn.PgError{Code: "23505"}) {
 		t.Fatal("expected unique violation for code 23505")
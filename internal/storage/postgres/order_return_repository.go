@@ -0,0 +1,197 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/vladislavdragonenkov/oms/internal/domain"
+)
+
+const orderReturnColumns = `id, order_id, status, items, reason, refund_minor, currency, created_at, updated_at`
+
+type returnRepository struct {
+	db *sql.DB
+}
+
+// NewReturnRepository создаёт PostgreSQL-реализацию ReturnRepository.
+func NewReturnRepository(store *Store) domain.ReturnRepository {
+	return &returnRepository{db: store.DB()}
+}
+
+// returnItemRow — позиция в JSONB-колонке order_returns.items.
+type returnItemRow struct {
+	ItemID      string `json:"item_id"`
+	SKU         string `json:"sku"`
+	Qty         int32  `json:"qty"`
+	RefundMinor int64  `json:"refund_minor"`
+}
+
+// Create блокирует строку заказа (FOR UPDATE), поэтому параллельные CreateReturn одного заказа
+// с разных реплик проверяют число возвратов и вставляют новый по очереди.
+func (r *returnRepository) Create(ret domain.OrderReturn, previous int) (domain.OrderReturn, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), opTimeout)
+	defer cancel()
+
+	if ret.ID == "" {
+		ret.ID = uuid.NewString()
+	}
+	now := time.Now().UTC()
+	ret.Status = domain.ReturnStatusRequested
+	ret.CreatedAt = now
+	ret.UpdatedAt = now
+
+	rows := make([]returnItemRow, 0, len(ret.Items))
+	for _, item := range ret.Items {
+		rows = append(rows, returnItemRow{ItemID: item.ItemID, SKU: item.SKU, Qty: item.Qty, RefundMinor: item.RefundMinor})
+	}
+	items, err := json.Marshal(rows)
+	if err != nil {
+		return domain.OrderReturn{}, fmt.Errorf("encode return items: %w", err)
+	}
+
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return domain.OrderReturn{}, fmt.Errorf("begin tx: %w", err)
+	}
+	defer func() {
+		if err != nil {
+			_ = tx.Rollback()
+		}
+	}()
+
+	var locked string
+	err = tx.QueryRowContext(ctx, `SELECT id FROM orders WHERE id = $1 FOR UPDATE`, ret.OrderID).Scan(&locked)
+	if errors.Is(err, sql.ErrNoRows) {
+		err = domain.ErrOrderNotFound
+		return domain.OrderReturn{}, err
+	}
+	if err != nil {
+		return domain.OrderReturn{}, fmt.Errorf("lock order: %w", err)
+	}
+	var existing int
+	if err = tx.QueryRowContext(ctx, `SELECT COUNT(*) FROM order_returns WHERE order_id = $1`, ret.OrderID).Scan(&existing); err != nil {
+		return domain.OrderReturn{}, fmt.Errorf("count order returns: %w", err)
+	}
+	if existing != previous {
+		err = domain.ErrReturnsChanged
+		return domain.OrderReturn{}, err
+	}
+
+	if _, err = tx.ExecContext(ctx, `
+		INSERT INTO order_returns (`+orderReturnColumns+`)
+		VALUES ($1,$2,$3,$4,$5,$6,$7,$8,$9)
+	`, ret.ID, ret.OrderID, string(ret.Status), items, ret.Reason, ret.RefundMinor, ret.Currency, ret.CreatedAt, ret.UpdatedAt); err != nil {
+		return domain.OrderReturn{}, fmt.Errorf("create order return: %w", err)
+	}
+	if err = tx.Commit(); err != nil {
+		return domain.OrderReturn{}, fmt.Errorf("commit order return: %w", err)
+	}
+	return ret, nil
+}
+
+func (r *returnRepository) Get(id string) (domain.OrderReturn, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), opTimeout)
+	defer cancel()
+
+	ret, err := scanOrderReturn(r.db.QueryRowContext(ctx, `
+		SELECT `+orderReturnColumns+` FROM order_returns WHERE id = $1
+	`, id))
+	if errors.Is(err, sql.ErrNoRows) {
+		return domain.OrderReturn{}, domain.ErrReturnNotFound
+	}
+	if err != nil {
+		return domain.OrderReturn{}, fmt.Errorf("get order return: %w", err)
+	}
+	return ret, nil
+}
+
+func (r *returnRepository) ListByOrder(orderID string) ([]domain.OrderReturn, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), opTimeout)
+	defer cancel()
+
+	rows, err := r.db.QueryContext(ctx, `
+		SELECT `+orderReturnColumns+`
+		FROM order_returns
+		WHERE order_id = $1
+		ORDER BY created_at ASC, id ASC
+	`, orderID)
+	if err != nil {
+		return nil, fmt.Errorf("list order returns: %w", err)
+	}
+	defer rows.Close()
+
+	returns := make([]domain.OrderReturn, 0)
+	for rows.Next() {
+		ret, err := scanOrderReturn(rows)
+		if err != nil {
+			return nil, fmt.Errorf("scan order return: %w", err)
+		}
+		returns = append(returns, ret)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("iterate order returns: %w", err)
+	}
+	return returns, nil
+}
+
+func (r *returnRepository) Advance(id string, from, to domain.ReturnStatus) (domain.OrderReturn, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), opTimeout)
+	defer cancel()
+
+	ret, err := scanOrderReturn(r.db.QueryRowContext(ctx, `
+		UPDATE order_returns
+		SET status = $1, updated_at = $2
+		WHERE id = $3 AND status = $4
+		RETURNING `+orderReturnColumns+`
+	`, string(to), time.Now().UTC(), id, string(from)))
+	if err == nil {
+		return ret, nil
+	}
+	if !errors.Is(err, sql.ErrNoRows) {
+		return domain.OrderReturn{}, fmt.Errorf("advance order return: %w", err)
+	}
+
+	var exists bool
+	if err := r.db.QueryRowContext(ctx, `SELECT EXISTS(SELECT 1 FROM order_returns WHERE id = $1)`, id).Scan(&exists); err != nil {
+		return domain.OrderReturn{}, fmt.Errorf("check order return: %w", err)
+	}
+	if !exists {
+		return domain.OrderReturn{}, domain.ErrReturnNotFound
+	}
+	return domain.OrderReturn{}, domain.ErrReturnStatusConflict
+}
+
+type orderReturnScanner interface {
+	Scan(dest ...any) error
+}
+
+func scanOrderReturn(row orderReturnScanner) (domain.OrderReturn, error) {
+	var (
+		ret    domain.OrderReturn
+		status string
+		items  []byte
+	)
+	if err := row.Scan(&ret.ID, &ret.OrderID, &status, &items, &ret.Reason, &ret.RefundMinor, &ret.Currency, &ret.CreatedAt, &ret.UpdatedAt); err != nil {
+		return domain.OrderReturn{}, err
+	}
+	var rows []returnItemRow
+	if err := json.Unmarshal(items, &rows); err != nil {
+		return domain.OrderReturn{}, fmt.Errorf("decode return items: %w", err)
+	}
+	ret.Items = make([]domain.ReturnItem, 0, len(rows))
+	for _, row := range rows {
+		ret.Items = append(ret.Items, domain.ReturnItem{ItemID: row.ItemID, SKU: row.SKU, Qty: row.Qty, RefundMinor: row.RefundMinor})
+	}
+	ret.Status = domain.ReturnStatus(status)
+	ret.CreatedAt = ret.CreatedAt.UTC()
+	ret.UpdatedAt = ret.UpdatedAt.UTC()
+	return ret, nil
+}
+
+var _ domain.ReturnRepository = (*returnRepository)(nil)
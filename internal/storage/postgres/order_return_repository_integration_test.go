@@ -0,0 +1,66 @@
+package postgres
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/vladislavdragonenkov/oms/internal/domain"
+)
+
+func TestReturnRepository_PostgresCreateAndAdvance(t *testing.T) {
+	store := openPostgresStoreForIntegrationTest(t)
+	orderRepo := NewOrderRepository(store)
+	repo := NewReturnRepository(store)
+
+	now := time.Now().UTC().Round(time.Microsecond)
+	order := sampleOrder("return-order", "customer-return", now.Add(-time.Hour))
+	if err := orderRepo.Create(order); err != nil {
+		t.Fatalf("create order: %v", err)
+	}
+
+	created, err := repo.Create(domain.OrderReturn{
+		OrderID:     order.ID,
+		Items:       []domain.ReturnItem{{ItemID: order.Items[0].ID, SKU: order.Items[0].SKU, Qty: 1, RefundMinor: 150}},
+		Reason:      "wrong size",
+		RefundMinor: 150,
+		Currency:    order.Currency,
+	}, 0)
+	if err != nil {
+		t.Fatalf("create return: %v", err)
+	}
+	if _, err := repo.Create(domain.OrderReturn{OrderID: order.ID, Currency: order.Currency}, 0); !errors.Is(err, domain.ErrReturnsChanged) {
+		t.Fatalf("expected ErrReturnsChanged, got %v", err)
+	}
+
+	got, err := repo.Get(created.ID)
+	if err != nil {
+		t.Fatalf("get: %v", err)
+	}
+	if got.Status != domain.ReturnStatusRequested || got.Reason != "wrong size" || got.RefundMinor != 150 ||
+		len(got.Items) != 1 || got.Items[0].SKU != "SKU-1" || got.Items[0].Qty != 1 {
+		t.Fatalf("unexpected return: %+v", got)
+	}
+	if _, err := repo.Get("missing"); !errors.Is(err, domain.ErrReturnNotFound) {
+		t.Fatalf("expected ErrReturnNotFound, got %v", err)
+	}
+
+	approved, err := repo.Advance(created.ID, domain.ReturnStatusRequested, domain.ReturnStatusApproved)
+	if err != nil || approved.Status != domain.ReturnStatusApproved {
+		t.Fatalf("advance: %+v, %v", approved, err)
+	}
+	if _, err := repo.Advance(created.ID, domain.ReturnStatusRequested, domain.ReturnStatusApproved); !errors.Is(err, domain.ErrReturnStatusConflict) {
+		t.Fatalf("expected ErrReturnStatusConflict, got %v", err)
+	}
+	if _, err := repo.Advance("missing", domain.ReturnStatusRequested, domain.ReturnStatusApproved); !errors.Is(err, domain.ErrReturnNotFound) {
+		t.Fatalf("expected ErrReturnNotFound, got %v", err)
+	}
+
+	list, err := repo.ListByOrder(order.ID)
+	if err != nil {
+		t.Fatalf("list by order: %v", err)
+	}
+	if len(list) != 1 || list[0].ID != created.ID || list[0].Status != domain.ReturnStatusApproved {
+		t.Fatalf("unexpected returns of order: %+v", list)
+	}
+}
@@ -3,7 +3,9 @@ package postgres
 import (
 	"context"
 	"database/sql"
+	"encoding/json"
 	"fmt"
+	"strings"
 	"time"
 
 	"github.com/google/uuid"
@@ -30,14 +32,18 @@ func (r *outboxRepository) Enqueue(msg domain.OutboxMessage) (domain.OutboxMessa
 		msg.ID = uuid.NewString()
 	}
 	now := time.Now().UTC()
+	headers, err := encodeOutboxHeaders(msg.Headers)
+	if err != nil {
+		return domain.OutboxMessage{}, err
+	}
 
-	_, err := r.db.ExecContext(ctx, `
+	_, err = r.db.ExecContext(ctx, `
 		INSERT INTO outbox_messages (
-			id, aggregate_type, aggregate_id, event_type, payload,
+			id, aggregate_type, aggregate_id, event_type, payload, headers,
 			status, attempt_count, created_at, updated_at
-		) VALUES ($1,$2,$3,$4,$5,'pending',0,$6,$7)
+		) VALUES ($1,$2,$3,$4,$5,$6,'pending',0,$7,$8)
 	`,
-		msg.ID, msg.AggregateType, msg.AggregateID, msg.EventType, msg.Payload, now, now,
+		msg.ID, msg.AggregateType, msg.AggregateID, msg.EventType, msg.Payload, headers, now, now,
 	)
 	if err != nil {
 		return domain.OutboxMessage{}, fmt.Errorf("enqueue outbox message: %w", err)
@@ -46,6 +52,47 @@ func (r *outboxRepository) Enqueue(msg domain.OutboxMessage) (domain.OutboxMessa
 	return msg, nil
 }
 
+// EnqueueBatch вставляет сообщения одним INSERT на несколько строк. created_at растёт на 1µs
+// на сообщение, чтобы PullPending (ORDER BY created_at, id) сохранял порядок пакета.
+func (r *outboxRepository) EnqueueBatch(msgs []domain.OutboxMessage) ([]domain.OutboxMessage, error) {
+	if len(msgs) == 0 {
+		return nil, nil
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), opTimeout)
+	defer cancel()
+
+	const columns = 8
+	now := time.Now().UTC()
+	stored := make([]domain.OutboxMessage, 0, len(msgs))
+	values := make([]string, 0, len(msgs))
+	args := make([]any, 0, len(msgs)*columns)
+	for i, msg := range msgs {
+		if msg.ID == "" {
+			msg.ID = uuid.NewString()
+		}
+		headers, err := encodeOutboxHeaders(msg.Headers)
+		if err != nil {
+			return nil, err
+		}
+		createdAt := now.Add(time.Duration(i) * time.Microsecond)
+		n := i * columns
+		values = append(values, fmt.Sprintf("($%d,$%d,$%d,$%d,$%d,$%d,'pending',0,$%d,$%d)", n+1, n+2, n+3, n+4, n+5, n+6, n+7, n+8))
+		args = append(args, msg.ID, msg.AggregateType, msg.AggregateID, msg.EventType, msg.Payload, headers, createdAt, createdAt)
+		stored = append(stored, msg)
+	}
+
+	_, err := r.db.ExecContext(ctx, `
+		INSERT INTO outbox_messages (
+			id, aggregate_type, aggregate_id, event_type, payload, headers,
+			status, attempt_count, created_at, updated_at
+		) VALUES `+strings.Join(values, ","), args...)
+	if err != nil {
+		return nil, fmt.Errorf("enqueue outbox batch of %d: %w", len(msgs), err)
+	}
+
+	return stored, nil
+}
+
 func (r *outboxRepository) PullPending(limit int) ([]domain.OutboxMessage, error) {
 	ctx, cancel := context.WithTimeout(context.Background(), opTimeout)
 	defer cancel()
@@ -73,9 +120,9 @@ func (r *outboxRepository) PullPending(limit int) ([]domain.OutboxMessage, error
 			    updated_at = $3
 			FROM candidates
 			WHERE outbox.id = candidates.id
-			RETURNING outbox.id, outbox.aggregate_type, outbox.aggregate_id, outbox.event_type, outbox.payload, outbox.created_at
+			RETURNING outbox.id, outbox.aggregate_type, outbox.aggregate_id, outbox.event_type, outbox.payload, outbox.headers, outbox.created_at
 		)
-		SELECT id, aggregate_type, aggregate_id, event_type, payload, created_at
+		SELECT id, aggregate_type, aggregate_id, event_type, payload, headers, created_at
 		FROM claimed
 		ORDER BY created_at, id
 	`, limit, staleBefore, now)
@@ -86,18 +133,25 @@ func (r *outboxRepository) PullPending(limit int) ([]domain.OutboxMessage, error
 
 	result := make([]domain.OutboxMessage, 0, limit)
 	for rows.Next() {
-		var msg domain.OutboxMessage
-		var createdAt time.Time
+		var (
+			msg     domain.OutboxMessage
+			headers []byte
+		)
 		if err := rows.Scan(
 			&msg.ID,
 			&msg.AggregateType,
 			&msg.AggregateID,
 			&msg.EventType,
 			&msg.Payload,
-			&createdAt,
+			&headers,
+			&msg.CreatedAt,
 		); err != nil {
 			return nil, fmt.Errorf("scan outbox message: %w", err)
 		}
+		if msg.Headers, err = decodeOutboxHeaders(headers); err != nil {
+			return nil, fmt.Errorf("outbox message %s: %w", msg.ID, err)
+		}
+		msg.CreatedAt = msg.CreatedAt.UTC()
 		result = append(result, msg)
 	}
 	if err := rows.Err(); err != nil {
@@ -107,6 +161,29 @@ func (r *outboxRepository) PullPending(limit int) ([]domain.OutboxMessage, error
 	return result, nil
 }
 
+// encodeOutboxHeaders сериализует headers для jsonb-колонки; отсутствие headers — пустой объект.
+func encodeOutboxHeaders(headers map[string]string) ([]byte, error) {
+	if len(headers) == 0 {
+		return []byte("{}"), nil
+	}
+	data, err := json.Marshal(headers)
+	if err != nil {
+		return nil, fmt.Errorf("encode outbox headers: %w", err)
+	}
+	return data, nil
+}
+
+func decodeOutboxHeaders(data []byte) (map[string]string, error) {
+	var headers map[string]string
+	if err := json.Unmarshal(data, &headers); err != nil {
+		return nil, fmt.Errorf("decode outbox headers: %w", err)
+	}
+	if len(headers) == 0 {
+		return nil, nil
+	}
+	return headers, nil
+}
+
 func (r *outboxRepository) Stats() (domain.OutboxStats, error) {
 	ctx, cancel := context.WithTimeout(context.Background(), opTimeout)
 	defer cancel()
@@ -139,6 +216,131 @@ func (r *outboxRepository) MarkFailed(id string) error {
 	return r.markStatus(id, "failed")
 }
 
+func (r *outboxRepository) DeleteSent(before time.Time, limit int) (int, error) {
+	if before.IsZero() {
+		before = time.Now().UTC()
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), opTimeout)
+	defer cancel()
+
+	var (
+		res sql.Result
+		err error
+	)
+
+	if limit > 0 {
+		res, err = r.db.ExecContext(ctx, `
+			DELETE FROM outbox_messages
+			WHERE id IN (
+				SELECT id
+				FROM outbox_messages
+				WHERE status = 'sent' AND updated_at <= $1
+				ORDER BY updated_at ASC
+				LIMIT $2
+			)
+			  AND status = 'sent'
+		`, before, limit)
+	} else {
+		res, err = r.db.ExecContext(ctx, `
+			DELETE FROM outbox_messages
+			WHERE status = 'sent' AND updated_at <= $1
+		`, before)
+	}
+	if err != nil {
+		return 0, fmt.Errorf("delete sent outbox messages: %w", err)
+	}
+
+	affected, err := res.RowsAffected()
+	if err != nil {
+		return 0, fmt.Errorf("rows affected for outbox cleanup: %w", err)
+	}
+
+	return int(affected), nil
+}
+
+func (r *outboxRepository) DeleteByAggregate(aggregateType, aggregateID string) (int, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), opTimeout)
+	defer cancel()
+
+	res, err := r.db.ExecContext(ctx, `
+		DELETE FROM outbox_messages
+		WHERE aggregate_type = $1 AND aggregate_id = $2
+	`, aggregateType, aggregateID)
+	if err != nil {
+		return 0, fmt.Errorf("delete outbox messages of %s %s: %w", aggregateType, aggregateID, err)
+	}
+
+	affected, err := res.RowsAffected()
+	if err != nil {
+		return 0, fmt.Errorf("rows affected for outbox aggregate delete: %w", err)
+	}
+
+	return int(affected), nil
+}
+
+func (r *outboxRepository) ListSent(filter domain.OutboxReplayFilter) ([]domain.OutboxMessage, error) {
+	return r.list(filter, true)
+}
+
+func (r *outboxRepository) ListEvents(filter domain.OutboxReplayFilter) ([]domain.OutboxMessage, error) {
+	return r.list(filter, false)
+}
+
+func (r *outboxRepository) list(filter domain.OutboxReplayFilter, onlySent bool) ([]domain.OutboxMessage, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), opTimeout)
+	defer cancel()
+
+	limit := filter.Limit
+	if limit <= 0 {
+		limit = 100
+	}
+
+	rows, err := r.db.QueryContext(ctx, `
+		SELECT id, aggregate_type, aggregate_id, event_type, payload, headers, created_at
+		FROM outbox_messages
+		WHERE (NOT $8 OR status = 'sent')
+		  AND ($1::timestamptz IS NULL OR created_at >= $1)
+		  AND ($2::timestamptz IS NULL OR created_at < $2)
+		  AND ($3 = '' OR aggregate_type = $3)
+		  AND ($4 = '' OR aggregate_id = $4)
+		  AND ($5::timestamptz IS NULL OR (created_at, id) > ($5, $6))
+		ORDER BY created_at, id
+		LIMIT $7
+	`,
+		nullTime(filter.From), nullTime(filter.To), filter.AggregateType, filter.AggregateID,
+		nullTime(filter.AfterCreatedAt), filter.AfterID, limit, onlySent,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("list outbox messages: %w", err)
+	}
+	defer rows.Close()
+
+	result := make([]domain.OutboxMessage, 0, limit)
+	for rows.Next() {
+		var (
+			msg     domain.OutboxMessage
+			headers []byte
+		)
+		if err := rows.Scan(&msg.ID, &msg.AggregateType, &msg.AggregateID, &msg.EventType, &msg.Payload, &headers, &msg.CreatedAt); err != nil {
+			return nil, fmt.Errorf("scan outbox message: %w", err)
+		}
+		if msg.Headers, err = decodeOutboxHeaders(headers); err != nil {
+			return nil, fmt.Errorf("outbox message %s: %w", msg.ID, err)
+		}
+		msg.CreatedAt = msg.CreatedAt.UTC()
+		result = append(result, msg)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("iterate outbox rows: %w", err)
+	}
+	return result, nil
+}
+
+func nullTime(t time.Time) sql.NullTime {
+	return sql.NullTime{Time: t.UTC(), Valid: !t.IsZero()}
+}
+
 func (r *outboxRepository) markStatus(id, status string) error {
 	ctx, cancel := context.WithTimeout(context.Background(), opTimeout)
 	defer cancel()
@@ -165,4 +367,9 @@ func (r *outboxRepository) markStatus(id, status string) error {
 	return nil
 }
 
-var _ domain.OutboxRepository = (*outboxRepository)(nil)
+var (
+	_ domain.OutboxRepository       = (*outboxRepository)(nil)
+	_ domain.OutboxReplaySource     = (*outboxRepository)(nil)
+	_ domain.OutboxFeedSource       = (*outboxRepository)(nil)
+	_ domain.OutboxAggregateDeleter = (*outboxRepository)(nil)
+)
@@ -84,6 +84,128 @@ func TestOutboxRepository_PostgresFlow(t *testing.T) {
 	}
 }
 
+func TestOutboxRepository_PostgresHeadersRoundTrip(t *testing.T) {
+	store := openPostgresStoreForIntegrationTest(t)
+	repo := NewOutboxRepository(store)
+
+	headers := map[string]string{"traceparent": "00-trace-span-01", "x-tenant-id": "tenant-a"}
+	if _, err := repo.Enqueue(domain.OutboxMessage{
+		ID:            "outbox-with-headers",
+		AggregateType: "order",
+		AggregateID:   "order-1",
+		EventType:     "OrderCreated",
+		Payload:       []byte(`{"id":"order-1"}`),
+		Headers:       headers,
+	}); err != nil {
+		t.Fatalf("enqueue with headers: %v", err)
+	}
+	if _, err := repo.Enqueue(domain.OutboxMessage{
+		ID:            "outbox-without-headers",
+		AggregateType: "order",
+		AggregateID:   "order-2",
+		EventType:     "OrderCreated",
+		Payload:       []byte(`{"id":"order-2"}`),
+	}); err != nil {
+		t.Fatalf("enqueue without headers: %v", err)
+	}
+
+	pending, err := repo.PullPending(10)
+	if err != nil {
+		t.Fatalf("pull pending: %v", err)
+	}
+	byID := make(map[string]domain.OutboxMessage, len(pending))
+	for _, msg := range pending {
+		byID[msg.ID] = msg
+	}
+	if got := byID["outbox-with-headers"].Headers; len(got) != 2 || got["traceparent"] != headers["traceparent"] || got["x-tenant-id"] != headers["x-tenant-id"] {
+		t.Fatalf("unexpected headers: %v", got)
+	}
+	if got := byID["outbox-without-headers"].Headers; got != nil {
+		t.Fatalf("expected nil headers, got %v", got)
+	}
+}
+
+func TestOutboxRepository_PostgresDeleteByAggregate(t *testing.T) {
+	store := openPostgresStoreForIntegrationTest(t)
+	repo := NewOutboxRepository(store)
+
+	for _, msg := range []domain.OutboxMessage{
+		{ID: "outbox-canary-sent", AggregateType: "order", AggregateID: "canary-order", EventType: "OrderCreated", Payload: []byte(`{}`)},
+		{ID: "outbox-canary-pending", AggregateType: "order", AggregateID: "canary-order", EventType: "OrderCanceled", Payload: []byte(`{}`)},
+		{ID: "outbox-real", AggregateType: "order", AggregateID: "real-order", EventType: "OrderCreated", Payload: []byte(`{}`)},
+	} {
+		if _, err := repo.Enqueue(msg); err != nil {
+			t.Fatalf("enqueue %s: %v", msg.ID, err)
+		}
+	}
+	if err := repo.MarkSent("outbox-canary-sent"); err != nil {
+		t.Fatalf("mark sent: %v", err)
+	}
+
+	deleted, err := repo.(domain.OutboxAggregateDeleter).DeleteByAggregate("order", "canary-order")
+	if err != nil {
+		t.Fatalf("delete by aggregate: %v", err)
+	}
+	if deleted != 2 {
+		t.Fatalf("expected 2 deleted messages, got %d", deleted)
+	}
+	pending, err := repo.PullPending(10)
+	if err != nil || len(pending) != 1 || pending[0].ID != "outbox-real" {
+		t.Fatalf("expected only the real order event to stay, got %+v err=%v", pending, err)
+	}
+}
+
+func TestOutboxRepository_PostgresEnqueueBatch(t *testing.T) {
+	store := openPostgresStoreForIntegrationTest(t)
+	repo := NewOutboxRepository(store)
+
+	stored, err := repo.EnqueueBatch([]domain.OutboxMessage{
+		{ID: "outbox-batch-z", AggregateType: "order", AggregateID: "order-1", EventType: "OrderStatusChanged", Payload: []byte(`{"status":"canceled"}`), Headers: map[string]string{"x-tenant-id": "tenant-a"}},
+		{AggregateType: "order", AggregateID: "order-1", EventType: "OrderCanceled", Payload: []byte(`{}`)},
+		{ID: "outbox-batch-a", AggregateType: "order", AggregateID: "order-1", EventType: "OrderSagaFailed", Payload: []byte(`{}`)},
+	})
+	if err != nil {
+		t.Fatalf("enqueue batch: %v", err)
+	}
+	if len(stored) != 3 || stored[1].ID == "" {
+		t.Fatalf("unexpected stored messages %+v", stored)
+	}
+
+	pending, err := repo.PullPending(10)
+	if err != nil {
+		t.Fatalf("pull pending: %v", err)
+	}
+	if len(pending) != 3 {
+		t.Fatalf("expected 3 pending messages, got %d", len(pending))
+	}
+	for i, msg := range pending {
+		if msg.ID != stored[i].ID {
+			t.Fatalf("message %d: expected %s, got %s", i, stored[i].ID, msg.ID)
+		}
+	}
+	if pending[0].Headers["x-tenant-id"] != "tenant-a" || pending[1].Headers != nil {
+		t.Fatalf("unexpected headers %v / %v", pending[0].Headers, pending[1].Headers)
+	}
+
+	// Пакет атомарен: дубликат ID отклоняет все строки.
+	if _, err := repo.EnqueueBatch([]domain.OutboxMessage{
+		{ID: "outbox-batch-new", AggregateType: "order", AggregateID: "order-2", EventType: "OrderCanceled", Payload: []byte(`{}`)},
+		{ID: "outbox-batch-a", AggregateType: "order", AggregateID: "order-2", EventType: "OrderCanceled", Payload: []byte(`{}`)},
+	}); err == nil {
+		t.Fatal("expected duplicate id error")
+	}
+	stats, err := repo.Stats()
+	if err != nil {
+		t.Fatalf("stats: %v", err)
+	}
+	if stats.PendingCount != 0 {
+		t.Fatalf("failed batch must not insert rows, got %d pending", stats.PendingCount)
+	}
+	if stored, err := repo.EnqueueBatch(nil); err != nil || len(stored) != 0 {
+		t.Fatalf("empty batch: %v %v", stored, err)
+	}
+}
+
 func TestOutboxRepository_PostgresMissingRows(t *testing.T) {
 	store := openPostgresStoreForIntegrationTest(t)
 	repo := NewOutboxRepository(store)
@@ -221,3 +343,114 @@ func TestOutboxRepository_PostgresPullPendingReclaimsStaleProcessing(t *testing.
 		t.Fatalf("expected reclaimed id %s, got %s", saved.ID, reclaimed[0].ID)
 	}
 }
+
+func TestOutboxRepository_PostgresDeleteSentKeepsUnsentAndFailed(t *testing.T) {
+	store := openPostgresStoreForIntegrationTest(t)
+	repo := NewOutboxRepository(store)
+
+	ids := make([]string, 0, 4)
+	for _, aggregateID := range []string{"order-sent-1", "order-sent-2", "order-failed", "order-pending"} {
+		saved, err := repo.Enqueue(domain.OutboxMessage{
+			AggregateType: "order",
+			AggregateID:   aggregateID,
+			EventType:     "OrderCreated",
+			Payload:       []byte(`{}`),
+		})
+		if err != nil {
+			t.Fatalf("enqueue: %v", err)
+		}
+		ids = append(ids, saved.ID)
+	}
+
+	for _, id := range ids[:2] {
+		if err := repo.MarkSent(id); err != nil {
+			t.Fatalf("mark sent: %v", err)
+		}
+	}
+	if err := repo.MarkFailed(ids[2]); err != nil {
+		t.Fatalf("mark failed: %v", err)
+	}
+
+	old := time.Now().UTC().Add(-48 * time.Hour)
+	if _, err := store.DB().Exec(`UPDATE outbox_messages SET updated_at = $1`, old); err != nil {
+		t.Fatalf("age outbox rows: %v", err)
+	}
+
+	deleted, err := repo.DeleteSent(time.Now().UTC().Add(-24*time.Hour), 1)
+	if err != nil {
+		t.Fatalf("delete sent: %v", err)
+	}
+	if deleted != 1 {
+		t.Fatalf("expected batch limit to be respected, got %d", deleted)
+	}
+	if deleted, err = repo.DeleteSent(time.Now().UTC().Add(-24*time.Hour), 0); err != nil || deleted != 1 {
+		t.Fatalf("expected remaining sent row to be deleted, got deleted=%d err=%v", deleted, err)
+	}
+
+	var remaining int
+	if err := store.DB().QueryRow(`SELECT COUNT(*) FROM outbox_messages WHERE status IN ('pending', 'failed')`).Scan(&remaining); err != nil {
+		t.Fatalf("count remaining: %v", err)
+	}
+	if remaining != 2 {
+		t.Fatalf("expected pending and failed rows to survive, got %d", remaining)
+	}
+}
+
+func TestOutboxRepository_PostgresListSent(t *testing.T) {
+	store := openPostgresStoreForIntegrationTest(t)
+	repo := NewOutboxRepository(store)
+	source, ok := repo.(domain.OutboxReplaySource)
+	if !ok {
+		t.Fatal("postgres outbox repository must implement OutboxReplaySource")
+	}
+
+	base := time.Date(2026, 5, 1, 10, 0, 0, 0, time.UTC)
+	ids := make([]string, 0, 4)
+	for i, aggregateID := range []string{"order-1", "order-2", "order-1", "order-pending"} {
+		saved, err := repo.Enqueue(domain.OutboxMessage{
+			AggregateType: "order",
+			AggregateID:   aggregateID,
+			EventType:     "OrderCreated",
+			Payload:       []byte(`{}`),
+			Headers:       map[string]string{"traceparent": "trace"},
+		})
+		if err != nil {
+			t.Fatalf("enqueue: %v", err)
+		}
+		if _, err := store.DB().Exec(`UPDATE outbox_messages SET created_at = $2 WHERE id = $1`, saved.ID, base.Add(time.Duration(i)*time.Minute)); err != nil {
+			t.Fatalf("set created_at: %v", err)
+		}
+		ids = append(ids, saved.ID)
+	}
+	for _, id := range ids[:3] {
+		if err := repo.MarkSent(id); err != nil {
+			t.Fatalf("mark sent: %v", err)
+		}
+	}
+
+	page, err := source.ListSent(domain.OutboxReplayFilter{Limit: 2})
+	if err != nil {
+		t.Fatalf("list sent: %v", err)
+	}
+	if len(page) != 2 || page[0].ID != ids[0] || page[1].ID != ids[1] || page[0].Headers["traceparent"] != "trace" {
+		t.Fatalf("unexpected first page: %+v", page)
+	}
+	next, err := source.ListSent(domain.OutboxReplayFilter{Limit: 2, AfterCreatedAt: page[1].CreatedAt, AfterID: page[1].ID})
+	if err != nil || len(next) != 1 || next[0].ID != ids[2] {
+		t.Fatalf("expected only the last sent message on the next page, got %+v (%v)", next, err)
+	}
+
+	byAggregate, err := source.ListSent(domain.OutboxReplayFilter{AggregateID: "order-1", From: base.Add(time.Minute), To: base.Add(time.Hour)})
+	if err != nil || len(byAggregate) != 1 || byAggregate[0].ID != ids[2] {
+		t.Fatalf("expected aggregate and time filters to apply, got %+v (%v)", byAggregate, err)
+	}
+
+	feed, ok := repo.(domain.OutboxFeedSource)
+	if !ok {
+		t.Fatal("postgres outbox repository must implement OutboxFeedSource")
+	}
+	events, err := feed.ListEvents(domain.OutboxReplayFilter{AfterCreatedAt: page[1].CreatedAt, AfterID: page[1].ID, To: base.Add(time.Hour)})
+	if err != nil || len(events) != 2 || events[0].ID != ids[2] || events[1].ID != ids[3] {
+		t.Fatalf("expected feed to include pending messages, got %+v (%v)", events, err)
+	}
+}
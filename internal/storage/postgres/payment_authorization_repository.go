@@ -0,0 +1,119 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/vladislavdragonenkov/oms/internal/domain"
+)
+
+const paymentAuthorizationColumns = `order_id, amount_minor, currency, authorized_at, expires_at, reauthorizations`
+
+type paymentAuthorizationRepository struct {
+	db *sql.DB
+}
+
+// NewPaymentAuthorizationRepository создаёт PostgreSQL-реализацию PaymentAuthorizationRepository.
+func NewPaymentAuthorizationRepository(store *Store) domain.PaymentAuthorizationRepository {
+	return &paymentAuthorizationRepository{db: store.DB()}
+}
+
+func (r *paymentAuthorizationRepository) Save(auth domain.PaymentAuthorization) error {
+	ctx, cancel := context.WithTimeout(context.Background(), opTimeout)
+	defer cancel()
+
+	if _, err := r.db.ExecContext(ctx, `
+		INSERT INTO payment_authorizations (`+paymentAuthorizationColumns+`)
+		VALUES ($1,$2,$3,$4,$5,$6)
+		ON CONFLICT (order_id) DO UPDATE SET
+		    amount_minor = EXCLUDED.amount_minor,
+		    currency = EXCLUDED.currency,
+		    authorized_at = EXCLUDED.authorized_at,
+		    expires_at = EXCLUDED.expires_at,
+		    reauthorizations = EXCLUDED.reauthorizations
+	`, auth.OrderID, auth.AmountMinor, auth.Currency, auth.AuthorizedAt.UTC(), auth.ExpiresAt.UTC(), auth.Reauthorizations); err != nil {
+		return fmt.Errorf("save payment authorization: %w", err)
+	}
+	return nil
+}
+
+func (r *paymentAuthorizationRepository) Get(orderID string) (domain.PaymentAuthorization, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), opTimeout)
+	defer cancel()
+
+	auth, err := scanPaymentAuthorization(r.db.QueryRowContext(ctx, `
+		SELECT `+paymentAuthorizationColumns+` FROM payment_authorizations WHERE order_id = $1
+	`, orderID))
+	if errors.Is(err, sql.ErrNoRows) {
+		return domain.PaymentAuthorization{}, domain.ErrPaymentAuthorizationNotFound
+	}
+	if err != nil {
+		return domain.PaymentAuthorization{}, fmt.Errorf("get payment authorization: %w", err)
+	}
+	return auth, nil
+}
+
+func (r *paymentAuthorizationRepository) Delete(orderID string) error {
+	ctx, cancel := context.WithTimeout(context.Background(), opTimeout)
+	defer cancel()
+
+	if _, err := r.db.ExecContext(ctx, `DELETE FROM payment_authorizations WHERE order_id = $1`, orderID); err != nil {
+		return fmt.Errorf("delete payment authorization: %w", err)
+	}
+	return nil
+}
+
+func (r *paymentAuthorizationRepository) ListExpiring(before time.Time, limit int) ([]domain.PaymentAuthorization, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), opTimeout)
+	defer cancel()
+
+	query := `
+		SELECT ` + paymentAuthorizationColumns + `
+		FROM payment_authorizations
+		WHERE expires_at <= $1
+		ORDER BY expires_at ASC, order_id ASC
+	`
+	args := []any{before.UTC()}
+	if limit > 0 {
+		query += ` LIMIT $2`
+		args = append(args, limit)
+	}
+
+	rows, err := r.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("list expiring payment authorizations: %w", err)
+	}
+	defer rows.Close()
+
+	auths := make([]domain.PaymentAuthorization, 0)
+	for rows.Next() {
+		auth, err := scanPaymentAuthorization(rows)
+		if err != nil {
+			return nil, fmt.Errorf("scan payment authorization: %w", err)
+		}
+		auths = append(auths, auth)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("iterate payment authorizations: %w", err)
+	}
+	return auths, nil
+}
+
+type paymentAuthorizationScanner interface {
+	Scan(dest ...any) error
+}
+
+func scanPaymentAuthorization(row paymentAuthorizationScanner) (domain.PaymentAuthorization, error) {
+	var auth domain.PaymentAuthorization
+	if err := row.Scan(&auth.OrderID, &auth.AmountMinor, &auth.Currency, &auth.AuthorizedAt, &auth.ExpiresAt, &auth.Reauthorizations); err != nil {
+		return domain.PaymentAuthorization{}, err
+	}
+	auth.AuthorizedAt = auth.AuthorizedAt.UTC()
+	auth.ExpiresAt = auth.ExpiresAt.UTC()
+	return auth, nil
+}
+
+var _ domain.PaymentAuthorizationRepository = (*paymentAuthorizationRepository)(nil)
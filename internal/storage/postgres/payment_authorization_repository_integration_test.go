@@ -0,0 +1,68 @@
+package postgres
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/vladislavdragonenkov/oms/internal/domain"
+)
+
+func TestPaymentAuthorizationRepository_PostgresSaveAndExpire(t *testing.T) {
+	store := openPostgresStoreForIntegrationTest(t)
+	orderRepo := NewOrderRepository(store)
+	repo := NewPaymentAuthorizationRepository(store)
+
+	now := time.Now().UTC().Round(time.Microsecond)
+	for _, id := range []string{"auth-order-1", "auth-order-2"} {
+		if err := orderRepo.Create(sampleOrder(id, "customer-auth", now.Add(-time.Hour))); err != nil {
+			t.Fatalf("create order %s: %v", id, err)
+		}
+	}
+
+	soon := domain.PaymentAuthorization{OrderID: "auth-order-1", AmountMinor: 1500, Currency: "USD", AuthorizedAt: now, ExpiresAt: now.Add(time.Minute)}
+	later := domain.PaymentAuthorization{OrderID: "auth-order-2", AmountMinor: 900, Currency: "USD", AuthorizedAt: now, ExpiresAt: now.Add(24 * time.Hour)}
+	for _, auth := range []domain.PaymentAuthorization{later, soon} {
+		if err := repo.Save(auth); err != nil {
+			t.Fatalf("save %s: %v", auth.OrderID, err)
+		}
+	}
+
+	got, err := repo.Get(soon.OrderID)
+	if err != nil {
+		t.Fatalf("get: %v", err)
+	}
+	if got.AmountMinor != 1500 || got.Currency != "USD" || !got.ExpiresAt.Equal(soon.ExpiresAt) || !got.AuthorizedAt.Equal(now) {
+		t.Fatalf("unexpected authorization: %+v", got)
+	}
+
+	expiring, err := repo.ListExpiring(now.Add(time.Hour), 10)
+	if err != nil {
+		t.Fatalf("list expiring: %v", err)
+	}
+	if len(expiring) != 1 || expiring[0].OrderID != soon.OrderID {
+		t.Fatalf("expected only the soon-expiring authorization, got %+v", expiring)
+	}
+
+	soon.ExpiresAt = now.Add(48 * time.Hour)
+	soon.Reauthorizations = 1
+	if err := repo.Save(soon); err != nil {
+		t.Fatalf("resave: %v", err)
+	}
+	if got, _ := repo.Get(soon.OrderID); got.Reauthorizations != 1 || !got.ExpiresAt.Equal(soon.ExpiresAt) {
+		t.Fatalf("expected upserted authorization, got %+v", got)
+	}
+	if expiring, _ := repo.ListExpiring(now.Add(36*time.Hour), 0); len(expiring) != 1 || expiring[0].OrderID != later.OrderID {
+		t.Fatalf("expected authorizations ordered by expiry, got %+v", expiring)
+	}
+
+	if err := repo.Delete(soon.OrderID); err != nil {
+		t.Fatalf("delete: %v", err)
+	}
+	if err := repo.Delete(soon.OrderID); err != nil {
+		t.Fatalf("delete must be idempotent: %v", err)
+	}
+	if _, err := repo.Get(soon.OrderID); !errors.Is(err, domain.ErrPaymentAuthorizationNotFound) {
+		t.Fatalf("expected ErrPaymentAuthorizationNotFound, got %v", err)
+	}
+}
@@ -0,0 +1,130 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/vladislavdragonenkov/oms/internal/domain"
+)
+
+const paymentRetryColumns = `order_id, attempt, run_at, last_error, updated_at`
+
+type paymentRetryRepository struct {
+	db *sql.DB
+}
+
+// NewPaymentRetryRepository создаёт PostgreSQL-реализацию PaymentRetryRepository.
+func NewPaymentRetryRepository(store *Store) domain.PaymentRetryRepository {
+	return &paymentRetryRepository{db: store.DB()}
+}
+
+func (r *paymentRetryRepository) Save(retry domain.PaymentRetry) error {
+	ctx, cancel := context.WithTimeout(context.Background(), opTimeout)
+	defer cancel()
+
+	if _, err := r.db.ExecContext(ctx, `
+		INSERT INTO payment_retries (`+paymentRetryColumns+`)
+		VALUES ($1,$2,$3,$4,$5)
+		ON CONFLICT (order_id) DO UPDATE SET
+		    attempt = EXCLUDED.attempt,
+		    run_at = EXCLUDED.run_at,
+		    last_error = EXCLUDED.last_error,
+		    updated_at = EXCLUDED.updated_at,
+		    claimed_until = NULL
+	`, retry.OrderID, retry.Attempt, retry.RunAt.UTC(), retry.LastError, retry.UpdatedAt.UTC()); err != nil {
+		return fmt.Errorf("save payment retry: %w", err)
+	}
+	return nil
+}
+
+func (r *paymentRetryRepository) Get(orderID string) (domain.PaymentRetry, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), opTimeout)
+	defer cancel()
+
+	retry, err := scanPaymentRetry(r.db.QueryRowContext(ctx, `
+		SELECT `+paymentRetryColumns+` FROM payment_retries WHERE order_id = $1
+	`, orderID))
+	if errors.Is(err, sql.ErrNoRows) {
+		return domain.PaymentRetry{}, domain.ErrPaymentRetryNotFound
+	}
+	if err != nil {
+		return domain.PaymentRetry{}, fmt.Errorf("get payment retry: %w", err)
+	}
+	return retry, nil
+}
+
+func (r *paymentRetryRepository) Delete(orderID string) error {
+	ctx, cancel := context.WithTimeout(context.Background(), opTimeout)
+	defer cancel()
+
+	if _, err := r.db.ExecContext(ctx, `DELETE FROM payment_retries WHERE order_id = $1`, orderID); err != nil {
+		return fmt.Errorf("delete payment retry: %w", err)
+	}
+	return nil
+}
+
+func (r *paymentRetryRepository) ListDue(now time.Time, limit int) ([]domain.PaymentRetry, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), opTimeout)
+	defer cancel()
+
+	var batch any
+	if limit > 0 {
+		batch = limit
+	}
+	now = now.UTC()
+	// Повторы забираются арендой, как отложенные отмены: параллельная реплика пропускает
+	// занятые строки и не запускает оплату заказа второй раз.
+	rows, err := r.db.QueryContext(ctx, `
+		WITH candidates AS (
+			SELECT order_id
+			FROM payment_retries
+			WHERE run_at <= $1
+			  AND (claimed_until IS NULL OR claimed_until <= $1)
+			ORDER BY run_at ASC, order_id ASC
+			LIMIT $2
+			FOR UPDATE SKIP LOCKED
+		),
+		claimed AS (
+			UPDATE payment_retries AS retries
+			SET claimed_until = $3
+			FROM candidates
+			WHERE retries.order_id = candidates.order_id
+			RETURNING retries.order_id, retries.attempt, retries.run_at, retries.last_error, retries.updated_at
+		)
+		SELECT `+paymentRetryColumns+`
+		FROM claimed
+		ORDER BY run_at ASC, order_id ASC
+	`, now, batch, now.Add(dueTaskLease))
+	if err != nil {
+		return nil, fmt.Errorf("list due payment retries: %w", err)
+	}
+	defer rows.Close()
+
+	retries := make([]domain.PaymentRetry, 0)
+	for rows.Next() {
+		retry, err := scanPaymentRetry(rows)
+		if err != nil {
+			return nil, fmt.Errorf("scan payment retry: %w", err)
+		}
+		retries = append(retries, retry)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("iterate payment retries: %w", err)
+	}
+	return retries, nil
+}
+
+func scanPaymentRetry(row paymentAuthorizationScanner) (domain.PaymentRetry, error) {
+	var retry domain.PaymentRetry
+	if err := row.Scan(&retry.OrderID, &retry.Attempt, &retry.RunAt, &retry.LastError, &retry.UpdatedAt); err != nil {
+		return domain.PaymentRetry{}, err
+	}
+	retry.RunAt = retry.RunAt.UTC()
+	retry.UpdatedAt = retry.UpdatedAt.UTC()
+	return retry, nil
+}
+
+var _ domain.PaymentRetryRepository = (*paymentRetryRepository)(nil)
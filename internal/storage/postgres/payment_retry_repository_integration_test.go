@@ -0,0 +1,76 @@
+package postgres
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/vladislavdragonenkov/oms/internal/domain"
+)
+
+func TestPaymentRetryRepository_PostgresSaveAndListDue(t *testing.T) {
+	store := openPostgresStoreForIntegrationTest(t)
+	orderRepo := NewOrderRepository(store)
+	repo := NewPaymentRetryRepository(store)
+
+	now := time.Now().UTC().Round(time.Microsecond)
+	for _, id := range []string{"retry-order-1", "retry-order-2"} {
+		if err := orderRepo.Create(sampleOrder(id, "customer-retry", now.Add(-time.Hour))); err != nil {
+			t.Fatalf("create order %s: %v", id, err)
+		}
+	}
+
+	due := domain.PaymentRetry{OrderID: "retry-order-1", Attempt: 1, RunAt: now.Add(-time.Second), LastError: "psp timeout", UpdatedAt: now}
+	later := domain.PaymentRetry{OrderID: "retry-order-2", Attempt: 2, RunAt: now.Add(time.Hour), UpdatedAt: now}
+	for _, retry := range []domain.PaymentRetry{later, due} {
+		if err := repo.Save(retry); err != nil {
+			t.Fatalf("save %s: %v", retry.OrderID, err)
+		}
+	}
+
+	got, err := repo.Get(due.OrderID)
+	if err != nil {
+		t.Fatalf("get: %v", err)
+	}
+	if got.Attempt != 1 || got.LastError != "psp timeout" || !got.RunAt.Equal(due.RunAt) {
+		t.Fatalf("unexpected retry: %+v", got)
+	}
+
+	listed, err := repo.ListDue(now, 10)
+	if err != nil {
+		t.Fatalf("list due: %v", err)
+	}
+	if len(listed) != 1 || listed[0].OrderID != due.OrderID {
+		t.Fatalf("expected only the due retry, got %+v", listed)
+	}
+	if again, _ := repo.ListDue(now, 10); len(again) != 0 {
+		t.Fatalf("claimed retry must not be listed again, got %+v", again)
+	}
+
+	due.Attempt = 2
+	due.RunAt = now.Add(-time.Second)
+	if err := repo.Save(due); err != nil {
+		t.Fatalf("resave: %v", err)
+	}
+	// Новая попытка снимает аренду предыдущей.
+	if listed, _ := repo.ListDue(now, 10); len(listed) != 1 || listed[0].Attempt != 2 {
+		t.Fatalf("expected resaved retry to be due again, got %+v", listed)
+	}
+	due.RunAt = now.Add(2 * time.Hour)
+	if err := repo.Save(due); err != nil {
+		t.Fatalf("resave: %v", err)
+	}
+	if listed, _ := repo.ListDue(now.Add(3*time.Hour), 0); len(listed) != 2 || listed[0].OrderID != later.OrderID {
+		t.Fatalf("expected retries ordered by run time, got %+v", listed)
+	}
+
+	if err := repo.Delete(due.OrderID); err != nil {
+		t.Fatalf("delete: %v", err)
+	}
+	if err := repo.Delete(due.OrderID); err != nil {
+		t.Fatalf("delete must be idempotent: %v", err)
+	}
+	if _, err := repo.Get(due.OrderID); !errors.Is(err, domain.ErrPaymentRetryNotFound) {
+		t.Fatalf("expected ErrPaymentRetryNotFound, got %v", err)
+	}
+}
@@ -0,0 +1,126 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	"github.com/vladislavdragonenkov/oms/internal/domain"
+)
+
+type quotaRepository struct {
+	db *sql.DB
+}
+
+// NewQuotaRepository создаёт PostgreSQL-реализацию QuotaRepository.
+func NewQuotaRepository(store *Store) domain.QuotaRepository {
+	return &quotaRepository{db: store.DB()}
+}
+
+// Consume сериализует списания одного principal'а за сутки advisory-локом транзакции:
+// строки по валютам могут ещё не существовать, поэтому SELECT ... FOR UPDATE не подходит.
+func (r *quotaRepository) Consume(principal string, day time.Time, currency string, amountMinor int64, quota domain.OrderQuota) (usage domain.QuotaUsage, err error) {
+	ctx, cancel := context.WithTimeout(context.Background(), opTimeout)
+	defer cancel()
+
+	day = domain.QuotaDay(day)
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return domain.QuotaUsage{}, fmt.Errorf("begin tx: %w", err)
+	}
+	defer func() {
+		if err != nil {
+			_ = tx.Rollback()
+		}
+	}()
+
+	if _, err = tx.ExecContext(ctx, `SELECT pg_advisory_xact_lock(hashtextextended($1 || '|' || $2, 0))`,
+		principal, day.Format(time.DateOnly)); err != nil {
+		return domain.QuotaUsage{}, fmt.Errorf("lock quota usage: %w", err)
+	}
+	usage, err = loadQuotaUsage(ctx, tx, principal, day)
+	if err != nil {
+		return domain.QuotaUsage{}, err
+	}
+	if err = quota.Check(usage, currency, amountMinor); err != nil {
+		return usage, err
+	}
+
+	if _, err = tx.ExecContext(ctx, `
+		INSERT INTO order_quota_usage (principal, day, currency, orders, amount_minor, updated_at)
+		VALUES ($1, $2, $3, 1, $4, $5)
+		ON CONFLICT (principal, day, currency) DO UPDATE SET
+			orders = order_quota_usage.orders + 1,
+			amount_minor = order_quota_usage.amount_minor + EXCLUDED.amount_minor,
+			updated_at = EXCLUDED.updated_at
+	`, principal, day, currency, amountMinor, time.Now().UTC()); err != nil {
+		return domain.QuotaUsage{}, fmt.Errorf("consume quota: %w", err)
+	}
+	if err = tx.Commit(); err != nil {
+		return domain.QuotaUsage{}, fmt.Errorf("commit quota consume: %w", err)
+	}
+
+	usage.Orders++
+	usage.AmountMinor[currency] += amountMinor
+	return usage, nil
+}
+
+func (r *quotaRepository) Release(principal string, day time.Time, currency string, amountMinor int64) error {
+	ctx, cancel := context.WithTimeout(context.Background(), opTimeout)
+	defer cancel()
+
+	if _, err := r.db.ExecContext(ctx, `
+		UPDATE order_quota_usage
+		SET orders = GREATEST(orders - 1, 0),
+			amount_minor = GREATEST(amount_minor - $4, 0),
+			updated_at = $5
+		WHERE principal = $1 AND day = $2 AND currency = $3
+	`, principal, domain.QuotaDay(day), currency, amountMinor, time.Now().UTC()); err != nil {
+		return fmt.Errorf("release quota: %w", err)
+	}
+	return nil
+}
+
+func (r *quotaRepository) Usage(principal string, day time.Time) (domain.QuotaUsage, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), opTimeout)
+	defer cancel()
+
+	return loadQuotaUsage(ctx, r.db, principal, domain.QuotaDay(day))
+}
+
+type quotaQuerier interface {
+	QueryContext(ctx context.Context, query string, args ...any) (*sql.Rows, error)
+}
+
+func loadQuotaUsage(ctx context.Context, q quotaQuerier, principal string, day time.Time) (domain.QuotaUsage, error) {
+	rows, err := q.QueryContext(ctx, `
+		SELECT currency, orders, amount_minor
+		FROM order_quota_usage
+		WHERE principal = $1 AND day = $2
+	`, principal, day)
+	if err != nil {
+		return domain.QuotaUsage{}, fmt.Errorf("load quota usage: %w", err)
+	}
+	defer rows.Close()
+
+	usage := domain.QuotaUsage{Principal: principal, Day: day, AmountMinor: make(map[string]int64)}
+	for rows.Next() {
+		var (
+			currency string
+			orders   int64
+			amount   int64
+		)
+		if err := rows.Scan(&currency, &orders, &amount); err != nil {
+			return domain.QuotaUsage{}, fmt.Errorf("scan quota usage: %w", err)
+		}
+		usage.Orders += orders
+		usage.AmountMinor[currency] = amount
+	}
+	if err := rows.Err(); err != nil {
+		return domain.QuotaUsage{}, fmt.Errorf("iterate quota usage: %w", err)
+	}
+	return usage, nil
+}
+
+var _ domain.QuotaRepository = (*quotaRepository)(nil)
@@ -0,0 +1,53 @@
+package postgres
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/vladislavdragonenkov/oms/internal/domain"
+)
+
+func TestQuotaRepository_PostgresConsumeReleaseUsage(t *testing.T) {
+	store := openPostgresStoreForIntegrationTest(t)
+	repo := NewQuotaRepository(store)
+
+	day := time.Date(2026, 10, 16, 23, 59, 0, 0, time.UTC)
+	quota := domain.OrderQuota{MaxOrders: 3, MaxAmountMinor: map[string]int64{"RUB": 1000}}
+
+	if _, err := repo.Consume("partner-a", day, "RUB", 700, quota); err != nil {
+		t.Fatalf("consume: %v", err)
+	}
+	usage, err := repo.Consume("partner-a", day, "USD", 50, quota)
+	if err != nil {
+		t.Fatalf("consume usd: %v", err)
+	}
+	if usage.Orders != 2 || usage.AmountMinor["RUB"] != 700 || usage.AmountMinor["USD"] != 50 {
+		t.Fatalf("unexpected usage after consume: %+v", usage)
+	}
+
+	var exceeded *domain.QuotaExceededError
+	if _, err := repo.Consume("partner-a", day, "RUB", 301, quota); !errors.As(err, &exceeded) || exceeded.Limit != domain.QuotaLimitAmount {
+		t.Fatalf("expected amount quota error, got %v", err)
+	}
+	if _, err := repo.Consume("partner-a", day, "RUB", 300, quota); err != nil {
+		t.Fatalf("consume up to limit: %v", err)
+	}
+	if _, err := repo.Consume("partner-a", day, "EUR", 1, quota); !errors.As(err, &exceeded) || exceeded.Limit != domain.QuotaLimitOrders {
+		t.Fatalf("expected orders quota error, got %v", err)
+	}
+
+	if err := repo.Release("partner-a", day, "RUB", 300); err != nil {
+		t.Fatalf("release: %v", err)
+	}
+	usage, err = repo.Usage("partner-a", day)
+	if err != nil {
+		t.Fatalf("usage: %v", err)
+	}
+	if usage.Orders != 2 || usage.AmountMinor["RUB"] != 700 {
+		t.Fatalf("unexpected usage after release: %+v", usage)
+	}
+	if next, _ := repo.Usage("partner-a", day.Add(time.Minute)); next.Orders != 0 {
+		t.Fatalf("quota must reset at UTC midnight, got %+v", next)
+	}
+}
@@ -0,0 +1,120 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/vladislavdragonenkov/oms/internal/domain"
+)
+
+type sagaDispatchRepository struct {
+	db *sql.DB
+}
+
+// NewSagaDispatchRepository создаёт PostgreSQL-реализацию SagaDispatchRepository.
+func NewSagaDispatchRepository(store *Store) domain.SagaDispatchRepository {
+	return &sagaDispatchRepository{db: store.DB()}
+}
+
+func (r *sagaDispatchRepository) Record(intent domain.SagaDispatchIntent) (domain.SagaDispatchIntent, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), opTimeout)
+	defer cancel()
+
+	if intent.ID == "" {
+		intent.ID = uuid.NewString()
+	}
+	if intent.RequestedAt.IsZero() {
+		intent.RequestedAt = time.Now().UTC()
+	}
+
+	if _, err := r.db.ExecContext(ctx, `
+		INSERT INTO saga_dispatch_intents (id, order_id, operation, reason, amount_minor, requested_at, claimed_until)
+		VALUES ($1,$2,$3,$4,$5,$6,$7)
+	`, intent.ID, intent.OrderID, string(intent.Operation), intent.Reason, intent.AmountMinor, intent.RequestedAt, nullTime(intent.ClaimedUntil)); err != nil {
+		return domain.SagaDispatchIntent{}, fmt.Errorf("record saga dispatch intent: %w", err)
+	}
+	return intent, nil
+}
+
+func (r *sagaDispatchRepository) Complete(id string) error {
+	ctx, cancel := context.WithTimeout(context.Background(), opTimeout)
+	defer cancel()
+
+	if _, err := r.db.ExecContext(ctx, `DELETE FROM saga_dispatch_intents WHERE id = $1`, id); err != nil {
+		return fmt.Errorf("complete saga dispatch intent: %w", err)
+	}
+	return nil
+}
+
+func (r *sagaDispatchRepository) ClaimPending(now, until time.Time, limit int) ([]domain.SagaDispatchIntent, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), opTimeout)
+	defer cancel()
+
+	var batch any
+	if limit > 0 {
+		batch = limit
+	}
+	// Как due-задачи: параллельная реплика пропускает занятые строки, а намерение, которое
+	// выполняет живая реплика, не видно, пока она продлевает claimed_until.
+	rows, err := r.db.QueryContext(ctx, `
+		WITH candidates AS (
+			SELECT id
+			FROM saga_dispatch_intents
+			WHERE claimed_until IS NULL OR claimed_until <= $1
+			ORDER BY requested_at ASC, id ASC
+			LIMIT $2
+			FOR UPDATE SKIP LOCKED
+		),
+		claimed AS (
+			UPDATE saga_dispatch_intents AS intents
+			SET claimed_until = $3
+			FROM candidates
+			WHERE intents.id = candidates.id
+			RETURNING intents.id, intents.order_id, intents.operation, intents.reason,
+			          intents.amount_minor, intents.requested_at, intents.claimed_until
+		)
+		SELECT id, order_id, operation, reason, amount_minor, requested_at, claimed_until
+		FROM claimed
+		ORDER BY requested_at ASC, id ASC
+	`, now.UTC(), batch, until.UTC())
+	if err != nil {
+		return nil, fmt.Errorf("claim saga dispatch intents: %w", err)
+	}
+	defer rows.Close()
+
+	intents := make([]domain.SagaDispatchIntent, 0)
+	for rows.Next() {
+		var (
+			intent       domain.SagaDispatchIntent
+			operation    string
+			claimedUntil sql.NullTime
+		)
+		if err := rows.Scan(&intent.ID, &intent.OrderID, &operation, &intent.Reason, &intent.AmountMinor, &intent.RequestedAt, &claimedUntil); err != nil {
+			return nil, fmt.Errorf("scan saga dispatch intent: %w", err)
+		}
+		intent.Operation = domain.SagaOperation(operation)
+		intent.RequestedAt = intent.RequestedAt.UTC()
+		intent.ClaimedUntil = claimedUntil.Time.UTC()
+		intents = append(intents, intent)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("iterate saga dispatch intents: %w", err)
+	}
+	return intents, nil
+}
+
+func (r *sagaDispatchRepository) Renew(id string, until time.Time) error {
+	ctx, cancel := context.WithTimeout(context.Background(), opTimeout)
+	defer cancel()
+
+	if _, err := r.db.ExecContext(ctx, `UPDATE saga_dispatch_intents SET claimed_until = $1 WHERE id = $2`, nullTime(until), id); err != nil {
+		return fmt.Errorf("renew saga dispatch intent: %w", err)
+	}
+	return nil
+}
+
+var _ domain.SagaDispatchRepository = (*sagaDispatchRepository)(nil)
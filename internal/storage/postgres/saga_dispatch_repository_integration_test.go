@@ -0,0 +1,73 @@
+package postgres
+
+import (
+	"testing"
+	"time"
+
+	"github.com/vladislavdragonenkov/oms/internal/domain"
+)
+
+func TestSagaDispatchRepository_PostgresRecordClaimComplete(t *testing.T) {
+	store := openPostgresStoreForIntegrationTest(t)
+	orderRepo := NewOrderRepository(store)
+	repo := NewSagaDispatchRepository(store)
+
+	createdAt := time.Now().UTC().Add(-time.Minute).Round(time.Microsecond)
+	order := sampleOrder("dispatch-order", "customer-dispatch", createdAt)
+	if err := orderRepo.Create(order); err != nil {
+		t.Fatalf("create order: %v", err)
+	}
+
+	refund, err := repo.Record(domain.SagaDispatchIntent{
+		OrderID:     order.ID,
+		Operation:   domain.SagaOperationRefund,
+		Reason:      "customer request",
+		AmountMinor: 500,
+		RequestedAt: createdAt.Add(time.Second),
+	})
+	if err != nil {
+		t.Fatalf("record refund intent: %v", err)
+	}
+	start, err := repo.Record(domain.SagaDispatchIntent{OrderID: order.ID, Operation: domain.SagaOperationStart, RequestedAt: createdAt})
+	if err != nil {
+		t.Fatalf("record start intent: %v", err)
+	}
+
+	now := time.Now().UTC()
+	pending, err := repo.ClaimPending(now, now.Add(time.Minute), 0)
+	if err != nil {
+		t.Fatalf("claim pending: %v", err)
+	}
+	if len(pending) != 2 || pending[0].ID != start.ID || pending[1].ID != refund.ID {
+		t.Fatalf("expected intents ordered by requested_at, got %+v", pending)
+	}
+	if got := pending[1]; got.Operation != domain.SagaOperationRefund || got.AmountMinor != 500 || got.Reason != "customer request" {
+		t.Fatalf("unexpected refund intent: %+v", got)
+	}
+
+	if claimed, _ := repo.ClaimPending(now, now.Add(time.Minute), 0); len(claimed) != 0 {
+		t.Fatalf("claimed intents must be skipped until the lease expires, got %+v", claimed)
+	}
+
+	if err := repo.Complete(start.ID); err != nil {
+		t.Fatalf("complete: %v", err)
+	}
+	if err := repo.Renew(refund.ID, time.Time{}); err != nil {
+		t.Fatalf("release: %v", err)
+	}
+	pending, err = repo.ClaimPending(now, now.Add(time.Minute), 10)
+	if err != nil {
+		t.Fatalf("claim pending after complete: %v", err)
+	}
+	if len(pending) != 1 || pending[0].ID != refund.ID {
+		t.Fatalf("unexpected pending after complete: %+v", pending)
+	}
+
+	// Удаление заказа (например, GDPR) удаляет и его намерения.
+	if err := orderRepo.Delete(order.ID); err != nil {
+		t.Fatalf("delete order: %v", err)
+	}
+	if pending, _ = repo.ClaimPending(now.Add(time.Hour), now, 0); len(pending) != 0 {
+		t.Fatalf("expected intents removed with order, got %+v", pending)
+	}
+}
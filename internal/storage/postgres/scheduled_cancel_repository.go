@@ -0,0 +1,184 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/vladislavdragonenkov/oms/internal/domain"
+)
+
+const scheduledCancelColumns = `id, order_id, cancel_at, reason, status, note, created_at, updated_at`
+
+// dueTaskLease — на сколько ListDue закрепляет задачу за забравшим её планировщиком. Больше
+// таймаута саги, чтобы аренда не истекла посреди выполнения; после падения реплики задача
+// вернётся в выборку по её истечении.
+const dueTaskLease = 2 * time.Minute
+
+type scheduledCancelRepository struct {
+	db *sql.DB
+}
+
+// NewScheduledCancelRepository создаёт PostgreSQL-реализацию ScheduledCancelRepository.
+func NewScheduledCancelRepository(store *Store) domain.ScheduledCancelRepository {
+	return &scheduledCancelRepository{db: store.DB()}
+}
+
+func (r *scheduledCancelRepository) Create(task domain.ScheduledCancel) (domain.ScheduledCancel, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), opTimeout)
+	defer cancel()
+
+	if task.ID == "" {
+		task.ID = uuid.NewString()
+	}
+	now := time.Now().UTC()
+	task.CancelAt = task.CancelAt.UTC()
+	task.Status = domain.ScheduledCancelPending
+	task.Note = ""
+	task.CreatedAt = now
+	task.UpdatedAt = now
+
+	if _, err := r.db.ExecContext(ctx, `
+		INSERT INTO scheduled_order_cancels (`+scheduledCancelColumns+`)
+		VALUES ($1,$2,$3,$4,$5,$6,$7,$8)
+	`, task.ID, task.OrderID, task.CancelAt, task.Reason, string(task.Status), task.Note, task.CreatedAt, task.UpdatedAt); err != nil {
+		return domain.ScheduledCancel{}, fmt.Errorf("create scheduled cancel: %w", err)
+	}
+	return task, nil
+}
+
+func (r *scheduledCancelRepository) Get(id string) (domain.ScheduledCancel, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), opTimeout)
+	defer cancel()
+
+	task, err := scanScheduledCancel(r.db.QueryRowContext(ctx, `
+		SELECT `+scheduledCancelColumns+` FROM scheduled_order_cancels WHERE id = $1
+	`, id))
+	if errors.Is(err, sql.ErrNoRows) {
+		return domain.ScheduledCancel{}, domain.ErrScheduledCancelNotFound
+	}
+	if err != nil {
+		return domain.ScheduledCancel{}, fmt.Errorf("get scheduled cancel: %w", err)
+	}
+	return task, nil
+}
+
+func (r *scheduledCancelRepository) ListByOrder(orderID string) ([]domain.ScheduledCancel, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), opTimeout)
+	defer cancel()
+
+	return r.list(ctx, `
+		SELECT `+scheduledCancelColumns+`
+		FROM scheduled_order_cancels
+		WHERE order_id = $1
+		ORDER BY cancel_at ASC, id ASC
+	`, orderID)
+}
+
+func (r *scheduledCancelRepository) ListDue(now time.Time, limit int) ([]domain.ScheduledCancel, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), opTimeout)
+	defer cancel()
+
+	var batch any
+	if limit > 0 {
+		batch = limit
+	}
+	now = now.UTC()
+	// Задачи забираются арендой, как в outbox: параллельная реплика пропускает занятые строки
+	// и не видит задачу, пока не истечёт claimed_until.
+	return r.list(ctx, `
+		WITH candidates AS (
+			SELECT id
+			FROM scheduled_order_cancels
+			WHERE status = 'pending' AND cancel_at <= $1
+			  AND (claimed_until IS NULL OR claimed_until <= $1)
+			ORDER BY cancel_at ASC, id ASC
+			LIMIT $2
+			FOR UPDATE SKIP LOCKED
+		),
+		claimed AS (
+			UPDATE scheduled_order_cancels AS tasks
+			SET claimed_until = $3
+			FROM candidates
+			WHERE tasks.id = candidates.id
+			RETURNING tasks.id, tasks.order_id, tasks.cancel_at, tasks.reason, tasks.status,
+			          tasks.note, tasks.created_at, tasks.updated_at
+		)
+		SELECT `+scheduledCancelColumns+`
+		FROM claimed
+		ORDER BY cancel_at ASC, id ASC
+	`, now, batch, now.Add(dueTaskLease))
+}
+
+func (r *scheduledCancelRepository) Finish(id string, status domain.ScheduledCancelStatus, note string) (domain.ScheduledCancel, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), opTimeout)
+	defer cancel()
+
+	task, err := scanScheduledCancel(r.db.QueryRowContext(ctx, `
+		UPDATE scheduled_order_cancels
+		SET status = $1, note = $2, updated_at = $3
+		WHERE id = $4 AND status = 'pending'
+		RETURNING `+scheduledCancelColumns+`
+	`, string(status), note, time.Now().UTC(), id))
+	if err == nil {
+		return task, nil
+	}
+	if !errors.Is(err, sql.ErrNoRows) {
+		return domain.ScheduledCancel{}, fmt.Errorf("finish scheduled cancel: %w", err)
+	}
+
+	var exists bool
+	if err := r.db.QueryRowContext(ctx, `SELECT EXISTS(SELECT 1 FROM scheduled_order_cancels WHERE id = $1)`, id).Scan(&exists); err != nil {
+		return domain.ScheduledCancel{}, fmt.Errorf("check scheduled cancel: %w", err)
+	}
+	if !exists {
+		return domain.ScheduledCancel{}, domain.ErrScheduledCancelNotFound
+	}
+	return domain.ScheduledCancel{}, domain.ErrScheduledCancelNotPending
+}
+
+func (r *scheduledCancelRepository) list(ctx context.Context, query string, args ...any) ([]domain.ScheduledCancel, error) {
+	rows, err := r.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("list scheduled cancels: %w", err)
+	}
+	defer rows.Close()
+
+	tasks := make([]domain.ScheduledCancel, 0)
+	for rows.Next() {
+		task, err := scanScheduledCancel(rows)
+		if err != nil {
+			return nil, fmt.Errorf("scan scheduled cancel: %w", err)
+		}
+		tasks = append(tasks, task)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("iterate scheduled cancels: %w", err)
+	}
+	return tasks, nil
+}
+
+type scheduledCancelScanner interface {
+	Scan(dest ...any) error
+}
+
+func scanScheduledCancel(row scheduledCancelScanner) (domain.ScheduledCancel, error) {
+	var (
+		task   domain.ScheduledCancel
+		status string
+	)
+	if err := row.Scan(&task.ID, &task.OrderID, &task.CancelAt, &task.Reason, &status, &task.Note, &task.CreatedAt, &task.UpdatedAt); err != nil {
+		return domain.ScheduledCancel{}, err
+	}
+	task.Status = domain.ScheduledCancelStatus(status)
+	task.CancelAt = task.CancelAt.UTC()
+	task.CreatedAt = task.CreatedAt.UTC()
+	task.UpdatedAt = task.UpdatedAt.UTC()
+	return task, nil
+}
+
+var _ domain.ScheduledCancelRepository = (*scheduledCancelRepository)(nil)
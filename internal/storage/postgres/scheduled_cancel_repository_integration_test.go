@@ -0,0 +1,73 @@
+package postgres
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/vladislavdragonenkov/oms/internal/domain"
+)
+
+func TestScheduledCancelRepository_PostgresDueAndFinish(t *testing.T) {
+	store := openPostgresStoreForIntegrationTest(t)
+	orderRepo := NewOrderRepository(store)
+	repo := NewScheduledCancelRepository(store)
+
+	now := time.Now().UTC().Round(time.Microsecond)
+	order := sampleOrder("scheduled-cancel-order", "customer-scheduled", now.Add(-time.Hour))
+	if err := orderRepo.Create(order); err != nil {
+		t.Fatalf("create order: %v", err)
+	}
+
+	later, err := repo.Create(domain.ScheduledCancel{OrderID: order.ID, CancelAt: now.Add(time.Hour), Reason: "not shipped by friday"})
+	if err != nil {
+		t.Fatalf("create later task: %v", err)
+	}
+	due, err := repo.Create(domain.ScheduledCancel{OrderID: order.ID, CancelAt: now.Add(-time.Minute)})
+	if err != nil {
+		t.Fatalf("create due task: %v", err)
+	}
+
+	got, err := repo.Get(later.ID)
+	if err != nil {
+		t.Fatalf("get: %v", err)
+	}
+	if got.Reason != "not shipped by friday" || !got.CancelAt.Equal(later.CancelAt) || got.Status != domain.ScheduledCancelPending {
+		t.Fatalf("unexpected task: %+v", got)
+	}
+	if _, err := repo.Get("missing"); !errors.Is(err, domain.ErrScheduledCancelNotFound) {
+		t.Fatalf("expected ErrScheduledCancelNotFound, got %v", err)
+	}
+
+	dueTasks, err := repo.ListDue(now, 10)
+	if err != nil {
+		t.Fatalf("list due: %v", err)
+	}
+	if len(dueTasks) != 1 || dueTasks[0].ID != due.ID {
+		t.Fatalf("expected only the overdue task, got %+v", dueTasks)
+	}
+	if again, _ := repo.ListDue(now, 10); len(again) != 0 {
+		t.Fatalf("claimed task must not be listed again, got %+v", again)
+	}
+	if again, _ := repo.ListDue(now.Add(3*time.Minute), 10); len(again) != 1 || again[0].ID != due.ID {
+		t.Fatalf("task must be listed again after the claim expires, got %+v", again)
+	}
+
+	if _, err := repo.Finish(due.ID, domain.ScheduledCancelExecuted, ""); err != nil {
+		t.Fatalf("finish: %v", err)
+	}
+	if _, err := repo.Finish(due.ID, domain.ScheduledCancelCanceled, ""); !errors.Is(err, domain.ErrScheduledCancelNotPending) {
+		t.Fatalf("expected ErrScheduledCancelNotPending, got %v", err)
+	}
+	if _, err := repo.Finish("missing", domain.ScheduledCancelCanceled, ""); !errors.Is(err, domain.ErrScheduledCancelNotFound) {
+		t.Fatalf("expected ErrScheduledCancelNotFound, got %v", err)
+	}
+
+	list, err := repo.ListByOrder(order.ID)
+	if err != nil {
+		t.Fatalf("list by order: %v", err)
+	}
+	if len(list) != 2 || list[0].ID != due.ID || list[0].Status != domain.ScheduledCancelExecuted || list[1].ID != later.ID {
+		t.Fatalf("unexpected tasks of order: %+v", list)
+	}
+}
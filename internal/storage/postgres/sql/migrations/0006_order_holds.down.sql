@@ -0,0 +1,3 @@
+ALTER TABLE orders
+    DROP COLUMN IF EXISTS held_from_status,
+    DROP COLUMN IF EXISTS hold_reason;
@@ -0,0 +1,3 @@
+ALTER TABLE orders
+    ADD COLUMN IF NOT EXISTS hold_reason TEXT NOT NULL DEFAULT '',
+    ADD COLUMN IF NOT EXISTS held_from_status TEXT NOT NULL DEFAULT '';
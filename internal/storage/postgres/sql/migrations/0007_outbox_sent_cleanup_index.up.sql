@@ -0,0 +1,4 @@
+-- Частичный индекс под cleanup-воркер: удаление sent-сообщений по возрасту без скана всего outbox.
+CREATE INDEX IF NOT EXISTS idx_outbox_sent_updated_at
+    ON outbox_messages (updated_at)
+    WHERE status = 'sent';
@@ -0,0 +1,8 @@
+CREATE TABLE IF NOT EXISTS order_amounts (
+    order_id TEXT PRIMARY KEY REFERENCES orders (id) ON DELETE CASCADE,
+    subtotal_minor BIGINT NOT NULL,
+    discount_minor BIGINT NOT NULL DEFAULT 0,
+    tax_minor BIGINT NOT NULL DEFAULT 0,
+    total_minor BIGINT NOT NULL,
+    adjustments JSONB NOT NULL DEFAULT '[]'::jsonb
+);
@@ -0,0 +1,11 @@
+CREATE TABLE IF NOT EXISTS saga_dispatch_intents (
+    id TEXT PRIMARY KEY,
+    order_id TEXT NOT NULL REFERENCES orders (id) ON DELETE CASCADE,
+    operation TEXT NOT NULL,
+    reason TEXT NOT NULL DEFAULT '',
+    amount_minor BIGINT NOT NULL DEFAULT 0,
+    requested_at TIMESTAMPTZ NOT NULL
+);
+
+CREATE INDEX IF NOT EXISTS idx_saga_dispatch_intents_requested_at
+    ON saga_dispatch_intents (requested_at, id);
@@ -0,0 +1,9 @@
+CREATE TABLE IF NOT EXISTS order_quota_usage (
+    principal TEXT NOT NULL,
+    day DATE NOT NULL,
+    currency TEXT NOT NULL,
+    orders BIGINT NOT NULL DEFAULT 0,
+    amount_minor BIGINT NOT NULL DEFAULT 0,
+    updated_at TIMESTAMPTZ NOT NULL,
+    PRIMARY KEY (principal, day, currency)
+);
@@ -0,0 +1,2 @@
+ALTER TABLE outbox_messages
+    ADD COLUMN IF NOT EXISTS headers JSONB NOT NULL DEFAULT '{}'::jsonb;
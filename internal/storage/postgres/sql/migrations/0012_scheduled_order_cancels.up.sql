@@ -0,0 +1,17 @@
+CREATE TABLE IF NOT EXISTS scheduled_order_cancels (
+    id TEXT PRIMARY KEY,
+    order_id TEXT NOT NULL REFERENCES orders (id) ON DELETE CASCADE,
+    cancel_at TIMESTAMPTZ NOT NULL,
+    reason TEXT NOT NULL DEFAULT '',
+    status TEXT NOT NULL,
+    note TEXT NOT NULL DEFAULT '',
+    created_at TIMESTAMPTZ NOT NULL,
+    updated_at TIMESTAMPTZ NOT NULL
+);
+
+CREATE INDEX IF NOT EXISTS idx_scheduled_order_cancels_due
+    ON scheduled_order_cancels (cancel_at, id)
+    WHERE status = 'pending';
+
+CREATE INDEX IF NOT EXISTS idx_scheduled_order_cancels_order
+    ON scheduled_order_cancels (order_id, cancel_at);
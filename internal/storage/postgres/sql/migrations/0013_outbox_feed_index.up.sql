@@ -0,0 +1,3 @@
+-- Индекс под ленту GetEventsSince: курсор (created_at, id) по записям в любом статусе.
+CREATE INDEX IF NOT EXISTS idx_outbox_created_at_id
+    ON outbox_messages (created_at, id);
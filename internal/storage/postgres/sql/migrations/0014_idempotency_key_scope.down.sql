@@ -0,0 +1,13 @@
+-- Из записей с одинаковым key остаётся самая свежая.
+DELETE FROM idempotency_keys a
+USING idempotency_keys b
+WHERE a.key = b.key
+  AND (a.created_at, a.method, a.customer_id) < (b.created_at, b.method, b.customer_id);
+
+ALTER TABLE idempotency_keys
+    DROP CONSTRAINT IF EXISTS idempotency_keys_pkey,
+    ADD CONSTRAINT idempotency_keys_pkey PRIMARY KEY (key);
+
+ALTER TABLE idempotency_keys
+    DROP COLUMN IF EXISTS customer_id,
+    DROP COLUMN IF EXISTS method;
@@ -0,0 +1,9 @@
+-- Ключ идемпотентности действует в пределах метода и клиента. Существующие записи получают
+-- пустые method и customer_id: сервис учитывает их как повтор только при совпадении request_hash.
+ALTER TABLE idempotency_keys
+    ADD COLUMN IF NOT EXISTS method TEXT NOT NULL DEFAULT '',
+    ADD COLUMN IF NOT EXISTS customer_id TEXT NOT NULL DEFAULT '';
+
+ALTER TABLE idempotency_keys
+    DROP CONSTRAINT IF EXISTS idempotency_keys_pkey,
+    ADD CONSTRAINT idempotency_keys_pkey PRIMARY KEY (method, customer_id, key);
@@ -0,0 +1,9 @@
+UPDATE order_amounts a
+SET adjustments = (
+    SELECT jsonb_agg(t.elem - 'rounding_delta_scaled' ORDER BY t.ord)
+    FROM jsonb_array_elements(a.adjustments) WITH ORDINALITY AS t(elem, ord)
+)
+WHERE jsonb_array_length(a.adjustments) > 0;
+
+ALTER TABLE order_amounts
+    DROP COLUMN IF EXISTS rounding_mode;
@@ -0,0 +1,28 @@
+-- Аудит округления: правило округления заказа и дельта каждой процентной строки
+-- (applied - точное значение, в миллионных долях минимальной единицы).
+-- Все существующие разбивки считались половиной вверх, дельты досчитываются по сохранённым суммам:
+-- скидки — от subtotal, налоги — от subtotal за вычетом скидок.
+ALTER TABLE order_amounts
+    ADD COLUMN IF NOT EXISTS rounding_mode TEXT NOT NULL DEFAULT 'half_up';
+
+UPDATE order_amounts a
+SET adjustments = (
+    SELECT jsonb_agg(
+        CASE
+            WHEN COALESCE((t.elem->>'percent_scaled')::bigint, 0) = 0 THEN t.elem
+            ELSE t.elem || jsonb_build_object(
+                'rounding_delta_scaled',
+                (t.elem->>'applied_minor')::numeric * 1000000
+                    - (CASE WHEN t.elem->>'type' = 'discount' THEN a.subtotal_minor
+                            ELSE a.subtotal_minor - a.discount_minor END)::numeric
+                      * (t.elem->>'percent_scaled')::numeric
+            )
+        END
+        ORDER BY t.ord
+    )
+    FROM jsonb_array_elements(a.adjustments) WITH ORDINALITY AS t(elem, ord)
+)
+WHERE jsonb_array_length(a.adjustments) > 0;
+
+ALTER TABLE order_amounts
+    ALTER COLUMN rounding_mode SET DEFAULT '';
@@ -0,0 +1,3 @@
+-- Sandbox-заказы партнёров: сага ведёт их через заглушки склада и оплаты.
+ALTER TABLE orders
+    ADD COLUMN IF NOT EXISTS test_mode BOOLEAN NOT NULL DEFAULT FALSE;
@@ -0,0 +1,12 @@
+-- Блокировки сумм при двухфазной оплате: по одной на заказ в статусе authorized.
+CREATE TABLE IF NOT EXISTS payment_authorizations (
+    order_id TEXT PRIMARY KEY REFERENCES orders (id) ON DELETE CASCADE,
+    amount_minor BIGINT NOT NULL,
+    currency TEXT NOT NULL,
+    authorized_at TIMESTAMPTZ NOT NULL,
+    expires_at TIMESTAMPTZ NOT NULL,
+    reauthorizations INTEGER NOT NULL DEFAULT 0
+);
+
+CREATE INDEX IF NOT EXISTS idx_payment_authorizations_expires
+    ON payment_authorizations (expires_at, order_id);
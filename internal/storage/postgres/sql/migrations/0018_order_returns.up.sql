@@ -0,0 +1,15 @@
+-- Возвраты позиций заказа (RMA); позиции возврата хранятся в JSONB, как корректировки суммы.
+CREATE TABLE IF NOT EXISTS order_returns (
+    id TEXT PRIMARY KEY,
+    order_id TEXT NOT NULL REFERENCES orders (id) ON DELETE CASCADE,
+    status TEXT NOT NULL,
+    items JSONB NOT NULL,
+    reason TEXT NOT NULL DEFAULT '',
+    refund_minor BIGINT NOT NULL,
+    currency TEXT NOT NULL,
+    created_at TIMESTAMPTZ NOT NULL,
+    updated_at TIMESTAMPTZ NOT NULL
+);
+
+CREATE INDEX IF NOT EXISTS idx_order_returns_order
+    ON order_returns (order_id, created_at);
@@ -0,0 +1,11 @@
+-- Запланированные повторы оплаты заказов, оставшихся в reserved после временной ошибки PSP.
+CREATE TABLE IF NOT EXISTS payment_retries (
+    order_id TEXT PRIMARY KEY REFERENCES orders (id) ON DELETE CASCADE,
+    attempt INTEGER NOT NULL,
+    run_at TIMESTAMPTZ NOT NULL,
+    last_error TEXT NOT NULL DEFAULT '',
+    updated_at TIMESTAMPTZ NOT NULL
+);
+
+CREATE INDEX IF NOT EXISTS idx_payment_retries_run_at
+    ON payment_retries (run_at, order_id);
@@ -0,0 +1,2 @@
+ALTER TABLE scheduled_order_cancels
+    DROP COLUMN IF EXISTS claimed_until;
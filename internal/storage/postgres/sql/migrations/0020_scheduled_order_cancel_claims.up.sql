@@ -0,0 +1,3 @@
+-- Срок, до которого задачу держит забравший её планировщик; NULL — задача свободна.
+ALTER TABLE scheduled_order_cancels
+    ADD COLUMN IF NOT EXISTS claimed_until TIMESTAMPTZ;
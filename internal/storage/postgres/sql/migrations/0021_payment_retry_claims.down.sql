@@ -0,0 +1,2 @@
+ALTER TABLE payment_retries
+    DROP COLUMN IF EXISTS claimed_until;
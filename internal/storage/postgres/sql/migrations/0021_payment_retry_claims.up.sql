@@ -0,0 +1,3 @@
+-- Срок, до которого повтор держит забравший его планировщик; NULL — повтор свободен.
+ALTER TABLE payment_retries
+    ADD COLUMN IF NOT EXISTS claimed_until TIMESTAMPTZ;
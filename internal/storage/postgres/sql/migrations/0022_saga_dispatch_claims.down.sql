@@ -0,0 +1,2 @@
+ALTER TABLE saga_dispatch_intents
+    DROP COLUMN IF EXISTS claimed_until;
@@ -0,0 +1,3 @@
+-- Срок, до которого намерение держит выполняющая сагу реплика; NULL — намерение свободно.
+ALTER TABLE saga_dispatch_intents
+    ADD COLUMN IF NOT EXISTS claimed_until TIMESTAMPTZ;
@@ -4,9 +4,9 @@ import (
 	"context"
 	"database/sql"
 	"fmt"
-	"time"
 
 	"github.com/vladislavdragonenkov/oms/internal/domain"
+	"github.com/vladislavdragonenkov/oms/internal/timeutil"
 )
 
 type timelineRepository struct {
@@ -22,9 +22,11 @@ func (r *timelineRepository) Append(event domain.TimelineEvent) error {
 	ctx, cancel := context.WithTimeout(context.Background(), opTimeout)
 	defer cancel()
 
-	if event.Occurred.IsZero() {
-		event.Occurred = time.Now().UTC()
+	occurred, err := timeutil.Normalize(event.Occurred)
+	if err != nil {
+		return fmt.Errorf("append timeline event: %w", err)
 	}
+	event.Occurred = occurred
 
 	if _, err := r.db.ExecContext(ctx, `
 		INSERT INTO timeline_events (order_id, type, reason, occurred)
@@ -57,6 +59,7 @@ func (r *timelineRepository) List(orderID string) ([]domain.TimelineEvent, error
 		if err := rows.Scan(&event.OrderID, &event.Type, &event.Reason, &event.Occurred); err != nil {
 			return nil, fmt.Errorf("scan timeline event: %w", err)
 		}
+		event.Occurred = event.Occurred.UTC()
 		events = append(events, event)
 	}
 	if err := rows.Err(); err != nil {
@@ -66,4 +69,15 @@ func (r *timelineRepository) List(orderID string) ([]domain.TimelineEvent, error
 	return events, nil
 }
 
+func (r *timelineRepository) DeleteByOrder(orderID string) error {
+	ctx, cancel := context.WithTimeout(context.Background(), opTimeout)
+	defer cancel()
+
+	if _, err := r.db.ExecContext(ctx, `DELETE FROM timeline_events WHERE order_id = $1`, orderID); err != nil {
+		return fmt.Errorf("delete timeline events: %w", err)
+	}
+
+	return nil
+}
+
 var _ domain.TimelineRepository = (*timelineRepository)(nil)
@@ -0,0 +1,52 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+
+	"github.com/vladislavdragonenkov/oms/internal/domain"
+)
+
+type orderUnitOfWork struct {
+	db *sql.DB
+}
+
+// NewOrderUnitOfWork создаёт PostgreSQL-реализацию OrderUnitOfWork.
+func NewOrderUnitOfWork(store *Store) domain.OrderUnitOfWork {
+	return &orderUnitOfWork{db: store.DB()}
+}
+
+// CreateOrderWithIdempotency вставляет заказ и переводит idempotency-ключ в done одной транзакцией.
+// Если ключа нет (например, его успел удалить sweeper), заказ не сохраняется.
+func (u *orderUnitOfWork) CreateOrderWithIdempotency(order domain.Order, scope domain.IdempotencyScope, responseBody []byte, httpStatus int) error {
+	ctx, cancel := context.WithTimeout(context.Background(), opTimeout)
+	defer cancel()
+
+	tx, err := u.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("begin tx: %w", err)
+	}
+
+	defer func() {
+		if err != nil {
+			_ = tx.Rollback()
+		}
+	}()
+
+	if err = insertOrder(ctx, tx, order); err != nil {
+		return err
+	}
+
+	if err = markIdempotencyStatus(ctx, tx, scope, domain.IdempotencyStatusDone, responseBody, httpStatus); err != nil {
+		return err
+	}
+
+	if err = tx.Commit(); err != nil {
+		return fmt.Errorf("commit create order: %w", err)
+	}
+
+	return nil
+}
+
+var _ domain.OrderUnitOfWork = (*orderUnitOfWork)(nil)
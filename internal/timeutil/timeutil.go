@@ -0,0 +1,61 @@
+// Package timeutil задаёт единый формат времени на границах сервиса:
+// все метки хранятся и передаются в UTC в формате RFC3339Nano.
+package timeutil
+
+import (
+	"errors"
+	"fmt"
+	"time"
+)
+
+// MaxFutureSkew — насколько метка события может опережать локальные часы.
+// Больший сдвиг считается ошибкой часов источника, а не реальным событием.
+const MaxFutureSkew = 5 * time.Minute
+
+var (
+	// ErrInvalidTimestamp — строка не является меткой времени RFC3339Nano.
+	ErrInvalidTimestamp = errors.New("timeutil: invalid timestamp")
+	// ErrTimestampInFuture — метка опережает текущее время больше допустимого сдвига.
+	ErrTimestampInFuture = errors.New("timeutil: timestamp is too far in the future")
+)
+
+// Now возвращает текущее время в UTC.
+func Now() time.Time {
+	return time.Now().UTC()
+}
+
+// Format форматирует метку в UTC RFC3339Nano.
+func Format(t time.Time) string {
+	return t.UTC().Format(time.RFC3339Nano)
+}
+
+// Parse строго разбирает RFC3339Nano и приводит результат к UTC.
+// Пустая строка и другие форматы возвращают ErrInvalidTimestamp.
+func Parse(value string) (time.Time, error) {
+	ts, err := time.Parse(time.RFC3339Nano, value)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("%w %q: %v", ErrInvalidTimestamp, value, err)
+	}
+	return ts.UTC(), nil
+}
+
+// CheckSkew возвращает ErrTimestampInFuture, если t позже now больше чем на MaxFutureSkew.
+func CheckSkew(t, now time.Time) error {
+	if t.Sub(now) > MaxFutureSkew {
+		return fmt.Errorf("%w: %s is ahead of %s", ErrTimestampInFuture, Format(t), Format(now))
+	}
+	return nil
+}
+
+// Normalize приводит метку события к UTC: нулевая заменяется текущим временем,
+// слишком далёкая в будущем отклоняется.
+func Normalize(t time.Time) (time.Time, error) {
+	now := Now()
+	if t.IsZero() {
+		return now, nil
+	}
+	if err := CheckSkew(t, now); err != nil {
+		return time.Time{}, err
+	}
+	return t.UTC(), nil
+}
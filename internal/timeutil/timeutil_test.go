@@ -0,0 +1,63 @@
+package timeutil
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestFormat_ConvertsToUTC(t *testing.T) {
+	moscow := time.FixedZone("MSK", 3*60*60)
+	ts := time.Date(2026, 3, 1, 15, 4, 5, 123000000, moscow)
+
+	if got := Format(ts); got != "2026-03-01T12:04:05.123Z" {
+		t.Fatalf("unexpected format: %s", got)
+	}
+}
+
+func TestParse_StrictAndUTC(t *testing.T) {
+	ts, err := Parse("2026-03-01T15:04:05.5+03:00")
+	if err != nil {
+		t.Fatalf("parse failed: %v", err)
+	}
+	if ts.Location() != time.UTC || ts.Hour() != 12 {
+		t.Fatalf("expected UTC timestamp, got %s", ts)
+	}
+
+	for _, value := range []string{"", "2026-03-01", "2026-03-01 15:04:05", "1709305445"} {
+		if _, err := Parse(value); !errors.Is(err, ErrInvalidTimestamp) {
+			t.Fatalf("expected ErrInvalidTimestamp for %q, got %v", value, err)
+		}
+	}
+}
+
+func TestCheckSkew(t *testing.T) {
+	now := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+
+	if err := CheckSkew(now.Add(MaxFutureSkew), now); err != nil {
+		t.Fatalf("skew within limit must pass: %v", err)
+	}
+	if err := CheckSkew(now.Add(-24*time.Hour), now); err != nil {
+		t.Fatalf("past timestamp must pass: %v", err)
+	}
+	if err := CheckSkew(now.Add(MaxFutureSkew+time.Second), now); !errors.Is(err, ErrTimestampInFuture) {
+		t.Fatalf("expected ErrTimestampInFuture, got %v", err)
+	}
+}
+
+func TestNormalize(t *testing.T) {
+	got, err := Normalize(time.Time{})
+	if err != nil || got.IsZero() || got.Location() != time.UTC {
+		t.Fatalf("zero timestamp must be replaced with UTC now, got %s (%v)", got, err)
+	}
+
+	local := time.Now().In(time.FixedZone("X", -7*60*60)).Add(-time.Minute)
+	got, err = Normalize(local)
+	if err != nil || got.Location() != time.UTC || !got.Equal(local) {
+		t.Fatalf("expected same instant in UTC, got %s (%v)", got, err)
+	}
+
+	if _, err := Normalize(time.Now().Add(time.Hour)); !errors.Is(err, ErrTimestampInFuture) {
+		t.Fatalf("expected ErrTimestampInFuture, got %v", err)
+	}
+}
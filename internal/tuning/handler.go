@@ -0,0 +1,54 @@
+package tuning
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+
+	log "github.com/sirupsen/logrus"
+)
+
+// snapshot — ответ admin-эндпоинта.
+type snapshot struct {
+	Values  map[string]string `json:"values"`
+	Changes []Change          `json:"changes,omitempty"`
+}
+
+// Handler отдаёт текущие значения (GET) и перечитывает источник через reload (POST).
+// Невалидный источник — 422, текущие значения остаются.
+func Handler(store *Store, reload func() ([]Change, error), logger *log.Entry) http.Handler {
+	if logger == nil {
+		logger = log.WithField("component", "tuning")
+	}
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method {
+		case http.MethodGet:
+			writeJSON(w, http.StatusOK, snapshot{Values: store.Current().Map()})
+		case http.MethodPost:
+			changes, err := reload()
+			switch {
+			case errors.Is(err, ErrInvalid):
+				writeError(w, http.StatusUnprocessableEntity, err.Error())
+				return
+			case err != nil:
+				logger.WithError(err).Error("tuning reload failed")
+				writeError(w, http.StatusInternalServerError, err.Error())
+				return
+			}
+			writeJSON(w, http.StatusOK, snapshot{Values: store.Current().Map(), Changes: changes})
+		default:
+			w.Header().Set("Allow", "GET, POST")
+			writeError(w, http.StatusMethodNotAllowed, "method not allowed")
+		}
+	})
+}
+
+func writeJSON(w http.ResponseWriter, status int, body any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(body)
+}
+
+func writeError(w http.ResponseWriter, status int, message string) {
+	writeJSON(w, status, map[string]string{"error": message})
+}
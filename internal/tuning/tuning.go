@@ -0,0 +1,305 @@
+// Package tuning хранит параметры воркеров, которые безопасно менять без рестарта: частоту
+// и размер батча outbox, повторы публикации, таймаут саги и параллельность consumer'ов.
+// Новое значение проверяется целиком и применяется подписчиками; некорректный набор
+// отбрасывается, рабочие значения остаются.
+package tuning
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	log "github.com/sirupsen/logrus"
+
+	"github.com/vladislavdragonenkov/oms/internal/metrics"
+)
+
+// Ключи параметров в файле и в метриках.
+const (
+	KeyOutboxPollInterval  = "outbox_poll_interval"
+	KeyOutboxBatchSize     = "outbox_batch_size"
+	KeyOutboxMaxAttempts   = "outbox_max_attempts"
+	KeyOutboxRetryDelay    = "outbox_retry_delay"
+	KeySagaTimeout         = "saga_timeout"
+	KeyConsumerConcurrency = "consumer_concurrency"
+)
+
+// Пределы значений: защищают от опечаток вроде batch_size=1000000, которые положат БД.
+const (
+	maxPollInterval       = time.Hour
+	maxBatchSize          = 10000
+	maxAttempts           = 100
+	maxRetryDelay         = time.Minute
+	maxSagaTimeout        = time.Hour
+	maxConsumerConcurrent = 1024
+)
+
+// Результаты перезагрузки в oms_tuning_reloads_total.
+const (
+	ResultApplied   = "applied"
+	ResultUnchanged = "unchanged"
+	ResultInvalid   = "invalid"
+)
+
+// ErrInvalid — набор параметров не прошёл проверку.
+var ErrInvalid = errors.New("tuning: invalid values")
+
+// Values — параметры, меняемые на лету.
+type Values struct {
+	OutboxPollInterval time.Duration
+	OutboxBatchSize    int
+	OutboxMaxAttempts  int
+	OutboxRetryDelay   time.Duration
+	SagaTimeout        time.Duration
+	// ConsumerConcurrency — сколько сообщений consumer group обрабатывает одновременно по всем
+	// партициям; 0 — без ограничения (по одному на партицию).
+	ConsumerConcurrency int
+}
+
+// Validate проверяет значения по пределам.
+func (v Values) Validate() error {
+	var errs []error
+	if v.OutboxPollInterval <= 0 || v.OutboxPollInterval > maxPollInterval {
+		errs = append(errs, fmt.Errorf("%s must be in (0, %s]", KeyOutboxPollInterval, maxPollInterval))
+	}
+	if v.OutboxBatchSize <= 0 || v.OutboxBatchSize > maxBatchSize {
+		errs = append(errs, fmt.Errorf("%s must be in [1, %d]", KeyOutboxBatchSize, maxBatchSize))
+	}
+	if v.OutboxMaxAttempts <= 0 || v.OutboxMaxAttempts > maxAttempts {
+		errs = append(errs, fmt.Errorf("%s must be in [1, %d]", KeyOutboxMaxAttempts, maxAttempts))
+	}
+	if v.OutboxRetryDelay < 0 || v.OutboxRetryDelay > maxRetryDelay {
+		errs = append(errs, fmt.Errorf("%s must be in [0, %s]", KeyOutboxRetryDelay, maxRetryDelay))
+	}
+	if v.SagaTimeout <= 0 || v.SagaTimeout > maxSagaTimeout {
+		errs = append(errs, fmt.Errorf("%s must be in (0, %s]", KeySagaTimeout, maxSagaTimeout))
+	}
+	if v.ConsumerConcurrency < 0 || v.ConsumerConcurrency > maxConsumerConcurrent {
+		errs = append(errs, fmt.Errorf("%s must be in [0, %d]", KeyConsumerConcurrency, maxConsumerConcurrent))
+	}
+	if len(errs) > 0 {
+		return fmt.Errorf("%w: %w", ErrInvalid, errors.Join(errs...))
+	}
+	return nil
+}
+
+// Map возвращает значения по ключам; используется в логах, diff'е и admin-эндпоинте.
+func (v Values) Map() map[string]string {
+	return map[string]string{
+		KeyOutboxPollInterval:  v.OutboxPollInterval.String(),
+		KeyOutboxBatchSize:     strconv.Itoa(v.OutboxBatchSize),
+		KeyOutboxMaxAttempts:   strconv.Itoa(v.OutboxMaxAttempts),
+		KeyOutboxRetryDelay:    v.OutboxRetryDelay.String(),
+		KeySagaTimeout:         v.SagaTimeout.String(),
+		KeyConsumerConcurrency: strconv.Itoa(v.ConsumerConcurrency),
+	}
+}
+
+// Parse накладывает строки "key=value" (по одной на строку, # — комментарий) поверх base
+// и проверяет результат. Ключ, которого нет в raw, сохраняет значение из base.
+func Parse(raw string, base Values) (Values, error) {
+	next := base
+	seen := make(map[string]bool)
+	for lineNo, line := range strings.Split(raw, "\n") {
+		if idx := strings.IndexByte(line, '#'); idx >= 0 {
+			line = line[:idx]
+		}
+		line = strings.TrimSpace(line)
+		if line == "" {
+			continue
+		}
+		key, value, found := strings.Cut(line, "=")
+		key, value = strings.TrimSpace(key), strings.TrimSpace(value)
+		if !found || key == "" || value == "" {
+			return Values{}, fmt.Errorf("%w: line %d: expected key=value, got %q", ErrInvalid, lineNo+1, line)
+		}
+		if seen[key] {
+			return Values{}, fmt.Errorf("%w: line %d: duplicate key %q", ErrInvalid, lineNo+1, key)
+		}
+		seen[key] = true
+		if err := next.set(key, value); err != nil {
+			return Values{}, fmt.Errorf("%w: line %d: %w", ErrInvalid, lineNo+1, err)
+		}
+	}
+	if err := next.Validate(); err != nil {
+		return Values{}, err
+	}
+	return next, nil
+}
+
+func (v *Values) set(key, value string) error {
+	var err error
+	switch key {
+	case KeyOutboxPollInterval:
+		v.OutboxPollInterval, err = time.ParseDuration(value)
+	case KeyOutboxBatchSize:
+		v.OutboxBatchSize, err = strconv.Atoi(value)
+	case KeyOutboxMaxAttempts:
+		v.OutboxMaxAttempts, err = strconv.Atoi(value)
+	case KeyOutboxRetryDelay:
+		v.OutboxRetryDelay, err = time.ParseDuration(value)
+	case KeySagaTimeout:
+		v.SagaTimeout, err = time.ParseDuration(value)
+	case KeyConsumerConcurrency:
+		v.ConsumerConcurrency, err = strconv.Atoi(value)
+	default:
+		return fmt.Errorf("unknown key %q", key)
+	}
+	if err != nil {
+		return fmt.Errorf("%s: %w", key, err)
+	}
+	return nil
+}
+
+// Change — изменение одного параметра.
+type Change struct {
+	Key  string `json:"key"`
+	From string `json:"from"`
+	To   string `json:"to"`
+}
+
+// Diff возвращает изменённые параметры в порядке ключей.
+func Diff(prev, next Values) []Change {
+	before, after := prev.Map(), next.Map()
+	var changes []Change
+	for _, key := range keys {
+		if before[key] != after[key] {
+			changes = append(changes, Change{Key: key, From: before[key], To: after[key]})
+		}
+	}
+	return changes
+}
+
+var keys = []string{
+	KeyOutboxPollInterval,
+	KeyOutboxBatchSize,
+	KeyOutboxMaxAttempts,
+	KeyOutboxRetryDelay,
+	KeySagaTimeout,
+	KeyConsumerConcurrency,
+}
+
+type storeMetrics struct {
+	reloads *prometheus.CounterVec
+	changes *prometheus.CounterVec
+}
+
+func newStoreMetrics(registerer prometheus.Registerer) storeMetrics {
+	return storeMetrics{
+		reloads: metrics.Register(registerer, prometheus.NewCounterVec(prometheus.CounterOpts{
+			Name: "oms_tuning_reloads_total",
+			Help: "Runtime tuning reload attempts by result (applied, unchanged, invalid).",
+		}, []string{"result"})),
+		changes: metrics.Register(registerer, prometheus.NewCounterVec(prometheus.CounterOpts{
+			Name: "oms_tuning_changes_total",
+			Help: "Runtime tuning parameters changed without restart, by key.",
+		}, []string{"key"})),
+	}
+}
+
+// Option настраивает Store.
+type Option func(*Store)
+
+// WithLogger задаёт logger для записей о применённых изменениях.
+func WithLogger(logger *log.Entry) Option {
+	return func(s *Store) {
+		s.logger = logger
+	}
+}
+
+// WithRegisterer задаёт реестр метрик; nil — глобальный.
+func WithRegisterer(registerer prometheus.Registerer) Option {
+	return func(s *Store) {
+		s.registerer = registerer
+	}
+}
+
+// Store хранит текущие значения и раздаёт изменения подписчикам.
+type Store struct {
+	mu          sync.Mutex
+	current     Values
+	subscribers []func(Values)
+
+	logger     *log.Entry
+	registerer prometheus.Registerer
+	metrics    storeMetrics
+}
+
+// NewStore создаёт хранилище со стартовыми значениями; они должны проходить Validate.
+func NewStore(initial Values, options ...Option) (*Store, error) {
+	if err := initial.Validate(); err != nil {
+		return nil, err
+	}
+	s := &Store{current: initial}
+	for _, option := range options {
+		option(s)
+	}
+	if s.logger == nil {
+		s.logger = log.WithField("component", "tuning")
+	}
+	s.metrics = newStoreMetrics(s.registerer)
+	return s, nil
+}
+
+// Current возвращает текущие значения.
+func (s *Store) Current() Values {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.current
+}
+
+// Subscribe регистрирует получателя новых значений. Он вызывается только при изменениях,
+// под блокировкой Store, поэтому не должен обращаться к Store и долго работать.
+func (s *Store) Subscribe(apply func(Values)) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.subscribers = append(s.subscribers, apply)
+}
+
+// Apply проверяет next и, если что-то изменилось, раздаёт его подписчикам.
+// source попадает в лог (file, sighup, http).
+func (s *Store) Apply(next Values, source string) ([]Change, error) {
+	if err := next.Validate(); err != nil {
+		s.metrics.reloads.WithLabelValues(ResultInvalid).Inc()
+		return nil, err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	changes := Diff(s.current, next)
+	if len(changes) == 0 {
+		s.metrics.reloads.WithLabelValues(ResultUnchanged).Inc()
+		return nil, nil
+	}
+	s.current = next
+	for _, apply := range s.subscribers {
+		apply(next)
+	}
+
+	s.metrics.reloads.WithLabelValues(ResultApplied).Inc()
+	for _, change := range changes {
+		s.metrics.changes.WithLabelValues(change.Key).Inc()
+		s.logger.WithFields(log.Fields{
+			"key":    change.Key,
+			"from":   change.From,
+			"to":     change.To,
+			"source": source,
+		}).Warn("tuning parameter changed at runtime")
+	}
+	return changes, nil
+}
+
+// Reload читает значения через load и применяет их; ошибка чтения тоже учитывается как invalid,
+// текущие значения при этом не меняются.
+func (s *Store) Reload(load func() (Values, error), source string) ([]Change, error) {
+	next, err := load()
+	if err != nil {
+		s.metrics.reloads.WithLabelValues(ResultInvalid).Inc()
+		return nil, err
+	}
+	return s.Apply(next, source)
+}
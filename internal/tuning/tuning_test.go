@@ -0,0 +1,150 @@
+package tuning
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+)
+
+func baseValues() Values {
+	return Values{
+		OutboxPollInterval: time.Second,
+		OutboxBatchSize:    100,
+		OutboxMaxAttempts:  3,
+		OutboxRetryDelay:   50 * time.Millisecond,
+		SagaTimeout:        30 * time.Second,
+	}
+}
+
+func TestParse_OverlaysBase(t *testing.T) {
+	got, err := Parse("# tuned during incident\noutbox_batch_size = 500\nsaga_timeout=1m # longer PSP timeouts\n\nconsumer_concurrency=4\n", baseValues())
+	if err != nil {
+		t.Fatalf("Parse failed: %v", err)
+	}
+	want := baseValues()
+	want.OutboxBatchSize = 500
+	want.SagaTimeout = time.Minute
+	want.ConsumerConcurrency = 4
+	if got != want {
+		t.Fatalf("unexpected values:\n got %+v\nwant %+v", got, want)
+	}
+}
+
+func TestParse_Invalid(t *testing.T) {
+	for _, raw := range []string{
+		"outbox_batch_size",
+		"outbox_batch_size=",
+		"outbox_batch_size=ten",
+		"outbox_batch_size=0",
+		"outbox_batch_size=20000",
+		"outbox_poll_interval=-1s",
+		"outbox_retry_delay=2m",
+		"consumer_concurrency=-1",
+		"saga_timeout=5s\nsaga_timeout=10s",
+		"outbox_workers=4",
+	} {
+		if _, err := Parse(raw, baseValues()); !errors.Is(err, ErrInvalid) {
+			t.Fatalf("expected ErrInvalid for %q, got %v", raw, err)
+		}
+	}
+}
+
+func TestStore_ApplyNotifiesOnChange(t *testing.T) {
+	registry := prometheus.NewRegistry()
+	store, err := NewStore(baseValues(), WithRegisterer(registry))
+	if err != nil {
+		t.Fatalf("NewStore failed: %v", err)
+	}
+	var applied []Values
+	store.Subscribe(func(v Values) { applied = append(applied, v) })
+
+	next := baseValues()
+	next.OutboxPollInterval = 5 * time.Second
+	next.ConsumerConcurrency = 8
+	changes, err := store.Apply(next, "test")
+	if err != nil {
+		t.Fatalf("Apply failed: %v", err)
+	}
+	if len(changes) != 2 || changes[0] != (Change{Key: KeyOutboxPollInterval, From: "1s", To: "5s"}) || changes[1].Key != KeyConsumerConcurrency {
+		t.Fatalf("unexpected changes %+v", changes)
+	}
+	if len(applied) != 1 || applied[0] != next || store.Current() != next {
+		t.Fatalf("expected subscriber to receive new values, got %+v", applied)
+	}
+
+	if changes, err := store.Apply(next, "test"); err != nil || changes != nil {
+		t.Fatalf("expected no-op for unchanged values, got %+v, %v", changes, err)
+	}
+	invalid := next
+	invalid.OutboxBatchSize = 0
+	if _, err := store.Apply(invalid, "test"); !errors.Is(err, ErrInvalid) {
+		t.Fatalf("expected ErrInvalid, got %v", err)
+	}
+	if _, err := store.Reload(func() (Values, error) { return Values{}, errors.New("read failed") }, "test"); err == nil {
+		t.Fatal("expected reload error")
+	}
+	if len(applied) != 1 || store.Current() != next {
+		t.Fatalf("rejected values must not reach subscribers, applied=%d current=%+v", len(applied), store.Current())
+	}
+
+	for result, want := range map[string]float64{ResultApplied: 1, ResultUnchanged: 1, ResultInvalid: 2} {
+		if got := testutil.ToFloat64(store.metrics.reloads.WithLabelValues(result)); got != want {
+			t.Fatalf("reloads{result=%s}: got %v, want %v", result, got, want)
+		}
+	}
+	if got := testutil.ToFloat64(store.metrics.changes.WithLabelValues(KeyConsumerConcurrency)); got != 1 {
+		t.Fatalf("expected 1 consumer_concurrency change, got %v", got)
+	}
+}
+
+func TestHandler_GetAndReload(t *testing.T) {
+	store, err := NewStore(baseValues(), WithRegisterer(prometheus.NewRegistry()))
+	if err != nil {
+		t.Fatalf("NewStore failed: %v", err)
+	}
+	source := "outbox_batch_size=250"
+	handler := Handler(store, func() ([]Change, error) {
+		return store.Reload(func() (Values, error) { return Parse(source, baseValues()) }, "http")
+	}, nil)
+
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/admin/tuning", nil))
+	if rec.Code != http.StatusOK {
+		t.Fatalf("unexpected reload status %d: %s", rec.Code, rec.Body.String())
+	}
+	var body snapshot
+	if err := json.NewDecoder(rec.Body).Decode(&body); err != nil {
+		t.Fatalf("decode reload: %v", err)
+	}
+	if body.Values[KeyOutboxBatchSize] != "250" || len(body.Changes) != 1 {
+		t.Fatalf("unexpected reload response %+v", body)
+	}
+
+	source = "outbox_batch_size=0"
+	rec = httptest.NewRecorder()
+	handler.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/admin/tuning", nil))
+	if rec.Code != http.StatusUnprocessableEntity {
+		t.Fatalf("expected 422 for invalid source, got %d", rec.Code)
+	}
+
+	rec = httptest.NewRecorder()
+	handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/admin/tuning", nil))
+	if err := json.NewDecoder(rec.Body).Decode(&body); err != nil {
+		t.Fatalf("decode get: %v", err)
+	}
+	if body.Values[KeyOutboxBatchSize] != "250" {
+		t.Fatalf("invalid reload must keep current values, got %v", body.Values)
+	}
+
+	rec = httptest.NewRecorder()
+	handler.ServeHTTP(rec, httptest.NewRequest(http.MethodDelete, "/admin/tuning", nil))
+	if rec.Code != http.StatusMethodNotAllowed {
+		t.Fatalf("expected 405, got %d", rec.Code)
+	}
+}
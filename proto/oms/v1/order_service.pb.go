@@ -7,6 +7,7 @@
 package omsv1
 
 import (
+	_ "buf.build/gen/go/bufbuild/protovalidate/protocolbuffers/go/buf/validate"
 	_ "google.golang.org/genproto/googleapis/api/annotations"
 	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
 	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
@@ -24,34 +25,46 @@ const (
 type OrderStatus int32
 
 const (
-	OrderStatus_ORDER_STATUS_UNSPECIFIED OrderStatus = 0
-	OrderStatus_ORDER_STATUS_PENDING     OrderStatus = 1
-	OrderStatus_ORDER_STATUS_RESERVED    OrderStatus = 2
-	OrderStatus_ORDER_STATUS_PAID        OrderStatus = 3
-	OrderStatus_ORDER_STATUS_CONFIRMED   OrderStatus = 4
-	OrderStatus_ORDER_STATUS_CANCELED    OrderStatus = 5
-	OrderStatus_ORDER_STATUS_REFUNDED    OrderStatus = 6
+	OrderStatus_ORDER_STATUS_UNSPECIFIED       OrderStatus = 0
+	OrderStatus_ORDER_STATUS_PENDING           OrderStatus = 1
+	OrderStatus_ORDER_STATUS_RESERVED          OrderStatus = 2
+	OrderStatus_ORDER_STATUS_PAID              OrderStatus = 3
+	OrderStatus_ORDER_STATUS_CONFIRMED         OrderStatus = 4
+	OrderStatus_ORDER_STATUS_CANCELED          OrderStatus = 5
+	OrderStatus_ORDER_STATUS_REFUNDED          OrderStatus = 6
+	OrderStatus_ORDER_STATUS_ON_HOLD           OrderStatus = 7  // Остановлен на проверку (антифрод), сага не продвигается.
+	OrderStatus_ORDER_STATUS_BACKORDERED       OrderStatus = 8  // Нет товара на складе, заказ ждёт пополнения.
+	OrderStatus_ORDER_STATUS_AUTHORIZED        OrderStatus = 9  // Сумма заблокирована у PSP, заказ ждёт CaptureOrder.
+	OrderStatus_ORDER_STATUS_AWAITING_APPROVAL OrderStatus = 10 // Заказ B2B-клиента ждёт ApproveOrder/RejectOrder.
 )
 
 // Enum value maps for OrderStatus.
 var (
 	OrderStatus_name = map[int32]string{
-		0: "ORDER_STATUS_UNSPECIFIED",
-		1: "ORDER_STATUS_PENDING",
-		2: "ORDER_STATUS_RESERVED",
-		3: "ORDER_STATUS_PAID",
-		4: "ORDER_STATUS_CONFIRMED",
-		5: "ORDER_STATUS_CANCELED",
-		6: "ORDER_STATUS_REFUNDED",
+		0:  "ORDER_STATUS_UNSPECIFIED",
+		1:  "ORDER_STATUS_PENDING",
+		2:  "ORDER_STATUS_RESERVED",
+		3:  "ORDER_STATUS_PAID",
+		4:  "ORDER_STATUS_CONFIRMED",
+		5:  "ORDER_STATUS_CANCELED",
+		6:  "ORDER_STATUS_REFUNDED",
+		7:  "ORDER_STATUS_ON_HOLD",
+		8:  "ORDER_STATUS_BACKORDERED",
+		9:  "ORDER_STATUS_AUTHORIZED",
+		10: "ORDER_STATUS_AWAITING_APPROVAL",
 	}
 	OrderStatus_value = map[string]int32{
-		"ORDER_STATUS_UNSPECIFIED": 0,
-		"ORDER_STATUS_PENDING":     1,
-		"ORDER_STATUS_RESERVED":    2,
-		"ORDER_STATUS_PAID":        3,
-		"ORDER_STATUS_CONFIRMED":   4,
-		"ORDER_STATUS_CANCELED":    5,
-		"ORDER_STATUS_REFUNDED":    6,
+		"ORDER_STATUS_UNSPECIFIED":       0,
+		"ORDER_STATUS_PENDING":           1,
+		"ORDER_STATUS_RESERVED":          2,
+		"ORDER_STATUS_PAID":              3,
+		"ORDER_STATUS_CONFIRMED":         4,
+		"ORDER_STATUS_CANCELED":          5,
+		"ORDER_STATUS_REFUNDED":          6,
+		"ORDER_STATUS_ON_HOLD":           7,
+		"ORDER_STATUS_BACKORDERED":       8,
+		"ORDER_STATUS_AUTHORIZED":        9,
+		"ORDER_STATUS_AWAITING_APPROVAL": 10,
 	}
 )
 
@@ -82,6 +95,102 @@ func (OrderStatus) EnumDescriptor() ([]byte, []int) {
 	return file_proto_oms_v1_order_service_proto_rawDescGZIP(), []int{0}
 }
 
+type AdjustmentType int32
+
+const (
+	AdjustmentType_ADJUSTMENT_TYPE_UNSPECIFIED AdjustmentType = 0
+	AdjustmentType_ADJUSTMENT_TYPE_DISCOUNT    AdjustmentType = 1
+	AdjustmentType_ADJUSTMENT_TYPE_TAX         AdjustmentType = 2
+)
+
+// Enum value maps for AdjustmentType.
+var (
+	AdjustmentType_name = map[int32]string{
+		0: "ADJUSTMENT_TYPE_UNSPECIFIED",
+		1: "ADJUSTMENT_TYPE_DISCOUNT",
+		2: "ADJUSTMENT_TYPE_TAX",
+	}
+	AdjustmentType_value = map[string]int32{
+		"ADJUSTMENT_TYPE_UNSPECIFIED": 0,
+		"ADJUSTMENT_TYPE_DISCOUNT":    1,
+		"ADJUSTMENT_TYPE_TAX":         2,
+	}
+)
+
+func (x AdjustmentType) Enum() *AdjustmentType {
+	p := new(AdjustmentType)
+	*p = x
+	return p
+}
+
+func (x AdjustmentType) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (AdjustmentType) Descriptor() protoreflect.EnumDescriptor {
+	return file_proto_oms_v1_order_service_proto_enumTypes[1].Descriptor()
+}
+
+func (AdjustmentType) Type() protoreflect.EnumType {
+	return &file_proto_oms_v1_order_service_proto_enumTypes[1]
+}
+
+func (x AdjustmentType) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use AdjustmentType.Descriptor instead.
+func (AdjustmentType) EnumDescriptor() ([]byte, []int) {
+	return file_proto_oms_v1_order_service_proto_rawDescGZIP(), []int{1}
+}
+
+// Правило округления процентных строк до минимальной единицы валюты.
+type RoundingMode int32
+
+const (
+	RoundingMode_ROUNDING_MODE_UNSPECIFIED RoundingMode = 0 // Округления не было: заказ без скидок и налогов.
+	RoundingMode_ROUNDING_MODE_HALF_UP     RoundingMode = 1
+)
+
+// Enum value maps for RoundingMode.
+var (
+	RoundingMode_name = map[int32]string{
+		0: "ROUNDING_MODE_UNSPECIFIED",
+		1: "ROUNDING_MODE_HALF_UP",
+	}
+	RoundingMode_value = map[string]int32{
+		"ROUNDING_MODE_UNSPECIFIED": 0,
+		"ROUNDING_MODE_HALF_UP":     1,
+	}
+)
+
+func (x RoundingMode) Enum() *RoundingMode {
+	p := new(RoundingMode)
+	*p = x
+	return p
+}
+
+func (x RoundingMode) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (RoundingMode) Descriptor() protoreflect.EnumDescriptor {
+	return file_proto_oms_v1_order_service_proto_enumTypes[2].Descriptor()
+}
+
+func (RoundingMode) Type() protoreflect.EnumType {
+	return &file_proto_oms_v1_order_service_proto_enumTypes[2]
+}
+
+func (x RoundingMode) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use RoundingMode.Descriptor instead.
+func (RoundingMode) EnumDescriptor() ([]byte, []int) {
+	return file_proto_oms_v1_order_service_proto_rawDescGZIP(), []int{2}
+}
+
 type CourierVehicleType int32
 
 const (
@@ -118,11 +227,11 @@ func (x CourierVehicleType) String() string {
 }
 
 func (CourierVehicleType) Descriptor() protoreflect.EnumDescriptor {
-	return file_proto_oms_v1_order_service_proto_enumTypes[1].Descriptor()
+	return file_proto_oms_v1_order_service_proto_enumTypes[3].Descriptor()
 }
 
 func (CourierVehicleType) Type() protoreflect.EnumType {
-	return &file_proto_oms_v1_order_service_proto_enumTypes[1]
+	return &file_proto_oms_v1_order_service_proto_enumTypes[3]
 }
 
 func (x CourierVehicleType) Number() protoreflect.EnumNumber {
@@ -131,7 +240,7 @@ func (x CourierVehicleType) Number() protoreflect.EnumNumber {
 
 // Deprecated: Use CourierVehicleType.Descriptor instead.
 func (CourierVehicleType) EnumDescriptor() ([]byte, []int) {
-	return file_proto_oms_v1_order_service_proto_rawDescGZIP(), []int{1}
+	return file_proto_oms_v1_order_service_proto_rawDescGZIP(), []int{3}
 }
 
 type CourierSlotStatus int32
@@ -173,11 +282,11 @@ func (x CourierSlotStatus) String() string {
 }
 
 func (CourierSlotStatus) Descriptor() protoreflect.EnumDescriptor {
-	return file_proto_oms_v1_order_service_proto_enumTypes[2].Descriptor()
+	return file_proto_oms_v1_order_service_proto_enumTypes[4].Descriptor()
 }
 
 func (CourierSlotStatus) Type() protoreflect.EnumType {
-	return &file_proto_oms_v1_order_service_proto_enumTypes[2]
+	return &file_proto_oms_v1_order_service_proto_enumTypes[4]
 }
 
 func (x CourierSlotStatus) Number() protoreflect.EnumNumber {
@@ -186,7 +295,7 @@ func (x CourierSlotStatus) Number() protoreflect.EnumNumber {
 
 // Deprecated: Use CourierSlotStatus.Descriptor instead.
 func (CourierSlotStatus) EnumDescriptor() ([]byte, []int) {
-	return file_proto_oms_v1_order_service_proto_rawDescGZIP(), []int{2}
+	return file_proto_oms_v1_order_service_proto_rawDescGZIP(), []int{4}
 }
 
 type CourierRatingTag int32
@@ -237,11 +346,11 @@ func (x CourierRatingTag) String() string {
 }
 
 func (CourierRatingTag) Descriptor() protoreflect.EnumDescriptor {
-	return file_proto_oms_v1_order_service_proto_enumTypes[3].Descriptor()
+	return file_proto_oms_v1_order_service_proto_enumTypes[5].Descriptor()
 }
 
 func (CourierRatingTag) Type() protoreflect.EnumType {
-	return &file_proto_oms_v1_order_service_proto_enumTypes[3]
+	return &file_proto_oms_v1_order_service_proto_enumTypes[5]
 }
 
 func (x CourierRatingTag) Number() protoreflect.EnumNumber {
@@ -250,7 +359,117 @@ func (x CourierRatingTag) Number() protoreflect.EnumNumber {
 
 // Deprecated: Use CourierRatingTag.Descriptor instead.
 func (CourierRatingTag) EnumDescriptor() ([]byte, []int) {
-	return file_proto_oms_v1_order_service_proto_rawDescGZIP(), []int{3}
+	return file_proto_oms_v1_order_service_proto_rawDescGZIP(), []int{5}
+}
+
+type ScheduledCancelStatus int32
+
+const (
+	ScheduledCancelStatus_SCHEDULED_CANCEL_STATUS_UNSPECIFIED ScheduledCancelStatus = 0
+	ScheduledCancelStatus_SCHEDULED_CANCEL_STATUS_PENDING     ScheduledCancelStatus = 1 // Срок не наступил.
+	ScheduledCancelStatus_SCHEDULED_CANCEL_STATUS_EXECUTED    ScheduledCancelStatus = 2 // К сроку запущена отмена заказа.
+	ScheduledCancelStatus_SCHEDULED_CANCEL_STATUS_SKIPPED     ScheduledCancelStatus = 3 // К сроку заказ уже подтверждён или отменён.
+	ScheduledCancelStatus_SCHEDULED_CANCEL_STATUS_CANCELED    ScheduledCancelStatus = 4 // Задачу сняли до срока.
+)
+
+// Enum value maps for ScheduledCancelStatus.
+var (
+	ScheduledCancelStatus_name = map[int32]string{
+		0: "SCHEDULED_CANCEL_STATUS_UNSPECIFIED",
+		1: "SCHEDULED_CANCEL_STATUS_PENDING",
+		2: "SCHEDULED_CANCEL_STATUS_EXECUTED",
+		3: "SCHEDULED_CANCEL_STATUS_SKIPPED",
+		4: "SCHEDULED_CANCEL_STATUS_CANCELED",
+	}
+	ScheduledCancelStatus_value = map[string]int32{
+		"SCHEDULED_CANCEL_STATUS_UNSPECIFIED": 0,
+		"SCHEDULED_CANCEL_STATUS_PENDING":     1,
+		"SCHEDULED_CANCEL_STATUS_EXECUTED":    2,
+		"SCHEDULED_CANCEL_STATUS_SKIPPED":     3,
+		"SCHEDULED_CANCEL_STATUS_CANCELED":    4,
+	}
+)
+
+func (x ScheduledCancelStatus) Enum() *ScheduledCancelStatus {
+	p := new(ScheduledCancelStatus)
+	*p = x
+	return p
+}
+
+func (x ScheduledCancelStatus) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (ScheduledCancelStatus) Descriptor() protoreflect.EnumDescriptor {
+	return file_proto_oms_v1_order_service_proto_enumTypes[6].Descriptor()
+}
+
+func (ScheduledCancelStatus) Type() protoreflect.EnumType {
+	return &file_proto_oms_v1_order_service_proto_enumTypes[6]
+}
+
+func (x ScheduledCancelStatus) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use ScheduledCancelStatus.Descriptor instead.
+func (ScheduledCancelStatus) EnumDescriptor() ([]byte, []int) {
+	return file_proto_oms_v1_order_service_proto_rawDescGZIP(), []int{6}
+}
+
+type ReturnStatus int32
+
+const (
+	ReturnStatus_RETURN_STATUS_UNSPECIFIED ReturnStatus = 0
+	ReturnStatus_RETURN_STATUS_REQUESTED   ReturnStatus = 1 // Возврат заявлен, ждёт одобрения.
+	ReturnStatus_RETURN_STATUS_APPROVED    ReturnStatus = 2 // Одобрен, товар ждут на складе.
+	ReturnStatus_RETURN_STATUS_RECEIVED    ReturnStatus = 3 // Товар получен и возвращён в остатки.
+	ReturnStatus_RETURN_STATUS_REFUNDED    ReturnStatus = 4 // Деньги за позиции возвращены покупателю.
+)
+
+// Enum value maps for ReturnStatus.
+var (
+	ReturnStatus_name = map[int32]string{
+		0: "RETURN_STATUS_UNSPECIFIED",
+		1: "RETURN_STATUS_REQUESTED",
+		2: "RETURN_STATUS_APPROVED",
+		3: "RETURN_STATUS_RECEIVED",
+		4: "RETURN_STATUS_REFUNDED",
+	}
+	ReturnStatus_value = map[string]int32{
+		"RETURN_STATUS_UNSPECIFIED": 0,
+		"RETURN_STATUS_REQUESTED":   1,
+		"RETURN_STATUS_APPROVED":    2,
+		"RETURN_STATUS_RECEIVED":    3,
+		"RETURN_STATUS_REFUNDED":    4,
+	}
+)
+
+func (x ReturnStatus) Enum() *ReturnStatus {
+	p := new(ReturnStatus)
+	*p = x
+	return p
+}
+
+func (x ReturnStatus) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (ReturnStatus) Descriptor() protoreflect.EnumDescriptor {
+	return file_proto_oms_v1_order_service_proto_enumTypes[7].Descriptor()
+}
+
+func (ReturnStatus) Type() protoreflect.EnumType {
+	return &file_proto_oms_v1_order_service_proto_enumTypes[7]
+}
+
+func (x ReturnStatus) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use ReturnStatus.Descriptor instead.
+func (ReturnStatus) EnumDescriptor() ([]byte, []int) {
+	return file_proto_oms_v1_order_service_proto_rawDescGZIP(), []int{7}
 }
 
 type Money struct {
@@ -318,6 +537,10 @@ type OrderItem struct {
 	Sku   string `protobuf:"bytes,1,opt,name=sku,proto3" json:"sku,omitempty"`
 	Qty   int32  `protobuf:"varint,2,opt,name=qty,proto3" json:"qty,omitempty"`
 	Price *Money `protobuf:"bytes,3,opt,name=price,proto3" json:"price,omitempty"` // Цена за единицу, также в минимальных единицах.
+	// Идентификатор позиции для item-level операций (возврат позиции, обновление исполнения).
+	// Назначается сервером; в CreateOrder игнорируется.
+	Id            string `protobuf:"bytes,4,opt,name=id,proto3" json:"id,omitempty"`
+	CreatedAtUnix int64  `protobuf:"varint,5,opt,name=created_at_unix,json=createdAtUnix,proto3" json:"created_at_unix,omitempty"` // Момент добавления позиции в unix-времени; только в ответах.
 }
 
 func (x *OrderItem) Reset() {
@@ -373,18 +596,35 @@ func (x *OrderItem) GetPrice() *Money {
 	return nil
 }
 
+func (x *OrderItem) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *OrderItem) GetCreatedAtUnix() int64 {
+	if x != nil {
+		return x.CreatedAtUnix
+	}
+	return 0
+}
+
 type Order struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	Id         string       `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
-	CustomerId string       `protobuf:"bytes,2,opt,name=customer_id,json=customerId,proto3" json:"customer_id,omitempty"`
-	Status     OrderStatus  `protobuf:"varint,3,opt,name=status,proto3,enum=oms.v1.OrderStatus" json:"status,omitempty"`
-	Amount     *Money       `protobuf:"bytes,4,opt,name=amount,proto3" json:"amount,omitempty"` // Общая сумма заказа.
-	Items      []*OrderItem `protobuf:"bytes,5,rep,name=items,proto3" json:"items,omitempty"`
-	Version    int64        `protobuf:"varint,6,opt,name=version,proto3" json:"version,omitempty"`  // Optimistic locking.
-	Currency   string       `protobuf:"bytes,7,opt,name=currency,proto3" json:"currency,omitempty"` // Дублирование для удобства (чтение без Money).
+	Id         string        `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	CustomerId string        `protobuf:"bytes,2,opt,name=customer_id,json=customerId,proto3" json:"customer_id,omitempty"`
+	Status     OrderStatus   `protobuf:"varint,3,opt,name=status,proto3,enum=oms.v1.OrderStatus" json:"status,omitempty"`
+	Amount     *Money        `protobuf:"bytes,4,opt,name=amount,proto3" json:"amount,omitempty"` // Общая сумма заказа.
+	Items      []*OrderItem  `protobuf:"bytes,5,rep,name=items,proto3" json:"items,omitempty"`
+	Version    int64         `protobuf:"varint,6,opt,name=version,proto3" json:"version,omitempty"`                        // Optimistic locking.
+	Currency   string        `protobuf:"bytes,7,opt,name=currency,proto3" json:"currency,omitempty"`                       // Дублирование для удобства (чтение без Money).
+	HoldReason string        `protobuf:"bytes,8,opt,name=hold_reason,json=holdReason,proto3" json:"hold_reason,omitempty"` // Причина hold, заполнена только в статусе ON_HOLD.
+	Amounts    *OrderAmounts `protobuf:"bytes,9,opt,name=amounts,proto3" json:"amounts,omitempty"`                         // Разбивка суммы: позиции, скидки, налоги, итог.
+	TestMode   bool          `protobuf:"varint,10,opt,name=test_mode,json=testMode,proto3" json:"test_mode,omitempty"`     // Sandbox-заказ: склад и оплата не затрагиваются.
 }
 
 func (x *Order) Reset() {
@@ -468,18 +708,45 @@ func (x *Order) GetCurrency() string {
 	return ""
 }
 
-type TimelineEvent struct {
+func (x *Order) GetHoldReason() string {
+	if x != nil {
+		return x.HoldReason
+	}
+	return ""
+}
+
+func (x *Order) GetAmounts() *OrderAmounts {
+	if x != nil {
+		return x.Amounts
+	}
+	return nil
+}
+
+func (x *Order) GetTestMode() bool {
+	if x != nil {
+		return x.TestMode
+	}
+	return false
+}
+
+// Строка скидки или налога: задаётся либо fixed, либо percent.
+type PriceAdjustment struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	Type     string `protobuf:"bytes,1,opt,name=type,proto3" json:"type,omitempty"`
-	Reason   string `protobuf:"bytes,2,opt,name=reason,proto3" json:"reason,omitempty"`
-	UnixTime int64  `protobuf:"varint,3,opt,name=unix_time,json=unixTime,proto3" json:"unix_time,omitempty"` // Момент события в unix-времени.
+	Type    AdjustmentType `protobuf:"varint,1,opt,name=type,proto3,enum=oms.v1.AdjustmentType" json:"type,omitempty"`
+	Code    string         `protobuf:"bytes,2,opt,name=code,proto3" json:"code,omitempty"`       // Метка строки (промокод, вид налога).
+	Fixed   *Money         `protobuf:"bytes,3,opt,name=fixed,proto3" json:"fixed,omitempty"`     // Фиксированная сумма в валюте заказа.
+	Percent string         `protobuf:"bytes,4,opt,name=percent,proto3" json:"percent,omitempty"` // Десятичный процент, до 4 знаков после точки ("7.5").
+	Applied *Money         `protobuf:"bytes,5,opt,name=applied,proto3" json:"applied,omitempty"` // Рассчитанная сервером сумма строки (только в ответах).
+	// applied минус точное значение строки в минимальных единицах, десятичная строка со знаком
+	// ("0.2", "-0.45"); у фиксированных строк "0" (только в ответах).
+	RoundingDelta string `protobuf:"bytes,6,opt,name=rounding_delta,json=roundingDelta,proto3" json:"rounding_delta,omitempty"`
 }
 
-func (x *TimelineEvent) Reset() {
-	*x = TimelineEvent{}
+func (x *PriceAdjustment) Reset() {
+	*x = PriceAdjustment{}
 	if protoimpl.UnsafeEnabled {
 		mi := &file_proto_oms_v1_order_service_proto_msgTypes[3]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -487,13 +754,13 @@ func (x *TimelineEvent) Reset() {
 	}
 }
 
-func (x *TimelineEvent) String() string {
+func (x *PriceAdjustment) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*TimelineEvent) ProtoMessage() {}
+func (*PriceAdjustment) ProtoMessage() {}
 
-func (x *TimelineEvent) ProtoReflect() protoreflect.Message {
+func (x *PriceAdjustment) ProtoReflect() protoreflect.Message {
 	mi := &file_proto_oms_v1_order_service_proto_msgTypes[3]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -505,43 +772,72 @@ func (x *TimelineEvent) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use TimelineEvent.ProtoReflect.Descriptor instead.
-func (*TimelineEvent) Descriptor() ([]byte, []int) {
+// Deprecated: Use PriceAdjustment.ProtoReflect.Descriptor instead.
+func (*PriceAdjustment) Descriptor() ([]byte, []int) {
 	return file_proto_oms_v1_order_service_proto_rawDescGZIP(), []int{3}
 }
 
-func (x *TimelineEvent) GetType() string {
+func (x *PriceAdjustment) GetType() AdjustmentType {
 	if x != nil {
 		return x.Type
 	}
+	return AdjustmentType_ADJUSTMENT_TYPE_UNSPECIFIED
+}
+
+func (x *PriceAdjustment) GetCode() string {
+	if x != nil {
+		return x.Code
+	}
 	return ""
 }
 
-func (x *TimelineEvent) GetReason() string {
+func (x *PriceAdjustment) GetFixed() *Money {
 	if x != nil {
-		return x.Reason
+		return x.Fixed
+	}
+	return nil
+}
+
+func (x *PriceAdjustment) GetPercent() string {
+	if x != nil {
+		return x.Percent
 	}
 	return ""
 }
 
-func (x *TimelineEvent) GetUnixTime() int64 {
+func (x *PriceAdjustment) GetApplied() *Money {
 	if x != nil {
-		return x.UnixTime
+		return x.Applied
 	}
-	return 0
+	return nil
 }
 
-type CourierZoneInput struct {
+func (x *PriceAdjustment) GetRoundingDelta() string {
+	if x != nil {
+		return x.RoundingDelta
+	}
+	return ""
+}
+
+// Разбивка итоговой суммы заказа; total совпадает с Order.amount.
+type OrderAmounts struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	ZoneId    string `protobuf:"bytes,1,opt,name=zone_id,json=zoneId,proto3" json:"zone_id,omitempty"`
-	IsPrimary bool   `protobuf:"varint,2,opt,name=is_primary,json=isPrimary,proto3" json:"is_primary,omitempty"`
+	Subtotal     *Money             `protobuf:"bytes,1,opt,name=subtotal,proto3" json:"subtotal,omitempty"`
+	Discount     *Money             `protobuf:"bytes,2,opt,name=discount,proto3" json:"discount,omitempty"`
+	Tax          *Money             `protobuf:"bytes,3,opt,name=tax,proto3" json:"tax,omitempty"`
+	Total        *Money             `protobuf:"bytes,4,opt,name=total,proto3" json:"total,omitempty"`
+	Adjustments  []*PriceAdjustment `protobuf:"bytes,5,rep,name=adjustments,proto3" json:"adjustments,omitempty"`
+	RoundingMode RoundingMode       `protobuf:"varint,6,opt,name=rounding_mode,json=roundingMode,proto3,enum=oms.v1.RoundingMode" json:"rounding_mode,omitempty"`
+	// Суммарное влияние округления на total в минимальных единицах: дельты налогов со знаком плюс,
+	// скидок — со знаком минус.
+	RoundingDelta string `protobuf:"bytes,7,opt,name=rounding_delta,json=roundingDelta,proto3" json:"rounding_delta,omitempty"`
 }
 
-func (x *CourierZoneInput) Reset() {
-	*x = CourierZoneInput{}
+func (x *OrderAmounts) Reset() {
+	*x = OrderAmounts{}
 	if protoimpl.UnsafeEnabled {
 		mi := &file_proto_oms_v1_order_service_proto_msgTypes[4]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -549,13 +845,13 @@ func (x *CourierZoneInput) Reset() {
 	}
 }
 
-func (x *CourierZoneInput) String() string {
+func (x *OrderAmounts) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*CourierZoneInput) ProtoMessage() {}
+func (*OrderAmounts) ProtoMessage() {}
 
-func (x *CourierZoneInput) ProtoReflect() protoreflect.Message {
+func (x *OrderAmounts) ProtoReflect() protoreflect.Message {
 	mi := &file_proto_oms_v1_order_service_proto_msgTypes[4]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -567,37 +863,72 @@ func (x *CourierZoneInput) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use CourierZoneInput.ProtoReflect.Descriptor instead.
-func (*CourierZoneInput) Descriptor() ([]byte, []int) {
+// Deprecated: Use OrderAmounts.ProtoReflect.Descriptor instead.
+func (*OrderAmounts) Descriptor() ([]byte, []int) {
 	return file_proto_oms_v1_order_service_proto_rawDescGZIP(), []int{4}
 }
 
-func (x *CourierZoneInput) GetZoneId() string {
+func (x *OrderAmounts) GetSubtotal() *Money {
 	if x != nil {
-		return x.ZoneId
+		return x.Subtotal
 	}
-	return ""
+	return nil
 }
 
-func (x *CourierZoneInput) GetIsPrimary() bool {
+func (x *OrderAmounts) GetDiscount() *Money {
 	if x != nil {
-		return x.IsPrimary
+		return x.Discount
 	}
-	return false
+	return nil
 }
 
-type CourierZone struct {
+func (x *OrderAmounts) GetTax() *Money {
+	if x != nil {
+		return x.Tax
+	}
+	return nil
+}
+
+func (x *OrderAmounts) GetTotal() *Money {
+	if x != nil {
+		return x.Total
+	}
+	return nil
+}
+
+func (x *OrderAmounts) GetAdjustments() []*PriceAdjustment {
+	if x != nil {
+		return x.Adjustments
+	}
+	return nil
+}
+
+func (x *OrderAmounts) GetRoundingMode() RoundingMode {
+	if x != nil {
+		return x.RoundingMode
+	}
+	return RoundingMode_ROUNDING_MODE_UNSPECIFIED
+}
+
+func (x *OrderAmounts) GetRoundingDelta() string {
+	if x != nil {
+		return x.RoundingDelta
+	}
+	return ""
+}
+
+type TimelineEvent struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	ZoneId         string `protobuf:"bytes,1,opt,name=zone_id,json=zoneId,proto3" json:"zone_id,omitempty"`
-	IsPrimary      bool   `protobuf:"varint,2,opt,name=is_primary,json=isPrimary,proto3" json:"is_primary,omitempty"`
-	AssignedAtUnix int64  `protobuf:"varint,3,opt,name=assigned_at_unix,json=assignedAtUnix,proto3" json:"assigned_at_unix,omitempty"`
+	Type     string `protobuf:"bytes,1,opt,name=type,proto3" json:"type,omitempty"`
+	Reason   string `protobuf:"bytes,2,opt,name=reason,proto3" json:"reason,omitempty"`
+	UnixTime int64  `protobuf:"varint,3,opt,name=unix_time,json=unixTime,proto3" json:"unix_time,omitempty"` // Момент события в unix-времени.
 }
 
-func (x *CourierZone) Reset() {
-	*x = CourierZone{}
+func (x *TimelineEvent) Reset() {
+	*x = TimelineEvent{}
 	if protoimpl.UnsafeEnabled {
 		mi := &file_proto_oms_v1_order_service_proto_msgTypes[5]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -605,13 +936,13 @@ func (x *CourierZone) Reset() {
 	}
 }
 
-func (x *CourierZone) String() string {
+func (x *TimelineEvent) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*CourierZone) ProtoMessage() {}
+func (*TimelineEvent) ProtoMessage() {}
 
-func (x *CourierZone) ProtoReflect() protoreflect.Message {
+func (x *TimelineEvent) ProtoReflect() protoreflect.Message {
 	mi := &file_proto_oms_v1_order_service_proto_msgTypes[5]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -623,28 +954,146 @@ func (x *CourierZone) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use CourierZone.ProtoReflect.Descriptor instead.
-func (*CourierZone) Descriptor() ([]byte, []int) {
+// Deprecated: Use TimelineEvent.ProtoReflect.Descriptor instead.
+func (*TimelineEvent) Descriptor() ([]byte, []int) {
 	return file_proto_oms_v1_order_service_proto_rawDescGZIP(), []int{5}
 }
 
-func (x *CourierZone) GetZoneId() string {
+func (x *TimelineEvent) GetType() string {
 	if x != nil {
-		return x.ZoneId
+		return x.Type
 	}
 	return ""
 }
 
-func (x *CourierZone) GetIsPrimary() bool {
+func (x *TimelineEvent) GetReason() string {
 	if x != nil {
-		return x.IsPrimary
+		return x.Reason
 	}
-	return false
+	return ""
 }
 
-func (x *CourierZone) GetAssignedAtUnix() int64 {
+func (x *TimelineEvent) GetUnixTime() int64 {
 	if x != nil {
-		return x.AssignedAtUnix
+		return x.UnixTime
+	}
+	return 0
+}
+
+type CourierZoneInput struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	ZoneId    string `protobuf:"bytes,1,opt,name=zone_id,json=zoneId,proto3" json:"zone_id,omitempty"`
+	IsPrimary bool   `protobuf:"varint,2,opt,name=is_primary,json=isPrimary,proto3" json:"is_primary,omitempty"`
+}
+
+func (x *CourierZoneInput) Reset() {
+	*x = CourierZoneInput{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_proto_oms_v1_order_service_proto_msgTypes[6]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *CourierZoneInput) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CourierZoneInput) ProtoMessage() {}
+
+func (x *CourierZoneInput) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_oms_v1_order_service_proto_msgTypes[6]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CourierZoneInput.ProtoReflect.Descriptor instead.
+func (*CourierZoneInput) Descriptor() ([]byte, []int) {
+	return file_proto_oms_v1_order_service_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *CourierZoneInput) GetZoneId() string {
+	if x != nil {
+		return x.ZoneId
+	}
+	return ""
+}
+
+func (x *CourierZoneInput) GetIsPrimary() bool {
+	if x != nil {
+		return x.IsPrimary
+	}
+	return false
+}
+
+type CourierZone struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	ZoneId         string `protobuf:"bytes,1,opt,name=zone_id,json=zoneId,proto3" json:"zone_id,omitempty"`
+	IsPrimary      bool   `protobuf:"varint,2,opt,name=is_primary,json=isPrimary,proto3" json:"is_primary,omitempty"`
+	AssignedAtUnix int64  `protobuf:"varint,3,opt,name=assigned_at_unix,json=assignedAtUnix,proto3" json:"assigned_at_unix,omitempty"`
+}
+
+func (x *CourierZone) Reset() {
+	*x = CourierZone{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_proto_oms_v1_order_service_proto_msgTypes[7]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *CourierZone) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CourierZone) ProtoMessage() {}
+
+func (x *CourierZone) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_oms_v1_order_service_proto_msgTypes[7]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CourierZone.ProtoReflect.Descriptor instead.
+func (*CourierZone) Descriptor() ([]byte, []int) {
+	return file_proto_oms_v1_order_service_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *CourierZone) GetZoneId() string {
+	if x != nil {
+		return x.ZoneId
+	}
+	return ""
+}
+
+func (x *CourierZone) GetIsPrimary() bool {
+	if x != nil {
+		return x.IsPrimary
+	}
+	return false
+}
+
+func (x *CourierZone) GetAssignedAtUnix() int64 {
+	if x != nil {
+		return x.AssignedAtUnix
 	}
 	return 0
 }
@@ -666,7 +1115,7 @@ type Courier struct {
 func (x *Courier) Reset() {
 	*x = Courier{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_proto_oms_v1_order_service_proto_msgTypes[6]
+		mi := &file_proto_oms_v1_order_service_proto_msgTypes[8]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -679,7 +1128,7 @@ func (x *Courier) String() string {
 func (*Courier) ProtoMessage() {}
 
 func (x *Courier) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_oms_v1_order_service_proto_msgTypes[6]
+	mi := &file_proto_oms_v1_order_service_proto_msgTypes[8]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -692,7 +1141,7 @@ func (x *Courier) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use Courier.ProtoReflect.Descriptor instead.
 func (*Courier) Descriptor() ([]byte, []int) {
-	return file_proto_oms_v1_order_service_proto_rawDescGZIP(), []int{6}
+	return file_proto_oms_v1_order_service_proto_rawDescGZIP(), []int{8}
 }
 
 func (x *Courier) GetId() string {
@@ -760,7 +1209,7 @@ type CourierSlot struct {
 func (x *CourierSlot) Reset() {
 	*x = CourierSlot{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_proto_oms_v1_order_service_proto_msgTypes[7]
+		mi := &file_proto_oms_v1_order_service_proto_msgTypes[9]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -773,7 +1222,7 @@ func (x *CourierSlot) String() string {
 func (*CourierSlot) ProtoMessage() {}
 
 func (x *CourierSlot) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_oms_v1_order_service_proto_msgTypes[7]
+	mi := &file_proto_oms_v1_order_service_proto_msgTypes[9]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -786,7 +1235,7 @@ func (x *CourierSlot) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use CourierSlot.ProtoReflect.Descriptor instead.
 func (*CourierSlot) Descriptor() ([]byte, []int) {
-	return file_proto_oms_v1_order_service_proto_rawDescGZIP(), []int{7}
+	return file_proto_oms_v1_order_service_proto_rawDescGZIP(), []int{9}
 }
 
 func (x *CourierSlot) GetId() string {
@@ -846,7 +1295,7 @@ type CourierVehicleCapability struct {
 func (x *CourierVehicleCapability) Reset() {
 	*x = CourierVehicleCapability{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_proto_oms_v1_order_service_proto_msgTypes[8]
+		mi := &file_proto_oms_v1_order_service_proto_msgTypes[10]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -859,7 +1308,7 @@ func (x *CourierVehicleCapability) String() string {
 func (*CourierVehicleCapability) ProtoMessage() {}
 
 func (x *CourierVehicleCapability) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_oms_v1_order_service_proto_msgTypes[8]
+	mi := &file_proto_oms_v1_order_service_proto_msgTypes[10]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -872,7 +1321,7 @@ func (x *CourierVehicleCapability) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use CourierVehicleCapability.ProtoReflect.Descriptor instead.
 func (*CourierVehicleCapability) Descriptor() ([]byte, []int) {
-	return file_proto_oms_v1_order_service_proto_rawDescGZIP(), []int{8}
+	return file_proto_oms_v1_order_service_proto_rawDescGZIP(), []int{10}
 }
 
 func (x *CourierVehicleCapability) GetVehicleType() CourierVehicleType {
@@ -911,20 +1360,26 @@ func (x *CourierVehicleCapability) GetUpdatedAtUnix() int64 {
 }
 
 // ---- Requests & Responses ----
+// Правила buf.validate проверяет UnaryValidationInterceptor до вызова обработчика;
+// ID ограничены так же, как domain.ParseOrderID/ParseCustomerID.
 type CreateOrderRequest struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	CustomerId string       `protobuf:"bytes,1,opt,name=customer_id,json=customerId,proto3" json:"customer_id,omitempty"`
-	Items      []*OrderItem `protobuf:"bytes,2,rep,name=items,proto3" json:"items,omitempty"`
-	Currency   string       `protobuf:"bytes,3,opt,name=currency,proto3" json:"currency,omitempty"`
+	CustomerId  string             `protobuf:"bytes,1,opt,name=customer_id,json=customerId,proto3" json:"customer_id,omitempty"`
+	Items       []*OrderItem       `protobuf:"bytes,2,rep,name=items,proto3" json:"items,omitempty"`
+	Currency    string             `protobuf:"bytes,3,opt,name=currency,proto3" json:"currency,omitempty"`
+	Adjustments []*PriceAdjustment `protobuf:"bytes,4,rep,name=adjustments,proto3" json:"adjustments,omitempty"` // Необязательные скидки и налоги.
+	// Sandbox-заказ для проверки интеграции партнёра: сага резервирует и списывает через
+	// заглушки, реальные склад и деньги не затрагиваются. Флаг сохраняется в заказе навсегда.
+	TestMode bool `protobuf:"varint,5,opt,name=test_mode,json=testMode,proto3" json:"test_mode,omitempty"`
 }
 
 func (x *CreateOrderRequest) Reset() {
 	*x = CreateOrderRequest{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_proto_oms_v1_order_service_proto_msgTypes[9]
+		mi := &file_proto_oms_v1_order_service_proto_msgTypes[11]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -937,7 +1392,7 @@ func (x *CreateOrderRequest) String() string {
 func (*CreateOrderRequest) ProtoMessage() {}
 
 func (x *CreateOrderRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_oms_v1_order_service_proto_msgTypes[9]
+	mi := &file_proto_oms_v1_order_service_proto_msgTypes[11]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -950,7 +1405,7 @@ func (x *CreateOrderRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use CreateOrderRequest.ProtoReflect.Descriptor instead.
 func (*CreateOrderRequest) Descriptor() ([]byte, []int) {
-	return file_proto_oms_v1_order_service_proto_rawDescGZIP(), []int{9}
+	return file_proto_oms_v1_order_service_proto_rawDescGZIP(), []int{11}
 }
 
 func (x *CreateOrderRequest) GetCustomerId() string {
@@ -974,6 +1429,20 @@ func (x *CreateOrderRequest) GetCurrency() string {
 	return ""
 }
 
+func (x *CreateOrderRequest) GetAdjustments() []*PriceAdjustment {
+	if x != nil {
+		return x.Adjustments
+	}
+	return nil
+}
+
+func (x *CreateOrderRequest) GetTestMode() bool {
+	if x != nil {
+		return x.TestMode
+	}
+	return false
+}
+
 type CreateOrderResponse struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
@@ -985,7 +1454,7 @@ type CreateOrderResponse struct {
 func (x *CreateOrderResponse) Reset() {
 	*x = CreateOrderResponse{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_proto_oms_v1_order_service_proto_msgTypes[10]
+		mi := &file_proto_oms_v1_order_service_proto_msgTypes[12]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -998,7 +1467,7 @@ func (x *CreateOrderResponse) String() string {
 func (*CreateOrderResponse) ProtoMessage() {}
 
 func (x *CreateOrderResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_oms_v1_order_service_proto_msgTypes[10]
+	mi := &file_proto_oms_v1_order_service_proto_msgTypes[12]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1011,7 +1480,7 @@ func (x *CreateOrderResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use CreateOrderResponse.ProtoReflect.Descriptor instead.
 func (*CreateOrderResponse) Descriptor() ([]byte, []int) {
-	return file_proto_oms_v1_order_service_proto_rawDescGZIP(), []int{10}
+	return file_proto_oms_v1_order_service_proto_rawDescGZIP(), []int{12}
 }
 
 func (x *CreateOrderResponse) GetOrder() *Order {
@@ -1032,7 +1501,7 @@ type GetOrderRequest struct {
 func (x *GetOrderRequest) Reset() {
 	*x = GetOrderRequest{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_proto_oms_v1_order_service_proto_msgTypes[11]
+		mi := &file_proto_oms_v1_order_service_proto_msgTypes[13]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -1045,7 +1514,7 @@ func (x *GetOrderRequest) String() string {
 func (*GetOrderRequest) ProtoMessage() {}
 
 func (x *GetOrderRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_oms_v1_order_service_proto_msgTypes[11]
+	mi := &file_proto_oms_v1_order_service_proto_msgTypes[13]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1058,7 +1527,7 @@ func (x *GetOrderRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use GetOrderRequest.ProtoReflect.Descriptor instead.
 func (*GetOrderRequest) Descriptor() ([]byte, []int) {
-	return file_proto_oms_v1_order_service_proto_rawDescGZIP(), []int{11}
+	return file_proto_oms_v1_order_service_proto_rawDescGZIP(), []int{13}
 }
 
 func (x *GetOrderRequest) GetOrderId() string {
@@ -1080,7 +1549,7 @@ type GetOrderResponse struct {
 func (x *GetOrderResponse) Reset() {
 	*x = GetOrderResponse{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_proto_oms_v1_order_service_proto_msgTypes[12]
+		mi := &file_proto_oms_v1_order_service_proto_msgTypes[14]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -1093,7 +1562,7 @@ func (x *GetOrderResponse) String() string {
 func (*GetOrderResponse) ProtoMessage() {}
 
 func (x *GetOrderResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_oms_v1_order_service_proto_msgTypes[12]
+	mi := &file_proto_oms_v1_order_service_proto_msgTypes[14]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1106,7 +1575,7 @@ func (x *GetOrderResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use GetOrderResponse.ProtoReflect.Descriptor instead.
 func (*GetOrderResponse) Descriptor() ([]byte, []int) {
-	return file_proto_oms_v1_order_service_proto_rawDescGZIP(), []int{12}
+	return file_proto_oms_v1_order_service_proto_rawDescGZIP(), []int{14}
 }
 
 func (x *GetOrderResponse) GetOrder() *Order {
@@ -1123,34 +1592,32 @@ func (x *GetOrderResponse) GetTimeline() []*TimelineEvent {
 	return nil
 }
 
-type ListOrdersRequest struct {
+type GetOrdersRequest struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	CustomerId     string        `protobuf:"bytes,1,opt,name=customer_id,json=customerId,proto3" json:"customer_id,omitempty"`
-	PageSize       int32         `protobuf:"varint,2,opt,name=page_size,json=pageSize,proto3" json:"page_size,omitempty"`
-	PageToken      string        `protobuf:"bytes,3,opt,name=page_token,json=pageToken,proto3" json:"page_token,omitempty"`
-	FilterStatuses []OrderStatus `protobuf:"varint,4,rep,packed,name=filter_statuses,json=filterStatuses,proto3,enum=oms.v1.OrderStatus" json:"filter_statuses,omitempty"`
+	// ID заказов; повторы схлопываются. Не больше page_limits.max_page_size.
+	OrderIds []string `protobuf:"bytes,1,rep,name=order_ids,json=orderIds,proto3" json:"order_ids,omitempty"`
 }
 
-func (x *ListOrdersRequest) Reset() {
-	*x = ListOrdersRequest{}
+func (x *GetOrdersRequest) Reset() {
+	*x = GetOrdersRequest{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_proto_oms_v1_order_service_proto_msgTypes[13]
+		mi := &file_proto_oms_v1_order_service_proto_msgTypes[15]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
 }
 
-func (x *ListOrdersRequest) String() string {
+func (x *GetOrdersRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*ListOrdersRequest) ProtoMessage() {}
+func (*GetOrdersRequest) ProtoMessage() {}
 
-func (x *ListOrdersRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_oms_v1_order_service_proto_msgTypes[13]
+func (x *GetOrdersRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_oms_v1_order_service_proto_msgTypes[15]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1161,65 +1628,46 @@ func (x *ListOrdersRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use ListOrdersRequest.ProtoReflect.Descriptor instead.
-func (*ListOrdersRequest) Descriptor() ([]byte, []int) {
-	return file_proto_oms_v1_order_service_proto_rawDescGZIP(), []int{13}
-}
-
-func (x *ListOrdersRequest) GetCustomerId() string {
-	if x != nil {
-		return x.CustomerId
-	}
-	return ""
-}
-
-func (x *ListOrdersRequest) GetPageSize() int32 {
-	if x != nil {
-		return x.PageSize
-	}
-	return 0
-}
-
-func (x *ListOrdersRequest) GetPageToken() string {
-	if x != nil {
-		return x.PageToken
-	}
-	return ""
+// Deprecated: Use GetOrdersRequest.ProtoReflect.Descriptor instead.
+func (*GetOrdersRequest) Descriptor() ([]byte, []int) {
+	return file_proto_oms_v1_order_service_proto_rawDescGZIP(), []int{15}
 }
 
-func (x *ListOrdersRequest) GetFilterStatuses() []OrderStatus {
+func (x *GetOrdersRequest) GetOrderIds() []string {
 	if x != nil {
-		return x.FilterStatuses
+		return x.OrderIds
 	}
 	return nil
 }
 
-type ListOrdersResponse struct {
+type GetOrdersResponse struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	Orders        []*Order `protobuf:"bytes,1,rep,name=orders,proto3" json:"orders,omitempty"`
-	NextPageToken string   `protobuf:"bytes,2,opt,name=next_page_token,json=nextPageToken,proto3" json:"next_page_token,omitempty"`
+	// Найденные заказы по возрастанию ID, без timeline.
+	Orders []*Order `protobuf:"bytes,1,rep,name=orders,proto3" json:"orders,omitempty"`
+	// Запрошенные ID, которых нет, в порядке запроса.
+	MissingOrderIds []string `protobuf:"bytes,2,rep,name=missing_order_ids,json=missingOrderIds,proto3" json:"missing_order_ids,omitempty"`
 }
 
-func (x *ListOrdersResponse) Reset() {
-	*x = ListOrdersResponse{}
+func (x *GetOrdersResponse) Reset() {
+	*x = GetOrdersResponse{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_proto_oms_v1_order_service_proto_msgTypes[14]
+		mi := &file_proto_oms_v1_order_service_proto_msgTypes[16]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
 }
 
-func (x *ListOrdersResponse) String() string {
+func (x *GetOrdersResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*ListOrdersResponse) ProtoMessage() {}
+func (*GetOrdersResponse) ProtoMessage() {}
 
-func (x *ListOrdersResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_oms_v1_order_service_proto_msgTypes[14]
+func (x *GetOrdersResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_oms_v1_order_service_proto_msgTypes[16]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1230,50 +1678,52 @@ func (x *ListOrdersResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use ListOrdersResponse.ProtoReflect.Descriptor instead.
-func (*ListOrdersResponse) Descriptor() ([]byte, []int) {
-	return file_proto_oms_v1_order_service_proto_rawDescGZIP(), []int{14}
+// Deprecated: Use GetOrdersResponse.ProtoReflect.Descriptor instead.
+func (*GetOrdersResponse) Descriptor() ([]byte, []int) {
+	return file_proto_oms_v1_order_service_proto_rawDescGZIP(), []int{16}
 }
 
-func (x *ListOrdersResponse) GetOrders() []*Order {
+func (x *GetOrdersResponse) GetOrders() []*Order {
 	if x != nil {
 		return x.Orders
 	}
 	return nil
 }
 
-func (x *ListOrdersResponse) GetNextPageToken() string {
+func (x *GetOrdersResponse) GetMissingOrderIds() []string {
 	if x != nil {
-		return x.NextPageToken
+		return x.MissingOrderIds
 	}
-	return ""
+	return nil
 }
 
-type PayOrderRequest struct {
+type StreamOrderTimelineRequest struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
 	OrderId string `protobuf:"bytes,1,opt,name=order_id,json=orderId,proto3" json:"order_id,omitempty"`
+	// Сначала отдать уже записанные события, затем новые.
+	IncludeHistory bool `protobuf:"varint,2,opt,name=include_history,json=includeHistory,proto3" json:"include_history,omitempty"`
 }
 
-func (x *PayOrderRequest) Reset() {
-	*x = PayOrderRequest{}
+func (x *StreamOrderTimelineRequest) Reset() {
+	*x = StreamOrderTimelineRequest{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_proto_oms_v1_order_service_proto_msgTypes[15]
+		mi := &file_proto_oms_v1_order_service_proto_msgTypes[17]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
 }
 
-func (x *PayOrderRequest) String() string {
+func (x *StreamOrderTimelineRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*PayOrderRequest) ProtoMessage() {}
+func (*StreamOrderTimelineRequest) ProtoMessage() {}
 
-func (x *PayOrderRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_oms_v1_order_service_proto_msgTypes[15]
+func (x *StreamOrderTimelineRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_oms_v1_order_service_proto_msgTypes[17]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1284,44 +1734,52 @@ func (x *PayOrderRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use PayOrderRequest.ProtoReflect.Descriptor instead.
-func (*PayOrderRequest) Descriptor() ([]byte, []int) {
-	return file_proto_oms_v1_order_service_proto_rawDescGZIP(), []int{15}
+// Deprecated: Use StreamOrderTimelineRequest.ProtoReflect.Descriptor instead.
+func (*StreamOrderTimelineRequest) Descriptor() ([]byte, []int) {
+	return file_proto_oms_v1_order_service_proto_rawDescGZIP(), []int{17}
 }
 
-func (x *PayOrderRequest) GetOrderId() string {
+func (x *StreamOrderTimelineRequest) GetOrderId() string {
 	if x != nil {
 		return x.OrderId
 	}
 	return ""
 }
 
-type PayOrderResponse struct {
+func (x *StreamOrderTimelineRequest) GetIncludeHistory() bool {
+	if x != nil {
+		return x.IncludeHistory
+	}
+	return false
+}
+
+type StreamOrderTimelineResponse struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	OrderId string      `protobuf:"bytes,1,opt,name=order_id,json=orderId,proto3" json:"order_id,omitempty"`
-	Status  OrderStatus `protobuf:"varint,2,opt,name=status,proto3,enum=oms.v1.OrderStatus" json:"status,omitempty"` // ожидаемый статус, например pending/reserved.
+	Event *TimelineEvent `protobuf:"bytes,1,opt,name=event,proto3" json:"event,omitempty"`
+	// Событие записано до подписки (часть истории).
+	Historical bool `protobuf:"varint,2,opt,name=historical,proto3" json:"historical,omitempty"`
 }
 
-func (x *PayOrderResponse) Reset() {
-	*x = PayOrderResponse{}
+func (x *StreamOrderTimelineResponse) Reset() {
+	*x = StreamOrderTimelineResponse{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_proto_oms_v1_order_service_proto_msgTypes[16]
+		mi := &file_proto_oms_v1_order_service_proto_msgTypes[18]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
 }
 
-func (x *PayOrderResponse) String() string {
+func (x *StreamOrderTimelineResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*PayOrderResponse) ProtoMessage() {}
+func (*StreamOrderTimelineResponse) ProtoMessage() {}
 
-func (x *PayOrderResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_oms_v1_order_service_proto_msgTypes[16]
+func (x *StreamOrderTimelineResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_oms_v1_order_service_proto_msgTypes[18]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1332,51 +1790,50 @@ func (x *PayOrderResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use PayOrderResponse.ProtoReflect.Descriptor instead.
-func (*PayOrderResponse) Descriptor() ([]byte, []int) {
-	return file_proto_oms_v1_order_service_proto_rawDescGZIP(), []int{16}
+// Deprecated: Use StreamOrderTimelineResponse.ProtoReflect.Descriptor instead.
+func (*StreamOrderTimelineResponse) Descriptor() ([]byte, []int) {
+	return file_proto_oms_v1_order_service_proto_rawDescGZIP(), []int{18}
 }
 
-func (x *PayOrderResponse) GetOrderId() string {
+func (x *StreamOrderTimelineResponse) GetEvent() *TimelineEvent {
 	if x != nil {
-		return x.OrderId
+		return x.Event
 	}
-	return ""
+	return nil
 }
 
-func (x *PayOrderResponse) GetStatus() OrderStatus {
+func (x *StreamOrderTimelineResponse) GetHistorical() bool {
 	if x != nil {
-		return x.Status
+		return x.Historical
 	}
-	return OrderStatus_ORDER_STATUS_UNSPECIFIED
+	return false
 }
 
-type CancelOrderRequest struct {
+type WatchOrderRequest struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
 	OrderId string `protobuf:"bytes,1,opt,name=order_id,json=orderId,proto3" json:"order_id,omitempty"`
-	Reason  string `protobuf:"bytes,2,opt,name=reason,proto3" json:"reason,omitempty"`
 }
 
-func (x *CancelOrderRequest) Reset() {
-	*x = CancelOrderRequest{}
+func (x *WatchOrderRequest) Reset() {
+	*x = WatchOrderRequest{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_proto_oms_v1_order_service_proto_msgTypes[17]
+		mi := &file_proto_oms_v1_order_service_proto_msgTypes[19]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
 }
 
-func (x *CancelOrderRequest) String() string {
+func (x *WatchOrderRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*CancelOrderRequest) ProtoMessage() {}
+func (*WatchOrderRequest) ProtoMessage() {}
 
-func (x *CancelOrderRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_oms_v1_order_service_proto_msgTypes[17]
+func (x *WatchOrderRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_oms_v1_order_service_proto_msgTypes[19]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1387,51 +1844,47 @@ func (x *CancelOrderRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use CancelOrderRequest.ProtoReflect.Descriptor instead.
-func (*CancelOrderRequest) Descriptor() ([]byte, []int) {
-	return file_proto_oms_v1_order_service_proto_rawDescGZIP(), []int{17}
+// Deprecated: Use WatchOrderRequest.ProtoReflect.Descriptor instead.
+func (*WatchOrderRequest) Descriptor() ([]byte, []int) {
+	return file_proto_oms_v1_order_service_proto_rawDescGZIP(), []int{19}
 }
 
-func (x *CancelOrderRequest) GetOrderId() string {
+func (x *WatchOrderRequest) GetOrderId() string {
 	if x != nil {
 		return x.OrderId
 	}
 	return ""
 }
 
-func (x *CancelOrderRequest) GetReason() string {
-	if x != nil {
-		return x.Reason
-	}
-	return ""
-}
-
-type CancelOrderResponse struct {
+type WatchOrderResponse struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	OrderId string      `protobuf:"bytes,1,opt,name=order_id,json=orderId,proto3" json:"order_id,omitempty"`
-	Status  OrderStatus `protobuf:"varint,2,opt,name=status,proto3,enum=oms.v1.OrderStatus" json:"status,omitempty"`
+	// Состояние заказа после события.
+	Order *Order `protobuf:"bytes,1,opt,name=order,proto3" json:"order,omitempty"`
+	// Событие timeline, после которого отправлено сообщение; пусто в первом сообщении потока
+	// (снимок на момент подписки).
+	Event *TimelineEvent `protobuf:"bytes,2,opt,name=event,proto3" json:"event,omitempty"`
 }
 
-func (x *CancelOrderResponse) Reset() {
-	*x = CancelOrderResponse{}
+func (x *WatchOrderResponse) Reset() {
+	*x = WatchOrderResponse{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_proto_oms_v1_order_service_proto_msgTypes[18]
+		mi := &file_proto_oms_v1_order_service_proto_msgTypes[20]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
 }
 
-func (x *CancelOrderResponse) String() string {
+func (x *WatchOrderResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*CancelOrderResponse) ProtoMessage() {}
+func (*WatchOrderResponse) ProtoMessage() {}
 
-func (x *CancelOrderResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_oms_v1_order_service_proto_msgTypes[18]
+func (x *WatchOrderResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_oms_v1_order_service_proto_msgTypes[20]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1442,52 +1895,53 @@ func (x *CancelOrderResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use CancelOrderResponse.ProtoReflect.Descriptor instead.
-func (*CancelOrderResponse) Descriptor() ([]byte, []int) {
-	return file_proto_oms_v1_order_service_proto_rawDescGZIP(), []int{18}
+// Deprecated: Use WatchOrderResponse.ProtoReflect.Descriptor instead.
+func (*WatchOrderResponse) Descriptor() ([]byte, []int) {
+	return file_proto_oms_v1_order_service_proto_rawDescGZIP(), []int{20}
 }
 
-func (x *CancelOrderResponse) GetOrderId() string {
+func (x *WatchOrderResponse) GetOrder() *Order {
 	if x != nil {
-		return x.OrderId
+		return x.Order
 	}
-	return ""
+	return nil
 }
 
-func (x *CancelOrderResponse) GetStatus() OrderStatus {
+func (x *WatchOrderResponse) GetEvent() *TimelineEvent {
 	if x != nil {
-		return x.Status
+		return x.Event
 	}
-	return OrderStatus_ORDER_STATUS_UNSPECIFIED
+	return nil
 }
 
-type RefundOrderRequest struct {
+type ListOrdersRequest struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	OrderId string `protobuf:"bytes,1,opt,name=order_id,json=orderId,proto3" json:"order_id,omitempty"`
-	Amount  *Money `protobuf:"bytes,2,opt,name=amount,proto3" json:"amount,omitempty"` // Опционально частичный возврат.
-	Reason  string `protobuf:"bytes,3,opt,name=reason,proto3" json:"reason,omitempty"`
+	CustomerId     string        `protobuf:"bytes,1,opt,name=customer_id,json=customerId,proto3" json:"customer_id,omitempty"`
+	PageSize       int32         `protobuf:"varint,2,opt,name=page_size,json=pageSize,proto3" json:"page_size,omitempty"`
+	PageToken      string        `protobuf:"bytes,3,opt,name=page_token,json=pageToken,proto3" json:"page_token,omitempty"`
+	FilterStatuses []OrderStatus `protobuf:"varint,4,rep,packed,name=filter_statuses,json=filterStatuses,proto3,enum=oms.v1.OrderStatus" json:"filter_statuses,omitempty"`
 }
 
-func (x *RefundOrderRequest) Reset() {
-	*x = RefundOrderRequest{}
+func (x *ListOrdersRequest) Reset() {
+	*x = ListOrdersRequest{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_proto_oms_v1_order_service_proto_msgTypes[19]
+		mi := &file_proto_oms_v1_order_service_proto_msgTypes[21]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
 }
 
-func (x *RefundOrderRequest) String() string {
+func (x *ListOrdersRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*RefundOrderRequest) ProtoMessage() {}
+func (*ListOrdersRequest) ProtoMessage() {}
 
-func (x *RefundOrderRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_oms_v1_order_service_proto_msgTypes[19]
+func (x *ListOrdersRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_oms_v1_order_service_proto_msgTypes[21]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1498,58 +1952,65 @@ func (x *RefundOrderRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use RefundOrderRequest.ProtoReflect.Descriptor instead.
-func (*RefundOrderRequest) Descriptor() ([]byte, []int) {
-	return file_proto_oms_v1_order_service_proto_rawDescGZIP(), []int{19}
+// Deprecated: Use ListOrdersRequest.ProtoReflect.Descriptor instead.
+func (*ListOrdersRequest) Descriptor() ([]byte, []int) {
+	return file_proto_oms_v1_order_service_proto_rawDescGZIP(), []int{21}
 }
 
-func (x *RefundOrderRequest) GetOrderId() string {
+func (x *ListOrdersRequest) GetCustomerId() string {
 	if x != nil {
-		return x.OrderId
+		return x.CustomerId
 	}
 	return ""
 }
 
-func (x *RefundOrderRequest) GetAmount() *Money {
+func (x *ListOrdersRequest) GetPageSize() int32 {
 	if x != nil {
-		return x.Amount
+		return x.PageSize
 	}
-	return nil
+	return 0
 }
 
-func (x *RefundOrderRequest) GetReason() string {
+func (x *ListOrdersRequest) GetPageToken() string {
 	if x != nil {
-		return x.Reason
+		return x.PageToken
 	}
 	return ""
 }
 
-type RefundOrderResponse struct {
+func (x *ListOrdersRequest) GetFilterStatuses() []OrderStatus {
+	if x != nil {
+		return x.FilterStatuses
+	}
+	return nil
+}
+
+type ListOrdersResponse struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	OrderId string      `protobuf:"bytes,1,opt,name=order_id,json=orderId,proto3" json:"order_id,omitempty"`
-	Status  OrderStatus `protobuf:"varint,2,opt,name=status,proto3,enum=oms.v1.OrderStatus" json:"status,omitempty"`
+	Orders        []*Order `protobuf:"bytes,1,rep,name=orders,proto3" json:"orders,omitempty"`
+	NextPageToken string   `protobuf:"bytes,2,opt,name=next_page_token,json=nextPageToken,proto3" json:"next_page_token,omitempty"`
 }
 
-func (x *RefundOrderResponse) Reset() {
-	*x = RefundOrderResponse{}
+func (x *ListOrdersResponse) Reset() {
+	*x = ListOrdersResponse{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_proto_oms_v1_order_service_proto_msgTypes[20]
+		mi := &file_proto_oms_v1_order_service_proto_msgTypes[22]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
 }
 
-func (x *RefundOrderResponse) String() string {
+func (x *ListOrdersResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*RefundOrderResponse) ProtoMessage() {}
+func (*ListOrdersResponse) ProtoMessage() {}
 
-func (x *RefundOrderResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_oms_v1_order_service_proto_msgTypes[20]
+func (x *ListOrdersResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_oms_v1_order_service_proto_msgTypes[22]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1560,55 +2021,56 @@ func (x *RefundOrderResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use RefundOrderResponse.ProtoReflect.Descriptor instead.
-func (*RefundOrderResponse) Descriptor() ([]byte, []int) {
-	return file_proto_oms_v1_order_service_proto_rawDescGZIP(), []int{20}
+// Deprecated: Use ListOrdersResponse.ProtoReflect.Descriptor instead.
+func (*ListOrdersResponse) Descriptor() ([]byte, []int) {
+	return file_proto_oms_v1_order_service_proto_rawDescGZIP(), []int{22}
 }
 
-func (x *RefundOrderResponse) GetOrderId() string {
+func (x *ListOrdersResponse) GetOrders() []*Order {
 	if x != nil {
-		return x.OrderId
+		return x.Orders
 	}
-	return ""
+	return nil
 }
 
-func (x *RefundOrderResponse) GetStatus() OrderStatus {
+func (x *ListOrdersResponse) GetNextPageToken() string {
 	if x != nil {
-		return x.Status
+		return x.NextPageToken
 	}
-	return OrderStatus_ORDER_STATUS_UNSPECIFIED
+	return ""
 }
 
-type RegisterCourierRequest struct {
+type UpdateOrderRequest struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	CourierId   string              `protobuf:"bytes,1,opt,name=courier_id,json=courierId,proto3" json:"courier_id,omitempty"`
-	Phone       string              `protobuf:"bytes,2,opt,name=phone,proto3" json:"phone,omitempty"`
-	FirstName   string              `protobuf:"bytes,3,opt,name=first_name,json=firstName,proto3" json:"first_name,omitempty"`
-	LastName    string              `protobuf:"bytes,4,opt,name=last_name,json=lastName,proto3" json:"last_name,omitempty"`
-	VehicleType CourierVehicleType  `protobuf:"varint,5,opt,name=vehicle_type,json=vehicleType,proto3,enum=oms.v1.CourierVehicleType" json:"vehicle_type,omitempty"`
-	Zones       []*CourierZoneInput `protobuf:"bytes,6,rep,name=zones,proto3" json:"zones,omitempty"`
+	OrderId string `protobuf:"bytes,1,opt,name=order_id,json=orderId,proto3" json:"order_id,omitempty"`
+	// Order.version, на основе которой клиент собрал items; заказ изменился — ABORTED.
+	ExpectedVersion int64 `protobuf:"varint,2,opt,name=expected_version,json=expectedVersion,proto3" json:"expected_version,omitempty"`
+	// Новый полный состав заказа. Позиция с id меняет количество существующей позиции (sku и price
+	// не меняются и игнорируются); позиция без id добавляется, как в CreateOrder; позиции заказа,
+	// которых нет в списке, удаляются.
+	Items []*OrderItem `protobuf:"bytes,3,rep,name=items,proto3" json:"items,omitempty"`
 }
 
-func (x *RegisterCourierRequest) Reset() {
-	*x = RegisterCourierRequest{}
+func (x *UpdateOrderRequest) Reset() {
+	*x = UpdateOrderRequest{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_proto_oms_v1_order_service_proto_msgTypes[21]
+		mi := &file_proto_oms_v1_order_service_proto_msgTypes[23]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
 }
 
-func (x *RegisterCourierRequest) String() string {
+func (x *UpdateOrderRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*RegisterCourierRequest) ProtoMessage() {}
+func (*UpdateOrderRequest) ProtoMessage() {}
 
-func (x *RegisterCourierRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_oms_v1_order_service_proto_msgTypes[21]
+func (x *UpdateOrderRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_oms_v1_order_service_proto_msgTypes[23]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1619,78 +2081,57 @@ func (x *RegisterCourierRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use RegisterCourierRequest.ProtoReflect.Descriptor instead.
-func (*RegisterCourierRequest) Descriptor() ([]byte, []int) {
-	return file_proto_oms_v1_order_service_proto_rawDescGZIP(), []int{21}
-}
-
-func (x *RegisterCourierRequest) GetCourierId() string {
-	if x != nil {
-		return x.CourierId
-	}
-	return ""
-}
-
-func (x *RegisterCourierRequest) GetPhone() string {
-	if x != nil {
-		return x.Phone
-	}
-	return ""
-}
-
-func (x *RegisterCourierRequest) GetFirstName() string {
-	if x != nil {
-		return x.FirstName
-	}
-	return ""
+// Deprecated: Use UpdateOrderRequest.ProtoReflect.Descriptor instead.
+func (*UpdateOrderRequest) Descriptor() ([]byte, []int) {
+	return file_proto_oms_v1_order_service_proto_rawDescGZIP(), []int{23}
 }
 
-func (x *RegisterCourierRequest) GetLastName() string {
+func (x *UpdateOrderRequest) GetOrderId() string {
 	if x != nil {
-		return x.LastName
+		return x.OrderId
 	}
 	return ""
 }
 
-func (x *RegisterCourierRequest) GetVehicleType() CourierVehicleType {
+func (x *UpdateOrderRequest) GetExpectedVersion() int64 {
 	if x != nil {
-		return x.VehicleType
+		return x.ExpectedVersion
 	}
-	return CourierVehicleType_COURIER_VEHICLE_TYPE_UNSPECIFIED
+	return 0
 }
 
-func (x *RegisterCourierRequest) GetZones() []*CourierZoneInput {
+func (x *UpdateOrderRequest) GetItems() []*OrderItem {
 	if x != nil {
-		return x.Zones
+		return x.Items
 	}
 	return nil
 }
 
-type RegisterCourierResponse struct {
+type UpdateOrderResponse struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	Courier *Courier `protobuf:"bytes,1,opt,name=courier,proto3" json:"courier,omitempty"`
+	Order *Order `protobuf:"bytes,1,opt,name=order,proto3" json:"order,omitempty"`
 }
 
-func (x *RegisterCourierResponse) Reset() {
-	*x = RegisterCourierResponse{}
+func (x *UpdateOrderResponse) Reset() {
+	*x = UpdateOrderResponse{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_proto_oms_v1_order_service_proto_msgTypes[22]
+		mi := &file_proto_oms_v1_order_service_proto_msgTypes[24]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
 }
 
-func (x *RegisterCourierResponse) String() string {
+func (x *UpdateOrderResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*RegisterCourierResponse) ProtoMessage() {}
+func (*UpdateOrderResponse) ProtoMessage() {}
 
-func (x *RegisterCourierResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_oms_v1_order_service_proto_msgTypes[22]
+func (x *UpdateOrderResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_oms_v1_order_service_proto_msgTypes[24]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1701,43 +2142,43 @@ func (x *RegisterCourierResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use RegisterCourierResponse.ProtoReflect.Descriptor instead.
-func (*RegisterCourierResponse) Descriptor() ([]byte, []int) {
-	return file_proto_oms_v1_order_service_proto_rawDescGZIP(), []int{22}
+// Deprecated: Use UpdateOrderResponse.ProtoReflect.Descriptor instead.
+func (*UpdateOrderResponse) Descriptor() ([]byte, []int) {
+	return file_proto_oms_v1_order_service_proto_rawDescGZIP(), []int{24}
 }
 
-func (x *RegisterCourierResponse) GetCourier() *Courier {
+func (x *UpdateOrderResponse) GetOrder() *Order {
 	if x != nil {
-		return x.Courier
+		return x.Order
 	}
 	return nil
 }
 
-type GetCourierRequest struct {
+type PayOrderRequest struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	CourierId string `protobuf:"bytes,1,opt,name=courier_id,json=courierId,proto3" json:"courier_id,omitempty"`
+	OrderId string `protobuf:"bytes,1,opt,name=order_id,json=orderId,proto3" json:"order_id,omitempty"`
 }
 
-func (x *GetCourierRequest) Reset() {
-	*x = GetCourierRequest{}
+func (x *PayOrderRequest) Reset() {
+	*x = PayOrderRequest{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_proto_oms_v1_order_service_proto_msgTypes[23]
+		mi := &file_proto_oms_v1_order_service_proto_msgTypes[25]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
 }
 
-func (x *GetCourierRequest) String() string {
+func (x *PayOrderRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*GetCourierRequest) ProtoMessage() {}
+func (*PayOrderRequest) ProtoMessage() {}
 
-func (x *GetCourierRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_oms_v1_order_service_proto_msgTypes[23]
+func (x *PayOrderRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_oms_v1_order_service_proto_msgTypes[25]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1748,43 +2189,44 @@ func (x *GetCourierRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use GetCourierRequest.ProtoReflect.Descriptor instead.
-func (*GetCourierRequest) Descriptor() ([]byte, []int) {
-	return file_proto_oms_v1_order_service_proto_rawDescGZIP(), []int{23}
+// Deprecated: Use PayOrderRequest.ProtoReflect.Descriptor instead.
+func (*PayOrderRequest) Descriptor() ([]byte, []int) {
+	return file_proto_oms_v1_order_service_proto_rawDescGZIP(), []int{25}
 }
 
-func (x *GetCourierRequest) GetCourierId() string {
+func (x *PayOrderRequest) GetOrderId() string {
 	if x != nil {
-		return x.CourierId
+		return x.OrderId
 	}
 	return ""
 }
 
-type GetCourierResponse struct {
+type PayOrderResponse struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	Courier *Courier `protobuf:"bytes,1,opt,name=courier,proto3" json:"courier,omitempty"`
+	OrderId string      `protobuf:"bytes,1,opt,name=order_id,json=orderId,proto3" json:"order_id,omitempty"`
+	Status  OrderStatus `protobuf:"varint,2,opt,name=status,proto3,enum=oms.v1.OrderStatus" json:"status,omitempty"` // ожидаемый статус, например pending/reserved.
 }
 
-func (x *GetCourierResponse) Reset() {
-	*x = GetCourierResponse{}
+func (x *PayOrderResponse) Reset() {
+	*x = PayOrderResponse{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_proto_oms_v1_order_service_proto_msgTypes[24]
+		mi := &file_proto_oms_v1_order_service_proto_msgTypes[26]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
 }
 
-func (x *GetCourierResponse) String() string {
+func (x *PayOrderResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*GetCourierResponse) ProtoMessage() {}
+func (*PayOrderResponse) ProtoMessage() {}
 
-func (x *GetCourierResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_oms_v1_order_service_proto_msgTypes[24]
+func (x *PayOrderResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_oms_v1_order_service_proto_msgTypes[26]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1795,44 +2237,50 @@ func (x *GetCourierResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use GetCourierResponse.ProtoReflect.Descriptor instead.
-func (*GetCourierResponse) Descriptor() ([]byte, []int) {
-	return file_proto_oms_v1_order_service_proto_rawDescGZIP(), []int{24}
+// Deprecated: Use PayOrderResponse.ProtoReflect.Descriptor instead.
+func (*PayOrderResponse) Descriptor() ([]byte, []int) {
+	return file_proto_oms_v1_order_service_proto_rawDescGZIP(), []int{26}
 }
 
-func (x *GetCourierResponse) GetCourier() *Courier {
+func (x *PayOrderResponse) GetOrderId() string {
 	if x != nil {
-		return x.Courier
+		return x.OrderId
 	}
-	return nil
+	return ""
 }
 
-type ListCouriersByZoneRequest struct {
+func (x *PayOrderResponse) GetStatus() OrderStatus {
+	if x != nil {
+		return x.Status
+	}
+	return OrderStatus_ORDER_STATUS_UNSPECIFIED
+}
+
+type CaptureOrderRequest struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	ZoneId string `protobuf:"bytes,1,opt,name=zone_id,json=zoneId,proto3" json:"zone_id,omitempty"`
-	Limit  int32  `protobuf:"varint,2,opt,name=limit,proto3" json:"limit,omitempty"`
+	OrderId string `protobuf:"bytes,1,opt,name=order_id,json=orderId,proto3" json:"order_id,omitempty"`
 }
 
-func (x *ListCouriersByZoneRequest) Reset() {
-	*x = ListCouriersByZoneRequest{}
+func (x *CaptureOrderRequest) Reset() {
+	*x = CaptureOrderRequest{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_proto_oms_v1_order_service_proto_msgTypes[25]
+		mi := &file_proto_oms_v1_order_service_proto_msgTypes[27]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
 }
 
-func (x *ListCouriersByZoneRequest) String() string {
+func (x *CaptureOrderRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*ListCouriersByZoneRequest) ProtoMessage() {}
+func (*CaptureOrderRequest) ProtoMessage() {}
 
-func (x *ListCouriersByZoneRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_oms_v1_order_service_proto_msgTypes[25]
+func (x *CaptureOrderRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_oms_v1_order_service_proto_msgTypes[27]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1843,50 +2291,44 @@ func (x *ListCouriersByZoneRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use ListCouriersByZoneRequest.ProtoReflect.Descriptor instead.
-func (*ListCouriersByZoneRequest) Descriptor() ([]byte, []int) {
-	return file_proto_oms_v1_order_service_proto_rawDescGZIP(), []int{25}
+// Deprecated: Use CaptureOrderRequest.ProtoReflect.Descriptor instead.
+func (*CaptureOrderRequest) Descriptor() ([]byte, []int) {
+	return file_proto_oms_v1_order_service_proto_rawDescGZIP(), []int{27}
 }
 
-func (x *ListCouriersByZoneRequest) GetZoneId() string {
+func (x *CaptureOrderRequest) GetOrderId() string {
 	if x != nil {
-		return x.ZoneId
+		return x.OrderId
 	}
 	return ""
 }
 
-func (x *ListCouriersByZoneRequest) GetLimit() int32 {
-	if x != nil {
-		return x.Limit
-	}
-	return 0
-}
-
-type ListCouriersByZoneResponse struct {
+type CaptureOrderResponse struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	Couriers []*Courier `protobuf:"bytes,1,rep,name=couriers,proto3" json:"couriers,omitempty"`
+	OrderId string      `protobuf:"bytes,1,opt,name=order_id,json=orderId,proto3" json:"order_id,omitempty"`
+	Status  OrderStatus `protobuf:"varint,2,opt,name=status,proto3,enum=oms.v1.OrderStatus" json:"status,omitempty"` // authorized: списание выполняется асинхронно.
 }
 
-func (x *ListCouriersByZoneResponse) Reset() {
-	*x = ListCouriersByZoneResponse{}
+func (x *CaptureOrderResponse) Reset() {
+	*x = CaptureOrderResponse{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_proto_oms_v1_order_service_proto_msgTypes[26]
+		mi := &file_proto_oms_v1_order_service_proto_msgTypes[28]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
 }
 
-func (x *ListCouriersByZoneResponse) String() string {
+func (x *CaptureOrderResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*ListCouriersByZoneResponse) ProtoMessage() {}
+func (*CaptureOrderResponse) ProtoMessage() {}
 
-func (x *ListCouriersByZoneResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_oms_v1_order_service_proto_msgTypes[26]
+func (x *CaptureOrderResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_oms_v1_order_service_proto_msgTypes[28]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1897,44 +2339,51 @@ func (x *ListCouriersByZoneResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use ListCouriersByZoneResponse.ProtoReflect.Descriptor instead.
-func (*ListCouriersByZoneResponse) Descriptor() ([]byte, []int) {
-	return file_proto_oms_v1_order_service_proto_rawDescGZIP(), []int{26}
+// Deprecated: Use CaptureOrderResponse.ProtoReflect.Descriptor instead.
+func (*CaptureOrderResponse) Descriptor() ([]byte, []int) {
+	return file_proto_oms_v1_order_service_proto_rawDescGZIP(), []int{28}
 }
 
-func (x *ListCouriersByZoneResponse) GetCouriers() []*Courier {
+func (x *CaptureOrderResponse) GetOrderId() string {
 	if x != nil {
-		return x.Couriers
+		return x.OrderId
 	}
-	return nil
+	return ""
 }
 
-type ReplaceCourierZonesRequest struct {
+func (x *CaptureOrderResponse) GetStatus() OrderStatus {
+	if x != nil {
+		return x.Status
+	}
+	return OrderStatus_ORDER_STATUS_UNSPECIFIED
+}
+
+type CancelOrderRequest struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	CourierId string              `protobuf:"bytes,1,opt,name=courier_id,json=courierId,proto3" json:"courier_id,omitempty"`
-	Zones     []*CourierZoneInput `protobuf:"bytes,2,rep,name=zones,proto3" json:"zones,omitempty"`
+	OrderId string `protobuf:"bytes,1,opt,name=order_id,json=orderId,proto3" json:"order_id,omitempty"`
+	Reason  string `protobuf:"bytes,2,opt,name=reason,proto3" json:"reason,omitempty"`
 }
 
-func (x *ReplaceCourierZonesRequest) Reset() {
-	*x = ReplaceCourierZonesRequest{}
+func (x *CancelOrderRequest) Reset() {
+	*x = CancelOrderRequest{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_proto_oms_v1_order_service_proto_msgTypes[27]
+		mi := &file_proto_oms_v1_order_service_proto_msgTypes[29]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
 }
 
-func (x *ReplaceCourierZonesRequest) String() string {
+func (x *CancelOrderRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*ReplaceCourierZonesRequest) ProtoMessage() {}
+func (*CancelOrderRequest) ProtoMessage() {}
 
-func (x *ReplaceCourierZonesRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_oms_v1_order_service_proto_msgTypes[27]
+func (x *CancelOrderRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_oms_v1_order_service_proto_msgTypes[29]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1945,51 +2394,51 @@ func (x *ReplaceCourierZonesRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use ReplaceCourierZonesRequest.ProtoReflect.Descriptor instead.
-func (*ReplaceCourierZonesRequest) Descriptor() ([]byte, []int) {
-	return file_proto_oms_v1_order_service_proto_rawDescGZIP(), []int{27}
+// Deprecated: Use CancelOrderRequest.ProtoReflect.Descriptor instead.
+func (*CancelOrderRequest) Descriptor() ([]byte, []int) {
+	return file_proto_oms_v1_order_service_proto_rawDescGZIP(), []int{29}
 }
 
-func (x *ReplaceCourierZonesRequest) GetCourierId() string {
+func (x *CancelOrderRequest) GetOrderId() string {
 	if x != nil {
-		return x.CourierId
+		return x.OrderId
 	}
 	return ""
 }
 
-func (x *ReplaceCourierZonesRequest) GetZones() []*CourierZoneInput {
+func (x *CancelOrderRequest) GetReason() string {
 	if x != nil {
-		return x.Zones
+		return x.Reason
 	}
-	return nil
+	return ""
 }
 
-type ReplaceCourierZonesResponse struct {
+type CancelOrderResponse struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	CourierId string         `protobuf:"bytes,1,opt,name=courier_id,json=courierId,proto3" json:"courier_id,omitempty"`
-	Zones     []*CourierZone `protobuf:"bytes,2,rep,name=zones,proto3" json:"zones,omitempty"`
+	OrderId string      `protobuf:"bytes,1,opt,name=order_id,json=orderId,proto3" json:"order_id,omitempty"`
+	Status  OrderStatus `protobuf:"varint,2,opt,name=status,proto3,enum=oms.v1.OrderStatus" json:"status,omitempty"`
 }
 
-func (x *ReplaceCourierZonesResponse) Reset() {
-	*x = ReplaceCourierZonesResponse{}
+func (x *CancelOrderResponse) Reset() {
+	*x = CancelOrderResponse{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_proto_oms_v1_order_service_proto_msgTypes[28]
+		mi := &file_proto_oms_v1_order_service_proto_msgTypes[30]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
 }
 
-func (x *ReplaceCourierZonesResponse) String() string {
+func (x *CancelOrderResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*ReplaceCourierZonesResponse) ProtoMessage() {}
+func (*CancelOrderResponse) ProtoMessage() {}
 
-func (x *ReplaceCourierZonesResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_oms_v1_order_service_proto_msgTypes[28]
+func (x *CancelOrderResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_oms_v1_order_service_proto_msgTypes[30]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2000,54 +2449,53 @@ func (x *ReplaceCourierZonesResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use ReplaceCourierZonesResponse.ProtoReflect.Descriptor instead.
-func (*ReplaceCourierZonesResponse) Descriptor() ([]byte, []int) {
-	return file_proto_oms_v1_order_service_proto_rawDescGZIP(), []int{28}
+// Deprecated: Use CancelOrderResponse.ProtoReflect.Descriptor instead.
+func (*CancelOrderResponse) Descriptor() ([]byte, []int) {
+	return file_proto_oms_v1_order_service_proto_rawDescGZIP(), []int{30}
 }
 
-func (x *ReplaceCourierZonesResponse) GetCourierId() string {
+func (x *CancelOrderResponse) GetOrderId() string {
 	if x != nil {
-		return x.CourierId
+		return x.OrderId
 	}
 	return ""
 }
 
-func (x *ReplaceCourierZonesResponse) GetZones() []*CourierZone {
+func (x *CancelOrderResponse) GetStatus() OrderStatus {
 	if x != nil {
-		return x.Zones
+		return x.Status
 	}
-	return nil
+	return OrderStatus_ORDER_STATUS_UNSPECIFIED
 }
 
-type CreateCourierSlotRequest struct {
+type RefundOrderRequest struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	SlotId        string `protobuf:"bytes,1,opt,name=slot_id,json=slotId,proto3" json:"slot_id,omitempty"`
-	CourierId     string `protobuf:"bytes,2,opt,name=courier_id,json=courierId,proto3" json:"courier_id,omitempty"`
-	SlotStartUnix int64  `protobuf:"varint,3,opt,name=slot_start_unix,json=slotStartUnix,proto3" json:"slot_start_unix,omitempty"`
-	SlotEndUnix   int64  `protobuf:"varint,4,opt,name=slot_end_unix,json=slotEndUnix,proto3" json:"slot_end_unix,omitempty"`
-	DurationHours int32  `protobuf:"varint,5,opt,name=duration_hours,json=durationHours,proto3" json:"duration_hours,omitempty"`
+	OrderId string `protobuf:"bytes,1,opt,name=order_id,json=orderId,proto3" json:"order_id,omitempty"`
+	// Опционально частичный возврат.
+	Amount *Money `protobuf:"bytes,2,opt,name=amount,proto3" json:"amount,omitempty"`
+	Reason string `protobuf:"bytes,3,opt,name=reason,proto3" json:"reason,omitempty"`
 }
 
-func (x *CreateCourierSlotRequest) Reset() {
-	*x = CreateCourierSlotRequest{}
+func (x *RefundOrderRequest) Reset() {
+	*x = RefundOrderRequest{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_proto_oms_v1_order_service_proto_msgTypes[29]
+		mi := &file_proto_oms_v1_order_service_proto_msgTypes[31]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
 }
 
-func (x *CreateCourierSlotRequest) String() string {
+func (x *RefundOrderRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*CreateCourierSlotRequest) ProtoMessage() {}
+func (*RefundOrderRequest) ProtoMessage() {}
 
-func (x *CreateCourierSlotRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_oms_v1_order_service_proto_msgTypes[29]
+func (x *RefundOrderRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_oms_v1_order_service_proto_msgTypes[31]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2058,71 +2506,58 @@ func (x *CreateCourierSlotRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use CreateCourierSlotRequest.ProtoReflect.Descriptor instead.
-func (*CreateCourierSlotRequest) Descriptor() ([]byte, []int) {
-	return file_proto_oms_v1_order_service_proto_rawDescGZIP(), []int{29}
-}
-
-func (x *CreateCourierSlotRequest) GetSlotId() string {
-	if x != nil {
-		return x.SlotId
-	}
-	return ""
+// Deprecated: Use RefundOrderRequest.ProtoReflect.Descriptor instead.
+func (*RefundOrderRequest) Descriptor() ([]byte, []int) {
+	return file_proto_oms_v1_order_service_proto_rawDescGZIP(), []int{31}
 }
 
-func (x *CreateCourierSlotRequest) GetCourierId() string {
+func (x *RefundOrderRequest) GetOrderId() string {
 	if x != nil {
-		return x.CourierId
+		return x.OrderId
 	}
 	return ""
 }
 
-func (x *CreateCourierSlotRequest) GetSlotStartUnix() int64 {
-	if x != nil {
-		return x.SlotStartUnix
-	}
-	return 0
-}
-
-func (x *CreateCourierSlotRequest) GetSlotEndUnix() int64 {
+func (x *RefundOrderRequest) GetAmount() *Money {
 	if x != nil {
-		return x.SlotEndUnix
+		return x.Amount
 	}
-	return 0
+	return nil
 }
 
-func (x *CreateCourierSlotRequest) GetDurationHours() int32 {
+func (x *RefundOrderRequest) GetReason() string {
 	if x != nil {
-		return x.DurationHours
+		return x.Reason
 	}
-	return 0
+	return ""
 }
 
-type CreateCourierSlotResponse struct {
+type RefundOrderResponse struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	Slot *CourierSlot `protobuf:"bytes,1,opt,name=slot,proto3" json:"slot,omitempty"`
+	OrderId string      `protobuf:"bytes,1,opt,name=order_id,json=orderId,proto3" json:"order_id,omitempty"`
+	Status  OrderStatus `protobuf:"varint,2,opt,name=status,proto3,enum=oms.v1.OrderStatus" json:"status,omitempty"`
 }
 
-func (x *CreateCourierSlotResponse) Reset() {
-	*x = CreateCourierSlotResponse{}
+func (x *RefundOrderResponse) Reset() {
+	*x = RefundOrderResponse{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_proto_oms_v1_order_service_proto_msgTypes[30]
+		mi := &file_proto_oms_v1_order_service_proto_msgTypes[32]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
 }
 
-func (x *CreateCourierSlotResponse) String() string {
+func (x *RefundOrderResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*CreateCourierSlotResponse) ProtoMessage() {}
+func (*RefundOrderResponse) ProtoMessage() {}
 
-func (x *CreateCourierSlotResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_oms_v1_order_service_proto_msgTypes[30]
+func (x *RefundOrderResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_oms_v1_order_service_proto_msgTypes[32]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2133,45 +2568,51 @@ func (x *CreateCourierSlotResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use CreateCourierSlotResponse.ProtoReflect.Descriptor instead.
-func (*CreateCourierSlotResponse) Descriptor() ([]byte, []int) {
-	return file_proto_oms_v1_order_service_proto_rawDescGZIP(), []int{30}
+// Deprecated: Use RefundOrderResponse.ProtoReflect.Descriptor instead.
+func (*RefundOrderResponse) Descriptor() ([]byte, []int) {
+	return file_proto_oms_v1_order_service_proto_rawDescGZIP(), []int{32}
 }
 
-func (x *CreateCourierSlotResponse) GetSlot() *CourierSlot {
+func (x *RefundOrderResponse) GetOrderId() string {
 	if x != nil {
-		return x.Slot
+		return x.OrderId
 	}
-	return nil
+	return ""
 }
 
-type ListCourierSlotsRequest struct {
-	state         protoimpl.MessageState
-	sizeCache     protoimpl.SizeCache
-	unknownFields protoimpl.UnknownFields
-
-	CourierId string `protobuf:"bytes,1,opt,name=courier_id,json=courierId,proto3" json:"courier_id,omitempty"`
-	FromUnix  int64  `protobuf:"varint,2,opt,name=from_unix,json=fromUnix,proto3" json:"from_unix,omitempty"`
-	ToUnix    int64  `protobuf:"varint,3,opt,name=to_unix,json=toUnix,proto3" json:"to_unix,omitempty"`
+func (x *RefundOrderResponse) GetStatus() OrderStatus {
+	if x != nil {
+		return x.Status
+	}
+	return OrderStatus_ORDER_STATUS_UNSPECIFIED
 }
 
-func (x *ListCourierSlotsRequest) Reset() {
-	*x = ListCourierSlotsRequest{}
+type HoldOrderRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	OrderId string `protobuf:"bytes,1,opt,name=order_id,json=orderId,proto3" json:"order_id,omitempty"`
+	Reason  string `protobuf:"bytes,2,opt,name=reason,proto3" json:"reason,omitempty"` // Обязательна: попадает в GetOrder и timeline.
+}
+
+func (x *HoldOrderRequest) Reset() {
+	*x = HoldOrderRequest{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_proto_oms_v1_order_service_proto_msgTypes[31]
+		mi := &file_proto_oms_v1_order_service_proto_msgTypes[33]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
 }
 
-func (x *ListCourierSlotsRequest) String() string {
+func (x *HoldOrderRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*ListCourierSlotsRequest) ProtoMessage() {}
+func (*HoldOrderRequest) ProtoMessage() {}
 
-func (x *ListCourierSlotsRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_oms_v1_order_service_proto_msgTypes[31]
+func (x *HoldOrderRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_oms_v1_order_service_proto_msgTypes[33]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2182,57 +2623,52 @@ func (x *ListCourierSlotsRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use ListCourierSlotsRequest.ProtoReflect.Descriptor instead.
-func (*ListCourierSlotsRequest) Descriptor() ([]byte, []int) {
-	return file_proto_oms_v1_order_service_proto_rawDescGZIP(), []int{31}
+// Deprecated: Use HoldOrderRequest.ProtoReflect.Descriptor instead.
+func (*HoldOrderRequest) Descriptor() ([]byte, []int) {
+	return file_proto_oms_v1_order_service_proto_rawDescGZIP(), []int{33}
 }
 
-func (x *ListCourierSlotsRequest) GetCourierId() string {
+func (x *HoldOrderRequest) GetOrderId() string {
 	if x != nil {
-		return x.CourierId
+		return x.OrderId
 	}
 	return ""
 }
 
-func (x *ListCourierSlotsRequest) GetFromUnix() int64 {
-	if x != nil {
-		return x.FromUnix
-	}
-	return 0
-}
-
-func (x *ListCourierSlotsRequest) GetToUnix() int64 {
+func (x *HoldOrderRequest) GetReason() string {
 	if x != nil {
-		return x.ToUnix
+		return x.Reason
 	}
-	return 0
+	return ""
 }
 
-type ListCourierSlotsResponse struct {
+type HoldOrderResponse struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	Slots []*CourierSlot `protobuf:"bytes,1,rep,name=slots,proto3" json:"slots,omitempty"`
+	OrderId    string      `protobuf:"bytes,1,opt,name=order_id,json=orderId,proto3" json:"order_id,omitempty"`
+	Status     OrderStatus `protobuf:"varint,2,opt,name=status,proto3,enum=oms.v1.OrderStatus" json:"status,omitempty"`
+	HoldReason string      `protobuf:"bytes,3,opt,name=hold_reason,json=holdReason,proto3" json:"hold_reason,omitempty"`
 }
 
-func (x *ListCourierSlotsResponse) Reset() {
-	*x = ListCourierSlotsResponse{}
+func (x *HoldOrderResponse) Reset() {
+	*x = HoldOrderResponse{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_proto_oms_v1_order_service_proto_msgTypes[32]
+		mi := &file_proto_oms_v1_order_service_proto_msgTypes[34]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
 }
 
-func (x *ListCourierSlotsResponse) String() string {
+func (x *HoldOrderResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*ListCourierSlotsResponse) ProtoMessage() {}
+func (*HoldOrderResponse) ProtoMessage() {}
 
-func (x *ListCourierSlotsResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_oms_v1_order_service_proto_msgTypes[32]
+func (x *HoldOrderResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_oms_v1_order_service_proto_msgTypes[34]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2243,43 +2679,58 @@ func (x *ListCourierSlotsResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use ListCourierSlotsResponse.ProtoReflect.Descriptor instead.
-func (*ListCourierSlotsResponse) Descriptor() ([]byte, []int) {
-	return file_proto_oms_v1_order_service_proto_rawDescGZIP(), []int{32}
+// Deprecated: Use HoldOrderResponse.ProtoReflect.Descriptor instead.
+func (*HoldOrderResponse) Descriptor() ([]byte, []int) {
+	return file_proto_oms_v1_order_service_proto_rawDescGZIP(), []int{34}
 }
 
-func (x *ListCourierSlotsResponse) GetSlots() []*CourierSlot {
+func (x *HoldOrderResponse) GetOrderId() string {
 	if x != nil {
-		return x.Slots
+		return x.OrderId
 	}
-	return nil
+	return ""
 }
 
-type GetCourierVehicleCapabilityRequest struct {
+func (x *HoldOrderResponse) GetStatus() OrderStatus {
+	if x != nil {
+		return x.Status
+	}
+	return OrderStatus_ORDER_STATUS_UNSPECIFIED
+}
+
+func (x *HoldOrderResponse) GetHoldReason() string {
+	if x != nil {
+		return x.HoldReason
+	}
+	return ""
+}
+
+type ReleaseOrderRequest struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	VehicleType CourierVehicleType `protobuf:"varint,1,opt,name=vehicle_type,json=vehicleType,proto3,enum=oms.v1.CourierVehicleType" json:"vehicle_type,omitempty"`
+	OrderId string `protobuf:"bytes,1,opt,name=order_id,json=orderId,proto3" json:"order_id,omitempty"`
+	Reason  string `protobuf:"bytes,2,opt,name=reason,proto3" json:"reason,omitempty"` // Результат проверки, пишется в timeline.
 }
 
-func (x *GetCourierVehicleCapabilityRequest) Reset() {
-	*x = GetCourierVehicleCapabilityRequest{}
+func (x *ReleaseOrderRequest) Reset() {
+	*x = ReleaseOrderRequest{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_proto_oms_v1_order_service_proto_msgTypes[33]
+		mi := &file_proto_oms_v1_order_service_proto_msgTypes[35]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
 }
 
-func (x *GetCourierVehicleCapabilityRequest) String() string {
+func (x *ReleaseOrderRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*GetCourierVehicleCapabilityRequest) ProtoMessage() {}
+func (*ReleaseOrderRequest) ProtoMessage() {}
 
-func (x *GetCourierVehicleCapabilityRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_oms_v1_order_service_proto_msgTypes[33]
+func (x *ReleaseOrderRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_oms_v1_order_service_proto_msgTypes[35]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2290,43 +2741,51 @@ func (x *GetCourierVehicleCapabilityRequest) ProtoReflect() protoreflect.Message
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use GetCourierVehicleCapabilityRequest.ProtoReflect.Descriptor instead.
-func (*GetCourierVehicleCapabilityRequest) Descriptor() ([]byte, []int) {
-	return file_proto_oms_v1_order_service_proto_rawDescGZIP(), []int{33}
+// Deprecated: Use ReleaseOrderRequest.ProtoReflect.Descriptor instead.
+func (*ReleaseOrderRequest) Descriptor() ([]byte, []int) {
+	return file_proto_oms_v1_order_service_proto_rawDescGZIP(), []int{35}
 }
 
-func (x *GetCourierVehicleCapabilityRequest) GetVehicleType() CourierVehicleType {
+func (x *ReleaseOrderRequest) GetOrderId() string {
 	if x != nil {
-		return x.VehicleType
+		return x.OrderId
 	}
-	return CourierVehicleType_COURIER_VEHICLE_TYPE_UNSPECIFIED
+	return ""
 }
 
-type GetCourierVehicleCapabilityResponse struct {
+func (x *ReleaseOrderRequest) GetReason() string {
+	if x != nil {
+		return x.Reason
+	}
+	return ""
+}
+
+type ReleaseOrderResponse struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	Capability *CourierVehicleCapability `protobuf:"bytes,1,opt,name=capability,proto3" json:"capability,omitempty"`
+	OrderId string      `protobuf:"bytes,1,opt,name=order_id,json=orderId,proto3" json:"order_id,omitempty"`
+	Status  OrderStatus `protobuf:"varint,2,opt,name=status,proto3,enum=oms.v1.OrderStatus" json:"status,omitempty"` // Статус, в который заказ вернулся после hold.
 }
 
-func (x *GetCourierVehicleCapabilityResponse) Reset() {
-	*x = GetCourierVehicleCapabilityResponse{}
+func (x *ReleaseOrderResponse) Reset() {
+	*x = ReleaseOrderResponse{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_proto_oms_v1_order_service_proto_msgTypes[34]
+		mi := &file_proto_oms_v1_order_service_proto_msgTypes[36]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
 }
 
-func (x *GetCourierVehicleCapabilityResponse) String() string {
+func (x *ReleaseOrderResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*GetCourierVehicleCapabilityResponse) ProtoMessage() {}
+func (*ReleaseOrderResponse) ProtoMessage() {}
 
-func (x *GetCourierVehicleCapabilityResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_oms_v1_order_service_proto_msgTypes[34]
+func (x *ReleaseOrderResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_oms_v1_order_service_proto_msgTypes[36]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2337,41 +2796,52 @@ func (x *GetCourierVehicleCapabilityResponse) ProtoReflect() protoreflect.Messag
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use GetCourierVehicleCapabilityResponse.ProtoReflect.Descriptor instead.
-func (*GetCourierVehicleCapabilityResponse) Descriptor() ([]byte, []int) {
-	return file_proto_oms_v1_order_service_proto_rawDescGZIP(), []int{34}
+// Deprecated: Use ReleaseOrderResponse.ProtoReflect.Descriptor instead.
+func (*ReleaseOrderResponse) Descriptor() ([]byte, []int) {
+	return file_proto_oms_v1_order_service_proto_rawDescGZIP(), []int{36}
 }
 
-func (x *GetCourierVehicleCapabilityResponse) GetCapability() *CourierVehicleCapability {
+func (x *ReleaseOrderResponse) GetOrderId() string {
 	if x != nil {
-		return x.Capability
+		return x.OrderId
 	}
-	return nil
+	return ""
 }
 
-type ListCourierVehicleCapabilitiesRequest struct {
+func (x *ReleaseOrderResponse) GetStatus() OrderStatus {
+	if x != nil {
+		return x.Status
+	}
+	return OrderStatus_ORDER_STATUS_UNSPECIFIED
+}
+
+type ApproveOrderRequest struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
+
+	OrderId  string `protobuf:"bytes,1,opt,name=order_id,json=orderId,proto3" json:"order_id,omitempty"`
+	Approver string `protobuf:"bytes,2,opt,name=approver,proto3" json:"approver,omitempty"` // Не используется: согласующий — оператор из токена authorization.
+	Comment  string `protobuf:"bytes,3,opt,name=comment,proto3" json:"comment,omitempty"`
 }
 
-func (x *ListCourierVehicleCapabilitiesRequest) Reset() {
-	*x = ListCourierVehicleCapabilitiesRequest{}
+func (x *ApproveOrderRequest) Reset() {
+	*x = ApproveOrderRequest{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_proto_oms_v1_order_service_proto_msgTypes[35]
+		mi := &file_proto_oms_v1_order_service_proto_msgTypes[37]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
 }
 
-func (x *ListCourierVehicleCapabilitiesRequest) String() string {
+func (x *ApproveOrderRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*ListCourierVehicleCapabilitiesRequest) ProtoMessage() {}
+func (*ApproveOrderRequest) ProtoMessage() {}
 
-func (x *ListCourierVehicleCapabilitiesRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_oms_v1_order_service_proto_msgTypes[35]
+func (x *ApproveOrderRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_oms_v1_order_service_proto_msgTypes[37]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2382,36 +2852,58 @@ func (x *ListCourierVehicleCapabilitiesRequest) ProtoReflect() protoreflect.Mess
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use ListCourierVehicleCapabilitiesRequest.ProtoReflect.Descriptor instead.
-func (*ListCourierVehicleCapabilitiesRequest) Descriptor() ([]byte, []int) {
-	return file_proto_oms_v1_order_service_proto_rawDescGZIP(), []int{35}
+// Deprecated: Use ApproveOrderRequest.ProtoReflect.Descriptor instead.
+func (*ApproveOrderRequest) Descriptor() ([]byte, []int) {
+	return file_proto_oms_v1_order_service_proto_rawDescGZIP(), []int{37}
 }
 
-type ListCourierVehicleCapabilitiesResponse struct {
+func (x *ApproveOrderRequest) GetOrderId() string {
+	if x != nil {
+		return x.OrderId
+	}
+	return ""
+}
+
+func (x *ApproveOrderRequest) GetApprover() string {
+	if x != nil {
+		return x.Approver
+	}
+	return ""
+}
+
+func (x *ApproveOrderRequest) GetComment() string {
+	if x != nil {
+		return x.Comment
+	}
+	return ""
+}
+
+type ApproveOrderResponse struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	Capabilities []*CourierVehicleCapability `protobuf:"bytes,1,rep,name=capabilities,proto3" json:"capabilities,omitempty"`
+	OrderId string      `protobuf:"bytes,1,opt,name=order_id,json=orderId,proto3" json:"order_id,omitempty"`
+	Status  OrderStatus `protobuf:"varint,2,opt,name=status,proto3,enum=oms.v1.OrderStatus" json:"status,omitempty"` // ORDER_STATUS_PENDING: заказ ждёт PayOrder.
 }
 
-func (x *ListCourierVehicleCapabilitiesResponse) Reset() {
-	*x = ListCourierVehicleCapabilitiesResponse{}
+func (x *ApproveOrderResponse) Reset() {
+	*x = ApproveOrderResponse{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_proto_oms_v1_order_service_proto_msgTypes[36]
+		mi := &file_proto_oms_v1_order_service_proto_msgTypes[38]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
 }
 
-func (x *ListCourierVehicleCapabilitiesResponse) String() string {
+func (x *ApproveOrderResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*ListCourierVehicleCapabilitiesResponse) ProtoMessage() {}
+func (*ApproveOrderResponse) ProtoMessage() {}
 
-func (x *ListCourierVehicleCapabilitiesResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_oms_v1_order_service_proto_msgTypes[36]
+func (x *ApproveOrderResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_oms_v1_order_service_proto_msgTypes[38]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2422,47 +2914,52 @@ func (x *ListCourierVehicleCapabilitiesResponse) ProtoReflect() protoreflect.Mes
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use ListCourierVehicleCapabilitiesResponse.ProtoReflect.Descriptor instead.
-func (*ListCourierVehicleCapabilitiesResponse) Descriptor() ([]byte, []int) {
-	return file_proto_oms_v1_order_service_proto_rawDescGZIP(), []int{36}
+// Deprecated: Use ApproveOrderResponse.ProtoReflect.Descriptor instead.
+func (*ApproveOrderResponse) Descriptor() ([]byte, []int) {
+	return file_proto_oms_v1_order_service_proto_rawDescGZIP(), []int{38}
 }
 
-func (x *ListCourierVehicleCapabilitiesResponse) GetCapabilities() []*CourierVehicleCapability {
+func (x *ApproveOrderResponse) GetOrderId() string {
 	if x != nil {
-		return x.Capabilities
+		return x.OrderId
 	}
-	return nil
+	return ""
 }
 
-type SubmitCourierRatingRequest struct {
+func (x *ApproveOrderResponse) GetStatus() OrderStatus {
+	if x != nil {
+		return x.Status
+	}
+	return OrderStatus_ORDER_STATUS_UNSPECIFIED
+}
+
+type RejectOrderRequest struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	RatingId  string             `protobuf:"bytes,1,opt,name=rating_id,json=ratingId,proto3" json:"rating_id,omitempty"`
-	CourierId string             `protobuf:"bytes,2,opt,name=courier_id,json=courierId,proto3" json:"courier_id,omitempty"`
-	Score     int32              `protobuf:"varint,3,opt,name=score,proto3" json:"score,omitempty"`
-	Tags      []CourierRatingTag `protobuf:"varint,4,rep,packed,name=tags,proto3,enum=oms.v1.CourierRatingTag" json:"tags,omitempty"`
-	Comment   string             `protobuf:"bytes,5,opt,name=comment,proto3" json:"comment,omitempty"`
+	OrderId  string `protobuf:"bytes,1,opt,name=order_id,json=orderId,proto3" json:"order_id,omitempty"`
+	Approver string `protobuf:"bytes,2,opt,name=approver,proto3" json:"approver,omitempty"` // Не используется: отклоняющий — оператор из токена authorization.
+	Reason   string `protobuf:"bytes,3,opt,name=reason,proto3" json:"reason,omitempty"`     // Обязательна: попадает в timeline и событие.
 }
 
-func (x *SubmitCourierRatingRequest) Reset() {
-	*x = SubmitCourierRatingRequest{}
+func (x *RejectOrderRequest) Reset() {
+	*x = RejectOrderRequest{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_proto_oms_v1_order_service_proto_msgTypes[37]
+		mi := &file_proto_oms_v1_order_service_proto_msgTypes[39]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
 }
 
-func (x *SubmitCourierRatingRequest) String() string {
+func (x *RejectOrderRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*SubmitCourierRatingRequest) ProtoMessage() {}
+func (*RejectOrderRequest) ProtoMessage() {}
 
-func (x *SubmitCourierRatingRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_oms_v1_order_service_proto_msgTypes[37]
+func (x *RejectOrderRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_oms_v1_order_service_proto_msgTypes[39]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2473,72 +2970,58 @@ func (x *SubmitCourierRatingRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use SubmitCourierRatingRequest.ProtoReflect.Descriptor instead.
-func (*SubmitCourierRatingRequest) Descriptor() ([]byte, []int) {
-	return file_proto_oms_v1_order_service_proto_rawDescGZIP(), []int{37}
+// Deprecated: Use RejectOrderRequest.ProtoReflect.Descriptor instead.
+func (*RejectOrderRequest) Descriptor() ([]byte, []int) {
+	return file_proto_oms_v1_order_service_proto_rawDescGZIP(), []int{39}
 }
 
-func (x *SubmitCourierRatingRequest) GetRatingId() string {
+func (x *RejectOrderRequest) GetOrderId() string {
 	if x != nil {
-		return x.RatingId
+		return x.OrderId
 	}
 	return ""
 }
 
-func (x *SubmitCourierRatingRequest) GetCourierId() string {
+func (x *RejectOrderRequest) GetApprover() string {
 	if x != nil {
-		return x.CourierId
+		return x.Approver
 	}
 	return ""
 }
 
-func (x *SubmitCourierRatingRequest) GetScore() int32 {
-	if x != nil {
-		return x.Score
-	}
-	return 0
-}
-
-func (x *SubmitCourierRatingRequest) GetTags() []CourierRatingTag {
-	if x != nil {
-		return x.Tags
-	}
-	return nil
-}
-
-func (x *SubmitCourierRatingRequest) GetComment() string {
+func (x *RejectOrderRequest) GetReason() string {
 	if x != nil {
-		return x.Comment
+		return x.Reason
 	}
 	return ""
 }
 
-type SubmitCourierRatingResponse struct {
+type RejectOrderResponse struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	RatingId  string `protobuf:"bytes,1,opt,name=rating_id,json=ratingId,proto3" json:"rating_id,omitempty"`
-	CourierId string `protobuf:"bytes,2,opt,name=courier_id,json=courierId,proto3" json:"courier_id,omitempty"`
+	OrderId string      `protobuf:"bytes,1,opt,name=order_id,json=orderId,proto3" json:"order_id,omitempty"`
+	Status  OrderStatus `protobuf:"varint,2,opt,name=status,proto3,enum=oms.v1.OrderStatus" json:"status,omitempty"`
 }
 
-func (x *SubmitCourierRatingResponse) Reset() {
-	*x = SubmitCourierRatingResponse{}
+func (x *RejectOrderResponse) Reset() {
+	*x = RejectOrderResponse{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_proto_oms_v1_order_service_proto_msgTypes[38]
+		mi := &file_proto_oms_v1_order_service_proto_msgTypes[40]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
 }
 
-func (x *SubmitCourierRatingResponse) String() string {
+func (x *RejectOrderResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*SubmitCourierRatingResponse) ProtoMessage() {}
+func (*RejectOrderResponse) ProtoMessage() {}
 
-func (x *SubmitCourierRatingResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_oms_v1_order_service_proto_msgTypes[38]
+func (x *RejectOrderResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_oms_v1_order_service_proto_msgTypes[40]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2549,50 +3032,57 @@ func (x *SubmitCourierRatingResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use SubmitCourierRatingResponse.ProtoReflect.Descriptor instead.
-func (*SubmitCourierRatingResponse) Descriptor() ([]byte, []int) {
-	return file_proto_oms_v1_order_service_proto_rawDescGZIP(), []int{38}
+// Deprecated: Use RejectOrderResponse.ProtoReflect.Descriptor instead.
+func (*RejectOrderResponse) Descriptor() ([]byte, []int) {
+	return file_proto_oms_v1_order_service_proto_rawDescGZIP(), []int{40}
 }
 
-func (x *SubmitCourierRatingResponse) GetRatingId() string {
+func (x *RejectOrderResponse) GetOrderId() string {
 	if x != nil {
-		return x.RatingId
+		return x.OrderId
 	}
 	return ""
 }
 
-func (x *SubmitCourierRatingResponse) GetCourierId() string {
+func (x *RejectOrderResponse) GetStatus() OrderStatus {
 	if x != nil {
-		return x.CourierId
+		return x.Status
 	}
-	return ""
+	return OrderStatus_ORDER_STATUS_UNSPECIFIED
 }
 
-type GetCourierRatingSummaryRequest struct {
+// Отложенная отмена заказа.
+type ScheduledCancel struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	CourierId string `protobuf:"bytes,1,opt,name=courier_id,json=courierId,proto3" json:"courier_id,omitempty"`
+	Id        string                `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	OrderId   string                `protobuf:"bytes,2,opt,name=order_id,json=orderId,proto3" json:"order_id,omitempty"`
+	CancelAt  string                `protobuf:"bytes,3,opt,name=cancel_at,json=cancelAt,proto3" json:"cancel_at,omitempty"` // RFC3339, UTC.
+	Reason    string                `protobuf:"bytes,4,opt,name=reason,proto3" json:"reason,omitempty"`
+	Status    ScheduledCancelStatus `protobuf:"varint,5,opt,name=status,proto3,enum=oms.v1.ScheduledCancelStatus" json:"status,omitempty"`
+	Note      string                `protobuf:"bytes,6,opt,name=note,proto3" json:"note,omitempty"`                            // Пояснение к SKIPPED.
+	CreatedAt string                `protobuf:"bytes,7,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"` // RFC3339, UTC.
 }
 
-func (x *GetCourierRatingSummaryRequest) Reset() {
-	*x = GetCourierRatingSummaryRequest{}
+func (x *ScheduledCancel) Reset() {
+	*x = ScheduledCancel{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_proto_oms_v1_order_service_proto_msgTypes[39]
+		mi := &file_proto_oms_v1_order_service_proto_msgTypes[41]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
 }
 
-func (x *GetCourierRatingSummaryRequest) String() string {
+func (x *ScheduledCancel) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*GetCourierRatingSummaryRequest) ProtoMessage() {}
+func (*ScheduledCancel) ProtoMessage() {}
 
-func (x *GetCourierRatingSummaryRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_oms_v1_order_service_proto_msgTypes[39]
+func (x *ScheduledCancel) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_oms_v1_order_service_proto_msgTypes[41]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2603,59 +3093,87 @@ func (x *GetCourierRatingSummaryRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use GetCourierRatingSummaryRequest.ProtoReflect.Descriptor instead.
-func (*GetCourierRatingSummaryRequest) Descriptor() ([]byte, []int) {
-	return file_proto_oms_v1_order_service_proto_rawDescGZIP(), []int{39}
+// Deprecated: Use ScheduledCancel.ProtoReflect.Descriptor instead.
+func (*ScheduledCancel) Descriptor() ([]byte, []int) {
+	return file_proto_oms_v1_order_service_proto_rawDescGZIP(), []int{41}
 }
 
-func (x *GetCourierRatingSummaryRequest) GetCourierId() string {
+func (x *ScheduledCancel) GetId() string {
 	if x != nil {
-		return x.CourierId
+		return x.Id
 	}
 	return ""
 }
 
-type CourierRatingSummary struct {
+func (x *ScheduledCancel) GetOrderId() string {
+	if x != nil {
+		return x.OrderId
+	}
+	return ""
+}
+
+func (x *ScheduledCancel) GetCancelAt() string {
+	if x != nil {
+		return x.CancelAt
+	}
+	return ""
+}
+
+func (x *ScheduledCancel) GetReason() string {
+	if x != nil {
+		return x.Reason
+	}
+	return ""
+}
+
+func (x *ScheduledCancel) GetStatus() ScheduledCancelStatus {
+	if x != nil {
+		return x.Status
+	}
+	return ScheduledCancelStatus_SCHEDULED_CANCEL_STATUS_UNSPECIFIED
+}
+
+func (x *ScheduledCancel) GetNote() string {
+	if x != nil {
+		return x.Note
+	}
+	return ""
+}
+
+func (x *ScheduledCancel) GetCreatedAt() string {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return ""
+}
+
+type ScheduleCancelRequest struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	CourierId       string  `protobuf:"bytes,1,opt,name=courier_id,json=courierId,proto3" json:"courier_id,omitempty"`
-	RatingsCount    int64   `protobuf:"varint,2,opt,name=ratings_count,json=ratingsCount,proto3" json:"ratings_count,omitempty"`
-	AverageScore    float64 `protobuf:"fixed64,3,opt,name=average_score,json=averageScore,proto3" json:"average_score,omitempty"`
-	LowRatingsCount int64   `protobuf:"varint,4,opt,name=low_ratings_count,json=lowRatingsCount,proto3" json:"low_ratings_count,omitempty"`
-	Score_1Count    int64   `protobuf:"varint,5,opt,name=score_1_count,json=score1Count,proto3" json:"score_1_count,omitempty"`
-	Score_2Count    int64   `protobuf:"varint,6,opt,name=score_2_count,json=score2Count,proto3" json:"score_2_count,omitempty"`
-	Score_3Count    int64   `protobuf:"varint,7,opt,name=score_3_count,json=score3Count,proto3" json:"score_3_count,omitempty"`
-	Score_4Count    int64   `protobuf:"varint,8,opt,name=score_4_count,json=score4Count,proto3" json:"score_4_count,omitempty"`
-	Score_5Count    int64   `protobuf:"varint,9,opt,name=score_5_count,json=score5Count,proto3" json:"score_5_count,omitempty"`
-	LastRatingUnix  int64   `protobuf:"varint,10,opt,name=last_rating_unix,json=lastRatingUnix,proto3" json:"last_rating_unix,omitempty"`
-	OnTimeCount     int64   `protobuf:"varint,11,opt,name=on_time_count,json=onTimeCount,proto3" json:"on_time_count,omitempty"`
-	PoliteCount     int64   `protobuf:"varint,12,opt,name=polite_count,json=politeCount,proto3" json:"polite_count,omitempty"`
-	CarefulCount    int64   `protobuf:"varint,13,opt,name=careful_count,json=carefulCount,proto3" json:"careful_count,omitempty"`
-	DelayedCount    int64   `protobuf:"varint,14,opt,name=delayed_count,json=delayedCount,proto3" json:"delayed_count,omitempty"`
-	RudeCount       int64   `protobuf:"varint,15,opt,name=rude_count,json=rudeCount,proto3" json:"rude_count,omitempty"`
-	DamagedCount    int64   `protobuf:"varint,16,opt,name=damaged_count,json=damagedCount,proto3" json:"damaged_count,omitempty"`
-	OtherIssueCount int64   `protobuf:"varint,17,opt,name=other_issue_count,json=otherIssueCount,proto3" json:"other_issue_count,omitempty"`
+	OrderId  string `protobuf:"bytes,1,opt,name=order_id,json=orderId,proto3" json:"order_id,omitempty"`
+	CancelAt string `protobuf:"bytes,2,opt,name=cancel_at,json=cancelAt,proto3" json:"cancel_at,omitempty"` // RFC3339 с часовым поясом, например "2026-03-06T18:00:00+03:00".
+	Reason   string `protobuf:"bytes,3,opt,name=reason,proto3" json:"reason,omitempty"`                     // Передаётся в CancelOrder при срабатывании.
 }
 
-func (x *CourierRatingSummary) Reset() {
-	*x = CourierRatingSummary{}
+func (x *ScheduleCancelRequest) Reset() {
+	*x = ScheduleCancelRequest{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_proto_oms_v1_order_service_proto_msgTypes[40]
+		mi := &file_proto_oms_v1_order_service_proto_msgTypes[42]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
 }
 
-func (x *CourierRatingSummary) String() string {
+func (x *ScheduleCancelRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*CourierRatingSummary) ProtoMessage() {}
+func (*ScheduleCancelRequest) ProtoMessage() {}
 
-func (x *CourierRatingSummary) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_oms_v1_order_service_proto_msgTypes[40]
+func (x *ScheduleCancelRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_oms_v1_order_service_proto_msgTypes[42]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2666,155 +3184,253 @@ func (x *CourierRatingSummary) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use CourierRatingSummary.ProtoReflect.Descriptor instead.
-func (*CourierRatingSummary) Descriptor() ([]byte, []int) {
-	return file_proto_oms_v1_order_service_proto_rawDescGZIP(), []int{40}
+// Deprecated: Use ScheduleCancelRequest.ProtoReflect.Descriptor instead.
+func (*ScheduleCancelRequest) Descriptor() ([]byte, []int) {
+	return file_proto_oms_v1_order_service_proto_rawDescGZIP(), []int{42}
 }
 
-func (x *CourierRatingSummary) GetCourierId() string {
+func (x *ScheduleCancelRequest) GetOrderId() string {
 	if x != nil {
-		return x.CourierId
+		return x.OrderId
 	}
 	return ""
 }
 
-func (x *CourierRatingSummary) GetRatingsCount() int64 {
+func (x *ScheduleCancelRequest) GetCancelAt() string {
 	if x != nil {
-		return x.RatingsCount
+		return x.CancelAt
 	}
-	return 0
+	return ""
 }
 
-func (x *CourierRatingSummary) GetAverageScore() float64 {
+func (x *ScheduleCancelRequest) GetReason() string {
 	if x != nil {
-		return x.AverageScore
+		return x.Reason
 	}
-	return 0
+	return ""
 }
 
-func (x *CourierRatingSummary) GetLowRatingsCount() int64 {
-	if x != nil {
-		return x.LowRatingsCount
-	}
-	return 0
+type ScheduleCancelResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	ScheduledCancel *ScheduledCancel `protobuf:"bytes,1,opt,name=scheduled_cancel,json=scheduledCancel,proto3" json:"scheduled_cancel,omitempty"`
 }
 
-func (x *CourierRatingSummary) GetScore_1Count() int64 {
-	if x != nil {
-		return x.Score_1Count
+func (x *ScheduleCancelResponse) Reset() {
+	*x = ScheduleCancelResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_proto_oms_v1_order_service_proto_msgTypes[43]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
 	}
-	return 0
 }
 
-func (x *CourierRatingSummary) GetScore_2Count() int64 {
-	if x != nil {
-		return x.Score_2Count
+func (x *ScheduleCancelResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ScheduleCancelResponse) ProtoMessage() {}
+
+func (x *ScheduleCancelResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_oms_v1_order_service_proto_msgTypes[43]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
 	}
-	return 0
+	return mi.MessageOf(x)
 }
 
-func (x *CourierRatingSummary) GetScore_3Count() int64 {
+// Deprecated: Use ScheduleCancelResponse.ProtoReflect.Descriptor instead.
+func (*ScheduleCancelResponse) Descriptor() ([]byte, []int) {
+	return file_proto_oms_v1_order_service_proto_rawDescGZIP(), []int{43}
+}
+
+func (x *ScheduleCancelResponse) GetScheduledCancel() *ScheduledCancel {
 	if x != nil {
-		return x.Score_3Count
+		return x.ScheduledCancel
 	}
-	return 0
+	return nil
 }
 
-func (x *CourierRatingSummary) GetScore_4Count() int64 {
-	if x != nil {
-		return x.Score_4Count
+type ListScheduledCancelsRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	OrderId string `protobuf:"bytes,1,opt,name=order_id,json=orderId,proto3" json:"order_id,omitempty"`
+}
+
+func (x *ListScheduledCancelsRequest) Reset() {
+	*x = ListScheduledCancelsRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_proto_oms_v1_order_service_proto_msgTypes[44]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
 	}
-	return 0
 }
 
-func (x *CourierRatingSummary) GetScore_5Count() int64 {
-	if x != nil {
-		return x.Score_5Count
+func (x *ListScheduledCancelsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListScheduledCancelsRequest) ProtoMessage() {}
+
+func (x *ListScheduledCancelsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_oms_v1_order_service_proto_msgTypes[44]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
 	}
-	return 0
+	return mi.MessageOf(x)
 }
 
-func (x *CourierRatingSummary) GetLastRatingUnix() int64 {
+// Deprecated: Use ListScheduledCancelsRequest.ProtoReflect.Descriptor instead.
+func (*ListScheduledCancelsRequest) Descriptor() ([]byte, []int) {
+	return file_proto_oms_v1_order_service_proto_rawDescGZIP(), []int{44}
+}
+
+func (x *ListScheduledCancelsRequest) GetOrderId() string {
 	if x != nil {
-		return x.LastRatingUnix
+		return x.OrderId
 	}
-	return 0
+	return ""
 }
 
-func (x *CourierRatingSummary) GetOnTimeCount() int64 {
-	if x != nil {
-		return x.OnTimeCount
+type ListScheduledCancelsResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	ScheduledCancels []*ScheduledCancel `protobuf:"bytes,1,rep,name=scheduled_cancels,json=scheduledCancels,proto3" json:"scheduled_cancels,omitempty"` // По возрастанию cancel_at.
+}
+
+func (x *ListScheduledCancelsResponse) Reset() {
+	*x = ListScheduledCancelsResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_proto_oms_v1_order_service_proto_msgTypes[45]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
 	}
-	return 0
 }
 
-func (x *CourierRatingSummary) GetPoliteCount() int64 {
-	if x != nil {
-		return x.PoliteCount
+func (x *ListScheduledCancelsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListScheduledCancelsResponse) ProtoMessage() {}
+
+func (x *ListScheduledCancelsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_oms_v1_order_service_proto_msgTypes[45]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
 	}
-	return 0
+	return mi.MessageOf(x)
 }
 
-func (x *CourierRatingSummary) GetCarefulCount() int64 {
+// Deprecated: Use ListScheduledCancelsResponse.ProtoReflect.Descriptor instead.
+func (*ListScheduledCancelsResponse) Descriptor() ([]byte, []int) {
+	return file_proto_oms_v1_order_service_proto_rawDescGZIP(), []int{45}
+}
+
+func (x *ListScheduledCancelsResponse) GetScheduledCancels() []*ScheduledCancel {
 	if x != nil {
-		return x.CarefulCount
+		return x.ScheduledCancels
 	}
-	return 0
+	return nil
 }
 
-func (x *CourierRatingSummary) GetDelayedCount() int64 {
-	if x != nil {
-		return x.DelayedCount
+type DeleteScheduledCancelRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	OrderId           string `protobuf:"bytes,1,opt,name=order_id,json=orderId,proto3" json:"order_id,omitempty"`
+	ScheduledCancelId string `protobuf:"bytes,2,opt,name=scheduled_cancel_id,json=scheduledCancelId,proto3" json:"scheduled_cancel_id,omitempty"`
+}
+
+func (x *DeleteScheduledCancelRequest) Reset() {
+	*x = DeleteScheduledCancelRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_proto_oms_v1_order_service_proto_msgTypes[46]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
 	}
-	return 0
 }
 
-func (x *CourierRatingSummary) GetRudeCount() int64 {
-	if x != nil {
-		return x.RudeCount
+func (x *DeleteScheduledCancelRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteScheduledCancelRequest) ProtoMessage() {}
+
+func (x *DeleteScheduledCancelRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_oms_v1_order_service_proto_msgTypes[46]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
 	}
-	return 0
+	return mi.MessageOf(x)
 }
 
-func (x *CourierRatingSummary) GetDamagedCount() int64 {
+// Deprecated: Use DeleteScheduledCancelRequest.ProtoReflect.Descriptor instead.
+func (*DeleteScheduledCancelRequest) Descriptor() ([]byte, []int) {
+	return file_proto_oms_v1_order_service_proto_rawDescGZIP(), []int{46}
+}
+
+func (x *DeleteScheduledCancelRequest) GetOrderId() string {
 	if x != nil {
-		return x.DamagedCount
+		return x.OrderId
 	}
-	return 0
+	return ""
 }
 
-func (x *CourierRatingSummary) GetOtherIssueCount() int64 {
+func (x *DeleteScheduledCancelRequest) GetScheduledCancelId() string {
 	if x != nil {
-		return x.OtherIssueCount
+		return x.ScheduledCancelId
 	}
-	return 0
+	return ""
 }
 
-type GetCourierRatingSummaryResponse struct {
+type DeleteScheduledCancelResponse struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	Summary *CourierRatingSummary `protobuf:"bytes,1,opt,name=summary,proto3" json:"summary,omitempty"`
+	ScheduledCancel *ScheduledCancel `protobuf:"bytes,1,opt,name=scheduled_cancel,json=scheduledCancel,proto3" json:"scheduled_cancel,omitempty"`
 }
 
-func (x *GetCourierRatingSummaryResponse) Reset() {
-	*x = GetCourierRatingSummaryResponse{}
+func (x *DeleteScheduledCancelResponse) Reset() {
+	*x = DeleteScheduledCancelResponse{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_proto_oms_v1_order_service_proto_msgTypes[41]
+		mi := &file_proto_oms_v1_order_service_proto_msgTypes[47]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
 }
 
-func (x *GetCourierRatingSummaryResponse) String() string {
+func (x *DeleteScheduledCancelResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*GetCourierRatingSummaryResponse) ProtoMessage() {}
+func (*DeleteScheduledCancelResponse) ProtoMessage() {}
 
-func (x *GetCourierRatingSummaryResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_oms_v1_order_service_proto_msgTypes[41]
+func (x *DeleteScheduledCancelResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_oms_v1_order_service_proto_msgTypes[47]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2825,683 +3441,4860 @@ func (x *GetCourierRatingSummaryResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use GetCourierRatingSummaryResponse.ProtoReflect.Descriptor instead.
-func (*GetCourierRatingSummaryResponse) Descriptor() ([]byte, []int) {
-	return file_proto_oms_v1_order_service_proto_rawDescGZIP(), []int{41}
+// Deprecated: Use DeleteScheduledCancelResponse.ProtoReflect.Descriptor instead.
+func (*DeleteScheduledCancelResponse) Descriptor() ([]byte, []int) {
+	return file_proto_oms_v1_order_service_proto_rawDescGZIP(), []int{47}
 }
 
-func (x *GetCourierRatingSummaryResponse) GetSummary() *CourierRatingSummary {
+func (x *DeleteScheduledCancelResponse) GetScheduledCancel() *ScheduledCancel {
 	if x != nil {
-		return x.Summary
+		return x.ScheduledCancel
 	}
 	return nil
 }
 
-var File_proto_oms_v1_order_service_proto protoreflect.FileDescriptor
+type ReturnItem struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
 
-var file_proto_oms_v1_order_service_proto_rawDesc = []byte{
-	0x0a, 0x20, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2f, 0x6f, 0x6d, 0x73, 0x2f, 0x76, 0x31, 0x2f, 0x6f,
-	0x72, 0x64, 0x65, 0x72, 0x5f, 0x73, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x2e, 0x70, 0x72, 0x6f,
-	0x74, 0x6f, 0x12, 0x06, 0x6f, 0x6d, 0x73, 0x2e, 0x76, 0x31, 0x1a, 0x1c, 0x67, 0x6f, 0x6f, 0x67,
-	0x6c, 0x65, 0x2f, 0x61, 0x70, 0x69, 0x2f, 0x61, 0x6e, 0x6e, 0x6f, 0x74, 0x61, 0x74, 0x69, 0x6f,
-	0x6e, 0x73, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x22, 0x46, 0x0a, 0x05, 0x4d, 0x6f, 0x6e, 0x65,
-	0x79, 0x12, 0x1a, 0x0a, 0x08, 0x63, 0x75, 0x72, 0x72, 0x65, 0x6e, 0x63, 0x79, 0x18, 0x01, 0x20,
-	0x01, 0x28, 0x09, 0x52, 0x08, 0x63, 0x75, 0x72, 0x72, 0x65, 0x6e, 0x63, 0x79, 0x12, 0x21, 0x0a,
-	0x0c, 0x61, 0x6d, 0x6f, 0x75, 0x6e, 0x74, 0x5f, 0x6d, 0x69, 0x6e, 0x6f, 0x72, 0x18, 0x02, 0x20,
-	0x01, 0x28, 0x03, 0x52, 0x0b, 0x61, 0x6d, 0x6f, 0x75, 0x6e, 0x74, 0x4d, 0x69, 0x6e, 0x6f, 0x72,
-	0x22, 0x54, 0x0a, 0x09, 0x4f, 0x72, 0x64, 0x65, 0x72, 0x49, 0x74, 0x65, 0x6d, 0x12, 0x10, 0x0a,
-	0x03, 0x73, 0x6b, 0x75, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x73, 0x6b, 0x75, 0x12,
-	0x10, 0x0a, 0x03, 0x71, 0x74, 0x79, 0x18, 0x02, 0x20, 0x01, 0x28, 0x05, 0x52, 0x03, 0x71, 0x74,
-	0x79, 0x12, 0x23, 0x0a, 0x05, 0x70, 0x72, 0x69, 0x63, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0b,
-	0x32, 0x0d, 0x2e, 0x6f, 0x6d, 0x73, 0x2e, 0x76, 0x31, 0x2e, 0x4d, 0x6f, 0x6e, 0x65, 0x79, 0x52,
-	0x05, 0x70, 0x72, 0x69, 0x63, 0x65, 0x22, 0xeb, 0x01, 0x0a, 0x05, 0x4f, 0x72, 0x64, 0x65, 0x72,
-	0x12, 0x0e, 0x0a, 0x02, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x02, 0x69, 0x64,
-	0x12, 0x1f, 0x0a, 0x0b, 0x63, 0x75, 0x73, 0x74, 0x6f, 0x6d, 0x65, 0x72, 0x5f, 0x69, 0x64, 0x18,
-	0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0a, 0x63, 0x75, 0x73, 0x74, 0x6f, 0x6d, 0x65, 0x72, 0x49,
-	0x64, 0x12, 0x2b, 0x0a, 0x06, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x18, 0x03, 0x20, 0x01, 0x28,
-	0x0e, 0x32, 0x13, 0x2e, 0x6f, 0x6d, 0x73, 0x2e, 0x76, 0x31, 0x2e, 0x4f, 0x72, 0x64, 0x65, 0x72,
-	0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x52, 0x06, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x12, 0x25,
-	0x0a, 0x06, 0x61, 0x6d, 0x6f, 0x75, 0x6e, 0x74, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x0d,
-	0x2e, 0x6f, 0x6d, 0x73, 0x2e, 0x76, 0x31, 0x2e, 0x4d, 0x6f, 0x6e, 0x65, 0x79, 0x52, 0x06, 0x61,
-	0x6d, 0x6f, 0x75, 0x6e, 0x74, 0x12, 0x27, 0x0a, 0x05, 0x69, 0x74, 0x65, 0x6d, 0x73, 0x18, 0x05,
-	0x20, 0x03, 0x28, 0x0b, 0x32, 0x11, 0x2e, 0x6f, 0x6d, 0x73, 0x2e, 0x76, 0x31, 0x2e, 0x4f, 0x72,
-	0x64, 0x65, 0x72, 0x49, 0x74, 0x65, 0x6d, 0x52, 0x05, 0x69, 0x74, 0x65, 0x6d, 0x73, 0x12, 0x18,
-	0x0a, 0x07, 0x76, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x18, 0x06, 0x20, 0x01, 0x28, 0x03, 0x52,
-	0x07, 0x76, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x12, 0x1a, 0x0a, 0x08, 0x63, 0x75, 0x72, 0x72,
-	0x65, 0x6e, 0x63, 0x79, 0x18, 0x07, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x63, 0x75, 0x72, 0x72,
-	0x65, 0x6e, 0x63, 0x79, 0x22, 0x58, 0x0a, 0x0d, 0x54, 0x69, 0x6d, 0x65, 0x6c, 0x69, 0x6e, 0x65,
-	0x45, 0x76, 0x65, 0x6e, 0x74, 0x12, 0x12, 0x0a, 0x04, 0x74, 0x79, 0x70, 0x65, 0x18, 0x01, 0x20,
-	0x01, 0x28, 0x09, 0x52, 0x04, 0x74, 0x79, 0x70, 0x65, 0x12, 0x16, 0x0a, 0x06, 0x72, 0x65, 0x61,
-	0x73, 0x6f, 0x6e, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x72, 0x65, 0x61, 0x73, 0x6f,
-	0x6e, 0x12, 0x1b, 0x0a, 0x09, 0x75, 0x6e, 0x69, 0x78, 0x5f, 0x74, 0x69, 0x6d, 0x65, 0x18, 0x03,
-	0x20, 0x01, 0x28, 0x03, 0x52, 0x08, 0x75, 0x6e, 0x69, 0x78, 0x54, 0x69, 0x6d, 0x65, 0x22, 0x4a,
-	0x0a, 0x10, 0x43, 0x6f, 0x75, 0x72, 0x69, 0x65, 0x72, 0x5a, 0x6f, 0x6e, 0x65, 0x49, 0x6e, 0x70,
-	0x75, 0x74, 0x12, 0x17, 0x0a, 0x07, 0x7a, 0x6f, 0x6e, 0x65, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20,
-	0x01, 0x28, 0x09, 0x52, 0x06, 0x7a, 0x6f, 0x6e, 0x65, 0x49, 0x64, 0x12, 0x1d, 0x0a, 0x0a, 0x69,
-	0x73, 0x5f, 0x70, 0x72, 0x69, 0x6d, 0x61, 0x72, 0x79, 0x18, 0x02, 0x20, 0x01, 0x28, 0x08, 0x52,
-	0x09, 0x69, 0x73, 0x50, 0x72, 0x69, 0x6d, 0x61, 0x72, 0x79, 0x22, 0x6f, 0x0a, 0x0b, 0x43, 0x6f,
-	0x75, 0x72, 0x69, 0x65, 0x72, 0x5a, 0x6f, 0x6e, 0x65, 0x12, 0x17, 0x0a, 0x07, 0x7a, 0x6f, 0x6e,
-	0x65, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x7a, 0x6f, 0x6e, 0x65,
-	0x49, 0x64, 0x12, 0x1d, 0x0a, 0x0a, 0x69, 0x73, 0x5f, 0x70, 0x72, 0x69, 0x6d, 0x61, 0x72, 0x79,
-	0x18, 0x02, 0x20, 0x01, 0x28, 0x08, 0x52, 0x09, 0x69, 0x73, 0x50, 0x72, 0x69, 0x6d, 0x61, 0x72,
-	0x79, 0x12, 0x28, 0x0a, 0x10, 0x61, 0x73, 0x73, 0x69, 0x67, 0x6e, 0x65, 0x64, 0x5f, 0x61, 0x74,
-	0x5f, 0x75, 0x6e, 0x69, 0x78, 0x18, 0x03, 0x20, 0x01, 0x28, 0x03, 0x52, 0x0e, 0x61, 0x73, 0x73,
-	0x69, 0x67, 0x6e, 0x65, 0x64, 0x41, 0x74, 0x55, 0x6e, 0x69, 0x78, 0x22, 0xf2, 0x01, 0x0a, 0x07,
-	0x43, 0x6f, 0x75, 0x72, 0x69, 0x65, 0x72, 0x12, 0x0e, 0x0a, 0x02, 0x69, 0x64, 0x18, 0x01, 0x20,
-	0x01, 0x28, 0x09, 0x52, 0x02, 0x69, 0x64, 0x12, 0x14, 0x0a, 0x05, 0x70, 0x68, 0x6f, 0x6e, 0x65,
-	0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x70, 0x68, 0x6f, 0x6e, 0x65, 0x12, 0x1d, 0x0a,
-	0x0a, 0x66, 0x69, 0x72, 0x73, 0x74, 0x5f, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28,
-	0x09, 0x52, 0x09, 0x66, 0x69, 0x72, 0x73, 0x74, 0x4e, 0x61, 0x6d, 0x65, 0x12, 0x1b, 0x0a, 0x09,
-	0x6c, 0x61, 0x73, 0x74, 0x5f, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52,
-	0x08, 0x6c, 0x61, 0x73, 0x74, 0x4e, 0x61, 0x6d, 0x65, 0x12, 0x3d, 0x0a, 0x0c, 0x76, 0x65, 0x68,
-	0x69, 0x63, 0x6c, 0x65, 0x5f, 0x74, 0x79, 0x70, 0x65, 0x18, 0x05, 0x20, 0x01, 0x28, 0x0e, 0x32,
-	0x1a, 0x2e, 0x6f, 0x6d, 0x73, 0x2e, 0x76, 0x31, 0x2e, 0x43, 0x6f, 0x75, 0x72, 0x69, 0x65, 0x72,
-	0x56, 0x65, 0x68, 0x69, 0x63, 0x6c, 0x65, 0x54, 0x79, 0x70, 0x65, 0x52, 0x0b, 0x76, 0x65, 0x68,
-	0x69, 0x63, 0x6c, 0x65, 0x54, 0x79, 0x70, 0x65, 0x12, 0x1b, 0x0a, 0x09, 0x69, 0x73, 0x5f, 0x61,
-	0x63, 0x74, 0x69, 0x76, 0x65, 0x18, 0x06, 0x20, 0x01, 0x28, 0x08, 0x52, 0x08, 0x69, 0x73, 0x41,
-	0x63, 0x74, 0x69, 0x76, 0x65, 0x12, 0x29, 0x0a, 0x05, 0x7a, 0x6f, 0x6e, 0x65, 0x73, 0x18, 0x07,
-	0x20, 0x03, 0x28, 0x0b, 0x32, 0x13, 0x2e, 0x6f, 0x6d, 0x73, 0x2e, 0x76, 0x31, 0x2e, 0x43, 0x6f,
-	0x75, 0x72, 0x69, 0x65, 0x72, 0x5a, 0x6f, 0x6e, 0x65, 0x52, 0x05, 0x7a, 0x6f, 0x6e, 0x65, 0x73,
-	0x22, 0xe2, 0x01, 0x0a, 0x0b, 0x43, 0x6f, 0x75, 0x72, 0x69, 0x65, 0x72, 0x53, 0x6c, 0x6f, 0x74,
-	0x12, 0x0e, 0x0a, 0x02, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x02, 0x69, 0x64,
-	0x12, 0x1d, 0x0a, 0x0a, 0x63, 0x6f, 0x75, 0x72, 0x69, 0x65, 0x72, 0x5f, 0x69, 0x64, 0x18, 0x02,
-	0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x63, 0x6f, 0x75, 0x72, 0x69, 0x65, 0x72, 0x49, 0x64, 0x12,
-	0x26, 0x0a, 0x0f, 0x73, 0x6c, 0x6f, 0x74, 0x5f, 0x73, 0x74, 0x61, 0x72, 0x74, 0x5f, 0x75, 0x6e,
-	0x69, 0x78, 0x18, 0x03, 0x20, 0x01, 0x28, 0x03, 0x52, 0x0d, 0x73, 0x6c, 0x6f, 0x74, 0x53, 0x74,
-	0x61, 0x72, 0x74, 0x55, 0x6e, 0x69, 0x78, 0x12, 0x22, 0x0a, 0x0d, 0x73, 0x6c, 0x6f, 0x74, 0x5f,
-	0x65, 0x6e, 0x64, 0x5f, 0x75, 0x6e, 0x69, 0x78, 0x18, 0x04, 0x20, 0x01, 0x28, 0x03, 0x52, 0x0b,
-	0x73, 0x6c, 0x6f, 0x74, 0x45, 0x6e, 0x64, 0x55, 0x6e, 0x69, 0x78, 0x12, 0x25, 0x0a, 0x0e, 0x64,
-	0x75, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x5f, 0x68, 0x6f, 0x75, 0x72, 0x73, 0x18, 0x05, 0x20,
-	0x01, 0x28, 0x05, 0x52, 0x0d, 0x64, 0x75, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x48, 0x6f, 0x75,
-	0x72, 0x73, 0x12, 0x31, 0x0a, 0x06, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x18, 0x06, 0x20, 0x01,
-	0x28, 0x0e, 0x32, 0x19, 0x2e, 0x6f, 0x6d, 0x73, 0x2e, 0x76, 0x31, 0x2e, 0x43, 0x6f, 0x75, 0x72,
-	0x69, 0x65, 0x72, 0x53, 0x6c, 0x6f, 0x74, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x52, 0x06, 0x73,
-	0x74, 0x61, 0x74, 0x75, 0x73, 0x22, 0x80, 0x02, 0x0a, 0x18, 0x43, 0x6f, 0x75, 0x72, 0x69, 0x65,
-	0x72, 0x56, 0x65, 0x68, 0x69, 0x63, 0x6c, 0x65, 0x43, 0x61, 0x70, 0x61, 0x62, 0x69, 0x6c, 0x69,
-	0x74, 0x79, 0x12, 0x3d, 0x0a, 0x0c, 0x76, 0x65, 0x68, 0x69, 0x63, 0x6c, 0x65, 0x5f, 0x74, 0x79,
-	0x70, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x1a, 0x2e, 0x6f, 0x6d, 0x73, 0x2e, 0x76,
-	0x31, 0x2e, 0x43, 0x6f, 0x75, 0x72, 0x69, 0x65, 0x72, 0x56, 0x65, 0x68, 0x69, 0x63, 0x6c, 0x65,
-	0x54, 0x79, 0x70, 0x65, 0x52, 0x0b, 0x76, 0x65, 0x68, 0x69, 0x63, 0x6c, 0x65, 0x54, 0x79, 0x70,
-	0x65, 0x12, 0x28, 0x0a, 0x10, 0x6d, 0x61, 0x78, 0x5f, 0x77, 0x65, 0x69, 0x67, 0x68, 0x74, 0x5f,
-	0x67, 0x72, 0x61, 0x6d, 0x73, 0x18, 0x02, 0x20, 0x01, 0x28, 0x05, 0x52, 0x0e, 0x6d, 0x61, 0x78,
-	0x57, 0x65, 0x69, 0x67, 0x68, 0x74, 0x47, 0x72, 0x61, 0x6d, 0x73, 0x12, 0x24, 0x0a, 0x0e, 0x6d,
-	0x61, 0x78, 0x5f, 0x76, 0x6f, 0x6c, 0x75, 0x6d, 0x65, 0x5f, 0x63, 0x6d, 0x33, 0x18, 0x03, 0x20,
-	0x01, 0x28, 0x05, 0x52, 0x0c, 0x6d, 0x61, 0x78, 0x56, 0x6f, 0x6c, 0x75, 0x6d, 0x65, 0x43, 0x6d,
-	0x33, 0x12, 0x2d, 0x0a, 0x13, 0x6d, 0x61, 0x78, 0x5f, 0x6f, 0x72, 0x64, 0x65, 0x72, 0x73, 0x5f,
-	0x70, 0x65, 0x72, 0x5f, 0x74, 0x72, 0x69, 0x70, 0x18, 0x04, 0x20, 0x01, 0x28, 0x05, 0x52, 0x10,
-	0x6d, 0x61, 0x78, 0x4f, 0x72, 0x64, 0x65, 0x72, 0x73, 0x50, 0x65, 0x72, 0x54, 0x72, 0x69, 0x70,
-	0x12, 0x26, 0x0a, 0x0f, 0x75, 0x70, 0x64, 0x61, 0x74, 0x65, 0x64, 0x5f, 0x61, 0x74, 0x5f, 0x75,
-	0x6e, 0x69, 0x78, 0x18, 0x05, 0x20, 0x01, 0x28, 0x03, 0x52, 0x0d, 0x75, 0x70, 0x64, 0x61, 0x74,
-	0x65, 0x64, 0x41, 0x74, 0x55, 0x6e, 0x69, 0x78, 0x22, 0x7a, 0x0a, 0x12, 0x43, 0x72, 0x65, 0x61,
-	0x74, 0x65, 0x4f, 0x72, 0x64, 0x65, 0x72, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x1f,
-	0x0a, 0x0b, 0x63, 0x75, 0x73, 0x74, 0x6f, 0x6d, 0x65, 0x72, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20,
-	0x01, 0x28, 0x09, 0x52, 0x0a, 0x63, 0x75, 0x73, 0x74, 0x6f, 0x6d, 0x65, 0x72, 0x49, 0x64, 0x12,
-	0x27, 0x0a, 0x05, 0x69, 0x74, 0x65, 0x6d, 0x73, 0x18, 0x02, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x11,
-	0x2e, 0x6f, 0x6d, 0x73, 0x2e, 0x76, 0x31, 0x2e, 0x4f, 0x72, 0x64, 0x65, 0x72, 0x49, 0x74, 0x65,
-	0x6d, 0x52, 0x05, 0x69, 0x74, 0x65, 0x6d, 0x73, 0x12, 0x1a, 0x0a, 0x08, 0x63, 0x75, 0x72, 0x72,
-	0x65, 0x6e, 0x63, 0x79, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x63, 0x75, 0x72, 0x72,
-	0x65, 0x6e, 0x63, 0x79, 0x22, 0x3a, 0x0a, 0x13, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x4f, 0x72,
-	0x64, 0x65, 0x72, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x23, 0x0a, 0x05, 0x6f,
-	0x72, 0x64, 0x65, 0x72, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x0d, 0x2e, 0x6f, 0x6d, 0x73,
-	0x2e, 0x76, 0x31, 0x2e, 0x4f, 0x72, 0x64, 0x65, 0x72, 0x52, 0x05, 0x6f, 0x72, 0x64, 0x65, 0x72,
-	0x22, 0x2c, 0x0a, 0x0f, 0x47, 0x65, 0x74, 0x4f, 0x72, 0x64, 0x65, 0x72, 0x52, 0x65, 0x71, 0x75,
-	0x65, 0x73, 0x74, 0x12, 0x19, 0x0a, 0x08, 0x6f, 0x72, 0x64, 0x65, 0x72, 0x5f, 0x69, 0x64, 0x18,
-	0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x6f, 0x72, 0x64, 0x65, 0x72, 0x49, 0x64, 0x22, 0x6a,
-	0x0a, 0x10, 0x47, 0x65, 0x74, 0x4f, 0x72, 0x64, 0x65, 0x72, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e,
-	0x73, 0x65, 0x12, 0x23, 0x0a, 0x05, 0x6f, 0x72, 0x64, 0x65, 0x72, 0x18, 0x01, 0x20, 0x01, 0x28,
-	0x0b, 0x32, 0x0d, 0x2e, 0x6f, 0x6d, 0x73, 0x2e, 0x76, 0x31, 0x2e, 0x4f, 0x72, 0x64, 0x65, 0x72,
-	0x52, 0x05, 0x6f, 0x72, 0x64, 0x65, 0x72, 0x12, 0x31, 0x0a, 0x08, 0x74, 0x69, 0x6d, 0x65, 0x6c,
-	0x69, 0x6e, 0x65, 0x18, 0x02, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x15, 0x2e, 0x6f, 0x6d, 0x73, 0x2e,
-	0x76, 0x31, 0x2e, 0x54, 0x69, 0x6d, 0x65, 0x6c, 0x69, 0x6e, 0x65, 0x45, 0x76, 0x65, 0x6e, 0x74,
-	0x52, 0x08, 0x74, 0x69, 0x6d, 0x65, 0x6c, 0x69, 0x6e, 0x65, 0x22, 0xae, 0x01, 0x0a, 0x11, 0x4c,
-	0x69, 0x73, 0x74, 0x4f, 0x72, 0x64, 0x65, 0x72, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74,
-	0x12, 0x1f, 0x0a, 0x0b, 0x63, 0x75, 0x73, 0x74, 0x6f, 0x6d, 0x65, 0x72, 0x5f, 0x69, 0x64, 0x18,
-	0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0a, 0x63, 0x75, 0x73, 0x74, 0x6f, 0x6d, 0x65, 0x72, 0x49,
-	0x64, 0x12, 0x1b, 0x0a, 0x09, 0x70, 0x61, 0x67, 0x65, 0x5f, 0x73, 0x69, 0x7a, 0x65, 0x18, 0x02,
-	0x20, 0x01, 0x28, 0x05, 0x52, 0x08, 0x70, 0x61, 0x67, 0x65, 0x53, 0x69, 0x7a, 0x65, 0x12, 0x1d,
-	0x0a, 0x0a, 0x70, 0x61, 0x67, 0x65, 0x5f, 0x74, 0x6f, 0x6b, 0x65, 0x6e, 0x18, 0x03, 0x20, 0x01,
-	0x28, 0x09, 0x52, 0x09, 0x70, 0x61, 0x67, 0x65, 0x54, 0x6f, 0x6b, 0x65, 0x6e, 0x12, 0x3c, 0x0a,
-	0x0f, 0x66, 0x69, 0x6c, 0x74, 0x65, 0x72, 0x5f, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x65, 0x73,
-	0x18, 0x04, 0x20, 0x03, 0x28, 0x0e, 0x32, 0x13, 0x2e, 0x6f, 0x6d, 0x73, 0x2e, 0x76, 0x31, 0x2e,
-	0x4f, 0x72, 0x64, 0x65, 0x72, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x52, 0x0e, 0x66, 0x69, 0x6c,
-	0x74, 0x65, 0x72, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x65, 0x73, 0x22, 0x63, 0x0a, 0x12, 0x4c,
-	0x69, 0x73, 0x74, 0x4f, 0x72, 0x64, 0x65, 0x72, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73,
-	0x65, 0x12, 0x25, 0x0a, 0x06, 0x6f, 0x72, 0x64, 0x65, 0x72, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28,
-	0x0b, 0x32, 0x0d, 0x2e, 0x6f, 0x6d, 0x73, 0x2e, 0x76, 0x31, 0x2e, 0x4f, 0x72, 0x64, 0x65, 0x72,
-	0x52, 0x06, 0x6f, 0x72, 0x64, 0x65, 0x72, 0x73, 0x12, 0x26, 0x0a, 0x0f, 0x6e, 0x65, 0x78, 0x74,
-	0x5f, 0x70, 0x61, 0x67, 0x65, 0x5f, 0x74, 0x6f, 0x6b, 0x65, 0x6e, 0x18, 0x02, 0x20, 0x01, 0x28,
-	0x09, 0x52, 0x0d, 0x6e, 0x65, 0x78, 0x74, 0x50, 0x61, 0x67, 0x65, 0x54, 0x6f, 0x6b, 0x65, 0x6e,
-	0x22, 0x2c, 0x0a, 0x0f, 0x50, 0x61, 0x79, 0x4f, 0x72, 0x64, 0x65, 0x72, 0x52, 0x65, 0x71, 0x75,
-	0x65, 0x73, 0x74, 0x12, 0x19, 0x0a, 0x08, 0x6f, 0x72, 0x64, 0x65, 0x72, 0x5f, 0x69, 0x64, 0x18,
-	0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x6f, 0x72, 0x64, 0x65, 0x72, 0x49, 0x64, 0x22, 0x5a,
-	0x0a, 0x10, 0x50, 0x61, 0x79, 0x4f, 0x72, 0x64, 0x65, 0x72, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e,
-	0x73, 0x65, 0x12, 0x19, 0x0a, 0x08, 0x6f, 0x72, 0x64, 0x65, 0x72, 0x5f, 0x69, 0x64, 0x18, 0x01,
-	0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x6f, 0x72, 0x64, 0x65, 0x72, 0x49, 0x64, 0x12, 0x2b, 0x0a,
-	0x06, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x13, 0x2e,
-	0x6f, 0x6d, 0x73, 0x2e, 0x76, 0x31, 0x2e, 0x4f, 0x72, 0x64, 0x65, 0x72, 0x53, 0x74, 0x61, 0x74,
-	0x75, 0x73, 0x52, 0x06, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x22, 0x47, 0x0a, 0x12, 0x43, 0x61,
-	0x6e, 0x63, 0x65, 0x6c, 0x4f, 0x72, 0x64, 0x65, 0x72, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74,
-	0x12, 0x19, 0x0a, 0x08, 0x6f, 0x72, 0x64, 0x65, 0x72, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01,
-	0x28, 0x09, 0x52, 0x07, 0x6f, 0x72, 0x64, 0x65, 0x72, 0x49, 0x64, 0x12, 0x16, 0x0a, 0x06, 0x72,
-	0x65, 0x61, 0x73, 0x6f, 0x6e, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x72, 0x65, 0x61,
-	0x73, 0x6f, 0x6e, 0x22, 0x5d, 0x0a, 0x13, 0x43, 0x61, 0x6e, 0x63, 0x65, 0x6c, 0x4f, 0x72, 0x64,
-	0x65, 0x72, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x19, 0x0a, 0x08, 0x6f, 0x72,
-	0x64, 0x65, 0x72, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x6f, 0x72,
-	0x64, 0x65, 0x72, 0x49, 0x64, 0x12, 0x2b, 0x0a, 0x06, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x18,
-	0x02, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x13, 0x2e, 0x6f, 0x6d, 0x73, 0x2e, 0x76, 0x31, 0x2e, 0x4f,
-	0x72, 0x64, 0x65, 0x72, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x52, 0x06, 0x73, 0x74, 0x61, 0x74,
-	0x75, 0x73, 0x22, 0x6e, 0x0a, 0x12, 0x52, 0x65, 0x66, 0x75, 0x6e, 0x64, 0x4f, 0x72, 0x64, 0x65,
-	0x72, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x19, 0x0a, 0x08, 0x6f, 0x72, 0x64, 0x65,
-	0x72, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x6f, 0x72, 0x64, 0x65,
-	0x72, 0x49, 0x64, 0x12, 0x25, 0x0a, 0x06, 0x61, 0x6d, 0x6f, 0x75, 0x6e, 0x74, 0x18, 0x02, 0x20,
-	0x01, 0x28, 0x0b, 0x32, 0x0d, 0x2e, 0x6f, 0x6d, 0x73, 0x2e, 0x76, 0x31, 0x2e, 0x4d, 0x6f, 0x6e,
-	0x65, 0x79, 0x52, 0x06, 0x61, 0x6d, 0x6f, 0x75, 0x6e, 0x74, 0x12, 0x16, 0x0a, 0x06, 0x72, 0x65,
-	0x61, 0x73, 0x6f, 0x6e, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x72, 0x65, 0x61, 0x73,
-	0x6f, 0x6e, 0x22, 0x5d, 0x0a, 0x13, 0x52, 0x65, 0x66, 0x75, 0x6e, 0x64, 0x4f, 0x72, 0x64, 0x65,
-	0x72, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x19, 0x0a, 0x08, 0x6f, 0x72, 0x64,
-	0x65, 0x72, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x6f, 0x72, 0x64,
-	0x65, 0x72, 0x49, 0x64, 0x12, 0x2b, 0x0a, 0x06, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x18, 0x02,
-	0x20, 0x01, 0x28, 0x0e, 0x32, 0x13, 0x2e, 0x6f, 0x6d, 0x73, 0x2e, 0x76, 0x31, 0x2e, 0x4f, 0x72,
-	0x64, 0x65, 0x72, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x52, 0x06, 0x73, 0x74, 0x61, 0x74, 0x75,
-	0x73, 0x22, 0xf8, 0x01, 0x0a, 0x16, 0x52, 0x65, 0x67, 0x69, 0x73, 0x74, 0x65, 0x72, 0x43, 0x6f,
-	0x75, 0x72, 0x69, 0x65, 0x72, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x1d, 0x0a, 0x0a,
-	0x63, 0x6f, 0x75, 0x72, 0x69, 0x65, 0x72, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09,
-	0x52, 0x09, 0x63, 0x6f, 0x75, 0x72, 0x69, 0x65, 0x72, 0x49, 0x64, 0x12, 0x14, 0x0a, 0x05, 0x70,
-	0x68, 0x6f, 0x6e, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x70, 0x68, 0x6f, 0x6e,
-	0x65, 0x12, 0x1d, 0x0a, 0x0a, 0x66, 0x69, 0x72, 0x73, 0x74, 0x5f, 0x6e, 0x61, 0x6d, 0x65, 0x18,
-	0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x66, 0x69, 0x72, 0x73, 0x74, 0x4e, 0x61, 0x6d, 0x65,
-	0x12, 0x1b, 0x0a, 0x09, 0x6c, 0x61, 0x73, 0x74, 0x5f, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x04, 0x20,
-	0x01, 0x28, 0x09, 0x52, 0x08, 0x6c, 0x61, 0x73, 0x74, 0x4e, 0x61, 0x6d, 0x65, 0x12, 0x3d, 0x0a,
-	0x0c, 0x76, 0x65, 0x68, 0x69, 0x63, 0x6c, 0x65, 0x5f, 0x74, 0x79, 0x70, 0x65, 0x18, 0x05, 0x20,
-	0x01, 0x28, 0x0e, 0x32, 0x1a, 0x2e, 0x6f, 0x6d, 0x73, 0x2e, 0x76, 0x31, 0x2e, 0x43, 0x6f, 0x75,
-	0x72, 0x69, 0x65, 0x72, 0x56, 0x65, 0x68, 0x69, 0x63, 0x6c, 0x65, 0x54, 0x79, 0x70, 0x65, 0x52,
-	0x0b, 0x76, 0x65, 0x68, 0x69, 0x63, 0x6c, 0x65, 0x54, 0x79, 0x70, 0x65, 0x12, 0x2e, 0x0a, 0x05,
-	0x7a, 0x6f, 0x6e, 0x65, 0x73, 0x18, 0x06, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x18, 0x2e, 0x6f, 0x6d,
-	0x73, 0x2e, 0x76, 0x31, 0x2e, 0x43, 0x6f, 0x75, 0x72, 0x69, 0x65, 0x72, 0x5a, 0x6f, 0x6e, 0x65,
-	0x49, 0x6e, 0x70, 0x75, 0x74, 0x52, 0x05, 0x7a, 0x6f, 0x6e, 0x65, 0x73, 0x22, 0x44, 0x0a, 0x17,
-	0x52, 0x65, 0x67, 0x69, 0x73, 0x74, 0x65, 0x72, 0x43, 0x6f, 0x75, 0x72, 0x69, 0x65, 0x72, 0x52,
-	0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x29, 0x0a, 0x07, 0x63, 0x6f, 0x75, 0x72, 0x69,
-	0x65, 0x72, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x0f, 0x2e, 0x6f, 0x6d, 0x73, 0x2e, 0x76,
-	0x31, 0x2e, 0x43, 0x6f, 0x75, 0x72, 0x69, 0x65, 0x72, 0x52, 0x07, 0x63, 0x6f, 0x75, 0x72, 0x69,
-	0x65, 0x72, 0x22, 0x32, 0x0a, 0x11, 0x47, 0x65, 0x74, 0x43, 0x6f, 0x75, 0x72, 0x69, 0x65, 0x72,
-	0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x1d, 0x0a, 0x0a, 0x63, 0x6f, 0x75, 0x72, 0x69,
-	0x65, 0x72, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x63, 0x6f, 0x75,
-	0x72, 0x69, 0x65, 0x72, 0x49, 0x64, 0x22, 0x3f, 0x0a, 0x12, 0x47, 0x65, 0x74, 0x43, 0x6f, 0x75,
-	0x72, 0x69, 0x65, 0x72, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x29, 0x0a, 0x07,
-	0x63, 0x6f, 0x75, 0x72, 0x69, 0x65, 0x72, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x0f, 0x2e,
-	0x6f, 0x6d, 0x73, 0x2e, 0x76, 0x31, 0x2e, 0x43, 0x6f, 0x75, 0x72, 0x69, 0x65, 0x72, 0x52, 0x07,
-	0x63, 0x6f, 0x75, 0x72, 0x69, 0x65, 0x72, 0x22, 0x4a, 0x0a, 0x19, 0x4c, 0x69, 0x73, 0x74, 0x43,
-	0x6f, 0x75, 0x72, 0x69, 0x65, 0x72, 0x73, 0x42, 0x79, 0x5a, 0x6f, 0x6e, 0x65, 0x52, 0x65, 0x71,
-	0x75, 0x65, 0x73, 0x74, 0x12, 0x17, 0x0a, 0x07, 0x7a, 0x6f, 0x6e, 0x65, 0x5f, 0x69, 0x64, 0x18,
-	0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x7a, 0x6f, 0x6e, 0x65, 0x49, 0x64, 0x12, 0x14, 0x0a,
-	0x05, 0x6c, 0x69, 0x6d, 0x69, 0x74, 0x18, 0x02, 0x20, 0x01, 0x28, 0x05, 0x52, 0x05, 0x6c, 0x69,
-	0x6d, 0x69, 0x74, 0x22, 0x49, 0x0a, 0x1a, 0x4c, 0x69, 0x73, 0x74, 0x43, 0x6f, 0x75, 0x72, 0x69,
-	0x65, 0x72, 0x73, 0x42, 0x79, 0x5a, 0x6f, 0x6e, 0x65, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73,
-	0x65, 0x12, 0x2b, 0x0a, 0x08, 0x63, 0x6f, 0x75, 0x72, 0x69, 0x65, 0x72, 0x73, 0x18, 0x01, 0x20,
-	0x03, 0x28, 0x0b, 0x32, 0x0f, 0x2e, 0x6f, 0x6d, 0x73, 0x2e, 0x76, 0x31, 0x2e, 0x43, 0x6f, 0x75,
-	0x72, 0x69, 0x65, 0x72, 0x52, 0x08, 0x63, 0x6f, 0x75, 0x72, 0x69, 0x65, 0x72, 0x73, 0x22, 0x6b,
-	0x0a, 0x1a, 0x52, 0x65, 0x70, 0x6c, 0x61, 0x63, 0x65, 0x43, 0x6f, 0x75, 0x72, 0x69, 0x65, 0x72,
-	0x5a, 0x6f, 0x6e, 0x65, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x1d, 0x0a, 0x0a,
-	0x63, 0x6f, 0x75, 0x72, 0x69, 0x65, 0x72, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09,
-	0x52, 0x09, 0x63, 0x6f, 0x75, 0x72, 0x69, 0x65, 0x72, 0x49, 0x64, 0x12, 0x2e, 0x0a, 0x05, 0x7a,
-	0x6f, 0x6e, 0x65, 0x73, 0x18, 0x02, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x18, 0x2e, 0x6f, 0x6d, 0x73,
-	0x2e, 0x76, 0x31, 0x2e, 0x43, 0x6f, 0x75, 0x72, 0x69, 0x65, 0x72, 0x5a, 0x6f, 0x6e, 0x65, 0x49,
-	0x6e, 0x70, 0x75, 0x74, 0x52, 0x05, 0x7a, 0x6f, 0x6e, 0x65, 0x73, 0x22, 0x67, 0x0a, 0x1b, 0x52,
-	0x65, 0x70, 0x6c, 0x61, 0x63, 0x65, 0x43, 0x6f, 0x75, 0x72, 0x69, 0x65, 0x72, 0x5a, 0x6f, 0x6e,
-	0x65, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x1d, 0x0a, 0x0a, 0x63, 0x6f,
-	0x75, 0x72, 0x69, 0x65, 0x72, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09,
-	0x63, 0x6f, 0x75, 0x72, 0x69, 0x65, 0x72, 0x49, 0x64, 0x12, 0x29, 0x0a, 0x05, 0x7a, 0x6f, 0x6e,
-	0x65, 0x73, 0x18, 0x02, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x13, 0x2e, 0x6f, 0x6d, 0x73, 0x2e, 0x76,
-	0x31, 0x2e, 0x43, 0x6f, 0x75, 0x72, 0x69, 0x65, 0x72, 0x5a, 0x6f, 0x6e, 0x65, 0x52, 0x05, 0x7a,
-	0x6f, 0x6e, 0x65, 0x73, 0x22, 0xc5, 0x01, 0x0a, 0x18, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x43,
-	0x6f, 0x75, 0x72, 0x69, 0x65, 0x72, 0x53, 0x6c, 0x6f, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73,
-	0x74, 0x12, 0x17, 0x0a, 0x07, 0x73, 0x6c, 0x6f, 0x74, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01,
-	0x28, 0x09, 0x52, 0x06, 0x73, 0x6c, 0x6f, 0x74, 0x49, 0x64, 0x12, 0x1d, 0x0a, 0x0a, 0x63, 0x6f,
-	0x75, 0x72, 0x69, 0x65, 0x72, 0x5f, 0x69, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09,
-	0x63, 0x6f, 0x75, 0x72, 0x69, 0x65, 0x72, 0x49, 0x64, 0x12, 0x26, 0x0a, 0x0f, 0x73, 0x6c, 0x6f,
-	0x74, 0x5f, 0x73, 0x74, 0x61, 0x72, 0x74, 0x5f, 0x75, 0x6e, 0x69, 0x78, 0x18, 0x03, 0x20, 0x01,
-	0x28, 0x03, 0x52, 0x0d, 0x73, 0x6c, 0x6f, 0x74, 0x53, 0x74, 0x61, 0x72, 0x74, 0x55, 0x6e, 0x69,
-	0x78, 0x12, 0x22, 0x0a, 0x0d, 0x73, 0x6c, 0x6f, 0x74, 0x5f, 0x65, 0x6e, 0x64, 0x5f, 0x75, 0x6e,
-	0x69, 0x78, 0x18, 0x04, 0x20, 0x01, 0x28, 0x03, 0x52, 0x0b, 0x73, 0x6c, 0x6f, 0x74, 0x45, 0x6e,
-	0x64, 0x55, 0x6e, 0x69, 0x78, 0x12, 0x25, 0x0a, 0x0e, 0x64, 0x75, 0x72, 0x61, 0x74, 0x69, 0x6f,
-	0x6e, 0x5f, 0x68, 0x6f, 0x75, 0x72, 0x73, 0x18, 0x05, 0x20, 0x01, 0x28, 0x05, 0x52, 0x0d, 0x64,
-	0x75, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x48, 0x6f, 0x75, 0x72, 0x73, 0x22, 0x44, 0x0a, 0x19,
-	0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x43, 0x6f, 0x75, 0x72, 0x69, 0x65, 0x72, 0x53, 0x6c, 0x6f,
-	0x74, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x27, 0x0a, 0x04, 0x73, 0x6c, 0x6f,
-	0x74, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x13, 0x2e, 0x6f, 0x6d, 0x73, 0x2e, 0x76, 0x31,
-	0x2e, 0x43, 0x6f, 0x75, 0x72, 0x69, 0x65, 0x72, 0x53, 0x6c, 0x6f, 0x74, 0x52, 0x04, 0x73, 0x6c,
-	0x6f, 0x74, 0x22, 0x6e, 0x0a, 0x17, 0x4c, 0x69, 0x73, 0x74, 0x43, 0x6f, 0x75, 0x72, 0x69, 0x65,
-	0x72, 0x53, 0x6c, 0x6f, 0x74, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x1d, 0x0a,
-	0x0a, 0x63, 0x6f, 0x75, 0x72, 0x69, 0x65, 0x72, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28,
-	0x09, 0x52, 0x09, 0x63, 0x6f, 0x75, 0x72, 0x69, 0x65, 0x72, 0x49, 0x64, 0x12, 0x1b, 0x0a, 0x09,
-	0x66, 0x72, 0x6f, 0x6d, 0x5f, 0x75, 0x6e, 0x69, 0x78, 0x18, 0x02, 0x20, 0x01, 0x28, 0x03, 0x52,
-	0x08, 0x66, 0x72, 0x6f, 0x6d, 0x55, 0x6e, 0x69, 0x78, 0x12, 0x17, 0x0a, 0x07, 0x74, 0x6f, 0x5f,
-	0x75, 0x6e, 0x69, 0x78, 0x18, 0x03, 0x20, 0x01, 0x28, 0x03, 0x52, 0x06, 0x74, 0x6f, 0x55, 0x6e,
-	0x69, 0x78, 0x22, 0x45, 0x0a, 0x18, 0x4c, 0x69, 0x73, 0x74, 0x43, 0x6f, 0x75, 0x72, 0x69, 0x65,
-	0x72, 0x53, 0x6c, 0x6f, 0x74, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x29,
-	0x0a, 0x05, 0x73, 0x6c, 0x6f, 0x74, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x13, 0x2e,
-	0x6f, 0x6d, 0x73, 0x2e, 0x76, 0x31, 0x2e, 0x43, 0x6f, 0x75, 0x72, 0x69, 0x65, 0x72, 0x53, 0x6c,
-	0x6f, 0x74, 0x52, 0x05, 0x73, 0x6c, 0x6f, 0x74, 0x73, 0x22, 0x63, 0x0a, 0x22, 0x47, 0x65, 0x74,
-	0x43, 0x6f, 0x75, 0x72, 0x69, 0x65, 0x72, 0x56, 0x65, 0x68, 0x69, 0x63, 0x6c, 0x65, 0x43, 0x61,
-	0x70, 0x61, 0x62, 0x69, 0x6c, 0x69, 0x74, 0x79, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12,
-	0x3d, 0x0a, 0x0c, 0x76, 0x65, 0x68, 0x69, 0x63, 0x6c, 0x65, 0x5f, 0x74, 0x79, 0x70, 0x65, 0x18,
-	0x01, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x1a, 0x2e, 0x6f, 0x6d, 0x73, 0x2e, 0x76, 0x31, 0x2e, 0x43,
-	0x6f, 0x75, 0x72, 0x69, 0x65, 0x72, 0x56, 0x65, 0x68, 0x69, 0x63, 0x6c, 0x65, 0x54, 0x79, 0x70,
-	0x65, 0x52, 0x0b, 0x76, 0x65, 0x68, 0x69, 0x63, 0x6c, 0x65, 0x54, 0x79, 0x70, 0x65, 0x22, 0x67,
-	0x0a, 0x23, 0x47, 0x65, 0x74, 0x43, 0x6f, 0x75, 0x72, 0x69, 0x65, 0x72, 0x56, 0x65, 0x68, 0x69,
-	0x63, 0x6c, 0x65, 0x43, 0x61, 0x70, 0x61, 0x62, 0x69, 0x6c, 0x69, 0x74, 0x79, 0x52, 0x65, 0x73,
-	0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x40, 0x0a, 0x0a, 0x63, 0x61, 0x70, 0x61, 0x62, 0x69, 0x6c,
-	0x69, 0x74, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x20, 0x2e, 0x6f, 0x6d, 0x73, 0x2e,
-	0x76, 0x31, 0x2e, 0x43, 0x6f, 0x75, 0x72, 0x69, 0x65, 0x72, 0x56, 0x65, 0x68, 0x69, 0x63, 0x6c,
-	0x65, 0x43, 0x61, 0x70, 0x61, 0x62, 0x69, 0x6c, 0x69, 0x74, 0x79, 0x52, 0x0a, 0x63, 0x61, 0x70,
-	0x61, 0x62, 0x69, 0x6c, 0x69, 0x74, 0x79, 0x22, 0x27, 0x0a, 0x25, 0x4c, 0x69, 0x73, 0x74, 0x43,
-	0x6f, 0x75, 0x72, 0x69, 0x65, 0x72, 0x56, 0x65, 0x68, 0x69, 0x63, 0x6c, 0x65, 0x43, 0x61, 0x70,
-	0x61, 0x62, 0x69, 0x6c, 0x69, 0x74, 0x69, 0x65, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74,
-	0x22, 0x6e, 0x0a, 0x26, 0x4c, 0x69, 0x73, 0x74, 0x43, 0x6f, 0x75, 0x72, 0x69, 0x65, 0x72, 0x56,
-	0x65, 0x68, 0x69, 0x63, 0x6c, 0x65, 0x43, 0x61, 0x70, 0x61, 0x62, 0x69, 0x6c, 0x69, 0x74, 0x69,
-	0x65, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x44, 0x0a, 0x0c, 0x63, 0x61,
-	0x70, 0x61, 0x62, 0x69, 0x6c, 0x69, 0x74, 0x69, 0x65, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b,
-	0x32, 0x20, 0x2e, 0x6f, 0x6d, 0x73, 0x2e, 0x76, 0x31, 0x2e, 0x43, 0x6f, 0x75, 0x72, 0x69, 0x65,
-	0x72, 0x56, 0x65, 0x68, 0x69, 0x63, 0x6c, 0x65, 0x43, 0x61, 0x70, 0x61, 0x62, 0x69, 0x6c, 0x69,
-	0x74, 0x79, 0x52, 0x0c, 0x63, 0x61, 0x70, 0x61, 0x62, 0x69, 0x6c, 0x69, 0x74, 0x69, 0x65, 0x73,
-	0x22, 0xb6, 0x01, 0x0a, 0x1a, 0x53, 0x75, 0x62, 0x6d, 0x69, 0x74, 0x43, 0x6f, 0x75, 0x72, 0x69,
-	0x65, 0x72, 0x52, 0x61, 0x74, 0x69, 0x6e, 0x67, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12,
-	0x1b, 0x0a, 0x09, 0x72, 0x61, 0x74, 0x69, 0x6e, 0x67, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01,
-	0x28, 0x09, 0x52, 0x08, 0x72, 0x61, 0x74, 0x69, 0x6e, 0x67, 0x49, 0x64, 0x12, 0x1d, 0x0a, 0x0a,
-	0x63, 0x6f, 0x75, 0x72, 0x69, 0x65, 0x72, 0x5f, 0x69, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09,
-	0x52, 0x09, 0x63, 0x6f, 0x75, 0x72, 0x69, 0x65, 0x72, 0x49, 0x64, 0x12, 0x14, 0x0a, 0x05, 0x73,
-	0x63, 0x6f, 0x72, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x05, 0x52, 0x05, 0x73, 0x63, 0x6f, 0x72,
-	0x65, 0x12, 0x2c, 0x0a, 0x04, 0x74, 0x61, 0x67, 0x73, 0x18, 0x04, 0x20, 0x03, 0x28, 0x0e, 0x32,
-	0x18, 0x2e, 0x6f, 0x6d, 0x73, 0x2e, 0x76, 0x31, 0x2e, 0x43, 0x6f, 0x75, 0x72, 0x69, 0x65, 0x72,
-	0x52, 0x61, 0x74, 0x69, 0x6e, 0x67, 0x54, 0x61, 0x67, 0x52, 0x04, 0x74, 0x61, 0x67, 0x73, 0x12,
-	0x18, 0x0a, 0x07, 0x63, 0x6f, 0x6d, 0x6d, 0x65, 0x6e, 0x74, 0x18, 0x05, 0x20, 0x01, 0x28, 0x09,
-	0x52, 0x07, 0x63, 0x6f, 0x6d, 0x6d, 0x65, 0x6e, 0x74, 0x22, 0x59, 0x0a, 0x1b, 0x53, 0x75, 0x62,
-	0x6d, 0x69, 0x74, 0x43, 0x6f, 0x75, 0x72, 0x69, 0x65, 0x72, 0x52, 0x61, 0x74, 0x69, 0x6e, 0x67,
-	0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x1b, 0x0a, 0x09, 0x72, 0x61, 0x74, 0x69,
-	0x6e, 0x67, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x72, 0x61, 0x74,
-	0x69, 0x6e, 0x67, 0x49, 0x64, 0x12, 0x1d, 0x0a, 0x0a, 0x63, 0x6f, 0x75, 0x72, 0x69, 0x65, 0x72,
-	0x5f, 0x69, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x63, 0x6f, 0x75, 0x72, 0x69,
-	0x65, 0x72, 0x49, 0x64, 0x22, 0x3f, 0x0a, 0x1e, 0x47, 0x65, 0x74, 0x43, 0x6f, 0x75, 0x72, 0x69,
-	0x65, 0x72, 0x52, 0x61, 0x74, 0x69, 0x6e, 0x67, 0x53, 0x75, 0x6d, 0x6d, 0x61, 0x72, 0x79, 0x52,
-	0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x1d, 0x0a, 0x0a, 0x63, 0x6f, 0x75, 0x72, 0x69, 0x65,
-	0x72, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x63, 0x6f, 0x75, 0x72,
-	0x69, 0x65, 0x72, 0x49, 0x64, 0x22, 0x8a, 0x05, 0x0a, 0x14, 0x43, 0x6f, 0x75, 0x72, 0x69, 0x65,
-	0x72, 0x52, 0x61, 0x74, 0x69, 0x6e, 0x67, 0x53, 0x75, 0x6d, 0x6d, 0x61, 0x72, 0x79, 0x12, 0x1d,
-	0x0a, 0x0a, 0x63, 0x6f, 0x75, 0x72, 0x69, 0x65, 0x72, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01,
-	0x28, 0x09, 0x52, 0x09, 0x63, 0x6f, 0x75, 0x72, 0x69, 0x65, 0x72, 0x49, 0x64, 0x12, 0x23, 0x0a,
-	0x0d, 0x72, 0x61, 0x74, 0x69, 0x6e, 0x67, 0x73, 0x5f, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x18, 0x02,
-	0x20, 0x01, 0x28, 0x03, 0x52, 0x0c, 0x72, 0x61, 0x74, 0x69, 0x6e, 0x67, 0x73, 0x43, 0x6f, 0x75,
-	0x6e, 0x74, 0x12, 0x23, 0x0a, 0x0d, 0x61, 0x76, 0x65, 0x72, 0x61, 0x67, 0x65, 0x5f, 0x73, 0x63,
-	0x6f, 0x72, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x01, 0x52, 0x0c, 0x61, 0x76, 0x65, 0x72, 0x61,
-	0x67, 0x65, 0x53, 0x63, 0x6f, 0x72, 0x65, 0x12, 0x2a, 0x0a, 0x11, 0x6c, 0x6f, 0x77, 0x5f, 0x72,
-	0x61, 0x74, 0x69, 0x6e, 0x67, 0x73, 0x5f, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x18, 0x04, 0x20, 0x01,
-	0x28, 0x03, 0x52, 0x0f, 0x6c, 0x6f, 0x77, 0x52, 0x61, 0x74, 0x69, 0x6e, 0x67, 0x73, 0x43, 0x6f,
-	0x75, 0x6e, 0x74, 0x12, 0x22, 0x0a, 0x0d, 0x73, 0x63, 0x6f, 0x72, 0x65, 0x5f, 0x31, 0x5f, 0x63,
-	0x6f, 0x75, 0x6e, 0x74, 0x18, 0x05, 0x20, 0x01, 0x28, 0x03, 0x52, 0x0b, 0x73, 0x63, 0x6f, 0x72,
-	0x65, 0x31, 0x43, 0x6f, 0x75, 0x6e, 0x74, 0x12, 0x22, 0x0a, 0x0d, 0x73, 0x63, 0x6f, 0x72, 0x65,
-	0x5f, 0x32, 0x5f, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x18, 0x06, 0x20, 0x01, 0x28, 0x03, 0x52, 0x0b,
-	0x73, 0x63, 0x6f, 0x72, 0x65, 0x32, 0x43, 0x6f, 0x75, 0x6e, 0x74, 0x12, 0x22, 0x0a, 0x0d, 0x73,
-	0x63, 0x6f, 0x72, 0x65, 0x5f, 0x33, 0x5f, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x18, 0x07, 0x20, 0x01,
-	0x28, 0x03, 0x52, 0x0b, 0x73, 0x63, 0x6f, 0x72, 0x65, 0x33, 0x43, 0x6f, 0x75, 0x6e, 0x74, 0x12,
-	0x22, 0x0a, 0x0d, 0x73, 0x63, 0x6f, 0x72, 0x65, 0x5f, 0x34, 0x5f, 0x63, 0x6f, 0x75, 0x6e, 0x74,
-	0x18, 0x08, 0x20, 0x01, 0x28, 0x03, 0x52, 0x0b, 0x73, 0x63, 0x6f, 0x72, 0x65, 0x34, 0x43, 0x6f,
-	0x75, 0x6e, 0x74, 0x12, 0x22, 0x0a, 0x0d, 0x73, 0x63, 0x6f, 0x72, 0x65, 0x5f, 0x35, 0x5f, 0x63,
-	0x6f, 0x75, 0x6e, 0x74, 0x18, 0x09, 0x20, 0x01, 0x28, 0x03, 0x52, 0x0b, 0x73, 0x63, 0x6f, 0x72,
-	0x65, 0x35, 0x43, 0x6f, 0x75, 0x6e, 0x74, 0x12, 0x28, 0x0a, 0x10, 0x6c, 0x61, 0x73, 0x74, 0x5f,
-	0x72, 0x61, 0x74, 0x69, 0x6e, 0x67, 0x5f, 0x75, 0x6e, 0x69, 0x78, 0x18, 0x0a, 0x20, 0x01, 0x28,
-	0x03, 0x52, 0x0e, 0x6c, 0x61, 0x73, 0x74, 0x52, 0x61, 0x74, 0x69, 0x6e, 0x67, 0x55, 0x6e, 0x69,
-	0x78, 0x12, 0x22, 0x0a, 0x0d, 0x6f, 0x6e, 0x5f, 0x74, 0x69, 0x6d, 0x65, 0x5f, 0x63, 0x6f, 0x75,
-	0x6e, 0x74, 0x18, 0x0b, 0x20, 0x01, 0x28, 0x03, 0x52, 0x0b, 0x6f, 0x6e, 0x54, 0x69, 0x6d, 0x65,
-	0x43, 0x6f, 0x75, 0x6e, 0x74, 0x12, 0x21, 0x0a, 0x0c, 0x70, 0x6f, 0x6c, 0x69, 0x74, 0x65, 0x5f,
-	0x63, 0x6f, 0x75, 0x6e, 0x74, 0x18, 0x0c, 0x20, 0x01, 0x28, 0x03, 0x52, 0x0b, 0x70, 0x6f, 0x6c,
-	0x69, 0x74, 0x65, 0x43, 0x6f, 0x75, 0x6e, 0x74, 0x12, 0x23, 0x0a, 0x0d, 0x63, 0x61, 0x72, 0x65,
-	0x66, 0x75, 0x6c, 0x5f, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x18, 0x0d, 0x20, 0x01, 0x28, 0x03, 0x52,
-	0x0c, 0x63, 0x61, 0x72, 0x65, 0x66, 0x75, 0x6c, 0x43, 0x6f, 0x75, 0x6e, 0x74, 0x12, 0x23, 0x0a,
-	0x0d, 0x64, 0x65, 0x6c, 0x61, 0x79, 0x65, 0x64, 0x5f, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x18, 0x0e,
-	0x20, 0x01, 0x28, 0x03, 0x52, 0x0c, 0x64, 0x65, 0x6c, 0x61, 0x79, 0x65, 0x64, 0x43, 0x6f, 0x75,
-	0x6e, 0x74, 0x12, 0x1d, 0x0a, 0x0a, 0x72, 0x75, 0x64, 0x65, 0x5f, 0x63, 0x6f, 0x75, 0x6e, 0x74,
-	0x18, 0x0f, 0x20, 0x01, 0x28, 0x03, 0x52, 0x09, 0x72, 0x75, 0x64, 0x65, 0x43, 0x6f, 0x75, 0x6e,
-	0x74, 0x12, 0x23, 0x0a, 0x0d, 0x64, 0x61, 0x6d, 0x61, 0x67, 0x65, 0x64, 0x5f, 0x63, 0x6f, 0x75,
-	0x6e, 0x74, 0x18, 0x10, 0x20, 0x01, 0x28, 0x03, 0x52, 0x0c, 0x64, 0x61, 0x6d, 0x61, 0x67, 0x65,
-	0x64, 0x43, 0x6f, 0x75, 0x6e, 0x74, 0x12, 0x2a, 0x0a, 0x11, 0x6f, 0x74, 0x68, 0x65, 0x72, 0x5f,
-	0x69, 0x73, 0x73, 0x75, 0x65, 0x5f, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x18, 0x11, 0x20, 0x01, 0x28,
-	0x03, 0x52, 0x0f, 0x6f, 0x74, 0x68, 0x65, 0x72, 0x49, 0x73, 0x73, 0x75, 0x65, 0x43, 0x6f, 0x75,
-	0x6e, 0x74, 0x22, 0x59, 0x0a, 0x1f, 0x47, 0x65, 0x74, 0x43, 0x6f, 0x75, 0x72, 0x69, 0x65, 0x72,
-	0x52, 0x61, 0x74, 0x69, 0x6e, 0x67, 0x53, 0x75, 0x6d, 0x6d, 0x61, 0x72, 0x79, 0x52, 0x65, 0x73,
-	0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x36, 0x0a, 0x07, 0x73, 0x75, 0x6d, 0x6d, 0x61, 0x72, 0x79,
-	0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1c, 0x2e, 0x6f, 0x6d, 0x73, 0x2e, 0x76, 0x31, 0x2e,
-	0x43, 0x6f, 0x75, 0x72, 0x69, 0x65, 0x72, 0x52, 0x61, 0x74, 0x69, 0x6e, 0x67, 0x53, 0x75, 0x6d,
-	0x6d, 0x61, 0x72, 0x79, 0x52, 0x07, 0x73, 0x75, 0x6d, 0x6d, 0x61, 0x72, 0x79, 0x2a, 0xc9, 0x01,
-	0x0a, 0x0b, 0x4f, 0x72, 0x64, 0x65, 0x72, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x12, 0x1c, 0x0a,
-	0x18, 0x4f, 0x52, 0x44, 0x45, 0x52, 0x5f, 0x53, 0x54, 0x41, 0x54, 0x55, 0x53, 0x5f, 0x55, 0x4e,
-	0x53, 0x50, 0x45, 0x43, 0x49, 0x46, 0x49, 0x45, 0x44, 0x10, 0x00, 0x12, 0x18, 0x0a, 0x14, 0x4f,
-	0x52, 0x44, 0x45, 0x52, 0x5f, 0x53, 0x54, 0x41, 0x54, 0x55, 0x53, 0x5f, 0x50, 0x45, 0x4e, 0x44,
-	0x49, 0x4e, 0x47, 0x10, 0x01, 0x12, 0x19, 0x0a, 0x15, 0x4f, 0x52, 0x44, 0x45, 0x52, 0x5f, 0x53,
-	0x54, 0x41, 0x54, 0x55, 0x53, 0x5f, 0x52, 0x45, 0x53, 0x45, 0x52, 0x56, 0x45, 0x44, 0x10, 0x02,
-	0x12, 0x15, 0x0a, 0x11, 0x4f, 0x52, 0x44, 0x45, 0x52, 0x5f, 0x53, 0x54, 0x41, 0x54, 0x55, 0x53,
-	0x5f, 0x50, 0x41, 0x49, 0x44, 0x10, 0x03, 0x12, 0x1a, 0x0a, 0x16, 0x4f, 0x52, 0x44, 0x45, 0x52,
-	0x5f, 0x53, 0x54, 0x41, 0x54, 0x55, 0x53, 0x5f, 0x43, 0x4f, 0x4e, 0x46, 0x49, 0x52, 0x4d, 0x45,
-	0x44, 0x10, 0x04, 0x12, 0x19, 0x0a, 0x15, 0x4f, 0x52, 0x44, 0x45, 0x52, 0x5f, 0x53, 0x54, 0x41,
-	0x54, 0x55, 0x53, 0x5f, 0x43, 0x41, 0x4e, 0x43, 0x45, 0x4c, 0x45, 0x44, 0x10, 0x05, 0x12, 0x19,
-	0x0a, 0x15, 0x4f, 0x52, 0x44, 0x45, 0x52, 0x5f, 0x53, 0x54, 0x41, 0x54, 0x55, 0x53, 0x5f, 0x52,
-	0x45, 0x46, 0x55, 0x4e, 0x44, 0x45, 0x44, 0x10, 0x06, 0x2a, 0x99, 0x01, 0x0a, 0x12, 0x43, 0x6f,
-	0x75, 0x72, 0x69, 0x65, 0x72, 0x56, 0x65, 0x68, 0x69, 0x63, 0x6c, 0x65, 0x54, 0x79, 0x70, 0x65,
-	0x12, 0x24, 0x0a, 0x20, 0x43, 0x4f, 0x55, 0x52, 0x49, 0x45, 0x52, 0x5f, 0x56, 0x45, 0x48, 0x49,
-	0x43, 0x4c, 0x45, 0x5f, 0x54, 0x59, 0x50, 0x45, 0x5f, 0x55, 0x4e, 0x53, 0x50, 0x45, 0x43, 0x49,
-	0x46, 0x49, 0x45, 0x44, 0x10, 0x00, 0x12, 0x20, 0x0a, 0x1c, 0x43, 0x4f, 0x55, 0x52, 0x49, 0x45,
-	0x52, 0x5f, 0x56, 0x45, 0x48, 0x49, 0x43, 0x4c, 0x45, 0x5f, 0x54, 0x59, 0x50, 0x45, 0x5f, 0x53,
-	0x43, 0x4f, 0x4f, 0x54, 0x45, 0x52, 0x10, 0x01, 0x12, 0x1d, 0x0a, 0x19, 0x43, 0x4f, 0x55, 0x52,
-	0x49, 0x45, 0x52, 0x5f, 0x56, 0x45, 0x48, 0x49, 0x43, 0x4c, 0x45, 0x5f, 0x54, 0x59, 0x50, 0x45,
-	0x5f, 0x42, 0x49, 0x4b, 0x45, 0x10, 0x02, 0x12, 0x1c, 0x0a, 0x18, 0x43, 0x4f, 0x55, 0x52, 0x49,
-	0x45, 0x52, 0x5f, 0x56, 0x45, 0x48, 0x49, 0x43, 0x4c, 0x45, 0x5f, 0x54, 0x59, 0x50, 0x45, 0x5f,
-	0x43, 0x41, 0x52, 0x10, 0x03, 0x2a, 0xbe, 0x01, 0x0a, 0x11, 0x43, 0x6f, 0x75, 0x72, 0x69, 0x65,
-	0x72, 0x53, 0x6c, 0x6f, 0x74, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x12, 0x23, 0x0a, 0x1f, 0x43,
-	0x4f, 0x55, 0x52, 0x49, 0x45, 0x52, 0x5f, 0x53, 0x4c, 0x4f, 0x54, 0x5f, 0x53, 0x54, 0x41, 0x54,
-	0x55, 0x53, 0x5f, 0x55, 0x4e, 0x53, 0x50, 0x45, 0x43, 0x49, 0x46, 0x49, 0x45, 0x44, 0x10, 0x00,
-	0x12, 0x1f, 0x0a, 0x1b, 0x43, 0x4f, 0x55, 0x52, 0x49, 0x45, 0x52, 0x5f, 0x53, 0x4c, 0x4f, 0x54,
-	0x5f, 0x53, 0x54, 0x41, 0x54, 0x55, 0x53, 0x5f, 0x50, 0x4c, 0x41, 0x4e, 0x4e, 0x45, 0x44, 0x10,
-	0x01, 0x12, 0x1e, 0x0a, 0x1a, 0x43, 0x4f, 0x55, 0x52, 0x49, 0x45, 0x52, 0x5f, 0x53, 0x4c, 0x4f,
-	0x54, 0x5f, 0x53, 0x54, 0x41, 0x54, 0x55, 0x53, 0x5f, 0x41, 0x43, 0x54, 0x49, 0x56, 0x45, 0x10,
-	0x02, 0x12, 0x21, 0x0a, 0x1d, 0x43, 0x4f, 0x55, 0x52, 0x49, 0x45, 0x52, 0x5f, 0x53, 0x4c, 0x4f,
-	0x54, 0x5f, 0x53, 0x54, 0x41, 0x54, 0x55, 0x53, 0x5f, 0x43, 0x4f, 0x4d, 0x50, 0x4c, 0x45, 0x54,
-	0x45, 0x44, 0x10, 0x03, 0x12, 0x20, 0x0a, 0x1c, 0x43, 0x4f, 0x55, 0x52, 0x49, 0x45, 0x52, 0x5f,
-	0x53, 0x4c, 0x4f, 0x54, 0x5f, 0x53, 0x54, 0x41, 0x54, 0x55, 0x53, 0x5f, 0x43, 0x41, 0x4e, 0x43,
-	0x45, 0x4c, 0x45, 0x44, 0x10, 0x04, 0x2a, 0xb7, 0x02, 0x0a, 0x10, 0x43, 0x6f, 0x75, 0x72, 0x69,
-	0x65, 0x72, 0x52, 0x61, 0x74, 0x69, 0x6e, 0x67, 0x54, 0x61, 0x67, 0x12, 0x22, 0x0a, 0x1e, 0x43,
-	0x4f, 0x55, 0x52, 0x49, 0x45, 0x52, 0x5f, 0x52, 0x41, 0x54, 0x49, 0x4e, 0x47, 0x5f, 0x54, 0x41,
-	0x47, 0x5f, 0x55, 0x4e, 0x53, 0x50, 0x45, 0x43, 0x49, 0x46, 0x49, 0x45, 0x44, 0x10, 0x00, 0x12,
-	0x1e, 0x0a, 0x1a, 0x43, 0x4f, 0x55, 0x52, 0x49, 0x45, 0x52, 0x5f, 0x52, 0x41, 0x54, 0x49, 0x4e,
-	0x47, 0x5f, 0x54, 0x41, 0x47, 0x5f, 0x4f, 0x4e, 0x5f, 0x54, 0x49, 0x4d, 0x45, 0x10, 0x01, 0x12,
-	0x1d, 0x0a, 0x19, 0x43, 0x4f, 0x55, 0x52, 0x49, 0x45, 0x52, 0x5f, 0x52, 0x41, 0x54, 0x49, 0x4e,
-	0x47, 0x5f, 0x54, 0x41, 0x47, 0x5f, 0x50, 0x4f, 0x4c, 0x49, 0x54, 0x45, 0x10, 0x02, 0x12, 0x27,
-	0x0a, 0x23, 0x43, 0x4f, 0x55, 0x52, 0x49, 0x45, 0x52, 0x5f, 0x52, 0x41, 0x54, 0x49, 0x4e, 0x47,
-	0x5f, 0x54, 0x41, 0x47, 0x5f, 0x43, 0x41, 0x52, 0x45, 0x46, 0x55, 0x4c, 0x5f, 0x48, 0x41, 0x4e,
-	0x44, 0x4c, 0x49, 0x4e, 0x47, 0x10, 0x03, 0x12, 0x27, 0x0a, 0x23, 0x43, 0x4f, 0x55, 0x52, 0x49,
-	0x45, 0x52, 0x5f, 0x52, 0x41, 0x54, 0x49, 0x4e, 0x47, 0x5f, 0x54, 0x41, 0x47, 0x5f, 0x44, 0x45,
-	0x4c, 0x41, 0x59, 0x45, 0x44, 0x5f, 0x44, 0x45, 0x4c, 0x49, 0x56, 0x45, 0x52, 0x59, 0x10, 0x04,
-	0x12, 0x24, 0x0a, 0x20, 0x43, 0x4f, 0x55, 0x52, 0x49, 0x45, 0x52, 0x5f, 0x52, 0x41, 0x54, 0x49,
-	0x4e, 0x47, 0x5f, 0x54, 0x41, 0x47, 0x5f, 0x52, 0x55, 0x44, 0x45, 0x5f, 0x42, 0x45, 0x48, 0x41,
-	0x56, 0x49, 0x4f, 0x52, 0x10, 0x05, 0x12, 0x24, 0x0a, 0x20, 0x43, 0x4f, 0x55, 0x52, 0x49, 0x45,
-	0x52, 0x5f, 0x52, 0x41, 0x54, 0x49, 0x4e, 0x47, 0x5f, 0x54, 0x41, 0x47, 0x5f, 0x44, 0x41, 0x4d,
-	0x41, 0x47, 0x45, 0x44, 0x5f, 0x4f, 0x52, 0x44, 0x45, 0x52, 0x10, 0x06, 0x12, 0x22, 0x0a, 0x1e,
-	0x43, 0x4f, 0x55, 0x52, 0x49, 0x45, 0x52, 0x5f, 0x52, 0x41, 0x54, 0x49, 0x4e, 0x47, 0x5f, 0x54,
-	0x41, 0x47, 0x5f, 0x4f, 0x54, 0x48, 0x45, 0x52, 0x5f, 0x49, 0x53, 0x53, 0x55, 0x45, 0x10, 0x07,
-	0x32, 0xeb, 0x04, 0x0a, 0x0c, 0x4f, 0x72, 0x64, 0x65, 0x72, 0x53, 0x65, 0x72, 0x76, 0x69, 0x63,
-	0x65, 0x12, 0x5d, 0x0a, 0x0b, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x4f, 0x72, 0x64, 0x65, 0x72,
-	0x12, 0x1a, 0x2e, 0x6f, 0x6d, 0x73, 0x2e, 0x76, 0x31, 0x2e, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65,
-	0x4f, 0x72, 0x64, 0x65, 0x72, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x1b, 0x2e, 0x6f,
-	0x6d, 0x73, 0x2e, 0x76, 0x31, 0x2e, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x4f, 0x72, 0x64, 0x65,
-	0x72, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x15, 0x82, 0xd3, 0xe4, 0x93, 0x02,
-	0x0f, 0x3a, 0x01, 0x2a, 0x22, 0x0a, 0x2f, 0x76, 0x31, 0x2f, 0x6f, 0x72, 0x64, 0x65, 0x72, 0x73,
-	0x12, 0x5c, 0x0a, 0x08, 0x47, 0x65, 0x74, 0x4f, 0x72, 0x64, 0x65, 0x72, 0x12, 0x17, 0x2e, 0x6f,
-	0x6d, 0x73, 0x2e, 0x76, 0x31, 0x2e, 0x47, 0x65, 0x74, 0x4f, 0x72, 0x64, 0x65, 0x72, 0x52, 0x65,
-	0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x18, 0x2e, 0x6f, 0x6d, 0x73, 0x2e, 0x76, 0x31, 0x2e, 0x47,
-	0x65, 0x74, 0x4f, 0x72, 0x64, 0x65, 0x72, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22,
-	0x1d, 0x82, 0xd3, 0xe4, 0x93, 0x02, 0x17, 0x12, 0x15, 0x2f, 0x76, 0x31, 0x2f, 0x6f, 0x72, 0x64,
-	0x65, 0x72, 0x73, 0x2f, 0x7b, 0x6f, 0x72, 0x64, 0x65, 0x72, 0x5f, 0x69, 0x64, 0x7d, 0x12, 0x57,
-	0x0a, 0x0a, 0x4c, 0x69, 0x73, 0x74, 0x4f, 0x72, 0x64, 0x65, 0x72, 0x73, 0x12, 0x19, 0x2e, 0x6f,
-	0x6d, 0x73, 0x2e, 0x76, 0x31, 0x2e, 0x4c, 0x69, 0x73, 0x74, 0x4f, 0x72, 0x64, 0x65, 0x72, 0x73,
-	0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x1a, 0x2e, 0x6f, 0x6d, 0x73, 0x2e, 0x76, 0x31,
-	0x2e, 0x4c, 0x69, 0x73, 0x74, 0x4f, 0x72, 0x64, 0x65, 0x72, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f,
-	0x6e, 0x73, 0x65, 0x22, 0x12, 0x82, 0xd3, 0xe4, 0x93, 0x02, 0x0c, 0x12, 0x0a, 0x2f, 0x76, 0x31,
-	0x2f, 0x6f, 0x72, 0x64, 0x65, 0x72, 0x73, 0x12, 0x63, 0x0a, 0x08, 0x50, 0x61, 0x79, 0x4f, 0x72,
-	0x64, 0x65, 0x72, 0x12, 0x17, 0x2e, 0x6f, 0x6d, 0x73, 0x2e, 0x76, 0x31, 0x2e, 0x50, 0x61, 0x79,
-	0x4f, 0x72, 0x64, 0x65, 0x72, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x18, 0x2e, 0x6f,
-	0x6d, 0x73, 0x2e, 0x76, 0x31, 0x2e, 0x50, 0x61, 0x79, 0x4f, 0x72, 0x64, 0x65, 0x72, 0x52, 0x65,
-	0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x24, 0x82, 0xd3, 0xe4, 0x93, 0x02, 0x1e, 0x3a, 0x01,
-	0x2a, 0x22, 0x19, 0x2f, 0x76, 0x31, 0x2f, 0x6f, 0x72, 0x64, 0x65, 0x72, 0x73, 0x2f, 0x7b, 0x6f,
-	0x72, 0x64, 0x65, 0x72, 0x5f, 0x69, 0x64, 0x7d, 0x2f, 0x70, 0x61, 0x79, 0x12, 0x6f, 0x0a, 0x0b,
-	0x43, 0x61, 0x6e, 0x63, 0x65, 0x6c, 0x4f, 0x72, 0x64, 0x65, 0x72, 0x12, 0x1a, 0x2e, 0x6f, 0x6d,
-	0x73, 0x2e, 0x76, 0x31, 0x2e, 0x43, 0x61, 0x6e, 0x63, 0x65, 0x6c, 0x4f, 0x72, 0x64, 0x65, 0x72,
-	0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x1b, 0x2e, 0x6f, 0x6d, 0x73, 0x2e, 0x76, 0x31,
-	0x2e, 0x43, 0x61, 0x6e, 0x63, 0x65, 0x6c, 0x4f, 0x72, 0x64, 0x65, 0x72, 0x52, 0x65, 0x73, 0x70,
-	0x6f, 0x6e, 0x73, 0x65, 0x22, 0x27, 0x82, 0xd3, 0xe4, 0x93, 0x02, 0x21, 0x3a, 0x01, 0x2a, 0x22,
-	0x1c, 0x2f, 0x76, 0x31, 0x2f, 0x6f, 0x72, 0x64, 0x65, 0x72, 0x73, 0x2f, 0x7b, 0x6f, 0x72, 0x64,
-	0x65, 0x72, 0x5f, 0x69, 0x64, 0x7d, 0x2f, 0x63, 0x61, 0x6e, 0x63, 0x65, 0x6c, 0x12, 0x6f, 0x0a,
-	0x0b, 0x52, 0x65, 0x66, 0x75, 0x6e, 0x64, 0x4f, 0x72, 0x64, 0x65, 0x72, 0x12, 0x1a, 0x2e, 0x6f,
-	0x6d, 0x73, 0x2e, 0x76, 0x31, 0x2e, 0x52, 0x65, 0x66, 0x75, 0x6e, 0x64, 0x4f, 0x72, 0x64, 0x65,
-	0x72, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x1b, 0x2e, 0x6f, 0x6d, 0x73, 0x2e, 0x76,
-	0x31, 0x2e, 0x52, 0x65, 0x66, 0x75, 0x6e, 0x64, 0x4f, 0x72, 0x64, 0x65, 0x72, 0x52, 0x65, 0x73,
-	0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x27, 0x82, 0xd3, 0xe4, 0x93, 0x02, 0x21, 0x3a, 0x01, 0x2a,
-	0x22, 0x1c, 0x2f, 0x76, 0x31, 0x2f, 0x6f, 0x72, 0x64, 0x65, 0x72, 0x73, 0x2f, 0x7b, 0x6f, 0x72,
-	0x64, 0x65, 0x72, 0x5f, 0x69, 0x64, 0x7d, 0x2f, 0x72, 0x65, 0x66, 0x75, 0x6e, 0x64, 0x32, 0x8a,
-	0x0b, 0x0a, 0x0e, 0x43, 0x6f, 0x75, 0x72, 0x69, 0x65, 0x72, 0x53, 0x65, 0x72, 0x76, 0x69, 0x63,
-	0x65, 0x12, 0x6b, 0x0a, 0x0f, 0x52, 0x65, 0x67, 0x69, 0x73, 0x74, 0x65, 0x72, 0x43, 0x6f, 0x75,
-	0x72, 0x69, 0x65, 0x72, 0x12, 0x1e, 0x2e, 0x6f, 0x6d, 0x73, 0x2e, 0x76, 0x31, 0x2e, 0x52, 0x65,
-	0x67, 0x69, 0x73, 0x74, 0x65, 0x72, 0x43, 0x6f, 0x75, 0x72, 0x69, 0x65, 0x72, 0x52, 0x65, 0x71,
-	0x75, 0x65, 0x73, 0x74, 0x1a, 0x1f, 0x2e, 0x6f, 0x6d, 0x73, 0x2e, 0x76, 0x31, 0x2e, 0x52, 0x65,
-	0x67, 0x69, 0x73, 0x74, 0x65, 0x72, 0x43, 0x6f, 0x75, 0x72, 0x69, 0x65, 0x72, 0x52, 0x65, 0x73,
-	0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x17, 0x82, 0xd3, 0xe4, 0x93, 0x02, 0x11, 0x3a, 0x01, 0x2a,
-	0x22, 0x0c, 0x2f, 0x76, 0x31, 0x2f, 0x63, 0x6f, 0x75, 0x72, 0x69, 0x65, 0x72, 0x73, 0x12, 0x66,
-	0x0a, 0x0a, 0x47, 0x65, 0x74, 0x43, 0x6f, 0x75, 0x72, 0x69, 0x65, 0x72, 0x12, 0x19, 0x2e, 0x6f,
-	0x6d, 0x73, 0x2e, 0x76, 0x31, 0x2e, 0x47, 0x65, 0x74, 0x43, 0x6f, 0x75, 0x72, 0x69, 0x65, 0x72,
-	0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x1a, 0x2e, 0x6f, 0x6d, 0x73, 0x2e, 0x76, 0x31,
-	0x2e, 0x47, 0x65, 0x74, 0x43, 0x6f, 0x75, 0x72, 0x69, 0x65, 0x72, 0x52, 0x65, 0x73, 0x70, 0x6f,
-	0x6e, 0x73, 0x65, 0x22, 0x21, 0x82, 0xd3, 0xe4, 0x93, 0x02, 0x1b, 0x12, 0x19, 0x2f, 0x76, 0x31,
-	0x2f, 0x63, 0x6f, 0x75, 0x72, 0x69, 0x65, 0x72, 0x73, 0x2f, 0x7b, 0x63, 0x6f, 0x75, 0x72, 0x69,
-	0x65, 0x72, 0x5f, 0x69, 0x64, 0x7d, 0x12, 0x81, 0x01, 0x0a, 0x12, 0x4c, 0x69, 0x73, 0x74, 0x43,
-	0x6f, 0x75, 0x72, 0x69, 0x65, 0x72, 0x73, 0x42, 0x79, 0x5a, 0x6f, 0x6e, 0x65, 0x12, 0x21, 0x2e,
-	0x6f, 0x6d, 0x73, 0x2e, 0x76, 0x31, 0x2e, 0x4c, 0x69, 0x73, 0x74, 0x43, 0x6f, 0x75, 0x72, 0x69,
-	0x65, 0x72, 0x73, 0x42, 0x79, 0x5a, 0x6f, 0x6e, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74,
-	0x1a, 0x22, 0x2e, 0x6f, 0x6d, 0x73, 0x2e, 0x76, 0x31, 0x2e, 0x4c, 0x69, 0x73, 0x74, 0x43, 0x6f,
-	0x75, 0x72, 0x69, 0x65, 0x72, 0x73, 0x42, 0x79, 0x5a, 0x6f, 0x6e, 0x65, 0x52, 0x65, 0x73, 0x70,
-	0x6f, 0x6e, 0x73, 0x65, 0x22, 0x24, 0x82, 0xd3, 0xe4, 0x93, 0x02, 0x1e, 0x12, 0x1c, 0x2f, 0x76,
-	0x31, 0x2f, 0x7a, 0x6f, 0x6e, 0x65, 0x73, 0x2f, 0x7b, 0x7a, 0x6f, 0x6e, 0x65, 0x5f, 0x69, 0x64,
-	0x7d, 0x2f, 0x63, 0x6f, 0x75, 0x72, 0x69, 0x65, 0x72, 0x73, 0x12, 0x8a, 0x01, 0x0a, 0x13, 0x52,
-	0x65, 0x70, 0x6c, 0x61, 0x63, 0x65, 0x43, 0x6f, 0x75, 0x72, 0x69, 0x65, 0x72, 0x5a, 0x6f, 0x6e,
-	0x65, 0x73, 0x12, 0x22, 0x2e, 0x6f, 0x6d, 0x73, 0x2e, 0x76, 0x31, 0x2e, 0x52, 0x65, 0x70, 0x6c,
-	0x61, 0x63, 0x65, 0x43, 0x6f, 0x75, 0x72, 0x69, 0x65, 0x72, 0x5a, 0x6f, 0x6e, 0x65, 0x73, 0x52,
-	0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x23, 0x2e, 0x6f, 0x6d, 0x73, 0x2e, 0x76, 0x31, 0x2e,
-	0x52, 0x65, 0x70, 0x6c, 0x61, 0x63, 0x65, 0x43, 0x6f, 0x75, 0x72, 0x69, 0x65, 0x72, 0x5a, 0x6f,
-	0x6e, 0x65, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x2a, 0x82, 0xd3, 0xe4,
-	0x93, 0x02, 0x24, 0x3a, 0x01, 0x2a, 0x1a, 0x1f, 0x2f, 0x76, 0x31, 0x2f, 0x63, 0x6f, 0x75, 0x72,
-	0x69, 0x65, 0x72, 0x73, 0x2f, 0x7b, 0x63, 0x6f, 0x75, 0x72, 0x69, 0x65, 0x72, 0x5f, 0x69, 0x64,
-	0x7d, 0x2f, 0x7a, 0x6f, 0x6e, 0x65, 0x73, 0x12, 0x84, 0x01, 0x0a, 0x11, 0x43, 0x72, 0x65, 0x61,
-	0x74, 0x65, 0x43, 0x6f, 0x75, 0x72, 0x69, 0x65, 0x72, 0x53, 0x6c, 0x6f, 0x74, 0x12, 0x20, 0x2e,
-	0x6f, 0x6d, 0x73, 0x2e, 0x76, 0x31, 0x2e, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x43, 0x6f, 0x75,
-	0x72, 0x69, 0x65, 0x72, 0x53, 0x6c, 0x6f, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a,
-	0x21, 0x2e, 0x6f, 0x6d, 0x73, 0x2e, 0x76, 0x31, 0x2e, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x43,
-	0x6f, 0x75, 0x72, 0x69, 0x65, 0x72, 0x53, 0x6c, 0x6f, 0x74, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e,
-	0x73, 0x65, 0x22, 0x2a, 0x82, 0xd3, 0xe4, 0x93, 0x02, 0x24, 0x3a, 0x01, 0x2a, 0x22, 0x1f, 0x2f,
-	0x76, 0x31, 0x2f, 0x63, 0x6f, 0x75, 0x72, 0x69, 0x65, 0x72, 0x73, 0x2f, 0x7b, 0x63, 0x6f, 0x75,
-	0x72, 0x69, 0x65, 0x72, 0x5f, 0x69, 0x64, 0x7d, 0x2f, 0x73, 0x6c, 0x6f, 0x74, 0x73, 0x12, 0x7e,
-	0x0a, 0x10, 0x4c, 0x69, 0x73, 0x74, 0x43, 0x6f, 0x75, 0x72, 0x69, 0x65, 0x72, 0x53, 0x6c, 0x6f,
-	0x74, 0x73, 0x12, 0x1f, 0x2e, 0x6f, 0x6d, 0x73, 0x2e, 0x76, 0x31, 0x2e, 0x4c, 0x69, 0x73, 0x74,
-	0x43, 0x6f, 0x75, 0x72, 0x69, 0x65, 0x72, 0x53, 0x6c, 0x6f, 0x74, 0x73, 0x52, 0x65, 0x71, 0x75,
-	0x65, 0x73, 0x74, 0x1a, 0x20, 0x2e, 0x6f, 0x6d, 0x73, 0x2e, 0x76, 0x31, 0x2e, 0x4c, 0x69, 0x73,
-	0x74, 0x43, 0x6f, 0x75, 0x72, 0x69, 0x65, 0x72, 0x53, 0x6c, 0x6f, 0x74, 0x73, 0x52, 0x65, 0x73,
-	0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x27, 0x82, 0xd3, 0xe4, 0x93, 0x02, 0x21, 0x12, 0x1f, 0x2f,
-	0x76, 0x31, 0x2f, 0x63, 0x6f, 0x75, 0x72, 0x69, 0x65, 0x72, 0x73, 0x2f, 0x7b, 0x63, 0x6f, 0x75,
-	0x72, 0x69, 0x65, 0x72, 0x5f, 0x69, 0x64, 0x7d, 0x2f, 0x73, 0x6c, 0x6f, 0x74, 0x73, 0x12, 0xaf,
-	0x01, 0x0a, 0x1b, 0x47, 0x65, 0x74, 0x43, 0x6f, 0x75, 0x72, 0x69, 0x65, 0x72, 0x56, 0x65, 0x68,
-	0x69, 0x63, 0x6c, 0x65, 0x43, 0x61, 0x70, 0x61, 0x62, 0x69, 0x6c, 0x69, 0x74, 0x79, 0x12, 0x2a,
-	0x2e, 0x6f, 0x6d, 0x73, 0x2e, 0x76, 0x31, 0x2e, 0x47, 0x65, 0x74, 0x43, 0x6f, 0x75, 0x72, 0x69,
-	0x65, 0x72, 0x56, 0x65, 0x68, 0x69, 0x63, 0x6c, 0x65, 0x43, 0x61, 0x70, 0x61, 0x62, 0x69, 0x6c,
-	0x69, 0x74, 0x79, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x2b, 0x2e, 0x6f, 0x6d, 0x73,
-	0x2e, 0x76, 0x31, 0x2e, 0x47, 0x65, 0x74, 0x43, 0x6f, 0x75, 0x72, 0x69, 0x65, 0x72, 0x56, 0x65,
-	0x68, 0x69, 0x63, 0x6c, 0x65, 0x43, 0x61, 0x70, 0x61, 0x62, 0x69, 0x6c, 0x69, 0x74, 0x79, 0x52,
-	0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x37, 0x82, 0xd3, 0xe4, 0x93, 0x02, 0x31, 0x12,
-	0x2f, 0x2f, 0x76, 0x31, 0x2f, 0x63, 0x6f, 0x75, 0x72, 0x69, 0x65, 0x72, 0x2d, 0x76, 0x65, 0x68,
-	0x69, 0x63, 0x6c, 0x65, 0x2d, 0x63, 0x61, 0x70, 0x61, 0x62, 0x69, 0x6c, 0x69, 0x74, 0x69, 0x65,
-	0x73, 0x2f, 0x7b, 0x76, 0x65, 0x68, 0x69, 0x63, 0x6c, 0x65, 0x5f, 0x74, 0x79, 0x70, 0x65, 0x7d,
-	0x12, 0xa9, 0x01, 0x0a, 0x1e, 0x4c, 0x69, 0x73, 0x74, 0x43, 0x6f, 0x75, 0x72, 0x69, 0x65, 0x72,
-	0x56, 0x65, 0x68, 0x69, 0x63, 0x6c, 0x65, 0x43, 0x61, 0x70, 0x61, 0x62, 0x69, 0x6c, 0x69, 0x74,
-	0x69, 0x65, 0x73, 0x12, 0x2d, 0x2e, 0x6f, 0x6d, 0x73, 0x2e, 0x76, 0x31, 0x2e, 0x4c, 0x69, 0x73,
-	0x74, 0x43, 0x6f, 0x75, 0x72, 0x69, 0x65, 0x72, 0x56, 0x65, 0x68, 0x69, 0x63, 0x6c, 0x65, 0x43,
-	0x61, 0x70, 0x61, 0x62, 0x69, 0x6c, 0x69, 0x74, 0x69, 0x65, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65,
-	0x73, 0x74, 0x1a, 0x2e, 0x2e, 0x6f, 0x6d, 0x73, 0x2e, 0x76, 0x31, 0x2e, 0x4c, 0x69, 0x73, 0x74,
-	0x43, 0x6f, 0x75, 0x72, 0x69, 0x65, 0x72, 0x56, 0x65, 0x68, 0x69, 0x63, 0x6c, 0x65, 0x43, 0x61,
-	0x70, 0x61, 0x62, 0x69, 0x6c, 0x69, 0x74, 0x69, 0x65, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e,
-	0x73, 0x65, 0x22, 0x28, 0x82, 0xd3, 0xe4, 0x93, 0x02, 0x22, 0x12, 0x20, 0x2f, 0x76, 0x31, 0x2f,
-	0x63, 0x6f, 0x75, 0x72, 0x69, 0x65, 0x72, 0x2d, 0x76, 0x65, 0x68, 0x69, 0x63, 0x6c, 0x65, 0x2d,
-	0x63, 0x61, 0x70, 0x61, 0x62, 0x69, 0x6c, 0x69, 0x74, 0x69, 0x65, 0x73, 0x12, 0x8c, 0x01, 0x0a,
-	0x13, 0x53, 0x75, 0x62, 0x6d, 0x69, 0x74, 0x43, 0x6f, 0x75, 0x72, 0x69, 0x65, 0x72, 0x52, 0x61,
-	0x74, 0x69, 0x6e, 0x67, 0x12, 0x22, 0x2e, 0x6f, 0x6d, 0x73, 0x2e, 0x76, 0x31, 0x2e, 0x53, 0x75,
-	0x62, 0x6d, 0x69, 0x74, 0x43, 0x6f, 0x75, 0x72, 0x69, 0x65, 0x72, 0x52, 0x61, 0x74, 0x69, 0x6e,
-	0x67, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x23, 0x2e, 0x6f, 0x6d, 0x73, 0x2e, 0x76,
-	0x31, 0x2e, 0x53, 0x75, 0x62, 0x6d, 0x69, 0x74, 0x43, 0x6f, 0x75, 0x72, 0x69, 0x65, 0x72, 0x52,
-	0x61, 0x74, 0x69, 0x6e, 0x67, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x2c, 0x82,
-	0xd3, 0xe4, 0x93, 0x02, 0x26, 0x3a, 0x01, 0x2a, 0x22, 0x21, 0x2f, 0x76, 0x31, 0x2f, 0x63, 0x6f,
-	0x75, 0x72, 0x69, 0x65, 0x72, 0x73, 0x2f, 0x7b, 0x63, 0x6f, 0x75, 0x72, 0x69, 0x65, 0x72, 0x5f,
-	0x69, 0x64, 0x7d, 0x2f, 0x72, 0x61, 0x74, 0x69, 0x6e, 0x67, 0x73, 0x12, 0x9d, 0x01, 0x0a, 0x17,
-	0x47, 0x65, 0x74, 0x43, 0x6f, 0x75, 0x72, 0x69, 0x65, 0x72, 0x52, 0x61, 0x74, 0x69, 0x6e, 0x67,
-	0x53, 0x75, 0x6d, 0x6d, 0x61, 0x72, 0x79, 0x12, 0x26, 0x2e, 0x6f, 0x6d, 0x73, 0x2e, 0x76, 0x31,
-	0x2e, 0x47, 0x65, 0x74, 0x43, 0x6f, 0x75, 0x72, 0x69, 0x65, 0x72, 0x52, 0x61, 0x74, 0x69, 0x6e,
-	0x67, 0x53, 0x75, 0x6d, 0x6d, 0x61, 0x72, 0x79, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a,
-	0x27, 0x2e, 0x6f, 0x6d, 0x73, 0x2e, 0x76, 0x31, 0x2e, 0x47, 0x65, 0x74, 0x43, 0x6f, 0x75, 0x72,
-	0x69, 0x65, 0x72, 0x52, 0x61, 0x74, 0x69, 0x6e, 0x67, 0x53, 0x75, 0x6d, 0x6d, 0x61, 0x72, 0x79,
-	0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x31, 0x82, 0xd3, 0xe4, 0x93, 0x02, 0x2b,
-	0x12, 0x29, 0x2f, 0x76, 0x31, 0x2f, 0x63, 0x6f, 0x75, 0x72, 0x69, 0x65, 0x72, 0x73, 0x2f, 0x7b,
-	0x63, 0x6f, 0x75, 0x72, 0x69, 0x65, 0x72, 0x5f, 0x69, 0x64, 0x7d, 0x2f, 0x72, 0x61, 0x74, 0x69,
-	0x6e, 0x67, 0x73, 0x2f, 0x73, 0x75, 0x6d, 0x6d, 0x61, 0x72, 0x79, 0x42, 0x38, 0x5a, 0x36, 0x67,
-	0x69, 0x74, 0x68, 0x75, 0x62, 0x2e, 0x63, 0x6f, 0x6d, 0x2f, 0x76, 0x6c, 0x61, 0x64, 0x69, 0x73,
-	0x6c, 0x61, 0x76, 0x64, 0x72, 0x61, 0x67, 0x6f, 0x6e, 0x65, 0x6e, 0x6b, 0x6f, 0x76, 0x2f, 0x6f,
-	0x6d, 0x73, 0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2f, 0x6f, 0x6d, 0x73, 0x2f, 0x76, 0x31, 0x3b,
-	0x6f, 0x6d, 0x73, 0x76, 0x31, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
+	ItemId string `protobuf:"bytes,1,opt,name=item_id,json=itemId,proto3" json:"item_id,omitempty"` // OrderItem.id возвращаемой позиции.
+	Qty    int32  `protobuf:"varint,2,opt,name=qty,proto3" json:"qty,omitempty"`
+	Sku    string `protobuf:"bytes,3,opt,name=sku,proto3" json:"sku,omitempty"`       // Только в ответах.
+	Refund *Money `protobuf:"bytes,4,opt,name=refund,proto3" json:"refund,omitempty"` // Сумма к возврату за позицию; только в ответах.
 }
 
-var (
-	file_proto_oms_v1_order_service_proto_rawDescOnce sync.Once
-	file_proto_oms_v1_order_service_proto_rawDescData = file_proto_oms_v1_order_service_proto_rawDesc
-)
+func (x *ReturnItem) Reset() {
+	*x = ReturnItem{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_proto_oms_v1_order_service_proto_msgTypes[48]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
 
-func file_proto_oms_v1_order_service_proto_rawDescGZIP() []byte {
-	file_proto_oms_v1_order_service_proto_rawDescOnce.Do(func() {
-		file_proto_oms_v1_order_service_proto_rawDescData = protoimpl.X.CompressGZIP(file_proto_oms_v1_order_service_proto_rawDescData)
-	})
-	return file_proto_oms_v1_order_service_proto_rawDescData
+func (x *ReturnItem) String() string {
+	return protoimpl.X.MessageStringOf(x)
 }
 
-var file_proto_oms_v1_order_service_proto_enumTypes = make([]protoimpl.EnumInfo, 4)
-var file_proto_oms_v1_order_service_proto_msgTypes = make([]protoimpl.MessageInfo, 42)
-var file_proto_oms_v1_order_service_proto_goTypes = []interface{}{
-	(OrderStatus)(0),                               // 0: oms.v1.OrderStatus
-	(CourierVehicleType)(0),                        // 1: oms.v1.CourierVehicleType
-	(CourierSlotStatus)(0),                         // 2: oms.v1.CourierSlotStatus
-	(CourierRatingTag)(0),                          // 3: oms.v1.CourierRatingTag
-	(*Money)(nil),                                  // 4: oms.v1.Money
-	(*OrderItem)(nil),                              // 5: oms.v1.OrderItem
-	(*Order)(nil),                                  // 6: oms.v1.Order
-	(*TimelineEvent)(nil),                          // 7: oms.v1.TimelineEvent
-	(*CourierZoneInput)(nil),                       // 8: oms.v1.CourierZoneInput
-	(*CourierZone)(nil),                            // 9: oms.v1.CourierZone
-	(*Courier)(nil),                                // 10: oms.v1.Courier
-	(*CourierSlot)(nil),                            // 11: oms.v1.CourierSlot
-	(*CourierVehicleCapability)(nil),               // 12: oms.v1.CourierVehicleCapability
-	(*CreateOrderRequest)(nil),                     // 13: oms.v1.CreateOrderRequest
-	(*CreateOrderResponse)(nil),                    // 14: oms.v1.CreateOrderResponse
-	(*GetOrderRequest)(nil),                        // 15: oms.v1.GetOrderRequest
-	(*GetOrderResponse)(nil),                       // 16: oms.v1.GetOrderResponse
-	(*ListOrdersRequest)(nil),                      // 17: oms.v1.ListOrdersRequest
-	(*ListOrdersResponse)(nil),                     // 18: oms.v1.ListOrdersResponse
-	(*PayOrderRequest)(nil),                        // 19: oms.v1.PayOrderRequest
-	(*PayOrderResponse)(nil),                       // 20: oms.v1.PayOrderResponse
-	(*CancelOrderRequest)(nil),                     // 21: oms.v1.CancelOrderRequest
-	(*CancelOrderResponse)(nil),                    // 22: oms.v1.CancelOrderResponse
-	(*RefundOrderRequest)(nil),                     // 23: oms.v1.RefundOrderRequest
-	(*RefundOrderResponse)(nil),                    // 24: oms.v1.RefundOrderResponse
-	(*RegisterCourierRequest)(nil),                 // 25: oms.v1.RegisterCourierRequest
-	(*RegisterCourierResponse)(nil),                // 26: oms.v1.RegisterCourierResponse
-	(*GetCourierRequest)(nil),                      // 27: oms.v1.GetCourierRequest
-	(*GetCourierResponse)(nil),                     // 28: oms.v1.GetCourierResponse
-	(*ListCouriersByZoneRequest)(nil),              // 29: oms.v1.ListCouriersByZoneRequest
-	(*ListCouriersByZoneResponse)(nil),             // 30: oms.v1.ListCouriersByZoneResponse
-	(*ReplaceCourierZonesRequest)(nil),             // 31: oms.v1.ReplaceCourierZonesRequest
-	(*ReplaceCourierZonesResponse)(nil),            // 32: oms.v1.ReplaceCourierZonesResponse
-	(*CreateCourierSlotRequest)(nil),               // 33: oms.v1.CreateCourierSlotRequest
-	(*CreateCourierSlotResponse)(nil),              // 34: oms.v1.CreateCourierSlotResponse
-	(*ListCourierSlotsRequest)(nil),                // 35: oms.v1.ListCourierSlotsRequest
-	(*ListCourierSlotsResponse)(nil),               // 36: oms.v1.ListCourierSlotsResponse
-	(*GetCourierVehicleCapabilityRequest)(nil),     // 37: oms.v1.GetCourierVehicleCapabilityRequest
-	(*GetCourierVehicleCapabilityResponse)(nil),    // 38: oms.v1.GetCourierVehicleCapabilityResponse
-	(*ListCourierVehicleCapabilitiesRequest)(nil),  // 39: oms.v1.ListCourierVehicleCapabilitiesRequest
-	(*ListCourierVehicleCapabilitiesResponse)(nil), // 40: oms.v1.ListCourierVehicleCapabilitiesResponse
-	(*SubmitCourierRatingRequest)(nil),             // 41: oms.v1.SubmitCourierRatingRequest
-	(*SubmitCourierRatingResponse)(nil),            // 42: oms.v1.SubmitCourierRatingResponse
-	(*GetCourierRatingSummaryRequest)(nil),         // 43: oms.v1.GetCourierRatingSummaryRequest
-	(*CourierRatingSummary)(nil),                   // 44: oms.v1.CourierRatingSummary
-	(*GetCourierRatingSummaryResponse)(nil),        // 45: oms.v1.GetCourierRatingSummaryResponse
+func (*ReturnItem) ProtoMessage() {}
+
+func (x *ReturnItem) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_oms_v1_order_service_proto_msgTypes[48]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
 }
-var file_proto_oms_v1_order_service_proto_depIdxs = []int32{
-	4,  // 0: oms.v1.OrderItem.price:type_name -> oms.v1.Money
-	0,  // 1: oms.v1.Order.status:type_name -> oms.v1.OrderStatus
-	4,  // 2: oms.v1.Order.amount:type_name -> oms.v1.Money
-	5,  // 3: oms.v1.Order.items:type_name -> oms.v1.OrderItem
-	1,  // 4: oms.v1.Courier.vehicle_type:type_name -> oms.v1.CourierVehicleType
-	9,  // 5: oms.v1.Courier.zones:type_name -> oms.v1.CourierZone
-	2,  // 6: oms.v1.CourierSlot.status:type_name -> oms.v1.CourierSlotStatus
-	1,  // 7: oms.v1.CourierVehicleCapability.vehicle_type:type_name -> oms.v1.CourierVehicleType
-	5,  // 8: oms.v1.CreateOrderRequest.items:type_name -> oms.v1.OrderItem
-	6,  // 9: oms.v1.CreateOrderResponse.order:type_name -> oms.v1.Order
-	6,  // 10: oms.v1.GetOrderResponse.order:type_name -> oms.v1.Order
-	7,  // 11: oms.v1.GetOrderResponse.timeline:type_name -> oms.v1.TimelineEvent
-	0,  // 12: oms.v1.ListOrdersRequest.filter_statuses:type_name -> oms.v1.OrderStatus
-	6,  // 13: oms.v1.ListOrdersResponse.orders:type_name -> oms.v1.Order
-	0,  // 14: oms.v1.PayOrderResponse.status:type_name -> oms.v1.OrderStatus
-	0,  // 15: oms.v1.CancelOrderResponse.status:type_name -> oms.v1.OrderStatus
-	4,  // 16: oms.v1.RefundOrderRequest.amount:type_name -> oms.v1.Money
-	0,  // 17: oms.v1.RefundOrderResponse.status:type_name -> oms.v1.OrderStatus
-	1,  // 18: oms.v1.RegisterCourierRequest.vehicle_type:type_name -> oms.v1.CourierVehicleType
-	8,  // 19: oms.v1.RegisterCourierRequest.zones:type_name -> oms.v1.CourierZoneInput
-	10, // 20: oms.v1.RegisterCourierResponse.courier:type_name -> oms.v1.Courier
-	10, // 21: oms.v1.GetCourierResponse.courier:type_name -> oms.v1.Courier
-	10, // 22: oms.v1.ListCouriersByZoneResponse.couriers:type_name -> oms.v1.Courier
-	8,  // 23: oms.v1.ReplaceCourierZonesRequest.zones:type_name -> oms.v1.CourierZoneInput
-	9,  // 24: oms.v1.ReplaceCourierZonesResponse.zones:type_name -> oms.v1.CourierZone
-	11, // 25: oms.v1.CreateCourierSlotResponse.slot:type_name -> oms.v1.CourierSlot
-	11, // 26: oms.v1.ListCourierSlotsResponse.slots:type_name -> oms.v1.CourierSlot
-	1,  // 27: oms.v1.GetCourierVehicleCapabilityRequest.vehicle_type:type_name -> oms.v1.CourierVehicleType
-	12, // 28: oms.v1.GetCourierVehicleCapabilityResponse.capability:type_name -> oms.v1.CourierVehicleCapability
-	12, // 29: oms.v1.ListCourierVehicleCapabilitiesResponse.capabilities:type_name -> oms.v1.CourierVehicleCapability
-	3,  // 30: oms.v1.SubmitCourierRatingRequest.tags:type_name -> oms.v1.CourierRatingTag
-	44, // 31: oms.v1.GetCourierRatingSummaryResponse.summary:type_name -> oms.v1.CourierRatingSummary
-	13, // 32: oms.v1.OrderService.CreateOrder:input_type -> oms.v1.CreateOrderRequest
-	15, // 33: oms.v1.OrderService.GetOrder:input_type -> oms.v1.GetOrderRequest
-	17, // 34: oms.v1.OrderService.ListOrders:input_type -> oms.v1.ListOrdersRequest
-	19, // 35: oms.v1.OrderService.PayOrder:input_type -> oms.v1.PayOrderRequest
-	21, // 36: oms.v1.OrderService.CancelOrder:input_type -> oms.v1.CancelOrderRequest
-	23, // 37: oms.v1.OrderService.RefundOrder:input_type -> oms.v1.RefundOrderRequest
-	25, // 38: oms.v1.CourierService.RegisterCourier:input_type -> oms.v1.RegisterCourierRequest
-	27, // 39: oms.v1.CourierService.GetCourier:input_type -> oms.v1.GetCourierRequest
-	29, // 40: oms.v1.CourierService.ListCouriersByZone:input_type -> oms.v1.ListCouriersByZoneRequest
-	31, // 41: oms.v1.CourierService.ReplaceCourierZones:input_type -> oms.v1.ReplaceCourierZonesRequest
-	33, // 42: oms.v1.CourierService.CreateCourierSlot:input_type -> oms.v1.CreateCourierSlotRequest
-	35, // 43: oms.v1.CourierService.ListCourierSlots:input_type -> oms.v1.ListCourierSlotsRequest
-	37, // 44: oms.v1.CourierService.GetCourierVehicleCapability:input_type -> oms.v1.GetCourierVehicleCapabilityRequest
-	39, // 45: oms.v1.CourierService.ListCourierVehicleCapabilities:input_type -> oms.v1.ListCourierVehicleCapabilitiesRequest
-	41, // 46: oms.v1.CourierService.SubmitCourierRating:input_type -> oms.v1.SubmitCourierRatingRequest
-	43, // 47: oms.v1.CourierService.GetCourierRatingSummary:input_type -> oms.v1.GetCourierRatingSummaryRequest
-	14, // 48: oms.v1.OrderService.CreateOrder:output_type -> oms.v1.CreateOrderResponse
-	16, // 49: oms.v1.OrderService.GetOrder:output_type -> oms.v1.GetOrderResponse
-	18, // 50: oms.v1.OrderService.ListOrders:output_type -> oms.v1.ListOrdersResponse
-	20, // 51: oms.v1.OrderService.PayOrder:output_type -> oms.v1.PayOrderResponse
-	22, // 52: oms.v1.OrderService.CancelOrder:output_type -> oms.v1.CancelOrderResponse
-	24, // 53: oms.v1.OrderService.RefundOrder:output_type -> oms.v1.RefundOrderResponse
-	26, // 54: oms.v1.CourierService.RegisterCourier:output_type -> oms.v1.RegisterCourierResponse
-	28, // 55: oms.v1.CourierService.GetCourier:output_type -> oms.v1.GetCourierResponse
-	30, // 56: oms.v1.CourierService.ListCouriersByZone:output_type -> oms.v1.ListCouriersByZoneResponse
-	32, // 57: oms.v1.CourierService.ReplaceCourierZones:output_type -> oms.v1.ReplaceCourierZonesResponse
-	34, // 58: oms.v1.CourierService.CreateCourierSlot:output_type -> oms.v1.CreateCourierSlotResponse
-	36, // 59: oms.v1.CourierService.ListCourierSlots:output_type -> oms.v1.ListCourierSlotsResponse
-	38, // 60: oms.v1.CourierService.GetCourierVehicleCapability:output_type -> oms.v1.GetCourierVehicleCapabilityResponse
-	40, // 61: oms.v1.CourierService.ListCourierVehicleCapabilities:output_type -> oms.v1.ListCourierVehicleCapabilitiesResponse
-	42, // 62: oms.v1.CourierService.SubmitCourierRating:output_type -> oms.v1.SubmitCourierRatingResponse
-	45, // 63: oms.v1.CourierService.GetCourierRatingSummary:output_type -> oms.v1.GetCourierRatingSummaryResponse
-	48, // [48:64] is the sub-list for method output_type
-	32, // [32:48] is the sub-list for method input_type
-	32, // [32:32] is the sub-list for extension type_name
-	32, // [32:32] is the sub-list for extension extendee
-	0,  // [0:32] is the sub-list for field type_name
+
+// Deprecated: Use ReturnItem.ProtoReflect.Descriptor instead.
+func (*ReturnItem) Descriptor() ([]byte, []int) {
+	return file_proto_oms_v1_order_service_proto_rawDescGZIP(), []int{48}
 }
 
-func init() { file_proto_oms_v1_order_service_proto_init() }
-func file_proto_oms_v1_order_service_proto_init() {
-	if File_proto_oms_v1_order_service_proto != nil {
-		return
+func (x *ReturnItem) GetItemId() string {
+	if x != nil {
+		return x.ItemId
 	}
-	if !protoimpl.UnsafeEnabled {
-		file_proto_oms_v1_order_service_proto_msgTypes[0].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*Money); i {
+	return ""
+}
+
+func (x *ReturnItem) GetQty() int32 {
+	if x != nil {
+		return x.Qty
+	}
+	return 0
+}
+
+func (x *ReturnItem) GetSku() string {
+	if x != nil {
+		return x.Sku
+	}
+	return ""
+}
+
+func (x *ReturnItem) GetRefund() *Money {
+	if x != nil {
+		return x.Refund
+	}
+	return nil
+}
+
+// Возврат части позиций заказа (RMA). Статус заказа при этом не меняется.
+type OrderReturn struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Id        string        `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	OrderId   string        `protobuf:"bytes,2,opt,name=order_id,json=orderId,proto3" json:"order_id,omitempty"`
+	Status    ReturnStatus  `protobuf:"varint,3,opt,name=status,proto3,enum=oms.v1.ReturnStatus" json:"status,omitempty"`
+	Items     []*ReturnItem `protobuf:"bytes,4,rep,name=items,proto3" json:"items,omitempty"`
+	Reason    string        `protobuf:"bytes,5,opt,name=reason,proto3" json:"reason,omitempty"`
+	Refund    *Money        `protobuf:"bytes,6,opt,name=refund,proto3" json:"refund,omitempty"`                        // Сумма к возврату по всем позициям.
+	CreatedAt string        `protobuf:"bytes,7,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"` // RFC3339, UTC.
+	UpdatedAt string        `protobuf:"bytes,8,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at,omitempty"` // RFC3339, UTC.
+}
+
+func (x *OrderReturn) Reset() {
+	*x = OrderReturn{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_proto_oms_v1_order_service_proto_msgTypes[49]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *OrderReturn) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*OrderReturn) ProtoMessage() {}
+
+func (x *OrderReturn) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_oms_v1_order_service_proto_msgTypes[49]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use OrderReturn.ProtoReflect.Descriptor instead.
+func (*OrderReturn) Descriptor() ([]byte, []int) {
+	return file_proto_oms_v1_order_service_proto_rawDescGZIP(), []int{49}
+}
+
+func (x *OrderReturn) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *OrderReturn) GetOrderId() string {
+	if x != nil {
+		return x.OrderId
+	}
+	return ""
+}
+
+func (x *OrderReturn) GetStatus() ReturnStatus {
+	if x != nil {
+		return x.Status
+	}
+	return ReturnStatus_RETURN_STATUS_UNSPECIFIED
+}
+
+func (x *OrderReturn) GetItems() []*ReturnItem {
+	if x != nil {
+		return x.Items
+	}
+	return nil
+}
+
+func (x *OrderReturn) GetReason() string {
+	if x != nil {
+		return x.Reason
+	}
+	return ""
+}
+
+func (x *OrderReturn) GetRefund() *Money {
+	if x != nil {
+		return x.Refund
+	}
+	return nil
+}
+
+func (x *OrderReturn) GetCreatedAt() string {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return ""
+}
+
+func (x *OrderReturn) GetUpdatedAt() string {
+	if x != nil {
+		return x.UpdatedAt
+	}
+	return ""
+}
+
+type CreateReturnRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	OrderId string        `protobuf:"bytes,1,opt,name=order_id,json=orderId,proto3" json:"order_id,omitempty"`
+	Items   []*ReturnItem `protobuf:"bytes,2,rep,name=items,proto3" json:"items,omitempty"` // Заполняются item_id и qty.
+	Reason  string        `protobuf:"bytes,3,opt,name=reason,proto3" json:"reason,omitempty"`
+}
+
+func (x *CreateReturnRequest) Reset() {
+	*x = CreateReturnRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_proto_oms_v1_order_service_proto_msgTypes[50]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *CreateReturnRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateReturnRequest) ProtoMessage() {}
+
+func (x *CreateReturnRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_oms_v1_order_service_proto_msgTypes[50]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateReturnRequest.ProtoReflect.Descriptor instead.
+func (*CreateReturnRequest) Descriptor() ([]byte, []int) {
+	return file_proto_oms_v1_order_service_proto_rawDescGZIP(), []int{50}
+}
+
+func (x *CreateReturnRequest) GetOrderId() string {
+	if x != nil {
+		return x.OrderId
+	}
+	return ""
+}
+
+func (x *CreateReturnRequest) GetItems() []*ReturnItem {
+	if x != nil {
+		return x.Items
+	}
+	return nil
+}
+
+func (x *CreateReturnRequest) GetReason() string {
+	if x != nil {
+		return x.Reason
+	}
+	return ""
+}
+
+type CreateReturnResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	OrderReturn *OrderReturn `protobuf:"bytes,1,opt,name=order_return,json=orderReturn,proto3" json:"order_return,omitempty"`
+}
+
+func (x *CreateReturnResponse) Reset() {
+	*x = CreateReturnResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_proto_oms_v1_order_service_proto_msgTypes[51]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *CreateReturnResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateReturnResponse) ProtoMessage() {}
+
+func (x *CreateReturnResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_oms_v1_order_service_proto_msgTypes[51]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateReturnResponse.ProtoReflect.Descriptor instead.
+func (*CreateReturnResponse) Descriptor() ([]byte, []int) {
+	return file_proto_oms_v1_order_service_proto_rawDescGZIP(), []int{51}
+}
+
+func (x *CreateReturnResponse) GetOrderReturn() *OrderReturn {
+	if x != nil {
+		return x.OrderReturn
+	}
+	return nil
+}
+
+type GetReturnRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	OrderId  string `protobuf:"bytes,1,opt,name=order_id,json=orderId,proto3" json:"order_id,omitempty"`
+	ReturnId string `protobuf:"bytes,2,opt,name=return_id,json=returnId,proto3" json:"return_id,omitempty"`
+}
+
+func (x *GetReturnRequest) Reset() {
+	*x = GetReturnRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_proto_oms_v1_order_service_proto_msgTypes[52]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *GetReturnRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetReturnRequest) ProtoMessage() {}
+
+func (x *GetReturnRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_oms_v1_order_service_proto_msgTypes[52]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetReturnRequest.ProtoReflect.Descriptor instead.
+func (*GetReturnRequest) Descriptor() ([]byte, []int) {
+	return file_proto_oms_v1_order_service_proto_rawDescGZIP(), []int{52}
+}
+
+func (x *GetReturnRequest) GetOrderId() string {
+	if x != nil {
+		return x.OrderId
+	}
+	return ""
+}
+
+func (x *GetReturnRequest) GetReturnId() string {
+	if x != nil {
+		return x.ReturnId
+	}
+	return ""
+}
+
+type GetReturnResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	OrderReturn *OrderReturn `protobuf:"bytes,1,opt,name=order_return,json=orderReturn,proto3" json:"order_return,omitempty"`
+}
+
+func (x *GetReturnResponse) Reset() {
+	*x = GetReturnResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_proto_oms_v1_order_service_proto_msgTypes[53]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *GetReturnResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetReturnResponse) ProtoMessage() {}
+
+func (x *GetReturnResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_oms_v1_order_service_proto_msgTypes[53]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetReturnResponse.ProtoReflect.Descriptor instead.
+func (*GetReturnResponse) Descriptor() ([]byte, []int) {
+	return file_proto_oms_v1_order_service_proto_rawDescGZIP(), []int{53}
+}
+
+func (x *GetReturnResponse) GetOrderReturn() *OrderReturn {
+	if x != nil {
+		return x.OrderReturn
+	}
+	return nil
+}
+
+type AdvanceReturnRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	OrderId  string `protobuf:"bytes,1,opt,name=order_id,json=orderId,proto3" json:"order_id,omitempty"`
+	ReturnId string `protobuf:"bytes,2,opt,name=return_id,json=returnId,proto3" json:"return_id,omitempty"`
+	// Следующий статус возврата: APPROVED, RECEIVED или REFUNDED. Повтор для возврата,
+	// уже находящегося в этом статусе, возвращает его без изменений.
+	Status ReturnStatus `protobuf:"varint,3,opt,name=status,proto3,enum=oms.v1.ReturnStatus" json:"status,omitempty"`
+}
+
+func (x *AdvanceReturnRequest) Reset() {
+	*x = AdvanceReturnRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_proto_oms_v1_order_service_proto_msgTypes[54]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *AdvanceReturnRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AdvanceReturnRequest) ProtoMessage() {}
+
+func (x *AdvanceReturnRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_oms_v1_order_service_proto_msgTypes[54]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AdvanceReturnRequest.ProtoReflect.Descriptor instead.
+func (*AdvanceReturnRequest) Descriptor() ([]byte, []int) {
+	return file_proto_oms_v1_order_service_proto_rawDescGZIP(), []int{54}
+}
+
+func (x *AdvanceReturnRequest) GetOrderId() string {
+	if x != nil {
+		return x.OrderId
+	}
+	return ""
+}
+
+func (x *AdvanceReturnRequest) GetReturnId() string {
+	if x != nil {
+		return x.ReturnId
+	}
+	return ""
+}
+
+func (x *AdvanceReturnRequest) GetStatus() ReturnStatus {
+	if x != nil {
+		return x.Status
+	}
+	return ReturnStatus_RETURN_STATUS_UNSPECIFIED
+}
+
+type AdvanceReturnResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	OrderReturn *OrderReturn `protobuf:"bytes,1,opt,name=order_return,json=orderReturn,proto3" json:"order_return,omitempty"`
+}
+
+func (x *AdvanceReturnResponse) Reset() {
+	*x = AdvanceReturnResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_proto_oms_v1_order_service_proto_msgTypes[55]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *AdvanceReturnResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AdvanceReturnResponse) ProtoMessage() {}
+
+func (x *AdvanceReturnResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_oms_v1_order_service_proto_msgTypes[55]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AdvanceReturnResponse.ProtoReflect.Descriptor instead.
+func (*AdvanceReturnResponse) Descriptor() ([]byte, []int) {
+	return file_proto_oms_v1_order_service_proto_rawDescGZIP(), []int{55}
+}
+
+func (x *AdvanceReturnResponse) GetOrderReturn() *OrderReturn {
+	if x != nil {
+		return x.OrderReturn
+	}
+	return nil
+}
+
+// Размеры страниц списочных RPC (ListOrders и последующие списки).
+type PageLimits struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	DefaultPageSize int32 `protobuf:"varint,1,opt,name=default_page_size,json=defaultPageSize,proto3" json:"default_page_size,omitempty"` // Применяется при page_size = 0.
+	MaxPageSize     int32 `protobuf:"varint,2,opt,name=max_page_size,json=maxPageSize,proto3" json:"max_page_size,omitempty"`             // page_size больше этого значения → InvalidArgument.
+}
+
+func (x *PageLimits) Reset() {
+	*x = PageLimits{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_proto_oms_v1_order_service_proto_msgTypes[56]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *PageLimits) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PageLimits) ProtoMessage() {}
+
+func (x *PageLimits) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_oms_v1_order_service_proto_msgTypes[56]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PageLimits.ProtoReflect.Descriptor instead.
+func (*PageLimits) Descriptor() ([]byte, []int) {
+	return file_proto_oms_v1_order_service_proto_rawDescGZIP(), []int{56}
+}
+
+func (x *PageLimits) GetDefaultPageSize() int32 {
+	if x != nil {
+		return x.DefaultPageSize
+	}
+	return 0
+}
+
+func (x *PageLimits) GetMaxPageSize() int32 {
+	if x != nil {
+		return x.MaxPageSize
+	}
+	return 0
+}
+
+type GetServiceInfoRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+}
+
+func (x *GetServiceInfoRequest) Reset() {
+	*x = GetServiceInfoRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_proto_oms_v1_order_service_proto_msgTypes[57]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *GetServiceInfoRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetServiceInfoRequest) ProtoMessage() {}
+
+func (x *GetServiceInfoRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_oms_v1_order_service_proto_msgTypes[57]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetServiceInfoRequest.ProtoReflect.Descriptor instead.
+func (*GetServiceInfoRequest) Descriptor() ([]byte, []int) {
+	return file_proto_oms_v1_order_service_proto_rawDescGZIP(), []int{57}
+}
+
+type GetServiceInfoResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	PageLimits *PageLimits `protobuf:"bytes,1,opt,name=page_limits,json=pageLimits,proto3" json:"page_limits,omitempty"`
+}
+
+func (x *GetServiceInfoResponse) Reset() {
+	*x = GetServiceInfoResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_proto_oms_v1_order_service_proto_msgTypes[58]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *GetServiceInfoResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetServiceInfoResponse) ProtoMessage() {}
+
+func (x *GetServiceInfoResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_oms_v1_order_service_proto_msgTypes[58]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetServiceInfoResponse.ProtoReflect.Descriptor instead.
+func (*GetServiceInfoResponse) Descriptor() ([]byte, []int) {
+	return file_proto_oms_v1_order_service_proto_rawDescGZIP(), []int{58}
+}
+
+func (x *GetServiceInfoResponse) GetPageLimits() *PageLimits {
+	if x != nil {
+		return x.PageLimits
+	}
+	return nil
+}
+
+type RegisterCourierRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	CourierId   string              `protobuf:"bytes,1,opt,name=courier_id,json=courierId,proto3" json:"courier_id,omitempty"`
+	Phone       string              `protobuf:"bytes,2,opt,name=phone,proto3" json:"phone,omitempty"`
+	FirstName   string              `protobuf:"bytes,3,opt,name=first_name,json=firstName,proto3" json:"first_name,omitempty"`
+	LastName    string              `protobuf:"bytes,4,opt,name=last_name,json=lastName,proto3" json:"last_name,omitempty"`
+	VehicleType CourierVehicleType  `protobuf:"varint,5,opt,name=vehicle_type,json=vehicleType,proto3,enum=oms.v1.CourierVehicleType" json:"vehicle_type,omitempty"`
+	Zones       []*CourierZoneInput `protobuf:"bytes,6,rep,name=zones,proto3" json:"zones,omitempty"`
+}
+
+func (x *RegisterCourierRequest) Reset() {
+	*x = RegisterCourierRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_proto_oms_v1_order_service_proto_msgTypes[59]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *RegisterCourierRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RegisterCourierRequest) ProtoMessage() {}
+
+func (x *RegisterCourierRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_oms_v1_order_service_proto_msgTypes[59]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RegisterCourierRequest.ProtoReflect.Descriptor instead.
+func (*RegisterCourierRequest) Descriptor() ([]byte, []int) {
+	return file_proto_oms_v1_order_service_proto_rawDescGZIP(), []int{59}
+}
+
+func (x *RegisterCourierRequest) GetCourierId() string {
+	if x != nil {
+		return x.CourierId
+	}
+	return ""
+}
+
+func (x *RegisterCourierRequest) GetPhone() string {
+	if x != nil {
+		return x.Phone
+	}
+	return ""
+}
+
+func (x *RegisterCourierRequest) GetFirstName() string {
+	if x != nil {
+		return x.FirstName
+	}
+	return ""
+}
+
+func (x *RegisterCourierRequest) GetLastName() string {
+	if x != nil {
+		return x.LastName
+	}
+	return ""
+}
+
+func (x *RegisterCourierRequest) GetVehicleType() CourierVehicleType {
+	if x != nil {
+		return x.VehicleType
+	}
+	return CourierVehicleType_COURIER_VEHICLE_TYPE_UNSPECIFIED
+}
+
+func (x *RegisterCourierRequest) GetZones() []*CourierZoneInput {
+	if x != nil {
+		return x.Zones
+	}
+	return nil
+}
+
+type RegisterCourierResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Courier *Courier `protobuf:"bytes,1,opt,name=courier,proto3" json:"courier,omitempty"`
+}
+
+func (x *RegisterCourierResponse) Reset() {
+	*x = RegisterCourierResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_proto_oms_v1_order_service_proto_msgTypes[60]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *RegisterCourierResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RegisterCourierResponse) ProtoMessage() {}
+
+func (x *RegisterCourierResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_oms_v1_order_service_proto_msgTypes[60]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RegisterCourierResponse.ProtoReflect.Descriptor instead.
+func (*RegisterCourierResponse) Descriptor() ([]byte, []int) {
+	return file_proto_oms_v1_order_service_proto_rawDescGZIP(), []int{60}
+}
+
+func (x *RegisterCourierResponse) GetCourier() *Courier {
+	if x != nil {
+		return x.Courier
+	}
+	return nil
+}
+
+type GetCourierRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	CourierId string `protobuf:"bytes,1,opt,name=courier_id,json=courierId,proto3" json:"courier_id,omitempty"`
+}
+
+func (x *GetCourierRequest) Reset() {
+	*x = GetCourierRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_proto_oms_v1_order_service_proto_msgTypes[61]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *GetCourierRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetCourierRequest) ProtoMessage() {}
+
+func (x *GetCourierRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_oms_v1_order_service_proto_msgTypes[61]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetCourierRequest.ProtoReflect.Descriptor instead.
+func (*GetCourierRequest) Descriptor() ([]byte, []int) {
+	return file_proto_oms_v1_order_service_proto_rawDescGZIP(), []int{61}
+}
+
+func (x *GetCourierRequest) GetCourierId() string {
+	if x != nil {
+		return x.CourierId
+	}
+	return ""
+}
+
+type GetCourierResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Courier *Courier `protobuf:"bytes,1,opt,name=courier,proto3" json:"courier,omitempty"`
+}
+
+func (x *GetCourierResponse) Reset() {
+	*x = GetCourierResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_proto_oms_v1_order_service_proto_msgTypes[62]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *GetCourierResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetCourierResponse) ProtoMessage() {}
+
+func (x *GetCourierResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_oms_v1_order_service_proto_msgTypes[62]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetCourierResponse.ProtoReflect.Descriptor instead.
+func (*GetCourierResponse) Descriptor() ([]byte, []int) {
+	return file_proto_oms_v1_order_service_proto_rawDescGZIP(), []int{62}
+}
+
+func (x *GetCourierResponse) GetCourier() *Courier {
+	if x != nil {
+		return x.Courier
+	}
+	return nil
+}
+
+type ListCouriersByZoneRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	ZoneId string `protobuf:"bytes,1,opt,name=zone_id,json=zoneId,proto3" json:"zone_id,omitempty"`
+	Limit  int32  `protobuf:"varint,2,opt,name=limit,proto3" json:"limit,omitempty"`
+}
+
+func (x *ListCouriersByZoneRequest) Reset() {
+	*x = ListCouriersByZoneRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_proto_oms_v1_order_service_proto_msgTypes[63]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ListCouriersByZoneRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListCouriersByZoneRequest) ProtoMessage() {}
+
+func (x *ListCouriersByZoneRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_oms_v1_order_service_proto_msgTypes[63]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListCouriersByZoneRequest.ProtoReflect.Descriptor instead.
+func (*ListCouriersByZoneRequest) Descriptor() ([]byte, []int) {
+	return file_proto_oms_v1_order_service_proto_rawDescGZIP(), []int{63}
+}
+
+func (x *ListCouriersByZoneRequest) GetZoneId() string {
+	if x != nil {
+		return x.ZoneId
+	}
+	return ""
+}
+
+func (x *ListCouriersByZoneRequest) GetLimit() int32 {
+	if x != nil {
+		return x.Limit
+	}
+	return 0
+}
+
+type ListCouriersByZoneResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Couriers []*Courier `protobuf:"bytes,1,rep,name=couriers,proto3" json:"couriers,omitempty"`
+}
+
+func (x *ListCouriersByZoneResponse) Reset() {
+	*x = ListCouriersByZoneResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_proto_oms_v1_order_service_proto_msgTypes[64]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ListCouriersByZoneResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListCouriersByZoneResponse) ProtoMessage() {}
+
+func (x *ListCouriersByZoneResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_oms_v1_order_service_proto_msgTypes[64]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListCouriersByZoneResponse.ProtoReflect.Descriptor instead.
+func (*ListCouriersByZoneResponse) Descriptor() ([]byte, []int) {
+	return file_proto_oms_v1_order_service_proto_rawDescGZIP(), []int{64}
+}
+
+func (x *ListCouriersByZoneResponse) GetCouriers() []*Courier {
+	if x != nil {
+		return x.Couriers
+	}
+	return nil
+}
+
+type ReplaceCourierZonesRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	CourierId string              `protobuf:"bytes,1,opt,name=courier_id,json=courierId,proto3" json:"courier_id,omitempty"`
+	Zones     []*CourierZoneInput `protobuf:"bytes,2,rep,name=zones,proto3" json:"zones,omitempty"`
+}
+
+func (x *ReplaceCourierZonesRequest) Reset() {
+	*x = ReplaceCourierZonesRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_proto_oms_v1_order_service_proto_msgTypes[65]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ReplaceCourierZonesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ReplaceCourierZonesRequest) ProtoMessage() {}
+
+func (x *ReplaceCourierZonesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_oms_v1_order_service_proto_msgTypes[65]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ReplaceCourierZonesRequest.ProtoReflect.Descriptor instead.
+func (*ReplaceCourierZonesRequest) Descriptor() ([]byte, []int) {
+	return file_proto_oms_v1_order_service_proto_rawDescGZIP(), []int{65}
+}
+
+func (x *ReplaceCourierZonesRequest) GetCourierId() string {
+	if x != nil {
+		return x.CourierId
+	}
+	return ""
+}
+
+func (x *ReplaceCourierZonesRequest) GetZones() []*CourierZoneInput {
+	if x != nil {
+		return x.Zones
+	}
+	return nil
+}
+
+type ReplaceCourierZonesResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	CourierId string         `protobuf:"bytes,1,opt,name=courier_id,json=courierId,proto3" json:"courier_id,omitempty"`
+	Zones     []*CourierZone `protobuf:"bytes,2,rep,name=zones,proto3" json:"zones,omitempty"`
+}
+
+func (x *ReplaceCourierZonesResponse) Reset() {
+	*x = ReplaceCourierZonesResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_proto_oms_v1_order_service_proto_msgTypes[66]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ReplaceCourierZonesResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ReplaceCourierZonesResponse) ProtoMessage() {}
+
+func (x *ReplaceCourierZonesResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_oms_v1_order_service_proto_msgTypes[66]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ReplaceCourierZonesResponse.ProtoReflect.Descriptor instead.
+func (*ReplaceCourierZonesResponse) Descriptor() ([]byte, []int) {
+	return file_proto_oms_v1_order_service_proto_rawDescGZIP(), []int{66}
+}
+
+func (x *ReplaceCourierZonesResponse) GetCourierId() string {
+	if x != nil {
+		return x.CourierId
+	}
+	return ""
+}
+
+func (x *ReplaceCourierZonesResponse) GetZones() []*CourierZone {
+	if x != nil {
+		return x.Zones
+	}
+	return nil
+}
+
+type CreateCourierSlotRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	SlotId        string `protobuf:"bytes,1,opt,name=slot_id,json=slotId,proto3" json:"slot_id,omitempty"`
+	CourierId     string `protobuf:"bytes,2,opt,name=courier_id,json=courierId,proto3" json:"courier_id,omitempty"`
+	SlotStartUnix int64  `protobuf:"varint,3,opt,name=slot_start_unix,json=slotStartUnix,proto3" json:"slot_start_unix,omitempty"`
+	SlotEndUnix   int64  `protobuf:"varint,4,opt,name=slot_end_unix,json=slotEndUnix,proto3" json:"slot_end_unix,omitempty"`
+	DurationHours int32  `protobuf:"varint,5,opt,name=duration_hours,json=durationHours,proto3" json:"duration_hours,omitempty"`
+}
+
+func (x *CreateCourierSlotRequest) Reset() {
+	*x = CreateCourierSlotRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_proto_oms_v1_order_service_proto_msgTypes[67]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *CreateCourierSlotRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateCourierSlotRequest) ProtoMessage() {}
+
+func (x *CreateCourierSlotRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_oms_v1_order_service_proto_msgTypes[67]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateCourierSlotRequest.ProtoReflect.Descriptor instead.
+func (*CreateCourierSlotRequest) Descriptor() ([]byte, []int) {
+	return file_proto_oms_v1_order_service_proto_rawDescGZIP(), []int{67}
+}
+
+func (x *CreateCourierSlotRequest) GetSlotId() string {
+	if x != nil {
+		return x.SlotId
+	}
+	return ""
+}
+
+func (x *CreateCourierSlotRequest) GetCourierId() string {
+	if x != nil {
+		return x.CourierId
+	}
+	return ""
+}
+
+func (x *CreateCourierSlotRequest) GetSlotStartUnix() int64 {
+	if x != nil {
+		return x.SlotStartUnix
+	}
+	return 0
+}
+
+func (x *CreateCourierSlotRequest) GetSlotEndUnix() int64 {
+	if x != nil {
+		return x.SlotEndUnix
+	}
+	return 0
+}
+
+func (x *CreateCourierSlotRequest) GetDurationHours() int32 {
+	if x != nil {
+		return x.DurationHours
+	}
+	return 0
+}
+
+type CreateCourierSlotResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Slot *CourierSlot `protobuf:"bytes,1,opt,name=slot,proto3" json:"slot,omitempty"`
+}
+
+func (x *CreateCourierSlotResponse) Reset() {
+	*x = CreateCourierSlotResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_proto_oms_v1_order_service_proto_msgTypes[68]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *CreateCourierSlotResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateCourierSlotResponse) ProtoMessage() {}
+
+func (x *CreateCourierSlotResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_oms_v1_order_service_proto_msgTypes[68]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateCourierSlotResponse.ProtoReflect.Descriptor instead.
+func (*CreateCourierSlotResponse) Descriptor() ([]byte, []int) {
+	return file_proto_oms_v1_order_service_proto_rawDescGZIP(), []int{68}
+}
+
+func (x *CreateCourierSlotResponse) GetSlot() *CourierSlot {
+	if x != nil {
+		return x.Slot
+	}
+	return nil
+}
+
+type ListCourierSlotsRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	CourierId string `protobuf:"bytes,1,opt,name=courier_id,json=courierId,proto3" json:"courier_id,omitempty"`
+	FromUnix  int64  `protobuf:"varint,2,opt,name=from_unix,json=fromUnix,proto3" json:"from_unix,omitempty"`
+	ToUnix    int64  `protobuf:"varint,3,opt,name=to_unix,json=toUnix,proto3" json:"to_unix,omitempty"`
+}
+
+func (x *ListCourierSlotsRequest) Reset() {
+	*x = ListCourierSlotsRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_proto_oms_v1_order_service_proto_msgTypes[69]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ListCourierSlotsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListCourierSlotsRequest) ProtoMessage() {}
+
+func (x *ListCourierSlotsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_oms_v1_order_service_proto_msgTypes[69]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListCourierSlotsRequest.ProtoReflect.Descriptor instead.
+func (*ListCourierSlotsRequest) Descriptor() ([]byte, []int) {
+	return file_proto_oms_v1_order_service_proto_rawDescGZIP(), []int{69}
+}
+
+func (x *ListCourierSlotsRequest) GetCourierId() string {
+	if x != nil {
+		return x.CourierId
+	}
+	return ""
+}
+
+func (x *ListCourierSlotsRequest) GetFromUnix() int64 {
+	if x != nil {
+		return x.FromUnix
+	}
+	return 0
+}
+
+func (x *ListCourierSlotsRequest) GetToUnix() int64 {
+	if x != nil {
+		return x.ToUnix
+	}
+	return 0
+}
+
+type ListCourierSlotsResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Slots []*CourierSlot `protobuf:"bytes,1,rep,name=slots,proto3" json:"slots,omitempty"`
+}
+
+func (x *ListCourierSlotsResponse) Reset() {
+	*x = ListCourierSlotsResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_proto_oms_v1_order_service_proto_msgTypes[70]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ListCourierSlotsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListCourierSlotsResponse) ProtoMessage() {}
+
+func (x *ListCourierSlotsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_oms_v1_order_service_proto_msgTypes[70]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListCourierSlotsResponse.ProtoReflect.Descriptor instead.
+func (*ListCourierSlotsResponse) Descriptor() ([]byte, []int) {
+	return file_proto_oms_v1_order_service_proto_rawDescGZIP(), []int{70}
+}
+
+func (x *ListCourierSlotsResponse) GetSlots() []*CourierSlot {
+	if x != nil {
+		return x.Slots
+	}
+	return nil
+}
+
+type GetCourierVehicleCapabilityRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	VehicleType CourierVehicleType `protobuf:"varint,1,opt,name=vehicle_type,json=vehicleType,proto3,enum=oms.v1.CourierVehicleType" json:"vehicle_type,omitempty"`
+}
+
+func (x *GetCourierVehicleCapabilityRequest) Reset() {
+	*x = GetCourierVehicleCapabilityRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_proto_oms_v1_order_service_proto_msgTypes[71]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *GetCourierVehicleCapabilityRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetCourierVehicleCapabilityRequest) ProtoMessage() {}
+
+func (x *GetCourierVehicleCapabilityRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_oms_v1_order_service_proto_msgTypes[71]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetCourierVehicleCapabilityRequest.ProtoReflect.Descriptor instead.
+func (*GetCourierVehicleCapabilityRequest) Descriptor() ([]byte, []int) {
+	return file_proto_oms_v1_order_service_proto_rawDescGZIP(), []int{71}
+}
+
+func (x *GetCourierVehicleCapabilityRequest) GetVehicleType() CourierVehicleType {
+	if x != nil {
+		return x.VehicleType
+	}
+	return CourierVehicleType_COURIER_VEHICLE_TYPE_UNSPECIFIED
+}
+
+type GetCourierVehicleCapabilityResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Capability *CourierVehicleCapability `protobuf:"bytes,1,opt,name=capability,proto3" json:"capability,omitempty"`
+}
+
+func (x *GetCourierVehicleCapabilityResponse) Reset() {
+	*x = GetCourierVehicleCapabilityResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_proto_oms_v1_order_service_proto_msgTypes[72]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *GetCourierVehicleCapabilityResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetCourierVehicleCapabilityResponse) ProtoMessage() {}
+
+func (x *GetCourierVehicleCapabilityResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_oms_v1_order_service_proto_msgTypes[72]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetCourierVehicleCapabilityResponse.ProtoReflect.Descriptor instead.
+func (*GetCourierVehicleCapabilityResponse) Descriptor() ([]byte, []int) {
+	return file_proto_oms_v1_order_service_proto_rawDescGZIP(), []int{72}
+}
+
+func (x *GetCourierVehicleCapabilityResponse) GetCapability() *CourierVehicleCapability {
+	if x != nil {
+		return x.Capability
+	}
+	return nil
+}
+
+type ListCourierVehicleCapabilitiesRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+}
+
+func (x *ListCourierVehicleCapabilitiesRequest) Reset() {
+	*x = ListCourierVehicleCapabilitiesRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_proto_oms_v1_order_service_proto_msgTypes[73]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ListCourierVehicleCapabilitiesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListCourierVehicleCapabilitiesRequest) ProtoMessage() {}
+
+func (x *ListCourierVehicleCapabilitiesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_oms_v1_order_service_proto_msgTypes[73]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListCourierVehicleCapabilitiesRequest.ProtoReflect.Descriptor instead.
+func (*ListCourierVehicleCapabilitiesRequest) Descriptor() ([]byte, []int) {
+	return file_proto_oms_v1_order_service_proto_rawDescGZIP(), []int{73}
+}
+
+type ListCourierVehicleCapabilitiesResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Capabilities []*CourierVehicleCapability `protobuf:"bytes,1,rep,name=capabilities,proto3" json:"capabilities,omitempty"`
+}
+
+func (x *ListCourierVehicleCapabilitiesResponse) Reset() {
+	*x = ListCourierVehicleCapabilitiesResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_proto_oms_v1_order_service_proto_msgTypes[74]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ListCourierVehicleCapabilitiesResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListCourierVehicleCapabilitiesResponse) ProtoMessage() {}
+
+func (x *ListCourierVehicleCapabilitiesResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_oms_v1_order_service_proto_msgTypes[74]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListCourierVehicleCapabilitiesResponse.ProtoReflect.Descriptor instead.
+func (*ListCourierVehicleCapabilitiesResponse) Descriptor() ([]byte, []int) {
+	return file_proto_oms_v1_order_service_proto_rawDescGZIP(), []int{74}
+}
+
+func (x *ListCourierVehicleCapabilitiesResponse) GetCapabilities() []*CourierVehicleCapability {
+	if x != nil {
+		return x.Capabilities
+	}
+	return nil
+}
+
+type SubmitCourierRatingRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	RatingId  string             `protobuf:"bytes,1,opt,name=rating_id,json=ratingId,proto3" json:"rating_id,omitempty"`
+	CourierId string             `protobuf:"bytes,2,opt,name=courier_id,json=courierId,proto3" json:"courier_id,omitempty"`
+	Score     int32              `protobuf:"varint,3,opt,name=score,proto3" json:"score,omitempty"`
+	Tags      []CourierRatingTag `protobuf:"varint,4,rep,packed,name=tags,proto3,enum=oms.v1.CourierRatingTag" json:"tags,omitempty"`
+	Comment   string             `protobuf:"bytes,5,opt,name=comment,proto3" json:"comment,omitempty"`
+}
+
+func (x *SubmitCourierRatingRequest) Reset() {
+	*x = SubmitCourierRatingRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_proto_oms_v1_order_service_proto_msgTypes[75]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *SubmitCourierRatingRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SubmitCourierRatingRequest) ProtoMessage() {}
+
+func (x *SubmitCourierRatingRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_oms_v1_order_service_proto_msgTypes[75]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SubmitCourierRatingRequest.ProtoReflect.Descriptor instead.
+func (*SubmitCourierRatingRequest) Descriptor() ([]byte, []int) {
+	return file_proto_oms_v1_order_service_proto_rawDescGZIP(), []int{75}
+}
+
+func (x *SubmitCourierRatingRequest) GetRatingId() string {
+	if x != nil {
+		return x.RatingId
+	}
+	return ""
+}
+
+func (x *SubmitCourierRatingRequest) GetCourierId() string {
+	if x != nil {
+		return x.CourierId
+	}
+	return ""
+}
+
+func (x *SubmitCourierRatingRequest) GetScore() int32 {
+	if x != nil {
+		return x.Score
+	}
+	return 0
+}
+
+func (x *SubmitCourierRatingRequest) GetTags() []CourierRatingTag {
+	if x != nil {
+		return x.Tags
+	}
+	return nil
+}
+
+func (x *SubmitCourierRatingRequest) GetComment() string {
+	if x != nil {
+		return x.Comment
+	}
+	return ""
+}
+
+type SubmitCourierRatingResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	RatingId  string `protobuf:"bytes,1,opt,name=rating_id,json=ratingId,proto3" json:"rating_id,omitempty"`
+	CourierId string `protobuf:"bytes,2,opt,name=courier_id,json=courierId,proto3" json:"courier_id,omitempty"`
+}
+
+func (x *SubmitCourierRatingResponse) Reset() {
+	*x = SubmitCourierRatingResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_proto_oms_v1_order_service_proto_msgTypes[76]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *SubmitCourierRatingResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SubmitCourierRatingResponse) ProtoMessage() {}
+
+func (x *SubmitCourierRatingResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_oms_v1_order_service_proto_msgTypes[76]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SubmitCourierRatingResponse.ProtoReflect.Descriptor instead.
+func (*SubmitCourierRatingResponse) Descriptor() ([]byte, []int) {
+	return file_proto_oms_v1_order_service_proto_rawDescGZIP(), []int{76}
+}
+
+func (x *SubmitCourierRatingResponse) GetRatingId() string {
+	if x != nil {
+		return x.RatingId
+	}
+	return ""
+}
+
+func (x *SubmitCourierRatingResponse) GetCourierId() string {
+	if x != nil {
+		return x.CourierId
+	}
+	return ""
+}
+
+type GetCourierRatingSummaryRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	CourierId string `protobuf:"bytes,1,opt,name=courier_id,json=courierId,proto3" json:"courier_id,omitempty"`
+}
+
+func (x *GetCourierRatingSummaryRequest) Reset() {
+	*x = GetCourierRatingSummaryRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_proto_oms_v1_order_service_proto_msgTypes[77]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *GetCourierRatingSummaryRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetCourierRatingSummaryRequest) ProtoMessage() {}
+
+func (x *GetCourierRatingSummaryRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_oms_v1_order_service_proto_msgTypes[77]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetCourierRatingSummaryRequest.ProtoReflect.Descriptor instead.
+func (*GetCourierRatingSummaryRequest) Descriptor() ([]byte, []int) {
+	return file_proto_oms_v1_order_service_proto_rawDescGZIP(), []int{77}
+}
+
+func (x *GetCourierRatingSummaryRequest) GetCourierId() string {
+	if x != nil {
+		return x.CourierId
+	}
+	return ""
+}
+
+type CourierRatingSummary struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	CourierId       string  `protobuf:"bytes,1,opt,name=courier_id,json=courierId,proto3" json:"courier_id,omitempty"`
+	RatingsCount    int64   `protobuf:"varint,2,opt,name=ratings_count,json=ratingsCount,proto3" json:"ratings_count,omitempty"`
+	AverageScore    float64 `protobuf:"fixed64,3,opt,name=average_score,json=averageScore,proto3" json:"average_score,omitempty"`
+	LowRatingsCount int64   `protobuf:"varint,4,opt,name=low_ratings_count,json=lowRatingsCount,proto3" json:"low_ratings_count,omitempty"`
+	Score_1Count    int64   `protobuf:"varint,5,opt,name=score_1_count,json=score1Count,proto3" json:"score_1_count,omitempty"`
+	Score_2Count    int64   `protobuf:"varint,6,opt,name=score_2_count,json=score2Count,proto3" json:"score_2_count,omitempty"`
+	Score_3Count    int64   `protobuf:"varint,7,opt,name=score_3_count,json=score3Count,proto3" json:"score_3_count,omitempty"`
+	Score_4Count    int64   `protobuf:"varint,8,opt,name=score_4_count,json=score4Count,proto3" json:"score_4_count,omitempty"`
+	Score_5Count    int64   `protobuf:"varint,9,opt,name=score_5_count,json=score5Count,proto3" json:"score_5_count,omitempty"`
+	LastRatingUnix  int64   `protobuf:"varint,10,opt,name=last_rating_unix,json=lastRatingUnix,proto3" json:"last_rating_unix,omitempty"`
+	OnTimeCount     int64   `protobuf:"varint,11,opt,name=on_time_count,json=onTimeCount,proto3" json:"on_time_count,omitempty"`
+	PoliteCount     int64   `protobuf:"varint,12,opt,name=polite_count,json=politeCount,proto3" json:"polite_count,omitempty"`
+	CarefulCount    int64   `protobuf:"varint,13,opt,name=careful_count,json=carefulCount,proto3" json:"careful_count,omitempty"`
+	DelayedCount    int64   `protobuf:"varint,14,opt,name=delayed_count,json=delayedCount,proto3" json:"delayed_count,omitempty"`
+	RudeCount       int64   `protobuf:"varint,15,opt,name=rude_count,json=rudeCount,proto3" json:"rude_count,omitempty"`
+	DamagedCount    int64   `protobuf:"varint,16,opt,name=damaged_count,json=damagedCount,proto3" json:"damaged_count,omitempty"`
+	OtherIssueCount int64   `protobuf:"varint,17,opt,name=other_issue_count,json=otherIssueCount,proto3" json:"other_issue_count,omitempty"`
+}
+
+func (x *CourierRatingSummary) Reset() {
+	*x = CourierRatingSummary{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_proto_oms_v1_order_service_proto_msgTypes[78]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *CourierRatingSummary) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CourierRatingSummary) ProtoMessage() {}
+
+func (x *CourierRatingSummary) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_oms_v1_order_service_proto_msgTypes[78]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CourierRatingSummary.ProtoReflect.Descriptor instead.
+func (*CourierRatingSummary) Descriptor() ([]byte, []int) {
+	return file_proto_oms_v1_order_service_proto_rawDescGZIP(), []int{78}
+}
+
+func (x *CourierRatingSummary) GetCourierId() string {
+	if x != nil {
+		return x.CourierId
+	}
+	return ""
+}
+
+func (x *CourierRatingSummary) GetRatingsCount() int64 {
+	if x != nil {
+		return x.RatingsCount
+	}
+	return 0
+}
+
+func (x *CourierRatingSummary) GetAverageScore() float64 {
+	if x != nil {
+		return x.AverageScore
+	}
+	return 0
+}
+
+func (x *CourierRatingSummary) GetLowRatingsCount() int64 {
+	if x != nil {
+		return x.LowRatingsCount
+	}
+	return 0
+}
+
+func (x *CourierRatingSummary) GetScore_1Count() int64 {
+	if x != nil {
+		return x.Score_1Count
+	}
+	return 0
+}
+
+func (x *CourierRatingSummary) GetScore_2Count() int64 {
+	if x != nil {
+		return x.Score_2Count
+	}
+	return 0
+}
+
+func (x *CourierRatingSummary) GetScore_3Count() int64 {
+	if x != nil {
+		return x.Score_3Count
+	}
+	return 0
+}
+
+func (x *CourierRatingSummary) GetScore_4Count() int64 {
+	if x != nil {
+		return x.Score_4Count
+	}
+	return 0
+}
+
+func (x *CourierRatingSummary) GetScore_5Count() int64 {
+	if x != nil {
+		return x.Score_5Count
+	}
+	return 0
+}
+
+func (x *CourierRatingSummary) GetLastRatingUnix() int64 {
+	if x != nil {
+		return x.LastRatingUnix
+	}
+	return 0
+}
+
+func (x *CourierRatingSummary) GetOnTimeCount() int64 {
+	if x != nil {
+		return x.OnTimeCount
+	}
+	return 0
+}
+
+func (x *CourierRatingSummary) GetPoliteCount() int64 {
+	if x != nil {
+		return x.PoliteCount
+	}
+	return 0
+}
+
+func (x *CourierRatingSummary) GetCarefulCount() int64 {
+	if x != nil {
+		return x.CarefulCount
+	}
+	return 0
+}
+
+func (x *CourierRatingSummary) GetDelayedCount() int64 {
+	if x != nil {
+		return x.DelayedCount
+	}
+	return 0
+}
+
+func (x *CourierRatingSummary) GetRudeCount() int64 {
+	if x != nil {
+		return x.RudeCount
+	}
+	return 0
+}
+
+func (x *CourierRatingSummary) GetDamagedCount() int64 {
+	if x != nil {
+		return x.DamagedCount
+	}
+	return 0
+}
+
+func (x *CourierRatingSummary) GetOtherIssueCount() int64 {
+	if x != nil {
+		return x.OtherIssueCount
+	}
+	return 0
+}
+
+type GetCourierRatingSummaryResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Summary *CourierRatingSummary `protobuf:"bytes,1,opt,name=summary,proto3" json:"summary,omitempty"`
+}
+
+func (x *GetCourierRatingSummaryResponse) Reset() {
+	*x = GetCourierRatingSummaryResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_proto_oms_v1_order_service_proto_msgTypes[79]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *GetCourierRatingSummaryResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetCourierRatingSummaryResponse) ProtoMessage() {}
+
+func (x *GetCourierRatingSummaryResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_oms_v1_order_service_proto_msgTypes[79]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetCourierRatingSummaryResponse.ProtoReflect.Descriptor instead.
+func (*GetCourierRatingSummaryResponse) Descriptor() ([]byte, []int) {
+	return file_proto_oms_v1_order_service_proto_rawDescGZIP(), []int{79}
+}
+
+func (x *GetCourierRatingSummaryResponse) GetSummary() *CourierRatingSummary {
+	if x != nil {
+		return x.Summary
+	}
+	return nil
+}
+
+type DeleteCustomerDataRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	CustomerId string `protobuf:"bytes,1,opt,name=customer_id,json=customerId,proto3" json:"customer_id,omitempty"`
+	// Основание для удаления (номер обращения/тикета legal) — попадает в аудит.
+	Reason string `protobuf:"bytes,2,opt,name=reason,proto3" json:"reason,omitempty"`
+}
+
+func (x *DeleteCustomerDataRequest) Reset() {
+	*x = DeleteCustomerDataRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_proto_oms_v1_order_service_proto_msgTypes[80]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *DeleteCustomerDataRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteCustomerDataRequest) ProtoMessage() {}
+
+func (x *DeleteCustomerDataRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_oms_v1_order_service_proto_msgTypes[80]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteCustomerDataRequest.ProtoReflect.Descriptor instead.
+func (*DeleteCustomerDataRequest) Descriptor() ([]byte, []int) {
+	return file_proto_oms_v1_order_service_proto_rawDescGZIP(), []int{80}
+}
+
+func (x *DeleteCustomerDataRequest) GetCustomerId() string {
+	if x != nil {
+		return x.CustomerId
+	}
+	return ""
+}
+
+func (x *DeleteCustomerDataRequest) GetReason() string {
+	if x != nil {
+		return x.Reason
+	}
+	return ""
+}
+
+type DeleteCustomerDataResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// Псевдоним, которым заменён customer_id в заказах.
+	Pseudonym                  string   `protobuf:"bytes,1,opt,name=pseudonym,proto3" json:"pseudonym,omitempty"`
+	OrderIds                   []string `protobuf:"bytes,2,rep,name=order_ids,json=orderIds,proto3" json:"order_ids,omitempty"`
+	TimelineEventsScrubbed     int32    `protobuf:"varint,3,opt,name=timeline_events_scrubbed,json=timelineEventsScrubbed,proto3" json:"timeline_events_scrubbed,omitempty"`
+	IdempotencyRecordsScrubbed int32    `protobuf:"varint,4,opt,name=idempotency_records_scrubbed,json=idempotencyRecordsScrubbed,proto3" json:"idempotency_records_scrubbed,omitempty"`
+	ErasedAtUnix               int64    `protobuf:"varint,5,opt,name=erased_at_unix,json=erasedAtUnix,proto3" json:"erased_at_unix,omitempty"`
+}
+
+func (x *DeleteCustomerDataResponse) Reset() {
+	*x = DeleteCustomerDataResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_proto_oms_v1_order_service_proto_msgTypes[81]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *DeleteCustomerDataResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteCustomerDataResponse) ProtoMessage() {}
+
+func (x *DeleteCustomerDataResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_oms_v1_order_service_proto_msgTypes[81]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteCustomerDataResponse.ProtoReflect.Descriptor instead.
+func (*DeleteCustomerDataResponse) Descriptor() ([]byte, []int) {
+	return file_proto_oms_v1_order_service_proto_rawDescGZIP(), []int{81}
+}
+
+func (x *DeleteCustomerDataResponse) GetPseudonym() string {
+	if x != nil {
+		return x.Pseudonym
+	}
+	return ""
+}
+
+func (x *DeleteCustomerDataResponse) GetOrderIds() []string {
+	if x != nil {
+		return x.OrderIds
+	}
+	return nil
+}
+
+func (x *DeleteCustomerDataResponse) GetTimelineEventsScrubbed() int32 {
+	if x != nil {
+		return x.TimelineEventsScrubbed
+	}
+	return 0
+}
+
+func (x *DeleteCustomerDataResponse) GetIdempotencyRecordsScrubbed() int32 {
+	if x != nil {
+		return x.IdempotencyRecordsScrubbed
+	}
+	return 0
+}
+
+func (x *DeleteCustomerDataResponse) GetErasedAtUnix() int64 {
+	if x != nil {
+		return x.ErasedAtUnix
+	}
+	return 0
+}
+
+type GetQuotaUsageRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// Principal (API-ключ партнёра), как его передаёт gateway в x-principal-id.
+	Principal string `protobuf:"bytes,1,opt,name=principal,proto3" json:"principal,omitempty"`
+	// UTC-сутки в формате YYYY-MM-DD; пусто — текущие.
+	Day string `protobuf:"bytes,2,opt,name=day,proto3" json:"day,omitempty"`
+}
+
+func (x *GetQuotaUsageRequest) Reset() {
+	*x = GetQuotaUsageRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_proto_oms_v1_order_service_proto_msgTypes[82]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *GetQuotaUsageRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetQuotaUsageRequest) ProtoMessage() {}
+
+func (x *GetQuotaUsageRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_oms_v1_order_service_proto_msgTypes[82]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetQuotaUsageRequest.ProtoReflect.Descriptor instead.
+func (*GetQuotaUsageRequest) Descriptor() ([]byte, []int) {
+	return file_proto_oms_v1_order_service_proto_rawDescGZIP(), []int{82}
+}
+
+func (x *GetQuotaUsageRequest) GetPrincipal() string {
+	if x != nil {
+		return x.Principal
+	}
+	return ""
+}
+
+func (x *GetQuotaUsageRequest) GetDay() string {
+	if x != nil {
+		return x.Day
+	}
+	return ""
+}
+
+type QuotaAmountUsage struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Currency  string `protobuf:"bytes,1,opt,name=currency,proto3" json:"currency,omitempty"`
+	UsedMinor int64  `protobuf:"varint,2,opt,name=used_minor,json=usedMinor,proto3" json:"used_minor,omitempty"`
+	// 0 — лимит суммы для валюты не задан.
+	LimitMinor int64 `protobuf:"varint,3,opt,name=limit_minor,json=limitMinor,proto3" json:"limit_minor,omitempty"`
+}
+
+func (x *QuotaAmountUsage) Reset() {
+	*x = QuotaAmountUsage{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_proto_oms_v1_order_service_proto_msgTypes[83]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *QuotaAmountUsage) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*QuotaAmountUsage) ProtoMessage() {}
+
+func (x *QuotaAmountUsage) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_oms_v1_order_service_proto_msgTypes[83]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use QuotaAmountUsage.ProtoReflect.Descriptor instead.
+func (*QuotaAmountUsage) Descriptor() ([]byte, []int) {
+	return file_proto_oms_v1_order_service_proto_rawDescGZIP(), []int{83}
+}
+
+func (x *QuotaAmountUsage) GetCurrency() string {
+	if x != nil {
+		return x.Currency
+	}
+	return ""
+}
+
+func (x *QuotaAmountUsage) GetUsedMinor() int64 {
+	if x != nil {
+		return x.UsedMinor
+	}
+	return 0
+}
+
+func (x *QuotaAmountUsage) GetLimitMinor() int64 {
+	if x != nil {
+		return x.LimitMinor
+	}
+	return 0
+}
+
+type GetQuotaUsageResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Principal string `protobuf:"bytes,1,opt,name=principal,proto3" json:"principal,omitempty"`
+	Day       string `protobuf:"bytes,2,opt,name=day,proto3" json:"day,omitempty"`
+	// false — квота для principal'а не настроена, расход не учитывается.
+	QuotaConfigured bool  `protobuf:"varint,3,opt,name=quota_configured,json=quotaConfigured,proto3" json:"quota_configured,omitempty"`
+	OrdersUsed      int64 `protobuf:"varint,4,opt,name=orders_used,json=ordersUsed,proto3" json:"orders_used,omitempty"`
+	// 0 — лимит числа заказов не задан.
+	OrdersLimit  int64               `protobuf:"varint,5,opt,name=orders_limit,json=ordersLimit,proto3" json:"orders_limit,omitempty"`
+	Amounts      []*QuotaAmountUsage `protobuf:"bytes,6,rep,name=amounts,proto3" json:"amounts,omitempty"`
+	ResetsAtUnix int64               `protobuf:"varint,7,opt,name=resets_at_unix,json=resetsAtUnix,proto3" json:"resets_at_unix,omitempty"`
+}
+
+func (x *GetQuotaUsageResponse) Reset() {
+	*x = GetQuotaUsageResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_proto_oms_v1_order_service_proto_msgTypes[84]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *GetQuotaUsageResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetQuotaUsageResponse) ProtoMessage() {}
+
+func (x *GetQuotaUsageResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_oms_v1_order_service_proto_msgTypes[84]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetQuotaUsageResponse.ProtoReflect.Descriptor instead.
+func (*GetQuotaUsageResponse) Descriptor() ([]byte, []int) {
+	return file_proto_oms_v1_order_service_proto_rawDescGZIP(), []int{84}
+}
+
+func (x *GetQuotaUsageResponse) GetPrincipal() string {
+	if x != nil {
+		return x.Principal
+	}
+	return ""
+}
+
+func (x *GetQuotaUsageResponse) GetDay() string {
+	if x != nil {
+		return x.Day
+	}
+	return ""
+}
+
+func (x *GetQuotaUsageResponse) GetQuotaConfigured() bool {
+	if x != nil {
+		return x.QuotaConfigured
+	}
+	return false
+}
+
+func (x *GetQuotaUsageResponse) GetOrdersUsed() int64 {
+	if x != nil {
+		return x.OrdersUsed
+	}
+	return 0
+}
+
+func (x *GetQuotaUsageResponse) GetOrdersLimit() int64 {
+	if x != nil {
+		return x.OrdersLimit
+	}
+	return 0
+}
+
+func (x *GetQuotaUsageResponse) GetAmounts() []*QuotaAmountUsage {
+	if x != nil {
+		return x.Amounts
+	}
+	return nil
+}
+
+func (x *GetQuotaUsageResponse) GetResetsAtUnix() int64 {
+	if x != nil {
+		return x.ResetsAtUnix
+	}
+	return 0
+}
+
+type GetEventsSinceRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// Курсор из next_cursor предыдущего ответа; пусто — с самого раннего хранимого события.
+	Cursor string `protobuf:"bytes,1,opt,name=cursor,proto3" json:"cursor,omitempty"`
+	// Размер пачки; 0 — 100, максимум 1000.
+	Limit int32 `protobuf:"varint,2,opt,name=limit,proto3" json:"limit,omitempty"`
+	// Фильтр по типу агрегата ("order", "customer"); пусто — все события.
+	AggregateType string `protobuf:"bytes,3,opt,name=aggregate_type,json=aggregateType,proto3" json:"aggregate_type,omitempty"`
+}
+
+func (x *GetEventsSinceRequest) Reset() {
+	*x = GetEventsSinceRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_proto_oms_v1_order_service_proto_msgTypes[85]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *GetEventsSinceRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetEventsSinceRequest) ProtoMessage() {}
+
+func (x *GetEventsSinceRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_oms_v1_order_service_proto_msgTypes[85]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetEventsSinceRequest.ProtoReflect.Descriptor instead.
+func (*GetEventsSinceRequest) Descriptor() ([]byte, []int) {
+	return file_proto_oms_v1_order_service_proto_rawDescGZIP(), []int{85}
+}
+
+func (x *GetEventsSinceRequest) GetCursor() string {
+	if x != nil {
+		return x.Cursor
+	}
+	return ""
+}
+
+func (x *GetEventsSinceRequest) GetLimit() int32 {
+	if x != nil {
+		return x.Limit
+	}
+	return 0
+}
+
+func (x *GetEventsSinceRequest) GetAggregateType() string {
+	if x != nil {
+		return x.AggregateType
+	}
+	return ""
+}
+
+// Событие ленты; id совпадает с x-event-id сообщения в Kafka.
+type FeedEvent struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Id            string `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	AggregateType string `protobuf:"bytes,2,opt,name=aggregate_type,json=aggregateType,proto3" json:"aggregate_type,omitempty"`
+	AggregateId   string `protobuf:"bytes,3,opt,name=aggregate_id,json=aggregateId,proto3" json:"aggregate_id,omitempty"`
+	EventType     string `protobuf:"bytes,4,opt,name=event_type,json=eventType,proto3" json:"event_type,omitempty"`
+	// Payload события в JSON, как в OutboxEnvelope.payload (без шифрования полей).
+	Payload           []byte `protobuf:"bytes,5,opt,name=payload,proto3" json:"payload,omitempty"`
+	CreatedAtUnixNano int64  `protobuf:"varint,6,opt,name=created_at_unix_nano,json=createdAtUnixNano,proto3" json:"created_at_unix_nano,omitempty"`
+}
+
+func (x *FeedEvent) Reset() {
+	*x = FeedEvent{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_proto_oms_v1_order_service_proto_msgTypes[86]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *FeedEvent) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*FeedEvent) ProtoMessage() {}
+
+func (x *FeedEvent) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_oms_v1_order_service_proto_msgTypes[86]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use FeedEvent.ProtoReflect.Descriptor instead.
+func (*FeedEvent) Descriptor() ([]byte, []int) {
+	return file_proto_oms_v1_order_service_proto_rawDescGZIP(), []int{86}
+}
+
+func (x *FeedEvent) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *FeedEvent) GetAggregateType() string {
+	if x != nil {
+		return x.AggregateType
+	}
+	return ""
+}
+
+func (x *FeedEvent) GetAggregateId() string {
+	if x != nil {
+		return x.AggregateId
+	}
+	return ""
+}
+
+func (x *FeedEvent) GetEventType() string {
+	if x != nil {
+		return x.EventType
+	}
+	return ""
+}
+
+func (x *FeedEvent) GetPayload() []byte {
+	if x != nil {
+		return x.Payload
+	}
+	return nil
+}
+
+func (x *FeedEvent) GetCreatedAtUnixNano() int64 {
+	if x != nil {
+		return x.CreatedAtUnixNano
+	}
+	return 0
+}
+
+type GetEventsSinceResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Events []*FeedEvent `protobuf:"bytes,1,rep,name=events,proto3" json:"events,omitempty"`
+	// Курсор для следующего запроса; если событий нет, равен курсору запроса.
+	NextCursor string `protobuf:"bytes,2,opt,name=next_cursor,json=nextCursor,proto3" json:"next_cursor,omitempty"`
+}
+
+func (x *GetEventsSinceResponse) Reset() {
+	*x = GetEventsSinceResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_proto_oms_v1_order_service_proto_msgTypes[87]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *GetEventsSinceResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetEventsSinceResponse) ProtoMessage() {}
+
+func (x *GetEventsSinceResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_oms_v1_order_service_proto_msgTypes[87]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetEventsSinceResponse.ProtoReflect.Descriptor instead.
+func (*GetEventsSinceResponse) Descriptor() ([]byte, []int) {
+	return file_proto_oms_v1_order_service_proto_rawDescGZIP(), []int{87}
+}
+
+func (x *GetEventsSinceResponse) GetEvents() []*FeedEvent {
+	if x != nil {
+		return x.Events
+	}
+	return nil
+}
+
+func (x *GetEventsSinceResponse) GetNextCursor() string {
+	if x != nil {
+		return x.NextCursor
+	}
+	return ""
+}
+
+type GetLogLevelsRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+}
+
+func (x *GetLogLevelsRequest) Reset() {
+	*x = GetLogLevelsRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_proto_oms_v1_order_service_proto_msgTypes[88]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *GetLogLevelsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetLogLevelsRequest) ProtoMessage() {}
+
+func (x *GetLogLevelsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_oms_v1_order_service_proto_msgTypes[88]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetLogLevelsRequest.ProtoReflect.Descriptor instead.
+func (*GetLogLevelsRequest) Descriptor() ([]byte, []int) {
+	return file_proto_oms_v1_order_service_proto_rawDescGZIP(), []int{88}
+}
+
+type SetLogLevelRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// Компонент (значение поля component в логах или его префикс до "-"); пусто — общий уровень.
+	Component string `protobuf:"bytes,1,opt,name=component,proto3" json:"component,omitempty"`
+	// trace|debug|info|warn|error; пусто — убрать переопределение компонента.
+	Level string `protobuf:"bytes,2,opt,name=level,proto3" json:"level,omitempty"`
+}
+
+func (x *SetLogLevelRequest) Reset() {
+	*x = SetLogLevelRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_proto_oms_v1_order_service_proto_msgTypes[89]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *SetLogLevelRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SetLogLevelRequest) ProtoMessage() {}
+
+func (x *SetLogLevelRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_oms_v1_order_service_proto_msgTypes[89]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SetLogLevelRequest.ProtoReflect.Descriptor instead.
+func (*SetLogLevelRequest) Descriptor() ([]byte, []int) {
+	return file_proto_oms_v1_order_service_proto_rawDescGZIP(), []int{89}
+}
+
+func (x *SetLogLevelRequest) GetComponent() string {
+	if x != nil {
+		return x.Component
+	}
+	return ""
+}
+
+func (x *SetLogLevelRequest) GetLevel() string {
+	if x != nil {
+		return x.Level
+	}
+	return ""
+}
+
+type LogLevels struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Global     string            `protobuf:"bytes,1,opt,name=global,proto3" json:"global,omitempty"`
+	Components map[string]string `protobuf:"bytes,2,rep,name=components,proto3" json:"components,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+}
+
+func (x *LogLevels) Reset() {
+	*x = LogLevels{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_proto_oms_v1_order_service_proto_msgTypes[90]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *LogLevels) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*LogLevels) ProtoMessage() {}
+
+func (x *LogLevels) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_oms_v1_order_service_proto_msgTypes[90]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use LogLevels.ProtoReflect.Descriptor instead.
+func (*LogLevels) Descriptor() ([]byte, []int) {
+	return file_proto_oms_v1_order_service_proto_rawDescGZIP(), []int{90}
+}
+
+func (x *LogLevels) GetGlobal() string {
+	if x != nil {
+		return x.Global
+	}
+	return ""
+}
+
+func (x *LogLevels) GetComponents() map[string]string {
+	if x != nil {
+		return x.Components
+	}
+	return nil
+}
+
+type RecalculateOrderRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	OrderId string `protobuf:"bytes,1,opt,name=order_id,json=orderId,proto3" json:"order_id,omitempty"`
+	// Основание пересчёта (тикет, обращение); попадает в timeline.
+	Reason string `protobuf:"bytes,2,opt,name=reason,proto3" json:"reason,omitempty"`
+}
+
+func (x *RecalculateOrderRequest) Reset() {
+	*x = RecalculateOrderRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_proto_oms_v1_order_service_proto_msgTypes[91]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *RecalculateOrderRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RecalculateOrderRequest) ProtoMessage() {}
+
+func (x *RecalculateOrderRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_oms_v1_order_service_proto_msgTypes[91]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RecalculateOrderRequest.ProtoReflect.Descriptor instead.
+func (*RecalculateOrderRequest) Descriptor() ([]byte, []int) {
+	return file_proto_oms_v1_order_service_proto_rawDescGZIP(), []int{91}
+}
+
+func (x *RecalculateOrderRequest) GetOrderId() string {
+	if x != nil {
+		return x.OrderId
+	}
+	return ""
+}
+
+func (x *RecalculateOrderRequest) GetReason() string {
+	if x != nil {
+		return x.Reason
+	}
+	return ""
+}
+
+type ForceTransitionRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	OrderId      string      `protobuf:"bytes,1,opt,name=order_id,json=orderId,proto3" json:"order_id,omitempty"`
+	TargetStatus OrderStatus `protobuf:"varint,2,opt,name=target_status,json=targetStatus,proto3,enum=oms.v1.OrderStatus" json:"target_status,omitempty"`
+	// Основание (тикет, инцидент); обязательно, попадает в timeline и событие OrderStatusForced.
+	Reason string `protobuf:"bytes,3,opt,name=reason,proto3" json:"reason,omitempty"`
+}
+
+func (x *ForceTransitionRequest) Reset() {
+	*x = ForceTransitionRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_proto_oms_v1_order_service_proto_msgTypes[92]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ForceTransitionRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ForceTransitionRequest) ProtoMessage() {}
+
+func (x *ForceTransitionRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_oms_v1_order_service_proto_msgTypes[92]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ForceTransitionRequest.ProtoReflect.Descriptor instead.
+func (*ForceTransitionRequest) Descriptor() ([]byte, []int) {
+	return file_proto_oms_v1_order_service_proto_rawDescGZIP(), []int{92}
+}
+
+func (x *ForceTransitionRequest) GetOrderId() string {
+	if x != nil {
+		return x.OrderId
+	}
+	return ""
+}
+
+func (x *ForceTransitionRequest) GetTargetStatus() OrderStatus {
+	if x != nil {
+		return x.TargetStatus
+	}
+	return OrderStatus_ORDER_STATUS_UNSPECIFIED
+}
+
+func (x *ForceTransitionRequest) GetReason() string {
+	if x != nil {
+		return x.Reason
+	}
+	return ""
+}
+
+type ForceTransitionResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Order          *Order      `protobuf:"bytes,1,opt,name=order,proto3" json:"order,omitempty"`
+	PreviousStatus OrderStatus `protobuf:"varint,2,opt,name=previous_status,json=previousStatus,proto3,enum=oms.v1.OrderStatus" json:"previous_status,omitempty"`
+}
+
+func (x *ForceTransitionResponse) Reset() {
+	*x = ForceTransitionResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_proto_oms_v1_order_service_proto_msgTypes[93]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ForceTransitionResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ForceTransitionResponse) ProtoMessage() {}
+
+func (x *ForceTransitionResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_oms_v1_order_service_proto_msgTypes[93]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ForceTransitionResponse.ProtoReflect.Descriptor instead.
+func (*ForceTransitionResponse) Descriptor() ([]byte, []int) {
+	return file_proto_oms_v1_order_service_proto_rawDescGZIP(), []int{93}
+}
+
+func (x *ForceTransitionResponse) GetOrder() *Order {
+	if x != nil {
+		return x.Order
+	}
+	return nil
+}
+
+func (x *ForceTransitionResponse) GetPreviousStatus() OrderStatus {
+	if x != nil {
+		return x.PreviousStatus
+	}
+	return OrderStatus_ORDER_STATUS_UNSPECIFIED
+}
+
+type ItemPriceChange struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	ItemId   string `protobuf:"bytes,1,opt,name=item_id,json=itemId,proto3" json:"item_id,omitempty"`
+	Sku      string `protobuf:"bytes,2,opt,name=sku,proto3" json:"sku,omitempty"`
+	OldPrice *Money `protobuf:"bytes,3,opt,name=old_price,json=oldPrice,proto3" json:"old_price,omitempty"`
+	NewPrice *Money `protobuf:"bytes,4,opt,name=new_price,json=newPrice,proto3" json:"new_price,omitempty"`
+}
+
+func (x *ItemPriceChange) Reset() {
+	*x = ItemPriceChange{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_proto_oms_v1_order_service_proto_msgTypes[94]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ItemPriceChange) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ItemPriceChange) ProtoMessage() {}
+
+func (x *ItemPriceChange) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_oms_v1_order_service_proto_msgTypes[94]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ItemPriceChange.ProtoReflect.Descriptor instead.
+func (*ItemPriceChange) Descriptor() ([]byte, []int) {
+	return file_proto_oms_v1_order_service_proto_rawDescGZIP(), []int{94}
+}
+
+func (x *ItemPriceChange) GetItemId() string {
+	if x != nil {
+		return x.ItemId
+	}
+	return ""
+}
+
+func (x *ItemPriceChange) GetSku() string {
+	if x != nil {
+		return x.Sku
+	}
+	return ""
+}
+
+func (x *ItemPriceChange) GetOldPrice() *Money {
+	if x != nil {
+		return x.OldPrice
+	}
+	return nil
+}
+
+func (x *ItemPriceChange) GetNewPrice() *Money {
+	if x != nil {
+		return x.NewPrice
+	}
+	return nil
+}
+
+type RecalculateOrderResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Order         *Order `protobuf:"bytes,1,opt,name=order,proto3" json:"order,omitempty"`
+	PreviousTotal *Money `protobuf:"bytes,2,opt,name=previous_total,json=previousTotal,proto3" json:"previous_total,omitempty"`
+	// Разница нового и прежнего итога в минимальных единицах; отрицательная — заказ подешевел.
+	DeltaMinor int64 `protobuf:"varint,3,opt,name=delta_minor,json=deltaMinor,proto3" json:"delta_minor,omitempty"`
+	// Позиции, цена которых изменилась; пусто — цены совпали с каталогом и заказ не менялся.
+	Changes []*ItemPriceChange `protobuf:"bytes,4,rep,name=changes,proto3" json:"changes,omitempty"`
+}
+
+func (x *RecalculateOrderResponse) Reset() {
+	*x = RecalculateOrderResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_proto_oms_v1_order_service_proto_msgTypes[95]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *RecalculateOrderResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RecalculateOrderResponse) ProtoMessage() {}
+
+func (x *RecalculateOrderResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_oms_v1_order_service_proto_msgTypes[95]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RecalculateOrderResponse.ProtoReflect.Descriptor instead.
+func (*RecalculateOrderResponse) Descriptor() ([]byte, []int) {
+	return file_proto_oms_v1_order_service_proto_rawDescGZIP(), []int{95}
+}
+
+func (x *RecalculateOrderResponse) GetOrder() *Order {
+	if x != nil {
+		return x.Order
+	}
+	return nil
+}
+
+func (x *RecalculateOrderResponse) GetPreviousTotal() *Money {
+	if x != nil {
+		return x.PreviousTotal
+	}
+	return nil
+}
+
+func (x *RecalculateOrderResponse) GetDeltaMinor() int64 {
+	if x != nil {
+		return x.DeltaMinor
+	}
+	return 0
+}
+
+func (x *RecalculateOrderResponse) GetChanges() []*ItemPriceChange {
+	if x != nil {
+		return x.Changes
+	}
+	return nil
+}
+
+var File_proto_oms_v1_order_service_proto protoreflect.FileDescriptor
+
+var file_proto_oms_v1_order_service_proto_rawDesc = []byte{
+	0x0a, 0x20, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2f, 0x6f, 0x6d, 0x73, 0x2f, 0x76, 0x31, 0x2f, 0x6f,
+	0x72, 0x64, 0x65, 0x72, 0x5f, 0x73, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x2e, 0x70, 0x72, 0x6f,
+	0x74, 0x6f, 0x12, 0x06, 0x6f, 0x6d, 0x73, 0x2e, 0x76, 0x31, 0x1a, 0x1b, 0x62, 0x75, 0x66, 0x2f,
+	0x76, 0x61, 0x6c, 0x69, 0x64, 0x61, 0x74, 0x65, 0x2f, 0x76, 0x61, 0x6c, 0x69, 0x64, 0x61, 0x74,
+	0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x1a, 0x1c, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2f,
+	0x61, 0x70, 0x69, 0x2f, 0x61, 0x6e, 0x6e, 0x6f, 0x74, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x2e,
+	0x70, 0x72, 0x6f, 0x74, 0x6f, 0x22, 0x46, 0x0a, 0x05, 0x4d, 0x6f, 0x6e, 0x65, 0x79, 0x12, 0x1a,
+	0x0a, 0x08, 0x63, 0x75, 0x72, 0x72, 0x65, 0x6e, 0x63, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x08, 0x63, 0x75, 0x72, 0x72, 0x65, 0x6e, 0x63, 0x79, 0x12, 0x21, 0x0a, 0x0c, 0x61, 0x6d,
+	0x6f, 0x75, 0x6e, 0x74, 0x5f, 0x6d, 0x69, 0x6e, 0x6f, 0x72, 0x18, 0x02, 0x20, 0x01, 0x28, 0x03,
+	0x52, 0x0b, 0x61, 0x6d, 0x6f, 0x75, 0x6e, 0x74, 0x4d, 0x69, 0x6e, 0x6f, 0x72, 0x22, 0x8c, 0x01,
+	0x0a, 0x09, 0x4f, 0x72, 0x64, 0x65, 0x72, 0x49, 0x74, 0x65, 0x6d, 0x12, 0x10, 0x0a, 0x03, 0x73,
+	0x6b, 0x75, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x73, 0x6b, 0x75, 0x12, 0x10, 0x0a,
+	0x03, 0x71, 0x74, 0x79, 0x18, 0x02, 0x20, 0x01, 0x28, 0x05, 0x52, 0x03, 0x71, 0x74, 0x79, 0x12,
+	0x23, 0x0a, 0x05, 0x70, 0x72, 0x69, 0x63, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x0d,
+	0x2e, 0x6f, 0x6d, 0x73, 0x2e, 0x76, 0x31, 0x2e, 0x4d, 0x6f, 0x6e, 0x65, 0x79, 0x52, 0x05, 0x70,
+	0x72, 0x69, 0x63, 0x65, 0x12, 0x0e, 0x0a, 0x02, 0x69, 0x64, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x02, 0x69, 0x64, 0x12, 0x26, 0x0a, 0x0f, 0x63, 0x72, 0x65, 0x61, 0x74, 0x65, 0x64, 0x5f,
+	0x61, 0x74, 0x5f, 0x75, 0x6e, 0x69, 0x78, 0x18, 0x05, 0x20, 0x01, 0x28, 0x03, 0x52, 0x0d, 0x63,
+	0x72, 0x65, 0x61, 0x74, 0x65, 0x64, 0x41, 0x74, 0x55, 0x6e, 0x69, 0x78, 0x22, 0xd9, 0x02, 0x0a,
+	0x05, 0x4f, 0x72, 0x64, 0x65, 0x72, 0x12, 0x0e, 0x0a, 0x02, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x02, 0x69, 0x64, 0x12, 0x1f, 0x0a, 0x0b, 0x63, 0x75, 0x73, 0x74, 0x6f, 0x6d,
+	0x65, 0x72, 0x5f, 0x69, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0a, 0x63, 0x75, 0x73,
+	0x74, 0x6f, 0x6d, 0x65, 0x72, 0x49, 0x64, 0x12, 0x2b, 0x0a, 0x06, 0x73, 0x74, 0x61, 0x74, 0x75,
+	0x73, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x13, 0x2e, 0x6f, 0x6d, 0x73, 0x2e, 0x76, 0x31,
+	0x2e, 0x4f, 0x72, 0x64, 0x65, 0x72, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x52, 0x06, 0x73, 0x74,
+	0x61, 0x74, 0x75, 0x73, 0x12, 0x25, 0x0a, 0x06, 0x61, 0x6d, 0x6f, 0x75, 0x6e, 0x74, 0x18, 0x04,
+	0x20, 0x01, 0x28, 0x0b, 0x32, 0x0d, 0x2e, 0x6f, 0x6d, 0x73, 0x2e, 0x76, 0x31, 0x2e, 0x4d, 0x6f,
+	0x6e, 0x65, 0x79, 0x52, 0x06, 0x61, 0x6d, 0x6f, 0x75, 0x6e, 0x74, 0x12, 0x27, 0x0a, 0x05, 0x69,
+	0x74, 0x65, 0x6d, 0x73, 0x18, 0x05, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x11, 0x2e, 0x6f, 0x6d, 0x73,
+	0x2e, 0x76, 0x31, 0x2e, 0x4f, 0x72, 0x64, 0x65, 0x72, 0x49, 0x74, 0x65, 0x6d, 0x52, 0x05, 0x69,
+	0x74, 0x65, 0x6d, 0x73, 0x12, 0x18, 0x0a, 0x07, 0x76, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x18,
+	0x06, 0x20, 0x01, 0x28, 0x03, 0x52, 0x07, 0x76, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x12, 0x1a,
+	0x0a, 0x08, 0x63, 0x75, 0x72, 0x72, 0x65, 0x6e, 0x63, 0x79, 0x18, 0x07, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x08, 0x63, 0x75, 0x72, 0x72, 0x65, 0x6e, 0x63, 0x79, 0x12, 0x1f, 0x0a, 0x0b, 0x68, 0x6f,
+	0x6c, 0x64, 0x5f, 0x72, 0x65, 0x61, 0x73, 0x6f, 0x6e, 0x18, 0x08, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x0a, 0x68, 0x6f, 0x6c, 0x64, 0x52, 0x65, 0x61, 0x73, 0x6f, 0x6e, 0x12, 0x2e, 0x0a, 0x07, 0x61,
+	0x6d, 0x6f, 0x75, 0x6e, 0x74, 0x73, 0x18, 0x09, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x14, 0x2e, 0x6f,
+	0x6d, 0x73, 0x2e, 0x76, 0x31, 0x2e, 0x4f, 0x72, 0x64, 0x65, 0x72, 0x41, 0x6d, 0x6f, 0x75, 0x6e,
+	0x74, 0x73, 0x52, 0x07, 0x61, 0x6d, 0x6f, 0x75, 0x6e, 0x74, 0x73, 0x12, 0x1b, 0x0a, 0x09, 0x74,
+	0x65, 0x73, 0x74, 0x5f, 0x6d, 0x6f, 0x64, 0x65, 0x18, 0x0a, 0x20, 0x01, 0x28, 0x08, 0x52, 0x08,
+	0x74, 0x65, 0x73, 0x74, 0x4d, 0x6f, 0x64, 0x65, 0x22, 0xe0, 0x01, 0x0a, 0x0f, 0x50, 0x72, 0x69,
+	0x63, 0x65, 0x41, 0x64, 0x6a, 0x75, 0x73, 0x74, 0x6d, 0x65, 0x6e, 0x74, 0x12, 0x2a, 0x0a, 0x04,
+	0x74, 0x79, 0x70, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x16, 0x2e, 0x6f, 0x6d, 0x73,
+	0x2e, 0x76, 0x31, 0x2e, 0x41, 0x64, 0x6a, 0x75, 0x73, 0x74, 0x6d, 0x65, 0x6e, 0x74, 0x54, 0x79,
+	0x70, 0x65, 0x52, 0x04, 0x74, 0x79, 0x70, 0x65, 0x12, 0x12, 0x0a, 0x04, 0x63, 0x6f, 0x64, 0x65,
+	0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x63, 0x6f, 0x64, 0x65, 0x12, 0x23, 0x0a, 0x05,
+	0x66, 0x69, 0x78, 0x65, 0x64, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x0d, 0x2e, 0x6f, 0x6d,
+	0x73, 0x2e, 0x76, 0x31, 0x2e, 0x4d, 0x6f, 0x6e, 0x65, 0x79, 0x52, 0x05, 0x66, 0x69, 0x78, 0x65,
+	0x64, 0x12, 0x18, 0x0a, 0x07, 0x70, 0x65, 0x72, 0x63, 0x65, 0x6e, 0x74, 0x18, 0x04, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x07, 0x70, 0x65, 0x72, 0x63, 0x65, 0x6e, 0x74, 0x12, 0x27, 0x0a, 0x07, 0x61,
+	0x70, 0x70, 0x6c, 0x69, 0x65, 0x64, 0x18, 0x05, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x0d, 0x2e, 0x6f,
+	0x6d, 0x73, 0x2e, 0x76, 0x31, 0x2e, 0x4d, 0x6f, 0x6e, 0x65, 0x79, 0x52, 0x07, 0x61, 0x70, 0x70,
+	0x6c, 0x69, 0x65, 0x64, 0x12, 0x25, 0x0a, 0x0e, 0x72, 0x6f, 0x75, 0x6e, 0x64, 0x69, 0x6e, 0x67,
+	0x5f, 0x64, 0x65, 0x6c, 0x74, 0x61, 0x18, 0x06, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0d, 0x72, 0x6f,
+	0x75, 0x6e, 0x64, 0x69, 0x6e, 0x67, 0x44, 0x65, 0x6c, 0x74, 0x61, 0x22, 0xc7, 0x02, 0x0a, 0x0c,
+	0x4f, 0x72, 0x64, 0x65, 0x72, 0x41, 0x6d, 0x6f, 0x75, 0x6e, 0x74, 0x73, 0x12, 0x29, 0x0a, 0x08,
+	0x73, 0x75, 0x62, 0x74, 0x6f, 0x74, 0x61, 0x6c, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x0d,
+	0x2e, 0x6f, 0x6d, 0x73, 0x2e, 0x76, 0x31, 0x2e, 0x4d, 0x6f, 0x6e, 0x65, 0x79, 0x52, 0x08, 0x73,
+	0x75, 0x62, 0x74, 0x6f, 0x74, 0x61, 0x6c, 0x12, 0x29, 0x0a, 0x08, 0x64, 0x69, 0x73, 0x63, 0x6f,
+	0x75, 0x6e, 0x74, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x0d, 0x2e, 0x6f, 0x6d, 0x73, 0x2e,
+	0x76, 0x31, 0x2e, 0x4d, 0x6f, 0x6e, 0x65, 0x79, 0x52, 0x08, 0x64, 0x69, 0x73, 0x63, 0x6f, 0x75,
+	0x6e, 0x74, 0x12, 0x1f, 0x0a, 0x03, 0x74, 0x61, 0x78, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0b, 0x32,
+	0x0d, 0x2e, 0x6f, 0x6d, 0x73, 0x2e, 0x76, 0x31, 0x2e, 0x4d, 0x6f, 0x6e, 0x65, 0x79, 0x52, 0x03,
+	0x74, 0x61, 0x78, 0x12, 0x23, 0x0a, 0x05, 0x74, 0x6f, 0x74, 0x61, 0x6c, 0x18, 0x04, 0x20, 0x01,
+	0x28, 0x0b, 0x32, 0x0d, 0x2e, 0x6f, 0x6d, 0x73, 0x2e, 0x76, 0x31, 0x2e, 0x4d, 0x6f, 0x6e, 0x65,
+	0x79, 0x52, 0x05, 0x74, 0x6f, 0x74, 0x61, 0x6c, 0x12, 0x39, 0x0a, 0x0b, 0x61, 0x64, 0x6a, 0x75,
+	0x73, 0x74, 0x6d, 0x65, 0x6e, 0x74, 0x73, 0x18, 0x05, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x17, 0x2e,
+	0x6f, 0x6d, 0x73, 0x2e, 0x76, 0x31, 0x2e, 0x50, 0x72, 0x69, 0x63, 0x65, 0x41, 0x64, 0x6a, 0x75,
+	0x73, 0x74, 0x6d, 0x65, 0x6e, 0x74, 0x52, 0x0b, 0x61, 0x64, 0x6a, 0x75, 0x73, 0x74, 0x6d, 0x65,
+	0x6e, 0x74, 0x73, 0x12, 0x39, 0x0a, 0x0d, 0x72, 0x6f, 0x75, 0x6e, 0x64, 0x69, 0x6e, 0x67, 0x5f,
+	0x6d, 0x6f, 0x64, 0x65, 0x18, 0x06, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x14, 0x2e, 0x6f, 0x6d, 0x73,
+	0x2e, 0x76, 0x31, 0x2e, 0x52, 0x6f, 0x75, 0x6e, 0x64, 0x69, 0x6e, 0x67, 0x4d, 0x6f, 0x64, 0x65,
+	0x52, 0x0c, 0x72, 0x6f, 0x75, 0x6e, 0x64, 0x69, 0x6e, 0x67, 0x4d, 0x6f, 0x64, 0x65, 0x12, 0x25,
+	0x0a, 0x0e, 0x72, 0x6f, 0x75, 0x6e, 0x64, 0x69, 0x6e, 0x67, 0x5f, 0x64, 0x65, 0x6c, 0x74, 0x61,
+	0x18, 0x07, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0d, 0x72, 0x6f, 0x75, 0x6e, 0x64, 0x69, 0x6e, 0x67,
+	0x44, 0x65, 0x6c, 0x74, 0x61, 0x22, 0x58, 0x0a, 0x0d, 0x54, 0x69, 0x6d, 0x65, 0x6c, 0x69, 0x6e,
+	0x65, 0x45, 0x76, 0x65, 0x6e, 0x74, 0x12, 0x12, 0x0a, 0x04, 0x74, 0x79, 0x70, 0x65, 0x18, 0x01,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x74, 0x79, 0x70, 0x65, 0x12, 0x16, 0x0a, 0x06, 0x72, 0x65,
+	0x61, 0x73, 0x6f, 0x6e, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x72, 0x65, 0x61, 0x73,
+	0x6f, 0x6e, 0x12, 0x1b, 0x0a, 0x09, 0x75, 0x6e, 0x69, 0x78, 0x5f, 0x74, 0x69, 0x6d, 0x65, 0x18,
+	0x03, 0x20, 0x01, 0x28, 0x03, 0x52, 0x08, 0x75, 0x6e, 0x69, 0x78, 0x54, 0x69, 0x6d, 0x65, 0x22,
+	0x4a, 0x0a, 0x10, 0x43, 0x6f, 0x75, 0x72, 0x69, 0x65, 0x72, 0x5a, 0x6f, 0x6e, 0x65, 0x49, 0x6e,
+	0x70, 0x75, 0x74, 0x12, 0x17, 0x0a, 0x07, 0x7a, 0x6f, 0x6e, 0x65, 0x5f, 0x69, 0x64, 0x18, 0x01,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x7a, 0x6f, 0x6e, 0x65, 0x49, 0x64, 0x12, 0x1d, 0x0a, 0x0a,
+	0x69, 0x73, 0x5f, 0x70, 0x72, 0x69, 0x6d, 0x61, 0x72, 0x79, 0x18, 0x02, 0x20, 0x01, 0x28, 0x08,
+	0x52, 0x09, 0x69, 0x73, 0x50, 0x72, 0x69, 0x6d, 0x61, 0x72, 0x79, 0x22, 0x6f, 0x0a, 0x0b, 0x43,
+	0x6f, 0x75, 0x72, 0x69, 0x65, 0x72, 0x5a, 0x6f, 0x6e, 0x65, 0x12, 0x17, 0x0a, 0x07, 0x7a, 0x6f,
+	0x6e, 0x65, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x7a, 0x6f, 0x6e,
+	0x65, 0x49, 0x64, 0x12, 0x1d, 0x0a, 0x0a, 0x69, 0x73, 0x5f, 0x70, 0x72, 0x69, 0x6d, 0x61, 0x72,
+	0x79, 0x18, 0x02, 0x20, 0x01, 0x28, 0x08, 0x52, 0x09, 0x69, 0x73, 0x50, 0x72, 0x69, 0x6d, 0x61,
+	0x72, 0x79, 0x12, 0x28, 0x0a, 0x10, 0x61, 0x73, 0x73, 0x69, 0x67, 0x6e, 0x65, 0x64, 0x5f, 0x61,
+	0x74, 0x5f, 0x75, 0x6e, 0x69, 0x78, 0x18, 0x03, 0x20, 0x01, 0x28, 0x03, 0x52, 0x0e, 0x61, 0x73,
+	0x73, 0x69, 0x67, 0x6e, 0x65, 0x64, 0x41, 0x74, 0x55, 0x6e, 0x69, 0x78, 0x22, 0xf2, 0x01, 0x0a,
+	0x07, 0x43, 0x6f, 0x75, 0x72, 0x69, 0x65, 0x72, 0x12, 0x0e, 0x0a, 0x02, 0x69, 0x64, 0x18, 0x01,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x02, 0x69, 0x64, 0x12, 0x14, 0x0a, 0x05, 0x70, 0x68, 0x6f, 0x6e,
+	0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x70, 0x68, 0x6f, 0x6e, 0x65, 0x12, 0x1d,
+	0x0a, 0x0a, 0x66, 0x69, 0x72, 0x73, 0x74, 0x5f, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x03, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x09, 0x66, 0x69, 0x72, 0x73, 0x74, 0x4e, 0x61, 0x6d, 0x65, 0x12, 0x1b, 0x0a,
+	0x09, 0x6c, 0x61, 0x73, 0x74, 0x5f, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x08, 0x6c, 0x61, 0x73, 0x74, 0x4e, 0x61, 0x6d, 0x65, 0x12, 0x3d, 0x0a, 0x0c, 0x76, 0x65,
+	0x68, 0x69, 0x63, 0x6c, 0x65, 0x5f, 0x74, 0x79, 0x70, 0x65, 0x18, 0x05, 0x20, 0x01, 0x28, 0x0e,
+	0x32, 0x1a, 0x2e, 0x6f, 0x6d, 0x73, 0x2e, 0x76, 0x31, 0x2e, 0x43, 0x6f, 0x75, 0x72, 0x69, 0x65,
+	0x72, 0x56, 0x65, 0x68, 0x69, 0x63, 0x6c, 0x65, 0x54, 0x79, 0x70, 0x65, 0x52, 0x0b, 0x76, 0x65,
+	0x68, 0x69, 0x63, 0x6c, 0x65, 0x54, 0x79, 0x70, 0x65, 0x12, 0x1b, 0x0a, 0x09, 0x69, 0x73, 0x5f,
+	0x61, 0x63, 0x74, 0x69, 0x76, 0x65, 0x18, 0x06, 0x20, 0x01, 0x28, 0x08, 0x52, 0x08, 0x69, 0x73,
+	0x41, 0x63, 0x74, 0x69, 0x76, 0x65, 0x12, 0x29, 0x0a, 0x05, 0x7a, 0x6f, 0x6e, 0x65, 0x73, 0x18,
+	0x07, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x13, 0x2e, 0x6f, 0x6d, 0x73, 0x2e, 0x76, 0x31, 0x2e, 0x43,
+	0x6f, 0x75, 0x72, 0x69, 0x65, 0x72, 0x5a, 0x6f, 0x6e, 0x65, 0x52, 0x05, 0x7a, 0x6f, 0x6e, 0x65,
+	0x73, 0x22, 0xe2, 0x01, 0x0a, 0x0b, 0x43, 0x6f, 0x75, 0x72, 0x69, 0x65, 0x72, 0x53, 0x6c, 0x6f,
+	0x74, 0x12, 0x0e, 0x0a, 0x02, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x02, 0x69,
+	0x64, 0x12, 0x1d, 0x0a, 0x0a, 0x63, 0x6f, 0x75, 0x72, 0x69, 0x65, 0x72, 0x5f, 0x69, 0x64, 0x18,
+	0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x63, 0x6f, 0x75, 0x72, 0x69, 0x65, 0x72, 0x49, 0x64,
+	0x12, 0x26, 0x0a, 0x0f, 0x73, 0x6c, 0x6f, 0x74, 0x5f, 0x73, 0x74, 0x61, 0x72, 0x74, 0x5f, 0x75,
+	0x6e, 0x69, 0x78, 0x18, 0x03, 0x20, 0x01, 0x28, 0x03, 0x52, 0x0d, 0x73, 0x6c, 0x6f, 0x74, 0x53,
+	0x74, 0x61, 0x72, 0x74, 0x55, 0x6e, 0x69, 0x78, 0x12, 0x22, 0x0a, 0x0d, 0x73, 0x6c, 0x6f, 0x74,
+	0x5f, 0x65, 0x6e, 0x64, 0x5f, 0x75, 0x6e, 0x69, 0x78, 0x18, 0x04, 0x20, 0x01, 0x28, 0x03, 0x52,
+	0x0b, 0x73, 0x6c, 0x6f, 0x74, 0x45, 0x6e, 0x64, 0x55, 0x6e, 0x69, 0x78, 0x12, 0x25, 0x0a, 0x0e,
+	0x64, 0x75, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x5f, 0x68, 0x6f, 0x75, 0x72, 0x73, 0x18, 0x05,
+	0x20, 0x01, 0x28, 0x05, 0x52, 0x0d, 0x64, 0x75, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x48, 0x6f,
+	0x75, 0x72, 0x73, 0x12, 0x31, 0x0a, 0x06, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x18, 0x06, 0x20,
+	0x01, 0x28, 0x0e, 0x32, 0x19, 0x2e, 0x6f, 0x6d, 0x73, 0x2e, 0x76, 0x31, 0x2e, 0x43, 0x6f, 0x75,
+	0x72, 0x69, 0x65, 0x72, 0x53, 0x6c, 0x6f, 0x74, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x52, 0x06,
+	0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x22, 0x80, 0x02, 0x0a, 0x18, 0x43, 0x6f, 0x75, 0x72, 0x69,
+	0x65, 0x72, 0x56, 0x65, 0x68, 0x69, 0x63, 0x6c, 0x65, 0x43, 0x61, 0x70, 0x61, 0x62, 0x69, 0x6c,
+	0x69, 0x74, 0x79, 0x12, 0x3d, 0x0a, 0x0c, 0x76, 0x65, 0x68, 0x69, 0x63, 0x6c, 0x65, 0x5f, 0x74,
+	0x79, 0x70, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x1a, 0x2e, 0x6f, 0x6d, 0x73, 0x2e,
+	0x76, 0x31, 0x2e, 0x43, 0x6f, 0x75, 0x72, 0x69, 0x65, 0x72, 0x56, 0x65, 0x68, 0x69, 0x63, 0x6c,
+	0x65, 0x54, 0x79, 0x70, 0x65, 0x52, 0x0b, 0x76, 0x65, 0x68, 0x69, 0x63, 0x6c, 0x65, 0x54, 0x79,
+	0x70, 0x65, 0x12, 0x28, 0x0a, 0x10, 0x6d, 0x61, 0x78, 0x5f, 0x77, 0x65, 0x69, 0x67, 0x68, 0x74,
+	0x5f, 0x67, 0x72, 0x61, 0x6d, 0x73, 0x18, 0x02, 0x20, 0x01, 0x28, 0x05, 0x52, 0x0e, 0x6d, 0x61,
+	0x78, 0x57, 0x65, 0x69, 0x67, 0x68, 0x74, 0x47, 0x72, 0x61, 0x6d, 0x73, 0x12, 0x24, 0x0a, 0x0e,
+	0x6d, 0x61, 0x78, 0x5f, 0x76, 0x6f, 0x6c, 0x75, 0x6d, 0x65, 0x5f, 0x63, 0x6d, 0x33, 0x18, 0x03,
+	0x20, 0x01, 0x28, 0x05, 0x52, 0x0c, 0x6d, 0x61, 0x78, 0x56, 0x6f, 0x6c, 0x75, 0x6d, 0x65, 0x43,
+	0x6d, 0x33, 0x12, 0x2d, 0x0a, 0x13, 0x6d, 0x61, 0x78, 0x5f, 0x6f, 0x72, 0x64, 0x65, 0x72, 0x73,
+	0x5f, 0x70, 0x65, 0x72, 0x5f, 0x74, 0x72, 0x69, 0x70, 0x18, 0x04, 0x20, 0x01, 0x28, 0x05, 0x52,
+	0x10, 0x6d, 0x61, 0x78, 0x4f, 0x72, 0x64, 0x65, 0x72, 0x73, 0x50, 0x65, 0x72, 0x54, 0x72, 0x69,
+	0x70, 0x12, 0x26, 0x0a, 0x0f, 0x75, 0x70, 0x64, 0x61, 0x74, 0x65, 0x64, 0x5f, 0x61, 0x74, 0x5f,
+	0x75, 0x6e, 0x69, 0x78, 0x18, 0x05, 0x20, 0x01, 0x28, 0x03, 0x52, 0x0d, 0x75, 0x70, 0x64, 0x61,
+	0x74, 0x65, 0x64, 0x41, 0x74, 0x55, 0x6e, 0x69, 0x78, 0x22, 0xaf, 0x05, 0x0a, 0x12, 0x43, 0x72,
+	0x65, 0x61, 0x74, 0x65, 0x4f, 0x72, 0x64, 0x65, 0x72, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74,
+	0x12, 0x41, 0x0a, 0x0b, 0x63, 0x75, 0x73, 0x74, 0x6f, 0x6d, 0x65, 0x72, 0x5f, 0x69, 0x64, 0x18,
+	0x01, 0x20, 0x01, 0x28, 0x09, 0x42, 0x20, 0xba, 0x48, 0x1d, 0xc8, 0x01, 0x01, 0x72, 0x18, 0x18,
+	0x80, 0x01, 0x32, 0x13, 0x5e, 0x5b, 0x41, 0x2d, 0x5a, 0x61, 0x2d, 0x7a, 0x30, 0x2d, 0x39, 0x2e,
+	0x5f, 0x3a, 0x40, 0x2d, 0x5d, 0x2a, 0x24, 0x52, 0x0a, 0x63, 0x75, 0x73, 0x74, 0x6f, 0x6d, 0x65,
+	0x72, 0x49, 0x64, 0x12, 0xa0, 0x02, 0x0a, 0x05, 0x69, 0x74, 0x65, 0x6d, 0x73, 0x18, 0x02, 0x20,
+	0x03, 0x28, 0x0b, 0x32, 0x11, 0x2e, 0x6f, 0x6d, 0x73, 0x2e, 0x76, 0x31, 0x2e, 0x4f, 0x72, 0x64,
+	0x65, 0x72, 0x49, 0x74, 0x65, 0x6d, 0x42, 0xf6, 0x01, 0xba, 0x48, 0xf2, 0x01, 0x92, 0x01, 0xee,
+	0x01, 0x08, 0x01, 0x22, 0xe9, 0x01, 0xba, 0x01, 0x36, 0x0a, 0x15, 0x63, 0x72, 0x65, 0x61, 0x74,
+	0x65, 0x5f, 0x6f, 0x72, 0x64, 0x65, 0x72, 0x2e, 0x69, 0x74, 0x65, 0x6d, 0x5f, 0x71, 0x74, 0x79,
+	0x12, 0x0f, 0x71, 0x74, 0x79, 0x20, 0x6d, 0x75, 0x73, 0x74, 0x20, 0x62, 0x65, 0x20, 0x3e, 0x20,
+	0x30, 0x1a, 0x0c, 0x74, 0x68, 0x69, 0x73, 0x2e, 0x71, 0x74, 0x79, 0x20, 0x3e, 0x20, 0x30, 0xba,
+	0x01, 0x3d, 0x0a, 0x17, 0x63, 0x72, 0x65, 0x61, 0x74, 0x65, 0x5f, 0x6f, 0x72, 0x64, 0x65, 0x72,
+	0x2e, 0x69, 0x74, 0x65, 0x6d, 0x5f, 0x70, 0x72, 0x69, 0x63, 0x65, 0x12, 0x11, 0x70, 0x72, 0x69,
+	0x63, 0x65, 0x20, 0x69, 0x73, 0x20, 0x72, 0x65, 0x71, 0x75, 0x69, 0x72, 0x65, 0x64, 0x1a, 0x0f,
+	0x68, 0x61, 0x73, 0x28, 0x74, 0x68, 0x69, 0x73, 0x2e, 0x70, 0x72, 0x69, 0x63, 0x65, 0x29, 0xba,
+	0x01, 0x6d, 0x0a, 0x18, 0x63, 0x72, 0x65, 0x61, 0x74, 0x65, 0x5f, 0x6f, 0x72, 0x64, 0x65, 0x72,
+	0x2e, 0x69, 0x74, 0x65, 0x6d, 0x5f, 0x61, 0x6d, 0x6f, 0x75, 0x6e, 0x74, 0x12, 0x1f, 0x70, 0x72,
+	0x69, 0x63, 0x65, 0x2e, 0x61, 0x6d, 0x6f, 0x75, 0x6e, 0x74, 0x5f, 0x6d, 0x69, 0x6e, 0x6f, 0x72,
+	0x20, 0x6d, 0x75, 0x73, 0x74, 0x20, 0x62, 0x65, 0x20, 0x3e, 0x3d, 0x20, 0x30, 0x1a, 0x30, 0x21,
+	0x68, 0x61, 0x73, 0x28, 0x74, 0x68, 0x69, 0x73, 0x2e, 0x70, 0x72, 0x69, 0x63, 0x65, 0x29, 0x20,
+	0x7c, 0x7c, 0x20, 0x74, 0x68, 0x69, 0x73, 0x2e, 0x70, 0x72, 0x69, 0x63, 0x65, 0x2e, 0x61, 0x6d,
+	0x6f, 0x75, 0x6e, 0x74, 0x5f, 0x6d, 0x69, 0x6e, 0x6f, 0x72, 0x20, 0x3e, 0x3d, 0x20, 0x30, 0x52,
+	0x05, 0x69, 0x74, 0x65, 0x6d, 0x73, 0x12, 0x22, 0x0a, 0x08, 0x63, 0x75, 0x72, 0x72, 0x65, 0x6e,
+	0x63, 0x79, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x42, 0x06, 0xba, 0x48, 0x03, 0xc8, 0x01, 0x01,
+	0x52, 0x08, 0x63, 0x75, 0x72, 0x72, 0x65, 0x6e, 0x63, 0x79, 0x12, 0x39, 0x0a, 0x0b, 0x61, 0x64,
+	0x6a, 0x75, 0x73, 0x74, 0x6d, 0x65, 0x6e, 0x74, 0x73, 0x18, 0x04, 0x20, 0x03, 0x28, 0x0b, 0x32,
+	0x17, 0x2e, 0x6f, 0x6d, 0x73, 0x2e, 0x76, 0x31, 0x2e, 0x50, 0x72, 0x69, 0x63, 0x65, 0x41, 0x64,
+	0x6a, 0x75, 0x73, 0x74, 0x6d, 0x65, 0x6e, 0x74, 0x52, 0x0b, 0x61, 0x64, 0x6a, 0x75, 0x73, 0x74,
+	0x6d, 0x65, 0x6e, 0x74, 0x73, 0x12, 0x1b, 0x0a, 0x09, 0x74, 0x65, 0x73, 0x74, 0x5f, 0x6d, 0x6f,
+	0x64, 0x65, 0x18, 0x05, 0x20, 0x01, 0x28, 0x08, 0x52, 0x08, 0x74, 0x65, 0x73, 0x74, 0x4d, 0x6f,
+	0x64, 0x65, 0x3a, 0xb6, 0x01, 0xba, 0x48, 0xb2, 0x01, 0x1a, 0xaf, 0x01, 0x0a, 0x1a, 0x63, 0x72,
+	0x65, 0x61, 0x74, 0x65, 0x5f, 0x6f, 0x72, 0x64, 0x65, 0x72, 0x2e, 0x69, 0x74, 0x65, 0x6d, 0x5f,
+	0x63, 0x75, 0x72, 0x72, 0x65, 0x6e, 0x63, 0x79, 0x12, 0x2a, 0x69, 0x74, 0x65, 0x6d, 0x73, 0x5b,
+	0x5d, 0x2e, 0x70, 0x72, 0x69, 0x63, 0x65, 0x2e, 0x63, 0x75, 0x72, 0x72, 0x65, 0x6e, 0x63, 0x79,
+	0x20, 0x6d, 0x75, 0x73, 0x74, 0x20, 0x6d, 0x61, 0x74, 0x63, 0x68, 0x20, 0x63, 0x75, 0x72, 0x72,
+	0x65, 0x6e, 0x63, 0x79, 0x1a, 0x65, 0x74, 0x68, 0x69, 0x73, 0x2e, 0x63, 0x75, 0x72, 0x72, 0x65,
+	0x6e, 0x63, 0x79, 0x20, 0x3d, 0x3d, 0x20, 0x27, 0x27, 0x20, 0x7c, 0x7c, 0x20, 0x74, 0x68, 0x69,
+	0x73, 0x2e, 0x69, 0x74, 0x65, 0x6d, 0x73, 0x2e, 0x61, 0x6c, 0x6c, 0x28, 0x69, 0x74, 0x65, 0x6d,
+	0x2c, 0x20, 0x21, 0x68, 0x61, 0x73, 0x28, 0x69, 0x74, 0x65, 0x6d, 0x2e, 0x70, 0x72, 0x69, 0x63,
+	0x65, 0x29, 0x20, 0x7c, 0x7c, 0x20, 0x69, 0x74, 0x65, 0x6d, 0x2e, 0x70, 0x72, 0x69, 0x63, 0x65,
+	0x2e, 0x63, 0x75, 0x72, 0x72, 0x65, 0x6e, 0x63, 0x79, 0x20, 0x3d, 0x3d, 0x20, 0x74, 0x68, 0x69,
+	0x73, 0x2e, 0x63, 0x75, 0x72, 0x72, 0x65, 0x6e, 0x63, 0x79, 0x29, 0x22, 0x3a, 0x0a, 0x13, 0x43,
+	0x72, 0x65, 0x61, 0x74, 0x65, 0x4f, 0x72, 0x64, 0x65, 0x72, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e,
+	0x73, 0x65, 0x12, 0x23, 0x0a, 0x05, 0x6f, 0x72, 0x64, 0x65, 0x72, 0x18, 0x01, 0x20, 0x01, 0x28,
+	0x0b, 0x32, 0x0d, 0x2e, 0x6f, 0x6d, 0x73, 0x2e, 0x76, 0x31, 0x2e, 0x4f, 0x72, 0x64, 0x65, 0x72,
+	0x52, 0x05, 0x6f, 0x72, 0x64, 0x65, 0x72, 0x22, 0x4e, 0x0a, 0x0f, 0x47, 0x65, 0x74, 0x4f, 0x72,
+	0x64, 0x65, 0x72, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x3b, 0x0a, 0x08, 0x6f, 0x72,
+	0x64, 0x65, 0x72, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x42, 0x20, 0xba, 0x48,
+	0x1d, 0xc8, 0x01, 0x01, 0x72, 0x18, 0x18, 0x80, 0x01, 0x32, 0x13, 0x5e, 0x5b, 0x41, 0x2d, 0x5a,
+	0x61, 0x2d, 0x7a, 0x30, 0x2d, 0x39, 0x2e, 0x5f, 0x3a, 0x40, 0x2d, 0x5d, 0x2a, 0x24, 0x52, 0x07,
+	0x6f, 0x72, 0x64, 0x65, 0x72, 0x49, 0x64, 0x22, 0x6a, 0x0a, 0x10, 0x47, 0x65, 0x74, 0x4f, 0x72,
+	0x64, 0x65, 0x72, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x23, 0x0a, 0x05, 0x6f,
+	0x72, 0x64, 0x65, 0x72, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x0d, 0x2e, 0x6f, 0x6d, 0x73,
+	0x2e, 0x76, 0x31, 0x2e, 0x4f, 0x72, 0x64, 0x65, 0x72, 0x52, 0x05, 0x6f, 0x72, 0x64, 0x65, 0x72,
+	0x12, 0x31, 0x0a, 0x08, 0x74, 0x69, 0x6d, 0x65, 0x6c, 0x69, 0x6e, 0x65, 0x18, 0x02, 0x20, 0x03,
+	0x28, 0x0b, 0x32, 0x15, 0x2e, 0x6f, 0x6d, 0x73, 0x2e, 0x76, 0x31, 0x2e, 0x54, 0x69, 0x6d, 0x65,
+	0x6c, 0x69, 0x6e, 0x65, 0x45, 0x76, 0x65, 0x6e, 0x74, 0x52, 0x08, 0x74, 0x69, 0x6d, 0x65, 0x6c,
+	0x69, 0x6e, 0x65, 0x22, 0x2f, 0x0a, 0x10, 0x47, 0x65, 0x74, 0x4f, 0x72, 0x64, 0x65, 0x72, 0x73,
+	0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x1b, 0x0a, 0x09, 0x6f, 0x72, 0x64, 0x65, 0x72,
+	0x5f, 0x69, 0x64, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x09, 0x52, 0x08, 0x6f, 0x72, 0x64, 0x65,
+	0x72, 0x49, 0x64, 0x73, 0x22, 0x66, 0x0a, 0x11, 0x47, 0x65, 0x74, 0x4f, 0x72, 0x64, 0x65, 0x72,
+	0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x25, 0x0a, 0x06, 0x6f, 0x72, 0x64,
+	0x65, 0x72, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x0d, 0x2e, 0x6f, 0x6d, 0x73, 0x2e,
+	0x76, 0x31, 0x2e, 0x4f, 0x72, 0x64, 0x65, 0x72, 0x52, 0x06, 0x6f, 0x72, 0x64, 0x65, 0x72, 0x73,
+	0x12, 0x2a, 0x0a, 0x11, 0x6d, 0x69, 0x73, 0x73, 0x69, 0x6e, 0x67, 0x5f, 0x6f, 0x72, 0x64, 0x65,
+	0x72, 0x5f, 0x69, 0x64, 0x73, 0x18, 0x02, 0x20, 0x03, 0x28, 0x09, 0x52, 0x0f, 0x6d, 0x69, 0x73,
+	0x73, 0x69, 0x6e, 0x67, 0x4f, 0x72, 0x64, 0x65, 0x72, 0x49, 0x64, 0x73, 0x22, 0x60, 0x0a, 0x1a,
+	0x53, 0x74, 0x72, 0x65, 0x61, 0x6d, 0x4f, 0x72, 0x64, 0x65, 0x72, 0x54, 0x69, 0x6d, 0x65, 0x6c,
+	0x69, 0x6e, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x19, 0x0a, 0x08, 0x6f, 0x72,
+	0x64, 0x65, 0x72, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x6f, 0x72,
+	0x64, 0x65, 0x72, 0x49, 0x64, 0x12, 0x27, 0x0a, 0x0f, 0x69, 0x6e, 0x63, 0x6c, 0x75, 0x64, 0x65,
+	0x5f, 0x68, 0x69, 0x73, 0x74, 0x6f, 0x72, 0x79, 0x18, 0x02, 0x20, 0x01, 0x28, 0x08, 0x52, 0x0e,
+	0x69, 0x6e, 0x63, 0x6c, 0x75, 0x64, 0x65, 0x48, 0x69, 0x73, 0x74, 0x6f, 0x72, 0x79, 0x22, 0x6a,
+	0x0a, 0x1b, 0x53, 0x74, 0x72, 0x65, 0x61, 0x6d, 0x4f, 0x72, 0x64, 0x65, 0x72, 0x54, 0x69, 0x6d,
+	0x65, 0x6c, 0x69, 0x6e, 0x65, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x2b, 0x0a,
+	0x05, 0x65, 0x76, 0x65, 0x6e, 0x74, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x15, 0x2e, 0x6f,
+	0x6d, 0x73, 0x2e, 0x76, 0x31, 0x2e, 0x54, 0x69, 0x6d, 0x65, 0x6c, 0x69, 0x6e, 0x65, 0x45, 0x76,
+	0x65, 0x6e, 0x74, 0x52, 0x05, 0x65, 0x76, 0x65, 0x6e, 0x74, 0x12, 0x1e, 0x0a, 0x0a, 0x68, 0x69,
+	0x73, 0x74, 0x6f, 0x72, 0x69, 0x63, 0x61, 0x6c, 0x18, 0x02, 0x20, 0x01, 0x28, 0x08, 0x52, 0x0a,
+	0x68, 0x69, 0x73, 0x74, 0x6f, 0x72, 0x69, 0x63, 0x61, 0x6c, 0x22, 0x2e, 0x0a, 0x11, 0x57, 0x61,
+	0x74, 0x63, 0x68, 0x4f, 0x72, 0x64, 0x65, 0x72, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12,
+	0x19, 0x0a, 0x08, 0x6f, 0x72, 0x64, 0x65, 0x72, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x07, 0x6f, 0x72, 0x64, 0x65, 0x72, 0x49, 0x64, 0x22, 0x66, 0x0a, 0x12, 0x57, 0x61,
+	0x74, 0x63, 0x68, 0x4f, 0x72, 0x64, 0x65, 0x72, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65,
+	0x12, 0x23, 0x0a, 0x05, 0x6f, 0x72, 0x64, 0x65, 0x72, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32,
+	0x0d, 0x2e, 0x6f, 0x6d, 0x73, 0x2e, 0x76, 0x31, 0x2e, 0x4f, 0x72, 0x64, 0x65, 0x72, 0x52, 0x05,
+	0x6f, 0x72, 0x64, 0x65, 0x72, 0x12, 0x2b, 0x0a, 0x05, 0x65, 0x76, 0x65, 0x6e, 0x74, 0x18, 0x02,
+	0x20, 0x01, 0x28, 0x0b, 0x32, 0x15, 0x2e, 0x6f, 0x6d, 0x73, 0x2e, 0x76, 0x31, 0x2e, 0x54, 0x69,
+	0x6d, 0x65, 0x6c, 0x69, 0x6e, 0x65, 0x45, 0x76, 0x65, 0x6e, 0x74, 0x52, 0x05, 0x65, 0x76, 0x65,
+	0x6e, 0x74, 0x22, 0xd0, 0x01, 0x0a, 0x11, 0x4c, 0x69, 0x73, 0x74, 0x4f, 0x72, 0x64, 0x65, 0x72,
+	0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x41, 0x0a, 0x0b, 0x63, 0x75, 0x73, 0x74,
+	0x6f, 0x6d, 0x65, 0x72, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x42, 0x20, 0xba,
+	0x48, 0x1d, 0xc8, 0x01, 0x01, 0x72, 0x18, 0x18, 0x80, 0x01, 0x32, 0x13, 0x5e, 0x5b, 0x41, 0x2d,
+	0x5a, 0x61, 0x2d, 0x7a, 0x30, 0x2d, 0x39, 0x2e, 0x5f, 0x3a, 0x40, 0x2d, 0x5d, 0x2a, 0x24, 0x52,
+	0x0a, 0x63, 0x75, 0x73, 0x74, 0x6f, 0x6d, 0x65, 0x72, 0x49, 0x64, 0x12, 0x1b, 0x0a, 0x09, 0x70,
+	0x61, 0x67, 0x65, 0x5f, 0x73, 0x69, 0x7a, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x05, 0x52, 0x08,
+	0x70, 0x61, 0x67, 0x65, 0x53, 0x69, 0x7a, 0x65, 0x12, 0x1d, 0x0a, 0x0a, 0x70, 0x61, 0x67, 0x65,
+	0x5f, 0x74, 0x6f, 0x6b, 0x65, 0x6e, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x70, 0x61,
+	0x67, 0x65, 0x54, 0x6f, 0x6b, 0x65, 0x6e, 0x12, 0x3c, 0x0a, 0x0f, 0x66, 0x69, 0x6c, 0x74, 0x65,
+	0x72, 0x5f, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x65, 0x73, 0x18, 0x04, 0x20, 0x03, 0x28, 0x0e,
+	0x32, 0x13, 0x2e, 0x6f, 0x6d, 0x73, 0x2e, 0x76, 0x31, 0x2e, 0x4f, 0x72, 0x64, 0x65, 0x72, 0x53,
+	0x74, 0x61, 0x74, 0x75, 0x73, 0x52, 0x0e, 0x66, 0x69, 0x6c, 0x74, 0x65, 0x72, 0x53, 0x74, 0x61,
+	0x74, 0x75, 0x73, 0x65, 0x73, 0x22, 0x63, 0x0a, 0x12, 0x4c, 0x69, 0x73, 0x74, 0x4f, 0x72, 0x64,
+	0x65, 0x72, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x25, 0x0a, 0x06, 0x6f,
+	0x72, 0x64, 0x65, 0x72, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x0d, 0x2e, 0x6f, 0x6d,
+	0x73, 0x2e, 0x76, 0x31, 0x2e, 0x4f, 0x72, 0x64, 0x65, 0x72, 0x52, 0x06, 0x6f, 0x72, 0x64, 0x65,
+	0x72, 0x73, 0x12, 0x26, 0x0a, 0x0f, 0x6e, 0x65, 0x78, 0x74, 0x5f, 0x70, 0x61, 0x67, 0x65, 0x5f,
+	0x74, 0x6f, 0x6b, 0x65, 0x6e, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0d, 0x6e, 0x65, 0x78,
+	0x74, 0x50, 0x61, 0x67, 0x65, 0x54, 0x6f, 0x6b, 0x65, 0x6e, 0x22, 0x83, 0x01, 0x0a, 0x12, 0x55,
+	0x70, 0x64, 0x61, 0x74, 0x65, 0x4f, 0x72, 0x64, 0x65, 0x72, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73,
+	0x74, 0x12, 0x19, 0x0a, 0x08, 0x6f, 0x72, 0x64, 0x65, 0x72, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x07, 0x6f, 0x72, 0x64, 0x65, 0x72, 0x49, 0x64, 0x12, 0x29, 0x0a, 0x10,
+	0x65, 0x78, 0x70, 0x65, 0x63, 0x74, 0x65, 0x64, 0x5f, 0x76, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e,
+	0x18, 0x02, 0x20, 0x01, 0x28, 0x03, 0x52, 0x0f, 0x65, 0x78, 0x70, 0x65, 0x63, 0x74, 0x65, 0x64,
+	0x56, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x12, 0x27, 0x0a, 0x05, 0x69, 0x74, 0x65, 0x6d, 0x73,
+	0x18, 0x03, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x11, 0x2e, 0x6f, 0x6d, 0x73, 0x2e, 0x76, 0x31, 0x2e,
+	0x4f, 0x72, 0x64, 0x65, 0x72, 0x49, 0x74, 0x65, 0x6d, 0x52, 0x05, 0x69, 0x74, 0x65, 0x6d, 0x73,
+	0x22, 0x3a, 0x0a, 0x13, 0x55, 0x70, 0x64, 0x61, 0x74, 0x65, 0x4f, 0x72, 0x64, 0x65, 0x72, 0x52,
+	0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x23, 0x0a, 0x05, 0x6f, 0x72, 0x64, 0x65, 0x72,
+	0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x0d, 0x2e, 0x6f, 0x6d, 0x73, 0x2e, 0x76, 0x31, 0x2e,
+	0x4f, 0x72, 0x64, 0x65, 0x72, 0x52, 0x05, 0x6f, 0x72, 0x64, 0x65, 0x72, 0x22, 0x4e, 0x0a, 0x0f,
+	0x50, 0x61, 0x79, 0x4f, 0x72, 0x64, 0x65, 0x72, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12,
+	0x3b, 0x0a, 0x08, 0x6f, 0x72, 0x64, 0x65, 0x72, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28,
+	0x09, 0x42, 0x20, 0xba, 0x48, 0x1d, 0xc8, 0x01, 0x01, 0x72, 0x18, 0x18, 0x80, 0x01, 0x32, 0x13,
+	0x5e, 0x5b, 0x41, 0x2d, 0x5a, 0x61, 0x2d, 0x7a, 0x30, 0x2d, 0x39, 0x2e, 0x5f, 0x3a, 0x40, 0x2d,
+	0x5d, 0x2a, 0x24, 0x52, 0x07, 0x6f, 0x72, 0x64, 0x65, 0x72, 0x49, 0x64, 0x22, 0x5a, 0x0a, 0x10,
+	0x50, 0x61, 0x79, 0x4f, 0x72, 0x64, 0x65, 0x72, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65,
+	0x12, 0x19, 0x0a, 0x08, 0x6f, 0x72, 0x64, 0x65, 0x72, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x07, 0x6f, 0x72, 0x64, 0x65, 0x72, 0x49, 0x64, 0x12, 0x2b, 0x0a, 0x06, 0x73,
+	0x74, 0x61, 0x74, 0x75, 0x73, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x13, 0x2e, 0x6f, 0x6d,
+	0x73, 0x2e, 0x76, 0x31, 0x2e, 0x4f, 0x72, 0x64, 0x65, 0x72, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73,
+	0x52, 0x06, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x22, 0x30, 0x0a, 0x13, 0x43, 0x61, 0x70, 0x74,
+	0x75, 0x72, 0x65, 0x4f, 0x72, 0x64, 0x65, 0x72, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12,
+	0x19, 0x0a, 0x08, 0x6f, 0x72, 0x64, 0x65, 0x72, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x07, 0x6f, 0x72, 0x64, 0x65, 0x72, 0x49, 0x64, 0x22, 0x5e, 0x0a, 0x14, 0x43, 0x61,
+	0x70, 0x74, 0x75, 0x72, 0x65, 0x4f, 0x72, 0x64, 0x65, 0x72, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e,
+	0x73, 0x65, 0x12, 0x19, 0x0a, 0x08, 0x6f, 0x72, 0x64, 0x65, 0x72, 0x5f, 0x69, 0x64, 0x18, 0x01,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x6f, 0x72, 0x64, 0x65, 0x72, 0x49, 0x64, 0x12, 0x2b, 0x0a,
+	0x06, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x13, 0x2e,
+	0x6f, 0x6d, 0x73, 0x2e, 0x76, 0x31, 0x2e, 0x4f, 0x72, 0x64, 0x65, 0x72, 0x53, 0x74, 0x61, 0x74,
+	0x75, 0x73, 0x52, 0x06, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x22, 0x69, 0x0a, 0x12, 0x43, 0x61,
+	0x6e, 0x63, 0x65, 0x6c, 0x4f, 0x72, 0x64, 0x65, 0x72, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74,
+	0x12, 0x3b, 0x0a, 0x08, 0x6f, 0x72, 0x64, 0x65, 0x72, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01,
+	0x28, 0x09, 0x42, 0x20, 0xba, 0x48, 0x1d, 0xc8, 0x01, 0x01, 0x72, 0x18, 0x18, 0x80, 0x01, 0x32,
+	0x13, 0x5e, 0x5b, 0x41, 0x2d, 0x5a, 0x61, 0x2d, 0x7a, 0x30, 0x2d, 0x39, 0x2e, 0x5f, 0x3a, 0x40,
+	0x2d, 0x5d, 0x2a, 0x24, 0x52, 0x07, 0x6f, 0x72, 0x64, 0x65, 0x72, 0x49, 0x64, 0x12, 0x16, 0x0a,
+	0x06, 0x72, 0x65, 0x61, 0x73, 0x6f, 0x6e, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x72,
+	0x65, 0x61, 0x73, 0x6f, 0x6e, 0x22, 0x5d, 0x0a, 0x13, 0x43, 0x61, 0x6e, 0x63, 0x65, 0x6c, 0x4f,
+	0x72, 0x64, 0x65, 0x72, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x19, 0x0a, 0x08,
+	0x6f, 0x72, 0x64, 0x65, 0x72, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07,
+	0x6f, 0x72, 0x64, 0x65, 0x72, 0x49, 0x64, 0x12, 0x2b, 0x0a, 0x06, 0x73, 0x74, 0x61, 0x74, 0x75,
+	0x73, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x13, 0x2e, 0x6f, 0x6d, 0x73, 0x2e, 0x76, 0x31,
+	0x2e, 0x4f, 0x72, 0x64, 0x65, 0x72, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x52, 0x06, 0x73, 0x74,
+	0x61, 0x74, 0x75, 0x73, 0x22, 0xe7, 0x01, 0x0a, 0x12, 0x52, 0x65, 0x66, 0x75, 0x6e, 0x64, 0x4f,
+	0x72, 0x64, 0x65, 0x72, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x3b, 0x0a, 0x08, 0x6f,
+	0x72, 0x64, 0x65, 0x72, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x42, 0x20, 0xba,
+	0x48, 0x1d, 0xc8, 0x01, 0x01, 0x72, 0x18, 0x18, 0x80, 0x01, 0x32, 0x13, 0x5e, 0x5b, 0x41, 0x2d,
+	0x5a, 0x61, 0x2d, 0x7a, 0x30, 0x2d, 0x39, 0x2e, 0x5f, 0x3a, 0x40, 0x2d, 0x5d, 0x2a, 0x24, 0x52,
+	0x07, 0x6f, 0x72, 0x64, 0x65, 0x72, 0x49, 0x64, 0x12, 0x7c, 0x0a, 0x06, 0x61, 0x6d, 0x6f, 0x75,
+	0x6e, 0x74, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x0d, 0x2e, 0x6f, 0x6d, 0x73, 0x2e, 0x76,
+	0x31, 0x2e, 0x4d, 0x6f, 0x6e, 0x65, 0x79, 0x42, 0x55, 0xba, 0x48, 0x52, 0xba, 0x01, 0x4f, 0x0a,
+	0x13, 0x72, 0x65, 0x66, 0x75, 0x6e, 0x64, 0x5f, 0x6f, 0x72, 0x64, 0x65, 0x72, 0x2e, 0x61, 0x6d,
+	0x6f, 0x75, 0x6e, 0x74, 0x12, 0x20, 0x61, 0x6d, 0x6f, 0x75, 0x6e, 0x74, 0x2e, 0x61, 0x6d, 0x6f,
+	0x75, 0x6e, 0x74, 0x5f, 0x6d, 0x69, 0x6e, 0x6f, 0x72, 0x20, 0x6d, 0x75, 0x73, 0x74, 0x20, 0x62,
+	0x65, 0x20, 0x3e, 0x3d, 0x20, 0x30, 0x1a, 0x16, 0x74, 0x68, 0x69, 0x73, 0x2e, 0x61, 0x6d, 0x6f,
+	0x75, 0x6e, 0x74, 0x5f, 0x6d, 0x69, 0x6e, 0x6f, 0x72, 0x20, 0x3e, 0x3d, 0x20, 0x30, 0x52, 0x06,
+	0x61, 0x6d, 0x6f, 0x75, 0x6e, 0x74, 0x12, 0x16, 0x0a, 0x06, 0x72, 0x65, 0x61, 0x73, 0x6f, 0x6e,
+	0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x72, 0x65, 0x61, 0x73, 0x6f, 0x6e, 0x22, 0x5d,
+	0x0a, 0x13, 0x52, 0x65, 0x66, 0x75, 0x6e, 0x64, 0x4f, 0x72, 0x64, 0x65, 0x72, 0x52, 0x65, 0x73,
+	0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x19, 0x0a, 0x08, 0x6f, 0x72, 0x64, 0x65, 0x72, 0x5f, 0x69,
+	0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x6f, 0x72, 0x64, 0x65, 0x72, 0x49, 0x64,
+	0x12, 0x2b, 0x0a, 0x06, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0e,
+	0x32, 0x13, 0x2e, 0x6f, 0x6d, 0x73, 0x2e, 0x76, 0x31, 0x2e, 0x4f, 0x72, 0x64, 0x65, 0x72, 0x53,
+	0x74, 0x61, 0x74, 0x75, 0x73, 0x52, 0x06, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x22, 0x45, 0x0a,
+	0x10, 0x48, 0x6f, 0x6c, 0x64, 0x4f, 0x72, 0x64, 0x65, 0x72, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73,
+	0x74, 0x12, 0x19, 0x0a, 0x08, 0x6f, 0x72, 0x64, 0x65, 0x72, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x07, 0x6f, 0x72, 0x64, 0x65, 0x72, 0x49, 0x64, 0x12, 0x16, 0x0a, 0x06,
+	0x72, 0x65, 0x61, 0x73, 0x6f, 0x6e, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x72, 0x65,
+	0x61, 0x73, 0x6f, 0x6e, 0x22, 0x7c, 0x0a, 0x11, 0x48, 0x6f, 0x6c, 0x64, 0x4f, 0x72, 0x64, 0x65,
+	0x72, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x19, 0x0a, 0x08, 0x6f, 0x72, 0x64,
+	0x65, 0x72, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x6f, 0x72, 0x64,
+	0x65, 0x72, 0x49, 0x64, 0x12, 0x2b, 0x0a, 0x06, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x18, 0x02,
+	0x20, 0x01, 0x28, 0x0e, 0x32, 0x13, 0x2e, 0x6f, 0x6d, 0x73, 0x2e, 0x76, 0x31, 0x2e, 0x4f, 0x72,
+	0x64, 0x65, 0x72, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x52, 0x06, 0x73, 0x74, 0x61, 0x74, 0x75,
+	0x73, 0x12, 0x1f, 0x0a, 0x0b, 0x68, 0x6f, 0x6c, 0x64, 0x5f, 0x72, 0x65, 0x61, 0x73, 0x6f, 0x6e,
+	0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0a, 0x68, 0x6f, 0x6c, 0x64, 0x52, 0x65, 0x61, 0x73,
+	0x6f, 0x6e, 0x22, 0x48, 0x0a, 0x13, 0x52, 0x65, 0x6c, 0x65, 0x61, 0x73, 0x65, 0x4f, 0x72, 0x64,
+	0x65, 0x72, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x19, 0x0a, 0x08, 0x6f, 0x72, 0x64,
+	0x65, 0x72, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x6f, 0x72, 0x64,
+	0x65, 0x72, 0x49, 0x64, 0x12, 0x16, 0x0a, 0x06, 0x72, 0x65, 0x61, 0x73, 0x6f, 0x6e, 0x18, 0x02,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x72, 0x65, 0x61, 0x73, 0x6f, 0x6e, 0x22, 0x5e, 0x0a, 0x14,
+	0x52, 0x65, 0x6c, 0x65, 0x61, 0x73, 0x65, 0x4f, 0x72, 0x64, 0x65, 0x72, 0x52, 0x65, 0x73, 0x70,
+	0x6f, 0x6e, 0x73, 0x65, 0x12, 0x19, 0x0a, 0x08, 0x6f, 0x72, 0x64, 0x65, 0x72, 0x5f, 0x69, 0x64,
+	0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x6f, 0x72, 0x64, 0x65, 0x72, 0x49, 0x64, 0x12,
+	0x2b, 0x0a, 0x06, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0e, 0x32,
+	0x13, 0x2e, 0x6f, 0x6d, 0x73, 0x2e, 0x76, 0x31, 0x2e, 0x4f, 0x72, 0x64, 0x65, 0x72, 0x53, 0x74,
+	0x61, 0x74, 0x75, 0x73, 0x52, 0x06, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x22, 0x66, 0x0a, 0x13,
+	0x41, 0x70, 0x70, 0x72, 0x6f, 0x76, 0x65, 0x4f, 0x72, 0x64, 0x65, 0x72, 0x52, 0x65, 0x71, 0x75,
+	0x65, 0x73, 0x74, 0x12, 0x19, 0x0a, 0x08, 0x6f, 0x72, 0x64, 0x65, 0x72, 0x5f, 0x69, 0x64, 0x18,
+	0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x6f, 0x72, 0x64, 0x65, 0x72, 0x49, 0x64, 0x12, 0x1a,
+	0x0a, 0x08, 0x61, 0x70, 0x70, 0x72, 0x6f, 0x76, 0x65, 0x72, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x08, 0x61, 0x70, 0x70, 0x72, 0x6f, 0x76, 0x65, 0x72, 0x12, 0x18, 0x0a, 0x07, 0x63, 0x6f,
+	0x6d, 0x6d, 0x65, 0x6e, 0x74, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x63, 0x6f, 0x6d,
+	0x6d, 0x65, 0x6e, 0x74, 0x22, 0x5e, 0x0a, 0x14, 0x41, 0x70, 0x70, 0x72, 0x6f, 0x76, 0x65, 0x4f,
+	0x72, 0x64, 0x65, 0x72, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x19, 0x0a, 0x08,
+	0x6f, 0x72, 0x64, 0x65, 0x72, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07,
+	0x6f, 0x72, 0x64, 0x65, 0x72, 0x49, 0x64, 0x12, 0x2b, 0x0a, 0x06, 0x73, 0x74, 0x61, 0x74, 0x75,
+	0x73, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x13, 0x2e, 0x6f, 0x6d, 0x73, 0x2e, 0x76, 0x31,
+	0x2e, 0x4f, 0x72, 0x64, 0x65, 0x72, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x52, 0x06, 0x73, 0x74,
+	0x61, 0x74, 0x75, 0x73, 0x22, 0x63, 0x0a, 0x12, 0x52, 0x65, 0x6a, 0x65, 0x63, 0x74, 0x4f, 0x72,
+	0x64, 0x65, 0x72, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x19, 0x0a, 0x08, 0x6f, 0x72,
+	0x64, 0x65, 0x72, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x6f, 0x72,
+	0x64, 0x65, 0x72, 0x49, 0x64, 0x12, 0x1a, 0x0a, 0x08, 0x61, 0x70, 0x70, 0x72, 0x6f, 0x76, 0x65,
+	0x72, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x61, 0x70, 0x70, 0x72, 0x6f, 0x76, 0x65,
+	0x72, 0x12, 0x16, 0x0a, 0x06, 0x72, 0x65, 0x61, 0x73, 0x6f, 0x6e, 0x18, 0x03, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x06, 0x72, 0x65, 0x61, 0x73, 0x6f, 0x6e, 0x22, 0x5d, 0x0a, 0x13, 0x52, 0x65, 0x6a,
+	0x65, 0x63, 0x74, 0x4f, 0x72, 0x64, 0x65, 0x72, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65,
+	0x12, 0x19, 0x0a, 0x08, 0x6f, 0x72, 0x64, 0x65, 0x72, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x07, 0x6f, 0x72, 0x64, 0x65, 0x72, 0x49, 0x64, 0x12, 0x2b, 0x0a, 0x06, 0x73,
+	0x74, 0x61, 0x74, 0x75, 0x73, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x13, 0x2e, 0x6f, 0x6d,
+	0x73, 0x2e, 0x76, 0x31, 0x2e, 0x4f, 0x72, 0x64, 0x65, 0x72, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73,
+	0x52, 0x06, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x22, 0xdb, 0x01, 0x0a, 0x0f, 0x53, 0x63, 0x68,
+	0x65, 0x64, 0x75, 0x6c, 0x65, 0x64, 0x43, 0x61, 0x6e, 0x63, 0x65, 0x6c, 0x12, 0x0e, 0x0a, 0x02,
+	0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x02, 0x69, 0x64, 0x12, 0x19, 0x0a, 0x08,
+	0x6f, 0x72, 0x64, 0x65, 0x72, 0x5f, 0x69, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07,
+	0x6f, 0x72, 0x64, 0x65, 0x72, 0x49, 0x64, 0x12, 0x1b, 0x0a, 0x09, 0x63, 0x61, 0x6e, 0x63, 0x65,
+	0x6c, 0x5f, 0x61, 0x74, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x63, 0x61, 0x6e, 0x63,
+	0x65, 0x6c, 0x41, 0x74, 0x12, 0x16, 0x0a, 0x06, 0x72, 0x65, 0x61, 0x73, 0x6f, 0x6e, 0x18, 0x04,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x72, 0x65, 0x61, 0x73, 0x6f, 0x6e, 0x12, 0x35, 0x0a, 0x06,
+	0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x18, 0x05, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x1d, 0x2e, 0x6f,
+	0x6d, 0x73, 0x2e, 0x76, 0x31, 0x2e, 0x53, 0x63, 0x68, 0x65, 0x64, 0x75, 0x6c, 0x65, 0x64, 0x43,
+	0x61, 0x6e, 0x63, 0x65, 0x6c, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x52, 0x06, 0x73, 0x74, 0x61,
+	0x74, 0x75, 0x73, 0x12, 0x12, 0x0a, 0x04, 0x6e, 0x6f, 0x74, 0x65, 0x18, 0x06, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x04, 0x6e, 0x6f, 0x74, 0x65, 0x12, 0x1d, 0x0a, 0x0a, 0x63, 0x72, 0x65, 0x61, 0x74,
+	0x65, 0x64, 0x5f, 0x61, 0x74, 0x18, 0x07, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x63, 0x72, 0x65,
+	0x61, 0x74, 0x65, 0x64, 0x41, 0x74, 0x22, 0x67, 0x0a, 0x15, 0x53, 0x63, 0x68, 0x65, 0x64, 0x75,
+	0x6c, 0x65, 0x43, 0x61, 0x6e, 0x63, 0x65, 0x6c, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12,
+	0x19, 0x0a, 0x08, 0x6f, 0x72, 0x64, 0x65, 0x72, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x07, 0x6f, 0x72, 0x64, 0x65, 0x72, 0x49, 0x64, 0x12, 0x1b, 0x0a, 0x09, 0x63, 0x61,
+	0x6e, 0x63, 0x65, 0x6c, 0x5f, 0x61, 0x74, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x63,
+	0x61, 0x6e, 0x63, 0x65, 0x6c, 0x41, 0x74, 0x12, 0x16, 0x0a, 0x06, 0x72, 0x65, 0x61, 0x73, 0x6f,
+	0x6e, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x72, 0x65, 0x61, 0x73, 0x6f, 0x6e, 0x22,
+	0x5c, 0x0a, 0x16, 0x53, 0x63, 0x68, 0x65, 0x64, 0x75, 0x6c, 0x65, 0x43, 0x61, 0x6e, 0x63, 0x65,
+	0x6c, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x42, 0x0a, 0x10, 0x73, 0x63, 0x68,
+	0x65, 0x64, 0x75, 0x6c, 0x65, 0x64, 0x5f, 0x63, 0x61, 0x6e, 0x63, 0x65, 0x6c, 0x18, 0x01, 0x20,
+	0x01, 0x28, 0x0b, 0x32, 0x17, 0x2e, 0x6f, 0x6d, 0x73, 0x2e, 0x76, 0x31, 0x2e, 0x53, 0x63, 0x68,
+	0x65, 0x64, 0x75, 0x6c, 0x65, 0x64, 0x43, 0x61, 0x6e, 0x63, 0x65, 0x6c, 0x52, 0x0f, 0x73, 0x63,
+	0x68, 0x65, 0x64, 0x75, 0x6c, 0x65, 0x64, 0x43, 0x61, 0x6e, 0x63, 0x65, 0x6c, 0x22, 0x38, 0x0a,
+	0x1b, 0x4c, 0x69, 0x73, 0x74, 0x53, 0x63, 0x68, 0x65, 0x64, 0x75, 0x6c, 0x65, 0x64, 0x43, 0x61,
+	0x6e, 0x63, 0x65, 0x6c, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x19, 0x0a, 0x08,
+	0x6f, 0x72, 0x64, 0x65, 0x72, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07,
+	0x6f, 0x72, 0x64, 0x65, 0x72, 0x49, 0x64, 0x22, 0x64, 0x0a, 0x1c, 0x4c, 0x69, 0x73, 0x74, 0x53,
+	0x63, 0x68, 0x65, 0x64, 0x75, 0x6c, 0x65, 0x64, 0x43, 0x61, 0x6e, 0x63, 0x65, 0x6c, 0x73, 0x52,
+	0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x44, 0x0a, 0x11, 0x73, 0x63, 0x68, 0x65, 0x64,
+	0x75, 0x6c, 0x65, 0x64, 0x5f, 0x63, 0x61, 0x6e, 0x63, 0x65, 0x6c, 0x73, 0x18, 0x01, 0x20, 0x03,
+	0x28, 0x0b, 0x32, 0x17, 0x2e, 0x6f, 0x6d, 0x73, 0x2e, 0x76, 0x31, 0x2e, 0x53, 0x63, 0x68, 0x65,
+	0x64, 0x75, 0x6c, 0x65, 0x64, 0x43, 0x61, 0x6e, 0x63, 0x65, 0x6c, 0x52, 0x10, 0x73, 0x63, 0x68,
+	0x65, 0x64, 0x75, 0x6c, 0x65, 0x64, 0x43, 0x61, 0x6e, 0x63, 0x65, 0x6c, 0x73, 0x22, 0x69, 0x0a,
+	0x1c, 0x44, 0x65, 0x6c, 0x65, 0x74, 0x65, 0x53, 0x63, 0x68, 0x65, 0x64, 0x75, 0x6c, 0x65, 0x64,
+	0x43, 0x61, 0x6e, 0x63, 0x65, 0x6c, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x19, 0x0a,
+	0x08, 0x6f, 0x72, 0x64, 0x65, 0x72, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x07, 0x6f, 0x72, 0x64, 0x65, 0x72, 0x49, 0x64, 0x12, 0x2e, 0x0a, 0x13, 0x73, 0x63, 0x68, 0x65,
+	0x64, 0x75, 0x6c, 0x65, 0x64, 0x5f, 0x63, 0x61, 0x6e, 0x63, 0x65, 0x6c, 0x5f, 0x69, 0x64, 0x18,
+	0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x11, 0x73, 0x63, 0x68, 0x65, 0x64, 0x75, 0x6c, 0x65, 0x64,
+	0x43, 0x61, 0x6e, 0x63, 0x65, 0x6c, 0x49, 0x64, 0x22, 0x63, 0x0a, 0x1d, 0x44, 0x65, 0x6c, 0x65,
+	0x74, 0x65, 0x53, 0x63, 0x68, 0x65, 0x64, 0x75, 0x6c, 0x65, 0x64, 0x43, 0x61, 0x6e, 0x63, 0x65,
+	0x6c, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x42, 0x0a, 0x10, 0x73, 0x63, 0x68,
+	0x65, 0x64, 0x75, 0x6c, 0x65, 0x64, 0x5f, 0x63, 0x61, 0x6e, 0x63, 0x65, 0x6c, 0x18, 0x01, 0x20,
+	0x01, 0x28, 0x0b, 0x32, 0x17, 0x2e, 0x6f, 0x6d, 0x73, 0x2e, 0x76, 0x31, 0x2e, 0x53, 0x63, 0x68,
+	0x65, 0x64, 0x75, 0x6c, 0x65, 0x64, 0x43, 0x61, 0x6e, 0x63, 0x65, 0x6c, 0x52, 0x0f, 0x73, 0x63,
+	0x68, 0x65, 0x64, 0x75, 0x6c, 0x65, 0x64, 0x43, 0x61, 0x6e, 0x63, 0x65, 0x6c, 0x22, 0x70, 0x0a,
+	0x0a, 0x52, 0x65, 0x74, 0x75, 0x72, 0x6e, 0x49, 0x74, 0x65, 0x6d, 0x12, 0x17, 0x0a, 0x07, 0x69,
+	0x74, 0x65, 0x6d, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x69, 0x74,
+	0x65, 0x6d, 0x49, 0x64, 0x12, 0x10, 0x0a, 0x03, 0x71, 0x74, 0x79, 0x18, 0x02, 0x20, 0x01, 0x28,
+	0x05, 0x52, 0x03, 0x71, 0x74, 0x79, 0x12, 0x10, 0x0a, 0x03, 0x73, 0x6b, 0x75, 0x18, 0x03, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x03, 0x73, 0x6b, 0x75, 0x12, 0x25, 0x0a, 0x06, 0x72, 0x65, 0x66, 0x75,
+	0x6e, 0x64, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x0d, 0x2e, 0x6f, 0x6d, 0x73, 0x2e, 0x76,
+	0x31, 0x2e, 0x4d, 0x6f, 0x6e, 0x65, 0x79, 0x52, 0x06, 0x72, 0x65, 0x66, 0x75, 0x6e, 0x64, 0x22,
+	0x8d, 0x02, 0x0a, 0x0b, 0x4f, 0x72, 0x64, 0x65, 0x72, 0x52, 0x65, 0x74, 0x75, 0x72, 0x6e, 0x12,
+	0x0e, 0x0a, 0x02, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x02, 0x69, 0x64, 0x12,
+	0x19, 0x0a, 0x08, 0x6f, 0x72, 0x64, 0x65, 0x72, 0x5f, 0x69, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x07, 0x6f, 0x72, 0x64, 0x65, 0x72, 0x49, 0x64, 0x12, 0x2c, 0x0a, 0x06, 0x73, 0x74,
+	0x61, 0x74, 0x75, 0x73, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x14, 0x2e, 0x6f, 0x6d, 0x73,
+	0x2e, 0x76, 0x31, 0x2e, 0x52, 0x65, 0x74, 0x75, 0x72, 0x6e, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73,
+	0x52, 0x06, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x12, 0x28, 0x0a, 0x05, 0x69, 0x74, 0x65, 0x6d,
+	0x73, 0x18, 0x04, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x12, 0x2e, 0x6f, 0x6d, 0x73, 0x2e, 0x76, 0x31,
+	0x2e, 0x52, 0x65, 0x74, 0x75, 0x72, 0x6e, 0x49, 0x74, 0x65, 0x6d, 0x52, 0x05, 0x69, 0x74, 0x65,
+	0x6d, 0x73, 0x12, 0x16, 0x0a, 0x06, 0x72, 0x65, 0x61, 0x73, 0x6f, 0x6e, 0x18, 0x05, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x06, 0x72, 0x65, 0x61, 0x73, 0x6f, 0x6e, 0x12, 0x25, 0x0a, 0x06, 0x72, 0x65,
+	0x66, 0x75, 0x6e, 0x64, 0x18, 0x06, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x0d, 0x2e, 0x6f, 0x6d, 0x73,
+	0x2e, 0x76, 0x31, 0x2e, 0x4d, 0x6f, 0x6e, 0x65, 0x79, 0x52, 0x06, 0x72, 0x65, 0x66, 0x75, 0x6e,
+	0x64, 0x12, 0x1d, 0x0a, 0x0a, 0x63, 0x72, 0x65, 0x61, 0x74, 0x65, 0x64, 0x5f, 0x61, 0x74, 0x18,
+	0x07, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x63, 0x72, 0x65, 0x61, 0x74, 0x65, 0x64, 0x41, 0x74,
+	0x12, 0x1d, 0x0a, 0x0a, 0x75, 0x70, 0x64, 0x61, 0x74, 0x65, 0x64, 0x5f, 0x61, 0x74, 0x18, 0x08,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x75, 0x70, 0x64, 0x61, 0x74, 0x65, 0x64, 0x41, 0x74, 0x22,
+	0x72, 0x0a, 0x13, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x52, 0x65, 0x74, 0x75, 0x72, 0x6e, 0x52,
+	0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x19, 0x0a, 0x08, 0x6f, 0x72, 0x64, 0x65, 0x72, 0x5f,
+	0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x6f, 0x72, 0x64, 0x65, 0x72, 0x49,
+	0x64, 0x12, 0x28, 0x0a, 0x05, 0x69, 0x74, 0x65, 0x6d, 0x73, 0x18, 0x02, 0x20, 0x03, 0x28, 0x0b,
+	0x32, 0x12, 0x2e, 0x6f, 0x6d, 0x73, 0x2e, 0x76, 0x31, 0x2e, 0x52, 0x65, 0x74, 0x75, 0x72, 0x6e,
+	0x49, 0x74, 0x65, 0x6d, 0x52, 0x05, 0x69, 0x74, 0x65, 0x6d, 0x73, 0x12, 0x16, 0x0a, 0x06, 0x72,
+	0x65, 0x61, 0x73, 0x6f, 0x6e, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x72, 0x65, 0x61,
+	0x73, 0x6f, 0x6e, 0x22, 0x4e, 0x0a, 0x14, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x52, 0x65, 0x74,
+	0x75, 0x72, 0x6e, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x36, 0x0a, 0x0c, 0x6f,
+	0x72, 0x64, 0x65, 0x72, 0x5f, 0x72, 0x65, 0x74, 0x75, 0x72, 0x6e, 0x18, 0x01, 0x20, 0x01, 0x28,
+	0x0b, 0x32, 0x13, 0x2e, 0x6f, 0x6d, 0x73, 0x2e, 0x76, 0x31, 0x2e, 0x4f, 0x72, 0x64, 0x65, 0x72,
+	0x52, 0x65, 0x74, 0x75, 0x72, 0x6e, 0x52, 0x0b, 0x6f, 0x72, 0x64, 0x65, 0x72, 0x52, 0x65, 0x74,
+	0x75, 0x72, 0x6e, 0x22, 0x4a, 0x0a, 0x10, 0x47, 0x65, 0x74, 0x52, 0x65, 0x74, 0x75, 0x72, 0x6e,
+	0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x19, 0x0a, 0x08, 0x6f, 0x72, 0x64, 0x65, 0x72,
+	0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x6f, 0x72, 0x64, 0x65, 0x72,
+	0x49, 0x64, 0x12, 0x1b, 0x0a, 0x09, 0x72, 0x65, 0x74, 0x75, 0x72, 0x6e, 0x5f, 0x69, 0x64, 0x18,
+	0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x72, 0x65, 0x74, 0x75, 0x72, 0x6e, 0x49, 0x64, 0x22,
+	0x4b, 0x0a, 0x11, 0x47, 0x65, 0x74, 0x52, 0x65, 0x74, 0x75, 0x72, 0x6e, 0x52, 0x65, 0x73, 0x70,
+	0x6f, 0x6e, 0x73, 0x65, 0x12, 0x36, 0x0a, 0x0c, 0x6f, 0x72, 0x64, 0x65, 0x72, 0x5f, 0x72, 0x65,
+	0x74, 0x75, 0x72, 0x6e, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x13, 0x2e, 0x6f, 0x6d, 0x73,
+	0x2e, 0x76, 0x31, 0x2e, 0x4f, 0x72, 0x64, 0x65, 0x72, 0x52, 0x65, 0x74, 0x75, 0x72, 0x6e, 0x52,
+	0x0b, 0x6f, 0x72, 0x64, 0x65, 0x72, 0x52, 0x65, 0x74, 0x75, 0x72, 0x6e, 0x22, 0x7c, 0x0a, 0x14,
+	0x41, 0x64, 0x76, 0x61, 0x6e, 0x63, 0x65, 0x52, 0x65, 0x74, 0x75, 0x72, 0x6e, 0x52, 0x65, 0x71,
+	0x75, 0x65, 0x73, 0x74, 0x12, 0x19, 0x0a, 0x08, 0x6f, 0x72, 0x64, 0x65, 0x72, 0x5f, 0x69, 0x64,
+	0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x6f, 0x72, 0x64, 0x65, 0x72, 0x49, 0x64, 0x12,
+	0x1b, 0x0a, 0x09, 0x72, 0x65, 0x74, 0x75, 0x72, 0x6e, 0x5f, 0x69, 0x64, 0x18, 0x02, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x08, 0x72, 0x65, 0x74, 0x75, 0x72, 0x6e, 0x49, 0x64, 0x12, 0x2c, 0x0a, 0x06,
+	0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x14, 0x2e, 0x6f,
+	0x6d, 0x73, 0x2e, 0x76, 0x31, 0x2e, 0x52, 0x65, 0x74, 0x75, 0x72, 0x6e, 0x53, 0x74, 0x61, 0x74,
+	0x75, 0x73, 0x52, 0x06, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x22, 0x4f, 0x0a, 0x15, 0x41, 0x64,
+	0x76, 0x61, 0x6e, 0x63, 0x65, 0x52, 0x65, 0x74, 0x75, 0x72, 0x6e, 0x52, 0x65, 0x73, 0x70, 0x6f,
+	0x6e, 0x73, 0x65, 0x12, 0x36, 0x0a, 0x0c, 0x6f, 0x72, 0x64, 0x65, 0x72, 0x5f, 0x72, 0x65, 0x74,
+	0x75, 0x72, 0x6e, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x13, 0x2e, 0x6f, 0x6d, 0x73, 0x2e,
+	0x76, 0x31, 0x2e, 0x4f, 0x72, 0x64, 0x65, 0x72, 0x52, 0x65, 0x74, 0x75, 0x72, 0x6e, 0x52, 0x0b,
+	0x6f, 0x72, 0x64, 0x65, 0x72, 0x52, 0x65, 0x74, 0x75, 0x72, 0x6e, 0x22, 0x5c, 0x0a, 0x0a, 0x50,
+	0x61, 0x67, 0x65, 0x4c, 0x69, 0x6d, 0x69, 0x74, 0x73, 0x12, 0x2a, 0x0a, 0x11, 0x64, 0x65, 0x66,
+	0x61, 0x75, 0x6c, 0x74, 0x5f, 0x70, 0x61, 0x67, 0x65, 0x5f, 0x73, 0x69, 0x7a, 0x65, 0x18, 0x01,
+	0x20, 0x01, 0x28, 0x05, 0x52, 0x0f, 0x64, 0x65, 0x66, 0x61, 0x75, 0x6c, 0x74, 0x50, 0x61, 0x67,
+	0x65, 0x53, 0x69, 0x7a, 0x65, 0x12, 0x22, 0x0a, 0x0d, 0x6d, 0x61, 0x78, 0x5f, 0x70, 0x61, 0x67,
+	0x65, 0x5f, 0x73, 0x69, 0x7a, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x05, 0x52, 0x0b, 0x6d, 0x61,
+	0x78, 0x50, 0x61, 0x67, 0x65, 0x53, 0x69, 0x7a, 0x65, 0x22, 0x17, 0x0a, 0x15, 0x47, 0x65, 0x74,
+	0x53, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x49, 0x6e, 0x66, 0x6f, 0x52, 0x65, 0x71, 0x75, 0x65,
+	0x73, 0x74, 0x22, 0x4d, 0x0a, 0x16, 0x47, 0x65, 0x74, 0x53, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65,
+	0x49, 0x6e, 0x66, 0x6f, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x33, 0x0a, 0x0b,
+	0x70, 0x61, 0x67, 0x65, 0x5f, 0x6c, 0x69, 0x6d, 0x69, 0x74, 0x73, 0x18, 0x01, 0x20, 0x01, 0x28,
+	0x0b, 0x32, 0x12, 0x2e, 0x6f, 0x6d, 0x73, 0x2e, 0x76, 0x31, 0x2e, 0x50, 0x61, 0x67, 0x65, 0x4c,
+	0x69, 0x6d, 0x69, 0x74, 0x73, 0x52, 0x0a, 0x70, 0x61, 0x67, 0x65, 0x4c, 0x69, 0x6d, 0x69, 0x74,
+	0x73, 0x22, 0xf8, 0x01, 0x0a, 0x16, 0x52, 0x65, 0x67, 0x69, 0x73, 0x74, 0x65, 0x72, 0x43, 0x6f,
+	0x75, 0x72, 0x69, 0x65, 0x72, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x1d, 0x0a, 0x0a,
+	0x63, 0x6f, 0x75, 0x72, 0x69, 0x65, 0x72, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x09, 0x63, 0x6f, 0x75, 0x72, 0x69, 0x65, 0x72, 0x49, 0x64, 0x12, 0x14, 0x0a, 0x05, 0x70,
+	0x68, 0x6f, 0x6e, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x70, 0x68, 0x6f, 0x6e,
+	0x65, 0x12, 0x1d, 0x0a, 0x0a, 0x66, 0x69, 0x72, 0x73, 0x74, 0x5f, 0x6e, 0x61, 0x6d, 0x65, 0x18,
+	0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x66, 0x69, 0x72, 0x73, 0x74, 0x4e, 0x61, 0x6d, 0x65,
+	0x12, 0x1b, 0x0a, 0x09, 0x6c, 0x61, 0x73, 0x74, 0x5f, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x04, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x08, 0x6c, 0x61, 0x73, 0x74, 0x4e, 0x61, 0x6d, 0x65, 0x12, 0x3d, 0x0a,
+	0x0c, 0x76, 0x65, 0x68, 0x69, 0x63, 0x6c, 0x65, 0x5f, 0x74, 0x79, 0x70, 0x65, 0x18, 0x05, 0x20,
+	0x01, 0x28, 0x0e, 0x32, 0x1a, 0x2e, 0x6f, 0x6d, 0x73, 0x2e, 0x76, 0x31, 0x2e, 0x43, 0x6f, 0x75,
+	0x72, 0x69, 0x65, 0x72, 0x56, 0x65, 0x68, 0x69, 0x63, 0x6c, 0x65, 0x54, 0x79, 0x70, 0x65, 0x52,
+	0x0b, 0x76, 0x65, 0x68, 0x69, 0x63, 0x6c, 0x65, 0x54, 0x79, 0x70, 0x65, 0x12, 0x2e, 0x0a, 0x05,
+	0x7a, 0x6f, 0x6e, 0x65, 0x73, 0x18, 0x06, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x18, 0x2e, 0x6f, 0x6d,
+	0x73, 0x2e, 0x76, 0x31, 0x2e, 0x43, 0x6f, 0x75, 0x72, 0x69, 0x65, 0x72, 0x5a, 0x6f, 0x6e, 0x65,
+	0x49, 0x6e, 0x70, 0x75, 0x74, 0x52, 0x05, 0x7a, 0x6f, 0x6e, 0x65, 0x73, 0x22, 0x44, 0x0a, 0x17,
+	0x52, 0x65, 0x67, 0x69, 0x73, 0x74, 0x65, 0x72, 0x43, 0x6f, 0x75, 0x72, 0x69, 0x65, 0x72, 0x52,
+	0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x29, 0x0a, 0x07, 0x63, 0x6f, 0x75, 0x72, 0x69,
+	0x65, 0x72, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x0f, 0x2e, 0x6f, 0x6d, 0x73, 0x2e, 0x76,
+	0x31, 0x2e, 0x43, 0x6f, 0x75, 0x72, 0x69, 0x65, 0x72, 0x52, 0x07, 0x63, 0x6f, 0x75, 0x72, 0x69,
+	0x65, 0x72, 0x22, 0x32, 0x0a, 0x11, 0x47, 0x65, 0x74, 0x43, 0x6f, 0x75, 0x72, 0x69, 0x65, 0x72,
+	0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x1d, 0x0a, 0x0a, 0x63, 0x6f, 0x75, 0x72, 0x69,
+	0x65, 0x72, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x63, 0x6f, 0x75,
+	0x72, 0x69, 0x65, 0x72, 0x49, 0x64, 0x22, 0x3f, 0x0a, 0x12, 0x47, 0x65, 0x74, 0x43, 0x6f, 0x75,
+	0x72, 0x69, 0x65, 0x72, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x29, 0x0a, 0x07,
+	0x63, 0x6f, 0x75, 0x72, 0x69, 0x65, 0x72, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x0f, 0x2e,
+	0x6f, 0x6d, 0x73, 0x2e, 0x76, 0x31, 0x2e, 0x43, 0x6f, 0x75, 0x72, 0x69, 0x65, 0x72, 0x52, 0x07,
+	0x63, 0x6f, 0x75, 0x72, 0x69, 0x65, 0x72, 0x22, 0x4a, 0x0a, 0x19, 0x4c, 0x69, 0x73, 0x74, 0x43,
+	0x6f, 0x75, 0x72, 0x69, 0x65, 0x72, 0x73, 0x42, 0x79, 0x5a, 0x6f, 0x6e, 0x65, 0x52, 0x65, 0x71,
+	0x75, 0x65, 0x73, 0x74, 0x12, 0x17, 0x0a, 0x07, 0x7a, 0x6f, 0x6e, 0x65, 0x5f, 0x69, 0x64, 0x18,
+	0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x7a, 0x6f, 0x6e, 0x65, 0x49, 0x64, 0x12, 0x14, 0x0a,
+	0x05, 0x6c, 0x69, 0x6d, 0x69, 0x74, 0x18, 0x02, 0x20, 0x01, 0x28, 0x05, 0x52, 0x05, 0x6c, 0x69,
+	0x6d, 0x69, 0x74, 0x22, 0x49, 0x0a, 0x1a, 0x4c, 0x69, 0x73, 0x74, 0x43, 0x6f, 0x75, 0x72, 0x69,
+	0x65, 0x72, 0x73, 0x42, 0x79, 0x5a, 0x6f, 0x6e, 0x65, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73,
+	0x65, 0x12, 0x2b, 0x0a, 0x08, 0x63, 0x6f, 0x75, 0x72, 0x69, 0x65, 0x72, 0x73, 0x18, 0x01, 0x20,
+	0x03, 0x28, 0x0b, 0x32, 0x0f, 0x2e, 0x6f, 0x6d, 0x73, 0x2e, 0x76, 0x31, 0x2e, 0x43, 0x6f, 0x75,
+	0x72, 0x69, 0x65, 0x72, 0x52, 0x08, 0x63, 0x6f, 0x75, 0x72, 0x69, 0x65, 0x72, 0x73, 0x22, 0x6b,
+	0x0a, 0x1a, 0x52, 0x65, 0x70, 0x6c, 0x61, 0x63, 0x65, 0x43, 0x6f, 0x75, 0x72, 0x69, 0x65, 0x72,
+	0x5a, 0x6f, 0x6e, 0x65, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x1d, 0x0a, 0x0a,
+	0x63, 0x6f, 0x75, 0x72, 0x69, 0x65, 0x72, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x09, 0x63, 0x6f, 0x75, 0x72, 0x69, 0x65, 0x72, 0x49, 0x64, 0x12, 0x2e, 0x0a, 0x05, 0x7a,
+	0x6f, 0x6e, 0x65, 0x73, 0x18, 0x02, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x18, 0x2e, 0x6f, 0x6d, 0x73,
+	0x2e, 0x76, 0x31, 0x2e, 0x43, 0x6f, 0x75, 0x72, 0x69, 0x65, 0x72, 0x5a, 0x6f, 0x6e, 0x65, 0x49,
+	0x6e, 0x70, 0x75, 0x74, 0x52, 0x05, 0x7a, 0x6f, 0x6e, 0x65, 0x73, 0x22, 0x67, 0x0a, 0x1b, 0x52,
+	0x65, 0x70, 0x6c, 0x61, 0x63, 0x65, 0x43, 0x6f, 0x75, 0x72, 0x69, 0x65, 0x72, 0x5a, 0x6f, 0x6e,
+	0x65, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x1d, 0x0a, 0x0a, 0x63, 0x6f,
+	0x75, 0x72, 0x69, 0x65, 0x72, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09,
+	0x63, 0x6f, 0x75, 0x72, 0x69, 0x65, 0x72, 0x49, 0x64, 0x12, 0x29, 0x0a, 0x05, 0x7a, 0x6f, 0x6e,
+	0x65, 0x73, 0x18, 0x02, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x13, 0x2e, 0x6f, 0x6d, 0x73, 0x2e, 0x76,
+	0x31, 0x2e, 0x43, 0x6f, 0x75, 0x72, 0x69, 0x65, 0x72, 0x5a, 0x6f, 0x6e, 0x65, 0x52, 0x05, 0x7a,
+	0x6f, 0x6e, 0x65, 0x73, 0x22, 0xc5, 0x01, 0x0a, 0x18, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x43,
+	0x6f, 0x75, 0x72, 0x69, 0x65, 0x72, 0x53, 0x6c, 0x6f, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73,
+	0x74, 0x12, 0x17, 0x0a, 0x07, 0x73, 0x6c, 0x6f, 0x74, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x06, 0x73, 0x6c, 0x6f, 0x74, 0x49, 0x64, 0x12, 0x1d, 0x0a, 0x0a, 0x63, 0x6f,
+	0x75, 0x72, 0x69, 0x65, 0x72, 0x5f, 0x69, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09,
+	0x63, 0x6f, 0x75, 0x72, 0x69, 0x65, 0x72, 0x49, 0x64, 0x12, 0x26, 0x0a, 0x0f, 0x73, 0x6c, 0x6f,
+	0x74, 0x5f, 0x73, 0x74, 0x61, 0x72, 0x74, 0x5f, 0x75, 0x6e, 0x69, 0x78, 0x18, 0x03, 0x20, 0x01,
+	0x28, 0x03, 0x52, 0x0d, 0x73, 0x6c, 0x6f, 0x74, 0x53, 0x74, 0x61, 0x72, 0x74, 0x55, 0x6e, 0x69,
+	0x78, 0x12, 0x22, 0x0a, 0x0d, 0x73, 0x6c, 0x6f, 0x74, 0x5f, 0x65, 0x6e, 0x64, 0x5f, 0x75, 0x6e,
+	0x69, 0x78, 0x18, 0x04, 0x20, 0x01, 0x28, 0x03, 0x52, 0x0b, 0x73, 0x6c, 0x6f, 0x74, 0x45, 0x6e,
+	0x64, 0x55, 0x6e, 0x69, 0x78, 0x12, 0x25, 0x0a, 0x0e, 0x64, 0x75, 0x72, 0x61, 0x74, 0x69, 0x6f,
+	0x6e, 0x5f, 0x68, 0x6f, 0x75, 0x72, 0x73, 0x18, 0x05, 0x20, 0x01, 0x28, 0x05, 0x52, 0x0d, 0x64,
+	0x75, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x48, 0x6f, 0x75, 0x72, 0x73, 0x22, 0x44, 0x0a, 0x19,
+	0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x43, 0x6f, 0x75, 0x72, 0x69, 0x65, 0x72, 0x53, 0x6c, 0x6f,
+	0x74, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x27, 0x0a, 0x04, 0x73, 0x6c, 0x6f,
+	0x74, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x13, 0x2e, 0x6f, 0x6d, 0x73, 0x2e, 0x76, 0x31,
+	0x2e, 0x43, 0x6f, 0x75, 0x72, 0x69, 0x65, 0x72, 0x53, 0x6c, 0x6f, 0x74, 0x52, 0x04, 0x73, 0x6c,
+	0x6f, 0x74, 0x22, 0x6e, 0x0a, 0x17, 0x4c, 0x69, 0x73, 0x74, 0x43, 0x6f, 0x75, 0x72, 0x69, 0x65,
+	0x72, 0x53, 0x6c, 0x6f, 0x74, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x1d, 0x0a,
+	0x0a, 0x63, 0x6f, 0x75, 0x72, 0x69, 0x65, 0x72, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x09, 0x63, 0x6f, 0x75, 0x72, 0x69, 0x65, 0x72, 0x49, 0x64, 0x12, 0x1b, 0x0a, 0x09,
+	0x66, 0x72, 0x6f, 0x6d, 0x5f, 0x75, 0x6e, 0x69, 0x78, 0x18, 0x02, 0x20, 0x01, 0x28, 0x03, 0x52,
+	0x08, 0x66, 0x72, 0x6f, 0x6d, 0x55, 0x6e, 0x69, 0x78, 0x12, 0x17, 0x0a, 0x07, 0x74, 0x6f, 0x5f,
+	0x75, 0x6e, 0x69, 0x78, 0x18, 0x03, 0x20, 0x01, 0x28, 0x03, 0x52, 0x06, 0x74, 0x6f, 0x55, 0x6e,
+	0x69, 0x78, 0x22, 0x45, 0x0a, 0x18, 0x4c, 0x69, 0x73, 0x74, 0x43, 0x6f, 0x75, 0x72, 0x69, 0x65,
+	0x72, 0x53, 0x6c, 0x6f, 0x74, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x29,
+	0x0a, 0x05, 0x73, 0x6c, 0x6f, 0x74, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x13, 0x2e,
+	0x6f, 0x6d, 0x73, 0x2e, 0x76, 0x31, 0x2e, 0x43, 0x6f, 0x75, 0x72, 0x69, 0x65, 0x72, 0x53, 0x6c,
+	0x6f, 0x74, 0x52, 0x05, 0x73, 0x6c, 0x6f, 0x74, 0x73, 0x22, 0x63, 0x0a, 0x22, 0x47, 0x65, 0x74,
+	0x43, 0x6f, 0x75, 0x72, 0x69, 0x65, 0x72, 0x56, 0x65, 0x68, 0x69, 0x63, 0x6c, 0x65, 0x43, 0x61,
+	0x70, 0x61, 0x62, 0x69, 0x6c, 0x69, 0x74, 0x79, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12,
+	0x3d, 0x0a, 0x0c, 0x76, 0x65, 0x68, 0x69, 0x63, 0x6c, 0x65, 0x5f, 0x74, 0x79, 0x70, 0x65, 0x18,
+	0x01, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x1a, 0x2e, 0x6f, 0x6d, 0x73, 0x2e, 0x76, 0x31, 0x2e, 0x43,
+	0x6f, 0x75, 0x72, 0x69, 0x65, 0x72, 0x56, 0x65, 0x68, 0x69, 0x63, 0x6c, 0x65, 0x54, 0x79, 0x70,
+	0x65, 0x52, 0x0b, 0x76, 0x65, 0x68, 0x69, 0x63, 0x6c, 0x65, 0x54, 0x79, 0x70, 0x65, 0x22, 0x67,
+	0x0a, 0x23, 0x47, 0x65, 0x74, 0x43, 0x6f, 0x75, 0x72, 0x69, 0x65, 0x72, 0x56, 0x65, 0x68, 0x69,
+	0x63, 0x6c, 0x65, 0x43, 0x61, 0x70, 0x61, 0x62, 0x69, 0x6c, 0x69, 0x74, 0x79, 0x52, 0x65, 0x73,
+	0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x40, 0x0a, 0x0a, 0x63, 0x61, 0x70, 0x61, 0x62, 0x69, 0x6c,
+	0x69, 0x74, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x20, 0x2e, 0x6f, 0x6d, 0x73, 0x2e,
+	0x76, 0x31, 0x2e, 0x43, 0x6f, 0x75, 0x72, 0x69, 0x65, 0x72, 0x56, 0x65, 0x68, 0x69, 0x63, 0x6c,
+	0x65, 0x43, 0x61, 0x70, 0x61, 0x62, 0x69, 0x6c, 0x69, 0x74, 0x79, 0x52, 0x0a, 0x63, 0x61, 0x70,
+	0x61, 0x62, 0x69, 0x6c, 0x69, 0x74, 0x79, 0x22, 0x27, 0x0a, 0x25, 0x4c, 0x69, 0x73, 0x74, 0x43,
+	0x6f, 0x75, 0x72, 0x69, 0x65, 0x72, 0x56, 0x65, 0x68, 0x69, 0x63, 0x6c, 0x65, 0x43, 0x61, 0x70,
+	0x61, 0x62, 0x69, 0x6c, 0x69, 0x74, 0x69, 0x65, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74,
+	0x22, 0x6e, 0x0a, 0x26, 0x4c, 0x69, 0x73, 0x74, 0x43, 0x6f, 0x75, 0x72, 0x69, 0x65, 0x72, 0x56,
+	0x65, 0x68, 0x69, 0x63, 0x6c, 0x65, 0x43, 0x61, 0x70, 0x61, 0x62, 0x69, 0x6c, 0x69, 0x74, 0x69,
+	0x65, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x44, 0x0a, 0x0c, 0x63, 0x61,
+	0x70, 0x61, 0x62, 0x69, 0x6c, 0x69, 0x74, 0x69, 0x65, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b,
+	0x32, 0x20, 0x2e, 0x6f, 0x6d, 0x73, 0x2e, 0x76, 0x31, 0x2e, 0x43, 0x6f, 0x75, 0x72, 0x69, 0x65,
+	0x72, 0x56, 0x65, 0x68, 0x69, 0x63, 0x6c, 0x65, 0x43, 0x61, 0x70, 0x61, 0x62, 0x69, 0x6c, 0x69,
+	0x74, 0x79, 0x52, 0x0c, 0x63, 0x61, 0x70, 0x61, 0x62, 0x69, 0x6c, 0x69, 0x74, 0x69, 0x65, 0x73,
+	0x22, 0xb6, 0x01, 0x0a, 0x1a, 0x53, 0x75, 0x62, 0x6d, 0x69, 0x74, 0x43, 0x6f, 0x75, 0x72, 0x69,
+	0x65, 0x72, 0x52, 0x61, 0x74, 0x69, 0x6e, 0x67, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12,
+	0x1b, 0x0a, 0x09, 0x72, 0x61, 0x74, 0x69, 0x6e, 0x67, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x08, 0x72, 0x61, 0x74, 0x69, 0x6e, 0x67, 0x49, 0x64, 0x12, 0x1d, 0x0a, 0x0a,
+	0x63, 0x6f, 0x75, 0x72, 0x69, 0x65, 0x72, 0x5f, 0x69, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x09, 0x63, 0x6f, 0x75, 0x72, 0x69, 0x65, 0x72, 0x49, 0x64, 0x12, 0x14, 0x0a, 0x05, 0x73,
+	0x63, 0x6f, 0x72, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x05, 0x52, 0x05, 0x73, 0x63, 0x6f, 0x72,
+	0x65, 0x12, 0x2c, 0x0a, 0x04, 0x74, 0x61, 0x67, 0x73, 0x18, 0x04, 0x20, 0x03, 0x28, 0x0e, 0x32,
+	0x18, 0x2e, 0x6f, 0x6d, 0x73, 0x2e, 0x76, 0x31, 0x2e, 0x43, 0x6f, 0x75, 0x72, 0x69, 0x65, 0x72,
+	0x52, 0x61, 0x74, 0x69, 0x6e, 0x67, 0x54, 0x61, 0x67, 0x52, 0x04, 0x74, 0x61, 0x67, 0x73, 0x12,
+	0x18, 0x0a, 0x07, 0x63, 0x6f, 0x6d, 0x6d, 0x65, 0x6e, 0x74, 0x18, 0x05, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x07, 0x63, 0x6f, 0x6d, 0x6d, 0x65, 0x6e, 0x74, 0x22, 0x59, 0x0a, 0x1b, 0x53, 0x75, 0x62,
+	0x6d, 0x69, 0x74, 0x43, 0x6f, 0x75, 0x72, 0x69, 0x65, 0x72, 0x52, 0x61, 0x74, 0x69, 0x6e, 0x67,
+	0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x1b, 0x0a, 0x09, 0x72, 0x61, 0x74, 0x69,
+	0x6e, 0x67, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x72, 0x61, 0x74,
+	0x69, 0x6e, 0x67, 0x49, 0x64, 0x12, 0x1d, 0x0a, 0x0a, 0x63, 0x6f, 0x75, 0x72, 0x69, 0x65, 0x72,
+	0x5f, 0x69, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x63, 0x6f, 0x75, 0x72, 0x69,
+	0x65, 0x72, 0x49, 0x64, 0x22, 0x3f, 0x0a, 0x1e, 0x47, 0x65, 0x74, 0x43, 0x6f, 0x75, 0x72, 0x69,
+	0x65, 0x72, 0x52, 0x61, 0x74, 0x69, 0x6e, 0x67, 0x53, 0x75, 0x6d, 0x6d, 0x61, 0x72, 0x79, 0x52,
+	0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x1d, 0x0a, 0x0a, 0x63, 0x6f, 0x75, 0x72, 0x69, 0x65,
+	0x72, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x63, 0x6f, 0x75, 0x72,
+	0x69, 0x65, 0x72, 0x49, 0x64, 0x22, 0x8a, 0x05, 0x0a, 0x14, 0x43, 0x6f, 0x75, 0x72, 0x69, 0x65,
+	0x72, 0x52, 0x61, 0x74, 0x69, 0x6e, 0x67, 0x53, 0x75, 0x6d, 0x6d, 0x61, 0x72, 0x79, 0x12, 0x1d,
+	0x0a, 0x0a, 0x63, 0x6f, 0x75, 0x72, 0x69, 0x65, 0x72, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x09, 0x63, 0x6f, 0x75, 0x72, 0x69, 0x65, 0x72, 0x49, 0x64, 0x12, 0x23, 0x0a,
+	0x0d, 0x72, 0x61, 0x74, 0x69, 0x6e, 0x67, 0x73, 0x5f, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x18, 0x02,
+	0x20, 0x01, 0x28, 0x03, 0x52, 0x0c, 0x72, 0x61, 0x74, 0x69, 0x6e, 0x67, 0x73, 0x43, 0x6f, 0x75,
+	0x6e, 0x74, 0x12, 0x23, 0x0a, 0x0d, 0x61, 0x76, 0x65, 0x72, 0x61, 0x67, 0x65, 0x5f, 0x73, 0x63,
+	0x6f, 0x72, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x01, 0x52, 0x0c, 0x61, 0x76, 0x65, 0x72, 0x61,
+	0x67, 0x65, 0x53, 0x63, 0x6f, 0x72, 0x65, 0x12, 0x2a, 0x0a, 0x11, 0x6c, 0x6f, 0x77, 0x5f, 0x72,
+	0x61, 0x74, 0x69, 0x6e, 0x67, 0x73, 0x5f, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x18, 0x04, 0x20, 0x01,
+	0x28, 0x03, 0x52, 0x0f, 0x6c, 0x6f, 0x77, 0x52, 0x61, 0x74, 0x69, 0x6e, 0x67, 0x73, 0x43, 0x6f,
+	0x75, 0x6e, 0x74, 0x12, 0x22, 0x0a, 0x0d, 0x73, 0x63, 0x6f, 0x72, 0x65, 0x5f, 0x31, 0x5f, 0x63,
+	0x6f, 0x75, 0x6e, 0x74, 0x18, 0x05, 0x20, 0x01, 0x28, 0x03, 0x52, 0x0b, 0x73, 0x63, 0x6f, 0x72,
+	0x65, 0x31, 0x43, 0x6f, 0x75, 0x6e, 0x74, 0x12, 0x22, 0x0a, 0x0d, 0x73, 0x63, 0x6f, 0x72, 0x65,
+	0x5f, 0x32, 0x5f, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x18, 0x06, 0x20, 0x01, 0x28, 0x03, 0x52, 0x0b,
+	0x73, 0x63, 0x6f, 0x72, 0x65, 0x32, 0x43, 0x6f, 0x75, 0x6e, 0x74, 0x12, 0x22, 0x0a, 0x0d, 0x73,
+	0x63, 0x6f, 0x72, 0x65, 0x5f, 0x33, 0x5f, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x18, 0x07, 0x20, 0x01,
+	0x28, 0x03, 0x52, 0x0b, 0x73, 0x63, 0x6f, 0x72, 0x65, 0x33, 0x43, 0x6f, 0x75, 0x6e, 0x74, 0x12,
+	0x22, 0x0a, 0x0d, 0x73, 0x63, 0x6f, 0x72, 0x65, 0x5f, 0x34, 0x5f, 0x63, 0x6f, 0x75, 0x6e, 0x74,
+	0x18, 0x08, 0x20, 0x01, 0x28, 0x03, 0x52, 0x0b, 0x73, 0x63, 0x6f, 0x72, 0x65, 0x34, 0x43, 0x6f,
+	0x75, 0x6e, 0x74, 0x12, 0x22, 0x0a, 0x0d, 0x73, 0x63, 0x6f, 0x72, 0x65, 0x5f, 0x35, 0x5f, 0x63,
+	0x6f, 0x75, 0x6e, 0x74, 0x18, 0x09, 0x20, 0x01, 0x28, 0x03, 0x52, 0x0b, 0x73, 0x63, 0x6f, 0x72,
+	0x65, 0x35, 0x43, 0x6f, 0x75, 0x6e, 0x74, 0x12, 0x28, 0x0a, 0x10, 0x6c, 0x61, 0x73, 0x74, 0x5f,
+	0x72, 0x61, 0x74, 0x69, 0x6e, 0x67, 0x5f, 0x75, 0x6e, 0x69, 0x78, 0x18, 0x0a, 0x20, 0x01, 0x28,
+	0x03, 0x52, 0x0e, 0x6c, 0x61, 0x73, 0x74, 0x52, 0x61, 0x74, 0x69, 0x6e, 0x67, 0x55, 0x6e, 0x69,
+	0x78, 0x12, 0x22, 0x0a, 0x0d, 0x6f, 0x6e, 0x5f, 0x74, 0x69, 0x6d, 0x65, 0x5f, 0x63, 0x6f, 0x75,
+	0x6e, 0x74, 0x18, 0x0b, 0x20, 0x01, 0x28, 0x03, 0x52, 0x0b, 0x6f, 0x6e, 0x54, 0x69, 0x6d, 0x65,
+	0x43, 0x6f, 0x75, 0x6e, 0x74, 0x12, 0x21, 0x0a, 0x0c, 0x70, 0x6f, 0x6c, 0x69, 0x74, 0x65, 0x5f,
+	0x63, 0x6f, 0x75, 0x6e, 0x74, 0x18, 0x0c, 0x20, 0x01, 0x28, 0x03, 0x52, 0x0b, 0x70, 0x6f, 0x6c,
+	0x69, 0x74, 0x65, 0x43, 0x6f, 0x75, 0x6e, 0x74, 0x12, 0x23, 0x0a, 0x0d, 0x63, 0x61, 0x72, 0x65,
+	0x66, 0x75, 0x6c, 0x5f, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x18, 0x0d, 0x20, 0x01, 0x28, 0x03, 0x52,
+	0x0c, 0x63, 0x61, 0x72, 0x65, 0x66, 0x75, 0x6c, 0x43, 0x6f, 0x75, 0x6e, 0x74, 0x12, 0x23, 0x0a,
+	0x0d, 0x64, 0x65, 0x6c, 0x61, 0x79, 0x65, 0x64, 0x5f, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x18, 0x0e,
+	0x20, 0x01, 0x28, 0x03, 0x52, 0x0c, 0x64, 0x65, 0x6c, 0x61, 0x79, 0x65, 0x64, 0x43, 0x6f, 0x75,
+	0x6e, 0x74, 0x12, 0x1d, 0x0a, 0x0a, 0x72, 0x75, 0x64, 0x65, 0x5f, 0x63, 0x6f, 0x75, 0x6e, 0x74,
+	0x18, 0x0f, 0x20, 0x01, 0x28, 0x03, 0x52, 0x09, 0x72, 0x75, 0x64, 0x65, 0x43, 0x6f, 0x75, 0x6e,
+	0x74, 0x12, 0x23, 0x0a, 0x0d, 0x64, 0x61, 0x6d, 0x61, 0x67, 0x65, 0x64, 0x5f, 0x63, 0x6f, 0x75,
+	0x6e, 0x74, 0x18, 0x10, 0x20, 0x01, 0x28, 0x03, 0x52, 0x0c, 0x64, 0x61, 0x6d, 0x61, 0x67, 0x65,
+	0x64, 0x43, 0x6f, 0x75, 0x6e, 0x74, 0x12, 0x2a, 0x0a, 0x11, 0x6f, 0x74, 0x68, 0x65, 0x72, 0x5f,
+	0x69, 0x73, 0x73, 0x75, 0x65, 0x5f, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x18, 0x11, 0x20, 0x01, 0x28,
+	0x03, 0x52, 0x0f, 0x6f, 0x74, 0x68, 0x65, 0x72, 0x49, 0x73, 0x73, 0x75, 0x65, 0x43, 0x6f, 0x75,
+	0x6e, 0x74, 0x22, 0x59, 0x0a, 0x1f, 0x47, 0x65, 0x74, 0x43, 0x6f, 0x75, 0x72, 0x69, 0x65, 0x72,
+	0x52, 0x61, 0x74, 0x69, 0x6e, 0x67, 0x53, 0x75, 0x6d, 0x6d, 0x61, 0x72, 0x79, 0x52, 0x65, 0x73,
+	0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x36, 0x0a, 0x07, 0x73, 0x75, 0x6d, 0x6d, 0x61, 0x72, 0x79,
+	0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1c, 0x2e, 0x6f, 0x6d, 0x73, 0x2e, 0x76, 0x31, 0x2e,
+	0x43, 0x6f, 0x75, 0x72, 0x69, 0x65, 0x72, 0x52, 0x61, 0x74, 0x69, 0x6e, 0x67, 0x53, 0x75, 0x6d,
+	0x6d, 0x61, 0x72, 0x79, 0x52, 0x07, 0x73, 0x75, 0x6d, 0x6d, 0x61, 0x72, 0x79, 0x22, 0x54, 0x0a,
+	0x19, 0x44, 0x65, 0x6c, 0x65, 0x74, 0x65, 0x43, 0x75, 0x73, 0x74, 0x6f, 0x6d, 0x65, 0x72, 0x44,
+	0x61, 0x74, 0x61, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x1f, 0x0a, 0x0b, 0x63, 0x75,
+	0x73, 0x74, 0x6f, 0x6d, 0x65, 0x72, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x0a, 0x63, 0x75, 0x73, 0x74, 0x6f, 0x6d, 0x65, 0x72, 0x49, 0x64, 0x12, 0x16, 0x0a, 0x06, 0x72,
+	0x65, 0x61, 0x73, 0x6f, 0x6e, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x72, 0x65, 0x61,
+	0x73, 0x6f, 0x6e, 0x22, 0xf9, 0x01, 0x0a, 0x1a, 0x44, 0x65, 0x6c, 0x65, 0x74, 0x65, 0x43, 0x75,
+	0x73, 0x74, 0x6f, 0x6d, 0x65, 0x72, 0x44, 0x61, 0x74, 0x61, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e,
+	0x73, 0x65, 0x12, 0x1c, 0x0a, 0x09, 0x70, 0x73, 0x65, 0x75, 0x64, 0x6f, 0x6e, 0x79, 0x6d, 0x18,
+	0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x70, 0x73, 0x65, 0x75, 0x64, 0x6f, 0x6e, 0x79, 0x6d,
+	0x12, 0x1b, 0x0a, 0x09, 0x6f, 0x72, 0x64, 0x65, 0x72, 0x5f, 0x69, 0x64, 0x73, 0x18, 0x02, 0x20,
+	0x03, 0x28, 0x09, 0x52, 0x08, 0x6f, 0x72, 0x64, 0x65, 0x72, 0x49, 0x64, 0x73, 0x12, 0x38, 0x0a,
+	0x18, 0x74, 0x69, 0x6d, 0x65, 0x6c, 0x69, 0x6e, 0x65, 0x5f, 0x65, 0x76, 0x65, 0x6e, 0x74, 0x73,
+	0x5f, 0x73, 0x63, 0x72, 0x75, 0x62, 0x62, 0x65, 0x64, 0x18, 0x03, 0x20, 0x01, 0x28, 0x05, 0x52,
+	0x16, 0x74, 0x69, 0x6d, 0x65, 0x6c, 0x69, 0x6e, 0x65, 0x45, 0x76, 0x65, 0x6e, 0x74, 0x73, 0x53,
+	0x63, 0x72, 0x75, 0x62, 0x62, 0x65, 0x64, 0x12, 0x40, 0x0a, 0x1c, 0x69, 0x64, 0x65, 0x6d, 0x70,
+	0x6f, 0x74, 0x65, 0x6e, 0x63, 0x79, 0x5f, 0x72, 0x65, 0x63, 0x6f, 0x72, 0x64, 0x73, 0x5f, 0x73,
+	0x63, 0x72, 0x75, 0x62, 0x62, 0x65, 0x64, 0x18, 0x04, 0x20, 0x01, 0x28, 0x05, 0x52, 0x1a, 0x69,
+	0x64, 0x65, 0x6d, 0x70, 0x6f, 0x74, 0x65, 0x6e, 0x63, 0x79, 0x52, 0x65, 0x63, 0x6f, 0x72, 0x64,
+	0x73, 0x53, 0x63, 0x72, 0x75, 0x62, 0x62, 0x65, 0x64, 0x12, 0x24, 0x0a, 0x0e, 0x65, 0x72, 0x61,
+	0x73, 0x65, 0x64, 0x5f, 0x61, 0x74, 0x5f, 0x75, 0x6e, 0x69, 0x78, 0x18, 0x05, 0x20, 0x01, 0x28,
+	0x03, 0x52, 0x0c, 0x65, 0x72, 0x61, 0x73, 0x65, 0x64, 0x41, 0x74, 0x55, 0x6e, 0x69, 0x78, 0x22,
+	0x46, 0x0a, 0x14, 0x47, 0x65, 0x74, 0x51, 0x75, 0x6f, 0x74, 0x61, 0x55, 0x73, 0x61, 0x67, 0x65,
+	0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x1c, 0x0a, 0x09, 0x70, 0x72, 0x69, 0x6e, 0x63,
+	0x69, 0x70, 0x61, 0x6c, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x70, 0x72, 0x69, 0x6e,
+	0x63, 0x69, 0x70, 0x61, 0x6c, 0x12, 0x10, 0x0a, 0x03, 0x64, 0x61, 0x79, 0x18, 0x02, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x03, 0x64, 0x61, 0x79, 0x22, 0x6e, 0x0a, 0x10, 0x51, 0x75, 0x6f, 0x74, 0x61,
+	0x41, 0x6d, 0x6f, 0x75, 0x6e, 0x74, 0x55, 0x73, 0x61, 0x67, 0x65, 0x12, 0x1a, 0x0a, 0x08, 0x63,
+	0x75, 0x72, 0x72, 0x65, 0x6e, 0x63, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x63,
+	0x75, 0x72, 0x72, 0x65, 0x6e, 0x63, 0x79, 0x12, 0x1d, 0x0a, 0x0a, 0x75, 0x73, 0x65, 0x64, 0x5f,
+	0x6d, 0x69, 0x6e, 0x6f, 0x72, 0x18, 0x02, 0x20, 0x01, 0x28, 0x03, 0x52, 0x09, 0x75, 0x73, 0x65,
+	0x64, 0x4d, 0x69, 0x6e, 0x6f, 0x72, 0x12, 0x1f, 0x0a, 0x0b, 0x6c, 0x69, 0x6d, 0x69, 0x74, 0x5f,
+	0x6d, 0x69, 0x6e, 0x6f, 0x72, 0x18, 0x03, 0x20, 0x01, 0x28, 0x03, 0x52, 0x0a, 0x6c, 0x69, 0x6d,
+	0x69, 0x74, 0x4d, 0x69, 0x6e, 0x6f, 0x72, 0x22, 0x90, 0x02, 0x0a, 0x15, 0x47, 0x65, 0x74, 0x51,
+	0x75, 0x6f, 0x74, 0x61, 0x55, 0x73, 0x61, 0x67, 0x65, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73,
+	0x65, 0x12, 0x1c, 0x0a, 0x09, 0x70, 0x72, 0x69, 0x6e, 0x63, 0x69, 0x70, 0x61, 0x6c, 0x18, 0x01,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x70, 0x72, 0x69, 0x6e, 0x63, 0x69, 0x70, 0x61, 0x6c, 0x12,
+	0x10, 0x0a, 0x03, 0x64, 0x61, 0x79, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x64, 0x61,
+	0x79, 0x12, 0x29, 0x0a, 0x10, 0x71, 0x75, 0x6f, 0x74, 0x61, 0x5f, 0x63, 0x6f, 0x6e, 0x66, 0x69,
+	0x67, 0x75, 0x72, 0x65, 0x64, 0x18, 0x03, 0x20, 0x01, 0x28, 0x08, 0x52, 0x0f, 0x71, 0x75, 0x6f,
+	0x74, 0x61, 0x43, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x75, 0x72, 0x65, 0x64, 0x12, 0x1f, 0x0a, 0x0b,
+	0x6f, 0x72, 0x64, 0x65, 0x72, 0x73, 0x5f, 0x75, 0x73, 0x65, 0x64, 0x18, 0x04, 0x20, 0x01, 0x28,
+	0x03, 0x52, 0x0a, 0x6f, 0x72, 0x64, 0x65, 0x72, 0x73, 0x55, 0x73, 0x65, 0x64, 0x12, 0x21, 0x0a,
+	0x0c, 0x6f, 0x72, 0x64, 0x65, 0x72, 0x73, 0x5f, 0x6c, 0x69, 0x6d, 0x69, 0x74, 0x18, 0x05, 0x20,
+	0x01, 0x28, 0x03, 0x52, 0x0b, 0x6f, 0x72, 0x64, 0x65, 0x72, 0x73, 0x4c, 0x69, 0x6d, 0x69, 0x74,
+	0x12, 0x32, 0x0a, 0x07, 0x61, 0x6d, 0x6f, 0x75, 0x6e, 0x74, 0x73, 0x18, 0x06, 0x20, 0x03, 0x28,
+	0x0b, 0x32, 0x18, 0x2e, 0x6f, 0x6d, 0x73, 0x2e, 0x76, 0x31, 0x2e, 0x51, 0x75, 0x6f, 0x74, 0x61,
+	0x41, 0x6d, 0x6f, 0x75, 0x6e, 0x74, 0x55, 0x73, 0x61, 0x67, 0x65, 0x52, 0x07, 0x61, 0x6d, 0x6f,
+	0x75, 0x6e, 0x74, 0x73, 0x12, 0x24, 0x0a, 0x0e, 0x72, 0x65, 0x73, 0x65, 0x74, 0x73, 0x5f, 0x61,
+	0x74, 0x5f, 0x75, 0x6e, 0x69, 0x78, 0x18, 0x07, 0x20, 0x01, 0x28, 0x03, 0x52, 0x0c, 0x72, 0x65,
+	0x73, 0x65, 0x74, 0x73, 0x41, 0x74, 0x55, 0x6e, 0x69, 0x78, 0x22, 0x6c, 0x0a, 0x15, 0x47, 0x65,
+	0x74, 0x45, 0x76, 0x65, 0x6e, 0x74, 0x73, 0x53, 0x69, 0x6e, 0x63, 0x65, 0x52, 0x65, 0x71, 0x75,
+	0x65, 0x73, 0x74, 0x12, 0x16, 0x0a, 0x06, 0x63, 0x75, 0x72, 0x73, 0x6f, 0x72, 0x18, 0x01, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x06, 0x63, 0x75, 0x72, 0x73, 0x6f, 0x72, 0x12, 0x14, 0x0a, 0x05, 0x6c,
+	0x69, 0x6d, 0x69, 0x74, 0x18, 0x02, 0x20, 0x01, 0x28, 0x05, 0x52, 0x05, 0x6c, 0x69, 0x6d, 0x69,
+	0x74, 0x12, 0x25, 0x0a, 0x0e, 0x61, 0x67, 0x67, 0x72, 0x65, 0x67, 0x61, 0x74, 0x65, 0x5f, 0x74,
+	0x79, 0x70, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0d, 0x61, 0x67, 0x67, 0x72, 0x65,
+	0x67, 0x61, 0x74, 0x65, 0x54, 0x79, 0x70, 0x65, 0x22, 0xcf, 0x01, 0x0a, 0x09, 0x46, 0x65, 0x65,
+	0x64, 0x45, 0x76, 0x65, 0x6e, 0x74, 0x12, 0x0e, 0x0a, 0x02, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x02, 0x69, 0x64, 0x12, 0x25, 0x0a, 0x0e, 0x61, 0x67, 0x67, 0x72, 0x65, 0x67,
+	0x61, 0x74, 0x65, 0x5f, 0x74, 0x79, 0x70, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0d,
+	0x61, 0x67, 0x67, 0x72, 0x65, 0x67, 0x61, 0x74, 0x65, 0x54, 0x79, 0x70, 0x65, 0x12, 0x21, 0x0a,
+	0x0c, 0x61, 0x67, 0x67, 0x72, 0x65, 0x67, 0x61, 0x74, 0x65, 0x5f, 0x69, 0x64, 0x18, 0x03, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x0b, 0x61, 0x67, 0x67, 0x72, 0x65, 0x67, 0x61, 0x74, 0x65, 0x49, 0x64,
+	0x12, 0x1d, 0x0a, 0x0a, 0x65, 0x76, 0x65, 0x6e, 0x74, 0x5f, 0x74, 0x79, 0x70, 0x65, 0x18, 0x04,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x65, 0x76, 0x65, 0x6e, 0x74, 0x54, 0x79, 0x70, 0x65, 0x12,
+	0x18, 0x0a, 0x07, 0x70, 0x61, 0x79, 0x6c, 0x6f, 0x61, 0x64, 0x18, 0x05, 0x20, 0x01, 0x28, 0x0c,
+	0x52, 0x07, 0x70, 0x61, 0x79, 0x6c, 0x6f, 0x61, 0x64, 0x12, 0x2f, 0x0a, 0x14, 0x63, 0x72, 0x65,
+	0x61, 0x74, 0x65, 0x64, 0x5f, 0x61, 0x74, 0x5f, 0x75, 0x6e, 0x69, 0x78, 0x5f, 0x6e, 0x61, 0x6e,
+	0x6f, 0x18, 0x06, 0x20, 0x01, 0x28, 0x03, 0x52, 0x11, 0x63, 0x72, 0x65, 0x61, 0x74, 0x65, 0x64,
+	0x41, 0x74, 0x55, 0x6e, 0x69, 0x78, 0x4e, 0x61, 0x6e, 0x6f, 0x22, 0x64, 0x0a, 0x16, 0x47, 0x65,
+	0x74, 0x45, 0x76, 0x65, 0x6e, 0x74, 0x73, 0x53, 0x69, 0x6e, 0x63, 0x65, 0x52, 0x65, 0x73, 0x70,
+	0x6f, 0x6e, 0x73, 0x65, 0x12, 0x29, 0x0a, 0x06, 0x65, 0x76, 0x65, 0x6e, 0x74, 0x73, 0x18, 0x01,
+	0x20, 0x03, 0x28, 0x0b, 0x32, 0x11, 0x2e, 0x6f, 0x6d, 0x73, 0x2e, 0x76, 0x31, 0x2e, 0x46, 0x65,
+	0x65, 0x64, 0x45, 0x76, 0x65, 0x6e, 0x74, 0x52, 0x06, 0x65, 0x76, 0x65, 0x6e, 0x74, 0x73, 0x12,
+	0x1f, 0x0a, 0x0b, 0x6e, 0x65, 0x78, 0x74, 0x5f, 0x63, 0x75, 0x72, 0x73, 0x6f, 0x72, 0x18, 0x02,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x0a, 0x6e, 0x65, 0x78, 0x74, 0x43, 0x75, 0x72, 0x73, 0x6f, 0x72,
+	0x22, 0x15, 0x0a, 0x13, 0x47, 0x65, 0x74, 0x4c, 0x6f, 0x67, 0x4c, 0x65, 0x76, 0x65, 0x6c, 0x73,
+	0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x22, 0x48, 0x0a, 0x12, 0x53, 0x65, 0x74, 0x4c, 0x6f,
+	0x67, 0x4c, 0x65, 0x76, 0x65, 0x6c, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x1c, 0x0a,
+	0x09, 0x63, 0x6f, 0x6d, 0x70, 0x6f, 0x6e, 0x65, 0x6e, 0x74, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x09, 0x63, 0x6f, 0x6d, 0x70, 0x6f, 0x6e, 0x65, 0x6e, 0x74, 0x12, 0x14, 0x0a, 0x05, 0x6c,
+	0x65, 0x76, 0x65, 0x6c, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x6c, 0x65, 0x76, 0x65,
+	0x6c, 0x22, 0xa5, 0x01, 0x0a, 0x09, 0x4c, 0x6f, 0x67, 0x4c, 0x65, 0x76, 0x65, 0x6c, 0x73, 0x12,
+	0x16, 0x0a, 0x06, 0x67, 0x6c, 0x6f, 0x62, 0x61, 0x6c, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x06, 0x67, 0x6c, 0x6f, 0x62, 0x61, 0x6c, 0x12, 0x41, 0x0a, 0x0a, 0x63, 0x6f, 0x6d, 0x70, 0x6f,
+	0x6e, 0x65, 0x6e, 0x74, 0x73, 0x18, 0x02, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x21, 0x2e, 0x6f, 0x6d,
+	0x73, 0x2e, 0x76, 0x31, 0x2e, 0x4c, 0x6f, 0x67, 0x4c, 0x65, 0x76, 0x65, 0x6c, 0x73, 0x2e, 0x43,
+	0x6f, 0x6d, 0x70, 0x6f, 0x6e, 0x65, 0x6e, 0x74, 0x73, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x52, 0x0a,
+	0x63, 0x6f, 0x6d, 0x70, 0x6f, 0x6e, 0x65, 0x6e, 0x74, 0x73, 0x1a, 0x3d, 0x0a, 0x0f, 0x43, 0x6f,
+	0x6d, 0x70, 0x6f, 0x6e, 0x65, 0x6e, 0x74, 0x73, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x12, 0x10, 0x0a,
+	0x03, 0x6b, 0x65, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x6b, 0x65, 0x79, 0x12,
+	0x14, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05,
+	0x76, 0x61, 0x6c, 0x75, 0x65, 0x3a, 0x02, 0x38, 0x01, 0x22, 0x4c, 0x0a, 0x17, 0x52, 0x65, 0x63,
+	0x61, 0x6c, 0x63, 0x75, 0x6c, 0x61, 0x74, 0x65, 0x4f, 0x72, 0x64, 0x65, 0x72, 0x52, 0x65, 0x71,
+	0x75, 0x65, 0x73, 0x74, 0x12, 0x19, 0x0a, 0x08, 0x6f, 0x72, 0x64, 0x65, 0x72, 0x5f, 0x69, 0x64,
+	0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x6f, 0x72, 0x64, 0x65, 0x72, 0x49, 0x64, 0x12,
+	0x16, 0x0a, 0x06, 0x72, 0x65, 0x61, 0x73, 0x6f, 0x6e, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x06, 0x72, 0x65, 0x61, 0x73, 0x6f, 0x6e, 0x22, 0xbc, 0x01, 0x0a, 0x16, 0x46, 0x6f, 0x72, 0x63,
+	0x65, 0x54, 0x72, 0x61, 0x6e, 0x73, 0x69, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x65, 0x71, 0x75, 0x65,
+	0x73, 0x74, 0x12, 0x3b, 0x0a, 0x08, 0x6f, 0x72, 0x64, 0x65, 0x72, 0x5f, 0x69, 0x64, 0x18, 0x01,
+	0x20, 0x01, 0x28, 0x09, 0x42, 0x20, 0xba, 0x48, 0x1d, 0xc8, 0x01, 0x01, 0x72, 0x18, 0x18, 0x80,
+	0x01, 0x32, 0x13, 0x5e, 0x5b, 0x41, 0x2d, 0x5a, 0x61, 0x2d, 0x7a, 0x30, 0x2d, 0x39, 0x2e, 0x5f,
+	0x3a, 0x40, 0x2d, 0x5d, 0x2a, 0x24, 0x52, 0x07, 0x6f, 0x72, 0x64, 0x65, 0x72, 0x49, 0x64, 0x12,
+	0x45, 0x0a, 0x0d, 0x74, 0x61, 0x72, 0x67, 0x65, 0x74, 0x5f, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73,
+	0x18, 0x02, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x13, 0x2e, 0x6f, 0x6d, 0x73, 0x2e, 0x76, 0x31, 0x2e,
+	0x4f, 0x72, 0x64, 0x65, 0x72, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x42, 0x0b, 0xba, 0x48, 0x08,
+	0xc8, 0x01, 0x01, 0x82, 0x01, 0x02, 0x10, 0x01, 0x52, 0x0c, 0x74, 0x61, 0x72, 0x67, 0x65, 0x74,
+	0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x12, 0x1e, 0x0a, 0x06, 0x72, 0x65, 0x61, 0x73, 0x6f, 0x6e,
+	0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x42, 0x06, 0xba, 0x48, 0x03, 0xc8, 0x01, 0x01, 0x52, 0x06,
+	0x72, 0x65, 0x61, 0x73, 0x6f, 0x6e, 0x22, 0x7c, 0x0a, 0x17, 0x46, 0x6f, 0x72, 0x63, 0x65, 0x54,
+	0x72, 0x61, 0x6e, 0x73, 0x69, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73,
+	0x65, 0x12, 0x23, 0x0a, 0x05, 0x6f, 0x72, 0x64, 0x65, 0x72, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b,
+	0x32, 0x0d, 0x2e, 0x6f, 0x6d, 0x73, 0x2e, 0x76, 0x31, 0x2e, 0x4f, 0x72, 0x64, 0x65, 0x72, 0x52,
+	0x05, 0x6f, 0x72, 0x64, 0x65, 0x72, 0x12, 0x3c, 0x0a, 0x0f, 0x70, 0x72, 0x65, 0x76, 0x69, 0x6f,
+	0x75, 0x73, 0x5f, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0e, 0x32,
+	0x13, 0x2e, 0x6f, 0x6d, 0x73, 0x2e, 0x76, 0x31, 0x2e, 0x4f, 0x72, 0x64, 0x65, 0x72, 0x53, 0x74,
+	0x61, 0x74, 0x75, 0x73, 0x52, 0x0e, 0x70, 0x72, 0x65, 0x76, 0x69, 0x6f, 0x75, 0x73, 0x53, 0x74,
+	0x61, 0x74, 0x75, 0x73, 0x22, 0x94, 0x01, 0x0a, 0x0f, 0x49, 0x74, 0x65, 0x6d, 0x50, 0x72, 0x69,
+	0x63, 0x65, 0x43, 0x68, 0x61, 0x6e, 0x67, 0x65, 0x12, 0x17, 0x0a, 0x07, 0x69, 0x74, 0x65, 0x6d,
+	0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x69, 0x74, 0x65, 0x6d, 0x49,
+	0x64, 0x12, 0x10, 0x0a, 0x03, 0x73, 0x6b, 0x75, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03,
+	0x73, 0x6b, 0x75, 0x12, 0x2a, 0x0a, 0x09, 0x6f, 0x6c, 0x64, 0x5f, 0x70, 0x72, 0x69, 0x63, 0x65,
+	0x18, 0x03, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x0d, 0x2e, 0x6f, 0x6d, 0x73, 0x2e, 0x76, 0x31, 0x2e,
+	0x4d, 0x6f, 0x6e, 0x65, 0x79, 0x52, 0x08, 0x6f, 0x6c, 0x64, 0x50, 0x72, 0x69, 0x63, 0x65, 0x12,
+	0x2a, 0x0a, 0x09, 0x6e, 0x65, 0x77, 0x5f, 0x70, 0x72, 0x69, 0x63, 0x65, 0x18, 0x04, 0x20, 0x01,
+	0x28, 0x0b, 0x32, 0x0d, 0x2e, 0x6f, 0x6d, 0x73, 0x2e, 0x76, 0x31, 0x2e, 0x4d, 0x6f, 0x6e, 0x65,
+	0x79, 0x52, 0x08, 0x6e, 0x65, 0x77, 0x50, 0x72, 0x69, 0x63, 0x65, 0x22, 0xc9, 0x01, 0x0a, 0x18,
+	0x52, 0x65, 0x63, 0x61, 0x6c, 0x63, 0x75, 0x6c, 0x61, 0x74, 0x65, 0x4f, 0x72, 0x64, 0x65, 0x72,
+	0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x23, 0x0a, 0x05, 0x6f, 0x72, 0x64, 0x65,
+	0x72, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x0d, 0x2e, 0x6f, 0x6d, 0x73, 0x2e, 0x76, 0x31,
+	0x2e, 0x4f, 0x72, 0x64, 0x65, 0x72, 0x52, 0x05, 0x6f, 0x72, 0x64, 0x65, 0x72, 0x12, 0x34, 0x0a,
+	0x0e, 0x70, 0x72, 0x65, 0x76, 0x69, 0x6f, 0x75, 0x73, 0x5f, 0x74, 0x6f, 0x74, 0x61, 0x6c, 0x18,
+	0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x0d, 0x2e, 0x6f, 0x6d, 0x73, 0x2e, 0x76, 0x31, 0x2e, 0x4d,
+	0x6f, 0x6e, 0x65, 0x79, 0x52, 0x0d, 0x70, 0x72, 0x65, 0x76, 0x69, 0x6f, 0x75, 0x73, 0x54, 0x6f,
+	0x74, 0x61, 0x6c, 0x12, 0x1f, 0x0a, 0x0b, 0x64, 0x65, 0x6c, 0x74, 0x61, 0x5f, 0x6d, 0x69, 0x6e,
+	0x6f, 0x72, 0x18, 0x03, 0x20, 0x01, 0x28, 0x03, 0x52, 0x0a, 0x64, 0x65, 0x6c, 0x74, 0x61, 0x4d,
+	0x69, 0x6e, 0x6f, 0x72, 0x12, 0x31, 0x0a, 0x07, 0x63, 0x68, 0x61, 0x6e, 0x67, 0x65, 0x73, 0x18,
+	0x04, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x17, 0x2e, 0x6f, 0x6d, 0x73, 0x2e, 0x76, 0x31, 0x2e, 0x49,
+	0x74, 0x65, 0x6d, 0x50, 0x72, 0x69, 0x63, 0x65, 0x43, 0x68, 0x61, 0x6e, 0x67, 0x65, 0x52, 0x07,
+	0x63, 0x68, 0x61, 0x6e, 0x67, 0x65, 0x73, 0x2a, 0xc2, 0x02, 0x0a, 0x0b, 0x4f, 0x72, 0x64, 0x65,
+	0x72, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x12, 0x1c, 0x0a, 0x18, 0x4f, 0x52, 0x44, 0x45, 0x52,
+	0x5f, 0x53, 0x54, 0x41, 0x54, 0x55, 0x53, 0x5f, 0x55, 0x4e, 0x53, 0x50, 0x45, 0x43, 0x49, 0x46,
+	0x49, 0x45, 0x44, 0x10, 0x00, 0x12, 0x18, 0x0a, 0x14, 0x4f, 0x52, 0x44, 0x45, 0x52, 0x5f, 0x53,
+	0x54, 0x41, 0x54, 0x55, 0x53, 0x5f, 0x50, 0x45, 0x4e, 0x44, 0x49, 0x4e, 0x47, 0x10, 0x01, 0x12,
+	0x19, 0x0a, 0x15, 0x4f, 0x52, 0x44, 0x45, 0x52, 0x5f, 0x53, 0x54, 0x41, 0x54, 0x55, 0x53, 0x5f,
+	0x52, 0x45, 0x53, 0x45, 0x52, 0x56, 0x45, 0x44, 0x10, 0x02, 0x12, 0x15, 0x0a, 0x11, 0x4f, 0x52,
+	0x44, 0x45, 0x52, 0x5f, 0x53, 0x54, 0x41, 0x54, 0x55, 0x53, 0x5f, 0x50, 0x41, 0x49, 0x44, 0x10,
+	0x03, 0x12, 0x1a, 0x0a, 0x16, 0x4f, 0x52, 0x44, 0x45, 0x52, 0x5f, 0x53, 0x54, 0x41, 0x54, 0x55,
+	0x53, 0x5f, 0x43, 0x4f, 0x4e, 0x46, 0x49, 0x52, 0x4d, 0x45, 0x44, 0x10, 0x04, 0x12, 0x19, 0x0a,
+	0x15, 0x4f, 0x52, 0x44, 0x45, 0x52, 0x5f, 0x53, 0x54, 0x41, 0x54, 0x55, 0x53, 0x5f, 0x43, 0x41,
+	0x4e, 0x43, 0x45, 0x4c, 0x45, 0x44, 0x10, 0x05, 0x12, 0x19, 0x0a, 0x15, 0x4f, 0x52, 0x44, 0x45,
+	0x52, 0x5f, 0x53, 0x54, 0x41, 0x54, 0x55, 0x53, 0x5f, 0x52, 0x45, 0x46, 0x55, 0x4e, 0x44, 0x45,
+	0x44, 0x10, 0x06, 0x12, 0x18, 0x0a, 0x14, 0x4f, 0x52, 0x44, 0x45, 0x52, 0x5f, 0x53, 0x54, 0x41,
+	0x54, 0x55, 0x53, 0x5f, 0x4f, 0x4e, 0x5f, 0x48, 0x4f, 0x4c, 0x44, 0x10, 0x07, 0x12, 0x1c, 0x0a,
+	0x18, 0x4f, 0x52, 0x44, 0x45, 0x52, 0x5f, 0x53, 0x54, 0x41, 0x54, 0x55, 0x53, 0x5f, 0x42, 0x41,
+	0x43, 0x4b, 0x4f, 0x52, 0x44, 0x45, 0x52, 0x45, 0x44, 0x10, 0x08, 0x12, 0x1b, 0x0a, 0x17, 0x4f,
+	0x52, 0x44, 0x45, 0x52, 0x5f, 0x53, 0x54, 0x41, 0x54, 0x55, 0x53, 0x5f, 0x41, 0x55, 0x54, 0x48,
+	0x4f, 0x52, 0x49, 0x5a, 0x45, 0x44, 0x10, 0x09, 0x12, 0x22, 0x0a, 0x1e, 0x4f, 0x52, 0x44, 0x45,
+	0x52, 0x5f, 0x53, 0x54, 0x41, 0x54, 0x55, 0x53, 0x5f, 0x41, 0x57, 0x41, 0x49, 0x54, 0x49, 0x4e,
+	0x47, 0x5f, 0x41, 0x50, 0x50, 0x52, 0x4f, 0x56, 0x41, 0x4c, 0x10, 0x0a, 0x2a, 0x68, 0x0a, 0x0e,
+	0x41, 0x64, 0x6a, 0x75, 0x73, 0x74, 0x6d, 0x65, 0x6e, 0x74, 0x54, 0x79, 0x70, 0x65, 0x12, 0x1f,
+	0x0a, 0x1b, 0x41, 0x44, 0x4a, 0x55, 0x53, 0x54, 0x4d, 0x45, 0x4e, 0x54, 0x5f, 0x54, 0x59, 0x50,
+	0x45, 0x5f, 0x55, 0x4e, 0x53, 0x50, 0x45, 0x43, 0x49, 0x46, 0x49, 0x45, 0x44, 0x10, 0x00, 0x12,
+	0x1c, 0x0a, 0x18, 0x41, 0x44, 0x4a, 0x55, 0x53, 0x54, 0x4d, 0x45, 0x4e, 0x54, 0x5f, 0x54, 0x59,
+	0x50, 0x45, 0x5f, 0x44, 0x49, 0x53, 0x43, 0x4f, 0x55, 0x4e, 0x54, 0x10, 0x01, 0x12, 0x17, 0x0a,
+	0x13, 0x41, 0x44, 0x4a, 0x55, 0x53, 0x54, 0x4d, 0x45, 0x4e, 0x54, 0x5f, 0x54, 0x59, 0x50, 0x45,
+	0x5f, 0x54, 0x41, 0x58, 0x10, 0x02, 0x2a, 0x48, 0x0a, 0x0c, 0x52, 0x6f, 0x75, 0x6e, 0x64, 0x69,
+	0x6e, 0x67, 0x4d, 0x6f, 0x64, 0x65, 0x12, 0x1d, 0x0a, 0x19, 0x52, 0x4f, 0x55, 0x4e, 0x44, 0x49,
+	0x4e, 0x47, 0x5f, 0x4d, 0x4f, 0x44, 0x45, 0x5f, 0x55, 0x4e, 0x53, 0x50, 0x45, 0x43, 0x49, 0x46,
+	0x49, 0x45, 0x44, 0x10, 0x00, 0x12, 0x19, 0x0a, 0x15, 0x52, 0x4f, 0x55, 0x4e, 0x44, 0x49, 0x4e,
+	0x47, 0x5f, 0x4d, 0x4f, 0x44, 0x45, 0x5f, 0x48, 0x41, 0x4c, 0x46, 0x5f, 0x55, 0x50, 0x10, 0x01,
+	0x2a, 0x99, 0x01, 0x0a, 0x12, 0x43, 0x6f, 0x75, 0x72, 0x69, 0x65, 0x72, 0x56, 0x65, 0x68, 0x69,
+	0x63, 0x6c, 0x65, 0x54, 0x79, 0x70, 0x65, 0x12, 0x24, 0x0a, 0x20, 0x43, 0x4f, 0x55, 0x52, 0x49,
+	0x45, 0x52, 0x5f, 0x56, 0x45, 0x48, 0x49, 0x43, 0x4c, 0x45, 0x5f, 0x54, 0x59, 0x50, 0x45, 0x5f,
+	0x55, 0x4e, 0x53, 0x50, 0x45, 0x43, 0x49, 0x46, 0x49, 0x45, 0x44, 0x10, 0x00, 0x12, 0x20, 0x0a,
+	0x1c, 0x43, 0x4f, 0x55, 0x52, 0x49, 0x45, 0x52, 0x5f, 0x56, 0x45, 0x48, 0x49, 0x43, 0x4c, 0x45,
+	0x5f, 0x54, 0x59, 0x50, 0x45, 0x5f, 0x53, 0x43, 0x4f, 0x4f, 0x54, 0x45, 0x52, 0x10, 0x01, 0x12,
+	0x1d, 0x0a, 0x19, 0x43, 0x4f, 0x55, 0x52, 0x49, 0x45, 0x52, 0x5f, 0x56, 0x45, 0x48, 0x49, 0x43,
+	0x4c, 0x45, 0x5f, 0x54, 0x59, 0x50, 0x45, 0x5f, 0x42, 0x49, 0x4b, 0x45, 0x10, 0x02, 0x12, 0x1c,
+	0x0a, 0x18, 0x43, 0x4f, 0x55, 0x52, 0x49, 0x45, 0x52, 0x5f, 0x56, 0x45, 0x48, 0x49, 0x43, 0x4c,
+	0x45, 0x5f, 0x54, 0x59, 0x50, 0x45, 0x5f, 0x43, 0x41, 0x52, 0x10, 0x03, 0x2a, 0xbe, 0x01, 0x0a,
+	0x11, 0x43, 0x6f, 0x75, 0x72, 0x69, 0x65, 0x72, 0x53, 0x6c, 0x6f, 0x74, 0x53, 0x74, 0x61, 0x74,
+	0x75, 0x73, 0x12, 0x23, 0x0a, 0x1f, 0x43, 0x4f, 0x55, 0x52, 0x49, 0x45, 0x52, 0x5f, 0x53, 0x4c,
+	0x4f, 0x54, 0x5f, 0x53, 0x54, 0x41, 0x54, 0x55, 0x53, 0x5f, 0x55, 0x4e, 0x53, 0x50, 0x45, 0x43,
+	0x49, 0x46, 0x49, 0x45, 0x44, 0x10, 0x00, 0x12, 0x1f, 0x0a, 0x1b, 0x43, 0x4f, 0x55, 0x52, 0x49,
+	0x45, 0x52, 0x5f, 0x53, 0x4c, 0x4f, 0x54, 0x5f, 0x53, 0x54, 0x41, 0x54, 0x55, 0x53, 0x5f, 0x50,
+	0x4c, 0x41, 0x4e, 0x4e, 0x45, 0x44, 0x10, 0x01, 0x12, 0x1e, 0x0a, 0x1a, 0x43, 0x4f, 0x55, 0x52,
+	0x49, 0x45, 0x52, 0x5f, 0x53, 0x4c, 0x4f, 0x54, 0x5f, 0x53, 0x54, 0x41, 0x54, 0x55, 0x53, 0x5f,
+	0x41, 0x43, 0x54, 0x49, 0x56, 0x45, 0x10, 0x02, 0x12, 0x21, 0x0a, 0x1d, 0x43, 0x4f, 0x55, 0x52,
+	0x49, 0x45, 0x52, 0x5f, 0x53, 0x4c, 0x4f, 0x54, 0x5f, 0x53, 0x54, 0x41, 0x54, 0x55, 0x53, 0x5f,
+	0x43, 0x4f, 0x4d, 0x50, 0x4c, 0x45, 0x54, 0x45, 0x44, 0x10, 0x03, 0x12, 0x20, 0x0a, 0x1c, 0x43,
+	0x4f, 0x55, 0x52, 0x49, 0x45, 0x52, 0x5f, 0x53, 0x4c, 0x4f, 0x54, 0x5f, 0x53, 0x54, 0x41, 0x54,
+	0x55, 0x53, 0x5f, 0x43, 0x41, 0x4e, 0x43, 0x45, 0x4c, 0x45, 0x44, 0x10, 0x04, 0x2a, 0xb7, 0x02,
+	0x0a, 0x10, 0x43, 0x6f, 0x75, 0x72, 0x69, 0x65, 0x72, 0x52, 0x61, 0x74, 0x69, 0x6e, 0x67, 0x54,
+	0x61, 0x67, 0x12, 0x22, 0x0a, 0x1e, 0x43, 0x4f, 0x55, 0x52, 0x49, 0x45, 0x52, 0x5f, 0x52, 0x41,
+	0x54, 0x49, 0x4e, 0x47, 0x5f, 0x54, 0x41, 0x47, 0x5f, 0x55, 0x4e, 0x53, 0x50, 0x45, 0x43, 0x49,
+	0x46, 0x49, 0x45, 0x44, 0x10, 0x00, 0x12, 0x1e, 0x0a, 0x1a, 0x43, 0x4f, 0x55, 0x52, 0x49, 0x45,
+	0x52, 0x5f, 0x52, 0x41, 0x54, 0x49, 0x4e, 0x47, 0x5f, 0x54, 0x41, 0x47, 0x5f, 0x4f, 0x4e, 0x5f,
+	0x54, 0x49, 0x4d, 0x45, 0x10, 0x01, 0x12, 0x1d, 0x0a, 0x19, 0x43, 0x4f, 0x55, 0x52, 0x49, 0x45,
+	0x52, 0x5f, 0x52, 0x41, 0x54, 0x49, 0x4e, 0x47, 0x5f, 0x54, 0x41, 0x47, 0x5f, 0x50, 0x4f, 0x4c,
+	0x49, 0x54, 0x45, 0x10, 0x02, 0x12, 0x27, 0x0a, 0x23, 0x43, 0x4f, 0x55, 0x52, 0x49, 0x45, 0x52,
+	0x5f, 0x52, 0x41, 0x54, 0x49, 0x4e, 0x47, 0x5f, 0x54, 0x41, 0x47, 0x5f, 0x43, 0x41, 0x52, 0x45,
+	0x46, 0x55, 0x4c, 0x5f, 0x48, 0x41, 0x4e, 0x44, 0x4c, 0x49, 0x4e, 0x47, 0x10, 0x03, 0x12, 0x27,
+	0x0a, 0x23, 0x43, 0x4f, 0x55, 0x52, 0x49, 0x45, 0x52, 0x5f, 0x52, 0x41, 0x54, 0x49, 0x4e, 0x47,
+	0x5f, 0x54, 0x41, 0x47, 0x5f, 0x44, 0x45, 0x4c, 0x41, 0x59, 0x45, 0x44, 0x5f, 0x44, 0x45, 0x4c,
+	0x49, 0x56, 0x45, 0x52, 0x59, 0x10, 0x04, 0x12, 0x24, 0x0a, 0x20, 0x43, 0x4f, 0x55, 0x52, 0x49,
+	0x45, 0x52, 0x5f, 0x52, 0x41, 0x54, 0x49, 0x4e, 0x47, 0x5f, 0x54, 0x41, 0x47, 0x5f, 0x52, 0x55,
+	0x44, 0x45, 0x5f, 0x42, 0x45, 0x48, 0x41, 0x56, 0x49, 0x4f, 0x52, 0x10, 0x05, 0x12, 0x24, 0x0a,
+	0x20, 0x43, 0x4f, 0x55, 0x52, 0x49, 0x45, 0x52, 0x5f, 0x52, 0x41, 0x54, 0x49, 0x4e, 0x47, 0x5f,
+	0x54, 0x41, 0x47, 0x5f, 0x44, 0x41, 0x4d, 0x41, 0x47, 0x45, 0x44, 0x5f, 0x4f, 0x52, 0x44, 0x45,
+	0x52, 0x10, 0x06, 0x12, 0x22, 0x0a, 0x1e, 0x43, 0x4f, 0x55, 0x52, 0x49, 0x45, 0x52, 0x5f, 0x52,
+	0x41, 0x54, 0x49, 0x4e, 0x47, 0x5f, 0x54, 0x41, 0x47, 0x5f, 0x4f, 0x54, 0x48, 0x45, 0x52, 0x5f,
+	0x49, 0x53, 0x53, 0x55, 0x45, 0x10, 0x07, 0x2a, 0xd6, 0x01, 0x0a, 0x15, 0x53, 0x63, 0x68, 0x65,
+	0x64, 0x75, 0x6c, 0x65, 0x64, 0x43, 0x61, 0x6e, 0x63, 0x65, 0x6c, 0x53, 0x74, 0x61, 0x74, 0x75,
+	0x73, 0x12, 0x27, 0x0a, 0x23, 0x53, 0x43, 0x48, 0x45, 0x44, 0x55, 0x4c, 0x45, 0x44, 0x5f, 0x43,
+	0x41, 0x4e, 0x43, 0x45, 0x4c, 0x5f, 0x53, 0x54, 0x41, 0x54, 0x55, 0x53, 0x5f, 0x55, 0x4e, 0x53,
+	0x50, 0x45, 0x43, 0x49, 0x46, 0x49, 0x45, 0x44, 0x10, 0x00, 0x12, 0x23, 0x0a, 0x1f, 0x53, 0x43,
+	0x48, 0x45, 0x44, 0x55, 0x4c, 0x45, 0x44, 0x5f, 0x43, 0x41, 0x4e, 0x43, 0x45, 0x4c, 0x5f, 0x53,
+	0x54, 0x41, 0x54, 0x55, 0x53, 0x5f, 0x50, 0x45, 0x4e, 0x44, 0x49, 0x4e, 0x47, 0x10, 0x01, 0x12,
+	0x24, 0x0a, 0x20, 0x53, 0x43, 0x48, 0x45, 0x44, 0x55, 0x4c, 0x45, 0x44, 0x5f, 0x43, 0x41, 0x4e,
+	0x43, 0x45, 0x4c, 0x5f, 0x53, 0x54, 0x41, 0x54, 0x55, 0x53, 0x5f, 0x45, 0x58, 0x45, 0x43, 0x55,
+	0x54, 0x45, 0x44, 0x10, 0x02, 0x12, 0x23, 0x0a, 0x1f, 0x53, 0x43, 0x48, 0x45, 0x44, 0x55, 0x4c,
+	0x45, 0x44, 0x5f, 0x43, 0x41, 0x4e, 0x43, 0x45, 0x4c, 0x5f, 0x53, 0x54, 0x41, 0x54, 0x55, 0x53,
+	0x5f, 0x53, 0x4b, 0x49, 0x50, 0x50, 0x45, 0x44, 0x10, 0x03, 0x12, 0x24, 0x0a, 0x20, 0x53, 0x43,
+	0x48, 0x45, 0x44, 0x55, 0x4c, 0x45, 0x44, 0x5f, 0x43, 0x41, 0x4e, 0x43, 0x45, 0x4c, 0x5f, 0x53,
+	0x54, 0x41, 0x54, 0x55, 0x53, 0x5f, 0x43, 0x41, 0x4e, 0x43, 0x45, 0x4c, 0x45, 0x44, 0x10, 0x04,
+	0x2a, 0x9e, 0x01, 0x0a, 0x0c, 0x52, 0x65, 0x74, 0x75, 0x72, 0x6e, 0x53, 0x74, 0x61, 0x74, 0x75,
+	0x73, 0x12, 0x1d, 0x0a, 0x19, 0x52, 0x45, 0x54, 0x55, 0x52, 0x4e, 0x5f, 0x53, 0x54, 0x41, 0x54,
+	0x55, 0x53, 0x5f, 0x55, 0x4e, 0x53, 0x50, 0x45, 0x43, 0x49, 0x46, 0x49, 0x45, 0x44, 0x10, 0x00,
+	0x12, 0x1b, 0x0a, 0x17, 0x52, 0x45, 0x54, 0x55, 0x52, 0x4e, 0x5f, 0x53, 0x54, 0x41, 0x54, 0x55,
+	0x53, 0x5f, 0x52, 0x45, 0x51, 0x55, 0x45, 0x53, 0x54, 0x45, 0x44, 0x10, 0x01, 0x12, 0x1a, 0x0a,
+	0x16, 0x52, 0x45, 0x54, 0x55, 0x52, 0x4e, 0x5f, 0x53, 0x54, 0x41, 0x54, 0x55, 0x53, 0x5f, 0x41,
+	0x50, 0x50, 0x52, 0x4f, 0x56, 0x45, 0x44, 0x10, 0x02, 0x12, 0x1a, 0x0a, 0x16, 0x52, 0x45, 0x54,
+	0x55, 0x52, 0x4e, 0x5f, 0x53, 0x54, 0x41, 0x54, 0x55, 0x53, 0x5f, 0x52, 0x45, 0x43, 0x45, 0x49,
+	0x56, 0x45, 0x44, 0x10, 0x03, 0x12, 0x1a, 0x0a, 0x16, 0x52, 0x45, 0x54, 0x55, 0x52, 0x4e, 0x5f,
+	0x53, 0x54, 0x41, 0x54, 0x55, 0x53, 0x5f, 0x52, 0x45, 0x46, 0x55, 0x4e, 0x44, 0x45, 0x44, 0x10,
+	0x04, 0x32, 0x99, 0x14, 0x0a, 0x0c, 0x4f, 0x72, 0x64, 0x65, 0x72, 0x53, 0x65, 0x72, 0x76, 0x69,
+	0x63, 0x65, 0x12, 0x5d, 0x0a, 0x0b, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x4f, 0x72, 0x64, 0x65,
+	0x72, 0x12, 0x1a, 0x2e, 0x6f, 0x6d, 0x73, 0x2e, 0x76, 0x31, 0x2e, 0x43, 0x72, 0x65, 0x61, 0x74,
+	0x65, 0x4f, 0x72, 0x64, 0x65, 0x72, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x1b, 0x2e,
+	0x6f, 0x6d, 0x73, 0x2e, 0x76, 0x31, 0x2e, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x4f, 0x72, 0x64,
+	0x65, 0x72, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x15, 0x82, 0xd3, 0xe4, 0x93,
+	0x02, 0x0f, 0x3a, 0x01, 0x2a, 0x22, 0x0a, 0x2f, 0x76, 0x31, 0x2f, 0x6f, 0x72, 0x64, 0x65, 0x72,
+	0x73, 0x12, 0x5c, 0x0a, 0x08, 0x47, 0x65, 0x74, 0x4f, 0x72, 0x64, 0x65, 0x72, 0x12, 0x17, 0x2e,
+	0x6f, 0x6d, 0x73, 0x2e, 0x76, 0x31, 0x2e, 0x47, 0x65, 0x74, 0x4f, 0x72, 0x64, 0x65, 0x72, 0x52,
+	0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x18, 0x2e, 0x6f, 0x6d, 0x73, 0x2e, 0x76, 0x31, 0x2e,
+	0x47, 0x65, 0x74, 0x4f, 0x72, 0x64, 0x65, 0x72, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65,
+	0x22, 0x1d, 0x82, 0xd3, 0xe4, 0x93, 0x02, 0x17, 0x12, 0x15, 0x2f, 0x76, 0x31, 0x2f, 0x6f, 0x72,
+	0x64, 0x65, 0x72, 0x73, 0x2f, 0x7b, 0x6f, 0x72, 0x64, 0x65, 0x72, 0x5f, 0x69, 0x64, 0x7d, 0x12,
+	0x60, 0x0a, 0x09, 0x47, 0x65, 0x74, 0x4f, 0x72, 0x64, 0x65, 0x72, 0x73, 0x12, 0x18, 0x2e, 0x6f,
+	0x6d, 0x73, 0x2e, 0x76, 0x31, 0x2e, 0x47, 0x65, 0x74, 0x4f, 0x72, 0x64, 0x65, 0x72, 0x73, 0x52,
+	0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x19, 0x2e, 0x6f, 0x6d, 0x73, 0x2e, 0x76, 0x31, 0x2e,
+	0x47, 0x65, 0x74, 0x4f, 0x72, 0x64, 0x65, 0x72, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73,
+	0x65, 0x22, 0x1e, 0x82, 0xd3, 0xe4, 0x93, 0x02, 0x18, 0x3a, 0x01, 0x2a, 0x22, 0x13, 0x2f, 0x76,
+	0x31, 0x2f, 0x6f, 0x72, 0x64, 0x65, 0x72, 0x73, 0x3a, 0x62, 0x61, 0x74, 0x63, 0x68, 0x47, 0x65,
+	0x74, 0x12, 0x8f, 0x01, 0x0a, 0x13, 0x53, 0x74, 0x72, 0x65, 0x61, 0x6d, 0x4f, 0x72, 0x64, 0x65,
+	0x72, 0x54, 0x69, 0x6d, 0x65, 0x6c, 0x69, 0x6e, 0x65, 0x12, 0x22, 0x2e, 0x6f, 0x6d, 0x73, 0x2e,
+	0x76, 0x31, 0x2e, 0x53, 0x74, 0x72, 0x65, 0x61, 0x6d, 0x4f, 0x72, 0x64, 0x65, 0x72, 0x54, 0x69,
+	0x6d, 0x65, 0x6c, 0x69, 0x6e, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x23, 0x2e,
+	0x6f, 0x6d, 0x73, 0x2e, 0x76, 0x31, 0x2e, 0x53, 0x74, 0x72, 0x65, 0x61, 0x6d, 0x4f, 0x72, 0x64,
+	0x65, 0x72, 0x54, 0x69, 0x6d, 0x65, 0x6c, 0x69, 0x6e, 0x65, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e,
+	0x73, 0x65, 0x22, 0x2d, 0x82, 0xd3, 0xe4, 0x93, 0x02, 0x27, 0x12, 0x25, 0x2f, 0x76, 0x31, 0x2f,
+	0x6f, 0x72, 0x64, 0x65, 0x72, 0x73, 0x2f, 0x7b, 0x6f, 0x72, 0x64, 0x65, 0x72, 0x5f, 0x69, 0x64,
+	0x7d, 0x2f, 0x74, 0x69, 0x6d, 0x65, 0x6c, 0x69, 0x6e, 0x65, 0x3a, 0x73, 0x74, 0x72, 0x65, 0x61,
+	0x6d, 0x30, 0x01, 0x12, 0x6a, 0x0a, 0x0a, 0x57, 0x61, 0x74, 0x63, 0x68, 0x4f, 0x72, 0x64, 0x65,
+	0x72, 0x12, 0x19, 0x2e, 0x6f, 0x6d, 0x73, 0x2e, 0x76, 0x31, 0x2e, 0x57, 0x61, 0x74, 0x63, 0x68,
+	0x4f, 0x72, 0x64, 0x65, 0x72, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x1a, 0x2e, 0x6f,
+	0x6d, 0x73, 0x2e, 0x76, 0x31, 0x2e, 0x57, 0x61, 0x74, 0x63, 0x68, 0x4f, 0x72, 0x64, 0x65, 0x72,
+	0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x23, 0x82, 0xd3, 0xe4, 0x93, 0x02, 0x1d,
+	0x12, 0x1b, 0x2f, 0x76, 0x31, 0x2f, 0x6f, 0x72, 0x64, 0x65, 0x72, 0x73, 0x2f, 0x7b, 0x6f, 0x72,
+	0x64, 0x65, 0x72, 0x5f, 0x69, 0x64, 0x7d, 0x3a, 0x77, 0x61, 0x74, 0x63, 0x68, 0x30, 0x01, 0x12,
+	0x57, 0x0a, 0x0a, 0x4c, 0x69, 0x73, 0x74, 0x4f, 0x72, 0x64, 0x65, 0x72, 0x73, 0x12, 0x19, 0x2e,
+	0x6f, 0x6d, 0x73, 0x2e, 0x76, 0x31, 0x2e, 0x4c, 0x69, 0x73, 0x74, 0x4f, 0x72, 0x64, 0x65, 0x72,
+	0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x1a, 0x2e, 0x6f, 0x6d, 0x73, 0x2e, 0x76,
+	0x31, 0x2e, 0x4c, 0x69, 0x73, 0x74, 0x4f, 0x72, 0x64, 0x65, 0x72, 0x73, 0x52, 0x65, 0x73, 0x70,
+	0x6f, 0x6e, 0x73, 0x65, 0x22, 0x12, 0x82, 0xd3, 0xe4, 0x93, 0x02, 0x0c, 0x12, 0x0a, 0x2f, 0x76,
+	0x31, 0x2f, 0x6f, 0x72, 0x64, 0x65, 0x72, 0x73, 0x12, 0x68, 0x0a, 0x0b, 0x55, 0x70, 0x64, 0x61,
+	0x74, 0x65, 0x4f, 0x72, 0x64, 0x65, 0x72, 0x12, 0x1a, 0x2e, 0x6f, 0x6d, 0x73, 0x2e, 0x76, 0x31,
+	0x2e, 0x55, 0x70, 0x64, 0x61, 0x74, 0x65, 0x4f, 0x72, 0x64, 0x65, 0x72, 0x52, 0x65, 0x71, 0x75,
+	0x65, 0x73, 0x74, 0x1a, 0x1b, 0x2e, 0x6f, 0x6d, 0x73, 0x2e, 0x76, 0x31, 0x2e, 0x55, 0x70, 0x64,
+	0x61, 0x74, 0x65, 0x4f, 0x72, 0x64, 0x65, 0x72, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65,
+	0x22, 0x20, 0x82, 0xd3, 0xe4, 0x93, 0x02, 0x1a, 0x3a, 0x01, 0x2a, 0x32, 0x15, 0x2f, 0x76, 0x31,
+	0x2f, 0x6f, 0x72, 0x64, 0x65, 0x72, 0x73, 0x2f, 0x7b, 0x6f, 0x72, 0x64, 0x65, 0x72, 0x5f, 0x69,
+	0x64, 0x7d, 0x12, 0x63, 0x0a, 0x08, 0x50, 0x61, 0x79, 0x4f, 0x72, 0x64, 0x65, 0x72, 0x12, 0x17,
+	0x2e, 0x6f, 0x6d, 0x73, 0x2e, 0x76, 0x31, 0x2e, 0x50, 0x61, 0x79, 0x4f, 0x72, 0x64, 0x65, 0x72,
+	0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x18, 0x2e, 0x6f, 0x6d, 0x73, 0x2e, 0x76, 0x31,
+	0x2e, 0x50, 0x61, 0x79, 0x4f, 0x72, 0x64, 0x65, 0x72, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73,
+	0x65, 0x22, 0x24, 0x82, 0xd3, 0xe4, 0x93, 0x02, 0x1e, 0x3a, 0x01, 0x2a, 0x22, 0x19, 0x2f, 0x76,
+	0x31, 0x2f, 0x6f, 0x72, 0x64, 0x65, 0x72, 0x73, 0x2f, 0x7b, 0x6f, 0x72, 0x64, 0x65, 0x72, 0x5f,
+	0x69, 0x64, 0x7d, 0x2f, 0x70, 0x61, 0x79, 0x12, 0x73, 0x0a, 0x0c, 0x43, 0x61, 0x70, 0x74, 0x75,
+	0x72, 0x65, 0x4f, 0x72, 0x64, 0x65, 0x72, 0x12, 0x1b, 0x2e, 0x6f, 0x6d, 0x73, 0x2e, 0x76, 0x31,
+	0x2e, 0x43, 0x61, 0x70, 0x74, 0x75, 0x72, 0x65, 0x4f, 0x72, 0x64, 0x65, 0x72, 0x52, 0x65, 0x71,
+	0x75, 0x65, 0x73, 0x74, 0x1a, 0x1c, 0x2e, 0x6f, 0x6d, 0x73, 0x2e, 0x76, 0x31, 0x2e, 0x43, 0x61,
+	0x70, 0x74, 0x75, 0x72, 0x65, 0x4f, 0x72, 0x64, 0x65, 0x72, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e,
+	0x73, 0x65, 0x22, 0x28, 0x82, 0xd3, 0xe4, 0x93, 0x02, 0x22, 0x3a, 0x01, 0x2a, 0x22, 0x1d, 0x2f,
+	0x76, 0x31, 0x2f, 0x6f, 0x72, 0x64, 0x65, 0x72, 0x73, 0x2f, 0x7b, 0x6f, 0x72, 0x64, 0x65, 0x72,
+	0x5f, 0x69, 0x64, 0x7d, 0x2f, 0x63, 0x61, 0x70, 0x74, 0x75, 0x72, 0x65, 0x12, 0x6f, 0x0a, 0x0b,
+	0x43, 0x61, 0x6e, 0x63, 0x65, 0x6c, 0x4f, 0x72, 0x64, 0x65, 0x72, 0x12, 0x1a, 0x2e, 0x6f, 0x6d,
+	0x73, 0x2e, 0x76, 0x31, 0x2e, 0x43, 0x61, 0x6e, 0x63, 0x65, 0x6c, 0x4f, 0x72, 0x64, 0x65, 0x72,
+	0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x1b, 0x2e, 0x6f, 0x6d, 0x73, 0x2e, 0x76, 0x31,
+	0x2e, 0x43, 0x61, 0x6e, 0x63, 0x65, 0x6c, 0x4f, 0x72, 0x64, 0x65, 0x72, 0x52, 0x65, 0x73, 0x70,
+	0x6f, 0x6e, 0x73, 0x65, 0x22, 0x27, 0x82, 0xd3, 0xe4, 0x93, 0x02, 0x21, 0x3a, 0x01, 0x2a, 0x22,
+	0x1c, 0x2f, 0x76, 0x31, 0x2f, 0x6f, 0x72, 0x64, 0x65, 0x72, 0x73, 0x2f, 0x7b, 0x6f, 0x72, 0x64,
+	0x65, 0x72, 0x5f, 0x69, 0x64, 0x7d, 0x2f, 0x63, 0x61, 0x6e, 0x63, 0x65, 0x6c, 0x12, 0x6f, 0x0a,
+	0x0b, 0x52, 0x65, 0x66, 0x75, 0x6e, 0x64, 0x4f, 0x72, 0x64, 0x65, 0x72, 0x12, 0x1a, 0x2e, 0x6f,
+	0x6d, 0x73, 0x2e, 0x76, 0x31, 0x2e, 0x52, 0x65, 0x66, 0x75, 0x6e, 0x64, 0x4f, 0x72, 0x64, 0x65,
+	0x72, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x1b, 0x2e, 0x6f, 0x6d, 0x73, 0x2e, 0x76,
+	0x31, 0x2e, 0x52, 0x65, 0x66, 0x75, 0x6e, 0x64, 0x4f, 0x72, 0x64, 0x65, 0x72, 0x52, 0x65, 0x73,
+	0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x27, 0x82, 0xd3, 0xe4, 0x93, 0x02, 0x21, 0x3a, 0x01, 0x2a,
+	0x22, 0x1c, 0x2f, 0x76, 0x31, 0x2f, 0x6f, 0x72, 0x64, 0x65, 0x72, 0x73, 0x2f, 0x7b, 0x6f, 0x72,
+	0x64, 0x65, 0x72, 0x5f, 0x69, 0x64, 0x7d, 0x2f, 0x72, 0x65, 0x66, 0x75, 0x6e, 0x64, 0x12, 0x67,
+	0x0a, 0x09, 0x48, 0x6f, 0x6c, 0x64, 0x4f, 0x72, 0x64, 0x65, 0x72, 0x12, 0x18, 0x2e, 0x6f, 0x6d,
+	0x73, 0x2e, 0x76, 0x31, 0x2e, 0x48, 0x6f, 0x6c, 0x64, 0x4f, 0x72, 0x64, 0x65, 0x72, 0x52, 0x65,
+	0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x19, 0x2e, 0x6f, 0x6d, 0x73, 0x2e, 0x76, 0x31, 0x2e, 0x48,
+	0x6f, 0x6c, 0x64, 0x4f, 0x72, 0x64, 0x65, 0x72, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65,
+	0x22, 0x25, 0x82, 0xd3, 0xe4, 0x93, 0x02, 0x1f, 0x3a, 0x01, 0x2a, 0x22, 0x1a, 0x2f, 0x76, 0x31,
+	0x2f, 0x6f, 0x72, 0x64, 0x65, 0x72, 0x73, 0x2f, 0x7b, 0x6f, 0x72, 0x64, 0x65, 0x72, 0x5f, 0x69,
+	0x64, 0x7d, 0x2f, 0x68, 0x6f, 0x6c, 0x64, 0x12, 0x73, 0x0a, 0x0c, 0x52, 0x65, 0x6c, 0x65, 0x61,
+	0x73, 0x65, 0x4f, 0x72, 0x64, 0x65, 0x72, 0x12, 0x1b, 0x2e, 0x6f, 0x6d, 0x73, 0x2e, 0x76, 0x31,
+	0x2e, 0x52, 0x65, 0x6c, 0x65, 0x61, 0x73, 0x65, 0x4f, 0x72, 0x64, 0x65, 0x72, 0x52, 0x65, 0x71,
+	0x75, 0x65, 0x73, 0x74, 0x1a, 0x1c, 0x2e, 0x6f, 0x6d, 0x73, 0x2e, 0x76, 0x31, 0x2e, 0x52, 0x65,
+	0x6c, 0x65, 0x61, 0x73, 0x65, 0x4f, 0x72, 0x64, 0x65, 0x72, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e,
+	0x73, 0x65, 0x22, 0x28, 0x82, 0xd3, 0xe4, 0x93, 0x02, 0x22, 0x3a, 0x01, 0x2a, 0x22, 0x1d, 0x2f,
+	0x76, 0x31, 0x2f, 0x6f, 0x72, 0x64, 0x65, 0x72, 0x73, 0x2f, 0x7b, 0x6f, 0x72, 0x64, 0x65, 0x72,
+	0x5f, 0x69, 0x64, 0x7d, 0x2f, 0x72, 0x65, 0x6c, 0x65, 0x61, 0x73, 0x65, 0x12, 0x73, 0x0a, 0x0c,
+	0x41, 0x70, 0x70, 0x72, 0x6f, 0x76, 0x65, 0x4f, 0x72, 0x64, 0x65, 0x72, 0x12, 0x1b, 0x2e, 0x6f,
+	0x6d, 0x73, 0x2e, 0x76, 0x31, 0x2e, 0x41, 0x70, 0x70, 0x72, 0x6f, 0x76, 0x65, 0x4f, 0x72, 0x64,
+	0x65, 0x72, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x1c, 0x2e, 0x6f, 0x6d, 0x73, 0x2e,
+	0x76, 0x31, 0x2e, 0x41, 0x70, 0x70, 0x72, 0x6f, 0x76, 0x65, 0x4f, 0x72, 0x64, 0x65, 0x72, 0x52,
+	0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x28, 0x82, 0xd3, 0xe4, 0x93, 0x02, 0x22, 0x3a,
+	0x01, 0x2a, 0x22, 0x1d, 0x2f, 0x76, 0x31, 0x2f, 0x6f, 0x72, 0x64, 0x65, 0x72, 0x73, 0x2f, 0x7b,
+	0x6f, 0x72, 0x64, 0x65, 0x72, 0x5f, 0x69, 0x64, 0x7d, 0x2f, 0x61, 0x70, 0x70, 0x72, 0x6f, 0x76,
+	0x65, 0x12, 0x6f, 0x0a, 0x0b, 0x52, 0x65, 0x6a, 0x65, 0x63, 0x74, 0x4f, 0x72, 0x64, 0x65, 0x72,
+	0x12, 0x1a, 0x2e, 0x6f, 0x6d, 0x73, 0x2e, 0x76, 0x31, 0x2e, 0x52, 0x65, 0x6a, 0x65, 0x63, 0x74,
+	0x4f, 0x72, 0x64, 0x65, 0x72, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x1b, 0x2e, 0x6f,
+	0x6d, 0x73, 0x2e, 0x76, 0x31, 0x2e, 0x52, 0x65, 0x6a, 0x65, 0x63, 0x74, 0x4f, 0x72, 0x64, 0x65,
+	0x72, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x27, 0x82, 0xd3, 0xe4, 0x93, 0x02,
+	0x21, 0x3a, 0x01, 0x2a, 0x22, 0x1c, 0x2f, 0x76, 0x31, 0x2f, 0x6f, 0x72, 0x64, 0x65, 0x72, 0x73,
+	0x2f, 0x7b, 0x6f, 0x72, 0x64, 0x65, 0x72, 0x5f, 0x69, 0x64, 0x7d, 0x2f, 0x72, 0x65, 0x6a, 0x65,
+	0x63, 0x74, 0x12, 0x83, 0x01, 0x0a, 0x0e, 0x53, 0x63, 0x68, 0x65, 0x64, 0x75, 0x6c, 0x65, 0x43,
+	0x61, 0x6e, 0x63, 0x65, 0x6c, 0x12, 0x1d, 0x2e, 0x6f, 0x6d, 0x73, 0x2e, 0x76, 0x31, 0x2e, 0x53,
+	0x63, 0x68, 0x65, 0x64, 0x75, 0x6c, 0x65, 0x43, 0x61, 0x6e, 0x63, 0x65, 0x6c, 0x52, 0x65, 0x71,
+	0x75, 0x65, 0x73, 0x74, 0x1a, 0x1e, 0x2e, 0x6f, 0x6d, 0x73, 0x2e, 0x76, 0x31, 0x2e, 0x53, 0x63,
+	0x68, 0x65, 0x64, 0x75, 0x6c, 0x65, 0x43, 0x61, 0x6e, 0x63, 0x65, 0x6c, 0x52, 0x65, 0x73, 0x70,
+	0x6f, 0x6e, 0x73, 0x65, 0x22, 0x32, 0x82, 0xd3, 0xe4, 0x93, 0x02, 0x2c, 0x3a, 0x01, 0x2a, 0x22,
+	0x27, 0x2f, 0x76, 0x31, 0x2f, 0x6f, 0x72, 0x64, 0x65, 0x72, 0x73, 0x2f, 0x7b, 0x6f, 0x72, 0x64,
+	0x65, 0x72, 0x5f, 0x69, 0x64, 0x7d, 0x2f, 0x73, 0x63, 0x68, 0x65, 0x64, 0x75, 0x6c, 0x65, 0x64,
+	0x2d, 0x63, 0x61, 0x6e, 0x63, 0x65, 0x6c, 0x73, 0x12, 0x92, 0x01, 0x0a, 0x14, 0x4c, 0x69, 0x73,
+	0x74, 0x53, 0x63, 0x68, 0x65, 0x64, 0x75, 0x6c, 0x65, 0x64, 0x43, 0x61, 0x6e, 0x63, 0x65, 0x6c,
+	0x73, 0x12, 0x23, 0x2e, 0x6f, 0x6d, 0x73, 0x2e, 0x76, 0x31, 0x2e, 0x4c, 0x69, 0x73, 0x74, 0x53,
+	0x63, 0x68, 0x65, 0x64, 0x75, 0x6c, 0x65, 0x64, 0x43, 0x61, 0x6e, 0x63, 0x65, 0x6c, 0x73, 0x52,
+	0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x24, 0x2e, 0x6f, 0x6d, 0x73, 0x2e, 0x76, 0x31, 0x2e,
+	0x4c, 0x69, 0x73, 0x74, 0x53, 0x63, 0x68, 0x65, 0x64, 0x75, 0x6c, 0x65, 0x64, 0x43, 0x61, 0x6e,
+	0x63, 0x65, 0x6c, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x2f, 0x82, 0xd3,
+	0xe4, 0x93, 0x02, 0x29, 0x12, 0x27, 0x2f, 0x76, 0x31, 0x2f, 0x6f, 0x72, 0x64, 0x65, 0x72, 0x73,
+	0x2f, 0x7b, 0x6f, 0x72, 0x64, 0x65, 0x72, 0x5f, 0x69, 0x64, 0x7d, 0x2f, 0x73, 0x63, 0x68, 0x65,
+	0x64, 0x75, 0x6c, 0x65, 0x64, 0x2d, 0x63, 0x61, 0x6e, 0x63, 0x65, 0x6c, 0x73, 0x12, 0xab, 0x01,
+	0x0a, 0x15, 0x44, 0x65, 0x6c, 0x65, 0x74, 0x65, 0x53, 0x63, 0x68, 0x65, 0x64, 0x75, 0x6c, 0x65,
+	0x64, 0x43, 0x61, 0x6e, 0x63, 0x65, 0x6c, 0x12, 0x24, 0x2e, 0x6f, 0x6d, 0x73, 0x2e, 0x76, 0x31,
+	0x2e, 0x44, 0x65, 0x6c, 0x65, 0x74, 0x65, 0x53, 0x63, 0x68, 0x65, 0x64, 0x75, 0x6c, 0x65, 0x64,
+	0x43, 0x61, 0x6e, 0x63, 0x65, 0x6c, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x25, 0x2e,
+	0x6f, 0x6d, 0x73, 0x2e, 0x76, 0x31, 0x2e, 0x44, 0x65, 0x6c, 0x65, 0x74, 0x65, 0x53, 0x63, 0x68,
+	0x65, 0x64, 0x75, 0x6c, 0x65, 0x64, 0x43, 0x61, 0x6e, 0x63, 0x65, 0x6c, 0x52, 0x65, 0x73, 0x70,
+	0x6f, 0x6e, 0x73, 0x65, 0x22, 0x45, 0x82, 0xd3, 0xe4, 0x93, 0x02, 0x3f, 0x2a, 0x3d, 0x2f, 0x76,
+	0x31, 0x2f, 0x6f, 0x72, 0x64, 0x65, 0x72, 0x73, 0x2f, 0x7b, 0x6f, 0x72, 0x64, 0x65, 0x72, 0x5f,
+	0x69, 0x64, 0x7d, 0x2f, 0x73, 0x63, 0x68, 0x65, 0x64, 0x75, 0x6c, 0x65, 0x64, 0x2d, 0x63, 0x61,
+	0x6e, 0x63, 0x65, 0x6c, 0x73, 0x2f, 0x7b, 0x73, 0x63, 0x68, 0x65, 0x64, 0x75, 0x6c, 0x65, 0x64,
+	0x5f, 0x63, 0x61, 0x6e, 0x63, 0x65, 0x6c, 0x5f, 0x69, 0x64, 0x7d, 0x12, 0x73, 0x0a, 0x0c, 0x43,
+	0x72, 0x65, 0x61, 0x74, 0x65, 0x52, 0x65, 0x74, 0x75, 0x72, 0x6e, 0x12, 0x1b, 0x2e, 0x6f, 0x6d,
+	0x73, 0x2e, 0x76, 0x31, 0x2e, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x52, 0x65, 0x74, 0x75, 0x72,
+	0x6e, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x1c, 0x2e, 0x6f, 0x6d, 0x73, 0x2e, 0x76,
+	0x31, 0x2e, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x52, 0x65, 0x74, 0x75, 0x72, 0x6e, 0x52, 0x65,
+	0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x28, 0x82, 0xd3, 0xe4, 0x93, 0x02, 0x22, 0x3a, 0x01,
+	0x2a, 0x22, 0x1d, 0x2f, 0x76, 0x31, 0x2f, 0x6f, 0x72, 0x64, 0x65, 0x72, 0x73, 0x2f, 0x7b, 0x6f,
+	0x72, 0x64, 0x65, 0x72, 0x5f, 0x69, 0x64, 0x7d, 0x2f, 0x72, 0x65, 0x74, 0x75, 0x72, 0x6e, 0x73,
+	0x12, 0x73, 0x0a, 0x09, 0x47, 0x65, 0x74, 0x52, 0x65, 0x74, 0x75, 0x72, 0x6e, 0x12, 0x18, 0x2e,
+	0x6f, 0x6d, 0x73, 0x2e, 0x76, 0x31, 0x2e, 0x47, 0x65, 0x74, 0x52, 0x65, 0x74, 0x75, 0x72, 0x6e,
+	0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x19, 0x2e, 0x6f, 0x6d, 0x73, 0x2e, 0x76, 0x31,
+	0x2e, 0x47, 0x65, 0x74, 0x52, 0x65, 0x74, 0x75, 0x72, 0x6e, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e,
+	0x73, 0x65, 0x22, 0x31, 0x82, 0xd3, 0xe4, 0x93, 0x02, 0x2b, 0x12, 0x29, 0x2f, 0x76, 0x31, 0x2f,
+	0x6f, 0x72, 0x64, 0x65, 0x72, 0x73, 0x2f, 0x7b, 0x6f, 0x72, 0x64, 0x65, 0x72, 0x5f, 0x69, 0x64,
+	0x7d, 0x2f, 0x72, 0x65, 0x74, 0x75, 0x72, 0x6e, 0x73, 0x2f, 0x7b, 0x72, 0x65, 0x74, 0x75, 0x72,
+	0x6e, 0x5f, 0x69, 0x64, 0x7d, 0x12, 0x8a, 0x01, 0x0a, 0x0d, 0x41, 0x64, 0x76, 0x61, 0x6e, 0x63,
+	0x65, 0x52, 0x65, 0x74, 0x75, 0x72, 0x6e, 0x12, 0x1c, 0x2e, 0x6f, 0x6d, 0x73, 0x2e, 0x76, 0x31,
+	0x2e, 0x41, 0x64, 0x76, 0x61, 0x6e, 0x63, 0x65, 0x52, 0x65, 0x74, 0x75, 0x72, 0x6e, 0x52, 0x65,
+	0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x1d, 0x2e, 0x6f, 0x6d, 0x73, 0x2e, 0x76, 0x31, 0x2e, 0x41,
+	0x64, 0x76, 0x61, 0x6e, 0x63, 0x65, 0x52, 0x65, 0x74, 0x75, 0x72, 0x6e, 0x52, 0x65, 0x73, 0x70,
+	0x6f, 0x6e, 0x73, 0x65, 0x22, 0x3c, 0x82, 0xd3, 0xe4, 0x93, 0x02, 0x36, 0x3a, 0x01, 0x2a, 0x22,
+	0x31, 0x2f, 0x76, 0x31, 0x2f, 0x6f, 0x72, 0x64, 0x65, 0x72, 0x73, 0x2f, 0x7b, 0x6f, 0x72, 0x64,
+	0x65, 0x72, 0x5f, 0x69, 0x64, 0x7d, 0x2f, 0x72, 0x65, 0x74, 0x75, 0x72, 0x6e, 0x73, 0x2f, 0x7b,
+	0x72, 0x65, 0x74, 0x75, 0x72, 0x6e, 0x5f, 0x69, 0x64, 0x7d, 0x3a, 0x61, 0x64, 0x76, 0x61, 0x6e,
+	0x63, 0x65, 0x12, 0x69, 0x0a, 0x0e, 0x47, 0x65, 0x74, 0x53, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65,
+	0x49, 0x6e, 0x66, 0x6f, 0x12, 0x1d, 0x2e, 0x6f, 0x6d, 0x73, 0x2e, 0x76, 0x31, 0x2e, 0x47, 0x65,
+	0x74, 0x53, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x49, 0x6e, 0x66, 0x6f, 0x52, 0x65, 0x71, 0x75,
+	0x65, 0x73, 0x74, 0x1a, 0x1e, 0x2e, 0x6f, 0x6d, 0x73, 0x2e, 0x76, 0x31, 0x2e, 0x47, 0x65, 0x74,
+	0x53, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x49, 0x6e, 0x66, 0x6f, 0x52, 0x65, 0x73, 0x70, 0x6f,
+	0x6e, 0x73, 0x65, 0x22, 0x18, 0x82, 0xd3, 0xe4, 0x93, 0x02, 0x12, 0x12, 0x10, 0x2f, 0x76, 0x31,
+	0x2f, 0x73, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x2d, 0x69, 0x6e, 0x66, 0x6f, 0x32, 0x8a, 0x0b,
+	0x0a, 0x0e, 0x43, 0x6f, 0x75, 0x72, 0x69, 0x65, 0x72, 0x53, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65,
+	0x12, 0x6b, 0x0a, 0x0f, 0x52, 0x65, 0x67, 0x69, 0x73, 0x74, 0x65, 0x72, 0x43, 0x6f, 0x75, 0x72,
+	0x69, 0x65, 0x72, 0x12, 0x1e, 0x2e, 0x6f, 0x6d, 0x73, 0x2e, 0x76, 0x31, 0x2e, 0x52, 0x65, 0x67,
+	0x69, 0x73, 0x74, 0x65, 0x72, 0x43, 0x6f, 0x75, 0x72, 0x69, 0x65, 0x72, 0x52, 0x65, 0x71, 0x75,
+	0x65, 0x73, 0x74, 0x1a, 0x1f, 0x2e, 0x6f, 0x6d, 0x73, 0x2e, 0x76, 0x31, 0x2e, 0x52, 0x65, 0x67,
+	0x69, 0x73, 0x74, 0x65, 0x72, 0x43, 0x6f, 0x75, 0x72, 0x69, 0x65, 0x72, 0x52, 0x65, 0x73, 0x70,
+	0x6f, 0x6e, 0x73, 0x65, 0x22, 0x17, 0x82, 0xd3, 0xe4, 0x93, 0x02, 0x11, 0x3a, 0x01, 0x2a, 0x22,
+	0x0c, 0x2f, 0x76, 0x31, 0x2f, 0x63, 0x6f, 0x75, 0x72, 0x69, 0x65, 0x72, 0x73, 0x12, 0x66, 0x0a,
+	0x0a, 0x47, 0x65, 0x74, 0x43, 0x6f, 0x75, 0x72, 0x69, 0x65, 0x72, 0x12, 0x19, 0x2e, 0x6f, 0x6d,
+	0x73, 0x2e, 0x76, 0x31, 0x2e, 0x47, 0x65, 0x74, 0x43, 0x6f, 0x75, 0x72, 0x69, 0x65, 0x72, 0x52,
+	0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x1a, 0x2e, 0x6f, 0x6d, 0x73, 0x2e, 0x76, 0x31, 0x2e,
+	0x47, 0x65, 0x74, 0x43, 0x6f, 0x75, 0x72, 0x69, 0x65, 0x72, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e,
+	0x73, 0x65, 0x22, 0x21, 0x82, 0xd3, 0xe4, 0x93, 0x02, 0x1b, 0x12, 0x19, 0x2f, 0x76, 0x31, 0x2f,
+	0x63, 0x6f, 0x75, 0x72, 0x69, 0x65, 0x72, 0x73, 0x2f, 0x7b, 0x63, 0x6f, 0x75, 0x72, 0x69, 0x65,
+	0x72, 0x5f, 0x69, 0x64, 0x7d, 0x12, 0x81, 0x01, 0x0a, 0x12, 0x4c, 0x69, 0x73, 0x74, 0x43, 0x6f,
+	0x75, 0x72, 0x69, 0x65, 0x72, 0x73, 0x42, 0x79, 0x5a, 0x6f, 0x6e, 0x65, 0x12, 0x21, 0x2e, 0x6f,
+	0x6d, 0x73, 0x2e, 0x76, 0x31, 0x2e, 0x4c, 0x69, 0x73, 0x74, 0x43, 0x6f, 0x75, 0x72, 0x69, 0x65,
+	0x72, 0x73, 0x42, 0x79, 0x5a, 0x6f, 0x6e, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a,
+	0x22, 0x2e, 0x6f, 0x6d, 0x73, 0x2e, 0x76, 0x31, 0x2e, 0x4c, 0x69, 0x73, 0x74, 0x43, 0x6f, 0x75,
+	0x72, 0x69, 0x65, 0x72, 0x73, 0x42, 0x79, 0x5a, 0x6f, 0x6e, 0x65, 0x52, 0x65, 0x73, 0x70, 0x6f,
+	0x6e, 0x73, 0x65, 0x22, 0x24, 0x82, 0xd3, 0xe4, 0x93, 0x02, 0x1e, 0x12, 0x1c, 0x2f, 0x76, 0x31,
+	0x2f, 0x7a, 0x6f, 0x6e, 0x65, 0x73, 0x2f, 0x7b, 0x7a, 0x6f, 0x6e, 0x65, 0x5f, 0x69, 0x64, 0x7d,
+	0x2f, 0x63, 0x6f, 0x75, 0x72, 0x69, 0x65, 0x72, 0x73, 0x12, 0x8a, 0x01, 0x0a, 0x13, 0x52, 0x65,
+	0x70, 0x6c, 0x61, 0x63, 0x65, 0x43, 0x6f, 0x75, 0x72, 0x69, 0x65, 0x72, 0x5a, 0x6f, 0x6e, 0x65,
+	0x73, 0x12, 0x22, 0x2e, 0x6f, 0x6d, 0x73, 0x2e, 0x76, 0x31, 0x2e, 0x52, 0x65, 0x70, 0x6c, 0x61,
+	0x63, 0x65, 0x43, 0x6f, 0x75, 0x72, 0x69, 0x65, 0x72, 0x5a, 0x6f, 0x6e, 0x65, 0x73, 0x52, 0x65,
+	0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x23, 0x2e, 0x6f, 0x6d, 0x73, 0x2e, 0x76, 0x31, 0x2e, 0x52,
+	0x65, 0x70, 0x6c, 0x61, 0x63, 0x65, 0x43, 0x6f, 0x75, 0x72, 0x69, 0x65, 0x72, 0x5a, 0x6f, 0x6e,
+	0x65, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x2a, 0x82, 0xd3, 0xe4, 0x93,
+	0x02, 0x24, 0x3a, 0x01, 0x2a, 0x1a, 0x1f, 0x2f, 0x76, 0x31, 0x2f, 0x63, 0x6f, 0x75, 0x72, 0x69,
+	0x65, 0x72, 0x73, 0x2f, 0x7b, 0x63, 0x6f, 0x75, 0x72, 0x69, 0x65, 0x72, 0x5f, 0x69, 0x64, 0x7d,
+	0x2f, 0x7a, 0x6f, 0x6e, 0x65, 0x73, 0x12, 0x84, 0x01, 0x0a, 0x11, 0x43, 0x72, 0x65, 0x61, 0x74,
+	0x65, 0x43, 0x6f, 0x75, 0x72, 0x69, 0x65, 0x72, 0x53, 0x6c, 0x6f, 0x74, 0x12, 0x20, 0x2e, 0x6f,
+	0x6d, 0x73, 0x2e, 0x76, 0x31, 0x2e, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x43, 0x6f, 0x75, 0x72,
+	0x69, 0x65, 0x72, 0x53, 0x6c, 0x6f, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x21,
+	0x2e, 0x6f, 0x6d, 0x73, 0x2e, 0x76, 0x31, 0x2e, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x43, 0x6f,
+	0x75, 0x72, 0x69, 0x65, 0x72, 0x53, 0x6c, 0x6f, 0x74, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73,
+	0x65, 0x22, 0x2a, 0x82, 0xd3, 0xe4, 0x93, 0x02, 0x24, 0x3a, 0x01, 0x2a, 0x22, 0x1f, 0x2f, 0x76,
+	0x31, 0x2f, 0x63, 0x6f, 0x75, 0x72, 0x69, 0x65, 0x72, 0x73, 0x2f, 0x7b, 0x63, 0x6f, 0x75, 0x72,
+	0x69, 0x65, 0x72, 0x5f, 0x69, 0x64, 0x7d, 0x2f, 0x73, 0x6c, 0x6f, 0x74, 0x73, 0x12, 0x7e, 0x0a,
+	0x10, 0x4c, 0x69, 0x73, 0x74, 0x43, 0x6f, 0x75, 0x72, 0x69, 0x65, 0x72, 0x53, 0x6c, 0x6f, 0x74,
+	0x73, 0x12, 0x1f, 0x2e, 0x6f, 0x6d, 0x73, 0x2e, 0x76, 0x31, 0x2e, 0x4c, 0x69, 0x73, 0x74, 0x43,
+	0x6f, 0x75, 0x72, 0x69, 0x65, 0x72, 0x53, 0x6c, 0x6f, 0x74, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65,
+	0x73, 0x74, 0x1a, 0x20, 0x2e, 0x6f, 0x6d, 0x73, 0x2e, 0x76, 0x31, 0x2e, 0x4c, 0x69, 0x73, 0x74,
+	0x43, 0x6f, 0x75, 0x72, 0x69, 0x65, 0x72, 0x53, 0x6c, 0x6f, 0x74, 0x73, 0x52, 0x65, 0x73, 0x70,
+	0x6f, 0x6e, 0x73, 0x65, 0x22, 0x27, 0x82, 0xd3, 0xe4, 0x93, 0x02, 0x21, 0x12, 0x1f, 0x2f, 0x76,
+	0x31, 0x2f, 0x63, 0x6f, 0x75, 0x72, 0x69, 0x65, 0x72, 0x73, 0x2f, 0x7b, 0x63, 0x6f, 0x75, 0x72,
+	0x69, 0x65, 0x72, 0x5f, 0x69, 0x64, 0x7d, 0x2f, 0x73, 0x6c, 0x6f, 0x74, 0x73, 0x12, 0xaf, 0x01,
+	0x0a, 0x1b, 0x47, 0x65, 0x74, 0x43, 0x6f, 0x75, 0x72, 0x69, 0x65, 0x72, 0x56, 0x65, 0x68, 0x69,
+	0x63, 0x6c, 0x65, 0x43, 0x61, 0x70, 0x61, 0x62, 0x69, 0x6c, 0x69, 0x74, 0x79, 0x12, 0x2a, 0x2e,
+	0x6f, 0x6d, 0x73, 0x2e, 0x76, 0x31, 0x2e, 0x47, 0x65, 0x74, 0x43, 0x6f, 0x75, 0x72, 0x69, 0x65,
+	0x72, 0x56, 0x65, 0x68, 0x69, 0x63, 0x6c, 0x65, 0x43, 0x61, 0x70, 0x61, 0x62, 0x69, 0x6c, 0x69,
+	0x74, 0x79, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x2b, 0x2e, 0x6f, 0x6d, 0x73, 0x2e,
+	0x76, 0x31, 0x2e, 0x47, 0x65, 0x74, 0x43, 0x6f, 0x75, 0x72, 0x69, 0x65, 0x72, 0x56, 0x65, 0x68,
+	0x69, 0x63, 0x6c, 0x65, 0x43, 0x61, 0x70, 0x61, 0x62, 0x69, 0x6c, 0x69, 0x74, 0x79, 0x52, 0x65,
+	0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x37, 0x82, 0xd3, 0xe4, 0x93, 0x02, 0x31, 0x12, 0x2f,
+	0x2f, 0x76, 0x31, 0x2f, 0x63, 0x6f, 0x75, 0x72, 0x69, 0x65, 0x72, 0x2d, 0x76, 0x65, 0x68, 0x69,
+	0x63, 0x6c, 0x65, 0x2d, 0x63, 0x61, 0x70, 0x61, 0x62, 0x69, 0x6c, 0x69, 0x74, 0x69, 0x65, 0x73,
+	0x2f, 0x7b, 0x76, 0x65, 0x68, 0x69, 0x63, 0x6c, 0x65, 0x5f, 0x74, 0x79, 0x70, 0x65, 0x7d, 0x12,
+	0xa9, 0x01, 0x0a, 0x1e, 0x4c, 0x69, 0x73, 0x74, 0x43, 0x6f, 0x75, 0x72, 0x69, 0x65, 0x72, 0x56,
+	0x65, 0x68, 0x69, 0x63, 0x6c, 0x65, 0x43, 0x61, 0x70, 0x61, 0x62, 0x69, 0x6c, 0x69, 0x74, 0x69,
+	0x65, 0x73, 0x12, 0x2d, 0x2e, 0x6f, 0x6d, 0x73, 0x2e, 0x76, 0x31, 0x2e, 0x4c, 0x69, 0x73, 0x74,
+	0x43, 0x6f, 0x75, 0x72, 0x69, 0x65, 0x72, 0x56, 0x65, 0x68, 0x69, 0x63, 0x6c, 0x65, 0x43, 0x61,
+	0x70, 0x61, 0x62, 0x69, 0x6c, 0x69, 0x74, 0x69, 0x65, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73,
+	0x74, 0x1a, 0x2e, 0x2e, 0x6f, 0x6d, 0x73, 0x2e, 0x76, 0x31, 0x2e, 0x4c, 0x69, 0x73, 0x74, 0x43,
+	0x6f, 0x75, 0x72, 0x69, 0x65, 0x72, 0x56, 0x65, 0x68, 0x69, 0x63, 0x6c, 0x65, 0x43, 0x61, 0x70,
+	0x61, 0x62, 0x69, 0x6c, 0x69, 0x74, 0x69, 0x65, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73,
+	0x65, 0x22, 0x28, 0x82, 0xd3, 0xe4, 0x93, 0x02, 0x22, 0x12, 0x20, 0x2f, 0x76, 0x31, 0x2f, 0x63,
+	0x6f, 0x75, 0x72, 0x69, 0x65, 0x72, 0x2d, 0x76, 0x65, 0x68, 0x69, 0x63, 0x6c, 0x65, 0x2d, 0x63,
+	0x61, 0x70, 0x61, 0x62, 0x69, 0x6c, 0x69, 0x74, 0x69, 0x65, 0x73, 0x12, 0x8c, 0x01, 0x0a, 0x13,
+	0x53, 0x75, 0x62, 0x6d, 0x69, 0x74, 0x43, 0x6f, 0x75, 0x72, 0x69, 0x65, 0x72, 0x52, 0x61, 0x74,
+	0x69, 0x6e, 0x67, 0x12, 0x22, 0x2e, 0x6f, 0x6d, 0x73, 0x2e, 0x76, 0x31, 0x2e, 0x53, 0x75, 0x62,
+	0x6d, 0x69, 0x74, 0x43, 0x6f, 0x75, 0x72, 0x69, 0x65, 0x72, 0x52, 0x61, 0x74, 0x69, 0x6e, 0x67,
+	0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x23, 0x2e, 0x6f, 0x6d, 0x73, 0x2e, 0x76, 0x31,
+	0x2e, 0x53, 0x75, 0x62, 0x6d, 0x69, 0x74, 0x43, 0x6f, 0x75, 0x72, 0x69, 0x65, 0x72, 0x52, 0x61,
+	0x74, 0x69, 0x6e, 0x67, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x2c, 0x82, 0xd3,
+	0xe4, 0x93, 0x02, 0x26, 0x3a, 0x01, 0x2a, 0x22, 0x21, 0x2f, 0x76, 0x31, 0x2f, 0x63, 0x6f, 0x75,
+	0x72, 0x69, 0x65, 0x72, 0x73, 0x2f, 0x7b, 0x63, 0x6f, 0x75, 0x72, 0x69, 0x65, 0x72, 0x5f, 0x69,
+	0x64, 0x7d, 0x2f, 0x72, 0x61, 0x74, 0x69, 0x6e, 0x67, 0x73, 0x12, 0x9d, 0x01, 0x0a, 0x17, 0x47,
+	0x65, 0x74, 0x43, 0x6f, 0x75, 0x72, 0x69, 0x65, 0x72, 0x52, 0x61, 0x74, 0x69, 0x6e, 0x67, 0x53,
+	0x75, 0x6d, 0x6d, 0x61, 0x72, 0x79, 0x12, 0x26, 0x2e, 0x6f, 0x6d, 0x73, 0x2e, 0x76, 0x31, 0x2e,
+	0x47, 0x65, 0x74, 0x43, 0x6f, 0x75, 0x72, 0x69, 0x65, 0x72, 0x52, 0x61, 0x74, 0x69, 0x6e, 0x67,
+	0x53, 0x75, 0x6d, 0x6d, 0x61, 0x72, 0x79, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x27,
+	0x2e, 0x6f, 0x6d, 0x73, 0x2e, 0x76, 0x31, 0x2e, 0x47, 0x65, 0x74, 0x43, 0x6f, 0x75, 0x72, 0x69,
+	0x65, 0x72, 0x52, 0x61, 0x74, 0x69, 0x6e, 0x67, 0x53, 0x75, 0x6d, 0x6d, 0x61, 0x72, 0x79, 0x52,
+	0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x31, 0x82, 0xd3, 0xe4, 0x93, 0x02, 0x2b, 0x12,
+	0x29, 0x2f, 0x76, 0x31, 0x2f, 0x63, 0x6f, 0x75, 0x72, 0x69, 0x65, 0x72, 0x73, 0x2f, 0x7b, 0x63,
+	0x6f, 0x75, 0x72, 0x69, 0x65, 0x72, 0x5f, 0x69, 0x64, 0x7d, 0x2f, 0x72, 0x61, 0x74, 0x69, 0x6e,
+	0x67, 0x73, 0x2f, 0x73, 0x75, 0x6d, 0x6d, 0x61, 0x72, 0x79, 0x32, 0xb3, 0x04, 0x0a, 0x0c, 0x41,
+	0x64, 0x6d, 0x69, 0x6e, 0x53, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x12, 0x5b, 0x0a, 0x12, 0x44,
+	0x65, 0x6c, 0x65, 0x74, 0x65, 0x43, 0x75, 0x73, 0x74, 0x6f, 0x6d, 0x65, 0x72, 0x44, 0x61, 0x74,
+	0x61, 0x12, 0x21, 0x2e, 0x6f, 0x6d, 0x73, 0x2e, 0x76, 0x31, 0x2e, 0x44, 0x65, 0x6c, 0x65, 0x74,
+	0x65, 0x43, 0x75, 0x73, 0x74, 0x6f, 0x6d, 0x65, 0x72, 0x44, 0x61, 0x74, 0x61, 0x52, 0x65, 0x71,
+	0x75, 0x65, 0x73, 0x74, 0x1a, 0x22, 0x2e, 0x6f, 0x6d, 0x73, 0x2e, 0x76, 0x31, 0x2e, 0x44, 0x65,
+	0x6c, 0x65, 0x74, 0x65, 0x43, 0x75, 0x73, 0x74, 0x6f, 0x6d, 0x65, 0x72, 0x44, 0x61, 0x74, 0x61,
+	0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x4c, 0x0a, 0x0d, 0x47, 0x65, 0x74, 0x51,
+	0x75, 0x6f, 0x74, 0x61, 0x55, 0x73, 0x61, 0x67, 0x65, 0x12, 0x1c, 0x2e, 0x6f, 0x6d, 0x73, 0x2e,
+	0x76, 0x31, 0x2e, 0x47, 0x65, 0x74, 0x51, 0x75, 0x6f, 0x74, 0x61, 0x55, 0x73, 0x61, 0x67, 0x65,
+	0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x1d, 0x2e, 0x6f, 0x6d, 0x73, 0x2e, 0x76, 0x31,
+	0x2e, 0x47, 0x65, 0x74, 0x51, 0x75, 0x6f, 0x74, 0x61, 0x55, 0x73, 0x61, 0x67, 0x65, 0x52, 0x65,
+	0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x4f, 0x0a, 0x0e, 0x47, 0x65, 0x74, 0x45, 0x76, 0x65,
+	0x6e, 0x74, 0x73, 0x53, 0x69, 0x6e, 0x63, 0x65, 0x12, 0x1d, 0x2e, 0x6f, 0x6d, 0x73, 0x2e, 0x76,
+	0x31, 0x2e, 0x47, 0x65, 0x74, 0x45, 0x76, 0x65, 0x6e, 0x74, 0x73, 0x53, 0x69, 0x6e, 0x63, 0x65,
+	0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x1e, 0x2e, 0x6f, 0x6d, 0x73, 0x2e, 0x76, 0x31,
+	0x2e, 0x47, 0x65, 0x74, 0x45, 0x76, 0x65, 0x6e, 0x74, 0x73, 0x53, 0x69, 0x6e, 0x63, 0x65, 0x52,
+	0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x3e, 0x0a, 0x0c, 0x47, 0x65, 0x74, 0x4c, 0x6f,
+	0x67, 0x4c, 0x65, 0x76, 0x65, 0x6c, 0x73, 0x12, 0x1b, 0x2e, 0x6f, 0x6d, 0x73, 0x2e, 0x76, 0x31,
+	0x2e, 0x47, 0x65, 0x74, 0x4c, 0x6f, 0x67, 0x4c, 0x65, 0x76, 0x65, 0x6c, 0x73, 0x52, 0x65, 0x71,
+	0x75, 0x65, 0x73, 0x74, 0x1a, 0x11, 0x2e, 0x6f, 0x6d, 0x73, 0x2e, 0x76, 0x31, 0x2e, 0x4c, 0x6f,
+	0x67, 0x4c, 0x65, 0x76, 0x65, 0x6c, 0x73, 0x12, 0x3c, 0x0a, 0x0b, 0x53, 0x65, 0x74, 0x4c, 0x6f,
+	0x67, 0x4c, 0x65, 0x76, 0x65, 0x6c, 0x12, 0x1a, 0x2e, 0x6f, 0x6d, 0x73, 0x2e, 0x76, 0x31, 0x2e,
+	0x53, 0x65, 0x74, 0x4c, 0x6f, 0x67, 0x4c, 0x65, 0x76, 0x65, 0x6c, 0x52, 0x65, 0x71, 0x75, 0x65,
+	0x73, 0x74, 0x1a, 0x11, 0x2e, 0x6f, 0x6d, 0x73, 0x2e, 0x76, 0x31, 0x2e, 0x4c, 0x6f, 0x67, 0x4c,
+	0x65, 0x76, 0x65, 0x6c, 0x73, 0x12, 0x55, 0x0a, 0x10, 0x52, 0x65, 0x63, 0x61, 0x6c, 0x63, 0x75,
+	0x6c, 0x61, 0x74, 0x65, 0x4f, 0x72, 0x64, 0x65, 0x72, 0x12, 0x1f, 0x2e, 0x6f, 0x6d, 0x73, 0x2e,
+	0x76, 0x31, 0x2e, 0x52, 0x65, 0x63, 0x61, 0x6c, 0x63, 0x75, 0x6c, 0x61, 0x74, 0x65, 0x4f, 0x72,
+	0x64, 0x65, 0x72, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x20, 0x2e, 0x6f, 0x6d, 0x73,
+	0x2e, 0x76, 0x31, 0x2e, 0x52, 0x65, 0x63, 0x61, 0x6c, 0x63, 0x75, 0x6c, 0x61, 0x74, 0x65, 0x4f,
+	0x72, 0x64, 0x65, 0x72, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x52, 0x0a, 0x0f,
+	0x46, 0x6f, 0x72, 0x63, 0x65, 0x54, 0x72, 0x61, 0x6e, 0x73, 0x69, 0x74, 0x69, 0x6f, 0x6e, 0x12,
+	0x1e, 0x2e, 0x6f, 0x6d, 0x73, 0x2e, 0x76, 0x31, 0x2e, 0x46, 0x6f, 0x72, 0x63, 0x65, 0x54, 0x72,
+	0x61, 0x6e, 0x73, 0x69, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a,
+	0x1f, 0x2e, 0x6f, 0x6d, 0x73, 0x2e, 0x76, 0x31, 0x2e, 0x46, 0x6f, 0x72, 0x63, 0x65, 0x54, 0x72,
+	0x61, 0x6e, 0x73, 0x69, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65,
+	0x42, 0x38, 0x5a, 0x36, 0x67, 0x69, 0x74, 0x68, 0x75, 0x62, 0x2e, 0x63, 0x6f, 0x6d, 0x2f, 0x76,
+	0x6c, 0x61, 0x64, 0x69, 0x73, 0x6c, 0x61, 0x76, 0x64, 0x72, 0x61, 0x67, 0x6f, 0x6e, 0x65, 0x6e,
+	0x6b, 0x6f, 0x76, 0x2f, 0x6f, 0x6d, 0x73, 0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2f, 0x6f, 0x6d,
+	0x73, 0x2f, 0x76, 0x31, 0x3b, 0x6f, 0x6d, 0x73, 0x76, 0x31, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74,
+	0x6f, 0x33,
+}
+
+var (
+	file_proto_oms_v1_order_service_proto_rawDescOnce sync.Once
+	file_proto_oms_v1_order_service_proto_rawDescData = file_proto_oms_v1_order_service_proto_rawDesc
+)
+
+func file_proto_oms_v1_order_service_proto_rawDescGZIP() []byte {
+	file_proto_oms_v1_order_service_proto_rawDescOnce.Do(func() {
+		file_proto_oms_v1_order_service_proto_rawDescData = protoimpl.X.CompressGZIP(file_proto_oms_v1_order_service_proto_rawDescData)
+	})
+	return file_proto_oms_v1_order_service_proto_rawDescData
+}
+
+var file_proto_oms_v1_order_service_proto_enumTypes = make([]protoimpl.EnumInfo, 8)
+var file_proto_oms_v1_order_service_proto_msgTypes = make([]protoimpl.MessageInfo, 97)
+var file_proto_oms_v1_order_service_proto_goTypes = []interface{}{
+	(OrderStatus)(0),                               // 0: oms.v1.OrderStatus
+	(AdjustmentType)(0),                            // 1: oms.v1.AdjustmentType
+	(RoundingMode)(0),                              // 2: oms.v1.RoundingMode
+	(CourierVehicleType)(0),                        // 3: oms.v1.CourierVehicleType
+	(CourierSlotStatus)(0),                         // 4: oms.v1.CourierSlotStatus
+	(CourierRatingTag)(0),                          // 5: oms.v1.CourierRatingTag
+	(ScheduledCancelStatus)(0),                     // 6: oms.v1.ScheduledCancelStatus
+	(ReturnStatus)(0),                              // 7: oms.v1.ReturnStatus
+	(*Money)(nil),                                  // 8: oms.v1.Money
+	(*OrderItem)(nil),                              // 9: oms.v1.OrderItem
+	(*Order)(nil),                                  // 10: oms.v1.Order
+	(*PriceAdjustment)(nil),                        // 11: oms.v1.PriceAdjustment
+	(*OrderAmounts)(nil),                           // 12: oms.v1.OrderAmounts
+	(*TimelineEvent)(nil),                          // 13: oms.v1.TimelineEvent
+	(*CourierZoneInput)(nil),                       // 14: oms.v1.CourierZoneInput
+	(*CourierZone)(nil),                            // 15: oms.v1.CourierZone
+	(*Courier)(nil),                                // 16: oms.v1.Courier
+	(*CourierSlot)(nil),                            // 17: oms.v1.CourierSlot
+	(*CourierVehicleCapability)(nil),               // 18: oms.v1.CourierVehicleCapability
+	(*CreateOrderRequest)(nil),                     // 19: oms.v1.CreateOrderRequest
+	(*CreateOrderResponse)(nil),                    // 20: oms.v1.CreateOrderResponse
+	(*GetOrderRequest)(nil),                        // 21: oms.v1.GetOrderRequest
+	(*GetOrderResponse)(nil),                       // 22: oms.v1.GetOrderResponse
+	(*GetOrdersRequest)(nil),                       // 23: oms.v1.GetOrdersRequest
+	(*GetOrdersResponse)(nil),                      // 24: oms.v1.GetOrdersResponse
+	(*StreamOrderTimelineRequest)(nil),             // 25: oms.v1.StreamOrderTimelineRequest
+	(*StreamOrderTimelineResponse)(nil),            // 26: oms.v1.StreamOrderTimelineResponse
+	(*WatchOrderRequest)(nil),                      // 27: oms.v1.WatchOrderRequest
+	(*WatchOrderResponse)(nil),                     // 28: oms.v1.WatchOrderResponse
+	(*ListOrdersRequest)(nil),                      // 29: oms.v1.ListOrdersRequest
+	(*ListOrdersResponse)(nil),                     // 30: oms.v1.ListOrdersResponse
+	(*UpdateOrderRequest)(nil),                     // 31: oms.v1.UpdateOrderRequest
+	(*UpdateOrderResponse)(nil),                    // 32: oms.v1.UpdateOrderResponse
+	(*PayOrderRequest)(nil),                        // 33: oms.v1.PayOrderRequest
+	(*PayOrderResponse)(nil),                       // 34: oms.v1.PayOrderResponse
+	(*CaptureOrderRequest)(nil),                    // 35: oms.v1.CaptureOrderRequest
+	(*CaptureOrderResponse)(nil),                   // 36: oms.v1.CaptureOrderResponse
+	(*CancelOrderRequest)(nil),                     // 37: oms.v1.CancelOrderRequest
+	(*CancelOrderResponse)(nil),                    // 38: oms.v1.CancelOrderResponse
+	(*RefundOrderRequest)(nil),                     // 39: oms.v1.RefundOrderRequest
+	(*RefundOrderResponse)(nil),                    // 40: oms.v1.RefundOrderResponse
+	(*HoldOrderRequest)(nil),                       // 41: oms.v1.HoldOrderRequest
+	(*HoldOrderResponse)(nil),                      // 42: oms.v1.HoldOrderResponse
+	(*ReleaseOrderRequest)(nil),                    // 43: oms.v1.ReleaseOrderRequest
+	(*ReleaseOrderResponse)(nil),                   // 44: oms.v1.ReleaseOrderResponse
+	(*ApproveOrderRequest)(nil),                    // 45: oms.v1.ApproveOrderRequest
+	(*ApproveOrderResponse)(nil),                   // 46: oms.v1.ApproveOrderResponse
+	(*RejectOrderRequest)(nil),                     // 47: oms.v1.RejectOrderRequest
+	(*RejectOrderResponse)(nil),                    // 48: oms.v1.RejectOrderResponse
+	(*ScheduledCancel)(nil),                        // 49: oms.v1.ScheduledCancel
+	(*ScheduleCancelRequest)(nil),                  // 50: oms.v1.ScheduleCancelRequest
+	(*ScheduleCancelResponse)(nil),                 // 51: oms.v1.ScheduleCancelResponse
+	(*ListScheduledCancelsRequest)(nil),            // 52: oms.v1.ListScheduledCancelsRequest
+	(*ListScheduledCancelsResponse)(nil),           // 53: oms.v1.ListScheduledCancelsResponse
+	(*DeleteScheduledCancelRequest)(nil),           // 54: oms.v1.DeleteScheduledCancelRequest
+	(*DeleteScheduledCancelResponse)(nil),          // 55: oms.v1.DeleteScheduledCancelResponse
+	(*ReturnItem)(nil),                             // 56: oms.v1.ReturnItem
+	(*OrderReturn)(nil),                            // 57: oms.v1.OrderReturn
+	(*CreateReturnRequest)(nil),                    // 58: oms.v1.CreateReturnRequest
+	(*CreateReturnResponse)(nil),                   // 59: oms.v1.CreateReturnResponse
+	(*GetReturnRequest)(nil),                       // 60: oms.v1.GetReturnRequest
+	(*GetReturnResponse)(nil),                      // 61: oms.v1.GetReturnResponse
+	(*AdvanceReturnRequest)(nil),                   // 62: oms.v1.AdvanceReturnRequest
+	(*AdvanceReturnResponse)(nil),                  // 63: oms.v1.AdvanceReturnResponse
+	(*PageLimits)(nil),                             // 64: oms.v1.PageLimits
+	(*GetServiceInfoRequest)(nil),                  // 65: oms.v1.GetServiceInfoRequest
+	(*GetServiceInfoResponse)(nil),                 // 66: oms.v1.GetServiceInfoResponse
+	(*RegisterCourierRequest)(nil),                 // 67: oms.v1.RegisterCourierRequest
+	(*RegisterCourierResponse)(nil),                // 68: oms.v1.RegisterCourierResponse
+	(*GetCourierRequest)(nil),                      // 69: oms.v1.GetCourierRequest
+	(*GetCourierResponse)(nil),                     // 70: oms.v1.GetCourierResponse
+	(*ListCouriersByZoneRequest)(nil),              // 71: oms.v1.ListCouriersByZoneRequest
+	(*ListCouriersByZoneResponse)(nil),             // 72: oms.v1.ListCouriersByZoneResponse
+	(*ReplaceCourierZonesRequest)(nil),             // 73: oms.v1.ReplaceCourierZonesRequest
+	(*ReplaceCourierZonesResponse)(nil),            // 74: oms.v1.ReplaceCourierZonesResponse
+	(*CreateCourierSlotRequest)(nil),               // 75: oms.v1.CreateCourierSlotRequest
+	(*CreateCourierSlotResponse)(nil),              // 76: oms.v1.CreateCourierSlotResponse
+	(*ListCourierSlotsRequest)(nil),                // 77: oms.v1.ListCourierSlotsRequest
+	(*ListCourierSlotsResponse)(nil),               // 78: oms.v1.ListCourierSlotsResponse
+	(*GetCourierVehicleCapabilityRequest)(nil),     // 79: oms.v1.GetCourierVehicleCapabilityRequest
+	(*GetCourierVehicleCapabilityResponse)(nil),    // 80: oms.v1.GetCourierVehicleCapabilityResponse
+	(*ListCourierVehicleCapabilitiesRequest)(nil),  // 81: oms.v1.ListCourierVehicleCapabilitiesRequest
+	(*ListCourierVehicleCapabilitiesResponse)(nil), // 82: oms.v1.ListCourierVehicleCapabilitiesResponse
+	(*SubmitCourierRatingRequest)(nil),             // 83: oms.v1.SubmitCourierRatingRequest
+	(*SubmitCourierRatingResponse)(nil),            // 84: oms.v1.SubmitCourierRatingResponse
+	(*GetCourierRatingSummaryRequest)(nil),         // 85: oms.v1.GetCourierRatingSummaryRequest
+	(*CourierRatingSummary)(nil),                   // 86: oms.v1.CourierRatingSummary
+	(*GetCourierRatingSummaryResponse)(nil),        // 87: oms.v1.GetCourierRatingSummaryResponse
+	(*DeleteCustomerDataRequest)(nil),              // 88: oms.v1.DeleteCustomerDataRequest
+	(*DeleteCustomerDataResponse)(nil),             // 89: oms.v1.DeleteCustomerDataResponse
+	(*GetQuotaUsageRequest)(nil),                   // 90: oms.v1.GetQuotaUsageRequest
+	(*QuotaAmountUsage)(nil),                       // 91: oms.v1.QuotaAmountUsage
+	(*GetQuotaUsageResponse)(nil),                  // 92: oms.v1.GetQuotaUsageResponse
+	(*GetEventsSinceRequest)(nil),                  // 93: oms.v1.GetEventsSinceRequest
+	(*FeedEvent)(nil),                              // 94: oms.v1.FeedEvent
+	(*GetEventsSinceResponse)(nil),                 // 95: oms.v1.GetEventsSinceResponse
+	(*GetLogLevelsRequest)(nil),                    // 96: oms.v1.GetLogLevelsRequest
+	(*SetLogLevelRequest)(nil),                     // 97: oms.v1.SetLogLevelRequest
+	(*LogLevels)(nil),                              // 98: oms.v1.LogLevels
+	(*RecalculateOrderRequest)(nil),                // 99: oms.v1.RecalculateOrderRequest
+	(*ForceTransitionRequest)(nil),                 // 100: oms.v1.ForceTransitionRequest
+	(*ForceTransitionResponse)(nil),                // 101: oms.v1.ForceTransitionResponse
+	(*ItemPriceChange)(nil),                        // 102: oms.v1.ItemPriceChange
+	(*RecalculateOrderResponse)(nil),               // 103: oms.v1.RecalculateOrderResponse
+	nil,                                            // 104: oms.v1.LogLevels.ComponentsEntry
+}
+var file_proto_oms_v1_order_service_proto_depIdxs = []int32{
+	8,   // 0: oms.v1.OrderItem.price:type_name -> oms.v1.Money
+	0,   // 1: oms.v1.Order.status:type_name -> oms.v1.OrderStatus
+	8,   // 2: oms.v1.Order.amount:type_name -> oms.v1.Money
+	9,   // 3: oms.v1.Order.items:type_name -> oms.v1.OrderItem
+	12,  // 4: oms.v1.Order.amounts:type_name -> oms.v1.OrderAmounts
+	1,   // 5: oms.v1.PriceAdjustment.type:type_name -> oms.v1.AdjustmentType
+	8,   // 6: oms.v1.PriceAdjustment.fixed:type_name -> oms.v1.Money
+	8,   // 7: oms.v1.PriceAdjustment.applied:type_name -> oms.v1.Money
+	8,   // 8: oms.v1.OrderAmounts.subtotal:type_name -> oms.v1.Money
+	8,   // 9: oms.v1.OrderAmounts.discount:type_name -> oms.v1.Money
+	8,   // 10: oms.v1.OrderAmounts.tax:type_name -> oms.v1.Money
+	8,   // 11: oms.v1.OrderAmounts.total:type_name -> oms.v1.Money
+	11,  // 12: oms.v1.OrderAmounts.adjustments:type_name -> oms.v1.PriceAdjustment
+	2,   // 13: oms.v1.OrderAmounts.rounding_mode:type_name -> oms.v1.RoundingMode
+	3,   // 14: oms.v1.Courier.vehicle_type:type_name -> oms.v1.CourierVehicleType
+	15,  // 15: oms.v1.Courier.zones:type_name -> oms.v1.CourierZone
+	4,   // 16: oms.v1.CourierSlot.status:type_name -> oms.v1.CourierSlotStatus
+	3,   // 17: oms.v1.CourierVehicleCapability.vehicle_type:type_name -> oms.v1.CourierVehicleType
+	9,   // 18: oms.v1.CreateOrderRequest.items:type_name -> oms.v1.OrderItem
+	11,  // 19: oms.v1.CreateOrderRequest.adjustments:type_name -> oms.v1.PriceAdjustment
+	10,  // 20: oms.v1.CreateOrderResponse.order:type_name -> oms.v1.Order
+	10,  // 21: oms.v1.GetOrderResponse.order:type_name -> oms.v1.Order
+	13,  // 22: oms.v1.GetOrderResponse.timeline:type_name -> oms.v1.TimelineEvent
+	10,  // 23: oms.v1.GetOrdersResponse.orders:type_name -> oms.v1.Order
+	13,  // 24: oms.v1.StreamOrderTimelineResponse.event:type_name -> oms.v1.TimelineEvent
+	10,  // 25: oms.v1.WatchOrderResponse.order:type_name -> oms.v1.Order
+	13,  // 26: oms.v1.WatchOrderResponse.event:type_name -> oms.v1.TimelineEvent
+	0,   // 27: oms.v1.ListOrdersRequest.filter_statuses:type_name -> oms.v1.OrderStatus
+	10,  // 28: oms.v1.ListOrdersResponse.orders:type_name -> oms.v1.Order
+	9,   // 29: oms.v1.UpdateOrderRequest.items:type_name -> oms.v1.OrderItem
+	10,  // 30: oms.v1.UpdateOrderResponse.order:type_name -> oms.v1.Order
+	0,   // 31: oms.v1.PayOrderResponse.status:type_name -> oms.v1.OrderStatus
+	0,   // 32: oms.v1.CaptureOrderResponse.status:type_name -> oms.v1.OrderStatus
+	0,   // 33: oms.v1.CancelOrderResponse.status:type_name -> oms.v1.OrderStatus
+	8,   // 34: oms.v1.RefundOrderRequest.amount:type_name -> oms.v1.Money
+	0,   // 35: oms.v1.RefundOrderResponse.status:type_name -> oms.v1.OrderStatus
+	0,   // 36: oms.v1.HoldOrderResponse.status:type_name -> oms.v1.OrderStatus
+	0,   // 37: oms.v1.ReleaseOrderResponse.status:type_name -> oms.v1.OrderStatus
+	0,   // 38: oms.v1.ApproveOrderResponse.status:type_name -> oms.v1.OrderStatus
+	0,   // 39: oms.v1.RejectOrderResponse.status:type_name -> oms.v1.OrderStatus
+	6,   // 40: oms.v1.ScheduledCancel.status:type_name -> oms.v1.ScheduledCancelStatus
+	49,  // 41: oms.v1.ScheduleCancelResponse.scheduled_cancel:type_name -> oms.v1.ScheduledCancel
+	49,  // 42: oms.v1.ListScheduledCancelsResponse.scheduled_cancels:type_name -> oms.v1.ScheduledCancel
+	49,  // 43: oms.v1.DeleteScheduledCancelResponse.scheduled_cancel:type_name -> oms.v1.ScheduledCancel
+	8,   // 44: oms.v1.ReturnItem.refund:type_name -> oms.v1.Money
+	7,   // 45: oms.v1.OrderReturn.status:type_name -> oms.v1.ReturnStatus
+	56,  // 46: oms.v1.OrderReturn.items:type_name -> oms.v1.ReturnItem
+	8,   // 47: oms.v1.OrderReturn.refund:type_name -> oms.v1.Money
+	56,  // 48: oms.v1.CreateReturnRequest.items:type_name -> oms.v1.ReturnItem
+	57,  // 49: oms.v1.CreateReturnResponse.order_return:type_name -> oms.v1.OrderReturn
+	57,  // 50: oms.v1.GetReturnResponse.order_return:type_name -> oms.v1.OrderReturn
+	7,   // 51: oms.v1.AdvanceReturnRequest.status:type_name -> oms.v1.ReturnStatus
+	57,  // 52: oms.v1.AdvanceReturnResponse.order_return:type_name -> oms.v1.OrderReturn
+	64,  // 53: oms.v1.GetServiceInfoResponse.page_limits:type_name -> oms.v1.PageLimits
+	3,   // 54: oms.v1.RegisterCourierRequest.vehicle_type:type_name -> oms.v1.CourierVehicleType
+	14,  // 55: oms.v1.RegisterCourierRequest.zones:type_name -> oms.v1.CourierZoneInput
+	16,  // 56: oms.v1.RegisterCourierResponse.courier:type_name -> oms.v1.Courier
+	16,  // 57: oms.v1.GetCourierResponse.courier:type_name -> oms.v1.Courier
+	16,  // 58: oms.v1.ListCouriersByZoneResponse.couriers:type_name -> oms.v1.Courier
+	14,  // 59: oms.v1.ReplaceCourierZonesRequest.zones:type_name -> oms.v1.CourierZoneInput
+	15,  // 60: oms.v1.ReplaceCourierZonesResponse.zones:type_name -> oms.v1.CourierZone
+	17,  // 61: oms.v1.CreateCourierSlotResponse.slot:type_name -> oms.v1.CourierSlot
+	17,  // 62: oms.v1.ListCourierSlotsResponse.slots:type_name -> oms.v1.CourierSlot
+	3,   // 63: oms.v1.GetCourierVehicleCapabilityRequest.vehicle_type:type_name -> oms.v1.CourierVehicleType
+	18,  // 64: oms.v1.GetCourierVehicleCapabilityResponse.capability:type_name -> oms.v1.CourierVehicleCapability
+	18,  // 65: oms.v1.ListCourierVehicleCapabilitiesResponse.capabilities:type_name -> oms.v1.CourierVehicleCapability
+	5,   // 66: oms.v1.SubmitCourierRatingRequest.tags:type_name -> oms.v1.CourierRatingTag
+	86,  // 67: oms.v1.GetCourierRatingSummaryResponse.summary:type_name -> oms.v1.CourierRatingSummary
+	91,  // 68: oms.v1.GetQuotaUsageResponse.amounts:type_name -> oms.v1.QuotaAmountUsage
+	94,  // 69: oms.v1.GetEventsSinceResponse.events:type_name -> oms.v1.FeedEvent
+	104, // 70: oms.v1.LogLevels.components:type_name -> oms.v1.LogLevels.ComponentsEntry
+	0,   // 71: oms.v1.ForceTransitionRequest.target_status:type_name -> oms.v1.OrderStatus
+	10,  // 72: oms.v1.ForceTransitionResponse.order:type_name -> oms.v1.Order
+	0,   // 73: oms.v1.ForceTransitionResponse.previous_status:type_name -> oms.v1.OrderStatus
+	8,   // 74: oms.v1.ItemPriceChange.old_price:type_name -> oms.v1.Money
+	8,   // 75: oms.v1.ItemPriceChange.new_price:type_name -> oms.v1.Money
+	10,  // 76: oms.v1.RecalculateOrderResponse.order:type_name -> oms.v1.Order
+	8,   // 77: oms.v1.RecalculateOrderResponse.previous_total:type_name -> oms.v1.Money
+	102, // 78: oms.v1.RecalculateOrderResponse.changes:type_name -> oms.v1.ItemPriceChange
+	19,  // 79: oms.v1.OrderService.CreateOrder:input_type -> oms.v1.CreateOrderRequest
+	21,  // 80: oms.v1.OrderService.GetOrder:input_type -> oms.v1.GetOrderRequest
+	23,  // 81: oms.v1.OrderService.GetOrders:input_type -> oms.v1.GetOrdersRequest
+	25,  // 82: oms.v1.OrderService.StreamOrderTimeline:input_type -> oms.v1.StreamOrderTimelineRequest
+	27,  // 83: oms.v1.OrderService.WatchOrder:input_type -> oms.v1.WatchOrderRequest
+	29,  // 84: oms.v1.OrderService.ListOrders:input_type -> oms.v1.ListOrdersRequest
+	31,  // 85: oms.v1.OrderService.UpdateOrder:input_type -> oms.v1.UpdateOrderRequest
+	33,  // 86: oms.v1.OrderService.PayOrder:input_type -> oms.v1.PayOrderRequest
+	35,  // 87: oms.v1.OrderService.CaptureOrder:input_type -> oms.v1.CaptureOrderRequest
+	37,  // 88: oms.v1.OrderService.CancelOrder:input_type -> oms.v1.CancelOrderRequest
+	39,  // 89: oms.v1.OrderService.RefundOrder:input_type -> oms.v1.RefundOrderRequest
+	41,  // 90: oms.v1.OrderService.HoldOrder:input_type -> oms.v1.HoldOrderRequest
+	43,  // 91: oms.v1.OrderService.ReleaseOrder:input_type -> oms.v1.ReleaseOrderRequest
+	45,  // 92: oms.v1.OrderService.ApproveOrder:input_type -> oms.v1.ApproveOrderRequest
+	47,  // 93: oms.v1.OrderService.RejectOrder:input_type -> oms.v1.RejectOrderRequest
+	50,  // 94: oms.v1.OrderService.ScheduleCancel:input_type -> oms.v1.ScheduleCancelRequest
+	52,  // 95: oms.v1.OrderService.ListScheduledCancels:input_type -> oms.v1.ListScheduledCancelsRequest
+	54,  // 96: oms.v1.OrderService.DeleteScheduledCancel:input_type -> oms.v1.DeleteScheduledCancelRequest
+	58,  // 97: oms.v1.OrderService.CreateReturn:input_type -> oms.v1.CreateReturnRequest
+	60,  // 98: oms.v1.OrderService.GetReturn:input_type -> oms.v1.GetReturnRequest
+	62,  // 99: oms.v1.OrderService.AdvanceReturn:input_type -> oms.v1.AdvanceReturnRequest
+	65,  // 100: oms.v1.OrderService.GetServiceInfo:input_type -> oms.v1.GetServiceInfoRequest
+	67,  // 101: oms.v1.CourierService.RegisterCourier:input_type -> oms.v1.RegisterCourierRequest
+	69,  // 102: oms.v1.CourierService.GetCourier:input_type -> oms.v1.GetCourierRequest
+	71,  // 103: oms.v1.CourierService.ListCouriersByZone:input_type -> oms.v1.ListCouriersByZoneRequest
+	73,  // 104: oms.v1.CourierService.ReplaceCourierZones:input_type -> oms.v1.ReplaceCourierZonesRequest
+	75,  // 105: oms.v1.CourierService.CreateCourierSlot:input_type -> oms.v1.CreateCourierSlotRequest
+	77,  // 106: oms.v1.CourierService.ListCourierSlots:input_type -> oms.v1.ListCourierSlotsRequest
+	79,  // 107: oms.v1.CourierService.GetCourierVehicleCapability:input_type -> oms.v1.GetCourierVehicleCapabilityRequest
+	81,  // 108: oms.v1.CourierService.ListCourierVehicleCapabilities:input_type -> oms.v1.ListCourierVehicleCapabilitiesRequest
+	83,  // 109: oms.v1.CourierService.SubmitCourierRating:input_type -> oms.v1.SubmitCourierRatingRequest
+	85,  // 110: oms.v1.CourierService.GetCourierRatingSummary:input_type -> oms.v1.GetCourierRatingSummaryRequest
+	88,  // 111: oms.v1.AdminService.DeleteCustomerData:input_type -> oms.v1.DeleteCustomerDataRequest
+	90,  // 112: oms.v1.AdminService.GetQuotaUsage:input_type -> oms.v1.GetQuotaUsageRequest
+	93,  // 113: oms.v1.AdminService.GetEventsSince:input_type -> oms.v1.GetEventsSinceRequest
+	96,  // 114: oms.v1.AdminService.GetLogLevels:input_type -> oms.v1.GetLogLevelsRequest
+	97,  // 115: oms.v1.AdminService.SetLogLevel:input_type -> oms.v1.SetLogLevelRequest
+	99,  // 116: oms.v1.AdminService.RecalculateOrder:input_type -> oms.v1.RecalculateOrderRequest
+	100, // 117: oms.v1.AdminService.ForceTransition:input_type -> oms.v1.ForceTransitionRequest
+	20,  // 118: oms.v1.OrderService.CreateOrder:output_type -> oms.v1.CreateOrderResponse
+	22,  // 119: oms.v1.OrderService.GetOrder:output_type -> oms.v1.GetOrderResponse
+	24,  // 120: oms.v1.OrderService.GetOrders:output_type -> oms.v1.GetOrdersResponse
+	26,  // 121: oms.v1.OrderService.StreamOrderTimeline:output_type -> oms.v1.StreamOrderTimelineResponse
+	28,  // 122: oms.v1.OrderService.WatchOrder:output_type -> oms.v1.WatchOrderResponse
+	30,  // 123: oms.v1.OrderService.ListOrders:output_type -> oms.v1.ListOrdersResponse
+	32,  // 124: oms.v1.OrderService.UpdateOrder:output_type -> oms.v1.UpdateOrderResponse
+	34,  // 125: oms.v1.OrderService.PayOrder:output_type -> oms.v1.PayOrderResponse
+	36,  // 126: oms.v1.OrderService.CaptureOrder:output_type -> oms.v1.CaptureOrderResponse
+	38,  // 127: oms.v1.OrderService.CancelOrder:output_type -> oms.v1.CancelOrderResponse
+	40,  // 128: oms.v1.OrderService.RefundOrder:output_type -> oms.v1.RefundOrderResponse
+	42,  // 129: oms.v1.OrderService.HoldOrder:output_type -> oms.v1.HoldOrderResponse
+	44,  // 130: oms.v1.OrderService.ReleaseOrder:output_type -> oms.v1.ReleaseOrderResponse
+	46,  // 131: oms.v1.OrderService.ApproveOrder:output_type -> oms.v1.ApproveOrderResponse
+	48,  // 132: oms.v1.OrderService.RejectOrder:output_type -> oms.v1.RejectOrderResponse
+	51,  // 133: oms.v1.OrderService.ScheduleCancel:output_type -> oms.v1.ScheduleCancelResponse
+	53,  // 134: oms.v1.OrderService.ListScheduledCancels:output_type -> oms.v1.ListScheduledCancelsResponse
+	55,  // 135: oms.v1.OrderService.DeleteScheduledCancel:output_type -> oms.v1.DeleteScheduledCancelResponse
+	59,  // 136: oms.v1.OrderService.CreateReturn:output_type -> oms.v1.CreateReturnResponse
+	61,  // 137: oms.v1.OrderService.GetReturn:output_type -> oms.v1.GetReturnResponse
+	63,  // 138: oms.v1.OrderService.AdvanceReturn:output_type -> oms.v1.AdvanceReturnResponse
+	66,  // 139: oms.v1.OrderService.GetServiceInfo:output_type -> oms.v1.GetServiceInfoResponse
+	68,  // 140: oms.v1.CourierService.RegisterCourier:output_type -> oms.v1.RegisterCourierResponse
+	70,  // 141: oms.v1.CourierService.GetCourier:output_type -> oms.v1.GetCourierResponse
+	72,  // 142: oms.v1.CourierService.ListCouriersByZone:output_type -> oms.v1.ListCouriersByZoneResponse
+	74,  // 143: oms.v1.CourierService.ReplaceCourierZones:output_type -> oms.v1.ReplaceCourierZonesResponse
+	76,  // 144: oms.v1.CourierService.CreateCourierSlot:output_type -> oms.v1.CreateCourierSlotResponse
+	78,  // 145: oms.v1.CourierService.ListCourierSlots:output_type -> oms.v1.ListCourierSlotsResponse
+	80,  // 146: oms.v1.CourierService.GetCourierVehicleCapability:output_type -> oms.v1.GetCourierVehicleCapabilityResponse
+	82,  // 147: oms.v1.CourierService.ListCourierVehicleCapabilities:output_type -> oms.v1.ListCourierVehicleCapabilitiesResponse
+	84,  // 148: oms.v1.CourierService.SubmitCourierRating:output_type -> oms.v1.SubmitCourierRatingResponse
+	87,  // 149: oms.v1.CourierService.GetCourierRatingSummary:output_type -> oms.v1.GetCourierRatingSummaryResponse
+	89,  // 150: oms.v1.AdminService.DeleteCustomerData:output_type -> oms.v1.DeleteCustomerDataResponse
+	92,  // 151: oms.v1.AdminService.GetQuotaUsage:output_type -> oms.v1.GetQuotaUsageResponse
+	95,  // 152: oms.v1.AdminService.GetEventsSince:output_type -> oms.v1.GetEventsSinceResponse
+	98,  // 153: oms.v1.AdminService.GetLogLevels:output_type -> oms.v1.LogLevels
+	98,  // 154: oms.v1.AdminService.SetLogLevel:output_type -> oms.v1.LogLevels
+	103, // 155: oms.v1.AdminService.RecalculateOrder:output_type -> oms.v1.RecalculateOrderResponse
+	101, // 156: oms.v1.AdminService.ForceTransition:output_type -> oms.v1.ForceTransitionResponse
+	118, // [118:157] is the sub-list for method output_type
+	79,  // [79:118] is the sub-list for method input_type
+	79,  // [79:79] is the sub-list for extension type_name
+	79,  // [79:79] is the sub-list for extension extendee
+	0,   // [0:79] is the sub-list for field type_name
+}
+
+func init() { file_proto_oms_v1_order_service_proto_init() }
+func file_proto_oms_v1_order_service_proto_init() {
+	if File_proto_oms_v1_order_service_proto != nil {
+		return
+	}
+	if !protoimpl.UnsafeEnabled {
+		file_proto_oms_v1_order_service_proto_msgTypes[0].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*Money); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_proto_oms_v1_order_service_proto_msgTypes[1].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*OrderItem); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_proto_oms_v1_order_service_proto_msgTypes[2].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*Order); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_proto_oms_v1_order_service_proto_msgTypes[3].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*PriceAdjustment); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_proto_oms_v1_order_service_proto_msgTypes[4].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*OrderAmounts); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_proto_oms_v1_order_service_proto_msgTypes[5].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*TimelineEvent); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_proto_oms_v1_order_service_proto_msgTypes[6].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*CourierZoneInput); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_proto_oms_v1_order_service_proto_msgTypes[7].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*CourierZone); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_proto_oms_v1_order_service_proto_msgTypes[8].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*Courier); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_proto_oms_v1_order_service_proto_msgTypes[9].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*CourierSlot); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_proto_oms_v1_order_service_proto_msgTypes[10].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*CourierVehicleCapability); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_proto_oms_v1_order_service_proto_msgTypes[11].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*CreateOrderRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_proto_oms_v1_order_service_proto_msgTypes[12].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*CreateOrderResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_proto_oms_v1_order_service_proto_msgTypes[13].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*GetOrderRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_proto_oms_v1_order_service_proto_msgTypes[14].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*GetOrderResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_proto_oms_v1_order_service_proto_msgTypes[15].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*GetOrdersRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_proto_oms_v1_order_service_proto_msgTypes[16].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*GetOrdersResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_proto_oms_v1_order_service_proto_msgTypes[17].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*StreamOrderTimelineRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_proto_oms_v1_order_service_proto_msgTypes[18].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*StreamOrderTimelineResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_proto_oms_v1_order_service_proto_msgTypes[19].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*WatchOrderRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_proto_oms_v1_order_service_proto_msgTypes[20].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*WatchOrderResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_proto_oms_v1_order_service_proto_msgTypes[21].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ListOrdersRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_proto_oms_v1_order_service_proto_msgTypes[22].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ListOrdersResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_proto_oms_v1_order_service_proto_msgTypes[23].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*UpdateOrderRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_proto_oms_v1_order_service_proto_msgTypes[24].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*UpdateOrderResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_proto_oms_v1_order_service_proto_msgTypes[25].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*PayOrderRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_proto_oms_v1_order_service_proto_msgTypes[26].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*PayOrderResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_proto_oms_v1_order_service_proto_msgTypes[27].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*CaptureOrderRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_proto_oms_v1_order_service_proto_msgTypes[28].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*CaptureOrderResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_proto_oms_v1_order_service_proto_msgTypes[29].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*CancelOrderRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_proto_oms_v1_order_service_proto_msgTypes[30].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*CancelOrderResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_proto_oms_v1_order_service_proto_msgTypes[31].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*RefundOrderRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_proto_oms_v1_order_service_proto_msgTypes[32].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*RefundOrderResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_proto_oms_v1_order_service_proto_msgTypes[33].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*HoldOrderRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_proto_oms_v1_order_service_proto_msgTypes[34].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*HoldOrderResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_proto_oms_v1_order_service_proto_msgTypes[35].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ReleaseOrderRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_proto_oms_v1_order_service_proto_msgTypes[36].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ReleaseOrderResponse); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -3512,8 +8305,8 @@ func file_proto_oms_v1_order_service_proto_init() {
 				return nil
 			}
 		}
-		file_proto_oms_v1_order_service_proto_msgTypes[1].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*OrderItem); i {
+		file_proto_oms_v1_order_service_proto_msgTypes[37].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ApproveOrderRequest); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -3524,8 +8317,8 @@ func file_proto_oms_v1_order_service_proto_init() {
 				return nil
 			}
 		}
-		file_proto_oms_v1_order_service_proto_msgTypes[2].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*Order); i {
+		file_proto_oms_v1_order_service_proto_msgTypes[38].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ApproveOrderResponse); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -3536,8 +8329,8 @@ func file_proto_oms_v1_order_service_proto_init() {
 				return nil
 			}
 		}
-		file_proto_oms_v1_order_service_proto_msgTypes[3].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*TimelineEvent); i {
+		file_proto_oms_v1_order_service_proto_msgTypes[39].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*RejectOrderRequest); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -3548,8 +8341,8 @@ func file_proto_oms_v1_order_service_proto_init() {
 				return nil
 			}
 		}
-		file_proto_oms_v1_order_service_proto_msgTypes[4].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*CourierZoneInput); i {
+		file_proto_oms_v1_order_service_proto_msgTypes[40].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*RejectOrderResponse); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -3560,8 +8353,8 @@ func file_proto_oms_v1_order_service_proto_init() {
 				return nil
 			}
 		}
-		file_proto_oms_v1_order_service_proto_msgTypes[5].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*CourierZone); i {
+		file_proto_oms_v1_order_service_proto_msgTypes[41].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ScheduledCancel); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -3572,8 +8365,8 @@ func file_proto_oms_v1_order_service_proto_init() {
 				return nil
 			}
 		}
-		file_proto_oms_v1_order_service_proto_msgTypes[6].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*Courier); i {
+		file_proto_oms_v1_order_service_proto_msgTypes[42].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ScheduleCancelRequest); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -3584,8 +8377,8 @@ func file_proto_oms_v1_order_service_proto_init() {
 				return nil
 			}
 		}
-		file_proto_oms_v1_order_service_proto_msgTypes[7].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*CourierSlot); i {
+		file_proto_oms_v1_order_service_proto_msgTypes[43].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ScheduleCancelResponse); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -3596,8 +8389,8 @@ func file_proto_oms_v1_order_service_proto_init() {
 				return nil
 			}
 		}
-		file_proto_oms_v1_order_service_proto_msgTypes[8].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*CourierVehicleCapability); i {
+		file_proto_oms_v1_order_service_proto_msgTypes[44].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ListScheduledCancelsRequest); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -3608,8 +8401,8 @@ func file_proto_oms_v1_order_service_proto_init() {
 				return nil
 			}
 		}
-		file_proto_oms_v1_order_service_proto_msgTypes[9].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*CreateOrderRequest); i {
+		file_proto_oms_v1_order_service_proto_msgTypes[45].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ListScheduledCancelsResponse); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -3620,8 +8413,8 @@ func file_proto_oms_v1_order_service_proto_init() {
 				return nil
 			}
 		}
-		file_proto_oms_v1_order_service_proto_msgTypes[10].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*CreateOrderResponse); i {
+		file_proto_oms_v1_order_service_proto_msgTypes[46].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*DeleteScheduledCancelRequest); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -3632,8 +8425,8 @@ func file_proto_oms_v1_order_service_proto_init() {
 				return nil
 			}
 		}
-		file_proto_oms_v1_order_service_proto_msgTypes[11].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*GetOrderRequest); i {
+		file_proto_oms_v1_order_service_proto_msgTypes[47].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*DeleteScheduledCancelResponse); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -3644,8 +8437,8 @@ func file_proto_oms_v1_order_service_proto_init() {
 				return nil
 			}
 		}
-		file_proto_oms_v1_order_service_proto_msgTypes[12].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*GetOrderResponse); i {
+		file_proto_oms_v1_order_service_proto_msgTypes[48].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ReturnItem); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -3656,8 +8449,8 @@ func file_proto_oms_v1_order_service_proto_init() {
 				return nil
 			}
 		}
-		file_proto_oms_v1_order_service_proto_msgTypes[13].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*ListOrdersRequest); i {
+		file_proto_oms_v1_order_service_proto_msgTypes[49].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*OrderReturn); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -3668,8 +8461,8 @@ func file_proto_oms_v1_order_service_proto_init() {
 				return nil
 			}
 		}
-		file_proto_oms_v1_order_service_proto_msgTypes[14].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*ListOrdersResponse); i {
+		file_proto_oms_v1_order_service_proto_msgTypes[50].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*CreateReturnRequest); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -3680,8 +8473,8 @@ func file_proto_oms_v1_order_service_proto_init() {
 				return nil
 			}
 		}
-		file_proto_oms_v1_order_service_proto_msgTypes[15].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*PayOrderRequest); i {
+		file_proto_oms_v1_order_service_proto_msgTypes[51].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*CreateReturnResponse); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -3692,8 +8485,8 @@ func file_proto_oms_v1_order_service_proto_init() {
 				return nil
 			}
 		}
-		file_proto_oms_v1_order_service_proto_msgTypes[16].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*PayOrderResponse); i {
+		file_proto_oms_v1_order_service_proto_msgTypes[52].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*GetReturnRequest); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -3704,8 +8497,8 @@ func file_proto_oms_v1_order_service_proto_init() {
 				return nil
 			}
 		}
-		file_proto_oms_v1_order_service_proto_msgTypes[17].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*CancelOrderRequest); i {
+		file_proto_oms_v1_order_service_proto_msgTypes[53].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*GetReturnResponse); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -3716,8 +8509,8 @@ func file_proto_oms_v1_order_service_proto_init() {
 				return nil
 			}
 		}
-		file_proto_oms_v1_order_service_proto_msgTypes[18].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*CancelOrderResponse); i {
+		file_proto_oms_v1_order_service_proto_msgTypes[54].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*AdvanceReturnRequest); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -3728,8 +8521,8 @@ func file_proto_oms_v1_order_service_proto_init() {
 				return nil
 			}
 		}
-		file_proto_oms_v1_order_service_proto_msgTypes[19].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*RefundOrderRequest); i {
+		file_proto_oms_v1_order_service_proto_msgTypes[55].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*AdvanceReturnResponse); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -3740,8 +8533,8 @@ func file_proto_oms_v1_order_service_proto_init() {
 				return nil
 			}
 		}
-		file_proto_oms_v1_order_service_proto_msgTypes[20].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*RefundOrderResponse); i {
+		file_proto_oms_v1_order_service_proto_msgTypes[56].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*PageLimits); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -3752,7 +8545,31 @@ func file_proto_oms_v1_order_service_proto_init() {
 				return nil
 			}
 		}
-		file_proto_oms_v1_order_service_proto_msgTypes[21].Exporter = func(v interface{}, i int) interface{} {
+		file_proto_oms_v1_order_service_proto_msgTypes[57].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*GetServiceInfoRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_proto_oms_v1_order_service_proto_msgTypes[58].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*GetServiceInfoResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_proto_oms_v1_order_service_proto_msgTypes[59].Exporter = func(v interface{}, i int) interface{} {
 			switch v := v.(*RegisterCourierRequest); i {
 			case 0:
 				return &v.state
@@ -3764,7 +8581,7 @@ func file_proto_oms_v1_order_service_proto_init() {
 				return nil
 			}
 		}
-		file_proto_oms_v1_order_service_proto_msgTypes[22].Exporter = func(v interface{}, i int) interface{} {
+		file_proto_oms_v1_order_service_proto_msgTypes[60].Exporter = func(v interface{}, i int) interface{} {
 			switch v := v.(*RegisterCourierResponse); i {
 			case 0:
 				return &v.state
@@ -3776,7 +8593,7 @@ func file_proto_oms_v1_order_service_proto_init() {
 				return nil
 			}
 		}
-		file_proto_oms_v1_order_service_proto_msgTypes[23].Exporter = func(v interface{}, i int) interface{} {
+		file_proto_oms_v1_order_service_proto_msgTypes[61].Exporter = func(v interface{}, i int) interface{} {
 			switch v := v.(*GetCourierRequest); i {
 			case 0:
 				return &v.state
@@ -3788,7 +8605,7 @@ func file_proto_oms_v1_order_service_proto_init() {
 				return nil
 			}
 		}
-		file_proto_oms_v1_order_service_proto_msgTypes[24].Exporter = func(v interface{}, i int) interface{} {
+		file_proto_oms_v1_order_service_proto_msgTypes[62].Exporter = func(v interface{}, i int) interface{} {
 			switch v := v.(*GetCourierResponse); i {
 			case 0:
 				return &v.state
@@ -3800,7 +8617,7 @@ func file_proto_oms_v1_order_service_proto_init() {
 				return nil
 			}
 		}
-		file_proto_oms_v1_order_service_proto_msgTypes[25].Exporter = func(v interface{}, i int) interface{} {
+		file_proto_oms_v1_order_service_proto_msgTypes[63].Exporter = func(v interface{}, i int) interface{} {
 			switch v := v.(*ListCouriersByZoneRequest); i {
 			case 0:
 				return &v.state
@@ -3812,7 +8629,7 @@ func file_proto_oms_v1_order_service_proto_init() {
 				return nil
 			}
 		}
-		file_proto_oms_v1_order_service_proto_msgTypes[26].Exporter = func(v interface{}, i int) interface{} {
+		file_proto_oms_v1_order_service_proto_msgTypes[64].Exporter = func(v interface{}, i int) interface{} {
 			switch v := v.(*ListCouriersByZoneResponse); i {
 			case 0:
 				return &v.state
@@ -3824,7 +8641,7 @@ func file_proto_oms_v1_order_service_proto_init() {
 				return nil
 			}
 		}
-		file_proto_oms_v1_order_service_proto_msgTypes[27].Exporter = func(v interface{}, i int) interface{} {
+		file_proto_oms_v1_order_service_proto_msgTypes[65].Exporter = func(v interface{}, i int) interface{} {
 			switch v := v.(*ReplaceCourierZonesRequest); i {
 			case 0:
 				return &v.state
@@ -3836,7 +8653,7 @@ func file_proto_oms_v1_order_service_proto_init() {
 				return nil
 			}
 		}
-		file_proto_oms_v1_order_service_proto_msgTypes[28].Exporter = func(v interface{}, i int) interface{} {
+		file_proto_oms_v1_order_service_proto_msgTypes[66].Exporter = func(v interface{}, i int) interface{} {
 			switch v := v.(*ReplaceCourierZonesResponse); i {
 			case 0:
 				return &v.state
@@ -3848,7 +8665,7 @@ func file_proto_oms_v1_order_service_proto_init() {
 				return nil
 			}
 		}
-		file_proto_oms_v1_order_service_proto_msgTypes[29].Exporter = func(v interface{}, i int) interface{} {
+		file_proto_oms_v1_order_service_proto_msgTypes[67].Exporter = func(v interface{}, i int) interface{} {
 			switch v := v.(*CreateCourierSlotRequest); i {
 			case 0:
 				return &v.state
@@ -3860,7 +8677,7 @@ func file_proto_oms_v1_order_service_proto_init() {
 				return nil
 			}
 		}
-		file_proto_oms_v1_order_service_proto_msgTypes[30].Exporter = func(v interface{}, i int) interface{} {
+		file_proto_oms_v1_order_service_proto_msgTypes[68].Exporter = func(v interface{}, i int) interface{} {
 			switch v := v.(*CreateCourierSlotResponse); i {
 			case 0:
 				return &v.state
@@ -3872,7 +8689,7 @@ func file_proto_oms_v1_order_service_proto_init() {
 				return nil
 			}
 		}
-		file_proto_oms_v1_order_service_proto_msgTypes[31].Exporter = func(v interface{}, i int) interface{} {
+		file_proto_oms_v1_order_service_proto_msgTypes[69].Exporter = func(v interface{}, i int) interface{} {
 			switch v := v.(*ListCourierSlotsRequest); i {
 			case 0:
 				return &v.state
@@ -3884,7 +8701,7 @@ func file_proto_oms_v1_order_service_proto_init() {
 				return nil
 			}
 		}
-		file_proto_oms_v1_order_service_proto_msgTypes[32].Exporter = func(v interface{}, i int) interface{} {
+		file_proto_oms_v1_order_service_proto_msgTypes[70].Exporter = func(v interface{}, i int) interface{} {
 			switch v := v.(*ListCourierSlotsResponse); i {
 			case 0:
 				return &v.state
@@ -3896,7 +8713,7 @@ func file_proto_oms_v1_order_service_proto_init() {
 				return nil
 			}
 		}
-		file_proto_oms_v1_order_service_proto_msgTypes[33].Exporter = func(v interface{}, i int) interface{} {
+		file_proto_oms_v1_order_service_proto_msgTypes[71].Exporter = func(v interface{}, i int) interface{} {
 			switch v := v.(*GetCourierVehicleCapabilityRequest); i {
 			case 0:
 				return &v.state
@@ -3908,7 +8725,7 @@ func file_proto_oms_v1_order_service_proto_init() {
 				return nil
 			}
 		}
-		file_proto_oms_v1_order_service_proto_msgTypes[34].Exporter = func(v interface{}, i int) interface{} {
+		file_proto_oms_v1_order_service_proto_msgTypes[72].Exporter = func(v interface{}, i int) interface{} {
 			switch v := v.(*GetCourierVehicleCapabilityResponse); i {
 			case 0:
 				return &v.state
@@ -3920,7 +8737,7 @@ func file_proto_oms_v1_order_service_proto_init() {
 				return nil
 			}
 		}
-		file_proto_oms_v1_order_service_proto_msgTypes[35].Exporter = func(v interface{}, i int) interface{} {
+		file_proto_oms_v1_order_service_proto_msgTypes[73].Exporter = func(v interface{}, i int) interface{} {
 			switch v := v.(*ListCourierVehicleCapabilitiesRequest); i {
 			case 0:
 				return &v.state
@@ -3932,7 +8749,7 @@ func file_proto_oms_v1_order_service_proto_init() {
 				return nil
 			}
 		}
-		file_proto_oms_v1_order_service_proto_msgTypes[36].Exporter = func(v interface{}, i int) interface{} {
+		file_proto_oms_v1_order_service_proto_msgTypes[74].Exporter = func(v interface{}, i int) interface{} {
 			switch v := v.(*ListCourierVehicleCapabilitiesResponse); i {
 			case 0:
 				return &v.state
@@ -3944,7 +8761,7 @@ func file_proto_oms_v1_order_service_proto_init() {
 				return nil
 			}
 		}
-		file_proto_oms_v1_order_service_proto_msgTypes[37].Exporter = func(v interface{}, i int) interface{} {
+		file_proto_oms_v1_order_service_proto_msgTypes[75].Exporter = func(v interface{}, i int) interface{} {
 			switch v := v.(*SubmitCourierRatingRequest); i {
 			case 0:
 				return &v.state
@@ -3956,7 +8773,7 @@ func file_proto_oms_v1_order_service_proto_init() {
 				return nil
 			}
 		}
-		file_proto_oms_v1_order_service_proto_msgTypes[38].Exporter = func(v interface{}, i int) interface{} {
+		file_proto_oms_v1_order_service_proto_msgTypes[76].Exporter = func(v interface{}, i int) interface{} {
 			switch v := v.(*SubmitCourierRatingResponse); i {
 			case 0:
 				return &v.state
@@ -3968,7 +8785,7 @@ func file_proto_oms_v1_order_service_proto_init() {
 				return nil
 			}
 		}
-		file_proto_oms_v1_order_service_proto_msgTypes[39].Exporter = func(v interface{}, i int) interface{} {
+		file_proto_oms_v1_order_service_proto_msgTypes[77].Exporter = func(v interface{}, i int) interface{} {
 			switch v := v.(*GetCourierRatingSummaryRequest); i {
 			case 0:
 				return &v.state
@@ -3980,7 +8797,7 @@ func file_proto_oms_v1_order_service_proto_init() {
 				return nil
 			}
 		}
-		file_proto_oms_v1_order_service_proto_msgTypes[40].Exporter = func(v interface{}, i int) interface{} {
+		file_proto_oms_v1_order_service_proto_msgTypes[78].Exporter = func(v interface{}, i int) interface{} {
 			switch v := v.(*CourierRatingSummary); i {
 			case 0:
 				return &v.state
@@ -3992,7 +8809,7 @@ func file_proto_oms_v1_order_service_proto_init() {
 				return nil
 			}
 		}
-		file_proto_oms_v1_order_service_proto_msgTypes[41].Exporter = func(v interface{}, i int) interface{} {
+		file_proto_oms_v1_order_service_proto_msgTypes[79].Exporter = func(v interface{}, i int) interface{} {
 			switch v := v.(*GetCourierRatingSummaryResponse); i {
 			case 0:
 				return &v.state
@@ -4004,16 +8821,208 @@ func file_proto_oms_v1_order_service_proto_init() {
 				return nil
 			}
 		}
+		file_proto_oms_v1_order_service_proto_msgTypes[80].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*DeleteCustomerDataRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_proto_oms_v1_order_service_proto_msgTypes[81].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*DeleteCustomerDataResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_proto_oms_v1_order_service_proto_msgTypes[82].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*GetQuotaUsageRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_proto_oms_v1_order_service_proto_msgTypes[83].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*QuotaAmountUsage); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_proto_oms_v1_order_service_proto_msgTypes[84].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*GetQuotaUsageResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_proto_oms_v1_order_service_proto_msgTypes[85].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*GetEventsSinceRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_proto_oms_v1_order_service_proto_msgTypes[86].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*FeedEvent); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_proto_oms_v1_order_service_proto_msgTypes[87].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*GetEventsSinceResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_proto_oms_v1_order_service_proto_msgTypes[88].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*GetLogLevelsRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_proto_oms_v1_order_service_proto_msgTypes[89].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*SetLogLevelRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_proto_oms_v1_order_service_proto_msgTypes[90].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*LogLevels); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_proto_oms_v1_order_service_proto_msgTypes[91].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*RecalculateOrderRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_proto_oms_v1_order_service_proto_msgTypes[92].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ForceTransitionRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_proto_oms_v1_order_service_proto_msgTypes[93].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ForceTransitionResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_proto_oms_v1_order_service_proto_msgTypes[94].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ItemPriceChange); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_proto_oms_v1_order_service_proto_msgTypes[95].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*RecalculateOrderResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
 	}
 	type x struct{}
 	out := protoimpl.TypeBuilder{
 		File: protoimpl.DescBuilder{
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: file_proto_oms_v1_order_service_proto_rawDesc,
-			NumEnums:      4,
-			NumMessages:   42,
+			NumEnums:      8,
+			NumMessages:   97,
 			NumExtensions: 0,
-			NumServices:   2,
+			NumServices:   3,
 		},
 		GoTypes:           file_proto_oms_v1_order_service_proto_goTypes,
 		DependencyIndexes: file_proto_oms_v1_order_service_proto_depIdxs,
@@ -19,12 +19,28 @@ import (
 const _ = grpc.SupportPackageIsVersion8
 
 const (
-	OrderService_CreateOrder_FullMethodName = "/oms.v1.OrderService/CreateOrder"
-	OrderService_GetOrder_FullMethodName    = "/oms.v1.OrderService/GetOrder"
-	OrderService_ListOrders_FullMethodName  = "/oms.v1.OrderService/ListOrders"
-	OrderService_PayOrder_FullMethodName    = "/oms.v1.OrderService/PayOrder"
-	OrderService_CancelOrder_FullMethodName = "/oms.v1.OrderService/CancelOrder"
-	OrderService_RefundOrder_FullMethodName = "/oms.v1.OrderService/RefundOrder"
+	OrderService_CreateOrder_FullMethodName           = "/oms.v1.OrderService/CreateOrder"
+	OrderService_GetOrder_FullMethodName              = "/oms.v1.OrderService/GetOrder"
+	OrderService_GetOrders_FullMethodName             = "/oms.v1.OrderService/GetOrders"
+	OrderService_StreamOrderTimeline_FullMethodName   = "/oms.v1.OrderService/StreamOrderTimeline"
+	OrderService_WatchOrder_FullMethodName            = "/oms.v1.OrderService/WatchOrder"
+	OrderService_ListOrders_FullMethodName            = "/oms.v1.OrderService/ListOrders"
+	OrderService_UpdateOrder_FullMethodName           = "/oms.v1.OrderService/UpdateOrder"
+	OrderService_PayOrder_FullMethodName              = "/oms.v1.OrderService/PayOrder"
+	OrderService_CaptureOrder_FullMethodName          = "/oms.v1.OrderService/CaptureOrder"
+	OrderService_CancelOrder_FullMethodName           = "/oms.v1.OrderService/CancelOrder"
+	OrderService_RefundOrder_FullMethodName           = "/oms.v1.OrderService/RefundOrder"
+	OrderService_HoldOrder_FullMethodName             = "/oms.v1.OrderService/HoldOrder"
+	OrderService_ReleaseOrder_FullMethodName          = "/oms.v1.OrderService/ReleaseOrder"
+	OrderService_ApproveOrder_FullMethodName          = "/oms.v1.OrderService/ApproveOrder"
+	OrderService_RejectOrder_FullMethodName           = "/oms.v1.OrderService/RejectOrder"
+	OrderService_ScheduleCancel_FullMethodName        = "/oms.v1.OrderService/ScheduleCancel"
+	OrderService_ListScheduledCancels_FullMethodName  = "/oms.v1.OrderService/ListScheduledCancels"
+	OrderService_DeleteScheduledCancel_FullMethodName = "/oms.v1.OrderService/DeleteScheduledCancel"
+	OrderService_CreateReturn_FullMethodName          = "/oms.v1.OrderService/CreateReturn"
+	OrderService_GetReturn_FullMethodName             = "/oms.v1.OrderService/GetReturn"
+	OrderService_AdvanceReturn_FullMethodName         = "/oms.v1.OrderService/AdvanceReturn"
+	OrderService_GetServiceInfo_FullMethodName        = "/oms.v1.OrderService/GetServiceInfo"
 )
 
 // OrderServiceClient is the client API for OrderService service.
@@ -37,14 +53,46 @@ type OrderServiceClient interface {
 	CreateOrder(ctx context.Context, in *CreateOrderRequest, opts ...grpc.CallOption) (*CreateOrderResponse, error)
 	// Получение статуса и деталей заказа.
 	GetOrder(ctx context.Context, in *GetOrderRequest, opts ...grpc.CallOption) (*GetOrderResponse, error)
+	// Пакетное чтение заказов по списку ID за один запрос к хранилищу.
+	GetOrders(ctx context.Context, in *GetOrdersRequest, opts ...grpc.CallOption) (*GetOrdersResponse, error)
+	// Поток событий timeline заказа по мере их записи (вместо опроса GetOrder).
+	StreamOrderTimeline(ctx context.Context, in *StreamOrderTimelineRequest, opts ...grpc.CallOption) (OrderService_StreamOrderTimelineClient, error)
+	// Поток состояния заказа: снимок при подписке, затем заказ после каждого события саги.
+	WatchOrder(ctx context.Context, in *WatchOrderRequest, opts ...grpc.CallOption) (OrderService_WatchOrderClient, error)
 	// Список заказов с пагинацией и фильтром по статусам.
 	ListOrders(ctx context.Context, in *ListOrdersRequest, opts ...grpc.CallOption) (*ListOrdersResponse, error)
+	// Изменение состава pending-заказа: добавление и удаление позиций, смена количества.
+	UpdateOrder(ctx context.Context, in *UpdateOrderRequest, opts ...grpc.CallOption) (*UpdateOrderResponse, error)
 	// Запуск платежа (асинхронный результат).
 	PayOrder(ctx context.Context, in *PayOrderRequest, opts ...grpc.CallOption) (*PayOrderResponse, error)
+	// Списание заблокированной суммы authorized-заказа (например, по отгрузке); асинхронно.
+	CaptureOrder(ctx context.Context, in *CaptureOrderRequest, opts ...grpc.CallOption) (*CaptureOrderResponse, error)
 	// Отмена заказа и запуск компенсаций.
 	CancelOrder(ctx context.Context, in *CancelOrderRequest, opts ...grpc.CallOption) (*CancelOrderResponse, error)
 	// Инициировать возврат средств.
 	RefundOrder(ctx context.Context, in *RefundOrderRequest, opts ...grpc.CallOption) (*RefundOrderResponse, error)
+	// Остановить заказ для проверки (например, внешней антифрод-системой).
+	HoldOrder(ctx context.Context, in *HoldOrderRequest, opts ...grpc.CallOption) (*HoldOrderResponse, error)
+	// Снять hold и продолжить обработку заказа.
+	ReleaseOrder(ctx context.Context, in *ReleaseOrderRequest, opts ...grpc.CallOption) (*ReleaseOrderResponse, error)
+	// Согласовать заказ в ORDER_STATUS_AWAITING_APPROVAL; после него заказ обрабатывается как обычный.
+	ApproveOrder(ctx context.Context, in *ApproveOrderRequest, opts ...grpc.CallOption) (*ApproveOrderResponse, error)
+	// Отклонить заказ в ORDER_STATUS_AWAITING_APPROVAL; заказ отменяется.
+	RejectOrder(ctx context.Context, in *RejectOrderRequest, opts ...grpc.CallOption) (*RejectOrderResponse, error)
+	// Запланировать отмену заказа на момент cancel_at ("отменить, если не отгружен до пятницы").
+	ScheduleCancel(ctx context.Context, in *ScheduleCancelRequest, opts ...grpc.CallOption) (*ScheduleCancelResponse, error)
+	// Отложенные отмены заказа, включая выполненные и снятые.
+	ListScheduledCancels(ctx context.Context, in *ListScheduledCancelsRequest, opts ...grpc.CallOption) (*ListScheduledCancelsResponse, error)
+	// Снять отложенную отмену до срока.
+	DeleteScheduledCancel(ctx context.Context, in *DeleteScheduledCancelRequest, opts ...grpc.CallOption) (*DeleteScheduledCancelResponse, error)
+	// Заявить возврат части позиций заказа.
+	CreateReturn(ctx context.Context, in *CreateReturnRequest, opts ...grpc.CallOption) (*CreateReturnResponse, error)
+	// Получить возврат позиций с текущим статусом.
+	GetReturn(ctx context.Context, in *GetReturnRequest, opts ...grpc.CallOption) (*GetReturnResponse, error)
+	// Перевести возврат на следующий этап: одобрение, приёмка на склад, возврат денег.
+	AdvanceReturn(ctx context.Context, in *AdvanceReturnRequest, opts ...grpc.CallOption) (*AdvanceReturnResponse, error)
+	// Параметры сервиса, на которые опираются клиенты: лимиты страниц списков.
+	GetServiceInfo(ctx context.Context, in *GetServiceInfoRequest, opts ...grpc.CallOption) (*GetServiceInfoResponse, error)
 }
 
 type orderServiceClient struct {
@@ -75,6 +123,82 @@ func (c *orderServiceClient) GetOrder(ctx context.Context, in *GetOrderRequest,
 	return out, nil
 }
 
+func (c *orderServiceClient) GetOrders(ctx context.Context, in *GetOrdersRequest, opts ...grpc.CallOption) (*GetOrdersResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetOrdersResponse)
+	err := c.cc.Invoke(ctx, OrderService_GetOrders_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *orderServiceClient) StreamOrderTimeline(ctx context.Context, in *StreamOrderTimelineRequest, opts ...grpc.CallOption) (OrderService_StreamOrderTimelineClient, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &OrderService_ServiceDesc.Streams[0], OrderService_StreamOrderTimeline_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &orderServiceStreamOrderTimelineClient{ClientStream: stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type OrderService_StreamOrderTimelineClient interface {
+	Recv() (*StreamOrderTimelineResponse, error)
+	grpc.ClientStream
+}
+
+type orderServiceStreamOrderTimelineClient struct {
+	grpc.ClientStream
+}
+
+func (x *orderServiceStreamOrderTimelineClient) Recv() (*StreamOrderTimelineResponse, error) {
+	m := new(StreamOrderTimelineResponse)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *orderServiceClient) WatchOrder(ctx context.Context, in *WatchOrderRequest, opts ...grpc.CallOption) (OrderService_WatchOrderClient, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &OrderService_ServiceDesc.Streams[1], OrderService_WatchOrder_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &orderServiceWatchOrderClient{ClientStream: stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type OrderService_WatchOrderClient interface {
+	Recv() (*WatchOrderResponse, error)
+	grpc.ClientStream
+}
+
+type orderServiceWatchOrderClient struct {
+	grpc.ClientStream
+}
+
+func (x *orderServiceWatchOrderClient) Recv() (*WatchOrderResponse, error) {
+	m := new(WatchOrderResponse)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
 func (c *orderServiceClient) ListOrders(ctx context.Context, in *ListOrdersRequest, opts ...grpc.CallOption) (*ListOrdersResponse, error) {
 	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
 	out := new(ListOrdersResponse)
@@ -85,6 +209,16 @@ func (c *orderServiceClient) ListOrders(ctx context.Context, in *ListOrdersReque
 	return out, nil
 }
 
+func (c *orderServiceClient) UpdateOrder(ctx context.Context, in *UpdateOrderRequest, opts ...grpc.CallOption) (*UpdateOrderResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(UpdateOrderResponse)
+	err := c.cc.Invoke(ctx, OrderService_UpdateOrder_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *orderServiceClient) PayOrder(ctx context.Context, in *PayOrderRequest, opts ...grpc.CallOption) (*PayOrderResponse, error) {
 	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
 	out := new(PayOrderResponse)
@@ -95,6 +229,16 @@ func (c *orderServiceClient) PayOrder(ctx context.Context, in *PayOrderRequest,
 	return out, nil
 }
 
+func (c *orderServiceClient) CaptureOrder(ctx context.Context, in *CaptureOrderRequest, opts ...grpc.CallOption) (*CaptureOrderResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(CaptureOrderResponse)
+	err := c.cc.Invoke(ctx, OrderService_CaptureOrder_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *orderServiceClient) CancelOrder(ctx context.Context, in *CancelOrderRequest, opts ...grpc.CallOption) (*CancelOrderResponse, error) {
 	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
 	out := new(CancelOrderResponse)
@@ -115,6 +259,116 @@ func (c *orderServiceClient) RefundOrder(ctx context.Context, in *RefundOrderReq
 	return out, nil
 }
 
+func (c *orderServiceClient) HoldOrder(ctx context.Context, in *HoldOrderRequest, opts ...grpc.CallOption) (*HoldOrderResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(HoldOrderResponse)
+	err := c.cc.Invoke(ctx, OrderService_HoldOrder_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *orderServiceClient) ReleaseOrder(ctx context.Context, in *ReleaseOrderRequest, opts ...grpc.CallOption) (*ReleaseOrderResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ReleaseOrderResponse)
+	err := c.cc.Invoke(ctx, OrderService_ReleaseOrder_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *orderServiceClient) ApproveOrder(ctx context.Context, in *ApproveOrderRequest, opts ...grpc.CallOption) (*ApproveOrderResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ApproveOrderResponse)
+	err := c.cc.Invoke(ctx, OrderService_ApproveOrder_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *orderServiceClient) RejectOrder(ctx context.Context, in *RejectOrderRequest, opts ...grpc.CallOption) (*RejectOrderResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(RejectOrderResponse)
+	err := c.cc.Invoke(ctx, OrderService_RejectOrder_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *orderServiceClient) ScheduleCancel(ctx context.Context, in *ScheduleCancelRequest, opts ...grpc.CallOption) (*ScheduleCancelResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ScheduleCancelResponse)
+	err := c.cc.Invoke(ctx, OrderService_ScheduleCancel_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *orderServiceClient) ListScheduledCancels(ctx context.Context, in *ListScheduledCancelsRequest, opts ...grpc.CallOption) (*ListScheduledCancelsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListScheduledCancelsResponse)
+	err := c.cc.Invoke(ctx, OrderService_ListScheduledCancels_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *orderServiceClient) DeleteScheduledCancel(ctx context.Context, in *DeleteScheduledCancelRequest, opts ...grpc.CallOption) (*DeleteScheduledCancelResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(DeleteScheduledCancelResponse)
+	err := c.cc.Invoke(ctx, OrderService_DeleteScheduledCancel_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *orderServiceClient) CreateReturn(ctx context.Context, in *CreateReturnRequest, opts ...grpc.CallOption) (*CreateReturnResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(CreateReturnResponse)
+	err := c.cc.Invoke(ctx, OrderService_CreateReturn_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *orderServiceClient) GetReturn(ctx context.Context, in *GetReturnRequest, opts ...grpc.CallOption) (*GetReturnResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetReturnResponse)
+	err := c.cc.Invoke(ctx, OrderService_GetReturn_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *orderServiceClient) AdvanceReturn(ctx context.Context, in *AdvanceReturnRequest, opts ...grpc.CallOption) (*AdvanceReturnResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(AdvanceReturnResponse)
+	err := c.cc.Invoke(ctx, OrderService_AdvanceReturn_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *orderServiceClient) GetServiceInfo(ctx context.Context, in *GetServiceInfoRequest, opts ...grpc.CallOption) (*GetServiceInfoResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetServiceInfoResponse)
+	err := c.cc.Invoke(ctx, OrderService_GetServiceInfo_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // OrderServiceServer is the server API for OrderService service.
 // All implementations must embed UnimplementedOrderServiceServer
 // for forward compatibility
@@ -125,14 +379,46 @@ type OrderServiceServer interface {
 	CreateOrder(context.Context, *CreateOrderRequest) (*CreateOrderResponse, error)
 	// Получение статуса и деталей заказа.
 	GetOrder(context.Context, *GetOrderRequest) (*GetOrderResponse, error)
+	// Пакетное чтение заказов по списку ID за один запрос к хранилищу.
+	GetOrders(context.Context, *GetOrdersRequest) (*GetOrdersResponse, error)
+	// Поток событий timeline заказа по мере их записи (вместо опроса GetOrder).
+	StreamOrderTimeline(*StreamOrderTimelineRequest, OrderService_StreamOrderTimelineServer) error
+	// Поток состояния заказа: снимок при подписке, затем заказ после каждого события саги.
+	WatchOrder(*WatchOrderRequest, OrderService_WatchOrderServer) error
 	// Список заказов с пагинацией и фильтром по статусам.
 	ListOrders(context.Context, *ListOrdersRequest) (*ListOrdersResponse, error)
+	// Изменение состава pending-заказа: добавление и удаление позиций, смена количества.
+	UpdateOrder(context.Context, *UpdateOrderRequest) (*UpdateOrderResponse, error)
 	// Запуск платежа (асинхронный результат).
 	PayOrder(context.Context, *PayOrderRequest) (*PayOrderResponse, error)
+	// Списание заблокированной суммы authorized-заказа (например, по отгрузке); асинхронно.
+	CaptureOrder(context.Context, *CaptureOrderRequest) (*CaptureOrderResponse, error)
 	// Отмена заказа и запуск компенсаций.
 	CancelOrder(context.Context, *CancelOrderRequest) (*CancelOrderResponse, error)
 	// Инициировать возврат средств.
 	RefundOrder(context.Context, *RefundOrderRequest) (*RefundOrderResponse, error)
+	// Остановить заказ для проверки (например, внешней антифрод-системой).
+	HoldOrder(context.Context, *HoldOrderRequest) (*HoldOrderResponse, error)
+	// Снять hold и продолжить обработку заказа.
+	ReleaseOrder(context.Context, *ReleaseOrderRequest) (*ReleaseOrderResponse, error)
+	// Согласовать заказ в ORDER_STATUS_AWAITING_APPROVAL; после него заказ обрабатывается как обычный.
+	ApproveOrder(context.Context, *ApproveOrderRequest) (*ApproveOrderResponse, error)
+	// Отклонить заказ в ORDER_STATUS_AWAITING_APPROVAL; заказ отменяется.
+	RejectOrder(context.Context, *RejectOrderRequest) (*RejectOrderResponse, error)
+	// Запланировать отмену заказа на момент cancel_at ("отменить, если не отгружен до пятницы").
+	ScheduleCancel(context.Context, *ScheduleCancelRequest) (*ScheduleCancelResponse, error)
+	// Отложенные отмены заказа, включая выполненные и снятые.
+	ListScheduledCancels(context.Context, *ListScheduledCancelsRequest) (*ListScheduledCancelsResponse, error)
+	// Снять отложенную отмену до срока.
+	DeleteScheduledCancel(context.Context, *DeleteScheduledCancelRequest) (*DeleteScheduledCancelResponse, error)
+	// Заявить возврат части позиций заказа.
+	CreateReturn(context.Context, *CreateReturnRequest) (*CreateReturnResponse, error)
+	// Получить возврат позиций с текущим статусом.
+	GetReturn(context.Context, *GetReturnRequest) (*GetReturnResponse, error)
+	// Перевести возврат на следующий этап: одобрение, приёмка на склад, возврат денег.
+	AdvanceReturn(context.Context, *AdvanceReturnRequest) (*AdvanceReturnResponse, error)
+	// Параметры сервиса, на которые опираются клиенты: лимиты страниц списков.
+	GetServiceInfo(context.Context, *GetServiceInfoRequest) (*GetServiceInfoResponse, error)
 	mustEmbedUnimplementedOrderServiceServer()
 }
 
@@ -146,18 +432,66 @@ func (UnimplementedOrderServiceServer) CreateOrder(context.Context, *CreateOrder
 func (UnimplementedOrderServiceServer) GetOrder(context.Context, *GetOrderRequest) (*GetOrderResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method GetOrder not implemented")
 }
+func (UnimplementedOrderServiceServer) GetOrders(context.Context, *GetOrdersRequest) (*GetOrdersResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetOrders not implemented")
+}
+func (UnimplementedOrderServiceServer) StreamOrderTimeline(*StreamOrderTimelineRequest, OrderService_StreamOrderTimelineServer) error {
+	return status.Errorf(codes.Unimplemented, "method StreamOrderTimeline not implemented")
+}
+func (UnimplementedOrderServiceServer) WatchOrder(*WatchOrderRequest, OrderService_WatchOrderServer) error {
+	return status.Errorf(codes.Unimplemented, "method WatchOrder not implemented")
+}
 func (UnimplementedOrderServiceServer) ListOrders(context.Context, *ListOrdersRequest) (*ListOrdersResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method ListOrders not implemented")
 }
+func (UnimplementedOrderServiceServer) UpdateOrder(context.Context, *UpdateOrderRequest) (*UpdateOrderResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UpdateOrder not implemented")
+}
 func (UnimplementedOrderServiceServer) PayOrder(context.Context, *PayOrderRequest) (*PayOrderResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method PayOrder not implemented")
 }
+func (UnimplementedOrderServiceServer) CaptureOrder(context.Context, *CaptureOrderRequest) (*CaptureOrderResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CaptureOrder not implemented")
+}
 func (UnimplementedOrderServiceServer) CancelOrder(context.Context, *CancelOrderRequest) (*CancelOrderResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method CancelOrder not implemented")
 }
 func (UnimplementedOrderServiceServer) RefundOrder(context.Context, *RefundOrderRequest) (*RefundOrderResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method RefundOrder not implemented")
 }
+func (UnimplementedOrderServiceServer) HoldOrder(context.Context, *HoldOrderRequest) (*HoldOrderResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method HoldOrder not implemented")
+}
+func (UnimplementedOrderServiceServer) ReleaseOrder(context.Context, *ReleaseOrderRequest) (*ReleaseOrderResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ReleaseOrder not implemented")
+}
+func (UnimplementedOrderServiceServer) ApproveOrder(context.Context, *ApproveOrderRequest) (*ApproveOrderResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ApproveOrder not implemented")
+}
+func (UnimplementedOrderServiceServer) RejectOrder(context.Context, *RejectOrderRequest) (*RejectOrderResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RejectOrder not implemented")
+}
+func (UnimplementedOrderServiceServer) ScheduleCancel(context.Context, *ScheduleCancelRequest) (*ScheduleCancelResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ScheduleCancel not implemented")
+}
+func (UnimplementedOrderServiceServer) ListScheduledCancels(context.Context, *ListScheduledCancelsRequest) (*ListScheduledCancelsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListScheduledCancels not implemented")
+}
+func (UnimplementedOrderServiceServer) DeleteScheduledCancel(context.Context, *DeleteScheduledCancelRequest) (*DeleteScheduledCancelResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DeleteScheduledCancel not implemented")
+}
+func (UnimplementedOrderServiceServer) CreateReturn(context.Context, *CreateReturnRequest) (*CreateReturnResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CreateReturn not implemented")
+}
+func (UnimplementedOrderServiceServer) GetReturn(context.Context, *GetReturnRequest) (*GetReturnResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetReturn not implemented")
+}
+func (UnimplementedOrderServiceServer) AdvanceReturn(context.Context, *AdvanceReturnRequest) (*AdvanceReturnResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method AdvanceReturn not implemented")
+}
+func (UnimplementedOrderServiceServer) GetServiceInfo(context.Context, *GetServiceInfoRequest) (*GetServiceInfoResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetServiceInfo not implemented")
+}
 func (UnimplementedOrderServiceServer) mustEmbedUnimplementedOrderServiceServer() {}
 
 // UnsafeOrderServiceServer may be embedded to opt out of forward compatibility for this service.
@@ -177,104 +511,398 @@ func _OrderService_CreateOrder_Handler(srv interface{}, ctx context.Context, dec
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(OrderServiceServer).CreateOrder(ctx, in)
+		return srv.(OrderServiceServer).CreateOrder(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: OrderService_CreateOrder_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(OrderServiceServer).CreateOrder(ctx, req.(*CreateOrderRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _OrderService_GetOrder_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetOrderRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(OrderServiceServer).GetOrder(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: OrderService_GetOrder_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(OrderServiceServer).GetOrder(ctx, req.(*GetOrderRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _OrderService_GetOrders_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetOrdersRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(OrderServiceServer).GetOrders(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: OrderService_GetOrders_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(OrderServiceServer).GetOrders(ctx, req.(*GetOrdersRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _OrderService_StreamOrderTimeline_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(StreamOrderTimelineRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(OrderServiceServer).StreamOrderTimeline(m, &orderServiceStreamOrderTimelineServer{ServerStream: stream})
+}
+
+type OrderService_StreamOrderTimelineServer interface {
+	Send(*StreamOrderTimelineResponse) error
+	grpc.ServerStream
+}
+
+type orderServiceStreamOrderTimelineServer struct {
+	grpc.ServerStream
+}
+
+func (x *orderServiceStreamOrderTimelineServer) Send(m *StreamOrderTimelineResponse) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func _OrderService_WatchOrder_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(WatchOrderRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(OrderServiceServer).WatchOrder(m, &orderServiceWatchOrderServer{ServerStream: stream})
+}
+
+type OrderService_WatchOrderServer interface {
+	Send(*WatchOrderResponse) error
+	grpc.ServerStream
+}
+
+type orderServiceWatchOrderServer struct {
+	grpc.ServerStream
+}
+
+func (x *orderServiceWatchOrderServer) Send(m *WatchOrderResponse) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func _OrderService_ListOrders_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListOrdersRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(OrderServiceServer).ListOrders(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: OrderService_ListOrders_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(OrderServiceServer).ListOrders(ctx, req.(*ListOrdersRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _OrderService_UpdateOrder_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UpdateOrderRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(OrderServiceServer).UpdateOrder(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: OrderService_UpdateOrder_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(OrderServiceServer).UpdateOrder(ctx, req.(*UpdateOrderRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _OrderService_PayOrder_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(PayOrderRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(OrderServiceServer).PayOrder(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: OrderService_PayOrder_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(OrderServiceServer).PayOrder(ctx, req.(*PayOrderRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _OrderService_CaptureOrder_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CaptureOrderRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(OrderServiceServer).CaptureOrder(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: OrderService_CaptureOrder_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(OrderServiceServer).CaptureOrder(ctx, req.(*CaptureOrderRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _OrderService_CancelOrder_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CancelOrderRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(OrderServiceServer).CancelOrder(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: OrderService_CancelOrder_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(OrderServiceServer).CancelOrder(ctx, req.(*CancelOrderRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _OrderService_RefundOrder_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RefundOrderRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(OrderServiceServer).RefundOrder(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: OrderService_RefundOrder_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(OrderServiceServer).RefundOrder(ctx, req.(*RefundOrderRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _OrderService_HoldOrder_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(HoldOrderRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(OrderServiceServer).HoldOrder(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: OrderService_HoldOrder_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(OrderServiceServer).HoldOrder(ctx, req.(*HoldOrderRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _OrderService_ReleaseOrder_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ReleaseOrderRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(OrderServiceServer).ReleaseOrder(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: OrderService_ReleaseOrder_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(OrderServiceServer).ReleaseOrder(ctx, req.(*ReleaseOrderRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _OrderService_ApproveOrder_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ApproveOrderRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(OrderServiceServer).ApproveOrder(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: OrderService_ApproveOrder_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(OrderServiceServer).ApproveOrder(ctx, req.(*ApproveOrderRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _OrderService_RejectOrder_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RejectOrderRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(OrderServiceServer).RejectOrder(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: OrderService_RejectOrder_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(OrderServiceServer).RejectOrder(ctx, req.(*RejectOrderRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _OrderService_ScheduleCancel_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ScheduleCancelRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(OrderServiceServer).ScheduleCancel(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: OrderService_ScheduleCancel_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(OrderServiceServer).ScheduleCancel(ctx, req.(*ScheduleCancelRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _OrderService_ListScheduledCancels_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListScheduledCancelsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(OrderServiceServer).ListScheduledCancels(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: OrderService_CreateOrder_FullMethodName,
+		FullMethod: OrderService_ListScheduledCancels_FullMethodName,
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(OrderServiceServer).CreateOrder(ctx, req.(*CreateOrderRequest))
+		return srv.(OrderServiceServer).ListScheduledCancels(ctx, req.(*ListScheduledCancelsRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _OrderService_GetOrder_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(GetOrderRequest)
+func _OrderService_DeleteScheduledCancel_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeleteScheduledCancelRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(OrderServiceServer).GetOrder(ctx, in)
+		return srv.(OrderServiceServer).DeleteScheduledCancel(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: OrderService_GetOrder_FullMethodName,
+		FullMethod: OrderService_DeleteScheduledCancel_FullMethodName,
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(OrderServiceServer).GetOrder(ctx, req.(*GetOrderRequest))
+		return srv.(OrderServiceServer).DeleteScheduledCancel(ctx, req.(*DeleteScheduledCancelRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _OrderService_ListOrders_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(ListOrdersRequest)
+func _OrderService_CreateReturn_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateReturnRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(OrderServiceServer).ListOrders(ctx, in)
+		return srv.(OrderServiceServer).CreateReturn(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: OrderService_ListOrders_FullMethodName,
+		FullMethod: OrderService_CreateReturn_FullMethodName,
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(OrderServiceServer).ListOrders(ctx, req.(*ListOrdersRequest))
+		return srv.(OrderServiceServer).CreateReturn(ctx, req.(*CreateReturnRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _OrderService_PayOrder_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(PayOrderRequest)
+func _OrderService_GetReturn_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetReturnRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(OrderServiceServer).PayOrder(ctx, in)
+		return srv.(OrderServiceServer).GetReturn(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: OrderService_PayOrder_FullMethodName,
+		FullMethod: OrderService_GetReturn_FullMethodName,
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(OrderServiceServer).PayOrder(ctx, req.(*PayOrderRequest))
+		return srv.(OrderServiceServer).GetReturn(ctx, req.(*GetReturnRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _OrderService_CancelOrder_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(CancelOrderRequest)
+func _OrderService_AdvanceReturn_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AdvanceReturnRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(OrderServiceServer).CancelOrder(ctx, in)
+		return srv.(OrderServiceServer).AdvanceReturn(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: OrderService_CancelOrder_FullMethodName,
+		FullMethod: OrderService_AdvanceReturn_FullMethodName,
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(OrderServiceServer).CancelOrder(ctx, req.(*CancelOrderRequest))
+		return srv.(OrderServiceServer).AdvanceReturn(ctx, req.(*AdvanceReturnRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _OrderService_RefundOrder_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(RefundOrderRequest)
+func _OrderService_GetServiceInfo_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetServiceInfoRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(OrderServiceServer).RefundOrder(ctx, in)
+		return srv.(OrderServiceServer).GetServiceInfo(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: OrderService_RefundOrder_FullMethodName,
+		FullMethod: OrderService_GetServiceInfo_FullMethodName,
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(OrderServiceServer).RefundOrder(ctx, req.(*RefundOrderRequest))
+		return srv.(OrderServiceServer).GetServiceInfo(ctx, req.(*GetServiceInfoRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
@@ -294,14 +922,26 @@ var OrderService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "GetOrder",
 			Handler:    _OrderService_GetOrder_Handler,
 		},
+		{
+			MethodName: "GetOrders",
+			Handler:    _OrderService_GetOrders_Handler,
+		},
 		{
 			MethodName: "ListOrders",
 			Handler:    _OrderService_ListOrders_Handler,
 		},
+		{
+			MethodName: "UpdateOrder",
+			Handler:    _OrderService_UpdateOrder_Handler,
+		},
 		{
 			MethodName: "PayOrder",
 			Handler:    _OrderService_PayOrder_Handler,
 		},
+		{
+			MethodName: "CaptureOrder",
+			Handler:    _OrderService_CaptureOrder_Handler,
+		},
 		{
 			MethodName: "CancelOrder",
 			Handler:    _OrderService_CancelOrder_Handler,
@@ -310,8 +950,63 @@ var OrderService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "RefundOrder",
 			Handler:    _OrderService_RefundOrder_Handler,
 		},
+		{
+			MethodName: "HoldOrder",
+			Handler:    _OrderService_HoldOrder_Handler,
+		},
+		{
+			MethodName: "ReleaseOrder",
+			Handler:    _OrderService_ReleaseOrder_Handler,
+		},
+		{
+			MethodName: "ApproveOrder",
+			Handler:    _OrderService_ApproveOrder_Handler,
+		},
+		{
+			MethodName: "RejectOrder",
+			Handler:    _OrderService_RejectOrder_Handler,
+		},
+		{
+			MethodName: "ScheduleCancel",
+			Handler:    _OrderService_ScheduleCancel_Handler,
+		},
+		{
+			MethodName: "ListScheduledCancels",
+			Handler:    _OrderService_ListScheduledCancels_Handler,
+		},
+		{
+			MethodName: "DeleteScheduledCancel",
+			Handler:    _OrderService_DeleteScheduledCancel_Handler,
+		},
+		{
+			MethodName: "CreateReturn",
+			Handler:    _OrderService_CreateReturn_Handler,
+		},
+		{
+			MethodName: "GetReturn",
+			Handler:    _OrderService_GetReturn_Handler,
+		},
+		{
+			MethodName: "AdvanceReturn",
+			Handler:    _OrderService_AdvanceReturn_Handler,
+		},
+		{
+			MethodName: "GetServiceInfo",
+			Handler:    _OrderService_GetServiceInfo_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "StreamOrderTimeline",
+			Handler:       _OrderService_StreamOrderTimeline_Handler,
+			ServerStreams: true,
+		},
+		{
+			StreamName:    "WatchOrder",
+			Handler:       _OrderService_WatchOrder_Handler,
+			ServerStreams: true,
+		},
 	},
-	Streams:  []grpc.StreamDesc{},
 	Metadata: "proto/oms/v1/order_service.proto",
 }
 
@@ -771,3 +1466,340 @@ var CourierService_ServiceDesc = grpc.ServiceDesc{
 	Streams:  []grpc.StreamDesc{},
 	Metadata: "proto/oms/v1/order_service.proto",
 }
+
+const (
+	AdminService_DeleteCustomerData_FullMethodName = "/oms.v1.AdminService/DeleteCustomerData"
+	AdminService_GetQuotaUsage_FullMethodName      = "/oms.v1.AdminService/GetQuotaUsage"
+	AdminService_GetEventsSince_FullMethodName     = "/oms.v1.AdminService/GetEventsSince"
+	AdminService_GetLogLevels_FullMethodName       = "/oms.v1.AdminService/GetLogLevels"
+	AdminService_SetLogLevel_FullMethodName        = "/oms.v1.AdminService/SetLogLevel"
+	AdminService_RecalculateOrder_FullMethodName   = "/oms.v1.AdminService/RecalculateOrder"
+	AdminService_ForceTransition_FullMethodName    = "/oms.v1.AdminService/ForceTransition"
+)
+
+// AdminServiceClient is the client API for AdminService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+//
+// ---- Административный gRPC сервис (не публикуется через gateway) ----
+type AdminServiceClient interface {
+	// Обезличивание персональных данных клиента (GDPR): заказы, timeline, idempotency-ответы.
+	DeleteCustomerData(ctx context.Context, in *DeleteCustomerDataRequest, opts ...grpc.CallOption) (*DeleteCustomerDataResponse, error)
+	// Расход дневной квоты заказов principal'а.
+	GetQuotaUsage(ctx context.Context, in *GetQuotaUsageRequest, opts ...grpc.CallOption) (*GetQuotaUsageResponse, error)
+	// Pull-лента событий outbox для потребителей без доступа к Kafka (at-least-once по курсору).
+	GetEventsSince(ctx context.Context, in *GetEventsSinceRequest, opts ...grpc.CallOption) (*GetEventsSinceResponse, error)
+	// Текущие уровни логирования: общий и по компонентам.
+	GetLogLevels(ctx context.Context, in *GetLogLevelsRequest, opts ...grpc.CallOption) (*LogLevels, error)
+	// Смена уровня логирования без рестарта; действует до SIGHUP или рестарта.
+	SetLogLevel(ctx context.Context, in *SetLogLevelRequest, opts ...grpc.CallOption) (*LogLevels, error)
+	// Пересчёт цен позиций pending-заказа по каталогу (исправление цены без отмены и пересоздания).
+	RecalculateOrder(ctx context.Context, in *RecalculateOrderRequest, opts ...grpc.CallOption) (*RecalculateOrderResponse, error)
+	// Ручной перевод зависшего заказа в статус в обход саги; пишет аудит в timeline и outbox.
+	ForceTransition(ctx context.Context, in *ForceTransitionRequest, opts ...grpc.CallOption) (*ForceTransitionResponse, error)
+}
+
+type adminServiceClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewAdminServiceClient(cc grpc.ClientConnInterface) AdminServiceClient {
+	return &adminServiceClient{cc}
+}
+
+func (c *adminServiceClient) DeleteCustomerData(ctx context.Context, in *DeleteCustomerDataRequest, opts ...grpc.CallOption) (*DeleteCustomerDataResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(DeleteCustomerDataResponse)
+	err := c.cc.Invoke(ctx, AdminService_DeleteCustomerData_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *adminServiceClient) GetQuotaUsage(ctx context.Context, in *GetQuotaUsageRequest, opts ...grpc.CallOption) (*GetQuotaUsageResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetQuotaUsageResponse)
+	err := c.cc.Invoke(ctx, AdminService_GetQuotaUsage_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *adminServiceClient) GetEventsSince(ctx context.Context, in *GetEventsSinceRequest, opts ...grpc.CallOption) (*GetEventsSinceResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetEventsSinceResponse)
+	err := c.cc.Invoke(ctx, AdminService_GetEventsSince_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *adminServiceClient) GetLogLevels(ctx context.Context, in *GetLogLevelsRequest, opts ...grpc.CallOption) (*LogLevels, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(LogLevels)
+	err := c.cc.Invoke(ctx, AdminService_GetLogLevels_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *adminServiceClient) SetLogLevel(ctx context.Context, in *SetLogLevelRequest, opts ...grpc.CallOption) (*LogLevels, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(LogLevels)
+	err := c.cc.Invoke(ctx, AdminService_SetLogLevel_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *adminServiceClient) RecalculateOrder(ctx context.Context, in *RecalculateOrderRequest, opts ...grpc.CallOption) (*RecalculateOrderResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(RecalculateOrderResponse)
+	err := c.cc.Invoke(ctx, AdminService_RecalculateOrder_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *adminServiceClient) ForceTransition(ctx context.Context, in *ForceTransitionRequest, opts ...grpc.CallOption) (*ForceTransitionResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ForceTransitionResponse)
+	err := c.cc.Invoke(ctx, AdminService_ForceTransition_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// AdminServiceServer is the server API for AdminService service.
+// All implementations must embed UnimplementedAdminServiceServer
+// for forward compatibility
+//
+// ---- Административный gRPC сервис (не публикуется через gateway) ----
+type AdminServiceServer interface {
+	// Обезличивание персональных данных клиента (GDPR): заказы, timeline, idempotency-ответы.
+	DeleteCustomerData(context.Context, *DeleteCustomerDataRequest) (*DeleteCustomerDataResponse, error)
+	// Расход дневной квоты заказов principal'а.
+	GetQuotaUsage(context.Context, *GetQuotaUsageRequest) (*GetQuotaUsageResponse, error)
+	// Pull-лента событий outbox для потребителей без доступа к Kafka (at-least-once по курсору).
+	GetEventsSince(context.Context, *GetEventsSinceRequest) (*GetEventsSinceResponse, error)
+	// Текущие уровни логирования: общий и по компонентам.
+	GetLogLevels(context.Context, *GetLogLevelsRequest) (*LogLevels, error)
+	// Смена уровня логирования без рестарта; действует до SIGHUP или рестарта.
+	SetLogLevel(context.Context, *SetLogLevelRequest) (*LogLevels, error)
+	// Пересчёт цен позиций pending-заказа по каталогу (исправление цены без отмены и пересоздания).
+	RecalculateOrder(context.Context, *RecalculateOrderRequest) (*RecalculateOrderResponse, error)
+	// Ручной перевод зависшего заказа в статус в обход саги; пишет аудит в timeline и outbox.
+	ForceTransition(context.Context, *ForceTransitionRequest) (*ForceTransitionResponse, error)
+	mustEmbedUnimplementedAdminServiceServer()
+}
+
+// UnimplementedAdminServiceServer must be embedded to have forward compatible implementations.
+type UnimplementedAdminServiceServer struct {
+}
+
+func (UnimplementedAdminServiceServer) DeleteCustomerData(context.Context, *DeleteCustomerDataRequest) (*DeleteCustomerDataResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DeleteCustomerData not implemented")
+}
+func (UnimplementedAdminServiceServer) GetQuotaUsage(context.Context, *GetQuotaUsageRequest) (*GetQuotaUsageResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetQuotaUsage not implemented")
+}
+func (UnimplementedAdminServiceServer) GetEventsSince(context.Context, *GetEventsSinceRequest) (*GetEventsSinceResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetEventsSince not implemented")
+}
+func (UnimplementedAdminServiceServer) GetLogLevels(context.Context, *GetLogLevelsRequest) (*LogLevels, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetLogLevels not implemented")
+}
+func (UnimplementedAdminServiceServer) SetLogLevel(context.Context, *SetLogLevelRequest) (*LogLevels, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SetLogLevel not implemented")
+}
+func (UnimplementedAdminServiceServer) RecalculateOrder(context.Context, *RecalculateOrderRequest) (*RecalculateOrderResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RecalculateOrder not implemented")
+}
+func (UnimplementedAdminServiceServer) ForceTransition(context.Context, *ForceTransitionRequest) (*ForceTransitionResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ForceTransition not implemented")
+}
+func (UnimplementedAdminServiceServer) mustEmbedUnimplementedAdminServiceServer() {}
+
+// UnsafeAdminServiceServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to AdminServiceServer will
+// result in compilation errors.
+type UnsafeAdminServiceServer interface {
+	mustEmbedUnimplementedAdminServiceServer()
+}
+
+func RegisterAdminServiceServer(s grpc.ServiceRegistrar, srv AdminServiceServer) {
+	s.RegisterService(&AdminService_ServiceDesc, srv)
+}
+
+func _AdminService_DeleteCustomerData_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeleteCustomerDataRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AdminServiceServer).DeleteCustomerData(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: AdminService_DeleteCustomerData_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AdminServiceServer).DeleteCustomerData(ctx, req.(*DeleteCustomerDataRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AdminService_GetQuotaUsage_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetQuotaUsageRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AdminServiceServer).GetQuotaUsage(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: AdminService_GetQuotaUsage_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AdminServiceServer).GetQuotaUsage(ctx, req.(*GetQuotaUsageRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AdminService_GetEventsSince_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetEventsSinceRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AdminServiceServer).GetEventsSince(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: AdminService_GetEventsSince_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AdminServiceServer).GetEventsSince(ctx, req.(*GetEventsSinceRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AdminService_GetLogLevels_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetLogLevelsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AdminServiceServer).GetLogLevels(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: AdminService_GetLogLevels_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AdminServiceServer).GetLogLevels(ctx, req.(*GetLogLevelsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AdminService_SetLogLevel_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SetLogLevelRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AdminServiceServer).SetLogLevel(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: AdminService_SetLogLevel_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AdminServiceServer).SetLogLevel(ctx, req.(*SetLogLevelRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AdminService_RecalculateOrder_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RecalculateOrderRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AdminServiceServer).RecalculateOrder(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: AdminService_RecalculateOrder_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AdminServiceServer).RecalculateOrder(ctx, req.(*RecalculateOrderRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AdminService_ForceTransition_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ForceTransitionRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AdminServiceServer).ForceTransition(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: AdminService_ForceTransition_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AdminServiceServer).ForceTransition(ctx, req.(*ForceTransitionRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// AdminService_ServiceDesc is the grpc.ServiceDesc for AdminService service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var AdminService_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "oms.v1.AdminService",
+	HandlerType: (*AdminServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "DeleteCustomerData",
+			Handler:    _AdminService_DeleteCustomerData_Handler,
+		},
+		{
+			MethodName: "GetQuotaUsage",
+			Handler:    _AdminService_GetQuotaUsage_Handler,
+		},
+		{
+			MethodName: "GetEventsSince",
+			Handler:    _AdminService_GetEventsSince_Handler,
+		},
+		{
+			MethodName: "GetLogLevels",
+			Handler:    _AdminService_GetLogLevels_Handler,
+		},
+		{
+			MethodName: "SetLogLevel",
+			Handler:    _AdminService_SetLogLevel_Handler,
+		},
+		{
+			MethodName: "RecalculateOrder",
+			Handler:    _AdminService_RecalculateOrder_Handler,
+		},
+		{
+			MethodName: "ForceTransition",
+			Handler:    _AdminService_ForceTransition_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "proto/oms/v1/order_service.proto",
+}
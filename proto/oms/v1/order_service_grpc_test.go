@@ -37,14 +37,26 @@ func (s *grpcTestOrderService) GetOrder(_ context.Context, req *GetOrderRequest)
 	return &GetOrderResponse{Order: &Order{Id: req.GetOrderId()}}, nil
 }
 
+func (s *grpcTestOrderService) GetOrders(_ context.Context, req *GetOrdersRequest) (*GetOrdersResponse, error) {
+	return &GetOrdersResponse{MissingOrderIds: req.GetOrderIds()}, nil
+}
+
 func (s *grpcTestOrderService) ListOrders(context.Context, *ListOrdersRequest) (*ListOrdersResponse, error) {
 	return &ListOrdersResponse{Orders: []*Order{{Id: "order-1"}}}, nil
 }
 
+func (s *grpcTestOrderService) UpdateOrder(_ context.Context, req *UpdateOrderRequest) (*UpdateOrderResponse, error) {
+	return &UpdateOrderResponse{Order: &Order{Id: req.GetOrderId(), Items: req.GetItems(), Version: req.GetExpectedVersion() + 1}}, nil
+}
+
 func (s *grpcTestOrderService) PayOrder(_ context.Context, req *PayOrderRequest) (*PayOrderResponse, error) {
 	return &PayOrderResponse{OrderId: req.GetOrderId(), Status: OrderStatus_ORDER_STATUS_PAID}, nil
 }
 
+func (s *grpcTestOrderService) CaptureOrder(_ context.Context, req *CaptureOrderRequest) (*CaptureOrderResponse, error) {
+	return &CaptureOrderResponse{OrderId: req.GetOrderId(), Status: OrderStatus_ORDER_STATUS_AUTHORIZED}, nil
+}
+
 func (s *grpcTestOrderService) CancelOrder(_ context.Context, req *CancelOrderRequest) (*CancelOrderResponse, error) {
 	return &CancelOrderResponse{OrderId: req.GetOrderId(), Status: OrderStatus_ORDER_STATUS_CANCELED}, nil
 }
@@ -53,6 +65,50 @@ func (s *grpcTestOrderService) RefundOrder(_ context.Context, req *RefundOrderRe
 	return &RefundOrderResponse{OrderId: req.GetOrderId(), Status: OrderStatus_ORDER_STATUS_REFUNDED}, nil
 }
 
+func (s *grpcTestOrderService) HoldOrder(_ context.Context, req *HoldOrderRequest) (*HoldOrderResponse, error) {
+	return &HoldOrderResponse{OrderId: req.GetOrderId(), Status: OrderStatus_ORDER_STATUS_ON_HOLD, HoldReason: req.GetReason()}, nil
+}
+
+func (s *grpcTestOrderService) ReleaseOrder(_ context.Context, req *ReleaseOrderRequest) (*ReleaseOrderResponse, error) {
+	return &ReleaseOrderResponse{OrderId: req.GetOrderId(), Status: OrderStatus_ORDER_STATUS_RESERVED}, nil
+}
+
+func (s *grpcTestOrderService) ApproveOrder(_ context.Context, req *ApproveOrderRequest) (*ApproveOrderResponse, error) {
+	return &ApproveOrderResponse{OrderId: req.GetOrderId(), Status: OrderStatus_ORDER_STATUS_PENDING}, nil
+}
+
+func (s *grpcTestOrderService) RejectOrder(_ context.Context, req *RejectOrderRequest) (*RejectOrderResponse, error) {
+	return &RejectOrderResponse{OrderId: req.GetOrderId(), Status: OrderStatus_ORDER_STATUS_CANCELED}, nil
+}
+
+func (s *grpcTestOrderService) ScheduleCancel(_ context.Context, req *ScheduleCancelRequest) (*ScheduleCancelResponse, error) {
+	return &ScheduleCancelResponse{ScheduledCancel: &ScheduledCancel{OrderId: req.GetOrderId(), CancelAt: req.GetCancelAt()}}, nil
+}
+
+func (s *grpcTestOrderService) ListScheduledCancels(_ context.Context, req *ListScheduledCancelsRequest) (*ListScheduledCancelsResponse, error) {
+	return &ListScheduledCancelsResponse{ScheduledCancels: []*ScheduledCancel{{OrderId: req.GetOrderId()}}}, nil
+}
+
+func (s *grpcTestOrderService) DeleteScheduledCancel(_ context.Context, req *DeleteScheduledCancelRequest) (*DeleteScheduledCancelResponse, error) {
+	return &DeleteScheduledCancelResponse{ScheduledCancel: &ScheduledCancel{Id: req.GetScheduledCancelId(), Status: ScheduledCancelStatus_SCHEDULED_CANCEL_STATUS_CANCELED}}, nil
+}
+
+func (s *grpcTestOrderService) CreateReturn(_ context.Context, req *CreateReturnRequest) (*CreateReturnResponse, error) {
+	return &CreateReturnResponse{OrderReturn: &OrderReturn{OrderId: req.GetOrderId(), Status: ReturnStatus_RETURN_STATUS_REQUESTED}}, nil
+}
+
+func (s *grpcTestOrderService) GetReturn(_ context.Context, req *GetReturnRequest) (*GetReturnResponse, error) {
+	return &GetReturnResponse{OrderReturn: &OrderReturn{Id: req.GetReturnId(), OrderId: req.GetOrderId()}}, nil
+}
+
+func (s *grpcTestOrderService) AdvanceReturn(_ context.Context, req *AdvanceReturnRequest) (*AdvanceReturnResponse, error) {
+	return &AdvanceReturnResponse{OrderReturn: &OrderReturn{Id: req.GetReturnId(), Status: req.GetStatus()}}, nil
+}
+
+func (s *grpcTestOrderService) GetServiceInfo(context.Context, *GetServiceInfoRequest) (*GetServiceInfoResponse, error) {
+	return &GetServiceInfoResponse{PageLimits: &PageLimits{DefaultPageSize: 100, MaxPageSize: 1000}}, nil
+}
+
 func TestOrderServiceClientMethods(t *testing.T) {
 	t.Run("success", func(t *testing.T) {
 		methods := map[string]int{}
@@ -64,14 +120,42 @@ func TestOrderServiceClientMethods(t *testing.T) {
 					out.Order = &Order{Id: "order-1"}
 				case *GetOrderResponse:
 					out.Order = &Order{Id: "order-1"}
+				case *GetOrdersResponse:
+					out.Orders = []*Order{{Id: "order-1"}}
 				case *ListOrdersResponse:
 					out.Orders = []*Order{{Id: "order-1"}}
+				case *UpdateOrderResponse:
+					out.Order = &Order{Id: "order-1"}
 				case *PayOrderResponse:
 					out.Status = OrderStatus_ORDER_STATUS_PAID
+				case *CaptureOrderResponse:
+					out.Status = OrderStatus_ORDER_STATUS_AUTHORIZED
 				case *CancelOrderResponse:
 					out.Status = OrderStatus_ORDER_STATUS_CANCELED
 				case *RefundOrderResponse:
 					out.Status = OrderStatus_ORDER_STATUS_REFUNDED
+				case *HoldOrderResponse:
+					out.Status = OrderStatus_ORDER_STATUS_ON_HOLD
+				case *ReleaseOrderResponse:
+					out.Status = OrderStatus_ORDER_STATUS_RESERVED
+				case *ApproveOrderResponse:
+					out.Status = OrderStatus_ORDER_STATUS_PENDING
+				case *RejectOrderResponse:
+					out.Status = OrderStatus_ORDER_STATUS_CANCELED
+				case *ScheduleCancelResponse:
+					out.ScheduledCancel = &ScheduledCancel{Id: "task-1"}
+				case *ListScheduledCancelsResponse:
+					out.ScheduledCancels = []*ScheduledCancel{{Id: "task-1"}}
+				case *DeleteScheduledCancelResponse:
+					out.ScheduledCancel = &ScheduledCancel{Id: "task-1"}
+				case *CreateReturnResponse:
+					out.OrderReturn = &OrderReturn{Id: "return-1"}
+				case *GetReturnResponse:
+					out.OrderReturn = &OrderReturn{Id: "return-1"}
+				case *AdvanceReturnResponse:
+					out.OrderReturn = &OrderReturn{Id: "return-1", Status: ReturnStatus_RETURN_STATUS_APPROVED}
+				case *GetServiceInfoResponse:
+					out.PageLimits = &PageLimits{MaxPageSize: 1000}
 				default:
 					t.Fatalf("unexpected reply type: %T", out)
 				}
@@ -87,26 +171,82 @@ func TestOrderServiceClientMethods(t *testing.T) {
 		if _, err := client.GetOrder(ctx, &GetOrderRequest{}); err != nil {
 			t.Fatalf("GetOrder failed: %v", err)
 		}
+		if _, err := client.GetOrders(ctx, &GetOrdersRequest{}); err != nil {
+			t.Fatalf("GetOrders failed: %v", err)
+		}
 		if _, err := client.ListOrders(ctx, &ListOrdersRequest{}); err != nil {
 			t.Fatalf("ListOrders failed: %v", err)
 		}
+		if _, err := client.UpdateOrder(ctx, &UpdateOrderRequest{}); err != nil {
+			t.Fatalf("UpdateOrder failed: %v", err)
+		}
 		if _, err := client.PayOrder(ctx, &PayOrderRequest{}); err != nil {
 			t.Fatalf("PayOrder failed: %v", err)
 		}
+		if _, err := client.CaptureOrder(ctx, &CaptureOrderRequest{}); err != nil {
+			t.Fatalf("CaptureOrder failed: %v", err)
+		}
 		if _, err := client.CancelOrder(ctx, &CancelOrderRequest{}); err != nil {
 			t.Fatalf("CancelOrder failed: %v", err)
 		}
 		if _, err := client.RefundOrder(ctx, &RefundOrderRequest{}); err != nil {
 			t.Fatalf("RefundOrder failed: %v", err)
 		}
+		if _, err := client.HoldOrder(ctx, &HoldOrderRequest{}); err != nil {
+			t.Fatalf("HoldOrder failed: %v", err)
+		}
+		if _, err := client.ReleaseOrder(ctx, &ReleaseOrderRequest{}); err != nil {
+			t.Fatalf("ReleaseOrder failed: %v", err)
+		}
+		if _, err := client.ApproveOrder(ctx, &ApproveOrderRequest{}); err != nil {
+			t.Fatalf("ApproveOrder failed: %v", err)
+		}
+		if _, err := client.RejectOrder(ctx, &RejectOrderRequest{}); err != nil {
+			t.Fatalf("RejectOrder failed: %v", err)
+		}
+		if _, err := client.ScheduleCancel(ctx, &ScheduleCancelRequest{}); err != nil {
+			t.Fatalf("ScheduleCancel failed: %v", err)
+		}
+		if _, err := client.ListScheduledCancels(ctx, &ListScheduledCancelsRequest{}); err != nil {
+			t.Fatalf("ListScheduledCancels failed: %v", err)
+		}
+		if _, err := client.DeleteScheduledCancel(ctx, &DeleteScheduledCancelRequest{}); err != nil {
+			t.Fatalf("DeleteScheduledCancel failed: %v", err)
+		}
+		if _, err := client.CreateReturn(ctx, &CreateReturnRequest{}); err != nil {
+			t.Fatalf("CreateReturn failed: %v", err)
+		}
+		if _, err := client.GetReturn(ctx, &GetReturnRequest{}); err != nil {
+			t.Fatalf("GetReturn failed: %v", err)
+		}
+		if _, err := client.AdvanceReturn(ctx, &AdvanceReturnRequest{}); err != nil {
+			t.Fatalf("AdvanceReturn failed: %v", err)
+		}
+		if _, err := client.GetServiceInfo(ctx, &GetServiceInfoRequest{}); err != nil {
+			t.Fatalf("GetServiceInfo failed: %v", err)
+		}
 
 		for _, method := range []string{
 			OrderService_CreateOrder_FullMethodName,
 			OrderService_GetOrder_FullMethodName,
+			OrderService_GetOrders_FullMethodName,
 			OrderService_ListOrders_FullMethodName,
+			OrderService_UpdateOrder_FullMethodName,
 			OrderService_PayOrder_FullMethodName,
+			OrderService_CaptureOrder_FullMethodName,
 			OrderService_CancelOrder_FullMethodName,
 			OrderService_RefundOrder_FullMethodName,
+			OrderService_HoldOrder_FullMethodName,
+			OrderService_ReleaseOrder_FullMethodName,
+			OrderService_ApproveOrder_FullMethodName,
+			OrderService_RejectOrder_FullMethodName,
+			OrderService_ScheduleCancel_FullMethodName,
+			OrderService_ListScheduledCancels_FullMethodName,
+			OrderService_DeleteScheduledCancel_FullMethodName,
+			OrderService_CreateReturn_FullMethodName,
+			OrderService_GetReturn_FullMethodName,
+			OrderService_AdvanceReturn_FullMethodName,
+			OrderService_GetServiceInfo_FullMethodName,
 		} {
 			if methods[method] != 1 {
 				t.Fatalf("expected method %s called exactly once, got %d", method, methods[method])
@@ -126,10 +266,54 @@ func TestOrderServiceClientMethods(t *testing.T) {
 		for name, call := range map[string]func() error{
 			"CreateOrder": func() error { _, err := client.CreateOrder(ctx, &CreateOrderRequest{}); return err },
 			"GetOrder":    func() error { _, err := client.GetOrder(ctx, &GetOrderRequest{}); return err },
+			"GetOrders":   func() error { _, err := client.GetOrders(ctx, &GetOrdersRequest{}); return err },
 			"ListOrders":  func() error { _, err := client.ListOrders(ctx, &ListOrdersRequest{}); return err },
+			"UpdateOrder": func() error { _, err := client.UpdateOrder(ctx, &UpdateOrderRequest{}); return err },
 			"PayOrder":    func() error { _, err := client.PayOrder(ctx, &PayOrderRequest{}); return err },
+			"CaptureOrder": func() error {
+				_, err := client.CaptureOrder(ctx, &CaptureOrderRequest{})
+				return err
+			},
 			"CancelOrder": func() error { _, err := client.CancelOrder(ctx, &CancelOrderRequest{}); return err },
 			"RefundOrder": func() error { _, err := client.RefundOrder(ctx, &RefundOrderRequest{}); return err },
+			"HoldOrder":   func() error { _, err := client.HoldOrder(ctx, &HoldOrderRequest{}); return err },
+			"ReleaseOrder": func() error {
+				_, err := client.ReleaseOrder(ctx, &ReleaseOrderRequest{})
+				return err
+			},
+			"ApproveOrder": func() error {
+				_, err := client.ApproveOrder(ctx, &ApproveOrderRequest{})
+				return err
+			},
+			"RejectOrder": func() error { _, err := client.RejectOrder(ctx, &RejectOrderRequest{}); return err },
+			"ScheduleCancel": func() error {
+				_, err := client.ScheduleCancel(ctx, &ScheduleCancelRequest{})
+				return err
+			},
+			"ListScheduledCancels": func() error {
+				_, err := client.ListScheduledCancels(ctx, &ListScheduledCancelsRequest{})
+				return err
+			},
+			"DeleteScheduledCancel": func() error {
+				_, err := client.DeleteScheduledCancel(ctx, &DeleteScheduledCancelRequest{})
+				return err
+			},
+			"CreateReturn": func() error {
+				_, err := client.CreateReturn(ctx, &CreateReturnRequest{})
+				return err
+			},
+			"GetReturn": func() error {
+				_, err := client.GetReturn(ctx, &GetReturnRequest{})
+				return err
+			},
+			"AdvanceReturn": func() error {
+				_, err := client.AdvanceReturn(ctx, &AdvanceReturnRequest{})
+				return err
+			},
+			"GetServiceInfo": func() error {
+				_, err := client.GetServiceInfo(ctx, &GetServiceInfoRequest{})
+				return err
+			},
 		} {
 			if err := call(); status.Code(err) != codes.Internal {
 				t.Fatalf("%s expected Internal error, got %v", name, err)
@@ -145,10 +329,58 @@ func TestUnimplementedOrderServiceServer(t *testing.T) {
 	for name, call := range map[string]func() error{
 		"CreateOrder": func() error { _, err := srv.CreateOrder(ctx, &CreateOrderRequest{}); return err },
 		"GetOrder":    func() error { _, err := srv.GetOrder(ctx, &GetOrderRequest{}); return err },
+		"GetOrders":   func() error { _, err := srv.GetOrders(ctx, &GetOrdersRequest{}); return err },
 		"ListOrders":  func() error { _, err := srv.ListOrders(ctx, &ListOrdersRequest{}); return err },
-		"PayOrder":    func() error { _, err := srv.PayOrder(ctx, &PayOrderRequest{}); return err },
+		"UpdateOrder": func() error { _, err := srv.UpdateOrder(ctx, &UpdateOrderRequest{}); return err },
+		"StreamOrderTimeline": func() error {
+			return srv.StreamOrderTimeline(&StreamOrderTimelineRequest{}, nil)
+		},
+		"WatchOrder": func() error { return srv.WatchOrder(&WatchOrderRequest{}, nil) },
+		"PayOrder":   func() error { _, err := srv.PayOrder(ctx, &PayOrderRequest{}); return err },
+		"CaptureOrder": func() error {
+			_, err := srv.CaptureOrder(ctx, &CaptureOrderRequest{})
+			return err
+		},
 		"CancelOrder": func() error { _, err := srv.CancelOrder(ctx, &CancelOrderRequest{}); return err },
 		"RefundOrder": func() error { _, err := srv.RefundOrder(ctx, &RefundOrderRequest{}); return err },
+		"HoldOrder":   func() error { _, err := srv.HoldOrder(ctx, &HoldOrderRequest{}); return err },
+		"ReleaseOrder": func() error {
+			_, err := srv.ReleaseOrder(ctx, &ReleaseOrderRequest{})
+			return err
+		},
+		"ApproveOrder": func() error {
+			_, err := srv.ApproveOrder(ctx, &ApproveOrderRequest{})
+			return err
+		},
+		"RejectOrder": func() error { _, err := srv.RejectOrder(ctx, &RejectOrderRequest{}); return err },
+		"ScheduleCancel": func() error {
+			_, err := srv.ScheduleCancel(ctx, &ScheduleCancelRequest{})
+			return err
+		},
+		"ListScheduledCancels": func() error {
+			_, err := srv.ListScheduledCancels(ctx, &ListScheduledCancelsRequest{})
+			return err
+		},
+		"DeleteScheduledCancel": func() error {
+			_, err := srv.DeleteScheduledCancel(ctx, &DeleteScheduledCancelRequest{})
+			return err
+		},
+		"CreateReturn": func() error {
+			_, err := srv.CreateReturn(ctx, &CreateReturnRequest{})
+			return err
+		},
+		"GetReturn": func() error {
+			_, err := srv.GetReturn(ctx, &GetReturnRequest{})
+			return err
+		},
+		"AdvanceReturn": func() error {
+			_, err := srv.AdvanceReturn(ctx, &AdvanceReturnRequest{})
+			return err
+		},
+		"GetServiceInfo": func() error {
+			_, err := srv.GetServiceInfo(ctx, &GetServiceInfoRequest{})
+			return err
+		},
 	} {
 		if err := call(); status.Code(err) != codes.Unimplemented {
 			t.Fatalf("%s expected Unimplemented error, got %v", name, err)
@@ -171,10 +403,24 @@ func TestGeneratedHandlers(t *testing.T) {
 	cases := []grpcGeneratedHandlerCase{
 		{name: "CreateOrder", method: OrderService_CreateOrder_FullMethodName, call: _OrderService_CreateOrder_Handler},
 		{name: "GetOrder", method: OrderService_GetOrder_FullMethodName, call: _OrderService_GetOrder_Handler},
+		{name: "GetOrders", method: OrderService_GetOrders_FullMethodName, call: _OrderService_GetOrders_Handler},
 		{name: "ListOrders", method: OrderService_ListOrders_FullMethodName, call: _OrderService_ListOrders_Handler},
+		{name: "UpdateOrder", method: OrderService_UpdateOrder_FullMethodName, call: _OrderService_UpdateOrder_Handler},
 		{name: "PayOrder", method: OrderService_PayOrder_FullMethodName, call: _OrderService_PayOrder_Handler},
+		{name: "CaptureOrder", method: OrderService_CaptureOrder_FullMethodName, call: _OrderService_CaptureOrder_Handler},
 		{name: "CancelOrder", method: OrderService_CancelOrder_FullMethodName, call: _OrderService_CancelOrder_Handler},
 		{name: "RefundOrder", method: OrderService_RefundOrder_FullMethodName, call: _OrderService_RefundOrder_Handler},
+		{name: "HoldOrder", method: OrderService_HoldOrder_FullMethodName, call: _OrderService_HoldOrder_Handler},
+		{name: "ReleaseOrder", method: OrderService_ReleaseOrder_FullMethodName, call: _OrderService_ReleaseOrder_Handler},
+		{name: "ApproveOrder", method: OrderService_ApproveOrder_FullMethodName, call: _OrderService_ApproveOrder_Handler},
+		{name: "RejectOrder", method: OrderService_RejectOrder_FullMethodName, call: _OrderService_RejectOrder_Handler},
+		{name: "ScheduleCancel", method: OrderService_ScheduleCancel_FullMethodName, call: _OrderService_ScheduleCancel_Handler},
+		{name: "ListScheduledCancels", method: OrderService_ListScheduledCancels_FullMethodName, call: _OrderService_ListScheduledCancels_Handler},
+		{name: "DeleteScheduledCancel", method: OrderService_DeleteScheduledCancel_FullMethodName, call: _OrderService_DeleteScheduledCancel_Handler},
+		{name: "CreateReturn", method: OrderService_CreateReturn_FullMethodName, call: _OrderService_CreateReturn_Handler},
+		{name: "GetReturn", method: OrderService_GetReturn_FullMethodName, call: _OrderService_GetReturn_Handler},
+		{name: "AdvanceReturn", method: OrderService_AdvanceReturn_FullMethodName, call: _OrderService_AdvanceReturn_Handler},
+		{name: "GetServiceInfo", method: OrderService_GetServiceInfo_FullMethodName, call: _OrderService_GetServiceInfo_Handler},
 	}
 
 	for _, tc := range cases {
@@ -219,8 +465,8 @@ func TestRegisterAndServiceDescriptor(t *testing.T) {
 	if got, want := OrderService_ServiceDesc.ServiceName, "oms.v1.OrderService"; got != want {
 		t.Fatalf("unexpected service name: got %s want %s", got, want)
 	}
-	if len(OrderService_ServiceDesc.Methods) != 6 {
-		t.Fatalf("expected 6 method descriptors, got %d", len(OrderService_ServiceDesc.Methods))
+	if len(OrderService_ServiceDesc.Methods) != 20 {
+		t.Fatalf("expected 20 method descriptors, got %d", len(OrderService_ServiceDesc.Methods))
 	}
 	if OrderService_ServiceDesc.Metadata == "" {
 		t.Fatalf("metadata should not be empty")
@@ -235,15 +481,53 @@ func decodeFor(name string) func(interface{}) error {
 			req.Currency = "USD"
 		case *GetOrderRequest:
 			req.OrderId = "order-1"
+		case *GetOrdersRequest:
+			req.OrderIds = []string{"order-1", "order-2"}
 		case *ListOrdersRequest:
 			req.CustomerId = "cust-1"
+		case *UpdateOrderRequest:
+			req.OrderId = "order-1"
+			req.ExpectedVersion = 2
 		case *PayOrderRequest:
 			req.OrderId = "order-1"
+		case *CaptureOrderRequest:
+			req.OrderId = "order-1"
 		case *CancelOrderRequest:
 			req.OrderId = "order-1"
 			req.Reason = "test"
 		case *RefundOrderRequest:
 			req.OrderId = "order-1"
+		case *HoldOrderRequest:
+			req.OrderId = "order-1"
+			req.Reason = "fraud"
+		case *ReleaseOrderRequest:
+			req.OrderId = "order-1"
+		case *ApproveOrderRequest:
+			req.OrderId = "order-1"
+			req.Approver = "buyer-lead"
+		case *RejectOrderRequest:
+			req.OrderId = "order-1"
+			req.Approver = "buyer-lead"
+			req.Reason = "over budget"
+		case *ScheduleCancelRequest:
+			req.OrderId = "order-1"
+			req.CancelAt = "2026-03-06T18:00:00Z"
+		case *ListScheduledCancelsRequest:
+			req.OrderId = "order-1"
+		case *DeleteScheduledCancelRequest:
+			req.OrderId = "order-1"
+			req.ScheduledCancelId = "task-1"
+		case *CreateReturnRequest:
+			req.OrderId = "order-1"
+			req.Items = []*ReturnItem{{ItemId: "item-1", Qty: 1}}
+		case *GetReturnRequest:
+			req.OrderId = "order-1"
+			req.ReturnId = "return-1"
+		case *AdvanceReturnRequest:
+			req.OrderId = "order-1"
+			req.ReturnId = "return-1"
+			req.Status = ReturnStatus_RETURN_STATUS_APPROVED
+		case *GetServiceInfoRequest:
 		default:
 			return status.Errorf(codes.Internal, "unexpected request type for %s: %T", name, req)
 		}
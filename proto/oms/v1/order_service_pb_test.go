@@ -93,14 +93,30 @@ func TestGeneratedMessageHelpers(t *testing.T) {
 		&CreateOrderResponse{Order: &Order{Id: "order-1"}},
 		&GetOrderRequest{OrderId: "order-1"},
 		&GetOrderResponse{Order: &Order{Id: "order-1"}, Timeline: []*TimelineEvent{{Type: "OrderCreated", UnixTime: 1}}},
+		&GetOrdersRequest{OrderIds: []string{"order-1", "order-2"}},
+		&GetOrdersResponse{Orders: []*Order{{Id: "order-1"}}, MissingOrderIds: []string{"order-2"}},
+		&WatchOrderRequest{OrderId: "order-1"},
+		&WatchOrderResponse{Order: &Order{Id: "order-1"}, Event: &TimelineEvent{Type: "OrderStatusChanged", UnixTime: 1}},
 		&ListOrdersRequest{CustomerId: "cust-1", PageSize: 10, PageToken: "token", FilterStatuses: []OrderStatus{OrderStatus_ORDER_STATUS_PENDING}},
 		&ListOrdersResponse{Orders: []*Order{{Id: "order-1"}}, NextPageToken: "next"},
+		&UpdateOrderRequest{OrderId: "order-1", ExpectedVersion: 2, Items: []*OrderItem{{Id: "item-1", Qty: 3}}},
+		&UpdateOrderResponse{Order: &Order{Id: "order-1", Version: 3}},
 		&PayOrderRequest{OrderId: "order-1"},
 		&PayOrderResponse{OrderId: "order-1", Status: OrderStatus_ORDER_STATUS_PAID},
+		&CaptureOrderRequest{OrderId: "order-1"},
+		&CaptureOrderResponse{OrderId: "order-1", Status: OrderStatus_ORDER_STATUS_AUTHORIZED},
 		&CancelOrderRequest{OrderId: "order-1", Reason: "user-request"},
 		&CancelOrderResponse{OrderId: "order-1", Status: OrderStatus_ORDER_STATUS_CANCELED},
 		&RefundOrderRequest{OrderId: "order-1", Amount: &Money{Currency: "USD", AmountMinor: 50}, Reason: "partial"},
 		&RefundOrderResponse{OrderId: "order-1", Status: OrderStatus_ORDER_STATUS_REFUNDED},
+		&ApproveOrderRequest{OrderId: "order-1", Approver: "buyer-lead", Comment: "budget ok"},
+		&ApproveOrderResponse{OrderId: "order-1", Status: OrderStatus_ORDER_STATUS_PENDING},
+		&RejectOrderRequest{OrderId: "order-1", Approver: "buyer-lead", Reason: "over budget"},
+		&RejectOrderResponse{OrderId: "order-1", Status: OrderStatus_ORDER_STATUS_CANCELED},
+		&CreateReturnRequest{OrderId: "order-1", Items: []*ReturnItem{{ItemId: "item-1", Qty: 1}}, Reason: "wrong size"},
+		&CreateReturnResponse{OrderReturn: &OrderReturn{Id: "return-1", Status: ReturnStatus_RETURN_STATUS_REQUESTED, Refund: &Money{Currency: "USD", AmountMinor: 50}}},
+		&GetReturnRequest{OrderId: "order-1", ReturnId: "return-1"},
+		&AdvanceReturnRequest{OrderId: "order-1", ReturnId: "return-1", Status: ReturnStatus_RETURN_STATUS_APPROVED},
 		&CourierZoneInput{ZoneId: "msk-cao-arbat", IsPrimary: true},
 		&CourierZone{ZoneId: "msk-cao-arbat", IsPrimary: true, AssignedAtUnix: 1},
 		&Courier{
@@ -0,0 +1,182 @@
+//go:build integration
+
+package integration
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/ory/dockertest/v3"
+	"github.com/ory/dockertest/v3/docker"
+
+	"github.com/vladislavdragonenkov/oms/internal/messaging/kafka"
+	"github.com/vladislavdragonenkov/oms/internal/storage/postgres"
+)
+
+const (
+	// Образы совпадают с docker-compose.yml, чтобы тесты гонялись на тех же версиях, что и локальный стенд.
+	postgresImage    = "postgres"
+	postgresImageTag = "16-alpine"
+	kafkaImage       = "confluentinc/cp-kafka"
+	kafkaImageTag    = "7.5.0"
+
+	dockerStartTimeout = 3 * time.Minute
+	// containerTTLSeconds — страховка от зависших контейнеров, если тест упал до Cleanup.
+	containerTTLSeconds = 600
+)
+
+// dockerEnv — окружение с реальными Postgres и Kafka, поднятыми через dockertest.
+type dockerEnv struct {
+	PostgresDSN  string
+	KafkaBrokers []string
+}
+
+// startDockerEnv поднимает Postgres и Kafka, применяет миграции и регистрирует удаление контейнеров.
+// Если Docker недоступен, тест пропускается.
+func startDockerEnv(t *testing.T) *dockerEnv {
+	t.Helper()
+
+	pool, err := dockertest.NewPool("")
+	if err != nil {
+		t.Skipf("docker is not available: %v", err)
+	}
+	if err := pool.Client.Ping(); err != nil {
+		t.Skipf("docker is not available: %v", err)
+	}
+	pool.MaxWait = dockerStartTimeout
+
+	return &dockerEnv{
+		PostgresDSN:  startPostgresContainer(t, pool),
+		KafkaBrokers: []string{startKafkaContainer(t, pool)},
+	}
+}
+
+func startPostgresContainer(t *testing.T, pool *dockertest.Pool) string {
+	t.Helper()
+
+	resource, err := pool.RunWithOptions(&dockertest.RunOptions{
+		Repository: postgresImage,
+		Tag:        postgresImageTag,
+		Env: []string{
+			"POSTGRES_DB=oms",
+			"POSTGRES_USER=oms",
+			"POSTGRES_PASSWORD=oms",
+		},
+	}, autoRemove)
+	if err != nil {
+		t.Fatalf("start postgres container: %v", err)
+	}
+	purgeOnCleanup(t, pool, resource)
+
+	dsn := fmt.Sprintf("postgres://oms:oms@%s/oms?sslmode=disable", resource.GetHostPort("5432/tcp"))
+
+	var store *postgres.Store
+	if err := pool.Retry(func() error {
+		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+
+		opened, openErr := postgres.Open(ctx, dsn)
+		if openErr != nil {
+			return openErr
+		}
+		store = opened
+		return nil
+	}); err != nil {
+		t.Fatalf("postgres container is not ready: %v", err)
+	}
+	defer func() {
+		_ = store.Close()
+	}()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+	if err := store.MigrateUp(ctx, 0); err != nil {
+		t.Fatalf("migrate up: %v", err)
+	}
+
+	return dsn
+}
+
+func startKafkaContainer(t *testing.T, pool *dockertest.Pool) string {
+	t.Helper()
+
+	// Advertised listener должен совпадать с адресом на хосте, поэтому порт выбираем заранее.
+	hostPort := freeTCPPort(t)
+	broker := net.JoinHostPort("127.0.0.1", strconv.Itoa(hostPort))
+
+	resource, err := pool.RunWithOptions(&dockertest.RunOptions{
+		Repository: kafkaImage,
+		Tag:        kafkaImageTag,
+		Env: []string{
+			"CLUSTER_ID=MkU3OEVBNTcwNTJENDM2Qk",
+			"KAFKA_NODE_ID=1",
+			"KAFKA_PROCESS_ROLES=broker,controller",
+			"KAFKA_CONTROLLER_QUORUM_VOTERS=1@localhost:29093",
+			"KAFKA_LISTENERS=PLAINTEXT://0.0.0.0:9092,CONTROLLER://0.0.0.0:29093",
+			"KAFKA_ADVERTISED_LISTENERS=PLAINTEXT://" + broker,
+			"KAFKA_LISTENER_SECURITY_PROTOCOL_MAP=PLAINTEXT:PLAINTEXT,CONTROLLER:PLAINTEXT",
+			"KAFKA_CONTROLLER_LISTENER_NAMES=CONTROLLER",
+			"KAFKA_INTER_BROKER_LISTENER_NAME=PLAINTEXT",
+			"KAFKA_OFFSETS_TOPIC_REPLICATION_FACTOR=1",
+			"KAFKA_TRANSACTION_STATE_LOG_REPLICATION_FACTOR=1",
+			"KAFKA_TRANSACTION_STATE_LOG_MIN_ISR=1",
+			"KAFKA_GROUP_INITIAL_REBALANCE_DELAY_MS=0",
+			"KAFKA_AUTO_CREATE_TOPICS_ENABLE=true",
+		},
+		ExposedPorts: []string{"9092/tcp"},
+		PortBindings: map[docker.Port][]docker.PortBinding{
+			"9092/tcp": {{HostIP: "127.0.0.1", HostPort: strconv.Itoa(hostPort)}},
+		},
+	}, autoRemove)
+	if err != nil {
+		t.Fatalf("start kafka container: %v", err)
+	}
+	purgeOnCleanup(t, pool, resource)
+
+	if err := pool.Retry(func() error {
+		producer, produceErr := kafka.NewProducer([]string{broker})
+		if produceErr != nil {
+			return produceErr
+		}
+		return producer.Close()
+	}); err != nil {
+		t.Fatalf("kafka container is not ready: %v", err)
+	}
+
+	return broker
+}
+
+func autoRemove(config *docker.HostConfig) {
+	config.AutoRemove = true
+	config.RestartPolicy = docker.RestartPolicy{Name: "no"}
+}
+
+func purgeOnCleanup(t *testing.T, pool *dockertest.Pool, resource *dockertest.Resource) {
+	t.Helper()
+
+	if err := resource.Expire(containerTTLSeconds); err != nil {
+		t.Logf("set container expiration: %v", err)
+	}
+	t.Cleanup(func() {
+		if err := pool.Purge(resource); err != nil {
+			t.Logf("purge container %s: %v", resource.Container.Name, err)
+		}
+	})
+}
+
+func freeTCPPort(t *testing.T) int {
+	t.Helper()
+
+	lis, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("allocate free port: %v", err)
+	}
+	defer func() {
+		_ = lis.Close()
+	}()
+	return lis.Addr().(*net.TCPAddr).Port
+}
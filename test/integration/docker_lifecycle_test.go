@@ -0,0 +1,226 @@
+//go:build integration
+
+package integration
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"net"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/IBM/sarama"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/metadata"
+
+	_ "github.com/jackc/pgx/v5/stdlib"
+
+	"github.com/vladislavdragonenkov/oms/internal/app"
+	"github.com/vladislavdragonenkov/oms/internal/messaging/kafka"
+	omsv1 "github.com/vladislavdragonenkov/oms/proto/oms/v1"
+)
+
+const probeMessageKey = "integration-probe"
+
+// outboxEnvelope — формат сообщения, которое outbox worker публикует в order-events.
+type outboxEnvelope struct {
+	ID          string          `json:"id"`
+	AggregateID string          `json:"aggregate_id"`
+	EventType   string          `json:"event_type"`
+	Payload     json.RawMessage `json:"payload"`
+}
+
+// TestDockerOrderLifecycle прогоняет заказ через настоящий app.Run: Postgres-хранилище,
+// outbox worker с публикацией в Kafka и внешний consumer, читающий order-events.
+func TestDockerOrderLifecycle(t *testing.T) {
+	env := startDockerEnv(t)
+	t.Setenv("KAFKA_BROKERS", strings.Join(env.KafkaBrokers, ","))
+
+	events := make(chan outboxEnvelope, 64)
+	probes := make(chan struct{}, 1)
+	startOrderEventsConsumer(t, env.KafkaBrokers, events, probes)
+	waitForConsumerAssignment(t, env.KafkaBrokers, probes)
+
+	cfg := app.DefaultConfig()
+	cfg.GRPCAddr = net.JoinHostPort("127.0.0.1", strconv.Itoa(freeTCPPort(t)))
+	cfg.MetricsAddr = net.JoinHostPort("127.0.0.1", strconv.Itoa(freeTCPPort(t)))
+	cfg.StorageDriver = app.StorageDriverPostgres
+	cfg.PostgresDSN = env.PostgresDSN
+	cfg.AllowMockIntegrations = true
+	cfg.OutboxPollInterval = 100 * time.Millisecond
+
+	runCtx, stopApp := context.WithCancel(context.Background())
+	runErr := make(chan error, 1)
+	go func() {
+		runErr <- app.Run(runCtx, cfg)
+	}()
+	t.Cleanup(func() {
+		stopApp()
+		select {
+		case err := <-runErr:
+			if err != nil && !errors.Is(err, context.Canceled) {
+				t.Errorf("app.Run returned error: %v", err)
+			}
+		case <-time.After(30 * time.Second):
+			t.Error("app.Run did not stop in time")
+		}
+	})
+
+	conn, err := grpc.NewClient(cfg.GRPCAddr, grpc.WithTransportCredentials(insecure.NewCredentials()))
+	require.NoError(t, err)
+	t.Cleanup(func() {
+		_ = conn.Close()
+	})
+	waitForServing(t, conn, runErr)
+
+	client := omsv1.NewOrderServiceClient(conn)
+	ctx, cancel := context.WithTimeout(context.Background(), time.Minute)
+	defer cancel()
+
+	createResp, err := client.CreateOrder(withIdempotencyKey(ctx), &omsv1.CreateOrderRequest{
+		CustomerId: "customer-docker-it",
+		Currency:   "USD",
+		Items: []*omsv1.OrderItem{
+			{Sku: "sku-docker", Qty: 2, Price: &omsv1.Money{Currency: "USD", AmountMinor: 1500}},
+		},
+	})
+	require.NoError(t, err)
+	orderID := createResp.Order.Id
+
+	_, err = client.PayOrder(withIdempotencyKey(ctx), &omsv1.PayOrderRequest{OrderId: orderID})
+	require.NoError(t, err)
+
+	require.Eventually(t, func() bool {
+		resp, getErr := client.GetOrder(ctx, &omsv1.GetOrderRequest{OrderId: orderID})
+		return getErr == nil && resp.Order.Status == omsv1.OrderStatus_ORDER_STATUS_CONFIRMED
+	}, 30*time.Second, 100*time.Millisecond, "order did not reach confirmed status")
+
+	// Consumer должен получить событие о подтверждении заказа, опубликованное outbox worker.
+	deadline := time.After(30 * time.Second)
+	for confirmed := false; !confirmed; {
+		select {
+		case event := <-events:
+			if event.AggregateID != orderID || event.EventType != "OrderStatusChanged" {
+				continue
+			}
+			var payload struct {
+				Status string `json:"status"`
+			}
+			require.NoError(t, json.Unmarshal(event.Payload, &payload))
+			confirmed = payload.Status == "confirmed"
+		case <-deadline:
+			t.Fatalf("confirmed event for order %s was not consumed from %s", orderID, kafka.TopicOrderEvents)
+		}
+	}
+
+	// Все outbox-записи заказа должны быть помечены отправленными.
+	db, err := sql.Open("pgx", env.PostgresDSN)
+	require.NoError(t, err)
+	defer func() {
+		_ = db.Close()
+	}()
+	require.Eventually(t, func() bool {
+		var unsent int
+		if scanErr := db.QueryRowContext(ctx, `
+			SELECT COUNT(*) FROM outbox_messages WHERE aggregate_id = $1 AND status <> 'sent'
+		`, orderID).Scan(&unsent); scanErr != nil {
+			return false
+		}
+		return unsent == 0
+	}, 10*time.Second, 100*time.Millisecond, "outbox messages were not marked as sent")
+}
+
+func startOrderEventsConsumer(t *testing.T, brokers []string, events chan<- outboxEnvelope, probes chan<- struct{}) {
+	t.Helper()
+
+	consumer, err := kafka.NewConsumer(brokers, "oms-integration-"+uuid.NewString(), []string{kafka.TopicOrderEvents},
+		func(_ context.Context, msg *sarama.ConsumerMessage) error {
+			if string(msg.Key) == probeMessageKey {
+				select {
+				case probes <- struct{}{}:
+				default:
+				}
+				return nil
+			}
+			var envelope outboxEnvelope
+			if err := json.Unmarshal(msg.Value, &envelope); err != nil {
+				return nil
+			}
+			select {
+			case events <- envelope:
+			default:
+			}
+			return nil
+		})
+	require.NoError(t, err)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	require.NoError(t, consumer.Start(ctx))
+	t.Cleanup(func() {
+		cancel()
+		_ = consumer.Stop()
+	})
+}
+
+// waitForConsumerAssignment публикует probe-сообщения, пока consumer их не увидит.
+// Consumer читает с OffsetNewest, поэтому без этого события заказа могут уйти до назначения партиций.
+func waitForConsumerAssignment(t *testing.T, brokers []string, probes <-chan struct{}) {
+	t.Helper()
+
+	producer, err := kafka.NewProducer(brokers)
+	require.NoError(t, err)
+	defer func() {
+		_ = producer.Close()
+	}()
+
+	deadline := time.After(time.Minute)
+	ticker := time.NewTicker(500 * time.Millisecond)
+	defer ticker.Stop()
+	for {
+		if err := producer.PublishEvent(kafka.TopicOrderEvents, probeMessageKey, map[string]string{"probe": "true"}); err != nil {
+			t.Logf("publish probe: %v", err)
+		}
+		select {
+		case <-probes:
+			return
+		case <-deadline:
+			t.Fatal("order-events consumer was not assigned partitions in time")
+		case <-ticker.C:
+		}
+	}
+}
+
+func waitForServing(t *testing.T, conn *grpc.ClientConn, runErr <-chan error) {
+	t.Helper()
+
+	health := healthpb.NewHealthClient(conn)
+	deadline := time.Now().Add(time.Minute)
+	for time.Now().Before(deadline) {
+		select {
+		case err := <-runErr:
+			t.Fatalf("app.Run exited before serving: %v", err)
+		default:
+		}
+
+		ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+		resp, err := health.Check(ctx, &healthpb.HealthCheckRequest{})
+		cancel()
+		if err == nil && resp.Status == healthpb.HealthCheckResponse_SERVING {
+			return
+		}
+		time.Sleep(200 * time.Millisecond)
+	}
+	t.Fatal("gRPC server did not become ready")
+}
+
+func withIdempotencyKey(ctx context.Context) context.Context {
+	return metadata.AppendToOutgoingContext(ctx, "idempotency-key", "docker-it-"+uuid.NewString())
+}